@@ -12,6 +12,7 @@ import (
 
 	"github.com/Layr-Labs/eigenda/core"
 	"github.com/Layr-Labs/eigenda/disperser"
+	"github.com/Layr-Labs/eigenda/disperser/common/pow"
 	"github.com/Layr-Labs/eigenda/encoding"
 	"github.com/Layr-Labs/eigenda/encoding/rs"
 	"google.golang.org/grpc"
@@ -36,6 +37,9 @@ type Config struct {
 	// If not set, default value is 100MiB for forward compatibility.
 	// Check official documentation for current max blob size on mainnet.
 	MaxRetrieveBlobSizeBytes int
+	// ProofOfWorkDifficulty is the base difficulty of the proof of work required by the disperser of
+	// unauthenticated dispersals (see pow.Difficulty). If zero, no proof of work is sent.
+	ProofOfWorkDifficulty uint32
 }
 
 // Deprecated: Use &Config{...} directly instead
@@ -152,9 +156,6 @@ func (c *disperserClient) DisperseBlob(ctx context.Context, data []byte, quorums
 		return nil, nil, api.NewErrorFailover(err)
 	}
 
-	ctxTimeout, cancel := context.WithTimeout(ctx, c.config.Timeout)
-	defer cancel()
-
 	quorumNumbers := make([]uint32, len(quorums))
 	for i, q := range quorums {
 		quorumNumbers[i] = uint32(q)
@@ -169,6 +170,20 @@ func (c *disperserClient) DisperseBlob(ctx context.Context, data []byte, quorums
 		Data:                data,
 		CustomQuorumNumbers: quorumNumbers,
 	}
+	if c.config.ProofOfWorkDifficulty > 0 {
+		timestamp := uint64(time.Now().Unix())
+		nonce, err := pow.Solve(ctx, data, timestamp, pow.Difficulty(c.config.ProofOfWorkDifficulty, len(data)))
+		if err != nil {
+			return nil, nil, err
+		}
+		request.ProofOfWork = &disperser_rpc.ProofOfWork{
+			Timestamp: timestamp,
+			Nonce:     nonce,
+		}
+	}
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, c.config.Timeout)
+	defer cancel()
 
 	reply, err := c.client.DisperseBlob(ctxTimeout, request)
 	if err != nil {
@@ -10,6 +10,7 @@ import (
 	"github.com/Layr-Labs/eigenda/encoding"
 	"github.com/wealdtech/go-merkletree/v2"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 )
 
@@ -25,12 +26,19 @@ type NodeClient interface {
 }
 
 type client struct {
-	timeout time.Duration
+	timeout     time.Duration
+	credentials credentials.TransportCredentials
 }
 
-func NewNodeClient(timeout time.Duration) NodeClient {
+// NewNodeClient creates a client of the retrieval servers of the nodes, which connects with the given credentials.
+// If creds is nil, the connections are plaintext.
+func NewNodeClient(timeout time.Duration, creds credentials.TransportCredentials) NodeClient {
+	if creds == nil {
+		creds = insecure.NewCredentials()
+	}
 	return client{
-		timeout: timeout,
+		timeout:     timeout,
+		credentials: creds,
 	}
 }
 
@@ -42,7 +50,7 @@ func (c client) GetBlobHeader(
 ) (*core.BlobHeader, *merkletree.Proof, error) {
 	conn, err := grpc.NewClient(
 		core.OperatorSocket(socket).GetV1RetrievalSocket(),
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithTransportCredentials(c.credentials),
 	)
 	if err != nil {
 		return nil, nil, err
@@ -87,7 +95,7 @@ func (c client) GetChunks(
 ) {
 	conn, err := grpc.NewClient(
 		core.OperatorSocket(opInfo.Socket).GetV1RetrievalSocket(),
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithTransportCredentials(c.credentials),
 	)
 	if err != nil {
 		chunksChan <- RetrievedChunks{
@@ -6,6 +6,8 @@ import (
 	"math/big"
 	"slices"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	disperser_rpc "github.com/Layr-Labs/eigenda/api/grpc/disperser/v2"
 	"github.com/Layr-Labs/eigenda/core"
@@ -31,6 +33,15 @@ type Accountant struct {
 
 	// number of bins in the circular accounting, restricted by minNumBins which is 3
 	numBins uint32
+
+	// retentionPeriod is the retention period requested for blobs, in whole seconds, and defaultRetentionPeriod is
+	// the period of the disperser relative to which the charge of a shorter period is prorated. If either is zero,
+	// blobs are charged in full.
+	retentionPeriod        atomic.Int64
+	defaultRetentionPeriod atomic.Int64
+
+	// store persists the local accounting, if set
+	store AccountantStore
 }
 
 type PeriodRecord struct {
@@ -43,7 +54,7 @@ func NewAccountant(accountID string, reservation *core.ReservedPayment, onDemand
 	for i := range periodRecords {
 		periodRecords[i] = PeriodRecord{Index: uint32(i), Usage: 0}
 	}
-	a := Accountant{
+	a := &Accountant{
 		accountID:         accountID,
 		reservation:       reservation,
 		onDemand:          onDemand,
@@ -55,7 +66,29 @@ func NewAccountant(accountID string, reservation *core.ReservedPayment, onDemand
 		numBins:           max(numBins, uint32(meterer.MinNumBins)),
 	}
 	// TODO: add a routine to refresh the on-chain state occasionally?
-	return &a
+	return a
+}
+
+// SetRetentionPeriod sets the retention period requested for blobs, which is rounded down to whole seconds like the
+// RetentionPeriodSeconds of the payment metadata. Zero requests the default retention period.
+func (a *Accountant) SetRetentionPeriod(retentionPeriod time.Duration) {
+	a.retentionPeriod.Store(int64(retentionPeriod / time.Second * time.Second))
+}
+
+// SetDefaultRetentionPeriod sets the default retention period of the disperser, relative to which the disperser
+// prorates the charge of blobs requesting a shorter retention period
+func (a *Accountant) SetDefaultRetentionPeriod(defaultRetentionPeriod time.Duration) {
+	a.defaultRetentionPeriod.Store(int64(defaultRetentionPeriod))
+}
+
+// RequestSymbolsCharged returns the number of symbols the disperser charges for a blob of numSymbols symbols with the
+// requested retention period, which is prorated relative to the default retention period like in
+// meterer.RequestSymbolsCharged.
+func (a *Accountant) RequestSymbolsCharged(numSymbols uint64) uint64 {
+	return core.RetentionAdjustedSymbols(
+		a.SymbolsCharged(numSymbols),
+		time.Duration(a.retentionPeriod.Load()),
+		time.Duration(a.defaultRetentionPeriod.Load()))
 }
 
 // BlobPaymentInfo calculates and records payment information. The accountant
@@ -69,7 +102,7 @@ func NewAccountant(accountID string, reservation *core.ReservedPayment, onDemand
 // api/proto/common/v2/common_v2.proto
 func (a *Accountant) BlobPaymentInfo(ctx context.Context, numSymbols uint64, quorumNumbers []uint8, timestamp int64) (*big.Int, error) {
 	currentReservationPeriod := meterer.GetReservationPeriodByNanosecond(timestamp, a.reservationWindow)
-	symbolUsage := a.SymbolsCharged(numSymbols)
+	symbolUsage := a.RequestSymbolsCharged(numSymbols)
 
 	a.usageLock.Lock()
 	defer a.usageLock.Unlock()
@@ -82,6 +115,9 @@ func (a *Accountant) BlobPaymentInfo(ctx context.Context, numSymbols uint64, quo
 		if err := QuorumCheck(quorumNumbers, a.reservation.QuorumNumbers); err != nil {
 			return big.NewInt(0), err
 		}
+		if err := a.saveState(); err != nil {
+			return big.NewInt(0), err
+		}
 		return big.NewInt(0), nil
 	}
 
@@ -92,25 +128,32 @@ func (a *Accountant) BlobPaymentInfo(ctx context.Context, numSymbols uint64, quo
 		if err := QuorumCheck(quorumNumbers, a.reservation.QuorumNumbers); err != nil {
 			return big.NewInt(0), err
 		}
+		if err := a.saveState(); err != nil {
+			return big.NewInt(0), err
+		}
 		return big.NewInt(0), nil
 	}
 
 	// reservation not available, rollback reservation records, attempt on-demand
 	//todo: rollback on-demand if disperser respond with some type of rejection?
 	relativePeriodRecord.Usage -= symbolUsage
-	incrementRequired := big.NewInt(int64(a.PaymentCharged(numSymbols)))
+	incrementRequired := big.NewInt(int64(a.PaymentCharged(symbolUsage)))
 	a.cumulativePayment.Add(a.cumulativePayment, incrementRequired)
 	if a.cumulativePayment.Cmp(a.onDemand.CumulativePayment) <= 0 {
 		if err := QuorumCheck(quorumNumbers, requiredQuorums); err != nil {
 			return big.NewInt(0), err
 		}
+		if err := a.saveState(); err != nil {
+			return big.NewInt(0), err
+		}
 		return a.cumulativePayment, nil
 	}
 
 	return big.NewInt(0), fmt.Errorf("neither reservation nor on-demand payment is available")
 }
 
-// AccountBlob accountant provides and records payment information
+// AccountBlob accountant provides and records payment information. The payment requests the retention period set
+// with SetRetentionPeriod, which the blob is charged for.
 func (a *Accountant) AccountBlob(ctx context.Context, timestamp int64, numSymbols uint64, quorums []uint8) (*core.PaymentMetadata, error) {
 	cumulativePayment, err := a.BlobPaymentInfo(ctx, numSymbols, quorums, timestamp)
 	if err != nil {
@@ -118,14 +161,166 @@ func (a *Accountant) AccountBlob(ctx context.Context, timestamp int64, numSymbol
 	}
 
 	pm := &core.PaymentMetadata{
-		AccountID:         a.accountID,
-		Timestamp:         timestamp,
-		CumulativePayment: cumulativePayment,
+		AccountID:              a.accountID,
+		Timestamp:              timestamp,
+		CumulativePayment:      cumulativePayment,
+		RetentionPeriodSeconds: uint32(time.Duration(a.retentionPeriod.Load()) / time.Second),
 	}
 
 	return pm, nil
 }
 
+// PaymentMethod is the way a blob is paid for
+type PaymentMethod int
+
+const (
+	// PaymentMethodNone means that the blob can't be paid for
+	PaymentMethodNone PaymentMethod = iota
+	// PaymentMethodReservation means that the blob is paid for with the reservation of the account
+	PaymentMethodReservation
+	// PaymentMethodOnDemand means that the blob is paid for with the on-demand deposit of the account
+	PaymentMethodOnDemand
+)
+
+func (m PaymentMethod) String() string {
+	switch m {
+	case PaymentMethodReservation:
+		return "reservation"
+	case PaymentMethodOnDemand:
+		return "on-demand"
+	default:
+		return "none"
+	}
+}
+
+// PaymentQuote describes how a blob would be paid for if it were dispersed now, and what it would cost
+type PaymentQuote struct {
+	// PaymentMethod is the way the blob would be paid for. It is PaymentMethodNone if neither the reservation nor the
+	// on-demand deposit can pay for the blob.
+	PaymentMethod PaymentMethod
+	// SymbolsCharged is the number of symbols the blob would be charged for
+	SymbolsCharged uint64
+	// OnDemandCost is the on-demand payment for the blob in wei, if it were paid for on-demand. It is set even if the
+	// blob would be paid for with the reservation.
+	OnDemandCost *big.Int
+	// ReservationSymbolsAvailable is the number of symbols the reservation can still pay for in the current
+	// reservation period, not counting the overflow into a later period
+	ReservationSymbolsAvailable uint64
+	// OnDemandBalanceAvailable is the part of the on-demand deposit which hasn't been spent, in wei
+	OnDemandBalanceAvailable *big.Int
+}
+
+// Affordable returns whether the blob can be paid for
+func (q *PaymentQuote) Affordable() bool {
+	return q.PaymentMethod != PaymentMethodNone
+}
+
+// QuotePayment reports how a blob of numSymbols symbols dispersed to the given quorums at the given time, in Unix
+// nanoseconds, would be paid for, and what it would cost, without recording any usage. It follows the same rules as
+// BlobPaymentInfo: the reservation is used if it has capacity, and the on-demand deposit otherwise.
+//
+// If paymentState isn't nil, it is combined with the local accounting, taking the larger usage and cumulative payment
+// of the two, so that usage by other clients of the same account which the disperser has recorded is accounted for.
+// An error is returned if the blob can't be dispersed to the quorums with the payment method which would be used.
+func (a *Accountant) QuotePayment(
+	numSymbols uint64,
+	quorumNumbers []uint8,
+	timestamp int64,
+	paymentState *disperser_rpc.GetPaymentStateReply,
+) (*PaymentQuote, error) {
+	currentReservationPeriod := meterer.GetReservationPeriodByNanosecond(timestamp, a.reservationWindow)
+	symbolUsage := a.RequestSymbolsCharged(numSymbols)
+
+	a.usageLock.Lock()
+	defer a.usageLock.Unlock()
+
+	usage := a.peekPeriodUsage(currentReservationPeriod, paymentState)
+	overflowUsage := a.peekPeriodUsage(currentReservationPeriod+2, paymentState)
+	cumulativePayment := new(big.Int).Set(a.cumulativePayment)
+	deposit := a.onDemand.CumulativePayment
+	if paymentState != nil {
+		if paymentState.GetCumulativePayment() != nil {
+			remotePayment := new(big.Int).SetBytes(paymentState.GetCumulativePayment())
+			if remotePayment.Cmp(cumulativePayment) > 0 {
+				cumulativePayment = remotePayment
+			}
+		}
+		if paymentState.GetOnchainCumulativePayment() != nil {
+			deposit = new(big.Int).SetBytes(paymentState.GetOnchainCumulativePayment())
+		}
+	}
+
+	binLimit := a.reservation.SymbolsPerSecond * uint64(a.reservationWindow)
+	balance := new(big.Int).Sub(deposit, cumulativePayment)
+	if balance.Sign() < 0 {
+		balance.SetInt64(0)
+	}
+	quote := &PaymentQuote{
+		PaymentMethod:               PaymentMethodNone,
+		SymbolsCharged:              symbolUsage,
+		OnDemandCost:                new(big.Int).SetUint64(a.PaymentCharged(symbolUsage)),
+		ReservationSymbolsAvailable: binLimit - min(usage, binLimit),
+		OnDemandBalanceAvailable:    balance,
+	}
+
+	// the same conditions as in BlobPaymentInfo, including the single overflow into a later period
+	fitsReservation := usage+symbolUsage <= binLimit
+	fitsOverflow := overflowUsage == 0 && usage < binLimit && symbolUsage <= binLimit
+	if fitsReservation || fitsOverflow {
+		if err := QuorumCheck(quorumNumbers, a.reservation.QuorumNumbers); err != nil {
+			return nil, err
+		}
+		quote.PaymentMethod = PaymentMethodReservation
+		return quote, nil
+	}
+
+	if quote.OnDemandCost.Cmp(balance) <= 0 {
+		if err := QuorumCheck(quorumNumbers, requiredQuorums); err != nil {
+			return nil, err
+		}
+		quote.PaymentMethod = PaymentMethodOnDemand
+	}
+
+	return quote, nil
+}
+
+// NextReservationPeriodDelay returns how long after timestamp, in Unix nanoseconds, the next reservation period starts.
+// The second return value is false if the reservation can't pay for a blob of numSymbols symbols in the next period,
+// because the blob is larger than the symbols of a period, or the reservation ends before the next period.
+func (a *Accountant) NextReservationPeriodDelay(numSymbols uint64, timestamp int64) (time.Duration, bool) {
+	binLimit := a.reservation.SymbolsPerSecond * a.reservationWindow
+	if binLimit == 0 || a.RequestSymbolsCharged(numSymbols) > binLimit {
+		return 0, false
+	}
+
+	currentReservationPeriod := meterer.GetReservationPeriodByNanosecond(timestamp, a.reservationWindow)
+	nextPeriodStart := (currentReservationPeriod + 1) * a.reservationWindow
+	if a.reservation.EndTimestamp != 0 && nextPeriodStart >= a.reservation.EndTimestamp {
+		return 0, false
+	}
+
+	return time.Unix(int64(nextPeriodStart), 0).Sub(time.Unix(0, timestamp)), true
+}
+
+// peekPeriodUsage returns the usage recorded for the reservation period with the given index, without modifying the
+// period records. If paymentState isn't nil, the larger of the local usage and the usage it records is returned. The
+// caller must hold usageLock.
+func (a *Accountant) peekPeriodUsage(index uint64, paymentState *disperser_rpc.GetPaymentStateReply) uint64 {
+	usage := uint64(0)
+	relativeIndex := uint32(index % uint64(a.numBins))
+	if int(relativeIndex) < len(a.periodRecords) && a.periodRecords[relativeIndex].Index == uint32(index) {
+		usage = a.periodRecords[relativeIndex].Usage
+	}
+
+	for _, record := range paymentState.GetPeriodRecords() {
+		if record != nil && record.Index == uint32(index) {
+			usage = max(usage, record.Usage)
+		}
+	}
+
+	return usage
+}
+
 // TODO: PaymentCharged and SymbolsCharged copied from meterer, should be refactored
 // PaymentCharged returns the chargeable price for a given data length
 func (a *Accountant) PaymentCharged(numSymbols uint64) uint64 {
@@ -225,6 +420,114 @@ func (a *Accountant) SetPaymentState(paymentState *disperser_rpc.GetPaymentState
 		}
 	}
 	a.periodRecords = periodRecords
+
+	if a.store != nil {
+		err := a.recoverState()
+		if err != nil {
+			return fmt.Errorf("recover accountant state: %w", err)
+		}
+	}
+	return nil
+}
+
+// Resync reconciles the local accounting with the disperser's view of the account, which is authoritative. The
+// cumulative payment is set to the largest payment recorded by the disperser, and the usage of each reservation period
+// known to the disperser is set to the usage it recorded. Periods newer than the disperser's records keep their local
+// usage. The on-chain deposit is refreshed too, if the disperser returns it.
+//
+// The local accounting diverges from the disperser's when the responses to dispersals are dropped, so that the
+// accountant charged for dispersals the disperser never recorded, or when several clients disperse for the same
+// account. Resync must be called while no dispersal of the client is in flight, since the payments of in-flight
+// dispersals aren't recorded by the disperser yet, and would be reused.
+func (a *Accountant) Resync(paymentState *disperser_rpc.GetPaymentStateReply) error {
+	if paymentState == nil {
+		return fmt.Errorf("payment state cannot be nil")
+	}
+
+	a.usageLock.Lock()
+	defer a.usageLock.Unlock()
+
+	if paymentState.GetCumulativePayment() == nil {
+		a.cumulativePayment = big.NewInt(0)
+	} else {
+		a.cumulativePayment = new(big.Int).SetBytes(paymentState.GetCumulativePayment())
+	}
+
+	if paymentState.GetOnchainCumulativePayment() != nil {
+		a.onDemand = &core.OnDemandPayment{
+			CumulativePayment: new(big.Int).SetBytes(paymentState.GetOnchainCumulativePayment()),
+		}
+	}
+
+	for _, record := range paymentState.GetPeriodRecords() {
+		if record == nil {
+			continue
+		}
+		relativeIndex := record.Index % a.numBins
+		if int(relativeIndex) >= len(a.periodRecords) {
+			continue
+		}
+		current := &a.periodRecords[relativeIndex]
+		if current.Index <= record.Index {
+			*current = PeriodRecord{Index: record.Index, Usage: record.Usage}
+		}
+	}
+
+	return a.saveState()
+}
+
+// SetStore sets the store which persists the local accounting of the accountant. The persisted state is recovered by
+// the next call to SetPaymentState, and saved each time a blob is accounted.
+func (a *Accountant) SetStore(store AccountantStore) {
+	a.usageLock.Lock()
+	defer a.usageLock.Unlock()
+	a.store = store
+}
+
+// recoverState merges the persisted local accounting into the state from the disperser. The disperser may not have
+// seen the last dispersals before a restart, e.g. if they were still in flight, so the highest cumulative payment and
+// usage of each period are kept. State persisted for another account is ignored.
+func (a *Accountant) recoverState() error {
+	state, err := a.store.Load()
+	if err != nil {
+		return err
+	}
+	if state == nil || state.AccountID != a.accountID {
+		return nil
+	}
+
+	if state.CumulativePayment != nil && state.CumulativePayment.Cmp(a.cumulativePayment) > 0 {
+		a.cumulativePayment = new(big.Int).Set(state.CumulativePayment)
+	}
+
+	for _, record := range state.PeriodRecords {
+		relativeIndex := record.Index % a.numBins
+		if int(relativeIndex) >= len(a.periodRecords) {
+			continue
+		}
+		current := &a.periodRecords[relativeIndex]
+		if current.Index == record.Index {
+			current.Usage = max(current.Usage, record.Usage)
+		} else if current.Index < record.Index {
+			*current = record
+		}
+	}
+	return nil
+}
+
+// saveState persists the local accounting, if the accountant has a store. The caller must hold usageLock.
+func (a *Accountant) saveState() error {
+	if a.store == nil {
+		return nil
+	}
+	err := a.store.Save(&AccountantState{
+		AccountID:         a.accountID,
+		CumulativePayment: new(big.Int).Set(a.cumulativePayment),
+		PeriodRecords:     slices.Clone(a.periodRecords),
+	})
+	if err != nil {
+		return fmt.Errorf("save accountant state: %w", err)
+	}
 	return nil
 }
 
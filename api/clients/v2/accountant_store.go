@@ -0,0 +1,94 @@
+package clients
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+)
+
+// AccountantState is the local accounting state of an Accountant, which is persisted across restarts of the client.
+type AccountantState struct {
+	AccountID string `json:"accountId"`
+	// CumulativePayment is the cumulative payment of the last on-demand dispersal, in wei
+	CumulativePayment *big.Int `json:"cumulativePayment"`
+	// PeriodRecords is the reservation usage of the recent reservation periods
+	PeriodRecords []PeriodRecord `json:"periodRecords"`
+}
+
+// AccountantStore persists the local accounting state of an Accountant. Without it, the state is lost on restart, and
+// the first dispersals after a restart may reuse cumulative payments the disperser has already seen.
+//
+// Implementations must be safe to call from multiple goroutines.
+type AccountantStore interface {
+	// Load returns the persisted state, or nil if no state has been persisted yet.
+	Load() (*AccountantState, error)
+	// Save persists the state, replacing the previously persisted state.
+	Save(state *AccountantState) error
+}
+
+// fileAccountantStore is an AccountantStore which persists the state to a JSON file.
+type fileAccountantStore struct {
+	path string
+}
+
+var _ AccountantStore = &fileAccountantStore{}
+
+// NewFileAccountantStore creates an AccountantStore which persists the state to the file at the given path. The
+// file is replaced atomically on each save, so a crash never leaves a partially written state.
+func NewFileAccountantStore(path string) AccountantStore {
+	return &fileAccountantStore{
+		path: path,
+	}
+}
+
+func (s *fileAccountantStore) Load() (*AccountantState, error) {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read accountant state: %w", err)
+	}
+
+	state := &AccountantState{}
+	err = json.Unmarshal(data, state)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal accountant state: %w", err)
+	}
+	return state, nil
+}
+
+func (s *fileAccountantStore) Save(state *AccountantState) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("marshal accountant state: %w", err)
+	}
+
+	tmpFile, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".tmp")
+	if err != nil {
+		return fmt.Errorf("create temporary accountant state file: %w", err)
+	}
+	defer func() {
+		_ = os.Remove(tmpFile.Name())
+	}()
+
+	_, err = tmpFile.Write(data)
+	if err == nil {
+		err = tmpFile.Sync()
+	}
+	if closeErr := tmpFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("write accountant state: %w", err)
+	}
+
+	err = os.Rename(tmpFile.Name(), s.path)
+	if err != nil {
+		return fmt.Errorf("replace accountant state: %w", err)
+	}
+	return nil
+}
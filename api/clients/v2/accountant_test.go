@@ -4,10 +4,12 @@ import (
 	"context"
 	"encoding/hex"
 	"math/big"
+	"path/filepath"
 	"sync"
 	"testing"
 	"time"
 
+	disperser_rpc "github.com/Layr-Labs/eigenda/api/grpc/disperser/v2"
 	"github.com/Layr-Labs/eigenda/core"
 	"github.com/Layr-Labs/eigenda/core/meterer"
 	"github.com/ethereum/go-ethereum/crypto"
@@ -383,6 +385,144 @@ func TestAccountBlob_ReservationOverflowReset(t *testing.T) {
 	assert.Equal(t, isRotation([]uint64{1000, 500, 0}, mapRecordUsage(accountant.periodRecords)), true)
 }
 
+func TestAccountantStoreRecovery(t *testing.T) {
+	privateKey1, err := crypto.GenerateKey()
+	assert.NoError(t, err)
+	accountId := hex.EncodeToString(privateKey1.D.Bytes())
+	store := NewFileAccountantStore(filepath.Join(t.TempDir(), "accountant.json"))
+
+	reservationWindow := uint64(5)
+	// The disperser hasn't seen the dispersals made before the restart
+	paymentState := &disperser_rpc.GetPaymentStateReply{
+		PaymentGlobalParams: &disperser_rpc.PaymentGlobalParams{
+			MinNumSymbols:     100,
+			PricePerSymbol:    1,
+			ReservationWindow: reservationWindow,
+		},
+		PeriodRecords: []*disperser_rpc.PeriodRecord{
+			{Index: 0, Usage: 0},
+			{Index: 1, Usage: 0},
+			{Index: 2, Usage: 0},
+		},
+		OnchainCumulativePayment: big.NewInt(10000).Bytes(),
+	}
+
+	accountant := NewAccountant(accountId, nil, nil, 0, 0, 0, numBins)
+	accountant.SetStore(store)
+	assert.NoError(t, accountant.SetPaymentState(paymentState))
+
+	ctx := context.Background()
+	quorums := []uint8{0, 1}
+	header, err := accountant.AccountBlob(ctx, time.Now().UnixNano(), 1500, quorums)
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(1500), header.CumulativePayment)
+
+	// After a restart, the persisted cumulative payment is recovered
+	restarted := NewAccountant(accountId, nil, nil, 0, 0, 0, numBins)
+	restarted.SetStore(store)
+	assert.NoError(t, restarted.SetPaymentState(paymentState))
+	assert.Equal(t, big.NewInt(1500), restarted.cumulativePayment)
+
+	header, err = restarted.AccountBlob(ctx, time.Now().UnixNano(), 1500, quorums)
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(3000), header.CumulativePayment)
+
+	// A higher cumulative payment from the disperser takes precedence
+	paymentState.CumulativePayment = big.NewInt(5000).Bytes()
+	restarted = NewAccountant(accountId, nil, nil, 0, 0, 0, numBins)
+	restarted.SetStore(store)
+	assert.NoError(t, restarted.SetPaymentState(paymentState))
+	assert.Equal(t, big.NewInt(5000), restarted.cumulativePayment)
+
+	// The state persisted for another account is ignored
+	other := NewAccountant("other", nil, nil, 0, 0, 0, numBins)
+	other.SetStore(store)
+	paymentState.CumulativePayment = nil
+	assert.NoError(t, other.SetPaymentState(paymentState))
+	assert.Equal(t, big.NewInt(0), other.cumulativePayment)
+}
+
+func TestAccountantStoreRecoversPeriodRecords(t *testing.T) {
+	store := NewFileAccountantStore(filepath.Join(t.TempDir(), "accountant.json"))
+	err := store.Save(&AccountantState{
+		AccountID:         "account",
+		CumulativePayment: big.NewInt(0),
+		PeriodRecords: []PeriodRecord{
+			{Index: 6, Usage: 300},
+			{Index: 4, Usage: 100},
+			{Index: 2, Usage: 500},
+		},
+	})
+	assert.NoError(t, err)
+
+	accountant := NewAccountant("account", nil, nil, 0, 0, 0, numBins)
+	accountant.SetStore(store)
+	err = accountant.SetPaymentState(&disperser_rpc.GetPaymentStateReply{
+		PaymentGlobalParams: &disperser_rpc.PaymentGlobalParams{ReservationWindow: 5},
+		PeriodRecords: []*disperser_rpc.PeriodRecord{
+			{Index: 3, Usage: 50},
+			{Index: 4, Usage: 200},
+			{Index: 5, Usage: 10},
+		},
+	})
+	assert.NoError(t, err)
+
+	// Newer periods replace older ones, and the highest usage of a period is kept
+	assert.Equal(t, []PeriodRecord{
+		{Index: 6, Usage: 300},
+		{Index: 4, Usage: 200},
+		{Index: 5, Usage: 10},
+	}, accountant.periodRecords)
+}
+
+func TestAccountantResync(t *testing.T) {
+	reservation := &core.ReservedPayment{
+		SymbolsPerSecond: 200,
+		StartTimestamp:   100,
+		EndTimestamp:     200,
+		QuorumSplits:     []byte{50, 50},
+		QuorumNumbers:    []uint8{0, 1},
+	}
+	onDemand := &core.OnDemandPayment{
+		CumulativePayment: big.NewInt(5000),
+	}
+	accountant := NewAccountant("account", reservation, onDemand, 5, 1, 100, numBins)
+	accountant.cumulativePayment = big.NewInt(3000)
+	accountant.periodRecords = []PeriodRecord{
+		{Index: 6, Usage: 100},
+		{Index: 4, Usage: 900},
+		{Index: 5, Usage: 10},
+	}
+
+	// The disperser recorded another client's payments, and didn't record a dropped dispersal of this client
+	err := accountant.Resync(&disperser_rpc.GetPaymentStateReply{
+		PeriodRecords: []*disperser_rpc.PeriodRecord{
+			{Index: 3, Usage: 50},
+			{Index: 4, Usage: 400},
+			{Index: 5, Usage: 700},
+		},
+		CumulativePayment:        big.NewInt(2000).Bytes(),
+		OnchainCumulativePayment: big.NewInt(8000).Bytes(),
+	})
+	assert.NoError(t, err)
+
+	assert.Equal(t, big.NewInt(2000), accountant.cumulativePayment)
+	assert.Equal(t, big.NewInt(8000), accountant.onDemand.CumulativePayment)
+	assert.Equal(t, []PeriodRecord{
+		{Index: 6, Usage: 100},
+		{Index: 4, Usage: 400},
+		{Index: 5, Usage: 700},
+	}, accountant.periodRecords)
+
+	// The next on-demand payment follows the disperser's largest recorded payment
+	payment, err := accountant.BlobPaymentInfo(context.Background(), 2000, []uint8{0, 1}, 5_000_000_000*5)
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(4000), payment)
+
+	err = accountant.Resync(nil)
+	assert.Error(t, err)
+}
+
 func TestQuorumCheck(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -466,3 +606,109 @@ func isRotation(arrA, arrB []uint64) bool {
 	}
 	return false
 }
+
+func TestQuotePayment(t *testing.T) {
+	reservation := &core.ReservedPayment{
+		SymbolsPerSecond: 200,
+		StartTimestamp:   100,
+		EndTimestamp:     200,
+		QuorumSplits:     []byte{50, 50},
+		QuorumNumbers:    []uint8{0, 1},
+	}
+	onDemand := &core.OnDemandPayment{
+		CumulativePayment: big.NewInt(500),
+	}
+	accountant := NewAccountant("account", reservation, onDemand, 5, 1, 100, numBins)
+
+	ctx := context.Background()
+	quorums := []uint8{0, 1}
+	now := time.Now().UnixNano()
+
+	quote, err := accountant.QuotePayment(500, quorums, now, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, PaymentMethodReservation, quote.PaymentMethod)
+	assert.Equal(t, uint64(500), quote.SymbolsCharged)
+	assert.Equal(t, uint64(1000), quote.ReservationSymbolsAvailable)
+	// quoting doesn't record any usage
+	assert.True(t, isRotation([]uint64{0, 0, 0}, mapRecordUsage(accountant.periodRecords)))
+
+	_, err = accountant.AccountBlob(ctx, now, 500, quorums)
+	assert.NoError(t, err)
+
+	// the reservation may overflow into a later period once
+	quote, err = accountant.QuotePayment(700, quorums, now, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, PaymentMethodReservation, quote.PaymentMethod)
+	assert.Equal(t, uint64(500), quote.ReservationSymbolsAvailable)
+
+	_, err = accountant.AccountBlob(ctx, now, 700, quorums)
+	assert.NoError(t, err)
+
+	quote, err = accountant.QuotePayment(300, quorums, now, nil)
+	assert.NoError(t, err)
+	assert.True(t, quote.Affordable())
+	assert.Equal(t, PaymentMethodOnDemand, quote.PaymentMethod)
+	assert.Equal(t, big.NewInt(300), quote.OnDemandCost)
+	assert.Equal(t, big.NewInt(500), quote.OnDemandBalanceAvailable)
+
+	quote, err = accountant.QuotePayment(600, quorums, now, nil)
+	assert.NoError(t, err)
+	assert.False(t, quote.Affordable())
+
+	// payments recorded by the disperser, e.g. made by other clients of the account, are accounted for
+	paymentState := &disperser_rpc.GetPaymentStateReply{
+		CumulativePayment: big.NewInt(400).Bytes(),
+	}
+	quote, err = accountant.QuotePayment(300, quorums, now, paymentState)
+	assert.NoError(t, err)
+	assert.False(t, quote.Affordable())
+	assert.Equal(t, big.NewInt(100), quote.OnDemandBalanceAvailable)
+
+	// the quorums must be covered by the payment method
+	_, err = accountant.QuotePayment(300, []uint8{0, 2}, now, nil)
+	assert.Error(t, err)
+}
+
+func TestAccountBlobRetentionPeriod(t *testing.T) {
+	reservation := &core.ReservedPayment{
+		SymbolsPerSecond: 200,
+		StartTimestamp:   100,
+		EndTimestamp:     200,
+		QuorumSplits:     []byte{50, 50},
+		QuorumNumbers:    []uint8{0, 1},
+	}
+	onDemand := &core.OnDemandPayment{
+		CumulativePayment: big.NewInt(1000),
+	}
+	accountant := NewAccountant("account", reservation, onDemand, 5, 1, 100, numBins)
+
+	ctx := context.Background()
+	quorums := []uint8{0, 1}
+	now := time.Now().UnixNano()
+
+	// without the default retention period of the disperser, blobs are charged in full
+	accountant.SetRetentionPeriod(time.Hour + 500*time.Millisecond)
+	header, err := accountant.AccountBlob(ctx, now, 400, quorums)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(3600), header.RetentionPeriodSeconds)
+	assert.True(t, isRotation([]uint64{400, 0, 0}, mapRecordUsage(accountant.periodRecords)))
+
+	// a quarter of the default retention period is charged a quarter of the symbols, like by the meterer
+	accountant.SetDefaultRetentionPeriod(4 * time.Hour)
+	assert.Equal(t, uint64(100), accountant.RequestSymbolsCharged(400))
+	header, err = accountant.AccountBlob(ctx, now, 400, quorums)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(3600), header.RetentionPeriodSeconds)
+	assert.True(t, isRotation([]uint64{500, 0, 0}, mapRecordUsage(accountant.periodRecords)))
+
+	quote, err := accountant.QuotePayment(1000, quorums, now, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(250), quote.SymbolsCharged)
+
+	// on-demand payments are prorated too
+	_, err = accountant.AccountBlob(ctx, now, 2000, quorums)
+	assert.NoError(t, err)
+	header, err = accountant.AccountBlob(ctx, now, 2000, quorums)
+	assert.NoError(t, err)
+	assert.Equal(t, big.NewInt(500), header.CumulativePayment)
+}
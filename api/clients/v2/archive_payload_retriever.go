@@ -0,0 +1,232 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/Layr-Labs/eigenda/api/clients/v2/coretypes"
+	"github.com/Layr-Labs/eigenda/api/clients/v2/verification"
+	"github.com/Layr-Labs/eigenda/common/aws/s3"
+	core "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+)
+
+// BlobArchive is a source of blobs which outlives the retention window of the DA network, such as a mirror of the
+// disperser's blob bucket.
+//
+// An archive isn't trusted: every blob retrieved from an archive is verified against the commitment of the
+// EigenDACert before it is used.
+type BlobArchive interface {
+	// GetBlob returns the serialized blob with the given key
+	GetBlob(ctx context.Context, blobKey core.BlobKey) ([]byte, error)
+}
+
+// s3BlobArchive is a BlobArchive backed by an S3 bucket, laid out like the blob bucket of the disperser
+type s3BlobArchive struct {
+	s3Client s3.Client
+	bucket   string
+}
+
+var _ BlobArchive = &s3BlobArchive{}
+
+// NewS3BlobArchive creates a BlobArchive which downloads blobs from an S3 bucket. Blobs are stored under the same keys
+// as in the blob bucket of the disperser, so a mirror of that bucket can be used directly.
+func NewS3BlobArchive(s3Client s3.Client, bucket string) BlobArchive {
+	return &s3BlobArchive{
+		s3Client: s3Client,
+		bucket:   bucket,
+	}
+}
+
+func (a *s3BlobArchive) GetBlob(ctx context.Context, blobKey core.BlobKey) ([]byte, error) {
+	blobBytes, err := a.s3Client.DownloadObject(ctx, a.bucket, s3.ScopedBlobKey(blobKey))
+	if err != nil {
+		return nil, fmt.Errorf("download blob %s from bucket %s: %w", blobKey.Hex(), a.bucket, err)
+	}
+	return blobBytes, nil
+}
+
+// httpBlobArchive is a BlobArchive served over HTTP, with each blob at a path named by the hex of its blob key
+type httpBlobArchive struct {
+	httpClient  *http.Client
+	baseURL     string
+	maxBlobSize int64
+}
+
+var _ BlobArchive = &httpBlobArchive{}
+
+// NewHTTPBlobArchive creates a BlobArchive which downloads the blob with key blobKey from {baseURL}/{blobKey.Hex()}.
+// Responses longer than maxBlobSize bytes are rejected, so that a misbehaving archive can't exhaust the memory of the
+// client. If httpClient is nil, http.DefaultClient is used.
+func NewHTTPBlobArchive(httpClient *http.Client, baseURL string, maxBlobSize int64) BlobArchive {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &httpBlobArchive{
+		httpClient:  httpClient,
+		baseURL:     strings.TrimSuffix(baseURL, "/"),
+		maxBlobSize: maxBlobSize,
+	}
+}
+
+func (a *httpBlobArchive) GetBlob(ctx context.Context, blobKey core.BlobKey) ([]byte, error) {
+	url := fmt.Sprintf("%s/%s", a.baseURL, blobKey.Hex())
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create request for %s: %w", url, err)
+	}
+
+	response, err := a.httpClient.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("get %s: %w", url, err)
+	}
+	defer func() {
+		_ = response.Body.Close()
+	}()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("get %s: unexpected status %s", url, response.Status)
+	}
+
+	blobBytes, err := io.ReadAll(io.LimitReader(response.Body, a.maxBlobSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("read body of %s: %w", url, err)
+	}
+	if int64(len(blobBytes)) > a.maxBlobSize {
+		return nil, fmt.Errorf("blob at %s exceeds the maximum size of %d bytes", url, a.maxBlobSize)
+	}
+
+	return blobBytes, nil
+}
+
+// ArchivePayloadRetriever provides the ability to get payloads from a BlobArchive. It is intended as a last resort, for
+// blobs which are no longer held by the relays and validators because the retention window of the DA network expired.
+//
+// This struct is goroutine safe.
+type ArchivePayloadRetriever struct {
+	log     logging.Logger
+	config  ArchivePayloadRetrieverConfig
+	archive BlobArchive
+	g1Srs   []bn254.G1Affine
+}
+
+var _ StreamingPayloadRetriever = &ArchivePayloadRetriever{}
+
+// NewArchivePayloadRetriever assembles an ArchivePayloadRetriever from subcomponents that have already been
+// constructed and initialized.
+func NewArchivePayloadRetriever(
+	log logging.Logger,
+	archivePayloadRetrieverConfig ArchivePayloadRetrieverConfig,
+	archive BlobArchive,
+	g1Srs []bn254.G1Affine) (*ArchivePayloadRetriever, error) {
+
+	err := archivePayloadRetrieverConfig.checkAndSetDefaults()
+	if err != nil {
+		return nil, fmt.Errorf("check and set ArchivePayloadRetrieverConfig config: %w", err)
+	}
+
+	return &ArchivePayloadRetriever{
+		log:     log,
+		config:  archivePayloadRetrieverConfig,
+		archive: archive,
+		g1Srs:   g1Srs,
+	}, nil
+}
+
+// GetPayload retrieves the blob of the cert from the archive, and verifies it against the commitment of the cert. If
+// the verification succeeds, the blob is decoded to yield the payload, and the payload is returned.
+//
+// The commitment is always verified, since the archive isn't part of the DA network and makes no guarantees about the
+// data it serves.
+//
+// This method does NOT verify the eigenDACert on chain: it is assumed that the input eigenDACert has already been
+// verified prior to calling this method.
+func (pr *ArchivePayloadRetriever) GetPayload(
+	ctx context.Context,
+	eigenDACert *verification.EigenDACert,
+) (*coretypes.Payload, error) {
+	return invokeWithMetrics(pr.config.Metrics, "GetPayloadFromArchive", func() (*coretypes.Payload, error) {
+		return pr.getPayload(ctx, eigenDACert)
+	})
+}
+
+// GetPayloadReader is like GetPayload, but returns a reader of the payload, which decodes the payload as it is read.
+// The blob is fully retrieved and verified against the certificate before the reader is returned, so the reader never
+// yields data which doesn't match the certificate.
+//
+// The caller must close the returned reader.
+func (pr *ArchivePayloadRetriever) GetPayloadReader(
+	ctx context.Context,
+	eigenDACert *verification.EigenDACert,
+) (io.ReadCloser, error) {
+	return invokeWithMetrics(pr.config.Metrics, "GetPayloadReaderFromArchive", func() (io.ReadCloser, error) {
+		blob, err := pr.getVerifiedBlob(ctx, eigenDACert)
+		if err != nil {
+			return nil, err
+		}
+		return decodeVerifiedBlobReader(pr.log, blob, &pr.config.PayloadClientConfig, eigenDACert)
+	})
+}
+
+// getPayload implements GetPayload, without recording its latency
+func (pr *ArchivePayloadRetriever) getPayload(
+	ctx context.Context,
+	eigenDACert *verification.EigenDACert,
+) (*coretypes.Payload, error) {
+	blob, err := pr.getVerifiedBlob(ctx, eigenDACert)
+	if err != nil {
+		return nil, err
+	}
+	return decodeVerifiedBlob(pr.log, blob, &pr.config.PayloadClientConfig, eigenDACert)
+}
+
+// getVerifiedBlob retrieves the blob of the cert from the archive, and checks that it matches the commitment of the
+// cert
+func (pr *ArchivePayloadRetriever) getVerifiedBlob(
+	ctx context.Context,
+	eigenDACert *verification.EigenDACert,
+) (*coretypes.Blob, error) {
+
+	blobKey, err := eigenDACert.ComputeBlobKey()
+	if err != nil {
+		return nil, fmt.Errorf("compute blob key: %w", err)
+	}
+
+	blobCommitments, err := verification.BlobCommitmentsBindingToInternal(
+		&eigenDACert.BlobInclusionInfo.BlobCertificate.BlobHeader.Commitment)
+	if err != nil {
+		return nil, fmt.Errorf("blob commitments binding to internal: %w", err)
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, pr.config.ArchiveTimeout)
+	defer cancel()
+
+	blobBytes, err := pr.archive.GetBlob(timeoutCtx, *blobKey)
+	if err != nil {
+		return nil, fmt.Errorf("get blob %s from archive: %w", blobKey.Hex(), err)
+	}
+
+	blob, err := coretypes.DeserializeBlob(
+		blobBytes,
+		eigenDACert.BlobInclusionInfo.BlobCertificate.BlobHeader.Commitment.Length)
+	if err != nil {
+		return nil, fmt.Errorf("deserialize blob %s: %w", blobKey.Hex(), err)
+	}
+
+	valid, err := verification.GenerateAndCompareBlobCommitment(
+		pr.g1Srs,
+		blob.Serialize(),
+		blobCommitments.Commitment)
+	if err != nil {
+		return nil, fmt.Errorf("generate and compare blob commitment: %w", err)
+	}
+	if !valid {
+		return nil, fmt.Errorf("commitment of blob %s from archive doesn't match cert commitment", blobKey.Hex())
+	}
+
+	return blob, nil
+}
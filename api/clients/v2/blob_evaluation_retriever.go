@@ -0,0 +1,300 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api/clients/v2/verification"
+	"github.com/Layr-Labs/eigenda/core"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/Layr-Labs/eigenda/encoding/rs"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+)
+
+// BlobVersionParamsReader reads the parameters of the blob versions defined on-chain. It is implemented by core.Reader.
+type BlobVersionParamsReader interface {
+	GetAllVersionedBlobParams(ctx context.Context) (map[uint16]*core.BlobVersionParameters, error)
+}
+
+// BlobEvaluation is an evaluation of the polynomial of a blob at a point of the evaluation domain of the blob
+type BlobEvaluation struct {
+	// Index is the index of the point in the evaluation domain of the blob, whose size is the length of the blob in
+	// symbols. For payloads in PolynomialFormEval, it is the index of the symbol in the encoded payload.
+	Index uint32
+	// Value is the evaluation of the blob polynomial at the point
+	Value fr.Element
+}
+
+// BlobEvaluationRetriever retrieves individual evaluations of the polynomial of a blob from the relays, without
+// retrieving the entire blob, so that light clients can cheaply read small records from large blobs.
+//
+// Each evaluation is read from the chunk of the encoded blob which contains it. A chunk holds the coefficients of the
+// polynomial which interpolates the blob polynomial over a coset of the extended evaluation domain, along with a KZG
+// multiproof of the chunk against the commitment of the blob. The evaluation domain of the blob is a subgroup of the
+// extended domain, so each of its points lies in one of the cosets. The chunks are verified against the commitment of
+// the cert before they are evaluated, so the returned evaluations are guaranteed to be those of the committed blob.
+//
+// Only the chunks which contain the requested evaluations are retrieved, but evaluations with nearby indices are
+// generally in different chunks, so the cost of a request grows with the number of evaluations requested.
+//
+// The relays must serve chunks to the client, which they may restrict to the validators.
+//
+// This struct is goroutine safe.
+type BlobEvaluationRetriever struct {
+	logger           logging.Logger
+	relayClient      RelayClient
+	verifier         encoding.Verifier
+	blobParamsReader BlobVersionParamsReader
+	relayTimeout     time.Duration
+
+	// blobParams caches the parameters of the blob versions, which never change once defined
+	blobParamsLock sync.Mutex
+	blobParams     map[uint16]*core.BlobVersionParameters
+}
+
+// NewBlobEvaluationRetriever creates a BlobEvaluationRetriever. Each request to a relay times out after relayTimeout.
+func NewBlobEvaluationRetriever(
+	logger logging.Logger,
+	relayClient RelayClient,
+	verifier encoding.Verifier,
+	blobParamsReader BlobVersionParamsReader,
+	relayTimeout time.Duration,
+) (*BlobEvaluationRetriever, error) {
+	if relayTimeout <= 0 {
+		return nil, fmt.Errorf("relay timeout must be positive, got %v", relayTimeout)
+	}
+
+	return &BlobEvaluationRetriever{
+		logger:           logger,
+		relayClient:      relayClient,
+		verifier:         verifier,
+		blobParamsReader: blobParamsReader,
+		relayTimeout:     relayTimeout,
+	}, nil
+}
+
+// GetEvaluations retrieves the evaluations of the polynomial of the blob of the cert at the points with indices in
+// [start, end) of the evaluation domain of the blob, verified against the commitment of the cert. The evaluations are
+// returned in order of index.
+func (r *BlobEvaluationRetriever) GetEvaluations(
+	ctx context.Context,
+	eigenDACert *verification.EigenDACert,
+	start uint32,
+	end uint32,
+) ([]BlobEvaluation, error) {
+	blobKey, err := eigenDACert.ComputeBlobKey()
+	if err != nil {
+		return nil, fmt.Errorf("compute blob key: %w", err)
+	}
+	blobHeader := &eigenDACert.BlobInclusionInfo.BlobCertificate.BlobHeader
+	commitments, err := verification.BlobCommitmentsBindingToInternal(&blobHeader.Commitment)
+	if err != nil {
+		return nil, fmt.Errorf("blob commitments binding to internal: %w", err)
+	}
+
+	// the evaluation domain is determined by the length of the blob, so the length must be proven
+	err = r.verifier.VerifyBlobLength(*commitments)
+	if err != nil {
+		return nil, fmt.Errorf("verify blob length: %w", err)
+	}
+	if start >= end || end > uint32(commitments.Length) {
+		return nil, fmt.Errorf("invalid evaluation range [%d, %d) for a blob of %d symbols",
+			start, end, commitments.Length)
+	}
+
+	blobParams, err := r.getBlobParams(ctx, blobHeader.Version)
+	if err != nil {
+		return nil, err
+	}
+	encodingParams, err := corev2.GetEncodingParams(commitments.Length, blobParams)
+	if err != nil {
+		return nil, fmt.Errorf("get encoding params: %w", err)
+	}
+
+	// chunkOfIndex maps the index of each evaluation to the chunk which contains it
+	chunkOfIndex := make(map[uint32]uint32, end-start)
+	chunkSet := make(map[uint32]struct{})
+	for index := start; index < end; index++ {
+		chunkIndex, err := chunkIndexOfEvaluation(index, uint64(commitments.Length), encodingParams)
+		if err != nil {
+			return nil, err
+		}
+		chunkOfIndex[index] = chunkIndex
+		chunkSet[chunkIndex] = struct{}{}
+	}
+	chunkIndices := make([]uint32, 0, len(chunkSet))
+	for chunkIndex := range chunkSet {
+		chunkIndices = append(chunkIndices, chunkIndex)
+	}
+	sort.Slice(chunkIndices, func(i, j int) bool { return chunkIndices[i] < chunkIndices[j] })
+
+	chunks, err := r.getVerifiedChunks(ctx, eigenDACert, *blobKey, commitments, encodingParams, chunkIndices)
+	if err != nil {
+		return nil, err
+	}
+
+	evaluations := make([]BlobEvaluation, 0, end-start)
+	for index := start; index < end; index++ {
+		point, err := evaluationPoint(index, uint64(commitments.Length))
+		if err != nil {
+			return nil, err
+		}
+		evaluations = append(evaluations, BlobEvaluation{
+			Index: index,
+			Value: evaluateFrame(chunks[chunkOfIndex[index]], point),
+		})
+	}
+	return evaluations, nil
+}
+
+// getVerifiedChunks retrieves the chunks with the given indices from the relays of the cert, trying the relays in a
+// random order until one returns chunks which match the commitments
+func (r *BlobEvaluationRetriever) getVerifiedChunks(
+	ctx context.Context,
+	eigenDACert *verification.EigenDACert,
+	blobKey corev2.BlobKey,
+	commitments *encoding.BlobCommitments,
+	encodingParams encoding.EncodingParams,
+	chunkIndices []uint32,
+) (map[uint32]*encoding.Frame, error) {
+	relayKeys := eigenDACert.BlobInclusionInfo.BlobCertificate.RelayKeys
+	if len(relayKeys) == 0 {
+		return nil, errors.New("relay key count is zero")
+	}
+
+	frameIndices := make([]encoding.ChunkNumber, len(chunkIndices))
+	for i, chunkIndex := range chunkIndices {
+		frameIndices[i] = encoding.ChunkNumber(chunkIndex)
+	}
+
+	var errs []error
+	for _, i := range rand.Perm(len(relayKeys)) {
+		relayKey := relayKeys[i]
+		frames, err := r.getChunksWithTimeout(ctx, relayKey, blobKey, chunkIndices)
+		if err == nil {
+			err = r.verifier.VerifyFrames(frames, frameIndices, *commitments, encodingParams)
+		}
+		if err != nil {
+			r.logger.Warn("chunks couldn't be retrieved from relay",
+				"blobKey", blobKey.Hex(), "relayKey", relayKey, "error", err)
+			errs = append(errs, fmt.Errorf("relay %d: %w", relayKey, err))
+			continue
+		}
+
+		chunks := make(map[uint32]*encoding.Frame, len(frames))
+		for j, frame := range frames {
+			chunks[chunkIndices[j]] = frame
+		}
+		return chunks, nil
+	}
+
+	return nil, fmt.Errorf("unable to retrieve chunks of blob %s from any relay: %w", blobKey.Hex(), errors.Join(errs...))
+}
+
+// getChunksWithTimeout retrieves the chunks with the given indices from a relay, and times out after relayTimeout
+func (r *BlobEvaluationRetriever) getChunksWithTimeout(
+	ctx context.Context,
+	relayKey corev2.RelayKey,
+	blobKey corev2.BlobKey,
+	chunkIndices []uint32,
+) ([]*encoding.Frame, error) {
+	timeoutCtx, cancel := context.WithTimeout(ctx, r.relayTimeout)
+	defer cancel()
+
+	bundles, err := r.relayClient.GetChunksByIndex(timeoutCtx, relayKey, []*ChunkRequestByIndex{
+		{BlobKey: blobKey, Indices: chunkIndices},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get chunks from relay: %w", err)
+	}
+	if len(bundles) != 1 {
+		return nil, fmt.Errorf("relay returned %d bundles, expected 1", len(bundles))
+	}
+	frames, err := new(core.Bundle).Deserialize(bundles[0])
+	if err != nil {
+		return nil, fmt.Errorf("deserialize bundle: %w", err)
+	}
+	if len(frames) != len(chunkIndices) {
+		return nil, fmt.Errorf("relay returned %d chunks, expected %d", len(frames), len(chunkIndices))
+	}
+	return frames, nil
+}
+
+// getBlobParams returns the parameters of the blob version, which are read on-chain the first time they are needed
+func (r *BlobEvaluationRetriever) getBlobParams(
+	ctx context.Context,
+	blobVersion uint16,
+) (*core.BlobVersionParameters, error) {
+	r.blobParamsLock.Lock()
+	defer r.blobParamsLock.Unlock()
+
+	if blobParams, ok := r.blobParams[blobVersion]; ok {
+		return blobParams, nil
+	}
+
+	allBlobParams, err := r.blobParamsReader.GetAllVersionedBlobParams(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get blob version params: %w", err)
+	}
+	r.blobParams = allBlobParams
+	blobParams, ok := allBlobParams[blobVersion]
+	if !ok {
+		return nil, fmt.Errorf("invalid blob version %d", blobVersion)
+	}
+	return blobParams, nil
+}
+
+// chunkIndexOfEvaluation returns the index of the chunk which contains the evaluation with the given index, for a blob
+// of blobLength symbols encoded with the given params.
+//
+// The point with index i of the evaluation domain of the blob is w_n^i = w_N^(i*N/n), where w_n is the primitive n-th
+// root of unity, n is the blob length, and N is the size of the extended domain. The chunk with index c holds the
+// evaluations at the coset {w_N^(j + k*numChunks)}, where j is the bit reversal of c in log2(numChunks) bits. The
+// point is in the coset if j = i*N/n mod numChunks, and bit reversal is its own inverse.
+func chunkIndexOfEvaluation(index uint32, blobLength uint64, params encoding.EncodingParams) (uint32, error) {
+	extendedLength := params.NumEvaluations()
+	if blobLength == 0 || extendedLength < blobLength || extendedLength%blobLength != 0 {
+		return 0, fmt.Errorf("blob length %d doesn't divide the extended length %d", blobLength, extendedLength)
+	}
+
+	leadingCosetIndex := (uint64(index) * (extendedLength / blobLength)) % params.NumChunks
+	chunkIndex, err := rs.GetLeadingCosetIndex(leadingCosetIndex, params.NumChunks)
+	if err != nil {
+		return 0, fmt.Errorf("get chunk index: %w", err)
+	}
+	return chunkIndex, nil
+}
+
+// evaluationPoint returns the point with the given index of the evaluation domain of a blob of blobLength symbols
+func evaluationPoint(index uint32, blobLength uint64) (fr.Element, error) {
+	scale := uint8(0)
+	for uint64(1)<<scale < blobLength {
+		scale++
+	}
+	if uint64(1)<<scale != blobLength || int(scale) >= len(encoding.Scale2RootOfUnity) {
+		return fr.Element{}, fmt.Errorf("invalid blob length %d", blobLength)
+	}
+
+	var point fr.Element
+	point.Exp(encoding.Scale2RootOfUnity[scale], new(big.Int).SetUint64(uint64(index)))
+	return point, nil
+}
+
+// evaluateFrame evaluates the interpolating polynomial of a frame at a point. It equals the blob polynomial at the
+// points of the coset of the frame.
+func evaluateFrame(frame *encoding.Frame, point fr.Element) fr.Element {
+	var value fr.Element
+	for i := len(frame.Coeffs) - 1; i >= 0; i-- {
+		value.Mul(&value, &point)
+		value.Add(&value, &frame.Coeffs[i])
+	}
+	return value
+}
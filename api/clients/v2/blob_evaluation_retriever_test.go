@@ -0,0 +1,147 @@
+package clients
+
+import (
+	"context"
+	"math/big"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api/clients/v2/verification"
+	commonv2 "github.com/Layr-Labs/eigenda/api/grpc/common/v2"
+	disperserv2 "github.com/Layr-Labs/eigenda/api/grpc/disperser/v2"
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/core"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/Layr-Labs/eigenda/encoding/kzg"
+	"github.com/Layr-Labs/eigenda/encoding/kzg/prover"
+	kzgverifier "github.com/Layr-Labs/eigenda/encoding/kzg/verifier"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeChunkRelay serves the chunks of a single blob
+type fakeChunkRelay struct {
+	RelayClient
+	frames []*encoding.Frame
+}
+
+func (r *fakeChunkRelay) GetChunksByIndex(
+	_ context.Context,
+	_ corev2.RelayKey,
+	requests []*ChunkRequestByIndex,
+) ([][]byte, error) {
+	bundles := make([][]byte, 0, len(requests))
+	for _, request := range requests {
+		bundle := make(core.Bundle, 0, len(request.Indices))
+		for _, index := range request.Indices {
+			bundle = append(bundle, r.frames[index])
+		}
+		bundleBytes, err := bundle.Serialize()
+		if err != nil {
+			return nil, err
+		}
+		bundles = append(bundles, bundleBytes)
+	}
+	return bundles, nil
+}
+
+type fakeBlobParamsReader map[uint16]*core.BlobVersionParameters
+
+func (r fakeBlobParamsReader) GetAllVersionedBlobParams(
+	context.Context,
+) (map[uint16]*core.BlobVersionParameters, error) {
+	return r, nil
+}
+
+func TestBlobEvaluationRetriever(t *testing.T) {
+	ctx := context.Background()
+	logger, err := common.NewLogger(common.DefaultLoggerConfig())
+	require.NoError(t, err)
+
+	kzgConfig := &kzg.KzgConfig{
+		G1Path:          "../../../inabox/resources/kzg/g1.point",
+		G2Path:          "../../../inabox/resources/kzg/g2.point",
+		CacheDir:        "../../../inabox/resources/kzg/SRSTables",
+		SRSOrder:        3000,
+		SRSNumberToLoad: 3000,
+		NumWorker:       uint64(runtime.GOMAXPROCS(0)),
+		LoadG2Points:    true,
+	}
+	blobProver, err := prover.NewProver(kzgConfig, nil)
+	require.NoError(t, err)
+	blobVerifier, err := kzgverifier.NewVerifier(kzgConfig, nil)
+	require.NoError(t, err)
+
+	// the coefficients of the blob polynomial
+	const blobLength = 64
+	coeffs := make([]fr.Element, blobLength)
+	blobBytes := make([]byte, 0, blobLength*encoding.BYTES_PER_SYMBOL)
+	for i := range coeffs {
+		_, err := coeffs[i].SetRandom()
+		require.NoError(t, err)
+		coeffBytes := coeffs[i].Bytes()
+		blobBytes = append(blobBytes, coeffBytes[:]...)
+	}
+
+	blobParams := &core.BlobVersionParameters{CodingRate: 8, MaxNumOperators: 16, NumChunks: 32}
+	commitments, err := blobProver.GetCommitmentsForPaddedLength(blobBytes)
+	require.NoError(t, err)
+	encodingParams, err := corev2.GetEncodingParams(commitments.Length, blobParams)
+	require.NoError(t, err)
+	frames, err := blobProver.GetFrames(blobBytes, encodingParams)
+	require.NoError(t, err)
+
+	commitmentsProto, err := commitments.ToProtobuf()
+	require.NoError(t, err)
+	inclusionInfo, err := verification.InclusionInfoProtoToBinding(&disperserv2.BlobInclusionInfo{
+		BlobCertificate: &commonv2.BlobCertificate{
+			RelayKeys: []corev2.RelayKey{0},
+			BlobHeader: &commonv2.BlobHeader{
+				Version:       0,
+				QuorumNumbers: []uint32{0},
+				PaymentHeader: &commonv2.PaymentHeader{},
+				Commitment:    commitmentsProto,
+			},
+		},
+	})
+	require.NoError(t, err)
+	cert := &verification.EigenDACert{BlobInclusionInfo: *inclusionInfo}
+
+	relay := &fakeChunkRelay{frames: frames}
+	retriever, err := NewBlobEvaluationRetriever(
+		logger, relay, blobVerifier, fakeBlobParamsReader{0: blobParams}, time.Second)
+	require.NoError(t, err)
+
+	// the evaluations match the blob polynomial at the points of the evaluation domain
+	evaluations, err := retriever.GetEvaluations(ctx, cert, 0, blobLength)
+	require.NoError(t, err)
+	require.Len(t, evaluations, blobLength)
+	for i, evaluation := range evaluations {
+		require.Equal(t, uint32(i), evaluation.Index)
+		var point, expected fr.Element
+		point.Exp(encoding.Scale2RootOfUnity[6], big.NewInt(int64(i)))
+		for j := blobLength - 1; j >= 0; j-- {
+			expected.Mul(&expected, &point)
+			expected.Add(&expected, &coeffs[j])
+		}
+		require.True(t, expected.Equal(&evaluation.Value), "evaluation %d", i)
+	}
+
+	evaluations, err = retriever.GetEvaluations(ctx, cert, 10, 12)
+	require.NoError(t, err)
+	require.Len(t, evaluations, 2)
+	require.Equal(t, uint32(10), evaluations[0].Index)
+
+	_, err = retriever.GetEvaluations(ctx, cert, 10, blobLength+1)
+	require.ErrorContains(t, err, "invalid evaluation range")
+
+	// chunks which don't match the commitment are rejected
+	tamperedFrame := *frames[3]
+	tamperedFrame.Coeffs = append([]fr.Element(nil), tamperedFrame.Coeffs...)
+	tamperedFrame.Coeffs[0].SetOne()
+	relay.frames[3] = &tamperedFrame
+	_, err = retriever.GetEvaluations(ctx, cert, 0, blobLength)
+	require.ErrorContains(t, err, "unable to retrieve chunks")
+}
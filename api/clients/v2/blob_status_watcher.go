@@ -0,0 +1,331 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	dispgrpc "github.com/Layr-Labs/eigenda/api/grpc/disperser/v2"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// BlobStatusCallbacks are invoked by a BlobStatusWatcher when the status of a watched blob changes. Any callback may be
+// nil, in which case the corresponding transitions aren't reported.
+//
+// Callbacks are called one at a time, from the goroutines of the watcher, so they must return quickly. Callbacks may
+// call the methods of the watcher, other than Close.
+type BlobStatusCallbacks struct {
+	// OnCertified is called once the blob is certified. The blob is no longer watched afterward.
+	OnCertified func(blobKey corev2.BlobKey, reply *dispgrpc.BlobStatusReply)
+
+	// OnFailed is called if the dispersal of the blob failed. The blob is no longer watched afterward.
+	OnFailed func(blobKey corev2.BlobKey, reply *dispgrpc.BlobStatusReply)
+
+	// OnExpired is called if the blob reached neither the certified nor the failed status within the expiry timeout of
+	// the watcher. lastStatus is the last status observed for the blob. The blob is no longer watched afterward.
+	OnExpired func(blobKey corev2.BlobKey, lastStatus dispgrpc.BlobStatus)
+
+	// OnStatusChange is called when the blob moves to an intermediate status, e.g. from QUEUED to ENCODED
+	OnStatusChange func(blobKey corev2.BlobKey, previousStatus dispgrpc.BlobStatus, newStatus dispgrpc.BlobStatus)
+}
+
+// watchedBlob is the state of a blob watched by a BlobStatusWatcher
+type watchedBlob struct {
+	status   dispgrpc.BlobStatus
+	deadline time.Time
+}
+
+// BlobStatusWatcher tracks the statuses of a set of blobs, and invokes callbacks as the blobs are certified, fail, or
+// expire.
+//
+// Certifications are received from the certification event stream of the disperser where it is supported. The stream
+// is resubscribed from the last event received if it breaks, and the statuses of all watched blobs are queried in bulk
+// on every reconnect, to backfill the transitions missed while disconnected. The statuses are also polled
+// periodically, since failures aren't reported on the stream.
+//
+// This struct is goroutine safe.
+type BlobStatusWatcher struct {
+	logger          logging.Logger
+	config          BlobStatusWatcherConfig
+	disperserClient DisperserClient
+	callbacks       BlobStatusCallbacks
+
+	lock    sync.Mutex
+	watched map[corev2.BlobKey]*watchedBlob
+
+	// callbackLock makes sure that the callbacks are called one at a time
+	callbackLock sync.Mutex
+
+	// backfill requests an immediate poll of the statuses of all watched blobs
+	backfill chan struct{}
+
+	cancel    context.CancelFunc
+	wg        sync.WaitGroup
+	closeOnce sync.Once
+}
+
+// NewBlobStatusWatcher creates a BlobStatusWatcher, and starts watching for status changes. Close must be called to
+// stop the watcher.
+func NewBlobStatusWatcher(
+	logger logging.Logger,
+	config BlobStatusWatcherConfig,
+	disperserClient DisperserClient,
+	callbacks BlobStatusCallbacks,
+) (*BlobStatusWatcher, error) {
+	err := config.checkAndSetDefaults()
+	if err != nil {
+		return nil, fmt.Errorf("check and set BlobStatusWatcherConfig defaults: %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	w := &BlobStatusWatcher{
+		logger:          logger,
+		config:          config,
+		disperserClient: disperserClient,
+		callbacks:       callbacks,
+		watched:         make(map[corev2.BlobKey]*watchedBlob),
+		backfill:        make(chan struct{}, 1),
+		cancel:          cancel,
+	}
+
+	w.wg.Add(2)
+	go w.pollLoop(ctx)
+	go w.streamLoop(ctx, uint64(time.Now().UnixNano()))
+
+	return w, nil
+}
+
+// Watch starts watching the blob with the given key. The blob expires if it isn't certified or failed within the
+// ExpiryTimeout of the watcher. Watching a blob which is already watched has no effect.
+func (w *BlobStatusWatcher) Watch(blobKey corev2.BlobKey) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if _, ok := w.watched[blobKey]; ok {
+		return
+	}
+	w.watched[blobKey] = &watchedBlob{
+		status:   dispgrpc.BlobStatus_UNKNOWN,
+		deadline: time.Now().Add(w.config.ExpiryTimeout),
+	}
+}
+
+// Unwatch stops watching the blob with the given key, without invoking any callback
+func (w *BlobStatusWatcher) Unwatch(blobKey corev2.BlobKey) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	delete(w.watched, blobKey)
+}
+
+// WatchedCount returns the number of blobs currently watched
+func (w *BlobStatusWatcher) WatchedCount() int {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	return len(w.watched)
+}
+
+// Close stops the watcher, and waits for its goroutines to exit. No callbacks are called after Close returns.
+func (w *BlobStatusWatcher) Close() error {
+	w.closeOnce.Do(func() {
+		w.cancel()
+		w.wg.Wait()
+	})
+	return nil
+}
+
+// pollLoop polls the statuses of the watched blobs every PollInterval, or immediately when a backfill is requested
+func (w *BlobStatusWatcher) pollLoop(ctx context.Context) {
+	defer w.wg.Done()
+
+	ticker := time.NewTicker(w.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-w.backfill:
+		}
+		w.poll(ctx)
+	}
+}
+
+// poll expires the blobs past their deadline, and queries the statuses of the remaining blobs in batches of
+// StatusBatchSize
+func (w *BlobStatusWatcher) poll(ctx context.Context) {
+	now := time.Now()
+	var expired []corev2.BlobKey
+	var expiredStatuses []dispgrpc.BlobStatus
+	var blobKeys []corev2.BlobKey
+
+	w.lock.Lock()
+	for blobKey, blob := range w.watched {
+		if now.After(blob.deadline) {
+			expired = append(expired, blobKey)
+			expiredStatuses = append(expiredStatuses, blob.status)
+			delete(w.watched, blobKey)
+			continue
+		}
+		blobKeys = append(blobKeys, blobKey)
+	}
+	w.lock.Unlock()
+
+	for i, blobKey := range expired {
+		w.logger.Warn("Watched blob expired", "blobKey", blobKey.Hex(), "lastStatus", expiredStatuses[i].String())
+		w.invokeCallback(ctx, func() {
+			if w.callbacks.OnExpired != nil {
+				w.callbacks.OnExpired(blobKey, expiredStatuses[i])
+			}
+		})
+	}
+
+	for start := 0; start < len(blobKeys); start += w.config.StatusBatchSize {
+		end := min(start+w.config.StatusBatchSize, len(blobKeys))
+		batch := blobKeys[start:end]
+
+		timeoutCtx, cancel := context.WithTimeout(ctx, w.config.StatusQueryTimeout)
+		reply, err := w.disperserClient.GetBlobStatuses(timeoutCtx, batch)
+		cancel()
+		if err != nil {
+			if ctx.Err() == nil {
+				w.logger.Warn("Failed to get statuses of watched blobs", "count", len(batch), "err", err)
+			}
+			return
+		}
+
+		for i, result := range reply.GetResults() {
+			if i >= len(batch) {
+				break
+			}
+			if result.GetBlobStatus() == nil {
+				w.logger.Debug("Status of watched blob unavailable",
+					"blobKey", batch[i].Hex(), "err", result.GetError())
+				continue
+			}
+			w.updateStatus(ctx, batch[i], result.GetBlobStatus())
+		}
+	}
+}
+
+// streamLoop subscribes to the certification events of the disperser, resubscribing from the timestamp of the last
+// event received whenever the stream breaks. It returns if the disperser doesn't support the stream, leaving the
+// watcher to rely on polling.
+func (w *BlobStatusWatcher) streamLoop(ctx context.Context, startTimestamp uint64) {
+	defer w.wg.Done()
+
+	for {
+		lastTimestamp, err := w.streamCertificationEvents(ctx, startTimestamp)
+		if ctx.Err() != nil {
+			return
+		}
+		if status.Code(err) == codes.Unimplemented {
+			w.logger.Debug("Certification events unavailable, polling blob statuses", "err", err)
+			return
+		}
+		w.logger.Debug("Certification event stream ended, resubscribing", "err", err)
+		startTimestamp = max(startTimestamp, lastTimestamp)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(w.config.ReconnectInterval):
+		}
+
+		// transitions may have been missed while disconnected
+		select {
+		case w.backfill <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// streamCertificationEvents receives certification events until the stream fails, and returns the timestamp of the
+// last event received along with the error the stream failed with
+func (w *BlobStatusWatcher) streamCertificationEvents(ctx context.Context, startTimestamp uint64) (uint64, error) {
+	stream, err := w.disperserClient.SubscribeCertificationEvents(ctx, startTimestamp)
+	if err != nil {
+		return startTimestamp, err
+	}
+
+	lastTimestamp := startTimestamp
+	for {
+		event, err := stream.Recv()
+		if err != nil {
+			return lastTimestamp, err
+		}
+		lastTimestamp = max(lastTimestamp, event.GetTimestamp())
+
+		blobCertified := event.GetBlobCertified()
+		if blobCertified == nil {
+			continue
+		}
+		blobKey, err := corev2.BytesToBlobKey(blobCertified.GetBlobKey())
+		if err != nil {
+			w.logger.Warn("Invalid blob key in certification event", "err", err)
+			continue
+		}
+		w.updateStatus(ctx, blobKey, &dispgrpc.BlobStatusReply{
+			Status:            dispgrpc.BlobStatus_COMPLETE,
+			SignedBatch:       blobCertified.GetSignedBatch(),
+			BlobInclusionInfo: blobCertified.GetBlobInclusionInfo(),
+		})
+	}
+}
+
+// updateStatus records the status of a blob, and invokes the callback of the transition if the status changed. Blobs
+// which aren't watched are ignored.
+func (w *BlobStatusWatcher) updateStatus(ctx context.Context, blobKey corev2.BlobKey, reply *dispgrpc.BlobStatusReply) {
+	newStatus := reply.GetStatus()
+
+	w.lock.Lock()
+	blob, ok := w.watched[blobKey]
+	if !ok || blob.status == newStatus {
+		w.lock.Unlock()
+		return
+	}
+	previousStatus := blob.status
+	blob.status = newStatus
+	terminal := newStatus == dispgrpc.BlobStatus_COMPLETE || newStatus == dispgrpc.BlobStatus_FAILED
+	if terminal {
+		delete(w.watched, blobKey)
+	}
+	w.lock.Unlock()
+
+	w.logger.Debug("Watched blob status changed", "blobKey", blobKey.Hex(),
+		"previousStatus", previousStatus.String(), "newStatus", newStatus.String())
+
+	w.invokeCallback(ctx, func() {
+		switch newStatus {
+		case dispgrpc.BlobStatus_COMPLETE:
+			if w.callbacks.OnCertified != nil {
+				w.callbacks.OnCertified(blobKey, reply)
+			}
+		case dispgrpc.BlobStatus_FAILED:
+			if w.callbacks.OnFailed != nil {
+				w.callbacks.OnFailed(blobKey, reply)
+			}
+		default:
+			if w.callbacks.OnStatusChange != nil {
+				w.callbacks.OnStatusChange(blobKey, previousStatus, newStatus)
+			}
+		}
+	})
+}
+
+// invokeCallback calls the callback, unless the watcher is closed
+func (w *BlobStatusWatcher) invokeCallback(ctx context.Context, callback func()) {
+	w.callbackLock.Lock()
+	defer w.callbackLock.Unlock()
+
+	if ctx.Err() != nil {
+		return
+	}
+	callback()
+}
@@ -0,0 +1,156 @@
+package clients
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	dispgrpc "github.com/Layr-Labs/eigenda/api/grpc/disperser/v2"
+	"github.com/Layr-Labs/eigenda/common"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeStatusesDisperserClient serves blob statuses in bulk, and optionally a stream of certification events
+type fakeStatusesDisperserClient struct {
+	DisperserClient
+
+	lock     sync.Mutex
+	statuses map[corev2.BlobKey]dispgrpc.BlobStatus
+
+	// events are sent on the certification event stream. If nil, the stream isn't supported.
+	events chan *dispgrpc.CertificationEvent
+}
+
+func (c *fakeStatusesDisperserClient) setStatus(blobKey corev2.BlobKey, status dispgrpc.BlobStatus) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.statuses[blobKey] = status
+}
+
+func (c *fakeStatusesDisperserClient) GetBlobStatuses(
+	ctx context.Context,
+	blobKeys []corev2.BlobKey,
+) (*dispgrpc.BlobStatusesReply, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	reply := &dispgrpc.BlobStatusesReply{}
+	for _, blobKey := range blobKeys {
+		result := &dispgrpc.BlobStatusResult{BlobKey: blobKey[:]}
+		status, ok := c.statuses[blobKey]
+		if ok {
+			result.BlobStatus = &dispgrpc.BlobStatusReply{Status: status}
+		} else {
+			result.Error = "blob not found"
+		}
+		reply.Results = append(reply.Results, result)
+	}
+	return reply, nil
+}
+
+func (c *fakeStatusesDisperserClient) SubscribeCertificationEvents(
+	ctx context.Context,
+	startTimestamp uint64,
+) (dispgrpc.Disperser_SubscribeCertificationEventsClient, error) {
+	return &fakeCertificationEventStream{ctx: ctx, events: c.events}, nil
+}
+
+func TestBlobStatusWatcher(t *testing.T) {
+	logger, err := common.NewLogger(common.DefaultLoggerConfig())
+	require.NoError(t, err)
+
+	certifiedKey := corev2.BlobKey{1}
+	failedKey := corev2.BlobKey{2}
+	expiredKey := corev2.BlobKey{3}
+	disperserClient := &fakeStatusesDisperserClient{
+		statuses: map[corev2.BlobKey]dispgrpc.BlobStatus{
+			certifiedKey: dispgrpc.BlobStatus_GATHERING_SIGNATURES,
+			failedKey:    dispgrpc.BlobStatus_QUEUED,
+		},
+		events: make(chan *dispgrpc.CertificationEvent, 1),
+	}
+
+	certified := make(chan corev2.BlobKey, 1)
+	failed := make(chan corev2.BlobKey, 1)
+	expired := make(chan dispgrpc.BlobStatus, 1)
+	var changesLock sync.Mutex
+	changes := make(map[corev2.BlobKey][]dispgrpc.BlobStatus)
+	callbacks := BlobStatusCallbacks{
+		OnCertified: func(blobKey corev2.BlobKey, reply *dispgrpc.BlobStatusReply) {
+			require.Equal(t, uint32(7), reply.GetBlobInclusionInfo().GetBlobIndex())
+			certified <- blobKey
+		},
+		OnFailed: func(blobKey corev2.BlobKey, reply *dispgrpc.BlobStatusReply) {
+			failed <- blobKey
+		},
+		OnExpired: func(blobKey corev2.BlobKey, lastStatus dispgrpc.BlobStatus) {
+			require.Equal(t, expiredKey, blobKey)
+			expired <- lastStatus
+		},
+		OnStatusChange: func(blobKey corev2.BlobKey, previousStatus dispgrpc.BlobStatus, newStatus dispgrpc.BlobStatus) {
+			changesLock.Lock()
+			defer changesLock.Unlock()
+			changes[blobKey] = append(changes[blobKey], newStatus)
+		},
+	}
+
+	watcher, err := NewBlobStatusWatcher(
+		logger,
+		BlobStatusWatcherConfig{PollInterval: 5 * time.Millisecond, ExpiryTimeout: time.Second},
+		disperserClient,
+		callbacks)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, watcher.Close())
+	}()
+
+	watcher.Watch(certifiedKey)
+	watcher.Watch(failedKey)
+	watcher.Watch(expiredKey)
+	require.Equal(t, 3, watcher.WatchedCount())
+
+	statusChanges := func(blobKey corev2.BlobKey) int {
+		changesLock.Lock()
+		defer changesLock.Unlock()
+		return len(changes[blobKey])
+	}
+	require.Eventually(t, func() bool { return statusChanges(failedKey) == 1 }, 5*time.Second, time.Millisecond)
+
+	// the blob is certified through the stream, while the disperser still reports it as gathering signatures
+	disperserClient.events <- &dispgrpc.CertificationEvent{
+		Event: &dispgrpc.CertificationEvent_BlobCertified{
+			BlobCertified: &dispgrpc.BlobCertifiedEvent{
+				BlobKey:           certifiedKey[:],
+				BlobInclusionInfo: &dispgrpc.BlobInclusionInfo{BlobIndex: 7},
+			},
+		},
+	}
+	require.Equal(t, certifiedKey, waitFor(t, certified))
+
+	// the failure is observed by polling
+	disperserClient.setStatus(failedKey, dispgrpc.BlobStatus_ENCODED)
+	require.Eventually(t, func() bool { return statusChanges(failedKey) == 2 }, 5*time.Second, time.Millisecond)
+	disperserClient.setStatus(failedKey, dispgrpc.BlobStatus_FAILED)
+	require.Equal(t, failedKey, waitFor(t, failed))
+
+	// the status of the last blob is never available
+	require.Equal(t, dispgrpc.BlobStatus_UNKNOWN, waitFor(t, expired))
+	require.Equal(t, 0, watcher.WatchedCount())
+
+	changesLock.Lock()
+	defer changesLock.Unlock()
+	require.Equal(t, []dispgrpc.BlobStatus{dispgrpc.BlobStatus_QUEUED, dispgrpc.BlobStatus_ENCODED}, changes[failedKey])
+}
+
+func waitFor[T any](t *testing.T, values <-chan T) T {
+	select {
+	case value := <-values:
+		return value
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "timed out")
+		var zero T
+		return zero
+	}
+}
@@ -0,0 +1,129 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/Layr-Labs/eigenda/api/clients/v2/coretypes"
+	"github.com/Layr-Labs/eigenda/api/clients/v2/verification"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	lru "github.com/hashicorp/golang-lru/v2"
+	"golang.org/x/sync/singleflight"
+)
+
+// CachedPayloadRetriever wraps a PayloadRetriever with an in-process LRU cache of the retrieved payloads, so that
+// repeated retrievals of the same blob, e.g. by a rollup derivation pipeline replaying its inputs after a restart,
+// don't fetch and decode the blob again.
+//
+// Payloads are keyed by blob key. The blob key commits to the blob header, which includes the commitment to the blob,
+// so a payload cached for a blob key is the payload of every cert with that blob key. The cache is bounded by the total
+// size of the cached payloads.
+//
+// The cached payloads are shared between callers, and must not be modified.
+//
+// This struct is goroutine safe.
+type CachedPayloadRetriever struct {
+	retriever PayloadRetriever
+
+	// lock guards cache and size
+	lock  sync.Mutex
+	cache *lru.Cache[corev2.BlobKey, *coretypes.Payload]
+	// size is the total size of the cached payloads, in bytes
+	size    uint64
+	maxSize uint64
+
+	// requests deduplicates the concurrent retrievals of a blob
+	requests singleflight.Group
+}
+
+var _ PayloadRetriever = &CachedPayloadRetriever{}
+
+// NewCachedPayloadRetriever creates a CachedPayloadRetriever which caches up to maxSize bytes of the payloads
+// retrieved by retriever.
+func NewCachedPayloadRetriever(retriever PayloadRetriever, maxSize uint64) (*CachedPayloadRetriever, error) {
+	if maxSize == 0 {
+		return nil, fmt.Errorf("max cache size must be positive")
+	}
+
+	pr := &CachedPayloadRetriever{
+		retriever: retriever,
+		maxSize:   maxSize,
+	}
+	// The cache is bounded by size rather than by number of entries, which is enforced in add
+	cache, err := lru.NewWithEvict[corev2.BlobKey, *coretypes.Payload](
+		int(min(maxSize, uint64(1<<31-1))),
+		func(_ corev2.BlobKey, payload *coretypes.Payload) {
+			pr.size -= uint64(len(payload.Serialize()))
+		})
+	if err != nil {
+		return nil, fmt.Errorf("new lru cache: %w", err)
+	}
+	pr.cache = cache
+
+	return pr, nil
+}
+
+// GetPayload returns the payload of the blob of the cert from the cache if possible, or retrieves it with the wrapped
+// PayloadRetriever otherwise.
+func (pr *CachedPayloadRetriever) GetPayload(
+	ctx context.Context,
+	eigenDACert *verification.EigenDACert,
+) (*coretypes.Payload, error) {
+
+	blobKey, err := eigenDACert.ComputeBlobKey()
+	if err != nil {
+		return nil, fmt.Errorf("compute blob key: %w", err)
+	}
+
+	pr.lock.Lock()
+	payload, ok := pr.cache.Get(*blobKey)
+	pr.lock.Unlock()
+	if ok {
+		return payload, nil
+	}
+
+	result, err, _ := pr.requests.Do(string(blobKey[:]), func() (interface{}, error) {
+		payload, err := pr.retriever.GetPayload(ctx, eigenDACert)
+		if err != nil {
+			return nil, err
+		}
+		pr.add(*blobKey, payload)
+		return payload, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result.(*coretypes.Payload), nil
+}
+
+// add caches a payload, evicting the least recently used payloads to stay within the maximum size. Payloads larger
+// than the cache aren't cached.
+func (pr *CachedPayloadRetriever) add(blobKey corev2.BlobKey, payload *coretypes.Payload) {
+	size := uint64(len(payload.Serialize()))
+	if size > pr.maxSize {
+		return
+	}
+
+	pr.lock.Lock()
+	defer pr.lock.Unlock()
+	if pr.cache.Contains(blobKey) {
+		return
+	}
+	pr.cache.Add(blobKey, payload)
+	pr.size += size
+	for pr.size > pr.maxSize {
+		pr.cache.RemoveOldest()
+	}
+}
+
+// Close closes the wrapped PayloadRetriever, if it can be closed.
+func (pr *CachedPayloadRetriever) Close() error {
+	closer, ok := pr.retriever.(io.Closer)
+	if !ok {
+		return nil
+	}
+	return closer.Close()
+}
@@ -0,0 +1,154 @@
+package clients
+
+import (
+	"sync"
+	"time"
+)
+
+// circuitBreakerState is the state of a circuitBreaker
+type circuitBreakerState int
+
+const (
+	// circuitClosed means that requests are sent to the endpoint
+	circuitClosed circuitBreakerState = iota
+	// circuitOpen means that the endpoint is degraded, and is skipped until the open duration has elapsed
+	circuitOpen
+	// circuitHalfOpen means that the open duration has elapsed, and requests are sent to the endpoint to probe whether
+	// it has recovered
+	circuitHalfOpen
+)
+
+func (s circuitBreakerState) String() string {
+	switch s {
+	case circuitClosed:
+		return "closed"
+	case circuitOpen:
+		return "open"
+	case circuitHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// requestOutcome is the outcome of a request, as recorded by a circuitBreaker
+type requestOutcome struct {
+	failed bool
+	slow   bool
+}
+
+// circuitBreaker tracks the outcomes of the recent requests to an endpoint, and trips when too many of them failed or
+// were slow, so that the endpoint is skipped while it is degraded:
+//
+//   - while closed, the outcomes of the last CircuitBreakerWindow requests are recorded. Once at least
+//     CircuitBreakerMinRequests are recorded, the breaker opens if the fraction of failed requests reaches
+//     CircuitBreakerFailureRate, or the fraction of requests slower than CircuitBreakerSlowRequestThreshold reaches
+//     CircuitBreakerSlowRate
+//   - while open, the endpoint is skipped for CircuitBreakerOpenDuration
+//   - once the open duration has elapsed, the breaker is half-open, and the next request probes the endpoint. The
+//     breaker closes if the probe succeeds in time, and opens again otherwise. Requests made while the probe is in
+//     flight are not held back, and the first to complete decides the state.
+//
+// Times are passed in by the caller, so that the breaker doesn't depend on the clock.
+//
+// This struct is goroutine safe.
+type circuitBreaker struct {
+	lock   sync.Mutex
+	config *FailoverDisperserClientConfig
+
+	state    circuitBreakerState
+	openedAt time.Time
+
+	// outcomes is a ring buffer of the outcomes of the most recent requests while closed
+	outcomes []requestOutcome
+	next     int
+	count    int
+}
+
+func newCircuitBreaker(config *FailoverDisperserClientConfig) *circuitBreaker {
+	return &circuitBreaker{
+		config:   config,
+		outcomes: make([]requestOutcome, config.CircuitBreakerWindow),
+	}
+}
+
+// available returns whether requests should be sent to the endpoint at time now. It moves an open breaker whose open
+// duration has elapsed to half-open.
+func (b *circuitBreaker) available(now time.Time) bool {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if b.state == circuitOpen && now.Sub(b.openedAt) >= b.config.CircuitBreakerOpenDuration {
+		b.state = circuitHalfOpen
+	}
+	return b.state != circuitOpen
+}
+
+// record records the outcome of a request to the endpoint which completed at time now, and returns the state of the
+// breaker before and after the request
+func (b *circuitBreaker) record(
+	now time.Time,
+	latency time.Duration,
+	failed bool,
+) (before circuitBreakerState, after circuitBreakerState) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	before = b.state
+	outcome := requestOutcome{
+		failed: failed,
+		slow:   latency > b.config.CircuitBreakerSlowRequestThreshold,
+	}
+
+	switch b.state {
+	case circuitClosed:
+		b.outcomes[b.next] = outcome
+		b.next = (b.next + 1) % len(b.outcomes)
+		b.count = min(b.count+1, len(b.outcomes))
+		if b.tripped() {
+			b.open(now)
+		}
+	case circuitHalfOpen:
+		if outcome.failed || outcome.slow {
+			b.open(now)
+		} else {
+			b.state = circuitClosed
+		}
+	case circuitOpen:
+		// the request was sent as a last resort, while the endpoint was skipped. Its outcome doesn't close the breaker
+		// before the probe, but a failure extends the open duration.
+		if outcome.failed {
+			b.openedAt = now
+		}
+	}
+
+	return before, b.state
+}
+
+// tripped returns whether the recorded outcomes exceed the failure or slow rates
+func (b *circuitBreaker) tripped() bool {
+	if b.count < b.config.CircuitBreakerMinRequests {
+		return false
+	}
+
+	failures, slow := 0, 0
+	for i := 0; i < b.count; i++ {
+		if b.outcomes[i].failed {
+			failures++
+		}
+		if b.outcomes[i].slow {
+			slow++
+		}
+	}
+	return float64(failures) >= b.config.CircuitBreakerFailureRate*float64(b.count) ||
+		float64(slow) >= b.config.CircuitBreakerSlowRate*float64(b.count)
+}
+
+// open opens the breaker at time now, and forgets the recorded outcomes, so that the breaker starts afresh once it
+// closes again
+func (b *circuitBreaker) open(now time.Time) {
+	b.state = circuitOpen
+	b.openedAt = now
+	b.next = 0
+	b.count = 0
+}
@@ -0,0 +1,80 @@
+package clients
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestCircuitBreaker(t *testing.T) *circuitBreaker {
+	config := &FailoverDisperserClientConfig{
+		CircuitBreakerWindow:               4,
+		CircuitBreakerMinRequests:          4,
+		CircuitBreakerFailureRate:          0.5,
+		CircuitBreakerSlowRate:             0.75,
+		CircuitBreakerSlowRequestThreshold: time.Second,
+		CircuitBreakerOpenDuration:         time.Minute,
+	}
+	require.NoError(t, config.checkAndSetDefaults())
+	return newCircuitBreaker(config)
+}
+
+func TestCircuitBreakerFailures(t *testing.T) {
+	breaker := newTestCircuitBreaker(t)
+	now := time.Unix(1000, 0)
+
+	// a single failure isn't enough to open the breaker
+	_, state := breaker.record(now, time.Millisecond, true)
+	require.Equal(t, circuitClosed, state)
+	for i := 0; i < 3; i++ {
+		_, state = breaker.record(now, time.Millisecond, false)
+		require.Equal(t, circuitClosed, state)
+	}
+
+	// the first failure is pushed out of the window, so half of the window has failed after two more failures
+	_, state = breaker.record(now, time.Millisecond, true)
+	require.Equal(t, circuitClosed, state)
+	before, state := breaker.record(now, time.Millisecond, true)
+	require.Equal(t, circuitClosed, before)
+	require.Equal(t, circuitOpen, state)
+	require.False(t, breaker.available(now.Add(59*time.Second)))
+
+	// once the open duration has elapsed, a failed probe opens the breaker again
+	require.True(t, breaker.available(now.Add(time.Minute)))
+	_, state = breaker.record(now.Add(time.Minute), time.Millisecond, true)
+	require.Equal(t, circuitOpen, state)
+	require.False(t, breaker.available(now.Add(time.Minute+time.Second)))
+
+	// a successful probe closes the breaker
+	now = now.Add(2 * time.Minute)
+	require.True(t, breaker.available(now))
+	before, state = breaker.record(now, time.Millisecond, false)
+	require.Equal(t, circuitHalfOpen, before)
+	require.Equal(t, circuitClosed, state)
+	require.True(t, breaker.available(now))
+}
+
+func TestCircuitBreakerLatency(t *testing.T) {
+	breaker := newTestCircuitBreaker(t)
+	now := time.Unix(1000, 0)
+
+	_, state := breaker.record(now, 2*time.Second, false)
+	require.Equal(t, circuitClosed, state)
+	_, state = breaker.record(now, time.Millisecond, false)
+	require.Equal(t, circuitClosed, state)
+	_, state = breaker.record(now, 2*time.Second, false)
+	require.Equal(t, circuitClosed, state)
+	_, state = breaker.record(now, 2*time.Second, false)
+	require.Equal(t, circuitOpen, state)
+
+	// a slow probe opens the breaker again
+	now = now.Add(time.Minute)
+	require.True(t, breaker.available(now))
+	_, state = breaker.record(now, 2*time.Second, false)
+	require.Equal(t, circuitOpen, state)
+
+	// requests sent as a last resort while the breaker is open don't close it
+	_, state = breaker.record(now, time.Millisecond, false)
+	require.Equal(t, circuitOpen, state)
+}
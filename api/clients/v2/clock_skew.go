@@ -0,0 +1,78 @@
+package clients
+
+import (
+	"sync"
+	"time"
+)
+
+// clockSkewWindowSize is the number of recent samples from which the clock skew is estimated
+const clockSkewWindowSize = 8
+
+// clockSkewSample is a single measurement of the clock skew, taken from the time reported by the disperser in reply to
+// a request
+type clockSkewSample struct {
+	// skew is the amount by which the clock of the disperser was ahead of the local clock
+	skew time.Duration
+	// roundTrip is the round trip time of the request, which bounds the error of the skew
+	roundTrip time.Duration
+}
+
+// clockSkewEstimator estimates the skew between the local clock and the clock of the disperser, in the manner of NTP.
+// Each sample assumes that the disperser read its clock halfway through the round trip of the request, so the error
+// of a sample is at most half of its round trip time. The estimate is taken from the recent sample with the shortest
+// round trip.
+//
+// This struct is goroutine safe.
+type clockSkewEstimator struct {
+	lock    sync.Mutex
+	samples []clockSkewSample
+	next    int
+}
+
+func newClockSkewEstimator(size int) *clockSkewEstimator {
+	return &clockSkewEstimator{
+		samples: make([]clockSkewSample, 0, size),
+	}
+}
+
+// addSample records a sample from a request sent at requestTime, whose reply was received at replyTime, and in which
+// the disperser reported remoteTimestamp, a Unix timestamp in nanoseconds. Replies without a timestamp are ignored.
+func (e *clockSkewEstimator) addSample(requestTime time.Time, replyTime time.Time, remoteTimestamp uint64) {
+	if remoteTimestamp == 0 {
+		return
+	}
+	roundTrip := replyTime.Sub(requestTime)
+	midpoint := requestTime.Add(roundTrip / 2)
+	sample := clockSkewSample{
+		skew:      time.Unix(0, int64(remoteTimestamp)).Sub(midpoint),
+		roundTrip: roundTrip,
+	}
+
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	if len(e.samples) < cap(e.samples) {
+		e.samples = append(e.samples, sample)
+		return
+	}
+	e.samples[e.next] = sample
+	e.next = (e.next + 1) % len(e.samples)
+}
+
+// skew returns the estimated amount by which the clock of the disperser is ahead of the local clock. The second return
+// value is false if no sample has been recorded yet.
+func (e *clockSkewEstimator) skew() (time.Duration, bool) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	if len(e.samples) == 0 {
+		return 0, false
+	}
+	best := e.samples[0]
+	for _, sample := range e.samples[1:] {
+		if sample.roundTrip < best.roundTrip {
+			best = sample
+		}
+	}
+	return best.skew, true
+}
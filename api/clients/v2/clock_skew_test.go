@@ -0,0 +1,70 @@
+package clients
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClockSkewEstimator(t *testing.T) {
+	estimator := newClockSkewEstimator(3)
+	_, measured := estimator.skew()
+	require.False(t, measured)
+
+	// replies without a timestamp are ignored
+	start := time.Unix(1000, 0)
+	estimator.addSample(start, start.Add(time.Second), 0)
+	_, measured = estimator.skew()
+	require.False(t, measured)
+
+	// the disperser is 5 seconds ahead, and read its clock halfway through the request
+	estimator.addSample(start, start.Add(2*time.Second), uint64(start.Add(6*time.Second).UnixNano()))
+	skew, measured := estimator.skew()
+	require.True(t, measured)
+	require.Equal(t, 5*time.Second, skew)
+
+	// the sample with the shortest round trip is the most accurate
+	estimator.addSample(start, start.Add(200*time.Millisecond), uint64(start.Add(4*time.Second).UnixNano()))
+	estimator.addSample(start, start.Add(time.Second), uint64(start.Add(10*time.Second).UnixNano()))
+	skew, _ = estimator.skew()
+	require.Equal(t, 3900*time.Millisecond, skew)
+
+	// old samples are dropped
+	estimator.addSample(start, start.Add(time.Second), uint64(start.Add(-time.Second).UnixNano()))
+	estimator.addSample(start, start.Add(time.Second), uint64(start.Add(-time.Second).UnixNano()))
+	skew, _ = estimator.skew()
+	require.Equal(t, -1500*time.Millisecond, skew)
+}
+
+func TestPaymentTimestamp(t *testing.T) {
+	client := &disperserClient{
+		config:    &DisperserClientConfig{},
+		clockSkew: newClockSkewEstimator(clockSkewWindowSize),
+	}
+
+	// until the skew is measured, the local clock is used
+	before := time.Now().UnixNano()
+	timestamp, err := client.paymentTimestamp()
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, timestamp, before)
+
+	// the disperser is an hour behind
+	now := time.Now()
+	client.clockSkew.addSample(now, now, uint64(now.Add(-time.Hour).UnixNano()))
+
+	// timestamps aren't adjusted by default
+	timestamp, err = client.paymentTimestamp()
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, timestamp, now.UnixNano())
+
+	client.config.AdjustForClockSkew = true
+	timestamp, err = client.paymentTimestamp()
+	require.NoError(t, err)
+	require.Less(t, timestamp, now.Add(-59*time.Minute).UnixNano())
+
+	// the skew exceeds the guard
+	client.config.MaxClockSkew = time.Minute
+	_, err = client.paymentTimestamp()
+	require.ErrorContains(t, err, "maximum skew")
+}
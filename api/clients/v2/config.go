@@ -2,10 +2,13 @@ package clients
 
 import (
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/Layr-Labs/eigenda/api/clients/codecs"
+	"github.com/Layr-Labs/eigenda/api/clients/v2/coretypes"
 	v2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/docker/go-units"
 )
 
 // PayloadClientConfig contains configuration values that are needed by both PayloadRetriever and PayloadDisperser
@@ -45,6 +48,14 @@ type PayloadClientConfig struct {
 	// BlobVersion needs to point to a version defined in the threshold registry contract.
 	// https://github.com/Layr-Labs/eigenda/blob/3ed9ef6ed3eb72c46ce3050eb84af28f0afdfae2/contracts/src/interfaces/IEigenDAThresholdRegistry.sol#L6
 	BlobVersion v2.BlobVersion
+
+	// Metrics receives the metrics of the client. If nil, no metrics are recorded.
+	Metrics ClientMetrics
+
+	// PayloadEncryptor encrypts payloads before they are dispersed, and decrypts them after they are retrieved. If nil,
+	// payloads are stored in plaintext. Retrievers configured with a PayloadEncryptor reject payloads which it can't
+	// decrypt, including plaintext payloads.
+	PayloadEncryptor PayloadEncryptor
 }
 
 // RelayPayloadRetrieverConfig contains an embedded PayloadClientConfig, plus all additional configuration values needed
@@ -73,6 +84,38 @@ type ValidatorPayloadRetrieverConfig struct {
 
 	// The maximum number of simultaneous connections to use when fetching chunks during validator retrieval
 	MaxConnectionCount uint
+
+	// Interceptors are installed on the connections to the validators. If nil, no interceptors are installed.
+	Interceptors *GrpcInterceptors
+}
+
+// ArchivePayloadRetrieverConfig contains an embedded PayloadClientConfig, plus all additional configuration values
+// needed by an ArchivePayloadRetriever
+type ArchivePayloadRetrieverConfig struct {
+	PayloadClientConfig
+
+	// The timeout duration for retrieving a blob from the archive
+	ArchiveTimeout time.Duration
+}
+
+// RetrievalClientConfig contains the configuration values needed by a RetrievalClient
+type RetrievalClientConfig struct {
+	// The maximum number of simultaneous requests for chunks made while retrieving a single blob
+	MaxConnectionCount int
+
+	// The maximum number of simultaneous requests for chunks made to a single operator, across all the blobs being
+	// retrieved. Requests beyond the limit wait for earlier requests to the operator to complete.
+	MaxRequestsPerOperator int
+
+	// Once no request for chunks has completed for longer than this percentile of the latencies of recent requests,
+	// a request is started to another operator, to hedge against the slow operators. Must be in (0, 1].
+	HedgePercentile float64
+
+	// The delay after which a request is hedged, until enough requests have completed to compute HedgePercentile
+	HedgeDelay time.Duration
+
+	// Interceptors are installed on the connections to the operators. If nil, no interceptors are installed.
+	Interceptors *GrpcInterceptors
 }
 
 // PayloadDisperserConfig contains an embedded PayloadClientConfig, plus all additional configuration values needed
@@ -80,20 +123,42 @@ type ValidatorPayloadRetrieverConfig struct {
 type PayloadDisperserConfig struct {
 	PayloadClientConfig
 
-	// SignerPaymentKey is the private key used for signing payment authorization headers
+	// SignerPaymentKey is the private key used for signing payment authorization headers. It is ignored if Signer is
+	// set.
 	SignerPaymentKey string
 
+	// Signer signs payment authorization headers and payment state requests, instead of a local signer built from
+	// SignerPaymentKey. It allows the key to be held by a remote signer, e.g. with auth.NewRemoteBlobRequestSigner,
+	// rather than in the config of the client.
+	Signer v2.BlobRequestSigner
+
 	// DisperseBlobTimeout is the duration after which the PayloadDisperser will time out, when trying to disperse a
 	// blob
 	DisperseBlobTimeout time.Duration
 
 	// BlobCertifiedTimeout is the duration after which the PayloadDisperser will time out, while polling
-	// the disperser for blob status, waiting for BlobStatus_CERTIFIED
+	// the disperser for blob status, waiting for BlobStatus_CERTIFIED. If the context passed to the PayloadDisperser
+	// has an earlier deadline, that deadline applies instead.
 	BlobCertifiedTimeout time.Duration
 
-	// BlobStatusPollInterval is the tick rate for the PayloadDisperser to use, while polling the disperser with
-	// GetBlobStatus.
+	// BlobStatusPollInterval is the initial interval for the PayloadDisperser to use, while polling the disperser with
+	// GetBlobStatus. The interval grows by BlobStatusPollBackoffMultiplier after each poll, up to
+	// MaxBlobStatusPollInterval, and is randomly jittered.
 	BlobStatusPollInterval time.Duration
+
+	// MaxBlobStatusPollInterval is the maximum interval between polls of GetBlobStatus. While the disperser streams
+	// certification events, polling only serves to detect failed dispersals, and happens at this interval.
+	MaxBlobStatusPollInterval time.Duration
+
+	// BlobStatusPollBackoffMultiplier is the factor by which the interval between polls of GetBlobStatus grows
+	BlobStatusPollBackoffMultiplier float64
+
+	// MaxConcurrentDispersals is the maximum number of payloads which SendPayloads disperses concurrently
+	MaxConcurrentDispersals int
+
+	// PayloadCodec is the codec used to encode payloads into blobs. The codec is recorded in the encoded payload, so
+	// retrievers decode payloads regardless of the codec they were dispersed with.
+	PayloadCodec coretypes.PayloadCodecID
 }
 
 // GetDefaultPayloadClientConfig creates a PayloadClientConfig with default values
@@ -106,6 +171,7 @@ func GetDefaultPayloadClientConfig() *PayloadClientConfig {
 		ContractCallTimeout:     5 * time.Second,
 		BlockNumberPollInterval: 1 * time.Second,
 		BlobVersion:             0,
+		Metrics:                 NoopClientMetrics{},
 	}
 }
 
@@ -129,6 +195,10 @@ func (cc *PayloadClientConfig) checkAndSetDefaults() error {
 
 	// BlobVersion may be 0, so don't do anything
 
+	if cc.Metrics == nil {
+		cc.Metrics = defaultConfig.Metrics
+	}
+
 	return nil
 }
 
@@ -205,15 +275,85 @@ func (rc *ValidatorPayloadRetrieverConfig) checkAndSetDefaults() error {
 	return nil
 }
 
+// GetDefaultArchivePayloadRetrieverConfig creates an ArchivePayloadRetrieverConfig with default values
+func GetDefaultArchivePayloadRetrieverConfig() *ArchivePayloadRetrieverConfig {
+	return &ArchivePayloadRetrieverConfig{
+		PayloadClientConfig: *GetDefaultPayloadClientConfig(),
+		ArchiveTimeout:      30 * time.Second,
+	}
+}
+
+// checkAndSetDefaults checks an existing config struct. It performs one of the following actions for any contained 0 values:
+//
+// 1. If 0 is an acceptable value for the field, do nothing.
+// 2. If 0 is NOT an acceptable value for the field, and a default value is defined, then set it to the default.
+// 3. If 0 is NOT an acceptable value for the field, and a default value is NOT defined, return an error.
+func (ac *ArchivePayloadRetrieverConfig) checkAndSetDefaults() error {
+	err := ac.PayloadClientConfig.checkAndSetDefaults()
+	if err != nil {
+		return err
+	}
+
+	defaultConfig := GetDefaultArchivePayloadRetrieverConfig()
+	if ac.ArchiveTimeout == 0 {
+		ac.ArchiveTimeout = defaultConfig.ArchiveTimeout
+	}
+
+	return nil
+}
+
+// GetDefaultRetrievalClientConfig creates a RetrievalClientConfig with default values
+func GetDefaultRetrievalClientConfig() *RetrievalClientConfig {
+	return &RetrievalClientConfig{
+		MaxConnectionCount:     100,
+		MaxRequestsPerOperator: 8,
+		HedgePercentile:        0.95,
+		HedgeDelay:             2 * time.Second,
+	}
+}
+
+// checkAndSetDefaults checks an existing config struct. It performs one of the following actions for any contained 0 values:
+//
+// 1. If 0 is an acceptable value for the field, do nothing.
+// 2. If 0 is NOT an acceptable value for the field, and a default value is defined, then set it to the default.
+// 3. If 0 is NOT an acceptable value for the field, and a default value is NOT defined, return an error.
+func (rc *RetrievalClientConfig) checkAndSetDefaults() error {
+	defaultConfig := GetDefaultRetrievalClientConfig()
+	if rc.MaxConnectionCount == 0 {
+		rc.MaxConnectionCount = defaultConfig.MaxConnectionCount
+	}
+	if rc.MaxRequestsPerOperator == 0 {
+		rc.MaxRequestsPerOperator = defaultConfig.MaxRequestsPerOperator
+	}
+	if rc.HedgePercentile == 0 {
+		rc.HedgePercentile = defaultConfig.HedgePercentile
+	}
+	if rc.HedgeDelay == 0 {
+		rc.HedgeDelay = defaultConfig.HedgeDelay
+	}
+
+	if rc.MaxConnectionCount < 0 || rc.MaxRequestsPerOperator < 0 {
+		return errors.New("MaxConnectionCount and MaxRequestsPerOperator must be positive")
+	}
+	if rc.HedgePercentile < 0 || rc.HedgePercentile > 1 {
+		return fmt.Errorf("HedgePercentile must be in (0, 1], got %f", rc.HedgePercentile)
+	}
+
+	return nil
+}
+
 // GetDefaultPayloadDisperserConfig creates a PayloadDisperserConfig with default values
 //
 // NOTE: EigenDACertVerifierAddr does not have a defined default. It must always be specifically configured.
 func GetDefaultPayloadDisperserConfig() *PayloadDisperserConfig {
 	return &PayloadDisperserConfig{
-		PayloadClientConfig:    *GetDefaultPayloadClientConfig(),
-		DisperseBlobTimeout:    2 * time.Minute,
-		BlobCertifiedTimeout:   2 * time.Minute,
-		BlobStatusPollInterval: 1 * time.Second,
+		PayloadClientConfig:             *GetDefaultPayloadClientConfig(),
+		DisperseBlobTimeout:             2 * time.Minute,
+		BlobCertifiedTimeout:            2 * time.Minute,
+		BlobStatusPollInterval:          500 * time.Millisecond,
+		MaxBlobStatusPollInterval:       10 * time.Second,
+		BlobStatusPollBackoffMultiplier: 1.5,
+		MaxConcurrentDispersals:         8,
 	}
 }
 
@@ -242,5 +382,306 @@ func (dc *PayloadDisperserConfig) checkAndSetDefaults() error {
 		dc.BlobStatusPollInterval = defaultConfig.BlobStatusPollInterval
 	}
 
+	if dc.MaxBlobStatusPollInterval == 0 {
+		dc.MaxBlobStatusPollInterval = max(defaultConfig.MaxBlobStatusPollInterval, dc.BlobStatusPollInterval)
+	}
+	if dc.MaxBlobStatusPollInterval < dc.BlobStatusPollInterval {
+		return fmt.Errorf("MaxBlobStatusPollInterval %v must not be less than BlobStatusPollInterval %v",
+			dc.MaxBlobStatusPollInterval, dc.BlobStatusPollInterval)
+	}
+
+	if dc.BlobStatusPollBackoffMultiplier == 0 {
+		dc.BlobStatusPollBackoffMultiplier = defaultConfig.BlobStatusPollBackoffMultiplier
+	}
+	if dc.BlobStatusPollBackoffMultiplier < 1 {
+		return fmt.Errorf("BlobStatusPollBackoffMultiplier must be at least 1, got %f",
+			dc.BlobStatusPollBackoffMultiplier)
+	}
+
+	// PayloadCodec may be 0, which is PayloadCodecDefault
+	_, err = coretypes.NewPayloadCodec(dc.PayloadCodec)
+	if err != nil {
+		return fmt.Errorf("invalid PayloadCodec: %w", err)
+	}
+
+	if dc.MaxConcurrentDispersals == 0 {
+		dc.MaxConcurrentDispersals = defaultConfig.MaxConcurrentDispersals
+	}
+	if dc.MaxConcurrentDispersals < 0 {
+		return fmt.Errorf("MaxConcurrentDispersals must be positive, got %d", dc.MaxConcurrentDispersals)
+	}
+
+	return nil
+}
+
+// FailoverDisperserClientConfig contains the configuration values needed by a FailoverDisperserClient
+type FailoverDisperserClientConfig struct {
+	// HealthCheckInterval is the interval at which the health of the dispersers is checked. Dispersers which don't
+	// support health checks are considered recovered from a failure after this interval.
+	HealthCheckInterval time.Duration
+
+	// HealthCheckTimeout is the timeout of each health check
+	HealthCheckTimeout time.Duration
+
+	// BlobOriginCacheSize is the number of recently dispersed blobs for which the disperser they were dispersed to is
+	// remembered, so that status queries for the blobs are sent to that disperser.
+	BlobOriginCacheSize int
+
+	// CircuitBreakerWindow is the number of recent requests to each disperser whose outcomes are considered by the
+	// circuit breaker of the disperser
+	CircuitBreakerWindow int
+
+	// CircuitBreakerMinRequests is the number of requests which must be recorded before the circuit breaker of a
+	// disperser can open
+	CircuitBreakerMinRequests int
+
+	// CircuitBreakerFailureRate is the fraction of recent requests which must have failed for the circuit breaker of a
+	// disperser to open. Values above 1 disable opening on failures.
+	CircuitBreakerFailureRate float64
+
+	// CircuitBreakerSlowRate is the fraction of recent requests which must have been slower than
+	// CircuitBreakerSlowRequestThreshold for the circuit breaker of a disperser to open. Values above 1 disable opening
+	// on latency.
+	CircuitBreakerSlowRate float64
+
+	// CircuitBreakerSlowRequestThreshold is the latency above which a request is considered slow
+	CircuitBreakerSlowRequestThreshold time.Duration
+
+	// CircuitBreakerOpenDuration is how long a disperser whose circuit breaker opened is skipped, before a request is
+	// sent to probe whether it has recovered
+	CircuitBreakerOpenDuration time.Duration
+}
+
+// GetDefaultFailoverDisperserClientConfig creates a FailoverDisperserClientConfig with default values
+func GetDefaultFailoverDisperserClientConfig() *FailoverDisperserClientConfig {
+	return &FailoverDisperserClientConfig{
+		HealthCheckInterval:       10 * time.Second,
+		HealthCheckTimeout:        5 * time.Second,
+		BlobOriginCacheSize:       10000,
+		CircuitBreakerWindow:      20,
+		CircuitBreakerMinRequests: 5,
+		CircuitBreakerFailureRate: 0.5,
+		CircuitBreakerSlowRate:    0.5,
+		// dispersals include the computation of the commitments of the blob, so they may take seconds when healthy
+		CircuitBreakerSlowRequestThreshold: 30 * time.Second,
+		CircuitBreakerOpenDuration:         30 * time.Second,
+	}
+}
+
+// checkAndSetDefaults checks an existing config struct. It performs one of the following actions for any contained 0 values:
+//
+// 1. If 0 is an acceptable value for the field, do nothing.
+// 2. If 0 is NOT an acceptable value for the field, and a default value is defined, then set it to the default.
+// 3. If 0 is NOT an acceptable value for the field, and a default value is NOT defined, return an error.
+func (fc *FailoverDisperserClientConfig) checkAndSetDefaults() error {
+	defaultConfig := GetDefaultFailoverDisperserClientConfig()
+
+	if fc.HealthCheckInterval == 0 {
+		fc.HealthCheckInterval = defaultConfig.HealthCheckInterval
+	}
+	if fc.HealthCheckTimeout == 0 {
+		fc.HealthCheckTimeout = defaultConfig.HealthCheckTimeout
+	}
+	if fc.BlobOriginCacheSize == 0 {
+		fc.BlobOriginCacheSize = defaultConfig.BlobOriginCacheSize
+	}
+	if fc.CircuitBreakerWindow == 0 {
+		fc.CircuitBreakerWindow = defaultConfig.CircuitBreakerWindow
+	}
+	if fc.CircuitBreakerMinRequests == 0 {
+		fc.CircuitBreakerMinRequests = defaultConfig.CircuitBreakerMinRequests
+	}
+	if fc.CircuitBreakerFailureRate == 0 {
+		fc.CircuitBreakerFailureRate = defaultConfig.CircuitBreakerFailureRate
+	}
+	if fc.CircuitBreakerSlowRate == 0 {
+		fc.CircuitBreakerSlowRate = defaultConfig.CircuitBreakerSlowRate
+	}
+	if fc.CircuitBreakerSlowRequestThreshold == 0 {
+		fc.CircuitBreakerSlowRequestThreshold = defaultConfig.CircuitBreakerSlowRequestThreshold
+	}
+	if fc.CircuitBreakerOpenDuration == 0 {
+		fc.CircuitBreakerOpenDuration = defaultConfig.CircuitBreakerOpenDuration
+	}
+
+	if fc.HealthCheckInterval < 0 || fc.HealthCheckTimeout < 0 || fc.BlobOriginCacheSize < 0 ||
+		fc.CircuitBreakerWindow < 0 || fc.CircuitBreakerMinRequests < 0 || fc.CircuitBreakerFailureRate < 0 ||
+		fc.CircuitBreakerSlowRate < 0 || fc.CircuitBreakerSlowRequestThreshold < 0 || fc.CircuitBreakerOpenDuration < 0 {
+		return fmt.Errorf("FailoverDisperserClientConfig values must not be negative")
+	}
+
+	return nil
+}
+
+// BlobStatusWatcherConfig contains the configuration values needed by a BlobStatusWatcher
+type BlobStatusWatcherConfig struct {
+	// PollInterval is the interval at which the statuses of the watched blobs are queried
+	PollInterval time.Duration
+
+	// ExpiryTimeout is the duration after which a watched blob which is neither certified nor failed expires
+	ExpiryTimeout time.Duration
+
+	// StatusBatchSize is the number of blob statuses queried in a single GetBlobStatuses call. It must not exceed the
+	// limit of the disperser.
+	StatusBatchSize int
+
+	// StatusQueryTimeout is the timeout of each GetBlobStatuses call
+	StatusQueryTimeout time.Duration
+
+	// ReconnectInterval is the delay before resubscribing to certification events after the stream breaks
+	ReconnectInterval time.Duration
+}
+
+// GetDefaultBlobStatusWatcherConfig creates a BlobStatusWatcherConfig with default values
+func GetDefaultBlobStatusWatcherConfig() *BlobStatusWatcherConfig {
+	return &BlobStatusWatcherConfig{
+		PollInterval:       5 * time.Second,
+		ExpiryTimeout:      2 * time.Minute,
+		StatusBatchSize:    100,
+		StatusQueryTimeout: 5 * time.Second,
+		ReconnectInterval:  time.Second,
+	}
+}
+
+// checkAndSetDefaults checks an existing config struct. It performs one of the following actions for any contained 0 values:
+//
+// 1. If 0 is an acceptable value for the field, do nothing.
+// 2. If 0 is NOT an acceptable value for the field, and a default value is defined, then set it to the default.
+// 3. If 0 is NOT an acceptable value for the field, and a default value is NOT defined, return an error.
+func (wc *BlobStatusWatcherConfig) checkAndSetDefaults() error {
+	defaultConfig := GetDefaultBlobStatusWatcherConfig()
+
+	if wc.PollInterval == 0 {
+		wc.PollInterval = defaultConfig.PollInterval
+	}
+	if wc.ExpiryTimeout == 0 {
+		wc.ExpiryTimeout = defaultConfig.ExpiryTimeout
+	}
+	if wc.StatusBatchSize == 0 {
+		wc.StatusBatchSize = defaultConfig.StatusBatchSize
+	}
+	if wc.StatusQueryTimeout == 0 {
+		wc.StatusQueryTimeout = defaultConfig.StatusQueryTimeout
+	}
+	if wc.ReconnectInterval == 0 {
+		wc.ReconnectInterval = defaultConfig.ReconnectInterval
+	}
+
+	if wc.PollInterval < 0 || wc.ExpiryTimeout < 0 || wc.StatusBatchSize < 0 || wc.StatusQueryTimeout < 0 ||
+		wc.ReconnectInterval < 0 {
+		return fmt.Errorf("BlobStatusWatcherConfig values must not be negative")
+	}
+
+	return nil
+}
+
+// PayloadSplittingConfig contains the configuration values needed by a SplittingPayloadDisperser and a
+// ReassemblingPayloadRetriever
+type PayloadSplittingConfig struct {
+	// MaxPartSize is the maximum size of the payload of each blob, in bytes. Payloads which are larger are split into
+	// parts of at most this size. It must leave room for the overhead of the payload codec and of the PayloadEncryptor,
+	// if one is configured, below the maximum blob size of the disperser.
+	MaxPartSize int
+
+	// MaxPayloadSize is the maximum size of a payload reassembled from parts, in bytes. Manifests which describe larger
+	// payloads are rejected, so that a manifest can't make the retriever exhaust its memory.
+	MaxPayloadSize uint64
+
+	// MaxConcurrentRetrievals is the maximum number of parts retrieved at once while reassembling a payload
+	MaxConcurrentRetrievals int
+}
+
+// GetDefaultPayloadSplittingConfig creates a PayloadSplittingConfig with default values
+func GetDefaultPayloadSplittingConfig() *PayloadSplittingConfig {
+	return &PayloadSplittingConfig{
+		// the payload capacity of a 16 MiB blob is just under 15.5 MiB
+		MaxPartSize:             15 * units.MiB,
+		MaxPayloadSize:          1 * units.GiB,
+		MaxConcurrentRetrievals: 4,
+	}
+}
+
+// checkAndSetDefaults checks an existing config struct. It performs one of the following actions for any contained 0 values:
+//
+// 1. If 0 is an acceptable value for the field, do nothing.
+// 2. If 0 is NOT an acceptable value for the field, and a default value is defined, then set it to the default.
+// 3. If 0 is NOT an acceptable value for the field, and a default value is NOT defined, return an error.
+func (sc *PayloadSplittingConfig) checkAndSetDefaults() error {
+	defaultConfig := GetDefaultPayloadSplittingConfig()
+
+	if sc.MaxPartSize == 0 {
+		sc.MaxPartSize = defaultConfig.MaxPartSize
+	}
+	if sc.MaxPayloadSize == 0 {
+		sc.MaxPayloadSize = defaultConfig.MaxPayloadSize
+	}
+	if sc.MaxConcurrentRetrievals == 0 {
+		sc.MaxConcurrentRetrievals = defaultConfig.MaxConcurrentRetrievals
+	}
+
+	if sc.MaxPartSize < 0 || sc.MaxConcurrentRetrievals < 0 {
+		return fmt.Errorf("PayloadSplittingConfig values must not be negative")
+	}
+
+	return nil
+}
+
+// QuorumProfile is the trade-off between security and cost which a PutGetClient makes when choosing the quorums to
+// disperse payloads to
+type QuorumProfile int
+
+const (
+	// QuorumProfileCost disperses payloads to the quorums required by the cert verifier only. These quorums can be
+	// paid for on-demand, so dispersals succeed as long as the account has any means of payment.
+	QuorumProfileCost QuorumProfile = iota
+	// QuorumProfileSecurity disperses payloads to the additional quorums of the config on top of the required quorums,
+	// so that more independent stake attests to their availability. Additional quorums can only be paid for with a
+	// reservation which covers them, so a payload which the reservation can't currently pay for is dispersed to the
+	// required quorums only.
+	QuorumProfileSecurity
+)
+
+func (p QuorumProfile) String() string {
+	switch p {
+	case QuorumProfileCost:
+		return "cost"
+	case QuorumProfileSecurity:
+		return "security"
+	default:
+		return fmt.Sprintf("unknown(%d)", int(p))
+	}
+}
+
+// PutGetClientConfig contains the configuration values needed by a PutGetClient
+type PutGetClientConfig struct {
+	// CertVerifierAddress is the address of the EigenDACertVerifier contract which certs are built for and verified
+	// against
+	CertVerifierAddress string
+
+	// QuorumProfile determines which quorums payloads are dispersed to
+	QuorumProfile QuorumProfile
+
+	// AdditionalQuorums are the quorums which payloads are dispersed to on top of the required quorums, with
+	// QuorumProfileSecurity. They are ignored with QuorumProfileCost.
+	AdditionalQuorums []uint8
+}
+
+// checkAndSetDefaults checks an existing config struct. It performs one of the following actions for any contained 0 values:
+//
+// 1. If 0 is an acceptable value for the field, do nothing.
+// 2. If 0 is NOT an acceptable value for the field, and a default value is defined, then set it to the default.
+// 3. If 0 is NOT an acceptable value for the field, and a default value is NOT defined, return an error.
+func (pc *PutGetClientConfig) checkAndSetDefaults() error {
+	if pc.CertVerifierAddress == "" {
+		return errors.New("CertVerifierAddress must be set")
+	}
+
+	// QuorumProfile may be 0, which is QuorumProfileCost
+	if pc.QuorumProfile != QuorumProfileCost && pc.QuorumProfile != QuorumProfileSecurity {
+		return fmt.Errorf("invalid QuorumProfile %v", pc.QuorumProfile)
+	}
+
+	// AdditionalQuorums may be empty, so don't do anything
+
 	return nil
 }
@@ -2,6 +2,7 @@ package coretypes
 
 import (
 	"fmt"
+	"io"
 
 	"github.com/Layr-Labs/eigenda/api/clients/codecs"
 	"github.com/Layr-Labs/eigenda/encoding"
@@ -76,6 +77,25 @@ func (b *Blob) ToPayload(payloadForm codecs.PolynomialForm) (*Payload, error) {
 	return payload, nil
 }
 
+// ToPayloadReader is like ToPayload, but returns a reader of the payload, which decodes the payload as it is read. This
+// allows consumers of large payloads to start processing the payload before it is fully decoded, and avoids holding
+// the entire decoded payload in memory.
+//
+// The caller must close the returned reader.
+func (b *Blob) ToPayloadReader(payloadForm codecs.PolynomialForm) (io.ReadCloser, error) {
+	encodedPayload, err := b.toEncodedPayload(payloadForm)
+	if err != nil {
+		return nil, fmt.Errorf("to encoded payload: %w", err)
+	}
+
+	reader, err := encodedPayload.decodeReader()
+	if err != nil {
+		return nil, fmt.Errorf("decode payload: %w", err)
+	}
+
+	return reader, nil
+}
+
 // BlobLengthSymbols returns the length of the blob, in symbols
 func (b *Blob) BlobLengthSymbols() uint32 {
 	return b.blobLengthSymbols
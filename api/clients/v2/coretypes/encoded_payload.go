@@ -3,6 +3,7 @@ package coretypes
 import (
 	"encoding/binary"
 	"fmt"
+	"io"
 
 	"github.com/Layr-Labs/eigenda/api/clients/codecs"
 	"github.com/Layr-Labs/eigenda/encoding/rs"
@@ -14,68 +15,73 @@ import (
 //
 // Example encoding:
 //
-//             Encoded Payload header (32 bytes total)                   Encoded Payload Data (len is multiple of 32)
-// [0x00, version byte, big-endian uint32 len of payload, 0x00, ...] + [0x00, 31 bytes of data, 0x00, 31 bytes of data,...]
+//             Encoded Payload header (32 bytes total)                              Encoded Payload Data (len is multiple of 32)
+// [0x00, version byte, big-endian uint32 len, codec id byte, 0x00, ...] + [0x00, 31 bytes of data, 0x00, 31 bytes of data,...]
+//
+// The layout of the data, and the meaning of the length in the header, are defined by the PayloadCodec identified in
+// the header. The example shows the data of PayloadCodecDefault, whose identifier is 0x00, so payloads encoded with the
+// default codec are identical to payloads encoded before codecs were introduced.
 type encodedPayload struct {
 	// the size of these bytes is guaranteed to be a multiple of 32
 	bytes []byte
 }
 
-// newEncodedPayload accepts a payload, and performs the PayloadEncodingVersion0 encoding to create an encoded payload
-func newEncodedPayload(payload *Payload) (*encodedPayload, error) {
+// newEncodedPayload accepts a payload, and performs the PayloadEncodingVersion0 encoding with the given codec to create
+// an encoded payload
+func newEncodedPayload(payload *Payload, payloadCodec PayloadCodec) (*encodedPayload, error) {
 	encodedPayloadHeader := make([]byte, 32)
 	// first byte is always 0 to ensure the payloadHeader is a valid bn254 element
 	encodedPayloadHeader[1] = byte(codecs.PayloadEncodingVersion0) // encode version byte
 
-	payloadBytes := payload.Serialize()
+	encodedData, length, err := payloadCodec.Encode(payload.Serialize())
+	if err != nil {
+		return nil, fmt.Errorf("encode payload with codec %d: %w", payloadCodec.ID(), err)
+	}
 
-	// encode payload length as uint32
-	binary.BigEndian.PutUint32(
-		encodedPayloadHeader[2:6],
-		uint32(len(payloadBytes))) // uint32 should be more than enough to store the length (approx 4gb)
+	binary.BigEndian.PutUint32(encodedPayloadHeader[2:6], length)
+	encodedPayloadHeader[6] = byte(payloadCodec.ID())
 
-	// encode payload modulo bn254, and align to 32 bytes
-	encodedData := codec.PadPayload(payloadBytes)
 	encodedPayloadBytes := append(encodedPayloadHeader, encodedData...)
 
 	return &encodedPayload{encodedPayloadBytes}, nil
 }
 
-// decode applies the inverse of PayloadEncodingVersion0 to an encodedPayload, and returns the decoded Payload
+// decode applies the inverse of PayloadEncodingVersion0 and of the codec recorded in the header to an encodedPayload,
+// and returns the decoded Payload
 func (ep *encodedPayload) decode() (*Payload, error) {
+	payloadCodec, err := NewPayloadCodec(PayloadCodecID(ep.bytes[6]))
+	if err != nil {
+		return nil, fmt.Errorf("get payload codec: %w", err)
+	}
+
 	claimedLength := binary.BigEndian.Uint32(ep.bytes[2:6])
 
-	// decode raw data modulo bn254
-	unpaddedData, err := codec.RemoveInternalPadding(ep.bytes[32:])
+	payloadBytes, err := payloadCodec.Decode(ep.bytes[32:], claimedLength)
 	if err != nil {
-		return nil, fmt.Errorf("remove internal padding: %w", err)
+		return nil, fmt.Errorf("decode payload with codec %d: %w", payloadCodec.ID(), err)
 	}
 
-	unpaddedDataLength := uint32(len(unpaddedData))
+	return NewPayload(payloadBytes), nil
+}
 
-	// data length is checked when constructing an encoded payload. If this error is encountered, that means there
-	// must be a flaw in the logic at construction time (or someone was bad and didn't use the proper construction methods)
-	if unpaddedDataLength < claimedLength {
-		return nil, fmt.Errorf(
-			"length of unpadded data %d is less than length claimed in encoded payload header %d. this should never happen",
-			unpaddedDataLength, claimedLength)
+// toFieldElements converts the encoded payload to an array of field elements
+// decodeReader is like decode, but returns a reader of the payload, which decodes the encoded payload as it is read
+func (ep *encodedPayload) decodeReader() (io.ReadCloser, error) {
+	payloadCodec, err := NewPayloadCodec(PayloadCodecID(ep.bytes[6]))
+	if err != nil {
+		return nil, fmt.Errorf("get payload codec: %w", err)
 	}
 
-	// unpadded data length can be slightly bigger than the claimed length, since RemoveInternalPadding doesn't
-	// do anything to remove trailing zeros that may have been added when the data was initially padded.
-	// however, this extra padding shouldn't exceed 31 bytes, because that's the most that would be added
-	// when padding the data length to 32 bytes. If this error occurs, that means there must be a flaw in the logic at
-	// construction time (or someone was bad and didn't use the proper construction methods)
-	if unpaddedDataLength > claimedLength+31 {
-		return nil, fmt.Errorf(
-			"length of unpadded data %d is more than 31 bytes longer than claimed length %d. this should never happen",
-			unpaddedDataLength, claimedLength)
+	claimedLength := binary.BigEndian.Uint32(ep.bytes[2:6])
+
+	reader, err := payloadCodec.DecodeReader(ep.bytes[32:], claimedLength)
+	if err != nil {
+		return nil, fmt.Errorf("decode payload with codec %d: %w", payloadCodec.ID(), err)
 	}
 
-	return NewPayload(unpaddedData[0:claimedLength]), nil
+	return reader, nil
 }
 
-// toFieldElements converts the encoded payload to an array of field elements
 func (ep *encodedPayload) toFieldElements() ([]fr.Element, error) {
 	fieldElements, err := rs.ToFrArray(ep.bytes)
 	if err != nil {
@@ -87,20 +93,29 @@ func (ep *encodedPayload) toFieldElements() ([]fr.Element, error) {
 
 // encodedPayloadFromElements accepts an array of field elements, and converts them into an encoded payload
 //
-// maxPayloadLength is the maximum length in bytes that the contained Payload is permitted to be
+// maxPayloadLength is the maximum length in bytes that the contained Payload is permitted to be, when encoded with
+// PayloadCodecDefault. Payloads encoded with other codecs are permitted to occupy the same number of bytes.
 func encodedPayloadFromElements(fieldElements []fr.Element, maxPayloadLength uint32) (*encodedPayload, error) {
 	polynomialBytes := rs.SerializeFieldElements(fieldElements)
 	// this is the payload length in bytes, as claimed by the encoded payload header
 	payloadLength := binary.BigEndian.Uint32(polynomialBytes[2:6])
 
-	if payloadLength > maxPayloadLength {
+	payloadCodec, err := NewPayloadCodec(PayloadCodecID(polynomialBytes[6]))
+	if err != nil {
+		return nil, fmt.Errorf("get payload codec: %w", err)
+	}
+
+	// this is the length you would get if you encoded a payload of the length claimed in the encoded payload header
+	paddedLength := payloadCodec.EncodedDataLength(payloadLength)
+
+	maxPaddedLength := codec.GetPaddedDataLength(maxPayloadLength)
+	if paddedLength > maxPaddedLength {
 		return nil, fmt.Errorf(
-			"payload length claimed in encoded payload header (%d bytes) is larger than the permitted maximum (%d bytes)",
-			payloadLength, maxPayloadLength)
+			"payload length claimed in encoded payload header (%d bytes) encodes to %d bytes with codec %d, "+
+				"which is larger than the permitted maximum (%d bytes)",
+			payloadLength, paddedLength, payloadCodec.ID(), maxPaddedLength)
 	}
 
-	// this is the length you would get if you padded a payload of the length claimed in the encoded payload header
-	paddedLength := codec.GetPaddedDataLength(payloadLength)
 	// add 32 to the padded data length, since the encoded payload includes an encoded payload header
 	encodedPayloadLength := paddedLength + 32
 
@@ -15,7 +15,7 @@ func TestDecodeShortBytes(t *testing.T) {
 	testRandom := random.NewTestRandom()
 	originalData := testRandom.Bytes(testRandom.Intn(1024) + 33)
 
-	encodedPayload, err := newEncodedPayload(NewPayload(originalData))
+	encodedPayload, err := newEncodedPayload(NewPayload(originalData), &defaultPayloadCodec{})
 	require.NoError(t, err)
 
 	// truncate
@@ -31,7 +31,7 @@ func TestDecodeLongBytes(t *testing.T) {
 	testRandom := random.NewTestRandom()
 	originalData := testRandom.Bytes(testRandom.Intn(1024) + 1)
 
-	encodedPayload, err := newEncodedPayload(NewPayload(originalData))
+	encodedPayload, err := newEncodedPayload(NewPayload(originalData), &defaultPayloadCodec{})
 	require.NoError(t, err)
 
 	// appending 33 bytes to the encoded payload guarantees that, after removing padding, the unpadded bytes will be
@@ -54,7 +54,7 @@ func TestEncodeTooManyElements(t *testing.T) {
 		require.NoError(t, err)
 
 		almostTooLongData := testRandom.Bytes(int(maxPermissiblePayloadLength))
-		almostTooLongEncodedPayload, err := newEncodedPayload(NewPayload(almostTooLongData))
+		almostTooLongEncodedPayload, err := newEncodedPayload(NewPayload(almostTooLongData), &defaultPayloadCodec{})
 		require.NoError(t, err)
 		almostTooLongFieldElements, err := almostTooLongEncodedPayload.toFieldElements()
 		require.NoError(t, err)
@@ -63,7 +63,7 @@ func TestEncodeTooManyElements(t *testing.T) {
 		require.NoError(t, err)
 
 		tooLongData := testRandom.Bytes(int(maxPermissiblePayloadLength) + 1)
-		tooLongEncodedPayload, err := newEncodedPayload(NewPayload(tooLongData))
+		tooLongEncodedPayload, err := newEncodedPayload(NewPayload(tooLongData), &defaultPayloadCodec{})
 		require.NoError(t, err)
 		tooLongFieldElements, err := tooLongEncodedPayload.toFieldElements()
 		require.NoError(t, err)
@@ -79,7 +79,7 @@ func TestTrailingNonZeros(t *testing.T) {
 	testRandom := random.NewTestRandom()
 	originalData := testRandom.Bytes(testRandom.Intn(1024) + 1)
 
-	encodedPayload, err := newEncodedPayload(NewPayload(originalData))
+	encodedPayload, err := newEncodedPayload(NewPayload(originalData), &defaultPayloadCodec{})
 	require.NoError(t, err)
 
 	originalElements, err := encodedPayload.toFieldElements()
@@ -107,7 +107,7 @@ func TestEncodeWithFewerElements(t *testing.T) {
 	testRandom := random.NewTestRandom()
 	originalData := testRandom.Bytes(testRandom.Intn(1024) + 33)
 
-	encodedPayload, err := newEncodedPayload(NewPayload(originalData))
+	encodedPayload, err := newEncodedPayload(NewPayload(originalData), &defaultPayloadCodec{})
 	require.NoError(t, err)
 
 	originalFieldElements, err := encodedPayload.toFieldElements()
@@ -21,12 +21,24 @@ func NewPayload(payloadBytes []byte) *Payload {
 	}
 }
 
-// ToBlob converts the Payload bytes into a Blob
+// ToBlob converts the Payload bytes into a Blob, encoding the payload with PayloadCodecDefault
 //
 // The payloadForm indicates how payloads are interpreted. The form of a payload dictates what conversion, if any, must
 // be performed when creating a blob from the payload.
 func (p *Payload) ToBlob(payloadForm codecs.PolynomialForm) (*Blob, error) {
-	encodedPayload, err := newEncodedPayload(p)
+	return p.ToBlobWithCodec(payloadForm, PayloadCodecDefault)
+}
+
+// ToBlobWithCodec converts the Payload bytes into a Blob, encoding the payload with the given codec
+//
+// The codec is recorded in the blob, so Blob.ToPayload decodes the payload without being told which codec was used.
+func (p *Payload) ToBlobWithCodec(payloadForm codecs.PolynomialForm, codecID PayloadCodecID) (*Blob, error) {
+	payloadCodec, err := NewPayloadCodec(codecID)
+	if err != nil {
+		return nil, fmt.Errorf("get payload codec: %w", err)
+	}
+
+	encodedPayload, err := newEncodedPayload(p, payloadCodec)
 	if err != nil {
 		return nil, fmt.Errorf("encoding payload: %w", err)
 	}
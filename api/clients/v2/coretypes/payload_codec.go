@@ -0,0 +1,379 @@
+package coretypes
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/Layr-Labs/eigenda/encoding/rs"
+	"github.com/Layr-Labs/eigenda/encoding/utils/codec"
+	"github.com/klauspost/compress/zstd"
+)
+
+// PayloadCodecID identifies the codec used to encode a payload. It is recorded in the header of the encoded payload,
+// so that the payload can be decoded without knowing in advance how it was encoded.
+type PayloadCodecID uint8
+
+const (
+	// PayloadCodecDefault packs the payload into 31 byte groups, each prefixed with a 0x00 byte so that every 32 byte
+	// group is a valid bn254 field element.
+	PayloadCodecDefault PayloadCodecID = 0x0
+	// PayloadCodecGzip compresses the payload with gzip, and then packs it like PayloadCodecDefault
+	PayloadCodecGzip PayloadCodecID = 0x1
+	// PayloadCodecZstd compresses the payload with zstd, and then packs it like PayloadCodecDefault
+	PayloadCodecZstd PayloadCodecID = 0x2
+	// PayloadCodecRaw uses the payload as is, padded with zeros to a multiple of 32 bytes. Every 32 byte group of the
+	// payload must already be a valid bn254 field element, in big endian canonical form. This avoids the overhead of
+	// packing for payloads which are produced as field elements in the first place.
+	PayloadCodecRaw PayloadCodecID = 0x3
+)
+
+// MaxDecompressedPayloadLength is the maximum length of a payload decoded by a compressing codec. It protects the
+// decoder against blobs which decompress to unreasonable sizes.
+const MaxDecompressedPayloadLength = 128 * 1024 * 1024
+
+// PayloadCodec converts a payload into the data of an encoded payload, and back.
+type PayloadCodec interface {
+	// ID returns the identifier of the codec, which is recorded in the header of the encoded payload
+	ID() PayloadCodecID
+
+	// Encode converts a payload into the data of an encoded payload. The data is a sequence of valid bn254 field
+	// elements, so its length is a multiple of 32. The returned length is recorded in the header of the encoded
+	// payload, and is passed back to Decode.
+	Encode(payload []byte) (data []byte, length uint32, err error)
+
+	// Decode converts the data of an encoded payload back into the payload, given the length recorded in the header
+	Decode(data []byte, length uint32) ([]byte, error)
+
+	// DecodeReader is like Decode, but returns a reader of the payload, which decodes the data as it is read. Errors
+	// in the data which are only detected while decoding are returned by the reader.
+	DecodeReader(data []byte, length uint32) (io.ReadCloser, error)
+
+	// EncodedDataLength returns the length of the data of an encoded payload, given the length recorded in its header
+	EncodedDataLength(length uint32) uint32
+}
+
+// NewPayloadCodec returns the codec with the given identifier.
+func NewPayloadCodec(id PayloadCodecID) (PayloadCodec, error) {
+	switch id {
+	case PayloadCodecDefault:
+		return &defaultPayloadCodec{}, nil
+	case PayloadCodecGzip:
+		return &compressedPayloadCodec{
+			id:               id,
+			compress:         gzipCompress,
+			decompress:       gzipDecompress,
+			decompressReader: gzipDecompressReader,
+		}, nil
+	case PayloadCodecZstd:
+		return &compressedPayloadCodec{
+			id:               id,
+			compress:         zstdCompress,
+			decompress:       zstdDecompress,
+			decompressReader: zstdDecompressReader,
+		}, nil
+	case PayloadCodecRaw:
+		return &rawPayloadCodec{}, nil
+	default:
+		return nil, fmt.Errorf("unknown payload codec: %d", id)
+	}
+}
+
+// defaultPayloadCodec packs the payload into 31 byte groups
+type defaultPayloadCodec struct{}
+
+var _ PayloadCodec = &defaultPayloadCodec{}
+
+func (c *defaultPayloadCodec) ID() PayloadCodecID {
+	return PayloadCodecDefault
+}
+
+func (c *defaultPayloadCodec) Encode(payload []byte) ([]byte, uint32, error) {
+	// uint32 should be more than enough to store the length (approx 4gb)
+	return codec.PadPayload(payload), uint32(len(payload)), nil
+}
+
+func (c *defaultPayloadCodec) Decode(data []byte, length uint32) ([]byte, error) {
+	// decode raw data modulo bn254
+	unpaddedData, err := codec.RemoveInternalPadding(data)
+	if err != nil {
+		return nil, fmt.Errorf("remove internal padding: %w", err)
+	}
+
+	unpaddedDataLength := uint32(len(unpaddedData))
+
+	// data length is checked when constructing an encoded payload. If this error is encountered, that means there
+	// must be a flaw in the logic at construction time (or someone was bad and didn't use the proper construction methods)
+	if unpaddedDataLength < length {
+		return nil, fmt.Errorf(
+			"length of unpadded data %d is less than length claimed in encoded payload header %d. this should never happen",
+			unpaddedDataLength, length)
+	}
+
+	// unpadded data length can be slightly bigger than the claimed length, since RemoveInternalPadding doesn't
+	// do anything to remove trailing zeros that may have been added when the data was initially padded.
+	// however, this extra padding shouldn't exceed 31 bytes, because that's the most that would be added
+	// when padding the data length to 32 bytes. If this error occurs, that means there must be a flaw in the logic at
+	// construction time (or someone was bad and didn't use the proper construction methods)
+	if unpaddedDataLength > length+31 {
+		return nil, fmt.Errorf(
+			"length of unpadded data %d is more than 31 bytes longer than claimed length %d. this should never happen",
+			unpaddedDataLength, length)
+	}
+
+	return unpaddedData[0:length], nil
+}
+
+func (c *defaultPayloadCodec) DecodeReader(data []byte, length uint32) (io.ReadCloser, error) {
+	if len(data)%encoding.BYTES_PER_SYMBOL != 0 {
+		return nil, fmt.Errorf(
+			"data length %d must be a multiple of %d", len(data), encoding.BYTES_PER_SYMBOL)
+	}
+
+	// the same checks as in Decode, on the length the data would have once unpadded
+	unpaddedDataLength := uint32(len(data) / encoding.BYTES_PER_SYMBOL * (encoding.BYTES_PER_SYMBOL - 1))
+	if unpaddedDataLength < length {
+		return nil, fmt.Errorf(
+			"length of unpadded data %d is less than length claimed in encoded payload header %d. this should never happen",
+			unpaddedDataLength, length)
+	}
+	if unpaddedDataLength > length+31 {
+		return nil, fmt.Errorf(
+			"length of unpadded data %d is more than 31 bytes longer than claimed length %d. this should never happen",
+			unpaddedDataLength, length)
+	}
+
+	return io.NopCloser(&unpaddingReader{data: data, length: length}), nil
+}
+
+func (c *defaultPayloadCodec) EncodedDataLength(length uint32) uint32 {
+	return codec.GetPaddedDataLength(length)
+}
+
+// unpaddingReader reads the first length bytes of data with the internal padding removed, i.e. without the first byte
+// of every 32 byte group. It is the streaming equivalent of codec.RemoveInternalPadding.
+type unpaddingReader struct {
+	data []byte
+	// length is the number of unpadded bytes to read
+	length uint32
+	// offset is the number of unpadded bytes already read
+	offset uint32
+}
+
+func (r *unpaddingReader) Read(p []byte) (int, error) {
+	const bytesPerGroup = encoding.BYTES_PER_SYMBOL - 1
+
+	n := 0
+	for n < len(p) && r.offset < r.length {
+		group := r.offset / bytesPerGroup
+		groupOffset := r.offset % bytesPerGroup
+		srcIndex := group*encoding.BYTES_PER_SYMBOL + 1 + groupOffset
+		count := min(bytesPerGroup-groupOffset, r.length-r.offset, uint32(len(p)-n))
+
+		copy(p[n:], r.data[srcIndex:srcIndex+count])
+		n += int(count)
+		r.offset += count
+	}
+
+	if n == 0 && r.offset == r.length {
+		return 0, io.EOF
+	}
+	return n, nil
+}
+
+// compressedPayloadCodec compresses the payload, and then packs the compressed bytes like defaultPayloadCodec. The
+// length recorded in the header is the length of the compressed bytes.
+type compressedPayloadCodec struct {
+	defaultPayloadCodec
+	id               PayloadCodecID
+	compress         func(data []byte) ([]byte, error)
+	decompress       func(data []byte, maxLength int64) ([]byte, error)
+	decompressReader func(reader io.Reader) (io.ReadCloser, error)
+}
+
+var _ PayloadCodec = &compressedPayloadCodec{}
+
+func (c *compressedPayloadCodec) ID() PayloadCodecID {
+	return c.id
+}
+
+func (c *compressedPayloadCodec) Encode(payload []byte) ([]byte, uint32, error) {
+	compressed, err := c.compress(payload)
+	if err != nil {
+		return nil, 0, fmt.Errorf("compress payload: %w", err)
+	}
+	return c.defaultPayloadCodec.Encode(compressed)
+}
+
+func (c *compressedPayloadCodec) Decode(data []byte, length uint32) ([]byte, error) {
+	compressed, err := c.defaultPayloadCodec.Decode(data, length)
+	if err != nil {
+		return nil, err
+	}
+
+	payload, err := c.decompress(compressed, MaxDecompressedPayloadLength)
+	if err != nil {
+		return nil, fmt.Errorf("decompress payload: %w", err)
+	}
+	return payload, nil
+}
+
+func (c *compressedPayloadCodec) DecodeReader(data []byte, length uint32) (io.ReadCloser, error) {
+	compressed, err := c.defaultPayloadCodec.DecodeReader(data, length)
+	if err != nil {
+		return nil, err
+	}
+
+	reader, err := c.decompressReader(compressed)
+	if err != nil {
+		return nil, fmt.Errorf("decompress payload: %w", err)
+	}
+	return &limitedReadCloser{reader: reader, remaining: MaxDecompressedPayloadLength}, nil
+}
+
+// limitedReadCloser reads at most remaining bytes from reader, and fails if reader has more bytes
+type limitedReadCloser struct {
+	reader    io.ReadCloser
+	remaining int64
+}
+
+func (r *limitedReadCloser) Read(p []byte) (int, error) {
+	if r.remaining <= 0 {
+		// check whether there are bytes beyond the limit
+		var extra [1]byte
+		n, err := r.reader.Read(extra[:])
+		if n > 0 {
+			return 0, fmt.Errorf(
+				"decompressed payload exceeds the maximum length of %d bytes", MaxDecompressedPayloadLength)
+		}
+		return 0, err
+	}
+
+	if int64(len(p)) > r.remaining {
+		p = p[:r.remaining]
+	}
+	n, err := r.reader.Read(p)
+	r.remaining -= int64(n)
+	return n, err
+}
+
+func (r *limitedReadCloser) Close() error {
+	return r.reader.Close()
+}
+
+func gzipCompress(data []byte) ([]byte, error) {
+	var buffer bytes.Buffer
+	writer := gzip.NewWriter(&buffer)
+	_, err := writer.Write(data)
+	if err != nil {
+		return nil, err
+	}
+	err = writer.Close()
+	if err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+func gzipDecompress(data []byte, maxLength int64) ([]byte, error) {
+	reader, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = reader.Close()
+	}()
+	return readAtMost(reader, maxLength)
+}
+
+func gzipDecompressReader(reader io.Reader) (io.ReadCloser, error) {
+	return gzip.NewReader(reader)
+}
+
+func zstdCompress(data []byte) ([]byte, error) {
+	encoder, err := zstd.NewWriter(nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = encoder.Close()
+	}()
+	return encoder.EncodeAll(data, nil), nil
+}
+
+func zstdDecompress(data []byte, maxLength int64) ([]byte, error) {
+	decoder, err := zstd.NewReader(bytes.NewReader(data), zstd.WithDecoderMaxMemory(uint64(maxLength)))
+	if err != nil {
+		return nil, err
+	}
+	defer decoder.Close()
+	return readAtMost(decoder, maxLength)
+}
+
+func zstdDecompressReader(reader io.Reader) (io.ReadCloser, error) {
+	// with a concurrency of 1, the decoder decodes synchronously in Read, so it doesn't keep any goroutine running
+	decoder, err := zstd.NewReader(
+		reader,
+		zstd.WithDecoderConcurrency(1),
+		zstd.WithDecoderMaxMemory(MaxDecompressedPayloadLength))
+	if err != nil {
+		return nil, err
+	}
+	return decoder.IOReadCloser(), nil
+}
+
+// readAtMost reads all the data of reader, failing if there are more than maxLength bytes
+func readAtMost(reader io.Reader, maxLength int64) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(reader, maxLength+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > maxLength {
+		return nil, fmt.Errorf("decompressed payload exceeds the maximum length of %d bytes", maxLength)
+	}
+	return data, nil
+}
+
+// rawPayloadCodec uses the payload as is, padded with zeros to a multiple of 32 bytes
+type rawPayloadCodec struct{}
+
+var _ PayloadCodec = &rawPayloadCodec{}
+
+func (c *rawPayloadCodec) ID() PayloadCodecID {
+	return PayloadCodecRaw
+}
+
+func (c *rawPayloadCodec) Encode(payload []byte) ([]byte, uint32, error) {
+	data := make([]byte, c.EncodedDataLength(uint32(len(payload))))
+	copy(data, payload)
+
+	// check that every 32 byte group is a valid field element
+	_, err := rs.ToFrArray(data)
+	if err != nil {
+		return nil, 0, fmt.Errorf("raw payload is not a sequence of valid field elements: %w", err)
+	}
+
+	return data, uint32(len(payload)), nil
+}
+
+func (c *rawPayloadCodec) Decode(data []byte, length uint32) ([]byte, error) {
+	if uint32(len(data)) < length {
+		return nil, fmt.Errorf(
+			"length of data %d is less than length claimed in encoded payload header %d", len(data), length)
+	}
+	return data[:length], nil
+}
+
+func (c *rawPayloadCodec) DecodeReader(data []byte, length uint32) (io.ReadCloser, error) {
+	payload, err := c.Decode(data, length)
+	if err != nil {
+		return nil, err
+	}
+	return io.NopCloser(bytes.NewReader(payload)), nil
+}
+
+func (c *rawPayloadCodec) EncodedDataLength(length uint32) uint32 {
+	return (length + encoding.BYTES_PER_SYMBOL - 1) / encoding.BYTES_PER_SYMBOL * encoding.BYTES_PER_SYMBOL
+}
@@ -0,0 +1,124 @@
+package coretypes
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/api/clients/codecs"
+	"github.com/stretchr/testify/require"
+)
+
+// TestPayloadCodecs checks that payloads survive conversion to and from a blob with each codec, and that the codec is
+// detected from the blob
+func TestPayloadCodecs(t *testing.T) {
+	codecIDs := []PayloadCodecID{PayloadCodecDefault, PayloadCodecGzip, PayloadCodecZstd, PayloadCodecRaw}
+	// raw payloads must consist of valid field elements, so every 32nd byte is kept small
+	payloads := [][]byte{{}, {0x00}, {0x01, 0x02, 0x03}, bytes.Repeat([]byte{0x00, 0x55, 0x55, 0x55}, 1000)}
+
+	for _, codecID := range codecIDs {
+		for _, payloadBytes := range payloads {
+			for _, payloadForm := range []codecs.PolynomialForm{codecs.PolynomialFormEval, codecs.PolynomialFormCoeff} {
+				blob, err := NewPayload(payloadBytes).ToBlobWithCodec(payloadForm, codecID)
+				require.NoError(t, err)
+
+				blobDeserialized, err := DeserializeBlob(blob.Serialize(), blob.BlobLengthSymbols())
+				require.NoError(t, err)
+
+				payload, err := blobDeserialized.ToPayload(payloadForm)
+				require.NoError(t, err)
+				require.Equal(t, payloadBytes, payload.Serialize(), "codec %d", codecID)
+
+				reader, err := blobDeserialized.ToPayloadReader(payloadForm)
+				require.NoError(t, err)
+				streamedBytes, err := io.ReadAll(reader)
+				require.NoError(t, err)
+				require.NoError(t, reader.Close())
+				require.Equal(t, len(payloadBytes), len(streamedBytes), "codec %d", codecID)
+				require.True(t, bytes.Equal(payloadBytes, streamedBytes), "codec %d", codecID)
+			}
+		}
+	}
+}
+
+func TestCompressedPayloadCodecs(t *testing.T) {
+	payloadBytes := bytes.Repeat([]byte("eigenda"), 10000)
+
+	defaultBlob, err := NewPayload(payloadBytes).ToBlob(codecs.PolynomialFormEval)
+	require.NoError(t, err)
+
+	for _, codecID := range []PayloadCodecID{PayloadCodecGzip, PayloadCodecZstd} {
+		blob, err := NewPayload(payloadBytes).ToBlobWithCodec(codecs.PolynomialFormEval, codecID)
+		require.NoError(t, err)
+		require.Less(t, blob.BlobLengthSymbols(), defaultBlob.BlobLengthSymbols())
+	}
+
+	// the decompressed size is bounded
+	compressed, err := gzipCompress(payloadBytes)
+	require.NoError(t, err)
+	_, err = gzipDecompress(compressed, int64(len(payloadBytes)-1))
+	require.Error(t, err)
+	compressed, err = zstdCompress(payloadBytes)
+	require.NoError(t, err)
+	_, err = zstdDecompress(compressed, int64(len(payloadBytes)-1))
+	require.Error(t, err)
+}
+
+func TestRawPayloadCodecInvalidFieldElement(t *testing.T) {
+	// 0xFF... is larger than the bn254 modulus
+	_, err := NewPayload(bytes.Repeat([]byte{0xFF}, 32)).ToBlobWithCodec(codecs.PolynomialFormCoeff, PayloadCodecRaw)
+	require.Error(t, err)
+}
+
+func TestUnknownPayloadCodec(t *testing.T) {
+	_, err := NewPayload([]byte{1, 2, 3}).ToBlobWithCodec(codecs.PolynomialFormCoeff, PayloadCodecID(0xFF))
+	require.Error(t, err)
+
+	// a blob whose header names an unknown codec can't be decoded
+	encodedPayload, err := newEncodedPayload(NewPayload([]byte{1, 2, 3}), &defaultPayloadCodec{})
+	require.NoError(t, err)
+	encodedPayload.bytes[6] = 0xFF
+	_, err = encodedPayload.decode()
+	require.Error(t, err)
+}
+
+func TestUnpaddingReader(t *testing.T) {
+	payloadBytes := make([]byte, 1000)
+	for i := range payloadBytes {
+		payloadBytes[i] = byte(i)
+	}
+	data, length, err := (&defaultPayloadCodec{}).Encode(payloadBytes)
+	require.NoError(t, err)
+
+	// read in pieces which don't line up with the 31 byte groups
+	reader := &unpaddingReader{data: data, length: length}
+	var streamedBytes []byte
+	buffer := make([]byte, 7)
+	for {
+		n, err := reader.Read(buffer)
+		streamedBytes = append(streamedBytes, buffer[:n]...)
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+	}
+	require.Equal(t, payloadBytes, streamedBytes)
+}
+
+func TestDecompressReaderLimit(t *testing.T) {
+	payloadBytes := bytes.Repeat([]byte{0}, 1000)
+
+	for _, codecID := range []PayloadCodecID{PayloadCodecGzip, PayloadCodecZstd} {
+		payloadCodec, err := NewPayloadCodec(codecID)
+		require.NoError(t, err)
+		data, length, err := payloadCodec.Encode(payloadBytes)
+		require.NoError(t, err)
+
+		reader, err := payloadCodec.DecodeReader(data, length)
+		require.NoError(t, err)
+		reader.(*limitedReadCloser).remaining = int64(len(payloadBytes) - 1)
+		_, err = io.ReadAll(reader)
+		require.Error(t, err, "codec %d", codecID)
+		require.NoError(t, reader.Close())
+	}
+}
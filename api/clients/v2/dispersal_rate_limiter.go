@@ -0,0 +1,103 @@
+package clients
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// dispersalRateLimiter paces dispersals, so that blobs are sent when the disperser can accept them, rather than in
+// bursts which the disperser rejects and which burn retries:
+//
+//   - blobs which the reservation of the account can pay for are sent immediately
+//   - blobs which the reservation can't pay for in the current reservation period, and which can't be paid for
+//     on-demand, wait for the next reservation period
+//   - blobs paid for on-demand are paced to the global rate of on-demand dispersals advertised by the disperser, which
+//     is shared by all accounts, so this client never exceeds it on its own
+//
+// This struct is goroutine safe.
+type dispersalRateLimiter struct {
+	lock sync.Mutex
+	// onDemandLimiter limits the rate of on-demand dispersals, in symbols per second. It is nil until the global rate
+	// of the disperser is known, or if the disperser doesn't advertise one.
+	onDemandLimiter *rate.Limiter
+}
+
+func newDispersalRateLimiter() *dispersalRateLimiter {
+	return &dispersalRateLimiter{}
+}
+
+// setGlobalRate sets the global rate of on-demand dispersals advertised by the disperser, in symbols per second. A
+// second's worth of symbols may be dispersed in a burst. A rate of 0 means the rate isn't limited.
+func (l *dispersalRateLimiter) setGlobalRate(symbolsPerSecond uint64) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if symbolsPerSecond == 0 {
+		l.onDemandLimiter = nil
+		return
+	}
+	if l.onDemandLimiter == nil {
+		l.onDemandLimiter = rate.NewLimiter(rate.Limit(symbolsPerSecond), int(symbolsPerSecond))
+		return
+	}
+	l.onDemandLimiter.SetLimit(rate.Limit(symbolsPerSecond))
+	l.onDemandLimiter.SetBurst(int(symbolsPerSecond))
+}
+
+// wait blocks until a blob of numSymbols symbols can be dispersed to the given quorums, as described on
+// dispersalRateLimiter. The payment of the blob is quoted by the accountant at the time returned by timestamp, which
+// is the timestamp of the payment of the blob. If the blob can't be paid for at all, wait returns immediately, and
+// the error is reported when the blob is accounted for.
+func (l *dispersalRateLimiter) wait(
+	ctx context.Context,
+	accountant *Accountant,
+	timestamp func() (int64, error),
+	numSymbols uint64,
+	quorums []uint8,
+) error {
+	for {
+		now, err := timestamp()
+		if err != nil {
+			return err
+		}
+		quote, err := accountant.QuotePayment(numSymbols, quorums, now, nil)
+		if err != nil {
+			return nil
+		}
+
+		switch quote.PaymentMethod {
+		case PaymentMethodReservation:
+			return nil
+		case PaymentMethodOnDemand:
+			return l.waitOnDemand(ctx, quote.SymbolsCharged)
+		}
+
+		delay, ok := accountant.NextReservationPeriodDelay(numSymbols, now)
+		if !ok {
+			return nil
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// waitOnDemand blocks until symbols symbols may be dispersed on-demand within the global rate
+func (l *dispersalRateLimiter) waitOnDemand(ctx context.Context, symbols uint64) error {
+	l.lock.Lock()
+	limiter := l.onDemandLimiter
+	l.lock.Unlock()
+	if limiter == nil {
+		return nil
+	}
+
+	// blobs larger than the burst wait for a full burst, since they would never fit otherwise
+	return limiter.WaitN(ctx, int(min(symbols, uint64(limiter.Burst()))))
+}
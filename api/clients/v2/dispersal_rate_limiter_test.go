@@ -0,0 +1,87 @@
+package clients
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/stretchr/testify/require"
+)
+
+func nowTimestamp() (int64, error) {
+	return time.Now().UnixNano(), nil
+}
+
+func TestNextReservationPeriodDelay(t *testing.T) {
+	reservation := &core.ReservedPayment{SymbolsPerSecond: 10, QuorumNumbers: []uint8{0, 1}}
+	accountant := NewAccountant("account", reservation, &core.OnDemandPayment{}, 10, 1, 1, numBins)
+
+	timestamp := time.Unix(25, 500_000_000).UnixNano()
+	delay, ok := accountant.NextReservationPeriodDelay(100, timestamp)
+	require.True(t, ok)
+	require.Equal(t, 4500*time.Millisecond, delay)
+
+	// the blob is larger than the symbols of a period
+	_, ok = accountant.NextReservationPeriodDelay(101, timestamp)
+	require.False(t, ok)
+
+	// the reservation ends before the next period
+	reservation.EndTimestamp = 30
+	_, ok = accountant.NextReservationPeriodDelay(100, timestamp)
+	require.False(t, ok)
+}
+
+func TestDispersalRateLimiterReservation(t *testing.T) {
+	reservation := &core.ReservedPayment{SymbolsPerSecond: 100, QuorumNumbers: []uint8{0, 1}}
+	accountant := NewAccountant("account", reservation, &core.OnDemandPayment{CumulativePayment: big.NewInt(0)}, 1, 1, 1, numBins)
+	limiter := newDispersalRateLimiter()
+	quorums := []uint8{0, 1}
+
+	// the reservation can pay for the blob
+	require.NoError(t, limiter.wait(context.Background(), accountant, nowTimestamp, 100, quorums))
+
+	// the reservation period is used up, and the blob can't be paid for on-demand
+	_, err := accountant.AccountBlob(context.Background(), time.Now().UnixNano(), 100, quorums)
+	require.NoError(t, err)
+	cancelledCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = limiter.wait(cancelledCtx, accountant, nowTimestamp, 100, quorums)
+	if err != nil {
+		require.ErrorIs(t, err, context.Canceled)
+	}
+
+	// the blob is sent in the next reservation period
+	require.NoError(t, limiter.wait(context.Background(), accountant, nowTimestamp, 100, quorums))
+	quote, err := accountant.QuotePayment(100, quorums, time.Now().UnixNano(), nil)
+	require.NoError(t, err)
+	require.Equal(t, PaymentMethodReservation, quote.PaymentMethod)
+
+	// blobs which the reservation could never pay for aren't held back
+	require.NoError(t, limiter.wait(context.Background(), accountant, nowTimestamp, 1000, quorums))
+}
+
+func TestDispersalRateLimiterOnDemand(t *testing.T) {
+	onDemand := &core.OnDemandPayment{CumulativePayment: big.NewInt(1_000_000)}
+	accountant := NewAccountant("account", &core.ReservedPayment{}, onDemand, 1, 1, 1, numBins)
+	limiter := newDispersalRateLimiter()
+	quorums := []uint8{0, 1}
+
+	// on-demand dispersals aren't paced until the global rate is known
+	for i := 0; i < 10; i++ {
+		require.NoError(t, limiter.wait(context.Background(), accountant, nowTimestamp, 1000, quorums))
+	}
+
+	// a second's worth of symbols is sent in a burst, and the rest is paced to the global rate
+	limiter.setGlobalRate(2000)
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		require.NoError(t, limiter.wait(context.Background(), accountant, nowTimestamp, 1000, quorums))
+	}
+	require.GreaterOrEqual(t, time.Since(start), 400*time.Millisecond)
+
+	// blobs larger than the burst wait for a full burst
+	limiter.setGlobalRate(100_000)
+	require.NoError(t, limiter.wait(context.Background(), accountant, nowTimestamp, 200_000, quorums))
+}
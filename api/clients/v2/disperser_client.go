@@ -3,7 +3,10 @@ package clients
 import (
 	"context"
 	"fmt"
+	"math/big"
+	"strconv"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/docker/go-units"
@@ -16,33 +19,79 @@ import (
 	"github.com/Layr-Labs/eigenda/encoding"
 	"github.com/Layr-Labs/eigenda/encoding/rs"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
 )
 
 type DisperserClientConfig struct {
 	Hostname          string
 	Port              string
 	UseSecureGrpcFlag bool
+	// RetentionPeriod is the retention period requested for dispersed blobs, rounded down to whole seconds.
+	// If zero, blobs are retained for the default period.
+	RetentionPeriod time.Duration
+	// AccountantStatePath is the file where the local accounting of the payments is persisted, so that it's
+	// recovered after a restart. If empty, the accounting is only kept in memory, and is rebuilt from the payment
+	// state of the disperser on restart.
+	AccountantStatePath string
+	// RetryPolicy controls how requests to the disperser are retried and hedged. If nil, each request is attempted
+	// once.
+	RetryPolicy *RetryPolicy
+	// Metrics receives the metrics of the client. If nil, no metrics are recorded.
+	Metrics ClientMetrics
+	// AdjustForClockSkew shifts the timestamps of payments by the estimated skew between the local clock and the clock
+	// of the disperser, so that dispersals are accounted to the reservation period the disperser expects even if the
+	// local clock is off. The skew is estimated from the time reported by the disperser in GetPaymentState.
+	AdjustForClockSkew bool
+	// MaxClockSkew is the largest estimated skew between the local clock and the clock of the disperser at which blobs
+	// are dispersed. Beyond it, DisperseBlob fails with an error describing the skew, rather than having the disperser
+	// reject the payment. The check applies whether or not timestamps are adjusted for the skew. If zero, the skew
+	// isn't checked.
+	MaxClockSkew time.Duration
+	// PaceDispersals makes DisperseBlob wait until the disperser can accept the blob, rather than sending blobs the
+	// disperser would reject. Blobs which the reservation of the account can't pay for wait for the next reservation
+	// period, unless they can be paid for on-demand, and on-demand blobs are paced to the global on-demand rate
+	// advertised by the disperser.
+	PaceDispersals bool
+	// Interceptors are installed on the connection to the disperser. If nil, no interceptors are installed.
+	Interceptors *GrpcInterceptors
 }
 
 type DisperserClient interface {
 	Close() error
 	DisperseBlob(ctx context.Context, data []byte, blobVersion corev2.BlobVersion, quorums []core.QuorumID) (*dispv2.BlobStatus, corev2.BlobKey, error)
 	GetBlobStatus(ctx context.Context, blobKey corev2.BlobKey) (*disperser_rpc.BlobStatusReply, error)
+	GetBlobStatuses(ctx context.Context, blobKeys []corev2.BlobKey) (*disperser_rpc.BlobStatusesReply, error)
 	GetBlobCommitment(ctx context.Context, data []byte) (*disperser_rpc.BlobCommitmentReply, error)
+	SubscribeCertificationEvents(ctx context.Context, startTimestamp uint64) (disperser_rpc.Disperser_SubscribeCertificationEventsClient, error)
+}
+
+// PaymentQuoter is implemented by DisperserClients which can report how a blob would be paid for, and what it would
+// cost, before it is dispersed.
+type PaymentQuoter interface {
+	// QuotePayment reports how a blob of blobSize bytes dispersed to the given quorums now would be paid for. See
+	// Accountant.QuotePayment.
+	QuotePayment(ctx context.Context, blobSize int, quorums []core.QuorumID) (*PaymentQuote, error)
 }
 
 type disperserClient struct {
-	config             *DisperserClientConfig
-	signer             corev2.BlobRequestSigner
-	initOnceGrpc       sync.Once
-	initOnceAccountant sync.Once
-	conn               *grpc.ClientConn
-	client             disperser_rpc.DisperserClient
-	prover             encoding.Prover
-	accountant         *Accountant
+	config       *DisperserClientConfig
+	signer       corev2.BlobRequestSigner
+	initOnceGrpc sync.Once
+	// accountantLock serializes the attempts to populate the accountant, which is retried until it succeeds
+	accountantLock  sync.Mutex
+	accountantReady atomic.Bool
+	conn            *grpc.ClientConn
+	client          disperser_rpc.DisperserClient
+	prover          encoding.Prover
+	accountant      *Accountant
+	metrics         ClientMetrics
+	clockSkew       *clockSkewEstimator
+	rateLimiter     *dispersalRateLimiter
 }
 
 var _ DisperserClient = &disperserClient{}
+var _ PaymentQuoter = &disperserClient{}
 
 // DisperserClient maintains a single underlying grpc connection to the disperser server,
 // through which it sends requests to disperse blobs and get blob status.
@@ -77,14 +126,32 @@ func NewDisperserClient(config *DisperserClientConfig, signer corev2.BlobRequest
 	if signer == nil {
 		return nil, api.NewErrorInvalidArg("signer must be provided")
 	}
+	if config.RetryPolicy != nil {
+		if err := config.RetryPolicy.checkAndSetDefaults(); err != nil {
+			return nil, api.NewErrorInvalidArg(fmt.Sprintf("invalid retry policy: %v", err))
+		}
+	}
 
-	return &disperserClient{
-		config:     config,
-		signer:     signer,
-		prover:     prover,
-		accountant: accountant,
+	metrics := config.Metrics
+	if metrics == nil {
+		metrics = NoopClientMetrics{}
+	}
+
+	client := &disperserClient{
+		config:      config,
+		signer:      signer,
+		prover:      prover,
+		accountant:  accountant,
+		metrics:     metrics,
+		clockSkew:   newClockSkewEstimator(clockSkewWindowSize),
+		rateLimiter: newDispersalRateLimiter(),
 		// conn and client are initialized lazily
-	}, nil
+	}
+	if accountant != nil {
+		accountant.SetRetentionPeriod(config.RetentionPeriod)
+	}
+	client.accountantReady.Store(accountant != nil)
+	return client, nil
 }
 
 // PopulateAccountant populates the accountant with the payment state from the disperser.
@@ -95,6 +162,10 @@ func (c *disperserClient) PopulateAccountant(ctx context.Context) error {
 			return fmt.Errorf("error getting account ID: %w", err)
 		}
 		c.accountant = NewAccountant(accountId, nil, nil, 0, 0, 0, 0)
+		c.accountant.SetRetentionPeriod(c.config.RetentionPeriod)
+		if c.config.AccountantStatePath != "" {
+			c.accountant.SetStore(NewFileAccountantStore(c.config.AccountantStatePath))
+		}
 	}
 
 	paymentState, err := c.GetPaymentState(ctx)
@@ -106,10 +177,66 @@ func (c *disperserClient) PopulateAccountant(ctx context.Context) error {
 	if err != nil {
 		return fmt.Errorf("error setting payment state for accountant: %w", err)
 	}
+	c.accountantReady.Store(true)
 
 	return nil
 }
 
+// ResyncAccountant reconciles the accountant with the payment state of the disperser, e.g. after a dispersal was
+// rejected for an insufficient payment. It must be called while no dispersal of the client is in flight. See
+// Accountant.Resync.
+func (c *disperserClient) ResyncAccountant(ctx context.Context) error {
+	err := c.initOncePopulateAccountant(ctx)
+	if err != nil {
+		return err
+	}
+
+	paymentState, err := c.GetPaymentState(ctx)
+	if err != nil {
+		return fmt.Errorf("error getting payment state for resyncing accountant: %w", err)
+	}
+
+	err = c.accountant.Resync(paymentState)
+	if err != nil {
+		return fmt.Errorf("error resyncing accountant: %w", err)
+	}
+
+	return nil
+}
+
+// QuotePayment reports how a blob of blobSize bytes dispersed to the given quorums now would be paid for, and what it
+// would cost, without dispersing it. The local accounting, which includes the dispersals in flight, is combined with
+// the latest payment state of the disperser, which includes the dispersals of other clients of the same account.
+//
+// A quote isn't a reservation of capacity: concurrent dispersals may use up the capacity before the blob is dispersed.
+func (c *disperserClient) QuotePayment(
+	ctx context.Context,
+	blobSize int,
+	quorums []core.QuorumID,
+) (*PaymentQuote, error) {
+	err := c.initOncePopulateAccountant(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	paymentState, err := c.GetPaymentState(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error getting payment state for quoting payment: %w", err)
+	}
+
+	timestamp, err := c.paymentTimestamp()
+	if err != nil {
+		return nil, err
+	}
+
+	symbolLength := encoding.GetBlobLengthPowerOf2(uint(blobSize))
+	quote, err := c.accountant.QuotePayment(uint64(symbolLength), quorums, timestamp, paymentState)
+	if err != nil {
+		return nil, fmt.Errorf("error quoting payment: %w", err)
+	}
+	return quote, nil
+}
+
 // Close closes the grpc connection to the disperser server.
 // It is thread safe and can be called multiple times.
 func (c *disperserClient) Close() error {
@@ -142,7 +269,19 @@ func (c *disperserClient) DisperseBlob(
 	}
 
 	symbolLength := encoding.GetBlobLengthPowerOf2(uint(len(data)))
-	payment, err := c.accountant.AccountBlob(ctx, time.Now().UnixNano(), uint64(symbolLength), quorums)
+	if c.config.PaceDispersals {
+		err = c.rateLimiter.wait(ctx, c.accountant, c.paymentTimestamp, uint64(symbolLength), quorums)
+		if err != nil {
+			return nil, [32]byte{}, fmt.Errorf("wait for dispersal rate limit: %w", err)
+		}
+	}
+
+	timestamp, err := c.paymentTimestamp()
+	if err != nil {
+		return nil, [32]byte{}, err
+	}
+
+	payment, err := c.accountant.AccountBlob(ctx, timestamp, uint64(symbolLength), quorums)
 	if err != nil {
 		return nil, [32]byte{}, fmt.Errorf("error accounting blob: %w", err)
 	}
@@ -216,8 +355,23 @@ func (c *disperserClient) DisperseBlob(
 		Signature:  sig,
 		BlobHeader: blobHeaderProto,
 	}
+	if delegation := c.paymentDelegation(); delegation != nil {
+		request.PaymentDelegationExpiry = delegation.Expiry
+		request.PaymentDelegationSignature = delegation.Signature
+	}
 
-	reply, err := c.client.DisperseBlob(ctx, request)
+	attempts := atomic.Int32{}
+	reply, err := invokeWithMetrics(c.metrics, "DisperseBlob", func() (*disperser_rpc.DisperseBlobReply, error) {
+		return invokeWithPolicy(ctx, c.config.RetryPolicy,
+			func(ctx context.Context) (*disperser_rpc.DisperseBlobReply, error) {
+				attempts.Add(1)
+				return c.client.DisperseBlob(ctx, request)
+			})
+	})
+	if err != nil && attempts.Load() > 1 {
+		// An earlier attempt may have been accepted, in which case the later attempts were rejected as duplicates
+		reply, err = c.getDispersedBlobStatus(ctx, blobHeader, err)
+	}
 	if err != nil {
 		return nil, [32]byte{}, fmt.Errorf("error while calling DisperseBlob: %w", err)
 	}
@@ -231,9 +385,38 @@ func (c *disperserClient) DisperseBlob(
 		return nil, [32]byte{}, fmt.Errorf("verify received blob key: %w", err)
 	}
 
+	c.metrics.RecordBytesDispersed(len(data))
+	symbolsCharged := c.accountant.RequestSymbolsCharged(uint64(symbolLength))
+	onDemandWei := big.NewInt(0)
+	if payment.CumulativePayment.Sign() > 0 {
+		onDemandWei.SetUint64(c.accountant.PaymentCharged(symbolsCharged))
+	}
+	c.metrics.RecordPaymentSpent(symbolsCharged, onDemandWei)
+
 	return &blobStatus, corev2.BlobKey(reply.GetBlobKey()), nil
 }
 
+// getDispersedBlobStatus looks up the status of a blob whose dispersal failed after several attempts, since an earlier
+// attempt may have been accepted by the disperser. It returns dispersalErr if the disperser doesn't know the blob.
+func (c *disperserClient) getDispersedBlobStatus(
+	ctx context.Context,
+	blobHeader *corev2.BlobHeader,
+	dispersalErr error,
+) (*disperser_rpc.DisperseBlobReply, error) {
+	blobKey, err := blobHeader.BlobKey()
+	if err != nil {
+		return nil, dispersalErr
+	}
+	statusReply, err := c.GetBlobStatus(ctx, blobKey)
+	if err != nil {
+		return nil, dispersalErr
+	}
+	return &disperser_rpc.DisperseBlobReply{
+		Result:  statusReply.GetStatus(),
+		BlobKey: blobKey[:],
+	}, nil
+}
+
 // verifyReceivedBlobKey computes the BlobKey from the BlobHeader which was sent to the disperser, and compares it with
 // the BlobKey which was returned by the disperser in the DisperseBlobReply
 //
@@ -278,7 +461,76 @@ func (c *disperserClient) GetBlobStatus(ctx context.Context, blobKey corev2.Blob
 	request := &disperser_rpc.BlobStatusRequest{
 		BlobKey: blobKey[:],
 	}
-	return c.client.GetBlobStatus(ctx, request)
+	return invokeWithMetrics(c.metrics, "GetBlobStatus", func() (*disperser_rpc.BlobStatusReply, error) {
+		return invokeWithPolicy(ctx, c.config.RetryPolicy,
+			func(ctx context.Context) (*disperser_rpc.BlobStatusReply, error) {
+				return c.client.GetBlobStatus(ctx, request)
+			})
+	})
+}
+
+// SubscribeCertificationEvents opens a stream of the certification events of the disperser. Events which occurred after
+// startTimestamp, in Unix nanoseconds, are replayed if they are within the replay window of the disperser. The stream is
+// closed when ctx is cancelled.
+//
+// Dispersers which don't support the stream fail the first Recv on the stream with codes.Unimplemented.
+func (c *disperserClient) SubscribeCertificationEvents(
+	ctx context.Context,
+	startTimestamp uint64,
+) (disperser_rpc.Disperser_SubscribeCertificationEventsClient, error) {
+	err := c.initOnceGrpcConnection()
+	if err != nil {
+		return nil, api.NewErrorInternal(err.Error())
+	}
+
+	request := &disperser_rpc.SubscribeCertificationEventsRequest{
+		StartTimestamp: startTimestamp,
+	}
+	// streams aren't retried, since a retried stream would replay the events already received
+	return c.client.SubscribeCertificationEvents(ctx, request)
+}
+
+// CheckHealth queries the gRPC health service of the disperser, and returns an error unless the disperser reports
+// that it is serving.
+func (c *disperserClient) CheckHealth(ctx context.Context) error {
+	err := c.initOnceGrpcConnection()
+	if err != nil {
+		return api.NewErrorInternal(err.Error())
+	}
+
+	reply, err := grpc_health_v1.NewHealthClient(c.conn).Check(ctx, &grpc_health_v1.HealthCheckRequest{
+		Service: disperser_rpc.Disperser_ServiceDesc.ServiceName,
+	})
+	if err != nil {
+		return fmt.Errorf("check health: %w", err)
+	}
+	if reply.GetStatus() != grpc_health_v1.HealthCheckResponse_SERVING {
+		return fmt.Errorf("disperser is %v", reply.GetStatus())
+	}
+	return nil
+}
+
+// GetBlobStatuses returns the statuses of the blobs with the given blob keys. The results are in the same order as
+// the blob keys. The disperser limits the number of blob keys which can be queried in a single call.
+func (c *disperserClient) GetBlobStatuses(ctx context.Context, blobKeys []corev2.BlobKey) (*disperser_rpc.BlobStatusesReply, error) {
+	err := c.initOnceGrpcConnection()
+	if err != nil {
+		return nil, api.NewErrorInternal(err.Error())
+	}
+
+	keys := make([][]byte, len(blobKeys))
+	for i := range blobKeys {
+		keys[i] = blobKeys[i][:]
+	}
+	request := &disperser_rpc.BlobStatusesRequest{
+		BlobKeys: keys,
+	}
+	return invokeWithMetrics(c.metrics, "GetBlobStatuses", func() (*disperser_rpc.BlobStatusesReply, error) {
+		return invokeWithPolicy(ctx, c.config.RetryPolicy,
+			func(ctx context.Context) (*disperser_rpc.BlobStatusesReply, error) {
+				return c.client.GetBlobStatuses(ctx, request)
+			})
+	})
 }
 
 // GetPaymentState returns the payment state of the disperser client
@@ -302,7 +554,76 @@ func (c *disperserClient) GetPaymentState(ctx context.Context) (*disperser_rpc.G
 		AccountId: accountID,
 		Signature: signature,
 	}
-	return c.client.GetPaymentState(ctx, request)
+	if delegation := c.paymentDelegation(); delegation != nil {
+		request.PaymentDelegationExpiry = delegation.Expiry
+		request.PaymentDelegationSignature = delegation.Signature
+	}
+	return invokeWithMetrics(c.metrics, "GetPaymentState", func() (*disperser_rpc.GetPaymentStateReply, error) {
+		return invokeWithPolicy(ctx, c.config.RetryPolicy,
+			func(ctx context.Context) (*disperser_rpc.GetPaymentStateReply, error) {
+				requestTime := time.Now()
+				var header metadata.MD
+				reply, err := c.client.GetPaymentState(ctx, request, grpc.Header(&header))
+				if err == nil {
+					c.clockSkew.addSample(requestTime, time.Now(), reply.GetCurrentTimestamp())
+					c.rateLimiter.setGlobalRate(reply.GetPaymentGlobalParams().GetGlobalSymbolsPerSecond())
+					c.setDefaultRetentionPeriod(header)
+				}
+				return reply, err
+			})
+	})
+}
+
+// setDefaultRetentionPeriod passes the default retention period reported by the disperser in the header of a
+// GetPaymentState reply to the accountant, so that it prorates the charge of a shorter retention period like the
+// disperser does. Dispersers which don't report it are charged in full for each blob.
+func (c *disperserClient) setDefaultRetentionPeriod(header metadata.MD) {
+	values := header.Get(api.DefaultRetentionPeriodHeader)
+	if len(values) == 0 || c.accountant == nil {
+		return
+	}
+	seconds, err := strconv.ParseUint(values[0], 10, 32)
+	if err != nil {
+		return
+	}
+	c.accountant.SetDefaultRetentionPeriod(time.Duration(seconds) * time.Second)
+}
+
+// paymentDelegation returns the delegation under which the signer signs requests on behalf of the payer, or nil if
+// the signer is the payer
+func (c *disperserClient) paymentDelegation() *corev2.PaymentDelegation {
+	delegatedSigner, ok := c.signer.(corev2.DelegatedBlobRequestSigner)
+	if !ok {
+		return nil
+	}
+	return delegatedSigner.GetPaymentDelegation()
+}
+
+// ClockSkew returns the estimated amount by which the clock of the disperser is ahead of the local clock, which is
+// negative if the local clock is ahead. The second return value is false if the skew hasn't been measured yet, which
+// happens on the first call to GetPaymentState, or if the disperser doesn't report its time.
+func (c *disperserClient) ClockSkew() (time.Duration, bool) {
+	return c.clockSkew.skew()
+}
+
+// paymentTimestamp returns the timestamp for the payment of a blob dispersed now, in nanoseconds, adjusted for the
+// estimated clock skew if AdjustForClockSkew is set. It fails if the estimated skew exceeds MaxClockSkew.
+func (c *disperserClient) paymentTimestamp() (int64, error) {
+	now := time.Now()
+	skew, measured := c.clockSkew.skew()
+	if !measured {
+		return now.UnixNano(), nil
+	}
+
+	if c.config.MaxClockSkew > 0 && (skew > c.config.MaxClockSkew || skew < -c.config.MaxClockSkew) {
+		return 0, fmt.Errorf(
+			"the clock of the disperser is %v ahead of the local clock, beyond the maximum skew of %v: "+
+				"synchronize the local clock, or increase MaxClockSkew", skew, c.config.MaxClockSkew)
+	}
+	if c.config.AdjustForClockSkew {
+		now = now.Add(skew)
+	}
+	return now.UnixNano(), nil
 }
 
 // GetBlobCommitment is a utility method that calculates commitment for a blob payload.
@@ -318,7 +639,12 @@ func (c *disperserClient) GetBlobCommitment(ctx context.Context, data []byte) (*
 	request := &disperser_rpc.BlobCommitmentRequest{
 		Blob: data,
 	}
-	return c.client.GetBlobCommitment(ctx, request)
+	return invokeWithMetrics(c.metrics, "GetBlobCommitment", func() (*disperser_rpc.BlobCommitmentReply, error) {
+		return invokeWithPolicy(ctx, c.config.RetryPolicy,
+			func(ctx context.Context) (*disperser_rpc.BlobCommitmentReply, error) {
+				return c.client.GetBlobCommitment(ctx, request)
+			})
+	})
 }
 
 // initOnceGrpcConnection initializes the grpc connection and client if they are not already initialized.
@@ -327,7 +653,7 @@ func (c *disperserClient) initOnceGrpcConnection() error {
 	var initErr error
 	c.initOnceGrpc.Do(func() {
 		addr := fmt.Sprintf("%v:%v", c.config.Hostname, c.config.Port)
-		dialOptions := getGrpcDialOptions(c.config.UseSecureGrpcFlag, 4*units.MiB)
+		dialOptions := getGrpcDialOptions(c.config.UseSecureGrpcFlag, 4*units.MiB, c.config.Interceptors)
 		conn, err := grpc.NewClient(addr, dialOptions...)
 		if err != nil {
 			initErr = err
@@ -343,20 +669,21 @@ func (c *disperserClient) initOnceGrpcConnection() error {
 }
 
 // initOncePopulateAccountant initializes the accountant if it is not already initialized.
-// If initialization fails, it caches the error and will return it on every subsequent call.
+// If initialization fails, the error is returned, and initialization is attempted again on the next call.
 func (c *disperserClient) initOncePopulateAccountant(ctx context.Context) error {
-	var initErr error
-	c.initOnceAccountant.Do(func() {
-		if c.accountant == nil {
-			err := c.PopulateAccountant(ctx)
-			if err != nil {
-				initErr = err
-				return
-			}
-		}
-	})
-	if initErr != nil {
-		return fmt.Errorf("populating accountant: %w", initErr)
+	if c.accountantReady.Load() {
+		return nil
+	}
+
+	c.accountantLock.Lock()
+	defer c.accountantLock.Unlock()
+	if c.accountantReady.Load() {
+		return nil
+	}
+
+	err := c.PopulateAccountant(ctx)
+	if err != nil {
+		return fmt.Errorf("populating accountant: %w", err)
 	}
 	return nil
 }
@@ -0,0 +1,398 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api"
+	disperser_rpc "github.com/Layr-Labs/eigenda/api/grpc/disperser/v2"
+	"github.com/Layr-Labs/eigenda/core"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	dispv2 "github.com/Layr-Labs/eigenda/disperser/common/v2"
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	lru "github.com/hashicorp/golang-lru/v2"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// HealthChecker is implemented by disperser clients which can check the health of their disperser.
+type HealthChecker interface {
+	// CheckHealth returns an error if the disperser isn't healthy
+	CheckHealth(ctx context.Context) error
+}
+
+// FailoverDisperserClient is a DisperserClient which spreads its requests over an ordered list of dispersers. Requests
+// are sent to the first healthy disperser, and fail over to the next disperser if a disperser fails.
+//
+// Each disperser has its own DisperserClient, and therefore its own Accountant, since each disperser tracks the
+// cumulative payments it has seen separately. Sharing an accountant would send cumulative payments to one disperser
+// which skip over the payments made to another.
+//
+// Each disperser has a circuit breaker, which opens when too many recent requests to the disperser failed or were slow.
+// Dispersers whose circuit breaker is open are skipped, and are probed with a request once the open duration has
+// elapsed, to detect that they have recovered.
+//
+// Blobs are only known to the disperser they were dispersed to, so status queries for a blob dispersed by this client
+// are sent to that disperser only. Status queries for other blobs are tried against each disperser in order.
+//
+// Failing over a dispersal which timed out may disperse the blob twice, if the first disperser accepted the blob
+// before timing out. In that case, both dispersals are paid for.
+//
+// This struct is goroutine safe.
+type FailoverDisperserClient struct {
+	logger logging.Logger
+	config FailoverDisperserClientConfig
+
+	// clients are the clients of the dispersers, in order of preference
+	clients  []DisperserClient
+	healthy  []atomic.Bool
+	breakers []*circuitBreaker
+
+	// blobOrigins maps the keys of recently dispersed blobs to the index of the disperser they were dispersed to
+	blobOrigins *lru.Cache[corev2.BlobKey, int]
+	// lastDispersal is the index of the disperser which accepted the most recent dispersal
+	lastDispersal atomic.Int32
+
+	stopHealthChecks chan struct{}
+	closeOnce        sync.Once
+}
+
+var _ DisperserClient = &FailoverDisperserClient{}
+var _ PaymentQuoter = &FailoverDisperserClient{}
+
+// BuildFailoverDisperserClient builds a FailoverDisperserClient which connects to the dispersers configured in
+// disperserConfigs, in order of preference.
+func BuildFailoverDisperserClient(
+	logger logging.Logger,
+	config FailoverDisperserClientConfig,
+	disperserConfigs []*DisperserClientConfig,
+	signer corev2.BlobRequestSigner,
+	prover encoding.Prover,
+) (*FailoverDisperserClient, error) {
+
+	// each disperser must persist its accounting separately
+	accountantStatePaths := make(map[string]struct{})
+	for _, disperserConfig := range disperserConfigs {
+		if disperserConfig == nil || disperserConfig.AccountantStatePath == "" {
+			continue
+		}
+		if _, ok := accountantStatePaths[disperserConfig.AccountantStatePath]; ok {
+			return nil, api.NewErrorInvalidArg(fmt.Sprintf(
+				"accountant state path %s is shared by multiple dispersers", disperserConfig.AccountantStatePath))
+		}
+		accountantStatePaths[disperserConfig.AccountantStatePath] = struct{}{}
+	}
+
+	clients := make([]DisperserClient, 0, len(disperserConfigs))
+	for i, disperserConfig := range disperserConfigs {
+		client, err := NewDisperserClient(disperserConfig, signer, prover, nil)
+		if err != nil {
+			return nil, fmt.Errorf("new disperser client %d: %w", i, err)
+		}
+		clients = append(clients, client)
+	}
+
+	return NewFailoverDisperserClient(logger, config, clients)
+}
+
+// NewFailoverDisperserClient creates a FailoverDisperserClient from the clients of the dispersers, in order of
+// preference. Clients which implement HealthChecker are health checked periodically.
+func NewFailoverDisperserClient(
+	logger logging.Logger,
+	config FailoverDisperserClientConfig,
+	clients []DisperserClient,
+) (*FailoverDisperserClient, error) {
+	if len(clients) == 0 {
+		return nil, api.NewErrorInvalidArg("at least one disperser must be provided")
+	}
+
+	err := config.checkAndSetDefaults()
+	if err != nil {
+		return nil, fmt.Errorf("check and set FailoverDisperserClientConfig defaults: %w", err)
+	}
+
+	blobOrigins, err := lru.New[corev2.BlobKey, int](config.BlobOriginCacheSize)
+	if err != nil {
+		return nil, fmt.Errorf("new blob origin cache: %w", err)
+	}
+
+	c := &FailoverDisperserClient{
+		logger:           logger,
+		config:           config,
+		clients:          clients,
+		healthy:          make([]atomic.Bool, len(clients)),
+		blobOrigins:      blobOrigins,
+		stopHealthChecks: make(chan struct{}),
+	}
+	for i := range c.healthy {
+		c.healthy[i].Store(true)
+		c.breakers = append(c.breakers, newCircuitBreaker(&c.config))
+	}
+
+	go c.healthCheckLoop()
+
+	return c, nil
+}
+
+// DisperseBlob disperses the blob to the first healthy disperser which accepts it.
+func (c *FailoverDisperserClient) DisperseBlob(
+	ctx context.Context,
+	data []byte,
+	blobVersion corev2.BlobVersion,
+	quorums []core.QuorumID,
+) (*dispv2.BlobStatus, corev2.BlobKey, error) {
+
+	type dispersal struct {
+		status  *dispv2.BlobStatus
+		blobKey corev2.BlobKey
+	}
+	result, index, err := invokeWithFailover(c, ctx, "DisperseBlob", c.order(-1),
+		func(client DisperserClient) (dispersal, error) {
+			blobStatus, blobKey, err := client.DisperseBlob(ctx, data, blobVersion, quorums)
+			return dispersal{status: blobStatus, blobKey: blobKey}, err
+		})
+	if err != nil {
+		return nil, corev2.BlobKey{}, err
+	}
+
+	c.blobOrigins.Add(result.blobKey, index)
+	c.lastDispersal.Store(int32(index))
+	return result.status, result.blobKey, nil
+}
+
+// GetBlobStatus returns the status of the blob from the disperser it was dispersed to, if known. Otherwise, each
+// disperser is queried in order.
+func (c *FailoverDisperserClient) GetBlobStatus(
+	ctx context.Context,
+	blobKey corev2.BlobKey,
+) (*disperser_rpc.BlobStatusReply, error) {
+	if index, ok := c.blobOrigins.Get(blobKey); ok {
+		return c.clients[index].GetBlobStatus(ctx, blobKey)
+	}
+
+	reply, _, err := invokeWithFailover(c, ctx, "GetBlobStatus", c.order(-1),
+		func(client DisperserClient) (*disperser_rpc.BlobStatusReply, error) {
+			return client.GetBlobStatus(ctx, blobKey)
+		})
+	return reply, err
+}
+
+// GetBlobStatuses returns the statuses of the blobs, preferring the disperser which the first blob was dispersed to.
+func (c *FailoverDisperserClient) GetBlobStatuses(
+	ctx context.Context,
+	blobKeys []corev2.BlobKey,
+) (*disperser_rpc.BlobStatusesReply, error) {
+	preferred := -1
+	if len(blobKeys) > 0 {
+		if index, ok := c.blobOrigins.Get(blobKeys[0]); ok {
+			preferred = index
+		}
+	}
+
+	reply, _, err := invokeWithFailover(c, ctx, "GetBlobStatuses", c.order(preferred),
+		func(client DisperserClient) (*disperser_rpc.BlobStatusesReply, error) {
+			return client.GetBlobStatuses(ctx, blobKeys)
+		})
+	return reply, err
+}
+
+// GetBlobCommitment computes the commitment of the blob with the first healthy disperser which responds.
+func (c *FailoverDisperserClient) GetBlobCommitment(
+	ctx context.Context,
+	data []byte,
+) (*disperser_rpc.BlobCommitmentReply, error) {
+	reply, _, err := invokeWithFailover(c, ctx, "GetBlobCommitment", c.order(-1),
+		func(client DisperserClient) (*disperser_rpc.BlobCommitmentReply, error) {
+			return client.GetBlobCommitment(ctx, data)
+		})
+	return reply, err
+}
+
+// QuotePayment quotes the payment of a blob with the disperser the blob would be dispersed to. Each disperser keeps
+// its own accounting, so the quote is only accurate for that disperser.
+func (c *FailoverDisperserClient) QuotePayment(
+	ctx context.Context,
+	blobSize int,
+	quorums []core.QuorumID,
+) (*PaymentQuote, error) {
+	quote, _, err := invokeWithFailover(c, ctx, "QuotePayment", c.order(-1),
+		func(client DisperserClient) (*PaymentQuote, error) {
+			quoter, ok := client.(PaymentQuoter)
+			if !ok {
+				return nil, api.NewErrorUnimplemented()
+			}
+			return quoter.QuotePayment(ctx, blobSize, quorums)
+		})
+	return quote, err
+}
+
+// SubscribeCertificationEvents subscribes to the certification events of the disperser which accepted the most recent
+// dispersal, failing over to the other dispersers if the subscription can't be made.
+func (c *FailoverDisperserClient) SubscribeCertificationEvents(
+	ctx context.Context,
+	startTimestamp uint64,
+) (disperser_rpc.Disperser_SubscribeCertificationEventsClient, error) {
+	stream, _, err := invokeWithFailover(c, ctx, "SubscribeCertificationEvents", c.order(int(c.lastDispersal.Load())),
+		func(client DisperserClient) (disperser_rpc.Disperser_SubscribeCertificationEventsClient, error) {
+			return client.SubscribeCertificationEvents(ctx, startTimestamp)
+		})
+	return stream, err
+}
+
+// Close stops the health checks, and closes the clients of all dispersers. Errors from closing the clients are joined.
+func (c *FailoverDisperserClient) Close() error {
+	var errs []error
+	c.closeOnce.Do(func() {
+		close(c.stopHealthChecks)
+		for i, client := range c.clients {
+			err := client.Close()
+			if err != nil {
+				errs = append(errs, fmt.Errorf("close disperser client %d: %w", i, err))
+			}
+		}
+	})
+	return errors.Join(errs...)
+}
+
+// order returns the indices of the dispersers in the order in which they should be tried: the preferred disperser
+// first, if preferred isn't negative, then the healthy dispersers whose circuit breaker isn't open, then the other
+// dispersers. The other dispersers are still tried as a last resort, since the health information may be stale.
+func (c *FailoverDisperserClient) order(preferred int) []int {
+	now := time.Now()
+	available := make([]bool, len(c.clients))
+	for i := range c.clients {
+		available[i] = c.healthy[i].Load() && c.breakers[i].available(now)
+	}
+
+	order := make([]int, 0, len(c.clients))
+	if preferred >= 0 {
+		order = append(order, preferred)
+	}
+	for _, wantAvailable := range []bool{true, false} {
+		for i := range c.clients {
+			if i != preferred && available[i] == wantAvailable {
+				order = append(order, i)
+			}
+		}
+	}
+	return order
+}
+
+// invokeWithFailover calls fn with the clients of the dispersers in the given order, until a call succeeds or fails
+// with an error which another disperser wouldn't resolve. It returns the result and the index of the disperser which
+// succeeded.
+func invokeWithFailover[T any](
+	c *FailoverDisperserClient,
+	ctx context.Context,
+	operation string,
+	order []int,
+	fn func(client DisperserClient) (T, error),
+) (T, int, error) {
+
+	var zero T
+	var errs []error
+	for _, index := range order {
+		start := time.Now()
+		result, err := fn(c.clients[index])
+		if ctx.Err() == nil {
+			c.recordOutcome(index, time.Since(start), err)
+		}
+		if err == nil {
+			return result, index, nil
+		}
+		errs = append(errs, fmt.Errorf("disperser %d: %w", index, err))
+
+		if ctx.Err() != nil || !shouldFailOver(err) {
+			break
+		}
+		if isAvailabilityFailure(err) {
+			c.setHealthy(index, false, err)
+		}
+		c.logger.Warn("Disperser request failed, failing over", "operation", operation, "disperser", index, "err", err)
+	}
+
+	return zero, -1, errors.Join(errs...)
+}
+
+// shouldFailOver returns whether a request which failed with err may succeed with another disperser. Requests which
+// were rejected as invalid would be rejected by any disperser.
+func shouldFailOver(err error) bool {
+	switch status.Code(err) {
+	case codes.InvalidArgument, codes.AlreadyExists, codes.Canceled:
+		return false
+	default:
+		return true
+	}
+}
+
+// isAvailabilityFailure returns whether a request which failed with err indicates that the disperser is unhealthy
+func isAvailabilityFailure(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.Internal, codes.Unknown:
+		return true
+	default:
+		return false
+	}
+}
+
+// recordOutcome records the outcome of a request to a disperser in its circuit breaker, logging changes of the state of
+// the breaker. Requests which failed because they were invalid count as successes, since the disperser responded.
+func (c *FailoverDisperserClient) recordOutcome(index int, latency time.Duration, err error) {
+	failed := err != nil && isAvailabilityFailure(err)
+	before, after := c.breakers[index].record(time.Now(), latency, failed)
+	if before == after {
+		return
+	}
+	if after == circuitOpen {
+		c.logger.Warn("Disperser circuit breaker opened", "disperser", index, "latency", latency, "err", err)
+	} else {
+		c.logger.Info("Disperser circuit breaker closed", "disperser", index)
+	}
+}
+
+// setHealthy records the health of a disperser, logging changes of health.
+func (c *FailoverDisperserClient) setHealthy(index int, healthy bool, err error) {
+	wasHealthy := c.healthy[index].Swap(healthy)
+	if wasHealthy && !healthy {
+		c.logger.Warn("Disperser is unhealthy", "disperser", index, "err", err)
+	} else if !wasHealthy && healthy {
+		c.logger.Info("Disperser is healthy again", "disperser", index)
+	}
+}
+
+// healthCheckLoop checks the health of the dispersers every HealthCheckInterval, until the client is closed.
+func (c *FailoverDisperserClient) healthCheckLoop() {
+	ticker := time.NewTicker(c.config.HealthCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.stopHealthChecks:
+			return
+		case <-ticker.C:
+			c.checkHealth()
+		}
+	}
+}
+
+// checkHealth checks the health of every disperser. Dispersers whose clients can't be health checked are considered
+// healthy again, since they have had HealthCheckInterval to recover.
+func (c *FailoverDisperserClient) checkHealth() {
+	for i, client := range c.clients {
+		checker, ok := client.(HealthChecker)
+		if !ok {
+			c.setHealthy(i, true, nil)
+			continue
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), c.config.HealthCheckTimeout)
+		err := checker.CheckHealth(ctx)
+		cancel()
+		c.setHealthy(i, err == nil, err)
+	}
+}
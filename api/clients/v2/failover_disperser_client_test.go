@@ -0,0 +1,195 @@
+package clients
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api"
+	disperser_rpc "github.com/Layr-Labs/eigenda/api/grpc/disperser/v2"
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/core"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	dispv2 "github.com/Layr-Labs/eigenda/disperser/common/v2"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeFailoverDisperserClient is a disperser client whose failures are controlled by the test
+type fakeFailoverDisperserClient struct {
+	DisperserClient
+
+	blobKey     corev2.BlobKey
+	disperseErr atomic.Pointer[error]
+	healthErr   atomic.Pointer[error]
+
+	disperseCalls atomic.Int32
+	statusCalls   atomic.Int32
+}
+
+func (c *fakeFailoverDisperserClient) DisperseBlob(
+	ctx context.Context,
+	data []byte,
+	blobVersion corev2.BlobVersion,
+	quorums []core.QuorumID,
+) (*dispv2.BlobStatus, corev2.BlobKey, error) {
+	c.disperseCalls.Add(1)
+	if err := c.disperseErr.Load(); err != nil {
+		return nil, corev2.BlobKey{}, *err
+	}
+	blobStatus := dispv2.Queued
+	return &blobStatus, c.blobKey, nil
+}
+
+func (c *fakeFailoverDisperserClient) GetBlobStatus(
+	ctx context.Context,
+	blobKey corev2.BlobKey,
+) (*disperser_rpc.BlobStatusReply, error) {
+	c.statusCalls.Add(1)
+	if blobKey != c.blobKey {
+		return nil, api.NewErrorNotFound("blob not found")
+	}
+	return &disperser_rpc.BlobStatusReply{Status: disperser_rpc.BlobStatus_QUEUED}, nil
+}
+
+func (c *fakeFailoverDisperserClient) CheckHealth(ctx context.Context) error {
+	if err := c.healthErr.Load(); err != nil {
+		return *err
+	}
+	return nil
+}
+
+func (c *fakeFailoverDisperserClient) Close() error {
+	return nil
+}
+
+func newTestFailoverDisperserClient(t *testing.T, healthCheckInterval time.Duration) (
+	*FailoverDisperserClient,
+	[]*fakeFailoverDisperserClient,
+) {
+	logger, err := common.NewLogger(common.DefaultLoggerConfig())
+	require.NoError(t, err)
+
+	fakes := []*fakeFailoverDisperserClient{
+		{blobKey: corev2.BlobKey{1}},
+		{blobKey: corev2.BlobKey{2}},
+	}
+	client, err := NewFailoverDisperserClient(
+		logger,
+		FailoverDisperserClientConfig{HealthCheckInterval: healthCheckInterval},
+		[]DisperserClient{fakes[0], fakes[1]})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, client.Close())
+	})
+
+	return client, fakes
+}
+
+func TestFailoverDisperserClientFailover(t *testing.T) {
+	client, fakes := newTestFailoverDisperserClient(t, time.Hour)
+	ctx := context.Background()
+
+	// the first disperser is preferred
+	_, blobKey, err := client.DisperseBlob(ctx, []byte{1}, 0, []core.QuorumID{0})
+	require.NoError(t, err)
+	require.Equal(t, fakes[0].blobKey, blobKey)
+
+	// the first disperser is unavailable, so the dispersal fails over to the second
+	unavailable := api.NewErrorUnavailable("unavailable")
+	fakes[0].disperseErr.Store(&unavailable)
+	_, blobKey, err = client.DisperseBlob(ctx, []byte{1}, 0, []core.QuorumID{0})
+	require.NoError(t, err)
+	require.Equal(t, fakes[1].blobKey, blobKey)
+	require.False(t, client.healthy[0].Load())
+
+	// the unhealthy disperser is tried last
+	fakes[0].disperseErr.Store(nil)
+	_, blobKey, err = client.DisperseBlob(ctx, []byte{1}, 0, []core.QuorumID{0})
+	require.NoError(t, err)
+	require.Equal(t, fakes[1].blobKey, blobKey)
+	require.Equal(t, int32(2), fakes[0].disperseCalls.Load())
+
+	// the status of a dispersed blob is queried from the disperser it was dispersed to
+	reply, err := client.GetBlobStatus(ctx, fakes[1].blobKey)
+	require.NoError(t, err)
+	require.Equal(t, disperser_rpc.BlobStatus_QUEUED, reply.Status)
+	require.Equal(t, int32(0), fakes[0].statusCalls.Load())
+	require.Equal(t, int32(1), fakes[1].statusCalls.Load())
+
+	// invalid requests aren't failed over
+	invalid := api.NewErrorInvalidArg("invalid")
+	fakes[1].disperseErr.Store(&invalid)
+	_, _, err = client.DisperseBlob(ctx, []byte{1}, 0, []core.QuorumID{0})
+	require.Error(t, err)
+	require.Equal(t, int32(2), fakes[0].disperseCalls.Load())
+}
+
+func TestFailoverDisperserClientUnknownBlobStatus(t *testing.T) {
+	client, fakes := newTestFailoverDisperserClient(t, time.Hour)
+
+	// the blob wasn't dispersed by this client, so the dispersers are queried in order
+	reply, err := client.GetBlobStatus(context.Background(), fakes[1].blobKey)
+	require.NoError(t, err)
+	require.Equal(t, disperser_rpc.BlobStatus_QUEUED, reply.Status)
+	require.Equal(t, int32(1), fakes[0].statusCalls.Load())
+	require.Equal(t, int32(1), fakes[1].statusCalls.Load())
+
+	_, err = client.GetBlobStatus(context.Background(), corev2.BlobKey{3})
+	require.Error(t, err)
+}
+
+func TestFailoverDisperserClientHealthChecks(t *testing.T) {
+	client, fakes := newTestFailoverDisperserClient(t, 10*time.Millisecond)
+
+	unhealthy := api.NewErrorUnavailable("unhealthy")
+	fakes[0].healthErr.Store(&unhealthy)
+	require.Eventually(t, func() bool {
+		return !client.healthy[0].Load()
+	}, time.Second, 5*time.Millisecond)
+	require.Equal(t, []int{1, 0}, client.order(-1))
+
+	fakes[0].healthErr.Store(nil)
+	require.Eventually(t, func() bool {
+		return client.healthy[0].Load()
+	}, time.Second, 5*time.Millisecond)
+	require.Equal(t, []int{0, 1}, client.order(-1))
+}
+
+func TestBuildFailoverDisperserClientSharedAccountantState(t *testing.T) {
+	logger, err := common.NewLogger(common.DefaultLoggerConfig())
+	require.NoError(t, err)
+
+	configs := []*DisperserClientConfig{
+		{Hostname: "disperser-1", Port: "443", AccountantStatePath: "accountant.json"},
+		{Hostname: "disperser-2", Port: "443", AccountantStatePath: "accountant.json"},
+	}
+	_, err = BuildFailoverDisperserClient(logger, FailoverDisperserClientConfig{}, configs, nil, nil)
+	require.Error(t, err)
+}
+
+func TestFailoverDisperserClientCircuitBreaker(t *testing.T) {
+	client, fakes := newTestFailoverDisperserClient(t, time.Hour)
+	ctx := context.Background()
+
+	// the first disperser keeps failing, and is no longer preferred once its circuit breaker opens, even though it is
+	// considered healthy again
+	unavailable := api.NewErrorUnavailable("unavailable")
+	fakes[0].disperseErr.Store(&unavailable)
+	for i := 0; i < client.config.CircuitBreakerMinRequests; i++ {
+		client.healthy[0].Store(true)
+		_, blobKey, err := client.DisperseBlob(ctx, []byte{1}, 0, []core.QuorumID{0})
+		require.NoError(t, err)
+		require.Equal(t, fakes[1].blobKey, blobKey)
+	}
+	client.healthy[0].Store(true)
+	require.Equal(t, []int{1, 0}, client.order(-1))
+
+	// once the open duration has elapsed, the first disperser is probed again, and closes the breaker if it recovered
+	fakes[0].disperseErr.Store(nil)
+	client.breakers[0].openedAt = time.Now().Add(-client.config.CircuitBreakerOpenDuration)
+	_, blobKey, err := client.DisperseBlob(ctx, []byte{1}, 0, []core.QuorumID{0})
+	require.NoError(t, err)
+	require.Equal(t, fakes[0].blobKey, blobKey)
+	require.Equal(t, circuitClosed, client.breakers[0].state)
+}
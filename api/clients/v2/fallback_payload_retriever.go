@@ -0,0 +1,189 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/Layr-Labs/eigenda/api/clients/v2/coretypes"
+	"github.com/Layr-Labs/eigenda/api/clients/v2/relay"
+	"github.com/Layr-Labs/eigenda/api/clients/v2/verification"
+	"github.com/Layr-Labs/eigenda/common/geth"
+	"github.com/Layr-Labs/eigenda/core/thegraph"
+	"github.com/Layr-Labs/eigenda/encoding/kzg"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+// FallbackPayloadRetriever retrieves payloads from the relays, and falls back to retrieving them from the EigenDA
+// validator nodes directly if no relay returns a valid blob. If an archive is configured, it is used as a last resort,
+// for blobs which are no longer held by the DA network because their retention window expired.
+//
+// Relays are tried first, since retrieving a blob from a relay is a single request, whereas retrieving it from the
+// validators requires fetching chunks from many nodes and decoding them. Both the relay and the validator retrievers
+// verify each blob against the commitment in the EigenDACert before decoding it to a payload, as does the archive
+// retriever, so a payload is never returned for a blob which doesn't match the cert, whichever source it came from.
+//
+// This struct is goroutine safe.
+type FallbackPayloadRetriever struct {
+	logger                    logging.Logger
+	relayPayloadRetriever     *RelayPayloadRetriever
+	validatorPayloadRetriever *ValidatorPayloadRetriever
+	// archivePayloadRetriever is nil if no archive is configured
+	archivePayloadRetriever *ArchivePayloadRetriever
+}
+
+var _ StreamingPayloadRetriever = &FallbackPayloadRetriever{}
+
+// BuildFallbackPayloadRetriever builds a FallbackPayloadRetriever from config structs.
+func BuildFallbackPayloadRetriever(
+	logger logging.Logger,
+	relayPayloadRetrieverConfig RelayPayloadRetrieverConfig,
+	relayClientConfig *RelayClientConfig,
+	relayUrlProvider relay.RelayUrlProvider,
+	validatorPayloadRetrieverConfig ValidatorPayloadRetrieverConfig,
+	ethConfig geth.EthClientConfig,
+	thegraphConfig thegraph.Config,
+	kzgConfig kzg.KzgConfig,
+) (*FallbackPayloadRetriever, error) {
+	validatorPayloadRetriever, err := BuildValidatorPayloadRetriever(
+		logger,
+		validatorPayloadRetrieverConfig,
+		ethConfig,
+		thegraphConfig,
+		kzgConfig)
+	if err != nil {
+		return nil, fmt.Errorf("build validator payload retriever: %w", err)
+	}
+
+	// the relay retriever verifies blobs with the same SRS points as the validator retriever
+	relayPayloadRetriever, err := BuildRelayPayloadRetriever(
+		logger,
+		relayPayloadRetrieverConfig,
+		relayClientConfig,
+		relayUrlProvider,
+		validatorPayloadRetriever.g1Srs)
+	if err != nil {
+		return nil, fmt.Errorf("build relay payload retriever: %w", err)
+	}
+
+	return NewFallbackPayloadRetriever(logger, relayPayloadRetriever, validatorPayloadRetriever), nil
+}
+
+// NewFallbackPayloadRetriever assembles a FallbackPayloadRetriever from retrievers that have already been constructed
+// and initialized.
+func NewFallbackPayloadRetriever(
+	logger logging.Logger,
+	relayPayloadRetriever *RelayPayloadRetriever,
+	validatorPayloadRetriever *ValidatorPayloadRetriever,
+) *FallbackPayloadRetriever {
+	return &FallbackPayloadRetriever{
+		logger:                    logger,
+		relayPayloadRetriever:     relayPayloadRetriever,
+		validatorPayloadRetriever: validatorPayloadRetriever,
+	}
+}
+
+// SetArchivePayloadRetriever configures an archive to retrieve payloads from when neither the relays nor the
+// validators return a blob matching the cert.
+//
+// This method must be called before the FallbackPayloadRetriever is used.
+func (pr *FallbackPayloadRetriever) SetArchivePayloadRetriever(archivePayloadRetriever *ArchivePayloadRetriever) {
+	pr.archivePayloadRetriever = archivePayloadRetriever
+}
+
+// GetPayload attempts to retrieve the payload from the relays which hold the blob, as claimed by the blob certificate.
+// If none of the relays returns a blob matching the cert, the payload is retrieved from the validator nodes of the
+// quorums of the blob. If that fails too, and an archive is configured, the payload is retrieved from the archive.
+//
+// This method does NOT verify the eigenDACert on chain: it is assumed that the input eigenDACert has already been
+// verified prior to calling this method.
+func (pr *FallbackPayloadRetriever) GetPayload(
+	ctx context.Context,
+	eigenDACert *verification.EigenDACert,
+) (*coretypes.Payload, error) {
+	var getFromArchive func(context.Context, *verification.EigenDACert) (*coretypes.Payload, error)
+	if pr.archivePayloadRetriever != nil {
+		getFromArchive = pr.archivePayloadRetriever.GetPayload
+	}
+
+	return retrieveWithFallback(
+		ctx,
+		pr.logger,
+		eigenDACert,
+		pr.relayPayloadRetriever.GetPayload,
+		pr.validatorPayloadRetriever.GetPayload,
+		getFromArchive)
+}
+
+// GetPayloadReader is like GetPayload, but returns a reader of the payload, which decodes the payload as it is read.
+// Whichever source the blob comes from, it is fully retrieved and verified against the certificate before the reader
+// is returned.
+//
+// The caller must close the returned reader.
+func (pr *FallbackPayloadRetriever) GetPayloadReader(
+	ctx context.Context,
+	eigenDACert *verification.EigenDACert,
+) (io.ReadCloser, error) {
+	var getFromArchive func(context.Context, *verification.EigenDACert) (io.ReadCloser, error)
+	if pr.archivePayloadRetriever != nil {
+		getFromArchive = pr.archivePayloadRetriever.GetPayloadReader
+	}
+
+	return retrieveWithFallback(
+		ctx,
+		pr.logger,
+		eigenDACert,
+		pr.relayPayloadRetriever.GetPayloadReader,
+		pr.validatorPayloadRetriever.GetPayloadReader,
+		getFromArchive)
+}
+
+// retrieveWithFallback calls getFromRelays, then getFromValidators if that fails, then getFromArchive if that fails
+// too and getFromArchive isn't nil. The result of the first call which succeeds is returned.
+func retrieveWithFallback[T any](
+	ctx context.Context,
+	logger logging.Logger,
+	eigenDACert *verification.EigenDACert,
+	getFromRelays func(context.Context, *verification.EigenDACert) (T, error),
+	getFromValidators func(context.Context, *verification.EigenDACert) (T, error),
+	getFromArchive func(context.Context, *verification.EigenDACert) (T, error),
+) (T, error) {
+	var zero T
+
+	result, relayErr := getFromRelays(ctx, eigenDACert)
+	if relayErr == nil {
+		return result, nil
+	}
+	if ctx.Err() != nil {
+		return zero, fmt.Errorf("get payload from relays: %w", relayErr)
+	}
+
+	logger.Warn("payload couldn't be retrieved from relays, falling back to validators", "error", relayErr)
+
+	result, validatorErr := getFromValidators(ctx, eigenDACert)
+	if validatorErr == nil {
+		return result, nil
+	}
+	if getFromArchive == nil || ctx.Err() != nil {
+		return zero, fmt.Errorf(
+			"get payload from relays: %w; get payload from validators: %w", relayErr, validatorErr)
+	}
+
+	logger.Warn("payload couldn't be retrieved from validators, falling back to archive", "error", validatorErr)
+
+	result, archiveErr := getFromArchive(ctx, eigenDACert)
+	if archiveErr != nil {
+		return zero, fmt.Errorf(
+			"get payload from relays: %w; get payload from validators: %w; get payload from archive: %w",
+			relayErr, validatorErr, archiveErr)
+	}
+
+	return result, nil
+}
+
+// Close closes the internal relay client.
+//
+// This method should only be called once.
+func (pr *FallbackPayloadRetriever) Close() error {
+	return pr.relayPayloadRetriever.Close()
+}
@@ -0,0 +1,74 @@
+package clients
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// GrpcInterceptors are gRPC interceptors installed on the connections of a client, which allow integrators to add
+// headers such as tenant IDs, tracing context or API keys to requests, and to wrap requests with their own retries or
+// telemetry, without wrapping the generated stubs.
+//
+// The interceptors run in order, the first being the outermost, for every request sent by the client. They run within
+// the retries and hedging of the client, if any, so each attempt of a request is intercepted separately.
+type GrpcInterceptors struct {
+	// Unary are the interceptors of unary requests
+	Unary []grpc.UnaryClientInterceptor
+	// Stream are the interceptors of streaming requests
+	Stream []grpc.StreamClientInterceptor
+}
+
+// NewHeaderInterceptors creates GrpcInterceptors which add the given headers to the metadata of every request. Header
+// names must be lowercase, as required by gRPC.
+func NewHeaderInterceptors(headers map[string]string) *GrpcInterceptors {
+	pairs := make([]string, 0, 2*len(headers))
+	for name, value := range headers {
+		pairs = append(pairs, name, value)
+	}
+
+	return &GrpcInterceptors{
+		Unary: []grpc.UnaryClientInterceptor{
+			func(
+				ctx context.Context,
+				method string,
+				req any,
+				reply any,
+				cc *grpc.ClientConn,
+				invoker grpc.UnaryInvoker,
+				opts ...grpc.CallOption,
+			) error {
+				return invoker(metadata.AppendToOutgoingContext(ctx, pairs...), method, req, reply, cc, opts...)
+			},
+		},
+		Stream: []grpc.StreamClientInterceptor{
+			func(
+				ctx context.Context,
+				desc *grpc.StreamDesc,
+				cc *grpc.ClientConn,
+				method string,
+				streamer grpc.Streamer,
+				opts ...grpc.CallOption,
+			) (grpc.ClientStream, error) {
+				return streamer(metadata.AppendToOutgoingContext(ctx, pairs...), desc, cc, method, opts...)
+			},
+		},
+	}
+}
+
+// dialOptions returns the dial options which install the interceptors. The interceptors may be nil.
+func (i *GrpcInterceptors) dialOptions() []grpc.DialOption {
+	if i == nil {
+		return nil
+	}
+
+	var options []grpc.DialOption
+	if len(i.Unary) > 0 {
+		options = append(options, grpc.WithChainUnaryInterceptor(i.Unary...))
+	}
+	if len(i.Stream) > 0 {
+		options = append(options, grpc.WithChainStreamInterceptor(i.Stream...))
+	}
+	return options
+}
@@ -0,0 +1,71 @@
+package clients
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestGrpcInterceptors(t *testing.T) {
+	// the server records the tenant header of each request
+	tenants := make(chan []string, 10)
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer(
+		grpc.UnaryInterceptor(func(
+			ctx context.Context,
+			req any,
+			info *grpc.UnaryServerInfo,
+			handler grpc.UnaryHandler,
+		) (any, error) {
+			md, _ := metadata.FromIncomingContext(ctx)
+			tenants <- md.Get("x-tenant-id")
+			return handler(ctx, req)
+		}))
+	grpc_health_v1.RegisterHealthServer(server, health.NewServer())
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	t.Cleanup(server.Stop)
+
+	// the interceptors run in order, after the headers are added
+	var calls []string
+	interceptors := NewHeaderInterceptors(map[string]string{"x-tenant-id": "tenant-1"})
+	interceptors.Unary = append(interceptors.Unary, func(
+		ctx context.Context,
+		method string,
+		req any,
+		reply any,
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		md, _ := metadata.FromOutgoingContext(ctx)
+		calls = append(calls, method+" "+md.Get("x-tenant-id")[0])
+		return invoker(ctx, method, req, reply, cc, opts...)
+	})
+
+	dialOptions := getGrpcDialOptions(false, 1024, interceptors)
+	dialOptions = append(dialOptions, grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+		return listener.DialContext(ctx)
+	}))
+	conn, err := grpc.NewClient("passthrough:///bufnet", dialOptions...)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, conn.Close())
+	}()
+
+	_, err = grpc_health_v1.NewHealthClient(conn).Check(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+	require.NoError(t, err)
+	require.Equal(t, []string{"tenant-1"}, <-tenants)
+	require.Equal(t, []string{"/grpc.health.v1.Health/Check tenant-1"}, calls)
+
+	// no interceptors are installed without GrpcInterceptors
+	require.Len(t, getGrpcDialOptions(false, 1024, nil), 2)
+}
@@ -0,0 +1,52 @@
+package clients
+
+import (
+	"math/big"
+	"time"
+)
+
+// ClientMetrics receives metrics from the EigenDA clients. It allows integrators to export the metrics of the clients
+// to their own monitoring stack, e.g. Prometheus or OpenTelemetry, by implementing this interface.
+//
+// Methods are called synchronously from the goroutines making requests, and may be called concurrently, so
+// implementations must be goroutine safe and must return quickly.
+type ClientMetrics interface {
+	// RecordRequestLatency records the latency of a request made by a client. method names the request, e.g.
+	// "DisperseBlob", "SendPayload" or "GetPayloadFromRelays", and err is the error the request failed with, or nil if
+	// it succeeded.
+	RecordRequestLatency(method string, latency time.Duration, err error)
+
+	// RecordBytesDispersed records the size of a blob accepted by the disperser.
+	RecordBytesDispersed(blobBytes int)
+
+	// RecordCertObtained records that a cert was obtained for a dispersed payload. latency is the time from the
+	// start of the dispersal until the cert was built.
+	RecordCertObtained(latency time.Duration)
+
+	// RecordPaymentSpent records the payment for a blob accepted by the disperser. symbolsCharged is the number of
+	// symbols the blob was charged for. onDemandWei is the on-demand payment for the blob in wei, which is zero if the
+	// blob was paid for with a reservation.
+	RecordPaymentSpent(symbolsCharged uint64, onDemandWei *big.Int)
+}
+
+// NoopClientMetrics is a ClientMetrics which discards all metrics. It is used by the clients if no metrics are
+// configured.
+type NoopClientMetrics struct{}
+
+var _ ClientMetrics = NoopClientMetrics{}
+
+func (NoopClientMetrics) RecordRequestLatency(string, time.Duration, error) {}
+
+func (NoopClientMetrics) RecordBytesDispersed(int) {}
+
+func (NoopClientMetrics) RecordCertObtained(time.Duration) {}
+
+func (NoopClientMetrics) RecordPaymentSpent(uint64, *big.Int) {}
+
+// invokeWithMetrics calls fn, and records its latency as a request to the given method.
+func invokeWithMetrics[T any](metrics ClientMetrics, method string, fn func() (T, error)) (T, error) {
+	start := time.Now()
+	value, err := fn()
+	metrics.RecordRequestLatency(method, time.Since(start), err)
+	return value, err
+}
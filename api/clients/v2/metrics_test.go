@@ -0,0 +1,57 @@
+package clients
+
+import (
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type recordedRequest struct {
+	method string
+	err    error
+}
+
+// recordingMetrics is a ClientMetrics which keeps the requests it records
+type recordingMetrics struct {
+	NoopClientMetrics
+	lock     sync.Mutex
+	requests []recordedRequest
+}
+
+func (m *recordingMetrics) RecordRequestLatency(method string, _ time.Duration, err error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.requests = append(m.requests, recordedRequest{method: method, err: err})
+}
+
+func TestInvokeWithMetrics(t *testing.T) {
+	metrics := &recordingMetrics{}
+
+	value, err := invokeWithMetrics(metrics, "Succeed", func() (int, error) {
+		return 42, nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, 42, value)
+
+	failure := errors.New("failure")
+	_, err = invokeWithMetrics(metrics, "Fail", func() (int, error) {
+		return 0, failure
+	})
+	require.ErrorIs(t, err, failure)
+
+	require.Equal(t, []recordedRequest{{method: "Succeed"}, {method: "Fail", err: failure}}, metrics.requests)
+}
+
+func TestDefaultClientMetrics(t *testing.T) {
+	config := PayloadClientConfig{}
+	require.NoError(t, config.checkAndSetDefaults())
+	require.NotNil(t, config.Metrics)
+
+	// the default metrics discard everything
+	config.Metrics.RecordRequestLatency("GetPayloadFromRelays", time.Second, nil)
+	config.Metrics.RecordPaymentSpent(1, big.NewInt(0))
+}
@@ -5,6 +5,7 @@ package mock
 import (
 	"context"
 
+	clients "github.com/Layr-Labs/eigenda/api/clients/v2"
 	"github.com/Layr-Labs/eigenda/encoding"
 	"github.com/stretchr/testify/mock"
 
@@ -46,6 +47,45 @@ func (_m *MockRetrievalClient) GetBlob(ctx context.Context, blobKey v2.BlobKey,
 	return r0, r1
 }
 
+// GetBlobWithReport provides a mock function with given fields: ctx, blobKey, blobVersion, blobCommitments, referenceBlockNumber, quorumID
+func (_m *MockRetrievalClient) GetBlobWithReport(ctx context.Context, blobKey v2.BlobKey, blobVersion uint16, blobCommitments encoding.BlobCommitments, referenceBlockNumber uint64, quorumID uint8) ([]byte, *clients.RetrievalReport, error) {
+	ret := _m.Called(ctx, blobKey, blobVersion, blobCommitments, referenceBlockNumber, quorumID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBlobWithReport")
+	}
+
+	var r0 []byte
+	var r1 *clients.RetrievalReport
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, v2.BlobKey, uint16, encoding.BlobCommitments, uint64, uint8) ([]byte, *clients.RetrievalReport, error)); ok {
+		return rf(ctx, blobKey, blobVersion, blobCommitments, referenceBlockNumber, quorumID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, v2.BlobKey, uint16, encoding.BlobCommitments, uint64, uint8) []byte); ok {
+		r0 = rf(ctx, blobKey, blobVersion, blobCommitments, referenceBlockNumber, quorumID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]byte)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, v2.BlobKey, uint16, encoding.BlobCommitments, uint64, uint8) *clients.RetrievalReport); ok {
+		r1 = rf(ctx, blobKey, blobVersion, blobCommitments, referenceBlockNumber, quorumID)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*clients.RetrievalReport)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, v2.BlobKey, uint16, encoding.BlobCommitments, uint64, uint8) error); ok {
+		r2 = rf(ctx, blobKey, blobVersion, blobCommitments, referenceBlockNumber, quorumID)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // NewMockRetrievalClient creates a new instance of MockRetrievalClient. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockRetrievalClient(t interface {
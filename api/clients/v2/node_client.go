@@ -12,12 +12,18 @@ import (
 	"github.com/Layr-Labs/eigenda/core"
 	corev2 "github.com/Layr-Labs/eigenda/core/v2"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 )
 
 type NodeClientConfig struct {
 	Hostname          string
 	Port              string
 	UseSecureGrpcFlag bool
+	// Credentials secure the connection to the node in place of UseSecureGrpcFlag if set, e.g. with a client
+	// certificate which the node requires to accept dispersals.
+	Credentials credentials.TransportCredentials
+	// Interceptors are installed on the connection to the node. If nil, no interceptors are installed.
+	Interceptors *GrpcInterceptors
 }
 
 type NodeClient interface {
@@ -119,7 +125,10 @@ func (c *nodeClient) initOnceGrpcConnection() error {
 	var initErr error
 	c.initOnce.Do(func() {
 		addr := fmt.Sprintf("%v:%v", c.config.Hostname, c.config.Port)
-		dialOptions := getGrpcDialOptions(c.config.UseSecureGrpcFlag, 4*units.MiB)
+		dialOptions := getGrpcDialOptions(c.config.UseSecureGrpcFlag, 4*units.MiB, c.config.Interceptors)
+		if c.config.Credentials != nil {
+			dialOptions = getGrpcDialOptionsWithCredentials(c.config.Credentials, 4*units.MiB, c.config.Interceptors)
+		}
 		conn, err := grpc.NewClient(addr, dialOptions...)
 		if err != nil {
 			initErr = err
@@ -1,8 +1,13 @@
 package clients
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"math/rand"
+	"slices"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/Layr-Labs/eigenda/api/clients/v2/coretypes"
@@ -18,6 +23,10 @@ import (
 	gethcommon "github.com/ethereum/go-ethereum/common"
 )
 
+// blobStatusPollJitter is the maximum fraction by which the interval between polls of GetBlobStatus is randomly
+// lengthened or shortened
+const blobStatusPollJitter = 0.2
+
 // PayloadDisperser provides the ability to disperse payloads to EigenDA via a Disperser grpc service.
 //
 // This struct is goroutine safe.
@@ -39,10 +48,14 @@ func BuildPayloadDisperser(
 	encoderCfg *encoding.Config,
 ) (*PayloadDisperser, error) {
 
-	// 1 - verify key semantics and create signer
-	signer, err := auth.NewLocalBlobRequestSigner(payloadDispCfg.SignerPaymentKey)
-	if err != nil {
-		return nil, fmt.Errorf("new local blob request signer: %w", err)
+	// 1 - verify key semantics and create signer, unless a signer is provided
+	var err error
+	signer := payloadDispCfg.Signer
+	if signer == nil {
+		signer, err = auth.NewLocalBlobRequestSigner(payloadDispCfg.SignerPaymentKey)
+		if err != nil {
+			return nil, fmt.Errorf("new local blob request signer: %w", err)
+		}
 	}
 
 	// 2 - create prover (if applicable)
@@ -140,21 +153,45 @@ func (pd *PayloadDisperser) SendPayload(
 	// payload is the raw data to be stored on eigenDA
 	payload *coretypes.Payload,
 ) (*verification.EigenDACert, error) {
-	blob, err := payload.ToBlob(pd.config.PayloadPolynomialForm)
+	return pd.SendPayloadToQuorums(ctx, certVerifierAddress, payload, nil)
+}
+
+// SendPayloadToQuorums is like SendPayload, but disperses the payload to additionalQuorums on top of the quorums
+// required by the cert verifier. Each additional quorum independently attests to the availability of the blob, at no
+// extra charge, but the account must be able to pay for dispersals to the quorum. See QuotePayloadToQuorums.
+func (pd *PayloadDisperser) SendPayloadToQuorums(
+	ctx context.Context,
+	certVerifierAddress string,
+	payload *coretypes.Payload,
+	additionalQuorums []uint8,
+) (*verification.EigenDACert, error) {
+	return invokeWithMetrics(pd.config.Metrics, "SendPayload", func() (*verification.EigenDACert, error) {
+		return pd.sendPayload(ctx, certVerifierAddress, payload, additionalQuorums)
+	})
+}
+
+// sendPayload implements SendPayloadToQuorums, without recording its latency
+func (pd *PayloadDisperser) sendPayload(
+	ctx context.Context,
+	certVerifierAddress string,
+	payload *coretypes.Payload,
+	additionalQuorums []uint8,
+) (*verification.EigenDACert, error) {
+	blob, err := pd.payloadToBlob(payload)
 	if err != nil {
-		return nil, fmt.Errorf("convert payload to blob: %w", err)
+		return nil, err
 	}
 
-	timeoutCtx, cancel := context.WithTimeout(ctx, pd.config.ContractCallTimeout)
-	defer cancel()
-	requiredQuorums, err := pd.requiredQuorumsStore.GetQuorumNumbersRequired(timeoutCtx, certVerifierAddress)
+	quorums, err := pd.dispersalQuorums(ctx, certVerifierAddress, additionalQuorums)
 	if err != nil {
-		return nil, fmt.Errorf("get quorum numbers required: %w", err)
+		return nil, err
 	}
 
-	timeoutCtx, cancel = context.WithTimeout(ctx, pd.config.DisperseBlobTimeout)
+	timeoutCtx, cancel := context.WithTimeout(ctx, pd.config.DisperseBlobTimeout)
 	defer cancel()
 
+	// certification events which occur after this time are relevant to the blob
+	dispersalStart := time.Now()
 	// TODO (litt3): eventually, we should consider making DisperseBlob accept an actual blob object, instead of the
 	//  serialized bytes. The operations taking place in DisperseBlob require the bytes to be converted into field
 	//  elements anyway, so serializing the blob here is unnecessary work. This will be a larger change that affects
@@ -163,7 +200,7 @@ func (pd *PayloadDisperser) SendPayload(
 		timeoutCtx,
 		blob.Serialize(),
 		pd.config.BlobVersion,
-		requiredQuorums,
+		quorums,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("disperse blob: %w", err)
@@ -172,7 +209,7 @@ func (pd *PayloadDisperser) SendPayload(
 
 	timeoutCtx, cancel = context.WithTimeout(ctx, pd.config.BlobCertifiedTimeout)
 	defer cancel()
-	blobStatusReply, err := pd.pollBlobStatusUntilCertified(timeoutCtx, blobKey, blobStatus.ToProfobuf())
+	blobStatusReply, err := pd.pollBlobStatusUntilCertified(timeoutCtx, blobKey, blobStatus.ToProfobuf(), dispersalStart)
 	if err != nil {
 		return nil, fmt.Errorf("poll blob status until certified: %w", err)
 	}
@@ -191,10 +228,167 @@ func (pd *PayloadDisperser) SendPayload(
 		return nil, fmt.Errorf("verify cert for blobKey %v: %w", blobKey.Hex(), err)
 	}
 	pd.logger.Debug("EigenDACert verified", "blobKey", blobKey.Hex())
+	pd.config.Metrics.RecordCertObtained(time.Since(dispersalStart))
 
 	return eigenDACert, nil
 }
 
+// QuotePayload reports how the payload would be paid for if it were dispersed now, and what it would cost, without
+// dispersing it. This allows callers to delay or split payloads which can't currently be afforded, rather than having
+// their dispersal rejected.
+//
+// Quoting requires a DisperserClient which implements PaymentQuoter.
+func (pd *PayloadDisperser) QuotePayload(
+	ctx context.Context,
+	certVerifierAddress string,
+	payload *coretypes.Payload,
+) (*PaymentQuote, error) {
+	return pd.QuotePayloadToQuorums(ctx, certVerifierAddress, payload, nil)
+}
+
+// QuotePayloadToQuorums is like QuotePayload, for a payload dispersed with SendPayloadToQuorums. It returns an error if
+// the account can't pay for dispersals to all the quorums, e.g. because the reservation doesn't cover them.
+func (pd *PayloadDisperser) QuotePayloadToQuorums(
+	ctx context.Context,
+	certVerifierAddress string,
+	payload *coretypes.Payload,
+	additionalQuorums []uint8,
+) (*PaymentQuote, error) {
+	quoter, ok := pd.disperserClient.(PaymentQuoter)
+	if !ok {
+		return nil, fmt.Errorf("disperser client doesn't support payment quotes")
+	}
+
+	blob, err := pd.payloadToBlob(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	quorums, err := pd.dispersalQuorums(ctx, certVerifierAddress, additionalQuorums)
+	if err != nil {
+		return nil, err
+	}
+
+	quote, err := quoter.QuotePayment(ctx, len(blob.Serialize()), quorums)
+	if err != nil {
+		return nil, fmt.Errorf("quote payment: %w", err)
+	}
+	return quote, nil
+}
+
+// dispersalQuorums returns the quorums required by the cert verifier, followed by the additional quorums which aren't
+// already required
+func (pd *PayloadDisperser) dispersalQuorums(
+	ctx context.Context,
+	certVerifierAddress string,
+	additionalQuorums []uint8,
+) ([]uint8, error) {
+	timeoutCtx, cancel := context.WithTimeout(ctx, pd.config.ContractCallTimeout)
+	defer cancel()
+	requiredQuorums, err := pd.requiredQuorumsStore.GetQuorumNumbersRequired(timeoutCtx, certVerifierAddress)
+	if err != nil {
+		return nil, fmt.Errorf("get quorum numbers required: %w", err)
+	}
+
+	quorums := append([]uint8(nil), requiredQuorums...)
+	for _, quorum := range additionalQuorums {
+		if !slices.Contains(quorums, quorum) {
+			quorums = append(quorums, quorum)
+		}
+	}
+	return quorums, nil
+}
+
+// payloadToBlob encrypts the payload if a PayloadEncryptor is configured, and converts it into a blob with the
+// configured codec
+func (pd *PayloadDisperser) payloadToBlob(payload *coretypes.Payload) (*coretypes.Blob, error) {
+	if pd.config.PayloadEncryptor != nil {
+		ciphertext, err := pd.config.PayloadEncryptor.Encrypt(payload.Serialize())
+		if err != nil {
+			return nil, fmt.Errorf("encrypt payload: %w", err)
+		}
+		payload = coretypes.NewPayload(ciphertext)
+	}
+
+	blob, err := payload.ToBlobWithCodec(pd.config.PayloadPolynomialForm, pd.config.PayloadCodec)
+	if err != nil {
+		return nil, fmt.Errorf("convert payload to blob: %w", err)
+	}
+	return blob, nil
+}
+
+// PayloadDispersalResult is the outcome of the dispersal of one of the payloads passed to SendPayloads.
+type PayloadDispersalResult struct {
+	// Cert is the verified cert of the payload. It is nil if the dispersal failed.
+	Cert *verification.EigenDACert
+	// Err is the reason the dispersal failed. It is nil if the dispersal succeeded.
+	Err error
+}
+
+// SendPayloads disperses a batch of payloads, with up to MaxConcurrentDispersals dispersals in flight at once. Each
+// payload goes through the same steps as in SendPayload, and the dispersal of one payload failing doesn't affect the
+// others.
+//
+// The returned slice contains the result of each payload, in the order of the input payloads. Payloads which haven't
+// started dispersing when the context is cancelled fail with the error of the context.
+func (pd *PayloadDisperser) SendPayloads(
+	ctx context.Context,
+	certVerifierAddress string,
+	payloads []*coretypes.Payload,
+) []PayloadDispersalResult {
+	return sendConcurrently(ctx, payloads, pd.config.MaxConcurrentDispersals,
+		func(ctx context.Context, payload *coretypes.Payload) (*verification.EigenDACert, error) {
+			return pd.SendPayload(ctx, certVerifierAddress, payload)
+		})
+}
+
+// sendConcurrently calls send for each of the payloads, with at most maxConcurrency calls in flight at once, and
+// returns the results in the order of the payloads.
+func sendConcurrently(
+	ctx context.Context,
+	payloads []*coretypes.Payload,
+	maxConcurrency int,
+	send func(ctx context.Context, payload *coretypes.Payload) (*verification.EigenDACert, error),
+) []PayloadDispersalResult {
+
+	results := make([]PayloadDispersalResult, len(payloads))
+	semaphore := make(chan struct{}, maxConcurrency)
+	var waitGroup sync.WaitGroup
+
+	for i, payload := range payloads {
+		select {
+		case <-ctx.Done():
+			results[i].Err = fmt.Errorf("dispersal of payload %d not started: %w", i, ctx.Err())
+			continue
+		case semaphore <- struct{}{}:
+		}
+		// both cases of the select may be ready, in which case either is chosen
+		if ctx.Err() != nil {
+			<-semaphore
+			results[i].Err = fmt.Errorf("dispersal of payload %d not started: %w", i, ctx.Err())
+			continue
+		}
+
+		waitGroup.Add(1)
+		go func(i int, payload *coretypes.Payload) {
+			defer func() {
+				<-semaphore
+				waitGroup.Done()
+			}()
+
+			cert, err := send(ctx, payload)
+			if err != nil {
+				results[i].Err = fmt.Errorf("disperse payload %d: %w", i, err)
+				return
+			}
+			results[i].Cert = cert
+		}(i, payload)
+	}
+
+	waitGroup.Wait()
+	return results
+}
+
 // Close is responsible for calling close on all internal clients. This method will do its best to close all internal
 // clients, even if some closes fail.
 //
@@ -210,7 +404,15 @@ func (pd *PayloadDisperser) Close() error {
 	return nil
 }
 
-// pollBlobStatusUntilCertified polls the disperser for the status of a blob that has been dispersed
+// pollBlobStatusUntilCertified waits for a blob that has been dispersed to be certified.
+//
+// The disperser is polled with GetBlobStatus, with an interval which backs off exponentially and is jittered, so that
+// many clients waiting on the same batch don't poll in lockstep. Polls are scheduled so that the last poll happens
+// before the deadline of ctx, which is the hard deadline for certification.
+//
+// Concurrently, the certification events of the disperser are streamed, if the disperser supports it, and the blob
+// is reported certified as soon as its event is received. While the stream is active, polling continues at the maximum
+// interval, since the stream doesn't report failed dispersals.
 //
 // This method will only return a non-nil BlobStatusReply if the blob is reported to be CERTIFIED prior to the timeout.
 // In all other cases, this method will return a nil BlobStatusReply, along with an error describing the failure.
@@ -218,12 +420,23 @@ func (pd *PayloadDisperser) pollBlobStatusUntilCertified(
 	ctx context.Context,
 	blobKey core.BlobKey,
 	initialStatus dispgrpc.BlobStatus,
+	// dispersalStart is the time at which the blob was dispersed. Certification events from before this time aren't
+	// relevant to the blob.
+	dispersalStart time.Time,
 ) (*dispgrpc.BlobStatusReply, error) {
 
 	previousStatus := initialStatus
 
-	ticker := time.NewTicker(pd.config.BlobStatusPollInterval)
-	defer ticker.Stop()
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	certified := make(chan *dispgrpc.BlobStatusReply, 1)
+	var streaming atomic.Bool
+	go pd.watchCertificationEvents(ctx, blobKey, dispersalStart, &streaming, certified)
+
+	interval := pd.config.BlobStatusPollInterval
+	timer := time.NewTimer(pd.nextPollDelay(ctx, interval, streaming.Load()))
+	defer timer.Stop()
 
 	for {
 		select {
@@ -233,7 +446,15 @@ func (pd *PayloadDisperser) pollBlobStatusUntilCertified(
 				dispgrpc.BlobStatus_COMPLETE.String(),
 				previousStatus.String(),
 				ctx.Err())
-		case <-ticker.C:
+		case blobStatusReply := <-certified:
+			pd.logger.Debug("Blob certification event received", "blobKey", blobKey.Hex())
+			return blobStatusReply, nil
+		case <-timer.C:
+			interval = min(
+				time.Duration(float64(interval)*pd.config.BlobStatusPollBackoffMultiplier),
+				pd.config.MaxBlobStatusPollInterval)
+			timer.Reset(pd.nextPollDelay(ctx, interval, streaming.Load()))
+
 			// This call to the disperser doesn't have a dedicated timeout configured.
 			// If this call fails to return in a timely fashion, the timeout configured for the poll loop will trigger
 			blobStatusReply, err := pd.disperserClient.GetBlobStatus(ctx, blobKey)
@@ -270,6 +491,73 @@ func (pd *PayloadDisperser) pollBlobStatusUntilCertified(
 	}
 }
 
+// nextPollDelay returns the delay until the next poll of GetBlobStatus, given the current poll interval.
+func (pd *PayloadDisperser) nextPollDelay(ctx context.Context, interval time.Duration, streaming bool) time.Duration {
+	if streaming {
+		interval = pd.config.MaxBlobStatusPollInterval
+	}
+
+	// jitter the interval uniformly within +/- blobStatusPollJitter of its value
+	jitter := (rand.Float64()*2 - 1) * blobStatusPollJitter
+	delay := time.Duration(float64(interval) * (1 + jitter))
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return delay
+	}
+	// Don't sleep past the deadline: poll halfway to it instead, without polling faster than the initial interval.
+	remaining := time.Until(deadline)
+	if delay >= remaining {
+		delay = max(remaining/2, pd.config.BlobStatusPollInterval)
+	}
+	return delay
+}
+
+// watchCertificationEvents streams the certification events of the disperser, and sends the status of the blob to
+// certified once the blob is certified. streaming is set while the stream is healthy.
+//
+// If the disperser doesn't support the stream, or the stream fails, this method returns, and the caller relies on
+// polling alone.
+func (pd *PayloadDisperser) watchCertificationEvents(
+	ctx context.Context,
+	blobKey core.BlobKey,
+	dispersalStart time.Time,
+	streaming *atomic.Bool,
+	certified chan<- *dispgrpc.BlobStatusReply,
+) {
+	defer streaming.Store(false)
+
+	stream, err := pd.disperserClient.SubscribeCertificationEvents(ctx, uint64(dispersalStart.UnixNano()))
+	if err != nil {
+		pd.logger.Debug("Certification events unavailable, polling blob status", "err", err)
+		return
+	}
+
+	for {
+		event, err := stream.Recv()
+		if err != nil {
+			if ctx.Err() == nil {
+				pd.logger.Debug("Certification event stream ended, polling blob status", "err", err)
+			}
+			return
+		}
+		// the stream is known to be supported once the first event is received
+		streaming.Store(true)
+
+		blobCertified := event.GetBlobCertified()
+		if blobCertified == nil || !bytes.Equal(blobCertified.GetBlobKey(), blobKey[:]) {
+			continue
+		}
+
+		certified <- &dispgrpc.BlobStatusReply{
+			Status:            dispgrpc.BlobStatus_COMPLETE,
+			SignedBatch:       blobCertified.GetSignedBatch(),
+			BlobInclusionInfo: blobCertified.GetBlobInclusionInfo(),
+		}
+		return
+	}
+}
+
 // buildEigenDACert makes a call to the getNonSignerStakesAndSignature view function on the EigenDACertVerifier
 // contract, and then assembles an EigenDACert
 func (pd *PayloadDisperser) buildEigenDACert(
@@ -0,0 +1,242 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api"
+	"github.com/Layr-Labs/eigenda/api/clients/v2/coretypes"
+	"github.com/Layr-Labs/eigenda/api/clients/v2/verification"
+	dispgrpc "github.com/Layr-Labs/eigenda/api/grpc/disperser/v2"
+	"github.com/Layr-Labs/eigenda/common"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+// fakeStatusDisperserClient serves blob statuses, and optionally a stream of certification events
+type fakeStatusDisperserClient struct {
+	DisperserClient
+
+	// statuses are returned by successive calls to GetBlobStatus. The last status is repeated.
+	statuses    []dispgrpc.BlobStatus
+	statusCalls atomic.Int32
+
+	// events are sent on the certification event stream. If nil, the stream isn't supported.
+	events chan *dispgrpc.CertificationEvent
+}
+
+func (c *fakeStatusDisperserClient) GetBlobStatus(
+	ctx context.Context,
+	blobKey corev2.BlobKey,
+) (*dispgrpc.BlobStatusReply, error) {
+	call := int(c.statusCalls.Add(1)) - 1
+	return &dispgrpc.BlobStatusReply{Status: c.statuses[min(call, len(c.statuses)-1)]}, nil
+}
+
+func (c *fakeStatusDisperserClient) SubscribeCertificationEvents(
+	ctx context.Context,
+	startTimestamp uint64,
+) (dispgrpc.Disperser_SubscribeCertificationEventsClient, error) {
+	return &fakeCertificationEventStream{ctx: ctx, events: c.events}, nil
+}
+
+type fakeCertificationEventStream struct {
+	grpc.ClientStream
+	ctx    context.Context
+	events chan *dispgrpc.CertificationEvent
+}
+
+func (s *fakeCertificationEventStream) Recv() (*dispgrpc.CertificationEvent, error) {
+	if s.events == nil {
+		return nil, api.NewErrorUnimplemented()
+	}
+	select {
+	case event := <-s.events:
+		return event, nil
+	case <-s.ctx.Done():
+		return nil, s.ctx.Err()
+	}
+}
+
+func newStatusTestDisperser(t *testing.T, disperserClient DisperserClient) *PayloadDisperser {
+	logger, err := common.NewLogger(common.DefaultLoggerConfig())
+	require.NoError(t, err)
+
+	config := PayloadDisperserConfig{
+		BlobStatusPollInterval:    time.Millisecond,
+		MaxBlobStatusPollInterval: 20 * time.Millisecond,
+	}
+	require.NoError(t, config.checkAndSetDefaults())
+
+	return &PayloadDisperser{
+		logger:          logger,
+		config:          config,
+		disperserClient: disperserClient,
+	}
+}
+
+func TestSendConcurrently(t *testing.T) {
+	payloads := make([]*coretypes.Payload, 10)
+	certs := make(map[*coretypes.Payload]*verification.EigenDACert)
+	for i := range payloads {
+		payloads[i] = coretypes.NewPayload([]byte{byte(i)})
+		certs[payloads[i]] = &verification.EigenDACert{}
+	}
+	failedPayload := payloads[3]
+
+	maxConcurrency := 3
+	inFlight := atomic.Int32{}
+	maxInFlight := atomic.Int32{}
+	results := sendConcurrently(context.Background(), payloads, maxConcurrency,
+		func(ctx context.Context, payload *coretypes.Payload) (*verification.EigenDACert, error) {
+			current := inFlight.Add(1)
+			defer inFlight.Add(-1)
+			for {
+				observed := maxInFlight.Load()
+				if current <= observed || maxInFlight.CompareAndSwap(observed, current) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+
+			if payload == failedPayload {
+				return nil, errors.New("dispersal failed")
+			}
+			return certs[payload], nil
+		})
+
+	require.Len(t, results, len(payloads))
+	require.LessOrEqual(t, maxInFlight.Load(), int32(maxConcurrency))
+	for i, result := range results {
+		if i == 3 {
+			require.Error(t, result.Err)
+			require.Nil(t, result.Cert)
+			continue
+		}
+		require.NoError(t, result.Err)
+		require.Same(t, certs[payloads[i]], result.Cert)
+	}
+}
+
+func TestSendConcurrentlyCancelled(t *testing.T) {
+	payloads := make([]*coretypes.Payload, 4)
+	for i := range payloads {
+		payloads[i] = coretypes.NewPayload([]byte{byte(i)})
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	sent := atomic.Int32{}
+	results := sendConcurrently(ctx, payloads, 1,
+		func(ctx context.Context, payload *coretypes.Payload) (*verification.EigenDACert, error) {
+			sent.Add(1)
+			// cancel the batch while the first payload is being dispersed
+			cancel()
+			return nil, ctx.Err()
+		})
+
+	require.Equal(t, int32(1), sent.Load())
+	for _, result := range results {
+		require.ErrorIs(t, result.Err, context.Canceled)
+	}
+}
+
+func TestPollBlobStatusUntilCertified(t *testing.T) {
+	disperserClient := &fakeStatusDisperserClient{
+		statuses: []dispgrpc.BlobStatus{
+			dispgrpc.BlobStatus_QUEUED,
+			dispgrpc.BlobStatus_ENCODED,
+			dispgrpc.BlobStatus_GATHERING_SIGNATURES,
+			dispgrpc.BlobStatus_COMPLETE,
+		},
+	}
+	pd := newStatusTestDisperser(t, disperserClient)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	reply, err := pd.pollBlobStatusUntilCertified(ctx, corev2.BlobKey{1}, dispgrpc.BlobStatus_QUEUED, time.Now())
+	require.NoError(t, err)
+	require.Equal(t, dispgrpc.BlobStatus_COMPLETE, reply.Status)
+	require.Equal(t, int32(4), disperserClient.statusCalls.Load())
+
+	// terminal failure
+	disperserClient = &fakeStatusDisperserClient{
+		statuses: []dispgrpc.BlobStatus{dispgrpc.BlobStatus_QUEUED, dispgrpc.BlobStatus_FAILED},
+	}
+	pd = newStatusTestDisperser(t, disperserClient)
+	_, err = pd.pollBlobStatusUntilCertified(ctx, corev2.BlobKey{1}, dispgrpc.BlobStatus_QUEUED, time.Now())
+	require.Error(t, err)
+
+	// the deadline of the context is reached
+	disperserClient = &fakeStatusDisperserClient{
+		statuses: []dispgrpc.BlobStatus{dispgrpc.BlobStatus_QUEUED},
+	}
+	pd = newStatusTestDisperser(t, disperserClient)
+	shortCtx, shortCancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer shortCancel()
+	_, err = pd.pollBlobStatusUntilCertified(shortCtx, corev2.BlobKey{1}, dispgrpc.BlobStatus_QUEUED, time.Now())
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestPollBlobStatusUntilCertifiedStreaming(t *testing.T) {
+	blobKey := corev2.BlobKey{1}
+	disperserClient := &fakeStatusDisperserClient{
+		statuses: []dispgrpc.BlobStatus{dispgrpc.BlobStatus_QUEUED},
+		events:   make(chan *dispgrpc.CertificationEvent, 3),
+	}
+	signedBatch := &dispgrpc.SignedBatch{}
+	inclusionInfo := &dispgrpc.BlobInclusionInfo{BlobIndex: 7}
+	disperserClient.events <- &dispgrpc.CertificationEvent{
+		Event: &dispgrpc.CertificationEvent_BatchCertified{
+			BatchCertified: &dispgrpc.BatchCertifiedEvent{SignedBatch: signedBatch},
+		},
+	}
+	disperserClient.events <- &dispgrpc.CertificationEvent{
+		Event: &dispgrpc.CertificationEvent_BlobCertified{
+			BlobCertified: &dispgrpc.BlobCertifiedEvent{BlobKey: []byte{2}, SignedBatch: signedBatch},
+		},
+	}
+	disperserClient.events <- &dispgrpc.CertificationEvent{
+		Event: &dispgrpc.CertificationEvent_BlobCertified{
+			BlobCertified: &dispgrpc.BlobCertifiedEvent{
+				BlobKey:           blobKey[:],
+				SignedBatch:       signedBatch,
+				BlobInclusionInfo: inclusionInfo,
+			},
+		},
+	}
+	pd := newStatusTestDisperser(t, disperserClient)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	// the status never progresses past QUEUED, so the blob is certified through the stream
+	reply, err := pd.pollBlobStatusUntilCertified(ctx, blobKey, dispgrpc.BlobStatus_QUEUED, time.Now())
+	require.NoError(t, err)
+	require.Equal(t, dispgrpc.BlobStatus_COMPLETE, reply.Status)
+	require.Same(t, signedBatch, reply.SignedBatch)
+	require.Same(t, inclusionInfo, reply.BlobInclusionInfo)
+}
+
+func TestNextPollDelay(t *testing.T) {
+	pd := newStatusTestDisperser(t, &fakeStatusDisperserClient{})
+
+	// the delay is jittered around the interval
+	for i := 0; i < 100; i++ {
+		delay := pd.nextPollDelay(context.Background(), 10*time.Millisecond, false)
+		require.GreaterOrEqual(t, delay, 8*time.Millisecond)
+		require.LessOrEqual(t, delay, 12*time.Millisecond)
+	}
+
+	// while streaming, polls happen at the maximum interval
+	delay := pd.nextPollDelay(context.Background(), time.Millisecond, true)
+	require.GreaterOrEqual(t, delay, 16*time.Millisecond)
+
+	// the next poll happens before the deadline
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	delay = pd.nextPollDelay(ctx, time.Second, false)
+	require.Less(t, delay, 10*time.Millisecond)
+}
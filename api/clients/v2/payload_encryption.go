@@ -0,0 +1,310 @@
+package clients
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/hkdf"
+)
+
+// PayloadEncryptor encrypts payloads before they are dispersed, and decrypts them after they are retrieved, so that
+// applications can store confidential data on EigenDA.
+//
+// Encryption is applied to the payload bytes, before the payload codec. Ciphertext doesn't compress, so compressing
+// payload codecs bring no benefit to encrypted payloads.
+type PayloadEncryptor interface {
+	// Encrypt encrypts a payload
+	Encrypt(plaintext []byte) ([]byte, error)
+	// Decrypt decrypts a payload encrypted by Encrypt, and authenticates it. Payloads which weren't encrypted with a
+	// key known to the PayloadEncryptor are rejected.
+	Decrypt(ciphertext []byte) ([]byte, error)
+}
+
+// The format of an encrypted payload is:
+//
+//	magic (4 bytes) | scheme (1 byte) | scheme header | nonce (12 bytes) | AES-256-GCM ciphertext
+//
+// The scheme header of encryptionSchemeAESGCM is the ID of the key (4 bytes). The scheme header of
+// encryptionSchemeX25519 is the number of recipients (2 bytes), followed by a stanza for each recipient, made of an
+// ephemeral X25519 public key (32 bytes) and the file key wrapped for the recipient (48 bytes).
+//
+// Everything before the ciphertext is authenticated along with the ciphertext.
+var encryptedPayloadMagic = []byte{0xED, 0xA0, 'P', 'E'}
+
+const (
+	encryptionSchemeAESGCM byte = 0x01
+	encryptionSchemeX25519 byte = 0x02
+
+	encryptionKeySize   = 32
+	encryptionNonceSize = 12
+	// wrappedFileKeySize is the size of a file key encrypted with AES-256-GCM, including the tag
+	wrappedFileKeySize = encryptionKeySize + 16
+	x25519StanzaSize   = 32 + wrappedFileKeySize
+
+	x25519WrapInfo = "eigenda-payload-encryption-x25519"
+)
+
+// aesGCMPayloadEncryptor encrypts payloads with AES-256-GCM, with keys provided by the caller
+type aesGCMPayloadEncryptor struct {
+	keys        map[uint32][]byte
+	activeKeyID uint32
+}
+
+var _ PayloadEncryptor = &aesGCMPayloadEncryptor{}
+
+// NewAESGCMPayloadEncryptor creates a PayloadEncryptor which encrypts payloads with AES-256-GCM. Payloads are encrypted
+// with the key activeKeyID of keys, and can be decrypted with any of the keys, which allows keys to be rotated. The ID
+// of the key is recorded in each encrypted payload. Keys must be 32 bytes long.
+func NewAESGCMPayloadEncryptor(keys map[uint32][]byte, activeKeyID uint32) (PayloadEncryptor, error) {
+	for keyID, key := range keys {
+		if len(key) != encryptionKeySize {
+			return nil, fmt.Errorf("key %d must be %d bytes long, got %d", keyID, encryptionKeySize, len(key))
+		}
+	}
+	if _, ok := keys[activeKeyID]; !ok {
+		return nil, fmt.Errorf("active key %d isn't one of the keys", activeKeyID)
+	}
+
+	return &aesGCMPayloadEncryptor{
+		keys:        keys,
+		activeKeyID: activeKeyID,
+	}, nil
+}
+
+func (e *aesGCMPayloadEncryptor) Encrypt(plaintext []byte) ([]byte, error) {
+	header := make([]byte, 0, len(encryptedPayloadMagic)+5)
+	header = append(header, encryptedPayloadMagic...)
+	header = append(header, encryptionSchemeAESGCM)
+	header = binary.BigEndian.AppendUint32(header, e.activeKeyID)
+
+	return sealPayload(e.keys[e.activeKeyID], header, plaintext)
+}
+
+func (e *aesGCMPayloadEncryptor) Decrypt(ciphertext []byte) ([]byte, error) {
+	headerSize := len(encryptedPayloadMagic) + 5
+	err := checkEncryptedPayloadHeader(ciphertext, encryptionSchemeAESGCM, headerSize)
+	if err != nil {
+		return nil, err
+	}
+
+	keyID := binary.BigEndian.Uint32(ciphertext[len(encryptedPayloadMagic)+1:])
+	key, ok := e.keys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("payload is encrypted with unknown key %d", keyID)
+	}
+
+	return openPayload(key, ciphertext[:headerSize], ciphertext[headerSize:])
+}
+
+// x25519PayloadEncryptor encrypts payloads to a set of X25519 recipients, in the style of age: each payload is
+// encrypted with a random file key, which is wrapped for each recipient with a key derived from an X25519 key exchange
+type x25519PayloadEncryptor struct {
+	recipients []*ecdh.PublicKey
+	identity   *ecdh.PrivateKey
+}
+
+var _ PayloadEncryptor = &x25519PayloadEncryptor{}
+
+// NewX25519PayloadEncryptor creates a PayloadEncryptor which encrypts payloads so that any of the recipients can
+// decrypt them, and decrypts payloads encrypted to identity. identity may be nil if the PayloadEncryptor is only used
+// for encryption, and recipients may be empty if it is only used for decryption. A payload isn't decryptable by the
+// sender unless the public key of the sender's identity is one of the recipients.
+//
+// Key pairs can be generated with ecdh.X25519().GenerateKey.
+func NewX25519PayloadEncryptor(recipients []*ecdh.PublicKey, identity *ecdh.PrivateKey) (PayloadEncryptor, error) {
+	if len(recipients) == 0 && identity == nil {
+		return nil, errors.New("at least one recipient or an identity must be provided")
+	}
+	if len(recipients) > 0xFFFF {
+		return nil, fmt.Errorf("too many recipients: %d", len(recipients))
+	}
+	for i, recipient := range recipients {
+		if recipient.Curve() != ecdh.X25519() {
+			return nil, fmt.Errorf("recipient %d isn't an X25519 public key", i)
+		}
+	}
+	if identity != nil && identity.Curve() != ecdh.X25519() {
+		return nil, errors.New("identity isn't an X25519 private key")
+	}
+
+	return &x25519PayloadEncryptor{
+		recipients: recipients,
+		identity:   identity,
+	}, nil
+}
+
+func (e *x25519PayloadEncryptor) Encrypt(plaintext []byte) ([]byte, error) {
+	if len(e.recipients) == 0 {
+		return nil, errors.New("no recipients to encrypt to")
+	}
+
+	fileKey := make([]byte, encryptionKeySize)
+	_, err := io.ReadFull(rand.Reader, fileKey)
+	if err != nil {
+		return nil, fmt.Errorf("generate file key: %w", err)
+	}
+
+	header := make([]byte, 0, len(encryptedPayloadMagic)+3+len(e.recipients)*x25519StanzaSize)
+	header = append(header, encryptedPayloadMagic...)
+	header = append(header, encryptionSchemeX25519)
+	header = binary.BigEndian.AppendUint16(header, uint16(len(e.recipients)))
+
+	for i, recipient := range e.recipients {
+		ephemeral, err := ecdh.X25519().GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, fmt.Errorf("generate ephemeral key: %w", err)
+		}
+		wrapKey, err := x25519WrapKey(ephemeral, recipient, ephemeral.PublicKey(), recipient)
+		if err != nil {
+			return nil, fmt.Errorf("derive wrap key for recipient %d: %w", i, err)
+		}
+		aead, err := newPayloadAEAD(wrapKey)
+		if err != nil {
+			return nil, err
+		}
+
+		// each wrap key is used once, so a zero nonce is safe
+		header = append(header, ephemeral.PublicKey().Bytes()...)
+		header = aead.Seal(header, make([]byte, encryptionNonceSize), fileKey, nil)
+	}
+
+	return sealPayload(fileKey, header, plaintext)
+}
+
+func (e *x25519PayloadEncryptor) Decrypt(ciphertext []byte) ([]byte, error) {
+	if e.identity == nil {
+		return nil, errors.New("no identity to decrypt with")
+	}
+
+	prefixSize := len(encryptedPayloadMagic) + 3
+	err := checkEncryptedPayloadHeader(ciphertext, encryptionSchemeX25519, prefixSize)
+	if err != nil {
+		return nil, err
+	}
+	recipientCount := int(binary.BigEndian.Uint16(ciphertext[len(encryptedPayloadMagic)+1:]))
+	headerSize := prefixSize + recipientCount*x25519StanzaSize
+	if len(ciphertext) < headerSize {
+		return nil, errors.New("encrypted payload is truncated in recipient stanzas")
+	}
+
+	// like age, try each stanza, since the stanzas don't identify their recipients
+	for i := 0; i < recipientCount; i++ {
+		stanza := ciphertext[prefixSize+i*x25519StanzaSize : prefixSize+(i+1)*x25519StanzaSize]
+		ephemeral, err := ecdh.X25519().NewPublicKey(stanza[:32])
+		if err != nil {
+			continue
+		}
+		wrapKey, err := x25519WrapKey(e.identity, ephemeral, ephemeral, e.identity.PublicKey())
+		if err != nil {
+			continue
+		}
+		aead, err := newPayloadAEAD(wrapKey)
+		if err != nil {
+			return nil, err
+		}
+		fileKey, err := aead.Open(nil, make([]byte, encryptionNonceSize), stanza[32:], nil)
+		if err != nil {
+			continue
+		}
+
+		return openPayload(fileKey, ciphertext[:headerSize], ciphertext[headerSize:])
+	}
+
+	return nil, errors.New("payload isn't encrypted to this identity")
+}
+
+// x25519WrapKey derives the key which wraps the file key for a recipient from the X25519 shared secret of the ephemeral
+// key and the recipient's key. The public keys of both are bound into the derived key.
+func x25519WrapKey(
+	privateKey *ecdh.PrivateKey,
+	publicKey *ecdh.PublicKey,
+	ephemeral *ecdh.PublicKey,
+	recipient *ecdh.PublicKey,
+) ([]byte, error) {
+	sharedSecret, err := privateKey.ECDH(publicKey)
+	if err != nil {
+		return nil, err
+	}
+	salt := append(append([]byte{}, ephemeral.Bytes()...), recipient.Bytes()...)
+
+	wrapKey := make([]byte, encryptionKeySize)
+	_, err = io.ReadFull(hkdf.New(sha256.New, sharedSecret, salt, []byte(x25519WrapInfo)), wrapKey)
+	if err != nil {
+		return nil, err
+	}
+	return wrapKey, nil
+}
+
+// checkEncryptedPayloadHeader checks that data starts with the magic and the given scheme, and is at least minSize
+// bytes long
+func checkEncryptedPayloadHeader(data []byte, scheme byte, minSize int) error {
+	if len(data) < len(encryptedPayloadMagic)+1 || !bytes.Equal(data[:len(encryptedPayloadMagic)], encryptedPayloadMagic) {
+		return errors.New("payload isn't encrypted")
+	}
+	if data[len(encryptedPayloadMagic)] != scheme {
+		return fmt.Errorf("payload is encrypted with scheme %d, expected scheme %d",
+			data[len(encryptedPayloadMagic)], scheme)
+	}
+	if len(data) < minSize {
+		return errors.New("encrypted payload header is truncated")
+	}
+	return nil
+}
+
+// sealPayload encrypts plaintext with key, and returns header, followed by the nonce and the ciphertext. The header is
+// authenticated along with the ciphertext.
+func sealPayload(key []byte, header []byte, plaintext []byte) ([]byte, error) {
+	aead, err := newPayloadAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, encryptionNonceSize)
+	_, err = io.ReadFull(rand.Reader, nonce)
+	if err != nil {
+		return nil, fmt.Errorf("generate nonce: %w", err)
+	}
+
+	output := make([]byte, 0, len(header)+encryptionNonceSize+len(plaintext)+aead.Overhead())
+	output = append(output, header...)
+	output = append(output, nonce...)
+	return aead.Seal(output, nonce, plaintext, header), nil
+}
+
+// openPayload decrypts data, made of a nonce followed by the ciphertext, which was sealed with key and header
+func openPayload(key []byte, header []byte, data []byte) ([]byte, error) {
+	if len(data) < encryptionNonceSize {
+		return nil, errors.New("encrypted payload is truncated in nonce")
+	}
+
+	aead, err := newPayloadAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+
+	plaintext, err := aead.Open(nil, data[:encryptionNonceSize], data[encryptionNonceSize:], header)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt payload: %w", err)
+	}
+	return plaintext, nil
+}
+
+func newPayloadAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("create cipher: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("create GCM: %w", err)
+	}
+	return aead, nil
+}
@@ -0,0 +1,110 @@
+package clients
+
+import (
+	"bytes"
+	"crypto/ecdh"
+	"crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func randomEncryptionKey(t *testing.T) []byte {
+	key := make([]byte, encryptionKeySize)
+	_, err := rand.Read(key)
+	require.NoError(t, err)
+	return key
+}
+
+func TestAESGCMPayloadEncryptor(t *testing.T) {
+	oldKey := randomEncryptionKey(t)
+	newKey := randomEncryptionKey(t)
+	plaintext := []byte("confidential payload")
+
+	oldEncryptor, err := NewAESGCMPayloadEncryptor(map[uint32][]byte{1: oldKey}, 1)
+	require.NoError(t, err)
+	oldCiphertext, err := oldEncryptor.Encrypt(plaintext)
+	require.NoError(t, err)
+	require.False(t, bytes.Contains(oldCiphertext, plaintext))
+
+	// after rotation, payloads encrypted with the old key can still be decrypted
+	encryptor, err := NewAESGCMPayloadEncryptor(map[uint32][]byte{1: oldKey, 2: newKey}, 2)
+	require.NoError(t, err)
+	decrypted, err := encryptor.Decrypt(oldCiphertext)
+	require.NoError(t, err)
+	require.Equal(t, plaintext, decrypted)
+
+	ciphertext, err := encryptor.Encrypt(plaintext)
+	require.NoError(t, err)
+	decrypted, err = encryptor.Decrypt(ciphertext)
+	require.NoError(t, err)
+	require.Equal(t, plaintext, decrypted)
+
+	// the old encryptor doesn't know the new key
+	_, err = oldEncryptor.Decrypt(ciphertext)
+	require.Error(t, err)
+
+	// tampering with the ciphertext or the header is detected
+	tampered := bytes.Clone(ciphertext)
+	tampered[len(tampered)-1] ^= 1
+	_, err = encryptor.Decrypt(tampered)
+	require.Error(t, err)
+	tampered = bytes.Clone(oldCiphertext)
+	tampered[len(encryptedPayloadMagic)+4] = 2
+	_, err = encryptor.Decrypt(tampered)
+	require.Error(t, err)
+
+	// plaintext payloads are rejected
+	_, err = encryptor.Decrypt(plaintext)
+	require.Error(t, err)
+
+	// invalid keys are rejected
+	_, err = NewAESGCMPayloadEncryptor(map[uint32][]byte{1: oldKey[:16]}, 1)
+	require.Error(t, err)
+	_, err = NewAESGCMPayloadEncryptor(map[uint32][]byte{1: oldKey}, 2)
+	require.Error(t, err)
+}
+
+func TestX25519PayloadEncryptor(t *testing.T) {
+	alice, err := ecdh.X25519().GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	bob, err := ecdh.X25519().GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	eve, err := ecdh.X25519().GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	plaintext := []byte("confidential payload")
+
+	sender, err := NewX25519PayloadEncryptor([]*ecdh.PublicKey{alice.PublicKey(), bob.PublicKey()}, nil)
+	require.NoError(t, err)
+	ciphertext, err := sender.Encrypt(plaintext)
+	require.NoError(t, err)
+
+	// every recipient can decrypt the payload
+	for _, identity := range []*ecdh.PrivateKey{alice, bob} {
+		receiver, err := NewX25519PayloadEncryptor(nil, identity)
+		require.NoError(t, err)
+		decrypted, err := receiver.Decrypt(ciphertext)
+		require.NoError(t, err)
+		require.Equal(t, plaintext, decrypted)
+	}
+
+	// others can't
+	receiver, err := NewX25519PayloadEncryptor(nil, eve)
+	require.NoError(t, err)
+	_, err = receiver.Decrypt(ciphertext)
+	require.Error(t, err)
+
+	// the sender has no identity to decrypt with
+	_, err = sender.Decrypt(ciphertext)
+	require.Error(t, err)
+
+	// payloads encrypted with another scheme are rejected
+	aesEncryptor, err := NewAESGCMPayloadEncryptor(map[uint32][]byte{0: randomEncryptionKey(t)}, 0)
+	require.NoError(t, err)
+	aesCiphertext, err := aesEncryptor.Encrypt(plaintext)
+	require.NoError(t, err)
+	receiver, err = NewX25519PayloadEncryptor(nil, alice)
+	require.NoError(t, err)
+	_, err = receiver.Decrypt(aesCiphertext)
+	require.Error(t, err)
+}
@@ -1,10 +1,14 @@
 package clients
 
 import (
+	"bytes"
 	"context"
+	"fmt"
+	"io"
 
 	"github.com/Layr-Labs/eigenda/api/clients/v2/coretypes"
 	"github.com/Layr-Labs/eigenda/api/clients/v2/verification"
+	"github.com/Layr-Labs/eigensdk-go/logging"
 )
 
 // PayloadRetriever represents something that knows how to retrieve a payload from some backend using a verification.EigenDACert
@@ -15,3 +19,72 @@ type PayloadRetriever interface {
 	// GetPayload retrieves a payload from some backend, using the provided certificate
 	GetPayload(ctx context.Context, eigenDACert *verification.EigenDACert) (*coretypes.Payload, error)
 }
+
+// StreamingPayloadRetriever is a PayloadRetriever which can also deliver the payload as a stream.
+//
+// The blob must be retrieved in full before any of it is delivered, since the commitment of the cert can only be
+// checked against the entire blob. Decoding the payload from the verified blob, which includes decompressing it for
+// compressing codecs, happens as the stream is read, so consumers of large payloads can start processing the payload
+// without waiting for it to be fully decoded, and without holding the entire decoded payload in memory.
+type StreamingPayloadRetriever interface {
+	PayloadRetriever
+
+	// GetPayloadReader retrieves a payload from some backend, using the provided certificate, and returns a reader of
+	// the payload. The caller must close the returned reader.
+	GetPayloadReader(ctx context.Context, eigenDACert *verification.EigenDACert) (io.ReadCloser, error)
+}
+
+const decodeVerifiedBlobErrorMessage = `Commitment verification was successful, but conversion from blob to payload failed!
+	This is likely a problem with the local configuration, but could potentially indicate
+	malicious dispersed data. It should not be possible for a commitment to verify for an
+	invalid blob!`
+
+// decodeVerifiedBlob decodes a blob which has been verified against the commitment of eigenDACert into its payload,
+// and decrypts the payload if the config has a PayloadEncryptor
+func decodeVerifiedBlob(
+	logger logging.Logger,
+	blob *coretypes.Blob,
+	config *PayloadClientConfig,
+	eigenDACert *verification.EigenDACert,
+) (*coretypes.Payload, error) {
+	payload, err := blob.ToPayload(config.PayloadPolynomialForm)
+	if err != nil {
+		logger.Error(decodeVerifiedBlobErrorMessage, "eigenDACert", eigenDACert, "error", err)
+		return nil, fmt.Errorf("decode blob: %w", err)
+	}
+
+	if config.PayloadEncryptor == nil {
+		return payload, nil
+	}
+	plaintext, err := config.PayloadEncryptor.Decrypt(payload.Serialize())
+	if err != nil {
+		return nil, fmt.Errorf("decrypt payload: %w", err)
+	}
+	return coretypes.NewPayload(plaintext), nil
+}
+
+// decodeVerifiedBlobReader is like decodeVerifiedBlob, but returns a reader of the payload.
+//
+// An encrypted payload can only be authenticated once it is fully decrypted, so if the config has a PayloadEncryptor,
+// the payload is decoded and decrypted in full before the reader is returned.
+func decodeVerifiedBlobReader(
+	logger logging.Logger,
+	blob *coretypes.Blob,
+	config *PayloadClientConfig,
+	eigenDACert *verification.EigenDACert,
+) (io.ReadCloser, error) {
+	if config.PayloadEncryptor != nil {
+		payload, err := decodeVerifiedBlob(logger, blob, config, eigenDACert)
+		if err != nil {
+			return nil, err
+		}
+		return io.NopCloser(bytes.NewReader(payload.Serialize())), nil
+	}
+
+	reader, err := blob.ToPayloadReader(config.PayloadPolynomialForm)
+	if err != nil {
+		logger.Error(decodeVerifiedBlobErrorMessage, "eigenDACert", eigenDACert, "error", err)
+		return nil, fmt.Errorf("decode blob: %w", err)
+	}
+	return reader, nil
+}
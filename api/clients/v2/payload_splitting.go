@@ -0,0 +1,369 @@
+package clients
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/Layr-Labs/eigenda/api/clients/v2/coretypes"
+	"github.com/Layr-Labs/eigenda/api/clients/v2/verification"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+// PayloadSender disperses payloads, and returns their verified certs. It is implemented by PayloadDisperser.
+type PayloadSender interface {
+	// SendPayload disperses a payload, and returns its verified cert
+	SendPayload(
+		ctx context.Context,
+		certVerifierAddress string,
+		payload *coretypes.Payload,
+	) (*verification.EigenDACert, error)
+
+	// SendPayloads disperses a batch of payloads, and returns the result of each payload, in the order of the payloads
+	SendPayloads(ctx context.Context, certVerifierAddress string, payloads []*coretypes.Payload) []PayloadDispersalResult
+}
+
+var _ PayloadSender = &PayloadDisperser{}
+
+// The format of a payload manifest is:
+//
+//	magic (4 bytes) | version (1 byte) | payload size (8 bytes) | part count (4 bytes) | parts | checksum (32 bytes)
+//
+// Each part is made of the blob key of the part (32 bytes), the SHA-256 hash of the payload of the part (32 bytes), the
+// size of the payload of the part (4 bytes), the size of the cert of the part (4 bytes), and the RLP encoded cert of
+// the part. The checksum is the SHA-256 hash of everything before it, so that a payload which happens to start with the
+// magic bytes isn't mistaken for a manifest.
+var payloadManifestMagic = []byte{0xED, 0xA0, 'S', 'M'}
+
+const (
+	payloadManifestVersion    byte = 0x01
+	payloadManifestHeaderSize      = 4 + 1 + 8 + 4
+	payloadManifestPartSize        = 32 + 32 + 4 + 4
+)
+
+// payloadPart describes one of the parts of a split payload
+type payloadPart struct {
+	blobKey corev2.BlobKey
+	hash    [32]byte
+	size    uint32
+	cert    *verification.EigenDACert
+}
+
+// payloadManifest lists, in order, the parts which make up a payload which was too large to be dispersed as one blob
+type payloadManifest struct {
+	payloadSize uint64
+	parts       []payloadPart
+}
+
+// serialize encodes the manifest into the payload which is dispersed in place of the split payload
+func (m *payloadManifest) serialize() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.Write(payloadManifestMagic)
+	buffer.WriteByte(payloadManifestVersion)
+	buffer.Write(binary.BigEndian.AppendUint64(nil, m.payloadSize))
+	buffer.Write(binary.BigEndian.AppendUint32(nil, uint32(len(m.parts))))
+
+	for i, part := range m.parts {
+		certBytes, err := part.cert.Serialize(verification.CertSerializationRLP)
+		if err != nil {
+			return nil, fmt.Errorf("encode cert of part %d: %w", i, err)
+		}
+		buffer.Write(part.blobKey[:])
+		buffer.Write(part.hash[:])
+		buffer.Write(binary.BigEndian.AppendUint32(nil, part.size))
+		buffer.Write(binary.BigEndian.AppendUint32(nil, uint32(len(certBytes))))
+		buffer.Write(certBytes)
+	}
+
+	checksum := sha256.Sum256(buffer.Bytes())
+	buffer.Write(checksum[:])
+	return buffer.Bytes(), nil
+}
+
+// parsePayloadManifest decodes a manifest from the bytes of a payload. The second return value is false if the payload
+// isn't a manifest, in which case it is an ordinary payload.
+func parsePayloadManifest(payloadBytes []byte) (*payloadManifest, bool, error) {
+	if len(payloadBytes) < payloadManifestHeaderSize+sha256.Size ||
+		!bytes.Equal(payloadBytes[:len(payloadManifestMagic)], payloadManifestMagic) {
+		return nil, false, nil
+	}
+	body := payloadBytes[:len(payloadBytes)-sha256.Size]
+	checksum := sha256.Sum256(body)
+	if !bytes.Equal(checksum[:], payloadBytes[len(body):]) {
+		return nil, false, nil
+	}
+
+	// the checksum matches, so the payload was written as a manifest, and any error from here on is a malformed manifest
+	if version := body[4]; version != payloadManifestVersion {
+		return nil, true, fmt.Errorf("unsupported payload manifest version %d", version)
+	}
+	manifest := &payloadManifest{payloadSize: binary.BigEndian.Uint64(body[5:13])}
+	partCount := binary.BigEndian.Uint32(body[13:17])
+	body = body[payloadManifestHeaderSize:]
+
+	var totalSize uint64
+	for i := uint32(0); i < partCount; i++ {
+		if len(body) < payloadManifestPartSize {
+			return nil, true, fmt.Errorf("payload manifest is truncated at part %d", i)
+		}
+		var part payloadPart
+		copy(part.blobKey[:], body[:32])
+		copy(part.hash[:], body[32:64])
+		part.size = binary.BigEndian.Uint32(body[64:68])
+		certSize := binary.BigEndian.Uint32(body[68:72])
+		body = body[payloadManifestPartSize:]
+
+		if uint64(len(body)) < uint64(certSize) {
+			return nil, true, fmt.Errorf("payload manifest is truncated in the cert of part %d", i)
+		}
+		cert, err := verification.DeserializeEigenDACert(body[:certSize], verification.CertSerializationRLP)
+		if err != nil {
+			return nil, true, fmt.Errorf("decode cert of part %d: %w", i, err)
+		}
+		part.cert = cert
+		body = body[certSize:]
+
+		totalSize += uint64(part.size)
+		manifest.parts = append(manifest.parts, part)
+	}
+	if len(body) != 0 {
+		return nil, true, fmt.Errorf("payload manifest has %d trailing bytes", len(body))
+	}
+	if totalSize != manifest.payloadSize {
+		return nil, true, fmt.Errorf(
+			"payload manifest parts add up to %d bytes, but the payload size is %d", totalSize, manifest.payloadSize)
+	}
+
+	return manifest, true, nil
+}
+
+// SplittingPayloadDisperser disperses payloads which are larger than the maximum blob size. Payloads larger than
+// MaxPartSize are split into parts, which are dispersed as separate blobs. A manifest listing the blob key, hash and
+// cert of each part, in order, is then dispersed in place of the payload, and the cert of the manifest is returned.
+// Payloads which fit in a single blob are dispersed as they are.
+//
+// Payloads dispersed by a SplittingPayloadDisperser must be retrieved with a ReassemblingPayloadRetriever, which
+// retrieves the parts listed in a manifest and reassembles the payload.
+//
+// This struct is goroutine safe.
+type SplittingPayloadDisperser struct {
+	logger logging.Logger
+	config PayloadSplittingConfig
+	sender PayloadSender
+}
+
+// NewSplittingPayloadDisperser creates a SplittingPayloadDisperser which disperses blobs with sender
+func NewSplittingPayloadDisperser(
+	logger logging.Logger,
+	config PayloadSplittingConfig,
+	sender PayloadSender,
+) (*SplittingPayloadDisperser, error) {
+	err := config.checkAndSetDefaults()
+	if err != nil {
+		return nil, fmt.Errorf("check and set PayloadSplittingConfig defaults: %w", err)
+	}
+
+	return &SplittingPayloadDisperser{
+		logger: logger,
+		config: config,
+		sender: sender,
+	}, nil
+}
+
+// SendPayload disperses a payload of any size, and returns the verified cert of the payload, or of its manifest if the
+// payload had to be split. If any of the parts fails to disperse, no manifest is dispersed and an error is returned.
+func (sd *SplittingPayloadDisperser) SendPayload(
+	ctx context.Context,
+	certVerifierAddress string,
+	payload *coretypes.Payload,
+) (*verification.EigenDACert, error) {
+	payloadBytes := payload.Serialize()
+	if len(payloadBytes) <= sd.config.MaxPartSize {
+		return sd.sender.SendPayload(ctx, certVerifierAddress, payload)
+	}
+
+	var parts []*coretypes.Payload
+	for start := 0; start < len(payloadBytes); start += sd.config.MaxPartSize {
+		end := min(start+sd.config.MaxPartSize, len(payloadBytes))
+		parts = append(parts, coretypes.NewPayload(payloadBytes[start:end]))
+	}
+	sd.logger.Debug("Splitting payload", "size", len(payloadBytes), "parts", len(parts))
+
+	manifest := &payloadManifest{payloadSize: uint64(len(payloadBytes))}
+	results := sd.sender.SendPayloads(ctx, certVerifierAddress, parts)
+	var errs []error
+	for i, result := range results {
+		if result.Err != nil {
+			errs = append(errs, result.Err)
+			continue
+		}
+		blobKey, err := result.Cert.ComputeBlobKey()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("compute blob key of part %d: %w", i, err))
+			continue
+		}
+		partBytes := parts[i].Serialize()
+		manifest.parts = append(manifest.parts, payloadPart{
+			blobKey: *blobKey,
+			hash:    sha256.Sum256(partBytes),
+			size:    uint32(len(partBytes)),
+			cert:    result.Cert,
+		})
+	}
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("disperse %d of %d parts: %w", len(errs), len(parts), errors.Join(errs...))
+	}
+
+	manifestBytes, err := manifest.serialize()
+	if err != nil {
+		return nil, fmt.Errorf("serialize payload manifest: %w", err)
+	}
+	if len(manifestBytes) > sd.config.MaxPartSize {
+		return nil, fmt.Errorf("payload manifest of %d parts is %d bytes, which exceeds the maximum part size of %d",
+			len(parts), len(manifestBytes), sd.config.MaxPartSize)
+	}
+
+	cert, err := sd.sender.SendPayload(ctx, certVerifierAddress, coretypes.NewPayload(manifestBytes))
+	if err != nil {
+		return nil, fmt.Errorf("disperse payload manifest: %w", err)
+	}
+	return cert, nil
+}
+
+// ReassemblingPayloadRetriever wraps a PayloadRetriever, and reassembles payloads which were split into parts by a
+// SplittingPayloadDisperser. If the payload of a cert is a manifest, the parts it lists are retrieved, each part is
+// checked against the blob key and hash recorded in the manifest, and the parts are concatenated into the payload.
+// Other payloads are returned as they are.
+//
+// This struct is goroutine safe.
+type ReassemblingPayloadRetriever struct {
+	logger    logging.Logger
+	config    PayloadSplittingConfig
+	retriever PayloadRetriever
+}
+
+var _ PayloadRetriever = &ReassemblingPayloadRetriever{}
+
+// NewReassemblingPayloadRetriever creates a ReassemblingPayloadRetriever which retrieves manifests and parts with
+// retriever
+func NewReassemblingPayloadRetriever(
+	logger logging.Logger,
+	config PayloadSplittingConfig,
+	retriever PayloadRetriever,
+) (*ReassemblingPayloadRetriever, error) {
+	err := config.checkAndSetDefaults()
+	if err != nil {
+		return nil, fmt.Errorf("check and set PayloadSplittingConfig defaults: %w", err)
+	}
+
+	return &ReassemblingPayloadRetriever{
+		logger:    logger,
+		config:    config,
+		retriever: retriever,
+	}, nil
+}
+
+// GetPayload retrieves the payload of the cert, and reassembles it from its parts if it was split
+func (pr *ReassemblingPayloadRetriever) GetPayload(
+	ctx context.Context,
+	eigenDACert *verification.EigenDACert,
+) (*coretypes.Payload, error) {
+	payload, err := pr.retriever.GetPayload(ctx, eigenDACert)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest, isManifest, err := parsePayloadManifest(payload.Serialize())
+	if err != nil {
+		return nil, fmt.Errorf("parse payload manifest: %w", err)
+	}
+	if !isManifest {
+		return payload, nil
+	}
+	if manifest.payloadSize > pr.config.MaxPayloadSize {
+		return nil, fmt.Errorf("payload manifest describes a payload of %d bytes, which exceeds the maximum of %d",
+			manifest.payloadSize, pr.config.MaxPayloadSize)
+	}
+	pr.logger.Debug("Reassembling payload", "size", manifest.payloadSize, "parts", len(manifest.parts))
+
+	return pr.reassemble(ctx, manifest)
+}
+
+// reassemble retrieves the parts of the manifest, with at most MaxConcurrentRetrievals retrievals in flight, and
+// concatenates them
+func (pr *ReassemblingPayloadRetriever) reassemble(
+	ctx context.Context,
+	manifest *payloadManifest,
+) (*coretypes.Payload, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	partPayloads := make([][]byte, len(manifest.parts))
+	errs := make([]error, len(manifest.parts))
+	semaphore := make(chan struct{}, pr.config.MaxConcurrentRetrievals)
+	var waitGroup sync.WaitGroup
+
+	for i := range manifest.parts {
+		semaphore <- struct{}{}
+		if ctx.Err() != nil {
+			// a part has already failed, or the caller gave up
+			<-semaphore
+			errs[i] = fmt.Errorf("part %d not retrieved: %w", i, ctx.Err())
+			break
+		}
+		waitGroup.Add(1)
+		go func(i int) {
+			defer func() {
+				<-semaphore
+				waitGroup.Done()
+			}()
+
+			partPayloads[i], errs[i] = pr.retrievePart(ctx, &manifest.parts[i])
+			if errs[i] != nil {
+				errs[i] = fmt.Errorf("part %d: %w", i, errs[i])
+				// the payload can't be reassembled without this part, so the other retrievals are abandoned
+				cancel()
+			}
+		}(i)
+	}
+	waitGroup.Wait()
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, fmt.Errorf("retrieve parts: %w", err)
+	}
+
+	payloadBytes := make([]byte, 0, manifest.payloadSize)
+	for _, partPayload := range partPayloads {
+		payloadBytes = append(payloadBytes, partPayload...)
+	}
+	return coretypes.NewPayload(payloadBytes), nil
+}
+
+// retrievePart retrieves the payload of a part, and checks it against the blob key and hash of the manifest
+func (pr *ReassemblingPayloadRetriever) retrievePart(ctx context.Context, part *payloadPart) ([]byte, error) {
+	blobKey, err := part.cert.ComputeBlobKey()
+	if err != nil {
+		return nil, fmt.Errorf("compute blob key: %w", err)
+	}
+	if *blobKey != part.blobKey {
+		return nil, fmt.Errorf("cert is for blob %s, but the manifest lists blob %s", blobKey.Hex(), part.blobKey.Hex())
+	}
+
+	payload, err := pr.retriever.GetPayload(ctx, part.cert)
+	if err != nil {
+		return nil, fmt.Errorf("get payload of blob %s: %w", blobKey.Hex(), err)
+	}
+	partBytes := payload.Serialize()
+	if uint64(len(partBytes)) != uint64(part.size) {
+		return nil, fmt.Errorf("blob %s has a payload of %d bytes, expected %d", blobKey.Hex(), len(partBytes), part.size)
+	}
+	if sha256.Sum256(partBytes) != part.hash {
+		return nil, fmt.Errorf("payload of blob %s doesn't match the hash in the manifest", blobKey.Hex())
+	}
+	return partBytes, nil
+}
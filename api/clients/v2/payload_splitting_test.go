@@ -0,0 +1,195 @@
+package clients
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/api/clients/v2/coretypes"
+	"github.com/Layr-Labs/eigenda/api/clients/v2/verification"
+	"github.com/Layr-Labs/eigenda/common"
+	contractEigenDACertVerifier "github.com/Layr-Labs/eigenda/contracts/bindings/EigenDACertVerifier"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/stretchr/testify/require"
+)
+
+// fakePayloadStore disperses payloads by storing them under the blob key of a cert which commits to their hash, and
+// retrieves them by blob key
+type fakePayloadStore struct {
+	lock     sync.Mutex
+	payloads map[corev2.BlobKey][]byte
+	// failPayloadSize makes the dispersal of payloads of this size fail
+	failPayloadSize int
+}
+
+func newFakePayloadStore() *fakePayloadStore {
+	return &fakePayloadStore{payloads: make(map[corev2.BlobKey][]byte)}
+}
+
+func (s *fakePayloadStore) SendPayload(
+	_ context.Context,
+	_ string,
+	payload *coretypes.Payload,
+) (*verification.EigenDACert, error) {
+	payloadBytes := payload.Serialize()
+	if len(payloadBytes) == s.failPayloadSize {
+		return nil, errors.New("dispersal failed")
+	}
+
+	_, _, g1Gen, g2Gen := bn254.Generators()
+	cert := &verification.EigenDACert{}
+	blobHeader := &cert.BlobInclusionInfo.BlobCertificate.BlobHeader
+	blobHeader.QuorumNumbers = []byte{0, 1}
+	blobHeader.PaymentHeaderHash = sha256.Sum256(payloadBytes)
+	blobHeader.Commitment = contractEigenDACertVerifier.BlobCommitment{
+		Commitment: contractEigenDACertVerifier.BN254G1Point{
+			X: g1Gen.X.BigInt(new(big.Int)),
+			Y: g1Gen.Y.BigInt(new(big.Int)),
+		},
+		LengthCommitment: g2PointBinding(g2Gen),
+		LengthProof:      g2PointBinding(g2Gen),
+		Length:           16,
+	}
+	blobKey, err := cert.ComputeBlobKey()
+	if err != nil {
+		return nil, err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.payloads[*blobKey] = payloadBytes
+	return cert, nil
+}
+
+func (s *fakePayloadStore) SendPayloads(
+	ctx context.Context,
+	certVerifierAddress string,
+	payloads []*coretypes.Payload,
+) []PayloadDispersalResult {
+	return sendConcurrently(ctx, payloads, 2,
+		func(ctx context.Context, payload *coretypes.Payload) (*verification.EigenDACert, error) {
+			return s.SendPayload(ctx, certVerifierAddress, payload)
+		})
+}
+
+func (s *fakePayloadStore) GetPayload(
+	_ context.Context,
+	eigenDACert *verification.EigenDACert,
+) (*coretypes.Payload, error) {
+	blobKey, err := eigenDACert.ComputeBlobKey()
+	if err != nil {
+		return nil, err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	payloadBytes, ok := s.payloads[*blobKey]
+	if !ok {
+		return nil, fmt.Errorf("blob %s not found", blobKey.Hex())
+	}
+	return coretypes.NewPayload(payloadBytes), nil
+}
+
+func g2PointBinding(point bn254.G2Affine) contractEigenDACertVerifier.BN254G2Point {
+	return contractEigenDACertVerifier.BN254G2Point{
+		X: [2]*big.Int{point.X.A1.BigInt(new(big.Int)), point.X.A0.BigInt(new(big.Int))},
+		Y: [2]*big.Int{point.Y.A1.BigInt(new(big.Int)), point.Y.A0.BigInt(new(big.Int))},
+	}
+}
+
+func TestPayloadSplitting(t *testing.T) {
+	ctx := context.Background()
+	logger, err := common.NewLogger(common.DefaultLoggerConfig())
+	require.NoError(t, err)
+
+	store := newFakePayloadStore()
+	config := PayloadSplittingConfig{MaxPartSize: 2500, MaxConcurrentRetrievals: 2}
+	disperser, err := NewSplittingPayloadDisperser(logger, config, store)
+	require.NoError(t, err)
+	retriever, err := NewReassemblingPayloadRetriever(logger, config, store)
+	require.NoError(t, err)
+
+	payloadBytes := make([]byte, 8000)
+	for i := range payloadBytes {
+		payloadBytes[i] = byte(i)
+	}
+
+	// small payloads are dispersed as they are
+	cert, err := disperser.SendPayload(ctx, "", coretypes.NewPayload(payloadBytes[:2000]))
+	require.NoError(t, err)
+	require.Len(t, store.payloads, 1)
+	payload, err := retriever.GetPayload(ctx, cert)
+	require.NoError(t, err)
+	require.Equal(t, payloadBytes[:2000], payload.Serialize())
+
+	// large payloads are split into 4 parts, plus the manifest
+	cert, err = disperser.SendPayload(ctx, "", coretypes.NewPayload(payloadBytes))
+	require.NoError(t, err)
+	require.Len(t, store.payloads, 6)
+	payload, err = retriever.GetPayload(ctx, cert)
+	require.NoError(t, err)
+	require.Equal(t, payloadBytes, payload.Serialize())
+
+	// the dispersal fails if a part fails
+	store.failPayloadSize = 500
+	_, err = disperser.SendPayload(ctx, "", coretypes.NewPayload(payloadBytes))
+	require.ErrorContains(t, err, "disperse 1 of 4 parts")
+
+	// parts which don't match the manifest are rejected
+	manifestPayload, err := store.GetPayload(ctx, cert)
+	require.NoError(t, err)
+	manifest, isManifest, err := parsePayloadManifest(manifestPayload.Serialize())
+	require.NoError(t, err)
+	require.True(t, isManifest)
+	store.payloads[manifest.parts[2].blobKey] = make([]byte, 2500)
+	_, err = retriever.GetPayload(ctx, cert)
+	require.ErrorContains(t, err, "doesn't match the hash in the manifest")
+
+	// manifests of payloads which are too large are rejected
+	config.MaxPayloadSize = 3000
+	retriever, err = NewReassemblingPayloadRetriever(logger, config, store)
+	require.NoError(t, err)
+	_, err = retriever.GetPayload(ctx, cert)
+	require.ErrorContains(t, err, "exceeds the maximum")
+}
+
+func TestParsePayloadManifest(t *testing.T) {
+	store := newFakePayloadStore()
+	partCert, err := store.SendPayload(context.Background(), "", coretypes.NewPayload([]byte{1, 2, 3}))
+	require.NoError(t, err)
+	blobKey, err := partCert.ComputeBlobKey()
+	require.NoError(t, err)
+
+	manifest := &payloadManifest{
+		payloadSize: 3,
+		parts: []payloadPart{
+			{blobKey: *blobKey, hash: sha256.Sum256([]byte{1, 2, 3}), size: 3, cert: partCert},
+		},
+	}
+	manifestBytes, err := manifest.serialize()
+	require.NoError(t, err)
+
+	parsed, isManifest, err := parsePayloadManifest(manifestBytes)
+	require.NoError(t, err)
+	require.True(t, isManifest)
+	require.Equal(t, manifest.payloadSize, parsed.payloadSize)
+	require.Len(t, parsed.parts, 1)
+	require.Equal(t, manifest.parts[0].hash, parsed.parts[0].hash)
+	parsedBlobKey, err := parsed.parts[0].cert.ComputeBlobKey()
+	require.NoError(t, err)
+	require.Equal(t, blobKey, parsedBlobKey)
+
+	// payloads which start with the magic bytes, but don't have a valid checksum, are ordinary payloads
+	manifestBytes[len(manifestBytes)-1] ^= 1
+	_, isManifest, err = parsePayloadManifest(manifestBytes)
+	require.NoError(t, err)
+	require.False(t, isManifest)
+	_, isManifest, err = parsePayloadManifest([]byte{1, 2, 3})
+	require.NoError(t, err)
+	require.False(t, isManifest)
+}
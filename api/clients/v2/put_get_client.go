@@ -0,0 +1,112 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Layr-Labs/eigenda/api/clients/v2/coretypes"
+	"github.com/Layr-Labs/eigenda/api/clients/v2/verification"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+// quorumPayloadDisperser is the part of PayloadDisperser used by PutGetClient
+type quorumPayloadDisperser interface {
+	SendPayloadToQuorums(
+		ctx context.Context,
+		certVerifierAddress string,
+		payload *coretypes.Payload,
+		additionalQuorums []uint8,
+	) (*verification.EigenDACert, error)
+	QuotePayloadToQuorums(
+		ctx context.Context,
+		certVerifierAddress string,
+		payload *coretypes.Payload,
+		additionalQuorums []uint8,
+	) (*PaymentQuote, error)
+}
+
+var _ quorumPayloadDisperser = &PayloadDisperser{}
+
+// PutGetClient is a high level client, which stores data on EigenDA with Put, and reads it back with Get.
+//
+// Put chooses the quorums of each payload according to the configured QuorumProfile, and disperses the payload with a
+// PayloadDisperser, which pays for the blob with the reservation of the account if it has capacity, and on-demand
+// otherwise, waits until the blob is certified, and returns a cert which has been verified against the cert verifier.
+// Get retrieves the payload of a cert with a PayloadRetriever, which verifies the blob against the commitment of the
+// cert before decoding it.
+//
+// This struct is goroutine safe.
+type PutGetClient struct {
+	logger           logging.Logger
+	config           PutGetClientConfig
+	payloadDisperser quorumPayloadDisperser
+	payloadRetriever PayloadRetriever
+}
+
+// NewPutGetClient creates a PutGetClient from subcomponents that have already been constructed and initialized. The
+// payloadRetriever is typically a FallbackPayloadRetriever.
+func NewPutGetClient(
+	logger logging.Logger,
+	config PutGetClientConfig,
+	payloadDisperser *PayloadDisperser,
+	payloadRetriever PayloadRetriever,
+) (*PutGetClient, error) {
+	err := config.checkAndSetDefaults()
+	if err != nil {
+		return nil, fmt.Errorf("check and set PutGetClientConfig defaults: %w", err)
+	}
+
+	return &PutGetClient{
+		logger:           logger,
+		config:           config,
+		payloadDisperser: payloadDisperser,
+		payloadRetriever: payloadRetriever,
+	}, nil
+}
+
+// Put stores data on EigenDA, and returns the verified cert of the blob which holds it
+func (c *PutGetClient) Put(ctx context.Context, data []byte) (*verification.EigenDACert, error) {
+	payload := coretypes.NewPayload(data)
+	additionalQuorums := c.selectAdditionalQuorums(ctx, payload)
+
+	eigenDACert, err := c.payloadDisperser.SendPayloadToQuorums(
+		ctx, c.config.CertVerifierAddress, payload, additionalQuorums)
+	if err != nil {
+		return nil, fmt.Errorf("send payload: %w", err)
+	}
+	return eigenDACert, nil
+}
+
+// Get retrieves the data stored by Put for the cert
+func (c *PutGetClient) Get(ctx context.Context, eigenDACert *verification.EigenDACert) ([]byte, error) {
+	payload, err := c.payloadRetriever.GetPayload(ctx, eigenDACert)
+	if err != nil {
+		return nil, fmt.Errorf("get payload: %w", err)
+	}
+	return payload.Serialize(), nil
+}
+
+// selectAdditionalQuorums returns the quorums to disperse the payload to on top of the required quorums.
+//
+// With QuorumProfileSecurity, the additional quorums of the config are used if the reservation of the account can
+// currently pay for the payload, since on-demand payments only cover the required quorums. Otherwise, the payload is
+// dispersed to the required quorums only, rather than being held back until the reservation has capacity.
+func (c *PutGetClient) selectAdditionalQuorums(ctx context.Context, payload *coretypes.Payload) []uint8 {
+	if c.config.QuorumProfile != QuorumProfileSecurity || len(c.config.AdditionalQuorums) == 0 {
+		return nil
+	}
+
+	quote, err := c.payloadDisperser.QuotePayloadToQuorums(
+		ctx, c.config.CertVerifierAddress, payload, c.config.AdditionalQuorums)
+	if err != nil {
+		c.logger.Warn("Payload can't be dispersed to the additional quorums, dispersing to the required quorums only",
+			"additionalQuorums", c.config.AdditionalQuorums, "error", err)
+		return nil
+	}
+	if quote.PaymentMethod != PaymentMethodReservation {
+		c.logger.Warn("Reservation can't pay for the payload, dispersing to the required quorums only",
+			"additionalQuorums", c.config.AdditionalQuorums, "paymentMethod", quote.PaymentMethod)
+		return nil
+	}
+	return c.config.AdditionalQuorums
+}
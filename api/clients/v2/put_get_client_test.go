@@ -0,0 +1,105 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/api/clients/v2/coretypes"
+	"github.com/Layr-Labs/eigenda/api/clients/v2/verification"
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeQuorumDisperser stores payloads in a fakePayloadStore, and records the additional quorums of each dispersal
+type fakeQuorumDisperser struct {
+	store *fakePayloadStore
+	// paymentMethod is the payment method quoted for dispersals to additional quorums
+	paymentMethod PaymentMethod
+	// quoteErr is returned by quotes, if set
+	quoteErr error
+
+	additionalQuorums [][]uint8
+}
+
+func (d *fakeQuorumDisperser) SendPayloadToQuorums(
+	ctx context.Context,
+	certVerifierAddress string,
+	payload *coretypes.Payload,
+	additionalQuorums []uint8,
+) (*verification.EigenDACert, error) {
+	d.additionalQuorums = append(d.additionalQuorums, additionalQuorums)
+	return d.store.SendPayload(ctx, certVerifierAddress, payload)
+}
+
+func (d *fakeQuorumDisperser) QuotePayloadToQuorums(
+	_ context.Context,
+	_ string,
+	_ *coretypes.Payload,
+	_ []uint8,
+) (*PaymentQuote, error) {
+	if d.quoteErr != nil {
+		return nil, d.quoteErr
+	}
+	return &PaymentQuote{PaymentMethod: d.paymentMethod}, nil
+}
+
+func TestPutGetClient(t *testing.T) {
+	ctx := context.Background()
+	logger, err := common.NewLogger(common.DefaultLoggerConfig())
+	require.NoError(t, err)
+
+	store := newFakePayloadStore()
+	disperser := &fakeQuorumDisperser{store: store, paymentMethod: PaymentMethodReservation}
+	config := PutGetClientConfig{
+		CertVerifierAddress: "0x1",
+		QuorumProfile:       QuorumProfileSecurity,
+		AdditionalQuorums:   []uint8{2},
+	}
+	require.NoError(t, config.checkAndSetDefaults())
+	client := &PutGetClient{
+		logger:           logger,
+		config:           config,
+		payloadDisperser: disperser,
+		payloadRetriever: store,
+	}
+
+	data := []byte("hello eigenda")
+	cert, err := client.Put(ctx, data)
+	require.NoError(t, err)
+	retrieved, err := client.Get(ctx, cert)
+	require.NoError(t, err)
+	require.Equal(t, data, retrieved)
+	require.Equal(t, []uint8{2}, disperser.additionalQuorums[0])
+
+	// the additional quorums are dropped if the reservation can't pay for the payload
+	disperser.paymentMethod = PaymentMethodOnDemand
+	_, err = client.Put(ctx, []byte("on-demand"))
+	require.NoError(t, err)
+	require.Nil(t, disperser.additionalQuorums[1])
+
+	disperser.quoteErr = errors.New("quorum 2 not in reservation")
+	_, err = client.Put(ctx, []byte("not covered"))
+	require.NoError(t, err)
+	require.Nil(t, disperser.additionalQuorums[2])
+
+	// the cost profile never disperses to additional quorums
+	disperser.paymentMethod = PaymentMethodReservation
+	disperser.quoteErr = nil
+	client.config.QuorumProfile = QuorumProfileCost
+	_, err = client.Put(ctx, []byte("cost"))
+	require.NoError(t, err)
+	require.Nil(t, disperser.additionalQuorums[3])
+}
+
+func TestPutGetClientConfig(t *testing.T) {
+	config := PutGetClientConfig{}
+	require.Error(t, config.checkAndSetDefaults())
+
+	config = PutGetClientConfig{CertVerifierAddress: "0x1", QuorumProfile: 5}
+	require.Error(t, config.checkAndSetDefaults())
+
+	config = PutGetClientConfig{CertVerifierAddress: "0x1"}
+	require.NoError(t, config.checkAndSetDefaults())
+	require.Equal(t, QuorumProfileCost, config.QuorumProfile)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"sync"
 
 	"github.com/Layr-Labs/eigenda/api/clients/v2/relay"
@@ -14,6 +15,8 @@ import (
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	"github.com/hashicorp/go-multierror"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // MessageSigner is a function that signs a message with a private BLS key.
@@ -24,6 +27,12 @@ type RelayClientConfig struct {
 	MaxGRPCMessageSize uint
 	OperatorID         *core.OperatorID
 	MessageSigner      MessageSigner
+	// Interceptors are installed on the connections to the relays. If nil, no interceptors are installed.
+	Interceptors *GrpcInterceptors
+	// StreamBlobs makes GetBlob retrieve blobs with GetBlobStream, which sends them in parts, so that
+	// MaxGRPCMessageSize doesn't need to be larger than the largest blob. Relays which don't support GetBlobStream
+	// are sent unary GetBlob requests.
+	StreamBlobs bool
 }
 
 type ChunkRequestByRange struct {
@@ -106,9 +115,19 @@ func (c *relayClient) GetBlob(ctx context.Context, relayKey corev2.RelayKey, blo
 		return nil, fmt.Errorf("get grpc client for key %d: %w", relayKey, err)
 	}
 
-	res, err := client.GetBlob(ctx, &relaygrpc.GetBlobRequest{
+	request := &relaygrpc.GetBlobRequest{
 		BlobKey: blobKey[:],
-	})
+	}
+
+	if c.config.StreamBlobs {
+		blob, err := c.getBlobStream(ctx, client, request)
+		if status.Code(err) != codes.Unimplemented {
+			return blob, err
+		}
+		c.logger.Debug("relay doesn't support GetBlobStream, falling back to GetBlob", "relayKey", relayKey)
+	}
+
+	res, err := client.GetBlob(ctx, request)
 	if err != nil {
 		return nil, err
 	}
@@ -116,6 +135,48 @@ func (c *relayClient) GetBlob(ctx context.Context, relayKey corev2.RelayKey, blo
 	return res.GetBlob(), nil
 }
 
+// getBlobStream retrieves a blob with GetBlobStream, and reassembles the parts in which the relay sends it.
+func (c *relayClient) getBlobStream(
+	ctx context.Context,
+	client relaygrpc.RelayClient,
+	request *relaygrpc.GetBlobRequest) ([]byte, error) {
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := client.GetBlobStream(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	var blob []byte
+	for first := true; ; first = false {
+		part, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			if first {
+				return nil, errors.New("relay closed blob stream without sending any part")
+			}
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if first {
+			blob = make([]byte, 0, part.GetBlobSize())
+		}
+		if len(blob)+len(part.GetData()) > cap(blob) {
+			return nil, fmt.Errorf("relay sent more than the %d bytes of the blob", cap(blob))
+		}
+		blob = append(blob, part.GetData()...)
+	}
+
+	if len(blob) != cap(blob) {
+		return nil, fmt.Errorf("relay sent %d bytes of a blob of %d bytes", len(blob), cap(blob))
+	}
+	return blob, nil
+}
+
 // signGetChunksRequest signs the GetChunksRequest with the operator's private key
 // and sets the signature in the request.
 func (c *relayClient) signGetChunksRequest(ctx context.Context, request *relaygrpc.GetChunksRequest) error {
@@ -268,7 +329,7 @@ func (c *relayClient) initOnceGrpcConnection(ctx context.Context, key corev2.Rel
 		return fmt.Errorf("get relay url for key %d: %w", key, err)
 	}
 
-	dialOptions := getGrpcDialOptions(c.config.UseSecureGrpcFlag, c.config.MaxGRPCMessageSize)
+	dialOptions := getGrpcDialOptions(c.config.UseSecureGrpcFlag, c.config.MaxGRPCMessageSize, c.config.Interceptors)
 	conn, err := grpc.NewClient(relayUrl, dialOptions...)
 	if err != nil {
 		return fmt.Errorf("create grpc client for key %d: %w", key, err)
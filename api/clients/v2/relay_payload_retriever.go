@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"math/rand"
 
 	"github.com/Layr-Labs/eigenda/api/clients/v2/coretypes"
@@ -28,7 +29,7 @@ type RelayPayloadRetriever struct {
 	g1Srs       []bn254.G1Affine
 }
 
-var _ PayloadRetriever = &RelayPayloadRetriever{}
+var _ StreamingPayloadRetriever = &RelayPayloadRetriever{}
 
 // BuildRelayPayloadRetriever builds a RelayPayloadRetriever from config structs.
 func BuildRelayPayloadRetriever(
@@ -90,7 +91,49 @@ func NewRelayPayloadRetriever(
 // verified prior to calling this method.
 func (pr *RelayPayloadRetriever) GetPayload(
 	ctx context.Context,
-	eigenDACert *verification.EigenDACert) (*coretypes.Payload, error) {
+	eigenDACert *verification.EigenDACert,
+) (*coretypes.Payload, error) {
+	return invokeWithMetrics(pr.config.Metrics, "GetPayloadFromRelays", func() (*coretypes.Payload, error) {
+		return pr.getPayload(ctx, eigenDACert)
+	})
+}
+
+// GetPayloadReader is like GetPayload, but returns a reader of the payload, which decodes the payload as it is read.
+// The blob is fully retrieved and verified against the certificate before the reader is returned, so the reader never
+// yields data which doesn't match the certificate.
+//
+// The caller must close the returned reader.
+func (pr *RelayPayloadRetriever) GetPayloadReader(
+	ctx context.Context,
+	eigenDACert *verification.EigenDACert,
+) (io.ReadCloser, error) {
+	return invokeWithMetrics(pr.config.Metrics, "GetPayloadReaderFromRelays", func() (io.ReadCloser, error) {
+		blob, err := pr.getVerifiedBlob(ctx, eigenDACert)
+		if err != nil {
+			return nil, err
+		}
+		return decodeVerifiedBlobReader(pr.log, blob, &pr.config.PayloadClientConfig, eigenDACert)
+	})
+}
+
+// getPayload implements GetPayload, without recording its latency
+func (pr *RelayPayloadRetriever) getPayload(
+	ctx context.Context,
+	eigenDACert *verification.EigenDACert,
+) (*coretypes.Payload, error) {
+	blob, err := pr.getVerifiedBlob(ctx, eigenDACert)
+	if err != nil {
+		return nil, err
+	}
+	return decodeVerifiedBlob(pr.log, blob, &pr.config.PayloadClientConfig, eigenDACert)
+}
+
+// getVerifiedBlob iteratively attempts to fetch the blob of the cert from the relays which have it, and returns the
+// first blob which matches the commitment of the cert.
+func (pr *RelayPayloadRetriever) getVerifiedBlob(
+	ctx context.Context,
+	eigenDACert *verification.EigenDACert,
+) (*coretypes.Blob, error) {
 
 	blobKey, err := eigenDACert.ComputeBlobKey()
 	if err != nil {
@@ -154,18 +197,7 @@ func (pr *RelayPayloadRetriever) GetPayload(
 			continue
 		}
 
-		payload, err := blob.ToPayload(pr.config.PayloadPolynomialForm)
-		if err != nil {
-			pr.log.Error(
-				`Commitment verification was successful, but conversion from blob to payload failed!
-					This is likely a problem with the local configuration, but could potentially indicate
-					malicious dispersed data. It should not be possible for a commitment to verify for an
-					invalid blob!`,
-				"blobKey", blobKey.Hex(), "relayKey", relayKey, "eigenDACert", eigenDACert, "error", err)
-			return nil, fmt.Errorf("decode blob: %w", err)
-		}
-
-		return payload, nil
+		return blob, nil
 	}
 
 	return nil, fmt.Errorf("unable to retrieve blob %v from any relay. relay count: %d", blobKey.Hex(), relayKeyCount)
@@ -4,6 +4,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
 
 	"github.com/docker/go-units"
 
@@ -14,9 +19,6 @@ import (
 	"github.com/Layr-Labs/eigenda/encoding"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
-
-	"github.com/gammazero/workerpool"
 )
 
 // RetrievalClient is an object that can retrieve blobs from the DA nodes.
@@ -31,6 +33,35 @@ type RetrievalClient interface {
 		referenceBlockNumber uint64,
 		quorumID core.QuorumID,
 	) ([]byte, error)
+
+	// GetBlobWithReport is like GetBlob, but also returns a report of the operators which returned invalid chunks or
+	// failed to return chunks. The report is returned even if the blob can't be reconstructed.
+	GetBlobWithReport(
+		ctx context.Context,
+		blobKey corev2.BlobKey,
+		blobVersion corev2.BlobVersion,
+		blobCommitments encoding.BlobCommitments,
+		referenceBlockNumber uint64,
+		quorumID core.QuorumID,
+	) ([]byte, *RetrievalReport, error)
+}
+
+// RetrievalReport describes the operators which didn't contribute all their chunks to the retrieval of a blob.
+// Operators which weren't asked for chunks, because the blob was reconstructed before they were needed, aren't
+// included.
+type RetrievalReport struct {
+	// InvalidChunkOperators maps each operator which returned chunks failing verification to the verification error.
+	// The valid chunks returned by these operators are still used to reconstruct the blob.
+	InvalidChunkOperators map[core.OperatorID]error
+	// FailedOperators maps each operator which failed to return chunks to the error of its request
+	FailedOperators map[core.OperatorID]error
+}
+
+func newRetrievalReport() *RetrievalReport {
+	return &RetrievalReport{
+		InvalidChunkOperators: make(map[core.OperatorID]error),
+		FailedOperators:       make(map[core.OperatorID]error),
+	}
 }
 
 type retrievalClient struct {
@@ -38,7 +69,14 @@ type retrievalClient struct {
 	ethClient         core.Reader
 	indexedChainState core.IndexedChainState
 	verifier          encoding.Verifier
-	numConnections    int
+	config            RetrievalClientConfig
+
+	// operatorLimiters limits the number of simultaneous requests to each operator, across all the blobs retrieved
+	operatorLimiters     map[core.OperatorID]chan struct{}
+	operatorLimitersLock sync.Mutex
+
+	// latencies holds the latencies of recent requests for chunks, to decide when requests are hedged
+	latencies *latencyTracker
 }
 
 var _ RetrievalClient = &retrievalClient{}
@@ -49,15 +87,22 @@ func NewRetrievalClient(
 	ethClient core.Reader,
 	chainState core.IndexedChainState,
 	verifier encoding.Verifier,
-	numConnections int,
-) RetrievalClient {
+	config RetrievalClientConfig,
+) (RetrievalClient, error) {
+	err := config.checkAndSetDefaults()
+	if err != nil {
+		return nil, fmt.Errorf("check and set config defaults: %w", err)
+	}
+
 	return &retrievalClient{
 		logger:            logger.With("component", "RetrievalClient"),
 		ethClient:         ethClient,
 		indexedChainState: chainState,
 		verifier:          verifier,
-		numConnections:    numConnections,
-	}
+		config:            config,
+		operatorLimiters:  make(map[core.OperatorID]chan struct{}),
+		latencies:         newLatencyTracker(latencyWindowSize),
+	}, nil
 }
 
 func (r *retrievalClient) GetBlob(
@@ -68,94 +113,227 @@ func (r *retrievalClient) GetBlob(
 	referenceBlockNumber uint64,
 	quorumID core.QuorumID,
 ) ([]byte, error) {
+	blob, _, err := r.GetBlobWithReport(ctx, blobKey, blobVersion, blobCommitments, referenceBlockNumber, quorumID)
+	return blob, err
+}
+
+// GetBlobWithReport downloads chunks of a blob from the operators, and reconstructs the blob as soon as the valid
+// chunks are enough to do so, without waiting for the remaining operators.
+//
+// The proof of each chunk is verified. If some of the chunks returned by an operator are invalid, the valid chunks
+// are still used, and the operator is reported in the returned RetrievalReport.
+func (r *retrievalClient) GetBlobWithReport(
+	ctx context.Context,
+	blobKey corev2.BlobKey,
+	blobVersion corev2.BlobVersion,
+	blobCommitments encoding.BlobCommitments,
+	referenceBlockNumber uint64,
+	quorumID core.QuorumID,
+) ([]byte, *RetrievalReport, error) {
+
+	report := newRetrievalReport()
 
 	commitmentBatch := []encoding.BlobCommitments{blobCommitments}
 	err := r.verifier.VerifyCommitEquivalenceBatch(commitmentBatch)
 	if err != nil {
-		return nil, err
+		return nil, report, err
 	}
 
 	indexedOperatorState, err := r.indexedChainState.GetIndexedOperatorState(ctx, uint(referenceBlockNumber), []core.QuorumID{quorumID})
 	if err != nil {
-		return nil, err
+		return nil, report, err
 	}
 	operators, ok := indexedOperatorState.Operators[quorumID]
 	if !ok {
-		return nil, fmt.Errorf("no quorum with ID: %d", quorumID)
+		return nil, report, fmt.Errorf("no quorum with ID: %d", quorumID)
 	}
 
 	blobVersions, err := r.ethClient.GetAllVersionedBlobParams(ctx)
 	if err != nil {
-		return nil, err
+		return nil, report, err
 	}
 
 	blobParam, ok := blobVersions[blobVersion]
 	if !ok {
-		return nil, fmt.Errorf("invalid blob version %d", blobVersion)
+		return nil, report, fmt.Errorf("invalid blob version %d", blobVersion)
 	}
 
 	encodingParams, err := corev2.GetEncodingParams(blobCommitments.Length, blobParam)
 	if err != nil {
-		return nil, err
+		return nil, report, err
 	}
 
 	assignments, err := corev2.GetAssignments(indexedOperatorState.OperatorState, blobParam, quorumID)
 	if err != nil {
-		return nil, errors.New("failed to get assignments")
+		return nil, report, errors.New("failed to get assignments")
 	}
 
-	// Fetch chunks from all operators
-	chunksChan := make(chan clients.RetrievedChunks, len(operators))
-	pool := workerpool.New(r.numConnections)
+	// The operators are asked for chunks in a random order, so that retrievals are spread across the network
+	opIDs := make([]core.OperatorID, 0, len(operators))
 	for opID := range operators {
-		opID := opID
+		assignment, ok := assignments[opID]
+		if !ok {
+			return nil, report, fmt.Errorf("no assignment to operator %s", opID.Hex())
+		}
+		if assignment.NumChunks > 0 {
+			opIDs = append(opIDs, opID)
+		}
+	}
+	rand.Shuffle(len(opIDs), func(i, j int) { opIDs[i], opIDs[j] = opIDs[j], opIDs[i] })
+
+	// The blob can be reconstructed from any set of chunks holding as many symbols as the blob
+	requiredChunks := encoding.RoundUpDivide(uint64(blobCommitments.Length), encodingParams.ChunkLength)
+
+	// The remaining requests are cancelled once enough chunks are retrieved
+	fetchCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	chunksChan := make(chan clients.RetrievedChunks, len(opIDs))
+	next := 0
+	pending := 0
+	// pendingChunks is the number of chunks expected from the pending requests
+	pendingChunks := uint64(0)
+	startRequest := func() {
+		opID := opIDs[next]
+		next++
+		pending++
+		pendingChunks += uint64(assignments[opID].NumChunks)
 		opInfo := indexedOperatorState.IndexedOperators[opID]
-		pool.Submit(func() {
-			r.getChunksFromOperator(ctx, opID, opInfo, blobKey, quorumID, chunksChan)
-		})
+		go r.getChunksFromOperator(fetchCtx, opID, opInfo, blobKey, quorumID, chunksChan)
 	}
 
 	var chunks []*encoding.Frame
 	var indices []encoding.ChunkNumber
-	// TODO(ian-shim): if we gathered enough chunks, cancel remaining RPC calls
-	for i := 0; i < len(operators); i++ {
-		reply := <-chunksChan
-		if reply.Err != nil {
-			r.logger.Warn("failed to get chunks from operator", "operator", reply.OperatorID.Hex(), "err", reply.Err)
-			continue
-		}
-		assignment, ok := assignments[reply.OperatorID]
-		if !ok {
-			return nil, fmt.Errorf("no assignment to operator %s", reply.OperatorID.Hex())
-		}
-
-		assignmentIndices := make([]uint, len(assignment.GetIndices()))
-		for i, index := range assignment.GetIndices() {
-			assignmentIndices[i] = uint(index)
+	// Requests are started until the chunks expected from them are enough to reconstruct the blob. More requests are
+	// started as requests fail, or when no request completes within the hedge delay.
+	startRequests := func() {
+		for next < len(opIDs) && pending < r.config.MaxConnectionCount &&
+			uint64(len(chunks))+pendingChunks < requiredChunks {
+			startRequest()
 		}
+	}
+	startRequests()
 
-		err = r.verifier.VerifyFrames(reply.Chunks, assignmentIndices, blobCommitments, encodingParams)
-		if err != nil {
-			r.logger.Warn("failed to verify chunks from operator", "operator", reply.OperatorID.Hex(), "err", err)
+	hedgeTimer := time.NewTimer(r.latencies.percentile(r.config.HedgePercentile, r.config.HedgeDelay))
+	defer hedgeTimer.Stop()
+	for pending > 0 && uint64(len(chunks)) < requiredChunks {
+		select {
+		case <-ctx.Done():
+			return nil, report, ctx.Err()
+		case <-hedgeTimer.C:
+			if next < len(opIDs) && pending < r.config.MaxConnectionCount {
+				r.logger.Debug("hedging slow chunk requests", "blobKey", blobKey.Hex(), "pending", pending)
+				startRequest()
+			}
+			hedgeTimer.Reset(r.latencies.percentile(r.config.HedgePercentile, r.config.HedgeDelay))
 			continue
-		} else {
-			r.logger.Info("verified chunks from operator", "operator", reply.OperatorID.Hex())
+		case reply := <-chunksChan:
+			pending--
+			assignment := assignments[reply.OperatorID]
+			pendingChunks -= uint64(assignment.NumChunks)
+
+			if reply.Err != nil {
+				r.logger.Warn("failed to get chunks from operator", "operator", reply.OperatorID.Hex(), "err", reply.Err)
+				report.FailedOperators[reply.OperatorID] = reply.Err
+			} else {
+				assignmentIndices := make([]uint, len(assignment.GetIndices()))
+				for i, index := range assignment.GetIndices() {
+					assignmentIndices[i] = uint(index)
+				}
+
+				validChunks, validIndices, err := r.verifyChunks(
+					reply.Chunks, assignmentIndices, blobCommitments, encodingParams)
+				if err != nil {
+					r.logger.Warn("operator returned invalid chunks", "operator", reply.OperatorID.Hex(),
+						"validChunks", len(validChunks), "err", err)
+					report.InvalidChunkOperators[reply.OperatorID] = err
+				} else {
+					r.logger.Info("verified chunks from operator", "operator", reply.OperatorID.Hex())
+				}
+				chunks = append(chunks, validChunks...)
+				indices = append(indices, validIndices...)
+			}
 		}
 
-		chunks = append(chunks, reply.Chunks...)
-		indices = append(indices, assignmentIndices...)
+		startRequests()
+		if !hedgeTimer.Stop() {
+			select {
+			case <-hedgeTimer.C:
+			default:
+			}
+		}
+		hedgeTimer.Reset(r.latencies.percentile(r.config.HedgePercentile, r.config.HedgeDelay))
 	}
 
 	if len(chunks) == 0 {
-		return nil, errors.New("failed to retrieve any chunks")
+		return nil, report, fmt.Errorf("failed to retrieve any chunks: %d operators failed, %d returned invalid chunks",
+			len(report.FailedOperators), len(report.InvalidChunkOperators))
+	}
+	if uint64(len(chunks)) < requiredChunks {
+		return nil, report, fmt.Errorf(
+			"retrieved %d valid chunks, %d are required: %d operators failed, %d returned invalid chunks",
+			len(chunks), requiredChunks, len(report.FailedOperators), len(report.InvalidChunkOperators))
 	}
 
-	return r.verifier.Decode(
+	blob, err := r.verifier.Decode(
 		chunks,
 		indices,
 		encodingParams,
 		uint64(blobCommitments.Length)*encoding.BYTES_PER_SYMBOL,
 	)
+	if err != nil {
+		return nil, report, err
+	}
+	return blob, report, nil
+}
+
+// verifyChunks verifies the chunks returned by an operator against their assigned indices, and returns the valid
+// chunks with their indices. The chunks are verified as a batch, and only if the batch fails verification is each
+// chunk verified on its own, so that the valid chunks of an operator returning some invalid chunks can still be used.
+// A non-nil error is returned if any chunk is invalid.
+func (r *retrievalClient) verifyChunks(
+	chunks []*encoding.Frame,
+	indices []encoding.ChunkNumber,
+	blobCommitments encoding.BlobCommitments,
+	encodingParams encoding.EncodingParams,
+) ([]*encoding.Frame, []encoding.ChunkNumber, error) {
+
+	if len(chunks) == len(indices) {
+		err := r.verifier.VerifyFrames(chunks, indices, blobCommitments, encodingParams)
+		if err == nil {
+			return chunks, indices, nil
+		}
+	}
+
+	var validChunks []*encoding.Frame
+	var validIndices []encoding.ChunkNumber
+	invalidCount := 0
+	var firstErr error
+	for i := 0; i < len(chunks) && i < len(indices); i++ {
+		err := r.verifier.VerifyFrames(chunks[i:i+1], indices[i:i+1], blobCommitments, encodingParams)
+		if err != nil {
+			invalidCount++
+			if firstErr == nil {
+				firstErr = fmt.Errorf("verify chunk %d: %w", indices[i], err)
+			}
+			continue
+		}
+		validChunks = append(validChunks, chunks[i])
+		validIndices = append(validIndices, indices[i])
+	}
+
+	if len(chunks) != len(indices) {
+		return validChunks, validIndices, fmt.Errorf(
+			"returned %d chunks for %d assigned indices, %d of the returned chunks are invalid",
+			len(chunks), len(indices), invalidCount)
+	}
+	if firstErr != nil {
+		return validChunks, validIndices, fmt.Errorf("%d of %d chunks are invalid: %w",
+			invalidCount, len(chunks), firstErr)
+	}
+
+	// the batch failed verification, but each chunk is valid on its own
+	return validChunks, validIndices, nil
 }
 
 func (r *retrievalClient) getChunksFromOperator(
@@ -176,10 +354,21 @@ func (r *retrievalClient) getChunksFromOperator(
 	fudgeFactor := units.MiB      // to allow for some overhead from things like protobuf encoding
 	maxMessageSize := maxBlobSize*encodingRate + fudgeFactor
 
+	release, err := r.acquireOperator(ctx, opID)
+	if err != nil {
+		chunksChan <- clients.RetrievedChunks{
+			OperatorID: opID,
+			Err:        err,
+			Chunks:     nil,
+		}
+		return
+	}
+	defer release()
+	start := time.Now()
+
 	conn, err := grpc.NewClient(
 		core.OperatorSocket(opInfo.Socket).GetV2RetrievalSocket(),
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
-		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(maxMessageSize)),
+		getGrpcDialOptions(false, uint(maxMessageSize), r.config.Interceptors)...,
 	)
 	defer func() {
 		err := conn.Close()
@@ -212,6 +401,8 @@ func (r *retrievalClient) getChunksFromOperator(
 		return
 	}
 
+	r.latencies.record(time.Since(start))
+
 	chunks := make([]*encoding.Frame, len(reply.GetChunks()))
 	for i, data := range reply.GetChunks() {
 		var chunk *encoding.Frame
@@ -233,3 +424,76 @@ func (r *retrievalClient) getChunksFromOperator(
 		Chunks:     chunks,
 	}
 }
+
+// acquireOperator waits until a request can be made to the operator without exceeding MaxRequestsPerOperator, and
+// returns a function which releases the request.
+func (r *retrievalClient) acquireOperator(ctx context.Context, opID core.OperatorID) (func(), error) {
+	r.operatorLimitersLock.Lock()
+	limiter, ok := r.operatorLimiters[opID]
+	if !ok {
+		limiter = make(chan struct{}, r.config.MaxRequestsPerOperator)
+		r.operatorLimiters[opID] = limiter
+	}
+	r.operatorLimitersLock.Unlock()
+
+	select {
+	case limiter <- struct{}{}:
+		return func() { <-limiter }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// latencyWindowSize is the number of recent request latencies used to compute the hedge delay
+const latencyWindowSize = 1000
+
+// minLatencySamples is the number of request latencies needed before the hedge delay is computed from them
+const minLatencySamples = 20
+
+// latencyTracker holds the latencies of the most recent requests, in a ring buffer.
+//
+// This struct is goroutine safe.
+type latencyTracker struct {
+	lock      sync.Mutex
+	latencies []time.Duration
+	next      int
+}
+
+func newLatencyTracker(size int) *latencyTracker {
+	return &latencyTracker{
+		latencies: make([]time.Duration, 0, size),
+	}
+}
+
+// record adds the latency of a request, replacing the oldest latency if the window is full.
+func (t *latencyTracker) record(latency time.Duration) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if len(t.latencies) < cap(t.latencies) {
+		t.latencies = append(t.latencies, latency)
+		return
+	}
+	t.latencies[t.next] = latency
+	t.next = (t.next + 1) % len(t.latencies)
+}
+
+// percentile returns the given percentile of the recorded latencies, or the default latency if too few latencies
+// are recorded.
+func (t *latencyTracker) percentile(p float64, defaultLatency time.Duration) time.Duration {
+	t.lock.Lock()
+	if len(t.latencies) < minLatencySamples {
+		t.lock.Unlock()
+		return defaultLatency
+	}
+	latencies := make([]time.Duration, len(t.latencies))
+	copy(latencies, t.latencies)
+	t.lock.Unlock()
+
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+	index := int(math.Ceil(p*float64(len(latencies)))) - 1
+	if index < 0 {
+		index = 0
+	}
+	return latencies[index]
+}
@@ -0,0 +1,116 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLatencyTrackerPercentile(t *testing.T) {
+	tracker := newLatencyTracker(100)
+
+	// Too few latencies are recorded
+	for i := 1; i < minLatencySamples; i++ {
+		tracker.record(time.Duration(i) * time.Millisecond)
+	}
+	assert.Equal(t, time.Second, tracker.percentile(0.95, time.Second))
+
+	for i := minLatencySamples; i <= 100; i++ {
+		tracker.record(time.Duration(i) * time.Millisecond)
+	}
+	assert.Equal(t, 95*time.Millisecond, tracker.percentile(0.95, time.Second))
+	assert.Equal(t, 100*time.Millisecond, tracker.percentile(1, time.Second))
+	assert.Equal(t, 50*time.Millisecond, tracker.percentile(0.5, time.Second))
+
+	// The oldest latencies are replaced once the window is full
+	for i := 0; i < 50; i++ {
+		tracker.record(time.Second)
+	}
+	assert.Equal(t, 51*time.Millisecond, tracker.percentile(0.01, 0))
+	assert.Equal(t, 100*time.Millisecond, tracker.percentile(0.5, 0))
+	assert.Equal(t, time.Second, tracker.percentile(0.51, 0))
+}
+
+func TestRetrievalClientOperatorLimit(t *testing.T) {
+	config := RetrievalClientConfig{MaxRequestsPerOperator: 2}
+	require.NoError(t, config.checkAndSetDefaults())
+	r := &retrievalClient{
+		config:           config,
+		operatorLimiters: make(map[core.OperatorID]chan struct{}),
+	}
+	opID := core.OperatorID{1}
+
+	release1, err := r.acquireOperator(context.Background(), opID)
+	require.NoError(t, err)
+	_, err = r.acquireOperator(context.Background(), opID)
+	require.NoError(t, err)
+
+	// The limit is reached for the operator, but not for other operators
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = r.acquireOperator(ctx, opID)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	_, err = r.acquireOperator(context.Background(), core.OperatorID{2})
+	require.NoError(t, err)
+
+	release1()
+	_, err = r.acquireOperator(context.Background(), opID)
+	require.NoError(t, err)
+}
+
+// invalidChunksVerifier is an encoding.Verifier which rejects the chunks with the given indices
+type invalidChunksVerifier struct {
+	encoding.Verifier
+	invalidIndices map[encoding.ChunkNumber]bool
+}
+
+func (v *invalidChunksVerifier) VerifyFrames(
+	chunks []*encoding.Frame,
+	indices []encoding.ChunkNumber,
+	_ encoding.BlobCommitments,
+	_ encoding.EncodingParams,
+) error {
+	if len(chunks) != len(indices) {
+		return errors.New("mismatched chunks and indices")
+	}
+	for _, index := range indices {
+		if v.invalidIndices[index] {
+			return errors.New("invalid chunk")
+		}
+	}
+	return nil
+}
+
+func TestRetrievalClientVerifyChunks(t *testing.T) {
+	r := &retrievalClient{
+		verifier: &invalidChunksVerifier{invalidIndices: map[encoding.ChunkNumber]bool{3: true}},
+	}
+	chunks := []*encoding.Frame{{}, {}, {}, {}}
+
+	// All chunks are valid
+	validChunks, validIndices, err := r.verifyChunks(
+		chunks[:2], []encoding.ChunkNumber{1, 2}, encoding.BlobCommitments{}, encoding.EncodingParams{})
+	require.NoError(t, err)
+	assert.Len(t, validChunks, 2)
+	assert.Equal(t, []encoding.ChunkNumber{1, 2}, validIndices)
+
+	// The valid chunks are kept when some chunks are invalid
+	validChunks, validIndices, err = r.verifyChunks(
+		chunks, []encoding.ChunkNumber{1, 2, 3, 4}, encoding.BlobCommitments{}, encoding.EncodingParams{})
+	require.Error(t, err)
+	assert.Len(t, validChunks, 3)
+	assert.Equal(t, []encoding.ChunkNumber{1, 2, 4}, validIndices)
+
+	// Chunks which don't match the assigned indices are invalid
+	validChunks, validIndices, err = r.verifyChunks(
+		chunks[:3], []encoding.ChunkNumber{1, 2}, encoding.BlobCommitments{}, encoding.EncodingParams{})
+	require.Error(t, err)
+	assert.Len(t, validChunks, 2)
+	assert.Equal(t, []encoding.ChunkNumber{1, 2}, validIndices)
+}
@@ -0,0 +1,197 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RetryPolicy controls how the requests of the DisperserClient are retried and hedged.
+//
+// A retried or hedged dispersal resends the exact same signed request, including its payment, so the disperser accepts
+// it at most once. Retries still trade some risk for availability: an attempt which timed out may have been accepted,
+// in which case the retries are rejected as duplicates, and the client falls back to looking up the status of the blob.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts of a request, including the first attempt and hedged attempts.
+	// A value of 1 disables retries and hedging.
+	MaxAttempts int
+
+	// InitialBackoff is the delay before the first retry
+	InitialBackoff time.Duration
+
+	// MaxBackoff is the maximum delay between retries
+	MaxBackoff time.Duration
+
+	// BackoffMultiplier is the factor by which the delay grows after each retry
+	BackoffMultiplier float64
+
+	// RetryableCodes are the gRPC status codes after which a request is retried. Requests failing with other errors
+	// aren't retried.
+	RetryableCodes []codes.Code
+
+	// AttemptTimeout is the timeout of each attempt. If zero, attempts are only bounded by the context of the request.
+	AttemptTimeout time.Duration
+
+	// HedgeDelay is the delay after which another attempt is started if no attempt has completed, without waiting for
+	// the pending attempts. The first successful attempt is used. If zero, requests aren't hedged.
+	HedgeDelay time.Duration
+}
+
+// GetDefaultRetryPolicy creates a RetryPolicy with default values, which retries requests failing with transient
+// errors, without hedging.
+func GetDefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:       3,
+		InitialBackoff:    500 * time.Millisecond,
+		MaxBackoff:        5 * time.Second,
+		BackoffMultiplier: 2,
+		RetryableCodes:    []codes.Code{codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted},
+		AttemptTimeout:    0,
+		HedgeDelay:        0,
+	}
+}
+
+// checkAndSetDefaults checks an existing config struct. It performs one of the following actions for any contained 0 values:
+//
+// 1. If 0 is an acceptable value for the field, do nothing.
+// 2. If 0 is NOT an acceptable value for the field, and a default value is defined, then set it to the default.
+// 3. If 0 is NOT an acceptable value for the field, and a default value is NOT defined, return an error.
+func (rp *RetryPolicy) checkAndSetDefaults() error {
+	defaultPolicy := GetDefaultRetryPolicy()
+
+	if rp.MaxAttempts == 0 {
+		rp.MaxAttempts = defaultPolicy.MaxAttempts
+	}
+	if rp.MaxAttempts < 0 {
+		return fmt.Errorf("MaxAttempts must be positive, got %d", rp.MaxAttempts)
+	}
+
+	// InitialBackoff may be 0, so don't do anything
+
+	if rp.MaxBackoff == 0 {
+		rp.MaxBackoff = max(defaultPolicy.MaxBackoff, rp.InitialBackoff)
+	}
+	if rp.BackoffMultiplier == 0 {
+		rp.BackoffMultiplier = defaultPolicy.BackoffMultiplier
+	}
+	if rp.BackoffMultiplier < 1 {
+		return fmt.Errorf("BackoffMultiplier must be at least 1, got %f", rp.BackoffMultiplier)
+	}
+
+	// RetryableCodes may be empty, in which case only hedging applies
+
+	// AttemptTimeout and HedgeDelay may be 0, so don't do anything
+
+	return nil
+}
+
+// isRetryable returns whether a request failing with the given error may be retried.
+func (rp *RetryPolicy) isRetryable(err error) bool {
+	s, ok := status.FromError(err)
+	if !ok {
+		// errors from the client side of the connection are wrapped, rather than being status errors
+		if errors.Is(err, context.DeadlineExceeded) {
+			return slices.Contains(rp.RetryableCodes, codes.DeadlineExceeded)
+		}
+		return false
+	}
+	return slices.Contains(rp.RetryableCodes, s.Code())
+}
+
+// invokeWithPolicy calls fn until it succeeds, it fails with an error which isn't retryable, or the policy's attempts
+// are exhausted. A nil policy calls fn once. The error of the last completed attempt is returned if no attempt succeeds.
+func invokeWithPolicy[T any](
+	ctx context.Context,
+	policy *RetryPolicy,
+	fn func(ctx context.Context) (T, error),
+) (T, error) {
+	if policy == nil {
+		return fn(ctx)
+	}
+
+	type result struct {
+		value T
+		err   error
+	}
+	// the channel fits a result from every attempt, so abandoned attempts never block
+	results := make(chan result, policy.MaxAttempts)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	started := 0
+	pending := 0
+	startAttempt := func() {
+		started++
+		pending++
+		go func() {
+			attemptCtx := ctx
+			if policy.AttemptTimeout > 0 {
+				var attemptCancel context.CancelFunc
+				attemptCtx, attemptCancel = context.WithTimeout(ctx, policy.AttemptTimeout)
+				defer attemptCancel()
+			}
+			value, err := fn(attemptCtx)
+			results <- result{value: value, err: err}
+		}()
+	}
+
+	newHedgeTimer := func() <-chan time.Time {
+		if policy.HedgeDelay <= 0 || started >= policy.MaxAttempts {
+			return nil
+		}
+		return time.After(policy.HedgeDelay)
+	}
+
+	var zero T
+	var lastErr error
+	backoff := policy.InitialBackoff
+	// retriesAllowed is cleared once an attempt fails with an error which isn't retryable
+	retriesAllowed := true
+
+	startAttempt()
+	hedgeTimer := newHedgeTimer()
+	var retryTimer <-chan time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			if lastErr != nil {
+				return zero, fmt.Errorf("%w (last attempt: %v)", ctx.Err(), lastErr)
+			}
+			return zero, ctx.Err()
+		case <-hedgeTimer:
+			// while waiting to retry, there's no pending attempt to hedge
+			if retriesAllowed && pending > 0 && started < policy.MaxAttempts {
+				startAttempt()
+			}
+			hedgeTimer = newHedgeTimer()
+		case <-retryTimer:
+			retryTimer = nil
+			startAttempt()
+			hedgeTimer = newHedgeTimer()
+		case r := <-results:
+			pending--
+			if r.err == nil {
+				return r.value, nil
+			}
+			lastErr = r.err
+			if !policy.isRetryable(r.err) {
+				retriesAllowed = false
+			}
+			if pending > 0 {
+				// wait for the other attempts
+				continue
+			}
+			if !retriesAllowed || started >= policy.MaxAttempts {
+				return zero, lastErr
+			}
+			retryTimer = time.After(backoff)
+			backoff = min(time.Duration(float64(backoff)*policy.BackoffMultiplier), policy.MaxBackoff)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package clients
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func testRetryPolicy(t *testing.T) *RetryPolicy {
+	policy := &RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: time.Millisecond,
+		RetryableCodes: []codes.Code{codes.Unavailable},
+	}
+	require.NoError(t, policy.checkAndSetDefaults())
+	return policy
+}
+
+func TestInvokeWithPolicyRetries(t *testing.T) {
+	policy := testRetryPolicy(t)
+
+	attempts := atomic.Int32{}
+	value, err := invokeWithPolicy(context.Background(), policy, func(ctx context.Context) (int, error) {
+		if attempts.Add(1) < 3 {
+			return 0, api.NewErrorUnavailable("unavailable")
+		}
+		return 42, nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, 42, value)
+	require.Equal(t, int32(3), attempts.Load())
+
+	// The attempts are exhausted
+	attempts.Store(0)
+	_, err = invokeWithPolicy(context.Background(), policy, func(ctx context.Context) (int, error) {
+		attempts.Add(1)
+		return 0, api.NewErrorUnavailable("unavailable")
+	})
+	require.Equal(t, codes.Unavailable, status.Code(err))
+	require.Equal(t, int32(3), attempts.Load())
+
+	// Errors which aren't retryable are returned immediately
+	attempts.Store(0)
+	_, err = invokeWithPolicy(context.Background(), policy, func(ctx context.Context) (int, error) {
+		attempts.Add(1)
+		return 0, api.NewErrorInvalidArg("invalid")
+	})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	require.Equal(t, int32(1), attempts.Load())
+
+	// Without a policy, requests are attempted once
+	attempts.Store(0)
+	_, err = invokeWithPolicy(context.Background(), nil, func(ctx context.Context) (int, error) {
+		attempts.Add(1)
+		return 0, api.NewErrorUnavailable("unavailable")
+	})
+	require.Error(t, err)
+	require.Equal(t, int32(1), attempts.Load())
+}
+
+func TestInvokeWithPolicyAttemptTimeout(t *testing.T) {
+	policy := testRetryPolicy(t)
+	policy.AttemptTimeout = 10 * time.Millisecond
+	policy.RetryableCodes = append(policy.RetryableCodes, codes.DeadlineExceeded)
+
+	attempts := atomic.Int32{}
+	value, err := invokeWithPolicy(context.Background(), policy, func(ctx context.Context) (int, error) {
+		if attempts.Add(1) == 1 {
+			<-ctx.Done()
+			return 0, status.FromContextError(ctx.Err()).Err()
+		}
+		return 42, nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, 42, value)
+	require.Equal(t, int32(2), attempts.Load())
+}
+
+func TestInvokeWithPolicyHedging(t *testing.T) {
+	policy := testRetryPolicy(t)
+	policy.HedgeDelay = 10 * time.Millisecond
+
+	// The first attempt hangs, so the hedged attempt's result is used
+	attempts := atomic.Int32{}
+	release := make(chan struct{})
+	defer close(release)
+	value, err := invokeWithPolicy(context.Background(), policy, func(ctx context.Context) (int, error) {
+		if attempts.Add(1) == 1 {
+			<-release
+			return 1, nil
+		}
+		return 2, nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, value)
+	require.Equal(t, int32(2), attempts.Load())
+
+	// The request fails once all the attempts, including hedged ones, fail
+	attempts.Store(0)
+	start := time.Now()
+	_, err = invokeWithPolicy(context.Background(), policy, func(ctx context.Context) (int, error) {
+		attempts.Add(1)
+		time.Sleep(50 * time.Millisecond)
+		return 0, api.NewErrorUnavailable("unavailable")
+	})
+	require.Error(t, err)
+	require.Equal(t, int32(3), attempts.Load())
+	// the attempts overlapped
+	require.Less(t, time.Since(start), 140*time.Millisecond)
+}
@@ -0,0 +1,111 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/api/clients/v2"
+	"github.com/Layr-Labs/eigenda/common"
+	core "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestArchive starts an HTTP server serving the given blobs, keyed by the hex of their blob keys
+func newTestArchive(t *testing.T, blobs map[core.BlobKey][]byte) clients.BlobArchive {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for blobKey, blobBytes := range blobs {
+			if r.URL.Path == "/blobs/"+blobKey.Hex() {
+				_, _ = w.Write(blobBytes)
+				return
+			}
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	t.Cleanup(server.Close)
+
+	return clients.NewHTTPBlobArchive(server.Client(), server.URL+"/blobs/", 1024*1024)
+}
+
+func buildArchivePayloadRetriever(
+	t *testing.T,
+	tester RelayPayloadRetrieverTester,
+	archive clients.BlobArchive,
+) *clients.ArchivePayloadRetriever {
+	logger, err := common.NewLogger(common.DefaultLoggerConfig())
+	require.NoError(t, err)
+
+	retriever, err := clients.NewArchivePayloadRetriever(
+		logger, clients.ArchivePayloadRetrieverConfig{}, archive, tester.G1Srs)
+	require.NoError(t, err)
+	return retriever
+}
+
+// TestArchiveGetPayload tests that a blob retrieved from the archive is verified and decoded
+func TestArchiveGetPayload(t *testing.T) {
+	tester := buildRelayPayloadRetrieverTester(t)
+	blobKey, blobBytes, blobCert := buildBlobAndCert(t, tester, []core.RelayKey{})
+
+	retriever := buildArchivePayloadRetriever(t, tester, newTestArchive(t, map[core.BlobKey][]byte{blobKey: blobBytes}))
+
+	payload, err := retriever.GetPayload(context.Background(), blobCert)
+	require.NoError(t, err)
+	require.NotNil(t, payload)
+
+	// a blob missing from the archive
+	_, _, otherBlobCert := buildBlobAndCert(t, tester, []core.RelayKey{})
+	payload, err = retriever.GetPayload(context.Background(), otherBlobCert)
+	require.Error(t, err)
+	require.Nil(t, payload)
+}
+
+// TestArchiveReturnsDifferentBlob tests that a blob from the archive which doesn't match the cert is rejected
+func TestArchiveReturnsDifferentBlob(t *testing.T) {
+	tester := buildRelayPayloadRetrieverTester(t)
+	blobKey, _, blobCert := buildBlobAndCert(t, tester, []core.RelayKey{})
+	_, otherBlobBytes, _ := buildBlobAndCert(t, tester, []core.RelayKey{})
+
+	retriever := buildArchivePayloadRetriever(
+		t, tester, newTestArchive(t, map[core.BlobKey][]byte{blobKey: otherBlobBytes}))
+
+	payload, err := retriever.GetPayload(context.Background(), blobCert)
+	require.Error(t, err)
+	require.Nil(t, payload)
+}
+
+// TestFallbackToArchive tests that the payload is retrieved from the archive when neither the relays nor the
+// validators hold the blob
+func TestFallbackToArchive(t *testing.T) {
+	tester := buildFallbackPayloadRetrieverTester(t)
+	relayKeys := []core.RelayKey{tester.Random.Uint32()}
+	blobKey, blobBytes, blobCert := buildQuorumBlobAndCert(t, tester.RelayPayloadRetrieverTester, relayKeys)
+
+	tester.MockRelayClient.On("GetBlob", mock.Anything, relayKeys[0], blobKey).Return(nil, errors.New("expired")).Once()
+	tester.MockRetrievalClient.On(
+		"GetBlob", mock.Anything, blobKey, mock.Anything, mock.Anything, mock.Anything, uint8(0),
+	).Return(nil, errors.New("expired")).Once()
+
+	// without an archive, retrieval fails
+	_, err := tester.FallbackPayloadRetriever.GetPayload(context.Background(), blobCert)
+	require.Error(t, err)
+
+	tester.MockRelayClient.On("GetBlob", mock.Anything, relayKeys[0], blobKey).Return(nil, errors.New("expired")).Once()
+	tester.MockRetrievalClient.On(
+		"GetBlob", mock.Anything, blobKey, mock.Anything, mock.Anything, mock.Anything, uint8(0),
+	).Return(nil, errors.New("expired")).Once()
+
+	tester.FallbackPayloadRetriever.SetArchivePayloadRetriever(buildArchivePayloadRetriever(
+		t,
+		tester.RelayPayloadRetrieverTester,
+		newTestArchive(t, map[core.BlobKey][]byte{blobKey: blobBytes})))
+
+	payload, err := tester.FallbackPayloadRetriever.GetPayload(context.Background(), blobCert)
+	require.NoError(t, err)
+	require.NotNil(t, payload)
+
+	tester.MockRelayClient.AssertExpectations(t)
+	tester.MockRetrievalClient.AssertExpectations(t)
+}
@@ -0,0 +1,69 @@
+package test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/api/clients/v2"
+	core "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCachedPayloadRetrieverHit tests that a payload retrieved once is served from the cache afterwards
+func TestCachedPayloadRetrieverHit(t *testing.T) {
+	tester := buildRelayPayloadRetrieverTester(t)
+	relayKeys := []core.RelayKey{tester.Random.Uint32()}
+	blobKey, blobBytes, blobCert := buildBlobAndCert(t, tester, relayKeys)
+
+	cachedRetriever, err := clients.NewCachedPayloadRetriever(tester.RelayPayloadRetriever, 1024*1024)
+	require.NoError(t, err)
+
+	tester.MockRelayClient.On("GetBlob", mock.Anything, relayKeys[0], blobKey).Return(blobBytes, nil).Once()
+
+	payload, err := cachedRetriever.GetPayload(context.Background(), blobCert)
+	require.NoError(t, err)
+	require.NotNil(t, payload)
+
+	cachedPayload, err := cachedRetriever.GetPayload(context.Background(), blobCert)
+	require.NoError(t, err)
+	require.Equal(t, payload.Serialize(), cachedPayload.Serialize())
+
+	tester.MockRelayClient.AssertExpectations(t)
+	tester.MockRelayClient.AssertNumberOfCalls(t, "GetBlob", 1)
+}
+
+// TestCachedPayloadRetrieverEviction tests that the least recently used payloads are evicted to stay within the
+// maximum cache size
+func TestCachedPayloadRetrieverEviction(t *testing.T) {
+	tester := buildRelayPayloadRetrieverTester(t)
+	relayKeys := []core.RelayKey{tester.Random.Uint32()}
+	blobKey1, blobBytes1, blobCert1 := buildBlobAndCert(t, tester, relayKeys)
+	blobKey2, blobBytes2, blobCert2 := buildBlobAndCert(t, tester, relayKeys)
+
+	tester.MockRelayClient.On("GetBlob", mock.Anything, relayKeys[0], blobKey1).Return(blobBytes1, nil)
+	tester.MockRelayClient.On("GetBlob", mock.Anything, relayKeys[0], blobKey2).Return(blobBytes2, nil)
+
+	payload1, err := tester.RelayPayloadRetriever.GetPayload(context.Background(), blobCert1)
+	require.NoError(t, err)
+	payload2, err := tester.RelayPayloadRetriever.GetPayload(context.Background(), blobCert2)
+	require.NoError(t, err)
+
+	// The cache only fits the larger of the two payloads
+	maxSize := max(len(payload1.Serialize()), len(payload2.Serialize()))
+	cachedRetriever, err := clients.NewCachedPayloadRetriever(tester.RelayPayloadRetriever, uint64(maxSize))
+	require.NoError(t, err)
+
+	_, err = cachedRetriever.GetPayload(context.Background(), blobCert1)
+	require.NoError(t, err)
+	_, err = cachedRetriever.GetPayload(context.Background(), blobCert2)
+	require.NoError(t, err)
+	_, err = cachedRetriever.GetPayload(context.Background(), blobCert1)
+	require.NoError(t, err)
+
+	// Each payload is retrieved once before the cache is used, and blob 1 is retrieved again after being evicted,
+	// unless one of the payloads is empty
+	if len(payload1.Serialize()) > 0 && len(payload2.Serialize()) > 0 {
+		tester.MockRelayClient.AssertNumberOfCalls(t, "GetBlob", 5)
+	}
+}
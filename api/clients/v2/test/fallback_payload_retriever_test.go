@@ -0,0 +1,124 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/api/clients/v2"
+	clientsmock "github.com/Layr-Labs/eigenda/api/clients/v2/mock"
+	"github.com/Layr-Labs/eigenda/api/clients/v2/verification"
+	"github.com/Layr-Labs/eigenda/common"
+	core "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type FallbackPayloadRetrieverTester struct {
+	RelayPayloadRetrieverTester
+	FallbackPayloadRetriever *clients.FallbackPayloadRetriever
+	MockRetrievalClient      *clientsmock.MockRetrievalClient
+}
+
+// buildFallbackPayloadRetrieverTester sets up a fallback retriever, with mocks for the relay and retrieval clients
+func buildFallbackPayloadRetrieverTester(t *testing.T) FallbackPayloadRetrieverTester {
+	logger, err := common.NewLogger(common.DefaultLoggerConfig())
+	require.NoError(t, err)
+
+	relayTester := buildRelayPayloadRetrieverTester(t)
+
+	mockRetrievalClient := &clientsmock.MockRetrievalClient{}
+	validatorPayloadRetriever, err := clients.NewValidatorPayloadRetriever(
+		logger,
+		clients.ValidatorPayloadRetrieverConfig{
+			BlsOperatorStateRetrieverAddr: "0x1",
+			EigenDAServiceManagerAddr:     "0x2",
+		},
+		mockRetrievalClient,
+		relayTester.G1Srs)
+	require.NoError(t, err)
+
+	return FallbackPayloadRetrieverTester{
+		RelayPayloadRetrieverTester: relayTester,
+		FallbackPayloadRetriever: clients.NewFallbackPayloadRetriever(
+			logger,
+			relayTester.RelayPayloadRetriever,
+			validatorPayloadRetriever),
+		MockRetrievalClient: mockRetrievalClient,
+	}
+}
+
+// buildQuorumBlobAndCert builds a blob and a cert for it, with the blob dispersed to quorum 0
+func buildQuorumBlobAndCert(
+	t *testing.T,
+	tester RelayPayloadRetrieverTester,
+	relayKeys []core.RelayKey,
+) (core.BlobKey, []byte, *verification.EigenDACert) {
+
+	_, blobBytes, eigenDACert := buildBlobAndCert(t, tester, relayKeys)
+	eigenDACert.BlobInclusionInfo.BlobCertificate.BlobHeader.QuorumNumbers = []byte{0}
+	blobKey, err := eigenDACert.ComputeBlobKey()
+	require.NoError(t, err)
+
+	return *blobKey, blobBytes, eigenDACert
+}
+
+// TestFallbackRelaySuccess tests that the validators aren't used when the payload is retrieved from a relay
+func TestFallbackRelaySuccess(t *testing.T) {
+	tester := buildFallbackPayloadRetrieverTester(t)
+	relayKeys := []core.RelayKey{tester.Random.Uint32()}
+	blobKey, blobBytes, blobCert := buildQuorumBlobAndCert(t, tester.RelayPayloadRetrieverTester, relayKeys)
+
+	tester.MockRelayClient.On("GetBlob", mock.Anything, relayKeys[0], blobKey).Return(blobBytes, nil).Once()
+
+	payload, err := tester.FallbackPayloadRetriever.GetPayload(context.Background(), blobCert)
+	require.NoError(t, err)
+	require.NotNil(t, payload)
+
+	tester.MockRelayClient.AssertExpectations(t)
+	tester.MockRetrievalClient.AssertNotCalled(t, "GetBlob")
+}
+
+// TestFallbackToValidators tests that the payload is retrieved from the validators when the relays return a blob
+// which doesn't match the cert
+func TestFallbackToValidators(t *testing.T) {
+	tester := buildFallbackPayloadRetrieverTester(t)
+	relayKeys := []core.RelayKey{tester.Random.Uint32()}
+	blobKey, blobBytes, blobCert := buildQuorumBlobAndCert(t, tester.RelayPayloadRetrieverTester, relayKeys)
+	_, otherBlobBytes, _ := buildBlobAndCert(t, tester.RelayPayloadRetrieverTester, relayKeys)
+
+	tester.MockRelayClient.On("GetBlob", mock.Anything, relayKeys[0], blobKey).Return(otherBlobBytes, nil).Once()
+	tester.MockRetrievalClient.On(
+		"GetBlob", mock.Anything, blobKey, mock.Anything, mock.Anything, mock.Anything, uint8(0),
+	).Return(blobBytes, nil).Once()
+
+	payload, err := tester.FallbackPayloadRetriever.GetPayload(context.Background(), blobCert)
+	require.NoError(t, err)
+	require.NotNil(t, payload)
+
+	tester.MockRelayClient.AssertExpectations(t)
+	tester.MockRetrievalClient.AssertExpectations(t)
+}
+
+// TestFallbackValidatorsReturnInvalidBlob tests that a blob from the validators which doesn't match the cert isn't
+// decoded, and that the errors of both sources are returned
+func TestFallbackValidatorsReturnInvalidBlob(t *testing.T) {
+	tester := buildFallbackPayloadRetrieverTester(t)
+	relayKeys := []core.RelayKey{tester.Random.Uint32()}
+	blobKey, _, blobCert := buildQuorumBlobAndCert(t, tester.RelayPayloadRetrieverTester, relayKeys)
+	_, otherBlobBytes, _ := buildBlobAndCert(t, tester.RelayPayloadRetrieverTester, relayKeys)
+
+	tester.MockRelayClient.On("GetBlob", mock.Anything, relayKeys[0], blobKey).Return(nil, errors.New("relay down")).Once()
+	tester.MockRetrievalClient.On(
+		"GetBlob", mock.Anything, blobKey, mock.Anything, mock.Anything, mock.Anything, uint8(0),
+	).Return(otherBlobBytes, nil).Once()
+
+	payload, err := tester.FallbackPayloadRetriever.GetPayload(context.Background(), blobCert)
+	require.Error(t, err)
+	require.Nil(t, payload)
+	require.Contains(t, err.Error(), "relays")
+	require.Contains(t, err.Error(), "validators")
+
+	tester.MockRelayClient.AssertExpectations(t)
+	tester.MockRetrievalClient.AssertExpectations(t)
+}
@@ -1,10 +1,12 @@
 package test
 
 import (
+	"bytes"
 	"context"
 	"encoding/binary"
 	"errors"
 	"fmt"
+	"io"
 	"math/big"
 	"runtime"
 	"testing"
@@ -157,6 +159,34 @@ func TestGetPayloadSuccess(t *testing.T) {
 	tester.MockRelayClient.AssertExpectations(t)
 }
 
+// TestGetPayloadReader tests that the payload read from the reader matches the payload returned by GetPayload, and
+// that a blob which doesn't match the cert is never delivered through the reader
+func TestGetPayloadReader(t *testing.T) {
+	tester := buildRelayPayloadRetrieverTester(t)
+	relayKeys := []core.RelayKey{tester.Random.Uint32()}
+	blobKey, blobBytes, blobCert := buildBlobAndCert(t, tester, relayKeys)
+	_, otherBlobBytes, _ := buildBlobAndCert(t, tester, relayKeys)
+
+	tester.MockRelayClient.On("GetBlob", mock.Anything, relayKeys[0], blobKey).Return(blobBytes, nil).Twice()
+
+	payload, err := tester.RelayPayloadRetriever.GetPayload(context.Background(), blobCert)
+	require.NoError(t, err)
+
+	reader, err := tester.RelayPayloadRetriever.GetPayloadReader(context.Background(), blobCert)
+	require.NoError(t, err)
+	payloadBytes, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	require.NoError(t, reader.Close())
+	require.True(t, bytes.Equal(payload.Serialize(), payloadBytes))
+
+	tester.MockRelayClient.On("GetBlob", mock.Anything, relayKeys[0], blobKey).Return(otherBlobBytes, nil).Once()
+	reader, err = tester.RelayPayloadRetriever.GetPayloadReader(context.Background(), blobCert)
+	require.Error(t, err)
+	require.Nil(t, reader)
+
+	tester.MockRelayClient.AssertExpectations(t)
+}
+
 // TestRelayCallTimeout verifies that calls to the relay timeout after the expected duration
 func TestRelayCallTimeout(t *testing.T) {
 	tester := buildRelayPayloadRetrieverTester(t)
@@ -8,16 +8,31 @@ import (
 	"google.golang.org/grpc/credentials/insecure"
 )
 
-// getGrpcDialOptions builds the gRPC dial options based on the useSecureGrpcFlag and maxMessageSize.
-func getGrpcDialOptions(useSecureGrpcFlag bool, maxMessageSize uint) []grpc.DialOption {
-	options := []grpc.DialOption{}
+// getGrpcDialOptions builds the gRPC dial options based on the useSecureGrpcFlag and maxMessageSize, and installs the
+// interceptors, which may be nil.
+func getGrpcDialOptions(
+	useSecureGrpcFlag bool,
+	maxMessageSize uint,
+	interceptors *GrpcInterceptors,
+) []grpc.DialOption {
+	var creds credentials.TransportCredentials
 	if useSecureGrpcFlag {
-		options = append(options, grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{})))
+		creds = credentials.NewTLS(&tls.Config{})
 	} else {
-		options = append(options, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		creds = insecure.NewCredentials()
 	}
+	return getGrpcDialOptionsWithCredentials(creds, maxMessageSize, interceptors)
+}
 
+// getGrpcDialOptionsWithCredentials builds the gRPC dial options of a connection secured with the given credentials.
+func getGrpcDialOptionsWithCredentials(
+	creds credentials.TransportCredentials,
+	maxMessageSize uint,
+	interceptors *GrpcInterceptors,
+) []grpc.DialOption {
+	options := []grpc.DialOption{grpc.WithTransportCredentials(creds)}
 	options = append(options, grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(int(maxMessageSize))))
+	options = append(options, interceptors.dialOptions()...)
 
 	return options
 }
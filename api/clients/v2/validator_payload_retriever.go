@@ -3,6 +3,7 @@ package clients
 import (
 	"context"
 	"fmt"
+	"io"
 
 	"github.com/Layr-Labs/eigenda/api/clients/v2/coretypes"
 	"github.com/Layr-Labs/eigenda/api/clients/v2/verification"
@@ -29,7 +30,7 @@ type ValidatorPayloadRetriever struct {
 	g1Srs           []bn254.G1Affine
 }
 
-var _ PayloadRetriever = &ValidatorPayloadRetriever{}
+var _ StreamingPayloadRetriever = &ValidatorPayloadRetriever{}
 
 // BuildValidatorPayloadRetriever builds a ValidatorPayloadRetriever from config structs.
 func BuildValidatorPayloadRetriever(
@@ -66,12 +67,18 @@ func BuildValidatorPayloadRetriever(
 		return nil, fmt.Errorf("new verifier: %w", err)
 	}
 
-	retrievalClient := NewRetrievalClient(
+	retrievalClientConfig := GetDefaultRetrievalClientConfig()
+	retrievalClientConfig.MaxConnectionCount = int(validatorPayloadRetrieverConfig.MaxConnectionCount)
+	retrievalClientConfig.Interceptors = validatorPayloadRetrieverConfig.Interceptors
+	retrievalClient, err := NewRetrievalClient(
 		logger,
 		reader,
 		indexedChainState,
 		kzgVerifier,
-		int(validatorPayloadRetrieverConfig.MaxConnectionCount))
+		*retrievalClientConfig)
+	if err != nil {
+		return nil, fmt.Errorf("new retrieval client: %w", err)
+	}
 
 	return &ValidatorPayloadRetriever{
 		logger:          logger,
@@ -110,6 +117,47 @@ func (pr *ValidatorPayloadRetriever) GetPayload(
 	ctx context.Context,
 	eigenDACert *verification.EigenDACert,
 ) (*coretypes.Payload, error) {
+	return invokeWithMetrics(pr.config.Metrics, "GetPayloadFromValidators", func() (*coretypes.Payload, error) {
+		return pr.getPayload(ctx, eigenDACert)
+	})
+}
+
+// GetPayloadReader is like GetPayload, but returns a reader of the payload, which decodes the payload as it is read.
+// The blob is fully retrieved and verified against the certificate before the reader is returned, so the reader never
+// yields data which doesn't match the certificate.
+//
+// The caller must close the returned reader.
+func (pr *ValidatorPayloadRetriever) GetPayloadReader(
+	ctx context.Context,
+	eigenDACert *verification.EigenDACert,
+) (io.ReadCloser, error) {
+	return invokeWithMetrics(pr.config.Metrics, "GetPayloadReaderFromValidators", func() (io.ReadCloser, error) {
+		blob, err := pr.getVerifiedBlob(ctx, eigenDACert)
+		if err != nil {
+			return nil, err
+		}
+		return decodeVerifiedBlobReader(pr.logger, blob, &pr.config.PayloadClientConfig, eigenDACert)
+	})
+}
+
+// getPayload implements GetPayload, without recording its latency
+func (pr *ValidatorPayloadRetriever) getPayload(
+	ctx context.Context,
+	eigenDACert *verification.EigenDACert,
+) (*coretypes.Payload, error) {
+	blob, err := pr.getVerifiedBlob(ctx, eigenDACert)
+	if err != nil {
+		return nil, err
+	}
+	return decodeVerifiedBlob(pr.logger, blob, &pr.config.PayloadClientConfig, eigenDACert)
+}
+
+// getVerifiedBlob iteratively attempts to retrieve the blob of the cert from the quorums listed in the cert, and
+// returns the first blob which matches the commitment of the cert.
+func (pr *ValidatorPayloadRetriever) getVerifiedBlob(
+	ctx context.Context,
+	eigenDACert *verification.EigenDACert,
+) (*coretypes.Blob, error) {
 
 	blobKey, err := eigenDACert.ComputeBlobKey()
 	if err != nil {
@@ -159,18 +207,7 @@ func (pr *ValidatorPayloadRetriever) GetPayload(
 			continue
 		}
 
-		payload, err := blob.ToPayload(pr.config.PayloadPolynomialForm)
-		if err != nil {
-			pr.logger.Error(
-				`Commitment verification was successful, but conversion from blob to payload failed!
-					This is likely a problem with the local configuration, but could potentially indicate
-					malicious dispersed data. It should not be possible for a commitment to verify for an
-					invalid blob!`,
-				"blobKey", blobKey.Hex(), "quorumID", quorumID, "eigenDACert", eigenDACert, "error", err)
-			return nil, fmt.Errorf("decode blob: %w", err)
-		}
-
-		return payload, nil
+		return blob, nil
 	}
 
 	return nil, fmt.Errorf("unable to retrieve payload from quorums %v", blobHeader.QuorumNumbers)
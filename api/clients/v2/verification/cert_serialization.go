@@ -0,0 +1,127 @@
+package verification
+
+import (
+	"fmt"
+
+	contractEigenDACertVerifier "github.com/Layr-Labs/eigenda/contracts/bindings/EigenDACertVerifier"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// CertSerializationType is the encoding of a serialized EigenDACert
+type CertSerializationType byte
+
+const (
+	// CertSerializationRLP encodes the cert with RLP, which is the encoding used in rollup inbox calldata by
+	// the EigenDA proxy
+	CertSerializationRLP CertSerializationType = iota
+	// CertSerializationABI encodes the cert as the ABI encoded arguments of verifyDACertV2 on the EigenDACertVerifier
+	// contract, which is the encoding expected by contracts which verify certs on-chain
+	CertSerializationABI
+)
+
+func (t CertSerializationType) String() string {
+	switch t {
+	case CertSerializationRLP:
+		return "rlp"
+	case CertSerializationABI:
+		return "abi"
+	default:
+		return fmt.Sprintf("unknown(%d)", byte(t))
+	}
+}
+
+// verifyDACertV2Method is the name of the method of the EigenDACertVerifier contract whose arguments are the ABI
+// encoding of a cert
+const verifyDACertV2Method = "verifyDACertV2"
+
+// Serialize encodes the cert with the given serialization type. The cert is decoded by DeserializeEigenDACert.
+func (c *EigenDACert) Serialize(serializationType CertSerializationType) ([]byte, error) {
+	switch serializationType {
+	case CertSerializationRLP:
+		certBytes, err := rlp.EncodeToBytes(c)
+		if err != nil {
+			return nil, fmt.Errorf("rlp encode cert: %w", err)
+		}
+		return certBytes, nil
+	case CertSerializationABI:
+		arguments, err := verifyDACertV2Arguments()
+		if err != nil {
+			return nil, err
+		}
+		certBytes, err := arguments.Pack(
+			c.BatchHeader,
+			c.BlobInclusionInfo,
+			c.NonSignerStakesAndSignature,
+			c.SignedQuorumNumbers)
+		if err != nil {
+			return nil, fmt.Errorf("abi encode cert: %w", err)
+		}
+		return certBytes, nil
+	default:
+		return nil, fmt.Errorf("unknown cert serialization type %v", serializationType)
+	}
+}
+
+// DeserializeEigenDACert decodes a cert encoded with the given serialization type
+func DeserializeEigenDACert(certBytes []byte, serializationType CertSerializationType) (*EigenDACert, error) {
+	switch serializationType {
+	case CertSerializationRLP:
+		cert := &EigenDACert{}
+		if err := rlp.DecodeBytes(certBytes, cert); err != nil {
+			return nil, fmt.Errorf("rlp decode cert: %w", err)
+		}
+		return cert, nil
+	case CertSerializationABI:
+		return abiDecodeCert(certBytes)
+	default:
+		return nil, fmt.Errorf("unknown cert serialization type %v", serializationType)
+	}
+}
+
+// abiDecodeCert decodes a cert encoded as the ABI encoded arguments of verifyDACertV2
+func abiDecodeCert(certBytes []byte) (*EigenDACert, error) {
+	arguments, err := verifyDACertV2Arguments()
+	if err != nil {
+		return nil, err
+	}
+	values, err := arguments.Unpack(certBytes)
+	if err != nil {
+		return nil, fmt.Errorf("abi decode cert: %w", err)
+	}
+	if len(values) != 4 {
+		return nil, fmt.Errorf("abi decode cert: expected 4 values, got %d", len(values))
+	}
+
+	// the values are anonymous structs with the same fields as the bindings, which ConvertType copies into the bindings
+	cert := &EigenDACert{
+		BatchHeader: *abi.ConvertType(
+			values[0], new(contractEigenDACertVerifier.BatchHeaderV2)).(*contractEigenDACertVerifier.BatchHeaderV2),
+		BlobInclusionInfo: *abi.ConvertType(
+			values[1], new(contractEigenDACertVerifier.BlobInclusionInfo)).(*contractEigenDACertVerifier.BlobInclusionInfo),
+		NonSignerStakesAndSignature: *abi.ConvertType(
+			values[2],
+			new(contractEigenDACertVerifier.NonSignerStakesAndSignature),
+		).(*contractEigenDACertVerifier.NonSignerStakesAndSignature),
+	}
+	signedQuorumNumbers, ok := values[3].([]byte)
+	if !ok {
+		return nil, fmt.Errorf("abi decode cert: signed quorum numbers have unexpected type %T", values[3])
+	}
+	cert.SignedQuorumNumbers = signedQuorumNumbers
+
+	return cert, nil
+}
+
+// verifyDACertV2Arguments returns the arguments of verifyDACertV2, from the ABI of the EigenDACertVerifier contract
+func verifyDACertV2Arguments() (abi.Arguments, error) {
+	contractAbi, err := contractEigenDACertVerifier.ContractEigenDACertVerifierMetaData.GetAbi()
+	if err != nil {
+		return nil, fmt.Errorf("get cert verifier abi: %w", err)
+	}
+	method, ok := contractAbi.Methods[verifyDACertV2Method]
+	if !ok {
+		return nil, fmt.Errorf("cert verifier abi has no method %s", verifyDACertV2Method)
+	}
+	return method.Inputs, nil
+}
@@ -0,0 +1,59 @@
+package verification
+
+import (
+	"math/big"
+	"testing"
+
+	contractEigenDACertVerifier "github.com/Layr-Labs/eigenda/contracts/bindings/EigenDACertVerifier"
+	v2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCertSerialization(t *testing.T) {
+	blobCerts := []*v2.BlobCertificate{
+		makeBlobCertificate(t, "0x1234"),
+		makeBlobCertificate(t, "0x5678"),
+	}
+	cert := buildCert(t, blobCerts, 1)
+	cert.BatchHeader.ReferenceBlockNumber = 100
+	point := contractEigenDACertVerifier.BN254G1Point{X: big.NewInt(1), Y: big.NewInt(2)}
+	cert.NonSignerStakesAndSignature = contractEigenDACertVerifier.NonSignerStakesAndSignature{
+		NonSignerQuorumBitmapIndices: []uint32{1, 2},
+		NonSignerPubkeys:             []contractEigenDACertVerifier.BN254G1Point{point, point},
+		QuorumApks:                   []contractEigenDACertVerifier.BN254G1Point{point},
+		ApkG2: contractEigenDACertVerifier.BN254G2Point{
+			X: [2]*big.Int{big.NewInt(3), big.NewInt(4)},
+			Y: [2]*big.Int{big.NewInt(5), big.NewInt(6)},
+		},
+		Sigma:                 point,
+		QuorumApkIndices:      []uint32{3},
+		TotalStakeIndices:     []uint32{4},
+		NonSignerStakeIndices: [][]uint32{{5, 6}},
+	}
+	cert.SignedQuorumNumbers = []byte{0, 1}
+
+	for _, serializationType := range []CertSerializationType{CertSerializationRLP, CertSerializationABI} {
+		t.Run(serializationType.String(), func(t *testing.T) {
+			certBytes, err := cert.Serialize(serializationType)
+			require.NoError(t, err)
+
+			decoded, err := DeserializeEigenDACert(certBytes, serializationType)
+			require.NoError(t, err)
+			require.Equal(t, cert, decoded)
+
+			// the decoded cert is the same blob, and is still included in the batch
+			blobKey, err := cert.ComputeBlobKey()
+			require.NoError(t, err)
+			decodedBlobKey, err := decoded.ComputeBlobKey()
+			require.NoError(t, err)
+			require.Equal(t, blobKey, decodedBlobKey)
+			require.NoError(t, decoded.VerifyBlobInclusion())
+
+			_, err = DeserializeEigenDACert(certBytes[:len(certBytes)/2], serializationType)
+			require.Error(t, err)
+		})
+	}
+
+	_, err := cert.Serialize(CertSerializationType(7))
+	require.Error(t, err)
+}
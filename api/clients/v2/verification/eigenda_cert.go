@@ -5,7 +5,10 @@ import (
 
 	disperser "github.com/Layr-Labs/eigenda/api/grpc/disperser/v2"
 	contractEigenDACertVerifier "github.com/Layr-Labs/eigenda/contracts/bindings/EigenDACertVerifier"
+	"github.com/Layr-Labs/eigenda/core"
 	v2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/wealdtech/go-merkletree/v2"
+	"github.com/wealdtech/go-merkletree/v2/keccak256"
 )
 
 // EigenDACert contains all data necessary to retrieve and validate a blob
@@ -76,3 +79,54 @@ func (c *EigenDACert) ComputeBlobKey() (*v2.BlobKey, error) {
 
 	return &blobKey, nil
 }
+
+// ComputeBlobCertificateHash computes the hash of the BlobCertificate that belongs to the EigenDACert. This is the
+// leaf which is committed to by the BatchRoot of the cert's BatchHeader.
+func (c *EigenDACert) ComputeBlobCertificateHash() ([32]byte, error) {
+	blobKey, err := c.ComputeBlobKey()
+	if err != nil {
+		return [32]byte{}, fmt.Errorf("compute blob key: %w", err)
+	}
+
+	blobCertificate := c.BlobInclusionInfo.BlobCertificate
+	certHash, err := v2.ComputeBlobCertificateHash(*blobKey, blobCertificate.Signature, blobCertificate.RelayKeys)
+	if err != nil {
+		return [32]byte{}, fmt.Errorf("compute blob certificate hash: %w", err)
+	}
+
+	return certHash, nil
+}
+
+// VerifyBlobInclusion checks the merkle inclusion proof contained in the EigenDACert against the BatchRoot of the
+// cert's BatchHeader.
+//
+// This method returns nil if the BlobCertificate is proven to be included in the batch. Otherwise, it returns an error.
+func (c *EigenDACert) VerifyBlobInclusion() error {
+	certHash, err := c.ComputeBlobCertificateHash()
+	if err != nil {
+		return err
+	}
+
+	proof, err := core.DeserializeMerkleProof(
+		c.BlobInclusionInfo.InclusionProof,
+		uint64(c.BlobInclusionInfo.BlobIndex))
+	if err != nil {
+		return fmt.Errorf("deserialize inclusion proof: %w", err)
+	}
+
+	verified, err := merkletree.VerifyProofUsing(
+		certHash[:],
+		false,
+		proof,
+		[][]byte{c.BatchHeader.BatchRoot[:]},
+		keccak256.New())
+	if err != nil {
+		return fmt.Errorf("verify inclusion proof: %w", err)
+	}
+	if !verified {
+		return fmt.Errorf("blob certificate at index %d is not included in batch with root %x",
+			c.BlobInclusionInfo.BlobIndex, c.BatchHeader.BatchRoot)
+	}
+
+	return nil
+}
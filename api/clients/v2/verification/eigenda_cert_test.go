@@ -0,0 +1,115 @@
+package verification
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/core"
+	v2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/stretchr/testify/require"
+)
+
+func makeBlobCertificate(t *testing.T, accountID string) *v2.BlobCertificate {
+	_, _, g1Gen, g2Gen := bn254.Generators()
+	lengthProof := encoding.LengthProof(g2Gen)
+
+	return &v2.BlobCertificate{
+		BlobHeader: &v2.BlobHeader{
+			BlobVersion: 0,
+			BlobCommitments: encoding.BlobCommitments{
+				Commitment:       (*encoding.G1Commitment)(&g1Gen),
+				LengthCommitment: (*encoding.G2Commitment)(&g2Gen),
+				LengthProof:      &lengthProof,
+				Length:           16,
+			},
+			QuorumNumbers: []core.QuorumID{0, 1},
+			PaymentMetadata: core.PaymentMetadata{
+				AccountID:         accountID,
+				Timestamp:         5,
+				CumulativePayment: big.NewInt(100),
+			},
+		},
+		Signature: []byte{1, 2, 3},
+		RelayKeys: []v2.RelayKey{0, 1},
+	}
+}
+
+// buildCert builds an EigenDACert for the blob certificate at blobIndex, within a batch containing all blobCerts
+func buildCert(t *testing.T, blobCerts []*v2.BlobCertificate, blobIndex uint32) *EigenDACert {
+	tree, err := v2.BuildMerkleTree(blobCerts)
+	require.NoError(t, err)
+
+	proof, err := tree.GenerateProofWithIndex(uint64(blobIndex), 0)
+	require.NoError(t, err)
+
+	inclusionInfo := &v2.BlobInclusionInfo{
+		BlobIndex:      blobIndex,
+		InclusionProof: core.SerializeMerkleProof(proof),
+	}
+	inclusionInfoProto, err := inclusionInfo.ToProtobuf(blobCerts[blobIndex])
+	require.NoError(t, err)
+
+	inclusionInfoBinding, err := InclusionInfoProtoToBinding(inclusionInfoProto)
+	require.NoError(t, err)
+
+	cert := &EigenDACert{
+		BlobInclusionInfo: *inclusionInfoBinding,
+	}
+	copy(cert.BatchHeader.BatchRoot[:], tree.Root())
+
+	return cert
+}
+
+func TestVerifyBlobInclusion(t *testing.T) {
+	blobCerts := []*v2.BlobCertificate{
+		makeBlobCertificate(t, "0x1234"),
+		makeBlobCertificate(t, "0x5678"),
+		makeBlobCertificate(t, "0x9abc"),
+	}
+
+	for i := range blobCerts {
+		cert := buildCert(t, blobCerts, uint32(i))
+		require.NoError(t, cert.VerifyBlobInclusion())
+
+		expectedHash, err := blobCerts[i].Hash()
+		require.NoError(t, err)
+		certHash, err := cert.ComputeBlobCertificateHash()
+		require.NoError(t, err)
+		require.Equal(t, expectedHash, certHash)
+	}
+}
+
+func TestVerifyBlobInclusionFailure(t *testing.T) {
+	blobCerts := []*v2.BlobCertificate{
+		makeBlobCertificate(t, "0x1234"),
+		makeBlobCertificate(t, "0x5678"),
+		makeBlobCertificate(t, "0x9abc"),
+	}
+
+	// wrong index
+	cert := buildCert(t, blobCerts, 1)
+	cert.BlobInclusionInfo.BlobIndex = 2
+	require.Error(t, cert.VerifyBlobInclusion())
+
+	// tampered proof
+	cert = buildCert(t, blobCerts, 1)
+	cert.BlobInclusionInfo.InclusionProof[0] ^= 0xff
+	require.Error(t, cert.VerifyBlobInclusion())
+
+	// malformed proof
+	cert = buildCert(t, blobCerts, 1)
+	cert.BlobInclusionInfo.InclusionProof = cert.BlobInclusionInfo.InclusionProof[1:]
+	require.Error(t, cert.VerifyBlobInclusion())
+
+	// tampered blob certificate
+	cert = buildCert(t, blobCerts, 1)
+	cert.BlobInclusionInfo.BlobCertificate.RelayKeys = []uint32{2}
+	require.Error(t, cert.VerifyBlobInclusion())
+
+	// wrong batch root
+	cert = buildCert(t, blobCerts, 1)
+	cert.BatchHeader.BatchRoot[0] ^= 0xff
+	require.Error(t, cert.VerifyBlobInclusion())
+}
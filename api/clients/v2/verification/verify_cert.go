@@ -0,0 +1,126 @@
+package verification
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	verifierBindings "github.com/Layr-Labs/eigenda/contracts/bindings/EigenDACertVerifier"
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+)
+
+// VerifyCert verifies an EigenDACert, without requiring any of the other EigenDA client machinery. It is intended for
+// systems which only need to check certs, such as bridges and fraud proof systems.
+//
+// The following checks are performed, and the first failing check is returned as an error:
+//
+//  1. The cert is structurally sound: the blob has at least one quorum, without duplicates, and every quorum of the
+//     blob was signed.
+//  2. The merkle inclusion proof of the blob certificate is valid against the batch root of the batch header.
+//  3. The blob is dispersed to every quorum required by the EigenDACertVerifier contract.
+//  4. The EigenDACertVerifier contract accepts the cert, which checks the signature over the batch header, and that
+//     each signed quorum meets its confirmation threshold.
+//
+// Checks 1 and 2 are local, and checks 3 and 4 are eth_calls against the latest block known to ethClient. The
+// reference block of the cert must not be ahead of that block, otherwise verification fails.
+//
+// The cert doesn't contain the blob data, so VerifyCert doesn't check that the blob matches the commitment of the cert.
+// Callers who have the blob can check this with VerifyCertCommitment.
+func VerifyCert(
+	ctx context.Context,
+	cert *EigenDACert,
+	// ethClient is any client which can make eth_calls, e.g. an ethclient.Client
+	ethClient bind.ContractCaller,
+	// certVerifierAddress is the address of the EigenDACertVerifier contract to verify the cert against
+	certVerifierAddress gethcommon.Address,
+) error {
+	blobQuorums := cert.BlobInclusionInfo.BlobCertificate.BlobHeader.QuorumNumbers
+
+	err := checkCertQuorums(cert)
+	if err != nil {
+		return err
+	}
+
+	err = cert.VerifyBlobInclusion()
+	if err != nil {
+		return fmt.Errorf("verify blob inclusion: %w", err)
+	}
+
+	certVerifierCaller, err := verifierBindings.NewContractEigenDACertVerifierCaller(certVerifierAddress, ethClient)
+	if err != nil {
+		return fmt.Errorf("bind to verifier contract at %s: %w", certVerifierAddress.Hex(), err)
+	}
+
+	requiredQuorums, err := certVerifierCaller.QuorumNumbersRequiredV2(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return fmt.Errorf("get quorum numbers required: %w", err)
+	}
+	for _, quorum := range requiredQuorums {
+		if !slices.Contains(blobQuorums, quorum) {
+			return fmt.Errorf("blob isn't dispersed to required quorum %d", quorum)
+		}
+	}
+
+	err = certVerifierCaller.VerifyDACertV2(
+		&bind.CallOpts{Context: ctx},
+		cert.BatchHeader,
+		cert.BlobInclusionInfo,
+		cert.NonSignerStakesAndSignature,
+		cert.SignedQuorumNumbers)
+	if err != nil {
+		return fmt.Errorf("verify cert v2: %w", err)
+	}
+
+	return nil
+}
+
+// VerifyCertCommitment checks that the commitment of the cert is the kzg-bn254 commitment of the blob, i.e. that the
+// blob is the data the cert attests to. g1Srs must contain at least as many points as the blob has field elements.
+func VerifyCertCommitment(cert *EigenDACert, g1Srs []bn254.G1Affine, blobBytes []byte) error {
+	certCommitment := cert.BlobInclusionInfo.BlobCertificate.BlobHeader.Commitment.Commitment
+
+	claimedCommitment := &encoding.G1Commitment{}
+	claimedCommitment.X.SetBigInt(certCommitment.X)
+	claimedCommitment.Y.SetBigInt(certCommitment.Y)
+
+	equal, err := GenerateAndCompareBlobCommitment(g1Srs, blobBytes, claimedCommitment)
+	if err != nil {
+		return fmt.Errorf("generate and compare blob commitment: %w", err)
+	}
+	if !equal {
+		return fmt.Errorf("commitment of blob doesn't match the commitment of the cert")
+	}
+
+	return nil
+}
+
+// checkCertQuorums checks that the quorums of the cert are consistent with each other
+func checkCertQuorums(cert *EigenDACert) error {
+	blobQuorums := cert.BlobInclusionInfo.BlobCertificate.BlobHeader.QuorumNumbers
+	if len(blobQuorums) == 0 {
+		return fmt.Errorf("blob has no quorums")
+	}
+
+	seenQuorums := make(map[uint8]struct{}, len(blobQuorums))
+	for _, quorum := range blobQuorums {
+		if _, ok := seenQuorums[quorum]; ok {
+			return fmt.Errorf("blob has duplicate quorum %d", quorum)
+		}
+		seenQuorums[quorum] = struct{}{}
+
+		if !slices.Contains(cert.SignedQuorumNumbers, quorum) {
+			return fmt.Errorf("quorum %d of the blob wasn't signed", quorum)
+		}
+	}
+
+	quorumApkCount := len(cert.NonSignerStakesAndSignature.QuorumApks)
+	if quorumApkCount != len(cert.SignedQuorumNumbers) {
+		return fmt.Errorf("cert has %d quorum apks for %d signed quorums",
+			quorumApkCount, len(cert.SignedQuorumNumbers))
+	}
+
+	return nil
+}
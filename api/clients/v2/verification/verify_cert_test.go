@@ -0,0 +1,111 @@
+package verification
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	verifierBindings "github.com/Layr-Labs/eigenda/contracts/bindings/EigenDACertVerifier"
+	v2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/ethereum/go-ethereum"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeCertVerifierContract answers the eth_calls made by VerifyCert
+type fakeCertVerifierContract struct {
+	t               *testing.T
+	requiredQuorums []byte
+	verifyErr       error
+	verifyCalls     int
+}
+
+func (f *fakeCertVerifierContract) CodeAt(
+	ctx context.Context,
+	contract gethcommon.Address,
+	blockNumber *big.Int,
+) ([]byte, error) {
+	return []byte{1}, nil
+}
+
+func (f *fakeCertVerifierContract) CallContract(
+	ctx context.Context,
+	call ethereum.CallMsg,
+	blockNumber *big.Int,
+) ([]byte, error) {
+	contractAbi, err := verifierBindings.ContractEigenDACertVerifierMetaData.GetAbi()
+	require.NoError(f.t, err)
+	method, err := contractAbi.MethodById(call.Data[:4])
+	require.NoError(f.t, err)
+
+	switch method.Name {
+	case "quorumNumbersRequiredV2":
+		return method.Outputs.Pack(f.requiredQuorums)
+	case "verifyDACertV2":
+		f.verifyCalls++
+		return nil, f.verifyErr
+	default:
+		require.FailNow(f.t, "unexpected call", method.Name)
+		return nil, nil
+	}
+}
+
+func buildVerifiableCert(t *testing.T) *EigenDACert {
+	blobCerts := []*v2.BlobCertificate{
+		makeBlobCertificate(t, "0x1234"),
+		makeBlobCertificate(t, "0x5678"),
+	}
+	cert := buildCert(t, blobCerts, 1)
+	cert.SignedQuorumNumbers = []byte{0, 1}
+	zeroG1Point := verifierBindings.BN254G1Point{X: big.NewInt(0), Y: big.NewInt(0)}
+	cert.NonSignerStakesAndSignature.QuorumApks = []verifierBindings.BN254G1Point{zeroG1Point, zeroG1Point}
+	cert.NonSignerStakesAndSignature.Sigma = zeroG1Point
+	cert.NonSignerStakesAndSignature.ApkG2 = verifierBindings.BN254G2Point{
+		X: [2]*big.Int{big.NewInt(0), big.NewInt(0)},
+		Y: [2]*big.Int{big.NewInt(0), big.NewInt(0)},
+	}
+	return cert
+}
+
+func TestVerifyCert(t *testing.T) {
+	contract := &fakeCertVerifierContract{t: t, requiredQuorums: []byte{0, 1}}
+	cert := buildVerifiableCert(t)
+
+	err := VerifyCert(context.Background(), cert, contract, gethcommon.Address{})
+	require.NoError(t, err)
+	require.Equal(t, 1, contract.verifyCalls)
+
+	// the contract rejects the cert
+	contract.verifyErr = errors.New("execution reverted")
+	err = VerifyCert(context.Background(), cert, contract, gethcommon.Address{})
+	require.Error(t, err)
+}
+
+func TestVerifyCertFailure(t *testing.T) {
+	contract := &fakeCertVerifierContract{t: t, requiredQuorums: []byte{0, 1}}
+
+	// a blob quorum isn't signed
+	cert := buildVerifiableCert(t)
+	cert.SignedQuorumNumbers = []byte{0}
+	cert.NonSignerStakesAndSignature.QuorumApks = cert.NonSignerStakesAndSignature.QuorumApks[:1]
+	require.Error(t, VerifyCert(context.Background(), cert, contract, gethcommon.Address{}))
+
+	// missing quorum apk
+	cert = buildVerifiableCert(t)
+	cert.NonSignerStakesAndSignature.QuorumApks = cert.NonSignerStakesAndSignature.QuorumApks[:1]
+	require.Error(t, VerifyCert(context.Background(), cert, contract, gethcommon.Address{}))
+
+	// invalid inclusion proof
+	cert = buildVerifiableCert(t)
+	cert.BatchHeader.BatchRoot[0] ^= 0xff
+	require.Error(t, VerifyCert(context.Background(), cert, contract, gethcommon.Address{}))
+
+	// a required quorum is missing from the blob
+	contract.requiredQuorums = []byte{0, 2}
+	cert = buildVerifiableCert(t)
+	require.Error(t, VerifyCert(context.Background(), cert, contract, gethcommon.Address{}))
+
+	// none of the failures reach the final contract check
+	require.Equal(t, 0, contract.verifyCalls)
+}
@@ -2,3 +2,29 @@ package api
 
 // EigenLabsDisperserID is the ID of the disperser that is managed by Eigen Labs.
 const EigenLabsDisperserID = uint32(0)
+
+// The versions of the node APIs, reported by nodes in their NodeInfo replies.
+const (
+	NodeAPIVersionV1 = "v1"
+	NodeAPIVersionV2 = "v2"
+)
+
+// The optional features of nodes, reported in their NodeInfo replies so that dispersers can adapt to operators which
+// haven't upgraded yet.
+const (
+	// NodeFeatureStoreChunksStream is the client-streaming StoreChunksStream RPC of the v1 dispersal API.
+	NodeFeatureStoreChunksStream = "store_chunks_stream"
+	// NodeFeatureBatchVerification is the verification of the chunk proofs of the blobs of a v2 batch in sub-batches,
+	// each with a single pairing check.
+	NodeFeatureBatchVerification = "batch_verification"
+)
+
+// DefaultRetentionPeriodHeader is the gRPC header in which the disperser reports its default retention period in
+// seconds with a GetPaymentState reply. Blobs requesting a shorter retention period are charged a prorated amount
+// relative to it.
+const DefaultRetentionPeriodHeader = "default-retention-period-seconds"
+
+// OperatorIDHeader is the gRPC metadata in which a disperser says, in hex, which operator a dispersal request to a node
+// is for. A node rotating its keys is registered as two operators with the same socket, and attests to each request
+// as the operator it's for.
+const OperatorIDHeader = "operator-id"
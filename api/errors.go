@@ -2,9 +2,12 @@ package api
 
 import (
 	"fmt"
+	"time"
 
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
 )
 
 // The canonical errors from the EigenDA gRPC API endpoints.
@@ -28,6 +31,67 @@ func NewErrorInvalidArg(msg string) error {
 	return newErrorGRPC(codes.InvalidArgument, msg)
 }
 
+// FieldViolation describes a field of a request which is invalid.
+type FieldViolation struct {
+	// Field is the path to the invalid field, e.g. "blob_certificates[1].blob_header.commitment.length"
+	Field string
+	// Description explains why the field is invalid
+	Description string
+}
+
+// HTTP Mapping: 400 Bad Request
+// The returned error carries a google.rpc.BadRequest detail listing the invalid fields of the request.
+// Clients can read them back with FieldViolations.
+func NewErrorInvalidArgWithFieldViolations(msg string, violations []FieldViolation) error {
+	st := status.New(codes.InvalidArgument, msg)
+	badRequest := &errdetails.BadRequest{
+		FieldViolations: make([]*errdetails.BadRequest_FieldViolation, len(violations)),
+	}
+	for i, violation := range violations {
+		badRequest.FieldViolations[i] = &errdetails.BadRequest_FieldViolation{
+			Field:       violation.Field,
+			Description: violation.Description,
+		}
+	}
+	stWithDetails, err := st.WithDetails(badRequest)
+	if err != nil {
+		// Only fails if the detail can't be marshalled, in which case fall back to the plain error
+		return st.Err()
+	}
+	return stWithDetails.Err()
+}
+
+// FieldViolations returns the field violations carried by a grpc error created with
+// NewErrorInvalidArgWithFieldViolations. It returns nil if the error doesn't carry any.
+func FieldViolations(err error) []FieldViolation {
+	st, ok := status.FromError(err)
+	if !ok {
+		return nil
+	}
+	var violations []FieldViolation
+	for _, detail := range st.Details() {
+		if badRequest, ok := detail.(*errdetails.BadRequest); ok {
+			for _, violation := range badRequest.GetFieldViolations() {
+				violations = append(violations, FieldViolation{
+					Field:       violation.GetField(),
+					Description: violation.GetDescription(),
+				})
+			}
+		}
+	}
+	return violations
+}
+
+// HTTP Mapping: 401 Unauthorized
+func NewErrorUnauthenticated(msg string) error {
+	return newErrorGRPC(codes.Unauthenticated, msg)
+}
+
+// HTTP Mapping: 403 Forbidden
+func NewErrorPermissionDenied(msg string) error {
+	return newErrorGRPC(codes.PermissionDenied, msg)
+}
+
 // HTTP Mapping: 404 Not Found
 func NewErrorNotFound(msg string) error {
 	return newErrorGRPC(codes.NotFound, msg)
@@ -38,6 +102,87 @@ func NewErrorResourceExhausted(msg string) error {
 	return newErrorGRPC(codes.ResourceExhausted, msg)
 }
 
+// HTTP Mapping: 429 Too Many Requests
+// The returned error carries a google.rpc.RetryInfo detail telling the client how long to wait before retrying.
+// Clients can read it back with RetryAfter.
+func NewErrorResourceExhaustedWithRetryAfter(msg string, retryAfter time.Duration) error {
+	st := status.New(codes.ResourceExhausted, msg)
+	stWithDetails, err := st.WithDetails(&errdetails.RetryInfo{
+		RetryDelay: durationpb.New(retryAfter),
+	})
+	if err != nil {
+		// Only fails if the detail can't be marshalled, in which case fall back to the plain error
+		return st.Err()
+	}
+	return stWithDetails.Err()
+}
+
+// RetryAfter returns the retry delay carried by a grpc error created with NewErrorResourceExhaustedWithRetryAfter.
+// The second return value is false if the error doesn't carry a retry delay.
+func RetryAfter(err error) (time.Duration, bool) {
+	st, ok := status.FromError(err)
+	if !ok {
+		return 0, false
+	}
+	for _, detail := range st.Details() {
+		if retryInfo, ok := detail.(*errdetails.RetryInfo); ok && retryInfo.GetRetryDelay() != nil {
+			return retryInfo.GetRetryDelay().AsDuration(), true
+		}
+	}
+	return 0, false
+}
+
+// QuotaViolation describes a quota which a request would exceed.
+type QuotaViolation struct {
+	// Subject identifies the quota, e.g. "quorum:1"
+	Subject string
+	// Description explains how the quota is exceeded
+	Description string
+}
+
+// HTTP Mapping: 429 Too Many Requests
+// The returned error carries a google.rpc.QuotaFailure detail listing the quotas the request would exceed.
+// Clients can read them back with QuotaViolations.
+func NewErrorResourceExhaustedWithQuotaViolations(msg string, violations []QuotaViolation) error {
+	st := status.New(codes.ResourceExhausted, msg)
+	quotaFailure := &errdetails.QuotaFailure{
+		Violations: make([]*errdetails.QuotaFailure_Violation, len(violations)),
+	}
+	for i, violation := range violations {
+		quotaFailure.Violations[i] = &errdetails.QuotaFailure_Violation{
+			Subject:     violation.Subject,
+			Description: violation.Description,
+		}
+	}
+	stWithDetails, err := st.WithDetails(quotaFailure)
+	if err != nil {
+		// Only fails if the detail can't be marshalled, in which case fall back to the plain error
+		return st.Err()
+	}
+	return stWithDetails.Err()
+}
+
+// QuotaViolations returns the quota violations carried by a grpc error created with
+// NewErrorResourceExhaustedWithQuotaViolations. It returns nil if the error doesn't carry any.
+func QuotaViolations(err error) []QuotaViolation {
+	st, ok := status.FromError(err)
+	if !ok {
+		return nil
+	}
+	var violations []QuotaViolation
+	for _, detail := range st.Details() {
+		if quotaFailure, ok := detail.(*errdetails.QuotaFailure); ok {
+			for _, violation := range quotaFailure.GetViolations() {
+				violations = append(violations, QuotaViolation{
+					Subject:     violation.GetSubject(),
+					Description: violation.GetDescription(),
+				})
+			}
+		}
+	}
+	return violations
+}
+
 // HTTP Mapping: 500 Internal Server Error
 func NewErrorInternal(msg string) error {
 	return newErrorGRPC(codes.Internal, msg)
@@ -53,6 +198,11 @@ func NewErrorUnimplemented() error {
 	return newErrorGRPC(codes.Unimplemented, "not implemented")
 }
 
+// HTTP Mapping: 503 Service Unavailable
+func NewErrorUnavailable(msg string) error {
+	return newErrorGRPC(codes.Unavailable, msg)
+}
+
 // HTTP Mapping: 504 Gateway Timeout
 func NewErrorDeadlineExceeded(msg string) error {
 	return newErrorGRPC(codes.DeadlineExceeded, msg)
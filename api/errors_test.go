@@ -4,6 +4,10 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func TestErrorFailoverErrorsIs(t *testing.T) {
@@ -44,3 +48,80 @@ func TestErrorFailoverZeroValue(t *testing.T) {
 		t.Error("should return 'Failover' for zero value")
 	}
 }
+
+func TestErrorResourceExhaustedWithRetryAfter(t *testing.T) {
+	err := NewErrorResourceExhaustedWithRetryAfter("queue is full", 3*time.Second)
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("expected ResourceExhausted, got %v", status.Code(err))
+	}
+
+	retryAfter, ok := RetryAfter(err)
+	if !ok {
+		t.Fatal("expected error to carry a retry delay")
+	}
+	if retryAfter != 3*time.Second {
+		t.Errorf("expected retry delay of 3s, got %v", retryAfter)
+	}
+
+	if _, ok := RetryAfter(NewErrorResourceExhausted("queue is full")); ok {
+		t.Error("should not find a retry delay on an error without one")
+	}
+	if _, ok := RetryAfter(fmt.Errorf("not a grpc error")); ok {
+		t.Error("should not find a retry delay on a non-grpc error")
+	}
+}
+
+func TestErrorResourceExhaustedWithQuotaViolations(t *testing.T) {
+	violations := []QuotaViolation{
+		{Subject: "quorum:0", Description: "disk quota exceeded"},
+		{Subject: "quorum:1", Description: "disk quota exceeded"},
+	}
+	err := NewErrorResourceExhaustedWithQuotaViolations("disk quota exceeded", violations)
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("expected ResourceExhausted, got %v", status.Code(err))
+	}
+
+	got := QuotaViolations(err)
+	if len(got) != len(violations) {
+		t.Fatalf("expected %d violations, got %d", len(violations), len(got))
+	}
+	for i := range violations {
+		if got[i] != violations[i] {
+			t.Errorf("expected violation %v, got %v", violations[i], got[i])
+		}
+	}
+
+	if QuotaViolations(NewErrorResourceExhausted("disk quota exceeded")) != nil {
+		t.Error("should not find violations on an error without any")
+	}
+	if QuotaViolations(fmt.Errorf("not a grpc error")) != nil {
+		t.Error("should not find violations on a non-grpc error")
+	}
+}
+
+func TestErrorInvalidArgWithFieldViolations(t *testing.T) {
+	violations := []FieldViolation{
+		{Field: "blob_certificates[0].blob_header.commitment.length", Description: "blob too large"},
+	}
+	err := NewErrorInvalidArgWithFieldViolations("blob too large", violations)
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument, got %v", status.Code(err))
+	}
+
+	got := FieldViolations(err)
+	if len(got) != len(violations) {
+		t.Fatalf("expected %d violations, got %d", len(violations), len(got))
+	}
+	for i := range violations {
+		if got[i] != violations[i] {
+			t.Errorf("expected violation %v, got %v", violations[i], got[i])
+		}
+	}
+
+	if FieldViolations(NewErrorInvalidArg("blob too large")) != nil {
+		t.Error("should not find violations on an error without any")
+	}
+	if FieldViolations(fmt.Errorf("not a grpc error")) != nil {
+		t.Error("should not find violations on a non-grpc error")
+	}
+}
@@ -19,10 +19,13 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	Disperser_DisperseBlob_FullMethodName      = "/disperser.v2.Disperser/DisperseBlob"
-	Disperser_GetBlobStatus_FullMethodName     = "/disperser.v2.Disperser/GetBlobStatus"
-	Disperser_GetBlobCommitment_FullMethodName = "/disperser.v2.Disperser/GetBlobCommitment"
-	Disperser_GetPaymentState_FullMethodName   = "/disperser.v2.Disperser/GetPaymentState"
+	Disperser_DisperseBlob_FullMethodName                 = "/disperser.v2.Disperser/DisperseBlob"
+	Disperser_GetBlobStatus_FullMethodName                = "/disperser.v2.Disperser/GetBlobStatus"
+	Disperser_GetBlobStatuses_FullMethodName              = "/disperser.v2.Disperser/GetBlobStatuses"
+	Disperser_SubscribeCertificationEvents_FullMethodName = "/disperser.v2.Disperser/SubscribeCertificationEvents"
+	Disperser_GetBlobCommitment_FullMethodName            = "/disperser.v2.Disperser/GetBlobCommitment"
+	Disperser_CheckBlobIntegrity_FullMethodName           = "/disperser.v2.Disperser/CheckBlobIntegrity"
+	Disperser_GetPaymentState_FullMethodName              = "/disperser.v2.Disperser/GetPaymentState"
 )
 
 // DisperserClient is the client API for Disperser service.
@@ -36,8 +39,19 @@ type DisperserClient interface {
 	DisperseBlob(ctx context.Context, in *DisperseBlobRequest, opts ...grpc.CallOption) (*DisperseBlobReply, error)
 	// GetBlobStatus is meant to be polled for the blob status.
 	GetBlobStatus(ctx context.Context, in *BlobStatusRequest, opts ...grpc.CallOption) (*BlobStatusReply, error)
+	// GetBlobStatuses returns the statuses of multiple blobs in a single call. It is intended for clients
+	// which track many in-flight blobs at once, and would otherwise need to poll GetBlobStatus for each of them.
+	GetBlobStatuses(ctx context.Context, in *BlobStatusesRequest, opts ...grpc.CallOption) (*BlobStatusesReply, error)
+	// SubscribeCertificationEvents streams an event whenever a batch is certified by the DA nodes, and whenever a blob
+	// becomes certified. It allows downstream indexers and rollup infrastructure to react to certification as it
+	// happens, rather than polling GetBlobStatus or the chain.
+	SubscribeCertificationEvents(ctx context.Context, in *SubscribeCertificationEventsRequest, opts ...grpc.CallOption) (Disperser_SubscribeCertificationEventsClient, error)
 	// GetBlobCommitment is a utility method that calculates commitment for a blob payload.
 	GetBlobCommitment(ctx context.Context, in *BlobCommitmentRequest, opts ...grpc.CallOption) (*BlobCommitmentReply, error)
+	// CheckBlobIntegrity re-reads a stored blob, recomputes its commitment, and compares it against the commitment in the
+	// stored blob header. The result is returned as an attestation signed by the disperser, which can be used for audits
+	// and to diagnose suspected storage corruption.
+	CheckBlobIntegrity(ctx context.Context, in *CheckBlobIntegrityRequest, opts ...grpc.CallOption) (*CheckBlobIntegrityReply, error)
 	// GetPaymentState is a utility method to get the payment state of a given account.
 	GetPaymentState(ctx context.Context, in *GetPaymentStateRequest, opts ...grpc.CallOption) (*GetPaymentStateReply, error)
 }
@@ -68,6 +82,47 @@ func (c *disperserClient) GetBlobStatus(ctx context.Context, in *BlobStatusReque
 	return out, nil
 }
 
+func (c *disperserClient) GetBlobStatuses(ctx context.Context, in *BlobStatusesRequest, opts ...grpc.CallOption) (*BlobStatusesReply, error) {
+	out := new(BlobStatusesReply)
+	err := c.cc.Invoke(ctx, Disperser_GetBlobStatuses_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *disperserClient) SubscribeCertificationEvents(ctx context.Context, in *SubscribeCertificationEventsRequest, opts ...grpc.CallOption) (Disperser_SubscribeCertificationEventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Disperser_ServiceDesc.Streams[0], Disperser_SubscribeCertificationEvents_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &disperserSubscribeCertificationEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Disperser_SubscribeCertificationEventsClient interface {
+	Recv() (*CertificationEvent, error)
+	grpc.ClientStream
+}
+
+type disperserSubscribeCertificationEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *disperserSubscribeCertificationEventsClient) Recv() (*CertificationEvent, error) {
+	m := new(CertificationEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *disperserClient) GetBlobCommitment(ctx context.Context, in *BlobCommitmentRequest, opts ...grpc.CallOption) (*BlobCommitmentReply, error) {
 	out := new(BlobCommitmentReply)
 	err := c.cc.Invoke(ctx, Disperser_GetBlobCommitment_FullMethodName, in, out, opts...)
@@ -77,6 +132,15 @@ func (c *disperserClient) GetBlobCommitment(ctx context.Context, in *BlobCommitm
 	return out, nil
 }
 
+func (c *disperserClient) CheckBlobIntegrity(ctx context.Context, in *CheckBlobIntegrityRequest, opts ...grpc.CallOption) (*CheckBlobIntegrityReply, error) {
+	out := new(CheckBlobIntegrityReply)
+	err := c.cc.Invoke(ctx, Disperser_CheckBlobIntegrity_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *disperserClient) GetPaymentState(ctx context.Context, in *GetPaymentStateRequest, opts ...grpc.CallOption) (*GetPaymentStateReply, error) {
 	out := new(GetPaymentStateReply)
 	err := c.cc.Invoke(ctx, Disperser_GetPaymentState_FullMethodName, in, out, opts...)
@@ -97,8 +161,19 @@ type DisperserServer interface {
 	DisperseBlob(context.Context, *DisperseBlobRequest) (*DisperseBlobReply, error)
 	// GetBlobStatus is meant to be polled for the blob status.
 	GetBlobStatus(context.Context, *BlobStatusRequest) (*BlobStatusReply, error)
+	// GetBlobStatuses returns the statuses of multiple blobs in a single call. It is intended for clients
+	// which track many in-flight blobs at once, and would otherwise need to poll GetBlobStatus for each of them.
+	GetBlobStatuses(context.Context, *BlobStatusesRequest) (*BlobStatusesReply, error)
+	// SubscribeCertificationEvents streams an event whenever a batch is certified by the DA nodes, and whenever a blob
+	// becomes certified. It allows downstream indexers and rollup infrastructure to react to certification as it
+	// happens, rather than polling GetBlobStatus or the chain.
+	SubscribeCertificationEvents(*SubscribeCertificationEventsRequest, Disperser_SubscribeCertificationEventsServer) error
 	// GetBlobCommitment is a utility method that calculates commitment for a blob payload.
 	GetBlobCommitment(context.Context, *BlobCommitmentRequest) (*BlobCommitmentReply, error)
+	// CheckBlobIntegrity re-reads a stored blob, recomputes its commitment, and compares it against the commitment in the
+	// stored blob header. The result is returned as an attestation signed by the disperser, which can be used for audits
+	// and to diagnose suspected storage corruption.
+	CheckBlobIntegrity(context.Context, *CheckBlobIntegrityRequest) (*CheckBlobIntegrityReply, error)
 	// GetPaymentState is a utility method to get the payment state of a given account.
 	GetPaymentState(context.Context, *GetPaymentStateRequest) (*GetPaymentStateReply, error)
 	mustEmbedUnimplementedDisperserServer()
@@ -114,9 +189,18 @@ func (UnimplementedDisperserServer) DisperseBlob(context.Context, *DisperseBlobR
 func (UnimplementedDisperserServer) GetBlobStatus(context.Context, *BlobStatusRequest) (*BlobStatusReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetBlobStatus not implemented")
 }
+func (UnimplementedDisperserServer) GetBlobStatuses(context.Context, *BlobStatusesRequest) (*BlobStatusesReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBlobStatuses not implemented")
+}
+func (UnimplementedDisperserServer) SubscribeCertificationEvents(*SubscribeCertificationEventsRequest, Disperser_SubscribeCertificationEventsServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeCertificationEvents not implemented")
+}
 func (UnimplementedDisperserServer) GetBlobCommitment(context.Context, *BlobCommitmentRequest) (*BlobCommitmentReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetBlobCommitment not implemented")
 }
+func (UnimplementedDisperserServer) CheckBlobIntegrity(context.Context, *CheckBlobIntegrityRequest) (*CheckBlobIntegrityReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckBlobIntegrity not implemented")
+}
 func (UnimplementedDisperserServer) GetPaymentState(context.Context, *GetPaymentStateRequest) (*GetPaymentStateReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetPaymentState not implemented")
 }
@@ -169,6 +253,45 @@ func _Disperser_GetBlobStatus_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Disperser_GetBlobStatuses_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BlobStatusesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DisperserServer).GetBlobStatuses(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Disperser_GetBlobStatuses_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DisperserServer).GetBlobStatuses(ctx, req.(*BlobStatusesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Disperser_SubscribeCertificationEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeCertificationEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DisperserServer).SubscribeCertificationEvents(m, &disperserSubscribeCertificationEventsServer{stream})
+}
+
+type Disperser_SubscribeCertificationEventsServer interface {
+	Send(*CertificationEvent) error
+	grpc.ServerStream
+}
+
+type disperserSubscribeCertificationEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *disperserSubscribeCertificationEventsServer) Send(m *CertificationEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _Disperser_GetBlobCommitment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(BlobCommitmentRequest)
 	if err := dec(in); err != nil {
@@ -187,6 +310,24 @@ func _Disperser_GetBlobCommitment_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Disperser_CheckBlobIntegrity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckBlobIntegrityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DisperserServer).CheckBlobIntegrity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Disperser_CheckBlobIntegrity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DisperserServer).CheckBlobIntegrity(ctx, req.(*CheckBlobIntegrityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Disperser_GetPaymentState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetPaymentStateRequest)
 	if err := dec(in); err != nil {
@@ -220,15 +361,29 @@ var Disperser_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetBlobStatus",
 			Handler:    _Disperser_GetBlobStatus_Handler,
 		},
+		{
+			MethodName: "GetBlobStatuses",
+			Handler:    _Disperser_GetBlobStatuses_Handler,
+		},
 		{
 			MethodName: "GetBlobCommitment",
 			Handler:    _Disperser_GetBlobCommitment_Handler,
 		},
+		{
+			MethodName: "CheckBlobIntegrity",
+			Handler:    _Disperser_CheckBlobIntegrity_Handler,
+		},
 		{
 			MethodName: "GetPaymentState",
 			Handler:    _Disperser_GetPaymentState_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeCertificationEvents",
+			Handler:       _Disperser_SubscribeCertificationEvents_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "disperser/v2/disperser_v2.proto",
 }
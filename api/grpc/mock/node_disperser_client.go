@@ -37,3 +37,8 @@ func (m *MockNodeDispersalClient) NodeInfo(ctx context.Context, in *node.NodeInf
 	args := m.Called()
 	return args.Get(0).(*node.NodeInfoReply), args.Error(1)
 }
+
+func (m *MockNodeDispersalClient) StoreChunksStream(ctx context.Context, opts ...grpc.CallOption) (node.Dispersal_StoreChunksStreamClient, error) {
+	args := m.Called()
+	return args.Get(0).(node.Dispersal_StoreChunksStreamClient), args.Error(1)
+}
@@ -19,10 +19,11 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	Dispersal_StoreChunks_FullMethodName = "/node.Dispersal/StoreChunks"
-	Dispersal_StoreBlobs_FullMethodName  = "/node.Dispersal/StoreBlobs"
-	Dispersal_AttestBatch_FullMethodName = "/node.Dispersal/AttestBatch"
-	Dispersal_NodeInfo_FullMethodName    = "/node.Dispersal/NodeInfo"
+	Dispersal_StoreChunks_FullMethodName       = "/node.Dispersal/StoreChunks"
+	Dispersal_StoreChunksStream_FullMethodName = "/node.Dispersal/StoreChunksStream"
+	Dispersal_StoreBlobs_FullMethodName        = "/node.Dispersal/StoreBlobs"
+	Dispersal_AttestBatch_FullMethodName       = "/node.Dispersal/AttestBatch"
+	Dispersal_NodeInfo_FullMethodName          = "/node.Dispersal/NodeInfo"
 )
 
 // DispersalClient is the client API for Dispersal service.
@@ -36,14 +37,19 @@ type DispersalClient interface {
 	// for the protocol-defined length of custody. It will return a signature at the
 	// end to attest to the data in this request it has processed.
 	StoreChunks(ctx context.Context, in *StoreChunksRequest, opts ...grpc.CallOption) (*StoreChunksReply, error)
+	// StoreChunksStream is a client-streaming variant of StoreChunks, which delivers the chunks one blob at a time
+	// so that the Node validates and stores each blob as it arrives, rather than holding the whole batch in memory.
+	// The first message of the stream must carry the batch header, and each following message carries the next blob
+	// of the batch, in the order of the batch. The Node signs the batch once the stream is closed.
+	StoreChunksStream(ctx context.Context, opts ...grpc.CallOption) (Dispersal_StoreChunksStreamClient, error)
 	// StoreBlobs is simiar to StoreChunks, but it stores the blobs using a different storage schema
 	// so that the stored blobs can later be aggregated by AttestBatch method to a bigger batch.
 	// StoreBlobs + AttestBatch will eventually replace and deprecate StoreChunks method.
-	// DEPRECATED: StoreBlobs method is not used
+	// It is used by the batcher when minibatching is enabled
 	StoreBlobs(ctx context.Context, in *StoreBlobsRequest, opts ...grpc.CallOption) (*StoreBlobsReply, error)
 	// AttestBatch is used to aggregate the batches stored by StoreBlobs method to a bigger batch.
 	// It will return a signature at the end to attest to the aggregated batch.
-	// DEPRECATED: AttestBatch method is not used
+	// It is used by the batcher when minibatching is enabled
 	AttestBatch(ctx context.Context, in *AttestBatchRequest, opts ...grpc.CallOption) (*AttestBatchReply, error)
 	// Retrieve node info metadata
 	NodeInfo(ctx context.Context, in *NodeInfoRequest, opts ...grpc.CallOption) (*NodeInfoReply, error)
@@ -66,6 +72,40 @@ func (c *dispersalClient) StoreChunks(ctx context.Context, in *StoreChunksReques
 	return out, nil
 }
 
+func (c *dispersalClient) StoreChunksStream(ctx context.Context, opts ...grpc.CallOption) (Dispersal_StoreChunksStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Dispersal_ServiceDesc.Streams[0], Dispersal_StoreChunksStream_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &dispersalStoreChunksStreamClient{stream}
+	return x, nil
+}
+
+type Dispersal_StoreChunksStreamClient interface {
+	Send(*StoreChunksStreamRequest) error
+	CloseAndRecv() (*StoreChunksReply, error)
+	grpc.ClientStream
+}
+
+type dispersalStoreChunksStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *dispersalStoreChunksStreamClient) Send(m *StoreChunksStreamRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *dispersalStoreChunksStreamClient) CloseAndRecv() (*StoreChunksReply, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(StoreChunksReply)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *dispersalClient) StoreBlobs(ctx context.Context, in *StoreBlobsRequest, opts ...grpc.CallOption) (*StoreBlobsReply, error) {
 	out := new(StoreBlobsReply)
 	err := c.cc.Invoke(ctx, Dispersal_StoreBlobs_FullMethodName, in, out, opts...)
@@ -104,14 +144,19 @@ type DispersalServer interface {
 	// for the protocol-defined length of custody. It will return a signature at the
 	// end to attest to the data in this request it has processed.
 	StoreChunks(context.Context, *StoreChunksRequest) (*StoreChunksReply, error)
+	// StoreChunksStream is a client-streaming variant of StoreChunks, which delivers the chunks one blob at a time
+	// so that the Node validates and stores each blob as it arrives, rather than holding the whole batch in memory.
+	// The first message of the stream must carry the batch header, and each following message carries the next blob
+	// of the batch, in the order of the batch. The Node signs the batch once the stream is closed.
+	StoreChunksStream(Dispersal_StoreChunksStreamServer) error
 	// StoreBlobs is simiar to StoreChunks, but it stores the blobs using a different storage schema
 	// so that the stored blobs can later be aggregated by AttestBatch method to a bigger batch.
 	// StoreBlobs + AttestBatch will eventually replace and deprecate StoreChunks method.
-	// DEPRECATED: StoreBlobs method is not used
+	// It is used by the batcher when minibatching is enabled
 	StoreBlobs(context.Context, *StoreBlobsRequest) (*StoreBlobsReply, error)
 	// AttestBatch is used to aggregate the batches stored by StoreBlobs method to a bigger batch.
 	// It will return a signature at the end to attest to the aggregated batch.
-	// DEPRECATED: AttestBatch method is not used
+	// It is used by the batcher when minibatching is enabled
 	AttestBatch(context.Context, *AttestBatchRequest) (*AttestBatchReply, error)
 	// Retrieve node info metadata
 	NodeInfo(context.Context, *NodeInfoRequest) (*NodeInfoReply, error)
@@ -125,6 +170,9 @@ type UnimplementedDispersalServer struct {
 func (UnimplementedDispersalServer) StoreChunks(context.Context, *StoreChunksRequest) (*StoreChunksReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method StoreChunks not implemented")
 }
+func (UnimplementedDispersalServer) StoreChunksStream(Dispersal_StoreChunksStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method StoreChunksStream not implemented")
+}
 func (UnimplementedDispersalServer) StoreBlobs(context.Context, *StoreBlobsRequest) (*StoreBlobsReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method StoreBlobs not implemented")
 }
@@ -165,6 +213,32 @@ func _Dispersal_StoreChunks_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Dispersal_StoreChunksStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(DispersalServer).StoreChunksStream(&dispersalStoreChunksStreamServer{stream})
+}
+
+type Dispersal_StoreChunksStreamServer interface {
+	SendAndClose(*StoreChunksReply) error
+	Recv() (*StoreChunksStreamRequest, error)
+	grpc.ServerStream
+}
+
+type dispersalStoreChunksStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *dispersalStoreChunksStreamServer) SendAndClose(m *StoreChunksReply) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *dispersalStoreChunksStreamServer) Recv() (*StoreChunksStreamRequest, error) {
+	m := new(StoreChunksStreamRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func _Dispersal_StoreBlobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(StoreBlobsRequest)
 	if err := dec(in); err != nil {
@@ -243,7 +317,13 @@ var Dispersal_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _Dispersal_NodeInfo_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StoreChunksStream",
+			Handler:       _Dispersal_StoreChunksStream_Handler,
+			ClientStreams: true,
+		},
+	},
 	Metadata: "node/node.proto",
 }
 
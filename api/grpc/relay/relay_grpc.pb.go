@@ -19,8 +19,9 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	Relay_GetBlob_FullMethodName   = "/relay.Relay/GetBlob"
-	Relay_GetChunks_FullMethodName = "/relay.Relay/GetChunks"
+	Relay_GetBlob_FullMethodName       = "/relay.Relay/GetBlob"
+	Relay_GetBlobStream_FullMethodName = "/relay.Relay/GetBlobStream"
+	Relay_GetChunks_FullMethodName     = "/relay.Relay/GetChunks"
 )
 
 // RelayClient is the client API for Relay service.
@@ -29,6 +30,10 @@ const (
 type RelayClient interface {
 	// GetBlob retrieves a blob stored by the relay.
 	GetBlob(ctx context.Context, in *GetBlobRequest, opts ...grpc.CallOption) (*GetBlobReply, error)
+	// GetBlobStream retrieves a blob stored by the relay, split into a stream of parts of bounded size. Unlike GetBlob,
+	// it doesn't require a maximum gRPC message size larger than the blob, and clients can process the first parts of
+	// the blob while the rest is in flight. The request is authenticated and rate limited like GetBlob requests.
+	GetBlobStream(ctx context.Context, in *GetBlobRequest, opts ...grpc.CallOption) (Relay_GetBlobStreamClient, error)
 	// GetChunks retrieves chunks from blobs stored by the relay.
 	GetChunks(ctx context.Context, in *GetChunksRequest, opts ...grpc.CallOption) (*GetChunksReply, error)
 }
@@ -50,6 +55,38 @@ func (c *relayClient) GetBlob(ctx context.Context, in *GetBlobRequest, opts ...g
 	return out, nil
 }
 
+func (c *relayClient) GetBlobStream(ctx context.Context, in *GetBlobRequest, opts ...grpc.CallOption) (Relay_GetBlobStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Relay_ServiceDesc.Streams[0], Relay_GetBlobStream_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &relayGetBlobStreamClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Relay_GetBlobStreamClient interface {
+	Recv() (*GetBlobStreamReply, error)
+	grpc.ClientStream
+}
+
+type relayGetBlobStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *relayGetBlobStreamClient) Recv() (*GetBlobStreamReply, error) {
+	m := new(GetBlobStreamReply)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *relayClient) GetChunks(ctx context.Context, in *GetChunksRequest, opts ...grpc.CallOption) (*GetChunksReply, error) {
 	out := new(GetChunksReply)
 	err := c.cc.Invoke(ctx, Relay_GetChunks_FullMethodName, in, out, opts...)
@@ -65,6 +102,10 @@ func (c *relayClient) GetChunks(ctx context.Context, in *GetChunksRequest, opts
 type RelayServer interface {
 	// GetBlob retrieves a blob stored by the relay.
 	GetBlob(context.Context, *GetBlobRequest) (*GetBlobReply, error)
+	// GetBlobStream retrieves a blob stored by the relay, split into a stream of parts of bounded size. Unlike GetBlob,
+	// it doesn't require a maximum gRPC message size larger than the blob, and clients can process the first parts of
+	// the blob while the rest is in flight. The request is authenticated and rate limited like GetBlob requests.
+	GetBlobStream(*GetBlobRequest, Relay_GetBlobStreamServer) error
 	// GetChunks retrieves chunks from blobs stored by the relay.
 	GetChunks(context.Context, *GetChunksRequest) (*GetChunksReply, error)
 	mustEmbedUnimplementedRelayServer()
@@ -77,6 +118,9 @@ type UnimplementedRelayServer struct {
 func (UnimplementedRelayServer) GetBlob(context.Context, *GetBlobRequest) (*GetBlobReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetBlob not implemented")
 }
+func (UnimplementedRelayServer) GetBlobStream(*GetBlobRequest, Relay_GetBlobStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetBlobStream not implemented")
+}
 func (UnimplementedRelayServer) GetChunks(context.Context, *GetChunksRequest) (*GetChunksReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetChunks not implemented")
 }
@@ -111,6 +155,27 @@ func _Relay_GetBlob_Handler(srv interface{}, ctx context.Context, dec func(inter
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Relay_GetBlobStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetBlobRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RelayServer).GetBlobStream(m, &relayGetBlobStreamServer{stream})
+}
+
+type Relay_GetBlobStreamServer interface {
+	Send(*GetBlobStreamReply) error
+	grpc.ServerStream
+}
+
+type relayGetBlobStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *relayGetBlobStreamServer) Send(m *GetBlobStreamReply) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _Relay_GetChunks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetChunksRequest)
 	if err := dec(in); err != nil {
@@ -145,6 +210,12 @@ var Relay_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _Relay_GetChunks_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "GetBlobStream",
+			Handler:       _Relay_GetBlobStream_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "relay/relay.proto",
 }
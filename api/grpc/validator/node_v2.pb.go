@@ -351,6 +351,342 @@ func (x *GetChunksReply) GetChunkEncodingFormat() ChunkEncodingFormat {
 	return ChunkEncodingFormat_UNKNOWN
 }
 
+// The parameter for the AuditChunks() RPC.
+type AuditChunksRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The number of stored blobs to sample. The Node caps it to its configured maximum.
+	NumBlobs uint32 `protobuf:"varint,1,opt,name=num_blobs,json=numBlobs,proto3" json:"num_blobs,omitempty"`
+}
+
+func (x *AuditChunksRequest) Reset() {
+	*x = AuditChunksRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_validator_node_v2_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AuditChunksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditChunksRequest) ProtoMessage() {}
+
+func (x *AuditChunksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_validator_node_v2_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditChunksRequest.ProtoReflect.Descriptor instead.
+func (*AuditChunksRequest) Descriptor() ([]byte, []int) {
+	return file_validator_node_v2_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *AuditChunksRequest) GetNumBlobs() uint32 {
+	if x != nil {
+		return x.NumBlobs
+	}
+	return 0
+}
+
+// A bundle (the chunks of a blob for a quorum) stored at the Node whose chunks failed verification.
+type CorruptBundle struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The unique identifier of the blob.
+	BlobKey []byte `protobuf:"bytes,1,opt,name=blob_key,json=blobKey,proto3" json:"blob_key,omitempty"`
+	// The quorum of the bundle.
+	QuorumId uint32 `protobuf:"varint,2,opt,name=quorum_id,json=quorumId,proto3" json:"quorum_id,omitempty"`
+	// Why the chunks failed verification.
+	Reason string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+}
+
+func (x *CorruptBundle) Reset() {
+	*x = CorruptBundle{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_validator_node_v2_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CorruptBundle) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CorruptBundle) ProtoMessage() {}
+
+func (x *CorruptBundle) ProtoReflect() protoreflect.Message {
+	mi := &file_validator_node_v2_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CorruptBundle.ProtoReflect.Descriptor instead.
+func (*CorruptBundle) Descriptor() ([]byte, []int) {
+	return file_validator_node_v2_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CorruptBundle) GetBlobKey() []byte {
+	if x != nil {
+		return x.BlobKey
+	}
+	return nil
+}
+
+func (x *CorruptBundle) GetQuorumId() uint32 {
+	if x != nil {
+		return x.QuorumId
+	}
+	return 0
+}
+
+func (x *CorruptBundle) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// The response to the AuditChunks() RPC.
+type AuditChunksReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The number of bundles which were re-verified.
+	NumBundles uint32 `protobuf:"varint,1,opt,name=num_bundles,json=numBundles,proto3" json:"num_bundles,omitempty"`
+	// The bundles whose chunks failed verification.
+	CorruptBundles []*CorruptBundle `protobuf:"bytes,2,rep,name=corrupt_bundles,json=corruptBundles,proto3" json:"corrupt_bundles,omitempty"`
+}
+
+func (x *AuditChunksReply) Reset() {
+	*x = AuditChunksReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_validator_node_v2_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AuditChunksReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditChunksReply) ProtoMessage() {}
+
+func (x *AuditChunksReply) ProtoReflect() protoreflect.Message {
+	mi := &file_validator_node_v2_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditChunksReply.ProtoReflect.Descriptor instead.
+func (*AuditChunksReply) Descriptor() ([]byte, []int) {
+	return file_validator_node_v2_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *AuditChunksReply) GetNumBundles() uint32 {
+	if x != nil {
+		return x.NumBundles
+	}
+	return 0
+}
+
+func (x *AuditChunksReply) GetCorruptBundles() []*CorruptBundle {
+	if x != nil {
+		return x.CorruptBundles
+	}
+	return nil
+}
+
+// The parameter for the GetChunkProof() RPC.
+type GetChunkProofRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The unique identifier of the blob.
+	BlobKey []byte `protobuf:"bytes,1,opt,name=blob_key,json=blobKey,proto3" json:"blob_key,omitempty"`
+	// The index of the chunk among all the chunks of the encoded blob.
+	ChunkIndex uint32 `protobuf:"varint,2,opt,name=chunk_index,json=chunkIndex,proto3" json:"chunk_index,omitempty"`
+}
+
+func (x *GetChunkProofRequest) Reset() {
+	*x = GetChunkProofRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_validator_node_v2_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetChunkProofRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetChunkProofRequest) ProtoMessage() {}
+
+func (x *GetChunkProofRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_validator_node_v2_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetChunkProofRequest.ProtoReflect.Descriptor instead.
+func (*GetChunkProofRequest) Descriptor() ([]byte, []int) {
+	return file_validator_node_v2_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetChunkProofRequest) GetBlobKey() []byte {
+	if x != nil {
+		return x.BlobKey
+	}
+	return nil
+}
+
+func (x *GetChunkProofRequest) GetChunkIndex() uint32 {
+	if x != nil {
+		return x.ChunkIndex
+	}
+	return 0
+}
+
+// The response to the GetChunkProof() RPC.
+type GetChunkProofReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The chunk, encoded in the GNARK chunk encoding format.
+	Chunk []byte `protobuf:"bytes,1,opt,name=chunk,proto3" json:"chunk,omitempty"`
+	// The KZG proof of the chunk against the commitment of the blob, serialized with bn254.G1Affine.Bytes(). It's the
+	// start of the encoded chunk.
+	KzgProof []byte `protobuf:"bytes,2,opt,name=kzg_proof,json=kzgProof,proto3" json:"kzg_proof,omitempty"`
+	// The quorum in which the chunk is assigned to the Node.
+	QuorumId uint32 `protobuf:"varint,3,opt,name=quorum_id,json=quorumId,proto3" json:"quorum_id,omitempty"`
+	// The certificate of the blob, which holds the commitment of the blob.
+	BlobCertificate *v2.BlobCertificate `protobuf:"bytes,4,opt,name=blob_certificate,json=blobCertificate,proto3" json:"blob_certificate,omitempty"`
+	// The header of the batch the blob was stored with.
+	BatchHeader *v2.BatchHeader `protobuf:"bytes,5,opt,name=batch_header,json=batchHeader,proto3" json:"batch_header,omitempty"`
+	// The index of the blob certificate in the batch.
+	BlobIndex uint32 `protobuf:"varint,6,opt,name=blob_index,json=blobIndex,proto3" json:"blob_index,omitempty"`
+	// The merkle proof of the inclusion of the blob certificate in the batch root.
+	InclusionProof []byte `protobuf:"bytes,7,opt,name=inclusion_proof,json=inclusionProof,proto3" json:"inclusion_proof,omitempty"`
+}
+
+func (x *GetChunkProofReply) Reset() {
+	*x = GetChunkProofReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_validator_node_v2_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetChunkProofReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetChunkProofReply) ProtoMessage() {}
+
+func (x *GetChunkProofReply) ProtoReflect() protoreflect.Message {
+	mi := &file_validator_node_v2_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetChunkProofReply.ProtoReflect.Descriptor instead.
+func (*GetChunkProofReply) Descriptor() ([]byte, []int) {
+	return file_validator_node_v2_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetChunkProofReply) GetChunk() []byte {
+	if x != nil {
+		return x.Chunk
+	}
+	return nil
+}
+
+func (x *GetChunkProofReply) GetKzgProof() []byte {
+	if x != nil {
+		return x.KzgProof
+	}
+	return nil
+}
+
+func (x *GetChunkProofReply) GetQuorumId() uint32 {
+	if x != nil {
+		return x.QuorumId
+	}
+	return 0
+}
+
+func (x *GetChunkProofReply) GetBlobCertificate() *v2.BlobCertificate {
+	if x != nil {
+		return x.BlobCertificate
+	}
+	return nil
+}
+
+func (x *GetChunkProofReply) GetBatchHeader() *v2.BatchHeader {
+	if x != nil {
+		return x.BatchHeader
+	}
+	return nil
+}
+
+func (x *GetChunkProofReply) GetBlobIndex() uint32 {
+	if x != nil {
+		return x.BlobIndex
+	}
+	return 0
+}
+
+func (x *GetChunkProofReply) GetInclusionProof() []byte {
+	if x != nil {
+		return x.InclusionProof
+	}
+	return nil
+}
+
 // The parameter for the GetNodeInfo() RPC.
 type GetNodeInfoRequest struct {
 	state         protoimpl.MessageState
@@ -361,7 +697,7 @@ type GetNodeInfoRequest struct {
 func (x *GetNodeInfoRequest) Reset() {
 	*x = GetNodeInfoRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_validator_node_v2_proto_msgTypes[4]
+		mi := &file_validator_node_v2_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -374,7 +710,7 @@ func (x *GetNodeInfoRequest) String() string {
 func (*GetNodeInfoRequest) ProtoMessage() {}
 
 func (x *GetNodeInfoRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_validator_node_v2_proto_msgTypes[4]
+	mi := &file_validator_node_v2_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -387,7 +723,7 @@ func (x *GetNodeInfoRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetNodeInfoRequest.ProtoReflect.Descriptor instead.
 func (*GetNodeInfoRequest) Descriptor() ([]byte, []int) {
-	return file_validator_node_v2_proto_rawDescGZIP(), []int{4}
+	return file_validator_node_v2_proto_rawDescGZIP(), []int{9}
 }
 
 // Node info reply
@@ -406,12 +742,17 @@ type GetNodeInfoReply struct {
 	NumCpu uint32 `protobuf:"varint,4,opt,name=num_cpu,json=numCpu,proto3" json:"num_cpu,omitempty"`
 	// The amount of memory on the node in bytes.
 	MemBytes uint64 `protobuf:"varint,5,opt,name=mem_bytes,json=memBytes,proto3" json:"mem_bytes,omitempty"`
+	// The versions of the node APIs the node serves, e.g. "v1" and "v2".
+	ApiVersions []string `protobuf:"bytes,6,rep,name=api_versions,json=apiVersions,proto3" json:"api_versions,omitempty"`
+	// The optional features the node supports, e.g. "batch_verification". Dispersers use them to adapt to each
+	// operator, so that nodes can be upgraded one at a time. Nodes predating feature negotiation report none.
+	Features []string `protobuf:"bytes,7,rep,name=features,proto3" json:"features,omitempty"`
 }
 
 func (x *GetNodeInfoReply) Reset() {
 	*x = GetNodeInfoReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_validator_node_v2_proto_msgTypes[5]
+		mi := &file_validator_node_v2_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -424,7 +765,7 @@ func (x *GetNodeInfoReply) String() string {
 func (*GetNodeInfoReply) ProtoMessage() {}
 
 func (x *GetNodeInfoReply) ProtoReflect() protoreflect.Message {
-	mi := &file_validator_node_v2_proto_msgTypes[5]
+	mi := &file_validator_node_v2_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -437,7 +778,7 @@ func (x *GetNodeInfoReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetNodeInfoReply.ProtoReflect.Descriptor instead.
 func (*GetNodeInfoReply) Descriptor() ([]byte, []int) {
-	return file_validator_node_v2_proto_rawDescGZIP(), []int{5}
+	return file_validator_node_v2_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *GetNodeInfoReply) GetSemver() string {
@@ -475,6 +816,20 @@ func (x *GetNodeInfoReply) GetMemBytes() uint64 {
 	return 0
 }
 
+func (x *GetNodeInfoReply) GetApiVersions() []string {
+	if x != nil {
+		return x.ApiVersions
+	}
+	return nil
+}
+
+func (x *GetNodeInfoReply) GetFeatures() []string {
+	if x != nil {
+		return x.Features
+	}
+	return nil
+}
+
 var File_validator_node_v2_proto protoreflect.FileDescriptor
 
 var file_validator_node_v2_proto_rawDesc = []byte{
@@ -505,17 +860,62 @@ var file_validator_node_v2_proto_rawDesc = []byte{
 	0x20, 0x01, 0x28, 0x0e, 0x32, 0x1e, 0x2e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72,
 	0x2e, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x45, 0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x46, 0x6f,
 	0x72, 0x6d, 0x61, 0x74, 0x52, 0x13, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x45, 0x6e, 0x63, 0x6f, 0x64,
-	0x69, 0x6e, 0x67, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x22, 0x14, 0x0a, 0x12, 0x47, 0x65, 0x74,
-	0x4e, 0x6f, 0x64, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22,
-	0x84, 0x01, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52,
-	0x65, 0x70, 0x6c, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x6d, 0x76, 0x65, 0x72, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65, 0x6d, 0x76, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04,
-	0x61, 0x72, 0x63, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x61, 0x72, 0x63, 0x68,
-	0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x6f, 0x73,
-	0x12, 0x17, 0x0a, 0x07, 0x6e, 0x75, 0x6d, 0x5f, 0x63, 0x70, 0x75, 0x18, 0x04, 0x20, 0x01, 0x28,
-	0x0d, 0x52, 0x06, 0x6e, 0x75, 0x6d, 0x43, 0x70, 0x75, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x65, 0x6d,
-	0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x6d, 0x65,
-	0x6d, 0x42, 0x79, 0x74, 0x65, 0x73, 0x2a, 0x2d, 0x0a, 0x13, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x45,
+	0x69, 0x6e, 0x67, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x22, 0x31, 0x0a, 0x12, 0x41, 0x75, 0x64,
+	0x69, 0x74, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x1b, 0x0a, 0x09, 0x6e, 0x75, 0x6d, 0x5f, 0x62, 0x6c, 0x6f, 0x62, 0x73, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x08, 0x6e, 0x75, 0x6d, 0x42, 0x6c, 0x6f, 0x62, 0x73, 0x22, 0x5f, 0x0a, 0x0d,
+	0x43, 0x6f, 0x72, 0x72, 0x75, 0x70, 0x74, 0x42, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x12, 0x19, 0x0a,
+	0x08, 0x62, 0x6c, 0x6f, 0x62, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x07, 0x62, 0x6c, 0x6f, 0x62, 0x4b, 0x65, 0x79, 0x12, 0x1b, 0x0a, 0x09, 0x71, 0x75, 0x6f, 0x72,
+	0x75, 0x6d, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x71, 0x75, 0x6f,
+	0x72, 0x75, 0x6d, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x22, 0x76, 0x0a,
+	0x10, 0x41, 0x75, 0x64, 0x69, 0x74, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x73, 0x52, 0x65, 0x70, 0x6c,
+	0x79, 0x12, 0x1f, 0x0a, 0x0b, 0x6e, 0x75, 0x6d, 0x5f, 0x62, 0x75, 0x6e, 0x64, 0x6c, 0x65, 0x73,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x6e, 0x75, 0x6d, 0x42, 0x75, 0x6e, 0x64, 0x6c,
+	0x65, 0x73, 0x12, 0x41, 0x0a, 0x0f, 0x63, 0x6f, 0x72, 0x72, 0x75, 0x70, 0x74, 0x5f, 0x62, 0x75,
+	0x6e, 0x64, 0x6c, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x76, 0x61,
+	0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x43, 0x6f, 0x72, 0x72, 0x75, 0x70, 0x74, 0x42,
+	0x75, 0x6e, 0x64, 0x6c, 0x65, 0x52, 0x0e, 0x63, 0x6f, 0x72, 0x72, 0x75, 0x70, 0x74, 0x42, 0x75,
+	0x6e, 0x64, 0x6c, 0x65, 0x73, 0x22, 0x52, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x43, 0x68, 0x75, 0x6e,
+	0x6b, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x19, 0x0a,
+	0x08, 0x62, 0x6c, 0x6f, 0x62, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x07, 0x62, 0x6c, 0x6f, 0x62, 0x4b, 0x65, 0x79, 0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x68, 0x75, 0x6e,
+	0x6b, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x63,
+	0x68, 0x75, 0x6e, 0x6b, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x22, 0xae, 0x02, 0x0a, 0x12, 0x47, 0x65,
+	0x74, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x52, 0x65, 0x70, 0x6c, 0x79,
+	0x12, 0x14, 0x0a, 0x05, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x05, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x12, 0x1b, 0x0a, 0x09, 0x6b, 0x7a, 0x67, 0x5f, 0x70, 0x72,
+	0x6f, 0x6f, 0x66, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x6b, 0x7a, 0x67, 0x50, 0x72,
+	0x6f, 0x6f, 0x66, 0x12, 0x1b, 0x0a, 0x09, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x5f, 0x69, 0x64,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x49, 0x64,
+	0x12, 0x45, 0x0a, 0x10, 0x62, 0x6c, 0x6f, 0x62, 0x5f, 0x63, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69,
+	0x63, 0x61, 0x74, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x63, 0x6f, 0x6d,
+	0x6d, 0x6f, 0x6e, 0x2e, 0x76, 0x32, 0x2e, 0x42, 0x6c, 0x6f, 0x62, 0x43, 0x65, 0x72, 0x74, 0x69,
+	0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x52, 0x0f, 0x62, 0x6c, 0x6f, 0x62, 0x43, 0x65, 0x72, 0x74,
+	0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x12, 0x39, 0x0a, 0x0c, 0x62, 0x61, 0x74, 0x63, 0x68,
+	0x5f, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e,
+	0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x76, 0x32, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x48,
+	0x65, 0x61, 0x64, 0x65, 0x72, 0x52, 0x0b, 0x62, 0x61, 0x74, 0x63, 0x68, 0x48, 0x65, 0x61, 0x64,
+	0x65, 0x72, 0x12, 0x1d, 0x0a, 0x0a, 0x62, 0x6c, 0x6f, 0x62, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x62, 0x6c, 0x6f, 0x62, 0x49, 0x6e, 0x64, 0x65,
+	0x78, 0x12, 0x27, 0x0a, 0x0f, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x70,
+	0x72, 0x6f, 0x6f, 0x66, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0e, 0x69, 0x6e, 0x63, 0x6c,
+	0x75, 0x73, 0x69, 0x6f, 0x6e, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x22, 0x14, 0x0a, 0x12, 0x47, 0x65,
+	0x74, 0x4e, 0x6f, 0x64, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x22, 0xc3, 0x01, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x49, 0x6e, 0x66, 0x6f,
+	0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x6d, 0x76, 0x65, 0x72, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65, 0x6d, 0x76, 0x65, 0x72, 0x12, 0x12, 0x0a,
+	0x04, 0x61, 0x72, 0x63, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x61, 0x72, 0x63,
+	0x68, 0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x6f,
+	0x73, 0x12, 0x17, 0x0a, 0x07, 0x6e, 0x75, 0x6d, 0x5f, 0x63, 0x70, 0x75, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x06, 0x6e, 0x75, 0x6d, 0x43, 0x70, 0x75, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x65,
+	0x6d, 0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x6d,
+	0x65, 0x6d, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x61, 0x70, 0x69, 0x5f, 0x76,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x61,
+	0x70, 0x69, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x65,
+	0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x66, 0x65,
+	0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x2a, 0x2d, 0x0a, 0x13, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x45,
 	0x6e, 0x63, 0x6f, 0x64, 0x69, 0x6e, 0x67, 0x46, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x0b, 0x0a,
 	0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x09, 0x0a, 0x05, 0x47, 0x4e,
 	0x41, 0x52, 0x4b, 0x10, 0x01, 0x32, 0xa5, 0x01, 0x0a, 0x09, 0x44, 0x69, 0x73, 0x70, 0x65, 0x72,
@@ -528,12 +928,22 @@ var file_validator_node_v2_proto_rawDesc = []byte{
 	0x1d, 0x2e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x47, 0x65, 0x74, 0x4e,
 	0x6f, 0x64, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b,
 	0x2e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x47, 0x65, 0x74, 0x4e, 0x6f,
-	0x64, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x32, 0x9f, 0x01,
+	0x64, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x32, 0xbf, 0x02,
 	0x0a, 0x09, 0x52, 0x65, 0x74, 0x72, 0x69, 0x65, 0x76, 0x61, 0x6c, 0x12, 0x45, 0x0a, 0x09, 0x47,
 	0x65, 0x74, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x73, 0x12, 0x1b, 0x2e, 0x76, 0x61, 0x6c, 0x69, 0x64,
 	0x61, 0x74, 0x6f, 0x72, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x73, 0x52, 0x65,
 	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f,
 	0x72, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79,
+	0x22, 0x00, 0x12, 0x4b, 0x0a, 0x0b, 0x41, 0x75, 0x64, 0x69, 0x74, 0x43, 0x68, 0x75, 0x6e, 0x6b,
+	0x73, 0x12, 0x1d, 0x2e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x41, 0x75,
+	0x64, 0x69, 0x74, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x1b, 0x2e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x41, 0x75, 0x64,
+	0x69, 0x74, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x22, 0x00, 0x12,
+	0x51, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x50, 0x72, 0x6f, 0x6f, 0x66,
+	0x12, 0x1f, 0x2e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x47, 0x65, 0x74,
+	0x43, 0x68, 0x75, 0x6e, 0x6b, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x1d, 0x2e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x47, 0x65,
+	0x74, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x52, 0x65, 0x70, 0x6c, 0x79,
 	0x22, 0x00, 0x12, 0x4b, 0x0a, 0x0b, 0x47, 0x65, 0x74, 0x4e, 0x6f, 0x64, 0x65, 0x49, 0x6e, 0x66,
 	0x6f, 0x12, 0x1d, 0x2e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x47, 0x65,
 	0x74, 0x4e, 0x6f, 0x64, 0x65, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
@@ -558,33 +968,47 @@ func file_validator_node_v2_proto_rawDescGZIP() []byte {
 }
 
 var file_validator_node_v2_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_validator_node_v2_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_validator_node_v2_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
 var file_validator_node_v2_proto_goTypes = []interface{}{
-	(ChunkEncodingFormat)(0),   // 0: validator.ChunkEncodingFormat
-	(*StoreChunksRequest)(nil), // 1: validator.StoreChunksRequest
-	(*StoreChunksReply)(nil),   // 2: validator.StoreChunksReply
-	(*GetChunksRequest)(nil),   // 3: validator.GetChunksRequest
-	(*GetChunksReply)(nil),     // 4: validator.GetChunksReply
-	(*GetNodeInfoRequest)(nil), // 5: validator.GetNodeInfoRequest
-	(*GetNodeInfoReply)(nil),   // 6: validator.GetNodeInfoReply
-	(*v2.Batch)(nil),           // 7: common.v2.Batch
+	(ChunkEncodingFormat)(0),     // 0: validator.ChunkEncodingFormat
+	(*StoreChunksRequest)(nil),   // 1: validator.StoreChunksRequest
+	(*StoreChunksReply)(nil),     // 2: validator.StoreChunksReply
+	(*GetChunksRequest)(nil),     // 3: validator.GetChunksRequest
+	(*GetChunksReply)(nil),       // 4: validator.GetChunksReply
+	(*AuditChunksRequest)(nil),   // 5: validator.AuditChunksRequest
+	(*CorruptBundle)(nil),        // 6: validator.CorruptBundle
+	(*AuditChunksReply)(nil),     // 7: validator.AuditChunksReply
+	(*GetChunkProofRequest)(nil), // 8: validator.GetChunkProofRequest
+	(*GetChunkProofReply)(nil),   // 9: validator.GetChunkProofReply
+	(*GetNodeInfoRequest)(nil),   // 10: validator.GetNodeInfoRequest
+	(*GetNodeInfoReply)(nil),     // 11: validator.GetNodeInfoReply
+	(*v2.Batch)(nil),             // 12: common.v2.Batch
+	(*v2.BlobCertificate)(nil),   // 13: common.v2.BlobCertificate
+	(*v2.BatchHeader)(nil),       // 14: common.v2.BatchHeader
 }
 var file_validator_node_v2_proto_depIdxs = []int32{
-	7, // 0: validator.StoreChunksRequest.batch:type_name -> common.v2.Batch
-	0, // 1: validator.GetChunksReply.chunk_encoding_format:type_name -> validator.ChunkEncodingFormat
-	1, // 2: validator.Dispersal.StoreChunks:input_type -> validator.StoreChunksRequest
-	5, // 3: validator.Dispersal.GetNodeInfo:input_type -> validator.GetNodeInfoRequest
-	3, // 4: validator.Retrieval.GetChunks:input_type -> validator.GetChunksRequest
-	5, // 5: validator.Retrieval.GetNodeInfo:input_type -> validator.GetNodeInfoRequest
-	2, // 6: validator.Dispersal.StoreChunks:output_type -> validator.StoreChunksReply
-	6, // 7: validator.Dispersal.GetNodeInfo:output_type -> validator.GetNodeInfoReply
-	4, // 8: validator.Retrieval.GetChunks:output_type -> validator.GetChunksReply
-	6, // 9: validator.Retrieval.GetNodeInfo:output_type -> validator.GetNodeInfoReply
-	6, // [6:10] is the sub-list for method output_type
-	2, // [2:6] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	12, // 0: validator.StoreChunksRequest.batch:type_name -> common.v2.Batch
+	0,  // 1: validator.GetChunksReply.chunk_encoding_format:type_name -> validator.ChunkEncodingFormat
+	6,  // 2: validator.AuditChunksReply.corrupt_bundles:type_name -> validator.CorruptBundle
+	13, // 3: validator.GetChunkProofReply.blob_certificate:type_name -> common.v2.BlobCertificate
+	14, // 4: validator.GetChunkProofReply.batch_header:type_name -> common.v2.BatchHeader
+	1,  // 5: validator.Dispersal.StoreChunks:input_type -> validator.StoreChunksRequest
+	10, // 6: validator.Dispersal.GetNodeInfo:input_type -> validator.GetNodeInfoRequest
+	3,  // 7: validator.Retrieval.GetChunks:input_type -> validator.GetChunksRequest
+	5,  // 8: validator.Retrieval.AuditChunks:input_type -> validator.AuditChunksRequest
+	8,  // 9: validator.Retrieval.GetChunkProof:input_type -> validator.GetChunkProofRequest
+	10, // 10: validator.Retrieval.GetNodeInfo:input_type -> validator.GetNodeInfoRequest
+	2,  // 11: validator.Dispersal.StoreChunks:output_type -> validator.StoreChunksReply
+	11, // 12: validator.Dispersal.GetNodeInfo:output_type -> validator.GetNodeInfoReply
+	4,  // 13: validator.Retrieval.GetChunks:output_type -> validator.GetChunksReply
+	7,  // 14: validator.Retrieval.AuditChunks:output_type -> validator.AuditChunksReply
+	9,  // 15: validator.Retrieval.GetChunkProof:output_type -> validator.GetChunkProofReply
+	11, // 16: validator.Retrieval.GetNodeInfo:output_type -> validator.GetNodeInfoReply
+	11, // [11:17] is the sub-list for method output_type
+	5,  // [5:11] is the sub-list for method input_type
+	5,  // [5:5] is the sub-list for extension type_name
+	5,  // [5:5] is the sub-list for extension extendee
+	0,  // [0:5] is the sub-list for field type_name
 }
 
 func init() { file_validator_node_v2_proto_init() }
@@ -642,7 +1066,7 @@ func file_validator_node_v2_proto_init() {
 			}
 		}
 		file_validator_node_v2_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetNodeInfoRequest); i {
+			switch v := v.(*AuditChunksRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -654,6 +1078,66 @@ func file_validator_node_v2_proto_init() {
 			}
 		}
 		file_validator_node_v2_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CorruptBundle); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_validator_node_v2_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AuditChunksReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_validator_node_v2_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetChunkProofRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_validator_node_v2_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetChunkProofReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_validator_node_v2_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetNodeInfoRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_validator_node_v2_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*GetNodeInfoReply); i {
 			case 0:
 				return &v.state
@@ -672,7 +1156,7 @@ func file_validator_node_v2_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_validator_node_v2_proto_rawDesc,
 			NumEnums:      1,
-			NumMessages:   6,
+			NumMessages:   11,
 			NumExtensions: 0,
 			NumServices:   2,
 		},
@@ -158,8 +158,10 @@ var Dispersal_ServiceDesc = grpc.ServiceDesc{
 }
 
 const (
-	Retrieval_GetChunks_FullMethodName   = "/validator.Retrieval/GetChunks"
-	Retrieval_GetNodeInfo_FullMethodName = "/validator.Retrieval/GetNodeInfo"
+	Retrieval_GetChunks_FullMethodName     = "/validator.Retrieval/GetChunks"
+	Retrieval_AuditChunks_FullMethodName   = "/validator.Retrieval/AuditChunks"
+	Retrieval_GetChunkProof_FullMethodName = "/validator.Retrieval/GetChunkProof"
+	Retrieval_GetNodeInfo_FullMethodName   = "/validator.Retrieval/GetNodeInfo"
 )
 
 // RetrievalClient is the client API for Retrieval service.
@@ -169,6 +171,13 @@ type RetrievalClient interface {
 	// GetChunks retrieves the chunks for a blob custodied at the Node. Note that where possible, it is generally
 	// faster to retrieve chunks from the relay service if that service is available.
 	GetChunks(ctx context.Context, in *GetChunksRequest, opts ...grpc.CallOption) (*GetChunksReply, error)
+	// AuditChunks re-verifies the chunks of randomly sampled blobs stored at the Node against their commitments,
+	// and reports the bundles whose chunks are corrupt.
+	AuditChunks(ctx context.Context, in *AuditChunksRequest, opts ...grpc.CallOption) (*AuditChunksReply, error)
+	// GetChunkProof returns a chunk of a blob stored at the Node, along with the proofs that the chunk belongs to the blob
+	// and that the blob belongs to a batch, so that auditors and light clients can verify that the Node stores the chunks
+	// assigned to it.
+	GetChunkProof(ctx context.Context, in *GetChunkProofRequest, opts ...grpc.CallOption) (*GetChunkProofReply, error)
 	// Retrieve node info metadata
 	GetNodeInfo(ctx context.Context, in *GetNodeInfoRequest, opts ...grpc.CallOption) (*GetNodeInfoReply, error)
 }
@@ -190,6 +199,24 @@ func (c *retrievalClient) GetChunks(ctx context.Context, in *GetChunksRequest, o
 	return out, nil
 }
 
+func (c *retrievalClient) AuditChunks(ctx context.Context, in *AuditChunksRequest, opts ...grpc.CallOption) (*AuditChunksReply, error) {
+	out := new(AuditChunksReply)
+	err := c.cc.Invoke(ctx, Retrieval_AuditChunks_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *retrievalClient) GetChunkProof(ctx context.Context, in *GetChunkProofRequest, opts ...grpc.CallOption) (*GetChunkProofReply, error) {
+	out := new(GetChunkProofReply)
+	err := c.cc.Invoke(ctx, Retrieval_GetChunkProof_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *retrievalClient) GetNodeInfo(ctx context.Context, in *GetNodeInfoRequest, opts ...grpc.CallOption) (*GetNodeInfoReply, error) {
 	out := new(GetNodeInfoReply)
 	err := c.cc.Invoke(ctx, Retrieval_GetNodeInfo_FullMethodName, in, out, opts...)
@@ -206,6 +233,13 @@ type RetrievalServer interface {
 	// GetChunks retrieves the chunks for a blob custodied at the Node. Note that where possible, it is generally
 	// faster to retrieve chunks from the relay service if that service is available.
 	GetChunks(context.Context, *GetChunksRequest) (*GetChunksReply, error)
+	// AuditChunks re-verifies the chunks of randomly sampled blobs stored at the Node against their commitments,
+	// and reports the bundles whose chunks are corrupt.
+	AuditChunks(context.Context, *AuditChunksRequest) (*AuditChunksReply, error)
+	// GetChunkProof returns a chunk of a blob stored at the Node, along with the proofs that the chunk belongs to the blob
+	// and that the blob belongs to a batch, so that auditors and light clients can verify that the Node stores the chunks
+	// assigned to it.
+	GetChunkProof(context.Context, *GetChunkProofRequest) (*GetChunkProofReply, error)
 	// Retrieve node info metadata
 	GetNodeInfo(context.Context, *GetNodeInfoRequest) (*GetNodeInfoReply, error)
 	mustEmbedUnimplementedRetrievalServer()
@@ -218,6 +252,12 @@ type UnimplementedRetrievalServer struct {
 func (UnimplementedRetrievalServer) GetChunks(context.Context, *GetChunksRequest) (*GetChunksReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetChunks not implemented")
 }
+func (UnimplementedRetrievalServer) AuditChunks(context.Context, *AuditChunksRequest) (*AuditChunksReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AuditChunks not implemented")
+}
+func (UnimplementedRetrievalServer) GetChunkProof(context.Context, *GetChunkProofRequest) (*GetChunkProofReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetChunkProof not implemented")
+}
 func (UnimplementedRetrievalServer) GetNodeInfo(context.Context, *GetNodeInfoRequest) (*GetNodeInfoReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetNodeInfo not implemented")
 }
@@ -252,6 +292,42 @@ func _Retrieval_GetChunks_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Retrieval_AuditChunks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AuditChunksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RetrievalServer).AuditChunks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Retrieval_AuditChunks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RetrievalServer).AuditChunks(ctx, req.(*AuditChunksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Retrieval_GetChunkProof_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetChunkProofRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RetrievalServer).GetChunkProof(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Retrieval_GetChunkProof_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RetrievalServer).GetChunkProof(ctx, req.(*GetChunkProofRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Retrieval_GetNodeInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetNodeInfoRequest)
 	if err := dec(in); err != nil {
@@ -281,6 +357,14 @@ var Retrieval_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetChunks",
 			Handler:    _Retrieval_GetChunks_Handler,
 		},
+		{
+			MethodName: "AuditChunks",
+			Handler:    _Retrieval_AuditChunks_Handler,
+		},
+		{
+			MethodName: "GetChunkProof",
+			Handler:    _Retrieval_GetChunkProof_Handler,
+		},
 		{
 			MethodName: "GetNodeInfo",
 			Handler:    _Retrieval_GetNodeInfo_Handler,
@@ -0,0 +1,79 @@
+package hashing
+
+import (
+	pb "github.com/Layr-Labs/eigenda/api/grpc/disperser/v2"
+	"github.com/ethereum/go-ethereum/common"
+	"golang.org/x/crypto/sha3"
+)
+
+// This file contains code for hashing gRPC messages that are sent by the disperser.
+
+// HashBlobIntegrityAttestation hashes the given BlobIntegrityAttestation.
+func HashBlobIntegrityAttestation(attestation *pb.BlobIntegrityAttestation) []byte {
+	hasher := sha3.NewLegacyKeccak256()
+
+	hasher.Write(attestation.GetBlobKey())
+	if attestation.GetIntact() {
+		hasher.Write([]byte{1})
+	} else {
+		hasher.Write([]byte{0})
+	}
+	hashBlobCommitment(hasher, attestation.GetHeaderCommitment())
+	hashBlobCommitment(hasher, attestation.GetComputedCommitment())
+	hashUint64(hasher, attestation.GetCheckedAt())
+
+	return hasher.Sum(nil)
+}
+
+// HashDispersalReceipt hashes the given DispersalReceipt.
+func HashDispersalReceipt(receipt *pb.DispersalReceipt) []byte {
+	hasher := sha3.NewLegacyKeccak256()
+
+	hasher.Write(receipt.GetBlobKey())
+	hasher.Write(receipt.GetBlobHash())
+	// the variable length fields are prefixed with their length, so that their boundaries are unambiguous
+	hashUint32(hasher, uint32(len(receipt.GetAccountId())))
+	hasher.Write([]byte(receipt.GetAccountId()))
+	hashUint64(hasher, receipt.GetSymbolsCharged())
+	hashUint64(hasher, receipt.GetReceivedAt())
+	hashInt64(hasher, receipt.GetPaymentTimestamp())
+	hashUint32(hasher, uint32(len(receipt.GetCumulativePayment())))
+	hasher.Write(receipt.GetCumulativePayment())
+	hashUint32(hasher, uint32(len(receipt.GetPaymentCharged())))
+	hasher.Write(receipt.GetPaymentCharged())
+
+	return hasher.Sum(nil)
+}
+
+// paymentDelegationDomain separates the hashes of payment delegations from the hashes of other signed messages, so that
+// a signature over a delegation can't be mistaken for a signature over anything else.
+const paymentDelegationDomain = "EigenDA.PaymentDelegation"
+
+// HashPaymentDelegation hashes a payment delegation, by which the payer account authorizes the delegate account to
+// sign dispersal requests paid for by the payer, until the expiry Unix timestamp in seconds.
+func HashPaymentDelegation(payer common.Address, delegate common.Address, expiry uint64) []byte {
+	hasher := sha3.NewLegacyKeccak256()
+
+	hasher.Write([]byte(paymentDelegationDomain))
+	hasher.Write(payer[:])
+	hasher.Write(delegate[:])
+	hashUint64(hasher, expiry)
+
+	return hasher.Sum(nil)
+}
+
+// blobIntegrityRequestDomain separates the hashes of blob integrity requests from the hashes of other signed messages.
+const blobIntegrityRequestDomain = "EigenDA.BlobIntegrityRequest"
+
+// HashBlobIntegrityRequest hashes a CheckBlobIntegrity request for the given blob key, which the account which
+// dispersed the blob signs to authenticate the request. The timestamp is the Unix time in nanoseconds at which the
+// request was signed.
+func HashBlobIntegrityRequest(blobKey []byte, timestamp int64) []byte {
+	hasher := sha3.NewLegacyKeccak256()
+
+	hasher.Write([]byte(blobIntegrityRequestDomain))
+	hasher.Write(blobKey)
+	hashInt64(hasher, timestamp)
+
+	return hasher.Sum(nil)
+}
@@ -47,10 +47,10 @@ func hashBatchHeader(hasher hash.Hash, header *common.BatchHeader) {
 }
 
 func hashBlobCommitment(hasher hash.Hash, commitment *commonv1.BlobCommitment) {
-	hasher.Write(commitment.Commitment)
-	hasher.Write(commitment.LengthCommitment)
-	hasher.Write(commitment.LengthProof)
-	hashUint32(hasher, commitment.Length)
+	hasher.Write(commitment.GetCommitment())
+	hasher.Write(commitment.GetLengthCommitment())
+	hasher.Write(commitment.GetLengthProof())
+	hashUint32(hasher, commitment.GetLength())
 }
 
 func hashPaymentHeader(hasher hash.Hash, header *common.PaymentHeader) {
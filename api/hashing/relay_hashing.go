@@ -36,3 +36,31 @@ func HashGetChunksRequest(request *pb.GetChunksRequest) []byte {
 
 	return hasher.Sum(nil)
 }
+
+// HashGetBlobRequest hashes the given GetBlobRequest.
+func HashGetBlobRequest(request *pb.GetBlobRequest) []byte {
+	hasher := sha3.NewLegacyKeccak256()
+
+	hasher.Write(request.GetBlobKey())
+
+	return hasher.Sum(nil)
+}
+
+const relayClientRequestDomain = "EigenDA.RelayClientRequest"
+
+// HashRelayClientRequest hashes a request made to a relay by a relay client, which the client signs to authenticate
+// itself. The method is the full gRPC method name, the timestamp is the Unix time in nanoseconds at which the request
+// was signed, and the requestHash is the hash of the request message, e.g. from HashGetBlobRequest.
+func HashRelayClientRequest(method string, clientID string, timestamp int64, requestHash []byte) []byte {
+	hasher := sha3.NewLegacyKeccak256()
+
+	hasher.Write([]byte(relayClientRequestDomain))
+	hashUint32(hasher, uint32(len(method)))
+	hasher.Write([]byte(method))
+	hashUint32(hasher, uint32(len(clientID)))
+	hasher.Write([]byte(clientID))
+	hashInt64(hasher, timestamp)
+	hasher.Write(requestHash)
+
+	return hasher.Sum(nil)
+}
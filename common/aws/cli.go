@@ -15,8 +15,20 @@ var (
 	FragmentPrefixCharsFlagName         = "aws.fragment-prefix-chars"
 	FragmentParallelismFactorFlagName   = "aws.fragment-parallelism-factor"
 	FragmentParallelismConstantFlagName = "aws.fragment-parallelism-constant"
+	FragmentReadConcurrencyFlagName     = "aws.fragment-read-concurrency"
 	FragmentReadTimeoutFlagName         = "aws.fragment-read-timeout"
 	FragmentWriteTimeoutFlagName        = "aws.fragment-write-timeout"
+	EncryptionKMSKeyIDFlagName          = "aws.encryption-kms-key-id"
+	EncryptionDataKeyLifetimeFlagName   = "aws.encryption-data-key-lifetime"
+	ReplicaRegionFlagName               = "aws.replica-region"
+	ReplicaEndpointURLFlagName          = "aws.replica-endpoint-url"
+	ReplicaBucketSuffixFlagName         = "aws.replica-bucket-suffix"
+	ReplicationQueueSizeFlagName        = "aws.replication-queue-size"
+	ReplicationWorkersFlagName          = "aws.replication-workers"
+	ReplicationTimeoutFlagName          = "aws.replication-timeout"
+	ReplicationMaxQueuedBytesFlagName   = "aws.replication-max-queued-bytes"
+	ReplicationMaxAttemptsFlagName      = "aws.replication-max-attempts"
+	ReplicationRetryBackoffFlagName     = "aws.replication-retry-backoff"
 )
 
 type ClientConfig struct {
@@ -37,6 +49,41 @@ type ClientConfig struct {
 	// FragmentParallelismConstant helps determine the size of the pool of workers to help upload/download files.
 	// A non-zero value for this parameter adds a constant number of workers. Default is 0.
 	FragmentParallelismConstant int
+	// FragmentReadConcurrency is the maximum number of fragments of a single object which are read in parallel, so
+	// that reading an object split into many fragments doesn't take over the pool of workers. If zero, all the fragments
+	// of an object are read in parallel, as workers become available. Default is 16.
+	FragmentReadConcurrency int
+
+	// EncryptionKMSKeyID is the ID of the KMS key blobs and chunks written to S3 are encrypted with. If empty, they are
+	// written in plaintext. Encrypted objects are decrypted on read regardless.
+	EncryptionKMSKeyID string
+	// EncryptionDataKeyLifetime is the time a data key is used to encrypt objects before a new one is generated.
+	EncryptionDataKeyLifetime time.Duration
+
+	// ReplicaRegion is the region objects written to S3 are asynchronously replicated to. Reads which fail in the
+	// primary region fall back to the replica. If empty, objects are not replicated.
+	ReplicaRegion string
+	// ReplicaEndpointURL of the S3 endpoint in the replica region. If this is not set then the default AWS S3 endpoint
+	// will be used.
+	ReplicaEndpointURL string
+	// ReplicaBucketSuffix is appended to the name of a bucket to get the name of its replica, since bucket names are
+	// globally unique. Required if ReplicaRegion is set.
+	ReplicaBucketSuffix string
+	// ReplicationQueueSize is the maximum number of writes waiting to be replicated. Writes made while the queue is
+	// full are not replicated. Default is 1024.
+	ReplicationQueueSize int
+	// ReplicationWorkers is the number of writes replicated concurrently. Default is 8.
+	ReplicationWorkers int
+	// ReplicationTimeout bounds the time spent replicating a single write. Default is 30 seconds.
+	ReplicationTimeout time.Duration
+	// ReplicationMaxQueuedBytes is the maximum total size of the objects waiting to be replicated, which bounds the
+	// memory used by replication. Writes which would exceed it are not replicated. Default is 1 GiB.
+	ReplicationMaxQueuedBytes int64
+	// ReplicationMaxAttempts is the number of times a write is attempted before it is given up on. Default is 5.
+	ReplicationMaxAttempts int
+	// ReplicationRetryBackoff is the time waited before retrying a failed write, which doubles after each attempt.
+	// Default is 1 second.
+	ReplicationRetryBackoff time.Duration
 }
 
 func ClientFlags(envPrefix string, flagPrefix string) []cli.Flag {
@@ -89,6 +136,13 @@ func ClientFlags(envPrefix string, flagPrefix string) []cli.Flag {
 			Value:    0,
 			EnvVar:   common.PrefixEnvVar(envPrefix, "FRAGMENT_PARALLELISM_CONSTANT"),
 		},
+		cli.IntFlag{
+			Name:     common.PrefixFlag(flagPrefix, FragmentReadConcurrencyFlagName),
+			Usage:    "The maximum number of fragments of a single object read in parallel (0 for no limit)",
+			Required: false,
+			Value:    16,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "FRAGMENT_READ_CONCURRENCY"),
+		},
 		cli.DurationFlag{
 			Name:     common.PrefixFlag(flagPrefix, FragmentReadTimeoutFlagName),
 			Usage:    "The maximum time to wait for a single fragmented read",
@@ -103,6 +157,83 @@ func ClientFlags(envPrefix string, flagPrefix string) []cli.Flag {
 			Value:    30 * time.Second,
 			EnvVar:   common.PrefixEnvVar(envPrefix, "FRAGMENT_WRITE_TIMEOUT"),
 		},
+		cli.StringFlag{
+			Name:     common.PrefixFlag(flagPrefix, EncryptionKMSKeyIDFlagName),
+			Usage:    "ID of the KMS key blobs and chunks are encrypted with at rest. If not set, they are stored in plaintext",
+			Required: false,
+			Value:    "",
+			EnvVar:   common.PrefixEnvVar(envPrefix, "AWS_ENCRYPTION_KMS_KEY_ID"),
+		},
+		cli.DurationFlag{
+			Name:     common.PrefixFlag(flagPrefix, EncryptionDataKeyLifetimeFlagName),
+			Usage:    "The time a data key is used to encrypt blobs and chunks before a new one is generated",
+			Required: false,
+			Value:    10 * time.Minute,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "AWS_ENCRYPTION_DATA_KEY_LIFETIME"),
+		},
+		cli.StringFlag{
+			Name:     common.PrefixFlag(flagPrefix, ReplicaRegionFlagName),
+			Usage:    "AWS region objects written to S3 are replicated to. If not set, objects are not replicated",
+			Required: false,
+			Value:    "",
+			EnvVar:   common.PrefixEnvVar(envPrefix, "AWS_REPLICA_REGION"),
+		},
+		cli.StringFlag{
+			Name:     common.PrefixFlag(flagPrefix, ReplicaEndpointURLFlagName),
+			Usage:    "AWS Endpoint URL in the replica region",
+			Required: false,
+			Value:    "",
+			EnvVar:   common.PrefixEnvVar(envPrefix, "AWS_REPLICA_ENDPOINT_URL"),
+		},
+		cli.StringFlag{
+			Name:     common.PrefixFlag(flagPrefix, ReplicaBucketSuffixFlagName),
+			Usage:    "Suffix appended to the name of a bucket to get the name of its replica",
+			Required: false,
+			Value:    "",
+			EnvVar:   common.PrefixEnvVar(envPrefix, "AWS_REPLICA_BUCKET_SUFFIX"),
+		},
+		cli.IntFlag{
+			Name:     common.PrefixFlag(flagPrefix, ReplicationQueueSizeFlagName),
+			Usage:    "The maximum number of writes waiting to be replicated",
+			Required: false,
+			Value:    1024,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "AWS_REPLICATION_QUEUE_SIZE"),
+		},
+		cli.IntFlag{
+			Name:     common.PrefixFlag(flagPrefix, ReplicationWorkersFlagName),
+			Usage:    "The number of writes replicated concurrently",
+			Required: false,
+			Value:    8,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "AWS_REPLICATION_WORKERS"),
+		},
+		cli.DurationFlag{
+			Name:     common.PrefixFlag(flagPrefix, ReplicationTimeoutFlagName),
+			Usage:    "The maximum time to wait for a single write to be replicated",
+			Required: false,
+			Value:    30 * time.Second,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "AWS_REPLICATION_TIMEOUT"),
+		},
+		cli.Int64Flag{
+			Name:     common.PrefixFlag(flagPrefix, ReplicationMaxQueuedBytesFlagName),
+			Usage:    "The maximum total size in bytes of the objects waiting to be replicated",
+			Required: false,
+			Value:    1 << 30,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "AWS_REPLICATION_MAX_QUEUED_BYTES"),
+		},
+		cli.IntFlag{
+			Name:     common.PrefixFlag(flagPrefix, ReplicationMaxAttemptsFlagName),
+			Usage:    "The number of times a write is attempted to be replicated before it is given up on",
+			Required: false,
+			Value:    5,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "AWS_REPLICATION_MAX_ATTEMPTS"),
+		},
+		cli.DurationFlag{
+			Name:     common.PrefixFlag(flagPrefix, ReplicationRetryBackoffFlagName),
+			Usage:    "The time waited before retrying a failed replication, which doubles after each attempt",
+			Required: false,
+			Value:    time.Second,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "AWS_REPLICATION_RETRY_BACKOFF"),
+		},
 	}
 }
 
@@ -114,6 +245,18 @@ func ReadClientConfig(ctx *cli.Context, flagPrefix string) ClientConfig {
 		EndpointURL:                 ctx.GlobalString(common.PrefixFlag(flagPrefix, EndpointURLFlagName)),
 		FragmentParallelismFactor:   ctx.GlobalInt(common.PrefixFlag(flagPrefix, FragmentParallelismFactorFlagName)),
 		FragmentParallelismConstant: ctx.GlobalInt(common.PrefixFlag(flagPrefix, FragmentParallelismConstantFlagName)),
+		FragmentReadConcurrency:     ctx.GlobalInt(common.PrefixFlag(flagPrefix, FragmentReadConcurrencyFlagName)),
+		EncryptionKMSKeyID:          ctx.GlobalString(common.PrefixFlag(flagPrefix, EncryptionKMSKeyIDFlagName)),
+		EncryptionDataKeyLifetime:   ctx.GlobalDuration(common.PrefixFlag(flagPrefix, EncryptionDataKeyLifetimeFlagName)),
+		ReplicaRegion:               ctx.GlobalString(common.PrefixFlag(flagPrefix, ReplicaRegionFlagName)),
+		ReplicaEndpointURL:          ctx.GlobalString(common.PrefixFlag(flagPrefix, ReplicaEndpointURLFlagName)),
+		ReplicaBucketSuffix:         ctx.GlobalString(common.PrefixFlag(flagPrefix, ReplicaBucketSuffixFlagName)),
+		ReplicationQueueSize:        ctx.GlobalInt(common.PrefixFlag(flagPrefix, ReplicationQueueSizeFlagName)),
+		ReplicationWorkers:          ctx.GlobalInt(common.PrefixFlag(flagPrefix, ReplicationWorkersFlagName)),
+		ReplicationTimeout:          ctx.GlobalDuration(common.PrefixFlag(flagPrefix, ReplicationTimeoutFlagName)),
+		ReplicationMaxQueuedBytes:   ctx.GlobalInt64(common.PrefixFlag(flagPrefix, ReplicationMaxQueuedBytesFlagName)),
+		ReplicationMaxAttempts:      ctx.GlobalInt(common.PrefixFlag(flagPrefix, ReplicationMaxAttemptsFlagName)),
+		ReplicationRetryBackoff:     ctx.GlobalDuration(common.PrefixFlag(flagPrefix, ReplicationRetryBackoffFlagName)),
 	}
 }
 
@@ -123,5 +266,13 @@ func DefaultClientConfig() *ClientConfig {
 		Region:                      "us-east-2",
 		FragmentParallelismFactor:   8,
 		FragmentParallelismConstant: 0,
+		FragmentReadConcurrency:     16,
+		EncryptionDataKeyLifetime:   10 * time.Minute,
+		ReplicationQueueSize:        1024,
+		ReplicationWorkers:          8,
+		ReplicationTimeout:          30 * time.Second,
+		ReplicationMaxQueuedBytes:   1 << 30,
+		ReplicationMaxAttempts:      5,
+		ReplicationRetryBackoff:     time.Second,
 	}
 }
@@ -55,11 +55,13 @@ type Client interface {
 	UpdateItem(ctx context.Context, tableName string, key Key, item Item) (Item, error)
 	UpdateItemWithCondition(ctx context.Context, tableName string, key Key, item Item, condition expression.ConditionBuilder) (Item, error)
 	IncrementBy(ctx context.Context, tableName string, key Key, attr string, value uint64) (Item, error)
+	DecrementBy(ctx context.Context, tableName string, key Key, attr string, value uint64) (Item, error)
 	GetItem(ctx context.Context, tableName string, key Key) (Item, error)
 	GetItems(ctx context.Context, tableName string, keys []Key, consistentRead bool) ([]Item, error)
 	QueryIndex(ctx context.Context, tableName string, indexName string, keyCondition string, expAttributeValues ExpressionValues) ([]Item, error)
 	Query(ctx context.Context, tableName string, keyCondition string, expAttributeValues ExpressionValues) ([]Item, error)
 	QueryWithInput(ctx context.Context, input *dynamodb.QueryInput) ([]Item, error)
+	ScanWithInput(ctx context.Context, input *dynamodb.ScanInput) ([]Item, error)
 	QueryIndexCount(ctx context.Context, tableName string, indexName string, keyCondition string, expAttributeValues ExpressionValues) (int32, error)
 	QueryIndexWithPagination(ctx context.Context, tableName string, indexName string, keyCondition string, expAttributeValues ExpressionValues, limit int32, exclusiveStartKey map[string]types.AttributeValue, ascending bool) (QueryResult, error)
 	DeleteItem(ctx context.Context, tableName string, key Key) error
@@ -265,6 +267,44 @@ func (c *client) IncrementBy(ctx context.Context, tableName string, key Key, att
 	return resp.Attributes, nil
 }
 
+// DecrementBy decrements the attribute by the value for item that matches with the key.
+// Returns ErrConditionFailed if the item doesn't exist or the attribute is less than the value.
+func (c *client) DecrementBy(ctx context.Context, tableName string, key Key, attr string, value uint64) (Item, error) {
+	f, err := strconv.ParseFloat(strconv.FormatUint(value, 10), 64)
+	if err != nil {
+		return nil, err
+	}
+
+	update := expression.UpdateBuilder{}
+	update = update.Add(expression.Name(attr), expression.Value(aws.Float64(-f)))
+	condition := expression.Name(attr).GreaterThanEqual(expression.Value(aws.Float64(f)))
+	expr, err := expression.NewBuilder().WithUpdate(update).WithCondition(condition).Build()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.dynamoClient.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName:                 aws.String(tableName),
+		Key:                       key,
+		ConditionExpression:       expr.Condition(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		UpdateExpression:          expr.Update(),
+		ReturnValues:              types.ReturnValueUpdatedNew,
+	})
+
+	var ccfe *types.ConditionalCheckFailedException
+	if errors.As(err, &ccfe) {
+		return nil, ErrConditionFailed
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Attributes, nil
+}
+
 func (c *client) GetItem(ctx context.Context, tableName string, key Key) (Item, error) {
 	resp, err := c.dynamoClient.GetItem(ctx, &dynamodb.GetItemInput{Key: key, TableName: aws.String(tableName)})
 	if err != nil {
@@ -323,6 +363,22 @@ func (c *client) QueryWithInput(ctx context.Context, input *dynamodb.QueryInput)
 	return response.Items, nil
 }
 
+// ScanWithInput scans the whole table with a custom scan input, following the pagination until all items are read
+func (c *client) ScanWithInput(ctx context.Context, input *dynamodb.ScanInput) ([]Item, error) {
+	items := make([]Item, 0)
+	for {
+		response, err := c.dynamoClient.Scan(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, response.Items...)
+		if len(response.LastEvaluatedKey) == 0 {
+			return items, nil
+		}
+		input.ExclusiveStartKey = response.LastEvaluatedKey
+	}
+}
+
 // QueryIndexCount returns the count of the items in the index that match the given key
 func (c *client) QueryIndexCount(ctx context.Context, tableName string, indexName string, keyCondition string, expAttributeValues ExpressionValues) (int32, error) {
 	response, err := c.dynamoClient.Query(ctx, &dynamodb.QueryInput{
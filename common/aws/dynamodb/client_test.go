@@ -250,6 +250,20 @@ func TestBasicOperations(t *testing.T) {
 	assert.Equal(t, "1123", fetchedItem["BlobSize"].(*types.AttributeValueMemberN).Value)
 	assert.Equal(t, "456", fetchedItem["RequestedAt"].(*types.AttributeValueMemberN).Value)
 
+	_, err = dynamoClient.DecrementBy(ctx, tableName, commondynamodb.Key{
+		"MetadataKey": &types.AttributeValueMemberS{Value: "key"},
+	}, "BlobSize", 123)
+	assert.NoError(t, err)
+	_, err = dynamoClient.DecrementBy(ctx, tableName, commondynamodb.Key{
+		"MetadataKey": &types.AttributeValueMemberS{Value: "key"},
+	}, "BlobSize", 1001)
+	assert.ErrorIs(t, err, commondynamodb.ErrConditionFailed)
+	fetchedItem, err = dynamoClient.GetItem(ctx, tableName, commondynamodb.Key{
+		"MetadataKey": &types.AttributeValueMemberS{Value: "key"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "1000", fetchedItem["BlobSize"].(*types.AttributeValueMemberN).Value)
+
 	err = dynamoClient.DeleteTable(ctx, tableName)
 	assert.NoError(t, err)
 }
@@ -0,0 +1,270 @@
+package envelope
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// The envelope format of an encrypted object is:
+//
+//	magic (8 bytes) | key ID length (2 bytes) | key ID | encrypted data key length (2 bytes) | encrypted data key |
+//	nonce (12 bytes) | AES-256-GCM ciphertext
+//
+// The header, i.e. everything before the ciphertext, is authenticated along with the ciphertext, so the recorded key ID
+// cannot be tampered with. Objects which don't start with the magic are plaintext written before encryption was
+// enabled, and are returned unchanged by Decrypt. Blobs can't start with the magic since it isn't a valid field element,
+// and the chance of a serialized proof or chunk starting with it is negligible.
+var magic = []byte{0xED, 0xA0, 'E', 'N', 'V', 'L', 'P', 0x01}
+
+const (
+	nonceSize   = 12
+	dataKeySize = 32
+
+	// maxCachedDataKeys bounds the number of decrypted data keys kept in memory
+	maxCachedDataKeys = 1024
+)
+
+// ErrNoDataKeyProvider is returned when decrypting an encrypted object without a data key provider
+var ErrNoDataKeyProvider = errors.New("object is encrypted but no data key provider is configured")
+
+// DataKey is a key used to encrypt objects, along with its encrypted form which is stored alongside the objects
+type DataKey struct {
+	// KeyID identifies the master key the data key is encrypted with
+	KeyID string
+	// Plaintext is the data key used to encrypt objects. It is never persisted.
+	Plaintext []byte
+	// Encrypted is the data key encrypted with the master key
+	Encrypted []byte
+}
+
+// DataKeyProvider generates data keys, and decrypts the data keys stored with encrypted objects
+type DataKeyProvider interface {
+	// GenerateDataKey generates a new data key encrypted with the given master key
+	GenerateDataKey(ctx context.Context, keyID string) (*DataKey, error)
+	// DecryptDataKey decrypts a data key encrypted with the given master key
+	DecryptDataKey(ctx context.Context, keyID string, encrypted []byte) ([]byte, error)
+}
+
+// Encryptor encrypts objects with envelope encryption: each object is encrypted with a data key, which is itself
+// encrypted with a master key managed by the DataKeyProvider and stored in the header of the object.
+//
+// To limit the calls made to the provider, a data key is reused for encryption until it's older than the configured
+// lifetime, and decrypted data keys are cached.
+type Encryptor struct {
+	provider        DataKeyProvider
+	keyID           string
+	dataKeyLifetime time.Duration
+
+	mu               sync.Mutex
+	dataKey          *DataKey
+	dataKeyCreatedAt time.Time
+	decryptedKeys    map[string][]byte
+}
+
+// NewEncryptor creates an Encryptor which encrypts with data keys under the master key with the given ID.
+// If keyID is empty, objects are written in plaintext, but encrypted objects can still be decrypted. A nil provider
+// disables decryption as well.
+func NewEncryptor(provider DataKeyProvider, keyID string, dataKeyLifetime time.Duration) (*Encryptor, error) {
+	if keyID != "" && provider == nil {
+		return nil, errors.New("data key provider is required to encrypt objects")
+	}
+	if keyID != "" && dataKeyLifetime <= 0 {
+		return nil, errors.New("data key lifetime must be positive")
+	}
+	return &Encryptor{
+		provider:        provider,
+		keyID:           keyID,
+		dataKeyLifetime: dataKeyLifetime,
+		decryptedKeys:   make(map[string][]byte),
+	}, nil
+}
+
+// Enabled returns true if objects are encrypted. A nil Encryptor is valid, and neither encrypts nor decrypts.
+func (e *Encryptor) Enabled() bool {
+	return e != nil && e.keyID != ""
+}
+
+// Encrypt encrypts the data if encryption is enabled, and otherwise returns it unchanged
+func (e *Encryptor) Encrypt(ctx context.Context, data []byte) ([]byte, error) {
+	if !e.Enabled() {
+		return data, nil
+	}
+	dataKey, err := e.currentDataKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(dataKey.KeyID) > 0xFFFF || len(dataKey.Encrypted) > 0xFFFF {
+		return nil, fmt.Errorf("data key of master key %s is too large for the envelope header", dataKey.KeyID)
+	}
+
+	aead, err := newAEAD(dataKey.Plaintext)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, nonceSize)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	headerSize := len(magic) + 2 + len(dataKey.KeyID) + 2 + len(dataKey.Encrypted) + nonceSize
+	header := make([]byte, 0, headerSize+len(data)+aead.Overhead())
+	header = append(header, magic...)
+	header = binary.BigEndian.AppendUint16(header, uint16(len(dataKey.KeyID)))
+	header = append(header, dataKey.KeyID...)
+	header = binary.BigEndian.AppendUint16(header, uint16(len(dataKey.Encrypted)))
+	header = append(header, dataKey.Encrypted...)
+	header = append(header, nonce...)
+
+	return aead.Seal(header, nonce, data, header), nil
+}
+
+// Decrypt decrypts the data if it's encrypted, and otherwise returns it unchanged
+func (e *Encryptor) Decrypt(ctx context.Context, data []byte) ([]byte, error) {
+	if !IsEncrypted(data) {
+		return data, nil
+	}
+	if e == nil || e.provider == nil {
+		return nil, ErrNoDataKeyProvider
+	}
+
+	header, err := parseHeader(data)
+	if err != nil {
+		return nil, err
+	}
+	plaintextKey, err := e.decryptDataKey(ctx, header.keyID, header.encryptedKey)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newAEAD(plaintextKey)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := aead.Open(nil, header.nonce, data[header.size:], data[:header.size])
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt object encrypted with master key %s: %w", header.keyID, err)
+	}
+	return plaintext, nil
+}
+
+// IsEncrypted returns true if the data is in the envelope format
+func IsEncrypted(data []byte) bool {
+	return bytes.HasPrefix(data, magic)
+}
+
+// KeyID returns the ID of the master key the data is encrypted with, or an empty string if it isn't encrypted
+func KeyID(data []byte) (string, error) {
+	if !IsEncrypted(data) {
+		return "", nil
+	}
+	header, err := parseHeader(data)
+	if err != nil {
+		return "", err
+	}
+	return header.keyID, nil
+}
+
+func (e *Encryptor) currentDataKey(ctx context.Context) (*DataKey, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.dataKey != nil && time.Since(e.dataKeyCreatedAt) < e.dataKeyLifetime {
+		return e.dataKey, nil
+	}
+	dataKey, err := e.provider.GenerateDataKey(ctx, e.keyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate data key with master key %s: %w", e.keyID, err)
+	}
+	if len(dataKey.Plaintext) != dataKeySize {
+		return nil, fmt.Errorf("data key must be %d bytes, got %d", dataKeySize, len(dataKey.Plaintext))
+	}
+	e.dataKey = dataKey
+	e.dataKeyCreatedAt = time.Now()
+	return dataKey, nil
+}
+
+func (e *Encryptor) decryptDataKey(ctx context.Context, keyID string, encrypted []byte) ([]byte, error) {
+	e.mu.Lock()
+	plaintext, ok := e.decryptedKeys[string(encrypted)]
+	e.mu.Unlock()
+	if ok {
+		return plaintext, nil
+	}
+
+	plaintext, err := e.provider.DecryptDataKey(ctx, keyID, encrypted)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt data key with master key %s: %w", keyID, err)
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if len(e.decryptedKeys) >= maxCachedDataKeys {
+		e.decryptedKeys = make(map[string][]byte)
+	}
+	e.decryptedKeys[string(encrypted)] = plaintext
+	return plaintext, nil
+}
+
+type envelopeHeader struct {
+	keyID        string
+	encryptedKey []byte
+	nonce        []byte
+	// size is the length of the header, after which the ciphertext starts
+	size int
+}
+
+func parseHeader(data []byte) (*envelopeHeader, error) {
+	offset := len(magic)
+	readField := func(name string) ([]byte, error) {
+		if len(data) < offset+2 {
+			return nil, fmt.Errorf("envelope header is truncated before %s length", name)
+		}
+		length := int(binary.BigEndian.Uint16(data[offset:]))
+		offset += 2
+		if len(data) < offset+length {
+			return nil, fmt.Errorf("envelope header is truncated in %s", name)
+		}
+		field := data[offset : offset+length]
+		offset += length
+		return field, nil
+	}
+
+	keyID, err := readField("key ID")
+	if err != nil {
+		return nil, err
+	}
+	encryptedKey, err := readField("encrypted data key")
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < offset+nonceSize {
+		return nil, errors.New("envelope header is truncated in nonce")
+	}
+	nonce := data[offset : offset+nonceSize]
+	offset += nonceSize
+
+	return &envelopeHeader{
+		keyID:        string(keyID),
+		encryptedKey: encryptedKey,
+		nonce:        nonce,
+		size:         offset,
+	}, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GCM: %w", err)
+	}
+	return aead, nil
+}
@@ -0,0 +1,163 @@
+package envelope_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common/aws/envelope"
+	tu "github.com/Layr-Labs/eigenda/common/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeDataKeyProvider "encrypts" data keys by prefixing them with the ID of the master key
+type fakeDataKeyProvider struct {
+	generated int
+	decrypted int
+}
+
+func (p *fakeDataKeyProvider) GenerateDataKey(ctx context.Context, keyID string) (*envelope.DataKey, error) {
+	p.generated++
+	plaintext := tu.RandomBytes(32)
+	return &envelope.DataKey{
+		KeyID:     keyID,
+		Plaintext: plaintext,
+		Encrypted: append([]byte(keyID), plaintext...),
+	}, nil
+}
+
+func (p *fakeDataKeyProvider) DecryptDataKey(ctx context.Context, keyID string, encrypted []byte) ([]byte, error) {
+	p.decrypted++
+	if !bytes.HasPrefix(encrypted, []byte(keyID)) {
+		return nil, errors.New("data key is not encrypted with the master key")
+	}
+	return encrypted[len(keyID):], nil
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	tu.InitializeRandom()
+	ctx := context.Background()
+	provider := &fakeDataKeyProvider{}
+	encryptor, err := envelope.NewEncryptor(provider, "key-1", time.Hour)
+	require.NoError(t, err)
+	require.True(t, encryptor.Enabled())
+
+	data := tu.RandomBytes(1024)
+	encrypted, err := encryptor.Encrypt(ctx, data)
+	require.NoError(t, err)
+	require.True(t, envelope.IsEncrypted(encrypted))
+	require.False(t, bytes.Contains(encrypted, data))
+	keyID, err := envelope.KeyID(encrypted)
+	require.NoError(t, err)
+	require.Equal(t, "key-1", keyID)
+
+	decrypted, err := encryptor.Decrypt(ctx, encrypted)
+	require.NoError(t, err)
+	require.Equal(t, data, decrypted)
+
+	// the data key is reused within its lifetime, and cached once decrypted
+	encrypted2, err := encryptor.Encrypt(ctx, data)
+	require.NoError(t, err)
+	require.NotEqual(t, encrypted, encrypted2)
+	decrypted, err = encryptor.Decrypt(ctx, encrypted2)
+	require.NoError(t, err)
+	require.Equal(t, data, decrypted)
+	require.Equal(t, 1, provider.generated)
+	require.Equal(t, 1, provider.decrypted)
+
+	// an encryptor without a key decrypts objects written by others
+	reader, err := envelope.NewEncryptor(provider, "", 0)
+	require.NoError(t, err)
+	require.False(t, reader.Enabled())
+	decrypted, err = reader.Decrypt(ctx, encrypted)
+	require.NoError(t, err)
+	require.Equal(t, data, decrypted)
+
+	// tampering with the header or ciphertext is detected
+	for _, i := range []int{10, len(encrypted) - 1} {
+		tampered := bytes.Clone(encrypted)
+		tampered[i] ^= 1
+		_, err = encryptor.Decrypt(ctx, tampered)
+		require.Error(t, err)
+	}
+	_, err = encryptor.Decrypt(ctx, encrypted[:20])
+	require.Error(t, err)
+}
+
+func TestDataKeyRotation(t *testing.T) {
+	ctx := context.Background()
+	provider := &fakeDataKeyProvider{}
+	encryptor, err := envelope.NewEncryptor(provider, "key-1", time.Millisecond)
+	require.NoError(t, err)
+
+	_, err = encryptor.Encrypt(ctx, []byte{1, 2, 3})
+	require.NoError(t, err)
+	time.Sleep(2 * time.Millisecond)
+	_, err = encryptor.Encrypt(ctx, []byte{1, 2, 3})
+	require.NoError(t, err)
+	require.Equal(t, 2, provider.generated)
+}
+
+func TestPlaintextPassthrough(t *testing.T) {
+	ctx := context.Background()
+	data := []byte{0, 1, 2, 3}
+
+	var encryptor *envelope.Encryptor
+	require.False(t, encryptor.Enabled())
+	encrypted, err := encryptor.Encrypt(ctx, data)
+	require.NoError(t, err)
+	require.Equal(t, data, encrypted)
+	decrypted, err := encryptor.Decrypt(ctx, data)
+	require.NoError(t, err)
+	require.Equal(t, data, decrypted)
+
+	// encrypted objects can't be read without a provider
+	encryptor, err = envelope.NewEncryptor(&fakeDataKeyProvider{}, "key-1", time.Hour)
+	require.NoError(t, err)
+	encrypted, err = encryptor.Encrypt(ctx, data)
+	require.NoError(t, err)
+	noProvider, err := envelope.NewEncryptor(nil, "", 0)
+	require.NoError(t, err)
+	_, err = noProvider.Decrypt(ctx, encrypted)
+	require.ErrorIs(t, err, envelope.ErrNoDataKeyProvider)
+
+	_, err = envelope.NewEncryptor(nil, "key-1", time.Hour)
+	require.Error(t, err)
+}
+
+func TestLocalEncryptor(t *testing.T) {
+	tu.InitializeRandom()
+	ctx := context.Background()
+	keyFile := filepath.Join(t.TempDir(), "key")
+	require.NoError(t, os.WriteFile(keyFile, []byte(hex.EncodeToString(tu.RandomBytes(32))+"\n"), 0600))
+
+	encryptor, err := envelope.NewLocalEncryptor(keyFile, time.Hour)
+	require.NoError(t, err)
+	require.True(t, encryptor.Enabled())
+
+	data := tu.RandomBytes(1024)
+	encrypted, err := encryptor.Encrypt(ctx, data)
+	require.NoError(t, err)
+	require.True(t, envelope.IsEncrypted(encrypted))
+	decrypted, err := encryptor.Decrypt(ctx, encrypted)
+	require.NoError(t, err)
+	require.Equal(t, data, decrypted)
+
+	// Objects encrypted with another master key can't be decrypted
+	otherKeyFile := filepath.Join(t.TempDir(), "key")
+	require.NoError(t, os.WriteFile(otherKeyFile, []byte("0x"+hex.EncodeToString(tu.RandomBytes(32))), 0600))
+	otherEncryptor, err := envelope.NewLocalEncryptor(otherKeyFile, time.Hour)
+	require.NoError(t, err)
+	_, err = otherEncryptor.Decrypt(ctx, encrypted)
+	require.ErrorContains(t, err, "configured master key")
+
+	// The master key must be 32 bytes
+	require.NoError(t, os.WriteFile(otherKeyFile, []byte(hex.EncodeToString(tu.RandomBytes(16))), 0600))
+	_, err = envelope.NewLocalEncryptor(otherKeyFile, time.Hour)
+	require.Error(t, err)
+}
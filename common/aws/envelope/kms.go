@@ -0,0 +1,75 @@
+package envelope
+
+import (
+	"context"
+	"fmt"
+
+	commonaws "github.com/Layr-Labs/eigenda/common/aws"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	"github.com/aws/aws-sdk-go-v2/service/kms/types"
+)
+
+type kmsDataKeyProvider struct {
+	keyManager *kms.Client
+}
+
+var _ DataKeyProvider = &kmsDataKeyProvider{}
+
+// NewKMSDataKeyProvider creates a DataKeyProvider backed by AWS KMS
+func NewKMSDataKeyProvider(ctx context.Context, region string, endpoint string) (DataKeyProvider, error) {
+	if endpoint != "" {
+		return &kmsDataKeyProvider{
+			keyManager: kms.New(kms.Options{
+				Region:       region,
+				BaseEndpoint: aws.String(endpoint),
+			}),
+		}, nil
+	}
+
+	cfg, err := config.LoadDefaultConfig(ctx, config.WithRegion(region))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS config: %w", err)
+	}
+	return &kmsDataKeyProvider{
+		keyManager: kms.NewFromConfig(cfg),
+	}, nil
+}
+
+func (p *kmsDataKeyProvider) GenerateDataKey(ctx context.Context, keyID string) (*DataKey, error) {
+	output, err := p.keyManager.GenerateDataKey(ctx, &kms.GenerateDataKeyInput{
+		KeyId:   aws.String(keyID),
+		KeySpec: types.DataKeySpecAes256,
+	})
+	if err != nil {
+		return nil, err
+	}
+	// KMS returns the ARN of the key, which identifies it even if the configured ID is an alias
+	return &DataKey{
+		KeyID:     aws.ToString(output.KeyId),
+		Plaintext: output.Plaintext,
+		Encrypted: output.CiphertextBlob,
+	}, nil
+}
+
+func (p *kmsDataKeyProvider) DecryptDataKey(ctx context.Context, keyID string, encrypted []byte) ([]byte, error) {
+	output, err := p.keyManager.Decrypt(ctx, &kms.DecryptInput{
+		KeyId:          aws.String(keyID),
+		CiphertextBlob: encrypted,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return output.Plaintext, nil
+}
+
+// NewKMSEncryptor creates an Encryptor backed by AWS KMS, which encrypts with the master key in the AWS client config.
+// Objects encrypted with any KMS key accessible to the client can be decrypted, even if encryption is disabled.
+func NewKMSEncryptor(ctx context.Context, cfg commonaws.ClientConfig) (*Encryptor, error) {
+	provider, err := NewKMSDataKeyProvider(ctx, cfg.Region, cfg.EndpointURL)
+	if err != nil {
+		return nil, err
+	}
+	return NewEncryptor(provider, cfg.EncryptionKMSKeyID, cfg.EncryptionDataKeyLifetime)
+}
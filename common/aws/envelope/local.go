@@ -0,0 +1,94 @@
+package envelope
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// localKeyIDPrefix prefixes the IDs of local master keys, so that they can't be mistaken for KMS key IDs
+const localKeyIDPrefix = "local:"
+
+type localDataKeyProvider struct {
+	keyID     string
+	masterKey []byte
+}
+
+var _ DataKeyProvider = &localDataKeyProvider{}
+
+// newLocalDataKeyProvider creates a DataKeyProvider which encrypts data keys with a 32 byte master key held in
+// memory. The ID of the master key is derived from its hash, so that objects encrypted with another master key are
+// reported as such instead of failing to decrypt.
+func newLocalDataKeyProvider(masterKey []byte) (*localDataKeyProvider, error) {
+	if len(masterKey) != dataKeySize {
+		return nil, fmt.Errorf("master key must be %d bytes, got %d", dataKeySize, len(masterKey))
+	}
+	hash := sha256.Sum256(masterKey)
+	return &localDataKeyProvider{
+		keyID:     localKeyIDPrefix + hex.EncodeToString(hash[:8]),
+		masterKey: masterKey,
+	}, nil
+}
+
+func (p *localDataKeyProvider) GenerateDataKey(ctx context.Context, keyID string) (*DataKey, error) {
+	if keyID != p.keyID {
+		return nil, fmt.Errorf("unknown master key %s", keyID)
+	}
+	plaintext := make([]byte, dataKeySize)
+	if _, err := rand.Read(plaintext); err != nil {
+		return nil, fmt.Errorf("failed to generate data key: %w", err)
+	}
+
+	aead, err := newAEAD(p.masterKey)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, nonceSize)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	return &DataKey{
+		KeyID:     p.keyID,
+		Plaintext: plaintext,
+		Encrypted: aead.Seal(nonce, nonce, plaintext, []byte(p.keyID)),
+	}, nil
+}
+
+func (p *localDataKeyProvider) DecryptDataKey(ctx context.Context, keyID string, encrypted []byte) ([]byte, error) {
+	if keyID != p.keyID {
+		return nil, fmt.Errorf("data key is encrypted with master key %s, but the configured master key is %s", keyID, p.keyID)
+	}
+	if len(encrypted) < nonceSize {
+		return nil, errors.New("encrypted data key is too short")
+	}
+
+	aead, err := newAEAD(p.masterKey)
+	if err != nil {
+		return nil, err
+	}
+	return aead.Open(nil, encrypted[:nonceSize], encrypted[nonceSize:], []byte(p.keyID))
+}
+
+// NewLocalEncryptor creates an Encryptor whose data keys are encrypted with the hex encoded 32 byte master key in the
+// given file, for deployments without access to KMS.
+func NewLocalEncryptor(masterKeyFile string, dataKeyLifetime time.Duration) (*Encryptor, error) {
+	data, err := os.ReadFile(masterKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read master key file: %w", err)
+	}
+	masterKey, err := hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(string(data)), "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode master key: %w", err)
+	}
+	provider, err := newLocalDataKeyProvider(masterKey)
+	if err != nil {
+		return nil, err
+	}
+	return NewEncryptor(provider, provider.keyID, dataKeyLifetime)
+}
@@ -54,6 +54,11 @@ func (c *MockDynamoDBClient) IncrementBy(ctx context.Context, tableName string,
 	return args.Get(0).(dynamodb.Item), args.Error(1)
 }
 
+func (c *MockDynamoDBClient) DecrementBy(ctx context.Context, tableName string, key dynamodb.Key, attr string, value uint64) (dynamodb.Item, error) {
+	args := c.Called()
+	return args.Get(0).(dynamodb.Item), args.Error(1)
+}
+
 func (c *MockDynamoDBClient) GetItem(ctx context.Context, tableName string, key dynamodb.Key) (dynamodb.Item, error) {
 	args := c.Called()
 	return args.Get(0).(dynamodb.Item), args.Error(1)
@@ -79,6 +84,11 @@ func (c *MockDynamoDBClient) QueryWithInput(ctx context.Context, input *awsdynam
 	return args.Get(0).([]dynamodb.Item), args.Error(1)
 }
 
+func (c *MockDynamoDBClient) ScanWithInput(ctx context.Context, input *awsdynamodb.ScanInput) ([]dynamodb.Item, error) {
+	args := c.Called()
+	return args.Get(0).([]dynamodb.Item), args.Error(1)
+}
+
 func (c *MockDynamoDBClient) QueryIndexCount(ctx context.Context, tableName string, indexName string, keyCondition string, expAttributeValues dynamodb.ExpressionValues) (int32, error) {
 	args := c.Called()
 	return args.Get(0).(int32), args.Error(1)
@@ -3,12 +3,15 @@ package mock
 import (
 	"context"
 	"errors"
+	"fmt"
 	"strings"
+	"sync"
 
 	"github.com/Layr-Labs/eigenda/common/aws/s3"
 )
 
 type S3Client struct {
+	mu     sync.Mutex
 	bucket map[string][]byte
 	Called map[string]int
 }
@@ -19,19 +22,23 @@ func NewS3Client() *S3Client {
 	return &S3Client{
 		bucket: make(map[string][]byte),
 		Called: map[string]int{
-			"DownloadObject":           0,
-			"HeadObject":               0,
-			"UploadObject":             0,
-			"DeleteObject":             0,
-			"ListObjects":              0,
-			"CreateBucket":             0,
-			"FragmentedUploadObject":   0,
-			"FragmentedDownloadObject": 0,
+			"DownloadObject":                0,
+			"DownloadObjectRange":           0,
+			"HeadObject":                    0,
+			"UploadObject":                  0,
+			"DeleteObject":                  0,
+			"ListObjects":                   0,
+			"CreateBucket":                  0,
+			"FragmentedUploadObject":        0,
+			"FragmentedDownloadObject":      0,
+			"FragmentedDownloadObjectRange": 0,
 		},
 	}
 }
 
 func (s *S3Client) DownloadObject(ctx context.Context, bucket string, key string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	s.Called["DownloadObject"]++
 	data, ok := s.bucket[key]
 	if !ok {
@@ -40,7 +47,29 @@ func (s *S3Client) DownloadObject(ctx context.Context, bucket string, key string
 	return data, nil
 }
 
+func (s *S3Client) DownloadObjectRange(
+	ctx context.Context,
+	bucket string,
+	key string,
+	offset int,
+	length int) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Called["DownloadObjectRange"]++
+	data, ok := s.bucket[key]
+	if !ok {
+		return []byte{}, s3.ErrObjectNotFound
+	}
+	if offset < 0 || length <= 0 || offset+length > len(data) {
+		return nil, fmt.Errorf("range of %d bytes at offset %d is invalid for an object of %d bytes",
+			length, offset, len(data))
+	}
+	return data[offset : offset+length], nil
+}
+
 func (s *S3Client) HeadObject(ctx context.Context, bucket string, key string) (*int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	s.Called["HeadObject"]++
 	data, ok := s.bucket[key]
 	if !ok {
@@ -51,18 +80,24 @@ func (s *S3Client) HeadObject(ctx context.Context, bucket string, key string) (*
 }
 
 func (s *S3Client) UploadObject(ctx context.Context, bucket string, key string, data []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	s.Called["UploadObject"]++
 	s.bucket[key] = data
 	return nil
 }
 
 func (s *S3Client) DeleteObject(ctx context.Context, bucket string, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	s.Called["DeleteObject"]++
 	delete(s.bucket, key)
 	return nil
 }
 
 func (s *S3Client) ListObjects(ctx context.Context, bucket string, prefix string) ([]s3.Object, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	s.Called["ListObjects"]++
 	objects := make([]s3.Object, 0, 1000)
 	for k, v := range s.bucket {
@@ -74,6 +109,8 @@ func (s *S3Client) ListObjects(ctx context.Context, bucket string, prefix string
 }
 
 func (s *S3Client) CreateBucket(ctx context.Context, bucket string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	s.Called["CreateBucket"]++
 	return nil
 }
@@ -84,6 +121,8 @@ func (s *S3Client) FragmentedUploadObject(
 	key string,
 	data []byte,
 	fragmentSize int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	s.Called["FragmentedUploadObject"]++
 	fragments, err := s3.BreakIntoFragments(key, data, fragmentSize)
 	if err != nil {
@@ -101,6 +140,8 @@ func (s *S3Client) FragmentedDownloadObject(
 	key string,
 	fileSize int,
 	fragmentSize int) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	s.Called["FragmentedDownloadObject"]++
 	if fileSize <= 0 {
 		return nil, errors.New("fileSize must be greater than 0")
@@ -132,3 +173,35 @@ func (s *S3Client) FragmentedDownloadObject(
 	}
 	return data, nil
 }
+
+func (s *S3Client) FragmentedDownloadObjectRange(
+	ctx context.Context,
+	bucket string,
+	key string,
+	fileSize int,
+	fragmentSize int,
+	offset int,
+	length int) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Called["FragmentedDownloadObjectRange"]++
+
+	fragmentRanges, err := s3.GetFragmentRanges(key, fileSize, fragmentSize, offset, length)
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([]byte, 0, length)
+	for _, fragmentRange := range fragmentRanges {
+		fragmentData, ok := s.bucket[fragmentRange.FragmentKey]
+		if !ok {
+			return nil, s3.ErrObjectNotFound
+		}
+		if fragmentRange.Offset+fragmentRange.Length > len(fragmentData) {
+			return nil, fmt.Errorf("fragment %s has %d bytes, expected at least %d",
+				fragmentRange.FragmentKey, len(fragmentData), fragmentRange.Offset+fragmentRange.Length)
+		}
+		data = append(data, fragmentData[fragmentRange.Offset:fragmentRange.Offset+fragmentRange.Length]...)
+	}
+	return data, nil
+}
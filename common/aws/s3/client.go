@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"fmt"
 	"runtime"
 	"sync"
 
@@ -33,9 +34,16 @@ type Object struct {
 	Size int64
 }
 
+// fragmentFetcher reads objects, or ranges of objects, from S3.
+type fragmentFetcher interface {
+	GetObject(ctx context.Context, params *s3.GetObjectInput, optFns ...func(*s3.Options)) (*s3.GetObjectOutput, error)
+}
+
 type client struct {
 	cfg      *commonaws.ClientConfig
 	s3Client *s3.Client
+	// fragmentFetcher reads the fragments of objects. It's the S3 client, unless replaced in tests.
+	fragmentFetcher fragmentFetcher
 
 	// concurrencyLimiter is a channel that limits the number of concurrent operations.
 	concurrencyLimiter chan struct{}
@@ -48,65 +56,70 @@ var _ Client = (*client)(nil)
 func NewClient(ctx context.Context, cfg commonaws.ClientConfig, logger logging.Logger) (Client, error) {
 	var err error
 	once.Do(func() {
-		customResolver := aws.EndpointResolverWithOptionsFunc(
-			func(service, region string, options ...interface{}) (aws.Endpoint, error) {
-				if cfg.EndpointURL != "" {
-					return aws.Endpoint{
-						PartitionID:   "aws",
-						URL:           cfg.EndpointURL,
-						SigningRegion: cfg.Region,
-					}, nil
-				}
+		ref, err = newClient(cfg, logger)
+	})
+	return ref, err
+}
 
-				// returning EndpointNotFoundError will allow the service to fallback to its default resolution
-				return aws.Endpoint{}, &aws.EndpointNotFoundError{}
-			})
+// newClient creates a new client. Unlike NewClient, each call creates a distinct client.
+func newClient(cfg commonaws.ClientConfig, logger logging.Logger) (*client, error) {
+	customResolver := aws.EndpointResolverWithOptionsFunc(
+		func(service, region string, options ...interface{}) (aws.Endpoint, error) {
+			if cfg.EndpointURL != "" {
+				return aws.Endpoint{
+					PartitionID:   "aws",
+					URL:           cfg.EndpointURL,
+					SigningRegion: cfg.Region,
+				}, nil
+			}
+
+			// returning EndpointNotFoundError will allow the service to fallback to its default resolution
+			return aws.Endpoint{}, &aws.EndpointNotFoundError{}
+		})
 
-		options := [](func(*config.LoadOptions) error){
-			config.WithRegion(cfg.Region),
-			config.WithEndpointResolverWithOptions(customResolver),
-			config.WithRetryMode(aws.RetryModeStandard),
-		}
-		// If access key and secret access key are not provided, use the default credential provider
-		if len(cfg.AccessKey) > 0 && len(cfg.SecretAccessKey) > 0 {
-			options = append(options,
-				config.WithCredentialsProvider(
-					credentials.NewStaticCredentialsProvider(cfg.AccessKey, cfg.SecretAccessKey, "")))
-		}
-		awsConfig, errCfg := config.LoadDefaultConfig(context.Background(), options...)
+	options := [](func(*config.LoadOptions) error){
+		config.WithRegion(cfg.Region),
+		config.WithEndpointResolverWithOptions(customResolver),
+		config.WithRetryMode(aws.RetryModeStandard),
+	}
+	// If access key and secret access key are not provided, use the default credential provider
+	if len(cfg.AccessKey) > 0 && len(cfg.SecretAccessKey) > 0 {
+		options = append(options,
+			config.WithCredentialsProvider(
+				credentials.NewStaticCredentialsProvider(cfg.AccessKey, cfg.SecretAccessKey, "")))
+	}
+	awsConfig, err := config.LoadDefaultConfig(context.Background(), options...)
 
-		if errCfg != nil {
-			err = errCfg
-			return
-		}
+	if err != nil {
+		return nil, err
+	}
 
-		s3Client := s3.NewFromConfig(awsConfig, func(o *s3.Options) {
-			o.UsePathStyle = true
-		})
+	s3Client := s3.NewFromConfig(awsConfig, func(o *s3.Options) {
+		o.UsePathStyle = true
+	})
 
-		workers := 0
-		if cfg.FragmentParallelismConstant > 0 {
-			workers = cfg.FragmentParallelismConstant
-		}
-		if cfg.FragmentParallelismFactor > 0 {
-			workers = cfg.FragmentParallelismFactor * runtime.NumCPU()
-		}
+	workers := 0
+	if cfg.FragmentParallelismConstant > 0 {
+		workers = cfg.FragmentParallelismConstant
+	}
+	if cfg.FragmentParallelismFactor > 0 {
+		workers = cfg.FragmentParallelismFactor * runtime.NumCPU()
+	}
 
-		if workers == 0 {
-			workers = 1
-		}
+	if workers == 0 {
+		workers = 1
+	}
 
-		pool := &errgroup.Group{}
-		pool.SetLimit(workers)
+	pool := &errgroup.Group{}
+	pool.SetLimit(workers)
 
-		ref = &client{
-			cfg:                &cfg,
-			s3Client:           s3Client,
-			concurrencyLimiter: make(chan struct{}, workers),
-			logger:             logger.With("component", "S3Client"),
-		}
-	})
-	return ref, err
+	return &client{
+		cfg:                &cfg,
+		s3Client:           s3Client,
+		fragmentFetcher:    s3Client,
+		concurrencyLimiter: make(chan struct{}, workers),
+		logger:             logger.With("component", "S3Client"),
+	}, nil
 }
 
 func (s *client) DownloadObject(ctx context.Context, bucket string, key string) ([]byte, error) {
@@ -138,6 +151,29 @@ func (s *client) DownloadObject(ctx context.Context, bucket string, key string)
 	return buffer.Bytes(), nil
 }
 
+func (s *client) DownloadObjectRange(
+	ctx context.Context,
+	bucket string,
+	key string,
+	offset int,
+	length int) ([]byte, error) {
+	if offset < 0 || length <= 0 {
+		return nil, fmt.Errorf("invalid range of %d bytes at offset %d", length, offset)
+	}
+
+	resultChannel := make(chan *readResult, 1)
+	s.readTask(ctx, resultChannel, bucket, key, 0, byteRange(offset, length))
+	result := <-resultChannel
+	if result.err != nil {
+		return nil, result.err
+	}
+	if len(result.fragment.Data) != length {
+		return nil, fmt.Errorf("read %d bytes at offset %d of %s, expected %d",
+			len(result.fragment.Data), offset, key, length)
+	}
+	return result.fragment.Data, nil
+}
+
 func (s *client) HeadObject(ctx context.Context, bucket string, key string) (*int64, error) {
 	output, err := s.s3Client.HeadObject(ctx, &s3.HeadObjectInput{
 		Bucket: aws.String(bucket),
@@ -288,22 +324,101 @@ func (s *client) FragmentedDownloadObject(
 	if err != nil {
 		return nil, err
 	}
-	resultChannel := make(chan *readResult, len(fragmentKeys))
 
+	reads := make([]fragmentRead, len(fragmentKeys))
 	for i, fragmentKey := range fragmentKeys {
-		boundFragmentKey := fragmentKey
-		boundI := i
-		s.concurrencyLimiter <- struct{}{}
+		reads[i] = fragmentRead{key: fragmentKey}
+	}
+	fragments, err := s.readFragments(ctx, bucket, reads)
+	if err != nil {
+		return nil, err
+	}
+
+	return recombineFragments(fragments)
+}
+
+func (s *client) FragmentedDownloadObjectRange(
+	ctx context.Context,
+	bucket string,
+	key string,
+	fileSize int,
+	fragmentSize int,
+	offset int,
+	length int) ([]byte, error) {
+
+	fragmentRanges, err := GetFragmentRanges(key, fileSize, fragmentSize, offset, length)
+	if err != nil {
+		return nil, err
+	}
+
+	reads := make([]fragmentRead, len(fragmentRanges))
+	for i, fragmentRange := range fragmentRanges {
+		reads[i] = fragmentRead{
+			key:       fragmentRange.FragmentKey,
+			byteRange: byteRange(fragmentRange.Offset, fragmentRange.Length),
+		}
+	}
+	fragments, err := s.readFragments(ctx, bucket, reads)
+	if err != nil {
+		return nil, err
+	}
+
+	// the ranges are contiguous, so the data is the concatenation of the ranges
+	data := make([]byte, 0, length)
+	for i, fragment := range fragments {
+		fragmentRange := fragmentRanges[i]
+		if len(fragment.Data) != fragmentRange.Length {
+			return nil, fmt.Errorf("read %d bytes at offset %d of %s, expected %d",
+				len(fragment.Data), fragmentRange.Offset, fragmentRange.FragmentKey, fragmentRange.Length)
+		}
+		data = append(data, fragment.Data...)
+	}
+
+	return data, nil
+}
+
+// fragmentRead is the read of a fragment of an object. If byteRange isn't nil, only the given range of the fragment is
+// read.
+type fragmentRead struct {
+	key       string
+	byteRange *string
+}
+
+// readFragments reads fragments of an object in parallel, and returns them in the order of the reads. The reads take
+// workers from the pool of the client, and at most FragmentReadConcurrency of them run at once, so that reading a large
+// object doesn't take over the pool. Once a read fails, the reads which haven't completed are cancelled.
+func (s *client) readFragments(ctx context.Context, bucket string, reads []fragmentRead) ([]*Fragment, error) {
+	readCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	concurrency := s.cfg.FragmentReadConcurrency
+	if concurrency <= 0 || concurrency > len(reads) {
+		concurrency = len(reads)
+	}
+
+	indices := make(chan int, len(reads))
+	for i := range reads {
+		indices <- i
+	}
+	close(indices)
+
+	resultChannel := make(chan *readResult, len(reads))
+	for i := 0; i < concurrency; i++ {
 		go func() {
-			defer func() {
+			for index := range indices {
+				if readCtx.Err() != nil {
+					resultChannel <- &readResult{err: readCtx.Err()}
+					continue
+				}
+				s.concurrencyLimiter <- struct{}{}
+				s.readTask(readCtx, resultChannel, bucket, reads[index].key, index, reads[index].byteRange)
 				<-s.concurrencyLimiter
-			}()
-			s.readTask(ctx, resultChannel, bucket, boundFragmentKey, boundI)
+			}
 		}()
 	}
 
-	fragments := make([]*Fragment, len(fragmentKeys))
-	for i := 0; i < len(fragmentKeys); i++ {
+	fragments := make([]*Fragment, len(reads))
+	for range reads {
 		result := <-resultChannel
 		if result.err != nil {
 			return nil, result.err
@@ -315,8 +430,12 @@ func (s *client) FragmentedDownloadObject(
 		return nil, ctx.Err()
 	}
 
-	return recombineFragments(fragments)
+	return fragments, nil
+}
 
+// byteRange formats the HTTP range header which selects length bytes starting at offset.
+func byteRange(offset int, length int) *string {
+	return aws.String(fmt.Sprintf("bytes=%d-%d", offset, offset+length-1))
 }
 
 // readResult is the result of a read task.
@@ -325,22 +444,24 @@ type readResult struct {
 	err      error
 }
 
-// readTask reads a single file from S3.
+// readTask reads a single file from S3. If byteRange isn't nil, only the given range of the file is read.
 func (s *client) readTask(
 	ctx context.Context,
 	resultChannel chan *readResult,
 	bucket string,
 	key string,
-	index int) {
+	index int,
+	byteRange *string) {
 
 	result := &readResult{}
 	defer func() {
 		resultChannel <- result
 	}()
 
-	ret, err := s.s3Client.GetObject(ctx, &s3.GetObjectInput{
+	ret, err := s.fragmentFetcher.GetObject(ctx, &s3.GetObjectInput{
 		Bucket: aws.String(bucket),
 		Key:    aws.String(key),
+		Range:  byteRange,
 	})
 
 	if err != nil {
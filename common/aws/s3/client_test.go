@@ -0,0 +1,167 @@
+package s3
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	commonaws "github.com/Layr-Labs/eigenda/common/aws"
+	"github.com/Layr-Labs/eigenda/common/testutils"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeFragmentFetcher serves fragments whose data is their key, after calling the hook of the fetcher if any.
+type fakeFragmentFetcher struct {
+	// hook is called with the key of each fragment before it's served, and can fail the read
+	hook func(ctx context.Context, key string) error
+
+	mu      sync.Mutex
+	fetched []string
+}
+
+func (f *fakeFragmentFetcher) GetObject(
+	ctx context.Context,
+	params *s3.GetObjectInput,
+	optFns ...func(*s3.Options)) (*s3.GetObjectOutput, error) {
+
+	key := aws.ToString(params.Key)
+	f.mu.Lock()
+	f.fetched = append(f.fetched, key)
+	f.mu.Unlock()
+
+	if f.hook != nil {
+		if err := f.hook(ctx, key); err != nil {
+			return nil, err
+		}
+	}
+	data := []byte(key)
+	return &s3.GetObjectOutput{
+		ContentLength: aws.Int64(int64(len(data))),
+		Body:          io.NopCloser(bytes.NewReader(data)),
+	}, nil
+}
+
+func (f *fakeFragmentFetcher) numFetched() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.fetched)
+}
+
+func newTestClient(fetcher fragmentFetcher, readConcurrency int) *client {
+	return &client{
+		cfg:                &commonaws.ClientConfig{FragmentReadConcurrency: readConcurrency},
+		fragmentFetcher:    fetcher,
+		concurrencyLimiter: make(chan struct{}, 64),
+		logger:             testutils.GetLogger(),
+	}
+}
+
+func testFragmentReads(count int) []fragmentRead {
+	reads := make([]fragmentRead, count)
+	for i := range reads {
+		reads[i] = fragmentRead{key: fmt.Sprintf("object-%d", i)}
+	}
+	return reads
+}
+
+func TestReadFragmentsConcurrencyBound(t *testing.T) {
+	release := make(chan struct{})
+	inFlight := atomic.Int32{}
+	maxInFlight := atomic.Int32{}
+	fetcher := &fakeFragmentFetcher{
+		hook: func(ctx context.Context, key string) error {
+			n := inFlight.Add(1)
+			defer inFlight.Add(-1)
+			for {
+				observed := maxInFlight.Load()
+				if n <= observed || maxInFlight.CompareAndSwap(observed, n) {
+					break
+				}
+			}
+			select {
+			case <-release:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		},
+	}
+	c := newTestClient(fetcher, 3)
+
+	reads := testFragmentReads(20)
+	result := make(chan error, 1)
+	go func() {
+		_, err := c.readFragments(context.Background(), "bucket", reads)
+		result <- err
+	}()
+
+	// The reads block until released, so the bound is reached and never exceeded
+	require.Eventually(t, func() bool { return inFlight.Load() == 3 }, time.Second, time.Millisecond)
+	time.Sleep(50 * time.Millisecond)
+	require.Equal(t, int32(3), maxInFlight.Load())
+	require.Equal(t, 3, fetcher.numFetched())
+
+	close(release)
+	require.NoError(t, <-result)
+	require.Equal(t, int32(3), maxInFlight.Load())
+	require.Equal(t, len(reads), fetcher.numFetched())
+}
+
+func TestReadFragmentsCancelOnFailure(t *testing.T) {
+	errFragment := errors.New("fragment unavailable")
+	cancelled := atomic.Int32{}
+	fetcher := &fakeFragmentFetcher{
+		hook: func(ctx context.Context, key string) error {
+			if key == "object-0" {
+				return errFragment
+			}
+			// The other reads only complete once they're cancelled
+			<-ctx.Done()
+			cancelled.Add(1)
+			return ctx.Err()
+		},
+	}
+	c := newTestClient(fetcher, 2)
+
+	reads := testFragmentReads(10)
+	fragments, err := c.readFragments(context.Background(), "bucket", reads)
+	require.ErrorIs(t, err, errFragment)
+	require.Nil(t, fragments)
+
+	// The reads in flight are cancelled, and the reads which haven't started are skipped
+	require.Eventually(t, func() bool { return int(cancelled.Load()) == fetcher.numFetched()-1 }, time.Second, time.Millisecond)
+	require.Less(t, fetcher.numFetched(), len(reads))
+}
+
+func TestReadFragmentsOrder(t *testing.T) {
+	reads := testFragmentReads(16)
+	// The later fragments are read faster, so the reads complete out of order
+	delays := make(map[string]time.Duration)
+	for i, read := range reads {
+		delays[read.key] = time.Duration(len(reads)-i) * time.Millisecond
+	}
+	fetcher := &fakeFragmentFetcher{
+		hook: func(ctx context.Context, key string) error {
+			time.Sleep(delays[key])
+			return nil
+		},
+	}
+	c := newTestClient(fetcher, 4)
+
+	fragments, err := c.readFragments(context.Background(), "bucket", reads)
+	require.NoError(t, err)
+	require.Len(t, fragments, len(reads))
+	for i, fragment := range fragments {
+		require.Equal(t, i, fragment.Index)
+		require.Equal(t, reads[i].key, fragment.FragmentKey)
+		require.Equal(t, []byte(reads[i].key), fragment.Data)
+	}
+}
@@ -83,6 +83,46 @@ func GetFragmentKeys(fileKey string, fragmentCount int) ([]string, error) {
 	return keys, nil
 }
 
+// FragmentRange is a range of bytes within a fragment of a file.
+type FragmentRange struct {
+	FragmentKey string
+	// Offset is the offset of the range within the fragment
+	Offset int
+	// Length is the length of the range
+	Length int
+}
+
+// GetFragmentRanges returns the ranges of the fragments of a file which hold the length bytes of the file starting at
+// offset, in order. The fileSize and fragmentSize must be the same as the values the file was broken into fragments
+// with.
+func GetFragmentRanges(fileKey string, fileSize int, fragmentSize int, offset int, length int) ([]FragmentRange, error) {
+	if fileSize <= 0 || fragmentSize <= 0 {
+		return nil, fmt.Errorf("fileSize and fragmentSize must be greater than 0, got %d and %d", fileSize, fragmentSize)
+	}
+	if offset < 0 || length <= 0 || offset+length > fileSize {
+		return nil, fmt.Errorf("range of %d bytes at offset %d is invalid for a file of %d bytes",
+			length, offset, fileSize)
+	}
+
+	fragmentCount := getFragmentCount(fileSize, fragmentSize)
+	ranges := make([]FragmentRange, 0, (length+fragmentSize-1)/fragmentSize+1)
+	for position := offset; position < offset+length; {
+		index := position / fragmentSize
+		fragmentKey, err := getFragmentKey(fileKey, fragmentCount, index)
+		if err != nil {
+			return nil, err
+		}
+		fragmentEnd := min((index+1)*fragmentSize, offset+length)
+		ranges = append(ranges, FragmentRange{
+			FragmentKey: fragmentKey,
+			Offset:      position - index*fragmentSize,
+			Length:      fragmentEnd - position,
+		})
+		position = fragmentEnd
+	}
+	return ranges, nil
+}
+
 // AllFragmentsExist returns true if all keys are fragment keys.
 // It checks if all fragment keys starting with 0th fragment and ending with nth fragment (marked by "f" postfix) exist.
 // Warning: this function sorts the input slice in place and therefore mutates the ordering of the input slice.
@@ -331,3 +331,49 @@ func TestSortAndCheckAllFragmentsExist(t *testing.T) {
 	}
 	require.False(t, SortAndCheckAllFragmentsExist(keys))
 }
+
+func TestGetFragmentRanges(t *testing.T) {
+	tu.InitializeRandom()
+
+	fileKey := tu.RandomString(10)
+	fragmentSize := rand.Intn(100) + 100
+	fileSize := fragmentSize*(rand.Intn(10)+3) + rand.Intn(fragmentSize)
+	data := tu.RandomBytes(fileSize)
+	fragments, err := BreakIntoFragments(fileKey, data, fragmentSize)
+	require.NoError(t, err)
+	fragmentData := make(map[string][]byte)
+	for _, fragment := range fragments {
+		fragmentData[fragment.FragmentKey] = fragment.Data
+	}
+
+	readRange := func(offset int, length int) []byte {
+		ranges, err := GetFragmentRanges(fileKey, fileSize, fragmentSize, offset, length)
+		require.NoError(t, err)
+		result := make([]byte, 0, length)
+		for _, fragmentRange := range ranges {
+			result = append(result,
+				fragmentData[fragmentRange.FragmentKey][fragmentRange.Offset:fragmentRange.Offset+fragmentRange.Length]...)
+		}
+		return result
+	}
+
+	// a range within a single fragment is read from that fragment only
+	ranges, err := GetFragmentRanges(fileKey, fileSize, fragmentSize, fragmentSize+1, fragmentSize-2)
+	require.NoError(t, err)
+	require.Len(t, ranges, 1)
+	require.Equal(t, data[fragmentSize+1:2*fragmentSize-1], readRange(fragmentSize+1, fragmentSize-2))
+
+	// ranges which span fragments, including the final fragment
+	for i := 0; i < 100; i++ {
+		offset := rand.Intn(fileSize)
+		length := rand.Intn(fileSize-offset) + 1
+		require.Equal(t, data[offset:offset+length], readRange(offset, length))
+	}
+	require.Equal(t, data, readRange(0, fileSize))
+
+	// ranges which extend past the end of the file are rejected
+	_, err = GetFragmentRanges(fileKey, fileSize, fragmentSize, fileSize-1, 2)
+	require.Error(t, err)
+	_, err = GetFragmentRanges(fileKey, fileSize, fragmentSize, 0, 0)
+	require.Error(t, err)
+}
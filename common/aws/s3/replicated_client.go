@@ -0,0 +1,368 @@
+package s3
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	commonaws "github.com/Layr-Labs/eigenda/common/aws"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// ReplicationConfig configures the replication of objects to a replica region.
+type ReplicationConfig struct {
+	// BucketSuffix is appended to the name of a bucket to get the name of its replica. It must not be empty, since
+	// bucket names are globally unique.
+	BucketSuffix string
+	// QueueSize is the maximum number of writes waiting to be replicated.
+	QueueSize int
+	// MaxQueuedBytes is the maximum total size of the objects waiting to be replicated, including the writes waiting
+	// to be retried. Since the queued writes hold the data of the objects in memory, this bounds the memory used by
+	// replication.
+	MaxQueuedBytes int64
+	// NumWorkers is the number of writes replicated concurrently.
+	NumWorkers int
+	// Timeout bounds the time spent replicating a single write.
+	Timeout time.Duration
+	// MaxAttempts is the number of times a write is attempted before it is given up on.
+	MaxAttempts int
+	// RetryBackoff is the time waited before retrying a failed write, which doubles after each attempt.
+	RetryBackoff time.Duration
+}
+
+// replicationTask is a write made to the primary region which has to be repeated in the replica region.
+type replicationTask struct {
+	operation  string
+	key        string
+	size       int64
+	enqueuedAt time.Time
+	attempts   int
+	replicate  func(ctx context.Context) error
+}
+
+// replicatedClient is a Client which asynchronously replicates the writes made to a primary region to a replica
+// region, and reads from the replica when reads from the primary region fail.
+//
+// Replication is best effort: writes are acknowledged once they are made to the primary region. Failed writes are
+// retried with backoff up to MaxAttempts times, and writes made while the queue is full are not replicated.
+type replicatedClient struct {
+	primary Client
+	replica Client
+	config  ReplicationConfig
+	tasks   chan *replicationTask
+	// queuedBytes is the total size of the objects of the queued tasks, and of the tasks waiting to be retried
+	queuedBytes atomic.Int64
+	logger      logging.Logger
+	metrics     *replicationMetrics
+}
+
+var _ Client = (*replicatedClient)(nil)
+
+// NewClientWithReplication creates a client for the region in cfg which replicates writes to cfg.ReplicaRegion.
+// If cfg.ReplicaRegion is empty, this is equivalent to NewClient. The registry may be nil.
+func NewClientWithReplication(
+	ctx context.Context,
+	cfg commonaws.ClientConfig,
+	logger logging.Logger,
+	registry *prometheus.Registry) (Client, error) {
+
+	if cfg.ReplicaRegion != "" && cfg.ReplicaBucketSuffix == "" {
+		return nil, fmt.Errorf("a replica bucket suffix is required to replicate to region %s", cfg.ReplicaRegion)
+	}
+
+	primary, err := NewClient(ctx, cfg, logger)
+	if err != nil || cfg.ReplicaRegion == "" {
+		return primary, err
+	}
+
+	replicaCfg := cfg
+	replicaCfg.Region = cfg.ReplicaRegion
+	replicaCfg.EndpointURL = cfg.ReplicaEndpointURL
+	replica, err := newClient(replicaCfg, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client for replica region %s: %w", cfg.ReplicaRegion, err)
+	}
+
+	return NewReplicatedClient(ctx, primary, replica, ReplicationConfig{
+		BucketSuffix:   cfg.ReplicaBucketSuffix,
+		QueueSize:      cfg.ReplicationQueueSize,
+		MaxQueuedBytes: cfg.ReplicationMaxQueuedBytes,
+		NumWorkers:     cfg.ReplicationWorkers,
+		Timeout:        cfg.ReplicationTimeout,
+		MaxAttempts:    cfg.ReplicationMaxAttempts,
+		RetryBackoff:   cfg.ReplicationRetryBackoff,
+	}, logger, registry)
+}
+
+// NewReplicatedClient creates a client which replicates the writes made to primary to replica. Replication stops when
+// the context is cancelled. The registry may be nil.
+func NewReplicatedClient(
+	ctx context.Context,
+	primary Client,
+	replica Client,
+	config ReplicationConfig,
+	logger logging.Logger,
+	registry *prometheus.Registry) (Client, error) {
+
+	if config.BucketSuffix == "" {
+		return nil, errors.New("replica bucket suffix is required")
+	}
+	if config.QueueSize <= 0 || config.MaxQueuedBytes <= 0 || config.NumWorkers <= 0 || config.Timeout <= 0 ||
+		config.MaxAttempts <= 0 || config.RetryBackoff <= 0 {
+		return nil, fmt.Errorf("invalid replication config: %+v", config)
+	}
+
+	c := &replicatedClient{
+		primary: primary,
+		replica: replica,
+		config:  config,
+		tasks:   make(chan *replicationTask, config.QueueSize),
+		logger:  logger.With("component", "S3ReplicatedClient"),
+		metrics: newReplicationMetrics(registry),
+	}
+	for i := 0; i < config.NumWorkers; i++ {
+		go c.replicate(ctx)
+	}
+	return c, nil
+}
+
+// replicaBucket returns the name of the replica of a bucket.
+func (c *replicatedClient) replicaBucket(bucket string) string {
+	return bucket + c.config.BucketSuffix
+}
+
+// enqueue queues a write of an object of the given size to be replicated. If the queue is full, the write is not
+// replicated.
+func (c *replicatedClient) enqueue(
+	operation string,
+	key string,
+	size int64,
+	replicate func(ctx context.Context) error) {
+
+	if c.queuedBytes.Add(size) > c.config.MaxQueuedBytes {
+		c.queuedBytes.Add(-size)
+		c.logger.Warn("replication queue is full, object will not be replicated",
+			"operation", operation, "key", key, "size", size)
+		c.metrics.reportReplication(operation, "dropped", 0)
+		return
+	}
+	c.push(&replicationTask{
+		operation:  operation,
+		key:        key,
+		size:       size,
+		enqueuedAt: time.Now(),
+		replicate:  replicate,
+	})
+}
+
+// push adds a task whose size is already counted in queuedBytes to the queue, dropping it if the queue is full.
+func (c *replicatedClient) push(task *replicationTask) {
+	select {
+	case c.tasks <- task:
+		c.metrics.reportQueueSize(len(c.tasks), c.queuedBytes.Load())
+	default:
+		c.queuedBytes.Add(-task.size)
+		c.logger.Warn("replication queue is full, object will not be replicated",
+			"operation", task.operation, "key", task.key)
+		c.metrics.reportReplication(task.operation, "dropped", 0)
+	}
+}
+
+func (c *replicatedClient) replicate(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case task := <-c.tasks:
+			c.metrics.reportQueueSize(len(c.tasks), c.queuedBytes.Load())
+			replicateCtx, cancel := context.WithTimeout(ctx, c.config.Timeout)
+			err := task.replicate(replicateCtx)
+			cancel()
+			task.attempts++
+			if err == nil {
+				c.queuedBytes.Add(-task.size)
+				c.metrics.reportReplication(task.operation, "success", time.Since(task.enqueuedAt))
+				continue
+			}
+			if task.attempts >= c.config.MaxAttempts || ctx.Err() != nil {
+				c.queuedBytes.Add(-task.size)
+				c.logger.Error("failed to replicate object, giving up",
+					"operation", task.operation, "key", task.key, "attempts", task.attempts, "err", err)
+				c.metrics.reportReplication(task.operation, "failure", 0)
+				continue
+			}
+
+			backoff := c.config.RetryBackoff << (task.attempts - 1)
+			c.logger.Warn("failed to replicate object, retrying",
+				"operation", task.operation, "key", task.key, "attempts", task.attempts, "backoff", backoff, "err", err)
+			c.metrics.reportReplication(task.operation, "retry", 0)
+			time.AfterFunc(backoff, func() {
+				c.push(task)
+			})
+		}
+	}
+}
+
+// shouldFallBack returns true if a read which failed in the primary region should be retried in the replica region.
+// Objects which are missing from the primary region aren't read from the replica, since the primary is authoritative.
+func shouldFallBack(err error) bool {
+	return err != nil && !errors.Is(err, ErrObjectNotFound)
+}
+
+func (c *replicatedClient) DownloadObject(ctx context.Context, bucket string, key string) ([]byte, error) {
+	data, err := c.primary.DownloadObject(ctx, bucket, key)
+	if !shouldFallBack(err) {
+		return data, err
+	}
+	c.logger.Warn("failed to download object from primary region, falling back to replica", "key", key, "err", err)
+	data, replicaErr := c.replica.DownloadObject(ctx, c.replicaBucket(bucket), key)
+	c.metrics.reportReadFallback(replicaErr)
+	if replicaErr != nil {
+		return nil, fmt.Errorf("failed to download object from primary (%w) and replica (%v)", err, replicaErr)
+	}
+	return data, nil
+}
+
+func (c *replicatedClient) DownloadObjectRange(
+	ctx context.Context,
+	bucket string,
+	key string,
+	offset int,
+	length int) ([]byte, error) {
+
+	data, err := c.primary.DownloadObjectRange(ctx, bucket, key, offset, length)
+	if !shouldFallBack(err) {
+		return data, err
+	}
+	c.logger.Warn("failed to download object range from primary region, falling back to replica", "key", key, "err", err)
+	data, replicaErr := c.replica.DownloadObjectRange(ctx, c.replicaBucket(bucket), key, offset, length)
+	c.metrics.reportReadFallback(replicaErr)
+	if replicaErr != nil {
+		return nil, fmt.Errorf("failed to download object range from primary (%w) and replica (%v)", err, replicaErr)
+	}
+	return data, nil
+}
+
+func (c *replicatedClient) HeadObject(ctx context.Context, bucket string, key string) (*int64, error) {
+	size, err := c.primary.HeadObject(ctx, bucket, key)
+	if !shouldFallBack(err) {
+		return size, err
+	}
+	c.logger.Warn("failed to get object from primary region, falling back to replica", "key", key, "err", err)
+	size, replicaErr := c.replica.HeadObject(ctx, c.replicaBucket(bucket), key)
+	c.metrics.reportReadFallback(replicaErr)
+	if replicaErr != nil {
+		return nil, fmt.Errorf("failed to get object from primary (%w) and replica (%v)", err, replicaErr)
+	}
+	return size, nil
+}
+
+func (c *replicatedClient) UploadObject(ctx context.Context, bucket string, key string, data []byte) error {
+	err := c.primary.UploadObject(ctx, bucket, key, data)
+	if err != nil {
+		return err
+	}
+	c.enqueue("upload", key, int64(len(data)), func(ctx context.Context) error {
+		return c.replica.UploadObject(ctx, c.replicaBucket(bucket), key, data)
+	})
+	return nil
+}
+
+func (c *replicatedClient) DeleteObject(ctx context.Context, bucket string, key string) error {
+	err := c.primary.DeleteObject(ctx, bucket, key)
+	if err != nil {
+		return err
+	}
+	c.enqueue("delete", key, 0, func(ctx context.Context) error {
+		return c.replica.DeleteObject(ctx, c.replicaBucket(bucket), key)
+	})
+	return nil
+}
+
+func (c *replicatedClient) ListObjects(ctx context.Context, bucket string, prefix string) ([]Object, error) {
+	objects, err := c.primary.ListObjects(ctx, bucket, prefix)
+	if !shouldFallBack(err) {
+		return objects, err
+	}
+	c.logger.Warn("failed to list objects in primary region, falling back to replica", "prefix", prefix, "err", err)
+	objects, replicaErr := c.replica.ListObjects(ctx, c.replicaBucket(bucket), prefix)
+	c.metrics.reportReadFallback(replicaErr)
+	if replicaErr != nil {
+		return nil, fmt.Errorf("failed to list objects in primary (%w) and replica (%v)", err, replicaErr)
+	}
+	return objects, nil
+}
+
+// CreateBucket creates the bucket in both regions. Unlike writes to objects, this is done synchronously.
+func (c *replicatedClient) CreateBucket(ctx context.Context, bucket string) error {
+	err := c.primary.CreateBucket(ctx, bucket)
+	if err != nil {
+		return err
+	}
+	return c.replica.CreateBucket(ctx, c.replicaBucket(bucket))
+}
+
+func (c *replicatedClient) FragmentedUploadObject(
+	ctx context.Context,
+	bucket string,
+	key string,
+	data []byte,
+	fragmentSize int) error {
+
+	err := c.primary.FragmentedUploadObject(ctx, bucket, key, data, fragmentSize)
+	if err != nil {
+		return err
+	}
+	c.enqueue("fragmented_upload", key, int64(len(data)), func(ctx context.Context) error {
+		return c.replica.FragmentedUploadObject(ctx, c.replicaBucket(bucket), key, data, fragmentSize)
+	})
+	return nil
+}
+
+func (c *replicatedClient) FragmentedDownloadObject(
+	ctx context.Context,
+	bucket string,
+	key string,
+	fileSize int,
+	fragmentSize int) ([]byte, error) {
+
+	data, err := c.primary.FragmentedDownloadObject(ctx, bucket, key, fileSize, fragmentSize)
+	if !shouldFallBack(err) {
+		return data, err
+	}
+	c.logger.Warn("failed to download fragmented object from primary region, falling back to replica", "key", key, "err", err)
+	data, replicaErr := c.replica.FragmentedDownloadObject(ctx, c.replicaBucket(bucket), key, fileSize, fragmentSize)
+	c.metrics.reportReadFallback(replicaErr)
+	if replicaErr != nil {
+		return nil, fmt.Errorf("failed to download fragmented object from primary (%w) and replica (%v)", err, replicaErr)
+	}
+	return data, nil
+}
+
+func (c *replicatedClient) FragmentedDownloadObjectRange(
+	ctx context.Context,
+	bucket string,
+	key string,
+	fileSize int,
+	fragmentSize int,
+	offset int,
+	length int) ([]byte, error) {
+
+	data, err := c.primary.FragmentedDownloadObjectRange(ctx, bucket, key, fileSize, fragmentSize, offset, length)
+	if !shouldFallBack(err) {
+		return data, err
+	}
+	c.logger.Warn("failed to download fragmented object range from primary region, falling back to replica",
+		"key", key, "err", err)
+	data, replicaErr := c.replica.FragmentedDownloadObjectRange(
+		ctx, c.replicaBucket(bucket), key, fileSize, fragmentSize, offset, length)
+	c.metrics.reportReadFallback(replicaErr)
+	if replicaErr != nil {
+		return nil, fmt.Errorf("failed to download fragmented object range from primary (%w) and replica (%v)",
+			err, replicaErr)
+	}
+	return data, nil
+}
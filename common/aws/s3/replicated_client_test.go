@@ -0,0 +1,259 @@
+package s3_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	commonaws "github.com/Layr-Labs/eigenda/common/aws"
+	"github.com/Layr-Labs/eigenda/common/aws/mock"
+	"github.com/Layr-Labs/eigenda/common/aws/s3"
+	"github.com/Layr-Labs/eigenda/common/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+var errRegionOutage = errors.New("region outage")
+
+// outageClient is a client whose reads fail while its region is down
+type outageClient struct {
+	s3.Client
+	down bool
+}
+
+func (c *outageClient) DownloadObject(ctx context.Context, bucket string, key string) ([]byte, error) {
+	if c.down {
+		return nil, errRegionOutage
+	}
+	return c.Client.DownloadObject(ctx, bucket, key)
+}
+
+func (c *outageClient) FragmentedDownloadObject(ctx context.Context, bucket string, key string, fileSize int, fragmentSize int) ([]byte, error) {
+	if c.down {
+		return nil, errRegionOutage
+	}
+	return c.Client.FragmentedDownloadObject(ctx, bucket, key, fileSize, fragmentSize)
+}
+
+// flakyClient is a client whose first uploads fail, and whose uploads block while it is paused
+type flakyClient struct {
+	s3.Client
+	lock     sync.Mutex
+	failures int
+	paused   chan struct{}
+}
+
+func (c *flakyClient) UploadObject(ctx context.Context, bucket string, key string, data []byte) error {
+	if c.paused != nil {
+		<-c.paused
+	}
+	c.lock.Lock()
+	if c.failures > 0 {
+		c.failures--
+		c.lock.Unlock()
+		return errRegionOutage
+	}
+	c.lock.Unlock()
+	return c.Client.UploadObject(ctx, bucket, key, data)
+}
+
+func testReplicationConfig(queueSize int) s3.ReplicationConfig {
+	return s3.ReplicationConfig{
+		BucketSuffix:   "-replica",
+		QueueSize:      queueSize,
+		MaxQueuedBytes: 1 << 20,
+		NumWorkers:     2,
+		Timeout:        time.Second,
+		MaxAttempts:    3,
+		RetryBackoff:   10 * time.Millisecond,
+	}
+}
+
+func newTestReplicatedClient(t *testing.T, ctx context.Context, queueSize int) (s3.Client, *outageClient, *mock.S3Client) {
+	primary := &outageClient{Client: mock.NewS3Client()}
+	replica := mock.NewS3Client()
+	client, err := s3.NewReplicatedClient(
+		ctx, primary, replica, testReplicationConfig(queueSize), testutils.GetLogger(), nil)
+	require.NoError(t, err)
+	return client, primary, replica
+}
+
+func TestReplicatedClientReplicatesWrites(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client, primary, replica := newTestReplicatedClient(t, ctx, 16)
+
+	data := []byte("blob")
+	err := client.UploadObject(ctx, "bucket", "blob", data)
+	require.NoError(t, err)
+	chunks := make([]byte, 2500)
+	err = client.FragmentedUploadObject(ctx, "bucket", "chunks", chunks, 1000)
+	require.NoError(t, err)
+
+	// writes are acknowledged once made to the primary region
+	fetched, err := primary.DownloadObject(ctx, "bucket", "blob")
+	require.NoError(t, err)
+	require.Equal(t, data, fetched)
+
+	require.Eventually(t, func() bool {
+		fetched, err := replica.DownloadObject(ctx, "bucket-replica", "blob")
+		if err != nil {
+			return false
+		}
+		fetchedChunks, err := replica.FragmentedDownloadObject(ctx, "bucket-replica", "chunks", len(chunks), 1000)
+		return err == nil && string(fetched) == string(data) && len(fetchedChunks) == len(chunks)
+	}, time.Second, 10*time.Millisecond)
+
+	// deletes are replicated too
+	err = client.DeleteObject(ctx, "bucket", "blob")
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		_, err := replica.HeadObject(ctx, "bucket-replica", "blob")
+		return errors.Is(err, s3.ErrObjectNotFound)
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestReplicatedClientReadFallback(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client, primary, replica := newTestReplicatedClient(t, ctx, 16)
+
+	data := []byte("blob")
+	err := client.UploadObject(ctx, "bucket", "blob", data)
+	require.NoError(t, err)
+	chunks := make([]byte, 2500)
+	err = client.FragmentedUploadObject(ctx, "bucket", "chunks", chunks, 1000)
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		_, err := replica.HeadObject(ctx, "bucket-replica", "blob")
+		return err == nil
+	}, time.Second, 10*time.Millisecond)
+	require.Eventually(t, func() bool {
+		objects, err := replica.ListObjects(ctx, "bucket-replica", "chunks")
+		return err == nil && len(objects) == 3
+	}, time.Second, 10*time.Millisecond)
+
+	// reads are served from the replica while the primary region is down
+	primary.down = true
+	fetched, err := client.DownloadObject(ctx, "bucket", "blob")
+	require.NoError(t, err)
+	require.Equal(t, data, fetched)
+	fetchedChunks, err := client.FragmentedDownloadObject(ctx, "bucket", "chunks", len(chunks), 1000)
+	require.NoError(t, err)
+	require.Equal(t, chunks, fetchedChunks)
+
+	// objects missing from both regions can't be read
+	_, err = client.DownloadObject(ctx, "bucket", "missing")
+	require.ErrorIs(t, err, errRegionOutage)
+
+	// objects missing from the primary region aren't read from the replica
+	primary.down = false
+	err = replica.UploadObject(ctx, "bucket-replica", "stale", data)
+	require.NoError(t, err)
+	_, err = client.DownloadObject(ctx, "bucket", "stale")
+	require.ErrorIs(t, err, s3.ErrObjectNotFound)
+}
+
+func TestReplicatedClientQueueFull(t *testing.T) {
+	// the workers are stopped, so nothing is dequeued
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	client, _, _ := newTestReplicatedClient(t, ctx, 1)
+
+	// writes succeed even when they can't be replicated
+	for i := 0; i < 3; i++ {
+		err := client.UploadObject(context.Background(), "bucket", "blob", []byte("blob"))
+		require.NoError(t, err)
+	}
+}
+
+func TestReplicatedClientQueueBytesBound(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	replica := &flakyClient{Client: mock.NewS3Client(), paused: make(chan struct{})}
+	config := testReplicationConfig(16)
+	config.MaxQueuedBytes = 10
+	config.NumWorkers = 1
+	client, err := s3.NewReplicatedClient(
+		ctx, mock.NewS3Client(), replica, config, testutils.GetLogger(), nil)
+	require.NoError(t, err)
+
+	// the third write would take the queued bytes over the bound, so it isn't replicated
+	for _, key := range []string{"a", "b", "c"} {
+		err := client.UploadObject(ctx, "bucket", key, []byte("blob"))
+		require.NoError(t, err)
+	}
+	close(replica.paused)
+
+	require.Eventually(t, func() bool {
+		_, errA := replica.HeadObject(ctx, "bucket-replica", "a")
+		_, errB := replica.HeadObject(ctx, "bucket-replica", "b")
+		return errA == nil && errB == nil
+	}, time.Second, 10*time.Millisecond)
+	_, err = replica.HeadObject(ctx, "bucket-replica", "c")
+	require.ErrorIs(t, err, s3.ErrObjectNotFound)
+
+	// the bytes of replicated writes are released
+	err = client.UploadObject(ctx, "bucket", "d", []byte("blob"))
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		_, err := replica.HeadObject(ctx, "bucket-replica", "d")
+		return err == nil
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestReplicatedClientRetries(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	replica := &flakyClient{Client: mock.NewS3Client(), failures: 2}
+	config := testReplicationConfig(16)
+	config.MaxQueuedBytes = 4
+	client, err := s3.NewReplicatedClient(
+		ctx, mock.NewS3Client(), replica, config, testutils.GetLogger(), nil)
+	require.NoError(t, err)
+
+	// a write which fails fewer than MaxAttempts times is eventually replicated
+	err = client.UploadObject(ctx, "bucket", "a", []byte("blob"))
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		_, err := replica.HeadObject(ctx, "bucket-replica", "a")
+		return err == nil
+	}, time.Second, 10*time.Millisecond)
+
+	// a write which fails MaxAttempts times is given up on, and its bytes are released
+	replica.lock.Lock()
+	replica.failures = 3
+	replica.lock.Unlock()
+	err = client.UploadObject(ctx, "bucket", "b", []byte("blob"))
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		replica.lock.Lock()
+		defer replica.lock.Unlock()
+		return replica.failures == 0
+	}, time.Second, 10*time.Millisecond)
+	require.Eventually(t, func() bool {
+		err := client.UploadObject(ctx, "bucket", "c", []byte("blob"))
+		require.NoError(t, err)
+		_, err = replica.HeadObject(ctx, "bucket-replica", "c")
+		return err == nil
+	}, time.Second, 50*time.Millisecond)
+	_, err = replica.HeadObject(ctx, "bucket-replica", "b")
+	require.ErrorIs(t, err, s3.ErrObjectNotFound)
+}
+
+func TestReplicatedClientRequiresBucketSuffix(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	config := testReplicationConfig(16)
+	config.BucketSuffix = ""
+	_, err := s3.NewReplicatedClient(
+		ctx, mock.NewS3Client(), mock.NewS3Client(), config, testutils.GetLogger(), nil)
+	require.Error(t, err)
+
+	cfg := *commonaws.DefaultClientConfig()
+	cfg.ReplicaRegion = "us-west-2"
+	_, err = s3.NewClientWithReplication(ctx, cfg, testutils.GetLogger(), nil)
+	require.Error(t, err)
+}
@@ -0,0 +1,101 @@
+package s3
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const replicationNamespace = "eigenda_s3_replication"
+
+// replicationMetrics is a struct that holds the metrics for the replication of objects to a replica region.
+type replicationMetrics struct {
+	replicationLag   *prometheus.SummaryVec
+	replicationCount *prometheus.CounterVec
+	queueSize        *prometheus.GaugeVec
+	queuedBytes      *prometheus.GaugeVec
+	readFallbacks    *prometheus.CounterVec
+}
+
+// newReplicationMetrics sets up metrics for replication. If the registry is nil, the metrics aren't registered.
+func newReplicationMetrics(registry *prometheus.Registry) *replicationMetrics {
+	factory := promauto.With(nil)
+	if registry != nil {
+		factory = promauto.With(registry)
+	}
+
+	replicationLag := factory.NewSummaryVec(
+		prometheus.SummaryOpts{
+			Namespace:  replicationNamespace,
+			Name:       "lag_ms",
+			Help:       "The time between a write to the primary region and its replication to the replica region.",
+			Objectives: map[float64]float64{0.5: 0.05, 0.9: 0.01, 0.99: 0.001},
+		},
+		[]string{"operation"},
+	)
+
+	replicationCount := factory.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: replicationNamespace,
+			Name:      "writes_total",
+			Help:      "The number of writes replicated to the replica region, by outcome.",
+		},
+		[]string{"operation", "status"},
+	)
+
+	queueSize := factory.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: replicationNamespace,
+			Name:      "queue_size",
+			Help:      "The number of writes waiting to be replicated.",
+		},
+		[]string{},
+	)
+
+	queuedBytes := factory.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: replicationNamespace,
+			Name:      "queued_bytes",
+			Help:      "The total size of the objects waiting to be replicated, including the writes waiting to be retried.",
+		},
+		[]string{},
+	)
+
+	readFallbacks := factory.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: replicationNamespace,
+			Name:      "read_fallbacks_total",
+			Help:      "The number of reads which failed in the primary region and were served from the replica region.",
+		},
+		[]string{"status"},
+	)
+
+	return &replicationMetrics{
+		replicationLag:   replicationLag,
+		replicationCount: replicationCount,
+		queueSize:        queueSize,
+		queuedBytes:      queuedBytes,
+		readFallbacks:    readFallbacks,
+	}
+}
+
+func (m *replicationMetrics) reportReplication(operation string, status string, lag time.Duration) {
+	m.replicationCount.WithLabelValues(operation, status).Inc()
+	if status == "success" {
+		m.replicationLag.WithLabelValues(operation).Observe(float64(lag.Milliseconds()))
+	}
+}
+
+func (m *replicationMetrics) reportQueueSize(size int, bytes int64) {
+	m.queueSize.WithLabelValues().Set(float64(size))
+	m.queuedBytes.WithLabelValues().Set(float64(bytes))
+}
+
+func (m *replicationMetrics) reportReadFallback(err error) {
+	status := "success"
+	if err != nil {
+		status = "failure"
+	}
+	m.readFallbacks.WithLabelValues(status).Inc()
+}
@@ -8,6 +8,10 @@ type Client interface {
 	// DownloadObject downloads an object from S3.
 	DownloadObject(ctx context.Context, bucket string, key string) ([]byte, error)
 
+	// DownloadObjectRange downloads length bytes of an object from S3, starting at offset, with a single ranged read.
+	// Returns an error if the range extends past the end of the object.
+	DownloadObjectRange(ctx context.Context, bucket string, key string, offset int, length int) ([]byte, error)
+
 	// HeadObject retrieves the size of an object in S3. Returns error if the object does not exist.
 	HeadObject(ctx context.Context, bucket string, key string) (*int64, error)
 
@@ -52,4 +56,17 @@ type Client interface {
 		key string,
 		fileSize int,
 		fragmentSize int) ([]byte, error)
+
+	// FragmentedDownloadObjectRange downloads length bytes of a file uploaded with FragmentedUploadObject, starting at
+	// offset, without downloading the rest of the file. Each fragment which overlaps the range is read with a single
+	// ranged read, so a range within one fragment is read with a single request. The fileSize and fragmentSize must be
+	// the same as the values used in the FragmentedUploadObject call.
+	FragmentedDownloadObjectRange(
+		ctx context.Context,
+		bucket string,
+		key string,
+		fileSize int,
+		fragmentSize int,
+		offset int,
+		length int) ([]byte, error)
 }
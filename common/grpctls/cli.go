@@ -0,0 +1,44 @@
+package grpctls
+
+import (
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/urfave/cli"
+)
+
+var (
+	NodeClientCertFileFlagName = "node-client.tls-cert-file"
+	NodeClientKeyFileFlagName  = "node-client.tls-key-file"
+	NodeClientCAFileFlagName   = "node-client.tls-ca-file"
+)
+
+// NodeClientFlags are the flags configuring TLS on the connections of a disperser to the nodes
+func NodeClientFlags(envPrefix string, flagPrefix string) []cli.Flag {
+	return []cli.Flag{
+		cli.StringFlag{
+			Name:     common.PrefixFlag(flagPrefix, NodeClientCertFileFlagName),
+			Usage:    "Path of the PEM encoded client certificate presented to the nodes, which may require it to accept dispersals",
+			Required: false,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "NODE_CLIENT_TLS_CERT_FILE"),
+		},
+		cli.StringFlag{
+			Name:     common.PrefixFlag(flagPrefix, NodeClientKeyFileFlagName),
+			Usage:    "Path of the PEM encoded private key of the client certificate presented to the nodes",
+			Required: false,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "NODE_CLIENT_TLS_KEY_FILE"),
+		},
+		cli.StringFlag{
+			Name:     common.PrefixFlag(flagPrefix, NodeClientCAFileFlagName),
+			Usage:    "Path of the PEM encoded CA certificates which sign the certificates of the nodes. If set without a client certificate, the connections are encrypted without client authentication",
+			Required: false,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "NODE_CLIENT_TLS_CA_FILE"),
+		},
+	}
+}
+
+func ReadNodeClientConfig(ctx *cli.Context, flagPrefix string) ClientConfig {
+	return ClientConfig{
+		CertFile: ctx.GlobalString(common.PrefixFlag(flagPrefix, NodeClientCertFileFlagName)),
+		KeyFile:  ctx.GlobalString(common.PrefixFlag(flagPrefix, NodeClientKeyFileFlagName)),
+		CAFile:   ctx.GlobalString(common.PrefixFlag(flagPrefix, NodeClientCAFileFlagName)),
+	}
+}
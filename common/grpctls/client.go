@@ -0,0 +1,68 @@
+package grpctls
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// ClientConfig configures TLS on the gRPC connections of a client. The connections are plaintext if nothing is set.
+type ClientConfig struct {
+	// CertFile and KeyFile are the PEM encoded certificate and key presented to the servers, which may require them.
+	CertFile string
+	KeyFile  string
+	// CAFile holds the PEM encoded CA certificates which sign the certificates of the servers. If empty, the certificates
+	// of the servers are verified with the system roots.
+	CAFile string
+}
+
+// Enabled returns true if the connections use TLS.
+func (c *ClientConfig) Enabled() bool {
+	return c.CertFile != "" || c.CAFile != ""
+}
+
+// Validate checks that the config is consistent.
+func (c *ClientConfig) Validate() error {
+	if (c.CertFile == "") != (c.KeyFile == "") {
+		return errors.New("the tls cert file and key file must be set together")
+	}
+	return nil
+}
+
+// Credentials loads the files of the config, and returns the transport credentials of the connections.
+func (c *ClientConfig) Credentials() (credentials.TransportCredentials, error) {
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	if !c.Enabled() {
+		return insecure.NewCredentials(), nil
+	}
+
+	config := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+	}
+	if c.CertFile != "" {
+		certificate, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load tls client certificate: %w", err)
+		}
+		config.Certificates = []tls.Certificate{certificate}
+	}
+	if c.CAFile != "" {
+		pem, err := os.ReadFile(c.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA file %s: %w", c.CAFile, err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no CA certificates found in %s", c.CAFile)
+		}
+		config.RootCAs = pool
+	}
+	return credentials.NewTLS(config), nil
+}
@@ -0,0 +1,23 @@
+package grpctls_test
+
+import (
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/common/grpctls"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientConfig(t *testing.T) {
+	require.False(t, (&grpctls.ClientConfig{}).Enabled())
+	require.True(t, (&grpctls.ClientConfig{CAFile: "ca.crt"}).Enabled())
+	require.NoError(t, (&grpctls.ClientConfig{}).Validate())
+	require.Error(t, (&grpctls.ClientConfig{CertFile: "client.crt"}).Validate())
+	require.Error(t, (&grpctls.ClientConfig{KeyFile: "client.key"}).Validate())
+
+	creds, err := (&grpctls.ClientConfig{}).Credentials()
+	require.NoError(t, err)
+	require.Equal(t, "insecure", creds.Info().SecurityProtocol)
+
+	_, err = (&grpctls.ClientConfig{CAFile: "missing.crt"}).Credentials()
+	require.Error(t, err)
+}
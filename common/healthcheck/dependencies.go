@@ -0,0 +1,140 @@
+package healthcheck
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+const (
+	// DependencyCheckInterval is the interval at which the health of dependencies is checked
+	DependencyCheckInterval = 10 * time.Second
+	// DependencyCheckTimeout bounds the time spent checking the health of a single dependency
+	DependencyCheckTimeout = 5 * time.Second
+)
+
+// Dependency is an external dependency of a server, such as a database, a chain RPC or an object store.
+type Dependency struct {
+	// Name identifies the dependency. Its health is reported as the status of the service "<server name>/<Name>".
+	Name string
+	// Check returns an error if the dependency is unhealthy
+	Check func(ctx context.Context) error
+}
+
+// DependencyChecker periodically checks the health of the dependencies of a server, and reports it through the gRPC
+// health servers registered with it. A service is SERVING only while all of its dependencies are healthy, so that
+// load balancers and probes stop routing requests to servers which can't serve them.
+type DependencyChecker struct {
+	dependencies []Dependency
+	logger       logging.Logger
+
+	mu sync.Mutex
+	// healthServers are the health servers registered with the checker, by service name
+	healthServers map[string]*health.Server
+	// healthy is the result of the last check of each dependency, by dependency name
+	healthy map[string]bool
+}
+
+// NewDependencyChecker creates a checker of the given dependencies. Until the first check, all dependencies are
+// considered healthy.
+func NewDependencyChecker(logger logging.Logger, dependencies ...Dependency) *DependencyChecker {
+	healthy := make(map[string]bool, len(dependencies))
+	for _, dependency := range dependencies {
+		healthy[dependency.Name] = true
+	}
+	return &DependencyChecker{
+		dependencies:  dependencies,
+		logger:        logger.With("component", "DependencyChecker"),
+		healthServers: make(map[string]*health.Server),
+		healthy:       healthy,
+	}
+}
+
+// Start checks the dependencies once, then every DependencyCheckInterval until the context is cancelled. When the
+// context is cancelled, all services are reported as NOT_SERVING.
+func (c *DependencyChecker) Start(ctx context.Context) {
+	c.CheckDependencies(ctx)
+	go func() {
+		ticker := time.NewTicker(DependencyCheckInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				c.mu.Lock()
+				for _, healthServer := range c.healthServers {
+					healthServer.Shutdown()
+				}
+				c.mu.Unlock()
+				return
+			case <-ticker.C:
+				c.CheckDependencies(ctx)
+			}
+		}
+	}()
+}
+
+// CheckDependencies checks the health of all dependencies concurrently, and updates the status of the services.
+func (c *DependencyChecker) CheckDependencies(ctx context.Context) {
+	healthy := make([]bool, len(c.dependencies))
+	var wg sync.WaitGroup
+	for i, dependency := range c.dependencies {
+		wg.Add(1)
+		go func(i int, dependency Dependency) {
+			defer wg.Done()
+			checkCtx, cancel := context.WithTimeout(ctx, DependencyCheckTimeout)
+			defer cancel()
+			if err := dependency.Check(checkCtx); err != nil {
+				c.logger.Warn("dependency is unhealthy", "dependency", dependency.Name, "err", err)
+				return
+			}
+			healthy[i] = true
+		}(i, dependency)
+	}
+	wg.Wait()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i, dependency := range c.dependencies {
+		c.healthy[dependency.Name] = healthy[i]
+	}
+	for name, healthServer := range c.healthServers {
+		c.updateStatus(name, healthServer)
+	}
+}
+
+// RegisterHealthServer registers a gRPC health server reporting the health of the service name and of each of its
+// dependencies with the given gRPC server. Registering the same name with several gRPC servers shares the health
+// server between them.
+func (c *DependencyChecker) RegisterHealthServer(name string, server *grpc.Server) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	healthServer, ok := c.healthServers[name]
+	if !ok {
+		healthServer = health.NewServer()
+		c.healthServers[name] = healthServer
+		c.updateStatus(name, healthServer)
+	}
+	grpc_health_v1.RegisterHealthServer(server, healthServer)
+}
+
+// updateStatus sets the status of the service name, of the server as a whole and of each dependency.
+// The caller must hold the lock.
+func (c *DependencyChecker) updateStatus(name string, healthServer *health.Server) {
+	status := grpc_health_v1.HealthCheckResponse_SERVING
+	for _, dependency := range c.dependencies {
+		dependencyStatus := grpc_health_v1.HealthCheckResponse_SERVING
+		if !c.healthy[dependency.Name] {
+			dependencyStatus = grpc_health_v1.HealthCheckResponse_NOT_SERVING
+			status = grpc_health_v1.HealthCheckResponse_NOT_SERVING
+		}
+		healthServer.SetServingStatus(name+"/"+dependency.Name, dependencyStatus)
+	}
+	healthServer.SetServingStatus(name, status)
+	// The empty service name is the health of the server as a whole, which is what probes check by default
+	healthServer.SetServingStatus("", status)
+}
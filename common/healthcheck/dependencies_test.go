@@ -0,0 +1,74 @@
+package healthcheck_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync/atomic"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/common/healthcheck"
+	"github.com/Layr-Labs/eigenda/common/testutils"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestDependencyChecker(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var dbDown atomic.Bool
+	checker := healthcheck.NewDependencyChecker(testutils.GetLogger(),
+		healthcheck.Dependency{
+			Name: "dynamodb",
+			Check: func(ctx context.Context) error {
+				if dbDown.Load() {
+					return errors.New("connection refused")
+				}
+				return nil
+			},
+		},
+		healthcheck.Dependency{
+			Name:  "chain",
+			Check: func(ctx context.Context) error { return nil },
+		},
+	)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	gs := grpc.NewServer()
+	checker.RegisterHealthServer("test.Service", gs)
+	go func() {
+		_ = gs.Serve(listener)
+	}()
+	defer gs.Stop()
+	checker.Start(ctx)
+
+	conn, err := grpc.NewClient(listener.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer func() { _ = conn.Close() }()
+	client := grpc_health_v1.NewHealthClient(conn)
+	status := func(service string) grpc_health_v1.HealthCheckResponse_ServingStatus {
+		reply, err := client.Check(ctx, &grpc_health_v1.HealthCheckRequest{Service: service})
+		require.NoError(t, err)
+		return reply.GetStatus()
+	}
+
+	require.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, status(""))
+	require.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, status("test.Service"))
+	require.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, status("test.Service/dynamodb"))
+
+	// the service isn't serving while any of its dependencies is unhealthy
+	dbDown.Store(true)
+	checker.CheckDependencies(ctx)
+	require.Equal(t, grpc_health_v1.HealthCheckResponse_NOT_SERVING, status(""))
+	require.Equal(t, grpc_health_v1.HealthCheckResponse_NOT_SERVING, status("test.Service"))
+	require.Equal(t, grpc_health_v1.HealthCheckResponse_NOT_SERVING, status("test.Service/dynamodb"))
+	require.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, status("test.Service/chain"))
+
+	dbDown.Store(false)
+	checker.CheckDependencies(ctx)
+	require.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, status("test.Service"))
+}
@@ -13,6 +13,7 @@ import (
 )
 
 var _ kvstore.Store[[]byte] = &levelDBStore{}
+var _ kvstore.Compactor = &levelDBStore{}
 
 // levelDBStore implements kvstore.Store interfaces with levelDB as the backend engine.
 type levelDBStore struct {
@@ -87,6 +88,12 @@ func (store *levelDBStore) WriteBatch(keys [][]byte, values [][]byte) error {
 	return store.db.Write(batch, nil)
 }
 
+// Compact compacts the keys in the range [start, limit). A nil start or limit means the range is unbounded on that
+// side.
+func (store *levelDBStore) Compact(start []byte, limit []byte) error {
+	return store.db.CompactRange(util.Range{Start: start, Limit: limit})
+}
+
 // NewBatch creates a new batch for the store.
 func (store *levelDBStore) NewBatch() kvstore.Batch[[]byte] {
 	return &levelDBBatch{
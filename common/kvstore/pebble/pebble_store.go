@@ -0,0 +1,347 @@
+package pebble
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/Layr-Labs/eigenda/common/kvstore"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/cockroachdb/pebble"
+	"github.com/cockroachdb/pebble/bloom"
+	"github.com/syndtr/goleveldb/leveldb/iterator"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+var _ kvstore.Store[[]byte] = &pebbleStore{}
+var _ kvstore.Compactor = &pebbleStore{}
+var _ kvstore.RangeDeleter = &pebbleStore{}
+
+const (
+	// memTableSize is the size of each memtable. Chunks are large and written in bursts when a batch arrives, so
+	// memtables much larger than the default 4MiB keep writes from stalling on flushes.
+	memTableSize = 64 << 20
+	// memTableStopWritesThreshold is the number of queued memtables above which writes stall
+	memTableStopWritesThreshold = 4
+	// l0CompactionThreshold is the read amplification of L0 above which it is compacted
+	l0CompactionThreshold = 4
+	// l0StopWritesThreshold is the read amplification of L0 above which writes stall
+	l0StopWritesThreshold = 32
+	// lBaseMaxBytes is the maximum size of the base level of the LSM
+	lBaseMaxBytes = 512 << 20
+	// maxConcurrentCompactions is the number of compactions which may run concurrently. NVMe drives sustain many
+	// concurrent IOs, so compactions are parallelized to keep up with ingestion.
+	maxConcurrentCompactions = 4
+	// bytesPerSync is the number of bytes written to sstables and the WAL between background syncs, which smooths
+	// out writes to disk
+	bytesPerSync = 1 << 20
+	// cacheSize is the size of the block cache
+	cacheSize = 256 << 20
+	// numLevels is the number of levels of the LSM
+	numLevels = 7
+)
+
+// pebbleStore implements kvstore.Store interfaces with Pebble as the backend engine.
+type pebbleStore struct {
+	db   *pebble.DB
+	path string
+
+	logger logging.Logger
+
+	// iterators are the iterators which haven't been released yet. Pebble refuses to close a DB with open iterators,
+	// so they are released on shutdown, like LevelDB does.
+	iterators     map[*pebbleIterator]struct{}
+	iteratorsLock sync.Mutex
+
+	shutdown bool
+}
+
+// NewStore returns a new pebbleStore built using Pebble. The store is tuned for large values which are written in
+// bursts and don't compress, such as encoded chunks.
+func NewStore(logger logging.Logger, path string) (kvstore.Store[[]byte], error) {
+	cache := pebble.NewCache(cacheSize)
+	defer cache.Unref()
+
+	options := &pebble.Options{
+		Cache:                       cache,
+		MemTableSize:                memTableSize,
+		MemTableStopWritesThreshold: memTableStopWritesThreshold,
+		L0CompactionThreshold:       l0CompactionThreshold,
+		L0StopWritesThreshold:       l0StopWritesThreshold,
+		LBaseMaxBytes:               lBaseMaxBytes,
+		MaxConcurrentCompactions:    func() int { return maxConcurrentCompactions },
+		BytesPerSync:                bytesPerSync,
+		WALBytesPerSync:             bytesPerSync,
+		Levels:                      make([]pebble.LevelOptions, numLevels),
+	}
+	for i := range options.Levels {
+		// Chunks are field elements, which are incompressible, so compressing blocks only costs CPU
+		options.Levels[i].Compression = pebble.NoCompression
+		options.Levels[i].FilterPolicy = bloom.FilterPolicy(10)
+	}
+
+	db, err := pebble.Open(path, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pebbleStore{
+		db:        db,
+		path:      path,
+		logger:    logger,
+		iterators: make(map[*pebbleIterator]struct{}),
+	}, nil
+}
+
+// Put stores a data in the store.
+func (store *pebbleStore) Put(key []byte, value []byte) error {
+	if value == nil {
+		value = []byte{}
+	}
+	return store.db.Set(key, value, pebble.NoSync)
+}
+
+// Get retrieves data from the store. Returns kvstore.ErrNotFound if the data is not found.
+func (store *pebbleStore) Get(key []byte) ([]byte, error) {
+	data, closer, err := store.db.Get(key)
+	if err != nil {
+		if errors.Is(err, pebble.ErrNotFound) {
+			return nil, kvstore.ErrNotFound
+		}
+		return nil, err
+	}
+	defer func() {
+		_ = closer.Close()
+	}()
+
+	// The data returned by pebble is only valid until the closer is closed
+	value := make([]byte, len(data))
+	copy(value, data)
+	return value, nil
+}
+
+// NewIterator creates a new iterator. Only keys prefixed with the given prefix will be iterated.
+func (store *pebbleStore) NewIterator(prefix []byte) (iterator.Iterator, error) {
+	keyRange := util.BytesPrefix(prefix)
+	it, err := store.db.NewIter(&pebble.IterOptions{
+		LowerBound: keyRange.Start,
+		UpperBound: keyRange.Limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	iter := &pebbleIterator{store: store, it: it}
+	store.iteratorsLock.Lock()
+	store.iterators[iter] = struct{}{}
+	store.iteratorsLock.Unlock()
+	return iter, nil
+}
+
+// Delete deletes data from the store.
+func (store *pebbleStore) Delete(key []byte) error {
+	return store.db.Delete(key, pebble.NoSync)
+}
+
+// DeleteRange deletes all keys in the range [start, limit) with a single range tombstone.
+func (store *pebbleStore) DeleteRange(start []byte, limit []byte) error {
+	return store.db.DeleteRange(start, limit, pebble.NoSync)
+}
+
+// ApproximateSize returns the approximate number of bytes on disk used by the keys in the range [start, limit).
+func (store *pebbleStore) ApproximateSize(start []byte, limit []byte) (uint64, error) {
+	return store.db.EstimateDiskUsage(start, limit)
+}
+
+// Compact compacts the keys in the range [start, limit). A nil start or limit means the range is unbounded on that
+// side.
+func (store *pebbleStore) Compact(start []byte, limit []byte) error {
+	if start == nil || limit == nil {
+		// Pebble requires explicit bounds, so bound the range by the first and last keys of the store
+		it, err := store.db.NewIter(nil)
+		if err != nil {
+			return err
+		}
+		if start == nil && it.First() {
+			start = append([]byte{}, it.Key()...)
+		}
+		if limit == nil && it.Last() {
+			limit = append(append([]byte{}, it.Key()...), 0)
+		}
+		if err := it.Close(); err != nil {
+			return err
+		}
+		if start == nil || limit == nil {
+			// The store is empty
+			return nil
+		}
+	}
+	if bytes.Compare(start, limit) >= 0 {
+		return nil
+	}
+	return store.db.Compact(start, limit, true)
+}
+
+// NewBatch creates a new batch for the store.
+func (store *pebbleStore) NewBatch() kvstore.Batch[[]byte] {
+	return &pebbleBatch{
+		store: store,
+		batch: store.db.NewBatch(),
+	}
+}
+
+// pebbleBatch accumulates operations in a batch which is never committed itself, since a pebble batch can't be
+// modified once committed. Like a LevelDB batch, it may be applied several times, and each application writes all
+// operations added so far.
+type pebbleBatch struct {
+	store *pebbleStore
+	batch *pebble.Batch
+}
+
+func (m *pebbleBatch) Put(key []byte, value []byte) {
+	if value == nil {
+		value = []byte{}
+	}
+	// Set only fails on a batch which has been committed
+	_ = m.batch.Set(key, value, nil)
+}
+
+func (m *pebbleBatch) Delete(key []byte) {
+	_ = m.batch.Delete(key, nil)
+}
+
+func (m *pebbleBatch) Apply() error {
+	commit := m.store.db.NewBatch()
+	if err := commit.Apply(m.batch, nil); err != nil {
+		return err
+	}
+	return m.store.db.Apply(commit, pebble.NoSync)
+}
+
+// Size returns the number of operations in the batch.
+func (m *pebbleBatch) Size() uint32 {
+	return m.batch.Count()
+}
+
+// pebbleIterator adapts a pebble iterator to the LevelDB iterator interface. Like a LevelDB iterator, it is
+// positioned before the first key until it is moved.
+type pebbleIterator struct {
+	util.BasicReleaser
+	store *pebbleStore
+	it    *pebble.Iterator
+	// positioned is true once the iterator has been moved
+	positioned bool
+	err        error
+}
+
+func (it *pebbleIterator) First() bool {
+	it.positioned = true
+	return it.it.First()
+}
+
+func (it *pebbleIterator) Last() bool {
+	it.positioned = true
+	return it.it.Last()
+}
+
+func (it *pebbleIterator) Seek(key []byte) bool {
+	it.positioned = true
+	return it.it.SeekGE(key)
+}
+
+func (it *pebbleIterator) Next() bool {
+	if !it.positioned {
+		return it.First()
+	}
+	return it.it.Next()
+}
+
+func (it *pebbleIterator) Prev() bool {
+	if !it.positioned {
+		return it.Last()
+	}
+	return it.it.Prev()
+}
+
+func (it *pebbleIterator) Valid() bool {
+	return it.positioned && it.it.Valid()
+}
+
+func (it *pebbleIterator) Error() error {
+	if it.err != nil {
+		return it.err
+	}
+	return it.it.Error()
+}
+
+func (it *pebbleIterator) Key() []byte {
+	if !it.Valid() {
+		return nil
+	}
+	return it.it.Key()
+}
+
+func (it *pebbleIterator) Value() []byte {
+	if !it.Valid() {
+		return nil
+	}
+	return it.it.Value()
+}
+
+func (it *pebbleIterator) Release() {
+	if it.Released() {
+		return
+	}
+	it.err = it.it.Close()
+	it.BasicReleaser.Release()
+
+	it.store.iteratorsLock.Lock()
+	defer it.store.iteratorsLock.Unlock()
+	delete(it.store.iterators, it)
+}
+
+// Shutdown shuts down the store.
+//
+// Warning: it is not thread safe to call this method concurrently with other methods on this class,
+// or while there exist unclosed iterators.
+func (store *pebbleStore) Shutdown() error {
+	store.iteratorsLock.Lock()
+	iterators := make([]*pebbleIterator, 0, len(store.iterators))
+	for it := range store.iterators {
+		iterators = append(iterators, it)
+	}
+	store.iteratorsLock.Unlock()
+	for _, it := range iterators {
+		it.Release()
+	}
+
+	err := store.db.Close()
+	if err != nil {
+		return err
+	}
+
+	store.shutdown = true
+	return nil
+}
+
+// Destroy destroys the store.
+//
+// Warning: it is not thread safe to call this method concurrently with other methods on this class,
+// or while there exist unclosed iterators.
+func (store *pebbleStore) Destroy() error {
+	if !store.shutdown {
+		err := store.Shutdown()
+		if err != nil {
+			return err
+		}
+	}
+
+	store.logger.Info(fmt.Sprintf("destroying Pebble store at path: %s", store.path))
+	err := os.RemoveAll(store.path)
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -46,3 +46,22 @@ type Store[K any] interface {
 	// or while there exist unclosed iterators.
 	Destroy() error
 }
+
+// Compactor is implemented by stores which can be asked to compact their underlying storage.
+type Compactor interface {
+	// Compact compacts the underlying storage of the keys in the range [start, limit), discarding deleted and
+	// overwritten data so that its disk space is reclaimed. A nil start or limit means the range is unbounded on
+	// that side.
+	Compact(start []byte, limit []byte) error
+}
+
+// RangeDeleter is implemented by stores which can delete a contiguous range of keys without reading it, e.g. with
+// range tombstones.
+type RangeDeleter interface {
+	// DeleteRange deletes all keys in the range [start, limit).
+	DeleteRange(start []byte, limit []byte) error
+
+	// ApproximateSize returns the approximate number of bytes on disk used by the keys in the range [start, limit).
+	// Data which hasn't been flushed to disk yet isn't counted.
+	ApproximateSize(start []byte, limit []byte) (uint64, error)
+}
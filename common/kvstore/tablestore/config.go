@@ -10,6 +10,8 @@ const (
 	LevelDB StoreType = iota
 	// MapStore is an in-memory store. This store does not preserve data across restarts.
 	MapStore
+	// Pebble is a Pebble-backed store.
+	Pebble
 )
 
 // Config is the configuration for a TableStore.
@@ -18,7 +20,7 @@ type Config struct {
 	Type StoreType
 	// The path to the file system directory where the store will write its data. Default is nil.
 	// Some store implementations may ignore this field (e.g. MapStore). Other store implementations may require
-	// this field to be set (e.g. LevelDB).
+	// this field to be set (e.g. LevelDB, Pebble).
 	Path *string
 	// If true, the store will perform garbage collection on a background goroutine. Default is true.
 	GarbageCollectionEnabled bool
@@ -52,6 +54,14 @@ func DefaultLevelDBConfig(path string) *Config {
 	return config
 }
 
+// DefaultPebbleConfig returns a Config with default values for a Pebble store.
+func DefaultPebbleConfig(path string) *Config {
+	config := DefaultConfig()
+	config.Type = Pebble
+	config.Path = &path
+	return config
+}
+
 // DefaultMapStoreConfig returns a Config with default values for a MapStore.
 func DefaultMapStoreConfig() *Config {
 	config := DefaultConfig()
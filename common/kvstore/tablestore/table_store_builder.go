@@ -7,6 +7,7 @@ import (
 	"github.com/Layr-Labs/eigenda/common/kvstore"
 	"github.com/Layr-Labs/eigenda/common/kvstore/leveldb"
 	"github.com/Layr-Labs/eigenda/common/kvstore/mapstore"
+	"github.com/Layr-Labs/eigenda/common/kvstore/pebble"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	"math"
 	"sort"
@@ -57,8 +58,14 @@ func Start(logger logging.Logger, config *Config) (kvstore.TableStore, error) {
 	return start(logger, base, config)
 }
 
-// Future work: if we ever decide to permit third parties to provide custom store implementations not in this module,
-// we will need to make this method public.
+// StartWithStore creates a new TableStore on top of the given base store, e.g. a store wrapping one of the stores of
+// this module. The type and path of the config are ignored.
+func StartWithStore(logger logging.Logger, base kvstore.Store[[]byte], config *Config) (kvstore.TableStore, error) {
+	if config == nil {
+		return nil, errors.New("config is required")
+	}
+	return start(logger, base, config)
+}
 
 // start creates a new TableStore instance with the given base store and table names. If modifySchema is true, the
 // tables in the store are made to match the given list of tables by adding and removing tables as needed. If
@@ -125,6 +132,11 @@ func buildBaseStore(
 		return leveldb.NewStore(logger, *path)
 	case MapStore:
 		return mapstore.NewStore(), nil
+	case Pebble:
+		if path == nil {
+			return nil, errors.New("path is required for Pebble store")
+		}
+		return pebble.NewStore(logger, *path)
 	default:
 		return nil, fmt.Errorf("unknown store type: %d", storeType)
 	}
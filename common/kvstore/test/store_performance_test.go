@@ -6,6 +6,7 @@ import (
 	"github.com/Layr-Labs/eigenda/common/kvstore"
 	"github.com/Layr-Labs/eigenda/common/kvstore/leveldb"
 	"github.com/Layr-Labs/eigenda/common/kvstore/mapstore"
+	"github.com/Layr-Labs/eigenda/common/kvstore/pebble"
 	tu "github.com/Layr-Labs/eigenda/common/testutils"
 	"github.com/stretchr/testify/assert"
 	"golang.org/x/exp/rand"
@@ -116,3 +117,13 @@ func BenchmarkLevelDB(b *testing.B) {
 
 	writeThenReadBenchmark(b, store)
 }
+
+func BenchmarkPebble(b *testing.B) {
+	logger, err := common.NewLogger(common.DefaultLoggerConfig())
+	assert.NoError(b, err)
+
+	store, err := pebble.NewStore(logger, dbPath)
+	assert.NoError(b, err)
+
+	writeThenReadBenchmark(b, store)
+}
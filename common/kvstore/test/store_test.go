@@ -5,6 +5,7 @@ import (
 	"github.com/Layr-Labs/eigenda/common/kvstore"
 	"github.com/Layr-Labs/eigenda/common/kvstore/leveldb"
 	"github.com/Layr-Labs/eigenda/common/kvstore/mapstore"
+	"github.com/Layr-Labs/eigenda/common/kvstore/pebble"
 	"github.com/Layr-Labs/eigenda/common/kvstore/tablestore"
 	tu "github.com/Layr-Labs/eigenda/common/testutils"
 	"github.com/Layr-Labs/eigensdk-go/logging"
@@ -22,6 +23,9 @@ var storeBuilders = []func(logger logging.Logger, path string) (kvstore.Store[[]
 	func(logger logging.Logger, path string) (kvstore.Store[[]byte], error) {
 		return leveldb.NewStore(logger, path)
 	},
+	func(logger logging.Logger, path string) (kvstore.Store[[]byte], error) {
+		return pebble.NewStore(logger, path)
+	},
 	func(logger logging.Logger, path string) (kvstore.Store[[]byte], error) {
 		config := tablestore.DefaultMapStoreConfig()
 		config.Schema = []string{"test"}
@@ -40,6 +44,15 @@ var storeBuilders = []func(logger logging.Logger, path string) (kvstore.Store[[]
 		}
 		return NewTableAsAStore(tableStore)
 	},
+	func(logger logging.Logger, path string) (kvstore.Store[[]byte], error) {
+		config := tablestore.DefaultPebbleConfig(path)
+		config.Schema = []string{"test"}
+		tableStore, err := tablestore.Start(logger, config)
+		if err != nil {
+			return nil, err
+		}
+		return NewTableAsAStore(tableStore)
+	},
 }
 
 var dbPath = "test-store"
@@ -56,7 +69,6 @@ func verifyDBIsDeleted(t *testing.T) {
 
 func randomOperationsTest(t *testing.T, store kvstore.Store[[]byte]) {
 	tu.InitializeRandom()
-	deleteDBDirectory(t)
 
 	expectedData := make(map[string][]byte)
 
@@ -132,6 +144,7 @@ func TestRandomOperations(t *testing.T) {
 	assert.NoError(t, err)
 
 	for _, builder := range storeBuilders {
+		deleteDBDirectory(t)
 		store, err := builder(logger, dbPath)
 		assert.NoError(t, err)
 		randomOperationsTest(t, store)
@@ -140,7 +153,6 @@ func TestRandomOperations(t *testing.T) {
 
 func writeBatchTest(t *testing.T, store kvstore.Store[[]byte]) {
 	tu.InitializeRandom()
-	deleteDBDirectory(t)
 
 	var err error
 
@@ -199,6 +211,7 @@ func TestWriteBatch(t *testing.T) {
 	assert.NoError(t, err)
 
 	for _, builder := range storeBuilders {
+		deleteDBDirectory(t)
 		store, err := builder(logger, dbPath)
 		assert.NoError(t, err)
 		writeBatchTest(t, store)
@@ -207,7 +220,6 @@ func TestWriteBatch(t *testing.T) {
 
 func deleteBatchTest(t *testing.T, store kvstore.Store[[]byte]) {
 	tu.InitializeRandom()
-	deleteDBDirectory(t)
 
 	expectedData := make(map[string][]byte)
 
@@ -265,6 +277,7 @@ func TestDeleteBatch(t *testing.T) {
 	assert.NoError(t, err)
 
 	for _, builder := range storeBuilders {
+		deleteDBDirectory(t)
 		store, err := builder(logger, dbPath)
 		assert.NoError(t, err)
 		deleteBatchTest(t, store)
@@ -273,7 +286,6 @@ func TestDeleteBatch(t *testing.T) {
 
 func iterationTest(t *testing.T, store kvstore.Store[[]byte]) {
 	tu.InitializeRandom()
-	deleteDBDirectory(t)
 
 	expectedData := make(map[string][]byte)
 
@@ -317,6 +329,7 @@ func TestIteration(t *testing.T) {
 	assert.NoError(t, err)
 
 	for _, builder := range storeBuilders {
+		deleteDBDirectory(t)
 		store, err := builder(logger, dbPath)
 		assert.NoError(t, err)
 		iterationTest(t, store)
@@ -325,7 +338,6 @@ func TestIteration(t *testing.T) {
 
 func iterationWithPrefixTest(t *testing.T, store kvstore.Store[[]byte]) {
 	tu.InitializeRandom()
-	deleteDBDirectory(t)
 
 	prefixA := tu.RandomBytes(8)
 	prefixB := tu.RandomBytes(8)
@@ -403,6 +415,7 @@ func TestIterationWithPrefix(t *testing.T) {
 	assert.NoError(t, err)
 
 	for _, builder := range storeBuilders {
+		deleteDBDirectory(t)
 		store, err := builder(logger, dbPath)
 		assert.NoError(t, err)
 		iterationWithPrefixTest(t, store)
@@ -411,7 +424,6 @@ func TestIterationWithPrefix(t *testing.T) {
 
 func putNilTest(t *testing.T, store kvstore.Store[[]byte]) {
 	tu.InitializeRandom()
-	deleteDBDirectory(t)
 
 	key := tu.RandomBytes(32)
 
@@ -432,6 +444,7 @@ func TestPutNil(t *testing.T) {
 	assert.NoError(t, err)
 
 	for _, builder := range storeBuilders {
+		deleteDBDirectory(t)
 		store, err := builder(logger, dbPath)
 		assert.NoError(t, err)
 		putNilTest(t, store)
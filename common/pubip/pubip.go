@@ -31,6 +31,10 @@ func buildSimpleProviderByName(name string) Provider {
 		return NewSimpleProvider(SeepIPProvider, SeeIPURL)
 	} else if name == IpifyProvider {
 		return NewSimpleProvider(IpifyProvider, IpifyURL)
+	} else if name == StunProvider {
+		return NewStunProvider(StunProvider, StunServer)
+	} else if server, ok := strings.CutPrefix(name, StunProvider+":"); ok && server != "" {
+		return NewStunProvider(name, server)
 	} else if name == MockIpProvider {
 		return &mockProvider{}
 	}
@@ -0,0 +1,180 @@
+package pubip
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+)
+
+const (
+	// StunProvider is the name of the provider which asks a STUN server for the node's public IP. A STUN server can
+	// be given as "stun:host:port", otherwise StunServer is used.
+	StunProvider = "stun"
+	StunServer   = "stun.l.google.com:19302"
+
+	// stunTimeout is how long a STUN server is waited for when the context has no deadline
+	stunTimeout = 5 * time.Second
+
+	stunHeaderSize          = 20
+	stunMagicCookie         = 0x2112A442
+	stunBindingRequest      = 0x0001
+	stunBindingResponse     = 0x0101
+	stunAttrMappedAddress   = 0x0001
+	stunAttrXorMappedAddr   = 0x0020
+	stunAddressFamilyIPv4   = 0x01
+	stunAddressFamilyIPv6   = 0x02
+	stunMaxResponseSize     = 1500
+	stunTransactionIDLength = 12
+)
+
+var _ Provider = (*stunProvider)(nil)
+
+// stunProvider is an implementation of the Provider interface which sends a STUN binding request (RFC 5389) to a
+// STUN server, which replies with the address the request came from. Unlike the http providers, it sees the address
+// of the node's UDP traffic, which is what NATs of home connections map to the node.
+type stunProvider struct {
+	name   string
+	server string
+}
+
+// NewStunProvider creates a new provider which asks the STUN server at the given host:port for the public IP.
+func NewStunProvider(name, server string) Provider {
+	return &stunProvider{
+		name:   name,
+		server: server,
+	}
+}
+
+func (s *stunProvider) Name() string {
+	return s.name
+}
+
+func (s *stunProvider) PublicIPAddress(ctx context.Context) (string, error) {
+	ip, err := s.bindingRequest(ctx)
+	if err != nil {
+		return "", fmt.Errorf("%s: failed to retrieve public ip address from %s: %w", s.name, s.server, err)
+	}
+	return ip.String(), nil
+}
+
+func (s *stunProvider) bindingRequest(ctx context.Context) (net.IP, error) {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "udp", s.server)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = conn.Close() }()
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(stunTimeout)
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		return nil, err
+	}
+
+	request := make([]byte, stunHeaderSize)
+	binary.BigEndian.PutUint16(request[0:2], stunBindingRequest)
+	binary.BigEndian.PutUint16(request[2:4], 0)
+	binary.BigEndian.PutUint32(request[4:8], stunMagicCookie)
+	transactionID := request[8 : 8+stunTransactionIDLength]
+	if _, err := rand.Read(transactionID); err != nil {
+		return nil, err
+	}
+	if _, err := conn.Write(request); err != nil {
+		return nil, err
+	}
+
+	response := make([]byte, stunMaxResponseSize)
+	for {
+		n, err := conn.Read(response)
+		if err != nil {
+			return nil, err
+		}
+		// Responses to other requests are ignored
+		if n >= stunHeaderSize && bytes.Equal(response[8:8+stunTransactionIDLength], transactionID) {
+			return parseStunBindingResponse(response[:n])
+		}
+	}
+}
+
+// parseStunBindingResponse returns the address reported in a STUN binding response, preferring the XOR-MAPPED-ADDRESS
+// attribute over the MAPPED-ADDRESS attribute of older servers.
+func parseStunBindingResponse(response []byte) (net.IP, error) {
+	if len(response) < stunHeaderSize {
+		return nil, errors.New("stun response too short")
+	}
+	if msgType := binary.BigEndian.Uint16(response[0:2]); msgType != stunBindingResponse {
+		return nil, fmt.Errorf("unexpected stun message type 0x%04x", msgType)
+	}
+	if binary.BigEndian.Uint32(response[4:8]) != stunMagicCookie {
+		return nil, errors.New("invalid stun magic cookie")
+	}
+	length := int(binary.BigEndian.Uint16(response[2:4]))
+	if stunHeaderSize+length > len(response) {
+		return nil, errors.New("truncated stun response")
+	}
+
+	var mapped net.IP
+	attributes := response[stunHeaderSize : stunHeaderSize+length]
+	for len(attributes) >= 4 {
+		attrType := binary.BigEndian.Uint16(attributes[0:2])
+		attrLength := int(binary.BigEndian.Uint16(attributes[2:4]))
+		if 4+attrLength > len(attributes) {
+			return nil, errors.New("truncated stun attribute")
+		}
+		value := attributes[4 : 4+attrLength]
+		switch attrType {
+		case stunAttrXorMappedAddr:
+			return parseStunAddress(value, response[4:stunHeaderSize])
+		case stunAttrMappedAddress:
+			ip, err := parseStunAddress(value, nil)
+			if err != nil {
+				return nil, err
+			}
+			mapped = ip
+		}
+		// Attributes are padded to a multiple of 4 bytes
+		padded := (attrLength + 3) &^ 3
+		if 4+padded > len(attributes) {
+			break
+		}
+		attributes = attributes[4+padded:]
+	}
+	if mapped == nil {
+		return nil, errors.New("no mapped address in stun response")
+	}
+	return mapped, nil
+}
+
+// parseStunAddress parses the value of an address attribute. The address is XORed with xorKey, the magic cookie
+// followed by the transaction ID, if it's not nil.
+func parseStunAddress(value []byte, xorKey []byte) (net.IP, error) {
+	if len(value) < 4 {
+		return nil, errors.New("stun address attribute too short")
+	}
+	var ip net.IP
+	switch value[1] {
+	case stunAddressFamilyIPv4:
+		ip = make(net.IP, net.IPv4len)
+	case stunAddressFamilyIPv6:
+		ip = make(net.IP, net.IPv6len)
+	default:
+		return nil, fmt.Errorf("unknown stun address family %d", value[1])
+	}
+	if len(value) < 4+len(ip) {
+		return nil, errors.New("stun address attribute too short")
+	}
+	copy(ip, value[4:4+len(ip)])
+	if xorKey != nil {
+		for i := range ip {
+			ip[i] ^= xorKey[i]
+		}
+	}
+	return ip, nil
+}
@@ -0,0 +1,92 @@
+package pubip
+
+import (
+	"context"
+	"encoding/binary"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// startStunServer starts a STUN server on localhost which replies to binding requests with the address of the
+// requester, encoded with the given attribute.
+func startStunServer(t *testing.T, attrType uint16) string {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	go func() {
+		request := make([]byte, stunMaxResponseSize)
+		for {
+			n, addr, err := conn.ReadFrom(request)
+			if err != nil {
+				return
+			}
+			if n < stunHeaderSize || binary.BigEndian.Uint16(request[0:2]) != stunBindingRequest {
+				continue
+			}
+			udpAddr := addr.(*net.UDPAddr)
+			ip := udpAddr.IP.To4()
+			port := uint16(udpAddr.Port)
+
+			value := make([]byte, 8)
+			value[1] = stunAddressFamilyIPv4
+			copy(value[4:], ip)
+			if attrType == stunAttrXorMappedAddr {
+				port ^= stunMagicCookie >> 16
+				for i := range ip {
+					value[4+i] ^= request[4+i]
+				}
+			}
+			binary.BigEndian.PutUint16(value[2:4], port)
+
+			response := make([]byte, stunHeaderSize+4+len(value))
+			binary.BigEndian.PutUint16(response[0:2], stunBindingResponse)
+			binary.BigEndian.PutUint16(response[2:4], uint16(4+len(value)))
+			copy(response[4:stunHeaderSize], request[4:stunHeaderSize])
+			binary.BigEndian.PutUint16(response[stunHeaderSize:], attrType)
+			binary.BigEndian.PutUint16(response[stunHeaderSize+2:], uint16(len(value)))
+			copy(response[stunHeaderSize+4:], value)
+			_, _ = conn.WriteTo(response, addr)
+		}
+	}()
+	return conn.LocalAddr().String()
+}
+
+func TestStunProvider(t *testing.T) {
+	for _, attrType := range []uint16{stunAttrXorMappedAddr, stunAttrMappedAddress} {
+		server := startStunServer(t, attrType)
+		provider := NewStunProvider(StunProvider, server)
+		ip, err := provider.PublicIPAddress(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, "127.0.0.1", ip)
+	}
+}
+
+func TestParseStunBindingResponse(t *testing.T) {
+	_, err := parseStunBindingResponse([]byte{0x01})
+	require.ErrorContains(t, err, "too short")
+
+	response := make([]byte, stunHeaderSize)
+	binary.BigEndian.PutUint16(response[0:2], stunBindingResponse)
+	binary.BigEndian.PutUint32(response[4:8], stunMagicCookie)
+	_, err = parseStunBindingResponse(response)
+	require.ErrorContains(t, err, "no mapped address")
+
+	binary.BigEndian.PutUint16(response[2:4], 8)
+	_, err = parseStunBindingResponse(response)
+	require.ErrorContains(t, err, "truncated")
+}
+
+func TestStunProviderByName(t *testing.T) {
+	provider := buildSimpleProviderByName(StunProvider)
+	require.Equal(t, StunProvider, provider.Name())
+	require.Equal(t, StunServer, provider.(*stunProvider).server)
+
+	provider = buildSimpleProviderByName("stun:stun.example.com:3478")
+	require.Equal(t, "stun:stun.example.com:3478", provider.Name())
+	require.Equal(t, "stun.example.com:3478", provider.(*stunProvider).server)
+
+	require.Nil(t, buildSimpleProviderByName("stun:"))
+}
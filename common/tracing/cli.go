@@ -0,0 +1,58 @@
+package tracing
+
+import (
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/urfave/cli"
+)
+
+var (
+	OTLPEndpointFlagName   = "tracing.otlp-endpoint"
+	SampleRatioFlagName    = "tracing.sample-ratio"
+	ExportIntervalFlagName = "tracing.export-interval"
+	MaxQueueSizeFlagName   = "tracing.max-queue-size"
+)
+
+func CLIFlags(envPrefix string, flagPrefix string) []cli.Flag {
+	return []cli.Flag{
+		cli.StringFlag{
+			Name:     common.PrefixFlag(flagPrefix, OTLPEndpointFlagName),
+			Usage:    "Base URL of the OTLP/HTTP collector traces are exported to, e.g. http://localhost:4318. Tracing is disabled if not set",
+			Required: false,
+			Value:    "",
+			EnvVar:   common.PrefixEnvVar(envPrefix, "TRACING_OTLP_ENDPOINT"),
+		},
+		cli.Float64Flag{
+			Name:     common.PrefixFlag(flagPrefix, SampleRatioFlagName),
+			Usage:    "Fraction of blobs which are traced, between 0 and 1",
+			Required: false,
+			Value:    0.01,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "TRACING_SAMPLE_RATIO"),
+		},
+		cli.DurationFlag{
+			Name:     common.PrefixFlag(flagPrefix, ExportIntervalFlagName),
+			Usage:    "Interval at which spans are exported to the collector",
+			Required: false,
+			Value:    5 * time.Second,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "TRACING_EXPORT_INTERVAL"),
+		},
+		cli.IntFlag{
+			Name:     common.PrefixFlag(flagPrefix, MaxQueueSizeFlagName),
+			Usage:    "Number of spans buffered for export. Spans are dropped while the buffer is full",
+			Required: false,
+			Value:    4096,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "TRACING_MAX_QUEUE_SIZE"),
+		},
+	}
+}
+
+// ReadCLIConfig reads the tracing config. The service name is left for the caller to set.
+func ReadCLIConfig(ctx *cli.Context, flagPrefix string) Config {
+	return Config{
+		OTLPEndpoint:   ctx.GlobalString(common.PrefixFlag(flagPrefix, OTLPEndpointFlagName)),
+		SampleRatio:    ctx.GlobalFloat64(common.PrefixFlag(flagPrefix, SampleRatioFlagName)),
+		ExportInterval: ctx.GlobalDuration(common.PrefixFlag(flagPrefix, ExportIntervalFlagName)),
+		MaxQueueSize:   ctx.GlobalInt(common.PrefixFlag(flagPrefix, MaxQueueSizeFlagName)),
+	}
+}
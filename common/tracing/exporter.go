@@ -0,0 +1,299 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+const (
+	// maxExportBatchSize is the maximum number of spans exported in a single request to the collector
+	maxExportBatchSize = 512
+	// exportTimeout bounds each request to the collector
+	exportTimeout = 10 * time.Second
+	// instrumentationScope is the name of the instrumentation scope reported with each span
+	instrumentationScope = "github.com/Layr-Labs/eigenda"
+)
+
+// Config configures tracing
+type Config struct {
+	// OTLPEndpoint is the base URL of the OTLP/HTTP collector spans are exported to, e.g. http://localhost:4318.
+	// If empty, tracing is disabled.
+	OTLPEndpoint string
+	// ServiceName is the name of the service reported with each span
+	ServiceName string
+	// SampleRatio is the fraction of traces which are recorded, between 0 and 1
+	SampleRatio float64
+	// ExportInterval is the interval at which spans are exported to the collector
+	ExportInterval time.Duration
+	// MaxQueueSize is the number of spans buffered for export. Spans ended while the buffer is full are dropped.
+	MaxQueueSize int
+}
+
+// NewTracer creates a tracer which exports spans to the OTLP/HTTP collector in the config
+func NewTracer(config Config, logger logging.Logger) (*Tracer, error) {
+	if config.OTLPEndpoint == "" {
+		return nil, errors.New("OTLP endpoint is required")
+	}
+	if config.ServiceName == "" {
+		return nil, errors.New("service name is required")
+	}
+	if config.SampleRatio < 0 || config.SampleRatio > 1 {
+		return nil, fmt.Errorf("sample ratio must be between 0 and 1, got %f", config.SampleRatio)
+	}
+	if config.ExportInterval <= 0 {
+		return nil, errors.New("export interval must be positive")
+	}
+	if config.MaxQueueSize <= 0 {
+		return nil, errors.New("max queue size must be positive")
+	}
+
+	return &Tracer{
+		config: config,
+		exporter: &exporter{
+			url:         strings.TrimSuffix(config.OTLPEndpoint, "/") + "/v1/traces",
+			serviceName: config.ServiceName,
+			client:      &http.Client{Timeout: exportTimeout},
+			queue:       make(chan *Span, config.MaxQueueSize),
+			logger:      logger.With("component", "TracingExporter"),
+		},
+	}, nil
+}
+
+// Init creates a tracer from the config, starts exporting its spans, and sets it as the global tracer. Tracing stays
+// disabled if no OTLP endpoint is configured.
+func Init(ctx context.Context, config Config, logger logging.Logger) error {
+	if config.OTLPEndpoint == "" {
+		return nil
+	}
+	tracer, err := NewTracer(config, logger)
+	if err != nil {
+		return err
+	}
+	tracer.Start(ctx)
+	SetGlobalTracer(tracer)
+	logger.Info("Exporting traces", "endpoint", config.OTLPEndpoint, "sampleRatio", config.SampleRatio)
+	return nil
+}
+
+// Start exports spans in the background until the context is cancelled, at which point the remaining spans are flushed
+func (t *Tracer) Start(ctx context.Context) {
+	go t.exporter.run(ctx, t.config.ExportInterval)
+}
+
+// exporter batches ended spans and posts them to an OTLP/HTTP collector, encoded as OTLP JSON
+//
+// TODO: replace the tracer and exporter with the OpenTelemetry SDK (go.opentelemetry.io/otel/sdk/trace) and its OTLP
+// exporter (go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp) once they are added to go.mod. The blob
+// trace and root span IDs need a custom sdktrace.IDGenerator, and the sampler must stay trace ID ratio based, so that
+// every component still joins the same trace and makes the same sampling decision.
+type exporter struct {
+	url         string
+	serviceName string
+	client      *http.Client
+	queue       chan *Span
+	logger      logging.Logger
+}
+
+func (e *exporter) enqueue(span *Span) {
+	select {
+	case e.queue <- span:
+	default:
+		e.logger.Debug("span export queue is full, dropping span", "name", span.name)
+	}
+}
+
+func (e *exporter) run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	batch := make([]*Span, 0, maxExportBatchSize)
+	flush := func(ctx context.Context) {
+		if len(batch) == 0 {
+			return
+		}
+		if err := e.post(ctx, batch); err != nil {
+			e.logger.Warn("failed to export spans", "numSpans", len(batch), "err", err)
+		}
+		batch = batch[:0]
+	}
+
+	for {
+		select {
+		case span := <-e.queue:
+			batch = append(batch, span)
+			if len(batch) >= maxExportBatchSize {
+				flush(ctx)
+			}
+		case <-ticker.C:
+			flush(ctx)
+		case <-ctx.Done():
+			// drain what's been queued so far, without blocking on spans ended afterwards
+			for len(e.queue) > 0 && len(batch) < maxExportBatchSize {
+				batch = append(batch, <-e.queue)
+			}
+			flushCtx, cancel := context.WithTimeout(context.Background(), exportTimeout)
+			flush(flushCtx)
+			cancel()
+			return
+		}
+	}
+}
+
+func (e *exporter) post(ctx context.Context, spans []*Span) error {
+	body, err := json.Marshal(e.encode(spans))
+	if err != nil {
+		return fmt.Errorf("failed to encode spans: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post spans: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("collector responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// The types below are the subset of the OTLP JSON encoding of trace data used by the exporter.
+// See https://opentelemetry.io/docs/specs/otlp/#json-protobuf-encoding
+
+type otlpTraceData struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpAttribute `json:"attributes"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope  `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name string `json:"name"`
+}
+
+type otlpSpan struct {
+	TraceID           string          `json:"traceId"`
+	SpanID            string          `json:"spanId"`
+	ParentSpanID      string          `json:"parentSpanId,omitempty"`
+	Name              string          `json:"name"`
+	Kind              int             `json:"kind"`
+	StartTimeUnixNano string          `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string          `json:"endTimeUnixNano"`
+	Attributes        []otlpAttribute `json:"attributes,omitempty"`
+	Status            otlpStatus      `json:"status"`
+}
+
+type otlpAttribute struct {
+	Key   string       `json:"key"`
+	Value otlpAnyValue `json:"value"`
+}
+
+type otlpAnyValue struct {
+	StringValue *string  `json:"stringValue,omitempty"`
+	BoolValue   *bool    `json:"boolValue,omitempty"`
+	IntValue    *string  `json:"intValue,omitempty"`
+	DoubleValue *float64 `json:"doubleValue,omitempty"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+const (
+	otlpSpanKindInternal = 1
+	otlpStatusCodeOk     = 1
+	otlpStatusCodeError  = 2
+)
+
+func (e *exporter) encode(spans []*Span) otlpTraceData {
+	encoded := make([]otlpSpan, 0, len(spans))
+	for _, span := range spans {
+		span.mu.Lock()
+		s := otlpSpan{
+			TraceID:           span.spanContext.TraceID.String(),
+			SpanID:            span.spanContext.SpanID.String(),
+			Name:              span.name,
+			Kind:              otlpSpanKindInternal,
+			StartTimeUnixNano: strconv.FormatInt(span.start.UnixNano(), 10),
+			EndTimeUnixNano:   strconv.FormatInt(span.end.UnixNano(), 10),
+			Attributes:        encodeAttributes(span.attributes),
+			Status:            otlpStatus{Code: otlpStatusCodeOk},
+		}
+		if span.parentSpanID != (SpanID{}) {
+			s.ParentSpanID = span.parentSpanID.String()
+		}
+		if span.err != nil {
+			s.Status = otlpStatus{Code: otlpStatusCodeError, Message: span.err.Error()}
+		}
+		span.mu.Unlock()
+		encoded = append(encoded, s)
+	}
+
+	return otlpTraceData{
+		ResourceSpans: []otlpResourceSpans{{
+			Resource: otlpResource{
+				Attributes: encodeAttributes([]Attribute{String("service.name", e.serviceName)}),
+			},
+			ScopeSpans: []otlpScopeSpans{{
+				Scope: otlpScope{Name: instrumentationScope},
+				Spans: encoded,
+			}},
+		}},
+	}
+}
+
+func encodeAttributes(attributes []Attribute) []otlpAttribute {
+	encoded := make([]otlpAttribute, 0, len(attributes))
+	for _, attribute := range attributes {
+		var value otlpAnyValue
+		switch v := attribute.Value.(type) {
+		case string:
+			value.StringValue = &v
+		case bool:
+			value.BoolValue = &v
+		case int:
+			s := strconv.FormatInt(int64(v), 10)
+			value.IntValue = &s
+		case int64:
+			s := strconv.FormatInt(v, 10)
+			value.IntValue = &s
+		case uint64:
+			s := strconv.FormatUint(v, 10)
+			value.IntValue = &s
+		case float64:
+			value.DoubleValue = &v
+		default:
+			s := fmt.Sprintf("%v", v)
+			value.StringValue = &s
+		}
+		encoded = append(encoded, otlpAttribute{Key: attribute.Key, Value: value})
+	}
+	return encoded
+}
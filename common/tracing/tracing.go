@@ -0,0 +1,246 @@
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"math"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// TraceID identifies a trace
+type TraceID [16]byte
+
+// SpanID identifies a span within a trace
+type SpanID [8]byte
+
+func (t TraceID) String() string {
+	return hex.EncodeToString(t[:])
+}
+
+func (s SpanID) String() string {
+	return hex.EncodeToString(s[:])
+}
+
+// SpanContext identifies a span, and carries the sampling decision of its trace
+type SpanContext struct {
+	TraceID TraceID
+	SpanID  SpanID
+	Sampled bool
+}
+
+// Attribute is a key-value pair describing a span. Values may be strings, bools, integers or floats.
+type Attribute struct {
+	Key   string
+	Value any
+}
+
+func String(key string, value string) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+func Int64(key string, value int64) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+func Bool(key string, value bool) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// Span is an operation within a trace. A nil span is valid, and records nothing.
+type Span struct {
+	tracer *Tracer
+
+	name         string
+	spanContext  SpanContext
+	parentSpanID SpanID
+	start        time.Time
+
+	mu         sync.Mutex
+	end        time.Time
+	attributes []Attribute
+	err        error
+	ended      bool
+}
+
+// SpanContext returns the context identifying the span
+func (s *Span) SpanContext() SpanContext {
+	if s == nil {
+		return SpanContext{}
+	}
+	return s.spanContext
+}
+
+// SetAttributes adds attributes to the span
+func (s *Span) SetAttributes(attributes ...Attribute) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.attributes = append(s.attributes, attributes...)
+}
+
+// RecordError marks the span as failed with the given error. Nil errors are ignored.
+func (s *Span) RecordError(err error) {
+	if s == nil || err == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.err = err
+}
+
+// End completes the span and queues it for export. Calls after the first have no effect.
+func (s *Span) End() {
+	s.endAt(time.Now())
+}
+
+func (s *Span) endAt(end time.Time) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	if s.ended {
+		s.mu.Unlock()
+		return
+	}
+	s.ended = true
+	s.end = end
+	s.mu.Unlock()
+
+	s.tracer.export(s)
+}
+
+type spanContextKey struct{}
+
+// ContextWithSpan returns a copy of ctx carrying the span, so that spans started from it are children of the span
+func ContextWithSpan(ctx context.Context, span *Span) context.Context {
+	if span == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, spanContextKey{}, span)
+}
+
+// SpanFromContext returns the span carried by ctx, or nil if there is none
+func SpanFromContext(ctx context.Context) *Span {
+	span, _ := ctx.Value(spanContextKey{}).(*Span)
+	return span
+}
+
+// Tracer creates spans and exports the sampled ones to an OTLP collector
+type Tracer struct {
+	config   Config
+	exporter *exporter
+}
+
+var globalTracer atomic.Pointer[Tracer]
+
+// SetGlobalTracer sets the tracer used by the package level functions. Until it is set, they record nothing.
+func SetGlobalTracer(tracer *Tracer) {
+	globalTracer.Store(tracer)
+}
+
+// Enabled returns true if a global tracer is set, so that callers can skip preparing attributes of spans which would
+// not be recorded
+func Enabled() bool {
+	return globalTracer.Load() != nil
+}
+
+// StartSpan starts a child of the span carried by ctx. If ctx carries no span, nothing is recorded.
+func StartSpan(ctx context.Context, name string, attributes ...Attribute) (context.Context, *Span) {
+	parent := SpanFromContext(ctx)
+	if parent == nil {
+		return ctx, nil
+	}
+	span := parent.tracer.newSpan(name, parent.spanContext.TraceID, parent.spanContext.SpanID, newSpanID(), parent.spanContext.Sampled, attributes)
+	return ContextWithSpan(ctx, span), span
+}
+
+// StartBlobRootSpan starts the root span of the trace of the blob with the given key
+func StartBlobRootSpan(ctx context.Context, blobKey [32]byte, name string, attributes ...Attribute) (context.Context, *Span) {
+	tracer := globalTracer.Load()
+	if tracer == nil {
+		return ctx, nil
+	}
+	traceID, rootSpanID := blobTraceIDs(blobKey)
+	span := tracer.newSpan(name, traceID, SpanID{}, rootSpanID, tracer.sampled(traceID), attributes)
+	return ContextWithSpan(ctx, span), span
+}
+
+// StartBlobSpan starts a span in the trace of the blob with the given key, as a child of the root span of the trace
+func StartBlobSpan(ctx context.Context, blobKey [32]byte, name string, attributes ...Attribute) (context.Context, *Span) {
+	tracer := globalTracer.Load()
+	if tracer == nil {
+		return ctx, nil
+	}
+	traceID, rootSpanID := blobTraceIDs(blobKey)
+	span := tracer.newSpan(name, traceID, rootSpanID, newSpanID(), tracer.sampled(traceID), attributes)
+	return ContextWithSpan(ctx, span), span
+}
+
+// RecordBlobSpan records a span which has already completed in the trace of the blob with the given key. It is used
+// for stages whose timing is only known after the fact, such as the time a blob spent queued.
+func RecordBlobSpan(blobKey [32]byte, name string, start time.Time, end time.Time, attributes ...Attribute) {
+	tracer := globalTracer.Load()
+	if tracer == nil {
+		return
+	}
+	traceID, rootSpanID := blobTraceIDs(blobKey)
+	span := tracer.newSpan(name, traceID, rootSpanID, newSpanID(), tracer.sampled(traceID), attributes)
+	span.start = start
+	span.endAt(end)
+}
+
+// blobTraceIDs derives the trace ID and root span ID of a blob's trace from its key. Every component handling the blob
+// derives the same IDs, so their spans join a single trace without the trace context being stored with the blob.
+func blobTraceIDs(blobKey [32]byte) (TraceID, SpanID) {
+	var traceID TraceID
+	var spanID SpanID
+	copy(traceID[:], blobKey[:16])
+	copy(spanID[:], blobKey[16:24])
+	return traceID, spanID
+}
+
+func (t *Tracer) newSpan(name string, traceID TraceID, parentSpanID SpanID, spanID SpanID, sampled bool, attributes []Attribute) *Span {
+	return &Span{
+		tracer: t,
+		name:   name,
+		spanContext: SpanContext{
+			TraceID: traceID,
+			SpanID:  spanID,
+			Sampled: sampled,
+		},
+		parentSpanID: parentSpanID,
+		start:        time.Now(),
+		attributes:   attributes,
+	}
+}
+
+// sampled decides whether a trace is sampled from its ID, so that every component makes the same decision
+func (t *Tracer) sampled(traceID TraceID) bool {
+	if t.config.SampleRatio >= 1 {
+		return true
+	}
+	if t.config.SampleRatio <= 0 {
+		return false
+	}
+	bound := uint64(t.config.SampleRatio * math.MaxUint64)
+	return binary.BigEndian.Uint64(traceID[8:]) < bound
+}
+
+func (t *Tracer) export(span *Span) {
+	if !span.spanContext.Sampled {
+		return
+	}
+	t.exporter.enqueue(span)
+}
+
+func newSpanID() SpanID {
+	var spanID SpanID
+	_, _ = rand.Read(spanID[:])
+	return spanID
+}
@@ -0,0 +1,152 @@
+package tracing_test
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common/testutils"
+	"github.com/Layr-Labs/eigenda/common/tracing"
+	"github.com/stretchr/testify/require"
+)
+
+type exportedSpan struct {
+	TraceID      string `json:"traceId"`
+	SpanID       string `json:"spanId"`
+	ParentSpanID string `json:"parentSpanId"`
+	Name         string `json:"name"`
+	Attributes   []struct {
+		Key   string         `json:"key"`
+		Value map[string]any `json:"value"`
+	} `json:"attributes"`
+	Status struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"status"`
+}
+
+type mockCollector struct {
+	mu    sync.Mutex
+	spans []exportedSpan
+}
+
+func (c *mockCollector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var data struct {
+		ResourceSpans []struct {
+			ScopeSpans []struct {
+				Spans []exportedSpan `json:"spans"`
+			} `json:"scopeSpans"`
+		} `json:"resourceSpans"`
+	}
+	if r.URL.Path != "/v1/traces" || json.NewDecoder(r.Body).Decode(&data) != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, rs := range data.ResourceSpans {
+		for _, ss := range rs.ScopeSpans {
+			c.spans = append(c.spans, ss.Spans...)
+		}
+	}
+}
+
+func (c *mockCollector) getSpans() []exportedSpan {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]exportedSpan{}, c.spans...)
+}
+
+func startTracer(t *testing.T, collector *mockCollector, sampleRatio float64) {
+	server := httptest.NewServer(collector)
+	t.Cleanup(server.Close)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	err := tracing.Init(ctx, tracing.Config{
+		OTLPEndpoint:   server.URL,
+		ServiceName:    "test",
+		SampleRatio:    sampleRatio,
+		ExportInterval: 10 * time.Millisecond,
+		MaxQueueSize:   100,
+	}, testutils.GetLogger())
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		tracing.SetGlobalTracer(nil)
+	})
+}
+
+func TestBlobTrace(t *testing.T) {
+	collector := &mockCollector{}
+	startTracer(t, collector, 1)
+
+	blobKey := [32]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24}
+	traceID := hex.EncodeToString(blobKey[:16])
+	rootSpanID := hex.EncodeToString(blobKey[16:24])
+
+	ctx, root := tracing.StartBlobRootSpan(context.Background(), blobKey, "DisperseBlob", tracing.String("account_id", "0x1234"))
+	_, child := tracing.StartSpan(ctx, "MeterRequest", tracing.Int64("blob_size", 100))
+	child.RecordError(errors.New("insufficient funds"))
+	child.End()
+	root.End()
+	// spans of the blob started elsewhere join the same trace
+	_, encode := tracing.StartBlobSpan(context.Background(), blobKey, "EncodeBlob")
+	encode.End()
+	tracing.RecordBlobSpan(blobKey, "Queued", time.Now().Add(-time.Second), time.Now())
+
+	require.Eventually(t, func() bool {
+		return len(collector.getSpans()) == 4
+	}, 5*time.Second, 10*time.Millisecond)
+
+	spans := make(map[string]exportedSpan)
+	for _, span := range collector.getSpans() {
+		require.Equal(t, traceID, span.TraceID)
+		spans[span.Name] = span
+	}
+
+	require.Equal(t, rootSpanID, spans["DisperseBlob"].SpanID)
+	require.Empty(t, spans["DisperseBlob"].ParentSpanID)
+	require.Equal(t, "account_id", spans["DisperseBlob"].Attributes[0].Key)
+	require.Equal(t, "0x1234", spans["DisperseBlob"].Attributes[0].Value["stringValue"])
+
+	require.Equal(t, rootSpanID, spans["MeterRequest"].ParentSpanID)
+	require.Equal(t, "100", spans["MeterRequest"].Attributes[0].Value["intValue"])
+	require.Equal(t, 2, spans["MeterRequest"].Status.Code)
+	require.Equal(t, "insufficient funds", spans["MeterRequest"].Status.Message)
+
+	require.Equal(t, rootSpanID, spans["EncodeBlob"].ParentSpanID)
+	require.Equal(t, rootSpanID, spans["Queued"].ParentSpanID)
+}
+
+func TestUnsampledTrace(t *testing.T) {
+	collector := &mockCollector{}
+	startTracer(t, collector, 0)
+
+	ctx, root := tracing.StartBlobRootSpan(context.Background(), [32]byte{1}, "DisperseBlob")
+	require.False(t, root.SpanContext().Sampled)
+	_, child := tracing.StartSpan(ctx, "MeterRequest")
+	require.False(t, child.SpanContext().Sampled)
+	child.End()
+	root.End()
+
+	time.Sleep(100 * time.Millisecond)
+	require.Empty(t, collector.getSpans())
+}
+
+func TestTracingDisabled(t *testing.T) {
+	ctx, root := tracing.StartBlobRootSpan(context.Background(), [32]byte{1}, "DisperseBlob")
+	require.Nil(t, root)
+	_, child := tracing.StartSpan(ctx, "MeterRequest")
+	require.Nil(t, child)
+
+	// nil spans are valid
+	child.SetAttributes(tracing.Bool("ok", true))
+	child.RecordError(errors.New("error"))
+	child.End()
+}
@@ -4,7 +4,9 @@ import (
 	"crypto/sha256"
 	"errors"
 	"fmt"
+	"time"
 
+	"github.com/Layr-Labs/eigenda/api/hashing"
 	core "github.com/Layr-Labs/eigenda/core/v2"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
@@ -68,3 +70,70 @@ func (*authenticator) AuthenticatePaymentStateRequest(sig []byte, accountId stri
 
 	return nil
 }
+
+func (*authenticator) AuthenticateDelegatedBlobRequest(
+	header *core.BlobHeader,
+	signature []byte,
+	delegation *core.PaymentDelegation,
+) error {
+	blobKey, err := header.BlobKey()
+	if err != nil {
+		return fmt.Errorf("failed to get blob key: %v", err)
+	}
+
+	delegate, err := recoverSigner(blobKey[:], signature)
+	if err != nil {
+		return err
+	}
+
+	return authenticateDelegation(common.HexToAddress(header.PaymentMetadata.AccountID), delegate, delegation)
+}
+
+func (*authenticator) AuthenticateDelegatedPaymentStateRequest(
+	signature []byte,
+	accountId string,
+	delegation *core.PaymentDelegation,
+) error {
+	hash := sha256.Sum256([]byte(accountId))
+	delegate, err := recoverSigner(hash[:], signature)
+	if err != nil {
+		return err
+	}
+
+	return authenticateDelegation(common.HexToAddress(accountId), delegate, delegation)
+}
+
+// authenticateDelegation checks that the delegation was signed by the payer for the delegate, and hasn't expired
+func authenticateDelegation(payer common.Address, delegate common.Address, delegation *core.PaymentDelegation) error {
+	if delegation == nil {
+		return errors.New("payment delegation must be provided")
+	}
+	if uint64(time.Now().Unix()) > delegation.Expiry {
+		return fmt.Errorf("payment delegation expired at %d", delegation.Expiry)
+	}
+
+	signer, err := recoverSigner(hashing.HashPaymentDelegation(payer, delegate, delegation.Expiry), delegation.Signature)
+	if err != nil {
+		return fmt.Errorf("invalid payment delegation: %w", err)
+	}
+	if signer.Cmp(payer) != 0 {
+		return fmt.Errorf("payment delegation to %s isn't signed by the payer %s", delegate.Hex(), payer.Hex())
+	}
+
+	return nil
+}
+
+// recoverSigner returns the address of the key which signed the hash
+func recoverSigner(hash []byte, signature []byte) (common.Address, error) {
+	// Ensure the signature is 65 bytes (Recovery ID is the last byte)
+	if len(signature) != 65 {
+		return common.Address{}, fmt.Errorf("signature length is unexpected: %d", len(signature))
+	}
+
+	sigPublicKeyECDSA, err := crypto.SigToPub(hash, signature)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to recover public key from signature: %v", err)
+	}
+
+	return crypto.PubkeyToAddress(*sigPublicKeyECDSA), nil
+}
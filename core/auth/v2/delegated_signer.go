@@ -0,0 +1,103 @@
+package v2
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api/hashing"
+	core "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// SignPaymentDelegation signs a PaymentDelegation with the key of the payer, authorizing the delegate to sign dispersal
+// requests paid for by the payer until expiry, a Unix timestamp in seconds. The payer key isn't needed after the
+// delegation is signed, so the delegation may be signed offline, and handed to the dispersing process.
+func SignPaymentDelegation(
+	ctx context.Context,
+	payer DigestSigner,
+	delegate common.Address,
+	expiry uint64,
+) (*core.PaymentDelegation, error) {
+	signature, err := signDigest(ctx, payer, hashing.HashPaymentDelegation(payer.Address(), delegate, expiry))
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign payment delegation: %w", err)
+	}
+
+	return &core.PaymentDelegation{
+		Expiry:    expiry,
+		Signature: signature,
+	}, nil
+}
+
+// DelegatedBlobRequestSigner signs requests with the key of a delegate, on behalf of a payer which delegated payments to
+// the delegate. The requests are paid for by the payer, and carry the delegation along with the signature of the
+// delegate.
+type DelegatedBlobRequestSigner struct {
+	delegate   DigestSigner
+	payer      common.Address
+	delegation *core.PaymentDelegation
+	timeout    time.Duration
+}
+
+var _ core.DelegatedBlobRequestSigner = &DelegatedBlobRequestSigner{}
+
+// NewDelegatedBlobRequestSigner creates a DelegatedBlobRequestSigner. The delegation must be signed by the payer for
+// the delegate, and must not have expired. Each signature requested from the delegate times out after the given
+// timeout.
+func NewDelegatedBlobRequestSigner(
+	delegate DigestSigner,
+	payer common.Address,
+	delegation *core.PaymentDelegation,
+	timeout time.Duration,
+) (*DelegatedBlobRequestSigner, error) {
+	if delegate == nil {
+		return nil, fmt.Errorf("delegate must be provided")
+	}
+	if timeout <= 0 {
+		return nil, fmt.Errorf("timeout must be positive, got %v", timeout)
+	}
+	err := authenticateDelegation(payer, delegate.Address(), delegation)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DelegatedBlobRequestSigner{
+		delegate:   delegate,
+		payer:      payer,
+		delegation: delegation,
+		timeout:    timeout,
+	}, nil
+}
+
+func (s *DelegatedBlobRequestSigner) SignBlobRequest(header *core.BlobHeader) ([]byte, error) {
+	blobKey, err := header.BlobKey()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blob key: %w", err)
+	}
+
+	return s.sign(blobKey[:])
+}
+
+func (s *DelegatedBlobRequestSigner) SignPaymentStateRequest() ([]byte, error) {
+	hash := sha256.Sum256([]byte(s.payer.Hex()))
+	return s.sign(hash[:])
+}
+
+// GetAccountID returns the account ID of the payer, which is charged for the dispersals
+func (s *DelegatedBlobRequestSigner) GetAccountID() (string, error) {
+	return s.payer.Hex(), nil
+}
+
+func (s *DelegatedBlobRequestSigner) GetPaymentDelegation() *core.PaymentDelegation {
+	return s.delegation
+}
+
+// sign signs the digest with the key of the delegate
+func (s *DelegatedBlobRequestSigner) sign(digest []byte) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+
+	return signDigest(ctx, s.delegate, digest)
+}
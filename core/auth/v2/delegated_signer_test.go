@@ -0,0 +1,83 @@
+package v2_test
+
+import (
+	"context"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	auth "github.com/Layr-Labs/eigenda/core/auth/v2"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDelegatedBlobRequestSigner(t *testing.T) {
+	ctx := context.Background()
+	authenticator := auth.NewAuthenticator()
+
+	payer := newDigestSigner(t)
+	delegate := newDigestSigner(t)
+
+	expiry := uint64(time.Now().Add(time.Hour).Unix())
+	delegation, err := auth.SignPaymentDelegation(ctx, payer, delegate.Address(), expiry)
+	require.NoError(t, err)
+
+	signer, err := auth.NewDelegatedBlobRequestSigner(delegate, payer.Address(), delegation, time.Second)
+	require.NoError(t, err)
+	require.Equal(t, delegation, signer.GetPaymentDelegation())
+
+	// requests are charged to the payer
+	accountID, err := signer.GetAccountID()
+	require.NoError(t, err)
+	require.Equal(t, payer.Address().Hex(), accountID)
+
+	header := testHeader(t, accountID)
+
+	sig, err := signer.SignBlobRequest(header)
+	require.NoError(t, err)
+	require.NoError(t, authenticator.AuthenticateDelegatedBlobRequest(header, sig, delegation))
+	// the delegate isn't the payer, so the request isn't valid without the delegation
+	require.Error(t, authenticator.AuthenticateBlobRequest(header, sig))
+	require.Error(t, authenticator.AuthenticateDelegatedBlobRequest(header, sig, nil))
+
+	sig, err = signer.SignPaymentStateRequest()
+	require.NoError(t, err)
+	require.NoError(t, authenticator.AuthenticateDelegatedPaymentStateRequest(sig, accountID, delegation))
+	require.Error(t, authenticator.AuthenticatePaymentStateRequest(sig, accountID))
+
+	// the delegation is bound to the expiry signed by the payer
+	extended := &corev2.PaymentDelegation{Expiry: expiry + 1, Signature: delegation.Signature}
+	require.ErrorContains(
+		t, authenticator.AuthenticateDelegatedPaymentStateRequest(sig, accountID, extended), "isn't signed by the payer")
+
+	// expired delegations are rejected
+	expired, err := auth.SignPaymentDelegation(ctx, payer, delegate.Address(), uint64(time.Now().Add(-time.Minute).Unix()))
+	require.NoError(t, err)
+	require.ErrorContains(t, authenticator.AuthenticateDelegatedPaymentStateRequest(sig, accountID, expired), "expired")
+	_, err = auth.NewDelegatedBlobRequestSigner(delegate, payer.Address(), expired, time.Second)
+	require.ErrorContains(t, err, "expired")
+
+	// delegations to another delegate are rejected
+	other := newDigestSigner(t)
+	otherDelegation, err := auth.SignPaymentDelegation(ctx, payer, other.Address(), expiry)
+	require.NoError(t, err)
+	require.Error(t, authenticator.AuthenticateDelegatedPaymentStateRequest(sig, accountID, otherDelegation))
+	_, err = auth.NewDelegatedBlobRequestSigner(delegate, payer.Address(), otherDelegation, time.Second)
+	require.Error(t, err)
+
+	// delegations signed by someone other than the payer are rejected
+	forged, err := auth.SignPaymentDelegation(ctx, other, delegate.Address(), expiry)
+	require.NoError(t, err)
+	require.Error(t, authenticator.AuthenticateDelegatedPaymentStateRequest(sig, accountID, forged))
+	_, err = auth.NewDelegatedBlobRequestSigner(delegate, payer.Address(), forged, time.Second)
+	require.Error(t, err)
+}
+
+func newDigestSigner(t *testing.T) auth.DigestSigner {
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	signer, err := auth.NewLocalDigestSigner(hex.EncodeToString(crypto.FromECDSA(privateKey)))
+	require.NoError(t, err)
+	return signer
+}
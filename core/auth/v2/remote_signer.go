@@ -0,0 +1,170 @@
+package v2
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"fmt"
+	"time"
+
+	awscommon "github.com/Layr-Labs/eigenda/common/aws"
+	core "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// DigestSigner signs 32 byte digests with a secp256k1 key which it holds, so that the key never has to be loaded into
+// the memory of the client. It is implemented for keys held in AWS KMS by NewKMSDigestSigner, and may be implemented
+// for other key management systems, HSMs or remote signing services.
+//
+// The digests are signed as they are. Signers which only sign messages with the EIP-191 prefix, as Ledger devices do,
+// can't be used, since the disperser verifies signatures over the raw digests.
+type DigestSigner interface {
+	// SignDigest returns the 65 byte [R || S || V] signature of the digest. V may be either 0 or 1, or 27 or 28.
+	SignDigest(ctx context.Context, digest []byte) ([]byte, error)
+
+	// Address returns the Ethereum address of the key, which is the account ID of the signed requests
+	Address() common.Address
+}
+
+// RemoteBlobRequestSigner is a BlobRequestSigner which signs requests with a DigestSigner, rather than with a private
+// key held by the client. Each signature is checked against the address of the signer before it is used, so that a
+// misconfigured signer is reported by the client rather than by the disperser.
+type RemoteBlobRequestSigner struct {
+	signer  DigestSigner
+	timeout time.Duration
+}
+
+var _ core.BlobRequestSigner = &RemoteBlobRequestSigner{}
+
+// NewRemoteBlobRequestSigner creates a RemoteBlobRequestSigner. Each signature requested from the signer times out
+// after the given timeout.
+func NewRemoteBlobRequestSigner(signer DigestSigner, timeout time.Duration) (*RemoteBlobRequestSigner, error) {
+	if signer == nil {
+		return nil, fmt.Errorf("signer must be provided")
+	}
+	if timeout <= 0 {
+		return nil, fmt.Errorf("timeout must be positive, got %v", timeout)
+	}
+
+	return &RemoteBlobRequestSigner{
+		signer:  signer,
+		timeout: timeout,
+	}, nil
+}
+
+func (s *RemoteBlobRequestSigner) SignBlobRequest(header *core.BlobHeader) ([]byte, error) {
+	blobKey, err := header.BlobKey()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blob key: %w", err)
+	}
+
+	return s.sign(blobKey[:])
+}
+
+func (s *RemoteBlobRequestSigner) SignPaymentStateRequest() ([]byte, error) {
+	accountId, err := s.GetAccountID()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account ID: %w", err)
+	}
+
+	hash := sha256.Sum256([]byte(accountId))
+	return s.sign(hash[:])
+}
+
+func (s *RemoteBlobRequestSigner) GetAccountID() (string, error) {
+	return s.signer.Address().Hex(), nil
+}
+
+// sign signs the digest with the remote signer, and checks that the signature was made by the key of the signer
+func (s *RemoteBlobRequestSigner) sign(digest []byte) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+
+	return signDigest(ctx, s.signer, digest)
+}
+
+// signDigest signs the digest with the signer, and checks that the signature was made by the key of the signer. The
+// returned signature has V in {0, 1}, as expected by the disperser.
+func signDigest(ctx context.Context, signer DigestSigner, digest []byte) ([]byte, error) {
+	sig, err := signer.SignDigest(ctx, digest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign with remote signer: %w", err)
+	}
+	if len(sig) != 65 {
+		return nil, fmt.Errorf("remote signer returned a signature of %d bytes, expected 65", len(sig))
+	}
+
+	sig = common.CopyBytes(sig)
+	if sig[64] >= 27 {
+		sig[64] -= 27
+	}
+
+	publicKey, err := crypto.SigToPub(digest, sig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to recover public key from remote signature: %w", err)
+	}
+	if address := crypto.PubkeyToAddress(*publicKey); address != signer.Address() {
+		return nil, fmt.Errorf("remote signature was made by %s, expected %s", address.Hex(), signer.Address().Hex())
+	}
+
+	return sig, nil
+}
+
+// localDigestSigner is a DigestSigner for a private key held in memory
+type localDigestSigner struct {
+	privateKey *ecdsa.PrivateKey
+}
+
+var _ DigestSigner = &localDigestSigner{}
+
+// NewLocalDigestSigner creates a DigestSigner which signs with the given hex encoded private key
+func NewLocalDigestSigner(privateKeyHex string) (DigestSigner, error) {
+	privateKey, err := crypto.ToECDSA(common.FromHex(privateKeyHex))
+	if err != nil {
+		return nil, fmt.Errorf("create ECDSA private key: %w", err)
+	}
+
+	return &localDigestSigner{privateKey: privateKey}, nil
+}
+
+func (s *localDigestSigner) SignDigest(_ context.Context, digest []byte) ([]byte, error) {
+	return crypto.Sign(digest, s.privateKey)
+}
+
+func (s *localDigestSigner) Address() common.Address {
+	return crypto.PubkeyToAddress(s.privateKey.PublicKey)
+}
+
+// kmsDigestSigner is a DigestSigner for a secp256k1 key held in AWS KMS
+type kmsDigestSigner struct {
+	keyID      string
+	publicKey  *ecdsa.PublicKey
+	keyManager *kms.Client
+}
+
+var _ DigestSigner = &kmsDigestSigner{}
+
+// NewKMSDigestSigner creates a DigestSigner which signs with the AWS KMS key with the given ID. The key must have the
+// ECC_SECG_P256K1 key spec.
+func NewKMSDigestSigner(ctx context.Context, keyManager *kms.Client, keyID string) (DigestSigner, error) {
+	publicKey, err := awscommon.LoadPublicKeyKMS(ctx, keyManager, keyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ecdsa public key: %w", err)
+	}
+
+	return &kmsDigestSigner{
+		keyID:      keyID,
+		publicKey:  publicKey,
+		keyManager: keyManager,
+	}, nil
+}
+
+func (s *kmsDigestSigner) SignDigest(ctx context.Context, digest []byte) ([]byte, error) {
+	return awscommon.SignKMS(ctx, s.keyManager, s.keyID, s.publicKey, digest)
+}
+
+func (s *kmsDigestSigner) Address() common.Address {
+	return crypto.PubkeyToAddress(*s.publicKey)
+}
@@ -0,0 +1,79 @@
+package v2
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeDigestSigner is a DigestSigner holding its key in memory, which reports the signing address of another key if
+// address is set
+type fakeDigestSigner struct {
+	privateKey *ecdsa.PrivateKey
+	address    *common.Address
+	// ethereumV returns signatures with V in {27, 28}
+	ethereumV bool
+}
+
+func (s *fakeDigestSigner) SignDigest(_ context.Context, digest []byte) ([]byte, error) {
+	sig, err := crypto.Sign(digest, s.privateKey)
+	if err != nil {
+		return nil, err
+	}
+	if s.ethereumV {
+		sig[64] += 27
+	}
+	return sig, nil
+}
+
+func (s *fakeDigestSigner) Address() common.Address {
+	if s.address != nil {
+		return *s.address
+	}
+	return crypto.PubkeyToAddress(s.privateKey.PublicKey)
+}
+
+func TestRemoteBlobRequestSigner(t *testing.T) {
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	address := crypto.PubkeyToAddress(privateKey.PublicKey)
+
+	for _, ethereumV := range []bool{false, true} {
+		signer, err := NewRemoteBlobRequestSigner(
+			&fakeDigestSigner{privateKey: privateKey, ethereumV: ethereumV}, time.Second)
+		require.NoError(t, err)
+
+		accountID, err := signer.GetAccountID()
+		require.NoError(t, err)
+		require.Equal(t, address.Hex(), accountID)
+
+		// the signatures are accepted by the disperser
+		sig, err := signer.SignPaymentStateRequest()
+		require.NoError(t, err)
+		require.NoError(t, NewAuthenticator().AuthenticatePaymentStateRequest(sig, accountID))
+
+		// and match the signatures of a local signer
+		localSigner := &LocalBlobRequestSigner{PrivateKey: privateKey}
+		localSig, err := localSigner.SignPaymentStateRequest()
+		require.NoError(t, err)
+		require.Equal(t, localSig, sig)
+	}
+
+	// signatures from another key are rejected
+	otherKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	signer, err := NewRemoteBlobRequestSigner(&fakeDigestSigner{privateKey: otherKey, address: &address}, time.Second)
+	require.NoError(t, err)
+	digest := sha256.Sum256([]byte("digest"))
+	_, err = signer.sign(digest[:])
+	require.ErrorContains(t, err, "expected "+address.Hex())
+
+	_, err = NewRemoteBlobRequestSigner(nil, time.Second)
+	require.Error(t, err)
+}
@@ -502,6 +502,13 @@ type PaymentMetadata struct {
 	Timestamp int64 `json:"timestamp"`
 	// CumulativePayment represents the total amount of payment (in wei) made by the user up to this point
 	CumulativePayment *big.Int `json:"cumulative_payment"`
+	// RetentionPeriodSeconds is the retention period requested for the blob. Zero means the default retention period.
+	RetentionPeriodSeconds uint32 `json:"retention_period_seconds,omitempty"`
+}
+
+// RetentionPeriod returns the retention period requested for the blob, or zero if the default period applies
+func (pm *PaymentMetadata) RetentionPeriod() time.Duration {
+	return time.Duration(pm.RetentionPeriodSeconds) * time.Second
 }
 
 // Hash returns the Keccak256 hash of the PaymentMetadata
@@ -509,7 +516,7 @@ func (pm *PaymentMetadata) Hash() ([32]byte, error) {
 	if pm == nil {
 		return [32]byte{}, errors.New("payment metadata is nil")
 	}
-	blobHeaderType, err := abi.NewType("tuple", "", []abi.ArgumentMarshaling{
+	components := []abi.ArgumentMarshaling{
 		{
 			Name: "accountID",
 			Type: "string",
@@ -522,7 +529,16 @@ func (pm *PaymentMetadata) Hash() ([32]byte, error) {
 			Name: "cumulativePayment",
 			Type: "uint256",
 		},
-	})
+	}
+	// The retention period is only hashed when set, so that the hash of headers using the default retention period
+	// is unchanged
+	if pm.RetentionPeriodSeconds != 0 {
+		components = append(components, abi.ArgumentMarshaling{
+			Name: "retentionPeriodSeconds",
+			Type: "uint32",
+		})
+	}
+	blobHeaderType, err := abi.NewType("tuple", "", components)
 	if err != nil {
 		return [32]byte{}, err
 	}
@@ -551,14 +567,18 @@ func (pm *PaymentMetadata) MarshalDynamoDBAttributeValue() (types.AttributeValue
 		return nil, errors.New("payment metadata is nil")
 	}
 
-	return &types.AttributeValueMemberM{
-		Value: map[string]types.AttributeValue{
-			"AccountID": &types.AttributeValueMemberS{Value: pm.AccountID},
-			"Timestamp": &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", pm.Timestamp)},
-			"CumulativePayment": &types.AttributeValueMemberN{
-				Value: pm.CumulativePayment.String(),
-			},
+	value := map[string]types.AttributeValue{
+		"AccountID": &types.AttributeValueMemberS{Value: pm.AccountID},
+		"Timestamp": &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", pm.Timestamp)},
+		"CumulativePayment": &types.AttributeValueMemberN{
+			Value: pm.CumulativePayment.String(),
 		},
+	}
+	if pm.RetentionPeriodSeconds != 0 {
+		value["RetentionPeriodSeconds"] = &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", pm.RetentionPeriodSeconds)}
+	}
+	return &types.AttributeValueMemberM{
+		Value: value,
 	}, nil
 }
 
@@ -586,6 +606,14 @@ func (pm *PaymentMetadata) UnmarshalDynamoDBAttributeValue(av types.AttributeVal
 		return fmt.Errorf("expected *types.AttributeValueMemberN for CumulativePayment, got %T", m.Value["CumulativePayment"])
 	}
 	pm.CumulativePayment, _ = new(big.Int).SetString(cp.Value, 10)
+	// RetentionPeriodSeconds is only stored if it was requested
+	if rps, ok := m.Value["RetentionPeriodSeconds"].(*types.AttributeValueMemberN); ok {
+		retentionPeriodSeconds, err := strconv.ParseUint(rps.Value, 10, 32)
+		if err != nil {
+			return fmt.Errorf("failed to parse RetentionPeriodSeconds: %w", err)
+		}
+		pm.RetentionPeriodSeconds = uint32(retentionPeriodSeconds)
+	}
 	return nil
 }
 
@@ -594,9 +622,10 @@ func (pm *PaymentMetadata) ToProtobuf() *commonpbv2.PaymentHeader {
 		return nil
 	}
 	return &commonpbv2.PaymentHeader{
-		AccountId:         pm.AccountID,
-		Timestamp:         pm.Timestamp,
-		CumulativePayment: pm.CumulativePayment.Bytes(),
+		AccountId:              pm.AccountID,
+		Timestamp:              pm.Timestamp,
+		CumulativePayment:      pm.CumulativePayment.Bytes(),
+		RetentionPeriodSeconds: pm.RetentionPeriodSeconds,
 	}
 }
 
@@ -607,9 +636,10 @@ func ConvertToPaymentMetadata(ph *commonpbv2.PaymentHeader) *PaymentMetadata {
 	}
 
 	return &PaymentMetadata{
-		AccountID:         ph.AccountId,
-		Timestamp:         ph.Timestamp,
-		CumulativePayment: new(big.Int).SetBytes(ph.CumulativePayment),
+		AccountID:              ph.AccountId,
+		Timestamp:              ph.Timestamp,
+		CumulativePayment:      new(big.Int).SetBytes(ph.CumulativePayment),
+		RetentionPeriodSeconds: ph.GetRetentionPeriodSeconds(),
 	}
 }
 
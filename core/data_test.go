@@ -2,8 +2,10 @@ package core_test
 
 import (
 	"bytes"
+	"math/big"
 	"math/rand"
 	"testing"
+	"time"
 
 	"github.com/Layr-Labs/eigenda/core"
 	"github.com/Layr-Labs/eigenda/encoding"
@@ -279,3 +281,42 @@ func TestReservedPayment_IsActive(t *testing.T) {
 		})
 	}
 }
+
+func TestPaymentMetadata_RetentionPeriod(t *testing.T) {
+	pm := core.PaymentMetadata{
+		AccountID:         "0x1234",
+		Timestamp:         1000,
+		CumulativePayment: big.NewInt(42),
+	}
+	hash, err := pm.Hash()
+	assert.NoError(t, err)
+	assert.Equal(t, time.Duration(0), pm.RetentionPeriod())
+
+	pmWithRetention := pm
+	pmWithRetention.RetentionPeriodSeconds = 3600
+	assert.Equal(t, time.Hour, pmWithRetention.RetentionPeriod())
+
+	// The retention period is committed to by the hash
+	hashWithRetention, err := pmWithRetention.Hash()
+	assert.NoError(t, err)
+	assert.NotEqual(t, hash, hashWithRetention)
+
+	// The retention period survives a round trip through dynamo and protobuf
+	av, err := pmWithRetention.MarshalDynamoDBAttributeValue()
+	assert.NoError(t, err)
+	var unmarshalled core.PaymentMetadata
+	assert.NoError(t, unmarshalled.UnmarshalDynamoDBAttributeValue(av))
+	assert.Equal(t, pmWithRetention.RetentionPeriodSeconds, unmarshalled.RetentionPeriodSeconds)
+	assert.Equal(t, pmWithRetention.RetentionPeriodSeconds, core.ConvertToPaymentMetadata(pmWithRetention.ToProtobuf()).RetentionPeriodSeconds)
+}
+
+func TestRetentionAdjustedSymbols(t *testing.T) {
+	defaultRetention := 14 * 24 * time.Hour
+	assert.Equal(t, uint64(1400), core.RetentionAdjustedSymbols(1400, 0, defaultRetention))
+	assert.Equal(t, uint64(1400), core.RetentionAdjustedSymbols(1400, defaultRetention, defaultRetention))
+	assert.Equal(t, uint64(1400), core.RetentionAdjustedSymbols(1400, 2*defaultRetention, defaultRetention))
+	assert.Equal(t, uint64(1400), core.RetentionAdjustedSymbols(1400, time.Hour, 0))
+	assert.Equal(t, uint64(700), core.RetentionAdjustedSymbols(1400, 7*24*time.Hour, defaultRetention))
+	// rounds up
+	assert.Equal(t, uint64(5), core.RetentionAdjustedSymbols(1400, time.Hour, defaultRetention))
+}
@@ -0,0 +1,131 @@
+package indexer
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/Layr-Labs/eigenda/common"
+	eigendasrvmg "github.com/Layr-Labs/eigenda/contracts/bindings/EigenDAServiceManager"
+	regcoord "github.com/Layr-Labs/eigenda/contracts/bindings/RegistryCoordinator"
+	stakereg "github.com/Layr-Labs/eigenda/contracts/bindings/StakeRegistry"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+)
+
+// OperatorStakeUpdate is a change of the stake of an operator in a quorum.
+type OperatorStakeUpdate struct {
+	Operator core.OperatorID
+	Quorum   core.QuorumID
+	Stake    *big.Int
+}
+
+// OperatorStakesFilterer finds the changes of the operator state between blocks, so that the state at a block can be
+// derived from the state at an earlier block without fetching it again.
+type OperatorStakesFilterer interface {
+	// FilterStakeUpdates returns the stake updates in the blocks after fromBlock up to toBlock, in the order they
+	// happened. complete is false if the operator state also changed otherwise in these blocks, e.g. because
+	// operators registered or deregistered, in which case the state can't be derived from the stake updates alone.
+	FilterStakeUpdates(ctx context.Context, fromBlock, toBlock uint) (updates []OperatorStakeUpdate, complete bool, err error)
+}
+
+type operatorStakesFilterer struct {
+	Filterer ethereum.LogFilterer
+
+	RegistryCoordinatorAddress gethcommon.Address
+	StakeRegistryAddress       gethcommon.Address
+	IndexRegistryAddress       gethcommon.Address
+
+	stakeRegistry *stakereg.ContractStakeRegistryFilterer
+	// stakeUpdateID and socketUpdateID are the topics of the OperatorStakeUpdate and OperatorSocketUpdate events
+	stakeUpdateID  gethcommon.Hash
+	socketUpdateID gethcommon.Hash
+}
+
+var _ OperatorStakesFilterer = (*operatorStakesFilterer)(nil)
+
+func NewOperatorStakesFilterer(eigenDAServiceManagerAddr gethcommon.Address, client common.EthClient) (*operatorStakesFilterer, error) {
+	contractEigenDAServiceManager, err := eigendasrvmg.NewContractEigenDAServiceManager(eigenDAServiceManagerAddr, client)
+	if err != nil {
+		return nil, err
+	}
+	registryCoordinatorAddr, err := contractEigenDAServiceManager.RegistryCoordinator(&bind.CallOpts{})
+	if err != nil {
+		return nil, err
+	}
+	registryCoordinator, err := regcoord.NewContractRegistryCoordinator(registryCoordinatorAddr, client)
+	if err != nil {
+		return nil, err
+	}
+	stakeRegistryAddr, err := registryCoordinator.StakeRegistry(&bind.CallOpts{})
+	if err != nil {
+		return nil, err
+	}
+	indexRegistryAddr, err := registryCoordinator.IndexRegistry(&bind.CallOpts{})
+	if err != nil {
+		return nil, err
+	}
+
+	stakeRegistry, err := stakereg.NewContractStakeRegistryFilterer(stakeRegistryAddr, client)
+	if err != nil {
+		return nil, err
+	}
+	stakeRegistryABI, err := stakereg.ContractStakeRegistryMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	registryCoordinatorABI, err := regcoord.ContractRegistryCoordinatorMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+
+	return &operatorStakesFilterer{
+		Filterer:                   client,
+		RegistryCoordinatorAddress: registryCoordinatorAddr,
+		StakeRegistryAddress:       stakeRegistryAddr,
+		IndexRegistryAddress:       indexRegistryAddr,
+		stakeRegistry:              stakeRegistry,
+		stakeUpdateID:              stakeRegistryABI.Events["OperatorStakeUpdate"].ID,
+		socketUpdateID:             registryCoordinatorABI.Events["OperatorSocketUpdate"].ID,
+	}, nil
+}
+
+func (f *operatorStakesFilterer) FilterStakeUpdates(ctx context.Context, fromBlock, toBlock uint) ([]OperatorStakeUpdate, bool, error) {
+	if toBlock <= fromBlock {
+		return nil, true, nil
+	}
+	logs, err := f.Filterer.FilterLogs(ctx, ethereum.FilterQuery{
+		FromBlock: new(big.Int).SetUint64(uint64(fromBlock) + 1),
+		ToBlock:   new(big.Int).SetUint64(uint64(toBlock)),
+		Addresses: []gethcommon.Address{f.RegistryCoordinatorAddress, f.StakeRegistryAddress, f.IndexRegistryAddress},
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	updates := make([]OperatorStakeUpdate, 0)
+	for _, log := range logs {
+		if log.Removed || len(log.Topics) == 0 {
+			continue
+		}
+		switch {
+		case log.Address == f.StakeRegistryAddress && log.Topics[0] == f.stakeUpdateID:
+			event, err := f.stakeRegistry.ParseOperatorStakeUpdate(log)
+			if err != nil {
+				return nil, false, err
+			}
+			updates = append(updates, OperatorStakeUpdate{
+				Operator: event.OperatorId,
+				Quorum:   event.QuorumNumber,
+				Stake:    event.Stake,
+			})
+		case log.Address == f.RegistryCoordinatorAddress && log.Topics[0] == f.socketUpdateID:
+			// Sockets aren't part of the operator state
+		default:
+			// Registrations, index updates and changes of the quorums change the operators or their indices
+			return nil, false, nil
+		}
+	}
+	return updates, true, nil
+}
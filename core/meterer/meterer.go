@@ -21,6 +21,10 @@ type Config struct {
 
 	// UpdateInterval is the interval for refreshing the on-chain state
 	UpdateInterval time.Duration
+
+	// DefaultRetentionPeriod is the period blobs are retained for unless a shorter period is requested. Blobs requesting
+	// a shorter retention period are charged proportionally less. If zero, the retention period doesn't affect pricing.
+	DefaultRetentionPeriod time.Duration
 }
 
 // Meterer handles payment accounting across different accounts. Disperser API server receives requests from clients and each request contains a blob header
@@ -72,55 +76,110 @@ func (m *Meterer) Start(ctx context.Context) {
 	}()
 }
 
+// Charge describes how MeterRequest charged a request, so that the charge can be reversed by RefundRequest.
+type Charge struct {
+	// SymbolsCharged is the number of symbols the request was charged for
+	SymbolsCharged uint64
+	// OverflowSymbols is the number of symbols carried over to the bin two reservation periods after the period of the
+	// request, when the request overflowed the bin of its own period. It is 0 for on-demand requests.
+	OverflowSymbols uint64
+}
+
 // MeterRequest validates a blob header and adds it to the meterer's state
 // TODO: return error if there's a rejection (with reasoning) or internal error (should be very rare)
 func (m *Meterer) MeterRequest(ctx context.Context, header core.PaymentMetadata, numSymbols uint64, quorumNumbers []uint8, receivedAt time.Time) (uint64, error) {
+	charge, err := m.ChargeRequest(ctx, header, numSymbols, quorumNumbers, receivedAt)
+	if err != nil {
+		return 0, err
+	}
+	return charge.SymbolsCharged, nil
+}
+
+// ChargeRequest meters a request like MeterRequest, and returns how the request was charged.
+func (m *Meterer) ChargeRequest(ctx context.Context, header core.PaymentMetadata, numSymbols uint64, quorumNumbers []uint8, receivedAt time.Time) (*Charge, error) {
 	accountID := gethcommon.HexToAddress(header.AccountID)
-	symbolsCharged := m.SymbolsCharged(numSymbols)
+	charge := &Charge{SymbolsCharged: m.RequestSymbolsCharged(header, numSymbols)}
 	m.logger.Info("Validating incoming request's payment metadata", "paymentMetadata", header, "numSymbols", numSymbols, "quorumNumbers", quorumNumbers)
 	// Validate against the payment method
 	if header.CumulativePayment.Sign() == 0 {
 		reservation, err := m.ChainPaymentState.GetReservedPaymentByAccount(ctx, accountID)
 		if err != nil {
-			return 0, fmt.Errorf("failed to get active reservation by account: %w", err)
+			return nil, fmt.Errorf("failed to get active reservation by account: %w", err)
 		}
-		if err := m.ServeReservationRequest(ctx, header, reservation, symbolsCharged, quorumNumbers, receivedAt); err != nil {
-			return 0, fmt.Errorf("invalid reservation: %w", err)
+		charge.OverflowSymbols, err = m.ServeReservationRequest(ctx, header, reservation, charge.SymbolsCharged, quorumNumbers, receivedAt)
+		if err != nil {
+			return nil, fmt.Errorf("invalid reservation: %w", err)
 		}
 	} else {
 		onDemandPayment, err := m.ChainPaymentState.GetOnDemandPaymentByAccount(ctx, accountID)
 		if err != nil {
-			return 0, fmt.Errorf("failed to get on-demand payment by account: %w", err)
+			return nil, fmt.Errorf("failed to get on-demand payment by account: %w", err)
 		}
-		if err := m.ServeOnDemandRequest(ctx, header, onDemandPayment, symbolsCharged, quorumNumbers, receivedAt); err != nil {
-			return 0, fmt.Errorf("invalid on-demand request: %w", err)
+		if err := m.ServeOnDemandRequest(ctx, header, onDemandPayment, charge.SymbolsCharged, quorumNumbers, receivedAt); err != nil {
+			return nil, fmt.Errorf("invalid on-demand request: %w", err)
 		}
 	}
 
+	return charge, nil
+}
+
+// RefundRequest reverses the charge of a request which was metered by MeterRequest but failed terminally afterwards.
+// Symbols are credited back to the reservation bins the request was charged to, and on-demand payments are flagged
+// for refund since the cumulative payment can't be rolled back. overflowSymbols is the OverflowSymbols of the Charge
+// of the request. Returns the number of symbols refunded.
+//
+// The bin of the request is credited first. The overflow is only credited afterwards, and failing to credit it is
+// logged rather than returned, so that retrying a failed refund never credits the bin of the request twice.
+func (m *Meterer) RefundRequest(ctx context.Context, header core.PaymentMetadata, numSymbols uint64, overflowSymbols uint64, reason string) (uint64, error) {
+	symbolsCharged := m.RequestSymbolsCharged(header, numSymbols)
+	if header.CumulativePayment == nil || header.CumulativePayment.Sign() == 0 {
+		reservationPeriod := GetReservationPeriodByNanosecond(header.Timestamp, m.ChainPaymentState.GetReservationWindow())
+		if _, err := m.OffchainStore.CreditReservationBin(ctx, header.AccountID, reservationPeriod, symbolsCharged); err != nil {
+			return 0, fmt.Errorf("failed to credit reservation bin: %w", err)
+		}
+		if overflowSymbols == 0 {
+			return symbolsCharged, nil
+		}
+		if _, err := m.OffchainStore.CreditReservationBin(ctx, header.AccountID, reservationPeriod+2, overflowSymbols); err != nil {
+			m.logger.Error("failed to credit overflow reservation bin",
+				"accountID", header.AccountID,
+				"reservationPeriod", reservationPeriod+2,
+				"symbols", overflowSymbols,
+				"err", err)
+			return symbolsCharged, nil
+		}
+		return symbolsCharged + overflowSymbols, nil
+	}
+
+	if err := m.OffchainStore.FlagOnDemandPaymentForRefund(ctx, header.AccountID, header.CumulativePayment, reason); err != nil {
+		return 0, fmt.Errorf("failed to flag on-demand payment for refund: %w", err)
+	}
 	return symbolsCharged, nil
 }
 
-// ServeReservationRequest handles the rate limiting logic for incoming requests
-func (m *Meterer) ServeReservationRequest(ctx context.Context, header core.PaymentMetadata, reservation *core.ReservedPayment, symbolsCharged uint64, quorumNumbers []uint8, receivedAt time.Time) error {
+// ServeReservationRequest handles the rate limiting logic for incoming requests. Returns the number of symbols carried
+// over to the overflow bin.
+func (m *Meterer) ServeReservationRequest(ctx context.Context, header core.PaymentMetadata, reservation *core.ReservedPayment, symbolsCharged uint64, quorumNumbers []uint8, receivedAt time.Time) (uint64, error) {
 	m.logger.Info("Recording and validating reservation usage", "header", header, "reservation", reservation)
 	if !reservation.IsActiveByNanosecond(header.Timestamp) {
-		return fmt.Errorf("reservation not active")
+		return 0, fmt.Errorf("reservation not active")
 	}
 	if err := m.ValidateQuorum(quorumNumbers, reservation.QuorumNumbers); err != nil {
-		return fmt.Errorf("invalid quorum for reservation: %w", err)
+		return 0, fmt.Errorf("invalid quorum for reservation: %w", err)
 	}
 	reservationWindow := m.ChainPaymentState.GetReservationWindow()
 	requestReservationPeriod := GetReservationPeriodByNanosecond(header.Timestamp, reservationWindow)
 	if !m.ValidateReservationPeriod(reservation, requestReservationPeriod, receivedAt) {
-		return fmt.Errorf("invalid reservation period for reservation")
+		return 0, fmt.Errorf("invalid reservation period for reservation")
 	}
 
 	// Update bin usage atomically and check against reservation's data rate as the bin limit
-	if err := m.IncrementBinUsage(ctx, header, reservation, symbolsCharged, requestReservationPeriod); err != nil {
-		return fmt.Errorf("bin overflows: %w", err)
+	overflowSymbols, err := m.IncrementBinUsage(ctx, header, reservation, symbolsCharged, requestReservationPeriod)
+	if err != nil {
+		return 0, fmt.Errorf("bin overflows: %w", err)
 	}
 
-	return nil
+	return overflowSymbols, nil
 }
 
 // ValidateQuorums ensures that the quorums listed in the blobHeader are present within allowedQuorums
@@ -157,29 +216,31 @@ func (m *Meterer) ValidateReservationPeriod(reservation *core.ReservedPayment, r
 	return true
 }
 
-// IncrementBinUsage increments the bin usage atomically and checks for overflow
-func (m *Meterer) IncrementBinUsage(ctx context.Context, header core.PaymentMetadata, reservation *core.ReservedPayment, symbolsCharged uint64, requestReservationPeriod uint64) error {
+// IncrementBinUsage increments the bin usage atomically and checks for overflow. Returns the number of symbols carried
+// over to the overflow bin.
+func (m *Meterer) IncrementBinUsage(ctx context.Context, header core.PaymentMetadata, reservation *core.ReservedPayment, symbolsCharged uint64, requestReservationPeriod uint64) (uint64, error) {
 	newUsage, err := m.OffchainStore.UpdateReservationBin(ctx, header.AccountID, requestReservationPeriod, symbolsCharged)
 	if err != nil {
-		return fmt.Errorf("failed to increment bin usage: %w", err)
+		return 0, fmt.Errorf("failed to increment bin usage: %w", err)
 	}
 
 	// metered usage stays within the bin limit
 	usageLimit := m.GetReservationBinLimit(reservation)
 	if newUsage <= usageLimit {
-		return nil
+		return 0, nil
 	} else if newUsage-symbolsCharged >= usageLimit {
 		// metered usage before updating the size already exceeded the limit
-		return fmt.Errorf("bin has already been filled")
+		return 0, fmt.Errorf("bin has already been filled")
 	}
 	if newUsage <= 2*usageLimit && requestReservationPeriod+2 <= GetReservationPeriod(int64(reservation.EndTimestamp), m.ChainPaymentState.GetReservationWindow()) {
-		_, err := m.OffchainStore.UpdateReservationBin(ctx, header.AccountID, uint64(requestReservationPeriod+2), newUsage-usageLimit)
+		overflowSymbols := newUsage - usageLimit
+		_, err := m.OffchainStore.UpdateReservationBin(ctx, header.AccountID, uint64(requestReservationPeriod+2), overflowSymbols)
 		if err != nil {
-			return err
+			return 0, err
 		}
-		return nil
+		return overflowSymbols, nil
 	}
-	return fmt.Errorf("overflow usage exceeds bin limit")
+	return 0, fmt.Errorf("overflow usage exceeds bin limit")
 }
 
 // GetReservationPeriodByNanosecondTimestamp returns the current reservation period by chunking nanosecond timestamp by the bin interval;
@@ -291,6 +352,19 @@ func (m *Meterer) SymbolsCharged(numSymbols uint64) uint64 {
 	return roundedUp
 }
 
+// RequestSymbolsCharged returns the number of symbols MeterRequest charges for a request of numSymbols symbols with the
+// given payment header.
+func (m *Meterer) RequestSymbolsCharged(header core.PaymentMetadata, numSymbols uint64) uint64 {
+	return m.RetentionAdjustedSymbols(m.SymbolsCharged(numSymbols), header.RetentionPeriod())
+}
+
+// RetentionAdjustedSymbols prorates the number of symbols charged by the requested retention period relative to the
+// default retention period, rounding up. Retention periods which are unset or not shorter than the default are charged
+// in full.
+func (m *Meterer) RetentionAdjustedSymbols(symbolsCharged uint64, retentionPeriod time.Duration) uint64 {
+	return core.RetentionAdjustedSymbols(symbolsCharged, retentionPeriod, m.DefaultRetentionPeriod)
+}
+
 // IncrementBinUsage increments the bin usage atomically and checks for overflow
 func (m *Meterer) IncrementGlobalBinUsage(ctx context.Context, symbolsCharged uint64, receivedAt time.Time) error {
 	globalPeriod := GetReservationPeriod(receivedAt.Unix(), m.ChainPaymentState.GetGlobalRatePeriodInterval())
@@ -380,6 +380,56 @@ func TestMetererOnDemand(t *testing.T) {
 	assert.Equal(t, numValidPayments, len(result))
 }
 
+func TestMetererRetentionPeriod(t *testing.T) {
+	ctx := context.Background()
+	chainState := &mock.MockOnchainPaymentState{}
+	chainState.On("GetReservationWindow", testifymock.Anything).Return(uint64(5), nil)
+	chainState.On("GetMinNumSymbols", testifymock.Anything).Return(uint64(3), nil)
+
+	privateKey, err := crypto.GenerateKey()
+	assert.NoError(t, err)
+	accountID := crypto.PubkeyToAddress(privateKey.PublicKey)
+	now := time.Now()
+	reservation := &core.ReservedPayment{
+		SymbolsPerSecond: 1000,
+		StartTimestamp:   uint64(now.Unix()) - 120,
+		EndTimestamp:     uint64(now.Unix()) + 180,
+		QuorumSplits:     []byte{50, 50},
+		QuorumNumbers:    []uint8{0, 1},
+	}
+	chainState.On("GetReservedPaymentByAccount", testifymock.Anything, accountID).Return(reservation, nil)
+
+	retentionMeterer := meterer.NewMeterer(
+		meterer.Config{DefaultRetentionPeriod: 14 * 24 * time.Hour},
+		chainState,
+		mt.OffchainStore,
+		testutils.GetLogger(),
+	)
+
+	// 1000 symbols are rounded up to 1002 symbols, which are prorated by the requested retention period
+	header := createPaymentHeader(now.UnixNano(), big.NewInt(0), accountID)
+	header.RetentionPeriodSeconds = uint32((24 * time.Hour).Seconds())
+	oneDayCharged, err := retentionMeterer.MeterRequest(ctx, *header, 1000, []uint8{0, 1}, now)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(72), oneDayCharged)
+
+	header = createPaymentHeader(now.UnixNano(), big.NewInt(0), accountID)
+	header.RetentionPeriodSeconds = uint32((7 * 24 * time.Hour).Seconds())
+	sevenDaysCharged, err := retentionMeterer.MeterRequest(ctx, *header, 1000, []uint8{0, 1}, now)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(501), sevenDaysCharged)
+
+	// the default retention period is charged in full
+	header = createPaymentHeader(now.UnixNano(), big.NewInt(0), accountID)
+	defaultCharged, err := retentionMeterer.MeterRequest(ctx, *header, 1000, []uint8{0, 1}, now)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1002), defaultCharged)
+
+	// longer retention periods cost more
+	assert.Less(t, oneDayCharged, sevenDaysCharged)
+	assert.Less(t, sevenDaysCharged, defaultCharged)
+}
+
 func TestMeterer_paymentCharged(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -498,3 +548,92 @@ func createPaymentHeader(timestamp int64, cumulativePayment *big.Int, accountID
 		CumulativePayment: cumulativePayment,
 	}
 }
+
+func TestMetererRefundRequest(t *testing.T) {
+	ctx := context.Background()
+	paymentChainState.On("GetReservationWindow", testifymock.Anything).Return(uint64(5), nil)
+	paymentChainState.On("GetGlobalSymbolsPerSecond", testifymock.Anything).Return(uint64(1009), nil)
+	paymentChainState.On("GetGlobalRatePeriodInterval", testifymock.Anything).Return(uint64(1), nil)
+	paymentChainState.On("GetMinNumSymbols", testifymock.Anything).Return(uint64(3), nil)
+	paymentChainState.On("GetReservedPaymentByAccount", testifymock.Anything, testifymock.MatchedBy(func(account gethcommon.Address) bool {
+		return account == accountID1
+	})).Return(account1Reservations, nil)
+	quorumNumbers := []uint8{0, 1}
+
+	// symbols of a failed reservation request are credited back to its bin
+	now := time.Now()
+	reservationPeriod := meterer.GetReservationPeriodByNanosecond(now.UnixNano(), mt.ChainPaymentState.GetReservationWindow())
+	binKey := commondynamodb.Key{
+		"AccountID":         &types.AttributeValueMemberS{Value: accountID1.Hex()},
+		"ReservationPeriod": &types.AttributeValueMemberN{Value: strconv.Itoa(int(reservationPeriod))},
+	}
+	header := createPaymentHeader(now.UnixNano(), big.NewInt(0), accountID1)
+	symbolsCharged, err := mt.MeterRequest(ctx, *header, 20, quorumNumbers, now)
+	assert.NoError(t, err)
+	item, err := dynamoClient.GetItem(ctx, reservationTableName, binKey)
+	assert.NoError(t, err)
+	assert.Equal(t, strconv.Itoa(int(symbolsCharged)), item["BinUsage"].(*types.AttributeValueMemberN).Value)
+
+	symbolsRefunded, err := mt.RefundRequest(ctx, *header, 20, 0, "encoding failed")
+	assert.NoError(t, err)
+	assert.Equal(t, symbolsCharged, symbolsRefunded)
+	item, err = dynamoClient.GetItem(ctx, reservationTableName, binKey)
+	assert.NoError(t, err)
+	assert.Equal(t, "0", item["BinUsage"].(*types.AttributeValueMemberN).Value)
+
+	// bin usage can't go negative
+	_, err = mt.RefundRequest(ctx, *header, 20, 0, "encoding failed")
+	assert.ErrorContains(t, err, "failed to credit reservation bin")
+
+	// symbols carried over to the overflow bin are credited back to it
+	overflowBinKey := commondynamodb.Key{
+		"AccountID":         binKey["AccountID"],
+		"ReservationPeriod": &types.AttributeValueMemberN{Value: strconv.Itoa(int(reservationPeriod + 2))},
+	}
+	filling, err := mt.ChargeRequest(ctx, *header, 90, quorumNumbers, now)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), filling.OverflowSymbols)
+	overflowing, err := mt.ChargeRequest(ctx, *header, 20, quorumNumbers, now)
+	assert.NoError(t, err)
+	assert.Equal(t, filling.SymbolsCharged+overflowing.SymbolsCharged-100, overflowing.OverflowSymbols)
+	item, err = dynamoClient.GetItem(ctx, reservationTableName, overflowBinKey)
+	assert.NoError(t, err)
+	assert.Equal(t, strconv.Itoa(int(overflowing.OverflowSymbols)), item["BinUsage"].(*types.AttributeValueMemberN).Value)
+
+	symbolsRefunded, err = mt.RefundRequest(ctx, *header, 20, overflowing.OverflowSymbols, "encoding failed")
+	assert.NoError(t, err)
+	assert.Equal(t, overflowing.SymbolsCharged+overflowing.OverflowSymbols, symbolsRefunded)
+	item, err = dynamoClient.GetItem(ctx, reservationTableName, binKey)
+	assert.NoError(t, err)
+	assert.Equal(t, strconv.Itoa(int(filling.SymbolsCharged)), item["BinUsage"].(*types.AttributeValueMemberN).Value)
+	item, err = dynamoClient.GetItem(ctx, reservationTableName, overflowBinKey)
+	assert.NoError(t, err)
+	assert.Equal(t, "0", item["BinUsage"].(*types.AttributeValueMemberN).Value)
+
+	// on-demand payments are flagged for refund
+	payment := big.NewInt(4242)
+	paymentKey := commondynamodb.Key{
+		"AccountID":          &types.AttributeValueMemberS{Value: accountID1.Hex()},
+		"CumulativePayments": &types.AttributeValueMemberN{Value: payment.String()},
+	}
+	err = dynamoClient.PutItem(ctx, ondemandTableName, commondynamodb.Item{
+		"AccountID":          paymentKey["AccountID"],
+		"CumulativePayments": paymentKey["CumulativePayments"],
+		"DataLength":         &types.AttributeValueMemberN{Value: "21"},
+	})
+	assert.NoError(t, err)
+	header = createPaymentHeader(now.UnixNano(), payment, accountID1)
+	symbolsRefunded, err = mt.RefundRequest(ctx, *header, 20, 0, "dispersal failed")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(21), symbolsRefunded)
+	item, err = dynamoClient.GetItem(ctx, ondemandTableName, paymentKey)
+	assert.NoError(t, err)
+	assert.True(t, item["RefundRequested"].(*types.AttributeValueMemberBOOL).Value)
+	assert.Equal(t, "dispersal failed", item["RefundReason"].(*types.AttributeValueMemberS).Value)
+	assert.Equal(t, "21", item["DataLength"].(*types.AttributeValueMemberN).Value)
+
+	// unknown payments aren't created
+	header = createPaymentHeader(now.UnixNano(), big.NewInt(4243), accountID1)
+	_, err = mt.RefundRequest(ctx, *header, 20, 0, "dispersal failed")
+	assert.ErrorContains(t, err, "not found")
+}
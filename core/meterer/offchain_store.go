@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"math/big"
 	"strconv"
+	"time"
 
 	pb "github.com/Layr-Labs/eigenda/api/grpc/disperser/v2"
 	commonaws "github.com/Layr-Labs/eigenda/common/aws"
@@ -13,6 +14,7 @@ import (
 	"github.com/Layr-Labs/eigenda/core"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
 )
@@ -93,6 +95,40 @@ func (s *OffchainStore) UpdateReservationBin(ctx context.Context, accountID stri
 	return binUsageValue, nil
 }
 
+// CreditReservationBin decrements the usage of a reservation bin, e.g. to credit back the symbols of a request which
+// failed after it was metered. Returns the new bin usage.
+func (s *OffchainStore) CreditReservationBin(ctx context.Context, accountID string, reservationPeriod uint64, size uint64) (uint64, error) {
+	key := map[string]types.AttributeValue{
+		"AccountID":         &types.AttributeValueMemberS{Value: accountID},
+		"ReservationPeriod": &types.AttributeValueMemberN{Value: strconv.FormatUint(reservationPeriod, 10)},
+	}
+
+	res, err := s.dynamoClient.DecrementBy(ctx, s.reservationTableName, key, "BinUsage", size)
+	if errors.Is(err, commondynamodb.ErrConditionFailed) {
+		return 0, fmt.Errorf("bin usage of period %d is less than %d symbols", reservationPeriod, size)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to decrement bin usage: %w", err)
+	}
+
+	binUsage, ok := res["BinUsage"]
+	if !ok {
+		return 0, errors.New("BinUsage is not present in the response")
+	}
+
+	binUsageAttr, ok := binUsage.(*types.AttributeValueMemberN)
+	if !ok {
+		return 0, fmt.Errorf("unexpected type for BinUsage: %T", binUsage)
+	}
+
+	binUsageValue, err := strconv.ParseUint(binUsageAttr.Value, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse BinUsage: %w", err)
+	}
+
+	return binUsageValue, nil
+}
+
 func (s *OffchainStore) UpdateGlobalBin(ctx context.Context, reservationPeriod uint64, size uint64) (uint64, error) {
 	key := map[string]types.AttributeValue{
 		"ReservationPeriod": &types.AttributeValueMemberN{Value: strconv.FormatUint(reservationPeriod, 10)},
@@ -164,6 +200,31 @@ func (s *OffchainStore) RemoveOnDemandPayment(ctx context.Context, accountID str
 	return nil
 }
 
+// FlagOnDemandPaymentForRefund marks an on-demand payment as owed back to the account, e.g. because the request failed
+// after it was paid. The payment record is kept so that the cumulative payment invariants still hold; refunds are
+// settled separately.
+func (s *OffchainStore) FlagOnDemandPaymentForRefund(ctx context.Context, accountID string, payment *big.Int, reason string) error {
+	_, err := s.dynamoClient.UpdateItemWithCondition(ctx, s.onDemandTableName,
+		commondynamodb.Key{
+			"AccountID":          &types.AttributeValueMemberS{Value: accountID},
+			"CumulativePayments": &types.AttributeValueMemberN{Value: payment.String()},
+		},
+		commondynamodb.Item{
+			"RefundRequested":   &types.AttributeValueMemberBOOL{Value: true},
+			"RefundReason":      &types.AttributeValueMemberS{Value: reason},
+			"RefundRequestedAt": &types.AttributeValueMemberN{Value: strconv.FormatInt(time.Now().Unix(), 10)},
+		},
+		expression.AttributeExists(expression.Name("DataLength")),
+	)
+	if errors.Is(err, commondynamodb.ErrConditionFailed) {
+		return fmt.Errorf("payment %s of account %s not found", payment.String(), accountID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to flag payment for refund: %w", err)
+	}
+	return nil
+}
+
 // GetRelevantOnDemandRecords gets previous cumulative payment, next cumulative payment, blob size of next payment
 // The queries are done sequentially instead of one-go for efficient querying and would not cause race condition errors for honest requests
 func (s *OffchainStore) GetRelevantOnDemandRecords(ctx context.Context, accountID string, cumulativePayment *big.Int) (*big.Int, *big.Int, uint32, error) {
@@ -318,6 +379,33 @@ func (s *OffchainStore) GetLargestCumulativePayment(ctx context.Context, account
 	return payment, nil
 }
 
+// GetAccountIDs returns the IDs of all accounts which have reservation usage or on-demand payments recorded
+func (s *OffchainStore) GetAccountIDs(ctx context.Context) ([]string, error) {
+	seen := make(map[string]struct{})
+	accountIDs := make([]string, 0)
+	for _, tableName := range []string{s.reservationTableName, s.onDemandTableName} {
+		items, err := s.dynamoClient.ScanWithInput(ctx, &dynamodb.ScanInput{
+			TableName:            aws.String(tableName),
+			ProjectionExpression: aws.String("AccountID"),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan table %s: %w", tableName, err)
+		}
+		for _, item := range items {
+			accountIDAttr, ok := item["AccountID"].(*types.AttributeValueMemberS)
+			if !ok {
+				return nil, fmt.Errorf("AccountID has invalid type: %T", item["AccountID"])
+			}
+			if _, ok := seen[accountIDAttr.Value]; ok {
+				continue
+			}
+			seen[accountIDAttr.Value] = struct{}{}
+			accountIDs = append(accountIDs, accountIDAttr.Value)
+		}
+	}
+	return accountIDs, nil
+}
+
 func parsePeriodRecord(bin map[string]types.AttributeValue) (*pb.PeriodRecord, error) {
 	reservationPeriod, ok := bin["ReservationPeriod"]
 	if !ok {
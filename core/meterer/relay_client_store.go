@@ -0,0 +1,234 @@
+package meterer
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+
+	commonaws "github.com/Layr-Labs/eigenda/common/aws"
+	commondynamodb "github.com/Layr-Labs/eigenda/common/aws/dynamodb"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+)
+
+// ErrRelayClientNotFound is returned when a relay client isn't registered in the RelayClientStore
+var ErrRelayClientNotFound = errors.New("relay client not found")
+
+// RelayClient is a client of the relays, which authenticates with an API key or ECDSA signatures, and whose usage of
+// the relays is limited by quotas.
+type RelayClient struct {
+	// ClientID identifies the client
+	ClientID string
+	// APIKeyHash is the keccak256 hash of the API key of the client. Empty if the client can't authenticate with an
+	// API key.
+	APIKeyHash []byte
+	// Address is the Ethereum address of the key with which the client signs requests. The zero address if the client
+	// can't authenticate with signatures.
+	Address gethcommon.Address
+	// MaxRequestsPerPeriod is the maximum number of requests the client can make in each quota period. Zero means
+	// that the number of requests isn't limited.
+	MaxRequestsPerPeriod uint64
+	// MaxBytesPerPeriod is the maximum number of bytes the client can fetch in each quota period. Zero means that the
+	// number of bytes isn't limited.
+	MaxBytesPerPeriod uint64
+}
+
+// RelayUsage is the usage of the relays by a client in a quota period
+type RelayUsage struct {
+	Requests uint64
+	Bytes    uint64
+}
+
+// RelayClientStore stores the relay clients and their usage of the relays, alongside the OffchainStore of the meterer.
+// The usage of each client is recorded by quota period, so that it can be billed.
+type RelayClientStore struct {
+	dynamoClient    commondynamodb.Client
+	clientTableName string
+	usageTableName  string
+	logger          logging.Logger
+}
+
+func NewRelayClientStore(
+	cfg commonaws.ClientConfig,
+	clientTableName string,
+	usageTableName string,
+	logger logging.Logger,
+) (RelayClientStore, error) {
+
+	dynamoClient, err := commondynamodb.NewClient(cfg, logger)
+	if err != nil {
+		return RelayClientStore{}, err
+	}
+
+	err = dynamoClient.TableExists(context.Background(), clientTableName)
+	if err != nil {
+		return RelayClientStore{}, err
+	}
+	err = dynamoClient.TableExists(context.Background(), usageTableName)
+	if err != nil {
+		return RelayClientStore{}, err
+	}
+
+	return RelayClientStore{
+		dynamoClient:    dynamoClient,
+		clientTableName: clientTableName,
+		usageTableName:  usageTableName,
+		logger:          logger,
+	}, nil
+}
+
+// PutRelayClient registers a relay client, replacing any client with the same ID
+func (s *RelayClientStore) PutRelayClient(ctx context.Context, client *RelayClient) error {
+	item := commondynamodb.Item{
+		"ClientID":             &types.AttributeValueMemberS{Value: client.ClientID},
+		"MaxRequestsPerPeriod": &types.AttributeValueMemberN{Value: strconv.FormatUint(client.MaxRequestsPerPeriod, 10)},
+		"MaxBytesPerPeriod":    &types.AttributeValueMemberN{Value: strconv.FormatUint(client.MaxBytesPerPeriod, 10)},
+	}
+	if len(client.APIKeyHash) > 0 {
+		item["APIKeyHash"] = &types.AttributeValueMemberS{Value: hex.EncodeToString(client.APIKeyHash)}
+	}
+	if client.Address != (gethcommon.Address{}) {
+		item["Address"] = &types.AttributeValueMemberS{Value: client.Address.Hex()}
+	}
+
+	err := s.dynamoClient.PutItem(ctx, s.clientTableName, item)
+	if err != nil {
+		return fmt.Errorf("failed to put relay client %s: %w", client.ClientID, err)
+	}
+	return nil
+}
+
+// GetRelayClient returns the relay client with the given ID, or ErrRelayClientNotFound if it isn't registered
+func (s *RelayClientStore) GetRelayClient(ctx context.Context, clientID string) (*RelayClient, error) {
+	item, err := s.dynamoClient.GetItem(ctx, s.clientTableName, commondynamodb.Key{
+		"ClientID": &types.AttributeValueMemberS{Value: clientID},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get relay client %s: %w", clientID, err)
+	}
+	if item == nil {
+		return nil, ErrRelayClientNotFound
+	}
+
+	client := &RelayClient{ClientID: clientID}
+	if client.MaxRequestsPerPeriod, err = parseUint64Attribute(item, "MaxRequestsPerPeriod"); err != nil {
+		return nil, err
+	}
+	if client.MaxBytesPerPeriod, err = parseUint64Attribute(item, "MaxBytesPerPeriod"); err != nil {
+		return nil, err
+	}
+	if attr, ok := item["APIKeyHash"].(*types.AttributeValueMemberS); ok {
+		client.APIKeyHash, err = hex.DecodeString(attr.Value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse APIKeyHash: %w", err)
+		}
+	}
+	if attr, ok := item["Address"].(*types.AttributeValueMemberS); ok {
+		if !gethcommon.IsHexAddress(attr.Value) {
+			return nil, fmt.Errorf("invalid Address: %s", attr.Value)
+		}
+		client.Address = gethcommon.HexToAddress(attr.Value)
+	}
+
+	return client, nil
+}
+
+// IncrementRelayUsage adds requests and bytes to the usage of a client in a quota period, and returns the new usage
+func (s *RelayClientStore) IncrementRelayUsage(
+	ctx context.Context,
+	clientID string,
+	period uint64,
+	requests uint64,
+	bytes uint64,
+) (*RelayUsage, error) {
+	key := relayUsageKey(clientID, period)
+
+	res, err := s.dynamoClient.IncrementBy(ctx, s.usageTableName, key, "RequestCount", requests)
+	if err != nil {
+		return nil, fmt.Errorf("failed to increment request count: %w", err)
+	}
+	requestCount, err := parseUint64Attribute(res, "RequestCount")
+	if err != nil {
+		return nil, err
+	}
+
+	res, err = s.dynamoClient.IncrementBy(ctx, s.usageTableName, key, "ByteCount", bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to increment byte count: %w", err)
+	}
+	byteCount, err := parseUint64Attribute(res, "ByteCount")
+	if err != nil {
+		return nil, err
+	}
+
+	return &RelayUsage{Requests: requestCount, Bytes: byteCount}, nil
+}
+
+// CreditRelayUsage removes requests and bytes from the usage of a client in a quota period, e.g. to credit back a
+// request which was rejected after its usage was recorded
+func (s *RelayClientStore) CreditRelayUsage(
+	ctx context.Context,
+	clientID string,
+	period uint64,
+	requests uint64,
+	bytes uint64,
+) error {
+	key := relayUsageKey(clientID, period)
+
+	_, err := s.dynamoClient.DecrementBy(ctx, s.usageTableName, key, "RequestCount", requests)
+	if err != nil {
+		return fmt.Errorf("failed to decrement request count: %w", err)
+	}
+	_, err = s.dynamoClient.DecrementBy(ctx, s.usageTableName, key, "ByteCount", bytes)
+	if err != nil {
+		return fmt.Errorf("failed to decrement byte count: %w", err)
+	}
+	return nil
+}
+
+// GetRelayUsage returns the usage of a client in a quota period
+func (s *RelayClientStore) GetRelayUsage(ctx context.Context, clientID string, period uint64) (*RelayUsage, error) {
+	item, err := s.dynamoClient.GetItem(ctx, s.usageTableName, relayUsageKey(clientID, period))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get relay usage of client %s: %w", clientID, err)
+	}
+	if item == nil {
+		return &RelayUsage{}, nil
+	}
+
+	usage := &RelayUsage{}
+	if usage.Requests, err = parseUint64Attribute(item, "RequestCount"); err != nil {
+		return nil, err
+	}
+	if usage.Bytes, err = parseUint64Attribute(item, "ByteCount"); err != nil {
+		return nil, err
+	}
+	return usage, nil
+}
+
+func relayUsageKey(clientID string, period uint64) commondynamodb.Key {
+	return commondynamodb.Key{
+		"ClientID":    &types.AttributeValueMemberS{Value: clientID},
+		"QuotaPeriod": &types.AttributeValueMemberN{Value: strconv.FormatUint(period, 10)},
+	}
+}
+
+// parseUint64Attribute parses a numeric attribute of an item. Missing attributes are parsed as zero.
+func parseUint64Attribute(item commondynamodb.Item, name string) (uint64, error) {
+	attr, ok := item[name]
+	if !ok {
+		return 0, nil
+	}
+	numAttr, ok := attr.(*types.AttributeValueMemberN)
+	if !ok {
+		return 0, fmt.Errorf("unexpected type for %s: %T", name, attr)
+	}
+	value, err := strconv.ParseUint(numAttr.Value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse %s: %w", name, err)
+	}
+	return value, nil
+}
@@ -0,0 +1,63 @@
+package meterer_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/common/testutils"
+	"github.com/Layr-Labs/eigenda/core/meterer"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRelayClientStore(t *testing.T) {
+	clientTableName := "relay_clients_test"
+	usageTableName := "relay_usage_test"
+	err := meterer.CreateRelayClientTable(clientConfig, clientTableName)
+	assert.NoError(t, err)
+	err = meterer.CreateRelayUsageTable(clientConfig, usageTableName)
+	assert.NoError(t, err)
+
+	ctx := context.Background()
+	store, err := meterer.NewRelayClientStore(
+		clientConfig, clientTableName, usageTableName, testutils.GetLogger())
+	assert.NoError(t, err)
+
+	_, err = store.GetRelayClient(ctx, "client1")
+	assert.ErrorIs(t, err, meterer.ErrRelayClientNotFound)
+
+	client := &meterer.RelayClient{
+		ClientID:             "client1",
+		APIKeyHash:           []byte{1, 2, 3},
+		Address:              gethcommon.HexToAddress("0x1234"),
+		MaxRequestsPerPeriod: 10,
+		MaxBytesPerPeriod:    1000,
+	}
+	err = store.PutRelayClient(ctx, client)
+	assert.NoError(t, err)
+	storedClient, err := store.GetRelayClient(ctx, "client1")
+	assert.NoError(t, err)
+	assert.Equal(t, client, storedClient)
+
+	usage, err := store.IncrementRelayUsage(ctx, "client1", 1, 1, 100)
+	assert.NoError(t, err)
+	assert.Equal(t, &meterer.RelayUsage{Requests: 1, Bytes: 100}, usage)
+	usage, err = store.IncrementRelayUsage(ctx, "client1", 1, 1, 200)
+	assert.NoError(t, err)
+	assert.Equal(t, &meterer.RelayUsage{Requests: 2, Bytes: 300}, usage)
+
+	// usage is recorded separately for each period
+	usage, err = store.IncrementRelayUsage(ctx, "client1", 2, 1, 50)
+	assert.NoError(t, err)
+	assert.Equal(t, &meterer.RelayUsage{Requests: 1, Bytes: 50}, usage)
+
+	err = store.CreditRelayUsage(ctx, "client1", 1, 1, 200)
+	assert.NoError(t, err)
+	usage, err = store.GetRelayUsage(ctx, "client1", 1)
+	assert.NoError(t, err)
+	assert.Equal(t, &meterer.RelayUsage{Requests: 1, Bytes: 100}, usage)
+
+	usage, err = store.GetRelayUsage(ctx, "client2", 1)
+	assert.NoError(t, err)
+	assert.Equal(t, &meterer.RelayUsage{}, usage)
+}
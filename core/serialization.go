@@ -62,8 +62,9 @@ func (h *BatchHeader) SetBatchRoot(blobHeaders []*BlobHeader) (*merkletree.Merkl
 
 func (h *BatchHeader) SetBatchRootFromBlobHeaderHashes(blobHeaderHashes [][32]byte) (*merkletree.MerkleTree, error) {
 	leafs := make([][]byte, len(blobHeaderHashes))
-	for i, hash := range blobHeaderHashes {
-		leafs[i] = hash[:]
+	for i := range blobHeaderHashes {
+		// Slice the element rather than the loop variable, which is reused across iterations
+		leafs[i] = blobHeaderHashes[i][:]
 	}
 	tree, err := merkletree.NewTree(merkletree.WithData(leafs), merkletree.WithHashType(keccak256.New()))
 	if err != nil {
@@ -5,6 +5,7 @@ import (
 	"math"
 	"math/big"
 	"strconv"
+	"time"
 
 	"golang.org/x/exp/constraints"
 )
@@ -37,3 +38,14 @@ func ValidatePort(portStr string) error {
 	}
 	return err
 }
+
+// RetentionAdjustedSymbols prorates symbolsCharged by retentionPeriod relative to defaultRetentionPeriod, rounding up.
+// Symbols are charged in full if either period is zero, or if retentionPeriod is not shorter than the default.
+func RetentionAdjustedSymbols(symbolsCharged uint64, retentionPeriod time.Duration, defaultRetentionPeriod time.Duration) uint64 {
+	if retentionPeriod <= 0 || defaultRetentionPeriod <= 0 || retentionPeriod >= defaultRetentionPeriod {
+		return symbolsCharged
+	}
+	adjusted := new(big.Int).Mul(new(big.Int).SetUint64(symbolsCharged), big.NewInt(int64(retentionPeriod)))
+	adjusted = RoundUpDivideBig(adjusted, big.NewInt(int64(defaultRetentionPeriod)))
+	return adjusted.Uint64()
+}
@@ -3,6 +3,13 @@ package v2
 type BlobRequestAuthenticator interface {
 	AuthenticateBlobRequest(header *BlobHeader, signature []byte) error
 	AuthenticatePaymentStateRequest(signature []byte, accountId string) error
+
+	// AuthenticateDelegatedBlobRequest authenticates a blob request signed by a delegate of the account in the payment
+	// header, under the given delegation from the account
+	AuthenticateDelegatedBlobRequest(header *BlobHeader, signature []byte, delegation *PaymentDelegation) error
+	// AuthenticateDelegatedPaymentStateRequest authenticates a payment state request signed by a delegate of the
+	// account, under the given delegation from the account
+	AuthenticateDelegatedPaymentStateRequest(signature []byte, accountId string, delegation *PaymentDelegation) error
 }
 
 type BlobRequestSigner interface {
@@ -10,3 +17,22 @@ type BlobRequestSigner interface {
 	SignPaymentStateRequest() ([]byte, error)
 	GetAccountID() (string, error)
 }
+
+// PaymentDelegation authorizes a delegate account to sign requests on behalf of a payer account, so that blobs paid for
+// by the payer can be dispersed without access to the key of the payer. The payer and the delegate aren't part of the
+// delegation: the payer is the account in the payment header, and the delegate is the signer of the request.
+type PaymentDelegation struct {
+	// Expiry is the Unix timestamp in seconds until which the delegation is valid
+	Expiry uint64
+	// Signature is the signature of the payer over the delegation, as computed by hashing.HashPaymentDelegation
+	Signature []byte
+}
+
+// DelegatedBlobRequestSigner is a BlobRequestSigner which signs requests as a delegate of a payer account.
+// GetAccountID returns the account of the payer, which the dispersed blobs are paid for by.
+type DelegatedBlobRequestSigner interface {
+	BlobRequestSigner
+
+	// GetPaymentDelegation returns the delegation from the payer to the signer
+	GetPaymentDelegation() *PaymentDelegation
+}
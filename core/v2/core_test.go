@@ -202,13 +202,14 @@ func checkBatchByUniversalVerifier(
 	cst core.IndexedChainState,
 	packagedBlobs map[core.OperatorID][]*corev2.BlobShard,
 	pool common.WorkerPool,
+	maxBlobsPerSubBatch int,
 ) {
 
 	ctx := context.Background()
 	state, _ := cst.GetIndexedOperatorState(context.Background(), 0, quorumNumbers)
 
 	for id := range state.IndexedOperators {
-		val := corev2.NewShardValidator(v, id, testutils.GetLogger())
+		val := corev2.NewShardValidator(v, id, maxBlobsPerSubBatch, testutils.GetLogger())
 		blobs := packagedBlobs[id]
 		st, err := cst.GetOperatorStateByOperator(ctx, 0, id)
 		require.NoError(t, err)
@@ -243,9 +244,12 @@ func TestValidationSucceeds(t *testing.T) {
 
 		packagedBlobs, cst := prepareBlobs(t, operatorCount, certs, blobs, bn)
 
-		t.Run(fmt.Sprintf("universal verifier operatorCount=%v over %v blobs", operatorCount, len(blobs)), func(t *testing.T) {
-			checkBatchByUniversalVerifier(t, cst, packagedBlobs, pool)
-		})
+		// 0 verifies all blobs with the same encoding params together, and 1 verifies each blob separately
+		for _, maxBlobsPerSubBatch := range []int{0, 1} {
+			t.Run(fmt.Sprintf("universal verifier operatorCount=%v over %v blobs maxBlobsPerSubBatch=%v", operatorCount, len(blobs), maxBlobsPerSubBatch), func(t *testing.T) {
+				checkBatchByUniversalVerifier(t, cst, packagedBlobs, pool, maxBlobsPerSubBatch)
+			})
+		}
 
 	}
 
@@ -227,6 +227,20 @@ func (c *BlobCertificate) Hash() ([32]byte, error) {
 		return [32]byte{}, fmt.Errorf("blob header is nil")
 	}
 
+	blobKey, err := c.BlobHeader.BlobKey()
+	if err != nil {
+		return [32]byte{}, err
+	}
+
+	return ComputeBlobCertificateHash(blobKey, c.Signature, c.RelayKeys)
+}
+
+// ComputeBlobCertificateHash accepts as parameters the elements which contribute to the hash of a BlobCertificate, and
+// returns the hash. This is the value committed to by the leaves of a batch's merkle tree.
+//
+// This function exists so that the hash can be computed when only the BlobKey, and not the full BlobHeader, is known
+// (e.g. when working with a certificate reconstructed from on-chain data).
+func ComputeBlobCertificateHash(blobKey BlobKey, signature []byte, relayKeys []RelayKey) ([32]byte, error) {
 	blobKeyType, err := abi.NewType("bytes32", "", nil)
 	if err != nil {
 		return [32]byte{}, err
@@ -254,12 +268,7 @@ func (c *BlobCertificate) Hash() ([32]byte, error) {
 		},
 	}
 
-	blobKey, err := c.BlobHeader.BlobKey()
-	if err != nil {
-		return [32]byte{}, err
-	}
-
-	bytes, err := arguments.Pack(blobKey, c.Signature, c.RelayKeys)
+	bytes, err := arguments.Pack(blobKey, signature, relayKeys)
 	if err != nil {
 		return [32]byte{}, err
 	}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
 
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/core"
@@ -31,16 +32,23 @@ type BlobShard struct {
 type shardValidator struct {
 	verifier   encoding.Verifier
 	operatorID core.OperatorID
-	logger     logging.Logger
+	// maxBlobsPerSubBatch is the maximum number of blobs whose chunks are verified with a single pairing check.
+	// 0 means that all blobs with the same encoding params are verified together.
+	maxBlobsPerSubBatch int
+	logger              logging.Logger
 }
 
 var _ ShardValidator = (*shardValidator)(nil)
 
-func NewShardValidator(v encoding.Verifier, operatorID core.OperatorID, logger logging.Logger) *shardValidator {
+// NewShardValidator creates a new shard validator. Chunks of at most maxBlobsPerSubBatch blobs are verified with a
+// single pairing check, and the sub-batches are verified in parallel. 0 means that all blobs with the same encoding
+// params are verified together.
+func NewShardValidator(v encoding.Verifier, operatorID core.OperatorID, maxBlobsPerSubBatch int, logger logging.Logger) *shardValidator {
 	return &shardValidator{
-		verifier:   v,
-		operatorID: operatorID,
-		logger:     logger,
+		verifier:            v,
+		operatorID:          operatorID,
+		maxBlobsPerSubBatch: maxBlobsPerSubBatch,
+		logger:              logger,
 	}
 }
 
@@ -113,6 +121,62 @@ func filterOperatorStateQuorums(operatorState *core.OperatorState, quorums []cor
 	return filtered
 }
 
+// blobQuorumSamples are the samples of the chunks of a blob in one quorum, which are verified together with the
+// samples of other blobs with the same encoding params
+type blobQuorumSamples struct {
+	params  encoding.EncodingParams
+	samples []encoding.Sample
+}
+
+// prepareBlob validates the chunks of a blob against the assignments of the operator, and returns the samples to
+// verify for each of its quorums
+func (v *shardValidator) prepareBlob(blob *BlobShard, blobVersionParams *BlobVersionParameterMap, state *core.OperatorState) ([]blobQuorumSamples, error) {
+	relevantQuorums := filterOperatorStateQuorums(state, blob.BlobHeader.QuorumNumbers)
+	if len(blob.Bundles) != len(relevantQuorums) {
+		return nil, fmt.Errorf("number of bundles (%d) does not match number of relevant quorums (%d)", len(blob.Bundles), len(relevantQuorums))
+	}
+
+	quorumSamples := make([]blobQuorumSamples, 0, len(blob.BlobHeader.QuorumNumbers))
+	for _, quorum := range blob.BlobHeader.QuorumNumbers {
+		blobParams, ok := blobVersionParams.Get(blob.BlobHeader.BlobVersion)
+		if !ok {
+			return nil, fmt.Errorf("blob version %d not found", blob.BlobHeader.BlobVersion)
+		}
+		chunks, assignment, err := v.validateBlobQuorum(quorum, blob, blobParams, state)
+		if errors.Is(err, ErrBlobQuorumSkip) {
+			v.logger.Warn("Skipping blob for quorum", "quorum", quorum, "err", err)
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+
+		// TODO: Define params for the blob
+		params, err := GetEncodingParams(blob.BlobHeader.BlobCommitments.Length, blobParams)
+		if err != nil {
+			return nil, err
+		}
+
+		// The blob index of the samples is set once the sub-batch they are verified in is known
+		indices := assignment.GetIndices()
+		samples := make([]encoding.Sample, len(chunks))
+		for ind := range chunks {
+			samples[ind] = encoding.Sample{
+				Commitment:      blob.BlobHeader.BlobCommitments.Commitment,
+				Chunk:           chunks[ind],
+				AssignmentIndex: uint(indices[ind]),
+			}
+		}
+		quorumSamples = append(quorumSamples, blobQuorumSamples{
+			params:  params,
+			samples: samples,
+		})
+	}
+	return quorumSamples, nil
+}
+
+// ValidateBlobs validates the chunks of the blobs against their commitments. The blobs are prepared in parallel on
+// the pool, then the chunks of blobs sharing encoding params are verified with one pairing check per sub-batch of at
+// most maxBlobsPerSubBatch blobs, with the sub-batches verified in parallel.
 func (v *shardValidator) ValidateBlobs(ctx context.Context, blobs []*BlobShard, blobVersionParams *BlobVersionParameterMap, pool common.WorkerPool, state *core.OperatorState) error {
 	if len(blobs) == 0 {
 		return fmt.Errorf("no blobs")
@@ -122,79 +186,67 @@ func (v *shardValidator) ValidateBlobs(ctx context.Context, blobs []*BlobShard,
 		return fmt.Errorf("blob version params is nil")
 	}
 
-	var err error
-	subBatchMap := make(map[encoding.EncodingParams]*encoding.SubBatch)
-	blobCommitmentList := make([]encoding.BlobCommitments, len(blobs))
-
+	// Prepare the samples of each blob in parallel
+	blobSamples := make([][]blobQuorumSamples, len(blobs))
+	prepareErrs := make([]error, len(blobs))
+	var wg sync.WaitGroup
+	wg.Add(len(blobs))
 	for k, blob := range blobs {
-		relevantQuorums := filterOperatorStateQuorums(state, blob.BlobHeader.QuorumNumbers)
-		if len(blob.Bundles) != len(relevantQuorums) {
-			return fmt.Errorf("number of bundles (%d) does not match number of relevant quorums (%d)", len(blob.Bundles), len(relevantQuorums))
+		k := k
+		blob := blob
+		pool.Submit(func() {
+			defer wg.Done()
+			blobSamples[k], prepareErrs[k] = v.prepareBlob(blob, blobVersionParams, state)
+		})
+	}
+	wg.Wait()
+	for _, err := range prepareErrs {
+		if err != nil {
+			return err
 		}
+	}
 
-		// Saved for the blob length validation
-		blobCommitmentList[k] = blob.BlobHeader.BlobCommitments
-
-		// for each quorum
-		for _, quorum := range blob.BlobHeader.QuorumNumbers {
-			blobParams, ok := blobVersionParams.Get(blob.BlobHeader.BlobVersion)
+	// Group the samples into sub-batches of blobs with the same encoding params, keeping the order of the blobs
+	subBatches := make([]*encoding.SubBatch, 0)
+	subBatchParams := make([]encoding.EncodingParams, 0)
+	// openSubBatches are the indices of the sub-batches which still have room for more blobs, by encoding params
+	openSubBatches := make(map[encoding.EncodingParams]int)
+	for _, quorumSamples := range blobSamples {
+		for _, qs := range quorumSamples {
+			i, ok := openSubBatches[qs.params]
 			if !ok {
-				return fmt.Errorf("blob version %d not found", blob.BlobHeader.BlobVersion)
-			}
-			chunks, assignment, err := v.validateBlobQuorum(quorum, blob, blobParams, state)
-			if errors.Is(err, ErrBlobQuorumSkip) {
-				v.logger.Warn("Skipping blob for quorum", "quorum", quorum, "err", err)
-				continue
-			} else if err != nil {
-				return err
+				i = len(subBatches)
+				subBatches = append(subBatches, &encoding.SubBatch{})
+				subBatchParams = append(subBatchParams, qs.params)
+				openSubBatches[qs.params] = i
 			}
-
-			// TODO: Define params for the blob
-			params, err := GetEncodingParams(blob.BlobHeader.BlobCommitments.Length, blobParams)
-
-			if err != nil {
-				return err
-			}
-
-			// Check the received chunks against the commitment
-			blobIndex := 0
-			subBatch, ok := subBatchMap[params]
-			if ok {
-				blobIndex = subBatch.NumBlobs
-			}
-
-			indices := assignment.GetIndices()
-			samples := make([]encoding.Sample, len(chunks))
-			for ind := range chunks {
-				samples[ind] = encoding.Sample{
-					Commitment:      blob.BlobHeader.BlobCommitments.Commitment,
-					Chunk:           chunks[ind],
-					AssignmentIndex: uint(indices[ind]),
-					BlobIndex:       blobIndex,
-				}
+			subBatch := subBatches[i]
+			for ind := range qs.samples {
+				qs.samples[ind].BlobIndex = subBatch.NumBlobs
 			}
-
-			// update subBatch
-			if !ok {
-				subBatchMap[params] = &encoding.SubBatch{
-					Samples:  samples,
-					NumBlobs: 1,
-				}
-			} else {
-				subBatch.Samples = append(subBatch.Samples, samples...)
-				subBatch.NumBlobs += 1
+			subBatch.Samples = append(subBatch.Samples, qs.samples...)
+			subBatch.NumBlobs++
+			if v.maxBlobsPerSubBatch > 0 && subBatch.NumBlobs >= v.maxBlobsPerSubBatch {
+				delete(openSubBatches, qs.params)
 			}
 		}
 	}
 
-	// Parallelize the universal verification for each subBatch
-	numResult := len(subBatchMap) + len(blobCommitmentList)
+	blobCommitmentList := make([]encoding.BlobCommitments, len(blobs))
+	for k, blob := range blobs {
+		// Saved for the blob length validation
+		blobCommitmentList[k] = blob.BlobHeader.BlobCommitments
+	}
+
+	// Parallelize the universal verification for each subBatch, the length proof of each blob, and the commitment
+	// equivalence check
+	numResult := len(subBatches) + len(blobCommitmentList) + 1
 	// create a channel to accept results, we don't use stop
 	out := make(chan error, numResult)
 
 	// parallelize subBatch verification
-	for params, subBatch := range subBatchMap {
-		params := params
+	for i, subBatch := range subBatches {
+		params := subBatchParams[i]
 		subBatch := subBatch
 		pool.Submit(func() {
 			v.universalVerifyWorker(params, subBatch, out)
@@ -208,11 +260,11 @@ func (v *shardValidator) ValidateBlobs(ctx context.Context, blobs []*BlobShard,
 			v.verifyBlobLengthWorker(blobCommitments, out)
 		})
 	}
+
 	// check if commitments are equivalent
-	err = v.verifier.VerifyCommitEquivalenceBatch(blobCommitmentList)
-	if err != nil {
-		return err
-	}
+	pool.Submit(func() {
+		out <- v.verifier.VerifyCommitEquivalenceBatch(blobCommitmentList)
+	})
 
 	for i := 0; i < numResult; i++ {
 		err := <-out
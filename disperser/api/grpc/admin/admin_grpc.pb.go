@@ -0,0 +1,390 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v4.23.4
+// source: admin/admin.proto
+
+package admin
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	Admin_PauseIntake_FullMethodName              = "/admin.Admin/PauseIntake"
+	Admin_ResumeIntake_FullMethodName             = "/admin.Admin/ResumeIntake"
+	Admin_DrainBatcher_FullMethodName             = "/admin.Admin/DrainBatcher"
+	Admin_ResumeBatcher_FullMethodName            = "/admin.Admin/ResumeBatcher"
+	Admin_ForceBatch_FullMethodName               = "/admin.Admin/ForceBatch"
+	Admin_RefreshPaymentState_FullMethodName      = "/admin.Admin/RefreshPaymentState"
+	Admin_GetQueueStats_FullMethodName            = "/admin.Admin/GetQueueStats"
+	Admin_GetAccountingDivergences_FullMethodName = "/admin.Admin/GetAccountingDivergences"
+)
+
+// AdminClient is the client API for Admin service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type AdminClient interface {
+	// PauseIntake makes the API server reject new dispersal requests until ResumeIntake is called.
+	PauseIntake(ctx context.Context, in *PauseIntakeRequest, opts ...grpc.CallOption) (*PauseIntakeReply, error)
+	// ResumeIntake makes the API server accept new dispersal requests again.
+	ResumeIntake(ctx context.Context, in *ResumeIntakeRequest, opts ...grpc.CallOption) (*ResumeIntakeReply, error)
+	// DrainBatcher makes the batcher stop encoding new blobs, and create batches from the blobs which have already
+	// been encoded until none are left. The progress of the drain can be followed with GetQueueStats.
+	DrainBatcher(ctx context.Context, in *DrainBatcherRequest, opts ...grpc.CallOption) (*DrainBatcherReply, error)
+	// ResumeBatcher makes a drained batcher encode new blobs again.
+	ResumeBatcher(ctx context.Context, in *ResumeBatcherRequest, opts ...grpc.CallOption) (*ResumeBatcherReply, error)
+	// ForceBatch makes the batcher create a batch from the blobs which have been encoded so far, without waiting
+	// for a batch trigger.
+	ForceBatch(ctx context.Context, in *ForceBatchRequest, opts ...grpc.CallOption) (*ForceBatchReply, error)
+	// RefreshPaymentState makes the API server re-read the on-chain payment state.
+	RefreshPaymentState(ctx context.Context, in *RefreshPaymentStateRequest, opts ...grpc.CallOption) (*RefreshPaymentStateReply, error)
+	// GetQueueStats returns statistics about the internal queues of the component.
+	GetQueueStats(ctx context.Context, in *GetQueueStatsRequest, opts ...grpc.CallOption) (*GetQueueStatsReply, error)
+	// GetAccountingDivergences returns the divergences between the off-chain payment accounting and the on-chain
+	// deposits and reservations found by the last reconciliation.
+	GetAccountingDivergences(ctx context.Context, in *GetAccountingDivergencesRequest, opts ...grpc.CallOption) (*GetAccountingDivergencesReply, error)
+}
+
+type adminClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAdminClient(cc grpc.ClientConnInterface) AdminClient {
+	return &adminClient{cc}
+}
+
+func (c *adminClient) PauseIntake(ctx context.Context, in *PauseIntakeRequest, opts ...grpc.CallOption) (*PauseIntakeReply, error) {
+	out := new(PauseIntakeReply)
+	err := c.cc.Invoke(ctx, Admin_PauseIntake_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) ResumeIntake(ctx context.Context, in *ResumeIntakeRequest, opts ...grpc.CallOption) (*ResumeIntakeReply, error) {
+	out := new(ResumeIntakeReply)
+	err := c.cc.Invoke(ctx, Admin_ResumeIntake_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) DrainBatcher(ctx context.Context, in *DrainBatcherRequest, opts ...grpc.CallOption) (*DrainBatcherReply, error) {
+	out := new(DrainBatcherReply)
+	err := c.cc.Invoke(ctx, Admin_DrainBatcher_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) ResumeBatcher(ctx context.Context, in *ResumeBatcherRequest, opts ...grpc.CallOption) (*ResumeBatcherReply, error) {
+	out := new(ResumeBatcherReply)
+	err := c.cc.Invoke(ctx, Admin_ResumeBatcher_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) ForceBatch(ctx context.Context, in *ForceBatchRequest, opts ...grpc.CallOption) (*ForceBatchReply, error) {
+	out := new(ForceBatchReply)
+	err := c.cc.Invoke(ctx, Admin_ForceBatch_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) RefreshPaymentState(ctx context.Context, in *RefreshPaymentStateRequest, opts ...grpc.CallOption) (*RefreshPaymentStateReply, error) {
+	out := new(RefreshPaymentStateReply)
+	err := c.cc.Invoke(ctx, Admin_RefreshPaymentState_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) GetQueueStats(ctx context.Context, in *GetQueueStatsRequest, opts ...grpc.CallOption) (*GetQueueStatsReply, error) {
+	out := new(GetQueueStatsReply)
+	err := c.cc.Invoke(ctx, Admin_GetQueueStats_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminClient) GetAccountingDivergences(ctx context.Context, in *GetAccountingDivergencesRequest, opts ...grpc.CallOption) (*GetAccountingDivergencesReply, error) {
+	out := new(GetAccountingDivergencesReply)
+	err := c.cc.Invoke(ctx, Admin_GetAccountingDivergences_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AdminServer is the server API for Admin service.
+// All implementations must embed UnimplementedAdminServer
+// for forward compatibility
+type AdminServer interface {
+	// PauseIntake makes the API server reject new dispersal requests until ResumeIntake is called.
+	PauseIntake(context.Context, *PauseIntakeRequest) (*PauseIntakeReply, error)
+	// ResumeIntake makes the API server accept new dispersal requests again.
+	ResumeIntake(context.Context, *ResumeIntakeRequest) (*ResumeIntakeReply, error)
+	// DrainBatcher makes the batcher stop encoding new blobs, and create batches from the blobs which have already
+	// been encoded until none are left. The progress of the drain can be followed with GetQueueStats.
+	DrainBatcher(context.Context, *DrainBatcherRequest) (*DrainBatcherReply, error)
+	// ResumeBatcher makes a drained batcher encode new blobs again.
+	ResumeBatcher(context.Context, *ResumeBatcherRequest) (*ResumeBatcherReply, error)
+	// ForceBatch makes the batcher create a batch from the blobs which have been encoded so far, without waiting
+	// for a batch trigger.
+	ForceBatch(context.Context, *ForceBatchRequest) (*ForceBatchReply, error)
+	// RefreshPaymentState makes the API server re-read the on-chain payment state.
+	RefreshPaymentState(context.Context, *RefreshPaymentStateRequest) (*RefreshPaymentStateReply, error)
+	// GetQueueStats returns statistics about the internal queues of the component.
+	GetQueueStats(context.Context, *GetQueueStatsRequest) (*GetQueueStatsReply, error)
+	// GetAccountingDivergences returns the divergences between the off-chain payment accounting and the on-chain
+	// deposits and reservations found by the last reconciliation.
+	GetAccountingDivergences(context.Context, *GetAccountingDivergencesRequest) (*GetAccountingDivergencesReply, error)
+	mustEmbedUnimplementedAdminServer()
+}
+
+// UnimplementedAdminServer must be embedded to have forward compatible implementations.
+type UnimplementedAdminServer struct {
+}
+
+func (UnimplementedAdminServer) PauseIntake(context.Context, *PauseIntakeRequest) (*PauseIntakeReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PauseIntake not implemented")
+}
+func (UnimplementedAdminServer) ResumeIntake(context.Context, *ResumeIntakeRequest) (*ResumeIntakeReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResumeIntake not implemented")
+}
+func (UnimplementedAdminServer) DrainBatcher(context.Context, *DrainBatcherRequest) (*DrainBatcherReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DrainBatcher not implemented")
+}
+func (UnimplementedAdminServer) ResumeBatcher(context.Context, *ResumeBatcherRequest) (*ResumeBatcherReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResumeBatcher not implemented")
+}
+func (UnimplementedAdminServer) ForceBatch(context.Context, *ForceBatchRequest) (*ForceBatchReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ForceBatch not implemented")
+}
+func (UnimplementedAdminServer) RefreshPaymentState(context.Context, *RefreshPaymentStateRequest) (*RefreshPaymentStateReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RefreshPaymentState not implemented")
+}
+func (UnimplementedAdminServer) GetQueueStats(context.Context, *GetQueueStatsRequest) (*GetQueueStatsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetQueueStats not implemented")
+}
+func (UnimplementedAdminServer) GetAccountingDivergences(context.Context, *GetAccountingDivergencesRequest) (*GetAccountingDivergencesReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAccountingDivergences not implemented")
+}
+func (UnimplementedAdminServer) mustEmbedUnimplementedAdminServer() {}
+
+// UnsafeAdminServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AdminServer will
+// result in compilation errors.
+type UnsafeAdminServer interface {
+	mustEmbedUnimplementedAdminServer()
+}
+
+func RegisterAdminServer(s grpc.ServiceRegistrar, srv AdminServer) {
+	s.RegisterService(&Admin_ServiceDesc, srv)
+}
+
+func _Admin_PauseIntake_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PauseIntakeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).PauseIntake(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_PauseIntake_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).PauseIntake(ctx, req.(*PauseIntakeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_ResumeIntake_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResumeIntakeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).ResumeIntake(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_ResumeIntake_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).ResumeIntake(ctx, req.(*ResumeIntakeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_DrainBatcher_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DrainBatcherRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).DrainBatcher(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_DrainBatcher_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).DrainBatcher(ctx, req.(*DrainBatcherRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_ResumeBatcher_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResumeBatcherRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).ResumeBatcher(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_ResumeBatcher_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).ResumeBatcher(ctx, req.(*ResumeBatcherRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_ForceBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ForceBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).ForceBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_ForceBatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).ForceBatch(ctx, req.(*ForceBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_RefreshPaymentState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RefreshPaymentStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).RefreshPaymentState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_RefreshPaymentState_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).RefreshPaymentState(ctx, req.(*RefreshPaymentStateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_GetQueueStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetQueueStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).GetQueueStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_GetQueueStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).GetQueueStats(ctx, req.(*GetQueueStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Admin_GetAccountingDivergences_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAccountingDivergencesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServer).GetAccountingDivergences(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Admin_GetAccountingDivergences_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServer).GetAccountingDivergences(ctx, req.(*GetAccountingDivergencesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Admin_ServiceDesc is the grpc.ServiceDesc for Admin service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Admin_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "admin.Admin",
+	HandlerType: (*AdminServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "PauseIntake",
+			Handler:    _Admin_PauseIntake_Handler,
+		},
+		{
+			MethodName: "ResumeIntake",
+			Handler:    _Admin_ResumeIntake_Handler,
+		},
+		{
+			MethodName: "DrainBatcher",
+			Handler:    _Admin_DrainBatcher_Handler,
+		},
+		{
+			MethodName: "ResumeBatcher",
+			Handler:    _Admin_ResumeBatcher_Handler,
+		},
+		{
+			MethodName: "ForceBatch",
+			Handler:    _Admin_ForceBatch_Handler,
+		},
+		{
+			MethodName: "RefreshPaymentState",
+			Handler:    _Admin_RefreshPaymentState_Handler,
+		},
+		{
+			MethodName: "GetQueueStats",
+			Handler:    _Admin_GetQueueStats_Handler,
+		},
+		{
+			MethodName: "GetAccountingDivergences",
+			Handler:    _Admin_GetAccountingDivergences_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "admin/admin.proto",
+}
@@ -0,0 +1,214 @@
+package apiserver
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api"
+	dispv2 "github.com/Layr-Labs/eigenda/disperser/common/v2"
+	"github.com/Layr-Labs/eigenda/disperser/common/v2/blobstore"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+)
+
+// pendingBlobsPageSize is the number of blobs read per page when counting the pending blobs of each account
+const pendingBlobsPageSize = 1000
+
+// AccountConcurrencyConfig configures the limit on the number of blobs of an account which are in flight, i.e. whose
+// dispersal requests are being processed, or which are waiting to be encoded
+type AccountConcurrencyConfig struct {
+	// DefaultMaxInFlightRequests is the number of in-flight blobs of an account above which new requests of the account
+	// are rejected. If zero, the number of in-flight blobs of accounts without overrides is unlimited.
+	DefaultMaxInFlightRequests int
+	// MaxInFlightRequestsOverrides overrides the default limit of individual accounts. An override of zero means the
+	// number of in-flight blobs of the account is unlimited.
+	MaxInFlightRequestsOverrides map[gethcommon.Address]int
+	// PendingBlobsRefreshInterval is the interval at which the number of blobs of each account waiting to be encoded
+	// is read from the metadata store. Required if any account is limited.
+	PendingBlobsRefreshInterval time.Duration
+}
+
+// PendingBlobReader reads the blobs with a given status, a page at a time
+type PendingBlobReader interface {
+	GetBlobMetadataByStatusPaginated(
+		ctx context.Context,
+		status dispv2.BlobStatus,
+		exclusiveStartKey *blobstore.StatusIndexCursor,
+		limit int32,
+	) ([]*dispv2.BlobMetadata, *blobstore.StatusIndexCursor, error)
+}
+
+// AccountConcurrencyLimiter limits the number of blobs of each account which are in flight, so that a single client
+// can't monopolize the goroutines of the API server and the encoder. A blob is in flight from the moment its dispersal
+// request is received until it leaves the Queued and Encoded statuses. This complements the payment meter, which
+// limits the rate of symbols dispersed but not the number of blobs in flight.
+//
+// The blobs waiting to be encoded are counted from the metadata store, so they include the blobs accepted by the other
+// API servers. The blobs accepted by this server since the last count are added to it.
+type AccountConcurrencyLimiter struct {
+	config AccountConcurrencyConfig
+	reader PendingBlobReader
+	logger logging.Logger
+
+	mu sync.Mutex
+	// inFlight is the number of requests being processed, by account
+	inFlight map[gethcommon.Address]int
+	// pending is the number of blobs in the Queued and Encoded statuses as of the last refresh, by account
+	pending map[gethcommon.Address]int
+	// accepted holds the times at which the blobs accepted by this server were stored, by account, for the blobs
+	// which may not have been counted by the last refresh
+	accepted map[gethcommon.Address][]time.Time
+}
+
+func NewAccountConcurrencyLimiter(
+	config AccountConcurrencyConfig,
+	reader PendingBlobReader,
+	logger logging.Logger) *AccountConcurrencyLimiter {
+
+	return &AccountConcurrencyLimiter{
+		config:   config,
+		reader:   reader,
+		logger:   logger.With("component", "AccountConcurrencyLimiter"),
+		inFlight: make(map[gethcommon.Address]int),
+		pending:  make(map[gethcommon.Address]int),
+		accepted: make(map[gethcommon.Address][]time.Time),
+	}
+}
+
+// Limited returns true if the blobs in flight of any account are limited
+func (c AccountConcurrencyConfig) Limited() bool {
+	if c.DefaultMaxInFlightRequests > 0 {
+		return true
+	}
+	for _, limit := range c.MaxInFlightRequestsOverrides {
+		if limit > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Start periodically refreshes the number of pending blobs of each account until the context is cancelled
+func (l *AccountConcurrencyLimiter) Start(ctx context.Context) {
+	if !l.config.Limited() {
+		return
+	}
+
+	if err := l.Refresh(ctx); err != nil {
+		l.logger.Error("failed to refresh pending blobs", "err", err)
+	}
+
+	go func() {
+		ticker := time.NewTicker(l.config.PendingBlobsRefreshInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				if err := l.Refresh(ctx); err != nil {
+					l.logger.Error("failed to refresh pending blobs", "err", err)
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// Refresh counts the blobs of each account in the Queued and Encoded statuses
+func (l *AccountConcurrencyLimiter) Refresh(ctx context.Context) error {
+	start := time.Now()
+	pending := make(map[gethcommon.Address]int)
+	for _, status := range []dispv2.BlobStatus{dispv2.Queued, dispv2.Encoded} {
+		var cursor *blobstore.StatusIndexCursor
+		for {
+			metadatas, next, err := l.reader.GetBlobMetadataByStatusPaginated(ctx, status, cursor, pendingBlobsPageSize)
+			if err != nil {
+				return fmt.Errorf("failed to get blobs with status %s: %w", status.String(), err)
+			}
+			for _, metadata := range metadatas {
+				pending[gethcommon.HexToAddress(metadata.BlobHeader.PaymentMetadata.AccountID)]++
+			}
+			if len(metadatas) == 0 || next == nil {
+				break
+			}
+			cursor = next
+		}
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.pending = pending
+	// the blobs stored before the count started were counted if they were still pending
+	for account, times := range l.accepted {
+		uncounted := times[:0]
+		for _, t := range times {
+			if !t.Before(start) {
+				uncounted = append(uncounted, t)
+			}
+		}
+		if len(uncounted) == 0 {
+			delete(l.accepted, account)
+		} else {
+			l.accepted[account] = uncounted
+		}
+	}
+
+	return nil
+}
+
+// maxInFlightRequests returns the limit of the account, or zero if the account is unlimited
+func (l *AccountConcurrencyLimiter) maxInFlightRequests(account gethcommon.Address) int {
+	if limit, ok := l.config.MaxInFlightRequestsOverrides[account]; ok {
+		return limit
+	}
+	return l.config.DefaultMaxInFlightRequests
+}
+
+// Acquire counts a request of the account as in flight, and returns the function to call once the request is
+// processed. It returns a ResourceExhausted error if the account already has as many blobs in flight as allowed.
+// If the blob of the request is stored, Accepted must be called before the returned function, so that the blob stays
+// in flight until it's encoded.
+func (l *AccountConcurrencyLimiter) Acquire(accountID string) (func(), error) {
+	account := gethcommon.HexToAddress(accountID)
+	limit := l.maxInFlightRequests(account)
+	if limit == 0 {
+		return func() {}, nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	inFlight := l.inFlight[account] + l.pending[account] + len(l.accepted[account])
+	if inFlight >= limit {
+		return nil, api.NewErrorResourceExhausted(
+			fmt.Sprintf("account %s has too many blobs in flight (limit %d)", account.Hex(), limit))
+	}
+	l.inFlight[account]++
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			l.mu.Lock()
+			defer l.mu.Unlock()
+			l.inFlight[account]--
+			if l.inFlight[account] == 0 {
+				delete(l.inFlight, account)
+			}
+		})
+	}, nil
+}
+
+// Accepted counts a blob of the account which was stored as in flight, until a refresh finds that it was encoded
+func (l *AccountConcurrencyLimiter) Accepted(accountID string) {
+	account := gethcommon.HexToAddress(accountID)
+	if l.maxInFlightRequests(account) == 0 {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.accepted[account] = append(l.accepted[account], time.Now())
+}
@@ -0,0 +1,151 @@
+package apiserver_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/common/testutils"
+	"github.com/Layr-Labs/eigenda/core"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/disperser/apiserver"
+	dispv2 "github.com/Layr-Labs/eigenda/disperser/common/v2"
+	"github.com/Layr-Labs/eigenda/disperser/common/v2/blobstore"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// pendingBlobs is a PendingBlobReader returning the accounts of the blobs of each status, one blob per page
+type pendingBlobs map[dispv2.BlobStatus][]string
+
+func (p pendingBlobs) GetBlobMetadataByStatusPaginated(
+	ctx context.Context,
+	status dispv2.BlobStatus,
+	exclusiveStartKey *blobstore.StatusIndexCursor,
+	limit int32,
+) ([]*dispv2.BlobMetadata, *blobstore.StatusIndexCursor, error) {
+	next := 0
+	if exclusiveStartKey != nil {
+		next = int(exclusiveStartKey.UpdatedAt)
+	}
+	if next >= len(p[status]) {
+		return nil, exclusiveStartKey, nil
+	}
+	metadata := &dispv2.BlobMetadata{
+		BlobHeader: &corev2.BlobHeader{
+			PaymentMetadata: core.PaymentMetadata{AccountID: p[status][next]},
+		},
+	}
+	return []*dispv2.BlobMetadata{metadata}, &blobstore.StatusIndexCursor{UpdatedAt: uint64(next + 1)}, nil
+}
+
+func TestAccountConcurrencyLimiter(t *testing.T) {
+	account := "0x1aa8226f6d354380dde75ee6b634875c4203e522"
+	vip := "0x0000000000000000000000000000000000000002"
+	unlimited := "0x0000000000000000000000000000000000000003"
+	limiter := apiserver.NewAccountConcurrencyLimiter(apiserver.AccountConcurrencyConfig{
+		DefaultMaxInFlightRequests: 2,
+		MaxInFlightRequestsOverrides: map[gethcommon.Address]int{
+			gethcommon.HexToAddress(vip):       3,
+			gethcommon.HexToAddress(unlimited): 0,
+		},
+	}, pendingBlobs{}, testutils.GetLogger())
+
+	release1, err := limiter.Acquire(account)
+	require.NoError(t, err)
+	// account IDs are case insensitive
+	release2, err := limiter.Acquire(gethcommon.HexToAddress(account).Hex())
+	require.NoError(t, err)
+	_, err = limiter.Acquire(account)
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	// limits are per account
+	for i := 0; i < 3; i++ {
+		_, err = limiter.Acquire(vip)
+		require.NoError(t, err)
+	}
+	_, err = limiter.Acquire(vip)
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+	for i := 0; i < 10; i++ {
+		_, err = limiter.Acquire(unlimited)
+		require.NoError(t, err)
+	}
+
+	// requests are admitted again once in-flight requests complete, and releasing twice has no effect
+	release1()
+	release1()
+	_, err = limiter.Acquire(account)
+	require.NoError(t, err)
+	_, err = limiter.Acquire(account)
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+	release2()
+	_, err = limiter.Acquire(account)
+	require.NoError(t, err)
+}
+
+func TestAccountConcurrencyLimiterDisabled(t *testing.T) {
+	limiter := apiserver.NewAccountConcurrencyLimiter(apiserver.AccountConcurrencyConfig{}, pendingBlobs{}, testutils.GetLogger())
+	for i := 0; i < 100; i++ {
+		_, err := limiter.Acquire("0x1aa8226f6d354380dde75ee6b634875c4203e522")
+		require.NoError(t, err)
+	}
+}
+
+func TestAccountConcurrencyLimiterPendingBlobs(t *testing.T) {
+	account := "0x1aa8226f6d354380dde75ee6b634875c4203e522"
+	other := "0x0000000000000000000000000000000000000002"
+	reader := pendingBlobs{}
+	limiter := apiserver.NewAccountConcurrencyLimiter(apiserver.AccountConcurrencyConfig{
+		DefaultMaxInFlightRequests: 3,
+	}, reader, testutils.GetLogger())
+
+	// blobs waiting to be encoded count against the limit of their account
+	reader[dispv2.Queued] = []string{account, other}
+	reader[dispv2.Encoded] = []string{account}
+	err := limiter.Refresh(context.Background())
+	require.NoError(t, err)
+	release, err := limiter.Acquire(account)
+	require.NoError(t, err)
+	_, err = limiter.Acquire(account)
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	// once encoded, they no longer do
+	reader[dispv2.Queued] = []string{other}
+	reader[dispv2.Encoded] = nil
+	err = limiter.Refresh(context.Background())
+	require.NoError(t, err)
+	_, err = limiter.Acquire(account)
+	require.NoError(t, err)
+	release()
+}
+
+func TestAccountConcurrencyLimiterAcceptedBlobs(t *testing.T) {
+	account := "0x1aa8226f6d354380dde75ee6b634875c4203e522"
+	reader := pendingBlobs{}
+	limiter := apiserver.NewAccountConcurrencyLimiter(apiserver.AccountConcurrencyConfig{
+		DefaultMaxInFlightRequests: 1,
+	}, reader, testutils.GetLogger())
+
+	// a blob which is accepted stays in flight after its request completes
+	release, err := limiter.Acquire(account)
+	require.NoError(t, err)
+	limiter.Accepted(account)
+	release()
+	_, err = limiter.Acquire(account)
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	// while it's waiting to be encoded, it's counted by the refresh instead
+	reader[dispv2.Queued] = []string{account}
+	err = limiter.Refresh(context.Background())
+	require.NoError(t, err)
+	_, err = limiter.Acquire(account)
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	// once it's encoded, it's no longer in flight
+	reader[dispv2.Queued] = nil
+	err = limiter.Refresh(context.Background())
+	require.NoError(t, err)
+	_, err = limiter.Acquire(account)
+	require.NoError(t, err)
+}
@@ -0,0 +1,172 @@
+package apiserver
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api"
+	dispv2 "github.com/Layr-Labs/eigenda/disperser/common/v2"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+const (
+	// minRetryAfter and maxRetryAfter bound the retry delay suggested to clients when the encoding queue is full
+	minRetryAfter = 1 * time.Second
+	maxRetryAfter = 5 * time.Minute
+)
+
+// BackpressureConfig configures admission control based on the depth of the encoding queue
+type BackpressureConfig struct {
+	// MaxQueuedBlobs is the number of blobs waiting to be encoded above which new dispersal requests are rejected.
+	// If zero, requests are never rejected because of the depth of the encoding queue.
+	MaxQueuedBlobs int32
+	// QueueDepthRefreshInterval is the interval at which the depth of the encoding queue is refreshed
+	QueueDepthRefreshInterval time.Duration
+}
+
+// QueueDepthReader reads the number of blobs with a given status
+type QueueDepthReader interface {
+	GetBlobMetadataCountByStatus(ctx context.Context, status dispv2.BlobStatus) (int32, error)
+}
+
+// EncodingBackpressure tracks the depth of the encoding queue, i.e. the number of blobs which are queued but not yet
+// encoded, along with the rate at which the encoder drains it. Once the queue is deeper than the configured limit,
+// new dispersal requests are rejected with a retry delay derived from the time the encoder needs to catch up, rather
+// than accepting blobs which would time out waiting to be encoded.
+type EncodingBackpressure struct {
+	config BackpressureConfig
+	reader QueueDepthReader
+	logger logging.Logger
+
+	mu sync.Mutex
+	// queueDepth is the number of queued blobs as of the last refresh, plus the blobs admitted since
+	queueDepth int32
+	// refreshes is the number of successful refreshes, so that admissions made before the last refresh aren't cancelled
+	// from the depth it read
+	refreshes uint64
+	// drainRate is the observed number of blobs encoded per second
+	drainRate float64
+	// lastRefresh is the time of the last successful refresh. Zero if the queue depth hasn't been read yet.
+	lastRefresh time.Time
+}
+
+func NewEncodingBackpressure(config BackpressureConfig, reader QueueDepthReader, logger logging.Logger) *EncodingBackpressure {
+	return &EncodingBackpressure{
+		config: config,
+		reader: reader,
+		logger: logger.With("component", "EncodingBackpressure"),
+	}
+}
+
+// Enabled returns true if requests may be rejected because of the depth of the encoding queue
+func (b *EncodingBackpressure) Enabled() bool {
+	return b.config.MaxQueuedBlobs > 0
+}
+
+// Start periodically refreshes the depth of the encoding queue until the context is cancelled
+func (b *EncodingBackpressure) Start(ctx context.Context) {
+	if !b.Enabled() {
+		return
+	}
+
+	if err := b.Refresh(ctx); err != nil {
+		b.logger.Error("failed to refresh encoding queue depth", "err", err)
+	}
+
+	go func() {
+		ticker := time.NewTicker(b.config.QueueDepthRefreshInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				if err := b.Refresh(ctx); err != nil {
+					b.logger.Error("failed to refresh encoding queue depth", "err", err)
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// Refresh reads the current depth of the encoding queue, and updates the observed drain rate
+func (b *EncodingBackpressure) Refresh(ctx context.Context) error {
+	depth, err := b.reader.GetBlobMetadataCountByStatus(ctx, dispv2.Queued)
+	if err != nil {
+		return fmt.Errorf("failed to get number of queued blobs: %w", err)
+	}
+	now := time.Now()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !b.lastRefresh.IsZero() {
+		// queueDepth includes the blobs admitted since the last refresh, so any shortfall was picked up by the encoder
+		drained := b.queueDepth - depth
+		elapsed := now.Sub(b.lastRefresh).Seconds()
+		if drained > 0 && elapsed > 0 {
+			b.drainRate = float64(drained) / elapsed
+		}
+	}
+
+	b.queueDepth = depth
+	b.refreshes++
+	b.lastRefresh = now
+	b.logger.Debug("refreshed encoding queue depth", "queueDepth", depth, "drainRate", b.drainRate)
+
+	return nil
+}
+
+// Admit returns a ResourceExhausted error carrying a retry delay if the encoding queue is full. Otherwise it counts
+// the request towards the queue depth, and returns the function to call if the blob of the request isn't queued after
+// all, e.g. because the request is rejected by the payment meter.
+func (b *EncodingBackpressure) Admit() (func(), error) {
+	if !b.Enabled() {
+		return func() {}, nil
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.queueDepth >= b.config.MaxQueuedBlobs {
+		retryAfter := b.retryAfter()
+		return nil, api.NewErrorResourceExhaustedWithRetryAfter(
+			fmt.Sprintf("encoding queue is full (%d blobs queued), retry after %v", b.queueDepth, retryAfter),
+			retryAfter)
+	}
+
+	b.queueDepth++
+	refreshes := b.refreshes
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+			// a refresh since the admission read the depth without the blob, which was never queued
+			if b.refreshes == refreshes {
+				b.queueDepth--
+			}
+		})
+	}, nil
+}
+
+// retryAfter estimates how long the encoder needs to drain the queue below its limit.
+// Must be called with the lock held.
+func (b *EncodingBackpressure) retryAfter() time.Duration {
+	if b.drainRate <= 0 {
+		// The encoder hasn't been observed draining the queue yet, so check back after the next refresh
+		return min(max(b.config.QueueDepthRefreshInterval, minRetryAfter), maxRetryAfter)
+	}
+
+	excess := float64(b.queueDepth - b.config.MaxQueuedBlobs + 1)
+	seconds := math.Ceil(excess / b.drainRate)
+	if seconds > maxRetryAfter.Seconds() {
+		return maxRetryAfter
+	}
+	return max(time.Duration(seconds)*time.Second, minRetryAfter)
+}
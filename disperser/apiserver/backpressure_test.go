@@ -0,0 +1,106 @@
+package apiserver_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api"
+	"github.com/Layr-Labs/eigenda/common/testutils"
+	"github.com/Layr-Labs/eigenda/disperser/apiserver"
+	dispv2 "github.com/Layr-Labs/eigenda/disperser/common/v2"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type mockQueueDepthReader struct {
+	depth int32
+}
+
+func (r *mockQueueDepthReader) GetBlobMetadataCountByStatus(ctx context.Context, blobStatus dispv2.BlobStatus) (int32, error) {
+	return r.depth, nil
+}
+
+func admit(t *testing.T, backpressure *apiserver.EncodingBackpressure) func() {
+	cancel, err := backpressure.Admit()
+	require.NoError(t, err)
+	return cancel
+}
+
+func TestEncodingBackpressure(t *testing.T) {
+	reader := &mockQueueDepthReader{depth: 8}
+	backpressure := apiserver.NewEncodingBackpressure(apiserver.BackpressureConfig{
+		MaxQueuedBlobs:            10,
+		QueueDepthRefreshInterval: 5 * time.Second,
+	}, reader, testutils.GetLogger())
+	require.True(t, backpressure.Enabled())
+	require.NoError(t, backpressure.Refresh(context.Background()))
+
+	// Two more blobs fit in the queue
+	admit(t, backpressure)
+	admit(t, backpressure)
+
+	// The queue is full, and the encoder hasn't been observed draining it yet
+	_, err := backpressure.Admit()
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+	retryAfter, ok := api.RetryAfter(err)
+	require.True(t, ok)
+	require.Equal(t, 5*time.Second, retryAfter)
+
+	// The encoder drains a blob from the queue, which makes room for one more
+	reader.depth = 9
+	time.Sleep(10 * time.Millisecond)
+	require.NoError(t, backpressure.Refresh(context.Background()))
+	admit(t, backpressure)
+
+	// Now that the drain rate is known, the retry delay is based on how long the encoder needs to catch up
+	_, err = backpressure.Admit()
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+	retryAfter, ok = api.RetryAfter(err)
+	require.True(t, ok)
+	require.Equal(t, time.Second, retryAfter)
+
+	// The queue has room again
+	reader.depth = 5
+	require.NoError(t, backpressure.Refresh(context.Background()))
+	admit(t, backpressure)
+}
+
+func TestEncodingBackpressureDisabled(t *testing.T) {
+	reader := &mockQueueDepthReader{depth: 1000}
+	backpressure := apiserver.NewEncodingBackpressure(apiserver.BackpressureConfig{}, reader, testutils.GetLogger())
+	require.False(t, backpressure.Enabled())
+	require.NoError(t, backpressure.Refresh(context.Background()))
+	admit(t, backpressure)
+}
+
+func TestEncodingBackpressureCancelledAdmission(t *testing.T) {
+	reader := &mockQueueDepthReader{depth: 9}
+	backpressure := apiserver.NewEncodingBackpressure(apiserver.BackpressureConfig{
+		MaxQueuedBlobs:            10,
+		QueueDepthRefreshInterval: 5 * time.Second,
+	}, reader, testutils.GetLogger())
+	require.NoError(t, backpressure.Refresh(context.Background()))
+
+	// A blob which isn't queued after all gives its place in the queue back, and cancelling twice has no effect
+	cancel := admit(t, backpressure)
+	_, err := backpressure.Admit()
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+	cancel()
+	cancel()
+	admit(t, backpressure)
+	_, err = backpressure.Admit()
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	// Admissions made before a refresh are already excluded from the depth it read
+	reader.depth = 8
+	require.NoError(t, backpressure.Refresh(context.Background()))
+	cancel = admit(t, backpressure)
+	require.NoError(t, backpressure.Refresh(context.Background()))
+	cancel()
+	admit(t, backpressure)
+	admit(t, backpressure)
+	_, err = backpressure.Admit()
+	require.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
@@ -0,0 +1,254 @@
+package apiserver
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api"
+	pb "github.com/Layr-Labs/eigenda/api/grpc/disperser/v2"
+	"github.com/Layr-Labs/eigenda/api/hashing"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	dispcommon "github.com/Layr-Labs/eigenda/disperser/common"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	lru "github.com/hashicorp/golang-lru/v2"
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc/metadata"
+)
+
+// The gRPC metadata keys with which the account which dispersed a blob authenticates a CheckBlobIntegrity request of
+// the blob. Only that account can check the integrity of the blob.
+const (
+	// BlobIntegrityTimestampHeader holds the Unix time in nanoseconds at which the request was signed.
+	BlobIntegrityTimestampHeader = "blob-integrity-timestamp"
+	// BlobIntegritySignatureHeader holds the hex encoded ECDSA signature of the account over the hash of the request,
+	// computed with hashing.HashBlobIntegrityRequest.
+	BlobIntegritySignatureHeader = "blob-integrity-signature"
+)
+
+// BlobIntegrityConfig configures the authentication and the rate limits of CheckBlobIntegrity, each call of which
+// re-reads a blob, recomputes its commitment and signs an attestation.
+type BlobIntegrityConfig struct {
+	// MaxSignatureAge is the maximum difference between the timestamp of a signed request and the current time, which
+	// limits the time during which a signed request can be replayed.
+	MaxSignatureAge time.Duration
+	// AccountChecksPerSecond is the rate at which each account may check the integrity of its blobs.
+	AccountChecksPerSecond float64
+	// AccountBurst is the number of checks an account may make at once.
+	AccountBurst int
+	// MaxConcurrentChecks is the number of integrity checks processed concurrently across all the accounts, above
+	// which new checks are rejected. If zero, the number of concurrent checks is unlimited.
+	MaxConcurrentChecks int
+}
+
+// Validate checks that the config is consistent.
+func (c *BlobIntegrityConfig) Validate() error {
+	if c.MaxSignatureAge <= 0 {
+		return fmt.Errorf("blob integrity max signature age must be positive, got %s", c.MaxSignatureAge)
+	}
+	if c.AccountChecksPerSecond <= 0 || c.AccountBurst <= 0 {
+		return errors.New("blob integrity checks per second and burst of accounts must be positive")
+	}
+	if c.MaxConcurrentChecks < 0 {
+		return fmt.Errorf("max concurrent blob integrity checks must not be negative, got %d", c.MaxConcurrentChecks)
+	}
+	return nil
+}
+
+// blobIntegrityLimiter limits the rate of the integrity checks of each account, and the number of checks processed
+// concurrently. This object is thread safe.
+type blobIntegrityLimiter struct {
+	config BlobIntegrityConfig
+	// accounts holds the rate limiters of the accounts which checked their blobs recently
+	accounts *lru.Cache[gethcommon.Address, *rate.Limiter]
+	// inFlight is the number of checks being processed
+	inFlight atomic.Int32
+}
+
+// blobIntegrityLimiterCacheSize is the number of accounts whose rate limiters are kept
+const blobIntegrityLimiterCacheSize = 10_000
+
+func newBlobIntegrityLimiter(config BlobIntegrityConfig) (*blobIntegrityLimiter, error) {
+	accounts, err := lru.New[gethcommon.Address, *rate.Limiter](blobIntegrityLimiterCacheSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the account cache: %w", err)
+	}
+	return &blobIntegrityLimiter{
+		config:   config,
+		accounts: accounts,
+	}, nil
+}
+
+// acquire checks the rate limit of the account and the number of concurrent checks, and returns the function to call
+// once the check is processed.
+func (l *blobIntegrityLimiter) acquire(account gethcommon.Address) (func(), error) {
+	limiter, ok := l.accounts.Get(account)
+	if !ok {
+		limiter = rate.NewLimiter(rate.Limit(l.config.AccountChecksPerSecond), l.config.AccountBurst)
+		// another request of the account may have added a limiter concurrently
+		if previous, found, _ := l.accounts.PeekOrAdd(account, limiter); found {
+			limiter = previous
+		}
+	}
+	if !limiter.Allow() {
+		return nil, api.NewErrorResourceExhausted(
+			fmt.Sprintf("account %s is limited to %v blob integrity checks per second",
+				account.Hex(), l.config.AccountChecksPerSecond))
+	}
+
+	inFlight := l.inFlight.Add(1)
+	if l.config.MaxConcurrentChecks > 0 && inFlight > int32(l.config.MaxConcurrentChecks) {
+		l.inFlight.Add(-1)
+		return nil, api.NewErrorResourceExhausted("too many blob integrity checks in flight")
+	}
+	return func() {
+		l.inFlight.Add(-1)
+	}, nil
+}
+
+// authenticateBlobIntegrityRequest checks that a CheckBlobIntegrity request of the blob was recently signed by the
+// given account.
+func authenticateBlobIntegrityRequest(
+	ctx context.Context,
+	blobKey corev2.BlobKey,
+	account gethcommon.Address,
+	maxSignatureAge time.Duration,
+	now time.Time,
+) error {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return errors.New("missing request signature")
+	}
+	timestamps := md.Get(BlobIntegrityTimestampHeader)
+	signatures := md.Get(BlobIntegritySignatureHeader)
+	if len(timestamps) != 1 || len(signatures) != 1 {
+		return fmt.Errorf("exactly one %s and %s header must be provided",
+			BlobIntegrityTimestampHeader, BlobIntegritySignatureHeader)
+	}
+
+	timestamp, err := strconv.ParseInt(timestamps[0], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid timestamp: %w", err)
+	}
+	age := now.Sub(time.Unix(0, timestamp))
+	if age > maxSignatureAge || age < -maxSignatureAge {
+		return fmt.Errorf("request timestamp is %s away from the current time, max is %s", age, maxSignatureAge)
+	}
+
+	signature, err := hex.DecodeString(signatures[0])
+	if err != nil {
+		return fmt.Errorf("invalid signature encoding: %w", err)
+	}
+	if len(signature) != 65 {
+		return fmt.Errorf("signature length is unexpected: %d", len(signature))
+	}
+	publicKey, err := crypto.SigToPub(hashing.HashBlobIntegrityRequest(blobKey[:], timestamp), signature)
+	if err != nil {
+		return fmt.Errorf("failed to recover public key from signature: %w", err)
+	}
+	if crypto.PubkeyToAddress(*publicKey) != account {
+		return fmt.Errorf("request isn't signed by the account %s which dispersed the blob", account.Hex())
+	}
+	return nil
+}
+
+// BlobIntegritySigner signs the attestations returned by CheckBlobIntegrity
+type BlobIntegritySigner interface {
+	// SignBlobIntegrityAttestation signs the keccak hash of the attestation
+	SignBlobIntegrityAttestation(ctx context.Context, attestation *pb.BlobIntegrityAttestation) ([]byte, error)
+}
+
+// NewKMSBlobIntegritySigner creates a BlobIntegritySigner which signs with the given AWS KMS key
+func NewKMSBlobIntegritySigner(ctx context.Context, region string, endpoint string, keyID string) (BlobIntegritySigner, error) {
+	return newKMSSigner(ctx, region, endpoint, keyID)
+}
+
+// CheckBlobIntegrity re-reads the stored blob, recomputes its commitment, and compares it against the commitment in the
+// stored blob header. A blob which is missing from the blob store, or from which no commitment can be computed, is
+// attested as not intact. The request must be signed by the account which dispersed the blob, and is rate limited per
+// account.
+func (s *DispersalServerV2) CheckBlobIntegrity(ctx context.Context, req *pb.CheckBlobIntegrityRequest) (*pb.CheckBlobIntegrityReply, error) {
+	start := time.Now()
+	defer func() {
+		s.metrics.reportCheckBlobIntegrityLatency(time.Since(start))
+	}()
+
+	if s.blobIntegritySigner == nil {
+		return nil, api.NewErrorUnimplemented()
+	}
+	if len(req.GetBlobKey()) != 32 {
+		return nil, api.NewErrorInvalidArg("blob key must be present and with 32 bytes")
+	}
+	blobKey, err := corev2.BytesToBlobKey(req.GetBlobKey())
+	if err != nil {
+		return nil, api.NewErrorInvalidArg(fmt.Sprintf("failed to parse the blob key bytes: %v", err))
+	}
+
+	metadata, err := s.blobMetadataStore.GetBlobMetadata(ctx, blobKey)
+	if err != nil {
+		if errors.Is(err, dispcommon.ErrMetadataNotFound) {
+			return nil, api.NewErrorNotFound(fmt.Sprintf("blob metadata not found for blob key: %s", blobKey.Hex()))
+		}
+		return nil, api.NewErrorInternal(fmt.Sprintf("failed to get blob metadata: %v", err))
+	}
+
+	account := gethcommon.HexToAddress(metadata.BlobHeader.PaymentMetadata.AccountID)
+	err = authenticateBlobIntegrityRequest(ctx, blobKey, account, s.blobIntegrityLimiter.config.MaxSignatureAge, time.Now())
+	if err != nil {
+		return nil, api.NewErrorUnauthenticated(fmt.Sprintf("failed to authenticate the request: %v", err))
+	}
+	release, err := s.blobIntegrityLimiter.acquire(account)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	headerCommitment, err := metadata.BlobHeader.BlobCommitments.ToProtobuf()
+	if err != nil {
+		return nil, api.NewErrorInternal(fmt.Sprintf("failed to serialize the commitment in the blob header: %v", err))
+	}
+
+	attestation := &pb.BlobIntegrityAttestation{
+		BlobKey:          blobKey[:],
+		HeaderCommitment: headerCommitment,
+	}
+
+	blob, err := s.blobStore.GetBlob(ctx, blobKey)
+	switch {
+	case errors.Is(err, dispcommon.ErrBlobNotFound):
+		s.logger.Warn("integrity check found blob missing from blob store", "blobKey", blobKey.Hex())
+	case err != nil:
+		return nil, api.NewErrorInternal(fmt.Sprintf("failed to get blob: %v", err))
+	default:
+		commitments, err := s.prover.GetCommitmentsForPaddedLength(blob)
+		if err != nil {
+			s.logger.Warn("integrity check failed to compute commitment of stored blob", "blobKey", blobKey.Hex(), "err", err)
+			break
+		}
+		computedCommitment, err := commitments.ToProtobuf()
+		if err != nil {
+			return nil, api.NewErrorInternal(fmt.Sprintf("failed to serialize the computed commitment: %v", err))
+		}
+		attestation.ComputedCommitment = computedCommitment
+		attestation.Intact = commitments.Equal(&metadata.BlobHeader.BlobCommitments)
+		if !attestation.Intact {
+			s.logger.Warn("integrity check found commitment of stored blob does not match blob header", "blobKey", blobKey.Hex())
+		}
+	}
+	attestation.CheckedAt = uint64(time.Now().UnixNano())
+
+	signature, err := s.blobIntegritySigner.SignBlobIntegrityAttestation(ctx, attestation)
+	if err != nil {
+		return nil, api.NewErrorInternal(fmt.Sprintf("failed to sign the integrity attestation: %v", err))
+	}
+
+	return &pb.CheckBlobIntegrityReply{
+		Attestation: attestation,
+		Signature:   signature,
+	}, nil
+}
@@ -0,0 +1,467 @@
+package apiserver
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api"
+	pb "github.com/Layr-Labs/eigenda/api/grpc/disperser/v2"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	dispv2 "github.com/Layr-Labs/eigenda/disperser/common/v2"
+	"github.com/Layr-Labs/eigenda/disperser/common/v2/blobstore"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+const (
+	// maxCertificationEventsPerRead is the page size of the reads of attestations and certified blobs from the
+	// metadata store
+	maxCertificationEventsPerRead = 1000
+	// certificationEventDelay is how far behind the current time events are read from the metadata store. Records are
+	// timestamped before they're written, so this gives writes in flight time to land before the cursor moves past them.
+	certificationEventDelay = 2 * time.Second
+	// certificationEventPollTimeout bounds the time spent reading events from the metadata store in a single poll
+	certificationEventPollTimeout = 30 * time.Second
+	// certificationEventBufferSize is the number of events buffered for a subscriber. Subscribers which fall further
+	// behind are disconnected, and may resume from the timestamp of the last event they received.
+	certificationEventBufferSize = 1000
+	// maxConcurrentCertificationEventReplays is the maximum number of subscriptions concurrently reading past events
+	// from the metadata store
+	maxConcurrentCertificationEventReplays = 4
+)
+
+// CertificationEventsConfig configures the stream of certification events
+type CertificationEventsConfig struct {
+	// MaxSubscribers is the maximum number of concurrent subscriptions to certification events.
+	// If zero, subscribing to certification events is disabled.
+	MaxSubscribers int32
+	// PollInterval is the interval at which the metadata store is polled for new events. A single poller is shared by
+	// all the subscriptions.
+	PollInterval time.Duration
+	// ReplayWindow is how far in the past a subscription may start
+	ReplayWindow time.Duration
+}
+
+// readCertificationEventsFunc reads the events with a timestamp in (after, until] from the metadata store, ordered by
+// time. It may stop early, and returns the timestamp up to which all events were read.
+type readCertificationEventsFunc func(ctx context.Context, after uint64, until uint64) ([]*pb.CertificationEvent, uint64, error)
+
+// certificationEventSubscriber receives the events read by a certificationEventBroadcaster
+type certificationEventSubscriber struct {
+	events chan *pb.CertificationEvent
+	// dropped is closed when the subscriber falls too far behind and is unsubscribed
+	dropped chan struct{}
+}
+
+// certificationEventBroadcaster polls the metadata store for certification events on behalf of all the subscriptions,
+// and fans the events out to them. The poller runs while there is at least one subscriber.
+type certificationEventBroadcaster struct {
+	pollInterval time.Duration
+	read         readCertificationEventsFunc
+	logger       logging.Logger
+
+	lock        sync.Mutex
+	subscribers map[*certificationEventSubscriber]struct{}
+	running     bool
+	// position is the timestamp up to which events have been read and sent to the subscribers
+	position uint64
+}
+
+func newCertificationEventBroadcaster(
+	pollInterval time.Duration,
+	read readCertificationEventsFunc,
+	logger logging.Logger) *certificationEventBroadcaster {
+
+	return &certificationEventBroadcaster{
+		pollInterval: pollInterval,
+		read:         read,
+		logger:       logger,
+		subscribers:  make(map[*certificationEventSubscriber]struct{}),
+	}
+}
+
+// subscribe registers a subscriber, which receives the events with a timestamp after the returned position
+func (b *certificationEventBroadcaster) subscribe() (*certificationEventSubscriber, uint64) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	subscriber := &certificationEventSubscriber{
+		events:  make(chan *pb.CertificationEvent, certificationEventBufferSize),
+		dropped: make(chan struct{}),
+	}
+	b.subscribers[subscriber] = struct{}{}
+	if !b.running {
+		b.running = true
+		b.position = certificationEventHorizon()
+		go b.run()
+	}
+	return subscriber, b.position
+}
+
+func (b *certificationEventBroadcaster) unsubscribe(subscriber *certificationEventSubscriber) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	delete(b.subscribers, subscriber)
+}
+
+// run polls the metadata store until there are no subscribers left
+func (b *certificationEventBroadcaster) run() {
+	ticker := time.NewTicker(b.pollInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		b.lock.Lock()
+		if len(b.subscribers) == 0 {
+			b.running = false
+			b.lock.Unlock()
+			return
+		}
+		after := b.position
+		b.lock.Unlock()
+
+		ctx, cancel := context.WithTimeout(context.Background(), certificationEventPollTimeout)
+		events, position, err := b.read(ctx, after, certificationEventHorizon())
+		cancel()
+		if err != nil {
+			// the position only moves past events which were read, so they're retried on the next poll
+			b.logger.Warn("failed to poll certification events", "err", err)
+			continue
+		}
+		b.broadcast(events, position)
+	}
+}
+
+// broadcast sends the events to the subscribers, and disconnects the subscribers which can't keep up
+func (b *certificationEventBroadcaster) broadcast(events []*pb.CertificationEvent, position uint64) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	b.position = position
+	for subscriber := range b.subscribers {
+		for _, event := range events {
+			select {
+			case subscriber.events <- event:
+				continue
+			default:
+			}
+			delete(b.subscribers, subscriber)
+			close(subscriber.dropped)
+			break
+		}
+	}
+}
+
+// certificationEventHorizon returns the timestamp up to which events may be read from the metadata store
+func certificationEventHorizon() uint64 {
+	return uint64(time.Now().Add(-certificationEventDelay).UnixNano())
+}
+
+// SubscribeCertificationEvents streams an event whenever a batch is certified, and whenever a blob becomes certified.
+// Events which occurred before the subscription are read from the metadata store, after which the subscriber receives
+// the events read by the poller shared by all subscriptions. A subscriber can resume from the timestamp of the last
+// event it received.
+func (s *DispersalServerV2) SubscribeCertificationEvents(req *pb.SubscribeCertificationEventsRequest, stream pb.Disperser_SubscribeCertificationEventsServer) error {
+	if s.certificationEventsConfig.MaxSubscribers <= 0 {
+		return api.NewErrorUnimplemented()
+	}
+
+	now := time.Now()
+	start := uint64(now.UnixNano())
+	if req.GetStartTimestamp() != 0 {
+		startTime := time.Unix(0, int64(req.GetStartTimestamp()))
+		if startTime.After(now) {
+			return api.NewErrorInvalidArg("start timestamp is in the future")
+		}
+		if now.Sub(startTime) > s.certificationEventsConfig.ReplayWindow {
+			return api.NewErrorInvalidArg(fmt.Sprintf("start timestamp is older than the replay window of %v", s.certificationEventsConfig.ReplayWindow))
+		}
+		start = req.GetStartTimestamp()
+	}
+
+	if s.certificationEventSubscribers.Add(1) > s.certificationEventsConfig.MaxSubscribers {
+		s.certificationEventSubscribers.Add(-1)
+		return api.NewErrorResourceExhausted("too many subscribers to certification events")
+	}
+	defer s.certificationEventSubscribers.Add(-1)
+
+	ctx := stream.Context()
+	subscriber, position := s.certificationEvents.subscribe()
+	defer s.certificationEvents.unsubscribe(subscriber)
+
+	if start < position {
+		select {
+		case s.certificationEventReplays <- struct{}{}:
+		case <-ctx.Done():
+			return nil
+		}
+		err := s.replayCertificationEvents(ctx, stream, start, position)
+		<-s.certificationEventReplays
+		if err != nil {
+			return err
+		}
+		start = position
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-subscriber.dropped:
+			return api.NewErrorResourceExhausted("subscriber fell too far behind the certification events")
+		case event := <-subscriber.events:
+			if event.GetTimestamp() <= start {
+				continue
+			}
+			if err := stream.Send(event); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// replayCertificationEvents sends the events with a timestamp in (after, until] to the stream
+func (s *DispersalServerV2) replayCertificationEvents(ctx context.Context, stream pb.Disperser_SubscribeCertificationEventsServer, after uint64, until uint64) error {
+	for after < until {
+		events, position, err := s.readCertificationEvents(ctx, after, until)
+		if err != nil {
+			s.logger.Warn("failed to read past certification events", "err", err)
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(s.certificationEventsConfig.PollInterval):
+			}
+			continue
+		}
+		for _, event := range events {
+			if err := stream.Send(event); err != nil {
+				return err
+			}
+		}
+		after = position
+	}
+	return nil
+}
+
+// readCertificationEvents reads the events with a timestamp in (after, until] from the metadata store, ordered by
+// time. At most a page of attestations and a page of certified blobs are read, and the returned timestamp is the one up
+// to which all events were read.
+func (s *DispersalServerV2) readCertificationEvents(ctx context.Context, after uint64, until uint64) ([]*pb.CertificationEvent, uint64, error) {
+	if after >= until {
+		return nil, after, nil
+	}
+
+	attestations, attestedUntil, err := s.readAttestations(ctx, after, until)
+	if err != nil {
+		return nil, after, fmt.Errorf("failed to get attestations: %w", err)
+	}
+	metadatas, certifiedUntil, err := s.readCertifiedBlobs(ctx, after, until)
+	if err != nil {
+		return nil, after, fmt.Errorf("failed to get certified blobs: %w", err)
+	}
+
+	// the events are only complete up to the earlier of the two timestamps
+	position := min(attestedUntil, certifiedUntil)
+	events := make([]*pb.CertificationEvent, 0, len(attestations)+len(metadatas))
+	for _, attestation := range attestations {
+		// The dispatcher stores an empty attestation while it gathers signatures, which is replaced by the
+		// aggregated attestation with a later timestamp
+		if attestation.AttestedAt > position || len(attestation.QuorumNumbers) == 0 {
+			continue
+		}
+		attestationProto, err := attestation.ToProtobuf()
+		if err != nil {
+			s.logger.Error("failed to convert attestation to protobuf", "err", err)
+			continue
+		}
+		events = append(events, &pb.CertificationEvent{
+			Timestamp: attestation.AttestedAt,
+			Event: &pb.CertificationEvent_BatchCertified{
+				BatchCertified: &pb.BatchCertifiedEvent{
+					SignedBatch: &pb.SignedBatch{
+						Header:      attestation.BatchHeader.ToProtobuf(),
+						Attestation: attestationProto,
+					},
+				},
+			},
+		})
+	}
+
+	certified := make([]*dispv2.BlobMetadata, 0, len(metadatas))
+	for _, metadata := range metadatas {
+		if metadata.UpdatedAt <= position {
+			certified = append(certified, metadata)
+		}
+	}
+	blobEvents, err := s.blobCertifiedEvents(ctx, certified)
+	if err != nil {
+		return nil, after, err
+	}
+	events = append(events, blobEvents...)
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].GetTimestamp() < events[j].GetTimestamp()
+	})
+	return events, position, nil
+}
+
+// readAttestations reads a page of the attestations with a timestamp in (after, until], and returns the timestamp up to
+// which all attestations were read. Attestations sharing a timestamp are never split across pages.
+func (s *DispersalServerV2) readAttestations(ctx context.Context, after uint64, until uint64) ([]*corev2.Attestation, uint64, error) {
+	attestations, err := s.blobMetadataStore.GetAttestationByAttestedAtForward(ctx, after, until+1, maxCertificationEventsPerRead)
+	if err != nil {
+		return nil, after, err
+	}
+	if len(attestations) < maxCertificationEventsPerRead {
+		return attestations, until, nil
+	}
+
+	// drop the attestations sharing the last timestamp, since some of them may be on the next page
+	last := attestations[len(attestations)-1].AttestedAt
+	complete := len(attestations)
+	for complete > 0 && attestations[complete-1].AttestedAt == last {
+		complete--
+	}
+	if complete > 0 {
+		return attestations[:complete], last - 1, nil
+	}
+
+	// the whole page shares a timestamp, so all the attestations with that timestamp are read at once
+	attestations, err = s.blobMetadataStore.GetAttestationByAttestedAtForward(ctx, last-1, last+1, 0)
+	if err != nil {
+		return nil, after, err
+	}
+	return attestations, last, nil
+}
+
+// readCertifiedBlobs reads the certified blobs with a timestamp in (after, until] from the status index, and returns the
+// timestamp up to which all certified blobs were read. Pages are read from the exclusive start key of the previous page
+// until at least one complete timestamp is read, so blobs sharing a timestamp are never split across reads.
+func (s *DispersalServerV2) readCertifiedBlobs(ctx context.Context, after uint64, until uint64) ([]*dispv2.BlobMetadata, uint64, error) {
+	cursor := &blobstore.StatusIndexCursor{UpdatedAt: after}
+	metadatas := make([]*dispv2.BlobMetadata, 0)
+	for {
+		page, next, err := s.blobMetadataStore.GetBlobMetadataByStatusPaginated(ctx, dispv2.Complete, cursor, maxCertificationEventsPerRead)
+		if err != nil {
+			return nil, after, err
+		}
+		for _, metadata := range page {
+			if metadata.UpdatedAt <= after {
+				continue
+			}
+			if metadata.UpdatedAt > until {
+				return metadatas, until, nil
+			}
+			metadatas = append(metadatas, metadata)
+		}
+		if len(page) == 0 || next == nil {
+			return metadatas, until, nil
+		}
+		cursor = next
+
+		// drop the blobs sharing the last timestamp, since some of them may be on the next page
+		if len(metadatas) > 0 && metadatas[0].UpdatedAt != metadatas[len(metadatas)-1].UpdatedAt {
+			last := metadatas[len(metadatas)-1].UpdatedAt
+			complete := len(metadatas)
+			for metadatas[complete-1].UpdatedAt == last {
+				complete--
+			}
+			return metadatas[:complete], last - 1, nil
+		}
+	}
+}
+
+// blobCertifiedEvents builds the events of the certified blobs. The certificates of the blobs are read at once, and the
+// signed batch of each batch is read once.
+func (s *DispersalServerV2) blobCertifiedEvents(ctx context.Context, metadatas []*dispv2.BlobMetadata) ([]*pb.CertificationEvent, error) {
+	if len(metadatas) == 0 {
+		return nil, nil
+	}
+
+	blobKeys := make([]corev2.BlobKey, 0, len(metadatas))
+	updatedAt := make(map[corev2.BlobKey]uint64, len(metadatas))
+	for _, metadata := range metadatas {
+		blobKey, err := metadata.BlobHeader.BlobKey()
+		if err != nil {
+			s.logger.Error("failed to get blob key", "err", err)
+			continue
+		}
+		blobKeys = append(blobKeys, blobKey)
+		updatedAt[blobKey] = metadata.UpdatedAt
+	}
+
+	certs, _, err := s.blobMetadataStore.GetBlobCertificates(ctx, blobKeys)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get certificates of certified blobs: %w", err)
+	}
+	certsByKey := make(map[corev2.BlobKey]*corev2.BlobCertificate, len(certs))
+	for _, cert := range certs {
+		blobKey, err := cert.BlobHeader.BlobKey()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get blob key of certificate: %w", err)
+		}
+		certsByKey[blobKey] = cert
+	}
+
+	signedBatches := make(map[[32]byte]*pb.SignedBatch)
+	events := make([]*pb.CertificationEvent, 0, len(blobKeys))
+	for _, blobKey := range blobKeys {
+		cert, ok := certsByKey[blobKey]
+		if !ok {
+			return nil, fmt.Errorf("no certificate found for certified blob %s", blobKey.Hex())
+		}
+		inclusionInfos, err := s.blobMetadataStore.GetBlobInclusionInfos(ctx, blobKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get inclusion info of certified blob %s: %w", blobKey.Hex(), err)
+		}
+
+		var event *pb.CertificationEvent
+		for _, inclusionInfo := range inclusionInfos {
+			batchHeaderHash, err := inclusionInfo.BatchHeader.Hash()
+			if err != nil {
+				s.logger.Error("failed to get batch header hash from blob inclusion info", "err", err, "blobKey", blobKey.Hex())
+				continue
+			}
+			signedBatch, ok := signedBatches[batchHeaderHash]
+			if !ok {
+				batchHeader, attestation, err := s.blobMetadataStore.GetSignedBatch(ctx, batchHeaderHash)
+				if err != nil {
+					s.logger.Error("failed to get signed batch", "err", err, "blobKey", blobKey.Hex())
+					continue
+				}
+				attestationProto, err := attestation.ToProtobuf()
+				if err != nil {
+					s.logger.Error("failed to convert attestation to protobuf", "err", err, "blobKey", blobKey.Hex())
+					continue
+				}
+				signedBatch = &pb.SignedBatch{
+					Header:      batchHeader.ToProtobuf(),
+					Attestation: attestationProto,
+				}
+				signedBatches[batchHeaderHash] = signedBatch
+			}
+			inclusionInfoProto, err := inclusionInfo.ToProtobuf(cert)
+			if err != nil {
+				s.logger.Error("failed to convert blob inclusion info to protobuf", "err", err, "blobKey", blobKey.Hex())
+				continue
+			}
+			event = &pb.CertificationEvent{
+				Timestamp: updatedAt[blobKey],
+				Event: &pb.CertificationEvent_BlobCertified{
+					BlobCertified: &pb.BlobCertifiedEvent{
+						BlobKey:           blobKey[:],
+						SignedBatch:       signedBatch,
+						BlobInclusionInfo: inclusionInfoProto,
+					},
+				},
+			}
+			break
+		}
+		if event == nil {
+			return nil, fmt.Errorf("no signed batch found for certified blob %s", blobKey.Hex())
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
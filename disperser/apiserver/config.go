@@ -29,6 +29,10 @@ const (
 	RetrievalBlobRateFlagName   = "auth.retrieval-blob-rate"
 	RetrievalThroughputFlagName = "auth.retrieval-throughput"
 
+	ProofOfWorkDifficultyFlagName = "auth.proof-of-work-difficulty"
+	ProofOfWorkWindowFlagName     = "auth.proof-of-work-window"
+	ProofOfWorkTableNameFlagName  = "auth.proof-of-work-table-name"
+
 	// We allow the user to specify the blob rate in blobs/sec, but internally we use blobs/sec * 1e6 (i.e. blobs/microsec).
 	// This is because the rate limiter takes an integer rate.
 	blobRateMultiplier = 1e6
@@ -67,6 +71,15 @@ type RateConfig struct {
 
 	AllowlistFile            string
 	AllowlistRefreshInterval time.Duration
+
+	// ProofOfWorkDifficulty is the number of leading zero bits required of the proof of work of unauthenticated
+	// requests for blobs up to pow.BaseBlobSize. If zero, no proof of work is required.
+	ProofOfWorkDifficulty uint32
+	// ProofOfWorkWindow is how far the timestamp of a proof of work may be from the time it is received at.
+	ProofOfWorkWindow time.Duration
+	// ProofOfWorkTableName is the name of the DynamoDB table recording the proofs of work used with any of the
+	// dispersers behind the same endpoint. If empty, each disperser only records the proofs used with it.
+	ProofOfWorkTableName string
 }
 
 func AllowlistFileFlag(envPrefix string) cli.Flag {
@@ -137,6 +150,27 @@ func CLIFlags(envPrefix string) []cli.Flag {
 			EnvVar:   common.PrefixEnvVar(envPrefix, "RETRIEVAL_BYTE_RATE"),
 			Required: true,
 		},
+		cli.UintFlag{
+			Name:     ProofOfWorkDifficultyFlagName,
+			Usage:    "The number of leading zero bits required of the proof of work of unauthenticated requests for blobs up to 128KiB. Each doubling of the blob size requires one more bit. If 0, no proof of work is required. Intended for networks without payments",
+			Required: false,
+			Value:    0,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "PROOF_OF_WORK_DIFFICULTY"),
+		},
+		cli.DurationFlag{
+			Name:     ProofOfWorkWindowFlagName,
+			Usage:    "How far the timestamp of a proof of work may be from the time the request is received at",
+			Required: false,
+			Value:    time.Minute,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "PROOF_OF_WORK_WINDOW"),
+		},
+		cli.StringFlag{
+			Name:     ProofOfWorkTableNameFlagName,
+			Usage:    "Name of the DynamoDB table recording the proofs of work used, keyed by Digest with TTL on ExpiresAt. Required to prevent a proof of work from being used once with each disperser when there are several behind the same endpoint",
+			Required: false,
+			Value:    "",
+			EnvVar:   common.PrefixEnvVar(envPrefix, "PROOF_OF_WORK_TABLE_NAME"),
+		},
 	}
 }
 
@@ -242,5 +276,8 @@ func ReadCLIConfig(c *cli.Context) (RateConfig, error) {
 		RetrievalThroughput:      common.RateParam(c.Int(RetrievalThroughputFlagName)),
 		AllowlistFile:            c.String(AllowlistFileFlagName),
 		AllowlistRefreshInterval: c.Duration(AllowlistRefreshIntervalFlagName),
+		ProofOfWorkDifficulty:    uint32(c.Uint(ProofOfWorkDifficultyFlagName)),
+		ProofOfWorkWindow:        c.Duration(ProofOfWorkWindowFlagName),
+		ProofOfWorkTableName:     c.String(ProofOfWorkTableNameFlagName),
 	}, nil
 }
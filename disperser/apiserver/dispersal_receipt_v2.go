@@ -0,0 +1,63 @@
+package apiserver
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api"
+	pb "github.com/Layr-Labs/eigenda/api/grpc/disperser/v2"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// DispersalReceiptSigner signs the receipts returned by DisperseBlob
+type DispersalReceiptSigner interface {
+	// SignDispersalReceipt signs the keccak hash of the receipt
+	SignDispersalReceipt(ctx context.Context, receipt *pb.DispersalReceipt) ([]byte, error)
+}
+
+// NewKMSDispersalReceiptSigner creates a DispersalReceiptSigner which signs with the given AWS KMS key
+func NewKMSDispersalReceiptSigner(ctx context.Context, region string, endpoint string, keyID string) (DispersalReceiptSigner, error) {
+	return newKMSSigner(ctx, region, endpoint, keyID)
+}
+
+// signDispersalReceipt creates and signs the receipt of what the account is charged for dispersing the blob. The charge
+// is computed like the payment meter does. It returns no receipt if the disperser isn't configured to sign receipts.
+func (s *DispersalServerV2) signDispersalReceipt(
+	ctx context.Context,
+	blob []byte,
+	blobHeader *corev2.BlobHeader,
+	receivedAt time.Time,
+) (*pb.DispersalReceipt, []byte, error) {
+	if s.dispersalReceiptSigner == nil {
+		return nil, nil, nil
+	}
+
+	blobKey, err := blobHeader.BlobKey()
+	if err != nil {
+		return nil, nil, api.NewErrorInvalidArg(fmt.Sprintf("failed to get blob key: %v", err))
+	}
+	blobLength := encoding.GetBlobLengthPowerOf2(uint(len(blob)))
+	symbolsCharged := s.meterer.RequestSymbolsCharged(blobHeader.PaymentMetadata, uint64(blobLength))
+	receipt := &pb.DispersalReceipt{
+		BlobKey:          blobKey[:],
+		BlobHash:         crypto.Keccak256(blob),
+		AccountId:        blobHeader.PaymentMetadata.AccountID,
+		SymbolsCharged:   symbolsCharged,
+		ReceivedAt:       uint64(receivedAt.UnixNano()),
+		PaymentTimestamp: blobHeader.PaymentMetadata.Timestamp,
+	}
+	if blobHeader.PaymentMetadata.CumulativePayment != nil && blobHeader.PaymentMetadata.CumulativePayment.Sign() != 0 {
+		receipt.CumulativePayment = blobHeader.PaymentMetadata.CumulativePayment.Bytes()
+		receipt.PaymentCharged = s.meterer.PaymentCharged(symbolsCharged).Bytes()
+	}
+
+	signature, err := s.dispersalReceiptSigner.SignDispersalReceipt(ctx, receipt)
+	if err != nil {
+		s.logger.Error("failed to sign dispersal receipt", "blobKey", blobKey.Hex(), "err", err)
+		return nil, nil, api.NewErrorInternal(fmt.Sprintf("failed to sign the dispersal receipt: %v", err))
+	}
+	return receipt, signature, nil
+}
@@ -5,64 +5,162 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/Layr-Labs/eigenda/api"
 	pb "github.com/Layr-Labs/eigenda/api/grpc/disperser/v2"
+	"github.com/Layr-Labs/eigenda/common/tracing"
 	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/core/meterer"
 	corev2 "github.com/Layr-Labs/eigenda/core/v2"
 	"github.com/Layr-Labs/eigenda/disperser/common"
 	dispv2 "github.com/Layr-Labs/eigenda/disperser/common/v2"
 	"github.com/Layr-Labs/eigenda/encoding"
 	"github.com/Layr-Labs/eigenda/encoding/rs"
+	gethcommon "github.com/ethereum/go-ethereum/common"
 )
 
+// refundTimeout bounds the refund of a request which failed after it was metered
+const refundTimeout = 10 * time.Second
+
 func (s *DispersalServerV2) DisperseBlob(ctx context.Context, req *pb.DisperseBlobRequest) (*pb.DisperseBlobReply, error) {
 	start := time.Now()
 	defer func() {
 		s.metrics.reportDisperseBlobLatency(time.Since(start))
 	}()
 
+	ctx, span := startDisperseBlobSpan(ctx, req)
+	reply, err := s.disperseBlob(ctx, req, start)
+	span.RecordError(err)
+	span.End()
+	return reply, err
+}
+
+func (s *DispersalServerV2) disperseBlob(ctx context.Context, req *pb.DisperseBlobRequest, start time.Time) (*pb.DisperseBlobReply, error) {
+	if err := s.checkIntake(); err != nil {
+		return nil, err
+	}
+
 	// Validate the request
 	onchainState := s.onchainState.Load()
 	if onchainState == nil {
 		return nil, api.NewErrorInternal("onchain state is nil")
 	}
-	if err := s.validateDispersalRequest(req, onchainState); err != nil {
-		return nil, api.NewErrorInvalidArg(fmt.Sprintf("failed to validate the request: %v", err))
+	_, validateSpan := tracing.StartSpan(ctx, "ValidateRequest")
+	blobHeader, err := s.validateRequest(ctx, req, onchainState)
+	validateSpan.RecordError(err)
+	validateSpan.End()
+	if err != nil {
+		return nil, err
+	}
+
+	// Reject the request if the account already has too many blobs in flight
+	release, err := s.accountConcurrency.Acquire(blobHeader.PaymentMetadata.AccountID)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	// Reject the request before charging for it if the encoder can't keep up. The blob counts towards the depth of
+	// the encoding queue from now on, unless the request fails before the blob is queued.
+	cancelAdmission, err := s.backpressure.Admit()
+	if err != nil {
+		return nil, err
 	}
+	queued := false
+	defer func() {
+		if !queued {
+			cancelAdmission()
+		}
+	}()
 
 	// Check against payment meter to make sure there is quota remaining
-	if err := s.checkPaymentMeter(ctx, req, start); err != nil {
+	_, meterSpan := tracing.StartSpan(ctx, "MeterRequest")
+	charge, err := s.checkPaymentMeter(ctx, req, start)
+	meterSpan.RecordError(err)
+	meterSpan.End()
+	if err != nil {
+		return nil, err
+	}
+
+	// Sign the receipt after charging the account, so that requests without quota never reach the signer, and before
+	// storing the blob, so that a blob is never queued without the client holding its receipt. The account is refunded
+	// if signing fails.
+	blob := req.GetBlob()
+	receipt, receiptSignature, err := s.signDispersalReceipt(ctx, blob, blobHeader, start)
+	if err != nil {
+		s.refundCharge(ctx, blobHeader, len(blob), charge, "failed to sign the dispersal receipt")
 		return nil, err
 	}
 
 	finishedValidation := time.Now()
 	s.metrics.reportValidateDispersalRequestLatency(finishedValidation.Sub(start))
 
-	blob := req.GetBlob()
 	s.metrics.reportDisperseBlobSize(len(blob))
-	blobHeader, err := corev2.BlobHeaderFromProtobuf(req.GetBlobHeader())
-	if err != nil {
-		return nil, api.NewErrorInvalidArg(fmt.Sprintf("failed to parse the blob header proto: %v", err))
-	}
 	s.logger.Debug("received a new blob dispersal request", "blobSizeBytes", len(blob), "quorums", req.GetBlobHeader().GetQuorumNumbers())
 
-	blobKey, err := s.StoreBlob(ctx, blob, blobHeader, req.GetSignature(), time.Now(), onchainState.TTL)
+	_, storeSpan := tracing.StartSpan(ctx, "StoreBlob")
+	blobKey, err := s.StoreBlob(ctx, blob, blobHeader, req.GetSignature(), time.Now(), onchainState.TTL, charge.OverflowSymbols)
+	storeSpan.RecordError(err)
+	storeSpan.End()
 	if err != nil {
 		return nil, err
 	}
+	queued = true
+	s.accountConcurrency.Accepted(blobHeader.PaymentMetadata.AccountID)
 	s.logger.Debug("stored blob", "blobKey", blobKey.Hex())
 
 	s.metrics.reportStoreBlobLatency(time.Since(finishedValidation))
 
 	return &pb.DisperseBlobReply{
-		Result:  dispv2.Queued.ToProfobuf(),
-		BlobKey: blobKey[:],
+		Result:           dispv2.Queued.ToProfobuf(),
+		BlobKey:          blobKey[:],
+		Receipt:          receipt,
+		ReceiptSignature: receiptSignature,
 	}, nil
 }
 
-func (s *DispersalServerV2) StoreBlob(ctx context.Context, data []byte, blobHeader *corev2.BlobHeader, signature []byte, requestedAt time.Time, ttl time.Duration) (corev2.BlobKey, error) {
+// startDisperseBlobSpan starts the root span of the trace of the blob in the request. Requests from which the blob key
+// can't be derived are invalid, and aren't traced.
+func startDisperseBlobSpan(ctx context.Context, req *pb.DisperseBlobRequest) (context.Context, *tracing.Span) {
+	if !tracing.Enabled() {
+		return ctx, nil
+	}
+	blobHeader, err := corev2.BlobHeaderFromProtobuf(req.GetBlobHeader())
+	if err != nil {
+		return ctx, nil
+	}
+	blobKey, err := blobHeader.BlobKey()
+	if err != nil {
+		return ctx, nil
+	}
+	return tracing.StartBlobRootSpan(ctx, blobKey, "DisperseBlob",
+		tracing.String("blob_key", blobKey.Hex()),
+		tracing.String("account_id", blobHeader.PaymentMetadata.AccountID),
+		tracing.Int64("blob_size", int64(len(req.GetBlob()))),
+		tracing.String("quorums", fmt.Sprintf("%v", blobHeader.QuorumNumbers)))
+}
+
+// validateRequest validates the dispersal request, and returns the blob header it contains
+func (s *DispersalServerV2) validateRequest(ctx context.Context, req *pb.DisperseBlobRequest, onchainState *OnchainState) (*corev2.BlobHeader, error) {
+	if err := s.validateDispersalRequest(req, onchainState); err != nil {
+		return nil, api.NewErrorInvalidArg(fmt.Sprintf("failed to validate the request: %v", err))
+	}
+	blobHeader, err := corev2.BlobHeaderFromProtobuf(req.GetBlobHeader())
+	if err != nil {
+		return nil, api.NewErrorInvalidArg(fmt.Sprintf("failed to parse the blob header proto: %v", err))
+	}
+	if err := s.validatePaymentQuorums(ctx, blobHeader); err != nil {
+		return nil, err
+	}
+	return blobHeader, nil
+}
+
+// StoreBlob stores the blob and queues it for encoding. overflowSymbols is the number of symbols of the blob charged to
+// the overflow bin of the reservation, which are credited back if the blob fails.
+func (s *DispersalServerV2) StoreBlob(ctx context.Context, data []byte, blobHeader *corev2.BlobHeader, signature []byte, requestedAt time.Time, ttl time.Duration, overflowSymbols uint64) (corev2.BlobKey, error) {
 	blobKey, err := blobHeader.BlobKey()
 	if err != nil {
 		return corev2.BlobKey{}, api.NewErrorInvalidArg(fmt.Sprintf("failed to get blob key: %v", err))
@@ -87,6 +185,8 @@ func (s *DispersalServerV2) StoreBlob(ctx context.Context, data []byte, blobHead
 		BlobSize:    uint64(len(data)),
 		RequestedAt: uint64(requestedAt.UnixNano()),
 		UpdatedAt:   uint64(requestedAt.UnixNano()),
+
+		ReservationOverflowSymbols: overflowSymbols,
 	}
 	err = s.blobMetadataStore.PutBlobMetadata(ctx, blobMetadata)
 	if err != nil {
@@ -100,11 +200,12 @@ func (s *DispersalServerV2) StoreBlob(ctx context.Context, data []byte, blobHead
 	return blobKey, err
 }
 
-func (s *DispersalServerV2) checkPaymentMeter(ctx context.Context, req *pb.DisperseBlobRequest, receivedAt time.Time) error {
+// checkPaymentMeter charges the account for the request, and returns how it was charged
+func (s *DispersalServerV2) checkPaymentMeter(ctx context.Context, req *pb.DisperseBlobRequest, receivedAt time.Time) (*meterer.Charge, error) {
 	blobHeaderProto := req.GetBlobHeader()
 	blobHeader, err := corev2.BlobHeaderFromProtobuf(blobHeaderProto)
 	if err != nil {
-		return api.NewErrorInvalidArg(fmt.Sprintf("invalid blob header: %s", err.Error()))
+		return nil, api.NewErrorInvalidArg(fmt.Sprintf("invalid blob header: %s", err.Error()))
 	}
 	blobLength := encoding.GetBlobLengthPowerOf2(uint(len(req.GetBlob())))
 
@@ -114,18 +215,44 @@ func (s *DispersalServerV2) checkPaymentMeter(ctx context.Context, req *pb.Dispe
 	accountID := blobHeaderProto.GetPaymentHeader().GetAccountId()
 
 	paymentHeader := core.PaymentMetadata{
-		AccountID:         accountID,
-		Timestamp:         timestamp,
-		CumulativePayment: cumulativePayment,
+		AccountID:              accountID,
+		Timestamp:              timestamp,
+		CumulativePayment:      cumulativePayment,
+		RetentionPeriodSeconds: blobHeaderProto.GetPaymentHeader().GetRetentionPeriodSeconds(),
 	}
 
-	symbolsCharged, err := s.meterer.MeterRequest(ctx, paymentHeader, uint64(blobLength), blobHeader.QuorumNumbers, receivedAt)
+	charge, err := s.meterer.ChargeRequest(ctx, paymentHeader, uint64(blobLength), blobHeader.QuorumNumbers, receivedAt)
 	if err != nil {
-		return api.NewErrorResourceExhausted(err.Error())
+		return nil, api.NewErrorResourceExhausted(err.Error())
 	}
-	s.metrics.reportDisperseMeteredBytes(int(symbolsCharged) * encoding.BYTES_PER_SYMBOL)
+	s.metrics.reportDisperseMeteredBytes(int(charge.SymbolsCharged) * encoding.BYTES_PER_SYMBOL)
 
-	return nil
+	return charge, nil
+}
+
+// refundCharge reverses the charge of a request which failed after it was metered. Failures are logged with what's
+// needed to settle the payment manually, since the request has already failed.
+func (s *DispersalServerV2) refundCharge(
+	ctx context.Context,
+	blobHeader *corev2.BlobHeader,
+	blobSize int,
+	charge *meterer.Charge,
+	reason string) {
+
+	// the request context may be what failed the request, so the refund isn't bound to it
+	refundCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), refundTimeout)
+	defer cancel()
+	header := blobHeader.PaymentMetadata
+	blobLength := encoding.GetBlobLengthPowerOf2(uint(blobSize))
+	if _, err := s.meterer.RefundRequest(refundCtx, header, uint64(blobLength), charge.OverflowSymbols, reason); err != nil {
+		s.logger.Error("failed to refund payment of failed request",
+			"accountID", header.AccountID,
+			"timestamp", header.Timestamp,
+			"cumulativePayment", header.CumulativePayment,
+			"blobSize", blobSize,
+			"reason", reason,
+			"err", err)
+	}
 }
 
 func (s *DispersalServerV2) validateDispersalRequest(
@@ -188,10 +315,12 @@ func (s *DispersalServerV2) validateDispersalRequest(
 		return fmt.Errorf("too many quorum numbers specified: maximum is %d", onchainState.QuorumCount)
 	}
 
-	for _, quorum := range blobHeaderProto.GetQuorumNumbers() {
-		if quorum > corev2.MaxQuorumID || uint8(quorum) >= onchainState.QuorumCount {
-			return fmt.Errorf("invalid quorum number %d; maximum is %d", quorum, onchainState.QuorumCount)
-		}
+	if err = validateQuorumNumbers(blobHeaderProto.GetQuorumNumbers(), onchainState); err != nil {
+		return err
+	}
+
+	if err = s.validateRetentionPeriod(blobHeader); err != nil {
+		return err
 	}
 
 	// validate every 32 bytes is a valid field element
@@ -205,7 +334,16 @@ func (s *DispersalServerV2) validateDispersalRequest(
 		return fmt.Errorf("invalid blob version %d; valid blob versions are: %v", blobHeaderProto.GetVersion(), onchainState.BlobVersionParameters.Keys())
 	}
 
-	if err = s.authenticator.AuthenticateBlobRequest(blobHeader, signature); err != nil {
+	if len(req.GetPaymentDelegationSignature()) > 0 {
+		// the request is signed by a delegate of the payer
+		delegation := &corev2.PaymentDelegation{
+			Expiry:    req.GetPaymentDelegationExpiry(),
+			Signature: req.GetPaymentDelegationSignature(),
+		}
+		if err = s.authenticator.AuthenticateDelegatedBlobRequest(blobHeader, signature, delegation); err != nil {
+			return fmt.Errorf("authentication failed: %w", err)
+		}
+	} else if err = s.authenticator.AuthenticateBlobRequest(blobHeader, signature); err != nil {
 		return fmt.Errorf("authentication failed: %w", err)
 	}
 
@@ -219,3 +357,95 @@ func (s *DispersalServerV2) validateDispersalRequest(
 
 	return nil
 }
+
+// validateQuorumNumbers checks that each quorum is registered on-chain with valid security parameters, and that no
+// quorum is repeated. The returned error gives the reason each rejected quorum is invalid.
+func validateQuorumNumbers(quorumNumbers []uint32, onchainState *OnchainState) error {
+	seen := make(map[uint32]struct{}, len(quorumNumbers))
+	reasons := make([]string, 0)
+	for _, quorum := range quorumNumbers {
+		if _, ok := seen[quorum]; ok {
+			reasons = append(reasons, fmt.Sprintf("quorum %d is duplicated", quorum))
+			continue
+		}
+		seen[quorum] = struct{}{}
+
+		if quorum > corev2.MaxQuorumID || uint8(quorum) >= onchainState.QuorumCount {
+			reasons = append(reasons, fmt.Sprintf("quorum %d is not registered on-chain, the quorum count is %d", quorum, onchainState.QuorumCount))
+			continue
+		}
+		securityParam, ok := onchainState.QuorumSecurityParams[core.QuorumID(quorum)]
+		if !ok {
+			reasons = append(reasons, fmt.Sprintf("quorum %d has no security parameters registered on-chain", quorum))
+			continue
+		}
+		if err := securityParam.Validate(); err != nil {
+			reasons = append(reasons, fmt.Sprintf("quorum %d has invalid security parameters: %v", quorum, err))
+		}
+	}
+
+	if len(reasons) > 0 {
+		return fmt.Errorf("invalid quorum numbers: %s", strings.Join(reasons, "; "))
+	}
+	return nil
+}
+
+// validatePaymentQuorums checks that each of the blob's quorums is covered by the payment method of the request, i.e.
+// by the account's reservation for reservation payments, or by the on-demand quorums for on-demand payments
+func (s *DispersalServerV2) validatePaymentQuorums(ctx context.Context, blobHeader *corev2.BlobHeader) error {
+	if s.meterer == nil {
+		return nil
+	}
+
+	var allowedQuorums []core.QuorumID
+	var paymentMethod string
+	if blobHeader.PaymentMetadata.CumulativePayment.Sign() == 0 {
+		accountID := gethcommon.HexToAddress(blobHeader.PaymentMetadata.AccountID)
+		reservation, err := s.meterer.ChainPaymentState.GetReservedPaymentByAccount(ctx, accountID)
+		if err != nil || reservation == nil {
+			// accounts without a reservation are rejected by the payment meter
+			return nil
+		}
+		allowedQuorums = reservation.QuorumNumbers
+		paymentMethod = "the account's reservation"
+	} else {
+		quorums, err := s.meterer.ChainPaymentState.GetOnDemandQuorumNumbers(ctx)
+		if err != nil {
+			return api.NewErrorInternal(fmt.Sprintf("failed to get on-demand quorum numbers: %v", err))
+		}
+		allowedQuorums = quorums
+		paymentMethod = "on-demand payments"
+	}
+
+	reasons := make([]string, 0)
+	for _, quorum := range blobHeader.QuorumNumbers {
+		if !slices.Contains(allowedQuorums, quorum) {
+			reasons = append(reasons, fmt.Sprintf("quorum %d is not covered by %s, which covers quorums %v", quorum, paymentMethod, allowedQuorums))
+		}
+	}
+	if len(reasons) > 0 {
+		return api.NewErrorInvalidArg(fmt.Sprintf("invalid quorum numbers for payment: %s", strings.Join(reasons, "; ")))
+	}
+	return nil
+}
+
+// validateRetentionPeriod checks that the requested retention period, if any, is no shorter than the minimum retention
+// period of each of the blob's quorums, and no longer than the default retention period
+func (s *DispersalServerV2) validateRetentionPeriod(blobHeader *corev2.BlobHeader) error {
+	retentionPeriod := blobHeader.PaymentMetadata.RetentionPeriod()
+	if retentionPeriod == 0 {
+		return nil
+	}
+
+	defaultRetentionPeriod := s.retentionConfig.DefaultRetentionPeriod
+	if defaultRetentionPeriod > 0 && retentionPeriod > defaultRetentionPeriod {
+		return fmt.Errorf("retention period %v exceeds the default retention period %v", retentionPeriod, defaultRetentionPeriod)
+	}
+	for _, quorum := range blobHeader.QuorumNumbers {
+		minRetentionPeriod, ok := s.retentionConfig.QuorumMinRetentionPeriods[quorum]
+		if ok && retentionPeriod < minRetentionPeriod {
+			return fmt.Errorf("retention period %v is shorter than the minimum retention period %v of quorum %d", retentionPeriod, minRetentionPeriod, quorum)
+		}
+	}
+	return nil
+}
@@ -12,6 +12,14 @@ import (
 	corev2 "github.com/Layr-Labs/eigenda/core/v2"
 	dispcommon "github.com/Layr-Labs/eigenda/disperser/common"
 	dispv2 "github.com/Layr-Labs/eigenda/disperser/common/v2"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	// MaxBlobStatusesPerRequest is the maximum number of blob keys which can be queried in a single GetBlobStatuses call
+	MaxBlobStatusesPerRequest = 100
+	// blobStatusesConcurrency is the maximum number of blob statuses looked up concurrently for a GetBlobStatuses call
+	blobStatusesConcurrency = 16
 )
 
 func (s *DispersalServerV2) GetBlobStatus(ctx context.Context, req *pb.BlobStatusRequest) (*pb.BlobStatusReply, error) {
@@ -29,6 +37,66 @@ func (s *DispersalServerV2) GetBlobStatus(ctx context.Context, req *pb.BlobStatu
 		return nil, api.NewErrorInvalidArg(fmt.Sprintf("failed to parse the blob key bytes: %v", err))
 	}
 
+	return s.getBlobStatus(ctx, blobKey)
+}
+
+// GetBlobStatuses returns the statuses of up to MaxBlobStatusesPerRequest blobs. Failing to retrieve the status of
+// an individual blob doesn't fail the request; the error is instead reported in that blob's result.
+func (s *DispersalServerV2) GetBlobStatuses(ctx context.Context, req *pb.BlobStatusesRequest) (*pb.BlobStatusesReply, error) {
+	start := time.Now()
+	defer func() {
+		s.metrics.reportGetBlobStatusesLatency(time.Since(start))
+	}()
+
+	blobKeyBytes := req.GetBlobKeys()
+	if len(blobKeyBytes) == 0 {
+		return nil, api.NewErrorInvalidArg("at least one blob key must be present")
+	}
+	if len(blobKeyBytes) > MaxBlobStatusesPerRequest {
+		return nil, api.NewErrorInvalidArg(fmt.Sprintf("too many blob keys: maximum is %d", MaxBlobStatusesPerRequest))
+	}
+
+	blobKeys := make([]corev2.BlobKey, len(blobKeyBytes))
+	for i, keyBytes := range blobKeyBytes {
+		if len(keyBytes) != 32 {
+			return nil, api.NewErrorInvalidArg(fmt.Sprintf("blob key at index %d must be 32 bytes", i))
+		}
+		blobKey, err := corev2.BytesToBlobKey(keyBytes)
+		if err != nil {
+			return nil, api.NewErrorInvalidArg(fmt.Sprintf("failed to parse the blob key bytes at index %d: %v", i, err))
+		}
+		blobKeys[i] = blobKey
+	}
+
+	results := make([]*pb.BlobStatusResult, len(blobKeys))
+	pool := errgroup.Group{}
+	pool.SetLimit(blobStatusesConcurrency)
+	for i, blobKey := range blobKeys {
+		i, blobKey := i, blobKey
+		pool.Go(func() error {
+			result := &pb.BlobStatusResult{
+				BlobKey: blobKey[:],
+			}
+			reply, err := s.getBlobStatus(ctx, blobKey)
+			if err != nil {
+				result.Error = err.Error()
+			} else {
+				result.BlobStatus = reply
+			}
+			results[i] = result
+			return nil
+		})
+	}
+	_ = pool.Wait()
+
+	return &pb.BlobStatusesReply{
+		Results: results,
+	}, nil
+}
+
+// getBlobStatus returns the status of the blob with the given key, along with its signed batch and inclusion info
+// if the blob has been included in a batch.
+func (s *DispersalServerV2) getBlobStatus(ctx context.Context, blobKey corev2.BlobKey) (*pb.BlobStatusReply, error) {
 	metadata, err := s.blobMetadataStore.GetBlobMetadata(ctx, blobKey)
 	if err != nil {
 		if strings.Contains(err.Error(), "metadata not found") {
@@ -0,0 +1,41 @@
+package apiserver
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"github.com/Layr-Labs/eigenda/api"
+)
+
+// intakeGate allows the intake of new dispersal requests to be paused, e.g. through the admin API
+type intakeGate struct {
+	// pausedReason is the reason intake is paused, or nil if intake isn't paused
+	pausedReason atomic.Pointer[string]
+}
+
+// PauseIntake makes the server reject new dispersal requests with the given reason until ResumeIntake is called
+func (g *intakeGate) PauseIntake(reason string) {
+	g.pausedReason.Store(&reason)
+}
+
+// ResumeIntake makes the server accept new dispersal requests again
+func (g *intakeGate) ResumeIntake() {
+	g.pausedReason.Store(nil)
+}
+
+// IntakePaused returns true if new dispersal requests are being rejected
+func (g *intakeGate) IntakePaused() bool {
+	return g.pausedReason.Load() != nil
+}
+
+// checkIntake returns an Unavailable error if intake is paused
+func (g *intakeGate) checkIntake() error {
+	reason := g.pausedReason.Load()
+	if reason == nil {
+		return nil
+	}
+	if *reason == "" {
+		return api.NewErrorUnavailable("dispersal intake is paused")
+	}
+	return api.NewErrorUnavailable(fmt.Sprintf("dispersal intake is paused: %s", *reason))
+}
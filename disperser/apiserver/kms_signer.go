@@ -0,0 +1,70 @@
+package apiserver
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+
+	pb "github.com/Layr-Labs/eigenda/api/grpc/disperser/v2"
+	"github.com/Layr-Labs/eigenda/api/hashing"
+	awscommon "github.com/Layr-Labs/eigenda/common/aws"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+)
+
+// kmsSigner signs the messages returned by the disperser with an AWS KMS key
+type kmsSigner struct {
+	keyID      string
+	publicKey  *ecdsa.PublicKey
+	keyManager *kms.Client
+}
+
+var (
+	_ BlobIntegritySigner    = &kmsSigner{}
+	_ DispersalReceiptSigner = &kmsSigner{}
+)
+
+func newKMSSigner(ctx context.Context, region string, endpoint string, keyID string) (*kmsSigner, error) {
+	cfg, err := config.LoadDefaultConfig(ctx, config.WithRegion(region))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS config: %w", err)
+	}
+
+	var keyManager *kms.Client
+	if endpoint != "" {
+		keyManager = kms.New(kms.Options{
+			Region:       region,
+			BaseEndpoint: aws.String(endpoint),
+		})
+	} else {
+		keyManager = kms.NewFromConfig(cfg)
+	}
+
+	key, err := awscommon.LoadPublicKeyKMS(ctx, keyManager, keyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ecdsa public key: %w", err)
+	}
+
+	return &kmsSigner{
+		keyID:      keyID,
+		publicKey:  key,
+		keyManager: keyManager,
+	}, nil
+}
+
+func (s *kmsSigner) SignBlobIntegrityAttestation(ctx context.Context, attestation *pb.BlobIntegrityAttestation) ([]byte, error) {
+	signature, err := awscommon.SignKMS(ctx, s.keyManager, s.keyID, s.publicKey, hashing.HashBlobIntegrityAttestation(attestation))
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign attestation: %w", err)
+	}
+	return signature, nil
+}
+
+func (s *kmsSigner) SignDispersalReceipt(ctx context.Context, receipt *pb.DispersalReceipt) ([]byte, error) {
+	signature, err := awscommon.SignKMS(ctx, s.keyManager, s.keyID, s.publicKey, hashing.HashDispersalReceipt(receipt))
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign receipt: %w", err)
+	}
+	return signature, nil
+}
@@ -31,6 +31,8 @@ type metricsV2 struct {
 	validateDispersalRequestLatency *prometheus.SummaryVec
 	storeBlobLatency                *prometheus.SummaryVec
 	getBlobStatusLatency            *prometheus.SummaryVec
+	getBlobStatusesLatency          *prometheus.SummaryVec
+	checkBlobIntegrityLatency       *prometheus.SummaryVec
 
 	registry *prometheus.Registry
 	httpPort string
@@ -128,6 +130,26 @@ func newAPIServerV2Metrics(registry *prometheus.Registry, metricsConfig disperse
 		[]string{},
 	)
 
+	getBlobStatusesLatency := promauto.With(registry).NewSummaryVec(
+		prometheus.SummaryOpts{
+			Namespace:  namespace,
+			Name:       "get_blob_statuses_latency_ms",
+			Help:       "The time required to get the statuses of a batch of blobs.",
+			Objectives: objectives,
+		},
+		[]string{},
+	)
+
+	checkBlobIntegrityLatency := promauto.With(registry).NewSummaryVec(
+		prometheus.SummaryOpts{
+			Namespace:  namespace,
+			Name:       "check_blob_integrity_latency_ms",
+			Help:       "The time required to check the integrity of a stored blob.",
+			Objectives: objectives,
+		},
+		[]string{},
+	)
+
 	return &metricsV2{
 		grpcServerOption:                grpcServerOption,
 		getBlobCommitmentLatency:        getBlobCommitmentLatency,
@@ -138,6 +160,8 @@ func newAPIServerV2Metrics(registry *prometheus.Registry, metricsConfig disperse
 		validateDispersalRequestLatency: validateDispersalRequestLatency,
 		storeBlobLatency:                storeBlobLatency,
 		getBlobStatusLatency:            getBlobStatusLatency,
+		getBlobStatusesLatency:          getBlobStatusesLatency,
+		checkBlobIntegrityLatency:       checkBlobIntegrityLatency,
 		registry:                        registry,
 		httpPort:                        metricsConfig.HTTPPort,
 		logger:                          logger.With("component", "DisperserV2Metrics"),
@@ -192,3 +216,11 @@ func (m *metricsV2) reportStoreBlobLatency(duration time.Duration) {
 func (m *metricsV2) reportGetBlobStatusLatency(duration time.Duration) {
 	m.getBlobStatusLatency.WithLabelValues().Observe(common.ToMilliseconds(duration))
 }
+
+func (m *metricsV2) reportGetBlobStatusesLatency(duration time.Duration) {
+	m.getBlobStatusesLatency.WithLabelValues().Observe(common.ToMilliseconds(duration))
+}
+
+func (m *metricsV2) reportCheckBlobIntegrityLatency(duration time.Duration) {
+	m.checkBlobIntegrityLatency.WithLabelValues().Observe(common.ToMilliseconds(duration))
+}
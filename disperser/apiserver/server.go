@@ -23,6 +23,7 @@ import (
 	"github.com/Layr-Labs/eigenda/core/meterer"
 	"github.com/Layr-Labs/eigenda/disperser"
 	dispcommon "github.com/Layr-Labs/eigenda/disperser/common"
+	"github.com/Layr-Labs/eigenda/disperser/common/pow"
 	"github.com/Layr-Labs/eigenda/encoding"
 	"github.com/Layr-Labs/eigenda/encoding/rs"
 	"github.com/Layr-Labs/eigensdk-go/logging"
@@ -39,6 +40,7 @@ const systemAccountKey = "system"
 
 type DispersalServer struct {
 	pb.UnimplementedDisperserServer
+	intakeGate
 	mu *sync.RWMutex
 
 	serverConfig disperser.ServerConfig
@@ -51,6 +53,8 @@ type DispersalServer struct {
 	meterer       *meterer.Meterer
 	ratelimiter   common.RateLimiter
 	authenticator core.BlobRequestAuthenticator
+	// powVerifier checks the proofs of work of unauthenticated requests. If nil, no proof of work is required.
+	powVerifier *pow.Verifier
 
 	metrics     *disperser.Metrics
 	grpcMetrics *grpcprom.ServerMetrics
@@ -79,6 +83,7 @@ func NewDispersalServer(
 	meterer *meterer.Meterer,
 	ratelimiter common.RateLimiter,
 	rateConfig RateConfig,
+	proofStore pow.ProofStore,
 	maxBlobSize int,
 ) *DispersalServer {
 	logger := _logger.With("component", "DispersalServer")
@@ -91,6 +96,16 @@ func NewDispersalServer(
 
 	authenticator := auth.NewAuthenticator()
 
+	var powVerifier *pow.Verifier
+	if rateConfig.ProofOfWorkDifficulty > 0 {
+		logger.Info("proof of work is required of unauthenticated requests", "difficulty", rateConfig.ProofOfWorkDifficulty, "window", rateConfig.ProofOfWorkWindow.String())
+		if proofStore == nil {
+			logger.Warn("proofs of work used are only recorded by this disperser, so they can be used again with other dispersers behind the same endpoint")
+			proofStore = pow.NewLocalProofStore(rateConfig.ProofOfWorkWindow)
+		}
+		powVerifier = pow.NewVerifier(rateConfig.ProofOfWorkDifficulty, rateConfig.ProofOfWorkWindow, proofStore)
+	}
+
 	return &DispersalServer{
 		serverConfig:  serverConfig,
 		rateConfig:    rateConfig,
@@ -102,6 +117,7 @@ func NewDispersalServer(
 		grpcMetrics:   grpcMetrics,
 		ratelimiter:   ratelimiter,
 		authenticator: authenticator,
+		powVerifier:   powVerifier,
 		mu:            &sync.RWMutex{},
 		quorumConfig:  QuorumConfig{},
 		maxBlobSize:   maxBlobSize,
@@ -251,6 +267,22 @@ func (s *DispersalServer) DisperseBlob(ctx context.Context, req *pb.DisperseBlob
 		return nil, api.NewErrorInvalidArg(err.Error())
 	}
 
+	if err := s.verifyProofOfWork(ctx, req); err != nil {
+		if errors.Is(err, pow.ErrProofNotRecorded) {
+			s.logger.Error("failed to verify proof of work", "err", err)
+			for _, quorumID := range req.CustomQuorumNumbers {
+				s.metrics.HandleFailedRequest(codes.Internal.String(), fmt.Sprint(quorumID), len(req.GetData()), "DisperseBlob")
+			}
+			s.metrics.HandleInternalFailureRpcRequest("DisperseBlob")
+			return nil, api.NewErrorInternal(err.Error())
+		}
+		for _, quorumID := range req.CustomQuorumNumbers {
+			s.metrics.HandleFailedRequest(codes.InvalidArgument.String(), fmt.Sprint(quorumID), len(req.GetData()), "DisperseBlob")
+		}
+		s.metrics.HandleInvalidArgRpcRequest("DisperseBlob")
+		return nil, api.NewErrorInvalidArg(err.Error())
+	}
+
 	reply, err := s.disperseBlob(ctx, blob, "", "DisperseBlob", nil)
 	if err != nil {
 		// Note the disperseBlob already updated metrics for this error.
@@ -261,6 +293,18 @@ func (s *DispersalServer) DisperseBlob(ctx context.Context, req *pb.DisperseBlob
 	return reply, err
 }
 
+// verifyProofOfWork checks the proof of work of an unauthenticated request, if the server requires one.
+func (s *DispersalServer) verifyProofOfWork(ctx context.Context, req *pb.DisperseBlobRequest) error {
+	if s.powVerifier == nil {
+		return nil
+	}
+	proof := req.GetProofOfWork()
+	if proof == nil {
+		return fmt.Errorf("proof of work of %d bits is required", s.powVerifier.Difficulty(len(req.GetData())))
+	}
+	return s.powVerifier.Verify(ctx, req.GetData(), proof.GetTimestamp(), proof.GetNonce(), time.Now())
+}
+
 // Note: disperseBlob will internally update metrics upon an error; the caller doesn't need
 // to track the error again.
 func (s *DispersalServer) disperseBlob(ctx context.Context, blob *core.Blob, authenticatedAddress string, apiMethodName string, paymentHeader *core.PaymentMetadata) (*pb.DisperseBlobReply, error) {
@@ -271,6 +315,10 @@ func (s *DispersalServer) disperseBlob(ctx context.Context, blob *core.Blob, aut
 
 	dispersalStart := time.Now()
 
+	if err := s.checkIntake(); err != nil {
+		return nil, err
+	}
+
 	securityParams := blob.RequestHeader.SecurityParams
 	securityParamsStrings := make([]string, len(securityParams))
 	for i, sp := range securityParams {
@@ -688,6 +736,7 @@ func (s *DispersalServer) GetBlobStatus(ctx context.Context, req *pb.BlobStatusR
 					InclusionProof: confirmationInfo.BlobInclusionProof,
 					// ref: api/proto/disperser/disperser.proto:BlobVerificationProof.quorum_indexes
 					QuorumIndexes: quorumIndexes,
+					Simulated:     confirmationInfo.Simulated,
 				},
 			},
 		}, nil
@@ -759,6 +808,10 @@ func (s *DispersalServer) RetrieveBlob(ctx context.Context, req *pb.RetrieveBlob
 			s.logger.Warn("failed to retrieve blob metadata", "err", err)
 			return nil, api.NewErrorNotFound("no metadata found for the given batch header hash and blob index")
 		}
+		if errors.Is(err, dispcommon.ErrCapabilityUnsupported) {
+			s.logger.Warn("blob metadata store cannot look up blobs by batch", "err", err)
+			return nil, api.NewErrorUnimplemented()
+		}
 		s.logger.Error("failed to retrieve blob metadata", "err", err)
 		s.metrics.HandleInternalFailureRpcRequest("RetrieveBlob")
 		s.metrics.IncrementFailedBlobRequestNum(codes.Internal.String(), "", "RetrieveBlob")
@@ -866,7 +919,27 @@ func (s *DispersalServer) Start(ctx context.Context) error {
 
 	// Register Server for Health Checks
 	name := pb.Disperser_ServiceDesc.ServiceName
-	healthcheck.RegisterHealthServer(name, gs)
+	dependencyChecker := healthcheck.NewDependencyChecker(s.logger,
+		healthcheck.Dependency{
+			Name: "dynamodb",
+			Check: func(ctx context.Context) error {
+				_, err := s.blobStore.GetBlobMetadata(ctx, disperser.BlobKey{})
+				if err != nil && !errors.Is(err, dispcommon.ErrMetadataNotFound) {
+					return err
+				}
+				return nil
+			},
+		},
+		healthcheck.Dependency{
+			Name: "chain",
+			Check: func(ctx context.Context) error {
+				_, err := s.tx.GetCurrentBlockNumber(ctx)
+				return err
+			},
+		},
+	)
+	dependencyChecker.RegisterHealthServer(name, gs)
+	dependencyChecker.Start(ctx)
 
 	s.logger.Info("GRPC Listening", "port", s.serverConfig.GrpcPort, "address", listener.Addr().String(), "maxBlobSize", s.maxBlobSize)
 
@@ -853,12 +853,15 @@ func newTestServer(transactor core.Writer, testName string) *apiserver.Dispersal
 		AllowlistRefreshInterval: 10 * time.Minute,
 	}
 
-	queue = blobstore.NewSharedStorage(s3BucketName, s3Client, blobMetadataStore, logger)
+	queue, err = blobstore.NewSharedStorage(s3BucketName, s3Client, blobMetadataStore, logger)
+	if err != nil {
+		panic("failed to create shared storage: " + err.Error())
+	}
 
 	return apiserver.NewDispersalServer(disperser.ServerConfig{
 		GrpcPort:    "51001",
 		GrpcTimeout: 1 * time.Second,
-	}, queue, transactor, logger, disperser.NewMetrics(prometheus.NewRegistry(), "9001", logger), grpcprom.NewServerMetrics(), mt, ratelimiter, rateConfig, testMaxBlobSize)
+	}, queue, transactor, logger, disperser.NewMetrics(prometheus.NewRegistry(), "9001", logger), grpcprom.NewServerMetrics(), mt, ratelimiter, rateConfig, nil, testMaxBlobSize)
 }
 
 func disperseBlob(t *testing.T, server *apiserver.DispersalServer, data []byte) (pb.BlobStatus, uint, []byte) {
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"strconv"
 	"sync/atomic"
 	"time"
 
@@ -20,17 +21,21 @@ import (
 	corev2 "github.com/Layr-Labs/eigenda/core/v2"
 	v2 "github.com/Layr-Labs/eigenda/core/v2"
 	"github.com/Layr-Labs/eigenda/disperser"
+	dispcommon "github.com/Layr-Labs/eigenda/disperser/common"
+	dispv2 "github.com/Layr-Labs/eigenda/disperser/common/v2"
 	"github.com/Layr-Labs/eigenda/disperser/common/v2/blobstore"
 	"github.com/Layr-Labs/eigenda/encoding"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	gethcommon "github.com/ethereum/go-ethereum/common"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/reflection"
 )
 
 type OnchainState struct {
 	QuorumCount           uint8
 	RequiredQuorums       []core.QuorumID
+	QuorumSecurityParams  map[core.QuorumID]*core.SecurityParam
 	BlobVersionParameters *corev2.BlobVersionParameterMap
 	TTL                   time.Duration
 }
@@ -40,12 +45,22 @@ type DispersalServerV1 struct {
 	pbv1.UnimplementedDisperserServer
 }
 
+// RetentionConfig bounds the retention periods which can be requested for blobs
+type RetentionConfig struct {
+	// DefaultRetentionPeriod is the period blobs are retained for if no retention period is requested.
+	// Requested retention periods may not exceed it. If zero, requested retention periods are not bounded above.
+	DefaultRetentionPeriod time.Duration
+	// QuorumMinRetentionPeriods are the shortest retention periods which can be requested for blobs in each quorum
+	QuorumMinRetentionPeriods map[core.QuorumID]time.Duration
+}
+
 type DispersalServerV2 struct {
 	pb.UnimplementedDisperserServer
+	intakeGate
 
 	serverConfig      disperser.ServerConfig
 	blobStore         *blobstore.BlobStore
-	blobMetadataStore *blobstore.BlobMetadataStore
+	blobMetadataStore blobstore.MetadataStore
 	meterer           *meterer.Meterer
 
 	chainReader   core.Reader
@@ -57,6 +72,19 @@ type DispersalServerV2 struct {
 	onchainState                atomic.Pointer[OnchainState]
 	maxNumSymbolsPerBlob        uint64
 	onchainStateRefreshInterval time.Duration
+	retentionConfig             RetentionConfig
+	backpressure                *EncodingBackpressure
+	accountConcurrency          *AccountConcurrencyLimiter
+
+	certificationEventsConfig     CertificationEventsConfig
+	certificationEventSubscribers atomic.Int32
+	certificationEvents           *certificationEventBroadcaster
+	// certificationEventReplays limits the number of subscriptions concurrently reading past events
+	certificationEventReplays chan struct{}
+
+	blobIntegritySigner    BlobIntegritySigner
+	blobIntegrityLimiter   *blobIntegrityLimiter
+	dispersalReceiptSigner DispersalReceiptSigner
 
 	metricsConfig disperser.MetricsConfig
 	metrics       *metricsV2
@@ -66,13 +94,20 @@ type DispersalServerV2 struct {
 func NewDispersalServerV2(
 	serverConfig disperser.ServerConfig,
 	blobStore *blobstore.BlobStore,
-	blobMetadataStore *blobstore.BlobMetadataStore,
+	blobMetadataStore blobstore.MetadataStore,
 	chainReader core.Reader,
 	meterer *meterer.Meterer,
 	authenticator corev2.BlobRequestAuthenticator,
 	prover encoding.Prover,
 	maxNumSymbolsPerBlob uint64,
 	onchainStateRefreshInterval time.Duration,
+	retentionConfig RetentionConfig,
+	backpressureConfig BackpressureConfig,
+	accountConcurrencyConfig AccountConcurrencyConfig,
+	certificationEventsConfig CertificationEventsConfig,
+	blobIntegrityConfig BlobIntegrityConfig,
+	blobIntegritySigner BlobIntegritySigner,
+	dispersalReceiptSigner DispersalReceiptSigner,
 	_logger logging.Logger,
 	registry *prometheus.Registry,
 	metricsConfig disperser.MetricsConfig,
@@ -98,13 +133,32 @@ func NewDispersalServerV2(
 	if maxNumSymbolsPerBlob == 0 {
 		return nil, errors.New("maxNumSymbolsPerBlob is required")
 	}
+	if backpressureConfig.MaxQueuedBlobs > 0 && backpressureConfig.QueueDepthRefreshInterval <= 0 {
+		return nil, errors.New("queue depth refresh interval is required when max queued blobs is set")
+	}
+	if accountConcurrencyConfig.Limited() && accountConcurrencyConfig.PendingBlobsRefreshInterval <= 0 {
+		return nil, errors.New("pending blobs refresh interval is required when the blobs in flight of accounts are limited")
+	}
+	if certificationEventsConfig.MaxSubscribers > 0 && certificationEventsConfig.PollInterval <= 0 {
+		return nil, errors.New("certification events poll interval is required when subscribing to certification events is enabled")
+	}
+	if blobIntegritySigner != nil {
+		if err := blobIntegrityConfig.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid blob integrity config: %w", err)
+		}
+	}
 	if _logger == nil {
 		return nil, errors.New("logger is required")
 	}
 
 	logger := _logger.With("component", "DispersalServerV2")
 
-	return &DispersalServerV2{
+	integrityLimiter, err := newBlobIntegrityLimiter(blobIntegrityConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	server := &DispersalServerV2{
 		serverConfig:      serverConfig,
 		blobStore:         blobStore,
 		blobMetadataStore: blobMetadataStore,
@@ -117,10 +171,21 @@ func NewDispersalServerV2(
 
 		maxNumSymbolsPerBlob:        maxNumSymbolsPerBlob,
 		onchainStateRefreshInterval: onchainStateRefreshInterval,
+		retentionConfig:             retentionConfig,
+		backpressure:                NewEncodingBackpressure(backpressureConfig, blobMetadataStore, logger),
+		accountConcurrency:          NewAccountConcurrencyLimiter(accountConcurrencyConfig, blobMetadataStore, logger),
+		certificationEventsConfig:   certificationEventsConfig,
+		certificationEventReplays:   make(chan struct{}, maxConcurrentCertificationEventReplays),
+		blobIntegritySigner:         blobIntegritySigner,
+		blobIntegrityLimiter:        integrityLimiter,
+		dispersalReceiptSigner:      dispersalReceiptSigner,
 
 		metricsConfig: metricsConfig,
 		metrics:       newAPIServerV2Metrics(registry, metricsConfig, logger),
-	}, nil
+	}
+	server.certificationEvents = newCertificationEventBroadcaster(
+		certificationEventsConfig.PollInterval, server.readCertificationEvents, logger)
+	return server, nil
 }
 
 func (s *DispersalServerV2) Start(ctx context.Context) error {
@@ -147,12 +212,39 @@ func (s *DispersalServerV2) Start(ctx context.Context) error {
 
 	// Register Server for Health Checks
 	name := pb.Disperser_ServiceDesc.ServiceName
-	healthcheck.RegisterHealthServer(name, gs)
+	dependencyChecker := healthcheck.NewDependencyChecker(s.logger,
+		healthcheck.Dependency{
+			Name: "dynamodb",
+			Check: func(ctx context.Context) error {
+				_, err := s.blobMetadataStore.GetBlobMetadata(ctx, corev2.BlobKey{})
+				if err != nil && !errors.Is(err, dispcommon.ErrMetadataNotFound) {
+					return err
+				}
+				return nil
+			},
+		},
+		healthcheck.Dependency{
+			Name:  "s3",
+			Check: s.blobStore.CheckHealth,
+		},
+		healthcheck.Dependency{
+			Name: "chain",
+			Check: func(ctx context.Context) error {
+				_, err := s.chainReader.GetCurrentBlockNumber(ctx)
+				return err
+			},
+		},
+	)
+	dependencyChecker.RegisterHealthServer(name, gs)
+	dependencyChecker.Start(ctx)
 
 	if err := s.RefreshOnchainState(ctx); err != nil {
 		return fmt.Errorf("failed to refresh onchain quorum state: %w", err)
 	}
 
+	s.backpressure.Start(ctx)
+	s.accountConcurrency.Start(ctx)
+
 	go func() {
 		ticker := time.NewTicker(s.onchainStateRefreshInterval)
 		defer ticker.Stop()
@@ -238,6 +330,14 @@ func (s *DispersalServerV2) RefreshOnchainState(ctx context.Context) error {
 	if err != nil {
 		return fmt.Errorf("failed to get required quorum numbers: %w", err)
 	}
+	securityParams, err := s.chainReader.GetQuorumSecurityParams(ctx, currentBlock)
+	if err != nil {
+		return fmt.Errorf("failed to get quorum security params: %w", err)
+	}
+	quorumSecurityParams := make(map[core.QuorumID]*core.SecurityParam, len(securityParams))
+	for i := range securityParams {
+		quorumSecurityParams[securityParams[i].QuorumID] = &securityParams[i]
+	}
 
 	blockStaleMeasure, err := s.chainReader.GetBlockStaleMeasure(ctx)
 	if err != nil {
@@ -255,6 +355,7 @@ func (s *DispersalServerV2) RefreshOnchainState(ctx context.Context) error {
 	onchainState := &OnchainState{
 		QuorumCount:           quorumCount,
 		RequiredQuorums:       requiredQuorums,
+		QuorumSecurityParams:  quorumSecurityParams,
 		BlobVersionParameters: v2.NewBlobVersionParameterMap(blobParams),
 		TTL:                   time.Duration((storeDurationBlocks+blockStaleMeasure)*12) * time.Second,
 	}
@@ -275,8 +376,18 @@ func (s *DispersalServerV2) GetPaymentState(ctx context.Context, req *pb.GetPaym
 
 	accountID := gethcommon.HexToAddress(req.AccountId)
 
-	// validate the signature
-	if err := s.authenticator.AuthenticatePaymentStateRequest(req.GetSignature(), req.GetAccountId()); err != nil {
+	// validate the signature, which is made by a delegate of the account if the request carries a payment delegation
+	var err error
+	if len(req.GetPaymentDelegationSignature()) > 0 {
+		delegation := &corev2.PaymentDelegation{
+			Expiry:    req.GetPaymentDelegationExpiry(),
+			Signature: req.GetPaymentDelegationSignature(),
+		}
+		err = s.authenticator.AuthenticateDelegatedPaymentStateRequest(req.GetSignature(), req.GetAccountId(), delegation)
+	} else {
+		err = s.authenticator.AuthenticatePaymentStateRequest(req.GetSignature(), req.GetAccountId())
+	}
+	if err != nil {
 		s.logger.Debug("failed to validate signature", "err", err, "accountID", accountID)
 		return nil, api.NewErrorInvalidArg(fmt.Sprintf("authentication failed: %s", err.Error()))
 	}
@@ -340,6 +451,13 @@ func (s *DispersalServerV2) GetPaymentState(ctx context.Context, req *pb.GetPaym
 		ReservationWindow:      reservationWindow,
 	}
 
+	// report the default retention period, relative to which the charge of a shorter retention period is prorated
+	header := metadata.Pairs(api.DefaultRetentionPeriodHeader,
+		strconv.FormatInt(int64(s.meterer.DefaultRetentionPeriod/time.Second), 10))
+	if err := grpc.SetHeader(ctx, header); err != nil {
+		s.logger.Debug("failed to set default retention period header", "err", err)
+	}
+
 	// build reply
 	reply := &pb.GetPaymentStateReply{
 		PaymentGlobalParams:      &paymentGlobalParams,
@@ -347,6 +465,31 @@ func (s *DispersalServerV2) GetPaymentState(ctx context.Context, req *pb.GetPaym
 		Reservation:              pbReservation,
 		CumulativePayment:        largestCumulativePaymentBytes,
 		OnchainCumulativePayment: onchainCumulativePaymentBytes,
+		CurrentTimestamp:         uint64(time.Now().UnixNano()),
 	}
 	return reply, nil
 }
+
+// QueueStats returns the number of blobs in each of the in-progress statuses, and whether intake is paused
+func (s *DispersalServerV2) QueueStats(ctx context.Context) (map[string]int64, error) {
+	stats := map[string]int64{
+		"intake_paused": 0,
+	}
+	if s.IntakePaused() {
+		stats["intake_paused"] = 1
+	}
+
+	counted := map[string]dispv2.BlobStatus{
+		"queued_blobs":               dispv2.Queued,
+		"encoded_blobs":              dispv2.Encoded,
+		"gathering_signatures_blobs": dispv2.GatheringSignatures,
+	}
+	for name, status := range counted {
+		count, err := s.blobMetadataStore.GetBlobMetadataCountByStatus(ctx, status)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count %s blobs: %w", status.String(), err)
+		}
+		stats[name] = int64(count)
+	}
+	return stats, nil
+}
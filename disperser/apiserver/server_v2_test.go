@@ -2,10 +2,14 @@ package apiserver_test
 
 import (
 	"context"
+	"crypto/ecdsa"
 	"crypto/rand"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"math/big"
 	"net"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -29,12 +33,17 @@ import (
 	"github.com/Layr-Labs/eigenda/disperser/common/v2/blobstore"
 	"github.com/Layr-Labs/eigenda/encoding"
 	"github.com/Layr-Labs/eigenda/encoding/utils/codec"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
 	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/peer"
 
 	pbcommonv2 "github.com/Layr-Labs/eigenda/api/grpc/common/v2"
 	pbv2 "github.com/Layr-Labs/eigenda/api/grpc/disperser/v2"
+	"github.com/Layr-Labs/eigenda/api/hashing"
 	"github.com/Layr-Labs/eigenda/disperser"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/stretchr/testify/assert"
 	tmock "github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -44,7 +53,9 @@ type testComponents struct {
 	DispersalServerV2 *apiserver.DispersalServerV2
 	BlobStore         *blobstore.BlobStore
 	BlobMetadataStore *blobstore.BlobMetadataStore
+	DynamoClient      dynamodb.Client
 	ChainReader       *mock.MockWriter
+	Meterer           *meterer.Meterer
 	Signer            *auth.LocalBlobRequestSigner
 	Peer              *peer.Peer
 }
@@ -94,6 +105,18 @@ func TestV2DisperseBlob(t *testing.T) {
 	assert.Equal(t, pbv2.BlobStatus_QUEUED, reply.Result)
 	assert.Equal(t, blobKey[:], reply.BlobKey)
 
+	// Check the receipt of the on-demand payment
+	require.NotNil(t, reply.Receipt)
+	assert.Equal(t, blobKey[:], reply.Receipt.BlobKey)
+	assert.Equal(t, crypto.Keccak256(data), reply.Receipt.BlobHash)
+	assert.Equal(t, accountID, reply.Receipt.AccountId)
+	assert.Greater(t, reply.Receipt.SymbolsCharged, uint64(0))
+	assert.GreaterOrEqual(t, reply.Receipt.ReceivedAt, uint64(now.UnixNano()))
+	assert.Equal(t, int64(5), reply.Receipt.PaymentTimestamp)
+	assert.Equal(t, big.NewInt(100).Bytes(), reply.Receipt.CumulativePayment)
+	assert.NotEmpty(t, reply.Receipt.PaymentCharged)
+	assert.Equal(t, hashing.HashDispersalReceipt(reply.Receipt), reply.ReceiptSignature)
+
 	// Check if the blob is stored
 	storedData, err := c.BlobStore.GetBlob(ctx, blobKey)
 	assert.NoError(t, err)
@@ -155,7 +178,7 @@ func TestV2DisperseBlobRequestValidation(t *testing.T) {
 	// request with too many quorums
 	invalidReqProto = &pbcommonv2.BlobHeader{
 		Version:       0,
-		QuorumNumbers: []uint32{0, 1, 2, 3},
+		QuorumNumbers: []uint32{0, 1, 2, 3, 4},
 		Commitment:    commitmentProto,
 		PaymentHeader: &pbcommonv2.PaymentHeader{
 			AccountId:         accountID,
@@ -173,7 +196,7 @@ func TestV2DisperseBlobRequestValidation(t *testing.T) {
 	// request with invalid quorum
 	invalidReqProto = &pbcommonv2.BlobHeader{
 		Version:       0,
-		QuorumNumbers: []uint32{2, 54},
+		QuorumNumbers: []uint32{0, 0, 3, 54},
 		Commitment:    commitmentProto,
 		PaymentHeader: &pbcommonv2.PaymentHeader{
 			AccountId:         accountID,
@@ -186,7 +209,10 @@ func TestV2DisperseBlobRequestValidation(t *testing.T) {
 		Signature:  []byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31, 32, 33, 34, 35, 36, 37, 38, 39, 40, 41, 42, 43, 44, 45, 46, 47, 48, 49, 50, 51, 52, 53, 54, 55, 56, 57, 58, 59, 60, 61, 62, 63, 64, 65},
 		BlobHeader: invalidReqProto,
 	})
-	assert.ErrorContains(t, err, "invalid quorum")
+	assert.ErrorContains(t, err, "invalid quorum numbers")
+	assert.ErrorContains(t, err, "quorum 0 is duplicated")
+	assert.ErrorContains(t, err, "quorum 3 has invalid security parameters")
+	assert.ErrorContains(t, err, "quorum 54 is not registered on-chain")
 
 	// request with invalid blob version
 	invalidReqProto = &pbcommonv2.BlobHeader{
@@ -247,6 +273,69 @@ func TestV2DisperseBlobRequestValidation(t *testing.T) {
 	})
 	assert.ErrorContains(t, err, "invalid payment metadata")
 
+	// request with retention period shorter than the quorum minimum
+	invalidReqProto = &pbcommonv2.BlobHeader{
+		Version:       0,
+		QuorumNumbers: []uint32{0, 1},
+		Commitment:    commitmentProto,
+		PaymentHeader: &pbcommonv2.PaymentHeader{
+			AccountId:              accountID,
+			Timestamp:              5,
+			CumulativePayment:      big.NewInt(100).Bytes(),
+			RetentionPeriodSeconds: 60,
+		},
+	}
+	_, err = c.DispersalServerV2.DisperseBlob(context.Background(), &pbv2.DisperseBlobRequest{
+		Blob:       data,
+		Signature:  []byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31, 32, 33, 34, 35, 36, 37, 38, 39, 40, 41, 42, 43, 44, 45, 46, 47, 48, 49, 50, 51, 52, 53, 54, 55, 56, 57, 58, 59, 60, 61, 62, 63, 64, 65},
+		BlobHeader: invalidReqProto,
+	})
+	assert.ErrorContains(t, err, "is shorter than the minimum retention period")
+
+	// request with retention period longer than the default
+	invalidReqProto.PaymentHeader.RetentionPeriodSeconds = uint32((30 * 24 * time.Hour).Seconds())
+	_, err = c.DispersalServerV2.DisperseBlob(context.Background(), &pbv2.DisperseBlobRequest{
+		Blob:       data,
+		Signature:  []byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31, 32, 33, 34, 35, 36, 37, 38, 39, 40, 41, 42, 43, 44, 45, 46, 47, 48, 49, 50, 51, 52, 53, 54, 55, 56, 57, 58, 59, 60, 61, 62, 63, 64, 65},
+		BlobHeader: invalidReqProto,
+	})
+	assert.ErrorContains(t, err, "exceeds the default retention period")
+
+	// request with a quorum not covered by on-demand payments
+	invalidReqProto = &pbcommonv2.BlobHeader{
+		Version:       0,
+		QuorumNumbers: []uint32{0, 2},
+		Commitment:    commitmentProto,
+		PaymentHeader: &pbcommonv2.PaymentHeader{
+			AccountId:         accountID,
+			Timestamp:         5,
+			CumulativePayment: big.NewInt(100).Bytes(),
+		},
+	}
+	blobHeader, err = corev2.BlobHeaderFromProtobuf(invalidReqProto)
+	assert.NoError(t, err)
+	sig, err = signer.SignBlobRequest(blobHeader)
+	assert.NoError(t, err)
+	_, err = c.DispersalServerV2.DisperseBlob(context.Background(), &pbv2.DisperseBlobRequest{
+		Blob:       data,
+		Signature:  sig,
+		BlobHeader: invalidReqProto,
+	})
+	assert.ErrorContains(t, err, "quorum 2 is not covered by on-demand payments")
+
+	// request with a quorum not covered by the account's reservation
+	invalidReqProto.PaymentHeader.CumulativePayment = big.NewInt(0).Bytes()
+	blobHeader, err = corev2.BlobHeaderFromProtobuf(invalidReqProto)
+	assert.NoError(t, err)
+	sig, err = signer.SignBlobRequest(blobHeader)
+	assert.NoError(t, err)
+	_, err = c.DispersalServerV2.DisperseBlob(context.Background(), &pbv2.DisperseBlobRequest{
+		Blob:       data,
+		Signature:  sig,
+		BlobHeader: invalidReqProto,
+	})
+	assert.ErrorContains(t, err, "quorum 2 is not covered by the account's reservation")
+
 	// request with invalid commitment
 	invalidCommitment := commitmentProto
 	invalidCommitment.Length = commitmentProto.Length - 1
@@ -406,6 +495,300 @@ func TestV2GetBlobStatus(t *testing.T) {
 	require.Equal(t, attestationProto, reply.GetSignedBatch().GetAttestation())
 }
 
+func TestV2GetBlobStatuses(t *testing.T) {
+	c := newTestServerV2(t)
+	ctx := peer.NewContext(context.Background(), c.Peer)
+
+	blobKeys := make([][]byte, 0)
+	statuses := []dispv2.BlobStatus{dispv2.Queued, dispv2.Encoded, dispv2.Failed}
+	for i, blobStatus := range statuses {
+		blobHeader := &corev2.BlobHeader{
+			BlobVersion:     0,
+			BlobCommitments: mockCommitment,
+			QuorumNumbers:   []core.QuorumID{0},
+			PaymentMetadata: core.PaymentMetadata{
+				AccountID:         "0x1234",
+				Timestamp:         int64(i),
+				CumulativePayment: big.NewInt(532),
+			},
+		}
+		blobKey, err := blobHeader.BlobKey()
+		require.NoError(t, err)
+		now := time.Now()
+		err = c.BlobMetadataStore.PutBlobMetadata(ctx, &dispv2.BlobMetadata{
+			BlobHeader: blobHeader,
+			BlobStatus: blobStatus,
+			Expiry:     uint64(now.Add(time.Hour).Unix()),
+			NumRetries: 0,
+			UpdatedAt:  uint64(now.UnixNano()),
+		})
+		require.NoError(t, err)
+		blobKeys = append(blobKeys, blobKey[:])
+	}
+	// A blob which doesn't exist
+	missingKey := corev2.BlobKey{1, 2, 3}
+	blobKeys = append(blobKeys, missingKey[:])
+
+	reply, err := c.DispersalServerV2.GetBlobStatuses(ctx, &pbv2.BlobStatusesRequest{
+		BlobKeys: blobKeys,
+	})
+	require.NoError(t, err)
+	require.Len(t, reply.GetResults(), len(blobKeys))
+	for i, blobStatus := range statuses {
+		result := reply.GetResults()[i]
+		require.Equal(t, blobKeys[i], result.GetBlobKey())
+		require.Empty(t, result.GetError())
+		require.Equal(t, blobStatus.ToProfobuf(), result.GetBlobStatus().GetStatus())
+	}
+	missingResult := reply.GetResults()[len(statuses)]
+	require.Equal(t, missingKey[:], missingResult.GetBlobKey())
+	require.Nil(t, missingResult.GetBlobStatus())
+	require.Contains(t, missingResult.GetError(), "blob metadata not found")
+
+	// Invalid requests
+	_, err = c.DispersalServerV2.GetBlobStatuses(ctx, &pbv2.BlobStatusesRequest{})
+	require.ErrorContains(t, err, "at least one blob key must be present")
+
+	_, err = c.DispersalServerV2.GetBlobStatuses(ctx, &pbv2.BlobStatusesRequest{
+		BlobKeys: [][]byte{{1, 2, 3}},
+	})
+	require.ErrorContains(t, err, "must be 32 bytes")
+
+	tooManyKeys := make([][]byte, apiserver.MaxBlobStatusesPerRequest+1)
+	for i := range tooManyKeys {
+		tooManyKeys[i] = blobKeys[0]
+	}
+	_, err = c.DispersalServerV2.GetBlobStatuses(ctx, &pbv2.BlobStatusesRequest{
+		BlobKeys: tooManyKeys,
+	})
+	require.ErrorContains(t, err, "too many blob keys")
+}
+
+type mockCertificationEventStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	events chan *pbv2.CertificationEvent
+}
+
+func (m *mockCertificationEventStream) Context() context.Context {
+	return m.ctx
+}
+
+func (m *mockCertificationEventStream) Send(event *pbv2.CertificationEvent) error {
+	m.events <- event
+	return nil
+}
+
+func TestV2SubscribeCertificationEvents(t *testing.T) {
+	c := newTestServerV2(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	blobHeader := &corev2.BlobHeader{
+		BlobVersion:     0,
+		BlobCommitments: mockCommitment,
+		QuorumNumbers:   []core.QuorumID{0},
+		PaymentMetadata: core.PaymentMetadata{
+			AccountID:         "0x1234",
+			Timestamp:         0,
+			CumulativePayment: big.NewInt(532),
+		},
+	}
+	blobKey, err := blobHeader.BlobKey()
+	require.NoError(t, err)
+	now := time.Now()
+	err = c.BlobMetadataStore.PutBlobMetadata(ctx, &dispv2.BlobMetadata{
+		BlobHeader: blobHeader,
+		BlobStatus: dispv2.GatheringSignatures,
+		Expiry:     uint64(now.Add(time.Hour).Unix()),
+		UpdatedAt:  uint64(now.UnixNano()),
+	})
+	require.NoError(t, err)
+	err = c.BlobMetadataStore.PutBlobCertificate(ctx, &corev2.BlobCertificate{
+		BlobHeader: blobHeader,
+		RelayKeys:  []corev2.RelayKey{0},
+	}, nil)
+	require.NoError(t, err)
+	batchHeader := &corev2.BatchHeader{
+		BatchRoot:            [32]byte{4, 5, 6},
+		ReferenceBlockNumber: 100,
+	}
+	err = c.BlobMetadataStore.PutBatchHeader(ctx, batchHeader)
+	require.NoError(t, err)
+	err = c.BlobMetadataStore.PutBlobInclusionInfo(ctx, &corev2.BlobInclusionInfo{
+		BatchHeader:    batchHeader,
+		BlobKey:        blobKey,
+		BlobIndex:      1,
+		InclusionProof: []byte("inclusion proof"),
+	})
+	require.NoError(t, err)
+	err = c.BlobMetadataStore.PutAttestation(ctx, &corev2.Attestation{
+		BatchHeader: batchHeader,
+		AttestedAt:  uint64(now.Add(-10 * time.Second).UnixNano()),
+		APKG2: &core.G2Point{
+			G2Affine: &bn254.G2Affine{
+				X: mockCommitment.LengthCommitment.X,
+				Y: mockCommitment.LengthCommitment.Y,
+			},
+		},
+		Sigma: &core.Signature{
+			G1Point: core.NewG1Point(big.NewInt(5), big.NewInt(6)),
+		},
+		QuorumNumbers: []core.QuorumID{0},
+		QuorumResults: map[core.QuorumID]uint8{0: 100},
+	})
+	require.NoError(t, err)
+	err = c.BlobMetadataStore.UpdateBlobStatus(ctx, blobKey, dispv2.Complete)
+	require.NoError(t, err)
+
+	// Subscriptions can't start in the future or before the replay window
+	stream := &mockCertificationEventStream{
+		ctx:    ctx,
+		events: make(chan *pbv2.CertificationEvent, 10),
+	}
+	err = c.DispersalServerV2.SubscribeCertificationEvents(&pbv2.SubscribeCertificationEventsRequest{
+		StartTimestamp: uint64(now.Add(time.Minute).UnixNano()),
+	}, stream)
+	require.ErrorContains(t, err, "start timestamp is in the future")
+	err = c.DispersalServerV2.SubscribeCertificationEvents(&pbv2.SubscribeCertificationEventsRequest{
+		StartTimestamp: uint64(now.Add(-2 * time.Hour).UnixNano()),
+	}, stream)
+	require.ErrorContains(t, err, "older than the replay window")
+
+	done := make(chan error, 1)
+	go func() {
+		done <- c.DispersalServerV2.SubscribeCertificationEvents(&pbv2.SubscribeCertificationEventsRequest{
+			StartTimestamp: uint64(now.Add(-time.Minute).UnixNano()),
+		}, stream)
+	}()
+
+	select {
+	case event := <-stream.events:
+		require.Equal(t, batchHeader.BatchRoot[:], event.GetBatchCertified().GetSignedBatch().GetHeader().GetBatchRoot())
+		require.Equal(t, []uint32{0}, event.GetBatchCertified().GetSignedBatch().GetAttestation().GetQuorumNumbers())
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for batch certified event")
+	}
+
+	// The subscriber limit is enforced
+	err = c.DispersalServerV2.SubscribeCertificationEvents(&pbv2.SubscribeCertificationEventsRequest{}, stream)
+	require.ErrorContains(t, err, "too many subscribers")
+
+	select {
+	case event := <-stream.events:
+		require.Equal(t, blobKey[:], event.GetBlobCertified().GetBlobKey())
+		require.Equal(t, uint32(1), event.GetBlobCertified().GetBlobInclusionInfo().GetBlobIndex())
+		require.Equal(t, batchHeader.BatchRoot[:], event.GetBlobCertified().GetSignedBatch().GetHeader().GetBatchRoot())
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for blob certified event")
+	}
+
+	cancel()
+	require.NoError(t, <-done)
+}
+
+func TestV2SubscribeCertificationEventsSharedTimestamp(t *testing.T) {
+	c := newTestServerV2(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	batchHeader := &corev2.BatchHeader{
+		BatchRoot:            [32]byte{7, 8, 9},
+		ReferenceBlockNumber: 100,
+	}
+	err := c.BlobMetadataStore.PutBatchHeader(ctx, batchHeader)
+	require.NoError(t, err)
+	now := time.Now()
+	err = c.BlobMetadataStore.PutAttestation(ctx, &corev2.Attestation{
+		BatchHeader: batchHeader,
+		AttestedAt:  uint64(now.Add(-20 * time.Second).UnixNano()),
+		APKG2: &core.G2Point{
+			G2Affine: &bn254.G2Affine{
+				X: mockCommitment.LengthCommitment.X,
+				Y: mockCommitment.LengthCommitment.Y,
+			},
+		},
+		Sigma: &core.Signature{
+			G1Point: core.NewG1Point(big.NewInt(5), big.NewInt(6)),
+		},
+		QuorumNumbers: []core.QuorumID{0},
+		QuorumResults: map[core.QuorumID]uint8{0: 100},
+	})
+	require.NoError(t, err)
+
+	// blobs certified at the same time are all sent, along with the batch they were certified in
+	certifiedAt := uint64(now.Add(-10 * time.Second).UnixNano())
+	blobKeys := make(map[corev2.BlobKey]struct{})
+	for i := 0; i < 3; i++ {
+		blobHeader := &corev2.BlobHeader{
+			BlobVersion:     0,
+			BlobCommitments: mockCommitment,
+			QuorumNumbers:   []core.QuorumID{0},
+			PaymentMetadata: core.PaymentMetadata{
+				AccountID:         "0x1234",
+				Timestamp:         int64(i),
+				CumulativePayment: big.NewInt(532),
+			},
+		}
+		blobKey, err := blobHeader.BlobKey()
+		require.NoError(t, err)
+		blobKeys[blobKey] = struct{}{}
+		err = c.BlobMetadataStore.PutBlobMetadata(ctx, &dispv2.BlobMetadata{
+			BlobHeader: blobHeader,
+			BlobStatus: dispv2.Complete,
+			Expiry:     uint64(now.Add(time.Hour).Unix()),
+			UpdatedAt:  certifiedAt,
+		})
+		require.NoError(t, err)
+		err = c.BlobMetadataStore.PutBlobCertificate(ctx, &corev2.BlobCertificate{
+			BlobHeader: blobHeader,
+			RelayKeys:  []corev2.RelayKey{0},
+		}, nil)
+		require.NoError(t, err)
+		err = c.BlobMetadataStore.PutBlobInclusionInfo(ctx, &corev2.BlobInclusionInfo{
+			BatchHeader:    batchHeader,
+			BlobKey:        blobKey,
+			BlobIndex:      uint32(i),
+			InclusionProof: []byte("inclusion proof"),
+		})
+		require.NoError(t, err)
+	}
+
+	stream := &mockCertificationEventStream{
+		ctx:    ctx,
+		events: make(chan *pbv2.CertificationEvent, 10),
+	}
+	done := make(chan error, 1)
+	go func() {
+		done <- c.DispersalServerV2.SubscribeCertificationEvents(&pbv2.SubscribeCertificationEventsRequest{
+			StartTimestamp: uint64(now.Add(-time.Minute).UnixNano()),
+		}, stream)
+	}()
+
+	select {
+	case event := <-stream.events:
+		require.Equal(t, batchHeader.BatchRoot[:], event.GetBatchCertified().GetSignedBatch().GetHeader().GetBatchRoot())
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for batch certified event")
+	}
+	for len(blobKeys) > 0 {
+		select {
+		case event := <-stream.events:
+			require.Equal(t, certifiedAt, event.GetTimestamp())
+			blobKey, err := corev2.BytesToBlobKey(event.GetBlobCertified().GetBlobKey())
+			require.NoError(t, err)
+			require.Contains(t, blobKeys, blobKey)
+			delete(blobKeys, blobKey)
+			require.Equal(t, batchHeader.BatchRoot[:], event.GetBlobCertified().GetSignedBatch().GetHeader().GetBatchRoot())
+		case <-time.After(10 * time.Second):
+			t.Fatal("timed out waiting for blob certified event")
+		}
+	}
+
+	cancel()
+	require.NoError(t, <-done)
+}
+
 func TestV2GetBlobCommitment(t *testing.T) {
 	c := newTestServerV2(t)
 	data := make([]byte, 50)
@@ -431,7 +814,189 @@ func TestV2GetBlobCommitment(t *testing.T) {
 	assert.Equal(t, uint32(commit.Length), reply.BlobCommitment.Length)
 }
 
+// mockSigner "signs" attestations and receipts with their hash
+type mockSigner struct{}
+
+func (m *mockSigner) SignBlobIntegrityAttestation(ctx context.Context, attestation *pbv2.BlobIntegrityAttestation) ([]byte, error) {
+	return hashing.HashBlobIntegrityAttestation(attestation), nil
+}
+
+func (m *mockSigner) SignDispersalReceipt(ctx context.Context, receipt *pbv2.DispersalReceipt) ([]byte, error) {
+	return hashing.HashDispersalReceipt(receipt), nil
+}
+
+// failingReceiptSigner fails to sign dispersal receipts, as KMS does when it's unavailable
+type failingReceiptSigner struct{}
+
+func (m *failingReceiptSigner) SignDispersalReceipt(ctx context.Context, receipt *pbv2.DispersalReceipt) ([]byte, error) {
+	return nil, errors.New("kms unavailable")
+}
+
+func TestV2DisperseBlobReceiptSigningFailure(t *testing.T) {
+	c := newTestServerV2WithReceiptSigner(t, &failingReceiptSigner{})
+	ctx := peer.NewContext(context.Background(), c.Peer)
+	data := make([]byte, 50)
+	_, err := rand.Read(data)
+	require.NoError(t, err)
+
+	data = codec.ConvertByPaddingEmptyByte(data)
+	commitments, err := prover.GetCommitmentsForPaddedLength(data)
+	require.NoError(t, err)
+	accountID, err := c.Signer.GetAccountID()
+	require.NoError(t, err)
+	commitmentProto, err := commitments.ToProtobuf()
+	require.NoError(t, err)
+	blobHeaderProto := &pbcommonv2.BlobHeader{
+		Version:       0,
+		QuorumNumbers: []uint32{0, 1},
+		Commitment:    commitmentProto,
+		PaymentHeader: &pbcommonv2.PaymentHeader{
+			AccountId:         accountID,
+			Timestamp:         5,
+			CumulativePayment: big.NewInt(100).Bytes(),
+		},
+	}
+	blobHeader, err := corev2.BlobHeaderFromProtobuf(blobHeaderProto)
+	require.NoError(t, err)
+	sig, err := c.Signer.SignBlobRequest(blobHeader)
+	require.NoError(t, err)
+
+	reply, err := c.DispersalServerV2.DisperseBlob(ctx, &pbv2.DisperseBlobRequest{
+		Blob:       data,
+		Signature:  sig,
+		BlobHeader: blobHeaderProto,
+	})
+	assert.Nil(t, reply)
+	assert.ErrorContains(t, err, "failed to sign the dispersal receipt")
+
+	// The account is charged before the receipt is signed, so the payment of the failed request is flagged for refund
+	item, err := c.DynamoClient.GetItem(ctx, "ondemand_server_"+t.Name(), dynamodb.Key{
+		"AccountID":          &types.AttributeValueMemberS{Value: accountID},
+		"CumulativePayments": &types.AttributeValueMemberN{Value: "100"},
+	})
+	require.NoError(t, err)
+	require.NotNil(t, item)
+	assert.True(t, item["RefundRequested"].(*types.AttributeValueMemberBOOL).Value)
+	assert.Equal(t, "failed to sign the dispersal receipt", item["RefundReason"].(*types.AttributeValueMemberS).Value)
+
+	// and the blob isn't stored
+	blobKey, err := blobHeader.BlobKey()
+	require.NoError(t, err)
+	_, err = c.BlobMetadataStore.GetBlobMetadata(ctx, blobKey)
+	assert.Error(t, err)
+}
+
+func TestV2CheckBlobIntegrity(t *testing.T) {
+	c := newTestServerV2(t)
+	ctx := peer.NewContext(context.Background(), c.Peer)
+
+	privateKey, err := crypto.HexToECDSA(strings.TrimPrefix(privateKeyHex, "0x"))
+	require.NoError(t, err)
+	accountID := crypto.PubkeyToAddress(privateKey.PublicKey).Hex()
+
+	data := make([]byte, 50)
+	_, err = rand.Read(data)
+	require.NoError(t, err)
+	data = codec.ConvertByPaddingEmptyByte(data)
+	commitments, err := prover.GetCommitmentsForPaddedLength(data)
+	require.NoError(t, err)
+
+	putBlob := func(commitments encoding.BlobCommitments, timestamp int64, blob []byte) corev2.BlobKey {
+		blobHeader := &corev2.BlobHeader{
+			BlobVersion:     0,
+			BlobCommitments: commitments,
+			QuorumNumbers:   []core.QuorumID{0},
+			PaymentMetadata: core.PaymentMetadata{
+				AccountID:         accountID,
+				Timestamp:         timestamp,
+				CumulativePayment: big.NewInt(532),
+			},
+		}
+		blobKey, err := blobHeader.BlobKey()
+		require.NoError(t, err)
+		now := time.Now()
+		err = c.BlobMetadataStore.PutBlobMetadata(ctx, &dispv2.BlobMetadata{
+			BlobHeader: blobHeader,
+			BlobStatus: dispv2.Complete,
+			Expiry:     uint64(now.Add(time.Hour).Unix()),
+			UpdatedAt:  uint64(now.UnixNano()),
+		})
+		require.NoError(t, err)
+		if blob != nil {
+			require.NoError(t, c.BlobStore.StoreBlob(ctx, blobKey, blob))
+		}
+		return blobKey
+	}
+	// signedContext returns a context carrying the signature of the given key over a request of the blob
+	signedContext := func(key *ecdsa.PrivateKey, blobKey corev2.BlobKey, timestamp time.Time) context.Context {
+		signature, err := crypto.Sign(hashing.HashBlobIntegrityRequest(blobKey[:], timestamp.UnixNano()), key)
+		require.NoError(t, err)
+		return metadata.NewIncomingContext(ctx, metadata.Pairs(
+			apiserver.BlobIntegrityTimestampHeader, strconv.FormatInt(timestamp.UnixNano(), 10),
+			apiserver.BlobIntegritySignatureHeader, hex.EncodeToString(signature)))
+	}
+	checkIntegrity := func(blobKey corev2.BlobKey) *pbv2.BlobIntegrityAttestation {
+		reply, err := c.DispersalServerV2.CheckBlobIntegrity(
+			signedContext(privateKey, blobKey, time.Now()), &pbv2.CheckBlobIntegrityRequest{BlobKey: blobKey[:]})
+		require.NoError(t, err)
+		require.Equal(t, blobKey[:], reply.GetAttestation().GetBlobKey())
+		require.Equal(t, hashing.HashBlobIntegrityAttestation(reply.GetAttestation()), reply.GetSignature())
+		return reply.GetAttestation()
+	}
+	expectedCommitment, err := commitments.ToProtobuf()
+	require.NoError(t, err)
+
+	// the stored blob matches its header
+	intactBlobKey := putBlob(commitments, 1, data)
+	attestation := checkIntegrity(intactBlobKey)
+	require.True(t, attestation.GetIntact())
+	require.Equal(t, expectedCommitment, attestation.GetHeaderCommitment())
+	require.Equal(t, expectedCommitment, attestation.GetComputedCommitment())
+	require.NotZero(t, attestation.GetCheckedAt())
+
+	// the stored blob doesn't match its header
+	attestation = checkIntegrity(putBlob(mockCommitment, 2, data))
+	require.False(t, attestation.GetIntact())
+	require.Equal(t, expectedCommitment, attestation.GetComputedCommitment())
+	require.NotEqual(t, attestation.GetHeaderCommitment(), attestation.GetComputedCommitment())
+
+	// the blob is missing from the blob store
+	attestation = checkIntegrity(putBlob(commitments, 3, nil))
+	require.False(t, attestation.GetIntact())
+	require.Equal(t, expectedCommitment, attestation.GetHeaderCommitment())
+	require.Nil(t, attestation.GetComputedCommitment())
+
+	// requests which aren't signed recently by the account which dispersed the blob are rejected
+	request := &pbv2.CheckBlobIntegrityRequest{BlobKey: intactBlobKey[:]}
+	_, err = c.DispersalServerV2.CheckBlobIntegrity(ctx, request)
+	require.ErrorContains(t, err, "failed to authenticate the request")
+	otherKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	_, err = c.DispersalServerV2.CheckBlobIntegrity(signedContext(otherKey, intactBlobKey, time.Now()), request)
+	require.ErrorContains(t, err, "isn't signed by the account")
+	_, err = c.DispersalServerV2.CheckBlobIntegrity(
+		signedContext(privateKey, intactBlobKey, time.Now().Add(-time.Hour)), request)
+	require.ErrorContains(t, err, "away from the current time")
+
+	// the checks of an account are rate limited
+	checkIntegrity(intactBlobKey)
+	_, err = c.DispersalServerV2.CheckBlobIntegrity(signedContext(privateKey, intactBlobKey, time.Now()), request)
+	require.ErrorContains(t, err, "blob integrity checks per second")
+
+	// unknown blob
+	_, err = c.DispersalServerV2.CheckBlobIntegrity(ctx, &pbv2.CheckBlobIntegrityRequest{BlobKey: make([]byte, 32)})
+	require.ErrorContains(t, err, "blob metadata not found")
+
+	// invalid blob key
+	_, err = c.DispersalServerV2.CheckBlobIntegrity(ctx, &pbv2.CheckBlobIntegrityRequest{BlobKey: []byte{1, 2, 3}})
+	require.ErrorContains(t, err, "blob key must be present and with 32 bytes")
+}
+
 func newTestServerV2(t *testing.T) *testComponents {
+	return newTestServerV2WithReceiptSigner(t, &mockSigner{})
+}
+
+func newTestServerV2WithReceiptSigner(t *testing.T, receiptSigner apiserver.DispersalReceiptSigner) *testComponents {
 	logger := testutils.GetLogger()
 	// logger, err := common.NewLogger(common.DefaultLoggerConfig())
 	// if err != nil {
@@ -449,7 +1014,7 @@ func newTestServerV2(t *testing.T) *testComponents {
 	dynamoClient, err := dynamodb.NewClient(awsConfig, logger)
 	assert.NoError(t, err)
 	blobMetadataStore := blobstore.NewBlobMetadataStore(dynamoClient, logger, v2MetadataTableName)
-	blobStore := blobstore.NewBlobStore(s3BucketName, s3Client, logger)
+	blobStore := blobstore.NewBlobStore(s3BucketName, s3Client, nil, logger)
 	chainReader := &mock.MockWriter{}
 
 	// append test name to each table name for an unique store
@@ -500,7 +1065,13 @@ func newTestServerV2(t *testing.T) *testComponents {
 	meterer := meterer.NewMeterer(meterer.Config{}, mockState, store, logger)
 
 	chainReader.On("GetCurrentBlockNumber").Return(uint32(100), nil)
-	chainReader.On("GetQuorumCount").Return(uint8(2), nil)
+	chainReader.On("GetQuorumCount").Return(uint8(4), nil)
+	chainReader.On("GetQuorumSecurityParams", tmock.Anything).Return([]core.SecurityParam{
+		{QuorumID: 0, AdversaryThreshold: 33, ConfirmationThreshold: 55},
+		{QuorumID: 1, AdversaryThreshold: 33, ConfirmationThreshold: 55},
+		{QuorumID: 2, AdversaryThreshold: 33, ConfirmationThreshold: 55},
+		{QuorumID: 3, AdversaryThreshold: 50, ConfirmationThreshold: 50},
+	}, nil)
 	chainReader.On("GetRequiredQuorumNumbers", tmock.Anything).Return([]uint8{0, 1}, nil)
 	chainReader.On("GetBlockStaleMeasure", tmock.Anything).Return(uint32(10), nil)
 	chainReader.On("GetStoreDurationBlocks", tmock.Anything).Return(uint32(100), nil)
@@ -525,6 +1096,26 @@ func newTestServerV2(t *testing.T) *testComponents {
 		prover,
 		10,
 		time.Hour,
+		apiserver.RetentionConfig{
+			DefaultRetentionPeriod: 14 * 24 * time.Hour,
+			QuorumMinRetentionPeriods: map[core.QuorumID]time.Duration{
+				0: time.Hour,
+			},
+		},
+		apiserver.BackpressureConfig{},
+		apiserver.AccountConcurrencyConfig{},
+		apiserver.CertificationEventsConfig{
+			MaxSubscribers: 1,
+			PollInterval:   100 * time.Millisecond,
+			ReplayWindow:   time.Hour,
+		},
+		apiserver.BlobIntegrityConfig{
+			MaxSignatureAge:        time.Minute,
+			AccountChecksPerSecond: 0.001,
+			AccountBurst:           4,
+		},
+		&mockSigner{},
+		receiptSigner,
 		logger,
 		prometheus.NewRegistry(),
 		disperser.MetricsConfig{
@@ -549,7 +1140,9 @@ func newTestServerV2(t *testing.T) *testComponents {
 		DispersalServerV2: s,
 		BlobStore:         blobStore,
 		BlobMetadataStore: blobMetadataStore,
+		DynamoClient:      dynamoClient,
 		ChainReader:       chainReader,
+		Meterer:           meterer,
 		Signer:            signer,
 		Peer:              p,
 	}
@@ -7,11 +7,13 @@ import (
 	"fmt"
 	"math"
 	"math/big"
+	"sync/atomic"
 	"time"
 
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/core"
 	"github.com/Layr-Labs/eigenda/disperser"
+	"github.com/Layr-Labs/eigenda/disperser/common/leader"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -25,6 +27,9 @@ import (
 const (
 	QuantizationFactor = uint(1)
 	indexerWarmupDelay = 2 * time.Second
+	// expiredMetadataCleanupInterval is the interval at which expired blob metadata is deleted, if the metadata store
+	// doesn't delete it on its own
+	expiredMetadataCleanupInterval = time.Hour
 )
 
 type BatchPlan struct {
@@ -57,13 +62,36 @@ type Config struct {
 	NumConnections           int
 	EncodingRequestQueueSize int
 	// BatchSizeMBLimit is the maximum size of a batch in MB
-	BatchSizeMBLimit     uint
-	MaxNumRetriesPerBlob uint
+	BatchSizeMBLimit uint
+	// MaxNumBlobsPerBatch is the number of encoded blobs which triggers the creation of a batch. 0 means no limit.
+	MaxNumBlobsPerBatch uint
+	// MaxBlobAge is the time since a blob was requested after which a batch is created if the blob has been encoded.
+	// 0 means no limit.
+	MaxBlobAge time.Duration
+	// QuorumBatchThresholds override the batch thresholds for the encoded blobs of individual quorums
+	QuorumBatchThresholds map[core.QuorumID]BatchThresholds
+	MaxNumRetriesPerBlob  uint
 
 	FinalizationBlockDelay uint
 
 	TargetNumChunks          uint
 	MaxBlobsToFetchFromStore int
+
+	// MinibatchSize is the number of blobs dispersed to the operators in a single request when minibatching is
+	// enabled. Batches are then attested once all their minibatches are dispersed. 0 disables minibatching.
+	MinibatchSize uint
+	// MaxNodeConnections is the maximum number of concurrent requests to the operators when minibatching is enabled
+	MaxNodeConnections uint
+	// MaxNumRetriesPerDispersal is the number of times the dispersal of a minibatch to an operator is retried
+	MaxNumRetriesPerDispersal uint
+
+	// FinalizerConfirmationDepth is the number of blocks built on top of the block of a confirmation transaction after
+	// which its blobs are finalized. 0 finalizes blobs once the block is finalized by the chain.
+	FinalizerConfirmationDepth uint64
+
+	// SimulationMode disperses batches and collects signatures without confirming the batches on chain. Blobs are
+	// confirmed with synthetic confirmation info marked as simulated, so that load tests don't spend gas.
+	SimulationMode bool
 }
 
 type Batcher struct {
@@ -82,10 +110,20 @@ type Batcher struct {
 	TransactionManager    TxnManager
 	Metrics               *Metrics
 	HeartbeatChan         chan time.Time
+	// Fence guards the confirmation of batches on chain when running in active/standby mode. It is nil when leader
+	// election is disabled.
+	Fence leader.Fence
 
 	ethClient common.EthClient
 	finalizer Finalizer
 	logger    logging.Logger
+
+	// forceBatch triggers the creation of a batch without waiting for a batch trigger
+	forceBatch chan struct{}
+	// draining is set while the batcher is creating batches from the encoded blobs until none are left
+	draining atomic.Bool
+	// simulatedBatchID is the ID of the last batch confirmed in simulation mode
+	simulatedBatchID atomic.Uint32
 }
 
 func NewBatcher(
@@ -105,9 +143,17 @@ func NewBatcher(
 	metrics *Metrics,
 	heartbeatChan chan time.Time,
 ) (*Batcher, error) {
-	batchTrigger := NewEncodedSizeNotifier(
+	if !queue.MetadataCapabilities().SecondaryIndexes {
+		return nil, errors.New("batcher requires a metadata store with secondary indexes")
+	}
+	batchTrigger := NewBatchThresholdNotifier(
 		make(chan struct{}, 1),
-		uint64(config.BatchSizeMBLimit)*1024*1024, // convert to bytes
+		BatchThresholds{
+			MaxBatchSizeBytes: uint64(config.BatchSizeMBLimit) * 1024 * 1024, // convert to bytes
+			MaxNumBlobs:       config.MaxNumBlobsPerBatch,
+			MaxBlobAge:        config.MaxBlobAge,
+		},
+		config.QuorumBatchThresholds,
 	)
 	streamerConfig := StreamerConfig{
 		SRSOrder:                 config.SRSOrder,
@@ -144,6 +190,7 @@ func NewBatcher(
 		finalizer:     finalizer,
 		logger:        logger.With("component", "Batcher"),
 		HeartbeatChan: heartbeatChan,
+		forceBatch:    make(chan struct{}, 1),
 	}, nil
 }
 
@@ -212,6 +259,10 @@ func (b *Batcher) Start(ctx context.Context) error {
 
 	b.finalizer.Start(ctx)
 
+	if !b.Queue.MetadataCapabilities().TTL {
+		go b.deleteExpiredMetadata(ctx)
+	}
+
 	go func() {
 		ticker := time.NewTicker(b.PullInterval)
 		defer ticker.Stop()
@@ -221,23 +272,14 @@ func (b *Batcher) Start(ctx context.Context) error {
 			case <-ctx.Done():
 				return
 			case <-ticker.C:
-				if err := b.HandleSingleBatch(ctx); err != nil {
-					if errors.Is(err, errNoEncodedResults) {
-						b.logger.Warn("no encoded results to make a batch with")
-					} else {
-						b.logger.Error("failed to process a batch", "err", err)
-					}
-				}
+				b.handleBatch(ctx)
 			case <-batchTrigger.Notify:
 				ticker.Stop()
-
-				if err := b.HandleSingleBatch(ctx); err != nil {
-					if errors.Is(err, errNoEncodedResults) {
-						b.logger.Warn("no encoded results to make a batch with")
-					} else {
-						b.logger.Error("failed to process a batch", "err", err)
-					}
-				}
+				b.handleBatch(ctx)
+				ticker.Reset(b.PullInterval)
+			case <-b.forceBatch:
+				ticker.Stop()
+				b.handleBatch(ctx)
 				ticker.Reset(b.PullInterval)
 			}
 		}
@@ -246,6 +288,83 @@ func (b *Batcher) Start(ctx context.Context) error {
 	return nil
 }
 
+// deleteExpiredMetadata periodically deletes expired blob metadata, for metadata stores which don't expire it themselves
+func (b *Batcher) deleteExpiredMetadata(ctx context.Context) {
+	ticker := time.NewTicker(expiredMetadataCleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			deleted, err := b.Queue.DeleteExpiredBlobMetadata(ctx)
+			if err != nil {
+				b.logger.Error("failed to delete expired blob metadata", "err", err)
+				continue
+			}
+			b.logger.Info("deleted expired blob metadata", "numBlobs", deleted)
+		}
+	}
+}
+
+// handleBatch creates and disperses a single batch. While draining, it keeps triggering batches until there are no
+// encoded blobs left.
+func (b *Batcher) handleBatch(ctx context.Context) {
+	err := b.HandleSingleBatch(ctx)
+	if err != nil {
+		if errors.Is(err, errNoEncodedResults) {
+			b.logger.Warn("no encoded results to make a batch with")
+		} else {
+			b.logger.Error("failed to process a batch", "err", err)
+		}
+		return
+	}
+	if b.draining.Load() {
+		b.ForceBatch()
+	}
+}
+
+// ForceBatch triggers the creation of a batch from the encoded blobs without waiting for a batch trigger
+func (b *Batcher) ForceBatch() {
+	select {
+	case b.forceBatch <- struct{}{}:
+	default:
+		// a batch has already been forced
+	}
+}
+
+// Drain stops the encoding of new blobs, and creates batches from the encoded blobs until none are left. Blobs whose
+// encoding is in flight are still batched once they're encoded.
+func (b *Batcher) Drain() {
+	b.logger.Info("draining batcher")
+	b.EncodingStreamer.PauseEncoding()
+	b.draining.Store(true)
+	b.ForceBatch()
+}
+
+// Resume restarts the encoding of new blobs after a drain
+func (b *Batcher) Resume() {
+	b.logger.Info("resuming batcher")
+	b.draining.Store(false)
+	b.EncodingStreamer.ResumeEncoding()
+}
+
+// QueueStats returns the number of blobs waiting to be encoded and batched
+func (b *Batcher) QueueStats(ctx context.Context) (map[string]int64, error) {
+	total, _ := b.EncodingStreamer.EncodedBlobstore.GetEncodedResultStats()
+	stats := map[string]int64{
+		"encoding_requests_in_flight": int64(b.EncodingStreamer.EncodedBlobstore.GetEncodingRequestCount()),
+		"encoded_blobs":               int64(total.numBlobs),
+		"encoded_bytes":               int64(total.size),
+		"draining":                    0,
+	}
+	if b.draining.Load() {
+		stats["draining"] = 1
+	}
+	return stats, nil
+}
+
 // updateConfirmationInfo updates the confirmation info for each blob in the batch and returns failed blobs to retry.
 func (b *Batcher) updateConfirmationInfo(
 	ctx context.Context,
@@ -274,9 +393,14 @@ func (b *Batcher) updateConfirmationInfo(
 	if err != nil {
 		return nil, fmt.Errorf("HandleSingleBatch: error getting batch header hash: %w", err)
 	}
-	batchID, err := b.getBatchID(ctx, txnReceipt)
-	if err != nil {
-		return nil, fmt.Errorf("HandleSingleBatch: error fetching batch ID: %w", err)
+	var batchID uint32
+	if batchData.simulated {
+		batchID = b.simulatedBatchID.Add(1)
+	} else {
+		batchID, err = b.getBatchID(ctx, txnReceipt)
+		if err != nil {
+			return nil, fmt.Errorf("HandleSingleBatch: error fetching batch ID: %w", err)
+		}
 	}
 
 	blobsToRetry := make([]*disperser.BlobMetadata, 0)
@@ -319,6 +443,7 @@ func (b *Batcher) updateConfirmationInfo(
 			Fee:                     []byte{0}, // No fee
 			QuorumResults:           batchData.aggSig.QuorumResults,
 			BlobQuorumInfos:         batchData.blobHeaders[blobIndex].QuorumInfos,
+			Simulated:               batchData.simulated,
 		}
 
 		if status == disperser.Confirmed {
@@ -424,6 +549,8 @@ type confirmationMetadata struct {
 	blobHeaders []*core.BlobHeader
 	merkleTree  *merkletree.MerkleTree
 	aggSig      *core.SignatureAggregation
+	// simulated is set if the batch was not confirmed on chain because the batcher runs in simulation mode
+	simulated bool
 }
 
 func (b *Batcher) observeBlobAge(stage string, batch *batch) {
@@ -466,7 +593,15 @@ func (b *Batcher) HandleSingleBatch(ctx context.Context) error {
 	// Dispatch encoded batch
 	log.Debug("Dispatching encoded batch...")
 	stageTimer = time.Now()
-	update := b.Dispatcher.DisperseBatch(ctx, batch.State, batch.EncodedBlobs, batch.BatchHeader)
+	var update chan core.SigningMessage
+	if b.MinibatchSize > 0 {
+		update, err = b.disperseMinibatches(ctx, batch)
+		if err != nil {
+			return fmt.Errorf("HandleSingleBatch: error dispersing minibatches: %w", err)
+		}
+	} else {
+		update = b.Dispatcher.DisperseBatch(ctx, batch.State, batch.EncodedBlobs, batch.BatchHeader)
+	}
 	log.Debug("DisperseBatch took", "duration", time.Since(stageTimer))
 	b.observeBlobAge("attestation_requested", batch)
 	h, err := batch.State.OperatorState.Hash()
@@ -542,6 +677,17 @@ func (b *Batcher) HandleSingleBatch(ctx context.Context) error {
 	// Confirm the batch
 	log.Debug("Confirming batch...")
 
+	if b.Fence != nil {
+		// Blobs are left in the dispersing state, and will be recovered by the new leader
+		if err := b.Fence.CheckFencingToken(ctx); err != nil {
+			return fmt.Errorf("HandleSingleBatch: not confirming batch: %w", err)
+		}
+	}
+
+	if b.SimulationMode {
+		return b.confirmSimulatedBatch(ctx, batch, headerHash, aggSig)
+	}
+
 	txn, err := b.Transactor.BuildConfirmBatchTxn(ctx, batch.BatchHeader, aggSig.QuorumResults, aggSig)
 	if err != nil {
 		_ = b.handleFailure(ctx, batch.BlobMetadata, FailConfirmBatch)
@@ -563,6 +709,28 @@ func (b *Batcher) HandleSingleBatch(ctx context.Context) error {
 	return nil
 }
 
+// confirmSimulatedBatch confirms a batch without sending the confirmation transaction, as if it was included in the
+// reference block of the batch.
+func (b *Batcher) confirmSimulatedBatch(ctx context.Context, batch *batch, headerHash [32]byte, aggSig *core.SignatureAggregation) error {
+	b.logger.Info("confirming batch in simulation mode", "batchHeaderHash", fmt.Sprintf("%x", headerHash), "numBlobs", len(batch.BlobMetadata))
+	return b.ProcessConfirmedBatch(ctx, &ReceiptOrErr{
+		Receipt: &types.Receipt{
+			// The transaction hash is only used to identify the batch, since there is no transaction
+			TxHash:      headerHash,
+			BlockNumber: new(big.Int).SetUint64(uint64(batch.BatchHeader.ReferenceBlockNumber)),
+		},
+		Metadata: confirmationMetadata{
+			batchID:     uuid.Nil,
+			batchHeader: batch.BatchHeader,
+			blobs:       batch.BlobMetadata,
+			blobHeaders: batch.BlobHeaders,
+			merkleTree:  batch.MerkleTree,
+			aggSig:      aggSig,
+			simulated:   true,
+		},
+	})
+}
+
 func (b *Batcher) parseBatchIDFromReceipt(txReceipt *types.Receipt) (uint32, error) {
 	if len(txReceipt.Logs) == 0 {
 		return 0, errors.New("failed to get transaction receipt with logs")
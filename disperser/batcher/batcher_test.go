@@ -409,6 +409,47 @@ func TestBlobFailures(t *testing.T) {
 }
 
 // TestBlobRetry tests that the blob that has been dispersed to DA nodes but is pending onchain confirmation isn't re-dispersed.
+func TestBatcherSimulationMode(t *testing.T) {
+	blob := makeTestBlob([]*core.SecurityParam{{
+		QuorumID:              0,
+		AdversaryThreshold:    80,
+		ConfirmationThreshold: 100,
+	}})
+	components, batcher, getHeartbeats := makeBatcher(t)
+	batcher.SimulationMode = true
+	components.dispatcher.On("DisperseBatch").Return(map[core.OperatorID]struct{}{})
+
+	defer func() {
+		// Stop listening once the heartbeat is consumed, so that it isn't received by the following tests
+		assert.Eventually(t, func() bool { return len(batcher.HeartbeatChan) == 0 }, time.Second, 10*time.Millisecond)
+		getHeartbeats()
+	}()
+
+	blobStore := components.blobStore
+	ctx := context.Background()
+	_, blobKey := queueBlob(t, ctx, &blob, blobStore)
+
+	out := make(chan bat.EncodingResultOrStatus)
+	err := components.encodingStreamer.RequestEncoding(ctx, out)
+	assert.NoError(t, err)
+	err = components.encodingStreamer.ProcessEncodedBlobs(ctx, <-out)
+	assert.NoError(t, err)
+
+	err = batcher.HandleSingleBatch(ctx)
+	assert.NoError(t, err)
+	// no confirmation transaction is sent
+	components.transactor.AssertNotCalled(t, "BuildConfirmBatchTxn", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	assert.Empty(t, components.txnManager.Requests)
+
+	meta, err := blobStore.GetBlobMetadata(ctx, blobKey)
+	assert.NoError(t, err)
+	assert.Equal(t, disperser.Confirmed, meta.BlobStatus)
+	assert.True(t, meta.ConfirmationInfo.Simulated)
+	assert.Equal(t, uint32(1), meta.ConfirmationInfo.BatchID)
+	assert.Equal(t, meta.ConfirmationInfo.ReferenceBlockNumber, meta.ConfirmationInfo.ConfirmationBlockNumber)
+	assert.Equal(t, meta.ConfirmationInfo.BatchHeaderHash[:], meta.ConfirmationInfo.ConfirmationTxnHash[:])
+}
+
 func TestBlobRetry(t *testing.T) {
 	blob := makeTestBlob([]*core.SecurityParam{{
 		QuorumID:              0,
@@ -672,6 +713,88 @@ func TestBlobAttestationFailures(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+// TestMinibatchDispersal tests that blobs which aren't stored by enough operators when dispersing minibatches are
+// removed from the batch, and that the rest of the batch is confirmed.
+func TestMinibatchDispersal(t *testing.T) {
+	blob0 := makeTestBlob([]*core.SecurityParam{
+		{
+			QuorumID:              0,
+			AdversaryThreshold:    80,
+			ConfirmationThreshold: 100,
+		},
+		{
+			QuorumID:              1,
+			AdversaryThreshold:    80,
+			ConfirmationThreshold: 100,
+		},
+	})
+	blob1 := makeTestBlob([]*core.SecurityParam{
+		{
+			QuorumID:              2,
+			AdversaryThreshold:    80,
+			ConfirmationThreshold: 100,
+		},
+	})
+
+	components, batcher, _ := makeBatcher(t)
+	batcher.MinibatchSize = 1
+	batcher.MaxNodeConnections = 4
+	batcher.MaxNumRetriesPerDispersal = 1
+
+	blobStore := components.blobStore
+	ctx := context.Background()
+	_, blobKey0 := queueBlob(t, ctx, &blob0, blobStore)
+	_, blobKey1 := queueBlob(t, ctx, &blob1, blobStore)
+
+	out := make(chan bat.EncodingResultOrStatus)
+	err := components.encodingStreamer.RequestEncoding(ctx, out)
+	assert.NoError(t, err)
+	for i := 0; i < 3; i++ {
+		err = components.encodingStreamer.ProcessEncodedBlobs(ctx, <-out)
+		assert.NoError(t, err)
+	}
+
+	// operator 5 is only in quorum 2, so blob1 can't meet its confirmation threshold without it
+	socket5, err := components.chainData.GetOperatorSocket(ctx, 0, coremock.MakeOperatorId(5))
+	assert.NoError(t, err)
+	components.dispatcher.On("SendBlobsToOperator", mock.Anything, mock.Anything, mock.Anything, mock.MatchedBy(func(op *core.IndexedOperatorInfo) bool {
+		return op.Socket == socket5
+	})).Return(nil, errors.New("connection refused"))
+	components.dispatcher.On("SendBlobsToOperator", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return([]*core.Signature{{}}, nil)
+	blobHeaderHashes := make([][32]byte, 0)
+	components.dispatcher.On("AttestBatch", mock.Anything).Run(func(args mock.Arguments) {
+		blobHeaderHashes = args[0].([][32]byte)
+	}).Return(map[core.OperatorID]struct{}{}, nil)
+
+	txn := types.NewTransaction(0, gethcommon.Address{}, big.NewInt(0), 0, big.NewInt(0), nil)
+	components.transactor.On("BuildConfirmBatchTxn", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		quorumResults := args[2].(map[core.QuorumID]*core.QuorumResult)
+		assert.Len(t, quorumResults, 2)
+		assert.Contains(t, quorumResults, core.QuorumID(0))
+		assert.Contains(t, quorumResults, core.QuorumID(1))
+		assert.NotContains(t, quorumResults, core.QuorumID(2))
+	}).Return(txn, nil)
+	components.txnManager.On("ProcessTransaction").Return(nil)
+
+	err = batcher.HandleSingleBatch(ctx)
+	assert.NoError(t, err)
+	// blob0 is sent to the 4 operators of quorums 0 and 1, and blob1 to the 6 operators of quorum 2, with operator 5
+	// being retried once
+	components.dispatcher.AssertNumberOfCalls(t, "SendBlobsToOperator", 11)
+	components.dispatcher.AssertNumberOfCalls(t, "AttestBatch", 1)
+	assert.Len(t, blobHeaderHashes, 1)
+
+	// blob1 was removed from the batch and is retried later
+	assert.Greater(t, len(components.txnManager.Requests), 0)
+	meta0, err := blobStore.GetBlobMetadata(ctx, blobKey0)
+	assert.NoError(t, err)
+	assert.Equal(t, disperser.Dispersing, meta0.BlobStatus)
+	meta1, err := blobStore.GetBlobMetadata(ctx, blobKey1)
+	assert.NoError(t, err)
+	assert.Equal(t, disperser.Processing, meta1.BlobStatus)
+	assert.Equal(t, uint(1), meta1.NumRetries)
+}
+
 // TestBlobAttestationFailures2 tests a case where the attestation fails for some blobs in one quorum,
 // in which case the quorum should not be omitted from the confirmation transaction.
 func TestBlobAttestationFailures2(t *testing.T) {
@@ -836,3 +959,46 @@ func TestBatcherRecoverState(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, b2.BlobStatus, disperser.Failed)
 }
+
+func TestBatcherDrain(t *testing.T) {
+	blob := makeTestBlob([]*core.SecurityParam{{
+		QuorumID:              0,
+		AdversaryThreshold:    80,
+		ConfirmationThreshold: 100,
+	}})
+
+	components, batcher, _ := makeBatcher(t)
+
+	ctx := context.Background()
+	_, blobKey := queueBlob(t, ctx, &blob, components.blobStore)
+
+	batcher.Drain()
+	stats, err := batcher.QueueStats(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), stats["draining"])
+
+	// New blobs aren't picked up for encoding while draining
+	out := make(chan bat.EncodingResultOrStatus)
+	err = components.encodingStreamer.RequestEncoding(ctx, out)
+	assert.NoError(t, err)
+	timer := time.NewTimer(500 * time.Millisecond)
+	select {
+	case <-out:
+		t.Fatal("shouldn't have picked up any blobs to encode")
+	case <-timer.C:
+	}
+
+	batcher.Resume()
+	err = components.encodingStreamer.RequestEncoding(ctx, out)
+	assert.NoError(t, err)
+	err = components.encodingStreamer.ProcessEncodedBlobs(ctx, <-out)
+	assert.NoError(t, err)
+	encodedResult, err := components.encodingStreamer.EncodedBlobstore.GetEncodingResult(blobKey, 0)
+	assert.NoError(t, err)
+	assert.NotNil(t, encodedResult)
+
+	stats, err = batcher.QueueStats(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), stats["draining"])
+	assert.Equal(t, int64(1), stats["encoded_blobs"])
+}
@@ -183,6 +183,14 @@ func (e *encodedBlobStore) GetNewAndDeleteStaleEncodingResults(blockNumber uint)
 	return fetched
 }
 
+// GetEncodingRequestCount returns the number of encoding requests which haven't completed yet
+func (e *encodedBlobStore) GetEncodingRequestCount() int {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	return len(e.requested)
+}
+
 // GetEncodedResultSize returns the total size of all the chunks in the encoded results in bytes
 func (e *encodedBlobStore) GetEncodedResultSize() (int, uint64) {
 	e.mu.RLock()
@@ -191,6 +199,50 @@ func (e *encodedBlobStore) GetEncodedResultSize() (int, uint64) {
 	return len(e.encoded), e.encodedResultSize
 }
 
+// encodedResultStats summarizes a set of encoded results
+type encodedResultStats struct {
+	// numBlobs is the number of distinct blobs
+	numBlobs int
+	// size is the total size of all the chunks in bytes
+	size uint64
+	// oldestRequestedAt is the earliest request time of the blobs in nanoseconds since epoch
+	oldestRequestedAt uint64
+}
+
+// GetEncodedResultStats returns the stats of all the encoded results, as well as the stats of the encoded results of each quorum
+func (e *encodedBlobStore) GetEncodedResultStats() (encodedResultStats, map[core.QuorumID]encodedResultStats) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	total := encodedResultStats{}
+	byQuorum := make(map[core.QuorumID]encodedResultStats)
+	blobs := make(map[disperser.BlobKey]struct{})
+	for _, result := range e.encoded {
+		size := getChunksSize(result)
+		requestedAt := result.BlobMetadata.RequestMetadata.RequestedAt
+
+		blobKey := result.BlobMetadata.GetBlobKey()
+		if _, ok := blobs[blobKey]; !ok {
+			blobs[blobKey] = struct{}{}
+			total.numBlobs++
+		}
+		total.size += size
+		if total.oldestRequestedAt == 0 || requestedAt < total.oldestRequestedAt {
+			total.oldestRequestedAt = requestedAt
+		}
+
+		quorumStats := byQuorum[result.BlobQuorumInfo.QuorumID]
+		quorumStats.numBlobs++
+		quorumStats.size += size
+		if quorumStats.oldestRequestedAt == 0 || requestedAt < quorumStats.oldestRequestedAt {
+			quorumStats.oldestRequestedAt = requestedAt
+		}
+		byQuorum[result.BlobQuorumInfo.QuorumID] = quorumStats
+	}
+
+	return total, byQuorum
+}
+
 func getRequestID(key disperser.BlobKey, quorumID core.QuorumID) requestID {
 	return requestID(fmt.Sprintf("%s-%d", key.String(), quorumID))
 }
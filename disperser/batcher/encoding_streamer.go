@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/Layr-Labs/eigenda/common"
@@ -23,14 +24,35 @@ const encodingInterval = 2 * time.Second
 
 const operatorStateCacheSize = 32
 
+// batchThresholdCheckInterval is how often the age of the pending encoded blobs is checked against the batch thresholds
+const batchThresholdCheckInterval = time.Second
+
 var errNoEncodedResults = errors.New("no encoded results")
 
+// BatchThresholds are the limits which trigger the creation of a batch ahead of the next pull interval once they are
+// reached by the pending encoded blob results. A zero value disables the corresponding limit.
+type BatchThresholds struct {
+	// MaxBatchSizeBytes is the total size of the encoded blob results in bytes
+	MaxBatchSizeBytes uint64
+	// MaxNumBlobs is the number of encoded blobs
+	MaxNumBlobs uint
+	// MaxBlobAge is the time elapsed since the oldest encoded blob was requested
+	MaxBlobAge time.Duration
+}
+
 type EncodedSizeNotifier struct {
 	mu sync.Mutex
 
 	Notify chan struct{}
 	// threshold is the size of the total encoded blob results in bytes that triggers the notifier
 	threshold uint64
+	// maxNumBlobs is the number of encoded blobs that triggers the notifier
+	maxNumBlobs uint
+	// maxBlobAge is the age of the oldest encoded blob that triggers the notifier
+	maxBlobAge time.Duration
+	// quorumThresholds are evaluated against the encoded blob results of each quorum, in addition to the thresholds
+	// above which are evaluated against all encoded blob results
+	quorumThresholds map[core.QuorumID]BatchThresholds
 	// active is set to false after the notifier is triggered to prevent it from triggering again for the same batch
 	// This is reset when CreateBatch is called and the encoded results have been consumed
 	active bool
@@ -75,6 +97,9 @@ type EncodingStreamer struct {
 
 	encodingCtxCancelFuncs []context.CancelFunc
 
+	// encodingPaused is set while the streamer should not request the encoding of new blobs
+	encodingPaused atomic.Bool
+
 	metrics        *EncodingStreamerMetrics
 	batcherMetrics *Metrics
 	logger         logging.Logger
@@ -95,11 +120,74 @@ type batch struct {
 }
 
 func NewEncodedSizeNotifier(notify chan struct{}, threshold uint64) *EncodedSizeNotifier {
+	return NewBatchThresholdNotifier(notify, BatchThresholds{MaxBatchSizeBytes: threshold}, nil)
+}
+
+// NewBatchThresholdNotifier creates a notifier which is triggered when any of the global thresholds is reached by all
+// encoded blob results, or when any of the quorum thresholds is reached by the encoded blob results of that quorum.
+func NewBatchThresholdNotifier(notify chan struct{}, thresholds BatchThresholds, quorumThresholds map[core.QuorumID]BatchThresholds) *EncodedSizeNotifier {
+	if quorumThresholds == nil {
+		quorumThresholds = make(map[core.QuorumID]BatchThresholds)
+	}
 	return &EncodedSizeNotifier{
-		Notify:    notify,
-		threshold: threshold,
-		active:    true,
+		Notify:           notify,
+		threshold:        thresholds.MaxBatchSizeBytes,
+		maxNumBlobs:      thresholds.MaxNumBlobs,
+		maxBlobAge:       thresholds.MaxBlobAge,
+		quorumThresholds: quorumThresholds,
+		active:           true,
+	}
+}
+
+// hasAgeThreshold returns true if any of the global or quorum thresholds limits the age of the encoded blobs
+func (n *EncodedSizeNotifier) hasAgeThreshold() bool {
+	if n.maxBlobAge > 0 {
+		return true
+	}
+	for _, thresholds := range n.quorumThresholds {
+		if thresholds.MaxBlobAge > 0 {
+			return true
+		}
 	}
+	return false
+}
+
+// reachedThreshold returns a description of the first threshold reached by the given encoded result stats, or an
+// empty string if no threshold has been reached
+func (n *EncodedSizeNotifier) reachedThreshold(total encodedResultStats, byQuorum map[core.QuorumID]encodedResultStats, now time.Time) string {
+	if reason := checkBatchThresholds(BatchThresholds{
+		MaxBatchSizeBytes: n.threshold,
+		MaxNumBlobs:       n.maxNumBlobs,
+		MaxBlobAge:        n.maxBlobAge,
+	}, total, now); reason != "" {
+		return reason
+	}
+	for quorumID, thresholds := range n.quorumThresholds {
+		stats, ok := byQuorum[quorumID]
+		if !ok {
+			continue
+		}
+		if reason := checkBatchThresholds(thresholds, stats, now); reason != "" {
+			return fmt.Sprintf("quorum %d %s", quorumID, reason)
+		}
+	}
+	return ""
+}
+
+func checkBatchThresholds(thresholds BatchThresholds, stats encodedResultStats, now time.Time) string {
+	if stats.numBlobs == 0 {
+		return ""
+	}
+	if thresholds.MaxBatchSizeBytes > 0 && stats.size >= thresholds.MaxBatchSizeBytes {
+		return "encoded size threshold reached"
+	}
+	if thresholds.MaxNumBlobs > 0 && uint(stats.numBlobs) >= thresholds.MaxNumBlobs {
+		return "blob count threshold reached"
+	}
+	if thresholds.MaxBlobAge > 0 && now.Sub(time.Unix(0, int64(stats.oldestRequestedAt))) >= thresholds.MaxBlobAge {
+		return "blob age threshold reached"
+	}
+	return ""
 }
 
 func NewEncodingStreamer(
@@ -189,6 +277,23 @@ func (e *EncodingStreamer) Start(ctx context.Context) error {
 		}
 	}()
 
+	// goroutine for triggering batches once encoded blobs reach their maximum age
+	if e.EncodedSizeNotifier.hasAgeThreshold() {
+		go func() {
+			ticker := time.NewTicker(batchThresholdCheckInterval)
+			defer ticker.Stop()
+
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					e.checkBatchThresholds()
+				}
+			}
+		}()
+	}
+
 	return nil
 }
 
@@ -212,6 +317,11 @@ func (e *EncodingStreamer) dedupRequests(metadatas []*disperser.BlobMetadata, re
 }
 
 func (e *EncodingStreamer) RequestEncoding(ctx context.Context, encoderChan chan EncodingResultOrStatus) error {
+	if e.encodingPaused.Load() {
+		e.logger.Debug("encoding is paused, not requesting encoding of new blobs")
+		return nil
+	}
+
 	stageTimer := time.Now()
 	// pull new blobs and send to encoder
 	e.mu.Lock()
@@ -444,21 +554,45 @@ func (e *EncodingStreamer) ProcessEncodedBlobs(ctx context.Context, result Encod
 
 	count, encodedSize := e.EncodedBlobstore.GetEncodedResultSize()
 	e.metrics.UpdateEncodedBlobs(count, encodedSize)
-	if e.EncodedSizeNotifier.threshold > 0 && encodedSize >= e.EncodedSizeNotifier.threshold {
-		e.EncodedSizeNotifier.mu.Lock()
-
-		if e.EncodedSizeNotifier.active {
-			e.logger.Info("encoded size threshold reached", "size", encodedSize)
-			e.EncodedSizeNotifier.Notify <- struct{}{}
-			// make sure this doesn't keep triggering before encoded blob store is reset
-			e.EncodedSizeNotifier.active = false
-		}
-		e.EncodedSizeNotifier.mu.Unlock()
-	}
+	e.checkBatchThresholds()
 
 	return nil
 }
 
+// checkBatchThresholds notifies the batcher to create a batch if any of the batch thresholds has been reached
+func (e *EncodingStreamer) checkBatchThresholds() {
+	total, byQuorum := e.EncodedBlobstore.GetEncodedResultStats()
+	reason := e.EncodedSizeNotifier.reachedThreshold(total, byQuorum, time.Now())
+	if reason == "" {
+		return
+	}
+
+	e.EncodedSizeNotifier.mu.Lock()
+	defer e.EncodedSizeNotifier.mu.Unlock()
+	if e.EncodedSizeNotifier.active {
+		e.logger.Info(reason, "size", total.size, "numBlobs", total.numBlobs)
+		e.EncodedSizeNotifier.Notify <- struct{}{}
+		// make sure this doesn't keep triggering before encoded blob store is reset
+		e.EncodedSizeNotifier.active = false
+	}
+}
+
+// PauseEncoding stops the streamer from requesting the encoding of new blobs. Encoding requests which are already in
+// flight are unaffected.
+func (e *EncodingStreamer) PauseEncoding() {
+	e.encodingPaused.Store(true)
+}
+
+// ResumeEncoding allows the streamer to request the encoding of new blobs again
+func (e *EncodingStreamer) ResumeEncoding() {
+	e.encodingPaused.Store(false)
+}
+
+// EncodingPaused returns true if the streamer isn't requesting the encoding of new blobs
+func (e *EncodingStreamer) EncodingPaused() bool {
+	return e.encodingPaused.Load()
+}
+
 func (e *EncodingStreamer) UpdateReferenceBlock(currentBlockNumber uint) error {
 	blockNumber := currentBlockNumber
 	if blockNumber > e.FinalizationBlockDelay {
@@ -39,6 +39,10 @@ type components struct {
 }
 
 func createEncodingStreamer(t *testing.T, initialBlockNumber uint, batchThreshold uint64, streamerConfig batcher.StreamerConfig) (*batcher.EncodingStreamer, *components) {
+	return createEncodingStreamerWithThresholds(t, initialBlockNumber, batcher.BatchThresholds{MaxBatchSizeBytes: batchThreshold}, nil, streamerConfig)
+}
+
+func createEncodingStreamerWithThresholds(t *testing.T, initialBlockNumber uint, thresholds batcher.BatchThresholds, quorumThresholds map[core.QuorumID]batcher.BatchThresholds, streamerConfig batcher.StreamerConfig) (*batcher.EncodingStreamer, *components) {
 	logger := testutils.GetLogger()
 	blobStore := inmem.NewBlobStore()
 	cst, err := coremock.MakeChainDataMock(map[uint8]int{
@@ -51,7 +55,7 @@ func createEncodingStreamer(t *testing.T, initialBlockNumber uint, batchThreshol
 	assert.Nil(t, err)
 	encoderClient := disperser.NewLocalEncoderClient(p)
 	asgn := &core.StdAssignmentCoordinator{}
-	sizeNotifier := batcher.NewEncodedSizeNotifier(make(chan struct{}, 1), batchThreshold)
+	sizeNotifier := batcher.NewBatchThresholdNotifier(make(chan struct{}, 1), thresholds, quorumThresholds)
 	workerpool := workerpool.New(5)
 	metrics := batcher.NewMetrics("9100", logger)
 	encodingStreamer, err := batcher.NewEncodingStreamer(streamerConfig, blobStore, cst, encoderClient, asgn, sizeNotifier, workerpool, metrics.EncodingStreamerMetrics, metrics, logger)
@@ -200,6 +204,93 @@ func TestBatchTrigger(t *testing.T) {
 	}
 }
 
+func TestBatchTriggerBlobCount(t *testing.T) {
+	encodingStreamer, c := createEncodingStreamerWithThresholds(t, 10, batcher.BatchThresholds{MaxNumBlobs: 2}, nil, streamerConfig)
+
+	blob := makeTestBlob([]*core.SecurityParam{{
+		QuorumID:              0,
+		AdversaryThreshold:    80,
+		ConfirmationThreshold: 100,
+	}})
+	ctx := context.Background()
+	out := make(chan batcher.EncodingResultOrStatus)
+
+	_, err := c.blobStore.StoreBlob(ctx, &blob, uint64(time.Now().UnixNano()))
+	assert.Nil(t, err)
+	err = encodingStreamer.RequestEncoding(ctx, out)
+	assert.Nil(t, err)
+	err = encodingStreamer.ProcessEncodedBlobs(ctx, <-out)
+	assert.Nil(t, err)
+
+	select {
+	case <-encodingStreamer.EncodedSizeNotifier.Notify:
+		t.Fatal("expected not to be notified")
+	default:
+	}
+
+	_, err = c.blobStore.StoreBlob(ctx, &blob, uint64(time.Now().UnixNano()))
+	assert.Nil(t, err)
+	err = encodingStreamer.RequestEncoding(ctx, out)
+	assert.Nil(t, err)
+	err = encodingStreamer.ProcessEncodedBlobs(ctx, <-out)
+	assert.Nil(t, err)
+
+	select {
+	case <-encodingStreamer.EncodedSizeNotifier.Notify:
+	default:
+		t.Fatal("expected to be notified")
+	}
+}
+
+func TestBatchTriggerQuorumBlobAge(t *testing.T) {
+	quorumThresholds := map[core.QuorumID]batcher.BatchThresholds{
+		1: {MaxBlobAge: time.Minute},
+	}
+	encodingStreamer, c := createEncodingStreamerWithThresholds(t, 10, batcher.BatchThresholds{}, quorumThresholds, streamerConfig)
+
+	ctx := context.Background()
+	out := make(chan batcher.EncodingResultOrStatus)
+	requestedAt := uint64(time.Now().Add(-2 * time.Minute).UnixNano())
+
+	// An old blob in a quorum without an age threshold doesn't trigger a batch
+	blob := makeTestBlob([]*core.SecurityParam{{
+		QuorumID:              0,
+		AdversaryThreshold:    80,
+		ConfirmationThreshold: 100,
+	}})
+	_, err := c.blobStore.StoreBlob(ctx, &blob, requestedAt)
+	assert.Nil(t, err)
+	err = encodingStreamer.RequestEncoding(ctx, out)
+	assert.Nil(t, err)
+	err = encodingStreamer.ProcessEncodedBlobs(ctx, <-out)
+	assert.Nil(t, err)
+
+	select {
+	case <-encodingStreamer.EncodedSizeNotifier.Notify:
+		t.Fatal("expected not to be notified")
+	default:
+	}
+
+	// An old blob in a quorum with an age threshold triggers a batch
+	blob = makeTestBlob([]*core.SecurityParam{{
+		QuorumID:              1,
+		AdversaryThreshold:    70,
+		ConfirmationThreshold: 100,
+	}})
+	_, err = c.blobStore.StoreBlob(ctx, &blob, requestedAt)
+	assert.Nil(t, err)
+	err = encodingStreamer.RequestEncoding(ctx, out)
+	assert.Nil(t, err)
+	err = encodingStreamer.ProcessEncodedBlobs(ctx, <-out)
+	assert.Nil(t, err)
+
+	select {
+	case <-encodingStreamer.EncodedSizeNotifier.Notify:
+	default:
+		t.Fatal("expected to be notified")
+	}
+}
+
 func TestStreamingEncoding(t *testing.T) {
 	encodingStreamer, c := createEncodingStreamer(t, 0, 1e12, streamerConfig)
 
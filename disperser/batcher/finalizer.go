@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"math/big"
 	"time"
 
 	"github.com/Layr-Labs/eigenda/common"
@@ -27,11 +28,16 @@ type Finalizer interface {
 }
 
 type finalizer struct {
-	timeout              time.Duration
-	loopInterval         time.Duration
-	blobStore            disperser.BlobStore
-	ethClient            common.EthClient
-	rpcClient            common.RPCEthClient
+	timeout      time.Duration
+	loopInterval time.Duration
+	blobStore    disperser.BlobStore
+	ethClient    common.EthClient
+	rpcClient    common.RPCEthClient
+	// confirmationDepth is the number of blocks built on top of the block of a confirmation transaction after which
+	// its blobs are finalized. If it's 0, blobs are finalized once the block is finalized by the chain.
+	confirmationDepth uint64
+	// maxNumRetriesPerBlob bounds the number of times a blob whose confirmation transaction was reorged out of the
+	// chain is dispersed again
 	maxNumRetriesPerBlob uint
 	numBlobsPerFetch     int32
 	numWorkers           int
@@ -45,6 +51,7 @@ func NewFinalizer(
 	blobStore disperser.BlobStore,
 	ethClient common.EthClient,
 	rpcClient common.RPCEthClient,
+	confirmationDepth uint64,
 	maxNumRetriesPerBlob uint,
 	numBlobsPerFetch int32,
 	numWorkers int,
@@ -57,6 +64,7 @@ func NewFinalizer(
 		blobStore:            blobStore,
 		ethClient:            ethClient,
 		rpcClient:            rpcClient,
+		confirmationDepth:    confirmationDepth,
 		maxNumRetriesPerBlob: maxNumRetriesPerBlob,
 		numBlobsPerFetch:     numBlobsPerFetch,
 		numWorkers:           numWorkers,
@@ -85,6 +93,8 @@ func (f *finalizer) Start(ctx context.Context) {
 
 // FinalizeBlobs checks the latest finalized block and marks blobs in `confirmed` state as `finalized` if their confirmation
 // block number is less than or equal to the latest finalized block number.
+// Blobs whose confirmation transaction is no longer in the canonical chain by then are reverted to `processing`, so
+// that they are dispersed and confirmed again, unless they have been retried maxNumRetriesPerBlob times already.
 // If it failes to process some blobs, it will log the error, skip the failed blobs, and will not return an error. The function should be invoked again to retry.
 func (f *finalizer) FinalizeBlobs(ctx context.Context) error {
 	startTime := time.Now()
@@ -175,16 +185,33 @@ func (f *finalizer) updateBlobs(ctx context.Context, metadatas []*disperser.Blob
 			continue
 		}
 
+		// Batches confirmed in simulation mode have no confirmation transaction, so they can't be reorged
+		if confirmationMetadata.ConfirmationInfo.Simulated {
+			err = f.blobStore.MarkBlobFinalized(ctx, blobKey)
+			if err != nil {
+				f.logger.Error("error marking simulated blob as finalized", "blobKey", blobKey.String(), "err", err)
+				f.metrics.IncrementNumBlobs("failed")
+				continue
+			}
+			f.metrics.IncrementNumBlobs("finalized")
+			continue
+		}
+
 		// confirmation block number may have changed due to reorg
 		confirmationBlockNumber, err := f.getTransactionBlockNumber(ctx, confirmationMetadata.ConfirmationInfo.ConfirmationTxnHash)
 		if errors.Is(err, ethereum.NotFound) {
-			// The confirmed block is finalized, but the transaction is not found. It means the transaction should be considered forked/invalid and the blob should be considered as failed.
+			// The confirmed block is finalized, but the transaction is not found. It means the transaction was reorged
+			// out of the chain, so the blob has to be confirmed again.
 			f.logger.Warn("confirmed transaction not found", "blobKey", blobKey.String(), "confirmationTxnHash", confirmationMetadata.ConfirmationInfo.ConfirmationTxnHash.Hex(), "confirmationBlockNumber", confirmationMetadata.ConfirmationInfo.ConfirmationBlockNumber)
-			err := f.blobStore.MarkBlobFailed(ctx, m.GetBlobKey())
+			retry, err := f.blobStore.HandleBlobFailure(ctx, confirmationMetadata, f.maxNumRetriesPerBlob)
 			if err != nil {
-				f.logger.Error("error marking blob as failed", "blobKey", blobKey.String(), "err", err)
+				f.logger.Error("error reverting blob whose confirmation was reorged", "blobKey", blobKey.String(), "err", err)
+			}
+			if retry {
+				f.metrics.IncrementNumBlobs("reorged")
+			} else {
+				f.metrics.IncrementNumBlobs("failed")
 			}
-			f.metrics.IncrementNumBlobs("failed")
 			continue
 		}
 		if err != nil {
@@ -257,6 +284,10 @@ func (f *finalizer) getTransactionBlockNumber(ctx context.Context, hash gcommon.
 }
 
 func (f *finalizer) getLatestFinalizedBlock(ctx context.Context) (*types.Header, error) {
+	if f.confirmationDepth > 0 {
+		return f.getLatestConfirmedBlock(ctx)
+	}
+
 	var ctxWithTimeout context.Context
 	var cancel context.CancelFunc
 	var header = types.Header{}
@@ -285,3 +316,29 @@ func (f *finalizer) getLatestFinalizedBlock(ctx context.Context) (*types.Header,
 
 	return &header, nil
 }
+
+// getLatestConfirmedBlock returns the header of the latest block with confirmationDepth blocks built on top of it
+func (f *finalizer) getLatestConfirmedBlock(ctx context.Context) (*types.Header, error) {
+	var latestBlock uint64
+	var err error
+	for i := 0; i < maxRetries; i++ {
+		ctxWithTimeout, cancel := context.WithTimeout(ctx, f.timeout)
+		latestBlock, err = f.ethClient.BlockNumber(ctxWithTimeout)
+		cancel()
+		if err == nil {
+			break
+		}
+		retrySec := math.Pow(2, float64(i))
+		f.logger.Error("error getting latest block number", "err", err, "retrySec", retrySec)
+		time.Sleep(time.Duration(retrySec) * baseDelay)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Finalizer: error getting latest block number after retries: %w", err)
+	}
+
+	confirmedBlock := uint64(0)
+	if latestBlock > f.confirmationDepth {
+		confirmedBlock = latestBlock - f.confirmationDepth
+	}
+	return &types.Header{Number: new(big.Int).SetUint64(confirmedBlock)}, nil
+}
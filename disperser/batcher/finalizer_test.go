@@ -40,7 +40,7 @@ func TestFinalizedBlob(t *testing.T) {
 	}, nil)
 
 	metrics := batcher.NewMetrics("9100", logger)
-	finalizer := batcher.NewFinalizer(timeout, loopInterval, queue, ethClient, rpcClient, 1, 1, 1, logger, metrics.FinalizerMetrics)
+	finalizer := batcher.NewFinalizer(timeout, loopInterval, queue, ethClient, rpcClient, 0, 1, 1, 1, logger, metrics.FinalizerMetrics)
 
 	requestedAt := uint64(time.Now().UnixNano())
 	blob := makeTestBlob([]*core.SecurityParam{{
@@ -139,7 +139,7 @@ func TestUnfinalizedBlob(t *testing.T) {
 	}, nil)
 
 	metrics := batcher.NewMetrics("9100", logger)
-	finalizer := batcher.NewFinalizer(timeout, loopInterval, queue, ethClient, rpcClient, 1, 1, 1, logger, metrics.FinalizerMetrics)
+	finalizer := batcher.NewFinalizer(timeout, loopInterval, queue, ethClient, rpcClient, 0, 1, 1, 1, logger, metrics.FinalizerMetrics)
 
 	requestedAt := uint64(time.Now().UnixNano())
 	blob := makeTestBlob([]*core.SecurityParam{{
@@ -210,7 +210,7 @@ func TestNoReceipt(t *testing.T) {
 	ethClient.On("TransactionReceipt", m.Anything, m.Anything).Return(nil, ethereum.NotFound)
 
 	metrics := batcher.NewMetrics("9100", logger)
-	finalizer := batcher.NewFinalizer(timeout, loopInterval, queue, ethClient, rpcClient, 1, 1, 1, logger, metrics.FinalizerMetrics)
+	finalizer := batcher.NewFinalizer(timeout, loopInterval, queue, ethClient, rpcClient, 0, 1, 1, 1, logger, metrics.FinalizerMetrics)
 
 	requestedAt := uint64(time.Now().UnixNano())
 	blob := makeTestBlob([]*core.SecurityParam{{
@@ -257,17 +257,88 @@ func TestNoReceipt(t *testing.T) {
 	err = finalizer.FinalizeBlobs(context.Background())
 	assert.NoError(t, err)
 
-	// status should be kept at confirmed
+	// the confirmation was reorged out of the chain, so the blob is reverted to be confirmed again
 	metadatas, err := queue.GetBlobMetadataByStatus(ctx, disperser.Finalized)
 	assert.NoError(t, err)
 	assert.Len(t, metadatas, 0)
 	metadatas, err = queue.GetBlobMetadataByStatus(ctx, disperser.Failed)
 	assert.NoError(t, err)
-	assert.Len(t, metadatas, 1)
+	assert.Len(t, metadatas, 0)
 	metadatas, err = queue.GetBlobMetadataByStatus(ctx, disperser.Confirmed)
 	assert.NoError(t, err)
 	assert.Len(t, metadatas, 0)
 	metadatas, err = queue.GetBlobMetadataByStatus(ctx, disperser.Processing)
 	assert.NoError(t, err)
+	assert.Len(t, metadatas, 1)
+	assert.Equal(t, uint(1), metadatas[0].NumRetries)
+
+	// the blob fails once it runs out of retries
+	m, err = queue.MarkBlobConfirmed(ctx, metadatas[0], confirmationInfo)
+	assert.NoError(t, err)
+	assert.Equal(t, disperser.Confirmed, m.BlobStatus)
+	err = finalizer.FinalizeBlobs(context.Background())
+	assert.NoError(t, err)
+	metadatas, err = queue.GetBlobMetadataByStatus(ctx, disperser.Failed)
+	assert.NoError(t, err)
+	assert.Len(t, metadatas, 1)
+	metadatas, err = queue.GetBlobMetadataByStatus(ctx, disperser.Processing)
+	assert.NoError(t, err)
 	assert.Len(t, metadatas, 0)
 }
+
+func TestConfirmationDepth(t *testing.T) {
+	ctx := context.Background()
+	queue := inmem.NewBlobStore()
+	logger := testutils.GetLogger()
+	ethClient := &mock.MockEthClient{}
+	rpcClient := &mock.MockRPCEthClient{}
+
+	// with a confirmation depth of 10, blobs confirmed up to block 1_000_000 are finalized
+	ethClient.On("BlockNumber").Return(uint64(1_000_010))
+	metrics := batcher.NewMetrics("9100", logger)
+	finalizer := batcher.NewFinalizer(timeout, loopInterval, queue, ethClient, rpcClient, 10, 1, 1, 1, logger, metrics.FinalizerMetrics)
+
+	blob := makeTestBlob([]*core.SecurityParam{{
+		QuorumID:           0,
+		AdversaryThreshold: 80,
+	}})
+	requestedAt := uint64(time.Now().UnixNano())
+	confirmBlob := func(requestedAt uint64, txHash common.Hash, blockNumber uint32) disperser.BlobKey {
+		metadataKey, err := queue.StoreBlob(ctx, &blob, requestedAt)
+		assert.NoError(t, err)
+		_, err = queue.MarkBlobConfirmed(ctx, &disperser.BlobMetadata{
+			BlobHash:     metadataKey.BlobHash,
+			MetadataHash: metadataKey.MetadataHash,
+			BlobStatus:   disperser.Processing,
+			Expiry:       uint64(time.Now().Add(time.Hour).Unix()),
+			RequestMetadata: &disperser.RequestMetadata{
+				BlobRequestHeader: core.BlobRequestHeader{
+					SecurityParams: blob.RequestHeader.SecurityParams,
+				},
+				RequestedAt: requestedAt,
+			},
+		}, &disperser.ConfirmationInfo{
+			BatchHeaderHash:         [32]byte{1, 2, 3},
+			BlobCommitment:          &encoding.BlobCommitments{},
+			ConfirmationTxnHash:     txHash,
+			ConfirmationBlockNumber: blockNumber,
+		})
+		assert.NoError(t, err)
+		return metadataKey
+	}
+	finalKey := confirmBlob(requestedAt, common.HexToHash("0x1"), 1_000_000)
+	shallowKey := confirmBlob(requestedAt+1, common.HexToHash("0x2"), 1_000_001)
+	ethClient.On("TransactionReceipt", m.Anything, m.Anything).Return(&types.Receipt{
+		BlockNumber: new(big.Int).SetUint64(1_000_000),
+	}, nil)
+
+	err := finalizer.FinalizeBlobs(ctx)
+	assert.NoError(t, err)
+	metadata, err := queue.GetBlobMetadata(ctx, finalKey)
+	assert.NoError(t, err)
+	assert.Equal(t, disperser.Finalized, metadata.BlobStatus)
+	metadata, err = queue.GetBlobMetadata(ctx, shallowKey)
+	assert.NoError(t, err)
+	assert.Equal(t, disperser.Confirmed, metadata.BlobStatus)
+	rpcClient.AssertNotCalled(t, "CallContext", m.Anything, m.Anything, "eth_getBlockByNumber", "finalized", false)
+}
@@ -4,8 +4,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"time"
 
+	"github.com/Layr-Labs/eigenda/api"
 	commonpb "github.com/Layr-Labs/eigenda/api/grpc/common"
 	"github.com/Layr-Labs/eigenda/api/grpc/node"
 	"github.com/Layr-Labs/eigenda/core"
@@ -14,27 +16,45 @@ import (
 	"github.com/Layr-Labs/eigensdk-go/logging"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
 )
 
 type Config struct {
+	// Timeout is the attestation window, i.e. the time operators are given to sign a batch once it's dispersed
 	Timeout                   time.Duration
 	EnableGnarkBundleEncoding bool
+	// EnableStreamingStoreChunks sends the chunks of a batch to operators one blob at a time with StoreChunksStream,
+	// so that operators don't have to hold the whole batch in memory. Operators which don't report supporting it in
+	// their NodeInfo are sent the batch with StoreChunks.
+	EnableStreamingStoreChunks bool
+	// Retry configures the retries of StoreChunks requests to operators which failed with a transient error
+	Retry RetryConfig
+	// Credentials secure the connections to the operators, e.g. with a client certificate which the operators require
+	// to accept dispersals. If nil, the connections are plaintext.
+	Credentials credentials.TransportCredentials
 }
 
 type dispatcher struct {
 	*Config
 
-	logger  logging.Logger
-	metrics *batcher.DispatcherMetrics
+	retries  *retryScheduler
+	features *operatorFeatures
+	logger   logging.Logger
+	metrics  *batcher.DispatcherMetrics
 }
 
 func NewDispatcher(cfg *Config, logger logging.Logger, metrics *batcher.DispatcherMetrics) *dispatcher {
 	return &dispatcher{
-		Config:  cfg,
-		logger:  logger.With("component", "Dispatcher"),
-		metrics: metrics,
+		Config:   cfg,
+		retries:  newRetryScheduler(cfg.Retry),
+		features: newOperatorFeatures(featuresTTL),
+		logger:   logger.With("component", "Dispatcher"),
+		metrics:  metrics,
 	}
 }
 
@@ -50,6 +70,8 @@ func (c *dispatcher) DisperseBatch(ctx context.Context, state *core.IndexedOpera
 }
 
 func (c *dispatcher) sendAllChunks(ctx context.Context, state *core.IndexedOperatorState, blobs []core.EncodedBlob, batchHeader *core.BatchHeader, update chan core.SigningMessage) {
+	// Requests to all operators, including retries, must complete within the attestation window
+	deadline := time.Now().Add(c.Timeout)
 	for id, op := range state.IndexedOperators {
 		go func(op core.IndexedOperatorInfo, id core.OperatorID) {
 			blobMessages := make([]*core.EncodedBlobMessage, 0)
@@ -87,29 +109,9 @@ func (c *dispatcher) sendAllChunks(ctx context.Context, state *core.IndexedOpera
 				return
 			}
 
-			requestedAt := time.Now()
-			sig, err := c.sendChunks(ctx, blobMessages, batchHeader, &op)
-			latencyMs := float64(time.Since(requestedAt).Milliseconds())
-			if err != nil {
-				update <- core.SigningMessage{
-					Err:                  err,
-					Signature:            nil,
-					Operator:             id,
-					BatchHeaderHash:      batchHeaderHash,
-					AttestationLatencyMs: latencyMs,
-				}
-				c.metrics.ObserveLatency(id.Hex(), false, latencyMs)
-			} else {
-				update <- core.SigningMessage{
-					Signature:            sig,
-					Operator:             id,
-					BatchHeaderHash:      batchHeaderHash,
-					AttestationLatencyMs: latencyMs,
-					Err:                  nil,
-				}
-				c.metrics.ObserveLatency(id.Hex(), true, latencyMs)
-			}
-
+			update <- c.requestSignature(ctx, deadline, id, batchHeaderHash, func(ctx context.Context) (*core.Signature, error) {
+				return c.sendChunks(ctx, blobMessages, batchHeader, &op)
+			})
 		}(core.IndexedOperatorInfo{
 			PubkeyG1: op.PubkeyG1,
 			PubkeyG2: op.PubkeyG2,
@@ -118,22 +120,69 @@ func (c *dispatcher) sendAllChunks(ctx context.Context, state *core.IndexedOpera
 	}
 }
 
-func (c *dispatcher) sendChunks(ctx context.Context, blobs []*core.EncodedBlobMessage, batchHeader *core.BatchHeader, op *core.IndexedOperatorInfo) (*core.Signature, error) {
-	// TODO Add secure Grpc
+// requestSignature sends a request for the operator's signature, retrying it within the attestation window, and
+// returns the signing message to report for the operator.
+func (c *dispatcher) requestSignature(ctx context.Context, deadline time.Time, id core.OperatorID, batchHeaderHash [32]byte, send func(ctx context.Context) (*core.Signature, error)) core.SigningMessage {
+	requestedAt := time.Now()
+	ctx = metadata.AppendToOutgoingContext(ctx, api.OperatorIDHeader, id.Hex())
+	sig, attempts, err := c.retries.run(ctx, deadline, send)
+	latencyMs := float64(time.Since(requestedAt).Milliseconds())
+	if attempts > 1 {
+		c.logger.Debug("retried requesting signature from operator", "operator", id.Hex(), "attempts", attempts, "err", err)
+		c.metrics.ObserveRetries(err == nil, attempts-1)
+	}
+	if err != nil {
+		c.metrics.ObserveLatency(id.Hex(), false, latencyMs)
+		return core.SigningMessage{
+			Err:                  err,
+			Signature:            nil,
+			Operator:             id,
+			BatchHeaderHash:      batchHeaderHash,
+			AttestationLatencyMs: latencyMs,
+		}
+	}
+	c.metrics.ObserveLatency(id.Hex(), true, latencyMs)
+	return core.SigningMessage{
+		Signature:            sig,
+		Operator:             id,
+		BatchHeaderHash:      batchHeaderHash,
+		AttestationLatencyMs: latencyMs,
+		Err:                  nil,
+	}
+}
 
+func (c *dispatcher) dialOperator(op *core.IndexedOperatorInfo) (*grpc.ClientConn, error) {
+	creds := c.Credentials
+	if creds == nil {
+		creds = insecure.NewCredentials()
+	}
 	conn, err := grpc.NewClient(
 		core.OperatorSocket(op.Socket).GetV1DispersalSocket(),
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithTransportCredentials(creds),
 	)
 	if err != nil {
 		c.logger.Warn("Disperser cannot connect to operator dispersal socket", "dispersal_socket", core.OperatorSocket(op.Socket).GetV1DispersalSocket(), "err", err)
 		return nil, err
 	}
+	return conn, nil
+}
+
+func (c *dispatcher) sendChunks(ctx context.Context, blobs []*core.EncodedBlobMessage, batchHeader *core.BatchHeader, op *core.IndexedOperatorInfo) (*core.Signature, error) {
+	conn, err := c.dialOperator(op)
+	if err != nil {
+		return nil, err
+	}
 	defer conn.Close()
 
 	gc := node.NewDispersalClient(conn)
-	ctx, cancel := context.WithTimeout(ctx, c.Timeout)
-	defer cancel()
+	if c.EnableStreamingStoreChunks && c.supportsStreaming(ctx, gc, op) {
+		sig, err := c.streamChunks(ctx, gc, blobs, batchHeader)
+		if status.Code(err) != codes.Unimplemented {
+			return sig, err
+		}
+		c.logger.Debug("operator does not support StoreChunksStream, falling back to StoreChunks", "operator", op.Socket)
+	}
+
 	start := time.Now()
 	request, totalSize, err := GetStoreChunksRequest(blobs, batchHeader, c.EnableGnarkBundleEncoding)
 	if err != nil {
@@ -141,20 +190,172 @@ func (c *dispatcher) sendChunks(ctx context.Context, blobs []*core.EncodedBlobMe
 	}
 	c.logger.Debug("sending chunks to operator", "operator", op.Socket, "num blobs", len(blobs), "size", totalSize, "request message size", proto.Size(request), "request serialization time", time.Since(start), "use Gnark chunk encoding", c.EnableGnarkBundleEncoding)
 	opt := grpc.MaxCallSendMsgSize(60 * 1024 * 1024 * 1024)
+	// The context expires at the end of the attestation window
 	reply, err := gc.StoreChunks(ctx, request, opt)
 
 	if err != nil {
 		return nil, err
 	}
 
-	sigBytes := reply.GetSignature()
-	point, err := new(core.Signature).Deserialize(sigBytes)
+	return getSignature(reply)
+}
+
+// supportsStreaming returns true if the operator reported supporting StoreChunksStream in its NodeInfo.
+func (c *dispatcher) supportsStreaming(ctx context.Context, gc node.DispersalClient, op *core.IndexedOperatorInfo) bool {
+	supported, err := c.features.supports(ctx, op.Socket, api.NodeFeatureStoreChunksStream, func(ctx context.Context) (*node.NodeInfoReply, error) {
+		return gc.NodeInfo(ctx, &node.NodeInfoRequest{})
+	})
+	if err != nil {
+		c.logger.Debug("failed to get operator node info, assuming no optional features", "operator", op.Socket, "err", err)
+	}
+	return supported
+}
+
+// streamChunks sends the chunks of a batch to an operator with StoreChunksStream, serializing one blob at a time.
+func (c *dispatcher) streamChunks(ctx context.Context, gc node.DispersalClient, blobs []*core.EncodedBlobMessage, batchHeader *core.BatchHeader) (*core.Signature, error) {
+	// The context expires at the end of the attestation window
+	stream, err := gc.StoreChunksStream(ctx, grpc.MaxCallSendMsgSize(60*1024*1024*1024))
+	if err != nil {
+		return nil, err
+	}
+	send := func(request *node.StoreChunksStreamRequest) error {
+		err := stream.Send(request)
+		if errors.Is(err, io.EOF) {
+			// The operator ended the stream, and the reason is returned when receiving the reply
+			_, err = stream.CloseAndRecv()
+		}
+		return err
+	}
+
+	err = send(&node.StoreChunksStreamRequest{
+		Payload: &node.StoreChunksStreamRequest_BatchHeader{BatchHeader: getBatchHeaderMessage(batchHeader)},
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, blob := range blobs {
+		blobMessage, err := getBlobMessage(blob, c.EnableGnarkBundleEncoding)
+		if err != nil {
+			return nil, err
+		}
+		err = send(&node.StoreChunksStreamRequest{
+			Payload: &node.StoreChunksStreamRequest_Blob{Blob: blobMessage},
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	reply, err := stream.CloseAndRecv()
 	if err != nil {
 		return nil, err
 	}
-	sig := &core.Signature{G1Point: point}
-	return sig, nil
+	return getSignature(reply)
 }
+
+// getSignature deserializes the operator's signature from a StoreChunks reply.
+func getSignature(reply *node.StoreChunksReply) (*core.Signature, error) {
+	point, err := new(core.Signature).Deserialize(reply.GetSignature())
+	if err != nil {
+		return nil, err
+	}
+	return &core.Signature{G1Point: point}, nil
+}
+
+// SendBlobsToOperator stores the blobs of a minibatch on the operator, and returns the operator's signatures on the
+// blob header hashes, in the order of the blobs. The signature of a blob the operator discarded is nil.
+func (c *dispatcher) SendBlobsToOperator(ctx context.Context, blobs []*core.EncodedBlobMessage, batchHeader *core.BatchHeader, op *core.IndexedOperatorInfo) ([]*core.Signature, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.Timeout)
+	defer cancel()
+
+	conn, err := c.dialOperator(op)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	gc := node.NewDispersalClient(conn)
+	request, totalSize, err := GetStoreBlobsRequest(blobs, batchHeader, c.EnableGnarkBundleEncoding)
+	if err != nil {
+		return nil, err
+	}
+	c.logger.Debug("sending blobs to operator", "operator", op.Socket, "num blobs", len(blobs), "size", totalSize, "request message size", proto.Size(request), "use Gnark chunk encoding", c.EnableGnarkBundleEncoding)
+	opt := grpc.MaxCallSendMsgSize(60 * 1024 * 1024 * 1024)
+	reply, err := gc.StoreBlobs(ctx, request, opt)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(reply.GetSignatures()) != len(blobs) {
+		return nil, fmt.Errorf("number of signatures (%d) does not match the number of blobs (%d)", len(reply.GetSignatures()), len(blobs))
+	}
+	signatures := make([]*core.Signature, len(blobs))
+	for i, sigBytes := range reply.GetSignatures() {
+		if len(sigBytes.GetValue()) == 0 {
+			// The operator discarded the blob
+			continue
+		}
+		point, err := new(core.Signature).Deserialize(sigBytes.GetValue())
+		if err != nil {
+			return nil, err
+		}
+		signatures[i] = &core.Signature{G1Point: point}
+	}
+	return signatures, nil
+}
+
+// AttestBatch requests the operators to sign the batch made of the blobs they stored with SendBlobsToOperator.
+// Like DisperseBatch, it returns a channel which receives one signing message for each operator.
+func (c *dispatcher) AttestBatch(ctx context.Context, state *core.IndexedOperatorState, blobHeaderHashes [][32]byte, batchHeader *core.BatchHeader) (chan core.SigningMessage, error) {
+	batchHeaderHash, err := batchHeader.GetBatchHeaderHash()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get batch header hash: %w", err)
+	}
+	hashes := make([][]byte, len(blobHeaderHashes))
+	for i := range blobHeaderHashes {
+		hashes[i] = blobHeaderHashes[i][:]
+	}
+	request := &node.AttestBatchRequest{
+		BatchHeader:      getBatchHeaderMessage(batchHeader),
+		BlobHeaderHashes: hashes,
+	}
+
+	update := make(chan core.SigningMessage, len(state.IndexedOperators))
+	// Requests to all operators, including retries, must complete within the attestation window
+	deadline := time.Now().Add(c.Timeout)
+	for id, op := range state.IndexedOperators {
+		go func(op core.IndexedOperatorInfo, id core.OperatorID) {
+			update <- c.requestSignature(ctx, deadline, id, batchHeaderHash, func(ctx context.Context) (*core.Signature, error) {
+				return c.attestBatch(ctx, request, &op)
+			})
+		}(core.IndexedOperatorInfo{
+			PubkeyG1: op.PubkeyG1,
+			PubkeyG2: op.PubkeyG2,
+			Socket:   op.Socket,
+		}, id)
+	}
+	return update, nil
+}
+
+func (c *dispatcher) attestBatch(ctx context.Context, request *node.AttestBatchRequest, op *core.IndexedOperatorInfo) (*core.Signature, error) {
+	conn, err := c.dialOperator(op)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	gc := node.NewDispersalClient(conn)
+	reply, err := gc.AttestBatch(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	point, err := new(core.Signature).Deserialize(reply.GetSignature())
+	if err != nil {
+		return nil, err
+	}
+	return &core.Signature{G1Point: point}, nil
+}
+
 func GetStoreChunksRequest(blobMessages []*core.EncodedBlobMessage, batchHeader *core.BatchHeader, useGnarkBundleEncoding bool) (*node.StoreChunksRequest, int64, error) {
 	blobs := make([]*node.Blob, len(blobMessages))
 	totalSize := int64(0)
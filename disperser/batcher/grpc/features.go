@@ -0,0 +1,71 @@
+package dispatcher
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api/grpc/node"
+)
+
+// featuresTTL is how long the features reported by an operator are used before they're fetched again, so that
+// operators upgrading their nodes are picked up.
+const featuresTTL = 10 * time.Minute
+
+// operatorFeatures caches the optional features the operators report in their NodeInfo replies, so that the
+// dispatcher can adapt to each operator while nodes are upgraded one at a time.
+type operatorFeatures struct {
+	ttl time.Duration
+	// now returns the current time
+	now func() time.Time
+
+	// mu guards features
+	mu sync.Mutex
+	// features are the features reported by each operator, keyed by socket
+	features map[string]*reportedFeatures
+}
+
+type reportedFeatures struct {
+	features  map[string]struct{}
+	fetchedAt time.Time
+}
+
+func newOperatorFeatures(ttl time.Duration) *operatorFeatures {
+	return &operatorFeatures{
+		ttl:      ttl,
+		now:      time.Now,
+		features: make(map[string]*reportedFeatures),
+	}
+}
+
+// supports returns true if the operator at the socket reported the feature. The operator's features are fetched
+// with fetchInfo if they aren't known yet or have expired. Operators which fail to report their features, or predate
+// feature negotiation, are treated as supporting none until their features are fetched again.
+func (f *operatorFeatures) supports(
+	ctx context.Context,
+	socket string,
+	feature string,
+	fetchInfo func(ctx context.Context) (*node.NodeInfoReply, error),
+) (bool, error) {
+	f.mu.Lock()
+	reported, ok := f.features[socket]
+	f.mu.Unlock()
+
+	var err error
+	if !ok || f.now().Sub(reported.fetchedAt) >= f.ttl {
+		reported = &reportedFeatures{
+			features:  make(map[string]struct{}),
+			fetchedAt: f.now(),
+		}
+		var info *node.NodeInfoReply
+		info, err = fetchInfo(ctx)
+		for _, feature := range info.GetFeatures() {
+			reported.features[feature] = struct{}{}
+		}
+		f.mu.Lock()
+		f.features[socket] = reported
+		f.mu.Unlock()
+	}
+	_, supported := reported.features[feature]
+	return supported, err
+}
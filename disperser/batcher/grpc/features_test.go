@@ -0,0 +1,61 @@
+package dispatcher
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api"
+	"github.com/Layr-Labs/eigenda/api/grpc/node"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOperatorFeatures(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	f := newOperatorFeatures(time.Minute)
+	f.now = func() time.Time { return now }
+
+	fetches := 0
+	var reply *node.NodeInfoReply
+	var fetchErr error
+	fetchInfo := func(ctx context.Context) (*node.NodeInfoReply, error) {
+		fetches++
+		return reply, fetchErr
+	}
+
+	// Operators predating feature negotiation report no features
+	reply = &node.NodeInfoReply{Semver: "0.8.0"}
+	supported, err := f.supports(ctx, "old", api.NodeFeatureStoreChunksStream, fetchInfo)
+	require.NoError(t, err)
+	require.False(t, supported)
+
+	reply = &node.NodeInfoReply{Semver: "0.9.0", Features: []string{api.NodeFeatureStoreChunksStream}}
+	supported, err = f.supports(ctx, "new", api.NodeFeatureStoreChunksStream, fetchInfo)
+	require.NoError(t, err)
+	require.True(t, supported)
+	require.Equal(t, 2, fetches)
+
+	// The features are cached until they expire, after which upgraded operators are picked up
+	supported, err = f.supports(ctx, "old", api.NodeFeatureStoreChunksStream, fetchInfo)
+	require.NoError(t, err)
+	require.False(t, supported)
+	require.Equal(t, 2, fetches)
+	now = now.Add(time.Minute)
+	supported, err = f.supports(ctx, "old", api.NodeFeatureStoreChunksStream, fetchInfo)
+	require.NoError(t, err)
+	require.True(t, supported)
+	require.Equal(t, 3, fetches)
+
+	// Operators which fail to report their features are assumed to support none until the next fetch
+	now = now.Add(time.Minute)
+	reply, fetchErr = nil, errors.New("unavailable")
+	supported, err = f.supports(ctx, "new", api.NodeFeatureStoreChunksStream, fetchInfo)
+	require.Error(t, err)
+	require.False(t, supported)
+	supported, err = f.supports(ctx, "new", api.NodeFeatureStoreChunksStream, fetchInfo)
+	require.NoError(t, err)
+	require.False(t, supported)
+	require.Equal(t, 4, fetches)
+}
@@ -0,0 +1,89 @@
+package dispatcher
+
+import (
+	"context"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/core"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RetryConfig configures the retries of StoreChunks requests which failed with a transient error. Retries are only
+// sent within the attestation window, i.e. before the dispatcher's timeout since the batch was dispersed.
+type RetryConfig struct {
+	// MaxAttempts is the maximum number of StoreChunks requests sent to an operator for a batch.
+	// 0 or 1 disables retries.
+	MaxAttempts int
+	// InitialBackoff is the time waited before the first retry. It doubles with every retry.
+	InitialBackoff time.Duration
+	// MaxBackoff bounds the time waited between retries.
+	MaxBackoff time.Duration
+}
+
+// retryScheduler re-sends chunks to an operator with exponential backoff until it succeeds, fails with an error which
+// isn't transient, runs out of attempts, or the attestation window closes
+type retryScheduler struct {
+	config RetryConfig
+	// sleep waits for the given duration, returning early if the context is done
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+func newRetryScheduler(config RetryConfig) *retryScheduler {
+	return &retryScheduler{
+		config: config,
+		sleep:  sleepContext,
+	}
+}
+
+// run calls send with a context which expires at the deadline of the attestation window, and retries it while the
+// error is retryable. It returns the result of the last attempt, and the number of attempts made.
+func (r *retryScheduler) run(
+	ctx context.Context,
+	deadline time.Time,
+	send func(ctx context.Context) (*core.Signature, error),
+) (*core.Signature, int, error) {
+	ctx, cancel := context.WithDeadline(ctx, deadline)
+	defer cancel()
+
+	maxAttempts := max(r.config.MaxAttempts, 1)
+	backoff := r.config.InitialBackoff
+	attempt := 0
+	for {
+		attempt++
+		sig, err := send(ctx)
+		if err == nil || attempt >= maxAttempts || !isRetryable(err) {
+			return sig, attempt, err
+		}
+
+		// Don't retry if the window would close before the retry is sent
+		if time.Now().Add(backoff).After(deadline) {
+			return nil, attempt, err
+		}
+		if sleepErr := r.sleep(ctx, backoff); sleepErr != nil {
+			return nil, attempt, err
+		}
+		backoff = min(2*backoff, max(r.config.MaxBackoff, r.config.InitialBackoff))
+	}
+}
+
+// isRetryable returns true if the StoreChunks request failed with an error which may be transient
+func isRetryable(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.ResourceExhausted, codes.Aborted:
+		return true
+	default:
+		return false
+	}
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
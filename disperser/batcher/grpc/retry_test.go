@@ -0,0 +1,100 @@
+package dispatcher
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// newTestRetryScheduler creates a retryScheduler which records the backoffs instead of sleeping
+func newTestRetryScheduler(config RetryConfig) (*retryScheduler, *[]time.Duration) {
+	backoffs := make([]time.Duration, 0)
+	r := newRetryScheduler(config)
+	r.sleep = func(ctx context.Context, d time.Duration) error {
+		backoffs = append(backoffs, d)
+		return ctx.Err()
+	}
+	return r, &backoffs
+}
+
+// failingSender fails with the given errors in turn, and then succeeds
+func failingSender(errs ...error) func(ctx context.Context) (*core.Signature, error) {
+	return func(ctx context.Context) (*core.Signature, error) {
+		if len(errs) > 0 {
+			err := errs[0]
+			errs = errs[1:]
+			return nil, err
+		}
+		return &core.Signature{}, nil
+	}
+}
+
+func TestRetrySchedulerRetriesTransientErrors(t *testing.T) {
+	r, backoffs := newTestRetryScheduler(RetryConfig{
+		MaxAttempts:    5,
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     250 * time.Millisecond,
+	})
+	unavailable := status.Error(codes.Unavailable, "connection refused")
+	exhausted := status.Error(codes.ResourceExhausted, "rate limited")
+
+	sig, attempts, err := r.run(context.Background(), time.Now().Add(time.Minute),
+		failingSender(unavailable, exhausted, unavailable))
+	require.NoError(t, err)
+	require.NotNil(t, sig)
+	require.Equal(t, 4, attempts)
+	require.Equal(t, []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 250 * time.Millisecond}, *backoffs)
+}
+
+func TestRetrySchedulerStopsRetrying(t *testing.T) {
+	unavailable := status.Error(codes.Unavailable, "connection refused")
+
+	// permanent errors aren't retried
+	r, backoffs := newTestRetryScheduler(RetryConfig{MaxAttempts: 5, InitialBackoff: time.Millisecond, MaxBackoff: time.Second})
+	invalid := status.Error(codes.InvalidArgument, "invalid batch")
+	_, attempts, err := r.run(context.Background(), time.Now().Add(time.Minute), failingSender(invalid))
+	require.ErrorIs(t, err, invalid)
+	require.Equal(t, 1, attempts)
+	require.Empty(t, *backoffs)
+	_, attempts, err = r.run(context.Background(), time.Now().Add(time.Minute), failingSender(errors.New("no connection")))
+	require.Error(t, err)
+	require.Equal(t, 1, attempts)
+
+	// retries are bounded by the max attempts
+	_, attempts, err = r.run(context.Background(), time.Now().Add(time.Minute),
+		failingSender(unavailable, unavailable, unavailable, unavailable, unavailable, unavailable))
+	require.ErrorIs(t, err, unavailable)
+	require.Equal(t, 5, attempts)
+
+	// retries aren't sent after the attestation window closes
+	r, backoffs = newTestRetryScheduler(RetryConfig{MaxAttempts: 5, InitialBackoff: time.Second, MaxBackoff: time.Second})
+	_, attempts, err = r.run(context.Background(), time.Now().Add(500*time.Millisecond), failingSender(unavailable, unavailable))
+	require.ErrorIs(t, err, unavailable)
+	require.Equal(t, 1, attempts)
+	require.Empty(t, *backoffs)
+
+	// retries are disabled by default
+	r, _ = newTestRetryScheduler(RetryConfig{})
+	_, attempts, err = r.run(context.Background(), time.Now().Add(time.Minute), failingSender(unavailable))
+	require.ErrorIs(t, err, unavailable)
+	require.Equal(t, 1, attempts)
+}
+
+func TestRetrySchedulerAttemptDeadline(t *testing.T) {
+	r := newRetryScheduler(RetryConfig{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond})
+	deadline := time.Now().Add(time.Minute)
+
+	_, _, err := r.run(context.Background(), deadline, func(ctx context.Context) (*core.Signature, error) {
+		ctxDeadline, ok := ctx.Deadline()
+		require.True(t, ok)
+		require.Equal(t, deadline, ctxDeadline)
+		return &core.Signature{}, nil
+	})
+	require.NoError(t, err)
+}
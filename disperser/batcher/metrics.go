@@ -25,6 +25,7 @@ const (
 	FailGetBatchID             FailReason = "get_batch_id"
 	FailUpdateConfirmationInfo FailReason = "update_confirmation_info"
 	FailNoAggregatedSignature  FailReason = "no_aggregated_signature"
+	FailMinibatchDispersal     FailReason = "minibatch_dispersal"
 )
 
 type MetricsConfig struct {
@@ -54,6 +55,7 @@ type FinalizerMetrics struct {
 type DispatcherMetrics struct {
 	Latency         *prometheus.SummaryVec
 	OperatorLatency *prometheus.GaugeVec
+	Retries         *prometheus.CounterVec
 }
 
 type Metrics struct {
@@ -188,6 +190,14 @@ func NewMetrics(httpPort string, logger logging.Logger) *Metrics {
 			},
 			[]string{"operator_id"},
 		),
+		Retries: promauto.With(reg).NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "attestation_retries_total",
+				Help:      "number of StoreChunks requests retried, by the status of the final attempt",
+			},
+			[]string{"status"},
+		),
 	}
 
 	metrics := &Metrics{
@@ -308,6 +318,15 @@ func (t *DispatcherMetrics) ObserveLatency(operatorId string, success bool, late
 	}
 }
 
+// ObserveRetries records the number of times the StoreChunks request to an operator was retried
+func (t *DispatcherMetrics) ObserveRetries(success bool, retries int) {
+	label := "success"
+	if !success {
+		label = "failure"
+	}
+	t.Retries.WithLabelValues(label).Add(float64(retries))
+}
+
 // UpdateCompletedBlob increments the number and updates size of processed blobs.
 func (g *Metrics) UpdateCompletedBlob(size int, status disperser.BlobStatus) {
 	switch status {
@@ -0,0 +1,163 @@
+package batcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/Layr-Labs/eigenda/api"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/disperser"
+	"github.com/gammazero/workerpool"
+	"google.golang.org/grpc/metadata"
+)
+
+// disperseMinibatches disperses the blobs of the batch to the operators in minibatches of MinibatchSize blobs, so that
+// the size of the requests is bounded no matter how large the batch is, and then requests the operators to attest the
+// batch made of the blobs they stored. The returned channel receives the signing message of each operator.
+//
+// Blobs which weren't stored by enough operators to meet the confirmation threshold of all their quorums are failed
+// and removed from the batch, so that they don't prevent the rest of the batch from being confirmed.
+func (b *Batcher) disperseMinibatches(ctx context.Context, batch *batch) (chan core.SigningMessage, error) {
+	numBlobs := len(batch.EncodedBlobs)
+	// storedBy[i] is the set of operators which stored the i-th blob of the batch
+	storedBy := make([]map[core.OperatorID]struct{}, numBlobs)
+	for i := range storedBy {
+		storedBy[i] = make(map[core.OperatorID]struct{})
+	}
+
+	var mu sync.Mutex
+	for start := 0; start < numBlobs; start += int(b.MinibatchSize) {
+		end := min(start+int(b.MinibatchSize), numBlobs)
+		pool := workerpool.New(int(b.MaxNodeConnections))
+		for id, op := range batch.State.IndexedOperators {
+			id := id
+			op := op
+			blobs := make([]*core.EncodedBlobMessage, 0, end-start)
+			hasAnyBundles := false
+			for _, blob := range batch.EncodedBlobs[start:end] {
+				if len(blob.EncodedBundlesByOperator[id]) > 0 {
+					hasAnyBundles = true
+				}
+				blobs = append(blobs, &core.EncodedBlobMessage{
+					BlobHeader: blob.BlobHeader,
+					// Bundles will be empty if the operator is not in the quorums blob is dispersed on
+					EncodedBundles: blob.EncodedBundlesByOperator[id],
+				})
+			}
+			if !hasAnyBundles {
+				// Operator is not part of any quorum of the minibatch, no need to send blobs
+				continue
+			}
+
+			offset := start
+			pool.Submit(func() {
+				opCtx := metadata.AppendToOutgoingContext(ctx, api.OperatorIDHeader, id.Hex())
+				signatures, err := b.sendMinibatch(opCtx, blobs, batch.BatchHeader, op)
+				if err != nil {
+					b.logger.Warn("failed to disperse minibatch to operator", "operator", id.Hex(), "numBlobs", len(blobs), "err", err)
+					return
+				}
+				mu.Lock()
+				defer mu.Unlock()
+				for i, sig := range signatures {
+					if sig != nil {
+						storedBy[offset+i][id] = struct{}{}
+					}
+				}
+			})
+		}
+		pool.StopWait()
+		b.logger.Debug("dispersed minibatch", "firstBlob", start, "numBlobs", end-start)
+	}
+
+	// Remove the blobs which can't be attested from the batch
+	failed := make([]*disperser.BlobMetadata, 0)
+	encodedBlobs := make([]core.EncodedBlob, 0, numBlobs)
+	blobHeaders := make([]*core.BlobHeader, 0, numBlobs)
+	blobMetadata := make([]*disperser.BlobMetadata, 0, numBlobs)
+	for i, header := range batch.BlobHeaders {
+		if !isBlobStored(batch.State, header, storedBy[i]) {
+			failed = append(failed, batch.BlobMetadata[i])
+			continue
+		}
+		encodedBlobs = append(encodedBlobs, batch.EncodedBlobs[i])
+		blobHeaders = append(blobHeaders, header)
+		blobMetadata = append(blobMetadata, batch.BlobMetadata[i])
+	}
+	if len(blobHeaders) == 0 {
+		_ = b.handleFailure(ctx, batch.BlobMetadata, FailMinibatchDispersal)
+		return nil, errors.New("no blobs were stored by enough operators")
+	}
+	if len(failed) > 0 {
+		b.logger.Warn("removing blobs which weren't stored by enough operators from the batch", "numFailed", len(failed), "numBlobs", numBlobs)
+		_ = b.handleFailure(ctx, failed, FailMinibatchDispersal)
+		batch.EncodedBlobs = encodedBlobs
+		batch.BlobHeaders = blobHeaders
+		batch.BlobMetadata = blobMetadata
+		tree, err := batch.BatchHeader.SetBatchRoot(batch.BlobHeaders)
+		if err != nil {
+			_ = b.handleFailure(ctx, batch.BlobMetadata, FailBatchHeaderHash)
+			return nil, fmt.Errorf("failed to set batch root: %w", err)
+		}
+		batch.MerkleTree = tree
+	}
+
+	blobHeaderHashes := make([][32]byte, len(batch.BlobHeaders))
+	for i, header := range batch.BlobHeaders {
+		hash, err := header.GetBlobHeaderHash()
+		if err != nil {
+			_ = b.handleFailure(ctx, batch.BlobMetadata, FailBatchHeaderHash)
+			return nil, fmt.Errorf("failed to get blob header hash: %w", err)
+		}
+		blobHeaderHashes[i] = hash
+	}
+	update, err := b.Dispatcher.AttestBatch(ctx, batch.State, blobHeaderHashes, batch.BatchHeader)
+	if err != nil {
+		_ = b.handleFailure(ctx, batch.BlobMetadata, FailMinibatchDispersal)
+		return nil, fmt.Errorf("failed to request batch attestation: %w", err)
+	}
+	return update, nil
+}
+
+// sendMinibatch sends the blobs of a minibatch to an operator, retrying up to MaxNumRetriesPerDispersal times
+func (b *Batcher) sendMinibatch(ctx context.Context, blobs []*core.EncodedBlobMessage, batchHeader *core.BatchHeader, op *core.IndexedOperatorInfo) ([]*core.Signature, error) {
+	var err error
+	for attempt := uint(0); attempt <= b.MaxNumRetriesPerDispersal; attempt++ {
+		var signatures []*core.Signature
+		signatures, err = b.Dispatcher.SendBlobsToOperator(ctx, blobs, batchHeader, op)
+		if err == nil {
+			return signatures, nil
+		}
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	return nil, err
+}
+
+// isBlobStored returns whether the operators which stored the blob hold enough stake to meet the confirmation
+// threshold of each quorum of the blob
+func isBlobStored(state *core.IndexedOperatorState, header *core.BlobHeader, storedBy map[core.OperatorID]struct{}) bool {
+	for _, quorumInfo := range header.QuorumInfos {
+		total, ok := state.Totals[quorumInfo.QuorumID]
+		if !ok || total.Stake.Sign() == 0 {
+			return false
+		}
+		stored := big.NewInt(0)
+		for id := range storedBy {
+			if op, ok := state.Operators[quorumInfo.QuorumID][id]; ok {
+				stored.Add(stored, op.Stake)
+			}
+		}
+		// stored / total >= confirmationThreshold / 100
+		storedPercentage := new(big.Int).Mul(stored, big.NewInt(100))
+		threshold := new(big.Int).Mul(total.Stake, big.NewInt(int64(quorumInfo.ConfirmationThreshold)))
+		if storedPercentage.Cmp(threshold) < 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -8,11 +8,15 @@ import (
 	"github.com/Layr-Labs/eigenda/common/aws"
 	"github.com/Layr-Labs/eigenda/common/geth"
 	"github.com/Layr-Labs/eigenda/common/ratelimit"
+	"github.com/Layr-Labs/eigenda/common/tracing"
+	"github.com/Layr-Labs/eigenda/core"
 	"github.com/Layr-Labs/eigenda/disperser"
 	"github.com/Layr-Labs/eigenda/disperser/apiserver"
 	"github.com/Layr-Labs/eigenda/disperser/cmd/apiserver/flags"
+	"github.com/Layr-Labs/eigenda/disperser/common/admin"
 	"github.com/Layr-Labs/eigenda/disperser/common/blobstore"
 	"github.com/Layr-Labs/eigenda/encoding/kzg"
+	gethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/urfave/cli"
 )
 
@@ -27,6 +31,7 @@ type Config struct {
 	DisperserVersion            DisperserVersion
 	AwsClientConfig             aws.ClientConfig
 	BlobstoreConfig             blobstore.Config
+	MetadataStoreConfig         blobstore.MetadataStoreConfig
 	ServerConfig                disperser.ServerConfig
 	LoggerConfig                common.LoggerConfig
 	MetricsConfig               disperser.MetricsConfig
@@ -45,6 +50,15 @@ type Config struct {
 	MaxBlobSize                 int
 	MaxNumSymbolsPerBlob        uint
 	OnchainStateRefreshInterval time.Duration
+	QuorumMinRetentionPeriods   map[core.QuorumID]time.Duration
+	BackpressureConfig          apiserver.BackpressureConfig
+	AccountConcurrencyConfig    apiserver.AccountConcurrencyConfig
+	CertificationEventsConfig   apiserver.CertificationEventsConfig
+	AdminConfig                 admin.Config
+	TracingConfig               tracing.Config
+	BlobIntegrityKMSKeyID       string
+	BlobIntegrityConfig         apiserver.BlobIntegrityConfig
+	DispersalReceiptKMSKeyID    string
 
 	BLSOperatorStateRetrieverAddr string
 	EigenDAServiceManagerAddr     string
@@ -90,9 +104,20 @@ func NewConfig(ctx *cli.Context) (Config, error) {
 		}
 	}
 
+	quorumMinRetentionPeriods, err := readQuorumMinRetentionPeriods(ctx)
+	if err != nil {
+		return Config{}, err
+	}
+
+	maxInFlightRequestsOverrides, err := readMaxInFlightRequestsOverrides(ctx)
+	if err != nil {
+		return Config{}, err
+	}
+
 	config := Config{
-		DisperserVersion: DisperserVersion(version),
-		AwsClientConfig:  aws.ReadClientConfig(ctx, flags.FlagPrefix),
+		DisperserVersion:    DisperserVersion(version),
+		AwsClientConfig:     aws.ReadClientConfig(ctx, flags.FlagPrefix),
+		MetadataStoreConfig: blobstore.ReadMetadataStoreConfig(ctx, flags.FlagPrefix),
 		ServerConfig: disperser.ServerConfig{
 			GrpcPort:      ctx.GlobalString(flags.GrpcPortFlag.Name),
 			GrpcTimeout:   ctx.GlobalDuration(flags.GrpcTimeoutFlag.Name),
@@ -123,9 +148,76 @@ func NewConfig(ctx *cli.Context) (Config, error) {
 		MaxBlobSize:                 ctx.GlobalInt(flags.MaxBlobSize.Name),
 		MaxNumSymbolsPerBlob:        ctx.GlobalUint(flags.MaxNumSymbolsPerBlob.Name),
 		OnchainStateRefreshInterval: ctx.GlobalDuration(flags.OnchainStateRefreshInterval.Name),
+		QuorumMinRetentionPeriods:   quorumMinRetentionPeriods,
+		AdminConfig:                 admin.ReadCLIConfig(ctx, flags.FlagPrefix),
+		BlobIntegrityKMSKeyID:       ctx.GlobalString(flags.BlobIntegrityKMSKeyIDFlag.Name),
+		BlobIntegrityConfig: apiserver.BlobIntegrityConfig{
+			MaxSignatureAge:        ctx.GlobalDuration(flags.BlobIntegrityMaxSignatureAgeFlag.Name),
+			AccountChecksPerSecond: ctx.GlobalFloat64(flags.BlobIntegrityChecksPerSecondFlag.Name),
+			AccountBurst:           ctx.GlobalInt(flags.BlobIntegrityBurstFlag.Name),
+			MaxConcurrentChecks:    ctx.GlobalInt(flags.BlobIntegrityMaxConcurrentChecksFlag.Name),
+		},
+		DispersalReceiptKMSKeyID: ctx.GlobalString(flags.DispersalReceiptKMSKeyIDFlag.Name),
+		BackpressureConfig: apiserver.BackpressureConfig{
+			MaxQueuedBlobs:            int32(ctx.GlobalInt(flags.MaxQueuedBlobs.Name)),
+			QueueDepthRefreshInterval: ctx.GlobalDuration(flags.QueueDepthRefreshInterval.Name),
+		},
+		AccountConcurrencyConfig: apiserver.AccountConcurrencyConfig{
+			DefaultMaxInFlightRequests:   ctx.GlobalInt(flags.MaxInFlightRequestsPerAccount.Name),
+			MaxInFlightRequestsOverrides: maxInFlightRequestsOverrides,
+			PendingBlobsRefreshInterval:  ctx.GlobalDuration(flags.PendingBlobsRefreshInterval.Name),
+		},
+		CertificationEventsConfig: apiserver.CertificationEventsConfig{
+			MaxSubscribers: int32(ctx.GlobalInt(flags.CertificationEventsMaxSubscribers.Name)),
+			PollInterval:   ctx.GlobalDuration(flags.CertificationEventsPollInterval.Name),
+			ReplayWindow:   ctx.GlobalDuration(flags.CertificationEventsReplayWindow.Name),
+		},
 
 		BLSOperatorStateRetrieverAddr: ctx.GlobalString(flags.BlsOperatorStateRetrieverFlag.Name),
 		EigenDAServiceManagerAddr:     ctx.GlobalString(flags.EigenDAServiceManagerFlag.Name),
 	}
+	config.TracingConfig = tracing.ReadCLIConfig(ctx, flags.FlagPrefix)
+	config.TracingConfig.ServiceName = "disperser-apiserver"
 	return config, nil
 }
+
+func readQuorumMinRetentionPeriods(ctx *cli.Context) (map[core.QuorumID]time.Duration, error) {
+	quorums := ctx.GlobalIntSlice(flags.MinRetentionPeriodQuorums.Name)
+	periods := ctx.GlobalStringSlice(flags.MinRetentionPeriods.Name)
+	if len(quorums) != len(periods) {
+		return nil, fmt.Errorf("number of min retention period quorums (%d) does not match number of min retention periods (%d)", len(quorums), len(periods))
+	}
+
+	minRetentionPeriods := make(map[core.QuorumID]time.Duration, len(quorums))
+	for i, quorum := range quorums {
+		if quorum < 0 || quorum > core.MaxQuorumID {
+			return nil, fmt.Errorf("invalid min retention period quorum %d", quorum)
+		}
+		period, err := time.ParseDuration(periods[i])
+		if err != nil {
+			return nil, fmt.Errorf("invalid min retention period %q for quorum %d: %w", periods[i], quorum, err)
+		}
+		minRetentionPeriods[core.QuorumID(quorum)] = period
+	}
+	return minRetentionPeriods, nil
+}
+
+func readMaxInFlightRequestsOverrides(ctx *cli.Context) (map[gethcommon.Address]int, error) {
+	accounts := ctx.GlobalStringSlice(flags.MaxInFlightRequestsOverrideAccounts.Name)
+	limits := ctx.GlobalIntSlice(flags.MaxInFlightRequestsOverrides.Name)
+	if len(accounts) != len(limits) {
+		return nil, fmt.Errorf("number of max in-flight requests override accounts (%d) does not match number of overrides (%d)", len(accounts), len(limits))
+	}
+
+	overrides := make(map[gethcommon.Address]int, len(accounts))
+	for i, account := range accounts {
+		if !gethcommon.IsHexAddress(account) {
+			return nil, fmt.Errorf("invalid max in-flight requests override account %s", account)
+		}
+		if limits[i] < 0 {
+			return nil, fmt.Errorf("invalid max in-flight requests override %d for account %s", limits[i], account)
+		}
+		overrides[gethcommon.HexToAddress(account)] = limits[i]
+	}
+	return overrides, nil
+}
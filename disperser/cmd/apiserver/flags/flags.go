@@ -8,7 +8,10 @@ import (
 	"github.com/Layr-Labs/eigenda/common/aws"
 	"github.com/Layr-Labs/eigenda/common/geth"
 	"github.com/Layr-Labs/eigenda/common/ratelimit"
+	"github.com/Layr-Labs/eigenda/common/tracing"
 	"github.com/Layr-Labs/eigenda/disperser/apiserver"
+	"github.com/Layr-Labs/eigenda/disperser/common/admin"
+	"github.com/Layr-Labs/eigenda/disperser/common/blobstore"
 	"github.com/Layr-Labs/eigenda/encoding"
 	"github.com/Layr-Labs/eigenda/encoding/kzg"
 	"github.com/urfave/cli"
@@ -161,6 +164,119 @@ var (
 		Required: false,
 		EnvVar:   common.PrefixEnvVar(envVarPrefix, "ENABLE_PPROF"),
 	}
+	MinRetentionPeriodQuorums = cli.IntSliceFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "min-retention-period-quorums"),
+		Usage:    "quorums for which a minimum requested retention period is enforced. This flag is only relevant in v2",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "MIN_RETENTION_PERIOD_QUORUMS"),
+	}
+	MinRetentionPeriods = cli.StringSliceFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "min-retention-periods"),
+		Usage:    "minimum retention periods (e.g. 24h) which can be requested for each of the quorums in min-retention-period-quorums. This flag is only relevant in v2",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "MIN_RETENTION_PERIODS"),
+	}
+	MaxQueuedBlobs = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "max-queued-blobs"),
+		Usage:    "number of blobs waiting to be encoded above which new dispersal requests are rejected. 0 disables the limit. This flag is only relevant in v2",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "MAX_QUEUED_BLOBS"),
+		Value:    0,
+	}
+	QueueDepthRefreshInterval = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "queue-depth-refresh-interval"),
+		Usage:    "interval at which the number of blobs waiting to be encoded is refreshed. This flag is only relevant in v2",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "QUEUE_DEPTH_REFRESH_INTERVAL"),
+		Value:    5 * time.Second,
+	}
+	MaxInFlightRequestsPerAccount = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "max-in-flight-requests-per-account"),
+		Usage:    "number of in-flight blobs of an account, i.e. blobs being dispersed or waiting to be encoded, above which new requests of the account are rejected. 0 disables the limit. This flag is only relevant in v2",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "MAX_IN_FLIGHT_REQUESTS_PER_ACCOUNT"),
+		Value:    0,
+	}
+	MaxInFlightRequestsOverrideAccounts = cli.StringSliceFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "max-in-flight-requests-override-accounts"),
+		Usage:    "accounts whose limit on in-flight blobs overrides the default. Must be in the same order as max-in-flight-requests-overrides. This flag is only relevant in v2",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "MAX_IN_FLIGHT_REQUESTS_OVERRIDE_ACCOUNTS"),
+	}
+	MaxInFlightRequestsOverrides = cli.IntSliceFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "max-in-flight-requests-overrides"),
+		Usage:    "limits on in-flight blobs of the accounts in max-in-flight-requests-override-accounts. 0 disables the limit of an account. This flag is only relevant in v2",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "MAX_IN_FLIGHT_REQUESTS_OVERRIDES"),
+	}
+	PendingBlobsRefreshInterval = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "pending-blobs-refresh-interval"),
+		Usage:    "interval at which the number of blobs of each account waiting to be encoded is refreshed, if in-flight blobs are limited. This flag is only relevant in v2",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "PENDING_BLOBS_REFRESH_INTERVAL"),
+		Value:    5 * time.Second,
+	}
+	CertificationEventsMaxSubscribers = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "certification-events-max-subscribers"),
+		Usage:    "maximum number of concurrent subscriptions to certification events. 0 disables subscriptions. This flag is only relevant in v2",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "CERTIFICATION_EVENTS_MAX_SUBSCRIBERS"),
+		Value:    100,
+	}
+	CertificationEventsPollInterval = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "certification-events-poll-interval"),
+		Usage:    "interval at which new certification events are polled. The poller is shared by all subscriptions. This flag is only relevant in v2",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "CERTIFICATION_EVENTS_POLL_INTERVAL"),
+		Value:    1 * time.Second,
+	}
+	CertificationEventsReplayWindow = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "certification-events-replay-window"),
+		Usage:    "how far in the past a subscription to certification events may start. This flag is only relevant in v2",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "CERTIFICATION_EVENTS_REPLAY_WINDOW"),
+		Value:    1 * time.Hour,
+	}
+	BlobIntegrityKMSKeyIDFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "blob-integrity-kms-key-id"),
+		Usage:    "ID of the AWS KMS key used to sign blob integrity attestations. If not set, blob integrity checks are disabled. This flag is only relevant in v2",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "BLOB_INTEGRITY_KMS_KEY_ID"),
+	}
+	BlobIntegrityMaxSignatureAgeFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "blob-integrity-max-signature-age"),
+		Usage:    "max difference between the timestamp of a signed blob integrity check and the current time. This flag is only relevant in v2",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "BLOB_INTEGRITY_MAX_SIGNATURE_AGE"),
+		Value:    time.Minute,
+	}
+	BlobIntegrityChecksPerSecondFlag = cli.Float64Flag{
+		Name:     common.PrefixFlag(FlagPrefix, "blob-integrity-checks-per-second"),
+		Usage:    "rate at which each account may check the integrity of its blobs. This flag is only relevant in v2",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "BLOB_INTEGRITY_CHECKS_PER_SECOND"),
+		Value:    0.1,
+	}
+	BlobIntegrityBurstFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "blob-integrity-burst"),
+		Usage:    "number of blob integrity checks an account may make at once. This flag is only relevant in v2",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "BLOB_INTEGRITY_BURST"),
+		Value:    5,
+	}
+	BlobIntegrityMaxConcurrentChecksFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "blob-integrity-max-concurrent-checks"),
+		Usage:    "number of blob integrity checks processed concurrently, above which new checks are rejected. 0 means unlimited. This flag is only relevant in v2",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "BLOB_INTEGRITY_MAX_CONCURRENT_CHECKS"),
+		Value:    4,
+	}
+	DispersalReceiptKMSKeyIDFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "dispersal-receipt-kms-key-id"),
+		Usage:    "ID of the AWS KMS key used to sign dispersal receipts. If not set, no receipts are returned. This flag is only relevant in v2",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "DISPERSAL_RECEIPT_KMS_KEY_ID"),
+	}
 )
 
 var kzgFlags = []cli.Flag{
@@ -255,6 +371,23 @@ var optionalFlags = []cli.Flag{
 	MaxNumSymbolsPerBlob,
 	PprofHttpPort,
 	EnablePprof,
+	MinRetentionPeriodQuorums,
+	MinRetentionPeriods,
+	MaxQueuedBlobs,
+	QueueDepthRefreshInterval,
+	MaxInFlightRequestsPerAccount,
+	MaxInFlightRequestsOverrideAccounts,
+	MaxInFlightRequestsOverrides,
+	PendingBlobsRefreshInterval,
+	CertificationEventsMaxSubscribers,
+	CertificationEventsPollInterval,
+	CertificationEventsReplayWindow,
+	BlobIntegrityKMSKeyIDFlag,
+	BlobIntegrityMaxSignatureAgeFlag,
+	BlobIntegrityChecksPerSecondFlag,
+	BlobIntegrityBurstFlag,
+	BlobIntegrityMaxConcurrentChecksFlag,
+	DispersalReceiptKMSKeyIDFlag,
 }
 
 // Flags contains the list of configuration options available to the binary.
@@ -266,6 +399,9 @@ func init() {
 	Flags = append(Flags, common.LoggerCLIFlags(envVarPrefix, FlagPrefix)...)
 	Flags = append(Flags, ratelimit.RatelimiterCLIFlags(envVarPrefix, FlagPrefix)...)
 	Flags = append(Flags, aws.ClientFlags(envVarPrefix, FlagPrefix)...)
+	Flags = append(Flags, blobstore.MetadataStoreFlags(envVarPrefix, FlagPrefix)...)
 	Flags = append(Flags, apiserver.CLIFlags(envVarPrefix)...)
+	Flags = append(Flags, admin.CLIFlags(envVarPrefix, FlagPrefix)...)
+	Flags = append(Flags, tracing.CLIFlags(envVarPrefix, FlagPrefix)...)
 	Flags = append(Flags, kzgFlags...)
 }
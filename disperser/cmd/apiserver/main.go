@@ -10,21 +10,26 @@ import (
 	"github.com/Layr-Labs/eigenda/common"
 	mt "github.com/Layr-Labs/eigenda/core/meterer"
 	"github.com/Layr-Labs/eigenda/disperser/apiserver"
+	"github.com/Layr-Labs/eigenda/disperser/common/admin"
 	"github.com/Layr-Labs/eigenda/disperser/common/blobstore"
+	"github.com/Layr-Labs/eigenda/disperser/common/pow"
 	blobstorev2 "github.com/Layr-Labs/eigenda/disperser/common/v2/blobstore"
 	"github.com/Layr-Labs/eigenda/encoding/fft"
 	"github.com/Layr-Labs/eigenda/encoding/kzg/prover"
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/Layr-Labs/eigenda/common/aws/dynamodb"
+	"github.com/Layr-Labs/eigenda/common/aws/envelope"
 	"github.com/Layr-Labs/eigenda/common/aws/s3"
 	"github.com/Layr-Labs/eigenda/common/geth"
 	"github.com/Layr-Labs/eigenda/common/ratelimit"
 	"github.com/Layr-Labs/eigenda/common/store"
+	"github.com/Layr-Labs/eigenda/common/tracing"
 	authv2 "github.com/Layr-Labs/eigenda/core/auth/v2"
 	"github.com/Layr-Labs/eigenda/core/eth"
 	"github.com/Layr-Labs/eigenda/disperser"
 	"github.com/Layr-Labs/eigenda/disperser/cmd/apiserver/flags"
+	"github.com/Layr-Labs/eigensdk-go/logging"
 	gethcommon "github.com/ethereum/go-ethereum/common"
 	grpcprom "github.com/grpc-ecosystem/go-grpc-middleware/providers/prometheus"
 	"github.com/urfave/cli"
@@ -65,6 +70,10 @@ func RunDisperserServer(ctx *cli.Context) error {
 		return err
 	}
 
+	if err := tracing.Init(context.Background(), config.TracingConfig, logger); err != nil {
+		return fmt.Errorf("failed to initialize tracing: %w", err)
+	}
+
 	client, err := geth.NewMultiHomingClient(config.EthClientConfig, gethcommon.Address{}, logger)
 	if err != nil {
 		logger.Error("Cannot create chain.Client", "err", err)
@@ -84,7 +93,11 @@ func RunDisperserServer(ctx *cli.Context) error {
 		return fmt.Errorf("failed to get STORE_DURATION_BLOCKS: %w", err)
 	}
 
-	s3Client, err := s3.NewClient(context.Background(), config.AwsClientConfig, logger)
+	defaultRetentionPeriod := time.Duration((storeDurationBlocks+blockStaleMeasure)*12) * time.Second
+
+	reg := prometheus.NewRegistry()
+
+	s3Client, err := s3.NewClientWithReplication(context.Background(), config.AwsClientConfig, logger, reg)
 	if err != nil {
 		return err
 	}
@@ -94,13 +107,13 @@ func RunDisperserServer(ctx *cli.Context) error {
 		return err
 	}
 
-	reg := prometheus.NewRegistry()
-
 	var meterer *mt.Meterer
 	if config.EnablePaymentMeterer {
 		mtConfig := mt.Config{
 			ChainReadTimeout: config.ChainReadTimeout,
 			UpdateInterval:   config.OnchainStateRefreshInterval,
+
+			DefaultRetentionPeriod: defaultRetentionPeriod,
 		}
 
 		paymentChainState, err := mt.NewOnchainPaymentState(context.Background(), transactor, logger)
@@ -152,6 +165,11 @@ func RunDisperserServer(ctx *cli.Context) error {
 		ratelimiter = ratelimit.NewRateLimiter(reg, globalParams, bucketStore, logger)
 	}
 
+	var proofStore pow.ProofStore
+	if config.RateConfig.ProofOfWorkDifficulty > 0 && config.RateConfig.ProofOfWorkTableName != "" {
+		proofStore = pow.NewDynamoProofStore(dynamoClient, config.RateConfig.ProofOfWorkTableName)
+	}
+
 	if config.MaxBlobSize <= 0 || config.MaxBlobSize > 32*1024*1024 {
 		return fmt.Errorf("configured max blob size is invalid %v", config.MaxBlobSize)
 	}
@@ -168,8 +186,39 @@ func RunDisperserServer(ctx *cli.Context) error {
 		if err != nil {
 			return fmt.Errorf("failed to create encoder: %w", err)
 		}
-		blobMetadataStore := blobstorev2.NewBlobMetadataStore(dynamoClient, logger, config.BlobstoreConfig.TableName)
-		blobStore := blobstorev2.NewBlobStore(bucketName, s3Client, logger)
+		blobMetadataStore, err := blobstorev2.NewMetadataStore(context.Background(), config.MetadataStoreConfig, dynamoClient, config.BlobstoreConfig.TableName, logger)
+		if err != nil {
+			return fmt.Errorf("failed to create blob metadata store: %w", err)
+		}
+		encryptor, err := envelope.NewKMSEncryptor(context.Background(), config.AwsClientConfig)
+		if err != nil {
+			return fmt.Errorf("failed to create blob encryptor: %w", err)
+		}
+		blobStore := blobstorev2.NewBlobStore(bucketName, s3Client, encryptor, logger)
+
+		var blobIntegritySigner apiserver.BlobIntegritySigner
+		if config.BlobIntegrityKMSKeyID != "" {
+			blobIntegritySigner, err = apiserver.NewKMSBlobIntegritySigner(
+				context.Background(),
+				config.AwsClientConfig.Region,
+				config.AwsClientConfig.EndpointURL,
+				config.BlobIntegrityKMSKeyID)
+			if err != nil {
+				return fmt.Errorf("failed to create blob integrity signer: %w", err)
+			}
+		}
+
+		var dispersalReceiptSigner apiserver.DispersalReceiptSigner
+		if config.DispersalReceiptKMSKeyID != "" {
+			dispersalReceiptSigner, err = apiserver.NewKMSDispersalReceiptSigner(
+				context.Background(),
+				config.AwsClientConfig.Region,
+				config.AwsClientConfig.EndpointURL,
+				config.DispersalReceiptKMSKeyID)
+			if err != nil {
+				return fmt.Errorf("failed to create dispersal receipt signer: %w", err)
+			}
+		}
 
 		server, err := apiserver.NewDispersalServerV2(
 			config.ServerConfig,
@@ -181,6 +230,16 @@ func RunDisperserServer(ctx *cli.Context) error {
 			prover,
 			uint64(config.MaxNumSymbolsPerBlob),
 			config.OnchainStateRefreshInterval,
+			apiserver.RetentionConfig{
+				DefaultRetentionPeriod:    defaultRetentionPeriod,
+				QuorumMinRetentionPeriods: config.QuorumMinRetentionPeriods,
+			},
+			config.BackpressureConfig,
+			config.AccountConcurrencyConfig,
+			config.CertificationEventsConfig,
+			config.BlobIntegrityConfig,
+			blobIntegritySigner,
+			dispersalReceiptSigner,
 			logger,
 			reg,
 			config.MetricsConfig,
@@ -188,11 +247,24 @@ func RunDisperserServer(ctx *cli.Context) error {
 		if err != nil {
 			return err
 		}
+		if err := startAdminServer(config.AdminConfig, admin.Components{
+			Intake:       server,
+			PaymentState: paymentStateRefresher(meterer),
+			QueueStats:   server,
+		}, logger); err != nil {
+			return err
+		}
 		return server.Start(context.Background())
 	}
 
-	blobMetadataStore := blobstore.NewBlobMetadataStore(dynamoClient, logger, config.BlobstoreConfig.TableName, time.Duration((storeDurationBlocks+blockStaleMeasure)*12)*time.Second)
-	blobStore := blobstore.NewSharedStorage(bucketName, s3Client, blobMetadataStore, logger)
+	blobMetadataStore, err := blobstore.NewMetadataStore(context.Background(), config.MetadataStoreConfig, dynamoClient, config.BlobstoreConfig.TableName, defaultRetentionPeriod, logger)
+	if err != nil {
+		return fmt.Errorf("failed to create blob metadata store: %w", err)
+	}
+	blobStore, err := blobstore.NewSharedStorage(bucketName, s3Client, blobMetadataStore, logger)
+	if err != nil {
+		return err
+	}
 
 	grpcMetrics := grpcprom.NewServerMetrics()
 	metrics := disperser.NewMetrics(reg, config.MetricsConfig.HTTPPort, logger)
@@ -206,6 +278,7 @@ func RunDisperserServer(ctx *cli.Context) error {
 		meterer,
 		ratelimiter,
 		config.RateConfig,
+		proofStore,
 		config.MaxBlobSize,
 	)
 
@@ -218,5 +291,35 @@ func RunDisperserServer(ctx *cli.Context) error {
 		logger.Info("Enabled metrics for Disperser", "socket", httpSocket)
 	}
 
+	if err := startAdminServer(config.AdminConfig, admin.Components{
+		Intake:       server,
+		PaymentState: paymentStateRefresher(meterer),
+	}, logger); err != nil {
+		return err
+	}
+
 	return server.Start(context.Background())
 }
+
+// startAdminServer starts the admin server if an admin port is configured
+func startAdminServer(config admin.Config, components admin.Components, logger logging.Logger) error {
+	if config.GrpcPort == "" {
+		return nil
+	}
+	adminServer, err := admin.NewServer(config, components, logger)
+	if err != nil {
+		return fmt.Errorf("failed to create admin server: %w", err)
+	}
+	if err := adminServer.Start(context.Background()); err != nil {
+		return fmt.Errorf("failed to start admin server: %w", err)
+	}
+	return nil
+}
+
+// paymentStateRefresher returns the on-chain payment state of the meterer, or nil if payments aren't metered
+func paymentStateRefresher(meterer *mt.Meterer) admin.PaymentStateRefresher {
+	if meterer == nil {
+		return nil
+	}
+	return meterer.ChainPaymentState
+}
@@ -1,12 +1,21 @@
 package main
 
 import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/common/aws"
 	"github.com/Layr-Labs/eigenda/common/geth"
+	"github.com/Layr-Labs/eigenda/common/grpctls"
+	"github.com/Layr-Labs/eigenda/core"
 	"github.com/Layr-Labs/eigenda/core/thegraph"
 	"github.com/Layr-Labs/eigenda/disperser/batcher"
+	dispatcher "github.com/Layr-Labs/eigenda/disperser/batcher/grpc"
 	"github.com/Layr-Labs/eigenda/disperser/cmd/batcher/flags"
+	"github.com/Layr-Labs/eigenda/disperser/common/admin"
 	"github.com/Layr-Labs/eigenda/disperser/common/blobstore"
 	"github.com/Layr-Labs/eigenda/encoding/kzg"
 	"github.com/Layr-Labs/eigenda/indexer"
@@ -14,25 +23,37 @@ import (
 )
 
 type Config struct {
-	BatcherConfig    batcher.Config
-	TimeoutConfig    batcher.TimeoutConfig
-	BlobstoreConfig  blobstore.Config
-	EthClientConfig  geth.EthClientConfig
-	AwsClientConfig  aws.ClientConfig
-	EncoderConfig    kzg.KzgConfig
-	LoggerConfig     common.LoggerConfig
-	MetricsConfig    batcher.MetricsConfig
-	IndexerConfig    indexer.Config
-	KMSKeyConfig     common.KMSKeyConfig
-	ChainStateConfig thegraph.Config
-	UseGraph         bool
+	BatcherConfig       batcher.Config
+	TimeoutConfig       batcher.TimeoutConfig
+	BlobstoreConfig     blobstore.Config
+	MetadataStoreConfig blobstore.MetadataStoreConfig
+	EthClientConfig     geth.EthClientConfig
+	AwsClientConfig     aws.ClientConfig
+	EncoderConfig       kzg.KzgConfig
+	LoggerConfig        common.LoggerConfig
+	MetricsConfig       batcher.MetricsConfig
+	IndexerConfig       indexer.Config
+	KMSKeyConfig        common.KMSKeyConfig
+	ChainStateConfig    thegraph.Config
+	UseGraph            bool
 
 	IndexerDataDir string
 
 	BLSOperatorStateRetrieverAddr string
 	EigenDAServiceManagerAddr     string
 
-	EnableGnarkBundleEncoding bool
+	EnableGnarkBundleEncoding  bool
+	EnableStreamingStoreChunks bool
+	AttestationRetryConfig     dispatcher.RetryConfig
+	// NodeClientTLSConfig configures TLS on the connections to the dispersal servers of the operators
+	NodeClientTLSConfig grpctls.ClientConfig
+
+	EnableLeaderElection     bool
+	LeaderElectionTableName  string
+	LeaderElectionInstanceID string
+	LeaderLeaseDuration      time.Duration
+
+	AdminConfig admin.Config
 }
 
 func NewConfig(ctx *cli.Context) (Config, error) {
@@ -40,6 +61,24 @@ func NewConfig(ctx *cli.Context) (Config, error) {
 	if err != nil {
 		return Config{}, err
 	}
+	quorumBatchThresholds, err := readQuorumBatchThresholds(ctx)
+	if err != nil {
+		return Config{}, err
+	}
+	enableLeaderElection := ctx.GlobalBool(flags.EnableLeaderElectionFlag.Name)
+	leaderElectionTableName := ctx.GlobalString(flags.LeaderElectionTableNameFlag.Name)
+	leaderElectionInstanceID := ctx.GlobalString(flags.LeaderElectionInstanceIDFlag.Name)
+	if enableLeaderElection {
+		if leaderElectionTableName == "" {
+			return Config{}, errors.New("leader election table name must be specified when leader election is enabled")
+		}
+		if leaderElectionInstanceID == "" {
+			leaderElectionInstanceID, err = os.Hostname()
+			if err != nil {
+				return Config{}, fmt.Errorf("failed to get hostname for leader election instance ID: %w", err)
+			}
+		}
+	}
 	ethClientConfig := geth.ReadEthClientConfig(ctx)
 	kmsConfig := common.ReadKMSKeyConfig(ctx, flags.FlagPrefix)
 	if !kmsConfig.Disable {
@@ -50,10 +89,12 @@ func NewConfig(ctx *cli.Context) (Config, error) {
 			BucketName: ctx.GlobalString(flags.S3BucketNameFlag.Name),
 			TableName:  ctx.GlobalString(flags.DynamoDBTableNameFlag.Name),
 		},
-		EthClientConfig: ethClientConfig,
-		AwsClientConfig: aws.ReadClientConfig(ctx, flags.FlagPrefix),
-		EncoderConfig:   kzg.ReadCLIConfig(ctx),
-		LoggerConfig:    *loggerConfig,
+		EthClientConfig:     ethClientConfig,
+		AwsClientConfig:     aws.ReadClientConfig(ctx, flags.FlagPrefix),
+		MetadataStoreConfig: blobstore.ReadMetadataStoreConfig(ctx, flags.FlagPrefix),
+		NodeClientTLSConfig: grpctls.ReadNodeClientConfig(ctx, flags.FlagPrefix),
+		EncoderConfig:       kzg.ReadCLIConfig(ctx),
+		LoggerConfig:        *loggerConfig,
 		BatcherConfig: batcher.Config{
 			PullInterval:             ctx.GlobalDuration(flags.PullIntervalFlag.Name),
 			FinalizerInterval:        ctx.GlobalDuration(flags.FinalizerIntervalFlag.Name),
@@ -62,11 +103,21 @@ func NewConfig(ctx *cli.Context) (Config, error) {
 			NumConnections:           ctx.GlobalInt(flags.NumConnectionsFlag.Name),
 			EncodingRequestQueueSize: ctx.GlobalInt(flags.EncodingRequestQueueSizeFlag.Name),
 			BatchSizeMBLimit:         ctx.GlobalUint(flags.BatchSizeLimitFlag.Name),
+			MaxNumBlobsPerBatch:      ctx.GlobalUint(flags.MaxNumBlobsPerBatchFlag.Name),
+			MaxBlobAge:               ctx.GlobalDuration(flags.MaxBlobAgeFlag.Name),
+			QuorumBatchThresholds:    quorumBatchThresholds,
 			SRSOrder:                 ctx.GlobalInt(flags.SRSOrderFlag.Name),
 			MaxNumRetriesPerBlob:     ctx.GlobalUint(flags.MaxNumRetriesPerBlobFlag.Name),
 			TargetNumChunks:          ctx.GlobalUint(flags.TargetNumChunksFlag.Name),
 			MaxBlobsToFetchFromStore: ctx.GlobalInt(flags.MaxBlobsToFetchFromStoreFlag.Name),
 			FinalizationBlockDelay:   ctx.GlobalUint(flags.FinalizationBlockDelayFlag.Name),
+
+			MinibatchSize:             ctx.GlobalUint(flags.MinibatchSizeFlag.Name),
+			MaxNodeConnections:        ctx.GlobalUint(flags.MaxNodeConnectionsFlag.Name),
+			MaxNumRetriesPerDispersal: ctx.GlobalUint(flags.MaxNumRetriesPerDispersalFlag.Name),
+
+			FinalizerConfirmationDepth: ctx.GlobalUint64(flags.FinalizerConfirmationDepthFlag.Name),
+			SimulationMode:             ctx.GlobalBool(flags.SimulationModeFlag.Name),
 		},
 		TimeoutConfig: batcher.TimeoutConfig{
 			EncodingTimeout:     ctx.GlobalDuration(flags.EncodingTimeoutFlag.Name),
@@ -88,6 +139,58 @@ func NewConfig(ctx *cli.Context) (Config, error) {
 		IndexerConfig:                 indexer.ReadIndexerConfig(ctx),
 		KMSKeyConfig:                  kmsConfig,
 		EnableGnarkBundleEncoding:     ctx.Bool(flags.EnableGnarkBundleEncodingFlag.Name),
+		EnableStreamingStoreChunks:    ctx.Bool(flags.EnableStreamingStoreChunksFlag.Name),
+		EnableLeaderElection:          enableLeaderElection,
+		LeaderElectionTableName:       leaderElectionTableName,
+		LeaderElectionInstanceID:      leaderElectionInstanceID,
+		LeaderLeaseDuration:           ctx.GlobalDuration(flags.LeaderLeaseDurationFlag.Name),
+		AdminConfig:                   admin.ReadCLIConfig(ctx, flags.FlagPrefix),
+		AttestationRetryConfig: dispatcher.RetryConfig{
+			MaxAttempts:    ctx.GlobalInt(flags.AttestationMaxAttemptsFlag.Name),
+			InitialBackoff: ctx.GlobalDuration(flags.AttestationRetryInitialBackoffFlag.Name),
+			MaxBackoff:     ctx.GlobalDuration(flags.AttestationRetryMaxBackoffFlag.Name),
+		},
 	}
 	return config, nil
 }
+
+// readQuorumBatchThresholds reads the per-quorum batch threshold overrides. Each quorum ID must have a corresponding
+// entry in each of the quorum threshold flags.
+func readQuorumBatchThresholds(ctx *cli.Context) (map[core.QuorumID]batcher.BatchThresholds, error) {
+	quorums := ctx.GlobalIntSlice(flags.BatchThresholdQuorumFlag.Name)
+	sizeLimits := ctx.GlobalIntSlice(flags.BatchThresholdQuorumSizeLimitFlag.Name)
+	maxNumBlobs := ctx.GlobalIntSlice(flags.BatchThresholdQuorumMaxNumBlobsFlag.Name)
+	maxBlobAges := ctx.GlobalStringSlice(flags.BatchThresholdQuorumMaxBlobAgeFlag.Name)
+
+	numQuorums := len(quorums)
+	if len(sizeLimits) != numQuorums {
+		return nil, errors.New("number of quorum batch size limits does not match number of batch threshold quorums")
+	}
+	if len(maxNumBlobs) != numQuorums {
+		return nil, errors.New("number of quorum batch blob limits does not match number of batch threshold quorums")
+	}
+	if len(maxBlobAges) != numQuorums {
+		return nil, errors.New("number of quorum batch blob ages does not match number of batch threshold quorums")
+	}
+
+	thresholds := make(map[core.QuorumID]batcher.BatchThresholds, numQuorums)
+	for ind, quorumID := range quorums {
+		if quorumID < 0 || quorumID > core.MaxQuorumID {
+			return nil, fmt.Errorf("invalid batch threshold quorum %d", quorumID)
+		}
+		if sizeLimits[ind] < 0 || maxNumBlobs[ind] < 0 {
+			return nil, fmt.Errorf("batch thresholds for quorum %d must not be negative", quorumID)
+		}
+		maxBlobAge, err := time.ParseDuration(maxBlobAges[ind])
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse max blob age for quorum %d: %w", quorumID, err)
+		}
+		thresholds[core.QuorumID(quorumID)] = batcher.BatchThresholds{
+			MaxBatchSizeBytes: uint64(sizeLimits[ind]) * 1024 * 1024, // convert to bytes
+			MaxNumBlobs:       uint(maxNumBlobs[ind]),
+			MaxBlobAge:        maxBlobAge,
+		}
+	}
+
+	return thresholds, nil
+}
@@ -6,7 +6,10 @@ import (
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/common/aws"
 	"github.com/Layr-Labs/eigenda/common/geth"
+	"github.com/Layr-Labs/eigenda/common/grpctls"
 	"github.com/Layr-Labs/eigenda/core/thegraph"
+	"github.com/Layr-Labs/eigenda/disperser/common/admin"
+	"github.com/Layr-Labs/eigenda/disperser/common/blobstore"
 	"github.com/Layr-Labs/eigenda/indexer"
 	"github.com/urfave/cli"
 )
@@ -149,6 +152,19 @@ var (
 		EnvVar:   common.PrefixEnvVar(envVarPrefix, "FINALIZER_POOL_SIZE"),
 		Value:    4,
 	}
+	FinalizerConfirmationDepthFlag = cli.Uint64Flag{
+		Name:     common.PrefixFlag(FlagPrefix, "finalizer-confirmation-depth"),
+		Usage:    "Number of blocks built on top of the block of a confirmation transaction after which its blobs are finalized. 0 finalizes blobs once the block is finalized by the chain",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "FINALIZER_CONFIRMATION_DEPTH"),
+		Value:    0,
+	}
+	SimulationModeFlag = cli.BoolFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "simulation-mode"),
+		Usage:    "Disperse batches and collect signatures without confirming them on chain. Blobs are confirmed with synthetic certificates marked as simulated",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "SIMULATION_MODE"),
+	}
 	EncodingRequestQueueSizeFlag = cli.IntFlag{
 		Name:     common.PrefixFlag(FlagPrefix, "encoding-request-queue-size"),
 		Usage:    "Size of the encoding request queue",
@@ -199,6 +215,12 @@ var (
 		Required: false,
 		EnvVar:   common.PrefixEnvVar(envVarPrefix, "ENABLE_GNARK_BUNDLE_ENCODING"),
 	}
+	EnableStreamingStoreChunksFlag = cli.BoolFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "enable-streaming-store-chunks"),
+		Usage:    "Send the chunks of a batch to operators one blob at a time, falling back to a single request for operators which don't support it",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "ENABLE_STREAMING_STORE_CHUNKS"),
+	}
 	MaxNodeConnectionsFlag = cli.UintFlag{
 		Name:     common.PrefixFlag(FlagPrefix, "max-node-connections"),
 		Usage:    "Maximum number of connections to the node. Only used when minibatching is enabled. Defaults to 1024.",
@@ -206,6 +228,97 @@ var (
 		Value:    1024,
 		EnvVar:   common.PrefixEnvVar(envVarPrefix, "MAX_NODE_CONNECTIONS"),
 	}
+	MaxNumBlobsPerBatchFlag = cli.UintFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "max-num-blobs-per-batch"),
+		Usage:    "the number of encoded blobs which triggers the creation of a batch before the next pull interval. 0 means no limit",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "MAX_NUM_BLOBS_PER_BATCH"),
+		Value:    0,
+	}
+	MaxBlobAgeFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "max-blob-age"),
+		Usage:    "the time since a blob was requested after which a batch is created before the next pull interval if the blob has been encoded. 0 means no limit",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "MAX_BLOB_AGE"),
+		Value:    0,
+	}
+	BatchThresholdQuorumFlag = cli.IntSliceFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "batch-threshold-quorum"),
+		Usage:    "the quorum IDs for which batch thresholds are overridden. Each quorum must have a corresponding entry in each of the batch threshold quorum override flags",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "BATCH_THRESHOLD_QUORUM"),
+	}
+	BatchThresholdQuorumSizeLimitFlag = cli.IntSliceFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "batch-threshold-quorum-size-limit"),
+		Usage:    "the encoded size in MiB of a quorum's blobs which triggers the creation of a batch. 0 means no limit",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "BATCH_THRESHOLD_QUORUM_SIZE_LIMIT"),
+	}
+	BatchThresholdQuorumMaxNumBlobsFlag = cli.IntSliceFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "batch-threshold-quorum-max-num-blobs"),
+		Usage:    "the number of a quorum's encoded blobs which triggers the creation of a batch. 0 means no limit",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "BATCH_THRESHOLD_QUORUM_MAX_NUM_BLOBS"),
+	}
+	BatchThresholdQuorumMaxBlobAgeFlag = cli.StringSliceFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "batch-threshold-quorum-max-blob-age"),
+		Usage:    "the age of a quorum's oldest encoded blob (e.g. 30s) which triggers the creation of a batch. 0 means no limit",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "BATCH_THRESHOLD_QUORUM_MAX_BLOB_AGE"),
+	}
+	EnableLeaderElectionFlag = cli.BoolFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "enable-leader-election"),
+		Usage:    "Run in active/standby mode, where only the instance holding the leader lease creates and confirms batches",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "ENABLE_LEADER_ELECTION"),
+	}
+	LeaderElectionTableNameFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "leader-election-table-name"),
+		Usage:    "Name of the dynamodb table storing the leader lease. Required if leader election is enabled",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "LEADER_ELECTION_TABLE_NAME"),
+	}
+	LeaderElectionInstanceIDFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "leader-election-instance-id"),
+		Usage:    "Unique ID of this instance used as the owner of the leader lease. Defaults to the hostname",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "LEADER_ELECTION_INSTANCE_ID"),
+	}
+	LeaderLeaseDurationFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "leader-lease-duration"),
+		Usage:    "Duration of the leader lease. A standby instance takes over once the lease expires without being renewed",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "LEADER_LEASE_DURATION"),
+		Value:    30 * time.Second,
+	}
+	AttestationMaxAttemptsFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "attestation-max-attempts"),
+		Usage:    "Maximum number of StoreChunks requests sent to an operator for a batch. Requests which fail with a transient error are retried within the attestation timeout. 1 disables retries",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "ATTESTATION_MAX_ATTEMPTS"),
+		Value:    3,
+	}
+	AttestationRetryInitialBackoffFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "attestation-retry-initial-backoff"),
+		Usage:    "Time waited before retrying a StoreChunks request for the first time. It doubles with every retry",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "ATTESTATION_RETRY_INITIAL_BACKOFF"),
+		Value:    500 * time.Millisecond,
+	}
+	AttestationRetryMaxBackoffFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "attestation-retry-max-backoff"),
+		Usage:    "Maximum time waited between retries of a StoreChunks request",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "ATTESTATION_RETRY_MAX_BACKOFF"),
+		Value:    4 * time.Second,
+	}
+	MinibatchSizeFlag = cli.UintFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "minibatch-size"),
+		Usage:    "Number of blobs dispersed to the operators in a single request. Batches are dispersed in minibatches of this size and attested once all of them are dispersed. 0 disables minibatching",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "MINIBATCH_SIZE"),
+		Value:    0,
+	}
 	MaxNumRetriesPerDispersalFlag = cli.UintFlag{
 		Name:     common.PrefixFlag(FlagPrefix, "max-num-retries-per-dispersal"),
 		Usage:    "Maximum number of retries to disperse a minibatch. Only used when minibatching is enabled. Defaults to 3.",
@@ -240,6 +353,8 @@ var optionalFlags = []cli.Flag{
 	NumConnectionsFlag,
 	FinalizerIntervalFlag,
 	FinalizerPoolSizeFlag,
+	FinalizerConfirmationDepthFlag,
+	SimulationModeFlag,
 	EncodingRequestQueueSizeFlag,
 	MaxNumRetriesPerBlobFlag,
 	TargetNumChunksFlag,
@@ -247,7 +362,22 @@ var optionalFlags = []cli.Flag{
 	FinalizationBlockDelayFlag,
 	MaxNodeConnectionsFlag,
 	MaxNumRetriesPerDispersalFlag,
+	MinibatchSizeFlag,
 	EnableGnarkBundleEncodingFlag,
+	EnableStreamingStoreChunksFlag,
+	MaxNumBlobsPerBatchFlag,
+	MaxBlobAgeFlag,
+	BatchThresholdQuorumFlag,
+	BatchThresholdQuorumSizeLimitFlag,
+	BatchThresholdQuorumMaxNumBlobsFlag,
+	BatchThresholdQuorumMaxBlobAgeFlag,
+	EnableLeaderElectionFlag,
+	LeaderElectionTableNameFlag,
+	LeaderElectionInstanceIDFlag,
+	LeaderLeaseDurationFlag,
+	AttestationMaxAttemptsFlag,
+	AttestationRetryInitialBackoffFlag,
+	AttestationRetryMaxBackoffFlag,
 }
 
 // Flags contains the list of configuration options available to the binary.
@@ -259,6 +389,9 @@ func init() {
 	Flags = append(Flags, common.LoggerCLIFlags(envVarPrefix, FlagPrefix)...)
 	Flags = append(Flags, indexer.CLIFlags(envVarPrefix)...)
 	Flags = append(Flags, aws.ClientFlags(envVarPrefix, FlagPrefix)...)
+	Flags = append(Flags, blobstore.MetadataStoreFlags(envVarPrefix, FlagPrefix)...)
+	Flags = append(Flags, grpctls.NodeClientFlags(envVarPrefix, FlagPrefix)...)
 	Flags = append(Flags, thegraph.CLIFlags(envVarPrefix)...)
 	Flags = append(Flags, common.KMSWalletCLIFlags(envVarPrefix, FlagPrefix)...)
+	Flags = append(Flags, admin.CLIFlags(envVarPrefix, FlagPrefix)...)
 }
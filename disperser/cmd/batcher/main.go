@@ -17,10 +17,13 @@ import (
 	"github.com/Layr-Labs/eigenda/common/geth"
 	"github.com/Layr-Labs/eigenda/core"
 	coreeth "github.com/Layr-Labs/eigenda/core/eth"
+	"github.com/Layr-Labs/eigenda/disperser"
 	"github.com/Layr-Labs/eigenda/disperser/batcher"
 	dispatcher "github.com/Layr-Labs/eigenda/disperser/batcher/grpc"
 	"github.com/Layr-Labs/eigenda/disperser/cmd/batcher/flags"
+	"github.com/Layr-Labs/eigenda/disperser/common/admin"
 	"github.com/Layr-Labs/eigenda/disperser/common/blobstore"
+	"github.com/Layr-Labs/eigenda/disperser/common/leader"
 	"github.com/Layr-Labs/eigenda/disperser/encoder"
 	"github.com/Layr-Labs/eigensdk-go/aws/kms"
 	walletsdk "github.com/Layr-Labs/eigensdk-go/chainio/clients/wallet"
@@ -41,6 +44,10 @@ var (
 	healthProbePath         string        = "/tmp/health"
 	maxStallDuration        time.Duration = 240 * time.Second
 	handleBatchLivenessChan               = make(chan time.Time, 1)
+
+	// leaderLockName is the name of the lease shared by all batcher instances in active/standby mode
+	leaderLockName              = "batcher"
+	leaderElectionRetryInterval = 5 * time.Second
 )
 
 func main() {
@@ -98,9 +105,16 @@ func RunBatcher(ctx *cli.Context) error {
 
 	metrics := batcher.NewMetrics(config.MetricsConfig.HTTPPort, logger)
 
+	nodeClientCredentials, err := config.NodeClientTLSConfig.Credentials()
+	if err != nil {
+		return fmt.Errorf("failed to load the node client tls config: %w", err)
+	}
 	dispatcher := dispatcher.NewDispatcher(&dispatcher.Config{
-		Timeout:                   config.TimeoutConfig.AttestationTimeout,
-		EnableGnarkBundleEncoding: config.EnableGnarkBundleEncoding,
+		Timeout:                    config.TimeoutConfig.AttestationTimeout,
+		EnableGnarkBundleEncoding:  config.EnableGnarkBundleEncoding,
+		EnableStreamingStoreChunks: config.EnableStreamingStoreChunks,
+		Retry:                      config.AttestationRetryConfig,
+		Credentials:                nodeClientCredentials,
 	}, logger, metrics.DispatcherMetrics)
 	asgn := &core.StdAssignmentCoordinator{}
 
@@ -192,8 +206,37 @@ func RunBatcher(ctx *cli.Context) error {
 	if err != nil || storeDurationBlocks == 0 {
 		return fmt.Errorf("failed to get STORE_DURATION_BLOCKS: %w", err)
 	}
-	blobMetadataStore := blobstore.NewBlobMetadataStore(dynamoClient, logger, config.BlobstoreConfig.TableName, time.Duration((storeDurationBlocks+blockStaleMeasure)*12)*time.Second)
-	queue := blobstore.NewSharedStorage(bucketName, s3Client, blobMetadataStore, logger)
+	blobMetadataStore, err := blobstore.NewMetadataStore(context.Background(), config.MetadataStoreConfig, dynamoClient, config.BlobstoreConfig.TableName, time.Duration((storeDurationBlocks+blockStaleMeasure)*12)*time.Second, logger)
+	if err != nil {
+		return fmt.Errorf("failed to create blob metadata store: %w", err)
+	}
+	sharedStorage, err := blobstore.NewSharedStorage(bucketName, s3Client, blobMetadataStore, logger)
+	if err != nil {
+		return err
+	}
+	var queue disperser.BlobStore = sharedStorage
+
+	// In active/standby mode, block until this instance becomes the leader before touching any blob state
+	batcherCtx := context.Background()
+	var elector *leader.Elector
+	if config.EnableLeaderElection {
+		elector, err = leader.NewElector(dynamoClient, config.LeaderElectionTableName, leaderLockName, config.LeaderElectionInstanceID, config.LeaderLeaseDuration, logger)
+		if err != nil {
+			return fmt.Errorf("failed to create leader elector: %w", err)
+		}
+		logger.Info("Waiting to acquire leader lease", "instanceID", config.LeaderElectionInstanceID)
+		batcherCtx, err = elector.Run(context.Background(), leaderElectionRetryInterval)
+		if err != nil {
+			return fmt.Errorf("failed to acquire leader lease: %w", err)
+		}
+		queue = leader.NewFencedBlobStore(queue, elector)
+
+		// Exit once the lease is lost, so that the process is restarted as a standby
+		go func() {
+			<-batcherCtx.Done()
+			log.Fatalf("lost leader lease, exiting")
+		}()
+	}
 
 	cs := coreeth.NewChainState(tx, client)
 
@@ -229,7 +272,7 @@ func RunBatcher(ctx *cli.Context) error {
 	if err != nil {
 		return err
 	}
-	finalizer := batcher.NewFinalizer(config.TimeoutConfig.ChainReadTimeout, config.BatcherConfig.FinalizerInterval, queue, client, rpcClient, config.BatcherConfig.MaxNumRetriesPerBlob, 1000, config.BatcherConfig.FinalizerPoolSize, logger, metrics.FinalizerMetrics)
+	finalizer := batcher.NewFinalizer(config.TimeoutConfig.ChainReadTimeout, config.BatcherConfig.FinalizerInterval, queue, client, rpcClient, config.BatcherConfig.FinalizerConfirmationDepth, config.BatcherConfig.MaxNumRetriesPerBlob, 1000, config.BatcherConfig.FinalizerPoolSize, logger, metrics.FinalizerMetrics)
 	txnManager := batcher.NewTxnManager(client, wallet, config.EthClientConfig.NumConfirmations, 20, config.TimeoutConfig.TxnBroadcastTimeout, config.TimeoutConfig.ChainWriteTimeout, logger, metrics.TxnManagerMetrics)
 
 	// Enable Metrics Block
@@ -243,11 +286,27 @@ func RunBatcher(ctx *cli.Context) error {
 	if err != nil {
 		return err
 	}
-	err = batcher.Start(context.Background())
+	if elector != nil {
+		batcher.Fence = elector
+	}
+	err = batcher.Start(batcherCtx)
 	if err != nil {
 		return err
 	}
 
+	if config.AdminConfig.GrpcPort != "" {
+		adminServer, err := admin.NewServer(config.AdminConfig, admin.Components{
+			Batcher:    batcher,
+			QueueStats: batcher,
+		}, logger)
+		if err != nil {
+			return fmt.Errorf("failed to create admin server: %w", err)
+		}
+		if err := adminServer.Start(batcherCtx); err != nil {
+			return fmt.Errorf("failed to start admin server: %w", err)
+		}
+	}
+
 	// Signal readiness
 	if _, err := os.Create(readinessProbePath); err != nil {
 		log.Printf("Failed to create readiness file: %v at path %v \n", err, readinessProbePath)
@@ -6,9 +6,13 @@ import (
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/common/aws"
 	"github.com/Layr-Labs/eigenda/common/geth"
+	"github.com/Layr-Labs/eigenda/common/grpctls"
+	"github.com/Layr-Labs/eigenda/common/tracing"
 	"github.com/Layr-Labs/eigenda/core/thegraph"
 	corev2 "github.com/Layr-Labs/eigenda/core/v2"
 	"github.com/Layr-Labs/eigenda/disperser/cmd/controller/flags"
+	"github.com/Layr-Labs/eigenda/disperser/common/admin"
+	"github.com/Layr-Labs/eigenda/disperser/common/blobstore"
 	"github.com/Layr-Labs/eigenda/disperser/controller"
 	"github.com/Layr-Labs/eigenda/indexer"
 	"github.com/urfave/cli"
@@ -16,14 +20,36 @@ import (
 
 const MaxUint16 = ^uint16(0)
 
+// EncodingSizeClassConfig configures the worker pool encoding the blobs of up to MaxBlobSize bytes
+type EncodingSizeClassConfig struct {
+	MaxBlobSize                   uint64
+	NumConcurrentEncodingRequests int
+}
+
 type Config struct {
-	EncodingManagerConfig          controller.EncodingManagerConfig
-	DispatcherConfig               controller.DispatcherConfig
-	NumConcurrentEncodingRequests  int
+	EncodingManagerConfig         controller.EncodingManagerConfig
+	DispatcherConfig              controller.DispatcherConfig
+	PaymentReconcilerConfig       controller.PaymentReconcilerConfig
+	AccountingReconcilerConfig    controller.AccountingReconcilerConfig
+	NumConcurrentEncodingRequests int
+	// EncodingSizeClasses are the blob size classes encoded by dedicated worker pools
+	EncodingSizeClasses            []EncodingSizeClassConfig
 	NumConcurrentDispersalRequests int
 	NodeClientCacheSize            int
+	// NodeClientTLSConfig configures TLS on the connections to the dispersal servers of the validators
+	NodeClientTLSConfig grpctls.ClientConfig
+
+	DynamoDBTableName   string
+	MetadataStoreConfig blobstore.MetadataStoreConfig
 
-	DynamoDBTableName string
+	PaymentReconciliationEnabled    bool
+	AccountingReconciliationEnabled bool
+	ReservationsTableName           string
+	OnDemandTableName               string
+	GlobalRateTableName             string
+
+	BlobGCEnabled              bool
+	BlobGarbageCollectorConfig controller.BlobGarbageCollectorConfig
 
 	EthClientConfig                     geth.EthClientConfig
 	AwsClientConfig                     aws.ClientConfig
@@ -33,6 +59,8 @@ type Config struct {
 	IndexerConfig                       indexer.Config
 	ChainStateConfig                    thegraph.Config
 	UseGraph                            bool
+	TracingConfig                       tracing.Config
+	AdminConfig                         admin.Config
 
 	BLSOperatorStateRetrieverAddr string
 	EigenDAServiceManagerAddr     string
@@ -61,10 +89,27 @@ func NewConfig(ctx *cli.Context) (Config, error) {
 		}
 		relays[i] = corev2.RelayKey(relay)
 	}
+	sizeClassMaxBlobSizes := ctx.GlobalIntSlice(flags.EncodingSizeClassMaxBlobSizesFlag.Name)
+	sizeClassNumConcurrentRequests := ctx.GlobalIntSlice(flags.EncodingSizeClassNumConcurrentRequestsFlag.Name)
+	if len(sizeClassMaxBlobSizes) != len(sizeClassNumConcurrentRequests) {
+		return Config{}, fmt.Errorf("got %d encoding size class max blob sizes but %d concurrency limits", len(sizeClassMaxBlobSizes), len(sizeClassNumConcurrentRequests))
+	}
+	sizeClasses := make([]EncodingSizeClassConfig, len(sizeClassMaxBlobSizes))
+	for i, maxBlobSize := range sizeClassMaxBlobSizes {
+		if maxBlobSize <= 0 || sizeClassNumConcurrentRequests[i] <= 0 {
+			return Config{}, fmt.Errorf("invalid encoding size class: max blob size %d, concurrency %d", maxBlobSize, sizeClassNumConcurrentRequests[i])
+		}
+		sizeClasses[i] = EncodingSizeClassConfig{
+			MaxBlobSize:                   uint64(maxBlobSize),
+			NumConcurrentEncodingRequests: sizeClassNumConcurrentRequests[i],
+		}
+	}
 	config := Config{
 		DynamoDBTableName:                   ctx.GlobalString(flags.DynamoDBTableNameFlag.Name),
 		EthClientConfig:                     ethClientConfig,
 		AwsClientConfig:                     aws.ReadClientConfig(ctx, flags.FlagPrefix),
+		MetadataStoreConfig:                 blobstore.ReadMetadataStoreConfig(ctx, flags.FlagPrefix),
+		NodeClientTLSConfig:                 grpctls.ReadNodeClientConfig(ctx, flags.FlagPrefix),
 		DisperserStoreChunksSigningDisabled: ctx.GlobalBool(flags.DisperserStoreChunksSigningDisabledFlag.Name),
 		DisperserKMSKeyID:                   ctx.GlobalString(flags.DisperserKMSKeyIDFlag.Name),
 		LoggerConfig:                        *loggerConfig,
@@ -86,16 +131,45 @@ func NewConfig(ctx *cli.Context) (Config, error) {
 			NumRequestRetries:      ctx.GlobalInt(flags.NumRequestRetriesFlag.Name),
 			MaxBatchSize:           int32(ctx.GlobalInt(flags.MaxBatchSizeFlag.Name)),
 		},
-		NumConcurrentEncodingRequests:  ctx.GlobalInt(flags.NumConcurrentEncodingRequestsFlag.Name),
-		NumConcurrentDispersalRequests: ctx.GlobalInt(flags.NumConcurrentDispersalRequestsFlag.Name),
-		NodeClientCacheSize:            ctx.GlobalInt(flags.NodeClientCacheNumEntriesFlag.Name),
-		IndexerConfig:                  indexer.ReadIndexerConfig(ctx),
-		ChainStateConfig:               thegraph.ReadCLIConfig(ctx),
-		UseGraph:                       ctx.GlobalBool(flags.UseGraphFlag.Name),
+		PaymentReconcilerConfig: controller.PaymentReconcilerConfig{
+			PullInterval:  ctx.GlobalDuration(flags.PaymentReconciliationIntervalFlag.Name),
+			RefundTimeout: ctx.GlobalDuration(flags.PaymentRefundTimeoutFlag.Name),
+		},
+		AccountingReconcilerConfig: controller.AccountingReconcilerConfig{
+			Interval: ctx.GlobalDuration(flags.AccountingReconciliationIntervalFlag.Name),
+			Timeout:  ctx.GlobalDuration(flags.AccountingReconciliationTimeoutFlag.Name),
+		},
+		BlobGarbageCollectorConfig: controller.BlobGarbageCollectorConfig{
+			PullInterval:         ctx.GlobalDuration(flags.BlobGCIntervalFlag.Name),
+			BucketName:           ctx.GlobalString(flags.S3BucketNameFlag.Name),
+			MaxBlobsPerIteration: int32(ctx.GlobalInt(flags.BlobGCMaxBlobsPerIterationFlag.Name)),
+			MaxPurgesPerSecond:   ctx.GlobalFloat64(flags.BlobGCMaxPurgesPerSecondFlag.Name),
+			PurgeTimeout:         ctx.GlobalDuration(flags.BlobGCPurgeTimeoutFlag.Name),
+			DryRun:               ctx.GlobalBool(flags.BlobGCDryRunFlag.Name),
+		},
+		BlobGCEnabled:                   ctx.GlobalBool(flags.BlobGCEnabledFlag.Name),
+		PaymentReconciliationEnabled:    ctx.GlobalBool(flags.PaymentReconciliationEnabledFlag.Name),
+		AccountingReconciliationEnabled: ctx.GlobalBool(flags.AccountingReconciliationEnabledFlag.Name),
+		ReservationsTableName:           ctx.GlobalString(flags.ReservationsTableNameFlag.Name),
+		OnDemandTableName:               ctx.GlobalString(flags.OnDemandTableNameFlag.Name),
+		GlobalRateTableName:             ctx.GlobalString(flags.GlobalRateTableNameFlag.Name),
+		NumConcurrentEncodingRequests:   ctx.GlobalInt(flags.NumConcurrentEncodingRequestsFlag.Name),
+		EncodingSizeClasses:             sizeClasses,
+		NumConcurrentDispersalRequests:  ctx.GlobalInt(flags.NumConcurrentDispersalRequestsFlag.Name),
+		NodeClientCacheSize:             ctx.GlobalInt(flags.NodeClientCacheNumEntriesFlag.Name),
+		IndexerConfig:                   indexer.ReadIndexerConfig(ctx),
+		ChainStateConfig:                thegraph.ReadCLIConfig(ctx),
+		UseGraph:                        ctx.GlobalBool(flags.UseGraphFlag.Name),
 
 		BLSOperatorStateRetrieverAddr: ctx.GlobalString(flags.BlsOperatorStateRetrieverFlag.Name),
 		EigenDAServiceManagerAddr:     ctx.GlobalString(flags.EigenDAServiceManagerFlag.Name),
 		MetricsPort:                   ctx.GlobalInt(flags.MetricsPortFlag.Name),
+		AdminConfig:                   admin.ReadCLIConfig(ctx, flags.FlagPrefix),
+	}
+	config.TracingConfig = tracing.ReadCLIConfig(ctx, flags.FlagPrefix)
+	config.TracingConfig.ServiceName = "disperser-controller"
+	if config.BlobGCEnabled && config.BlobGarbageCollectorConfig.BucketName == "" {
+		return Config{}, fmt.Errorf("S3BucketName is required when the blob garbage collector is enabled")
 	}
 	if !config.DisperserStoreChunksSigningDisabled && config.DisperserKMSKeyID == "" {
 		return Config{}, fmt.Errorf("DisperserKMSKeyID is required when StoreChunks() signing is enabled")
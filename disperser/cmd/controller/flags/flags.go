@@ -6,7 +6,11 @@ import (
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/common/aws"
 	"github.com/Layr-Labs/eigenda/common/geth"
+	"github.com/Layr-Labs/eigenda/common/grpctls"
+	"github.com/Layr-Labs/eigenda/common/tracing"
 	"github.com/Layr-Labs/eigenda/core/thegraph"
+	"github.com/Layr-Labs/eigenda/disperser/common/admin"
+	"github.com/Layr-Labs/eigenda/disperser/common/blobstore"
 	"github.com/Layr-Labs/eigenda/indexer"
 	"github.com/urfave/cli"
 )
@@ -102,6 +106,18 @@ var (
 		EnvVar:   common.PrefixEnvVar(envVarPrefix, "NUM_CONCURRENT_ENCODING_REQUESTS"),
 		Value:    250,
 	}
+	EncodingSizeClassMaxBlobSizesFlag = cli.IntSliceFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "encoding-size-class-max-blob-sizes"),
+		Usage:    "Max blob sizes in bytes of the blob size classes encoded by dedicated worker pools. Blobs larger than all size classes are encoded by the default pool",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "ENCODING_SIZE_CLASS_MAX_BLOB_SIZES"),
+	}
+	EncodingSizeClassNumConcurrentRequestsFlag = cli.IntSliceFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "encoding-size-class-num-concurrent-requests"),
+		Usage:    "Number of concurrent encoding requests of each blob size class, in the order of encoding-size-class-max-blob-sizes",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "ENCODING_SIZE_CLASS_NUM_CONCURRENT_REQUESTS"),
+	}
 	MaxNumBlobsPerIterationFlag = cli.IntFlag{
 		Name:     common.PrefixFlag(FlagPrefix, "max-num-blobs-per-iteration"),
 		Usage:    "Max number of blobs to encode in a single iteration",
@@ -184,6 +200,113 @@ var (
 		Required: false,
 		EnvVar:   common.PrefixEnvVar(envVarPrefix, "DISPERSER_KMS_KEY_ID"),
 	}
+	PaymentReconciliationEnabledFlag = cli.BoolFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "payment-reconciliation-enabled"),
+		Usage:    "Whether to refund the payments of blobs which fail after they were metered. Should be enabled iff the API server meters payments",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "PAYMENT_RECONCILIATION_ENABLED"),
+	}
+	PaymentReconciliationIntervalFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "payment-reconciliation-interval"),
+		Usage:    "Interval at which failed blobs are looked up for refunds",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "PAYMENT_RECONCILIATION_INTERVAL"),
+		Value:    30 * time.Second,
+	}
+	PaymentRefundTimeoutFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "payment-refund-timeout"),
+		Usage:    "Timeout for refunding the payment of a single failed blob",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "PAYMENT_REFUND_TIMEOUT"),
+		Value:    5 * time.Second,
+	}
+	AccountingReconciliationEnabledFlag = cli.BoolFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "accounting-reconciliation-enabled"),
+		Usage:    "Whether to periodically compare the off-chain payment accounting against the on-chain deposits and reservations",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "ACCOUNTING_RECONCILIATION_ENABLED"),
+	}
+	AccountingReconciliationIntervalFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "accounting-reconciliation-interval"),
+		Usage:    "Interval at which the off-chain payment accounting is compared against the chain",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "ACCOUNTING_RECONCILIATION_INTERVAL"),
+		Value:    10 * time.Minute,
+	}
+	AccountingReconciliationTimeoutFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "accounting-reconciliation-timeout"),
+		Usage:    "Timeout for comparing the off-chain payment accounting of all accounts against the chain",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "ACCOUNTING_RECONCILIATION_TIMEOUT"),
+		Value:    5 * time.Minute,
+	}
+	ReservationsTableNameFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "reservations-table-name"),
+		Usage:    "Name of the dynamodb table storing reservation usages",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "RESERVATIONS_TABLE_NAME"),
+		Value:    "reservations",
+	}
+	OnDemandTableNameFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "on-demand-table-name"),
+		Usage:    "Name of the dynamodb table storing on-demand payments",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "ON_DEMAND_TABLE_NAME"),
+		Value:    "on_demand",
+	}
+	GlobalRateTableNameFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "global-rate-table-name"),
+		Usage:    "Name of the dynamodb table storing global rate usage",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "GLOBAL_RATE_TABLE_NAME"),
+		Value:    "global_rate",
+	}
+	BlobGCEnabledFlag = cli.BoolFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "blob-gc-enabled"),
+		Usage:    "Whether to delete expired blobs and their chunks from the object store",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "BLOB_GC_ENABLED"),
+	}
+	S3BucketNameFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "s3-bucket-name"),
+		Usage:    "Name of the bucket storing blobs and their chunks. Required when the blob garbage collector is enabled",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "S3_BUCKET_NAME"),
+	}
+	BlobGCIntervalFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "blob-gc-interval"),
+		Usage:    "Interval at which expired blobs are looked up for deletion",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "BLOB_GC_INTERVAL"),
+		Value:    time.Minute,
+	}
+	BlobGCMaxBlobsPerIterationFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "blob-gc-max-blobs-per-iteration"),
+		Usage:    "Maximum number of blobs looked up for deletion in a single iteration",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "BLOB_GC_MAX_BLOBS_PER_ITERATION"),
+		Value:    1000,
+	}
+	BlobGCMaxPurgesPerSecondFlag = cli.Float64Flag{
+		Name:     common.PrefixFlag(FlagPrefix, "blob-gc-max-purges-per-second"),
+		Usage:    "Maximum number of expired blobs deleted per second",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "BLOB_GC_MAX_PURGES_PER_SECOND"),
+		Value:    20,
+	}
+	BlobGCPurgeTimeoutFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "blob-gc-purge-timeout"),
+		Usage:    "Timeout for deleting a single expired blob",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "BLOB_GC_PURGE_TIMEOUT"),
+		Value:    10 * time.Second,
+	}
+	BlobGCDryRunFlag = cli.BoolFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "blob-gc-dry-run"),
+		Usage:    "Report the expired blobs and bytes the blob garbage collector would delete, without deleting anything",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "BLOB_GC_DRY_RUN"),
+	}
 )
 
 var requiredFlags = []cli.Flag{
@@ -206,6 +329,8 @@ var optionalFlags = []cli.Flag{
 	NumEncodingRetriesFlag,
 	NumRelayAssignmentFlag,
 	NumConcurrentEncodingRequestsFlag,
+	EncodingSizeClassMaxBlobSizesFlag,
+	EncodingSizeClassNumConcurrentRequestsFlag,
 	MaxNumBlobsPerIterationFlag,
 	OnchainStateRefreshIntervalFlag,
 
@@ -217,6 +342,22 @@ var optionalFlags = []cli.Flag{
 	MetricsPortFlag,
 	DisperserStoreChunksSigningDisabledFlag,
 	DisperserKMSKeyIDFlag,
+	PaymentReconciliationEnabledFlag,
+	PaymentReconciliationIntervalFlag,
+	PaymentRefundTimeoutFlag,
+	AccountingReconciliationEnabledFlag,
+	AccountingReconciliationIntervalFlag,
+	AccountingReconciliationTimeoutFlag,
+	ReservationsTableNameFlag,
+	OnDemandTableNameFlag,
+	GlobalRateTableNameFlag,
+	BlobGCEnabledFlag,
+	S3BucketNameFlag,
+	BlobGCIntervalFlag,
+	BlobGCMaxBlobsPerIterationFlag,
+	BlobGCMaxPurgesPerSecondFlag,
+	BlobGCPurgeTimeoutFlag,
+	BlobGCDryRunFlag,
 }
 
 var Flags []cli.Flag
@@ -227,5 +368,9 @@ func init() {
 	Flags = append(Flags, common.LoggerCLIFlags(envVarPrefix, FlagPrefix)...)
 	Flags = append(Flags, indexer.CLIFlags(envVarPrefix)...)
 	Flags = append(Flags, aws.ClientFlags(envVarPrefix, FlagPrefix)...)
+	Flags = append(Flags, blobstore.MetadataStoreFlags(envVarPrefix, FlagPrefix)...)
+	Flags = append(Flags, grpctls.NodeClientFlags(envVarPrefix, FlagPrefix)...)
 	Flags = append(Flags, thegraph.CLIFlags(envVarPrefix)...)
+	Flags = append(Flags, tracing.CLIFlags(envVarPrefix, FlagPrefix)...)
+	Flags = append(Flags, admin.CLIFlags(envVarPrefix, FlagPrefix)...)
 }
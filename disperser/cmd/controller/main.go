@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/Layr-Labs/eigenda/api/clients/v2"
 	"github.com/prometheus/client_golang/prometheus"
@@ -15,16 +16,21 @@ import (
 
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/common/aws/dynamodb"
+	"github.com/Layr-Labs/eigenda/common/aws/s3"
 	"github.com/Layr-Labs/eigenda/common/geth"
+	"github.com/Layr-Labs/eigenda/common/tracing"
 	"github.com/Layr-Labs/eigenda/core"
 	"github.com/Layr-Labs/eigenda/core/eth"
 	"github.com/Layr-Labs/eigenda/core/indexer"
+	mt "github.com/Layr-Labs/eigenda/core/meterer"
 	"github.com/Layr-Labs/eigenda/core/thegraph"
 	corev2 "github.com/Layr-Labs/eigenda/core/v2"
 	"github.com/Layr-Labs/eigenda/disperser/cmd/controller/flags"
+	"github.com/Layr-Labs/eigenda/disperser/common/admin"
 	"github.com/Layr-Labs/eigenda/disperser/common/v2/blobstore"
 	"github.com/Layr-Labs/eigenda/disperser/controller"
 	"github.com/Layr-Labs/eigenda/disperser/encoder"
+	"github.com/Layr-Labs/eigensdk-go/logging"
 	gethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/gammazero/workerpool"
@@ -64,6 +70,10 @@ func RunController(ctx *cli.Context) error {
 		return err
 	}
 
+	if err := tracing.Init(context.Background(), config.TracingConfig, logger); err != nil {
+		return fmt.Errorf("failed to initialize tracing: %w", err)
+	}
+
 	dynamoClient, err := dynamodb.NewClient(config.AwsClientConfig, logger)
 	if err != nil {
 		return err
@@ -78,11 +88,16 @@ func RunController(ctx *cli.Context) error {
 		return err
 	}
 
-	blobMetadataStore := blobstore.NewBlobMetadataStore(
+	blobMetadataStore, err := blobstore.NewMetadataStore(
+		context.Background(),
+		config.MetadataStoreConfig,
 		dynamoClient,
-		logger,
 		config.DynamoDBTableName,
+		logger,
 	)
+	if err != nil {
+		return fmt.Errorf("failed to create blob metadata store: %w", err)
+	}
 
 	metricsRegistry := prometheus.NewRegistry()
 	metricsRegistry.MustRegister(collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}))
@@ -105,11 +120,20 @@ func RunController(ctx *cli.Context) error {
 		return fmt.Errorf("failed to create encoder client: %v", err)
 	}
 	encodingPool := workerpool.New(config.NumConcurrentEncodingRequests)
+	sizeClasses := make([]controller.BlobSizeClass, len(config.EncodingSizeClasses))
+	for i, sizeClass := range config.EncodingSizeClasses {
+		sizeClasses[i] = controller.BlobSizeClass{
+			Name:        fmt.Sprintf("up_to_%d_bytes", sizeClass.MaxBlobSize),
+			MaxBlobSize: sizeClass.MaxBlobSize,
+			Pool:        workerpool.New(sizeClass.NumConcurrentEncodingRequests),
+		}
+	}
 	encodingManagerBlobSet := controller.NewBlobSet()
 	encodingManager, err := controller.NewEncodingManager(
 		&config.EncodingManagerConfig,
 		blobMetadataStore,
 		encodingPool,
+		sizeClasses,
 		encoderClient,
 		chainReader,
 		logger,
@@ -168,7 +192,12 @@ func RunController(ctx *cli.Context) error {
 		}
 	}
 
-	nodeClientManager, err := controller.NewNodeClientManager(config.NodeClientCacheSize, requestSigner, logger)
+	nodeClientCredentials, err := config.NodeClientTLSConfig.Credentials()
+	if err != nil {
+		return fmt.Errorf("failed to load the node client tls config: %w", err)
+	}
+	nodeClientManager, err := controller.NewNodeClientManager(
+		config.NodeClientCacheSize, requestSigner, nodeClientCredentials, logger)
 	if err != nil {
 		return fmt.Errorf("failed to create node client manager: %v", err)
 	}
@@ -195,6 +224,44 @@ func RunController(ctx *cli.Context) error {
 
 	c := context.Background()
 
+	var paymentReconciler *controller.PaymentReconciler
+	if config.PaymentReconciliationEnabled {
+		paymentReconciler, err = newPaymentReconciler(c, config, blobMetadataStore, chainReader, logger, metricsRegistry)
+		if err != nil {
+			return fmt.Errorf("failed to create payment reconciler: %v", err)
+		}
+	}
+
+	var accountingReconciler *controller.AccountingReconciler
+	if config.AccountingReconciliationEnabled {
+		offchainStore, err := mt.NewOffchainStore(
+			config.AwsClientConfig,
+			config.ReservationsTableName,
+			config.OnDemandTableName,
+			config.GlobalRateTableName,
+			logger,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create offchain store: %v", err)
+		}
+		accountingReconciler, err = controller.NewAccountingReconciler(&config.AccountingReconcilerConfig, &offchainStore, chainReader, logger, metricsRegistry)
+		if err != nil {
+			return fmt.Errorf("failed to create accounting reconciler: %v", err)
+		}
+	}
+
+	var blobGC *controller.BlobGarbageCollector
+	if config.BlobGCEnabled {
+		s3Client, err := s3.NewClientWithReplication(c, config.AwsClientConfig, logger, metricsRegistry)
+		if err != nil {
+			return fmt.Errorf("failed to create s3 client: %v", err)
+		}
+		blobGC, err = controller.NewBlobGarbageCollector(&config.BlobGarbageCollectorConfig, blobMetadataStore, s3Client, logger, metricsRegistry)
+		if err != nil {
+			return fmt.Errorf("failed to create blob garbage collector: %v", err)
+		}
+	}
+
 	err = controller.RecoverState(c, blobMetadataStore, logger)
 	if err != nil {
 		return fmt.Errorf("failed to recover state: %v", err)
@@ -210,6 +277,41 @@ func RunController(ctx *cli.Context) error {
 		return fmt.Errorf("failed to start dispatcher: %v", err)
 	}
 
+	if paymentReconciler != nil {
+		err = paymentReconciler.Start(c)
+		if err != nil {
+			return fmt.Errorf("failed to start payment reconciler: %v", err)
+		}
+	}
+
+	if accountingReconciler != nil {
+		err = accountingReconciler.Start(c)
+		if err != nil {
+			return fmt.Errorf("failed to start accounting reconciler: %v", err)
+		}
+	}
+
+	if blobGC != nil {
+		err = blobGC.Start(c)
+		if err != nil {
+			return fmt.Errorf("failed to start blob garbage collector: %v", err)
+		}
+	}
+
+	if config.AdminConfig.GrpcPort != "" {
+		components := admin.Components{}
+		if accountingReconciler != nil {
+			components.Accounting = accountingReconciler
+		}
+		adminServer, err := admin.NewServer(config.AdminConfig, components, logger)
+		if err != nil {
+			return fmt.Errorf("failed to create admin server: %w", err)
+		}
+		if err := adminServer.Start(c); err != nil {
+			return fmt.Errorf("failed to start admin server: %w", err)
+		}
+	}
+
 	go func() {
 		err := metricsServer.ListenAndServe()
 		if err != nil && !strings.Contains(err.Error(), "http: Server closed") {
@@ -219,3 +321,54 @@ func RunController(ctx *cli.Context) error {
 
 	return nil
 }
+
+// newPaymentReconciler creates a payment reconciler which refunds failed blobs through a meterer configured like the
+// API server's
+func newPaymentReconciler(
+	ctx context.Context,
+	config Config,
+	blobMetadataStore blobstore.MetadataStore,
+	chainReader *eth.Reader,
+	logger logging.Logger,
+	registry *prometheus.Registry,
+) (*controller.PaymentReconciler, error) {
+	blockStaleMeasure, err := chainReader.GetBlockStaleMeasure(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get BLOCK_STALE_MEASURE: %w", err)
+	}
+	storeDurationBlocks, err := chainReader.GetStoreDurationBlocks(ctx)
+	if err != nil || storeDurationBlocks == 0 {
+		return nil, fmt.Errorf("failed to get STORE_DURATION_BLOCKS: %w", err)
+	}
+	defaultRetentionPeriod := time.Duration((storeDurationBlocks+blockStaleMeasure)*12) * time.Second
+
+	paymentChainState, err := mt.NewOnchainPaymentState(ctx, chainReader, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create onchain payment state: %w", err)
+	}
+	if err := paymentChainState.RefreshOnchainPaymentState(ctx); err != nil {
+		return nil, fmt.Errorf("failed to make initial query to the on-chain state: %w", err)
+	}
+	offchainStore, err := mt.NewOffchainStore(
+		config.AwsClientConfig,
+		config.ReservationsTableName,
+		config.OnDemandTableName,
+		config.GlobalRateTableName,
+		logger,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create offchain store: %w", err)
+	}
+	meterer := mt.NewMeterer(
+		mt.Config{
+			UpdateInterval:         config.EncodingManagerConfig.OnchainStateRefreshInterval,
+			DefaultRetentionPeriod: defaultRetentionPeriod,
+		},
+		paymentChainState,
+		offchainStore,
+		logger,
+	)
+	meterer.Start(ctx)
+
+	return controller.NewPaymentReconciler(&config.PaymentReconcilerConfig, blobMetadataStore, meterer, logger, registry)
+}
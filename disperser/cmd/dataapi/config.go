@@ -2,6 +2,7 @@ package main
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/common/aws"
@@ -15,14 +16,15 @@ import (
 )
 
 type Config struct {
-	ServerVersion    uint
-	AwsClientConfig  aws.ClientConfig
-	BlobstoreConfig  blobstore.Config
-	EthClientConfig  geth.EthClientConfig
-	LoggerConfig     common.LoggerConfig
-	PrometheusConfig prometheus.Config
-	MetricsConfig    dataapi.MetricsConfig
-	ChainStateConfig thegraph.Config
+	ServerVersion       uint
+	AwsClientConfig     aws.ClientConfig
+	BlobstoreConfig     blobstore.Config
+	MetadataStoreConfig blobstore.MetadataStoreConfig
+	EthClientConfig     geth.EthClientConfig
+	LoggerConfig        common.LoggerConfig
+	PrometheusConfig    prometheus.Config
+	MetricsConfig       dataapi.MetricsConfig
+	ChainStateConfig    thegraph.Config
 
 	SocketAddr                   string
 	PrometheusApiAddr            string
@@ -33,6 +35,8 @@ type Config struct {
 
 	BLSOperatorStateRetrieverAddr string
 	EigenDAServiceManagerAddr     string
+	CertVerifierAddr              string
+	CertVerifierPollInterval      time.Duration
 
 	DisperserHostname  string
 	ChurnerHostname    string
@@ -56,6 +60,7 @@ func NewConfig(ctx *cli.Context) (Config, error) {
 			TableName:  ctx.GlobalString(flags.DynamoTableNameFlag.Name),
 		},
 		AwsClientConfig:               aws.ReadClientConfig(ctx, flags.FlagPrefix),
+		MetadataStoreConfig:           blobstore.ReadMetadataStoreConfig(ctx, flags.FlagPrefix),
 		EthClientConfig:               ethClientConfig,
 		LoggerConfig:                  *loggerConfig,
 		SocketAddr:                    ctx.GlobalString(flags.SocketAddrFlag.Name),
@@ -63,6 +68,8 @@ func NewConfig(ctx *cli.Context) (Config, error) {
 		SubgraphApiOperatorStateAddr:  ctx.GlobalString(flags.SubgraphApiOperatorStateAddrFlag.Name),
 		BLSOperatorStateRetrieverAddr: ctx.GlobalString(flags.BlsOperatorStateRetrieverFlag.Name),
 		EigenDAServiceManagerAddr:     ctx.GlobalString(flags.EigenDAServiceManagerFlag.Name),
+		CertVerifierAddr:              ctx.GlobalString(flags.CertVerifierAddrFlag.Name),
+		CertVerifierPollInterval:      ctx.GlobalDuration(flags.CertVerifierPollIntervalFlag.Name),
 		ServerMode:                    ctx.GlobalString(flags.ServerModeFlag.Name),
 		ServerVersion:                 version,
 		PrometheusConfig: prometheus.Config{
@@ -1,10 +1,13 @@
 package flags
 
 import (
+	"time"
+
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/common/aws"
 	"github.com/Layr-Labs/eigenda/common/geth"
 	"github.com/Layr-Labs/eigenda/core/thegraph"
+	"github.com/Layr-Labs/eigenda/disperser/common/blobstore"
 	"github.com/urfave/cli"
 )
 
@@ -139,6 +142,19 @@ var (
 		Value:    1,
 		EnvVar:   common.PrefixEnvVar(envVarPrefix, "DATA_API_VERSION"),
 	}
+	CertVerifierAddrFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "cert-verifier-address"),
+		Usage:    "Address of the EigenDA Cert Verifier. If unset, the v2 certificate verification endpoint is disabled",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "CERT_VERIFIER_ADDRESS"),
+	}
+	CertVerifierPollIntervalFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "cert-verifier-poll-interval"),
+		Usage:    "How often to poll the eth client for the latest block number while waiting for it to reach the reference block number of a cert being verified",
+		Required: false,
+		Value:    time.Second,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "CERT_VERIFIER_POLL_INTERVAL"),
+	}
 )
 
 var requiredFlags = []cli.Flag{
@@ -164,6 +180,8 @@ var optionalFlags = []cli.Flag{
 	ServerModeFlag,
 	MetricsHTTPPort,
 	DataApiServerVersionFlag,
+	CertVerifierAddrFlag,
+	CertVerifierPollIntervalFlag,
 }
 
 // Flags contains the list of configuration options available to the binary.
@@ -174,5 +192,6 @@ func init() {
 	Flags = append(Flags, common.LoggerCLIFlags(envVarPrefix, FlagPrefix)...)
 	Flags = append(Flags, geth.EthClientFlags(envVarPrefix)...)
 	Flags = append(Flags, aws.ClientFlags(envVarPrefix, FlagPrefix)...)
+	Flags = append(Flags, blobstore.MetadataStoreFlags(envVarPrefix, FlagPrefix)...)
 	Flags = append(Flags, thegraph.CLIFlags(envVarPrefix)...)
 }
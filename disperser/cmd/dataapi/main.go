@@ -8,6 +8,7 @@ import (
 	"os/signal"
 	"syscall"
 
+	"github.com/Layr-Labs/eigenda/api/clients/v2/verification"
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/common/aws/dynamodb"
 	"github.com/Layr-Labs/eigenda/common/aws/s3"
@@ -91,10 +92,17 @@ func RunDataApi(ctx *cli.Context) error {
 		return err
 	}
 
+	blobMetadataStore, err := blobstore.NewMetadataStore(context.Background(), config.MetadataStoreConfig, dynamoClient, config.BlobstoreConfig.TableName, 0, logger)
+	if err != nil {
+		return fmt.Errorf("failed to create blob metadata store: %w", err)
+	}
+	sharedStorage, err := blobstore.NewSharedStorage(config.BlobstoreConfig.BucketName, s3Client, blobMetadataStore, logger)
+	if err != nil {
+		return err
+	}
+
 	var (
 		promClient        = dataapi.NewPrometheusClient(promApi, config.PrometheusConfig.Cluster)
-		blobMetadataStore = blobstore.NewBlobMetadataStore(dynamoClient, logger, config.BlobstoreConfig.TableName, 0)
-		sharedStorage     = blobstore.NewSharedStorage(config.BlobstoreConfig.BucketName, s3Client, blobMetadataStore, logger)
 		subgraphApi       = subgraph.NewApi(config.SubgraphApiBatchMetadataAddr, config.SubgraphApiOperatorStateAddr)
 		subgraphClient    = dataapi.NewSubgraphClient(subgraphApi, logger)
 		chainState        = coreeth.NewChainState(tx, client)
@@ -124,16 +132,29 @@ func RunDataApi(ctx *cli.Context) error {
 	)
 
 	if config.ServerVersion == 2 {
-		blobMetadataStorev2 := blobstorev2.NewBlobMetadataStore(dynamoClient, logger, config.BlobstoreConfig.TableName)
+		blobMetadataStorev2, err := blobstorev2.NewMetadataStore(context.Background(), config.MetadataStoreConfig, dynamoClient, config.BlobstoreConfig.TableName, logger)
+		if err != nil {
+			return fmt.Errorf("failed to create blob metadata store: %w", err)
+		}
 		metrics = dataapi.NewMetrics(config.ServerVersion, blobMetadataStorev2, config.MetricsConfig.HTTPPort, logger)
+
+		var certVerifier verification.ICertVerifier
+		if config.CertVerifierAddr != "" {
+			certVerifier, err = verification.NewCertVerifier(logger, client, config.CertVerifierPollInterval)
+			if err != nil {
+				return fmt.Errorf("failed to create cert verifier: %w", err)
+			}
+		}
+
 		serverv2 := serverv2.NewServerV2(
 			dataapi.Config{
-				ServerMode:         config.ServerMode,
-				SocketAddr:         config.SocketAddr,
-				AllowOrigins:       config.AllowOrigins,
-				DisperserHostname:  config.DisperserHostname,
-				ChurnerHostname:    config.ChurnerHostname,
-				BatcherHealthEndpt: config.BatcherHealthEndpt,
+				ServerMode:          config.ServerMode,
+				SocketAddr:          config.SocketAddr,
+				AllowOrigins:        config.AllowOrigins,
+				DisperserHostname:   config.DisperserHostname,
+				ChurnerHostname:     config.ChurnerHostname,
+				BatcherHealthEndpt:  config.BatcherHealthEndpt,
+				CertVerifierAddress: config.CertVerifierAddr,
 			},
 			blobMetadataStorev2,
 			promClient,
@@ -143,6 +164,7 @@ func RunDataApi(ctx *cli.Context) error {
 			indexedChainState,
 			logger,
 			metrics,
+			certVerifier,
 		)
 
 		// Enable Metrics Block
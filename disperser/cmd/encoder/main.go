@@ -7,6 +7,7 @@ import (
 	"os"
 
 	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/aws/envelope"
 	"github.com/Layr-Labs/eigenda/common/aws/s3"
 	"github.com/Layr-Labs/eigenda/disperser/cmd/encoder/flags"
 	blobstorev2 "github.com/Layr-Labs/eigenda/disperser/common/v2/blobstore"
@@ -91,7 +92,7 @@ func RunEncoderServer(ctx *cli.Context) error {
 			return fmt.Errorf("failed to create encoder: %w", err)
 		}
 
-		s3Client, err := s3.NewClient(context.Background(), config.AwsClientConfig, logger)
+		s3Client, err := s3.NewClientWithReplication(context.Background(), config.AwsClientConfig, logger, reg)
 		if err != nil {
 			return err
 		}
@@ -101,11 +102,16 @@ func RunEncoderServer(ctx *cli.Context) error {
 			return fmt.Errorf("blob store bucket name is required")
 		}
 
-		blobStore := blobstorev2.NewBlobStore(blobStoreBucketName, s3Client, logger)
+		encryptor, err := envelope.NewKMSEncryptor(context.Background(), config.AwsClientConfig)
+		if err != nil {
+			return fmt.Errorf("failed to create encryptor: %w", err)
+		}
+
+		blobStore := blobstorev2.NewBlobStore(blobStoreBucketName, s3Client, encryptor, logger)
 		logger.Info("Blob store", "bucket", blobStoreBucketName)
 
 		chunkStoreBucketName := config.ChunkStoreConfig.BucketName
-		chunkWriter := chunkstore.NewChunkWriter(logger, s3Client, chunkStoreBucketName, DefaultFragmentSizeBytes)
+		chunkWriter := chunkstore.NewChunkWriter(logger, s3Client, chunkStoreBucketName, DefaultFragmentSizeBytes, encryptor)
 		logger.Info("Chunk store writer", "bucket", blobStoreBucketName)
 
 		server := encoder.NewEncoderServerV2(
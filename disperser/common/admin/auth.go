@@ -0,0 +1,43 @@
+package admin
+
+import (
+	"context"
+	"crypto/subtle"
+	"strings"
+
+	"github.com/Layr-Labs/eigenda/api"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+const bearerPrefix = "Bearer "
+
+// NewTokenAuthInterceptor returns an interceptor which rejects requests that don't carry the given bearer token in
+// their "authorization" metadata
+func NewTokenAuthInterceptor(token string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if !hasToken(ctx, token) {
+			return nil, api.NewErrorUnauthenticated("missing or invalid admin token")
+		}
+		return handler(ctx, req)
+	}
+}
+
+func hasToken(ctx context.Context, token string) bool {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return false
+	}
+	for _, value := range md.Get("authorization") {
+		provided, found := strings.CutPrefix(value, bearerPrefix)
+		if found && subtle.ConstantTimeCompare([]byte(provided), []byte(token)) == 1 {
+			return true
+		}
+	}
+	return false
+}
+
+// WithToken returns a context which carries the given bearer token to the admin server
+func WithToken(ctx context.Context, token string) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, "authorization", bearerPrefix+token)
+}
@@ -0,0 +1,64 @@
+package admin
+
+import (
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/urfave/cli"
+)
+
+var (
+	HostFlagName        = "admin.host"
+	GrpcPortFlagName    = "admin.grpc-port"
+	TokenFlagName       = "admin.token"
+	TLSCertFileFlagName = "admin.tls-cert-file"
+	TLSKeyFileFlagName  = "admin.tls-key-file"
+)
+
+func CLIFlags(envPrefix string, flagPrefix string) []cli.Flag {
+	return []cli.Flag{
+		cli.StringFlag{
+			Name:     common.PrefixFlag(flagPrefix, HostFlagName),
+			Usage:    "Address the admin gRPC server listens on. Non-loopback addresses require the admin TLS flags",
+			Required: false,
+			Value:    DefaultHost,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "ADMIN_HOST"),
+		},
+		cli.StringFlag{
+			Name:     common.PrefixFlag(flagPrefix, GrpcPortFlagName),
+			Usage:    "Port of the admin gRPC server. The admin server is disabled if not set",
+			Required: false,
+			Value:    "",
+			EnvVar:   common.PrefixEnvVar(envPrefix, "ADMIN_GRPC_PORT"),
+		},
+		cli.StringFlag{
+			Name:     common.PrefixFlag(flagPrefix, TokenFlagName),
+			Usage:    "Bearer token which must accompany every request to the admin gRPC server",
+			Required: false,
+			Value:    "",
+			EnvVar:   common.PrefixEnvVar(envPrefix, "ADMIN_TOKEN"),
+		},
+		cli.StringFlag{
+			Name:     common.PrefixFlag(flagPrefix, TLSCertFileFlagName),
+			Usage:    "Path of the PEM encoded certificate the admin gRPC server serves TLS with",
+			Required: false,
+			Value:    "",
+			EnvVar:   common.PrefixEnvVar(envPrefix, "ADMIN_TLS_CERT_FILE"),
+		},
+		cli.StringFlag{
+			Name:     common.PrefixFlag(flagPrefix, TLSKeyFileFlagName),
+			Usage:    "Path of the PEM encoded private key of the admin TLS certificate",
+			Required: false,
+			Value:    "",
+			EnvVar:   common.PrefixEnvVar(envPrefix, "ADMIN_TLS_KEY_FILE"),
+		},
+	}
+}
+
+func ReadCLIConfig(ctx *cli.Context, flagPrefix string) Config {
+	return Config{
+		Host:        ctx.GlobalString(common.PrefixFlag(flagPrefix, HostFlagName)),
+		GrpcPort:    ctx.GlobalString(common.PrefixFlag(flagPrefix, GrpcPortFlagName)),
+		Token:       ctx.GlobalString(common.PrefixFlag(flagPrefix, TokenFlagName)),
+		TLSCertFile: ctx.GlobalString(common.PrefixFlag(flagPrefix, TLSCertFileFlagName)),
+		TLSKeyFile:  ctx.GlobalString(common.PrefixFlag(flagPrefix, TLSKeyFileFlagName)),
+	}
+}
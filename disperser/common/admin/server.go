@@ -0,0 +1,266 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api"
+	pb "github.com/Layr-Labs/eigenda/disperser/api/grpc/admin"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// DefaultHost is the address the admin server listens on if none is configured, which only accepts local connections
+const DefaultHost = "127.0.0.1"
+
+// Config configures the admin server
+type Config struct {
+	// Host is the address the admin server listens on. If empty, DefaultHost is used. The admin server only listens on
+	// a non-loopback address with TLS, so that the token isn't sent in plaintext over the network.
+	Host string
+	// GrpcPort is the port the admin server listens on. If empty, the admin server is disabled.
+	GrpcPort string
+	// Token is the bearer token which must accompany every admin request
+	Token string
+	// TLSCertFile and TLSKeyFile are the PEM encoded certificate and key the admin server serves TLS with. If empty,
+	// the admin server serves plaintext, which is only allowed on a loopback address.
+	TLSCertFile string
+	TLSKeyFile  string
+}
+
+// host returns the address the admin server listens on
+func (c *Config) host() string {
+	if c.Host == "" {
+		return DefaultHost
+	}
+	return c.Host
+}
+
+// Validate checks that the config is consistent, and that the token isn't exposed in plaintext to the network.
+func (c *Config) Validate() error {
+	if c.Token == "" {
+		return errors.New("admin token is required")
+	}
+	if (c.TLSCertFile == "") != (c.TLSKeyFile == "") {
+		return errors.New("the admin tls cert file and key file must be set together")
+	}
+	if c.TLSCertFile == "" && !isLoopback(c.host()) {
+		return fmt.Errorf("admin server must use tls to listen on the non-loopback address %s", c.host())
+	}
+	return nil
+}
+
+// isLoopback returns true if the host only accepts connections from the local machine
+func isLoopback(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// IntakeController pauses and resumes the intake of new dispersal requests
+type IntakeController interface {
+	PauseIntake(reason string)
+	ResumeIntake()
+}
+
+// BatchController controls the creation of batches
+type BatchController interface {
+	// Drain stops the encoding of new blobs, and creates batches from the encoded blobs until none are left
+	Drain()
+	// Resume restarts the encoding of new blobs after a drain
+	Resume()
+	// ForceBatch creates a batch from the encoded blobs without waiting for a batch trigger
+	ForceBatch()
+}
+
+// PaymentStateRefresher refreshes the on-chain payment state
+type PaymentStateRefresher interface {
+	RefreshOnchainPaymentState(ctx context.Context) error
+}
+
+// QueueStatsProvider reports statistics about internal queues
+type QueueStatsProvider interface {
+	QueueStats(ctx context.Context) (map[string]int64, error)
+}
+
+// AccountingDivergence is a mismatch between the payments of an account recorded off-chain and its on-chain state
+type AccountingDivergence struct {
+	AccountID string
+	// Kind is the kind of divergence, such as "over_charge"
+	Kind string
+	// OffchainValue is the off-chain value which diverges
+	OffchainValue *big.Int
+	// OnchainValue is the on-chain value OffchainValue is compared against
+	OnchainValue *big.Int
+}
+
+// AccountingReporter reports the divergences between the off-chain payment accounting and the on-chain state
+type AccountingReporter interface {
+	// AccountingDivergences returns the time of the last reconciliation, and the divergences it found
+	AccountingDivergences() (time.Time, []AccountingDivergence)
+}
+
+// Components are the parts of a disperser component which can be controlled through the admin server.
+// Methods of the admin API which act on a nil component return UNIMPLEMENTED.
+type Components struct {
+	Intake       IntakeController
+	Batcher      BatchController
+	PaymentState PaymentStateRefresher
+	QueueStats   QueueStatsProvider
+	Accounting   AccountingReporter
+}
+
+// Server implements the admin API on top of the controllable components of a disperser component
+type Server struct {
+	pb.UnimplementedAdminServer
+
+	config     Config
+	components Components
+	logger     logging.Logger
+}
+
+var _ pb.AdminServer = (*Server)(nil)
+
+func NewServer(config Config, components Components, logger logging.Logger) (*Server, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return &Server{
+		config:     config,
+		components: components,
+		logger:     logger.With("component", "AdminServer"),
+	}, nil
+}
+
+// Start serves the admin API until the context is cancelled
+func (s *Server) Start(ctx context.Context) error {
+	options := []grpc.ServerOption{grpc.UnaryInterceptor(NewTokenAuthInterceptor(s.config.Token))}
+	if s.config.TLSCertFile != "" {
+		creds, err := credentials.NewServerTLSFromFile(s.config.TLSCertFile, s.config.TLSKeyFile)
+		if err != nil {
+			return fmt.Errorf("failed to load admin tls certificate: %w", err)
+		}
+		options = append(options, grpc.Creds(creds))
+	}
+
+	addr := net.JoinHostPort(s.config.host(), s.config.GrpcPort)
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("could not start tcp listener: %w", err)
+	}
+
+	gs := grpc.NewServer(options...)
+	pb.RegisterAdminServer(gs, s)
+
+	go func() {
+		<-ctx.Done()
+		gs.GracefulStop()
+	}()
+
+	s.logger.Info("Admin GRPC Listening", "port", s.config.GrpcPort, "address", listener.Addr().String())
+	go func() {
+		if err := gs.Serve(listener); err != nil {
+			s.logger.Error("admin server stopped", "err", err)
+		}
+	}()
+
+	return nil
+}
+
+func (s *Server) PauseIntake(ctx context.Context, req *pb.PauseIntakeRequest) (*pb.PauseIntakeReply, error) {
+	if s.components.Intake == nil {
+		return nil, api.NewErrorUnimplemented()
+	}
+	s.logger.Warn("pausing dispersal intake", "reason", req.GetReason())
+	s.components.Intake.PauseIntake(req.GetReason())
+	return &pb.PauseIntakeReply{}, nil
+}
+
+func (s *Server) ResumeIntake(ctx context.Context, req *pb.ResumeIntakeRequest) (*pb.ResumeIntakeReply, error) {
+	if s.components.Intake == nil {
+		return nil, api.NewErrorUnimplemented()
+	}
+	s.logger.Warn("resuming dispersal intake")
+	s.components.Intake.ResumeIntake()
+	return &pb.ResumeIntakeReply{}, nil
+}
+
+func (s *Server) DrainBatcher(ctx context.Context, req *pb.DrainBatcherRequest) (*pb.DrainBatcherReply, error) {
+	if s.components.Batcher == nil {
+		return nil, api.NewErrorUnimplemented()
+	}
+	s.logger.Warn("draining batcher")
+	s.components.Batcher.Drain()
+	return &pb.DrainBatcherReply{}, nil
+}
+
+func (s *Server) ResumeBatcher(ctx context.Context, req *pb.ResumeBatcherRequest) (*pb.ResumeBatcherReply, error) {
+	if s.components.Batcher == nil {
+		return nil, api.NewErrorUnimplemented()
+	}
+	s.logger.Warn("resuming batcher")
+	s.components.Batcher.Resume()
+	return &pb.ResumeBatcherReply{}, nil
+}
+
+func (s *Server) ForceBatch(ctx context.Context, req *pb.ForceBatchRequest) (*pb.ForceBatchReply, error) {
+	if s.components.Batcher == nil {
+		return nil, api.NewErrorUnimplemented()
+	}
+	s.logger.Info("forcing a batch")
+	s.components.Batcher.ForceBatch()
+	return &pb.ForceBatchReply{}, nil
+}
+
+func (s *Server) RefreshPaymentState(ctx context.Context, req *pb.RefreshPaymentStateRequest) (*pb.RefreshPaymentStateReply, error) {
+	if s.components.PaymentState == nil {
+		return nil, api.NewErrorUnimplemented()
+	}
+	s.logger.Info("refreshing on-chain payment state")
+	if err := s.components.PaymentState.RefreshOnchainPaymentState(ctx); err != nil {
+		return nil, api.NewErrorInternal(fmt.Sprintf("failed to refresh on-chain payment state: %v", err))
+	}
+	return &pb.RefreshPaymentStateReply{}, nil
+}
+
+func (s *Server) GetQueueStats(ctx context.Context, req *pb.GetQueueStatsRequest) (*pb.GetQueueStatsReply, error) {
+	if s.components.QueueStats == nil {
+		return nil, api.NewErrorUnimplemented()
+	}
+	stats, err := s.components.QueueStats.QueueStats(ctx)
+	if err != nil {
+		return nil, api.NewErrorInternal(fmt.Sprintf("failed to get queue stats: %v", err))
+	}
+	return &pb.GetQueueStatsReply{
+		Stats: stats,
+	}, nil
+}
+
+func (s *Server) GetAccountingDivergences(ctx context.Context, req *pb.GetAccountingDivergencesRequest) (*pb.GetAccountingDivergencesReply, error) {
+	if s.components.Accounting == nil {
+		return nil, api.NewErrorUnimplemented()
+	}
+	reconciledAt, divergences := s.components.Accounting.AccountingDivergences()
+	reply := &pb.GetAccountingDivergencesReply{
+		Divergences: make([]*pb.AccountingDivergence, 0, len(divergences)),
+	}
+	if !reconciledAt.IsZero() {
+		reply.ReconciledAt = uint64(reconciledAt.Unix())
+	}
+	for _, divergence := range divergences {
+		reply.Divergences = append(reply.Divergences, &pb.AccountingDivergence{
+			AccountId:     divergence.AccountID,
+			Kind:          divergence.Kind,
+			OffchainValue: divergence.OffchainValue.String(),
+			OnchainValue:  divergence.OnchainValue.String(),
+		})
+	}
+	return reply, nil
+}
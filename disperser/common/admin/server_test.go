@@ -0,0 +1,224 @@
+package admin_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common/testutils"
+	pb "github.com/Layr-Labs/eigenda/disperser/api/grpc/admin"
+	"github.com/Layr-Labs/eigenda/disperser/common/admin"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	testPort  = "32071"
+	testToken = "secret"
+)
+
+type mockIntake struct {
+	pausedReason *string
+}
+
+func (m *mockIntake) PauseIntake(reason string) {
+	m.pausedReason = &reason
+}
+
+func (m *mockIntake) ResumeIntake() {
+	m.pausedReason = nil
+}
+
+type mockPaymentState struct {
+	err error
+}
+
+func (m *mockPaymentState) RefreshOnchainPaymentState(ctx context.Context) error {
+	return m.err
+}
+
+type mockQueueStats struct{}
+
+func (m *mockQueueStats) QueueStats(ctx context.Context) (map[string]int64, error) {
+	return map[string]int64{"queued_blobs": 3}, nil
+}
+
+type mockAccounting struct {
+	reconciledAt time.Time
+	divergences  []admin.AccountingDivergence
+}
+
+func (m *mockAccounting) AccountingDivergences() (time.Time, []admin.AccountingDivergence) {
+	return m.reconciledAt, m.divergences
+}
+
+func startServer(t *testing.T, components admin.Components) pb.AdminClient {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	server, err := admin.NewServer(admin.Config{GrpcPort: testPort, Token: testToken}, components, testutils.GetLogger())
+	require.NoError(t, err)
+	require.NoError(t, server.Start(ctx))
+
+	conn, err := grpc.NewClient("localhost:"+testPort, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+	return pb.NewAdminClient(conn)
+}
+
+func TestNewServerRequiresToken(t *testing.T) {
+	_, err := admin.NewServer(admin.Config{GrpcPort: testPort}, admin.Components{}, testutils.GetLogger())
+	require.Error(t, err)
+}
+
+func TestConfigValidate(t *testing.T) {
+	require.NoError(t, (&admin.Config{Token: testToken}).Validate())
+	require.NoError(t, (&admin.Config{Host: "localhost", Token: testToken}).Validate())
+	require.NoError(t, (&admin.Config{Host: "::1", Token: testToken}).Validate())
+
+	// the token isn't sent in plaintext to non-loopback addresses
+	require.Error(t, (&admin.Config{Host: "0.0.0.0", Token: testToken}).Validate())
+	require.Error(t, (&admin.Config{Host: "10.0.0.1", Token: testToken}).Validate())
+	require.NoError(t, (&admin.Config{
+		Host:        "0.0.0.0",
+		Token:       testToken,
+		TLSCertFile: "admin.crt",
+		TLSKeyFile:  "admin.key",
+	}).Validate())
+	require.Error(t, (&admin.Config{Token: testToken, TLSCertFile: "admin.crt"}).Validate())
+}
+
+func TestAdminServerTLS(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "admin"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		IsCA:         true,
+		// the self-signed certificate is its own CA
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "admin.crt")
+	keyFile := filepath.Join(dir, "admin.key")
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	server, err := admin.NewServer(admin.Config{
+		GrpcPort:    "32072",
+		Token:       testToken,
+		TLSCertFile: certFile,
+		TLSKeyFile:  keyFile,
+	}, admin.Components{Intake: &mockIntake{}}, testutils.GetLogger())
+	require.NoError(t, err)
+	require.NoError(t, server.Start(ctx))
+
+	roots := x509.NewCertPool()
+	roots.AppendCertsFromPEM(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+	conn, err := grpc.NewClient("127.0.0.1:32072",
+		grpc.WithTransportCredentials(credentials.NewTLS(&tls.Config{RootCAs: roots})))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+	_, err = pb.NewAdminClient(conn).PauseIntake(
+		admin.WithToken(context.Background(), testToken), &pb.PauseIntakeRequest{Reason: "maintenance"})
+	require.NoError(t, err)
+
+	// plaintext clients can't connect
+	plaintext, err := grpc.NewClient("127.0.0.1:32072", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = plaintext.Close()
+	})
+	_, err = pb.NewAdminClient(plaintext).PauseIntake(
+		admin.WithToken(context.Background(), testToken), &pb.PauseIntakeRequest{Reason: "maintenance"})
+	require.Error(t, err)
+}
+
+func TestAdminServer(t *testing.T) {
+	intake := &mockIntake{}
+	paymentState := &mockPaymentState{}
+	client := startServer(t, admin.Components{
+		Intake:       intake,
+		PaymentState: paymentState,
+		QueueStats:   &mockQueueStats{},
+		Accounting: &mockAccounting{
+			reconciledAt: time.Unix(1700000000, 0),
+			divergences: []admin.AccountingDivergence{
+				{AccountID: "0x01", Kind: "over_charge", OffchainValue: big.NewInt(200), OnchainValue: big.NewInt(100)},
+			},
+		},
+	})
+	ctx := admin.WithToken(context.Background(), testToken)
+
+	// Requests without a valid token are rejected
+	_, err := client.PauseIntake(context.Background(), &pb.PauseIntakeRequest{Reason: "maintenance"})
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+	_, err = client.PauseIntake(admin.WithToken(context.Background(), "wrong"), &pb.PauseIntakeRequest{Reason: "maintenance"})
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+	require.Nil(t, intake.pausedReason)
+
+	_, err = client.PauseIntake(ctx, &pb.PauseIntakeRequest{Reason: "maintenance"})
+	require.NoError(t, err)
+	require.NotNil(t, intake.pausedReason)
+	require.Equal(t, "maintenance", *intake.pausedReason)
+
+	_, err = client.ResumeIntake(ctx, &pb.ResumeIntakeRequest{})
+	require.NoError(t, err)
+	require.Nil(t, intake.pausedReason)
+
+	_, err = client.RefreshPaymentState(ctx, &pb.RefreshPaymentStateRequest{})
+	require.NoError(t, err)
+	paymentState.err = errors.New("rpc unavailable")
+	_, err = client.RefreshPaymentState(ctx, &pb.RefreshPaymentStateRequest{})
+	require.Equal(t, codes.Internal, status.Code(err))
+
+	reply, err := client.GetQueueStats(ctx, &pb.GetQueueStatsRequest{})
+	require.NoError(t, err)
+	require.Equal(t, map[string]int64{"queued_blobs": 3}, reply.GetStats())
+
+	divergences, err := client.GetAccountingDivergences(ctx, &pb.GetAccountingDivergencesRequest{})
+	require.NoError(t, err)
+	require.Equal(t, uint64(1700000000), divergences.GetReconciledAt())
+	require.Len(t, divergences.GetDivergences(), 1)
+	require.Equal(t, "0x01", divergences.GetDivergences()[0].GetAccountId())
+	require.Equal(t, "over_charge", divergences.GetDivergences()[0].GetKind())
+	require.Equal(t, "200", divergences.GetDivergences()[0].GetOffchainValue())
+	require.Equal(t, "100", divergences.GetDivergences()[0].GetOnchainValue())
+
+	// The batcher isn't one of the components, so its methods are unimplemented
+	_, err = client.ForceBatch(ctx, &pb.ForceBatchRequest{})
+	require.Equal(t, codes.Unimplemented, status.Code(err))
+	_, err = client.DrainBatcher(ctx, &pb.DrainBatcherRequest{})
+	require.Equal(t, codes.Unimplemented, status.Code(err))
+}
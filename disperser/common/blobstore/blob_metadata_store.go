@@ -2,6 +2,7 @@ package blobstore
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strconv"
 	"time"
@@ -12,6 +13,7 @@ import (
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -21,6 +23,8 @@ const (
 	statusIndexName = "StatusIndex"
 	batchIndexName  = "BatchIndex"
 	expiryIndexName = "Status-Expiry-Index"
+
+	fencingTokenAttribute = "FencingToken"
 )
 
 // BlobMetadataStore is a blob metadata storage backed by DynamoDB
@@ -46,13 +50,29 @@ func NewBlobMetadataStore(dynamoDBClient commondynamodb.Client, logger logging.L
 	}
 }
 
+var _ IndexedMetadataStore = (*BlobMetadataStore)(nil)
+
+// Capabilities returns the capabilities of the store. The Expiry attribute is the TTL attribute of the table, so
+// DynamoDB deletes expired metadata.
+func (s *BlobMetadataStore) Capabilities() disperser.MetadataCapabilities {
+	return disperser.MetadataCapabilities{
+		Transactions:     false,
+		TTL:              true,
+		SecondaryIndexes: true,
+	}
+}
+
+func (s *BlobMetadataStore) TTL() time.Duration {
+	return s.ttl
+}
+
 func (s *BlobMetadataStore) QueueNewBlobMetadata(ctx context.Context, blobMetadata *disperser.BlobMetadata) error {
 	item, err := MarshalBlobMetadata(blobMetadata)
 	if err != nil {
 		return err
 	}
 
-	return s.dynamoDBClient.PutItem(ctx, s.tableName, item)
+	return s.putItem(ctx, item)
 }
 
 func (s *BlobMetadataStore) GetBlobMetadata(ctx context.Context, blobKey disperser.BlobKey) (*disperser.BlobMetadata, error) {
@@ -65,14 +85,14 @@ func (s *BlobMetadataStore) GetBlobMetadata(ctx context.Context, blobKey dispers
 		},
 	})
 
-	if item == nil {
-		return nil, fmt.Errorf("%w: metadata not found for key %s", common.ErrMetadataNotFound, blobKey)
-	}
-
 	if err != nil {
 		return nil, err
 	}
 
+	if item == nil {
+		return nil, fmt.Errorf("%w: metadata not found for key %s", common.ErrMetadataNotFound, blobKey)
+	}
+
 	metadata, err := UnmarshalBlobMetadata(item)
 	if err != nil {
 		return nil, err
@@ -329,7 +349,7 @@ func (s *BlobMetadataStore) GetBlobMetadataInBatch(ctx context.Context, batchHea
 }
 
 func (s *BlobMetadataStore) IncrementNumRetries(ctx context.Context, existingMetadata *disperser.BlobMetadata) error {
-	_, err := s.dynamoDBClient.UpdateItem(ctx, s.tableName, map[string]types.AttributeValue{
+	return s.updateItem(ctx, map[string]types.AttributeValue{
 		"BlobHash": &types.AttributeValueMemberS{
 			Value: existingMetadata.BlobHash,
 		},
@@ -341,8 +361,6 @@ func (s *BlobMetadataStore) IncrementNumRetries(ctx context.Context, existingMet
 			Value: strconv.Itoa(int(existingMetadata.NumRetries + 1)),
 		},
 	})
-
-	return err
 }
 
 func (s *BlobMetadataStore) UpdateConfirmationBlockNumber(ctx context.Context, existingMetadata *disperser.BlobMetadata, confirmationBlockNumber uint32) error {
@@ -357,7 +375,7 @@ func (s *BlobMetadataStore) UpdateConfirmationBlockNumber(ctx context.Context, e
 		return err
 	}
 
-	_, err = s.dynamoDBClient.UpdateItem(ctx, s.tableName, map[string]types.AttributeValue{
+	return s.updateItem(ctx, map[string]types.AttributeValue{
 		"BlobHash": &types.AttributeValueMemberS{
 			Value: existingMetadata.BlobHash,
 		},
@@ -365,8 +383,6 @@ func (s *BlobMetadataStore) UpdateConfirmationBlockNumber(ctx context.Context, e
 			Value: existingMetadata.MetadataHash,
 		},
 	}, item)
-
-	return err
 }
 
 func (s *BlobMetadataStore) UpdateBlobMetadata(ctx context.Context, metadataKey disperser.BlobKey, updated *disperser.BlobMetadata) error {
@@ -375,7 +391,7 @@ func (s *BlobMetadataStore) UpdateBlobMetadata(ctx context.Context, metadataKey
 		return err
 	}
 
-	_, err = s.dynamoDBClient.UpdateItem(ctx, s.tableName, map[string]types.AttributeValue{
+	return s.updateItem(ctx, map[string]types.AttributeValue{
 		"BlobHash": &types.AttributeValueMemberS{
 			Value: metadataKey.BlobHash,
 		},
@@ -383,12 +399,10 @@ func (s *BlobMetadataStore) UpdateBlobMetadata(ctx context.Context, metadataKey
 			Value: metadataKey.MetadataHash,
 		},
 	}, item)
-
-	return err
 }
 
 func (s *BlobMetadataStore) SetBlobStatus(ctx context.Context, metadataKey disperser.BlobKey, status disperser.BlobStatus) error {
-	_, err := s.dynamoDBClient.UpdateItem(ctx, s.tableName, map[string]types.AttributeValue{
+	return s.updateItem(ctx, map[string]types.AttributeValue{
 		"BlobHash": &types.AttributeValueMemberS{
 			Value: metadataKey.BlobHash,
 		},
@@ -400,7 +414,49 @@ func (s *BlobMetadataStore) SetBlobStatus(ctx context.Context, metadataKey dispe
 			Value: strconv.Itoa(int(status)),
 		},
 	})
+}
 
+// putItem puts the metadata item. If the context carries a fencing token, the token is written with the item, and the
+// write is rejected if the existing item was written with a newer token.
+func (s *BlobMetadataStore) putItem(ctx context.Context, item commondynamodb.Item) error {
+	token, ok := common.FencingTokenFromContext(ctx)
+	if !ok {
+		return s.dynamoDBClient.PutItem(ctx, s.tableName, item)
+	}
+
+	item[fencingTokenAttribute] = &types.AttributeValueMemberN{Value: strconv.FormatUint(token, 10)}
+	err := s.dynamoDBClient.PutItemWithCondition(
+		ctx,
+		s.tableName,
+		item,
+		"attribute_not_exists(#token) OR #token <= :token",
+		map[string]string{"#token": fencingTokenAttribute},
+		map[string]types.AttributeValue{":token": item[fencingTokenAttribute]},
+	)
+	if errors.Is(err, commondynamodb.ErrConditionFailed) {
+		return fmt.Errorf("%w: metadata was written with a fencing token newer than %d", common.ErrStaleFencingToken, token)
+	}
+	return err
+}
+
+// updateItem updates the attributes of the metadata with the given key. If the context carries a fencing token, the
+// token is written in the same update, conditioned on the metadata not having been written with a newer token. As
+// DynamoDB evaluates the condition atomically with the write, a former leader can't overwrite the metadata once the
+// new leader has written it.
+func (s *BlobMetadataStore) updateItem(ctx context.Context, key commondynamodb.Key, item commondynamodb.Item) error {
+	token, ok := common.FencingTokenFromContext(ctx)
+	if !ok {
+		_, err := s.dynamoDBClient.UpdateItem(ctx, s.tableName, key, item)
+		return err
+	}
+
+	item[fencingTokenAttribute] = &types.AttributeValueMemberN{Value: strconv.FormatUint(token, 10)}
+	condition := expression.AttributeNotExists(expression.Name(fencingTokenAttribute)).
+		Or(expression.Name(fencingTokenAttribute).LessThanEqual(expression.Value(token)))
+	_, err := s.dynamoDBClient.UpdateItemWithCondition(ctx, s.tableName, key, item, condition)
+	if errors.Is(err, commondynamodb.ErrConditionFailed) {
+		return fmt.Errorf("%w: metadata was written with a fencing token newer than %d", common.ErrStaleFencingToken, token)
+	}
 	return err
 }
 
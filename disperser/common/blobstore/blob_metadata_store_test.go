@@ -7,11 +7,13 @@ import (
 
 	commondynamodb "github.com/Layr-Labs/eigenda/common/aws/dynamodb"
 	"github.com/Layr-Labs/eigenda/disperser"
+	dispcommon "github.com/Layr-Labs/eigenda/disperser/common"
 	"github.com/Layr-Labs/eigenda/encoding"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
 	"github.com/consensys/gnark-crypto/ecc/bn254/fp"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestBlobMetadataStoreOperations(t *testing.T) {
@@ -389,3 +391,61 @@ func getConfirmedMetadata(t *testing.T, metadata *disperser.BlobMetadata, blobIn
 	metadata.ConfirmationInfo = confirmationInfo
 	return metadata
 }
+
+func TestBlobMetadataStoreFencedWrites(t *testing.T) {
+	ctx := context.Background()
+	blobKey := disperser.BlobKey{
+		BlobHash:     "fencedBlob",
+		MetadataHash: "fencedHash",
+	}
+	now := time.Now()
+	metadata := &disperser.BlobMetadata{
+		MetadataHash: blobKey.MetadataHash,
+		BlobHash:     blobKey.BlobHash,
+		BlobStatus:   disperser.Processing,
+		Expiry:       uint64(now.Add(time.Hour).Unix()),
+		RequestMetadata: &disperser.RequestMetadata{
+			BlobRequestHeader: blob.RequestHeader,
+			BlobSize:          blobSize,
+			RequestedAt:       uint64(now.Unix()),
+		},
+	}
+	// blobs are queued by the API server, which doesn't hold a fencing token
+	err := blobMetadataStore.QueueNewBlobMetadata(ctx, metadata)
+	require.NoError(t, err)
+
+	formerLeaderCtx := dispcommon.WithFencingToken(ctx, 1)
+	leaderCtx := dispcommon.WithFencingToken(ctx, 2)
+
+	// the former leader can write until the new leader does
+	err = blobMetadataStore.SetBlobStatus(formerLeaderCtx, blobKey, disperser.Dispersing)
+	require.NoError(t, err)
+	err = blobMetadataStore.IncrementNumRetries(leaderCtx, metadata)
+	require.NoError(t, err)
+
+	err = blobMetadataStore.SetBlobStatus(formerLeaderCtx, blobKey, disperser.Failed)
+	require.ErrorIs(t, err, dispcommon.ErrStaleFencingToken)
+	err = blobMetadataStore.UpdateBlobMetadata(formerLeaderCtx, blobKey, getConfirmedMetadata(t, metadata, 1))
+	require.ErrorIs(t, err, dispcommon.ErrStaleFencingToken)
+	err = blobMetadataStore.QueueNewBlobMetadata(formerLeaderCtx, metadata)
+	require.ErrorIs(t, err, dispcommon.ErrStaleFencingToken)
+
+	fetched, err := blobMetadataStore.GetBlobMetadata(ctx, blobKey)
+	require.NoError(t, err)
+	require.Equal(t, disperser.Dispersing, fetched.BlobStatus)
+	require.Equal(t, uint(1), fetched.NumRetries)
+
+	// the new leader keeps writing with the same token
+	err = blobMetadataStore.SetBlobStatus(leaderCtx, blobKey, disperser.Failed)
+	require.NoError(t, err)
+	fetched, err = blobMetadataStore.GetBlobMetadata(ctx, blobKey)
+	require.NoError(t, err)
+	require.Equal(t, disperser.Failed, fetched.BlobStatus)
+
+	deleteItems(t, []commondynamodb.Key{
+		{
+			"MetadataHash": &types.AttributeValueMemberS{Value: blobKey.MetadataHash},
+			"BlobHash":     &types.AttributeValueMemberS{Value: blobKey.BlobHash},
+		},
+	})
+}
@@ -2,6 +2,7 @@ package blobstore_test
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"os"
 	"testing"
@@ -45,7 +46,14 @@ var (
 	deployLocalStack bool
 	localStackPort   = "4569"
 
+	postgresPool     *dockertest.Pool
+	postgresResource *dockertest.Resource
+
+	deployPostgres bool
+	postgresPort   = "5433"
+
 	dynamoClient      dynamodb.Client
+	postgresDB        *sql.DB
 	blobMetadataStore *blobstore.BlobMetadataStore
 	sharedStorage     *blobstore.SharedBlobStore
 
@@ -68,6 +76,11 @@ func setup(m *testing.M) {
 		localStackPort = os.Getenv("LOCALSTACK_PORT")
 	}
 
+	deployPostgres = !(os.Getenv("DEPLOY_POSTGRES") == "false")
+	if !deployPostgres {
+		postgresPort = os.Getenv("POSTGRES_PORT")
+	}
+
 	if deployLocalStack {
 		var err error
 		dockertestPool, dockertestResource, err = deploy.StartDockertestWithLocalstackContainer(localStackPort)
@@ -106,11 +119,32 @@ func setup(m *testing.M) {
 	}
 
 	blobMetadataStore = blobstore.NewBlobMetadataStore(dynamoClient, logger, metadataTableName, time.Hour)
-	sharedStorage = blobstore.NewSharedStorage(bucketName, s3Client, blobMetadataStore, logger)
+	sharedStorage, err = blobstore.NewSharedStorage(bucketName, s3Client, blobMetadataStore, logger)
+	if err != nil {
+		teardown()
+		panic("failed to create shared storage: " + err.Error())
+	}
+
+	if deployPostgres {
+		postgresPool, postgresResource, err = deploy.StartDockertestWithPostgresContainer(postgresPort)
+		if err != nil {
+			teardown()
+			panic("failed to start postgres container")
+		}
+	}
+
+	postgresDB, err = sql.Open("postgres", deploy.PostgresDSN(postgresPort))
+	if err != nil {
+		teardown()
+		panic("failed to open postgres database: " + err.Error())
+	}
 }
 
 func teardown() {
 	if deployLocalStack {
 		deploy.PurgeDockertestResources(dockertestPool, dockertestResource)
 	}
+	if deployPostgres {
+		deploy.PurgeDockertestResources(postgresPool, postgresResource)
+	}
 }
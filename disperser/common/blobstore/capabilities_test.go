@@ -0,0 +1,60 @@
+package blobstore
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common/testutils"
+	"github.com/Layr-Labs/eigenda/disperser"
+	"github.com/Layr-Labs/eigenda/disperser/common"
+	"github.com/stretchr/testify/require"
+)
+
+// capabilityStub is a metadata store which declares the given capabilities, but implements none of the optional
+// interfaces
+type capabilityStub struct {
+	MetadataStore
+	capabilities disperser.MetadataCapabilities
+}
+
+func (s *capabilityStub) Capabilities() disperser.MetadataCapabilities {
+	return s.capabilities
+}
+
+func TestCheckCapabilities(t *testing.T) {
+	logger := testutils.GetLogger()
+
+	postgresStore, err := NewPostgresBlobMetadataStore(&sql.DB{}, logger, "blob_metadata", time.Hour)
+	require.NoError(t, err)
+	require.NoError(t, checkCapabilities(postgresStore))
+	require.NoError(t, checkCapabilities(NewBlobMetadataStore(nil, logger, "blob_metadata", time.Hour)))
+
+	require.NoError(t, checkCapabilities(&capabilityStub{capabilities: disperser.MetadataCapabilities{TTL: true}}))
+	require.ErrorContains(t,
+		checkCapabilities(&capabilityStub{capabilities: disperser.MetadataCapabilities{TTL: true, SecondaryIndexes: true}}),
+		"secondary indexes")
+	require.ErrorContains(t,
+		checkCapabilities(&capabilityStub{capabilities: disperser.MetadataCapabilities{TTL: true, Transactions: true}}),
+		"transactions")
+	require.ErrorContains(t,
+		checkCapabilities(&capabilityStub{capabilities: disperser.MetadataCapabilities{}}),
+		"no TTL")
+}
+
+func TestSharedBlobStoreWithoutSecondaryIndexes(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewSharedStorage("bucket", nil, &capabilityStub{capabilities: disperser.MetadataCapabilities{TTL: true}}, testutils.GetLogger())
+	require.NoError(t, err)
+	require.False(t, store.MetadataCapabilities().SecondaryIndexes)
+
+	_, err = store.GetBlobMetadataByStatus(ctx, disperser.Processing)
+	require.ErrorIs(t, err, common.ErrCapabilityUnsupported)
+	_, err = store.GetMetadataInBatch(ctx, [32]byte{1}, 0)
+	require.ErrorIs(t, err, common.ErrCapabilityUnsupported)
+
+	deleted, err := store.DeleteExpiredBlobMetadata(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(0), deleted)
+}
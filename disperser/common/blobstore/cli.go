@@ -0,0 +1,133 @@
+package blobstore
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common"
+	commondynamodb "github.com/Layr-Labs/eigenda/common/aws/dynamodb"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/urfave/cli"
+
+	// registers the "postgres" database/sql driver
+	_ "github.com/lib/pq"
+)
+
+var (
+	MetadataBackendFlagName   = "metadata-store.backend"
+	PostgresDSNFlagName       = "metadata-store.postgres-dsn"
+	PostgresTableNameFlagName = "metadata-store.postgres-table-name"
+)
+
+const (
+	// DynamoDBBackend stores blob metadata in the DynamoDB table of the disperser
+	DynamoDBBackend = "dynamodb"
+	// PostgresBackend stores blob metadata in Postgres, for deployments without AWS
+	PostgresBackend = "postgres"
+)
+
+// MetadataStoreConfig selects the backend storing blob metadata
+type MetadataStoreConfig struct {
+	// Backend is the backend storing blob metadata, either "dynamodb" or "postgres". Default is "dynamodb".
+	Backend string
+	// PostgresDSN is the connection string of the Postgres database. Required by the postgres backend.
+	PostgresDSN string
+	// PostgresTableName is the name of the table storing blob metadata. The tables of the v2 metadata store are named
+	// with it as a prefix.
+	PostgresTableName string
+}
+
+func MetadataStoreFlags(envPrefix string, flagPrefix string) []cli.Flag {
+	return []cli.Flag{
+		cli.StringFlag{
+			Name:     common.PrefixFlag(flagPrefix, MetadataBackendFlagName),
+			Usage:    fmt.Sprintf("The backend storing blob metadata (%s or %s)", DynamoDBBackend, PostgresBackend),
+			Required: false,
+			Value:    DynamoDBBackend,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "METADATA_STORE_BACKEND"),
+		},
+		cli.StringFlag{
+			Name:     common.PrefixFlag(flagPrefix, PostgresDSNFlagName),
+			Usage:    "The connection string of the Postgres database storing blob metadata, when the backend is postgres",
+			Required: false,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "METADATA_STORE_POSTGRES_DSN"),
+		},
+		cli.StringFlag{
+			Name:     common.PrefixFlag(flagPrefix, PostgresTableNameFlagName),
+			Usage:    "The name of the Postgres table storing blob metadata, when the backend is postgres",
+			Required: false,
+			Value:    "blob_metadata",
+			EnvVar:   common.PrefixEnvVar(envPrefix, "METADATA_STORE_POSTGRES_TABLE_NAME"),
+		},
+	}
+}
+
+func ReadMetadataStoreConfig(ctx *cli.Context, flagPrefix string) MetadataStoreConfig {
+	return MetadataStoreConfig{
+		Backend:           ctx.GlobalString(common.PrefixFlag(flagPrefix, MetadataBackendFlagName)),
+		PostgresDSN:       ctx.GlobalString(common.PrefixFlag(flagPrefix, PostgresDSNFlagName)),
+		PostgresTableName: ctx.GlobalString(common.PrefixFlag(flagPrefix, PostgresTableNameFlagName)),
+	}
+}
+
+// Verify checks that the config names a known backend, with the settings it requires
+func (c MetadataStoreConfig) Verify() error {
+	switch c.Backend {
+	case "", DynamoDBBackend:
+		return nil
+	case PostgresBackend:
+		if c.PostgresDSN == "" {
+			return errors.New("the postgres metadata store backend requires a connection string")
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown metadata store backend %q", c.Backend)
+	}
+}
+
+// OpenPostgres opens the Postgres database of the config, and checks that it's reachable
+func (c MetadataStoreConfig) OpenPostgres(ctx context.Context) (*sql.DB, error) {
+	db, err := sql.Open("postgres", c.PostgresDSN)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open postgres database: %w", err)
+	}
+	if err := db.PingContext(ctx); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("failed to connect to postgres database: %w", err)
+	}
+	return db, nil
+}
+
+// NewMetadataStore creates the metadata store of the configured backend. The DynamoDB backend uses the given client and
+// table. The Postgres table is created if it doesn't exist.
+func NewMetadataStore(
+	ctx context.Context,
+	config MetadataStoreConfig,
+	dynamoDBClient commondynamodb.Client,
+	dynamoDBTableName string,
+	ttl time.Duration,
+	logger logging.Logger,
+) (MetadataStore, error) {
+	if err := config.Verify(); err != nil {
+		return nil, err
+	}
+	if config.Backend != PostgresBackend {
+		return NewBlobMetadataStore(dynamoDBClient, logger, dynamoDBTableName, ttl), nil
+	}
+
+	db, err := config.OpenPostgres(ctx)
+	if err != nil {
+		return nil, err
+	}
+	store, err := NewPostgresBlobMetadataStore(db, logger, config.PostgresTableName, ttl)
+	if err != nil {
+		return nil, err
+	}
+	if err := store.CreateTable(ctx); err != nil {
+		return nil, err
+	}
+	return store, nil
+}
@@ -0,0 +1,525 @@
+package blobstore
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/disperser"
+	"github.com/Layr-Labs/eigenda/disperser/common"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// postgresMetadataColumns are the columns of the metadata table, in the order they're scanned
+const postgresMetadataColumns = "blob_hash, metadata_hash, blob_status, expiry, num_retries, metadata"
+
+var postgresTableNameRegex = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]{0,62}$`)
+
+// PostgresBlobMetadataStore is a blob metadata storage backed by Postgres, for deployments without AWS.
+// The blob metadata is stored in a single table, with the fields queried by the disperser extracted into indexed columns:
+// - Metadata: (Primary Key: BlobHash, MetadataHash) -> Metadata
+// - Indexes
+//   - Status index: (BlobStatus, RequestedAt)
+//   - Expiry index: (BlobStatus, Expiry)
+//   - Batch index: (BatchHeaderHash, BlobIndex)
+//   - Account index: (AccountID, RequestedAt)
+//
+// The status, expiry and number of retries are updated in place, so the columns take precedence over the fields of the
+// serialized metadata. Writes made with a fencing token (see common.WithFencingToken) store it in the fencing_token
+// column, and are conditioned on it in the same statement.
+//
+// Binaries open the database with MetadataStoreConfig.OpenPostgres, which uses the lib/pq driver.
+type PostgresBlobMetadataStore struct {
+	db        *sql.DB
+	logger    logging.Logger
+	tableName string
+	ttl       time.Duration
+}
+
+var (
+	_ IndexedMetadataStore       = (*PostgresBlobMetadataStore)(nil)
+	_ TransactionalMetadataStore = (*PostgresBlobMetadataStore)(nil)
+	_ ExpiringMetadataStore      = (*PostgresBlobMetadataStore)(nil)
+)
+
+func NewPostgresBlobMetadataStore(db *sql.DB, logger logging.Logger, tableName string, ttl time.Duration) (*PostgresBlobMetadataStore, error) {
+	if db == nil {
+		return nil, errors.New("database is required")
+	}
+	if !postgresTableNameRegex.MatchString(tableName) {
+		return nil, fmt.Errorf("invalid table name %q", tableName)
+	}
+	logger.Debugf("creating postgres blob metadata store with table %s with TTL: %s", tableName, ttl)
+	return &PostgresBlobMetadataStore{
+		db:        db,
+		logger:    logger.With("component", "PostgresBlobMetadataStore"),
+		tableName: tableName,
+		ttl:       ttl,
+	}, nil
+}
+
+// CreateTable creates the metadata table and its indexes if they don't exist
+func (s *PostgresBlobMetadataStore) CreateTable(ctx context.Context) error {
+	statements := []string{
+		fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %[1]s (
+			blob_hash TEXT NOT NULL,
+			metadata_hash TEXT NOT NULL,
+			blob_status INTEGER NOT NULL,
+			requested_at BIGINT NOT NULL,
+			expiry BIGINT NOT NULL,
+			num_retries INTEGER NOT NULL,
+			account_id TEXT NOT NULL,
+			batch_header_hash BYTEA,
+			blob_index INTEGER,
+			metadata JSONB NOT NULL,
+			fencing_token BIGINT NOT NULL DEFAULT 0,
+			PRIMARY KEY (blob_hash, metadata_hash)
+		)`, s.tableName),
+		fmt.Sprintf("CREATE INDEX IF NOT EXISTS %[1]s_status_idx ON %[1]s (blob_status, requested_at)", s.tableName),
+		fmt.Sprintf("CREATE INDEX IF NOT EXISTS %[1]s_expiry_idx ON %[1]s (blob_status, expiry)", s.tableName),
+		fmt.Sprintf("CREATE INDEX IF NOT EXISTS %[1]s_batch_idx ON %[1]s (batch_header_hash, blob_index)", s.tableName),
+		fmt.Sprintf("CREATE INDEX IF NOT EXISTS %[1]s_account_idx ON %[1]s (account_id, requested_at)", s.tableName),
+	}
+	for _, statement := range statements {
+		if _, err := s.db.ExecContext(ctx, statement); err != nil {
+			return fmt.Errorf("failed to create metadata table %s: %w", s.tableName, err)
+		}
+	}
+	return nil
+}
+
+// Capabilities returns the capabilities of the store. Postgres has no TTL, so expired metadata is deleted by the disperser.
+func (s *PostgresBlobMetadataStore) Capabilities() disperser.MetadataCapabilities {
+	return disperser.MetadataCapabilities{
+		Transactions:     true,
+		TTL:              false,
+		SecondaryIndexes: true,
+	}
+}
+
+func (s *PostgresBlobMetadataStore) TTL() time.Duration {
+	return s.ttl
+}
+
+func (s *PostgresBlobMetadataStore) QueueNewBlobMetadata(ctx context.Context, blobMetadata *disperser.BlobMetadata) error {
+	row, err := newPostgresMetadataRow(blobMetadata)
+	if err != nil {
+		return err
+	}
+	args := []any{blobMetadata.BlobHash, blobMetadata.MetadataHash, row.blobStatus, row.requestedAt, row.expiry,
+		row.numRetries, row.accountID, row.batchHeaderHash, row.blobIndex, row.metadata}
+	columns := ""
+	values := ""
+	fence := ""
+	token, fenced := common.FencingTokenFromContext(ctx)
+	if fenced {
+		args = append(args, int64(token))
+		columns = ", fencing_token"
+		values = ", $11"
+		fence = fmt.Sprintf(", fencing_token = EXCLUDED.fencing_token WHERE %s.fencing_token <= EXCLUDED.fencing_token", s.tableName)
+	}
+	result, err := s.db.ExecContext(ctx, fmt.Sprintf(`INSERT INTO %[1]s
+		(blob_hash, metadata_hash, blob_status, requested_at, expiry, num_retries, account_id, batch_header_hash, blob_index, metadata%[2]s)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10%[3]s)
+		ON CONFLICT (blob_hash, metadata_hash) DO UPDATE SET
+		blob_status = EXCLUDED.blob_status, requested_at = EXCLUDED.requested_at, expiry = EXCLUDED.expiry,
+		num_retries = EXCLUDED.num_retries, account_id = EXCLUDED.account_id, batch_header_hash = EXCLUDED.batch_header_hash,
+		blob_index = EXCLUDED.blob_index, metadata = EXCLUDED.metadata%[4]s`, s.tableName, columns, values, fence),
+		args...)
+	if err != nil {
+		return err
+	}
+	if !fenced {
+		return nil
+	}
+	inserted, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if inserted == 0 {
+		return fmt.Errorf("%w: metadata for key %s was written with a fencing token newer than %d",
+			common.ErrStaleFencingToken, blobMetadata.GetBlobKey(), token)
+	}
+	return nil
+}
+
+func (s *PostgresBlobMetadataStore) GetBlobMetadata(ctx context.Context, blobKey disperser.BlobKey) (*disperser.BlobMetadata, error) {
+	metadata, err := s.query(ctx,
+		fmt.Sprintf("SELECT %s FROM %s WHERE blob_hash = $1 AND metadata_hash = $2", postgresMetadataColumns, s.tableName),
+		blobKey.BlobHash, blobKey.MetadataHash)
+	if err != nil {
+		return nil, err
+	}
+	if len(metadata) == 0 {
+		return nil, fmt.Errorf("%w: metadata not found for key %s", common.ErrMetadataNotFound, blobKey)
+	}
+	return metadata[0], nil
+}
+
+// GetBulkBlobMetadata returns the metadata for the given blob keys
+// Note: ordering of items is not guaranteed
+func (s *PostgresBlobMetadataStore) GetBulkBlobMetadata(ctx context.Context, blobKeys []disperser.BlobKey) ([]*disperser.BlobMetadata, error) {
+	if len(blobKeys) == 0 {
+		return []*disperser.BlobMetadata{}, nil
+	}
+	conditions := make([]string, len(blobKeys))
+	args := make([]any, 0, 2*len(blobKeys))
+	for i, blobKey := range blobKeys {
+		conditions[i] = fmt.Sprintf("(blob_hash = $%d AND metadata_hash = $%d)", 2*i+1, 2*i+2)
+		args = append(args, blobKey.BlobHash, blobKey.MetadataHash)
+	}
+	return s.query(ctx,
+		fmt.Sprintf("SELECT %s FROM %s WHERE %s", postgresMetadataColumns, s.tableName, strings.Join(conditions, " OR ")),
+		args...)
+}
+
+// GetBlobMetadataByStatus returns all the unexpired metadata with the given status
+func (s *PostgresBlobMetadataStore) GetBlobMetadataByStatus(ctx context.Context, status disperser.BlobStatus) ([]*disperser.BlobMetadata, error) {
+	return s.query(ctx,
+		fmt.Sprintf("SELECT %s FROM %s WHERE blob_status = $1 AND expiry > $2 ORDER BY expiry", postgresMetadataColumns, s.tableName),
+		int(status), time.Now().Unix())
+}
+
+// GetBlobMetadataCountByStatus returns the count of all the unexpired metadata with the given status
+func (s *PostgresBlobMetadataStore) GetBlobMetadataCountByStatus(ctx context.Context, status disperser.BlobStatus) (int32, error) {
+	var count int32
+	err := s.db.QueryRowContext(ctx,
+		fmt.Sprintf("SELECT COUNT(*) FROM %s WHERE blob_status = $1 AND expiry > $2", s.tableName),
+		int(status), time.Now().Unix()).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// GetBlobMetadataByStatusWithPagination returns all the metadata with the given status upto the specified limit
+// along with items, also returns a pagination token that can be used to fetch the next set of items
+func (s *PostgresBlobMetadataStore) GetBlobMetadataByStatusWithPagination(ctx context.Context, status disperser.BlobStatus, limit int32, exclusiveStartKey *disperser.BlobStoreExclusiveStartKey) ([]*disperser.BlobMetadata, *disperser.BlobStoreExclusiveStartKey, error) {
+	condition := "blob_status = $1 AND expiry > $2"
+	args := []any{int(status), time.Now().Unix()}
+	if exclusiveStartKey != nil {
+		condition += " AND (expiry, blob_hash, metadata_hash) > ($3, $4, $5)"
+		args = append(args, exclusiveStartKey.Expiry, exclusiveStartKey.BlobHash, exclusiveStartKey.MetadataHash)
+	}
+	metadata, err := s.query(ctx,
+		fmt.Sprintf("SELECT %s FROM %s WHERE %s ORDER BY expiry, blob_hash, metadata_hash LIMIT %d",
+			postgresMetadataColumns, s.tableName, condition, limit),
+		args...)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(metadata) == 0 {
+		return nil, nil, nil
+	}
+	if len(metadata) < int(limit) {
+		return metadata, nil, nil
+	}
+
+	last := metadata[len(metadata)-1]
+	return metadata, &disperser.BlobStoreExclusiveStartKey{
+		BlobHash:     last.BlobHash,
+		MetadataHash: last.MetadataHash,
+		BlobStatus:   int32(last.BlobStatus),
+		Expiry:       int64(last.Expiry),
+	}, nil
+}
+
+func (s *PostgresBlobMetadataStore) GetAllBlobMetadataByBatch(ctx context.Context, batchHeaderHash [32]byte) ([]*disperser.BlobMetadata, error) {
+	metadata, err := s.query(ctx,
+		fmt.Sprintf("SELECT %s FROM %s WHERE batch_header_hash = $1 ORDER BY blob_index", postgresMetadataColumns, s.tableName),
+		batchHeaderHash[:])
+	if err != nil {
+		return nil, err
+	}
+	if len(metadata) == 0 {
+		return nil, fmt.Errorf("there is no metadata for batch %x", batchHeaderHash)
+	}
+	return metadata, nil
+}
+
+// GetAllBlobMetadataByBatchWithPagination returns all the metadata in the batch upto the specified limit
+// along with items, also returns a pagination token that can be used to fetch the next set of items
+func (s *PostgresBlobMetadataStore) GetAllBlobMetadataByBatchWithPagination(
+	ctx context.Context,
+	batchHeaderHash [32]byte,
+	limit int32,
+	exclusiveStartKey *disperser.BatchIndexExclusiveStartKey,
+) ([]*disperser.BlobMetadata, *disperser.BatchIndexExclusiveStartKey, error) {
+	condition := "batch_header_hash = $1"
+	args := []any{batchHeaderHash[:]}
+	if exclusiveStartKey != nil {
+		condition += " AND blob_index > $2"
+		args = append(args, exclusiveStartKey.BlobIndex)
+	}
+	metadata, err := s.query(ctx,
+		fmt.Sprintf("SELECT %s FROM %s WHERE %s ORDER BY blob_index LIMIT %d", postgresMetadataColumns, s.tableName, condition, limit),
+		args...)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(metadata) == 0 {
+		return nil, nil, nil
+	}
+	if len(metadata) < int(limit) {
+		return metadata, nil, nil
+	}
+
+	last := metadata[len(metadata)-1]
+	return metadata, &disperser.BatchIndexExclusiveStartKey{
+		BlobHash:        last.BlobHash,
+		MetadataHash:    last.MetadataHash,
+		BatchHeaderHash: batchHeaderHash[:],
+		BlobIndex:       last.ConfirmationInfo.BlobIndex,
+	}, nil
+}
+
+func (s *PostgresBlobMetadataStore) GetBlobMetadataInBatch(ctx context.Context, batchHeaderHash [32]byte, blobIndex uint32) (*disperser.BlobMetadata, error) {
+	metadata, err := s.query(ctx,
+		fmt.Sprintf("SELECT %s FROM %s WHERE batch_header_hash = $1 AND blob_index = $2", postgresMetadataColumns, s.tableName),
+		batchHeaderHash[:], blobIndex)
+	if err != nil {
+		return nil, err
+	}
+	if len(metadata) == 0 {
+		return nil, fmt.Errorf("%w: there is no metadata for batch %s and blob index %d", common.ErrMetadataNotFound, hexutil.Encode(batchHeaderHash[:]), blobIndex)
+	}
+	if len(metadata) > 1 {
+		s.logger.Error("there are multiple metadata for batch and blob index", "batchHeaderHash", hexutil.Encode(batchHeaderHash[:]), "blobIndex", blobIndex)
+	}
+	return metadata[0], nil
+}
+
+// GetBlobMetadataByAccount returns the metadata of the most recent blobs requested by the account, newest first
+func (s *PostgresBlobMetadataStore) GetBlobMetadataByAccount(ctx context.Context, accountID core.AccountID, limit int32) ([]*disperser.BlobMetadata, error) {
+	return s.query(ctx,
+		fmt.Sprintf("SELECT %s FROM %s WHERE account_id = $1 ORDER BY requested_at DESC LIMIT %d", postgresMetadataColumns, s.tableName, limit),
+		accountID)
+}
+
+func (s *PostgresBlobMetadataStore) IncrementNumRetries(ctx context.Context, existingMetadata *disperser.BlobMetadata) error {
+	return s.update(ctx, existingMetadata.GetBlobKey(), "num_retries = $3", existingMetadata.NumRetries+1)
+}
+
+func (s *PostgresBlobMetadataStore) UpdateConfirmationBlockNumber(ctx context.Context, existingMetadata *disperser.BlobMetadata, confirmationBlockNumber uint32) error {
+	updated := *existingMetadata
+	if updated.ConfirmationInfo == nil {
+		return fmt.Errorf("failed to update confirmation block number because confirmation info is missing for blob key %s", existingMetadata.GetBlobKey().String())
+	}
+
+	updated.ConfirmationInfo.ConfirmationBlockNumber = confirmationBlockNumber
+	return s.UpdateBlobMetadata(ctx, existingMetadata.GetBlobKey(), &updated)
+}
+
+func (s *PostgresBlobMetadataStore) UpdateBlobMetadata(ctx context.Context, metadataKey disperser.BlobKey, updated *disperser.BlobMetadata) error {
+	row, err := newPostgresMetadataRow(updated)
+	if err != nil {
+		return err
+	}
+	return s.update(ctx, metadataKey,
+		`blob_status = $3, requested_at = $4, expiry = $5, num_retries = $6, account_id = $7, batch_header_hash = $8,
+		blob_index = $9, metadata = $10`,
+		row.blobStatus, row.requestedAt, row.expiry, row.numRetries, row.accountID, row.batchHeaderHash, row.blobIndex, row.metadata)
+}
+
+func (s *PostgresBlobMetadataStore) SetBlobStatus(ctx context.Context, metadataKey disperser.BlobKey, status disperser.BlobStatus) error {
+	return s.update(ctx, metadataKey, "blob_status = $3", int(status))
+}
+
+func (s *PostgresBlobMetadataStore) UpdateBlobMetadataAtomically(ctx context.Context, metadataKey disperser.BlobKey, update func(*disperser.BlobMetadata) (*disperser.BlobMetadata, error)) (*disperser.BlobMetadata, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	var (
+		blobStatus   int
+		expiry       int64
+		numRetries   int
+		data         []byte
+		fencingToken int64
+	)
+	err = tx.QueryRowContext(ctx,
+		fmt.Sprintf("SELECT blob_status, expiry, num_retries, metadata, fencing_token FROM %s WHERE blob_hash = $1 AND metadata_hash = $2 FOR UPDATE", s.tableName),
+		metadataKey.BlobHash, metadataKey.MetadataHash).Scan(&blobStatus, &expiry, &numRetries, &data, &fencingToken)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: metadata not found for key %s", common.ErrMetadataNotFound, metadataKey)
+	}
+	if err != nil {
+		return nil, err
+	}
+	// the row is locked, so the token can't be superseded before the update below
+	token, fenced := common.FencingTokenFromContext(ctx)
+	if fenced && fencingToken > int64(token) {
+		return nil, fmt.Errorf("%w: metadata for key %s was written with fencing token %d, newer than %d",
+			common.ErrStaleFencingToken, metadataKey, fencingToken, token)
+	}
+	if !fenced {
+		token = uint64(fencingToken)
+	}
+	existing, err := unmarshalPostgresMetadata(data, blobStatus, expiry, numRetries)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal metadata for key %s: %w", metadataKey, err)
+	}
+
+	updated, err := update(existing)
+	if err != nil {
+		return nil, err
+	}
+	row, err := newPostgresMetadataRow(updated)
+	if err != nil {
+		return nil, err
+	}
+	_, err = tx.ExecContext(ctx,
+		fmt.Sprintf(`UPDATE %s SET blob_status = $3, requested_at = $4, expiry = $5, num_retries = $6, account_id = $7,
+		batch_header_hash = $8, blob_index = $9, metadata = $10, fencing_token = $11 WHERE blob_hash = $1 AND metadata_hash = $2`, s.tableName),
+		metadataKey.BlobHash, metadataKey.MetadataHash, row.blobStatus, row.requestedAt, row.expiry, row.numRetries,
+		row.accountID, row.batchHeaderHash, row.blobIndex, row.metadata, int64(token))
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return updated, nil
+}
+
+// DeleteExpiredBlobMetadata deletes the metadata which expired before the given time. Metadata without an expiry is
+// retained.
+func (s *PostgresBlobMetadataStore) DeleteExpiredBlobMetadata(ctx context.Context, expiredBefore time.Time) (int64, error) {
+	result, err := s.db.ExecContext(ctx,
+		fmt.Sprintf("DELETE FROM %s WHERE expiry > 0 AND expiry < $1", s.tableName),
+		expiredBefore.Unix())
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// update sets the columns of the metadata with the given key. The placeholders of the values start at $3. If the
+// context carries a fencing token, the token is set in the same statement, which only matches the metadata if it wasn't
+// written with a newer token.
+func (s *PostgresBlobMetadataStore) update(ctx context.Context, metadataKey disperser.BlobKey, set string, values ...any) error {
+	args := append([]any{metadataKey.BlobHash, metadataKey.MetadataHash}, values...)
+	query := fmt.Sprintf("UPDATE %s SET %s WHERE blob_hash = $1 AND metadata_hash = $2", s.tableName, set)
+	token, fenced := common.FencingTokenFromContext(ctx)
+	if fenced {
+		args = append(args, int64(token))
+		query = fmt.Sprintf("UPDATE %[1]s SET %[2]s, fencing_token = $%[3]d WHERE blob_hash = $1 AND metadata_hash = $2 AND fencing_token <= $%[3]d",
+			s.tableName, set, len(args))
+	}
+	result, err := s.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	updated, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if updated > 0 {
+		return nil
+	}
+	if fenced {
+		// tell a stale token apart from missing metadata
+		var exists bool
+		err = s.db.QueryRowContext(ctx,
+			fmt.Sprintf("SELECT EXISTS (SELECT 1 FROM %s WHERE blob_hash = $1 AND metadata_hash = $2)", s.tableName),
+			metadataKey.BlobHash, metadataKey.MetadataHash).Scan(&exists)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return fmt.Errorf("%w: metadata for key %s was written with a fencing token newer than %d",
+				common.ErrStaleFencingToken, metadataKey, token)
+		}
+	}
+	return fmt.Errorf("%w: metadata not found for key %s", common.ErrMetadataNotFound, metadataKey)
+}
+
+func (s *PostgresBlobMetadataStore) query(ctx context.Context, query string, args ...any) ([]*disperser.BlobMetadata, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	metadata := make([]*disperser.BlobMetadata, 0)
+	for rows.Next() {
+		var (
+			blobHash     string
+			metadataHash string
+			blobStatus   int
+			expiry       int64
+			numRetries   int
+			data         []byte
+		)
+		if err := rows.Scan(&blobHash, &metadataHash, &blobStatus, &expiry, &numRetries, &data); err != nil {
+			return nil, err
+		}
+		m, err := unmarshalPostgresMetadata(data, blobStatus, expiry, numRetries)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal metadata for key %s-%s: %w", blobHash, metadataHash, err)
+		}
+		metadata = append(metadata, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return metadata, nil
+}
+
+// postgresMetadataRow holds the values of the columns of a row in the metadata table, other than its key
+type postgresMetadataRow struct {
+	blobStatus      int
+	requestedAt     int64
+	expiry          int64
+	numRetries      int
+	accountID       string
+	batchHeaderHash []byte
+	blobIndex       sql.NullInt64
+	metadata        []byte
+}
+
+func newPostgresMetadataRow(metadata *disperser.BlobMetadata) (*postgresMetadataRow, error) {
+	data, err := json.Marshal(metadata)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal metadata: %w", err)
+	}
+	row := &postgresMetadataRow{
+		blobStatus: int(metadata.BlobStatus),
+		expiry:     int64(metadata.Expiry),
+		numRetries: int(metadata.NumRetries),
+		metadata:   data,
+	}
+	if metadata.RequestMetadata != nil {
+		row.requestedAt = int64(metadata.RequestMetadata.RequestedAt)
+		row.accountID = metadata.RequestMetadata.AccountID
+	}
+	if metadata.ConfirmationInfo != nil {
+		row.batchHeaderHash = metadata.ConfirmationInfo.BatchHeaderHash[:]
+		row.blobIndex = sql.NullInt64{Int64: int64(metadata.ConfirmationInfo.BlobIndex), Valid: true}
+	}
+	return row, nil
+}
+
+func unmarshalPostgresMetadata(data []byte, blobStatus int, expiry int64, numRetries int) (*disperser.BlobMetadata, error) {
+	metadata := &disperser.BlobMetadata{}
+	if err := json.Unmarshal(data, metadata); err != nil {
+		return nil, err
+	}
+	metadata.BlobStatus = disperser.BlobStatus(blobStatus)
+	metadata.Expiry = uint64(expiry)
+	metadata.NumRetries = uint(numRetries)
+	return metadata, nil
+}
@@ -0,0 +1,85 @@
+package blobstore
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common/testutils"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/disperser"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPostgresBlobMetadataStore(t *testing.T) {
+	logger := testutils.GetLogger()
+
+	_, err := NewPostgresBlobMetadataStore(nil, logger, "blob_metadata", time.Hour)
+	require.ErrorContains(t, err, "database is required")
+
+	db := &sql.DB{}
+	for _, tableName := range []string{"", "blob-metadata", "1blob_metadata", "blob_metadata; DROP TABLE blob_metadata"} {
+		_, err = NewPostgresBlobMetadataStore(db, logger, tableName, time.Hour)
+		require.ErrorContains(t, err, "invalid table name")
+	}
+
+	store, err := NewPostgresBlobMetadataStore(db, logger, "blob_metadata", time.Hour)
+	require.NoError(t, err)
+	require.Equal(t, time.Hour, store.TTL())
+}
+
+func TestPostgresMetadataRow(t *testing.T) {
+	metadata := &disperser.BlobMetadata{
+		BlobHash:     "blob1",
+		MetadataHash: "hash1",
+		BlobStatus:   disperser.Confirmed,
+		Expiry:       1000,
+		NumRetries:   1,
+		RequestMetadata: &disperser.RequestMetadata{
+			BlobRequestHeader: core.BlobRequestHeader{
+				BlobAuthHeader: core.BlobAuthHeader{
+					AccountID: "0x1234",
+				},
+				SecurityParams: []*core.SecurityParam{
+					{QuorumID: 0, AdversaryThreshold: 80, ConfirmationThreshold: 100},
+				},
+			},
+			BlobSize:    32,
+			RequestedAt: 123,
+		},
+		ConfirmationInfo: &disperser.ConfirmationInfo{
+			BatchHeaderHash:         [32]byte{1, 2, 3},
+			BlobIndex:               7,
+			ConfirmationBlockNumber: 150,
+			BlobInclusionProof:      []byte{4, 5, 6},
+		},
+	}
+
+	row, err := newPostgresMetadataRow(metadata)
+	require.NoError(t, err)
+	require.Equal(t, int(disperser.Confirmed), row.blobStatus)
+	require.Equal(t, int64(123), row.requestedAt)
+	require.Equal(t, int64(1000), row.expiry)
+	require.Equal(t, 1, row.numRetries)
+	require.Equal(t, "0x1234", row.accountID)
+	require.Equal(t, metadata.ConfirmationInfo.BatchHeaderHash[:], row.batchHeaderHash)
+	require.Equal(t, sql.NullInt64{Int64: 7, Valid: true}, row.blobIndex)
+
+	fetched, err := unmarshalPostgresMetadata(row.metadata, row.blobStatus, row.expiry, row.numRetries)
+	require.NoError(t, err)
+	require.Equal(t, metadata, fetched)
+
+	// the columns take precedence over the serialized metadata
+	fetched, err = unmarshalPostgresMetadata(row.metadata, int(disperser.Finalized), 2000, 2)
+	require.NoError(t, err)
+	require.Equal(t, disperser.Finalized, fetched.BlobStatus)
+	require.Equal(t, uint64(2000), fetched.Expiry)
+	require.Equal(t, uint(2), fetched.NumRetries)
+
+	// unconfirmed blobs aren't in the batch index
+	metadata.ConfirmationInfo = nil
+	row, err = newPostgresMetadataRow(metadata)
+	require.NoError(t, err)
+	require.Nil(t, row.batchHeaderHash)
+	require.False(t, row.blobIndex.Valid)
+}
@@ -0,0 +1,269 @@
+package blobstore_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/disperser"
+	dispcommon "github.com/Layr-Labs/eigenda/disperser/common"
+	"github.com/Layr-Labs/eigenda/disperser/common/blobstore"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+// newPostgresMetadataStore creates a store with its own table, so that the queries by status of the tests don't see the
+// metadata of each other
+func newPostgresMetadataStore(t *testing.T) *blobstore.PostgresBlobMetadataStore {
+	tableName := fmt.Sprintf("test_blob_metadata_%s", uuid.New().String()[:8])
+	store, err := blobstore.NewPostgresBlobMetadataStore(postgresDB, logger, tableName, time.Hour)
+	require.NoError(t, err)
+	require.NoError(t, store.CreateTable(context.Background()))
+	t.Cleanup(func() {
+		_, err := postgresDB.Exec("DROP TABLE " + tableName)
+		require.NoError(t, err)
+	})
+	return store
+}
+
+func newPostgresTestMetadata(blobHash string, status disperser.BlobStatus, accountID core.AccountID, requestedAt time.Time) *disperser.BlobMetadata {
+	requestHeader := blob.RequestHeader
+	requestHeader.AccountID = accountID
+	return &disperser.BlobMetadata{
+		BlobHash:     blobHash,
+		MetadataHash: "hash",
+		BlobStatus:   status,
+		Expiry:       uint64(requestedAt.Add(time.Hour).Unix()),
+		RequestMetadata: &disperser.RequestMetadata{
+			BlobRequestHeader: requestHeader,
+			BlobSize:          blobSize,
+			RequestedAt:       uint64(requestedAt.Unix()),
+		},
+	}
+}
+
+func TestPostgresBlobMetadataStoreOperations(t *testing.T) {
+	ctx := context.Background()
+	store := newPostgresMetadataStore(t)
+	now := time.Now()
+
+	metadata1 := newPostgresTestMetadata("blob1", disperser.Processing, "account1", now)
+	metadata2 := newPostgresTestMetadata("blob2", disperser.Finalized, "account1", now.Add(time.Second))
+	metadata2.ConfirmationInfo = &disperser.ConfirmationInfo{}
+	require.NoError(t, store.QueueNewBlobMetadata(ctx, metadata1))
+	require.NoError(t, store.QueueNewBlobMetadata(ctx, metadata2))
+
+	fetched, err := store.GetBlobMetadata(ctx, metadata1.GetBlobKey())
+	require.NoError(t, err)
+	require.Equal(t, metadata1, fetched)
+	fetched, err = store.GetBlobMetadata(ctx, metadata2.GetBlobKey())
+	require.NoError(t, err)
+	require.Equal(t, metadata2, fetched)
+	_, err = store.GetBlobMetadata(ctx, disperser.BlobKey{BlobHash: "missing", MetadataHash: "hash"})
+	require.ErrorIs(t, err, dispcommon.ErrMetadataNotFound)
+
+	bulk, err := store.GetBulkBlobMetadata(ctx, []disperser.BlobKey{metadata1.GetBlobKey(), metadata2.GetBlobKey()})
+	require.NoError(t, err)
+	require.ElementsMatch(t, []*disperser.BlobMetadata{metadata1, metadata2}, bulk)
+
+	processing, err := store.GetBlobMetadataByStatus(ctx, disperser.Processing)
+	require.NoError(t, err)
+	require.Equal(t, []*disperser.BlobMetadata{metadata1}, processing)
+	count, err := store.GetBlobMetadataCountByStatus(ctx, disperser.Processing)
+	require.NoError(t, err)
+	require.Equal(t, int32(1), count)
+
+	byAccount, err := store.GetBlobMetadataByAccount(ctx, "account1", 10)
+	require.NoError(t, err)
+	require.Equal(t, []*disperser.BlobMetadata{metadata2, metadata1}, byAccount)
+	byAccount, err = store.GetBlobMetadataByAccount(ctx, "account1", 1)
+	require.NoError(t, err)
+	require.Equal(t, []*disperser.BlobMetadata{metadata2}, byAccount)
+
+	// the status, retries and expiry are updated in place
+	require.NoError(t, store.SetBlobStatus(ctx, metadata1.GetBlobKey(), disperser.Dispersing))
+	require.NoError(t, store.IncrementNumRetries(ctx, metadata1))
+	fetched, err = store.GetBlobMetadata(ctx, metadata1.GetBlobKey())
+	require.NoError(t, err)
+	require.Equal(t, disperser.Dispersing, fetched.BlobStatus)
+	require.Equal(t, uint(1), fetched.NumRetries)
+
+	err = store.SetBlobStatus(ctx, disperser.BlobKey{BlobHash: "missing", MetadataHash: "hash"}, disperser.Dispersing)
+	require.ErrorIs(t, err, dispcommon.ErrMetadataNotFound)
+}
+
+func TestPostgresBlobMetadataStorePagination(t *testing.T) {
+	ctx := context.Background()
+	store := newPostgresMetadataStore(t)
+	now := time.Now()
+
+	numBlobs := 5
+	for i := 0; i < numBlobs; i++ {
+		metadata := newPostgresTestMetadata(fmt.Sprintf("blob%d", i), disperser.Processing, "account", now.Add(time.Duration(i)*time.Second))
+		require.NoError(t, store.QueueNewBlobMetadata(ctx, metadata))
+	}
+
+	// pages are ordered by expiry, and the last page is followed by no cursor
+	var (
+		cursor *disperser.BlobStoreExclusiveStartKey
+		hashes []string
+	)
+	for {
+		page, next, err := store.GetBlobMetadataByStatusWithPagination(ctx, disperser.Processing, 2, cursor)
+		require.NoError(t, err)
+		for _, metadata := range page {
+			hashes = append(hashes, metadata.BlobHash)
+		}
+		if next == nil {
+			break
+		}
+		require.Len(t, page, 2)
+		cursor = next
+	}
+	require.Equal(t, []string{"blob0", "blob1", "blob2", "blob3", "blob4"}, hashes)
+
+	page, next, err := store.GetBlobMetadataByStatusWithPagination(ctx, disperser.Failed, 2, nil)
+	require.NoError(t, err)
+	require.Nil(t, page)
+	require.Nil(t, next)
+}
+
+func TestPostgresBlobMetadataStoreBatchQueries(t *testing.T) {
+	ctx := context.Background()
+	store := newPostgresMetadataStore(t)
+	now := time.Now()
+
+	confirmed := make([]*disperser.BlobMetadata, 3)
+	for i := range confirmed {
+		metadata := newPostgresTestMetadata(fmt.Sprintf("blob%d", i), disperser.Processing, "account", now)
+		require.NoError(t, store.QueueNewBlobMetadata(ctx, metadata))
+		confirmed[i] = getConfirmedMetadata(t, metadata, uint32(i))
+		require.NoError(t, store.UpdateBlobMetadata(ctx, metadata.GetBlobKey(), confirmed[i]))
+	}
+	batchHeaderHash := confirmed[0].ConfirmationInfo.BatchHeaderHash
+
+	inBatch, err := store.GetAllBlobMetadataByBatch(ctx, batchHeaderHash)
+	require.NoError(t, err)
+	require.Equal(t, confirmed, inBatch)
+	_, err = store.GetAllBlobMetadataByBatch(ctx, [32]byte{9})
+	require.Error(t, err)
+
+	page, cursor, err := store.GetAllBlobMetadataByBatchWithPagination(ctx, batchHeaderHash, 2, nil)
+	require.NoError(t, err)
+	require.Equal(t, confirmed[:2], page)
+	require.NotNil(t, cursor)
+	require.Equal(t, uint32(1), cursor.BlobIndex)
+	page, cursor, err = store.GetAllBlobMetadataByBatchWithPagination(ctx, batchHeaderHash, 2, cursor)
+	require.NoError(t, err)
+	require.Equal(t, confirmed[2:], page)
+	require.Nil(t, cursor)
+
+	metadata, err := store.GetBlobMetadataInBatch(ctx, batchHeaderHash, 1)
+	require.NoError(t, err)
+	require.Equal(t, confirmed[1], metadata)
+	_, err = store.GetBlobMetadataInBatch(ctx, batchHeaderHash, 3)
+	require.ErrorIs(t, err, dispcommon.ErrMetadataNotFound)
+
+	require.NoError(t, store.UpdateConfirmationBlockNumber(ctx, confirmed[0], 200))
+	metadata, err = store.GetBlobMetadata(ctx, confirmed[0].GetBlobKey())
+	require.NoError(t, err)
+	require.Equal(t, uint32(200), metadata.ConfirmationInfo.ConfirmationBlockNumber)
+}
+
+func TestPostgresBlobMetadataStoreAtomicUpdate(t *testing.T) {
+	ctx := context.Background()
+	store := newPostgresMetadataStore(t)
+	metadata := newPostgresTestMetadata("blob", disperser.Processing, "account", time.Now())
+	require.NoError(t, store.QueueNewBlobMetadata(ctx, metadata))
+
+	updated, err := store.UpdateBlobMetadataAtomically(ctx, metadata.GetBlobKey(), func(existing *disperser.BlobMetadata) (*disperser.BlobMetadata, error) {
+		require.Equal(t, metadata, existing)
+		existing.BlobStatus = disperser.Failed
+		existing.NumRetries++
+		return existing, nil
+	})
+	require.NoError(t, err)
+	fetched, err := store.GetBlobMetadata(ctx, metadata.GetBlobKey())
+	require.NoError(t, err)
+	require.Equal(t, updated, fetched)
+	require.Equal(t, disperser.Failed, fetched.BlobStatus)
+	require.Equal(t, uint(1), fetched.NumRetries)
+
+	// nothing is written if the update fails
+	_, err = store.UpdateBlobMetadataAtomically(ctx, metadata.GetBlobKey(), func(existing *disperser.BlobMetadata) (*disperser.BlobMetadata, error) {
+		existing.BlobStatus = disperser.Finalized
+		return nil, fmt.Errorf("update failed")
+	})
+	require.ErrorContains(t, err, "update failed")
+	fetched, err = store.GetBlobMetadata(ctx, metadata.GetBlobKey())
+	require.NoError(t, err)
+	require.Equal(t, disperser.Failed, fetched.BlobStatus)
+
+	_, err = store.UpdateBlobMetadataAtomically(ctx, disperser.BlobKey{BlobHash: "missing", MetadataHash: "hash"}, func(existing *disperser.BlobMetadata) (*disperser.BlobMetadata, error) {
+		return existing, nil
+	})
+	require.ErrorIs(t, err, dispcommon.ErrMetadataNotFound)
+}
+
+func TestPostgresBlobMetadataStoreFencedWrites(t *testing.T) {
+	ctx := context.Background()
+	store := newPostgresMetadataStore(t)
+	metadata := newPostgresTestMetadata("fencedBlob", disperser.Processing, "account", time.Now())
+	// blobs are queued by the API server, which doesn't hold a fencing token
+	require.NoError(t, store.QueueNewBlobMetadata(ctx, metadata))
+
+	formerLeaderCtx := dispcommon.WithFencingToken(ctx, 1)
+	leaderCtx := dispcommon.WithFencingToken(ctx, 2)
+
+	// the former leader can write until the new leader does
+	require.NoError(t, store.SetBlobStatus(formerLeaderCtx, metadata.GetBlobKey(), disperser.Dispersing))
+	require.NoError(t, store.IncrementNumRetries(leaderCtx, metadata))
+
+	err := store.SetBlobStatus(formerLeaderCtx, metadata.GetBlobKey(), disperser.Failed)
+	require.ErrorIs(t, err, dispcommon.ErrStaleFencingToken)
+	err = store.UpdateBlobMetadata(formerLeaderCtx, metadata.GetBlobKey(), getConfirmedMetadata(t, metadata, 1))
+	require.ErrorIs(t, err, dispcommon.ErrStaleFencingToken)
+	err = store.QueueNewBlobMetadata(formerLeaderCtx, metadata)
+	require.ErrorIs(t, err, dispcommon.ErrStaleFencingToken)
+	_, err = store.UpdateBlobMetadataAtomically(formerLeaderCtx, metadata.GetBlobKey(), func(existing *disperser.BlobMetadata) (*disperser.BlobMetadata, error) {
+		return existing, nil
+	})
+	require.ErrorIs(t, err, dispcommon.ErrStaleFencingToken)
+
+	fetched, err := store.GetBlobMetadata(ctx, metadata.GetBlobKey())
+	require.NoError(t, err)
+	require.Equal(t, disperser.Dispersing, fetched.BlobStatus)
+	require.Equal(t, uint(1), fetched.NumRetries)
+
+	// the new leader keeps writing with the same token
+	require.NoError(t, store.SetBlobStatus(leaderCtx, metadata.GetBlobKey(), disperser.Failed))
+	fetched, err = store.GetBlobMetadata(ctx, metadata.GetBlobKey())
+	require.NoError(t, err)
+	require.Equal(t, disperser.Failed, fetched.BlobStatus)
+}
+
+func TestPostgresBlobMetadataStoreExpiry(t *testing.T) {
+	ctx := context.Background()
+	store := newPostgresMetadataStore(t)
+	now := time.Now()
+
+	expired := newPostgresTestMetadata("expired", disperser.Processing, "account", now.Add(-2*time.Hour))
+	live := newPostgresTestMetadata("live", disperser.Processing, "account", now)
+	require.NoError(t, store.QueueNewBlobMetadata(ctx, expired))
+	require.NoError(t, store.QueueNewBlobMetadata(ctx, live))
+
+	// expired metadata isn't returned by the status queries, and is deleted by the disperser
+	processing, err := store.GetBlobMetadataByStatus(ctx, disperser.Processing)
+	require.NoError(t, err)
+	require.Equal(t, []*disperser.BlobMetadata{live}, processing)
+
+	deleted, err := store.DeleteExpiredBlobMetadata(ctx, now)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), deleted)
+	_, err = store.GetBlobMetadata(ctx, expired.GetBlobKey())
+	require.ErrorIs(t, err, dispcommon.ErrMetadataNotFound)
+	_, err = store.GetBlobMetadata(ctx, live.GetBlobKey())
+	require.NoError(t, err)
+}
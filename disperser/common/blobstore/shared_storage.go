@@ -11,6 +11,7 @@ import (
 	"github.com/Layr-Labs/eigenda/common/aws/s3"
 	"github.com/Layr-Labs/eigenda/core"
 	"github.com/Layr-Labs/eigenda/disperser"
+	"github.com/Layr-Labs/eigenda/disperser/common"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	"github.com/gammazero/workerpool"
 )
@@ -19,10 +20,74 @@ const (
 	maxS3BlobFetchWorkers = 64
 )
 
-var errProcessingToDispersing = errors.New("blob transit to dispersing from non processing")
+var (
+	errProcessingToDispersing = errors.New("blob transit to dispersing from non processing")
+	errAlreadyConfirmed       = errors.New("blob is already confirmed")
+)
+
+// MetadataStore is the interface to a backend storing blob metadata, which reads and writes metadata by key.
+// Backends declare their optional features through Capabilities, and implement the interface below which corresponds
+// to each of them. The SharedBlobStore adapts to the capabilities of its backend, so new backends can be added without
+// changes to the business logic built on it.
+type MetadataStore interface {
+	// Capabilities returns the optional features of the backend
+	Capabilities() disperser.MetadataCapabilities
+	// TTL returns how long the metadata of blobs is retained for. If zero, it's retained indefinitely.
+	TTL() time.Duration
+	QueueNewBlobMetadata(ctx context.Context, blobMetadata *disperser.BlobMetadata) error
+	GetBlobMetadata(ctx context.Context, blobKey disperser.BlobKey) (*disperser.BlobMetadata, error)
+	GetBulkBlobMetadata(ctx context.Context, blobKeys []disperser.BlobKey) ([]*disperser.BlobMetadata, error)
+	UpdateBlobMetadata(ctx context.Context, metadataKey disperser.BlobKey, updated *disperser.BlobMetadata) error
+	SetBlobStatus(ctx context.Context, metadataKey disperser.BlobKey, status disperser.BlobStatus) error
+	IncrementNumRetries(ctx context.Context, existingMetadata *disperser.BlobMetadata) error
+	UpdateConfirmationBlockNumber(ctx context.Context, existingMetadata *disperser.BlobMetadata, confirmationBlockNumber uint32) error
+}
+
+// IndexedMetadataStore is implemented by backends with the SecondaryIndexes capability
+type IndexedMetadataStore interface {
+	MetadataStore
+	GetBlobMetadataByStatus(ctx context.Context, status disperser.BlobStatus) ([]*disperser.BlobMetadata, error)
+	GetBlobMetadataCountByStatus(ctx context.Context, status disperser.BlobStatus) (int32, error)
+	GetBlobMetadataByStatusWithPagination(ctx context.Context, status disperser.BlobStatus, limit int32, exclusiveStartKey *disperser.BlobStoreExclusiveStartKey) ([]*disperser.BlobMetadata, *disperser.BlobStoreExclusiveStartKey, error)
+	GetAllBlobMetadataByBatch(ctx context.Context, batchHeaderHash [32]byte) ([]*disperser.BlobMetadata, error)
+	GetAllBlobMetadataByBatchWithPagination(ctx context.Context, batchHeaderHash [32]byte, limit int32, exclusiveStartKey *disperser.BatchIndexExclusiveStartKey) ([]*disperser.BlobMetadata, *disperser.BatchIndexExclusiveStartKey, error)
+	GetBlobMetadataInBatch(ctx context.Context, batchHeaderHash [32]byte, blobIndex uint32) (*disperser.BlobMetadata, error)
+}
+
+// TransactionalMetadataStore is implemented by backends with the Transactions capability
+type TransactionalMetadataStore interface {
+	MetadataStore
+	// UpdateBlobMetadataAtomically reads the metadata with the given key, and writes the metadata returned by update
+	// in the same transaction. Nothing is written if update returns an error. It returns the metadata written.
+	UpdateBlobMetadataAtomically(ctx context.Context, metadataKey disperser.BlobKey, update func(*disperser.BlobMetadata) (*disperser.BlobMetadata, error)) (*disperser.BlobMetadata, error)
+}
+
+// ExpiringMetadataStore is implemented by backends without the TTL capability, whose expired metadata is deleted by
+// the disperser
+type ExpiringMetadataStore interface {
+	MetadataStore
+	// DeleteExpiredBlobMetadata deletes the metadata which expired before the given time, and returns the number of
+	// blobs deleted
+	DeleteExpiredBlobMetadata(ctx context.Context, expiredBefore time.Time) (int64, error)
+}
+
+// checkCapabilities checks that the store implements the interfaces corresponding to the capabilities it declares
+func checkCapabilities(store MetadataStore) error {
+	capabilities := store.Capabilities()
+	if _, ok := store.(IndexedMetadataStore); capabilities.SecondaryIndexes && !ok {
+		return errors.New("metadata store declares secondary indexes but does not implement IndexedMetadataStore")
+	}
+	if _, ok := store.(TransactionalMetadataStore); capabilities.Transactions && !ok {
+		return errors.New("metadata store declares transactions but does not implement TransactionalMetadataStore")
+	}
+	if _, ok := store.(ExpiringMetadataStore); !capabilities.TTL && !ok {
+		return errors.New("metadata store has no TTL but does not implement ExpiringMetadataStore")
+	}
+	return nil
+}
 
 // The shared blob store that the disperser is operating on.
-// The metadata store is backed by DynamoDB and the blob store is backed by S3.
+// The metadata store is backed by DynamoDB or Postgres and the blob store is backed by S3.
 //
 // Note:
 //   - For each entry in the store (i.e. an S3 object), the user has to ensure there is no
@@ -43,7 +108,8 @@ var errProcessingToDispersing = errors.New("blob transit to dispersing from non
 type SharedBlobStore struct {
 	bucketName        string
 	s3Client          s3.Client
-	blobMetadataStore *BlobMetadataStore
+	blobMetadataStore MetadataStore
+	capabilities      disperser.MetadataCapabilities
 	logger            logging.Logger
 }
 
@@ -65,13 +131,17 @@ type blobResultOrError struct {
 
 var _ disperser.BlobStore = (*SharedBlobStore)(nil)
 
-func NewSharedStorage(bucketName string, s3Client s3.Client, blobMetadataStore *BlobMetadataStore, logger logging.Logger) *SharedBlobStore {
+func NewSharedStorage(bucketName string, s3Client s3.Client, blobMetadataStore MetadataStore, logger logging.Logger) (*SharedBlobStore, error) {
+	if err := checkCapabilities(blobMetadataStore); err != nil {
+		return nil, err
+	}
 	return &SharedBlobStore{
 		bucketName:        bucketName,
 		s3Client:          s3Client,
 		blobMetadataStore: blobMetadataStore,
+		capabilities:      blobMetadataStore.Capabilities(),
 		logger:            logger.With("component", "SharedBlobStore"),
-	}
+	}, nil
 }
 
 func (s *SharedBlobStore) StoreBlob(ctx context.Context, blob *core.Blob, requestedAt uint64) (disperser.BlobKey, error) {
@@ -97,8 +167,8 @@ func (s *SharedBlobStore) StoreBlob(ctx context.Context, blob *core.Blob, reques
 
 	// don't expire if ttl is 0
 	expiry := uint64(0)
-	if s.blobMetadataStore.ttl > 0 {
-		expiry = uint64(time.Now().Add(s.blobMetadataStore.ttl).Unix())
+	if s.blobMetadataStore.TTL() > 0 {
+		expiry = uint64(time.Now().Add(s.blobMetadataStore.TTL()).Unix())
 	}
 	metadata := disperser.BlobMetadata{
 		BlobHash:     blobHash,
@@ -142,39 +212,77 @@ func (s *SharedBlobStore) getBlobContentParallel(ctx context.Context, blobKey di
 }
 
 func (s *SharedBlobStore) MarkBlobConfirmed(ctx context.Context, existingMetadata *disperser.BlobMetadata, confirmationInfo *disperser.ConfirmationInfo) (*disperser.BlobMetadata, error) {
-	// TODO (ian-shim): remove this check once we are sure that the metadata is never overwritten
+	confirm := func(refreshedMetadata *disperser.BlobMetadata) (*disperser.BlobMetadata, error) {
+		// TODO (ian-shim): remove this check once we are sure that the metadata is never overwritten
+		alreadyConfirmed, _ := refreshedMetadata.IsConfirmed()
+		if alreadyConfirmed {
+			s.logger.Warn("trying to confirm blob already marked as confirmed", "blobKey", existingMetadata.GetBlobKey().String())
+			return refreshedMetadata, errAlreadyConfirmed
+		}
+		newMetadata := *existingMetadata
+		// Update the TTL if needed
+		ttlFromNow := time.Now().Add(s.blobMetadataStore.TTL())
+		if existingMetadata.Expiry < uint64(ttlFromNow.Unix()) {
+			newMetadata.Expiry = uint64(ttlFromNow.Unix())
+		}
+		newMetadata.BlobStatus = disperser.Confirmed
+		newMetadata.ConfirmationInfo = confirmationInfo
+		return &newMetadata, nil
+	}
+
+	if s.capabilities.Transactions {
+		var refreshedMetadata *disperser.BlobMetadata
+		newMetadata, err := s.blobMetadataStore.(TransactionalMetadataStore).UpdateBlobMetadataAtomically(ctx, existingMetadata.GetBlobKey(), func(metadata *disperser.BlobMetadata) (*disperser.BlobMetadata, error) {
+			refreshedMetadata = metadata
+			return confirm(metadata)
+		})
+		if errors.Is(err, errAlreadyConfirmed) {
+			return refreshedMetadata, nil
+		}
+		return newMetadata, err
+	}
+
 	refreshedMetadata, err := s.GetBlobMetadata(ctx, existingMetadata.GetBlobKey())
 	if err != nil {
 		s.logger.Error("error getting blob metadata", "err", err)
 		return nil, err
 	}
-	alreadyConfirmed, _ := refreshedMetadata.IsConfirmed()
-	if alreadyConfirmed {
-		s.logger.Warn("trying to confirm blob already marked as confirmed", "blobKey", existingMetadata.GetBlobKey().String())
+	newMetadata, err := confirm(refreshedMetadata)
+	if errors.Is(err, errAlreadyConfirmed) {
 		return refreshedMetadata, nil
 	}
-	newMetadata := *existingMetadata
-	// Update the TTL if needed
-	ttlFromNow := time.Now().Add(s.blobMetadataStore.ttl)
-	if existingMetadata.Expiry < uint64(ttlFromNow.Unix()) {
-		newMetadata.Expiry = uint64(ttlFromNow.Unix())
-	}
-	newMetadata.BlobStatus = disperser.Confirmed
-	newMetadata.ConfirmationInfo = confirmationInfo
-	return &newMetadata, s.blobMetadataStore.UpdateBlobMetadata(ctx, existingMetadata.GetBlobKey(), &newMetadata)
+	return newMetadata, s.blobMetadataStore.UpdateBlobMetadata(ctx, existingMetadata.GetBlobKey(), newMetadata)
 }
 
 func (s *SharedBlobStore) MarkBlobDispersing(ctx context.Context, metadataKey disperser.BlobKey) error {
+	checkProcessing := func(refreshedMetadata *disperser.BlobMetadata) error {
+		status := refreshedMetadata.BlobStatus
+		if status != disperser.Processing {
+			s.logger.Error("error marking blob as dispersing from non processing state", "blobKey", metadataKey.String(), "status", status)
+			return errProcessingToDispersing
+		}
+		return nil
+	}
+
+	if s.capabilities.Transactions {
+		_, err := s.blobMetadataStore.(TransactionalMetadataStore).UpdateBlobMetadataAtomically(ctx, metadataKey, func(metadata *disperser.BlobMetadata) (*disperser.BlobMetadata, error) {
+			if err := checkProcessing(metadata); err != nil {
+				return nil, err
+			}
+			newMetadata := *metadata
+			newMetadata.BlobStatus = disperser.Dispersing
+			return &newMetadata, nil
+		})
+		return err
+	}
+
 	refreshedMetadata, err := s.GetBlobMetadata(ctx, metadataKey)
 	if err != nil {
 		s.logger.Error("error getting blob metadata while marking blobDispersing", "err", err)
 		return err
 	}
-
-	status := refreshedMetadata.BlobStatus
-	if status != disperser.Processing {
-		s.logger.Error("error marking blob as dispersing from non processing state", "blobKey", metadataKey.String(), "status", status)
-		return errProcessingToDispersing
+	if err := checkProcessing(refreshedMetadata); err != nil {
+		return err
 	}
 
 	return s.blobMetadataStore.SetBlobStatus(ctx, metadataKey, disperser.Dispersing)
@@ -246,23 +354,51 @@ func (s *SharedBlobStore) GetBlobsByMetadata(ctx context.Context, metadata []*di
 }
 
 func (s *SharedBlobStore) GetBlobMetadataByStatus(ctx context.Context, blobStatus disperser.BlobStatus) ([]*disperser.BlobMetadata, error) {
-	return s.blobMetadataStore.GetBlobMetadataByStatus(ctx, blobStatus)
+	store, err := s.indexedStore()
+	if err != nil {
+		return nil, err
+	}
+	return store.GetBlobMetadataByStatus(ctx, blobStatus)
 }
 
 func (s *SharedBlobStore) GetBlobMetadataByStatusWithPagination(ctx context.Context, blobStatus disperser.BlobStatus, limit int32, exclusiveStartKey *disperser.BlobStoreExclusiveStartKey) ([]*disperser.BlobMetadata, *disperser.BlobStoreExclusiveStartKey, error) {
-	return s.blobMetadataStore.GetBlobMetadataByStatusWithPagination(ctx, blobStatus, limit, exclusiveStartKey)
+	store, err := s.indexedStore()
+	if err != nil {
+		return nil, nil, err
+	}
+	return store.GetBlobMetadataByStatusWithPagination(ctx, blobStatus, limit, exclusiveStartKey)
 }
 
 func (s *SharedBlobStore) GetMetadataInBatch(ctx context.Context, batchHeaderHash [32]byte, blobIndex uint32) (*disperser.BlobMetadata, error) {
-	return s.blobMetadataStore.GetBlobMetadataInBatch(ctx, batchHeaderHash, blobIndex)
+	store, err := s.indexedStore()
+	if err != nil {
+		return nil, err
+	}
+	return store.GetBlobMetadataInBatch(ctx, batchHeaderHash, blobIndex)
 }
 
 func (s *SharedBlobStore) GetAllBlobMetadataByBatch(ctx context.Context, batchHeaderHash [32]byte) ([]*disperser.BlobMetadata, error) {
-	return s.blobMetadataStore.GetAllBlobMetadataByBatch(ctx, batchHeaderHash)
+	store, err := s.indexedStore()
+	if err != nil {
+		return nil, err
+	}
+	return store.GetAllBlobMetadataByBatch(ctx, batchHeaderHash)
 }
 
 func (s *SharedBlobStore) GetAllBlobMetadataByBatchWithPagination(ctx context.Context, batchHeaderHash [32]byte, limit int32, exclusiveStartKey *disperser.BatchIndexExclusiveStartKey) ([]*disperser.BlobMetadata, *disperser.BatchIndexExclusiveStartKey, error) {
-	return s.blobMetadataStore.GetAllBlobMetadataByBatchWithPagination(ctx, batchHeaderHash, limit, exclusiveStartKey)
+	store, err := s.indexedStore()
+	if err != nil {
+		return nil, nil, err
+	}
+	return store.GetAllBlobMetadataByBatchWithPagination(ctx, batchHeaderHash, limit, exclusiveStartKey)
+}
+
+// indexedStore returns the metadata store if it has secondary indexes
+func (s *SharedBlobStore) indexedStore() (IndexedMetadataStore, error) {
+	if !s.capabilities.SecondaryIndexes {
+		return nil, fmt.Errorf("querying metadata by index: %w", common.ErrCapabilityUnsupported)
+	}
+	return s.blobMetadataStore.(IndexedMetadataStore), nil
 }
 
 // GetMetadata returns a blob metadata given a metadata key
@@ -285,6 +421,17 @@ func (s *SharedBlobStore) HandleBlobFailure(ctx context.Context, metadata *dispe
 	}
 }
 
+func (s *SharedBlobStore) MetadataCapabilities() disperser.MetadataCapabilities {
+	return s.capabilities
+}
+
+func (s *SharedBlobStore) DeleteExpiredBlobMetadata(ctx context.Context) (int64, error) {
+	if s.capabilities.TTL {
+		return 0, nil
+	}
+	return s.blobMetadataStore.(ExpiringMetadataStore).DeleteExpiredBlobMetadata(ctx, time.Now())
+}
+
 func getMetadataHash(requestedAt uint64, securityParams []*core.SecurityParam) (string, error) {
 	var str string
 	str = fmt.Sprintf("%d/", requestedAt)
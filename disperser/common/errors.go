@@ -6,4 +6,9 @@ var (
 	ErrBlobNotFound     = errors.New("blob not found")
 	ErrMetadataNotFound = errors.New("metadata not found")
 	ErrAlreadyExists    = errors.New("record already exists")
+	// ErrCapabilityUnsupported is returned for operations which the metadata backend lacks the capability for
+	ErrCapabilityUnsupported = errors.New("not supported by the metadata store")
+	// ErrStaleFencingToken is returned for metadata writes made with a fencing token older than the one the metadata
+	// was last written with
+	ErrStaleFencingToken = errors.New("stale fencing token")
 )
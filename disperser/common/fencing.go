@@ -0,0 +1,18 @@
+package common
+
+import "context"
+
+type fencingTokenKey struct{}
+
+// WithFencingToken returns a context carrying the fencing token of the leader on whose behalf metadata writes are made.
+// Metadata stores write the token with the metadata in the same request, and reject the write with
+// ErrStaleFencingToken if the metadata was last written with a newer token.
+func WithFencingToken(ctx context.Context, token uint64) context.Context {
+	return context.WithValue(ctx, fencingTokenKey{}, token)
+}
+
+// FencingTokenFromContext returns the fencing token carried by the context, and false if it carries none
+func FencingTokenFromContext(ctx context.Context) (uint64, bool) {
+	token, ok := ctx.Value(fencingTokenKey{}).(uint64)
+	return token, ok
+}
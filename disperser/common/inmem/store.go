@@ -219,38 +219,36 @@ func (q *BlobStore) GetBlobMetadataByStatusWithPagination(ctx context.Context, s
 	q.mu.RLock()
 	defer q.mu.RUnlock()
 	metas := make([]*disperser.BlobMetadata, 0)
-	foundStart := exclusiveStartKey == nil
 
-	keys := make([]disperser.BlobKey, len(q.Metadata))
-	i := 0
-	for k := range q.Metadata {
-		keys[i] = k
-		i++
+	keys := make([]disperser.BlobKey, 0, len(q.Metadata))
+	for k, meta := range q.Metadata {
+		if meta.BlobStatus == status {
+			keys = append(keys, k)
+		}
 	}
+	// Blobs are ordered by expiry, and blobs with the same expiry by key, so that the start key identifies a single blob
 	sort.Slice(keys, func(i, j int) bool {
-		return q.Metadata[keys[i]].Expiry < q.Metadata[keys[j]].Expiry
+		return statusIndexLess(q.Metadata[keys[i]].Expiry, keys[i], q.Metadata[keys[j]].Expiry, keys[j])
 	})
 	for _, key := range keys {
 		meta := q.Metadata[key]
-		if meta.BlobStatus == status {
-			if foundStart {
-				metas = append(metas, meta)
-				if len(metas) == int(limit) {
-					return metas, &disperser.BlobStoreExclusiveStartKey{
-						BlobStatus: int32(meta.BlobStatus),
-						Expiry:     int64(meta.Expiry),
-					}, nil
-				}
-			} else if meta.BlobStatus == disperser.BlobStatus(exclusiveStartKey.BlobStatus) && meta.Expiry > uint64(exclusiveStartKey.Expiry) {
-				foundStart = true // Found the starting point, start appending metas from next item
-				metas = append(metas, meta)
-				if len(metas) == int(limit) {
-					return metas, &disperser.BlobStoreExclusiveStartKey{
-						BlobStatus: int32(meta.BlobStatus),
-						Expiry:     int64(meta.Expiry),
-					}, nil
-				}
+		if exclusiveStartKey != nil {
+			startKey := disperser.BlobKey{
+				BlobHash:     exclusiveStartKey.BlobHash,
+				MetadataHash: exclusiveStartKey.MetadataHash,
 			}
+			if !statusIndexLess(uint64(exclusiveStartKey.Expiry), startKey, meta.Expiry, key) {
+				continue
+			}
+		}
+		metas = append(metas, meta)
+		if len(metas) == int(limit) {
+			return metas, &disperser.BlobStoreExclusiveStartKey{
+				BlobHash:     key.BlobHash,
+				MetadataHash: key.MetadataHash,
+				BlobStatus:   int32(meta.BlobStatus),
+				Expiry:       int64(meta.Expiry),
+			}, nil
 		}
 	}
 
@@ -258,6 +256,18 @@ func (q *BlobStore) GetBlobMetadataByStatusWithPagination(ctx context.Context, s
 	return metas, nil, nil
 }
 
+// statusIndexLess returns true if the blob with expiry expiryA and key keyA comes before the blob with expiry expiryB
+// and key keyB when paginating blobs by status.
+func statusIndexLess(expiryA uint64, keyA disperser.BlobKey, expiryB uint64, keyB disperser.BlobKey) bool {
+	if expiryA != expiryB {
+		return expiryA < expiryB
+	}
+	if keyA.BlobHash != keyB.BlobHash {
+		return keyA.BlobHash < keyB.BlobHash
+	}
+	return keyA.MetadataHash < keyB.MetadataHash
+}
+
 func (q *BlobStore) GetMetadataInBatch(ctx context.Context, batchHeaderHash [32]byte, blobIndex uint32) (*disperser.BlobMetadata, error) {
 	q.mu.RLock()
 	defer q.mu.RUnlock()
@@ -343,6 +353,30 @@ func (q *BlobStore) GetBulkBlobMetadata(ctx context.Context, blobKeys []disperse
 	return metas, nil
 }
 
+// MetadataCapabilities returns the capabilities of the in-memory store. All updates are made under its lock, but
+// nothing expires on its own.
+func (q *BlobStore) MetadataCapabilities() disperser.MetadataCapabilities {
+	return disperser.MetadataCapabilities{
+		Transactions:     true,
+		TTL:              false,
+		SecondaryIndexes: true,
+	}
+}
+
+func (q *BlobStore) DeleteExpiredBlobMetadata(ctx context.Context) (int64, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	now := uint64(time.Now().Unix())
+	deleted := int64(0)
+	for key, meta := range q.Metadata {
+		if meta.Expiry > 0 && meta.Expiry < now {
+			delete(q.Metadata, key)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
 func (q *BlobStore) HandleBlobFailure(ctx context.Context, metadata *disperser.BlobMetadata, maxRetry uint) (bool, error) {
 	if metadata.NumRetries < maxRetry {
 		if err := q.MarkBlobProcessing(ctx, metadata.GetBlobKey()); err != nil {
@@ -120,3 +120,79 @@ func TestBlobStore(t *testing.T) {
 	assert.Equal(t, 1, len(allMeta))
 	assert.Equal(t, allMeta[0].BlobStatus, disperser.Confirmed)
 }
+
+func TestDeleteExpiredBlobMetadata(t *testing.T) {
+	bs := inmem.NewBlobStore()
+	ctx := context.Background()
+	assert.False(t, bs.MetadataCapabilities().TTL)
+
+	keys := make([]disperser.BlobKey, 3)
+	for i := range keys {
+		blobKey, err := bs.StoreBlob(ctx, &core.Blob{
+			RequestHeader: core.BlobRequestHeader{
+				SecurityParams: []*core.SecurityParam{},
+			},
+			Data: []byte{byte(i)},
+		}, uint64(time.Now().UnixNano())+uint64(i))
+		assert.Nil(t, err)
+		keys[i] = blobKey
+	}
+	expired, err := bs.GetBlobMetadata(ctx, keys[0])
+	assert.Nil(t, err)
+	expired.Expiry = uint64(time.Now().Add(-time.Minute).Unix())
+	unexpired, err := bs.GetBlobMetadata(ctx, keys[1])
+	assert.Nil(t, err)
+	unexpired.Expiry = uint64(time.Now().Add(time.Hour).Unix())
+
+	deleted, err := bs.DeleteExpiredBlobMetadata(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1), deleted)
+
+	_, err = bs.GetBlobMetadata(ctx, keys[0])
+	assert.NotNil(t, err)
+	_, err = bs.GetBlobMetadata(ctx, keys[1])
+	assert.Nil(t, err)
+	// metadata without an expiry is retained
+	_, err = bs.GetBlobMetadata(ctx, keys[2])
+	assert.Nil(t, err)
+}
+
+func TestGetBlobMetadataByStatusWithPagination(t *testing.T) {
+	bs := inmem.NewBlobStore()
+	ctx := context.Background()
+
+	// all the blobs have the same expiry, so the pages are split between blobs with the same expiry
+	numBlobs := 5
+	requestedAt := uint64(time.Now().UnixNano())
+	for i := 0; i < numBlobs; i++ {
+		_, err := bs.StoreBlob(ctx, &core.Blob{
+			RequestHeader: core.BlobRequestHeader{
+				SecurityParams: []*core.SecurityParam{},
+			},
+			Data: []byte{byte(i)},
+		}, requestedAt)
+		assert.Nil(t, err)
+	}
+
+	seen := make(map[disperser.BlobKey]struct{})
+	metas, startKey, err := bs.GetBlobMetadataByStatusWithPagination(ctx, disperser.Processing, 2, nil)
+	assert.Nil(t, err)
+	for {
+		for _, meta := range metas {
+			_, ok := seen[meta.GetBlobKey()]
+			assert.False(t, ok)
+			seen[meta.GetBlobKey()] = struct{}{}
+		}
+		if startKey == nil {
+			break
+		}
+		metas, startKey, err = bs.GetBlobMetadataByStatusWithPagination(ctx, disperser.Processing, 2, startKey)
+		assert.Nil(t, err)
+	}
+	assert.Len(t, seen, numBlobs)
+
+	metas, startKey, err = bs.GetBlobMetadataByStatusWithPagination(ctx, disperser.Confirmed, 2, nil)
+	assert.Nil(t, err)
+	assert.Len(t, metas, 0)
+	assert.Nil(t, startKey)
+}
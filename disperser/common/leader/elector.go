@@ -0,0 +1,353 @@
+package leader
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	commondynamodb "github.com/Layr-Labs/eigenda/common/aws/dynamodb"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+const (
+	lockNameAttribute     = "LockName"
+	ownerAttribute        = "Owner"
+	fencingTokenAttribute = "FencingToken"
+	expiresAtAttribute    = "ExpiresAt"
+)
+
+var (
+	// ErrNotLeader is returned when an operation requires the leader lease but it is not held by this instance
+	ErrNotLeader = errors.New("not the leader")
+)
+
+// Lease is the state of the leader lock as stored in DynamoDB
+type Lease struct {
+	// Owner is the ID of the instance holding the lease
+	Owner string
+	// FencingToken is incremented every time the lease changes owner. Writes made on behalf of a leader must be
+	// rejected if the fencing token they were made with is no longer the current one.
+	FencingToken uint64
+	// ExpiresAt is the time after which the lease can be taken over by another instance unless it is renewed
+	ExpiresAt time.Time
+}
+
+// Elector elects a single leader among instances sharing the same lock, using a lease stored in DynamoDB.
+// The leader must renew the lease before it expires, otherwise another instance can acquire it.
+// Expiry is based on wall clock time, so instances are assumed to have loosely synchronized clocks.
+type Elector struct {
+	dynamoClient  commondynamodb.Client
+	tableName     string
+	lockName      string
+	owner         string
+	leaseDuration time.Duration
+	logger        logging.Logger
+
+	mu sync.RWMutex
+	// fencingToken is the fencing token of the lease held by this instance, and is 0 if the lease is not held
+	fencingToken uint64
+	// expiresAt is the expiry of the lease held by this instance
+	expiresAt time.Time
+}
+
+func NewElector(
+	dynamoClient commondynamodb.Client,
+	tableName string,
+	lockName string,
+	owner string,
+	leaseDuration time.Duration,
+	logger logging.Logger,
+) (*Elector, error) {
+	if tableName == "" || lockName == "" {
+		return nil, errors.New("table name and lock name must be specified")
+	}
+	if owner == "" {
+		return nil, errors.New("owner must be specified")
+	}
+	if leaseDuration <= 0 {
+		return nil, fmt.Errorf("lease duration must be positive, got %v", leaseDuration)
+	}
+
+	return &Elector{
+		dynamoClient:  dynamoClient,
+		tableName:     tableName,
+		lockName:      lockName,
+		owner:         owner,
+		leaseDuration: leaseDuration,
+		logger:        logger.With("component", "LeaderElector"),
+	}, nil
+}
+
+// GenerateTableSchema returns the schema of the table storing leader leases
+func GenerateTableSchema(tableName string, readCapacityUnits int64, writeCapacityUnits int64) *dynamodb.CreateTableInput {
+	return &dynamodb.CreateTableInput{
+		AttributeDefinitions: []types.AttributeDefinition{
+			{
+				AttributeName: aws.String(lockNameAttribute),
+				AttributeType: types.ScalarAttributeTypeS,
+			},
+		},
+		KeySchema: []types.KeySchemaElement{
+			{
+				AttributeName: aws.String(lockNameAttribute),
+				KeyType:       types.KeyTypeHash,
+			},
+		},
+		TableName: aws.String(tableName),
+		ProvisionedThroughput: &types.ProvisionedThroughput{
+			ReadCapacityUnits:  aws.Int64(readCapacityUnits),
+			WriteCapacityUnits: aws.Int64(writeCapacityUnits),
+		},
+	}
+}
+
+// GetLease returns the current lease using a strongly consistent read, or nil if the lease has never been acquired
+func (e *Elector) GetLease(ctx context.Context) (*Lease, error) {
+	items, err := e.dynamoClient.GetItems(ctx, e.tableName, []commondynamodb.Key{e.key()}, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get lease %s: %w", e.lockName, err)
+	}
+	if len(items) == 0 {
+		return nil, nil
+	}
+	return unmarshalLease(items[0])
+}
+
+// TryAcquire attempts to acquire the lease, and returns true if this instance is the leader.
+// The lease can be acquired if it has never been held, if it has expired, or if it is already held by this instance,
+// in which case it is renewed.
+func (e *Elector) TryAcquire(ctx context.Context) (bool, error) {
+	current, err := e.GetLease(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	now := time.Now()
+	var fencingToken uint64 = 1
+	condition := fmt.Sprintf("attribute_not_exists(%s)", lockNameAttribute)
+	values := map[string]types.AttributeValue{}
+	if current != nil {
+		if current.Owner != e.owner && current.ExpiresAt.After(now) {
+			return false, nil
+		}
+		fencingToken = current.FencingToken
+		if current.Owner != e.owner {
+			fencingToken++
+		}
+		// Only succeed if nobody else changed the lease since it was read
+		condition = fmt.Sprintf("%s = :owner AND %s = :token", ownerAttribute, fencingTokenAttribute)
+		values[":owner"] = &types.AttributeValueMemberS{Value: current.Owner}
+		values[":token"] = &types.AttributeValueMemberN{Value: strconv.FormatUint(current.FencingToken, 10)}
+	}
+
+	expiresAt := now.Add(e.leaseDuration)
+	err = e.putLease(ctx, &Lease{Owner: e.owner, FencingToken: fencingToken, ExpiresAt: expiresAt}, condition, values)
+	if errors.Is(err, commondynamodb.ErrConditionFailed) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.fencingToken != fencingToken {
+		e.logger.Info("acquired leader lease", "owner", e.owner, "fencingToken", fencingToken, "expiresAt", expiresAt)
+	}
+	e.fencingToken = fencingToken
+	e.expiresAt = expiresAt
+	return true, nil
+}
+
+// Renew extends the lease held by this instance. It returns ErrNotLeader if the lease is no longer held.
+func (e *Elector) Renew(ctx context.Context) error {
+	fencingToken, ok := e.FencingToken()
+	if !ok {
+		return ErrNotLeader
+	}
+
+	expiresAt := time.Now().Add(e.leaseDuration)
+	condition := fmt.Sprintf("%s = :owner AND %s = :token", ownerAttribute, fencingTokenAttribute)
+	values := map[string]types.AttributeValue{
+		":owner": &types.AttributeValueMemberS{Value: e.owner},
+		":token": &types.AttributeValueMemberN{Value: strconv.FormatUint(fencingToken, 10)},
+	}
+	err := e.putLease(ctx, &Lease{Owner: e.owner, FencingToken: fencingToken, ExpiresAt: expiresAt}, condition, values)
+	if errors.Is(err, commondynamodb.ErrConditionFailed) {
+		e.relinquish()
+		return ErrNotLeader
+	}
+	if err != nil {
+		return err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.expiresAt = expiresAt
+	return nil
+}
+
+// Release gives up the lease so that a standby instance can take over without waiting for the lease to expire.
+// The fencing token is preserved so that the next leader gets a new token.
+func (e *Elector) Release(ctx context.Context) error {
+	fencingToken, ok := e.FencingToken()
+	if !ok {
+		return nil
+	}
+	e.relinquish()
+
+	condition := fmt.Sprintf("%s = :owner AND %s = :token", ownerAttribute, fencingTokenAttribute)
+	values := map[string]types.AttributeValue{
+		":owner": &types.AttributeValueMemberS{Value: e.owner},
+		":token": &types.AttributeValueMemberN{Value: strconv.FormatUint(fencingToken, 10)},
+	}
+	err := e.putLease(ctx, &Lease{Owner: e.owner, FencingToken: fencingToken, ExpiresAt: time.Now()}, condition, values)
+	if err != nil && !errors.Is(err, commondynamodb.ErrConditionFailed) {
+		return err
+	}
+	return nil
+}
+
+// FencingToken returns the fencing token of the lease held by this instance, and false if this instance does not
+// hold an unexpired lease
+func (e *Elector) FencingToken() (uint64, bool) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	if e.fencingToken == 0 || !time.Now().Before(e.expiresAt) {
+		return 0, false
+	}
+	return e.fencingToken, true
+}
+
+// CheckFencingToken returns ErrNotLeader unless this instance holds the lease and its fencing token is still the
+// current one in DynamoDB. It guards actions on behalf of the leader outside of the metadata store, such as confirming
+// batches on chain; metadata writes are fenced by the store itself (see FencedBlobStore).
+func (e *Elector) CheckFencingToken(ctx context.Context) error {
+	fencingToken, ok := e.FencingToken()
+	if !ok {
+		return ErrNotLeader
+	}
+	current, err := e.GetLease(ctx)
+	if err != nil {
+		return err
+	}
+	if current == nil || current.Owner != e.owner || current.FencingToken != fencingToken {
+		e.relinquish()
+		return ErrNotLeader
+	}
+	return nil
+}
+
+// Run blocks until this instance acquires the lease, retrying at the given interval, and then keeps renewing the lease
+// in the background. The returned context is canceled when the lease is lost or when ctx is done.
+func (e *Elector) Run(ctx context.Context, retryInterval time.Duration) (context.Context, error) {
+	ticker := time.NewTicker(retryInterval)
+	defer ticker.Stop()
+
+	for {
+		acquired, err := e.TryAcquire(ctx)
+		if err != nil {
+			e.logger.Warn("failed to acquire leader lease", "err", err)
+		}
+		if acquired {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+
+	leaderCtx, cancel := context.WithCancel(ctx)
+	go func() {
+		defer cancel()
+		// Renew well before the lease expires, so that a few failed renewals don't cost the lease
+		renewTicker := time.NewTicker(e.leaseDuration / 3)
+		defer renewTicker.Stop()
+
+		for {
+			select {
+			case <-leaderCtx.Done():
+				return
+			case <-renewTicker.C:
+				err := e.Renew(leaderCtx)
+				if errors.Is(err, ErrNotLeader) {
+					e.logger.Error("lost leader lease", "owner", e.owner)
+					return
+				}
+				if err != nil {
+					e.logger.Warn("failed to renew leader lease", "err", err)
+				}
+				if _, ok := e.FencingToken(); !ok {
+					e.logger.Error("leader lease expired before it could be renewed", "owner", e.owner)
+					return
+				}
+			}
+		}
+	}()
+
+	return leaderCtx, nil
+}
+
+func (e *Elector) relinquish() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.fencingToken = 0
+	e.expiresAt = time.Time{}
+}
+
+func (e *Elector) key() commondynamodb.Key {
+	return commondynamodb.Key{
+		lockNameAttribute: &types.AttributeValueMemberS{Value: e.lockName},
+	}
+}
+
+func (e *Elector) putLease(ctx context.Context, lease *Lease, condition string, values map[string]types.AttributeValue) error {
+	item := commondynamodb.Item{
+		lockNameAttribute:     &types.AttributeValueMemberS{Value: e.lockName},
+		ownerAttribute:        &types.AttributeValueMemberS{Value: lease.Owner},
+		fencingTokenAttribute: &types.AttributeValueMemberN{Value: strconv.FormatUint(lease.FencingToken, 10)},
+		expiresAtAttribute:    &types.AttributeValueMemberN{Value: strconv.FormatInt(lease.ExpiresAt.UnixMilli(), 10)},
+	}
+	if len(values) == 0 {
+		values = nil
+	}
+	return e.dynamoClient.PutItemWithCondition(ctx, e.tableName, item, condition, nil, values)
+}
+
+func unmarshalLease(item commondynamodb.Item) (*Lease, error) {
+	owner, ok := item[ownerAttribute].(*types.AttributeValueMemberS)
+	if !ok {
+		return nil, fmt.Errorf("lease is missing attribute %s", ownerAttribute)
+	}
+	fencingTokenAttr, ok := item[fencingTokenAttribute].(*types.AttributeValueMemberN)
+	if !ok {
+		return nil, fmt.Errorf("lease is missing attribute %s", fencingTokenAttribute)
+	}
+	fencingToken, err := strconv.ParseUint(fencingTokenAttr.Value, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse fencing token: %w", err)
+	}
+	expiresAtAttr, ok := item[expiresAtAttribute].(*types.AttributeValueMemberN)
+	if !ok {
+		return nil, fmt.Errorf("lease is missing attribute %s", expiresAtAttribute)
+	}
+	expiresAt, err := strconv.ParseInt(expiresAtAttr.Value, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse lease expiry: %w", err)
+	}
+
+	return &Lease{
+		Owner:        owner.Value,
+		FencingToken: fencingToken,
+		ExpiresAt:    time.UnixMilli(expiresAt),
+	}, nil
+}
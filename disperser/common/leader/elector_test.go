@@ -0,0 +1,157 @@
+package leader_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common/aws"
+	"github.com/Layr-Labs/eigenda/common/aws/dynamodb"
+	test_utils "github.com/Layr-Labs/eigenda/common/aws/dynamodb/utils"
+	"github.com/Layr-Labs/eigenda/common/testutils"
+	"github.com/Layr-Labs/eigenda/disperser/common/leader"
+	"github.com/Layr-Labs/eigenda/inabox/deploy"
+	"github.com/google/uuid"
+	"github.com/ory/dockertest/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	logger = testutils.GetLogger()
+
+	dockertestPool     *dockertest.Pool
+	dockertestResource *dockertest.Resource
+
+	deployLocalStack bool
+	localStackPort   = "4573"
+
+	dynamoClient   dynamodb.Client
+	leaseTableName = fmt.Sprintf("test-LeaderLease-%v", uuid.New())
+)
+
+func TestMain(m *testing.M) {
+	setup(m)
+	code := m.Run()
+	teardown()
+	os.Exit(code)
+}
+
+func setup(m *testing.M) {
+	deployLocalStack = !(os.Getenv("DEPLOY_LOCALSTACK") == "false")
+	if !deployLocalStack {
+		localStackPort = os.Getenv("LOCALSTACK_PORT")
+	}
+
+	if deployLocalStack {
+		var err error
+		dockertestPool, dockertestResource, err = deploy.StartDockertestWithLocalstackContainer(localStackPort)
+		if err != nil {
+			teardown()
+			panic("failed to start localstack container")
+		}
+	}
+
+	cfg := aws.ClientConfig{
+		Region:          "us-east-1",
+		AccessKey:       "localstack",
+		SecretAccessKey: "localstack",
+		EndpointURL:     fmt.Sprintf("http://0.0.0.0:%s", localStackPort),
+	}
+
+	_, err := test_utils.CreateTable(context.Background(), cfg, leaseTableName, leader.GenerateTableSchema(leaseTableName, 10, 10))
+	if err != nil {
+		teardown()
+		panic("failed to create dynamodb table: " + err.Error())
+	}
+
+	dynamoClient, err = dynamodb.NewClient(cfg, logger)
+	if err != nil {
+		teardown()
+		panic("failed to create dynamodb client: " + err.Error())
+	}
+}
+
+func teardown() {
+	if deployLocalStack {
+		deploy.PurgeDockertestResources(dockertestPool, dockertestResource)
+	}
+}
+
+func TestElectorFailover(t *testing.T) {
+	ctx := context.Background()
+	lockName := uuid.New().String()
+	leaseDuration := 2 * time.Second
+
+	active, err := leader.NewElector(dynamoClient, leaseTableName, lockName, "active", leaseDuration, logger)
+	require.NoError(t, err)
+	standby, err := leader.NewElector(dynamoClient, leaseTableName, lockName, "standby", leaseDuration, logger)
+	require.NoError(t, err)
+
+	acquired, err := active.TryAcquire(ctx)
+	require.NoError(t, err)
+	assert.True(t, acquired)
+	activeToken, ok := active.FencingToken()
+	assert.True(t, ok)
+	assert.NoError(t, active.CheckFencingToken(ctx))
+
+	// The standby can't acquire the lease while it is held
+	acquired, err = standby.TryAcquire(ctx)
+	require.NoError(t, err)
+	assert.False(t, acquired)
+	assert.ErrorIs(t, standby.CheckFencingToken(ctx), leader.ErrNotLeader)
+
+	// Renewing keeps the same fencing token
+	require.NoError(t, active.Renew(ctx))
+	token, ok := active.FencingToken()
+	assert.True(t, ok)
+	assert.Equal(t, activeToken, token)
+
+	// The standby takes over once the lease expires, with a new fencing token
+	time.Sleep(leaseDuration)
+	acquired, err = standby.TryAcquire(ctx)
+	require.NoError(t, err)
+	assert.True(t, acquired)
+	standbyToken, ok := standby.FencingToken()
+	assert.True(t, ok)
+	assert.Greater(t, standbyToken, activeToken)
+
+	// The former leader is fenced off
+	assert.ErrorIs(t, active.CheckFencingToken(ctx), leader.ErrNotLeader)
+	assert.ErrorIs(t, active.Renew(ctx), leader.ErrNotLeader)
+	assert.NoError(t, standby.CheckFencingToken(ctx))
+}
+
+func TestElectorRelease(t *testing.T) {
+	ctx := context.Background()
+	lockName := uuid.New().String()
+
+	active, err := leader.NewElector(dynamoClient, leaseTableName, lockName, "active", time.Minute, logger)
+	require.NoError(t, err)
+	standby, err := leader.NewElector(dynamoClient, leaseTableName, lockName, "standby", time.Minute, logger)
+	require.NoError(t, err)
+
+	acquired, err := active.TryAcquire(ctx)
+	require.NoError(t, err)
+	assert.True(t, acquired)
+	activeToken, _ := active.FencingToken()
+
+	// Releasing the lease lets the standby take over without waiting for the lease to expire
+	require.NoError(t, active.Release(ctx))
+	_, ok := active.FencingToken()
+	assert.False(t, ok)
+
+	leaderCtx, err := standby.Run(ctx, 100*time.Millisecond)
+	require.NoError(t, err)
+	assert.NoError(t, leaderCtx.Err())
+	standbyToken, ok := standby.FencingToken()
+	assert.True(t, ok)
+	assert.Greater(t, standbyToken, activeToken)
+
+	lease, err := standby.GetLease(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "standby", lease.Owner)
+	assert.Equal(t, standbyToken, lease.FencingToken)
+}
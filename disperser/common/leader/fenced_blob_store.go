@@ -0,0 +1,152 @@
+package leader
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/disperser"
+	"github.com/Layr-Labs/eigenda/disperser/common"
+)
+
+// Fence validates that writes made on behalf of the leader are still allowed
+type Fence interface {
+	// CheckFencingToken returns an error if the fencing token held by this instance is no longer the current one
+	CheckFencingToken(ctx context.Context) error
+}
+
+// FencingTokenSource provides the fencing token of the lease held by this instance
+type FencingTokenSource interface {
+	// FencingToken returns the fencing token of the lease held by this instance, and false if it holds no lease
+	FencingToken() (uint64, bool)
+}
+
+// FencedBlobStore is a BlobStore which makes metadata writes with this instance's fencing token, so that a former
+// leader cannot overwrite the state written by the new leader. The token is passed to the metadata store through the
+// context, and the store checks it in the write itself, so there is no window between the check and the write, and
+// no extra read of the lease. Reads are passed through unchanged.
+type FencedBlobStore struct {
+	disperser.BlobStore
+
+	tokens FencingTokenSource
+}
+
+var _ disperser.BlobStore = (*FencedBlobStore)(nil)
+
+func NewFencedBlobStore(blobStore disperser.BlobStore, tokens FencingTokenSource) *FencedBlobStore {
+	return &FencedBlobStore{
+		BlobStore: blobStore,
+		tokens:    tokens,
+	}
+}
+
+// fence returns a context carrying the fencing token of this instance, or ErrNotLeader if it holds no lease
+func (s *FencedBlobStore) fence(ctx context.Context) (context.Context, error) {
+	token, ok := s.tokens.FencingToken()
+	if !ok {
+		return nil, fmt.Errorf("metadata write rejected by fence: %w", ErrNotLeader)
+	}
+	return common.WithFencingToken(ctx, token), nil
+}
+
+// fenceError reports writes rejected by the metadata store for a stale fencing token as ErrNotLeader
+func fenceError(err error) error {
+	if errors.Is(err, common.ErrStaleFencingToken) {
+		return fmt.Errorf("metadata write rejected by fence: %w: %w", ErrNotLeader, err)
+	}
+	return err
+}
+
+func (s *FencedBlobStore) StoreBlob(ctx context.Context, blob *core.Blob, requestedAt uint64) (disperser.BlobKey, error) {
+	ctx, err := s.fence(ctx)
+	if err != nil {
+		return disperser.BlobKey{}, err
+	}
+	blobKey, err := s.BlobStore.StoreBlob(ctx, blob, requestedAt)
+	return blobKey, fenceError(err)
+}
+
+func (s *FencedBlobStore) MarkBlobConfirmed(ctx context.Context, existingMetadata *disperser.BlobMetadata, confirmationInfo *disperser.ConfirmationInfo) (*disperser.BlobMetadata, error) {
+	ctx, err := s.fence(ctx)
+	if err != nil {
+		return nil, err
+	}
+	metadata, err := s.BlobStore.MarkBlobConfirmed(ctx, existingMetadata, confirmationInfo)
+	return metadata, fenceError(err)
+}
+
+func (s *FencedBlobStore) MarkBlobDispersing(ctx context.Context, blobKey disperser.BlobKey) error {
+	ctx, err := s.fence(ctx)
+	if err != nil {
+		return err
+	}
+	return fenceError(s.BlobStore.MarkBlobDispersing(ctx, blobKey))
+}
+
+func (s *FencedBlobStore) MarkBlobInsufficientSignatures(ctx context.Context, existingMetadata *disperser.BlobMetadata, confirmationInfo *disperser.ConfirmationInfo) (*disperser.BlobMetadata, error) {
+	ctx, err := s.fence(ctx)
+	if err != nil {
+		return nil, err
+	}
+	metadata, err := s.BlobStore.MarkBlobInsufficientSignatures(ctx, existingMetadata, confirmationInfo)
+	return metadata, fenceError(err)
+}
+
+func (s *FencedBlobStore) MarkBlobFinalized(ctx context.Context, blobKey disperser.BlobKey) error {
+	ctx, err := s.fence(ctx)
+	if err != nil {
+		return err
+	}
+	return fenceError(s.BlobStore.MarkBlobFinalized(ctx, blobKey))
+}
+
+func (s *FencedBlobStore) MarkBlobProcessing(ctx context.Context, blobKey disperser.BlobKey) error {
+	ctx, err := s.fence(ctx)
+	if err != nil {
+		return err
+	}
+	return fenceError(s.BlobStore.MarkBlobProcessing(ctx, blobKey))
+}
+
+func (s *FencedBlobStore) MarkBlobFailed(ctx context.Context, blobKey disperser.BlobKey) error {
+	ctx, err := s.fence(ctx)
+	if err != nil {
+		return err
+	}
+	return fenceError(s.BlobStore.MarkBlobFailed(ctx, blobKey))
+}
+
+func (s *FencedBlobStore) IncrementBlobRetryCount(ctx context.Context, existingMetadata *disperser.BlobMetadata) error {
+	ctx, err := s.fence(ctx)
+	if err != nil {
+		return err
+	}
+	return fenceError(s.BlobStore.IncrementBlobRetryCount(ctx, existingMetadata))
+}
+
+func (s *FencedBlobStore) UpdateConfirmationBlockNumber(ctx context.Context, existingMetadata *disperser.BlobMetadata, confirmationBlockNumber uint32) error {
+	ctx, err := s.fence(ctx)
+	if err != nil {
+		return err
+	}
+	return fenceError(s.BlobStore.UpdateConfirmationBlockNumber(ctx, existingMetadata, confirmationBlockNumber))
+}
+
+func (s *FencedBlobStore) HandleBlobFailure(ctx context.Context, metadata *disperser.BlobMetadata, maxRetry uint) (bool, error) {
+	ctx, err := s.fence(ctx)
+	if err != nil {
+		return false, err
+	}
+	retried, err := s.BlobStore.HandleBlobFailure(ctx, metadata, maxRetry)
+	return retried, fenceError(err)
+}
+
+func (s *FencedBlobStore) DeleteExpiredBlobMetadata(ctx context.Context) (int64, error) {
+	ctx, err := s.fence(ctx)
+	if err != nil {
+		return 0, err
+	}
+	deleted, err := s.BlobStore.DeleteExpiredBlobMetadata(ctx)
+	return deleted, fenceError(err)
+}
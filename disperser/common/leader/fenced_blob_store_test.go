@@ -0,0 +1,91 @@
+package leader_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/disperser"
+	"github.com/Layr-Labs/eigenda/disperser/common"
+	"github.com/Layr-Labs/eigenda/disperser/common/inmem"
+	"github.com/Layr-Labs/eigenda/disperser/common/leader"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testTokenSource struct {
+	token  uint64
+	leader bool
+}
+
+func (s *testTokenSource) FencingToken() (uint64, bool) {
+	return s.token, s.leader
+}
+
+// tokenCheckingBlobStore rejects status updates made with a fencing token older than the newest one it has seen, as
+// the metadata stores do in the write itself
+type tokenCheckingBlobStore struct {
+	disperser.BlobStore
+
+	tokens []uint64
+	newest uint64
+}
+
+func (s *tokenCheckingBlobStore) MarkBlobProcessing(ctx context.Context, blobKey disperser.BlobKey) error {
+	token, ok := common.FencingTokenFromContext(ctx)
+	if !ok {
+		return s.BlobStore.MarkBlobProcessing(ctx, blobKey)
+	}
+	s.tokens = append(s.tokens, token)
+	if token < s.newest {
+		return common.ErrStaleFencingToken
+	}
+	s.newest = token
+	return s.BlobStore.MarkBlobProcessing(ctx, blobKey)
+}
+
+func TestFencedBlobStore(t *testing.T) {
+	ctx := context.Background()
+	tokens := &testTokenSource{token: 1, leader: true}
+	store := &tokenCheckingBlobStore{BlobStore: inmem.NewBlobStore()}
+	blobStore := leader.NewFencedBlobStore(store, tokens)
+
+	blob := &core.Blob{
+		RequestHeader: core.BlobRequestHeader{
+			SecurityParams: []*core.SecurityParam{{
+				QuorumID:              0,
+				AdversaryThreshold:    80,
+				ConfirmationThreshold: 100,
+			}},
+		},
+		Data: []byte("test"),
+	}
+	key, err := blobStore.StoreBlob(ctx, blob, uint64(time.Now().UnixNano()))
+	require.NoError(t, err)
+	require.NoError(t, blobStore.MarkBlobDispersing(ctx, key))
+
+	// writes carry the fencing token to the metadata store
+	require.NoError(t, blobStore.MarkBlobProcessing(ctx, key))
+	assert.Equal(t, []uint64{1}, store.tokens)
+
+	// a write rejected by the metadata store for a stale token is reported as a lost leadership
+	store.newest = 2
+	err = blobStore.MarkBlobProcessing(ctx, key)
+	assert.ErrorIs(t, err, leader.ErrNotLeader)
+	assert.ErrorIs(t, err, common.ErrStaleFencingToken)
+
+	// without a lease, writes are rejected before reaching the metadata store, but reads still succeed
+	tokens.leader = false
+	err = blobStore.MarkBlobProcessing(ctx, key)
+	assert.ErrorIs(t, err, leader.ErrNotLeader)
+	err = blobStore.MarkBlobFailed(ctx, key)
+	assert.ErrorIs(t, err, leader.ErrNotLeader)
+	_, err = blobStore.StoreBlob(ctx, blob, uint64(time.Now().UnixNano()))
+	assert.ErrorIs(t, err, leader.ErrNotLeader)
+	assert.Equal(t, []uint64{1, 1}, store.tokens)
+
+	metadata, err := blobStore.GetBlobMetadata(ctx, key)
+	require.NoError(t, err)
+	assert.Equal(t, disperser.Processing, metadata.BlobStatus)
+}
@@ -0,0 +1,114 @@
+// Package pow implements the proof of work dispersers may require of unauthenticated dispersal requests, so that
+// permissionless endpoints of networks without payments can't be flooded for free.
+package pow
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/bits"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// BaseBlobSize is the largest blob size the base difficulty applies to. Each doubling of the blob size beyond it
+// requires one more leading zero bit, so that the work is proportional to the size of the blob.
+const BaseBlobSize = 128 * 1024
+
+var (
+	ErrInsufficientWork = errors.New("proof of work does not meet the required difficulty")
+	ErrProofExpired     = errors.New("proof of work timestamp is outside the accepted window")
+	ErrProofReused      = errors.New("proof of work was already used")
+	// ErrProofNotRecorded is returned when a proof of work can't be checked against the proofs used, which is a
+	// failure of the disperser rather than of the request
+	ErrProofNotRecorded = errors.New("failed to record proof of work")
+)
+
+// Difficulty returns the number of leading zero bits required of the proof of work for a blob of the given size.
+func Difficulty(baseDifficulty uint32, blobSize int) uint32 {
+	if baseDifficulty == 0 {
+		return 0
+	}
+	difficulty := baseDifficulty
+	for size := BaseBlobSize; size < blobSize; size *= 2 {
+		difficulty++
+	}
+	return difficulty
+}
+
+// Digest returns the hash a proof of work is checked against, keccak256(dataHash || timestamp || nonce).
+func Digest(dataHash [32]byte, timestamp uint64, nonce uint64) [32]byte {
+	var buf [48]byte
+	copy(buf[:32], dataHash[:])
+	binary.BigEndian.PutUint64(buf[32:40], timestamp)
+	binary.BigEndian.PutUint64(buf[40:], nonce)
+	return crypto.Keccak256Hash(buf[:])
+}
+
+// leadingZeroBits returns the number of leading zero bits of the digest.
+func leadingZeroBits(digest [32]byte) uint32 {
+	zeros := uint32(0)
+	for _, b := range digest {
+		if b != 0 {
+			return zeros + uint32(bits.LeadingZeros8(b))
+		}
+		zeros += 8
+	}
+	return zeros
+}
+
+// Solve finds a nonce for which the digest of the data and timestamp has at least difficulty leading zero bits.
+func Solve(ctx context.Context, data []byte, timestamp uint64, difficulty uint32) (uint64, error) {
+	dataHash := crypto.Keccak256Hash(data)
+	for nonce := uint64(0); ; nonce++ {
+		if nonce%(1<<16) == 0 && ctx.Err() != nil {
+			return 0, fmt.Errorf("failed to solve proof of work: %w", ctx.Err())
+		}
+		if leadingZeroBits(Digest(dataHash, timestamp, nonce)) >= difficulty {
+			return nonce, nil
+		}
+	}
+}
+
+// Verifier checks the proofs of work of requests, and that no proof of work is used twice.
+type Verifier struct {
+	baseDifficulty uint32
+	window         time.Duration
+	store          ProofStore
+}
+
+// NewVerifier creates a verifier requiring baseDifficulty leading zero bits of the proofs of work of blobs up to
+// BaseBlobSize, and accepting proofs whose timestamp is within window of the current time. The proofs used are
+// recorded in the store, which must be shared by the dispersers behind the same endpoint, or else a proof can be used
+// once with each of them.
+func NewVerifier(baseDifficulty uint32, window time.Duration, store ProofStore) *Verifier {
+	return &Verifier{
+		baseDifficulty: baseDifficulty,
+		window:         window,
+		store:          store,
+	}
+}
+
+// Difficulty returns the number of leading zero bits required of the proof of work for a blob of the given size.
+func (v *Verifier) Difficulty(blobSize int) uint32 {
+	return Difficulty(v.baseDifficulty, blobSize)
+}
+
+// Verify checks the proof of work of a request for the given data, and records it so that it can't be used again.
+func (v *Verifier) Verify(ctx context.Context, data []byte, timestamp uint64, nonce uint64, now time.Time) error {
+	proofTime := time.Unix(int64(timestamp), 0)
+	if proofTime.Before(now.Add(-v.window)) || proofTime.After(now.Add(v.window)) {
+		return ErrProofExpired
+	}
+
+	difficulty := v.Difficulty(len(data))
+	digest := Digest(crypto.Keccak256Hash(data), timestamp, nonce)
+	if leadingZeroBits(digest) < difficulty {
+		return fmt.Errorf("%w of %d bits", ErrInsufficientWork, difficulty)
+	}
+
+	// the proof is accepted until its timestamp is outside the window, so it must be remembered until then
+	return v.store.Use(ctx, digest, proofTime.Add(v.window), now)
+}
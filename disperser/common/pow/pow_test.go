@@ -0,0 +1,92 @@
+package pow_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common/aws/dynamodb"
+	"github.com/Layr-Labs/eigenda/common/aws/mock"
+	"github.com/Layr-Labs/eigenda/disperser/common/pow"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDifficulty(t *testing.T) {
+	require.Equal(t, uint32(0), pow.Difficulty(0, 16*1024*1024))
+	require.Equal(t, uint32(10), pow.Difficulty(10, 1))
+	require.Equal(t, uint32(10), pow.Difficulty(10, pow.BaseBlobSize))
+	require.Equal(t, uint32(11), pow.Difficulty(10, pow.BaseBlobSize+1))
+	require.Equal(t, uint32(17), pow.Difficulty(10, 16*1024*1024))
+}
+
+func TestSolveAndVerify(t *testing.T) {
+	ctx := context.Background()
+	data := []byte("hello world")
+	now := time.Now()
+	timestamp := uint64(now.Unix())
+	verifier := pow.NewVerifier(16, time.Minute, pow.NewLocalProofStore(time.Minute))
+
+	nonce, err := pow.Solve(context.Background(), data, timestamp, verifier.Difficulty(len(data)))
+	require.NoError(t, err)
+	require.NoError(t, verifier.Verify(ctx, data, timestamp, nonce, now))
+
+	// proofs can't be reused
+	require.ErrorIs(t, verifier.Verify(ctx, data, timestamp, nonce, now), pow.ErrProofReused)
+	// for as long as the proof is in the window
+	require.ErrorIs(t, verifier.Verify(ctx, data, timestamp, nonce, now.Add(59*time.Second)), pow.ErrProofReused)
+
+	// proofs are bound to the data
+	err = verifier.Verify(ctx, []byte("goodbye world"), timestamp, nonce, now)
+	require.ErrorIs(t, err, pow.ErrInsufficientWork)
+
+	// proofs outside the window are rejected
+	nonce, err = pow.Solve(context.Background(), data, timestamp-120, verifier.Difficulty(len(data)))
+	require.NoError(t, err)
+	require.ErrorIs(t, verifier.Verify(ctx, data, timestamp-120, nonce, now), pow.ErrProofExpired)
+	require.ErrorIs(t, verifier.Verify(ctx, data, timestamp+120, nonce, now), pow.ErrProofExpired)
+}
+
+func TestSolveCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := pow.Solve(ctx, []byte("hello world"), uint64(time.Now().Unix()), 256)
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestSharedProofStore(t *testing.T) {
+	ctx := context.Background()
+	data := []byte("hello world")
+	now := time.Now()
+	timestamp := uint64(now.Unix())
+	store := pow.NewLocalProofStore(time.Minute)
+	verifier1 := pow.NewVerifier(8, time.Minute, store)
+	verifier2 := pow.NewVerifier(8, time.Minute, store)
+
+	// proofs used with one disperser can't be used with another sharing the store
+	nonce, err := pow.Solve(ctx, data, timestamp, verifier1.Difficulty(len(data)))
+	require.NoError(t, err)
+	require.NoError(t, verifier1.Verify(ctx, data, timestamp, nonce, now))
+	require.ErrorIs(t, verifier2.Verify(ctx, data, timestamp, nonce, now), pow.ErrProofReused)
+}
+
+func TestDynamoProofStore(t *testing.T) {
+	ctx := context.Background()
+	client := &mock.MockDynamoDBClient{}
+	store := pow.NewDynamoProofStore(client, "proofs")
+	now := time.Now()
+	digest := pow.Digest([32]byte{1}, uint64(now.Unix()), 0)
+
+	client.On("PutItemWithCondition").Return(nil).Once()
+	require.NoError(t, store.Use(ctx, digest, now.Add(time.Minute), now))
+
+	// the conditional put fails once the digest is recorded
+	client.On("PutItemWithCondition").Return(dynamodb.ErrConditionFailed).Once()
+	require.ErrorIs(t, store.Use(ctx, digest, now.Add(time.Minute), now), pow.ErrProofReused)
+
+	// failures to record the proof are distinguished from invalid proofs
+	client.On("PutItemWithCondition").Return(errors.New("throttled")).Once()
+	err := store.Use(ctx, digest, now.Add(time.Minute), now)
+	require.ErrorIs(t, err, pow.ErrProofNotRecorded)
+	require.NotErrorIs(t, err, pow.ErrProofReused)
+}
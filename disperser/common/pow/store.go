@@ -0,0 +1,94 @@
+package pow
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	commondynamodb "github.com/Layr-Labs/eigenda/common/aws/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// ProofStore records the proofs of work which were used.
+type ProofStore interface {
+	// Use records the digest of a proof of work as used until expiry, and returns ErrProofReused if it was already
+	// used. now is the time the proof is used at.
+	Use(ctx context.Context, digest [32]byte, expiry time.Time, now time.Time) error
+}
+
+// localProofStore records the proofs of work used with a single disperser in memory.
+type localProofStore struct {
+	window time.Duration
+
+	mu sync.Mutex
+	// The digests of the proofs of work used recently. A proof is remembered until its timestamp is outside the
+	// window, which is at most 2 windows after it is used, so the digests are kept in two generations which are
+	// rotated every 2 windows.
+	current   map[[32]byte]struct{}
+	previous  map[[32]byte]struct{}
+	rotatedAt time.Time
+}
+
+// NewLocalProofStore creates a store of the proofs of work used with this disperser, for proofs accepted within window
+// of their timestamp. It only prevents replays if this is the only disperser behind the endpoint.
+func NewLocalProofStore(window time.Duration) ProofStore {
+	return &localProofStore{
+		window:    window,
+		current:   make(map[[32]byte]struct{}),
+		previous:  make(map[[32]byte]struct{}),
+		rotatedAt: time.Now(),
+	}
+}
+
+func (s *localProofStore) Use(ctx context.Context, digest [32]byte, expiry time.Time, now time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if now.Sub(s.rotatedAt) >= 2*s.window {
+		s.previous = s.current
+		s.current = make(map[[32]byte]struct{})
+		s.rotatedAt = now
+	}
+	_, inCurrent := s.current[digest]
+	_, inPrevious := s.previous[digest]
+	if inCurrent || inPrevious {
+		return ErrProofReused
+	}
+	s.current[digest] = struct{}{}
+	return nil
+}
+
+// dynamoProofStore records the proofs of work used with any of the dispersers sharing a DynamoDB table.
+type dynamoProofStore struct {
+	client    commondynamodb.Client
+	tableName string
+}
+
+// NewDynamoProofStore creates a store of the proofs of work used with the dispersers sharing the given DynamoDB table.
+// The table is keyed by the hex encoded "Digest" of the proofs, and should expire items by their "ExpiresAt" attribute,
+// which holds the unix time after which the proof is no longer accepted.
+func NewDynamoProofStore(client commondynamodb.Client, tableName string) ProofStore {
+	return &dynamoProofStore{
+		client:    client,
+		tableName: tableName,
+	}
+}
+
+func (s *dynamoProofStore) Use(ctx context.Context, digest [32]byte, expiry time.Time, now time.Time) error {
+	item := commondynamodb.Item{
+		"Digest":    &types.AttributeValueMemberS{Value: hex.EncodeToString(digest[:])},
+		"ExpiresAt": &types.AttributeValueMemberN{Value: strconv.FormatInt(expiry.Unix(), 10)},
+	}
+	// items expired by TTL may not have been deleted yet, but their proofs are rejected as expired before reaching here
+	err := s.client.PutItemWithCondition(ctx, s.tableName, item, "attribute_not_exists(Digest)", nil, nil)
+	if errors.Is(err, commondynamodb.ErrConditionFailed) {
+		return ErrProofReused
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrProofNotRecorded, err)
+	}
+	return nil
+}
@@ -86,6 +86,13 @@ type BlobMetadata struct {
 	RequestedAt uint64
 	// UpdatedAt is the Unix timestamp of when the blob was last updated in _nanoseconds_
 	UpdatedAt uint64
+	// Purged indicates that the blob and its chunks were deleted from the object store after the blob expired
+	Purged bool
+	// ReservationOverflowSymbols is the number of symbols of the blob charged to the reservation bin two periods after
+	// the period of the blob, because the blob overflowed the bin of its own period
+	ReservationOverflowSymbols uint64
+	// PaymentRefunded indicates that the payment of the blob was refunded after the blob failed
+	PaymentRefunded bool
 
 	*encoding.FragmentInfo
 }
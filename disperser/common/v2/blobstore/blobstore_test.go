@@ -2,6 +2,7 @@ package blobstore_test
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"math/big"
 	"os"
@@ -32,6 +33,14 @@ var (
 	deployLocalStack bool
 	localStackPort   = "4571"
 
+	postgresPool     *dockertest.Pool
+	postgresResource *dockertest.Resource
+
+	deployPostgres bool
+	postgresPort   = "5434"
+
+	postgresDB *sql.DB
+
 	s3Client                s3.Client
 	dynamoClient            dynamodb.Client
 	mockDynamoClient        *mock.MockDynamoDBClient
@@ -60,6 +69,11 @@ func setup(m *testing.M) {
 		localStackPort = os.Getenv("LOCALSTACK_PORT")
 	}
 
+	deployPostgres = !(os.Getenv("DEPLOY_POSTGRES") == "false")
+	if !deployPostgres {
+		postgresPort = os.Getenv("POSTGRES_PORT")
+	}
+
 	if deployLocalStack {
 		var err error
 		dockertestPool, dockertestResource, err = deploy.StartDockertestWithLocalstackContainer(localStackPort)
@@ -102,7 +116,20 @@ func setup(m *testing.M) {
 		teardown()
 		panic("failed to create s3 bucket: " + err.Error())
 	}
-	blobStore = blobstore.NewBlobStore(s3BucketName, s3Client, logger)
+	blobStore = blobstore.NewBlobStore(s3BucketName, s3Client, nil, logger)
+
+	if deployPostgres {
+		postgresPool, postgresResource, err = deploy.StartDockertestWithPostgresContainer(postgresPort)
+		if err != nil {
+			teardown()
+			panic("failed to start postgres container")
+		}
+	}
+	postgresDB, err = sql.Open("postgres", deploy.PostgresDSN(postgresPort))
+	if err != nil {
+		teardown()
+		panic("failed to open postgres database: " + err.Error())
+	}
 
 	var X1, Y1 fp.Element
 	X1 = *X1.SetBigInt(big.NewInt(1))
@@ -153,4 +180,7 @@ func teardown() {
 	if deployLocalStack {
 		deploy.PurgeDockertestResources(dockertestPool, dockertestResource)
 	}
+	if deployPostgres {
+		deploy.PurgeDockertestResources(postgresPool, postgresResource)
+	}
 }
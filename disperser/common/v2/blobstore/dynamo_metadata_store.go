@@ -239,6 +239,51 @@ func (s *BlobMetadataStore) DeleteBlobMetadata(ctx context.Context, blobKey core
 	return err
 }
 
+// MarkBlobPurged records that the blob and its chunks were deleted from the object store.
+// The status of the blob is left unchanged.
+func (s *BlobMetadataStore) MarkBlobPurged(ctx context.Context, blobKey corev2.BlobKey) error {
+	_, err := s.dynamoDBClient.UpdateItemWithCondition(ctx, s.tableName, map[string]types.AttributeValue{
+		"PK": &types.AttributeValueMemberS{
+			Value: blobKeyPrefix + blobKey.Hex(),
+		},
+		"SK": &types.AttributeValueMemberS{
+			Value: blobMetadataSK,
+		},
+	}, map[string]types.AttributeValue{
+		"Purged": &types.AttributeValueMemberBOOL{
+			Value: true,
+		},
+	}, expression.AttributeExists(expression.Name("PK")))
+
+	if errors.Is(err, commondynamodb.ErrConditionFailed) {
+		return fmt.Errorf("%w: metadata not found for key %s", common.ErrMetadataNotFound, blobKey.Hex())
+	}
+
+	return err
+}
+
+// MarkBlobPaymentRefunded records that the payment of a failed blob was refunded, so that it isn't refunded again.
+func (s *BlobMetadataStore) MarkBlobPaymentRefunded(ctx context.Context, blobKey corev2.BlobKey) error {
+	_, err := s.dynamoDBClient.UpdateItemWithCondition(ctx, s.tableName, map[string]types.AttributeValue{
+		"PK": &types.AttributeValueMemberS{
+			Value: blobKeyPrefix + blobKey.Hex(),
+		},
+		"SK": &types.AttributeValueMemberS{
+			Value: blobMetadataSK,
+		},
+	}, map[string]types.AttributeValue{
+		"PaymentRefunded": &types.AttributeValueMemberBOOL{
+			Value: true,
+		},
+	}, expression.AttributeExists(expression.Name("PK")))
+
+	if errors.Is(err, commondynamodb.ErrConditionFailed) {
+		return fmt.Errorf("%w: metadata not found for key %s", common.ErrMetadataNotFound, blobKey.Hex())
+	}
+
+	return err
+}
+
 func (s *BlobMetadataStore) GetBlobMetadata(ctx context.Context, blobKey corev2.BlobKey) (*v2.BlobMetadata, error) {
 	item, err := s.dynamoDBClient.GetItem(ctx, s.tableName, map[string]types.AttributeValue{
 		"PK": &types.AttributeValueMemberS{
@@ -249,14 +294,14 @@ func (s *BlobMetadataStore) GetBlobMetadata(ctx context.Context, blobKey corev2.
 		},
 	})
 
-	if item == nil {
-		return nil, fmt.Errorf("%w: metadata not found for key %s", common.ErrMetadataNotFound, blobKey.Hex())
-	}
-
 	if err != nil {
 		return nil, err
 	}
 
+	if item == nil {
+		return nil, fmt.Errorf("%w: metadata not found for key %s", common.ErrMetadataNotFound, blobKey.Hex())
+	}
+
 	metadata, err := UnmarshalBlobMetadata(item)
 	if err != nil {
 		return nil, err
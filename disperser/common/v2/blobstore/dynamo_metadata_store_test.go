@@ -1368,6 +1368,84 @@ func TestBlobMetadataStoreUpdateBlobStatus(t *testing.T) {
 	})
 }
 
+func TestBlobMetadataStoreMarkBlobPurged(t *testing.T) {
+	ctx := context.Background()
+	blobKey, blobHeader := newBlob(t)
+
+	now := time.Now()
+	metadata := &v2.BlobMetadata{
+		BlobHeader: blobHeader,
+		Signature:  []byte("signature"),
+		BlobStatus: v2.Complete,
+		Expiry:     uint64(now.Unix()),
+		NumRetries: 0,
+		UpdatedAt:  uint64(now.UnixNano()),
+	}
+	err := blobMetadataStore.PutBlobMetadata(ctx, metadata)
+	assert.NoError(t, err)
+
+	err = blobMetadataStore.MarkBlobPurged(ctx, blobKey)
+	assert.NoError(t, err)
+	fetchedMetadata, err := blobMetadataStore.GetBlobMetadata(ctx, blobKey)
+	assert.NoError(t, err)
+	assert.True(t, fetchedMetadata.Purged)
+	assert.Equal(t, v2.Complete, fetchedMetadata.BlobStatus)
+
+	// blobs without metadata can't be marked purged
+	missingKey, _ := newBlob(t)
+	err = blobMetadataStore.MarkBlobPurged(ctx, missingKey)
+	assert.ErrorIs(t, err, common.ErrMetadataNotFound)
+
+	deleteItems(t, []commondynamodb.Key{
+		{
+			"PK": &types.AttributeValueMemberS{Value: "BlobKey#" + blobKey.Hex()},
+			"SK": &types.AttributeValueMemberS{Value: "BlobMetadata"},
+		},
+	})
+}
+
+func TestBlobMetadataStoreMarkBlobPaymentRefunded(t *testing.T) {
+	ctx := context.Background()
+	blobKey, blobHeader := newBlob(t)
+
+	now := time.Now()
+	metadata := &v2.BlobMetadata{
+		BlobHeader:                 blobHeader,
+		Signature:                  []byte("signature"),
+		BlobStatus:                 v2.Failed,
+		Expiry:                     uint64(now.Unix()),
+		NumRetries:                 0,
+		UpdatedAt:                  uint64(now.UnixNano()),
+		ReservationOverflowSymbols: 42,
+	}
+	err := blobMetadataStore.PutBlobMetadata(ctx, metadata)
+	assert.NoError(t, err)
+
+	fetchedMetadata, err := blobMetadataStore.GetBlobMetadata(ctx, blobKey)
+	assert.NoError(t, err)
+	assert.False(t, fetchedMetadata.PaymentRefunded)
+	assert.Equal(t, uint64(42), fetchedMetadata.ReservationOverflowSymbols)
+
+	err = blobMetadataStore.MarkBlobPaymentRefunded(ctx, blobKey)
+	assert.NoError(t, err)
+	fetchedMetadata, err = blobMetadataStore.GetBlobMetadata(ctx, blobKey)
+	assert.NoError(t, err)
+	assert.True(t, fetchedMetadata.PaymentRefunded)
+	assert.Equal(t, v2.Failed, fetchedMetadata.BlobStatus)
+
+	// blobs without metadata can't be marked refunded
+	missingKey, _ := newBlob(t)
+	err = blobMetadataStore.MarkBlobPaymentRefunded(ctx, missingKey)
+	assert.ErrorIs(t, err, common.ErrMetadataNotFound)
+
+	deleteItems(t, []commondynamodb.Key{
+		{
+			"PK": &types.AttributeValueMemberS{Value: "BlobKey#" + blobKey.Hex()},
+			"SK": &types.AttributeValueMemberS{Value: "BlobMetadata"},
+		},
+	})
+}
+
 func TestBlobMetadataStoreDispersals(t *testing.T) {
 	ctx := context.Background()
 	opID := core.OperatorID{0, 1}
@@ -0,0 +1,91 @@
+package blobstore
+
+import (
+	"context"
+
+	commondynamodb "github.com/Layr-Labs/eigenda/common/aws/dynamodb"
+	"github.com/Layr-Labs/eigenda/core"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	blobstorev1 "github.com/Layr-Labs/eigenda/disperser/common/blobstore"
+	v2 "github.com/Layr-Labs/eigenda/disperser/common/v2"
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+// MetadataStore is the interface to a backend storing the metadata of v2 blobs and batches. It's implemented by
+// BlobMetadataStore (DynamoDB) and PostgresBlobMetadataStore.
+type MetadataStore interface {
+	PutBlobMetadata(ctx context.Context, blobMetadata *v2.BlobMetadata) error
+	UpdateBlobStatus(ctx context.Context, blobKey corev2.BlobKey, status v2.BlobStatus) error
+	DeleteBlobMetadata(ctx context.Context, blobKey corev2.BlobKey) error
+	MarkBlobPurged(ctx context.Context, blobKey corev2.BlobKey) error
+	MarkBlobPaymentRefunded(ctx context.Context, blobKey corev2.BlobKey) error
+	GetBlobMetadata(ctx context.Context, blobKey corev2.BlobKey) (*v2.BlobMetadata, error)
+	GetBlobMetadataByStatus(ctx context.Context, status v2.BlobStatus, lastUpdatedAt uint64) ([]*v2.BlobMetadata, error)
+	GetBlobMetadataByRequestedAtForward(ctx context.Context, after BlobFeedCursor, before BlobFeedCursor, limit int) ([]*v2.BlobMetadata, *BlobFeedCursor, error)
+	GetBlobMetadataByRequestedAtBackward(ctx context.Context, before BlobFeedCursor, after BlobFeedCursor, limit int) ([]*v2.BlobMetadata, *BlobFeedCursor, error)
+	GetBlobMetadataByStatusPaginated(ctx context.Context, status v2.BlobStatus, exclusiveStartKey *StatusIndexCursor, limit int32) ([]*v2.BlobMetadata, *StatusIndexCursor, error)
+	GetBlobMetadataCountByStatus(ctx context.Context, status v2.BlobStatus) (int32, error)
+
+	PutBlobCertificate(ctx context.Context, blobCert *corev2.BlobCertificate, fragmentInfo *encoding.FragmentInfo) error
+	DeleteBlobCertificate(ctx context.Context, blobKey corev2.BlobKey) error
+	GetBlobCertificate(ctx context.Context, blobKey corev2.BlobKey) (*corev2.BlobCertificate, *encoding.FragmentInfo, error)
+	GetBlobCertificates(ctx context.Context, blobKeys []corev2.BlobKey) ([]*corev2.BlobCertificate, []*encoding.FragmentInfo, error)
+
+	PutDispersalRequest(ctx context.Context, req *corev2.DispersalRequest) error
+	GetDispersalRequest(ctx context.Context, batchHeaderHash [32]byte, operatorID core.OperatorID) (*corev2.DispersalRequest, error)
+	PutDispersalResponse(ctx context.Context, res *corev2.DispersalResponse) error
+	GetDispersalResponse(ctx context.Context, batchHeaderHash [32]byte, operatorID core.OperatorID) (*corev2.DispersalResponse, error)
+	GetDispersalResponses(ctx context.Context, batchHeaderHash [32]byte) ([]*corev2.DispersalResponse, error)
+
+	PutBatchHeader(ctx context.Context, batchHeader *corev2.BatchHeader) error
+	DeleteBatchHeader(ctx context.Context, batchHeaderHash [32]byte) error
+	GetBatchHeader(ctx context.Context, batchHeaderHash [32]byte) (*corev2.BatchHeader, error)
+	PutAttestation(ctx context.Context, attestation *corev2.Attestation) error
+	GetAttestation(ctx context.Context, batchHeaderHash [32]byte) (*corev2.Attestation, error)
+	GetAttestationByAttestedAtForward(ctx context.Context, after uint64, before uint64, limit int) ([]*corev2.Attestation, error)
+	GetAttestationByAttestedAtBackward(ctx context.Context, before uint64, after uint64, limit int) ([]*corev2.Attestation, error)
+	GetSignedBatch(ctx context.Context, batchHeaderHash [32]byte) (*corev2.BatchHeader, *corev2.Attestation, error)
+
+	PutBlobInclusionInfo(ctx context.Context, inclusionInfo *corev2.BlobInclusionInfo) error
+	PutBlobInclusionInfos(ctx context.Context, inclusionInfos []*corev2.BlobInclusionInfo) error
+	GetBlobInclusionInfo(ctx context.Context, blobKey corev2.BlobKey, batchHeaderHash [32]byte) (*corev2.BlobInclusionInfo, error)
+	GetBlobInclusionInfos(ctx context.Context, blobKey corev2.BlobKey) ([]*corev2.BlobInclusionInfo, error)
+	GetBlobAttestationInfo(ctx context.Context, blobKey corev2.BlobKey) (*v2.BlobAttestationInfo, error)
+}
+
+var (
+	_ MetadataStore = (*BlobMetadataStore)(nil)
+	_ MetadataStore = (*PostgresBlobMetadataStore)(nil)
+)
+
+// NewMetadataStore creates the v2 metadata store of the configured backend. The DynamoDB backend uses the given client
+// and table. The Postgres tables are named with the table name of the config as a prefix, and created if they don't
+// exist.
+func NewMetadataStore(
+	ctx context.Context,
+	config blobstorev1.MetadataStoreConfig,
+	dynamoDBClient commondynamodb.Client,
+	dynamoDBTableName string,
+	logger logging.Logger,
+) (MetadataStore, error) {
+	if err := config.Verify(); err != nil {
+		return nil, err
+	}
+	if config.Backend != blobstorev1.PostgresBackend {
+		return NewBlobMetadataStore(dynamoDBClient, logger, dynamoDBTableName), nil
+	}
+
+	db, err := config.OpenPostgres(ctx)
+	if err != nil {
+		return nil, err
+	}
+	store, err := NewPostgresBlobMetadataStore(db, logger, config.PostgresTableName+"_v2")
+	if err != nil {
+		return nil, err
+	}
+	if err := store.CreateTables(ctx); err != nil {
+		return nil, err
+	}
+	return store, nil
+}
@@ -0,0 +1,955 @@
+package blobstore
+
+import (
+	"context"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api"
+	"github.com/Layr-Labs/eigenda/core"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/disperser/common"
+	v2 "github.com/Layr-Labs/eigenda/disperser/common/v2"
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+// postgresBlobColumns are the columns of the blobs table, in the order they're scanned by queryBlobMetadata
+const postgresBlobColumns = "blob_key, blob_status, updated_at, purged, payment_refunded, metadata"
+
+// postgresTablePrefixRegex leaves room for the longest table suffix within the 63 characters of a Postgres identifier
+var postgresTablePrefixRegex = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]{0,39}$`)
+
+// PostgresBlobMetadataStore is a v2 blob metadata storage backed by Postgres, for deployments without AWS.
+// Each kind of record is stored in its own table, named with the prefix given to the store:
+// - Blobs: (Primary Key: BlobKey) -> BlobMetadata
+//   - Status index: (BlobStatus, UpdatedAt, BlobKey)
+//   - Feed index: (RequestedAt, BlobKey)
+//
+// - Certificates: (Primary Key: BlobKey) -> BlobCertificate, FragmentInfo
+// - Dispersal requests and responses: (Primary Key: BatchHeaderHash, OperatorID) -> DispersalRequest/Response
+// - Batch headers: (Primary Key: BatchHeaderHash) -> BatchHeader
+// - Attestations: (Primary Key: BatchHeaderHash) -> Attestation
+//   - Attestation index: (AttestedAt)
+//
+// - Inclusion infos: (Primary Key: BlobKey, BatchHeaderHash) -> BlobInclusionInfo
+//
+// The records are serialized as JSON. The status, update time and flags of blobs are updated in place, so their columns
+// take precedence over the fields of the serialized metadata.
+type PostgresBlobMetadataStore struct {
+	db          *sql.DB
+	logger      logging.Logger
+	tablePrefix string
+}
+
+func NewPostgresBlobMetadataStore(db *sql.DB, logger logging.Logger, tablePrefix string) (*PostgresBlobMetadataStore, error) {
+	if db == nil {
+		return nil, errors.New("database is required")
+	}
+	if !postgresTablePrefixRegex.MatchString(tablePrefix) {
+		return nil, fmt.Errorf("invalid table prefix %q", tablePrefix)
+	}
+	logger.Debugf("creating postgres blob metadata store v2 with table prefix %s", tablePrefix)
+	return &PostgresBlobMetadataStore{
+		db:          db,
+		logger:      logger.With("component", "PostgresBlobMetadataStoreV2"),
+		tablePrefix: tablePrefix,
+	}, nil
+}
+
+func (s *PostgresBlobMetadataStore) blobsTable() string {
+	return s.tablePrefix + "_blobs"
+}
+
+func (s *PostgresBlobMetadataStore) certificatesTable() string {
+	return s.tablePrefix + "_certificates"
+}
+
+func (s *PostgresBlobMetadataStore) dispersalRequestsTable() string {
+	return s.tablePrefix + "_dispersal_requests"
+}
+
+func (s *PostgresBlobMetadataStore) dispersalResponsesTable() string {
+	return s.tablePrefix + "_dispersal_responses"
+}
+
+func (s *PostgresBlobMetadataStore) batchHeadersTable() string {
+	return s.tablePrefix + "_batch_headers"
+}
+
+func (s *PostgresBlobMetadataStore) attestationsTable() string {
+	return s.tablePrefix + "_attestations"
+}
+
+func (s *PostgresBlobMetadataStore) inclusionInfosTable() string {
+	return s.tablePrefix + "_inclusion_infos"
+}
+
+// CreateTables creates the tables of the store and their indexes if they don't exist
+func (s *PostgresBlobMetadataStore) CreateTables(ctx context.Context) error {
+	statements := []string{
+		fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %[1]s (
+			blob_key TEXT PRIMARY KEY,
+			blob_status INTEGER NOT NULL,
+			updated_at BIGINT NOT NULL,
+			requested_at BIGINT NOT NULL,
+			purged BOOLEAN NOT NULL DEFAULT FALSE,
+			payment_refunded BOOLEAN NOT NULL DEFAULT FALSE,
+			metadata JSONB NOT NULL
+		)`, s.blobsTable()),
+		fmt.Sprintf("CREATE INDEX IF NOT EXISTS %[1]s_status_idx ON %[1]s (blob_status, updated_at, blob_key)", s.blobsTable()),
+		fmt.Sprintf("CREATE INDEX IF NOT EXISTS %[1]s_requested_at_idx ON %[1]s (requested_at, blob_key)", s.blobsTable()),
+		fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+			blob_key TEXT PRIMARY KEY,
+			certificate JSONB NOT NULL,
+			fragment_info JSONB NOT NULL
+		)`, s.certificatesTable()),
+		fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+			batch_header_hash TEXT NOT NULL,
+			operator_id TEXT NOT NULL,
+			request JSONB NOT NULL,
+			PRIMARY KEY (batch_header_hash, operator_id)
+		)`, s.dispersalRequestsTable()),
+		fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+			batch_header_hash TEXT NOT NULL,
+			operator_id TEXT NOT NULL,
+			response JSONB NOT NULL,
+			PRIMARY KEY (batch_header_hash, operator_id)
+		)`, s.dispersalResponsesTable()),
+		fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+			batch_header_hash TEXT PRIMARY KEY,
+			header JSONB NOT NULL
+		)`, s.batchHeadersTable()),
+		fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+			batch_header_hash TEXT PRIMARY KEY,
+			attested_at BIGINT NOT NULL,
+			attestation JSONB NOT NULL
+		)`, s.attestationsTable()),
+		fmt.Sprintf("CREATE INDEX IF NOT EXISTS %[1]s_attested_at_idx ON %[1]s (attested_at)", s.attestationsTable()),
+		fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+			blob_key TEXT NOT NULL,
+			batch_header_hash TEXT NOT NULL,
+			info JSONB NOT NULL,
+			PRIMARY KEY (blob_key, batch_header_hash)
+		)`, s.inclusionInfosTable()),
+	}
+	for _, statement := range statements {
+		if _, err := s.db.ExecContext(ctx, statement); err != nil {
+			return fmt.Errorf("failed to create metadata tables with prefix %s: %w", s.tablePrefix, err)
+		}
+	}
+	return nil
+}
+
+func (s *PostgresBlobMetadataStore) PutBlobMetadata(ctx context.Context, blobMetadata *v2.BlobMetadata) error {
+	s.logger.Debug("store put blob metadata", "blobMetadata", blobMetadata)
+	blobKey, err := blobMetadata.BlobHeader.BlobKey()
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(blobMetadata)
+	if err != nil {
+		return fmt.Errorf("failed to marshal blob metadata: %w", err)
+	}
+	return s.insert(ctx, fmt.Sprintf(`INSERT INTO %s
+		(blob_key, blob_status, updated_at, requested_at, purged, payment_refunded, metadata)
+		VALUES ($1, $2, $3, $4, $5, $6, $7) ON CONFLICT DO NOTHING`, s.blobsTable()),
+		blobKey.Hex(), int(blobMetadata.BlobStatus), int64(blobMetadata.UpdatedAt), int64(blobMetadata.RequestedAt),
+		blobMetadata.Purged, blobMetadata.PaymentRefunded, data)
+}
+
+func (s *PostgresBlobMetadataStore) UpdateBlobStatus(ctx context.Context, blobKey corev2.BlobKey, status v2.BlobStatus) error {
+	validStatuses := statusUpdatePrecondition[status]
+	if len(validStatuses) == 0 {
+		return fmt.Errorf("%w: invalid status transition to %s", ErrInvalidStateTransition, status.String())
+	}
+
+	// the statuses are integers, so they're inlined in the condition
+	valid := make([]string, len(validStatuses))
+	for i, validStatus := range validStatuses {
+		valid[i] = fmt.Sprintf("%d", int(validStatus))
+	}
+	result, err := s.db.ExecContext(ctx,
+		fmt.Sprintf("UPDATE %s SET blob_status = $2, updated_at = $3 WHERE blob_key = $1 AND blob_status IN (%s)",
+			s.blobsTable(), strings.Join(valid, ", ")),
+		blobKey.Hex(), int(status), time.Now().UnixNano())
+	if err != nil {
+		return err
+	}
+	updated, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if updated > 0 {
+		return nil
+	}
+
+	blob, err := s.GetBlobMetadata(ctx, blobKey)
+	if err != nil {
+		return fmt.Errorf("failed to get blob metadata for key %s: %v", blobKey.Hex(), err)
+	}
+	if blob.BlobStatus == status {
+		return fmt.Errorf("%w: blob already in status %s", common.ErrAlreadyExists, status.String())
+	}
+	return fmt.Errorf("%w: invalid status transition from %s to %s", ErrInvalidStateTransition, blob.BlobStatus.String(), status.String())
+}
+
+func (s *PostgresBlobMetadataStore) DeleteBlobMetadata(ctx context.Context, blobKey corev2.BlobKey) error {
+	_, err := s.db.ExecContext(ctx, fmt.Sprintf("DELETE FROM %s WHERE blob_key = $1", s.blobsTable()), blobKey.Hex())
+	return err
+}
+
+// MarkBlobPurged records that the blob and its chunks were deleted from the object store.
+// The status of the blob is left unchanged.
+func (s *PostgresBlobMetadataStore) MarkBlobPurged(ctx context.Context, blobKey corev2.BlobKey) error {
+	return s.setBlobFlag(ctx, blobKey, "purged")
+}
+
+// MarkBlobPaymentRefunded records that the payment of a failed blob was refunded, so that it isn't refunded again.
+func (s *PostgresBlobMetadataStore) MarkBlobPaymentRefunded(ctx context.Context, blobKey corev2.BlobKey) error {
+	return s.setBlobFlag(ctx, blobKey, "payment_refunded")
+}
+
+func (s *PostgresBlobMetadataStore) setBlobFlag(ctx context.Context, blobKey corev2.BlobKey, column string) error {
+	result, err := s.db.ExecContext(ctx,
+		fmt.Sprintf("UPDATE %s SET %s = TRUE WHERE blob_key = $1", s.blobsTable(), column),
+		blobKey.Hex())
+	if err != nil {
+		return err
+	}
+	updated, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if updated == 0 {
+		return fmt.Errorf("%w: metadata not found for key %s", common.ErrMetadataNotFound, blobKey.Hex())
+	}
+	return nil
+}
+
+func (s *PostgresBlobMetadataStore) GetBlobMetadata(ctx context.Context, blobKey corev2.BlobKey) (*v2.BlobMetadata, error) {
+	metadata, err := s.queryBlobMetadata(ctx,
+		fmt.Sprintf("SELECT %s FROM %s WHERE blob_key = $1", postgresBlobColumns, s.blobsTable()),
+		blobKey.Hex())
+	if err != nil {
+		return nil, err
+	}
+	if len(metadata) == 0 {
+		return nil, fmt.Errorf("%w: metadata not found for key %s", common.ErrMetadataNotFound, blobKey.Hex())
+	}
+	return metadata[0], nil
+}
+
+// GetBlobMetadataByStatus returns all the metadata with the given status that were updated after lastUpdatedAt
+// Because this function reads the entire index, it should only be used for status with a limited number of items.
+// Results are ordered by UpdatedAt in ascending order.
+func (s *PostgresBlobMetadataStore) GetBlobMetadataByStatus(ctx context.Context, status v2.BlobStatus, lastUpdatedAt uint64) ([]*v2.BlobMetadata, error) {
+	return s.queryBlobMetadata(ctx,
+		fmt.Sprintf("SELECT %s FROM %s WHERE blob_status = $1 AND updated_at > $2 ORDER BY updated_at, blob_key",
+			postgresBlobColumns, s.blobsTable()),
+		int(status), int64(lastUpdatedAt))
+}
+
+// GetBlobMetadataByRequestedAtForward returns blobs (as BlobMetadata) in cursor range
+// (after, before) (both exclusive). Blobs are retrieved and ordered by <RequestedAt, BlobKey>
+// in ascending order. Blobs outside of the window of the blob feed (see GetRequestedAtBucketIDRange) aren't returned.
+//
+// If limit > 0, returns at most that many blobs. If limit <= 0, returns all blobs in range.
+// Also returns the cursor of the last processed blob, or nil if no blobs were processed.
+func (s *PostgresBlobMetadataStore) GetBlobMetadataByRequestedAtForward(
+	ctx context.Context,
+	after BlobFeedCursor,
+	before BlobFeedCursor,
+	limit int,
+) ([]*v2.BlobMetadata, *BlobFeedCursor, error) {
+	if !after.LessThan(&before) {
+		return nil, nil, errors.New("after cursor must be less than before cursor")
+	}
+	return s.queryBlobFeed(ctx, after, before, limit, "ASC")
+}
+
+// GetBlobMetadataByRequestedAtBackward returns blobs (as BlobMetadata) in cursor range
+// (after, before) (both exclusive). Blobs are retrieved and ordered by <RequestedAt, BlobKey>
+// in descending order. Blobs outside of the window of the blob feed (see GetRequestedAtBucketIDRange) aren't returned.
+//
+// If limit > 0, returns at most that many blobs. If limit <= 0, returns all blobs in range.
+// Also returns the cursor of the last processed blob, or nil if no blobs were processed.
+func (s *PostgresBlobMetadataStore) GetBlobMetadataByRequestedAtBackward(
+	ctx context.Context,
+	before BlobFeedCursor,
+	after BlobFeedCursor,
+	limit int,
+) ([]*v2.BlobMetadata, *BlobFeedCursor, error) {
+	if !after.LessThan(&before) {
+		return nil, nil, errors.New("after cursor must be less than before cursor")
+	}
+	return s.queryBlobFeed(ctx, after, before, limit, "DESC")
+}
+
+func (s *PostgresBlobMetadataStore) queryBlobFeed(
+	ctx context.Context,
+	after BlobFeedCursor,
+	before BlobFeedCursor,
+	limit int,
+	order string,
+) ([]*v2.BlobMetadata, *BlobFeedCursor, error) {
+	// the window of the feed is the same as in DynamoDB, where it's made of whole buckets
+	startBucket, endBucket := GetRequestedAtBucketIDRange(after.RequestedAt, before.RequestedAt)
+	query := fmt.Sprintf(`SELECT %s FROM %s
+		WHERE (requested_at, blob_key) > ($1, $2) AND (requested_at, blob_key) < ($3, $4)
+		AND requested_at >= $5 AND requested_at < $6
+		ORDER BY requested_at %[3]s, blob_key %[3]s`, postgresBlobColumns, s.blobsTable(), order)
+	if limit > 0 {
+		query += fmt.Sprintf(" LIMIT %d", limit)
+	}
+	metadata, err := s.queryBlobMetadata(ctx, query,
+		int64(after.RequestedAt), feedCursorBlobKey(after.BlobKey),
+		int64(before.RequestedAt), feedCursorBlobKey(before.BlobKey),
+		int64(startBucket*requestedAtBucketSizeNano), bucketEnd(endBucket, requestedAtBucketSizeNano))
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(metadata) == 0 {
+		return metadata, nil, nil
+	}
+
+	last := metadata[len(metadata)-1]
+	lastKey, err := last.BlobHeader.BlobKey()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get blob key: %w", err)
+	}
+	return metadata, &BlobFeedCursor{RequestedAt: last.RequestedAt, BlobKey: &lastKey}, nil
+}
+
+// GetBlobMetadataByStatusPaginated returns all the metadata with the given status that were updated after the given cursor.
+// It also returns a new cursor to be used for the next page. As in DynamoDB, the cursor is nil once the end of the
+// index is reached, and the given cursor is returned if there are no results at all.
+func (s *PostgresBlobMetadataStore) GetBlobMetadataByStatusPaginated(
+	ctx context.Context,
+	status v2.BlobStatus,
+	exclusiveStartKey *StatusIndexCursor,
+	limit int32,
+) ([]*v2.BlobMetadata, *StatusIndexCursor, error) {
+	updatedAt := int64(0)
+	blobKey := ""
+	if exclusiveStartKey != nil {
+		updatedAt = int64(exclusiveStartKey.UpdatedAt)
+		blobKey = feedCursorBlobKey(exclusiveStartKey.BlobKey)
+	}
+	query := fmt.Sprintf(`SELECT %s FROM %s WHERE blob_status = $1 AND (updated_at, blob_key) > ($2, $3)
+		ORDER BY updated_at, blob_key`, postgresBlobColumns, s.blobsTable())
+	if limit > 0 {
+		query += fmt.Sprintf(" LIMIT %d", limit)
+	}
+	metadata, err := s.queryBlobMetadata(ctx, query, int(status), updatedAt, blobKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(metadata) == 0 {
+		return nil, exclusiveStartKey, nil
+	}
+	if limit <= 0 || len(metadata) < int(limit) {
+		return metadata, nil, nil
+	}
+
+	last := metadata[len(metadata)-1]
+	lastKey, err := last.BlobHeader.BlobKey()
+	if err != nil {
+		return nil, nil, err
+	}
+	return metadata, &StatusIndexCursor{BlobKey: &lastKey, UpdatedAt: last.UpdatedAt}, nil
+}
+
+// GetBlobMetadataCountByStatus returns the count of all the metadata with the given status
+func (s *PostgresBlobMetadataStore) GetBlobMetadataCountByStatus(ctx context.Context, status v2.BlobStatus) (int32, error) {
+	var count int32
+	err := s.db.QueryRowContext(ctx,
+		fmt.Sprintf("SELECT COUNT(*) FROM %s WHERE blob_status = $1", s.blobsTable()),
+		int(status)).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (s *PostgresBlobMetadataStore) PutBlobCertificate(ctx context.Context, blobCert *corev2.BlobCertificate, fragmentInfo *encoding.FragmentInfo) error {
+	blobKey, err := blobCert.BlobHeader.BlobKey()
+	if err != nil {
+		return err
+	}
+	certificate, err := json.Marshal(blobCert)
+	if err != nil {
+		return fmt.Errorf("failed to marshal blob certificate: %w", err)
+	}
+	fragments, err := json.Marshal(fragmentInfo)
+	if err != nil {
+		return fmt.Errorf("failed to marshal fragment info: %w", err)
+	}
+	return s.insert(ctx,
+		fmt.Sprintf("INSERT INTO %s (blob_key, certificate, fragment_info) VALUES ($1, $2, $3) ON CONFLICT DO NOTHING", s.certificatesTable()),
+		blobKey.Hex(), certificate, fragments)
+}
+
+func (s *PostgresBlobMetadataStore) DeleteBlobCertificate(ctx context.Context, blobKey corev2.BlobKey) error {
+	_, err := s.db.ExecContext(ctx, fmt.Sprintf("DELETE FROM %s WHERE blob_key = $1", s.certificatesTable()), blobKey.Hex())
+	return err
+}
+
+func (s *PostgresBlobMetadataStore) GetBlobCertificate(ctx context.Context, blobKey corev2.BlobKey) (*corev2.BlobCertificate, *encoding.FragmentInfo, error) {
+	certs, fragmentInfos, err := s.GetBlobCertificates(ctx, []corev2.BlobKey{blobKey})
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(certs) == 0 {
+		return nil, nil, fmt.Errorf("%w: certificate not found for key %s", common.ErrMetadataNotFound, blobKey.Hex())
+	}
+	return certs[0], fragmentInfos[0], nil
+}
+
+// GetBlobCertificates returns the certificates for the given blob keys
+// Note: the returned certificates are NOT necessarily ordered by the order of the input blob keys
+func (s *PostgresBlobMetadataStore) GetBlobCertificates(ctx context.Context, blobKeys []corev2.BlobKey) ([]*corev2.BlobCertificate, []*encoding.FragmentInfo, error) {
+	if len(blobKeys) == 0 {
+		return []*corev2.BlobCertificate{}, []*encoding.FragmentInfo{}, nil
+	}
+	placeholders := make([]string, len(blobKeys))
+	args := make([]any, len(blobKeys))
+	for i, blobKey := range blobKeys {
+		placeholders[i] = fmt.Sprintf("$%d", i+1)
+		args[i] = blobKey.Hex()
+	}
+	rows, err := s.db.QueryContext(ctx,
+		fmt.Sprintf("SELECT certificate, fragment_info FROM %s WHERE blob_key IN (%s)", s.certificatesTable(), strings.Join(placeholders, ", ")),
+		args...)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	certs := make([]*corev2.BlobCertificate, 0, len(blobKeys))
+	fragmentInfos := make([]*encoding.FragmentInfo, 0, len(blobKeys))
+	for rows.Next() {
+		var certificate, fragments []byte
+		if err := rows.Scan(&certificate, &fragments); err != nil {
+			return nil, nil, err
+		}
+		cert := &corev2.BlobCertificate{}
+		if err := json.Unmarshal(certificate, cert); err != nil {
+			return nil, nil, fmt.Errorf("failed to unmarshal blob certificate: %w", err)
+		}
+		fragmentInfo := &encoding.FragmentInfo{}
+		if err := json.Unmarshal(fragments, fragmentInfo); err != nil {
+			return nil, nil, fmt.Errorf("failed to unmarshal fragment info: %w", err)
+		}
+		certs = append(certs, cert)
+		fragmentInfos = append(fragmentInfos, fragmentInfo)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+	return certs, fragmentInfos, nil
+}
+
+func (s *PostgresBlobMetadataStore) PutDispersalRequest(ctx context.Context, req *corev2.DispersalRequest) error {
+	batchHeaderHash, err := req.BatchHeader.Hash()
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(req)
+	if err != nil {
+		return fmt.Errorf("failed to marshal dispersal request: %w", err)
+	}
+	return s.insert(ctx,
+		fmt.Sprintf("INSERT INTO %s (batch_header_hash, operator_id, request) VALUES ($1, $2, $3) ON CONFLICT DO NOTHING", s.dispersalRequestsTable()),
+		hex.EncodeToString(batchHeaderHash[:]), req.OperatorID.Hex(), data)
+}
+
+func (s *PostgresBlobMetadataStore) GetDispersalRequest(ctx context.Context, batchHeaderHash [32]byte, operatorID core.OperatorID) (*corev2.DispersalRequest, error) {
+	req := &corev2.DispersalRequest{}
+	found, err := s.getRecord(ctx,
+		fmt.Sprintf("SELECT request FROM %s WHERE batch_header_hash = $1 AND operator_id = $2", s.dispersalRequestsTable()),
+		req, hex.EncodeToString(batchHeaderHash[:]), operatorID.Hex())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dispersal request: %w", err)
+	}
+	if !found {
+		return nil, fmt.Errorf("%w: dispersal request not found for batch header hash %x and operator %s", common.ErrMetadataNotFound, batchHeaderHash, operatorID.Hex())
+	}
+	return req, nil
+}
+
+func (s *PostgresBlobMetadataStore) PutDispersalResponse(ctx context.Context, res *corev2.DispersalResponse) error {
+	if res.DispersalRequest == nil {
+		return errors.New("dispersal response is missing its request")
+	}
+	batchHeaderHash, err := res.BatchHeader.Hash()
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(res)
+	if err != nil {
+		return fmt.Errorf("failed to marshal dispersal response: %w", err)
+	}
+	return s.insert(ctx,
+		fmt.Sprintf("INSERT INTO %s (batch_header_hash, operator_id, response) VALUES ($1, $2, $3) ON CONFLICT DO NOTHING", s.dispersalResponsesTable()),
+		hex.EncodeToString(batchHeaderHash[:]), res.OperatorID.Hex(), data)
+}
+
+func (s *PostgresBlobMetadataStore) GetDispersalResponse(ctx context.Context, batchHeaderHash [32]byte, operatorID core.OperatorID) (*corev2.DispersalResponse, error) {
+	res := &corev2.DispersalResponse{}
+	found, err := s.getRecord(ctx,
+		fmt.Sprintf("SELECT response FROM %s WHERE batch_header_hash = $1 AND operator_id = $2", s.dispersalResponsesTable()),
+		res, hex.EncodeToString(batchHeaderHash[:]), operatorID.Hex())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dispersal response: %w", err)
+	}
+	if !found {
+		return nil, fmt.Errorf("%w: dispersal response not found for batch header hash %x and operator %s", common.ErrMetadataNotFound, batchHeaderHash, operatorID.Hex())
+	}
+	return res, nil
+}
+
+func (s *PostgresBlobMetadataStore) GetDispersalResponses(ctx context.Context, batchHeaderHash [32]byte) ([]*corev2.DispersalResponse, error) {
+	rows, err := s.db.QueryContext(ctx,
+		fmt.Sprintf("SELECT response FROM %s WHERE batch_header_hash = $1 ORDER BY operator_id", s.dispersalResponsesTable()),
+		hex.EncodeToString(batchHeaderHash[:]))
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	responses := make([]*corev2.DispersalResponse, 0)
+	for rows.Next() {
+		var data []byte
+		if err := rows.Scan(&data); err != nil {
+			return nil, err
+		}
+		res := &corev2.DispersalResponse{}
+		if err := json.Unmarshal(data, res); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal dispersal response: %w", err)
+		}
+		responses = append(responses, res)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(responses) == 0 {
+		return nil, fmt.Errorf("%w: dispersal responses not found for batch header hash %x", common.ErrMetadataNotFound, batchHeaderHash)
+	}
+	return responses, nil
+}
+
+func (s *PostgresBlobMetadataStore) PutBatchHeader(ctx context.Context, batchHeader *corev2.BatchHeader) error {
+	batchHeaderHash, err := batchHeader.Hash()
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(batchHeader)
+	if err != nil {
+		return fmt.Errorf("failed to marshal batch header: %w", err)
+	}
+	return s.insert(ctx,
+		fmt.Sprintf("INSERT INTO %s (batch_header_hash, header) VALUES ($1, $2) ON CONFLICT DO NOTHING", s.batchHeadersTable()),
+		hex.EncodeToString(batchHeaderHash[:]), data)
+}
+
+func (s *PostgresBlobMetadataStore) DeleteBatchHeader(ctx context.Context, batchHeaderHash [32]byte) error {
+	_, err := s.db.ExecContext(ctx,
+		fmt.Sprintf("DELETE FROM %s WHERE batch_header_hash = $1", s.batchHeadersTable()),
+		hex.EncodeToString(batchHeaderHash[:]))
+	return err
+}
+
+func (s *PostgresBlobMetadataStore) GetBatchHeader(ctx context.Context, batchHeaderHash [32]byte) (*corev2.BatchHeader, error) {
+	header := &corev2.BatchHeader{}
+	found, err := s.getRecord(ctx,
+		fmt.Sprintf("SELECT header FROM %s WHERE batch_header_hash = $1", s.batchHeadersTable()),
+		header, hex.EncodeToString(batchHeaderHash[:]))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get batch header: %w", err)
+	}
+	if !found {
+		return nil, fmt.Errorf("%w: batch header not found for hash %x", common.ErrMetadataNotFound, batchHeaderHash)
+	}
+	return header, nil
+}
+
+func (s *PostgresBlobMetadataStore) PutAttestation(ctx context.Context, attestation *corev2.Attestation) error {
+	if attestation.BatchHeader == nil {
+		return errors.New("attestation is missing its batch header")
+	}
+	batchHeaderHash, err := attestation.BatchHeader.Hash()
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(attestation)
+	if err != nil {
+		return fmt.Errorf("failed to marshal attestation: %w", err)
+	}
+
+	// Allow overwrite of existing attestation
+	_, err = s.db.ExecContext(ctx,
+		fmt.Sprintf(`INSERT INTO %s (batch_header_hash, attested_at, attestation) VALUES ($1, $2, $3)
+		ON CONFLICT (batch_header_hash) DO UPDATE SET attested_at = EXCLUDED.attested_at, attestation = EXCLUDED.attestation`,
+			s.attestationsTable()),
+		hex.EncodeToString(batchHeaderHash[:]), int64(attestation.AttestedAt), data)
+	return err
+}
+
+func (s *PostgresBlobMetadataStore) GetAttestation(ctx context.Context, batchHeaderHash [32]byte) (*corev2.Attestation, error) {
+	attestation := &corev2.Attestation{}
+	found, err := s.getRecord(ctx,
+		fmt.Sprintf("SELECT attestation FROM %s WHERE batch_header_hash = $1", s.attestationsTable()),
+		attestation, hex.EncodeToString(batchHeaderHash[:]))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get attestation: %w", err)
+	}
+	if !found {
+		return nil, fmt.Errorf("%w: attestation not found for hash %x", common.ErrMetadataNotFound, batchHeaderHash)
+	}
+	return attestation, nil
+}
+
+// GetAttestationByAttestedAtForward returns attestations within time range (after, before)
+// (both exclusive), retrieved and ordered by AttestedAt timestamp in ascending order.
+//
+// If limit > 0, returns at most that many attestations. If limit <= 0, returns all attestations
+// in the time range.
+func (s *PostgresBlobMetadataStore) GetAttestationByAttestedAtForward(
+	ctx context.Context,
+	after uint64,
+	before uint64,
+	limit int,
+) ([]*corev2.Attestation, error) {
+	return s.queryAttestations(ctx, after, before, limit, "ASC")
+}
+
+// GetAttestationByAttestedAtBackward returns attestations within time range (after, before)
+// (both exclusive), retrieved and ordered by AttestedAt timestamp in descending order.
+//
+// If limit > 0, returns at most that many attestations. If limit <= 0, returns all attestations
+// in the time range.
+func (s *PostgresBlobMetadataStore) GetAttestationByAttestedAtBackward(
+	ctx context.Context,
+	before uint64,
+	after uint64,
+	limit int,
+) ([]*corev2.Attestation, error) {
+	return s.queryAttestations(ctx, after, before, limit, "DESC")
+}
+
+func (s *PostgresBlobMetadataStore) queryAttestations(ctx context.Context, after uint64, before uint64, limit int, order string) ([]*corev2.Attestation, error) {
+	if after+1 > before-1 {
+		return nil, fmt.Errorf("no time point in exclusive time range (%d, %d)", after, before)
+	}
+	// the window is the same as in DynamoDB, where it's made of whole buckets
+	startBucket, endBucket := GetAttestedAtBucketIDRange(after, before)
+	query := fmt.Sprintf(`SELECT attestation FROM %s
+		WHERE attested_at > $1 AND attested_at < $2 AND attested_at >= $3 AND attested_at < $4
+		ORDER BY attested_at %s`, s.attestationsTable(), order)
+	if limit > 0 {
+		query += fmt.Sprintf(" LIMIT %d", limit)
+	}
+	rows, err := s.db.QueryContext(ctx, query,
+		toBigInt(after), toBigInt(before),
+		int64(startBucket*attestedAtBucketSizeNano), bucketEnd(endBucket, attestedAtBucketSizeNano))
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	attestations := make([]*corev2.Attestation, 0)
+	for rows.Next() {
+		var data []byte
+		if err := rows.Scan(&data); err != nil {
+			return nil, err
+		}
+		attestation := &corev2.Attestation{}
+		if err := json.Unmarshal(data, attestation); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal attestation: %w", err)
+		}
+		attestations = append(attestations, attestation)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return attestations, nil
+}
+
+func (s *PostgresBlobMetadataStore) GetSignedBatch(ctx context.Context, batchHeaderHash [32]byte) (*corev2.BatchHeader, *corev2.Attestation, error) {
+	var header, attestation []byte
+	err := s.db.QueryRowContext(ctx,
+		fmt.Sprintf("SELECT h.header, a.attestation FROM %s h LEFT JOIN %s a USING (batch_header_hash) WHERE h.batch_header_hash = $1",
+			s.batchHeadersTable(), s.attestationsTable()),
+		hex.EncodeToString(batchHeaderHash[:])).Scan(&header, &attestation)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil, fmt.Errorf("%w: batch header not found for hash %x", common.ErrMetadataNotFound, batchHeaderHash)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	if attestation == nil {
+		return nil, nil, fmt.Errorf("%w: attestation not found for hash %x", common.ErrMetadataNotFound, batchHeaderHash)
+	}
+
+	batchHeader := &corev2.BatchHeader{}
+	if err := json.Unmarshal(header, batchHeader); err != nil {
+		return nil, nil, fmt.Errorf("failed to unmarshal batch header: %w", err)
+	}
+	signedAttestation := &corev2.Attestation{}
+	if err := json.Unmarshal(attestation, signedAttestation); err != nil {
+		return nil, nil, fmt.Errorf("failed to unmarshal attestation: %w", err)
+	}
+	return batchHeader, signedAttestation, nil
+}
+
+func (s *PostgresBlobMetadataStore) PutBlobInclusionInfo(ctx context.Context, inclusionInfo *corev2.BlobInclusionInfo) error {
+	batchHeaderHash, data, err := marshalInclusionInfo(inclusionInfo)
+	if err != nil {
+		return err
+	}
+	return s.insert(ctx,
+		fmt.Sprintf("INSERT INTO %s (blob_key, batch_header_hash, info) VALUES ($1, $2, $3) ON CONFLICT DO NOTHING", s.inclusionInfosTable()),
+		inclusionInfo.BlobKey.Hex(), batchHeaderHash, data)
+}
+
+// PutBlobInclusionInfos puts multiple inclusion infos into the store in a single transaction. As with the batch writes
+// of DynamoDB, existing inclusion infos are overwritten.
+func (s *PostgresBlobMetadataStore) PutBlobInclusionInfos(ctx context.Context, inclusionInfos []*corev2.BlobInclusionInfo) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	for _, inclusionInfo := range inclusionInfos {
+		batchHeaderHash, data, err := marshalInclusionInfo(inclusionInfo)
+		if err != nil {
+			return err
+		}
+		_, err = tx.ExecContext(ctx,
+			fmt.Sprintf(`INSERT INTO %s (blob_key, batch_header_hash, info) VALUES ($1, $2, $3)
+			ON CONFLICT (blob_key, batch_header_hash) DO UPDATE SET info = EXCLUDED.info`, s.inclusionInfosTable()),
+			inclusionInfo.BlobKey.Hex(), batchHeaderHash, data)
+		if err != nil {
+			return err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+func (s *PostgresBlobMetadataStore) GetBlobInclusionInfo(ctx context.Context, blobKey corev2.BlobKey, batchHeaderHash [32]byte) (*corev2.BlobInclusionInfo, error) {
+	info := &corev2.BlobInclusionInfo{}
+	found, err := s.getRecord(ctx,
+		fmt.Sprintf("SELECT info FROM %s WHERE blob_key = $1 AND batch_header_hash = $2", s.inclusionInfosTable()),
+		info, blobKey.Hex(), hex.EncodeToString(batchHeaderHash[:]))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get inclusion info: %w", err)
+	}
+	if !found {
+		return nil, fmt.Errorf("%w: inclusion info not found for key %s", common.ErrMetadataNotFound, blobKey.Hex())
+	}
+	return info, nil
+}
+
+func (s *PostgresBlobMetadataStore) GetBlobInclusionInfos(ctx context.Context, blobKey corev2.BlobKey) ([]*corev2.BlobInclusionInfo, error) {
+	rows, err := s.db.QueryContext(ctx,
+		fmt.Sprintf("SELECT info FROM %s WHERE blob_key = $1 ORDER BY batch_header_hash", s.inclusionInfosTable()),
+		blobKey.Hex())
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	infos := make([]*corev2.BlobInclusionInfo, 0)
+	for rows.Next() {
+		var data []byte
+		if err := rows.Scan(&data); err != nil {
+			return nil, err
+		}
+		info := &corev2.BlobInclusionInfo{}
+		if err := json.Unmarshal(data, info); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal inclusion info: %w", err)
+		}
+		infos = append(infos, info)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(infos) == 0 {
+		return nil, fmt.Errorf("%w: inclusion info not found for key %s", common.ErrMetadataNotFound, blobKey.Hex())
+	}
+	return infos, nil
+}
+
+func (s *PostgresBlobMetadataStore) GetBlobAttestationInfo(ctx context.Context, blobKey corev2.BlobKey) (*v2.BlobAttestationInfo, error) {
+	blobInclusionInfos, err := s.GetBlobInclusionInfos(ctx, blobKey)
+	if err != nil {
+		s.logger.Error("failed to get blob inclusion info for blob", "err", err, "blobKey", blobKey.Hex())
+		return nil, api.NewErrorInternal(fmt.Sprintf("failed to get blob inclusion info: %s", err.Error()))
+	}
+
+	if len(blobInclusionInfos) > 1 {
+		s.logger.Warn("multiple inclusion info found for blob", "blobKey", blobKey.Hex())
+	}
+
+	for _, inclusionInfo := range blobInclusionInfos {
+		// get the signed batch from this inclusion info
+		batchHeaderHash, err := inclusionInfo.BatchHeader.Hash()
+		if err != nil {
+			s.logger.Error("failed to get batch header hash from blob inclusion info", "err", err, "blobKey", blobKey.Hex())
+			continue
+		}
+		_, attestation, err := s.GetSignedBatch(ctx, batchHeaderHash)
+		if err != nil {
+			s.logger.Error("failed to get signed batch", "err", err, "blobKey", blobKey.Hex())
+			continue
+		}
+
+		return &v2.BlobAttestationInfo{
+			InclusionInfo: inclusionInfo,
+			Attestation:   attestation,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("no attestation info found for blobkey: %s", blobKey.Hex())
+}
+
+// insert runs an INSERT ... ON CONFLICT DO NOTHING statement, and returns common.ErrAlreadyExists if the record
+// already existed
+func (s *PostgresBlobMetadataStore) insert(ctx context.Context, query string, args ...any) error {
+	result, err := s.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	inserted, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if inserted == 0 {
+		return common.ErrAlreadyExists
+	}
+	return nil
+}
+
+// getRecord unmarshals the JSON record selected by the query into record, and returns false if there's no such record
+func (s *PostgresBlobMetadataStore) getRecord(ctx context.Context, query string, record any, args ...any) (bool, error) {
+	var data []byte
+	err := s.db.QueryRowContext(ctx, query, args...).Scan(&data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if err := json.Unmarshal(data, record); err != nil {
+		return false, fmt.Errorf("failed to unmarshal record: %w", err)
+	}
+	return true, nil
+}
+
+func (s *PostgresBlobMetadataStore) queryBlobMetadata(ctx context.Context, query string, args ...any) ([]*v2.BlobMetadata, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	metadata := make([]*v2.BlobMetadata, 0)
+	for rows.Next() {
+		var (
+			blobKey         string
+			blobStatus      int
+			updatedAt       int64
+			purged          bool
+			paymentRefunded bool
+			data            []byte
+		)
+		if err := rows.Scan(&blobKey, &blobStatus, &updatedAt, &purged, &paymentRefunded, &data); err != nil {
+			return nil, err
+		}
+		m, err := unmarshalPostgresBlobMetadata(data, blobStatus, updatedAt, purged, paymentRefunded)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal blob metadata for key %s: %w", blobKey, err)
+		}
+		metadata = append(metadata, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return metadata, nil
+}
+
+// unmarshalPostgresBlobMetadata unmarshals the serialized metadata, with the fields updated in place taken from their
+// columns
+func unmarshalPostgresBlobMetadata(data []byte, blobStatus int, updatedAt int64, purged bool, paymentRefunded bool) (*v2.BlobMetadata, error) {
+	metadata := &v2.BlobMetadata{}
+	if err := json.Unmarshal(data, metadata); err != nil {
+		return nil, err
+	}
+	metadata.BlobStatus = v2.BlobStatus(blobStatus)
+	metadata.UpdatedAt = uint64(updatedAt)
+	metadata.Purged = purged
+	metadata.PaymentRefunded = paymentRefunded
+	return metadata, nil
+}
+
+func marshalInclusionInfo(inclusionInfo *corev2.BlobInclusionInfo) (string, []byte, error) {
+	if inclusionInfo.BatchHeader == nil {
+		return "", nil, errors.New("inclusion info is missing its batch header")
+	}
+	batchHeaderHash, err := inclusionInfo.BatchHeader.Hash()
+	if err != nil {
+		return "", nil, err
+	}
+	data, err := json.Marshal(inclusionInfo)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to marshal blob inclusion info: %w", err)
+	}
+	return hex.EncodeToString(batchHeaderHash[:]), data, nil
+}
+
+// feedCursorBlobKey returns the value of the blob key column for the blob key of a cursor. A nil key is ordered before
+// all the blob keys, as in BlobFeedCursor.LessThan.
+func feedCursorBlobKey(blobKey *corev2.BlobKey) string {
+	if blobKey == nil {
+		return ""
+	}
+	return blobKey.Hex()
+}
+
+// bucketEnd returns the exclusive end of the bucket with the given ID, capped to the range of BIGINT
+func bucketEnd(bucket uint64, bucketSizeNano uint64) int64 {
+	return toBigInt((bucket + 1) * bucketSizeNano)
+}
+
+// toBigInt converts a timestamp to a BIGINT, capping it to the range of BIGINT
+func toBigInt(timestamp uint64) int64 {
+	if timestamp > math.MaxInt64 {
+		return math.MaxInt64
+	}
+	return int64(timestamp)
+}
@@ -0,0 +1,439 @@
+package blobstore_test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/core"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/disperser/common"
+	v2 "github.com/Layr-Labs/eigenda/disperser/common/v2"
+	"github.com/Layr-Labs/eigenda/disperser/common/v2/blobstore"
+	"github.com/Layr-Labs/eigenda/encoding"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+// newPostgresMetadataStore creates a store with its own tables, so that the queries by status and time of the tests
+// don't see the records of each other
+func newPostgresMetadataStore(t *testing.T) *blobstore.PostgresBlobMetadataStore {
+	tablePrefix := fmt.Sprintf("test_%s", uuid.New().String()[:8])
+	store, err := blobstore.NewPostgresBlobMetadataStore(postgresDB, logger, tablePrefix)
+	require.NoError(t, err)
+	require.NoError(t, store.CreateTables(context.Background()))
+	t.Cleanup(func() {
+		for _, suffix := range []string{"blobs", "certificates", "dispersal_requests", "dispersal_responses", "batch_headers", "attestations", "inclusion_infos"} {
+			_, err := postgresDB.Exec(fmt.Sprintf("DROP TABLE %s_%s", tablePrefix, suffix))
+			require.NoError(t, err)
+		}
+	})
+	return store
+}
+
+func newPostgresTestAttestation(t *testing.T, batchHeader *corev2.BatchHeader, attestedAt uint64) *corev2.Attestation {
+	keyPair, err := core.GenRandomBlsKeys()
+	require.NoError(t, err)
+	return &corev2.Attestation{
+		BatchHeader: batchHeader,
+		AttestedAt:  attestedAt,
+		NonSignerPubKeys: []*core.G1Point{
+			core.NewG1Point(big.NewInt(1), big.NewInt(2)),
+		},
+		APKG2: keyPair.GetPubKeyG2(),
+		QuorumAPKs: map[uint8]*core.G1Point{
+			0: core.NewG1Point(big.NewInt(5), big.NewInt(6)),
+		},
+		Sigma: &core.Signature{
+			G1Point: core.NewG1Point(big.NewInt(9), big.NewInt(10)),
+		},
+		QuorumNumbers: []core.QuorumID{0},
+		QuorumResults: map[uint8]uint8{
+			0: 100,
+		},
+	}
+}
+
+func TestNewPostgresBlobMetadataStore(t *testing.T) {
+	_, err := blobstore.NewPostgresBlobMetadataStore(nil, logger, "blob_metadata")
+	require.ErrorContains(t, err, "database is required")
+
+	db := &sql.DB{}
+	for _, tablePrefix := range []string{"", "blob-metadata", "1blob_metadata", "blob_metadata; DROP TABLE blob_metadata"} {
+		_, err = blobstore.NewPostgresBlobMetadataStore(db, logger, tablePrefix)
+		require.ErrorContains(t, err, "invalid table prefix")
+	}
+}
+
+func TestPostgresBlobMetadataStoreOperations(t *testing.T) {
+	ctx := context.Background()
+	store := newPostgresMetadataStore(t)
+	blobKey1, blobHeader1 := newBlob(t)
+	blobKey2, blobHeader2 := newBlob(t)
+	now := time.Now()
+	metadata1 := &v2.BlobMetadata{
+		BlobHeader:  blobHeader1,
+		Signature:   []byte{1, 2, 3},
+		BlobStatus:  v2.Queued,
+		Expiry:      uint64(now.Add(time.Hour).Unix()),
+		BlobSize:    1024,
+		RequestedAt: uint64(now.UnixNano()),
+		UpdatedAt:   uint64(now.UnixNano()),
+		FragmentInfo: &encoding.FragmentInfo{
+			TotalChunkSizeBytes: 100,
+			FragmentSizeBytes:   1024,
+		},
+	}
+	metadata2 := &v2.BlobMetadata{
+		BlobHeader:  blobHeader2,
+		Signature:   []byte{4, 5, 6},
+		BlobStatus:  v2.Complete,
+		Expiry:      uint64(now.Add(time.Hour).Unix()),
+		RequestedAt: uint64(now.UnixNano()),
+		UpdatedAt:   uint64(now.UnixNano()),
+	}
+	require.NoError(t, store.PutBlobMetadata(ctx, metadata1))
+	require.NoError(t, store.PutBlobMetadata(ctx, metadata2))
+	err := store.PutBlobMetadata(ctx, metadata1)
+	require.ErrorIs(t, err, common.ErrAlreadyExists)
+
+	fetched, err := store.GetBlobMetadata(ctx, blobKey1)
+	require.NoError(t, err)
+	require.Equal(t, metadata1, fetched)
+	fetched, err = store.GetBlobMetadata(ctx, blobKey2)
+	require.NoError(t, err)
+	require.Equal(t, metadata2, fetched)
+	_, err = store.GetBlobMetadata(ctx, corev2.BlobKey{1})
+	require.ErrorIs(t, err, common.ErrMetadataNotFound)
+
+	queued, err := store.GetBlobMetadataByStatus(ctx, v2.Queued, 0)
+	require.NoError(t, err)
+	require.Equal(t, []*v2.BlobMetadata{metadata1}, queued)
+	queued, err = store.GetBlobMetadataByStatus(ctx, v2.Queued, metadata1.UpdatedAt+100)
+	require.NoError(t, err)
+	require.Len(t, queued, 0)
+	count, err := store.GetBlobMetadataCountByStatus(ctx, v2.Queued)
+	require.NoError(t, err)
+	require.Equal(t, int32(1), count)
+
+	// the status transitions are checked as in DynamoDB
+	require.NoError(t, store.UpdateBlobStatus(ctx, blobKey1, v2.Encoded))
+	err = store.UpdateBlobStatus(ctx, blobKey1, v2.Encoded)
+	require.ErrorIs(t, err, common.ErrAlreadyExists)
+	err = store.UpdateBlobStatus(ctx, blobKey1, v2.Queued)
+	require.ErrorIs(t, err, blobstore.ErrInvalidStateTransition)
+	err = store.UpdateBlobStatus(ctx, blobKey2, v2.Failed)
+	require.ErrorIs(t, err, blobstore.ErrInvalidStateTransition)
+	fetched, err = store.GetBlobMetadata(ctx, blobKey1)
+	require.NoError(t, err)
+	require.Equal(t, v2.Encoded, fetched.BlobStatus)
+	require.Greater(t, fetched.UpdatedAt, metadata1.UpdatedAt)
+
+	// the flags are set in place, and leave the status unchanged
+	require.NoError(t, store.MarkBlobPurged(ctx, blobKey2))
+	require.NoError(t, store.MarkBlobPaymentRefunded(ctx, blobKey2))
+	fetched, err = store.GetBlobMetadata(ctx, blobKey2)
+	require.NoError(t, err)
+	require.True(t, fetched.Purged)
+	require.True(t, fetched.PaymentRefunded)
+	require.Equal(t, v2.Complete, fetched.BlobStatus)
+	err = store.MarkBlobPurged(ctx, corev2.BlobKey{1})
+	require.ErrorIs(t, err, common.ErrMetadataNotFound)
+
+	require.NoError(t, store.DeleteBlobMetadata(ctx, blobKey1))
+	_, err = store.GetBlobMetadata(ctx, blobKey1)
+	require.ErrorIs(t, err, common.ErrMetadataNotFound)
+}
+
+func TestPostgresBlobMetadataStoreGetBlobMetadataByRequestedAt(t *testing.T) {
+	ctx := context.Background()
+	store := newPostgresMetadataStore(t)
+	now := uint64(time.Now().UnixNano())
+
+	// blobs share their timestamps in pairs, so that the cursor has to order them by blob key
+	numBlobs := 10
+	blobs := make([]*v2.BlobMetadata, numBlobs)
+	for i := 0; i < numBlobs; i++ {
+		_, blobHeader := newBlob(t)
+		blobs[i] = &v2.BlobMetadata{
+			BlobHeader:  blobHeader,
+			BlobStatus:  v2.Queued,
+			RequestedAt: now - uint64(numBlobs-i/2)*uint64(time.Second),
+			UpdatedAt:   now,
+		}
+		require.NoError(t, store.PutBlobMetadata(ctx, blobs[i]))
+	}
+	// a blob older than the window of the feed isn't returned
+	_, oldHeader := newBlob(t)
+	require.NoError(t, store.PutBlobMetadata(ctx, &v2.BlobMetadata{
+		BlobHeader:  oldHeader,
+		BlobStatus:  v2.Queued,
+		RequestedAt: now - uint64(30*24*time.Hour),
+	}))
+
+	start := blobstore.BlobFeedCursor{RequestedAt: 0}
+	end := blobstore.BlobFeedCursor{RequestedAt: now + 1}
+
+	all, lastCursor, err := store.GetBlobMetadataByRequestedAtForward(ctx, start, end, -1)
+	require.NoError(t, err)
+	require.Len(t, all, numBlobs)
+	for i := 1; i < numBlobs; i++ {
+		previous := blobstore.BlobFeedCursor{RequestedAt: all[i-1].RequestedAt, BlobKey: blobKeyOf(t, all[i-1])}
+		current := blobstore.BlobFeedCursor{RequestedAt: all[i].RequestedAt, BlobKey: blobKeyOf(t, all[i])}
+		require.True(t, previous.LessThan(&current))
+	}
+	require.Equal(t, all[numBlobs-1].RequestedAt, lastCursor.RequestedAt)
+
+	// paging with the last cursor visits all the blobs once, including those sharing a timestamp
+	cursor := start
+	paged := make([]*v2.BlobMetadata, 0, numBlobs)
+	for {
+		page, next, err := store.GetBlobMetadataByRequestedAtForward(ctx, cursor, end, 3)
+		require.NoError(t, err)
+		if next == nil {
+			require.Len(t, page, 0)
+			break
+		}
+		paged = append(paged, page...)
+		cursor = *next
+	}
+	require.Equal(t, all, paged)
+
+	backward, _, err := store.GetBlobMetadataByRequestedAtBackward(ctx, end, start, 4)
+	require.NoError(t, err)
+	require.Len(t, backward, 4)
+	for i, metadata := range backward {
+		require.Equal(t, all[numBlobs-1-i], metadata)
+	}
+
+	_, _, err = store.GetBlobMetadataByRequestedAtForward(ctx, end, start, 0)
+	require.Error(t, err)
+}
+
+func TestPostgresBlobMetadataStoreGetBlobMetadataByStatusPaginated(t *testing.T) {
+	ctx := context.Background()
+	store := newPostgresMetadataStore(t)
+	numBlobs := 23
+	pageSize := 10
+	now := uint64(time.Now().UnixNano())
+	for i := 0; i < numBlobs; i++ {
+		_, blobHeader := newBlob(t)
+		require.NoError(t, store.PutBlobMetadata(ctx, &v2.BlobMetadata{
+			BlobHeader: blobHeader,
+			BlobStatus: v2.Encoded,
+			UpdatedAt:  now + uint64(i),
+		}))
+	}
+
+	// no results return the given cursor
+	cursor := &blobstore.StatusIndexCursor{}
+	metadata, newCursor, err := store.GetBlobMetadataByStatusPaginated(ctx, v2.Queued, cursor, int32(pageSize))
+	require.NoError(t, err)
+	require.Len(t, metadata, 0)
+	require.Equal(t, cursor, newCursor)
+
+	// full pages return the cursor of their last blob, and the last partial page returns no cursor
+	for i := 0; i < numBlobs/pageSize; i++ {
+		metadata, cursor, err = store.GetBlobMetadataByStatusPaginated(ctx, v2.Encoded, cursor, int32(pageSize))
+		require.NoError(t, err)
+		require.Len(t, metadata, pageSize)
+		require.NotNil(t, cursor)
+		require.Equal(t, metadata[pageSize-1].UpdatedAt, cursor.UpdatedAt)
+		require.Equal(t, blobKeyOf(t, metadata[pageSize-1]), cursor.BlobKey)
+	}
+	metadata, cursor, err = store.GetBlobMetadataByStatusPaginated(ctx, v2.Encoded, cursor, int32(pageSize))
+	require.NoError(t, err)
+	require.Len(t, metadata, numBlobs%pageSize)
+	require.Nil(t, cursor)
+}
+
+func TestPostgresBlobMetadataStoreCertsAndDispersals(t *testing.T) {
+	ctx := context.Background()
+	store := newPostgresMetadataStore(t)
+
+	blobKey, blobHeader := newBlob(t)
+	blobCert := &corev2.BlobCertificate{
+		BlobHeader: blobHeader,
+		Signature:  []byte("signature"),
+		RelayKeys:  []corev2.RelayKey{0, 2, 4},
+	}
+	fragmentInfo := &encoding.FragmentInfo{
+		TotalChunkSizeBytes: 100,
+		FragmentSizeBytes:   1024 * 1024 * 4,
+	}
+	require.NoError(t, store.PutBlobCertificate(ctx, blobCert, fragmentInfo))
+	err := store.PutBlobCertificate(ctx, blobCert, fragmentInfo)
+	require.ErrorIs(t, err, common.ErrAlreadyExists)
+
+	fetchedCert, fetchedFragmentInfo, err := store.GetBlobCertificate(ctx, blobKey)
+	require.NoError(t, err)
+	require.Equal(t, blobCert, fetchedCert)
+	require.Equal(t, fragmentInfo, fetchedFragmentInfo)
+	certs, fragmentInfos, err := store.GetBlobCertificates(ctx, []corev2.BlobKey{blobKey, {1}})
+	require.NoError(t, err)
+	require.Equal(t, []*corev2.BlobCertificate{blobCert}, certs)
+	require.Equal(t, []*encoding.FragmentInfo{fragmentInfo}, fragmentInfos)
+
+	require.NoError(t, store.DeleteBlobCertificate(ctx, blobKey))
+	_, _, err = store.GetBlobCertificate(ctx, blobKey)
+	require.ErrorIs(t, err, common.ErrMetadataNotFound)
+
+	dispersalRequest := &corev2.DispersalRequest{
+		OperatorID:      core.OperatorID{0, 1},
+		OperatorAddress: gethcommon.HexToAddress("0x1234567"),
+		Socket:          "socket",
+		DispersedAt:     uint64(time.Now().UnixNano()),
+		BatchHeader: corev2.BatchHeader{
+			BatchRoot:            [32]byte{1, 2, 3},
+			ReferenceBlockNumber: 100,
+		},
+	}
+	bhh, err := dispersalRequest.BatchHeader.Hash()
+	require.NoError(t, err)
+	require.NoError(t, store.PutDispersalRequest(ctx, dispersalRequest))
+	err = store.PutDispersalRequest(ctx, dispersalRequest)
+	require.ErrorIs(t, err, common.ErrAlreadyExists)
+	fetchedRequest, err := store.GetDispersalRequest(ctx, bhh, dispersalRequest.OperatorID)
+	require.NoError(t, err)
+	require.Equal(t, dispersalRequest, fetchedRequest)
+
+	_, err = store.GetDispersalResponses(ctx, bhh)
+	require.ErrorIs(t, err, common.ErrMetadataNotFound)
+	dispersalResponse := &corev2.DispersalResponse{
+		DispersalRequest: dispersalRequest,
+		RespondedAt:      uint64(time.Now().UnixNano()),
+		Signature:        [32]byte{1, 1, 1},
+		Error:            "error",
+	}
+	require.NoError(t, store.PutDispersalResponse(ctx, dispersalResponse))
+	err = store.PutDispersalResponse(ctx, dispersalResponse)
+	require.ErrorIs(t, err, common.ErrAlreadyExists)
+	fetchedResponse, err := store.GetDispersalResponse(ctx, bhh, dispersalRequest.OperatorID)
+	require.NoError(t, err)
+	require.Equal(t, dispersalResponse, fetchedResponse)
+	responses, err := store.GetDispersalResponses(ctx, bhh)
+	require.NoError(t, err)
+	require.Equal(t, []*corev2.DispersalResponse{dispersalResponse}, responses)
+	_, err = store.GetDispersalResponse(ctx, bhh, core.OperatorID{2, 3})
+	require.ErrorIs(t, err, common.ErrMetadataNotFound)
+}
+
+func TestPostgresBlobMetadataStoreBatches(t *testing.T) {
+	ctx := context.Background()
+	store := newPostgresMetadataStore(t)
+	now := uint64(time.Now().UnixNano())
+
+	batchHeader := &corev2.BatchHeader{
+		BatchRoot:            [32]byte{1, 2, 3},
+		ReferenceBlockNumber: 100,
+	}
+	bhh, err := batchHeader.Hash()
+	require.NoError(t, err)
+	require.NoError(t, store.PutBatchHeader(ctx, batchHeader))
+	err = store.PutBatchHeader(ctx, batchHeader)
+	require.ErrorIs(t, err, common.ErrAlreadyExists)
+	fetchedHeader, err := store.GetBatchHeader(ctx, bhh)
+	require.NoError(t, err)
+	require.Equal(t, batchHeader, fetchedHeader)
+
+	blobKey := corev2.BlobKey{1, 1, 1}
+	inclusionInfo := &corev2.BlobInclusionInfo{
+		BatchHeader:    batchHeader,
+		BlobKey:        blobKey,
+		BlobIndex:      10,
+		InclusionProof: []byte("proof"),
+	}
+	require.NoError(t, store.PutBlobInclusionInfo(ctx, inclusionInfo))
+	err = store.PutBlobInclusionInfo(ctx, inclusionInfo)
+	require.ErrorIs(t, err, common.ErrAlreadyExists)
+	fetchedInfo, err := store.GetBlobInclusionInfo(ctx, blobKey, bhh)
+	require.NoError(t, err)
+	require.Equal(t, inclusionInfo, fetchedInfo)
+
+	// the batch isn't signed yet
+	_, _, err = store.GetSignedBatch(ctx, bhh)
+	require.ErrorIs(t, err, common.ErrMetadataNotFound)
+	_, err = store.GetBlobAttestationInfo(ctx, blobKey)
+	require.ErrorContains(t, err, "no attestation info found")
+
+	attestation := newPostgresTestAttestation(t, batchHeader, now)
+	require.NoError(t, store.PutAttestation(ctx, attestation))
+	fetchedAttestation, err := store.GetAttestation(ctx, bhh)
+	require.NoError(t, err)
+	require.Equal(t, attestation, fetchedAttestation)
+
+	// attestations are overwritten
+	updatedAttestation := newPostgresTestAttestation(t, batchHeader, now+1)
+	require.NoError(t, store.PutAttestation(ctx, updatedAttestation))
+	fetchedHeader, fetchedAttestation, err = store.GetSignedBatch(ctx, bhh)
+	require.NoError(t, err)
+	require.Equal(t, batchHeader, fetchedHeader)
+	require.Equal(t, updatedAttestation, fetchedAttestation)
+
+	attestationInfo, err := store.GetBlobAttestationInfo(ctx, blobKey)
+	require.NoError(t, err)
+	require.Equal(t, inclusionInfo, attestationInfo.InclusionInfo)
+	require.Equal(t, updatedAttestation, attestationInfo.Attestation)
+
+	// inclusion infos put together are overwritten
+	inclusionInfo1 := &corev2.BlobInclusionInfo{
+		BatchHeader:    batchHeader,
+		BlobKey:        corev2.BlobKey{2, 2, 2},
+		BlobIndex:      12,
+		InclusionProof: []byte("proof 1"),
+	}
+	updatedInclusionInfo := *inclusionInfo
+	updatedInclusionInfo.InclusionProof = []byte("updated proof")
+	require.NoError(t, store.PutBlobInclusionInfos(ctx, []*corev2.BlobInclusionInfo{&updatedInclusionInfo, inclusionInfo1}))
+	infos, err := store.GetBlobInclusionInfos(ctx, blobKey)
+	require.NoError(t, err)
+	require.Equal(t, []*corev2.BlobInclusionInfo{&updatedInclusionInfo}, infos)
+
+	require.NoError(t, store.DeleteBatchHeader(ctx, bhh))
+	_, err = store.GetBatchHeader(ctx, bhh)
+	require.ErrorIs(t, err, common.ErrMetadataNotFound)
+}
+
+func TestPostgresBlobMetadataStoreGetAttestationByAttestedAt(t *testing.T) {
+	ctx := context.Background()
+	store := newPostgresMetadataStore(t)
+	now := uint64(time.Now().UnixNano())
+
+	numBatches := 5
+	for i := 0; i < numBatches; i++ {
+		batchHeader := &corev2.BatchHeader{
+			BatchRoot:            [32]byte{byte(i)},
+			ReferenceBlockNumber: 100,
+		}
+		require.NoError(t, store.PutAttestation(ctx, newPostgresTestAttestation(t, batchHeader, now-uint64(numBatches-i)*uint64(time.Minute))))
+	}
+
+	forward, err := store.GetAttestationByAttestedAtForward(ctx, 0, now, -1)
+	require.NoError(t, err)
+	require.Len(t, forward, numBatches)
+	checkAttestationsOrdered(t, forward)
+
+	forward, err = store.GetAttestationByAttestedAtForward(ctx, forward[0].AttestedAt, now, 2)
+	require.NoError(t, err)
+	require.Len(t, forward, 2)
+	require.Equal(t, now-uint64(numBatches-1)*uint64(time.Minute), forward[0].AttestedAt)
+
+	backward, err := store.GetAttestationByAttestedAtBackward(ctx, now, 0, 3)
+	require.NoError(t, err)
+	require.Len(t, backward, 3)
+	checkAttestationsOrderedDesc(t, backward)
+	require.Equal(t, now-uint64(time.Minute), backward[0].AttestedAt)
+
+	_, err = store.GetAttestationByAttestedAtForward(ctx, now, now+1, 0)
+	require.Error(t, err)
+}
+
+func blobKeyOf(t *testing.T, metadata *v2.BlobMetadata) *corev2.BlobKey {
+	blobKey, err := metadata.BlobHeader.BlobKey()
+	require.NoError(t, err)
+	return &blobKey
+}
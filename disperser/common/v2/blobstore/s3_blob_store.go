@@ -3,6 +3,7 @@ package blobstore
 import (
 	"context"
 
+	"github.com/Layr-Labs/eigenda/common/aws/envelope"
 	"github.com/Layr-Labs/eigenda/common/aws/s3"
 	corev2 "github.com/Layr-Labs/eigenda/core/v2"
 	"github.com/Layr-Labs/eigenda/disperser/common"
@@ -14,13 +15,16 @@ type BlobStore struct {
 	bucketName string
 	s3Client   s3.Client
 	logger     logging.Logger
+	// encryptor encrypts blobs at rest. If nil, blobs are stored in plaintext.
+	encryptor *envelope.Encryptor
 }
 
-func NewBlobStore(s3BucketName string, s3Client s3.Client, logger logging.Logger) *BlobStore {
+func NewBlobStore(s3BucketName string, s3Client s3.Client, encryptor *envelope.Encryptor, logger logging.Logger) *BlobStore {
 	return &BlobStore{
 		bucketName: s3BucketName,
 		s3Client:   s3Client,
 		logger:     logger,
+		encryptor:  encryptor,
 	}
 }
 
@@ -32,6 +36,12 @@ func (b *BlobStore) StoreBlob(ctx context.Context, key corev2.BlobKey, data []by
 		return common.ErrAlreadyExists
 	}
 
+	data, err = b.encryptor.Encrypt(ctx, data)
+	if err != nil {
+		b.logger.Errorf("failed to encrypt blob %s: %v", key, err)
+		return err
+	}
+
 	err = b.s3Client.UploadObject(ctx, b.bucketName, s3.ScopedBlobKey(key), data)
 	if err != nil {
 		b.logger.Errorf("failed to upload blob in bucket %s: %v", b.bucketName, err)
@@ -52,5 +62,20 @@ func (b *BlobStore) GetBlob(ctx context.Context, key corev2.BlobKey) ([]byte, er
 		b.logger.Errorf("failed to download blob from bucket %s: %v", b.bucketName, err)
 		return nil, err
 	}
+
+	data, err = b.encryptor.Decrypt(ctx, data)
+	if err != nil {
+		b.logger.Errorf("failed to decrypt blob %s: %v", key, err)
+		return nil, err
+	}
 	return data, nil
 }
+
+// CheckHealth returns an error if the bucket of the blob store can't be reached
+func (b *BlobStore) CheckHealth(ctx context.Context) error {
+	_, err := b.s3Client.HeadObject(ctx, b.bucketName, s3.ScopedBlobKey(corev2.BlobKey{}))
+	if err != nil && !errors.Is(err, s3.ErrObjectNotFound) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,255 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	pb "github.com/Layr-Labs/eigenda/api/grpc/disperser/v2"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/core/meterer"
+	"github.com/Layr-Labs/eigenda/disperser/common/admin"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// DivergenceMissedDeposit is an account which was charged on-demand payments but has no deposit on chain
+	DivergenceMissedDeposit = "missed_deposit"
+	// DivergenceOverCharge is an account whose cumulative on-demand payment exceeds its deposit on chain
+	DivergenceOverCharge = "over_charge"
+	// DivergenceInactiveReservationUsage is an account whose reservation usage was recorded in a period in which it
+	// has no active reservation on chain
+	DivergenceInactiveReservationUsage = "inactive_reservation_usage"
+)
+
+// AccountingStore is the off-chain record of the payments of accounts. It is implemented by meterer.OffchainStore.
+type AccountingStore interface {
+	GetAccountIDs(ctx context.Context) ([]string, error)
+	GetLargestCumulativePayment(ctx context.Context, accountID string) (*big.Int, error)
+	GetPeriodRecords(ctx context.Context, accountID string, reservationPeriod uint64) ([meterer.MinNumBins]*pb.PeriodRecord, error)
+}
+
+// AccountingChainState reads the deposits and reservations of accounts from the chain. It is implemented by eth.Reader.
+type AccountingChainState interface {
+	GetCurrentBlockNumber(ctx context.Context) (uint32, error)
+	GetReservationWindow(ctx context.Context, blockNumber uint32) (uint64, error)
+	GetReservedPayments(ctx context.Context, accountIDs []gethcommon.Address) (map[gethcommon.Address]*core.ReservedPayment, error)
+	GetOnDemandPayments(ctx context.Context, accountIDs []gethcommon.Address) (map[gethcommon.Address]*core.OnDemandPayment, error)
+}
+
+type AccountingReconcilerConfig struct {
+	// Interval is how often the off-chain accounting is reconciled with the chain
+	Interval time.Duration
+	// Timeout bounds the time spent on a single reconciliation
+	Timeout time.Duration
+}
+
+// AccountingReconciler periodically compares the payments recorded by the meterer in the off-chain store against
+// the deposits and reservations of the accounts on chain, and flags the accounts whose accounting diverges. It only
+// reports divergences, through metrics and the admin API; settling them is left to the operator.
+type AccountingReconciler struct {
+	*AccountingReconcilerConfig
+
+	store      AccountingStore
+	chainState AccountingChainState
+	logger     logging.Logger
+
+	mu sync.Mutex
+	// reconciledAt is the time the last reconciliation completed
+	reconciledAt time.Time
+	// divergences are the divergences found by the last reconciliation
+	divergences []admin.AccountingDivergence
+
+	metrics *accountingReconcilerMetrics
+}
+
+var _ admin.AccountingReporter = (*AccountingReconciler)(nil)
+
+func NewAccountingReconciler(
+	config *AccountingReconcilerConfig,
+	store AccountingStore,
+	chainState AccountingChainState,
+	logger logging.Logger,
+	registry *prometheus.Registry,
+) (*AccountingReconciler, error) {
+	if config.Interval <= 0 || config.Timeout <= 0 {
+		return nil, fmt.Errorf("invalid accounting reconciler config")
+	}
+	return &AccountingReconciler{
+		AccountingReconcilerConfig: config,
+		store:                      store,
+		chainState:                 chainState,
+		logger:                     logger.With("component", "AccountingReconciler"),
+		metrics:                    newAccountingReconcilerMetrics(registry),
+	}, nil
+}
+
+func (r *AccountingReconciler) Start(ctx context.Context) error {
+	go func() {
+		ticker := time.NewTicker(r.Interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				reconcileCtx, cancel := context.WithTimeout(ctx, r.Timeout)
+				if err := r.Reconcile(reconcileCtx); err != nil {
+					r.logger.Error("failed to reconcile off-chain accounting", "err", err)
+					r.metrics.reportFailedReconciliation()
+				}
+				cancel()
+			}
+		}
+	}()
+
+	return nil
+}
+
+// Reconcile compares the off-chain accounting of all accounts with their on-chain state, and replaces the reported
+// divergences with the ones found
+func (r *AccountingReconciler) Reconcile(ctx context.Context) error {
+	accountIDs, err := r.store.GetAccountIDs(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get account IDs: %w", err)
+	}
+	accounts := make([]gethcommon.Address, len(accountIDs))
+	for i, accountID := range accountIDs {
+		accounts[i] = gethcommon.HexToAddress(accountID)
+	}
+
+	blockNumber, err := r.chainState.GetCurrentBlockNumber(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get current block number: %w", err)
+	}
+	reservationWindow, err := r.chainState.GetReservationWindow(ctx, blockNumber)
+	if err != nil {
+		return fmt.Errorf("failed to get reservation window: %w", err)
+	}
+	reservations, err := r.chainState.GetReservedPayments(ctx, accounts)
+	if err != nil {
+		return fmt.Errorf("failed to get reservations: %w", err)
+	}
+	deposits, err := r.chainState.GetOnDemandPayments(ctx, accounts)
+	if err != nil {
+		return fmt.Errorf("failed to get on-demand deposits: %w", err)
+	}
+
+	currentPeriod := meterer.GetReservationPeriod(time.Now().Unix(), reservationWindow)
+	divergences := make([]admin.AccountingDivergence, 0)
+	for i, accountID := range accountIDs {
+		onDemandDivergence, err := r.reconcileOnDemand(ctx, accountID, deposits[accounts[i]])
+		if err != nil {
+			return err
+		}
+		if onDemandDivergence != nil {
+			divergences = append(divergences, *onDemandDivergence)
+		}
+
+		reservationDivergence, err := r.reconcileReservation(ctx, accountID, reservations[accounts[i]], currentPeriod, reservationWindow)
+		if err != nil {
+			return err
+		}
+		if reservationDivergence != nil {
+			divergences = append(divergences, *reservationDivergence)
+		}
+	}
+
+	for _, divergence := range divergences {
+		r.logger.Warn("off-chain accounting diverges from the chain",
+			"accountID", divergence.AccountID,
+			"kind", divergence.Kind,
+			"offchainValue", divergence.OffchainValue,
+			"onchainValue", divergence.OnchainValue)
+	}
+
+	r.mu.Lock()
+	r.reconciledAt = time.Now()
+	r.divergences = divergences
+	r.mu.Unlock()
+	r.metrics.reportReconciliation(len(accountIDs), divergences)
+	return nil
+}
+
+// reconcileOnDemand compares the cumulative payment charged to the account with its on-chain deposit, which is nil
+// if the account has none
+func (r *AccountingReconciler) reconcileOnDemand(ctx context.Context, accountID string, deposit *core.OnDemandPayment) (*admin.AccountingDivergence, error) {
+	cumulativePayment, err := r.store.GetLargestCumulativePayment(ctx, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cumulative payment of account %s: %w", accountID, err)
+	}
+	if cumulativePayment.Sign() == 0 {
+		return nil, nil
+	}
+
+	if deposit == nil || deposit.CumulativePayment == nil {
+		return &admin.AccountingDivergence{
+			AccountID:     accountID,
+			Kind:          DivergenceMissedDeposit,
+			OffchainValue: cumulativePayment,
+			OnchainValue:  big.NewInt(0),
+		}, nil
+	}
+	if cumulativePayment.Cmp(deposit.CumulativePayment) > 0 {
+		return &admin.AccountingDivergence{
+			AccountID:     accountID,
+			Kind:          DivergenceOverCharge,
+			OffchainValue: cumulativePayment,
+			OnchainValue:  deposit.CumulativePayment,
+		}, nil
+	}
+	return nil, nil
+}
+
+// reconcileReservation checks that the reservation usage recorded for the account around the current period falls
+// within the periods of its on-chain reservation, which is nil if the account has none. Usage is recorded up to two
+// periods ahead of the current one when a bin overflows.
+func (r *AccountingReconciler) reconcileReservation(
+	ctx context.Context,
+	accountID string,
+	reservation *core.ReservedPayment,
+	currentPeriod uint64,
+	reservationWindow uint64,
+) (*admin.AccountingDivergence, error) {
+	// GetPeriodRecords returns the records of the periods after the one given, so this covers the previous period
+	// onwards
+	records, err := r.store.GetPeriodRecords(ctx, accountID, max(currentPeriod, 2)-2)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reservation usage of account %s: %w", accountID, err)
+	}
+
+	for _, record := range records {
+		if record == nil || record.GetUsage() == 0 {
+			continue
+		}
+		period := uint64(record.GetIndex())
+		if reservation != nil &&
+			period >= meterer.GetReservationPeriod(int64(reservation.StartTimestamp), reservationWindow) &&
+			period <= meterer.GetReservationPeriod(int64(reservation.EndTimestamp), reservationWindow) {
+			continue
+		}
+
+		symbolsPerSecond := uint64(0)
+		if reservation != nil {
+			symbolsPerSecond = reservation.SymbolsPerSecond
+		}
+		return &admin.AccountingDivergence{
+			AccountID:     accountID,
+			Kind:          DivergenceInactiveReservationUsage,
+			OffchainValue: new(big.Int).SetUint64(record.GetUsage()),
+			OnchainValue:  new(big.Int).SetUint64(symbolsPerSecond),
+		}, nil
+	}
+	return nil, nil
+}
+
+// AccountingDivergences returns the time of the last reconciliation, and the divergences it found
+func (r *AccountingReconciler) AccountingDivergences() (time.Time, []admin.AccountingDivergence) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.reconciledAt, r.divergences
+}
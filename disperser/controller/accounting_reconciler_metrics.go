@@ -0,0 +1,85 @@
+package controller
+
+import (
+	"time"
+
+	"github.com/Layr-Labs/eigenda/disperser/common/admin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const accountingReconcilerNamespace = "eigenda_accounting_reconciler"
+
+// accountingReconcilerMetrics is a struct that holds the metrics for the accounting reconciler.
+type accountingReconcilerMetrics struct {
+	divergentAccounts          *prometheus.GaugeVec
+	reconciledAccounts         *prometheus.GaugeVec
+	lastReconciliationTime     *prometheus.GaugeVec
+	failedReconciliationsCount *prometheus.CounterVec
+}
+
+// newAccountingReconcilerMetrics sets up metrics for the accounting reconciler.
+func newAccountingReconcilerMetrics(registry *prometheus.Registry) *accountingReconcilerMetrics {
+	divergentAccounts := promauto.With(registry).NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: accountingReconcilerNamespace,
+			Name:      "divergent_accounts",
+			Help:      "The number of accounts whose off-chain accounting diverged from the chain at the last reconciliation.",
+		},
+		[]string{"kind"},
+	)
+
+	reconciledAccounts := promauto.With(registry).NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: accountingReconcilerNamespace,
+			Name:      "reconciled_accounts",
+			Help:      "The number of accounts checked by the last reconciliation.",
+		},
+		[]string{},
+	)
+
+	lastReconciliationTime := promauto.With(registry).NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: accountingReconcilerNamespace,
+			Name:      "last_reconciliation_timestamp_seconds",
+			Help:      "The UNIX timestamp of the last completed reconciliation.",
+		},
+		[]string{},
+	)
+
+	failedReconciliationsCount := promauto.With(registry).NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: accountingReconcilerNamespace,
+			Name:      "failed_reconciliations_total",
+			Help:      "The number of reconciliations which failed.",
+		},
+		[]string{},
+	)
+
+	return &accountingReconcilerMetrics{
+		divergentAccounts:          divergentAccounts,
+		reconciledAccounts:         reconciledAccounts,
+		lastReconciliationTime:     lastReconciliationTime,
+		failedReconciliationsCount: failedReconciliationsCount,
+	}
+}
+
+func (m *accountingReconcilerMetrics) reportReconciliation(numAccounts int, divergences []admin.AccountingDivergence) {
+	counts := map[string]int{
+		DivergenceMissedDeposit:            0,
+		DivergenceOverCharge:               0,
+		DivergenceInactiveReservationUsage: 0,
+	}
+	for _, divergence := range divergences {
+		counts[divergence.Kind]++
+	}
+	for kind, count := range counts {
+		m.divergentAccounts.WithLabelValues(kind).Set(float64(count))
+	}
+	m.reconciledAccounts.WithLabelValues().Set(float64(numAccounts))
+	m.lastReconciliationTime.WithLabelValues().Set(float64(time.Now().Unix()))
+}
+
+func (m *accountingReconcilerMetrics) reportFailedReconciliation() {
+	m.failedReconciliationsCount.WithLabelValues().Inc()
+}
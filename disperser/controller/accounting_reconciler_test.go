@@ -0,0 +1,168 @@
+package controller_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	pb "github.com/Layr-Labs/eigenda/api/grpc/disperser/v2"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/core/meterer"
+	"github.com/Layr-Labs/eigenda/disperser/common/admin"
+	"github.com/Layr-Labs/eigenda/disperser/controller"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+)
+
+const testReservationWindow = 60
+
+type accountingStore struct {
+	cumulativePayments map[string]*big.Int
+	// periodUsage is the reservation usage of each account, by period
+	periodUsage map[string]map[uint64]uint64
+}
+
+func (s *accountingStore) GetAccountIDs(ctx context.Context) ([]string, error) {
+	accountIDs := make([]string, 0)
+	seen := make(map[string]bool)
+	for accountID := range s.cumulativePayments {
+		seen[accountID] = true
+		accountIDs = append(accountIDs, accountID)
+	}
+	for accountID := range s.periodUsage {
+		if !seen[accountID] {
+			accountIDs = append(accountIDs, accountID)
+		}
+	}
+	return accountIDs, nil
+}
+
+func (s *accountingStore) GetLargestCumulativePayment(ctx context.Context, accountID string) (*big.Int, error) {
+	if payment, ok := s.cumulativePayments[accountID]; ok {
+		return payment, nil
+	}
+	return big.NewInt(0), nil
+}
+
+func (s *accountingStore) GetPeriodRecords(ctx context.Context, accountID string, reservationPeriod uint64) ([meterer.MinNumBins]*pb.PeriodRecord, error) {
+	records := [meterer.MinNumBins]*pb.PeriodRecord{}
+	for i := range records {
+		period := reservationPeriod + 1 + uint64(i)
+		if usage, ok := s.periodUsage[accountID][period]; ok {
+			records[i] = &pb.PeriodRecord{Index: uint32(period), Usage: usage}
+		}
+	}
+	return records, nil
+}
+
+type accountingChainState struct {
+	reservations map[gethcommon.Address]*core.ReservedPayment
+	deposits     map[gethcommon.Address]*core.OnDemandPayment
+}
+
+func (s *accountingChainState) GetCurrentBlockNumber(ctx context.Context) (uint32, error) {
+	return 100, nil
+}
+
+func (s *accountingChainState) GetReservationWindow(ctx context.Context, blockNumber uint32) (uint64, error) {
+	return testReservationWindow, nil
+}
+
+func (s *accountingChainState) GetReservedPayments(ctx context.Context, accountIDs []gethcommon.Address) (map[gethcommon.Address]*core.ReservedPayment, error) {
+	return s.reservations, nil
+}
+
+func (s *accountingChainState) GetOnDemandPayments(ctx context.Context, accountIDs []gethcommon.Address) (map[gethcommon.Address]*core.OnDemandPayment, error) {
+	return s.deposits, nil
+}
+
+func TestAccountingReconciler(t *testing.T) {
+	ctx := context.Background()
+	now := uint64(time.Now().Unix())
+	currentPeriod := meterer.GetReservationPeriod(int64(now), testReservationWindow)
+
+	healthy := "0x0000000000000000000000000000000000000001"
+	overCharged := "0x0000000000000000000000000000000000000002"
+	missedDeposit := "0x0000000000000000000000000000000000000003"
+	expired := "0x0000000000000000000000000000000000000004"
+	noReservation := "0x0000000000000000000000000000000000000005"
+
+	activeReservation := &core.ReservedPayment{
+		SymbolsPerSecond: 10,
+		StartTimestamp:   now - 3600,
+		EndTimestamp:     now + 3600,
+	}
+	store := &accountingStore{
+		cumulativePayments: map[string]*big.Int{
+			healthy:       big.NewInt(100),
+			overCharged:   big.NewInt(300),
+			missedDeposit: big.NewInt(50),
+		},
+		periodUsage: map[string]map[uint64]uint64{
+			// overflow usage two periods ahead is within the reservation
+			healthy:       {currentPeriod: 500, currentPeriod + 2: 100},
+			expired:       {currentPeriod: 500},
+			noReservation: {currentPeriod - 1: 20},
+		},
+	}
+	chainState := &accountingChainState{
+		reservations: map[gethcommon.Address]*core.ReservedPayment{
+			gethcommon.HexToAddress(healthy): activeReservation,
+			gethcommon.HexToAddress(expired): {
+				SymbolsPerSecond: 10,
+				StartTimestamp:   now - 7200,
+				EndTimestamp:     now - 3600,
+			},
+		},
+		deposits: map[gethcommon.Address]*core.OnDemandPayment{
+			gethcommon.HexToAddress(healthy):     {CumulativePayment: big.NewInt(100)},
+			gethcommon.HexToAddress(overCharged): {CumulativePayment: big.NewInt(200)},
+		},
+	}
+
+	reconciler, err := controller.NewAccountingReconciler(&controller.AccountingReconcilerConfig{
+		Interval: time.Minute,
+		Timeout:  time.Second,
+	}, store, chainState, logger, prometheus.NewRegistry())
+	require.NoError(t, err)
+
+	reconciledAt, divergences := reconciler.AccountingDivergences()
+	require.True(t, reconciledAt.IsZero())
+	require.Empty(t, divergences)
+
+	require.NoError(t, reconciler.Reconcile(ctx))
+	reconciledAt, divergences = reconciler.AccountingDivergences()
+	require.False(t, reconciledAt.IsZero())
+
+	byAccount := make(map[string]admin.AccountingDivergence)
+	for _, divergence := range divergences {
+		byAccount[divergence.AccountID] = divergence
+	}
+	require.Len(t, byAccount, 4)
+	require.NotContains(t, byAccount, healthy)
+
+	require.Equal(t, controller.DivergenceOverCharge, byAccount[overCharged].Kind)
+	require.Equal(t, big.NewInt(300), byAccount[overCharged].OffchainValue)
+	require.Equal(t, big.NewInt(200), byAccount[overCharged].OnchainValue)
+
+	require.Equal(t, controller.DivergenceMissedDeposit, byAccount[missedDeposit].Kind)
+	require.Equal(t, big.NewInt(50), byAccount[missedDeposit].OffchainValue)
+
+	require.Equal(t, controller.DivergenceInactiveReservationUsage, byAccount[expired].Kind)
+	require.Equal(t, big.NewInt(500), byAccount[expired].OffchainValue)
+	require.Equal(t, big.NewInt(10), byAccount[expired].OnchainValue)
+
+	require.Equal(t, controller.DivergenceInactiveReservationUsage, byAccount[noReservation].Kind)
+	require.Equal(t, big.NewInt(20), byAccount[noReservation].OffchainValue)
+
+	// divergences which are settled are no longer reported
+	chainState.deposits[gethcommon.HexToAddress(overCharged)] = &core.OnDemandPayment{CumulativePayment: big.NewInt(300)}
+	chainState.deposits[gethcommon.HexToAddress(missedDeposit)] = &core.OnDemandPayment{CumulativePayment: big.NewInt(50)}
+	delete(store.periodUsage, expired)
+	delete(store.periodUsage, noReservation)
+	require.NoError(t, reconciler.Reconcile(ctx))
+	_, divergences = reconciler.AccountingDivergences()
+	require.Empty(t, divergences)
+}
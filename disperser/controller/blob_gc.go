@@ -0,0 +1,176 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common/aws/s3"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	v2 "github.com/Layr-Labs/eigenda/disperser/common/v2"
+	"github.com/Layr-Labs/eigenda/disperser/common/v2/blobstore"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/time/rate"
+)
+
+type BlobGarbageCollectorConfig struct {
+	// PullInterval is how often expired blobs are looked up
+	PullInterval time.Duration
+	// BucketName is the name of the bucket storing the blobs and their chunks
+	BucketName string
+	// MaxBlobsPerIteration is the maximum number of blobs looked up in a single iteration
+	MaxBlobsPerIteration int32
+	// MaxPurgesPerSecond limits the rate at which blobs are purged from the object store
+	MaxPurgesPerSecond float64
+	// PurgeTimeout bounds the time spent purging a single blob
+	PurgeTimeout time.Duration
+	// DryRun reports the blobs that would be purged and the bytes that would be reclaimed without deleting anything
+	DryRun bool
+}
+
+// BlobGarbageCollector deletes the payloads, proofs and chunk fragments of expired blobs from the object store, and
+// marks the metadata of the blobs as purged. The metadata itself is kept.
+//
+// Blobs are visited in the order they were requested in. Blobs which are no longer in the blob feed (see
+// blobstore.GetRequestedAtBucketIDRange) are never visited, so the bucket should also be configured with a TTL.
+type BlobGarbageCollector struct {
+	*BlobGarbageCollectorConfig
+
+	blobMetadataStore blobstore.MetadataStore
+	s3Client          s3.Client
+	limiter           *rate.Limiter
+	logger            logging.Logger
+
+	// cursor is the position of the last blob visited in the blob feed
+	cursor blobstore.BlobFeedCursor
+
+	metrics *blobGarbageCollectorMetrics
+}
+
+func NewBlobGarbageCollector(
+	config *BlobGarbageCollectorConfig,
+	blobMetadataStore blobstore.MetadataStore,
+	s3Client s3.Client,
+	logger logging.Logger,
+	registry *prometheus.Registry,
+) (*BlobGarbageCollector, error) {
+	if config.PullInterval <= 0 || config.PurgeTimeout <= 0 || config.MaxBlobsPerIteration <= 0 || config.MaxPurgesPerSecond <= 0 {
+		return nil, fmt.Errorf("invalid blob garbage collector config")
+	}
+	if config.BucketName == "" {
+		return nil, fmt.Errorf("bucket name is required")
+	}
+	return &BlobGarbageCollector{
+		BlobGarbageCollectorConfig: config,
+		blobMetadataStore:          blobMetadataStore,
+		s3Client:                   s3Client,
+		limiter:                    rate.NewLimiter(rate.Limit(config.MaxPurgesPerSecond), 1),
+		logger:                     logger.With("component", "BlobGarbageCollector"),
+		metrics:                    newBlobGarbageCollectorMetrics(registry, config.DryRun),
+	}, nil
+}
+
+func (g *BlobGarbageCollector) Start(ctx context.Context) error {
+	if g.DryRun {
+		g.logger.Warn("blob garbage collector is running in dry-run mode, expired blobs will not be deleted")
+	}
+
+	go func() {
+		ticker := time.NewTicker(g.PullInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := g.PurgeExpiredBlobs(ctx); err != nil {
+					g.logger.Error("failed to purge expired blobs", "err", err)
+				}
+			}
+		}
+	}()
+
+	return nil
+}
+
+// PurgeExpiredBlobs purges up to MaxBlobsPerIteration expired blobs requested after the last blob visited
+func (g *BlobGarbageCollector) PurgeExpiredBlobs(ctx context.Context) error {
+	now := time.Now()
+	before := blobstore.BlobFeedCursor{RequestedAt: uint64(now.UnixNano())}
+	if !g.cursor.LessThan(&before) {
+		return nil
+	}
+
+	blobs, _, err := g.blobMetadataStore.GetBlobMetadataByRequestedAtForward(ctx, g.cursor, before, int(g.MaxBlobsPerIteration))
+	if err != nil {
+		return fmt.Errorf("failed to get blobs: %w", err)
+	}
+
+	for _, metadata := range blobs {
+		// Blobs are ordered by the time they were requested at, so the blobs after an unexpired blob are unexpired too
+		if metadata.Expiry > uint64(now.Unix()) {
+			break
+		}
+		blobKey, err := metadata.BlobHeader.BlobKey()
+		if err != nil {
+			return fmt.Errorf("failed to get blob key: %w", err)
+		}
+
+		if !metadata.Purged {
+			if err := g.limiter.Wait(ctx); err != nil {
+				return err
+			}
+			g.purgeBlob(ctx, blobKey, metadata)
+		}
+		g.cursor = blobstore.BlobFeedCursor{
+			RequestedAt: metadata.RequestedAt,
+			BlobKey:     &blobKey,
+		}
+	}
+	return nil
+}
+
+func (g *BlobGarbageCollector) purgeBlob(ctx context.Context, blobKey corev2.BlobKey, metadata *v2.BlobMetadata) {
+	purgeCtx, cancel := context.WithTimeout(ctx, g.PurgeTimeout)
+	defer cancel()
+
+	reclaimedBytes, err := g.deleteObjects(purgeCtx, blobKey)
+	if err != nil {
+		// A blob that fails to be purged is not revisited, so it has to be deleted manually or by the bucket TTL
+		g.logger.Error("failed to purge expired blob", "blobKey", blobKey.Hex(), "status", metadata.BlobStatus.String(), "err", err)
+		g.metrics.reportFailedPurge()
+		return
+	}
+
+	g.logger.Debug("purged expired blob", "blobKey", blobKey.Hex(), "reclaimedBytes", reclaimedBytes, "dryRun", g.DryRun)
+	g.metrics.reportPurge(reclaimedBytes)
+}
+
+// deleteObjects deletes the blob, proofs and chunk fragments of a blob and marks the blob purged. Returns the number of
+// bytes reclaimed. In dry-run mode, nothing is deleted and the number of bytes that would be reclaimed is returned.
+func (g *BlobGarbageCollector) deleteObjects(ctx context.Context, blobKey corev2.BlobKey) (uint64, error) {
+	reclaimedBytes := uint64(0)
+	for _, prefix := range []string{s3.ScopedBlobKey(blobKey), s3.ScopedProofKey(blobKey), s3.ScopedChunkKey(blobKey)} {
+		objects, err := g.s3Client.ListObjects(ctx, g.BucketName, prefix)
+		if err != nil {
+			return 0, fmt.Errorf("failed to list objects with prefix %s: %w", prefix, err)
+		}
+		for _, object := range objects {
+			if !g.DryRun {
+				if err := g.s3Client.DeleteObject(ctx, g.BucketName, object.Key); err != nil {
+					return 0, fmt.Errorf("failed to delete object %s: %w", object.Key, err)
+				}
+			}
+			reclaimedBytes += uint64(object.Size)
+		}
+	}
+
+	if g.DryRun {
+		return reclaimedBytes, nil
+	}
+	if err := g.blobMetadataStore.MarkBlobPurged(ctx, blobKey); err != nil {
+		return 0, fmt.Errorf("failed to mark blob purged: %w", err)
+	}
+	return reclaimedBytes, nil
+}
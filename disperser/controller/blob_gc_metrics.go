@@ -0,0 +1,66 @@
+package controller
+
+import (
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const blobGarbageCollectorNamespace = "eigenda_blob_gc"
+
+// blobGarbageCollectorMetrics is a struct that holds the metrics for the blob garbage collector.
+type blobGarbageCollectorMetrics struct {
+	purgeCount       *prometheus.CounterVec
+	reclaimedBytes   *prometheus.CounterVec
+	failedPurgeCount *prometheus.CounterVec
+
+	// dryRun is the value of the dry_run label
+	dryRun string
+}
+
+// newBlobGarbageCollectorMetrics sets up metrics for the blob garbage collector.
+func newBlobGarbageCollectorMetrics(registry *prometheus.Registry, dryRun bool) *blobGarbageCollectorMetrics {
+	purgeCount := promauto.With(registry).NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: blobGarbageCollectorNamespace,
+			Name:      "purged_blobs_total",
+			Help:      "The number of expired blobs purged from the object store.",
+		},
+		[]string{"dry_run"},
+	)
+
+	reclaimedBytes := promauto.With(registry).NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: blobGarbageCollectorNamespace,
+			Name:      "reclaimed_bytes_total",
+			Help:      "The number of bytes reclaimed from the object store by purging expired blobs.",
+		},
+		[]string{"dry_run"},
+	)
+
+	failedPurgeCount := promauto.With(registry).NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: blobGarbageCollectorNamespace,
+			Name:      "failed_purges_total",
+			Help:      "The number of expired blobs which could not be purged.",
+		},
+		[]string{"dry_run"},
+	)
+
+	return &blobGarbageCollectorMetrics{
+		purgeCount:       purgeCount,
+		reclaimedBytes:   reclaimedBytes,
+		failedPurgeCount: failedPurgeCount,
+		dryRun:           strconv.FormatBool(dryRun),
+	}
+}
+
+func (m *blobGarbageCollectorMetrics) reportPurge(reclaimedBytes uint64) {
+	m.purgeCount.WithLabelValues(m.dryRun).Inc()
+	m.reclaimedBytes.WithLabelValues(m.dryRun).Add(float64(reclaimedBytes))
+}
+
+func (m *blobGarbageCollectorMetrics) reportFailedPurge() {
+	m.failedPurgeCount.WithLabelValues(m.dryRun).Inc()
+}
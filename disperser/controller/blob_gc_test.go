@@ -0,0 +1,101 @@
+package controller_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common/aws/mock"
+	"github.com/Layr-Labs/eigenda/common/aws/s3"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	v2 "github.com/Layr-Labs/eigenda/disperser/common/v2"
+	"github.com/Layr-Labs/eigenda/disperser/controller"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlobGarbageCollector(t *testing.T) {
+	ctx := context.Background()
+	s3Client := mock.NewS3Client()
+	now := time.Now()
+	putBlob := func(status v2.BlobStatus, requestedAt time.Time, expiry time.Time) corev2.BlobKey {
+		key, header := newBlob(t, []uint8{0, 1})
+		err := blobMetadataStore.PutBlobMetadata(ctx, &v2.BlobMetadata{
+			BlobHeader:  header,
+			BlobStatus:  status,
+			Expiry:      uint64(expiry.Unix()),
+			BlobSize:    1000,
+			RequestedAt: uint64(requestedAt.UnixNano()),
+			UpdatedAt:   uint64(requestedAt.UnixNano()),
+		})
+		require.NoError(t, err)
+		err = s3Client.UploadObject(ctx, "bucket", s3.ScopedBlobKey(key), make([]byte, 1000))
+		require.NoError(t, err)
+		err = s3Client.UploadObject(ctx, "bucket", s3.ScopedProofKey(key), make([]byte, 100))
+		require.NoError(t, err)
+		err = s3Client.FragmentedUploadObject(ctx, "bucket", s3.ScopedChunkKey(key), make([]byte, 2500), 1000)
+		require.NoError(t, err)
+		return key
+	}
+	objectCount := func(key corev2.BlobKey) int {
+		count := 0
+		for _, prefix := range []string{s3.ScopedBlobKey(key), s3.ScopedProofKey(key), s3.ScopedChunkKey(key)} {
+			objects, err := s3Client.ListObjects(ctx, "bucket", prefix)
+			require.NoError(t, err)
+			count += len(objects)
+		}
+		return count
+	}
+
+	completeKey := putBlob(v2.Complete, now.Add(-3*time.Hour), now.Add(-2*time.Hour))
+	failedKey := putBlob(v2.Failed, now.Add(-2*time.Hour), now.Add(-time.Hour))
+	unexpiredKey := putBlob(v2.Complete, now.Add(-time.Hour), now.Add(time.Hour))
+
+	// dry runs don't delete anything
+	dryRunGC, err := controller.NewBlobGarbageCollector(&controller.BlobGarbageCollectorConfig{
+		PullInterval:         time.Second,
+		BucketName:           "bucket",
+		MaxBlobsPerIteration: 1000,
+		MaxPurgesPerSecond:   1000,
+		PurgeTimeout:         time.Second,
+		DryRun:               true,
+	}, blobMetadataStore, s3Client, logger, prometheus.NewRegistry())
+	require.NoError(t, err)
+	err = dryRunGC.PurgeExpiredBlobs(ctx)
+	require.NoError(t, err)
+	for _, key := range []corev2.BlobKey{completeKey, failedKey, unexpiredKey} {
+		require.Equal(t, 5, objectCount(key))
+		metadata, err := blobMetadataStore.GetBlobMetadata(ctx, key)
+		require.NoError(t, err)
+		require.False(t, metadata.Purged)
+	}
+
+	gc, err := controller.NewBlobGarbageCollector(&controller.BlobGarbageCollectorConfig{
+		PullInterval:         time.Second,
+		BucketName:           "bucket",
+		MaxBlobsPerIteration: 1000,
+		MaxPurgesPerSecond:   1000,
+		PurgeTimeout:         time.Second,
+	}, blobMetadataStore, s3Client, logger, prometheus.NewRegistry())
+	require.NoError(t, err)
+	err = gc.PurgeExpiredBlobs(ctx)
+	require.NoError(t, err)
+	for _, key := range []corev2.BlobKey{completeKey, failedKey} {
+		require.Equal(t, 0, objectCount(key))
+		metadata, err := blobMetadataStore.GetBlobMetadata(ctx, key)
+		require.NoError(t, err)
+		require.True(t, metadata.Purged)
+	}
+	require.Equal(t, 5, objectCount(unexpiredKey))
+	metadata, err := blobMetadataStore.GetBlobMetadata(ctx, unexpiredKey)
+	require.NoError(t, err)
+	require.False(t, metadata.Purged)
+
+	// purged blobs aren't visited again
+	deleteCount := s3Client.Called["DeleteObject"]
+	err = gc.PurgeExpiredBlobs(ctx)
+	require.NoError(t, err)
+	require.Equal(t, deleteCount, s3Client.Called["DeleteObject"])
+
+	deleteBlobs(t, blobMetadataStore, []corev2.BlobKey{completeKey, failedKey, unexpiredKey}, nil)
+}
@@ -101,7 +101,7 @@ func setup(m *testing.M) {
 		teardown()
 		panic("failed to create s3 bucket: " + err.Error())
 	}
-	blobStore = blobstore.NewBlobStore(s3BucketName, s3Client, logger)
+	blobStore = blobstore.NewBlobStore(s3BucketName, s3Client, nil, logger)
 
 	var X1, Y1 fp.Element
 	X1 = *X1.SetBigInt(big.NewInt(1))
@@ -9,8 +9,10 @@ import (
 	"slices"
 	"time"
 
+	"github.com/Layr-Labs/eigenda/api"
 	"github.com/Layr-Labs/eigenda/api/clients/v2"
 	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/tracing"
 	"github.com/Layr-Labs/eigenda/core"
 	corev2 "github.com/Layr-Labs/eigenda/core/v2"
 	v2 "github.com/Layr-Labs/eigenda/disperser/common/v2"
@@ -19,6 +21,7 @@ import (
 	gethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/hashicorp/go-multierror"
 	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc/metadata"
 )
 
 var errNoBlobsToDispatch = errors.New("no blobs to dispatch")
@@ -38,7 +41,7 @@ type DispatcherConfig struct {
 type Dispatcher struct {
 	*DispatcherConfig
 
-	blobMetadataStore *blobstore.BlobMetadataStore
+	blobMetadataStore blobstore.MetadataStore
 	pool              common.WorkerPool
 	chainState        core.IndexedChainState
 	aggregator        core.SignatureAggregator
@@ -61,11 +64,13 @@ type batchData struct {
 	BlobKeys        []corev2.BlobKey
 	Metadata        map[corev2.BlobKey]*v2.BlobMetadata
 	OperatorState   *core.IndexedOperatorState
+	// DispatchedAt is the time the chunks of the batch started being sent to the operators
+	DispatchedAt time.Time
 }
 
 func NewDispatcher(
 	config *DispatcherConfig,
-	blobMetadataStore *blobstore.BlobMetadataStore,
+	blobMetadataStore blobstore.MetadataStore,
 	pool common.WorkerPool,
 	chainState core.IndexedChainState,
 	aggregator core.SignatureAggregator,
@@ -154,6 +159,9 @@ func (d *Dispatcher) HandleBatch(ctx context.Context) (chan core.SigningMessage,
 	if err != nil {
 		return nil, nil, err
 	}
+	batchData.DispatchedAt = time.Now()
+	recordBatchSpans(batchData, "CreateBatch", start, batchData.DispatchedAt,
+		tracing.Int64("reference_block_number", int64(referenceBlockNumber)))
 
 	batch := batchData.Batch
 	state := batchData.OperatorState
@@ -219,7 +227,7 @@ func (d *Dispatcher) HandleBatch(ctx context.Context) (chan core.SigningMessage,
 			var lastErr error
 			for i = 0; i < d.NumRequestRetries+1; i++ {
 				sendChunksStart := time.Now()
-				sig, err := d.sendChunks(ctx, client, batch)
+				sig, err := d.sendChunks(ctx, client, opID, batch)
 				lastErr = err
 				sendChunksFinished := time.Now()
 				d.metrics.reportSendChunksLatency(sendChunksFinished.Sub(sendChunksStart))
@@ -314,6 +322,9 @@ func (d *Dispatcher) HandleSignatures(ctx context.Context, batchData *batchData,
 	}
 	receiveSignaturesFinished := time.Now()
 	d.metrics.reportReceiveSignaturesLatency(receiveSignaturesFinished.Sub(handleSignaturesStart))
+	recordBatchSpans(batchData, "DistributeChunks", batchData.DispatchedAt, receiveSignaturesFinished,
+		tracing.Int64("num_operators", int64(len(batchData.OperatorState.IndexedOperators))),
+		tracing.Int64("num_signers", int64(len(quorumAttestation.SignerMap))))
 
 	nonZeroQuorums := make([]core.QuorumID, 0)
 	quorumResults := make(map[core.QuorumID]uint8)
@@ -382,6 +393,9 @@ func (d *Dispatcher) HandleSignatures(ctx context.Context, batchData *batchData,
 		return fmt.Errorf("failed to put attestation for batch %s: %w", batchHeaderHash, err)
 	}
 
+	recordBatchSpans(batchData, "AggregateSignatures", receiveSignaturesFinished, putAttestationFinished,
+		tracing.String("quorum_results", fmt.Sprintf("%v", quorumResults)))
+
 	err = d.updateBatchStatus(ctx, batchData, attestation.QuorumResults)
 	updateBatchStatusFinished := time.Now()
 	d.metrics.reportUpdateBatchStatusLatency(updateBatchStatusFinished.Sub(putAttestationFinished))
@@ -393,6 +407,16 @@ func (d *Dispatcher) HandleSignatures(ctx context.Context, batchData *batchData,
 	return nil
 }
 
+// recordBatchSpans records a span covering a stage of the dispatch of a batch in the trace of each blob in the batch
+func recordBatchSpans(batchData *batchData, name string, start time.Time, end time.Time, attributes ...tracing.Attribute) {
+	attributes = append(attributes,
+		tracing.String("batch_header_hash", hex.EncodeToString(batchData.BatchHeaderHash[:])),
+		tracing.Int64("num_blobs", int64(len(batchData.BlobKeys))))
+	for _, blobKey := range batchData.BlobKeys {
+		tracing.RecordBlobSpan(blobKey, name, start, end, attributes...)
+	}
+}
+
 func (d *Dispatcher) dedupBlobs(blobs []*v2.BlobMetadata) []*v2.BlobMetadata {
 	dedupedBlobs := make([]*v2.BlobMetadata, 0)
 	for _, blob := range blobs {
@@ -593,9 +617,10 @@ func (d *Dispatcher) GetOperatorState(ctx context.Context, metadatas []*v2.BlobM
 	return d.chainState.GetIndexedOperatorState(ctx, uint(blockNumber), quorumIds)
 }
 
-func (d *Dispatcher) sendChunks(ctx context.Context, client clients.NodeClient, batch *corev2.Batch) (*core.Signature, error) {
+func (d *Dispatcher) sendChunks(ctx context.Context, client clients.NodeClient, opID core.OperatorID, batch *corev2.Batch) (*core.Signature, error) {
 	ctxWithTimeout, cancel := context.WithTimeout(ctx, d.NodeRequestTimeout)
 	defer cancel()
+	ctxWithTimeout = metadata.AppendToOutgoingContext(ctxWithTimeout, api.OperatorIDHeader, opID.Hex())
 
 	sig, err := client.StoreChunks(ctxWithTimeout, batch)
 	if err != nil {
@@ -6,12 +6,14 @@ import (
 	"fmt"
 	"math"
 	"math/rand"
+	"sort"
 	"sync/atomic"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/tracing"
 	"github.com/Layr-Labs/eigenda/core"
 	corev2 "github.com/Layr-Labs/eigenda/core/v2"
 	"github.com/Layr-Labs/eigenda/disperser"
@@ -25,6 +27,20 @@ import (
 
 var errNoBlobsToEncode = errors.New("no blobs to encode")
 
+// defaultSizeClassName is the name of the size class of blobs larger than all configured size classes
+const defaultSizeClassName = "default"
+
+// BlobSizeClass is a class of blobs, by size, which are encoded by a dedicated worker pool. This gives each class its
+// own concurrency budget, so that a burst of large blobs can't starve small blobs of encoders.
+type BlobSizeClass struct {
+	// Name identifies the class in metrics
+	Name string
+	// MaxBlobSize is the size in bytes of the largest blob in the class
+	MaxBlobSize uint64
+	// Pool encodes the blobs in the class
+	Pool common.WorkerPool
+}
+
 type EncodingManagerConfig struct {
 	PullInterval time.Duration
 
@@ -51,11 +67,14 @@ type EncodingManager struct {
 	*EncodingManagerConfig
 
 	// components
-	blobMetadataStore *blobstore.BlobMetadataStore
-	pool              common.WorkerPool
-	encodingClient    disperser.EncoderClientV2
-	chainReader       core.Reader
-	logger            logging.Logger
+	blobMetadataStore blobstore.MetadataStore
+	// pool encodes the blobs which are larger than all size classes
+	pool common.WorkerPool
+	// sizeClasses are ordered by MaxBlobSize in ascending order
+	sizeClasses    []BlobSizeClass
+	encodingClient disperser.EncoderClientV2
+	chainReader    core.Reader
+	logger         logging.Logger
 
 	// state
 	cursor                *blobstore.StatusIndexCursor
@@ -70,8 +89,9 @@ type EncodingManager struct {
 
 func NewEncodingManager(
 	config *EncodingManagerConfig,
-	blobMetadataStore *blobstore.BlobMetadataStore,
+	blobMetadataStore blobstore.MetadataStore,
 	pool common.WorkerPool,
+	sizeClasses []BlobSizeClass,
 	encodingClient disperser.EncoderClientV2,
 	chainReader core.Reader,
 	logger logging.Logger,
@@ -86,10 +106,24 @@ func NewEncodingManager(
 	if int(config.NumRelayAssignment) > len(config.AvailableRelays) {
 		return nil, fmt.Errorf("NumRelayAssignment (%d) cannot be greater than NumRelays (%d)", config.NumRelayAssignment, len(config.AvailableRelays))
 	}
+	sortedSizeClasses := make([]BlobSizeClass, len(sizeClasses))
+	copy(sortedSizeClasses, sizeClasses)
+	sort.Slice(sortedSizeClasses, func(i, j int) bool {
+		return sortedSizeClasses[i].MaxBlobSize < sortedSizeClasses[j].MaxBlobSize
+	})
+	for i, sizeClass := range sortedSizeClasses {
+		if sizeClass.Pool == nil || sizeClass.Name == "" || sizeClass.Name == defaultSizeClassName {
+			return nil, fmt.Errorf("invalid blob size class %q", sizeClass.Name)
+		}
+		if i > 0 && sizeClass.MaxBlobSize == sortedSizeClasses[i-1].MaxBlobSize {
+			return nil, fmt.Errorf("blob size classes %q and %q have the same max blob size", sortedSizeClasses[i-1].Name, sizeClass.Name)
+		}
+	}
 	return &EncodingManager{
 		EncodingManagerConfig: config,
 		blobMetadataStore:     blobMetadataStore,
 		pool:                  pool,
+		sizeClasses:           sortedSizeClasses,
 		encodingClient:        encodingClient,
 		chainReader:           chainReader,
 		logger:                logger.With("component", "EncodingManager"),
@@ -206,8 +240,14 @@ func (e *EncodingManager) HandleBatch(ctx context.Context) error {
 		}
 
 		// Encode the blobs
-		e.pool.Submit(func() {
+		_, pool := e.sizeClass(blob.BlobSize)
+		pool.Submit(func() {
 			start := time.Now()
+			tracing.RecordBlobSpan(blobKey, "Queued", time.Unix(0, int64(blob.RequestedAt)), start)
+			spanCtx, span := tracing.StartBlobSpan(ctx, blobKey, "EncodeBlob",
+				tracing.Int64("blob_size", int64(blob.BlobSize)),
+				tracing.Int64("blob_version", int64(blob.BlobHeader.BlobVersion)))
+			defer span.End()
 
 			var i int
 			var finishedEncodingTime time.Time
@@ -216,9 +256,12 @@ func (e *EncodingManager) HandleBatch(ctx context.Context) error {
 			var success bool
 
 			for i = 0; i < e.NumEncodingRetries+1; i++ {
+				_, encodeSpan := tracing.StartSpan(spanCtx, "Encode", tracing.Int64("attempt", int64(i)))
 				encodingCtx, cancel := context.WithTimeout(ctx, e.EncodingRequestTimeout)
 				fragmentInfo, err := e.encodeBlob(encodingCtx, blobKey, blob, blobParams)
 				cancel()
+				encodeSpan.RecordError(err)
+				encodeSpan.End()
 				if err != nil {
 					e.logger.Error("failed to encode blob", "blobKey", blobKey.Hex(), "err", err)
 					continue
@@ -264,6 +307,7 @@ func (e *EncodingManager) HandleBatch(ctx context.Context) error {
 			}
 
 			e.metrics.reportBatchRetryCount(i)
+			span.SetAttributes(tracing.Int64("retries", int64(i)))
 
 			if success {
 				e.metrics.reportEncodingLatency(finishedEncodingTime.Sub(start))
@@ -276,6 +320,7 @@ func (e *EncodingManager) HandleBatch(ctx context.Context) error {
 				e.metrics.reportE2EEncodingLatency(time.Since(requestedAt))
 				e.metrics.reportCompletedBlob(int(blob.BlobSize), v2.Encoded)
 			} else {
+				span.RecordError(errors.New("failed to encode blob"))
 				e.metrics.reportFailedSubmission()
 				storeCtx, cancel := context.WithTimeout(ctx, e.StoreTimeout)
 				err = e.blobMetadataStore.UpdateBlobStatus(storeCtx, blobKey, v2.Failed)
@@ -290,6 +335,10 @@ func (e *EncodingManager) HandleBatch(ctx context.Context) error {
 	}
 
 	e.metrics.reportBatchSubmissionLatency(time.Since(submissionStart))
+	for _, sizeClass := range e.sizeClasses {
+		e.metrics.reportSizeClassQueueSize(sizeClass.Name, sizeClass.Pool.WaitingQueueSize())
+	}
+	e.metrics.reportSizeClassQueueSize(defaultSizeClassName, e.pool.WaitingQueueSize())
 
 	e.cursor = cursor
 
@@ -297,6 +346,16 @@ func (e *EncodingManager) HandleBatch(ctx context.Context) error {
 	return nil
 }
 
+// sizeClass returns the name of the size class of a blob and the pool encoding the blobs in the class
+func (e *EncodingManager) sizeClass(blobSize uint64) (string, common.WorkerPool) {
+	for _, sizeClass := range e.sizeClasses {
+		if blobSize <= sizeClass.MaxBlobSize {
+			return sizeClass.Name, sizeClass.Pool
+		}
+	}
+	return defaultSizeClassName, e.pool
+}
+
 func (e *EncodingManager) encodeBlob(ctx context.Context, blobKey corev2.BlobKey, blob *v2.BlobMetadata, blobParams *core.BlobVersionParameters) (*encoding.FragmentInfo, error) {
 	// Add headers for routing
 	md := metadata.New(map[string]string{
@@ -25,6 +25,7 @@ type encodingManagerMetrics struct {
 	failedSubmissionCount   *prometheus.CounterVec
 	completedBlobs          *prometheus.CounterVec
 	blobSetSize             *prometheus.GaugeVec
+	sizeClassQueueSize      *prometheus.GaugeVec
 }
 
 // NewEncodingManagerMetrics sets up metrics for the encoding manager.
@@ -143,6 +144,15 @@ func newEncodingManagerMetrics(registry *prometheus.Registry) *encodingManagerMe
 		[]string{},
 	)
 
+	sizeClassQueueSize := promauto.With(registry).NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: encodingManagerNamespace,
+			Name:      "size_class_queue_size",
+			Help:      "The number of blobs waiting for an encoder, by blob size class.",
+		},
+		[]string{"size_class"},
+	)
+
 	return &encodingManagerMetrics{
 		batchSubmissionLatency:  batchSubmissionLatency,
 		blobHandleLatency:       blobHandleLatency,
@@ -156,6 +166,7 @@ func newEncodingManagerMetrics(registry *prometheus.Registry) *encodingManagerMe
 		failedSubmissionCount:   failSubmissionCount,
 		completedBlobs:          completedBlobs,
 		blobSetSize:             blobSetSize,
+		sizeClassQueueSize:      sizeClassQueueSize,
 	}
 }
 
@@ -218,3 +229,7 @@ func (m *encodingManagerMetrics) reportCompletedBlob(size int, status dispv2.Blo
 func (m *encodingManagerMetrics) reportBlobSetSize(size int) {
 	m.blobSetSize.WithLabelValues().Set(float64(size))
 }
+
+func (m *encodingManagerMetrics) reportSizeClassQueueSize(sizeClass string, size int) {
+	m.sizeClassQueueSize.WithLabelValues(sizeClass).Set(float64(size))
+}
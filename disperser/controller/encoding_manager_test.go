@@ -350,7 +350,55 @@ func TestEncodingManagerHandleBatchRetryFailure(t *testing.T) {
 	deleteBlobs(t, blobMetadataStore, []corev2.BlobKey{blobKey1}, nil)
 }
 
+func TestEncodingManagerHandleBatchSizeClasses(t *testing.T) {
+	ctx := context.Background()
+	smallKey, smallHeader := newBlob(t, []core.QuorumID{0, 1})
+	largeKey, largeHeader := newBlob(t, []core.QuorumID{0, 1})
+	now := time.Now()
+	for _, metadata := range []*commonv2.BlobMetadata{
+		{
+			BlobHeader: smallHeader,
+			BlobStatus: commonv2.Queued,
+			BlobSize:   1024,
+			Expiry:     uint64(now.Add(time.Hour).Unix()),
+			UpdatedAt:  uint64(now.UnixNano()),
+		},
+		{
+			BlobHeader: largeHeader,
+			BlobStatus: commonv2.Queued,
+			BlobSize:   16 * 1024 * 1024,
+			Expiry:     uint64(now.Add(time.Hour).Unix()),
+			UpdatedAt:  uint64(now.UnixNano()),
+		},
+	} {
+		err := blobMetadataStore.PutBlobMetadata(ctx, metadata)
+		require.NoError(t, err)
+	}
+
+	smallPool := &commonmock.MockWorkerpool{}
+	smallPool.On("Submit", mock.Anything).Return(nil)
+	smallPool.On("WaitingQueueSize").Return(0)
+	c := newTestComponentsWithSizeClasses(t, true, []controller.BlobSizeClass{
+		{Name: "small", MaxBlobSize: 128 * 1024, Pool: smallPool},
+	})
+	c.BlobSet.On("Contains", mock.Anything).Return(false)
+	c.BlobSet.On("AddBlob", mock.Anything).Return(nil)
+	c.MockPool.On("Submit", mock.Anything).Return(nil)
+
+	err := c.EncodingManager.HandleBatch(ctx)
+	require.NoError(t, err)
+	// each blob is encoded by the pool of its size class
+	smallPool.AssertNumberOfCalls(t, "Submit", 1)
+	c.MockPool.AssertNumberOfCalls(t, "Submit", 1)
+
+	deleteBlobs(t, blobMetadataStore, []corev2.BlobKey{smallKey, largeKey}, nil)
+}
+
 func newTestComponents(t *testing.T, mockPool bool) *testComponents {
+	return newTestComponentsWithSizeClasses(t, mockPool, nil)
+}
+
+func newTestComponentsWithSizeClasses(t *testing.T, mockPool bool, sizeClasses []controller.BlobSizeClass) *testComponents {
 	logger := testutils.GetLogger()
 	// logger, err := common.NewLogger(common.DefaultLoggerConfig())
 	// require.NoError(t, err)
@@ -358,6 +406,7 @@ func newTestComponents(t *testing.T, mockPool bool) *testComponents {
 	var mockP *commonmock.MockWorkerpool
 	if mockPool {
 		mockP = &commonmock.MockWorkerpool{}
+		mockP.On("WaitingQueueSize").Return(0)
 		pool = mockP
 	} else {
 		pool = workerpool.New(5)
@@ -384,7 +433,7 @@ func newTestComponents(t *testing.T, mockPool bool) *testComponents {
 		AvailableRelays:             []corev2.RelayKey{0, 1, 2, 3},
 		MaxNumBlobsPerIteration:     5,
 		OnchainStateRefreshInterval: onchainRefreshInterval,
-	}, blobMetadataStore, pool, encodingClient, chainReader, logger, prometheus.NewRegistry(), blobSet)
+	}, blobMetadataStore, pool, sizeClasses, encodingClient, chainReader, logger, prometheus.NewRegistry(), blobSet)
 	assert.NoError(t, err)
 
 	ctx, cancel := context.WithTimeout(context.Background(), 2*onchainRefreshInterval)
@@ -6,6 +6,7 @@ import (
 	"github.com/Layr-Labs/eigenda/api/clients/v2"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	lru "github.com/hashicorp/golang-lru/v2"
+	"google.golang.org/grpc/credentials"
 )
 
 type NodeClientManager interface {
@@ -16,7 +17,9 @@ type nodeClientManager struct {
 	// nodeClients is a cache of node clients keyed by socket address
 	nodeClients   *lru.Cache[string, clients.NodeClient]
 	requestSigner clients.DispersalRequestSigner
-	logger        logging.Logger
+	// credentials secure the connections to the nodes. If nil, the connections are plaintext.
+	credentials credentials.TransportCredentials
+	logger      logging.Logger
 }
 
 var _ NodeClientManager = (*nodeClientManager)(nil)
@@ -24,6 +27,7 @@ var _ NodeClientManager = (*nodeClientManager)(nil)
 func NewNodeClientManager(
 	cacheSize int,
 	requestSigner clients.DispersalRequestSigner,
+	creds credentials.TransportCredentials,
 	logger logging.Logger) (NodeClientManager, error) {
 
 	closeClient := func(socket string, value clients.NodeClient) {
@@ -40,6 +44,7 @@ func NewNodeClientManager(
 	return &nodeClientManager{
 		nodeClients:   nodeClients,
 		requestSigner: requestSigner,
+		credentials:   creds,
 		logger:        logger,
 	}, nil
 }
@@ -51,8 +56,9 @@ func (m *nodeClientManager) GetClient(host, port string) (clients.NodeClient, er
 		var err error
 		client, err = clients.NewNodeClient(
 			&clients.NodeClientConfig{
-				Hostname: host,
-				Port:     port,
+				Hostname:    host,
+				Port:        port,
+				Credentials: m.credentials,
 			},
 			m.requestSigner)
 		if err != nil {
@@ -0,0 +1,163 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/core"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	v2 "github.com/Layr-Labs/eigenda/disperser/common/v2"
+	"github.com/Layr-Labs/eigenda/disperser/common/v2/blobstore"
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// PaymentRefunder reverses the charge of a request which was metered but failed terminally afterwards.
+// It is implemented by meterer.Meterer.
+type PaymentRefunder interface {
+	RefundRequest(ctx context.Context, header core.PaymentMetadata, numSymbols uint64, overflowSymbols uint64, reason string) (uint64, error)
+}
+
+// failedBlobsPageSize is the number of failed blobs read from the metadata store at once
+const failedBlobsPageSize = 1000
+
+type PaymentReconcilerConfig struct {
+	// PullInterval is how often failed blobs are looked up
+	PullInterval time.Duration
+	// RefundTimeout bounds the time spent refunding a single blob
+	RefundTimeout time.Duration
+}
+
+// PaymentReconciler credits back the payments of blobs which failed after they were metered by the API server, so
+// that users aren't charged for blobs that were never dispersed. Symbols charged to a reservation are credited back
+// to the reservation bin, and on-demand payments are flagged for refund.
+//
+// Each cycle goes over all failed blobs, and refunds those which aren't marked refunded in the metadata store yet, so
+// blobs which failed while the reconciler wasn't running are refunded too, and refunds which fail are retried. A blob
+// is marked refunded after its refund succeeds. If marking it fails, it's marked again in the next cycles without
+// being refunded again, so a blob can only be refunded twice if the reconciler stops between the two steps.
+type PaymentReconciler struct {
+	*PaymentReconcilerConfig
+
+	blobMetadataStore blobstore.MetadataStore
+	refunder          PaymentRefunder
+	logger            logging.Logger
+
+	// unmarked are the blobs which were refunded but couldn't be marked refunded in the metadata store
+	unmarked map[corev2.BlobKey]struct{}
+
+	metrics *paymentReconcilerMetrics
+}
+
+func NewPaymentReconciler(
+	config *PaymentReconcilerConfig,
+	blobMetadataStore blobstore.MetadataStore,
+	refunder PaymentRefunder,
+	logger logging.Logger,
+	registry *prometheus.Registry,
+) (*PaymentReconciler, error) {
+	if config.PullInterval <= 0 || config.RefundTimeout <= 0 {
+		return nil, fmt.Errorf("invalid payment reconciler config")
+	}
+	if refunder == nil {
+		return nil, fmt.Errorf("payment refunder is required")
+	}
+	return &PaymentReconciler{
+		PaymentReconcilerConfig: config,
+		blobMetadataStore:       blobMetadataStore,
+		refunder:                refunder,
+		logger:                  logger.With("component", "PaymentReconciler"),
+		unmarked:                make(map[corev2.BlobKey]struct{}),
+		metrics:                 newPaymentReconcilerMetrics(registry),
+	}, nil
+}
+
+func (r *PaymentReconciler) Start(ctx context.Context) error {
+	go func() {
+		ticker := time.NewTicker(r.PullInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := r.ReconcileFailedBlobs(ctx); err != nil {
+					r.logger.Error("failed to reconcile payments of failed blobs", "err", err)
+				}
+			}
+		}
+	}()
+
+	return nil
+}
+
+// ReconcileFailedBlobs refunds the payments of the failed blobs which weren't refunded yet
+func (r *PaymentReconciler) ReconcileFailedBlobs(ctx context.Context) error {
+	var cursor *blobstore.StatusIndexCursor
+	for {
+		failedBlobs, nextCursor, err := r.blobMetadataStore.GetBlobMetadataByStatusPaginated(ctx, v2.Failed, cursor, failedBlobsPageSize)
+		if err != nil {
+			return fmt.Errorf("failed to get failed blobs: %w", err)
+		}
+		for _, metadata := range failedBlobs {
+			if !metadata.PaymentRefunded {
+				r.reconcileBlob(ctx, metadata)
+			}
+		}
+		if nextCursor == nil || len(failedBlobs) == 0 {
+			return nil
+		}
+		cursor = nextCursor
+	}
+}
+
+func (r *PaymentReconciler) reconcileBlob(ctx context.Context, metadata *v2.BlobMetadata) {
+	blobKey, err := metadata.BlobHeader.BlobKey()
+	if err != nil {
+		r.logger.Error("failed to get blob key", "err", err)
+		r.metrics.reportFailedRefund()
+		return
+	}
+	if _, ok := r.unmarked[blobKey]; ok {
+		r.markRefunded(ctx, blobKey)
+		return
+	}
+	header := metadata.BlobHeader.PaymentMetadata
+	blobLength := encoding.GetBlobLengthPowerOf2(uint(metadata.BlobSize))
+
+	refundCtx, cancel := context.WithTimeout(ctx, r.RefundTimeout)
+	defer cancel()
+	symbolsRefunded, err := r.refunder.RefundRequest(refundCtx, header, uint64(blobLength), metadata.ReservationOverflowSymbols, fmt.Sprintf("blob %s failed", blobKey.Hex()))
+	if err != nil {
+		// The payment has to be settled manually, so log everything needed to do so
+		r.logger.Error("failed to refund payment of failed blob",
+			"blobKey", blobKey.Hex(),
+			"accountID", header.AccountID,
+			"timestamp", header.Timestamp,
+			"cumulativePayment", header.CumulativePayment,
+			"blobSize", metadata.BlobSize,
+			"err", err)
+		r.metrics.reportFailedRefund()
+		return
+	}
+
+	onDemand := header.CumulativePayment != nil && header.CumulativePayment.Sign() != 0
+	r.logger.Debug("refunded payment of failed blob", "blobKey", blobKey.Hex(), "accountID", header.AccountID, "symbols", symbolsRefunded, "onDemand", onDemand)
+	r.metrics.reportRefund(onDemand, symbolsRefunded)
+	r.unmarked[blobKey] = struct{}{}
+	r.markRefunded(ctx, blobKey)
+}
+
+// markRefunded marks a refunded blob in the metadata store. Blobs which can't be marked are kept in memory, so that
+// they are marked again rather than refunded again.
+func (r *PaymentReconciler) markRefunded(ctx context.Context, blobKey corev2.BlobKey) {
+	markCtx, cancel := context.WithTimeout(ctx, r.RefundTimeout)
+	defer cancel()
+	if err := r.blobMetadataStore.MarkBlobPaymentRefunded(markCtx, blobKey); err != nil {
+		r.logger.Error("failed to mark payment of failed blob refunded", "blobKey", blobKey.Hex(), "err", err)
+		return
+	}
+	delete(r.unmarked, blobKey)
+}
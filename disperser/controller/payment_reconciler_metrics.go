@@ -0,0 +1,64 @@
+package controller
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const paymentReconcilerNamespace = "eigenda_payment_reconciler"
+
+// paymentReconcilerMetrics is a struct that holds the metrics for the payment reconciler.
+type paymentReconcilerMetrics struct {
+	refundCount       *prometheus.CounterVec
+	refundedSymbols   *prometheus.CounterVec
+	failedRefundCount *prometheus.CounterVec
+}
+
+// newPaymentReconcilerMetrics sets up metrics for the payment reconciler.
+func newPaymentReconcilerMetrics(registry *prometheus.Registry) *paymentReconcilerMetrics {
+	refundCount := promauto.With(registry).NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: paymentReconcilerNamespace,
+			Name:      "refunds_total",
+			Help:      "The number of failed blobs whose payment was refunded.",
+		},
+		[]string{"type"},
+	)
+
+	refundedSymbols := promauto.With(registry).NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: paymentReconcilerNamespace,
+			Name:      "refunded_symbols_total",
+			Help:      "The number of symbols refunded for failed blobs.",
+		},
+		[]string{"type"},
+	)
+
+	failedRefundCount := promauto.With(registry).NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: paymentReconcilerNamespace,
+			Name:      "failed_refunds_total",
+			Help:      "The number of failed blobs whose payment could not be refunded.",
+		},
+		[]string{},
+	)
+
+	return &paymentReconcilerMetrics{
+		refundCount:       refundCount,
+		refundedSymbols:   refundedSymbols,
+		failedRefundCount: failedRefundCount,
+	}
+}
+
+func (m *paymentReconcilerMetrics) reportRefund(onDemand bool, symbols uint64) {
+	paymentType := "reservation"
+	if onDemand {
+		paymentType = "on_demand"
+	}
+	m.refundCount.WithLabelValues(paymentType).Inc()
+	m.refundedSymbols.WithLabelValues(paymentType).Add(float64(symbols))
+}
+
+func (m *paymentReconcilerMetrics) reportFailedRefund() {
+	m.failedRefundCount.WithLabelValues().Inc()
+}
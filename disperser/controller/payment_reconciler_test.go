@@ -0,0 +1,104 @@
+package controller_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/core"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	v2 "github.com/Layr-Labs/eigenda/disperser/common/v2"
+	"github.com/Layr-Labs/eigenda/disperser/controller"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+)
+
+type refundRecorder struct {
+	mu       sync.Mutex
+	refunds  map[string]uint64
+	overflow map[string]uint64
+	err      error
+}
+
+func (r *refundRecorder) RefundRequest(ctx context.Context, header core.PaymentMetadata, numSymbols uint64, overflowSymbols uint64, reason string) (uint64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err != nil {
+		return 0, r.err
+	}
+	r.refunds[header.AccountID] += numSymbols
+	r.overflow[header.AccountID] += overflowSymbols
+	return numSymbols + overflowSymbols, nil
+}
+
+func TestPaymentReconciler(t *testing.T) {
+	ctx := context.Background()
+	refunder := &refundRecorder{refunds: make(map[string]uint64), overflow: make(map[string]uint64)}
+	putBlob := func(status v2.BlobStatus, updatedAt time.Time, overflowSymbols uint64) (corev2.BlobKey, *corev2.BlobHeader) {
+		key, header := newBlob(t, []uint8{0, 1})
+		err := blobMetadataStore.PutBlobMetadata(ctx, &v2.BlobMetadata{
+			BlobHeader:                 header,
+			BlobStatus:                 status,
+			Expiry:                     uint64(updatedAt.Add(time.Hour).Unix()),
+			BlobSize:                   1000,
+			UpdatedAt:                  uint64(updatedAt.UnixNano()),
+			ReservationOverflowSymbols: overflowSymbols,
+		})
+		require.NoError(t, err)
+		return key, header
+	}
+	newReconciler := func() *controller.PaymentReconciler {
+		reconciler, err := controller.NewPaymentReconciler(&controller.PaymentReconcilerConfig{
+			PullInterval:  time.Second,
+			RefundTimeout: time.Second,
+		}, blobMetadataStore, refunder, logger, prometheus.NewRegistry())
+		require.NoError(t, err)
+		return reconciler
+	}
+
+	// blobs which failed before the reconciler started are refunded too, including blobs updated at the same time
+	failedAt := time.Now().Add(-time.Minute)
+	staleKey, staleHeader := putBlob(v2.Failed, failedAt, 0)
+	sameTimeKey, sameTimeHeader := putBlob(v2.Failed, failedAt, 0)
+	reconciler := newReconciler()
+
+	failedKey, failedHeader := putBlob(v2.Failed, time.Now(), 7)
+	completeKey, completeHeader := putBlob(v2.Complete, time.Now(), 0)
+	err := reconciler.ReconcileFailedBlobs(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(32), refunder.refunds[staleHeader.PaymentMetadata.AccountID])
+	require.Equal(t, uint64(32), refunder.refunds[sameTimeHeader.PaymentMetadata.AccountID])
+	require.Equal(t, uint64(32), refunder.refunds[failedHeader.PaymentMetadata.AccountID])
+	require.NotContains(t, refunder.refunds, completeHeader.PaymentMetadata.AccountID)
+	// the symbols charged to the overflow bin are refunded too
+	require.Equal(t, uint64(7), refunder.overflow[failedHeader.PaymentMetadata.AccountID])
+
+	// refunded blobs are marked, so each failed blob is refunded once, even after a restart
+	metadata, err := blobMetadataStore.GetBlobMetadata(ctx, failedKey)
+	require.NoError(t, err)
+	require.True(t, metadata.PaymentRefunded)
+	err = reconciler.ReconcileFailedBlobs(ctx)
+	require.NoError(t, err)
+	err = newReconciler().ReconcileFailedBlobs(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(32), refunder.refunds[failedHeader.PaymentMetadata.AccountID])
+	require.Equal(t, uint64(32), refunder.refunds[staleHeader.PaymentMetadata.AccountID])
+
+	// refunds which fail are retried
+	refunder.err = errors.New("dynamodb unavailable")
+	unrefundedKey, unrefundedHeader := putBlob(v2.Failed, time.Now(), 0)
+	err = reconciler.ReconcileFailedBlobs(ctx)
+	require.NoError(t, err)
+	require.NotContains(t, refunder.refunds, unrefundedHeader.PaymentMetadata.AccountID)
+	metadata, err = blobMetadataStore.GetBlobMetadata(ctx, unrefundedKey)
+	require.NoError(t, err)
+	require.False(t, metadata.PaymentRefunded)
+	refunder.err = nil
+	err = reconciler.ReconcileFailedBlobs(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(32), refunder.refunds[unrefundedHeader.PaymentMetadata.AccountID])
+
+	deleteBlobs(t, blobMetadataStore, []corev2.BlobKey{staleKey, sameTimeKey, failedKey, completeKey, unrefundedKey}, nil)
+}
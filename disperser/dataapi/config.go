@@ -7,6 +7,8 @@ type Config struct {
 	DisperserHostname  string
 	ChurnerHostname    string
 	BatcherHealthEndpt string
+	// CertVerifierAddress is the address of the EigenDACertVerifier contract used to verify certs. Optional.
+	CertVerifierAddress string
 }
 
 type DataApiVersion uint
@@ -46,11 +46,11 @@ func NewMetrics(serverVersion uint, blobMetadataStore interface{}, httpPort stri
 	reg.MustRegister(collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}))
 	reg.MustRegister(collectors.NewGoCollector())
 	if serverVersion == 1 {
-		if store, ok := blobMetadataStore.(*blobstore.BlobMetadataStore); ok {
+		if store, ok := blobMetadataStore.(blobstore.IndexedMetadataStore); ok {
 			reg.MustRegister(NewDynamoDBCollector(store, logger))
 		}
 	} else if serverVersion == 2 {
-		if store, ok := blobMetadataStore.(*blobstorev2.BlobMetadataStore); ok {
+		if store, ok := blobMetadataStore.(blobstorev2.MetadataStore); ok {
 			reg.MustRegister(NewBlobMetadataStoreV2Collector(store, logger))
 		}
 	}
@@ -210,12 +210,12 @@ func (g *Metrics) Start(ctx context.Context) {
 }
 
 type DynamoDBCollector struct {
-	blobMetadataStore *blobstore.BlobMetadataStore
+	blobMetadataStore blobstore.IndexedMetadataStore
 	blobStatusMetric  *prometheus.Desc
 	logger            logging.Logger
 }
 
-func NewDynamoDBCollector(blobMetadataStore *blobstore.BlobMetadataStore, logger logging.Logger) *DynamoDBCollector {
+func NewDynamoDBCollector(blobMetadataStore blobstore.IndexedMetadataStore, logger logging.Logger) *DynamoDBCollector {
 	return &DynamoDBCollector{
 		blobMetadataStore: blobMetadataStore,
 		blobStatusMetric: prometheus.NewDesc("dynamodb_blob_metadata_status_count",
@@ -254,14 +254,14 @@ type BlobStatusMetrics struct {
 
 // BlobMetadataStoreV2Collector collects metrics from the blob metadata store.
 type BlobMetadataStoreV2Collector struct {
-	blobMetadataStore *blobstorev2.BlobMetadataStore
+	blobMetadataStore blobstorev2.MetadataStore
 	statusMetrics     map[commonv2.BlobStatus]*BlobStatusMetrics
 	logger            logging.Logger
 	ctx               context.Context
 	cancel            context.CancelFunc
 }
 
-func NewBlobMetadataStoreV2Collector(blobMetadataStore *blobstorev2.BlobMetadataStore, logger logging.Logger) *BlobMetadataStoreV2Collector {
+func NewBlobMetadataStoreV2Collector(blobMetadataStore blobstorev2.MetadataStore, logger logging.Logger) *BlobMetadataStoreV2Collector {
 	ctx, cancel := context.WithCancel(context.Background())
 	collector := &BlobMetadataStoreV2Collector{
 		blobMetadataStore: blobMetadataStore,
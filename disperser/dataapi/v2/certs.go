@@ -0,0 +1,116 @@
+package v2
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api/clients/v2/verification"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	certCheckBlobKey         = "blob_key"
+	certCheckInclusionProof  = "inclusion_proof"
+	certCheckSignedQuorums   = "signed_quorums"
+	certCheckRequiredQuorums = "required_quorums"
+	certCheckOnchain         = "onchain_verification"
+
+	// The max amount of time to spend verifying a single cert. This bounds the time spent waiting for the eth
+	// client to catch up to the reference block number of the cert.
+	maxCertVerificationDuration = 15 * time.Second
+)
+
+// VerifyCertificate godoc
+//
+//	@Summary	Verify an EigenDA certificate against chain state
+//	@Tags		Certificates
+//	@Accept		json
+//	@Produce	json
+//	@Param		certificate	body		verification.EigenDACert	true	"The EigenDA certificate to verify"
+//	@Success	200			{object}	CertVerificationResponse
+//	@Failure	400			{object}	ErrorResponse	"error: Bad request"
+//	@Failure	500			{object}	ErrorResponse	"error: Server error"
+//	@Failure	503			{object}	ErrorResponse	"error: Certificate verification not enabled"
+//	@Router		/certs/verify [post]
+func (s *ServerV2) VerifyCertificate(c *gin.Context) {
+	handlerStart := time.Now()
+
+	if s.certVerifier == nil || s.certVerifierAddress == "" {
+		s.metrics.IncrementFailedRequestNum("VerifyCertificate")
+		errorResponse(c, errCertVerificationDisabled)
+		return
+	}
+
+	var cert verification.EigenDACert
+	if err := c.ShouldBindJSON(&cert); err != nil {
+		s.metrics.IncrementInvalidArgRequestNum("VerifyCertificate")
+		invalidParamsErrorResponse(c, fmt.Errorf("failed to parse certificate: %w", err))
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), maxCertVerificationDuration)
+	defer cancel()
+
+	response := s.verifyCertificate(ctx, &cert)
+	s.metrics.IncrementSuccessfulRequestNum("VerifyCertificate")
+	s.metrics.ObserveLatency("VerifyCertificate", time.Since(handlerStart))
+	c.JSON(http.StatusOK, response)
+}
+
+// verifyCertificate runs all verification checks against the cert, and returns a verdict describing the result of
+// each check. Checks which depend on the result of a failed check are not run, and are omitted from the verdict.
+func (s *ServerV2) verifyCertificate(ctx context.Context, cert *verification.EigenDACert) *CertVerificationResponse {
+	response := &CertVerificationResponse{
+		Verified: true,
+		Checks:   make([]*CertVerificationCheck, 0),
+	}
+	record := func(name string, err error) bool {
+		check := &CertVerificationCheck{
+			Name:   name,
+			Passed: err == nil,
+		}
+		if err != nil {
+			check.Error = err.Error()
+			response.Verified = false
+		}
+		response.Checks = append(response.Checks, check)
+		return err == nil
+	}
+
+	blobKey, err := cert.ComputeBlobKey()
+	if record(certCheckBlobKey, err) {
+		response.BlobKey = blobKey.Hex()
+		record(certCheckInclusionProof, cert.VerifyBlobInclusion())
+	}
+
+	blobQuorums := cert.BlobInclusionInfo.BlobCertificate.BlobHeader.QuorumNumbers
+	record(certCheckSignedQuorums, checkQuorumSubset(blobQuorums, cert.SignedQuorumNumbers, "signed"))
+
+	requiredQuorums, err := s.certVerifier.GetQuorumNumbersRequired(ctx, s.certVerifierAddress)
+	if err != nil {
+		err = fmt.Errorf("failed to get required quorums: %w", err)
+	} else {
+		err = checkQuorumSubset(requiredQuorums, blobQuorums, "blob")
+	}
+	record(certCheckRequiredQuorums, err)
+
+	record(certCheckOnchain, s.certVerifier.VerifyCertV2(ctx, s.certVerifierAddress, cert))
+
+	return response
+}
+
+// checkQuorumSubset returns an error if any quorum in subset is not also present in superset
+func checkQuorumSubset(subset []byte, superset []byte, supersetName string) error {
+	present := make(map[byte]struct{}, len(superset))
+	for _, q := range superset {
+		present[q] = struct{}{}
+	}
+	for _, q := range subset {
+		if _, ok := present[q]; !ok {
+			return fmt.Errorf("quorum %d is missing from %s quorums %v", q, supersetName, superset)
+		}
+	}
+	return nil
+}
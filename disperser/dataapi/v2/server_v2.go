@@ -9,6 +9,7 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/Layr-Labs/eigenda/api/clients/v2/verification"
 	"github.com/Layr-Labs/eigenda/core"
 	corev2 "github.com/Layr-Labs/eigenda/core/v2"
 	"github.com/Layr-Labs/eigenda/disperser/common/semver"
@@ -24,7 +25,10 @@ import (
 	ginswagger "github.com/swaggo/gin-swagger"
 )
 
-var errNotFound = errors.New("not found")
+var (
+	errNotFound                 = errors.New("not found")
+	errCertVerificationDisabled = errors.New("certificate verification is not enabled")
+)
 
 const (
 	maxBlobAge = 14 * 24 * time.Hour
@@ -176,6 +180,17 @@ type (
 		Throughput float64 `json:"throughput"`
 		Timestamp  uint64  `json:"timestamp"`
 	}
+
+	CertVerificationCheck struct {
+		Name   string `json:"name"`
+		Passed bool   `json:"passed"`
+		Error  string `json:"error,omitempty"`
+	}
+	CertVerificationResponse struct {
+		BlobKey  string                   `json:"blob_key"`
+		Verified bool                     `json:"verified"`
+		Checks   []*CertVerificationCheck `json:"checks"`
+	}
 )
 
 type ServerV2 struct {
@@ -184,7 +199,7 @@ type ServerV2 struct {
 	allowOrigins []string
 	logger       logging.Logger
 
-	blobMetadataStore *blobstore.BlobMetadataStore
+	blobMetadataStore blobstore.MetadataStore
 	subgraphClient    dataapi.SubgraphClient
 	chainReader       core.Reader
 	chainState        core.ChainState
@@ -192,13 +207,18 @@ type ServerV2 struct {
 	promClient        dataapi.PrometheusClient
 	metrics           *dataapi.Metrics
 
+	// certVerifier is used to verify EigenDA certs against the cert verifier contract at certVerifierAddress.
+	// Certificate verification is disabled if either is unset.
+	certVerifier        verification.ICertVerifier
+	certVerifierAddress string
+
 	operatorHandler *dataapi.OperatorHandler
 	metricsHandler  *dataapi.MetricsHandler
 }
 
 func NewServerV2(
 	config dataapi.Config,
-	blobMetadataStore *blobstore.BlobMetadataStore,
+	blobMetadataStore blobstore.MetadataStore,
 	promClient dataapi.PrometheusClient,
 	subgraphClient dataapi.SubgraphClient,
 	chainReader core.Reader,
@@ -206,22 +226,25 @@ func NewServerV2(
 	indexedChainState core.IndexedChainState,
 	logger logging.Logger,
 	metrics *dataapi.Metrics,
+	certVerifier verification.ICertVerifier,
 ) *ServerV2 {
 	l := logger.With("component", "DataAPIServerV2")
 	return &ServerV2{
-		logger:            l,
-		serverMode:        config.ServerMode,
-		socketAddr:        config.SocketAddr,
-		allowOrigins:      config.AllowOrigins,
-		blobMetadataStore: blobMetadataStore,
-		promClient:        promClient,
-		subgraphClient:    subgraphClient,
-		chainReader:       chainReader,
-		chainState:        chainState,
-		indexedChainState: indexedChainState,
-		metrics:           metrics,
-		operatorHandler:   dataapi.NewOperatorHandler(l, metrics, chainReader, chainState, indexedChainState, subgraphClient),
-		metricsHandler:    dataapi.NewMetricsHandler(promClient, dataapi.V2),
+		logger:              l,
+		serverMode:          config.ServerMode,
+		socketAddr:          config.SocketAddr,
+		allowOrigins:        config.AllowOrigins,
+		blobMetadataStore:   blobMetadataStore,
+		promClient:          promClient,
+		subgraphClient:      subgraphClient,
+		chainReader:         chainReader,
+		chainState:          chainState,
+		indexedChainState:   indexedChainState,
+		metrics:             metrics,
+		certVerifier:        certVerifier,
+		certVerifierAddress: config.CertVerifierAddress,
+		operatorHandler:     dataapi.NewOperatorHandler(l, metrics, chainReader, chainState, indexedChainState, subgraphClient),
+		metricsHandler:      dataapi.NewMetricsHandler(promClient, dataapi.V2),
 	}
 }
 
@@ -282,6 +305,10 @@ func (s *ServerV2) Start() error {
 			operators.GET("/liveness", s.CheckOperatorsLiveness)
 			operators.GET("/response/:batch_header_hash", s.FetchOperatorsResponses)
 		}
+		certs := v2.Group("/certs")
+		{
+			certs.POST("/verify", s.VerifyCertificate)
+		}
 		metrics := v2.Group("/metrics")
 		{
 			metrics.GET("/summary", s.FetchMetricsSummary)
@@ -321,6 +348,8 @@ func errorResponse(c *gin.Context, err error) {
 	switch {
 	case errors.Is(err, errNotFound):
 		code = http.StatusNotFound
+	case errors.Is(err, errCertVerificationDisabled):
+		code = http.StatusServiceUnavailable
 	default:
 		code = http.StatusInternalServerError
 	}
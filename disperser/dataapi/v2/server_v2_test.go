@@ -19,6 +19,8 @@ import (
 	"testing"
 	"time"
 
+	clientsmock "github.com/Layr-Labs/eigenda/api/clients/v2/mock"
+	"github.com/Layr-Labs/eigenda/api/clients/v2/verification"
 	"github.com/Layr-Labs/eigenda/common/aws"
 	"github.com/Layr-Labs/eigenda/common/aws/dynamodb"
 	commondynamodb "github.com/Layr-Labs/eigenda/common/aws/dynamodb"
@@ -83,7 +85,9 @@ var (
 	mockSubgraphApi   = &subgraphmock.MockSubgraphApi{}
 	subgraphClient    = dataapi.NewSubgraphClient(mockSubgraphApi, mockLogger)
 
-	config = dataapi.Config{ServerMode: "test", SocketAddr: ":8080", AllowOrigins: []string{"*"}, DisperserHostname: "localhost:32007", ChurnerHostname: "localhost:32009"}
+	config = dataapi.Config{ServerMode: "test", SocketAddr: ":8080", AllowOrigins: []string{"*"}, DisperserHostname: "localhost:32007", ChurnerHostname: "localhost:32009", CertVerifierAddress: "0x0000000000000000000000000000000000000001"}
+
+	mockCertVerifier = &clientsmock.MockCertVerifier{}
 
 	mockTx            = &coremock.MockWriter{}
 	opId0, _          = core.OperatorIDFromHex("e22dae12a0074f20b8fc96a0489376db34075e545ef60c4845d264a732568311")
@@ -222,7 +226,7 @@ func setup(m *testing.M) {
 		panic("failed to create dynamodb client: " + err.Error())
 	}
 	blobMetadataStore = blobstorev2.NewBlobMetadataStore(dynamoClient, logger, metadataTableName)
-	testDataApiServerV2 = serverv2.NewServerV2(config, blobMetadataStore, prometheusClient, subgraphClient, mockTx, mockChainState, mockIndexedChainState, mockLogger, dataapi.NewMetrics(serverVersion, nil, "9001", mockLogger))
+	testDataApiServerV2 = serverv2.NewServerV2(config, blobMetadataStore, prometheusClient, subgraphClient, mockTx, mockChainState, mockIndexedChainState, mockLogger, dataapi.NewMetrics(serverVersion, nil, "9001", mockLogger), mockCertVerifier)
 }
 
 // makeCommitment returns a test hardcoded BlobCommitments
@@ -390,6 +394,86 @@ func TestFetchBlobCertificate(t *testing.T) {
 	assert.Equal(t, blobCert.Signature, response.Certificate.Signature)
 }
 
+func TestVerifyCertificate(t *testing.T) {
+	r := setUpRouter()
+
+	blobHeader := makeBlobHeaderV2(t)
+	blobCert := &corev2.BlobCertificate{
+		BlobHeader: blobHeader,
+		Signature:  []byte{0, 1, 2, 3, 4},
+		RelayKeys:  []corev2.RelayKey{0, 2, 4},
+	}
+	blobKey, err := blobHeader.BlobKey()
+	require.NoError(t, err)
+
+	tree, err := corev2.BuildMerkleTree([]*corev2.BlobCertificate{blobCert})
+	require.NoError(t, err)
+	proof, err := tree.GenerateProofWithIndex(0, 0)
+	require.NoError(t, err)
+	inclusionInfo := &corev2.BlobInclusionInfo{
+		BlobIndex:      0,
+		InclusionProof: core.SerializeMerkleProof(proof),
+	}
+	inclusionInfoProto, err := inclusionInfo.ToProtobuf(blobCert)
+	require.NoError(t, err)
+	inclusionInfoBinding, err := verification.InclusionInfoProtoToBinding(inclusionInfoProto)
+	require.NoError(t, err)
+
+	cert := &verification.EigenDACert{
+		BlobInclusionInfo:   *inclusionInfoBinding,
+		SignedQuorumNumbers: blobHeader.QuorumNumbers,
+	}
+	copy(cert.BatchHeader.BatchRoot[:], tree.Root())
+
+	mockCertVerifier.On("GetQuorumNumbersRequired", mock.Anything, config.CertVerifierAddress).
+		Return(blobHeader.QuorumNumbers, nil).Once()
+	mockCertVerifier.On("VerifyCertV2", mock.Anything, config.CertVerifierAddress, mock.Anything).
+		Return(nil).Once()
+
+	r.POST("/v2/certs/verify", testDataApiServerV2.VerifyCertificate)
+
+	body, err := json.Marshal(cert)
+	require.NoError(t, err)
+	req := httptest.NewRequest(http.MethodPost, "/v2/certs/verify", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	response := decodeResponseBody[serverv2.CertVerificationResponse](t, w)
+
+	assert.True(t, response.Verified)
+	assert.Equal(t, blobKey.Hex(), response.BlobKey)
+	assert.Equal(t, 5, len(response.Checks))
+	for _, check := range response.Checks {
+		assert.True(t, check.Passed, check.Name)
+	}
+
+	// Tampering with the batch root fails the inclusion check
+	cert.BatchHeader.BatchRoot[0] ^= 0xff
+	mockCertVerifier.On("GetQuorumNumbersRequired", mock.Anything, config.CertVerifierAddress).
+		Return(blobHeader.QuorumNumbers, nil).Once()
+	mockCertVerifier.On("VerifyCertV2", mock.Anything, config.CertVerifierAddress, mock.Anything).
+		Return(fmt.Errorf("invalid cert")).Once()
+
+	body, err = json.Marshal(cert)
+	require.NoError(t, err)
+	req = httptest.NewRequest(http.MethodPost, "/v2/certs/verify", bytes.NewReader(body))
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	require.Equal(t, http.StatusOK, w.Code)
+	response = decodeResponseBody[serverv2.CertVerificationResponse](t, w)
+
+	assert.False(t, response.Verified)
+	for _, check := range response.Checks {
+		switch check.Name {
+		case "inclusion_proof", "onchain_verification":
+			assert.False(t, check.Passed, check.Name)
+			assert.NotEmpty(t, check.Error)
+		default:
+			assert.True(t, check.Passed, check.Name)
+		}
+	}
+}
+
 func TestFetchBlobFeed(t *testing.T) {
 	r := setUpRouter()
 	ctx := context.Background()
@@ -155,6 +155,8 @@ type ConfirmationInfo struct {
 	Fee                     []byte                               `json:"fee"`
 	QuorumResults           map[core.QuorumID]*core.QuorumResult `json:"quorum_results"`
 	BlobQuorumInfos         []*core.BlobQuorumInfo               `json:"blob_quorum_infos"`
+	// Simulated is set if the batch was not confirmed on chain because the batcher runs in simulation mode
+	Simulated bool `json:"simulated"`
 }
 
 type BlobStoreExclusiveStartKey struct {
@@ -214,10 +216,34 @@ type BlobStore interface {
 	// HandleBlobFailure handles a blob failure by either incrementing the retry count or marking the blob as failed
 	// Returns a boolean indicating whether the blob should be retried and an error
 	HandleBlobFailure(ctx context.Context, metadata *BlobMetadata, maxRetry uint) (bool, error)
+	// MetadataCapabilities returns the optional features of the backend storing blob metadata
+	MetadataCapabilities() MetadataCapabilities
+	// DeleteExpiredBlobMetadata deletes the metadata of expired blobs, and returns the number of blobs deleted.
+	// It does nothing if the metadata backend has the TTL capability.
+	DeleteExpiredBlobMetadata(ctx context.Context) (int64, error)
+}
+
+// MetadataCapabilities declares the optional features of the backend storing blob metadata, so that the disperser
+// can adapt to the backend it's deployed with
+type MetadataCapabilities struct {
+	// Transactions is true if the backend can read and update the metadata of a blob atomically.
+	// Without it, status transitions are checked and written in separate steps.
+	Transactions bool
+	// TTL is true if the backend deletes metadata once it has expired.
+	// Without it, expired metadata is deleted by the disperser.
+	TTL bool
+	// SecondaryIndexes is true if the backend can query metadata by status and by batch, rather than only by key.
+	// The batcher, and retrieving blobs by batch, require it.
+	SecondaryIndexes bool
 }
 
 type Dispatcher interface {
 	DisperseBatch(context.Context, *core.IndexedOperatorState, []core.EncodedBlob, *core.BatchHeader) chan core.SigningMessage
+	// SendBlobsToOperator stores the blobs of a minibatch on an operator, and returns its signatures on the blob
+	// header hashes. The signature of a blob the operator discarded is nil.
+	SendBlobsToOperator(ctx context.Context, blobs []*core.EncodedBlobMessage, batchHeader *core.BatchHeader, op *core.IndexedOperatorInfo) ([]*core.Signature, error)
+	// AttestBatch requests the operators to sign the batch made of the blobs they stored with SendBlobsToOperator
+	AttestBatch(ctx context.Context, state *core.IndexedOperatorState, blobHeaderHashes [][32]byte, batchHeader *core.BatchHeader) (chan core.SigningMessage, error)
 }
 
 // GenerateReverseIndexKey returns the key used to store the blob key in the reverse index
@@ -90,9 +90,18 @@ func (s *EncoderServerV2) Start() error {
 
 	// Register Server for Health Checks
 	name := pb.Encoder_ServiceDesc.ServiceName
-	healthcheck.RegisterHealthServer(name, gs)
+	ctx, cancel := context.WithCancel(context.Background())
+	dependencyChecker := healthcheck.NewDependencyChecker(s.logger,
+		healthcheck.Dependency{
+			Name:  "s3",
+			Check: s.blobStore.CheckHealth,
+		},
+	)
+	dependencyChecker.RegisterHealthServer(name, gs)
+	dependencyChecker.Start(ctx)
 
 	s.close = func() {
+		cancel()
 		err := listener.Close()
 		if err != nil {
 			log.Printf("failed to close listener: %v", err)
@@ -224,9 +224,9 @@ func createTestComponents(t *testing.T) *testComponents {
 
 	s3Client := mock.NewS3Client()
 	dynamoDBClient := &mock.MockDynamoDBClient{}
-	blobStore := blobstore.NewBlobStore(s3BucketName, s3Client, logger)
-	chunkStoreWriter := chunkstore.NewChunkWriter(logger, s3Client, s3BucketName, 512*1024)
-	chunkStoreReader := chunkstore.NewChunkReader(logger, s3Client, s3BucketName)
+	blobStore := blobstore.NewBlobStore(s3BucketName, s3Client, nil, logger)
+	chunkStoreWriter := chunkstore.NewChunkWriter(logger, s3Client, s3BucketName, 512*1024, nil)
+	chunkStoreReader := chunkstore.NewChunkReader(logger, s3Client, s3BucketName, nil)
 	encoderServer := encoder.NewEncoderServerV2(encoder.ServerConfig{
 		GrpcPort:              "8080",
 		MaxConcurrentRequests: 10,
@@ -64,3 +64,45 @@ func (d *Dispatcher) DisperseBatch(ctx context.Context, state *core.IndexedOpera
 
 	return update
 }
+
+func (d *Dispatcher) SendBlobsToOperator(ctx context.Context, blobs []*core.EncodedBlobMessage, batchHeader *core.BatchHeader, op *core.IndexedOperatorInfo) ([]*core.Signature, error) {
+	args := d.Called(ctx, blobs, batchHeader, op)
+	var signatures []*core.Signature
+	if args.Get(0) != nil {
+		signatures = args.Get(0).([]*core.Signature)
+	}
+	return signatures, args.Error(1)
+}
+
+func (d *Dispatcher) AttestBatch(ctx context.Context, state *core.IndexedOperatorState, blobHeaderHashes [][32]byte, batchHeader *core.BatchHeader) (chan core.SigningMessage, error) {
+	args := d.Called(blobHeaderHashes)
+	var nonSigners map[core.OperatorID]struct{}
+	if args.Get(0) != nil {
+		nonSigners = args.Get(0).(map[core.OperatorID]struct{})
+	}
+	if args.Error(1) != nil {
+		return nil, args.Error(1)
+	}
+	message, err := batchHeader.GetBatchHeaderHash()
+	if err != nil {
+		return nil, err
+	}
+
+	update := make(chan core.SigningMessage, len(state.IndexedOperators))
+	for id := range state.IndexedOperators {
+		if _, ok := nonSigners[id]; ok {
+			update <- core.SigningMessage{
+				Signature: nil,
+				Operator:  id,
+				Err:       errors.New("not a signer"),
+			}
+			continue
+		}
+		update <- core.SigningMessage{
+			Signature: d.state.PrivateOperators[id].KeyPair.SignMessage(message),
+			Operator:  id,
+			Err:       nil,
+		}
+	}
+	return update, nil
+}
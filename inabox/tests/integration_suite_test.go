@@ -179,7 +179,7 @@ func setupRetrievalClient(testConfig *deploy.Config) error {
 
 	cs := eth.NewChainState(tx, ethClient)
 	agn := &core.StdAssignmentCoordinator{}
-	nodeClient := clients.NewNodeClient(20 * time.Second)
+	nodeClient := clients.NewNodeClient(20*time.Second, nil)
 	srsOrder, err := strconv.Atoi(testConfig.Retriever.RETRIEVER_SRS_ORDER)
 	if err != nil {
 		return err
@@ -227,7 +227,12 @@ func setupRetrievalClient(testConfig *deploy.Config) error {
 	if err != nil {
 		return err
 	}
-	retrievalClientV2 = clientsv2.NewRetrievalClient(logger, chainReader, ics, v, 10)
+	retrievalClientConfig := clientsv2.GetDefaultRetrievalClientConfig()
+	retrievalClientConfig.MaxConnectionCount = 10
+	retrievalClientV2, err = clientsv2.NewRetrievalClient(logger, chainReader, ics, v, *retrievalClientConfig)
+	if err != nil {
+		return err
+	}
 
 	return ics.Start(context.Background())
 }
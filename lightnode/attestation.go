@@ -0,0 +1,84 @@
+package lightnode
+
+import (
+	"crypto/ecdsa"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/Layr-Labs/eigenda/core"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"golang.org/x/crypto/sha3"
+)
+
+// Attestation is a light node's signed statement of whether the chunks assigned to a validator for a blob could be
+// retrieved and verified.
+type Attestation struct {
+	BlobKey              corev2.BlobKey  `json:"blob_key"`
+	QuorumID             core.QuorumID   `json:"quorum_id"`
+	OperatorID           core.OperatorID `json:"operator_id"`
+	ReferenceBlockNumber uint64          `json:"reference_block_number"`
+	// ChunkIndices are the indices of the sampled chunks
+	ChunkIndices []uint32 `json:"chunk_indices"`
+	// Available is true if the sampled chunks were retrieved from the validator and verified against the blob commitment
+	Available bool `json:"available"`
+	// Timestamp is the UNIX time in seconds at which the chunks were sampled
+	Timestamp int64 `json:"timestamp"`
+	// Sampler is the address of the light node which sampled the chunks
+	Sampler   gethcommon.Address `json:"sampler"`
+	Signature []byte             `json:"signature"`
+}
+
+// Hash returns the keccak256 hash of the attestation's fields, which is the message signed by the sampler.
+func (a *Attestation) Hash() [32]byte {
+	hasher := sha3.NewLegacyKeccak256()
+	hasher.Write(a.BlobKey[:])
+	hasher.Write([]byte{a.QuorumID})
+	hasher.Write(a.OperatorID[:])
+	hasher.Write(binary.BigEndian.AppendUint64(nil, a.ReferenceBlockNumber))
+	hasher.Write(binary.BigEndian.AppendUint32(nil, uint32(len(a.ChunkIndices))))
+	for _, index := range a.ChunkIndices {
+		hasher.Write(binary.BigEndian.AppendUint32(nil, index))
+	}
+	if a.Available {
+		hasher.Write([]byte{1})
+	} else {
+		hasher.Write([]byte{0})
+	}
+	hasher.Write(binary.BigEndian.AppendUint64(nil, uint64(a.Timestamp)))
+	hasher.Write(a.Sampler[:])
+
+	var hash [32]byte
+	copy(hash[:], hasher.Sum(nil))
+	return hash
+}
+
+// Sign sets the sampler of the attestation to the address of the given key and signs the attestation with it.
+func (a *Attestation) Sign(key *ecdsa.PrivateKey) error {
+	a.Sampler = crypto.PubkeyToAddress(key.PublicKey)
+	hash := a.Hash()
+	signature, err := crypto.Sign(hash[:], key)
+	if err != nil {
+		return fmt.Errorf("failed to sign attestation: %w", err)
+	}
+	a.Signature = signature
+	return nil
+}
+
+// Verify checks that the attestation is signed by its sampler.
+func (a *Attestation) Verify() error {
+	if len(a.Signature) != 65 {
+		return fmt.Errorf("signature must be 65 bytes, got %d", len(a.Signature))
+	}
+	hash := a.Hash()
+	pubKey, err := crypto.SigToPub(hash[:], a.Signature)
+	if err != nil {
+		return fmt.Errorf("failed to recover public key from signature: %w", err)
+	}
+	if crypto.PubkeyToAddress(*pubKey) != a.Sampler {
+		return errors.New("attestation is not signed by its sampler")
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package lightnode
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/geth"
+	"github.com/Layr-Labs/eigenda/core/thegraph"
+	"github.com/Layr-Labs/eigenda/encoding/kzg"
+	"github.com/Layr-Labs/eigenda/lightnode/flags"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/urfave/cli"
+)
+
+type Config struct {
+	EncoderConfig    kzg.KzgConfig
+	EthClientConfig  geth.EthClientConfig
+	LoggerConfig     common.LoggerConfig
+	ChainStateConfig thegraph.Config
+
+	DataApiUrl                    string
+	BLSOperatorStateRetrieverAddr string
+	EigenDAServiceManagerAddr     string
+	// SignerKey is the key with which the light node signs its availability attestations
+	SignerKey *ecdsa.PrivateKey
+	HTTPPort  string
+	// Peers are the URLs of the light nodes to which availability attestations are gossiped
+	Peers []string
+
+	SampleInterval  time.Duration
+	SamplesPerRound int
+	ChunksPerSample int
+	RecentBlobs     int
+	Timeout         time.Duration
+}
+
+func NewConfig(ctx *cli.Context) (*Config, error) {
+	loggerConfig, err := common.ReadLoggerCLIConfig(ctx, flags.FlagPrefix)
+	if err != nil {
+		return nil, err
+	}
+	signerKey, err := crypto.HexToECDSA(strings.TrimPrefix(ctx.GlobalString(flags.SignerPrivateKeyHexFlag.Name), "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse signer private key: %w", err)
+	}
+
+	config := &Config{
+		LoggerConfig:                  *loggerConfig,
+		EncoderConfig:                 kzg.ReadCLIConfig(ctx),
+		EthClientConfig:               geth.ReadEthClientConfig(ctx),
+		ChainStateConfig:              thegraph.ReadCLIConfig(ctx),
+		DataApiUrl:                    strings.TrimSuffix(ctx.GlobalString(flags.DataApiUrlFlag.Name), "/"),
+		BLSOperatorStateRetrieverAddr: ctx.GlobalString(flags.BlsOperatorStateRetrieverFlag.Name),
+		EigenDAServiceManagerAddr:     ctx.GlobalString(flags.EigenDAServiceManagerFlag.Name),
+		SignerKey:                     signerKey,
+		HTTPPort:                      ctx.GlobalString(flags.HTTPPortFlag.Name),
+		Peers:                         ctx.GlobalStringSlice(flags.PeersFlag.Name),
+		SampleInterval:                ctx.GlobalDuration(flags.SampleIntervalFlag.Name),
+		SamplesPerRound:               ctx.GlobalInt(flags.SamplesPerRoundFlag.Name),
+		ChunksPerSample:               ctx.GlobalInt(flags.ChunksPerSampleFlag.Name),
+		RecentBlobs:                   ctx.GlobalInt(flags.RecentBlobsFlag.Name),
+		Timeout:                       ctx.GlobalDuration(flags.TimeoutFlag.Name),
+	}
+	if config.SampleInterval <= 0 {
+		return nil, errors.New("sample interval must be positive")
+	}
+	if config.SamplesPerRound <= 0 || config.ChunksPerSample <= 0 || config.RecentBlobs <= 0 {
+		return nil, errors.New("samples per round, chunks per sample and recent blobs must be positive")
+	}
+	return config, nil
+}
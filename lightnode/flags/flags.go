@@ -0,0 +1,121 @@
+package flags
+
+import (
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/geth"
+	"github.com/Layr-Labs/eigenda/core/thegraph"
+	"github.com/Layr-Labs/eigenda/encoding/kzg"
+	"github.com/urfave/cli"
+)
+
+const (
+	FlagPrefix = "lightnode"
+	envPrefix  = "LIGHTNODE"
+)
+
+var (
+	/* Required Flags */
+	DataApiUrlFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "data-api-url"),
+		Usage:    "URL of the data api from which recently certified blobs are discovered",
+		Required: true,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "DATA_API_URL"),
+	}
+	BlsOperatorStateRetrieverFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "bls-operator-state-retriever"),
+		Usage:    "Address of the BLS Operator State Retriever",
+		Required: true,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "BLS_OPERATOR_STATE_RETRIVER"),
+	}
+	EigenDAServiceManagerFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "eigenda-service-manager"),
+		Usage:    "Address of the EigenDA Service Manager",
+		Required: true,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "EIGENDA_SERVICE_MANAGER"),
+	}
+	SignerPrivateKeyHexFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "signer-private-key-hex"),
+		Usage:    "Hex encoded ECDSA private key with which the light node signs its availability attestations",
+		Required: true,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "SIGNER_PRIVATE_KEY_HEX"),
+	}
+
+	/* Optional Flags*/
+	HTTPPortFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "http-port"),
+		Usage:    "Port at which the light node serves and receives availability attestations",
+		Required: false,
+		Value:    "9600",
+		EnvVar:   common.PrefixEnvVar(envPrefix, "HTTP_PORT"),
+	}
+	PeersFlag = cli.StringSliceFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "peers"),
+		Usage:    "URLs of the light nodes to which availability attestations are gossiped",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "PEERS"),
+	}
+	SampleIntervalFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "sample-interval"),
+		Usage:    "Interval between sampling rounds",
+		Required: false,
+		Value:    30 * time.Second,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "SAMPLE_INTERVAL"),
+	}
+	SamplesPerRoundFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "samples-per-round"),
+		Usage:    "Number of blobs sampled in each sampling round",
+		Required: false,
+		Value:    4,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "SAMPLES_PER_ROUND"),
+	}
+	ChunksPerSampleFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "chunks-per-sample"),
+		Usage:    "Maximum number of the chunks fetched from a validator which are verified for each sample",
+		Required: false,
+		Value:    8,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "CHUNKS_PER_SAMPLE"),
+	}
+	RecentBlobsFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "recent-blobs"),
+		Usage:    "Number of the most recently dispersed blobs from which samples are drawn",
+		Required: false,
+		Value:    50,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "RECENT_BLOBS"),
+	}
+	TimeoutFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "timeout"),
+		Usage:    "Timeout of the requests to the data api, validators and peers",
+		Required: false,
+		Value:    10 * time.Second,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "TIMEOUT"),
+	}
+)
+
+func LightNodeFlags(envPrefix string) []cli.Flag {
+	return []cli.Flag{
+		DataApiUrlFlag,
+		BlsOperatorStateRetrieverFlag,
+		EigenDAServiceManagerFlag,
+		SignerPrivateKeyHexFlag,
+		HTTPPortFlag,
+		PeersFlag,
+		SampleIntervalFlag,
+		SamplesPerRoundFlag,
+		ChunksPerSampleFlag,
+		RecentBlobsFlag,
+		TimeoutFlag,
+	}
+}
+
+// Flags contains the list of configuration options available to the binary.
+var Flags []cli.Flag
+
+func init() {
+	Flags = append(Flags, LightNodeFlags(envPrefix)...)
+	Flags = append(Flags, kzg.CLIFlags(envPrefix)...)
+	Flags = append(Flags, geth.EthClientFlags(envPrefix)...)
+	Flags = append(Flags, common.LoggerCLIFlags(envPrefix, FlagPrefix)...)
+	Flags = append(Flags, thegraph.CLIFlags(envPrefix)...)
+}
@@ -0,0 +1,178 @@
+package lightnode
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+const (
+	// AttestationsPath is the path at which light nodes receive and serve availability attestations
+	AttestationsPath = "/api/v1/attestations"
+
+	// attestationRetention is how long attestations are kept and relayed. Older attestations are rejected, so that
+	// they can't be relayed again once they're forgotten.
+	attestationRetention = time.Hour
+	// maxClockSkew is how far in the future the timestamp of an attestation may be
+	maxClockSkew = time.Minute
+	// maxAttestationSize is the maximum size of a gossiped attestation, in bytes
+	maxAttestationSize = 64 * 1024
+)
+
+// Gossip keeps the recent availability attestations of the light nodes, and relays the attestations it hasn't seen
+// before to its peers, so that every light node eventually receives the attestations of the others.
+type Gossip struct {
+	peers  []string
+	client *http.Client
+	logger logging.Logger
+
+	mu sync.Mutex
+	// attestations are the recent attestations, by hash
+	attestations map[[32]byte]*Attestation
+}
+
+// NewGossip creates a new Gossip which relays attestations to the light nodes at the given URLs.
+func NewGossip(peers []string, timeout time.Duration, logger logging.Logger) *Gossip {
+	return &Gossip{
+		peers:        peers,
+		client:       &http.Client{Timeout: timeout},
+		logger:       logger.With("component", "Gossip"),
+		attestations: make(map[[32]byte]*Attestation),
+	}
+}
+
+// Start serves the attestations at the given socket address in the background, until the context is cancelled.
+func (g *Gossip) Start(ctx context.Context, socketAddr string) {
+	mux := http.NewServeMux()
+	mux.HandleFunc(AttestationsPath, g.ServeHTTP)
+	server := &http.Server{
+		Addr:              socketAddr,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			g.logger.Error("Gossip server failed", "err", err)
+		}
+	}()
+	go func() {
+		<-ctx.Done()
+		_ = server.Close()
+	}()
+}
+
+// ServeHTTP receives attestations gossiped by peers on POST, and lists the recent attestations on GET.
+func (g *Gossip) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(g.Attestations(time.Now())); err != nil {
+			g.logger.Error("Failed to write attestations", "err", err)
+		}
+	case http.MethodPost:
+		attestation := &Attestation{}
+		if err := json.NewDecoder(io.LimitReader(r.Body, maxAttestationSize)).Decode(attestation); err != nil {
+			http.Error(w, fmt.Sprintf("invalid attestation: %v", err), http.StatusBadRequest)
+			return
+		}
+		if err := g.receive(attestation, time.Now()); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// Publish records an attestation made by this light node and relays it to the peers.
+func (g *Gossip) Publish(attestation *Attestation) error {
+	return g.receive(attestation, time.Now())
+}
+
+// Attestations returns the attestations received within the retention before now, oldest first.
+func (g *Gossip) Attestations(now time.Time) []*Attestation {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.prune(now)
+
+	attestations := make([]*Attestation, 0, len(g.attestations))
+	for _, attestation := range g.attestations {
+		attestations = append(attestations, attestation)
+	}
+	sort.Slice(attestations, func(i, j int) bool {
+		return attestations[i].Timestamp < attestations[j].Timestamp
+	})
+	return attestations
+}
+
+// receive validates an attestation and, if it hasn't been seen before, records it and relays it to the peers in the
+// background.
+func (g *Gossip) receive(attestation *Attestation, now time.Time) error {
+	timestamp := time.Unix(attestation.Timestamp, 0)
+	if timestamp.Before(now.Add(-attestationRetention)) {
+		return fmt.Errorf("attestation is older than %s", attestationRetention)
+	}
+	if timestamp.After(now.Add(maxClockSkew)) {
+		return errors.New("attestation is from the future")
+	}
+	if err := attestation.Verify(); err != nil {
+		return err
+	}
+
+	hash := attestation.Hash()
+	g.mu.Lock()
+	g.prune(now)
+	_, seen := g.attestations[hash]
+	if !seen {
+		g.attestations[hash] = attestation
+	}
+	g.mu.Unlock()
+	if seen {
+		return nil
+	}
+
+	body, err := json.Marshal(attestation)
+	if err != nil {
+		return fmt.Errorf("failed to serialize attestation: %w", err)
+	}
+	for _, peer := range g.peers {
+		peer := peer
+		go g.relay(peer, body)
+	}
+	return nil
+}
+
+// relay sends a serialized attestation to a peer.
+func (g *Gossip) relay(peer string, body []byte) {
+	resp, err := g.client.Post(peer+AttestationsPath, "application/json", bytes.NewReader(body))
+	if err != nil {
+		g.logger.Debug("Failed to relay attestation", "peer", peer, "err", err)
+		return
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode != http.StatusAccepted {
+		g.logger.Debug("Peer rejected attestation", "peer", peer, "status", resp.StatusCode)
+	}
+}
+
+// prune drops the attestations older than the retention. The caller must hold the lock.
+func (g *Gossip) prune(now time.Time) {
+	oldest := now.Add(-attestationRetention).Unix()
+	for hash, attestation := range g.attestations {
+		if attestation.Timestamp < oldest {
+			delete(g.attestations, hash)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package lightnode_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common/testutils"
+	"github.com/Layr-Labs/eigenda/core"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/lightnode"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func newAttestation(t *testing.T, timestamp time.Time) *lightnode.Attestation {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	attestation := &lightnode.Attestation{
+		BlobKey:              corev2.BlobKey{1, 2, 3},
+		QuorumID:             1,
+		OperatorID:           core.OperatorID{4, 5, 6},
+		ReferenceBlockNumber: 100,
+		ChunkIndices:         []uint32{7, 8},
+		Available:            true,
+		Timestamp:            timestamp.Unix(),
+	}
+	require.NoError(t, attestation.Sign(key))
+	return attestation
+}
+
+func TestAttestationSignature(t *testing.T) {
+	attestation := newAttestation(t, time.Now())
+	require.NoError(t, attestation.Verify())
+
+	attestation.Available = false
+	require.Error(t, attestation.Verify())
+}
+
+func TestGossip(t *testing.T) {
+	logger := testutils.GetLogger()
+
+	// a relays to b, which relays back to a
+	var b *lightnode.Gossip
+	serverB := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b.ServeHTTP(w, r)
+	}))
+	defer serverB.Close()
+	a := lightnode.NewGossip([]string{serverB.URL}, time.Second, logger)
+	serverA := httptest.NewServer(a)
+	defer serverA.Close()
+	b = lightnode.NewGossip([]string{serverA.URL}, time.Second, logger)
+
+	attestation := newAttestation(t, time.Now())
+	require.NoError(t, a.Publish(attestation))
+	require.Eventually(t, func() bool {
+		return len(b.Attestations(time.Now())) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Equal(t, attestation.Hash(), b.Attestations(time.Now())[0].Hash())
+	// The attestation relayed back to a is deduplicated
+	require.Never(t, func() bool {
+		return len(a.Attestations(time.Now())) != 1
+	}, 200*time.Millisecond, 10*time.Millisecond)
+
+	post := func(attestation *lightnode.Attestation) int {
+		body, err := json.Marshal(attestation)
+		require.NoError(t, err)
+		resp, err := http.Post(serverA.URL+lightnode.AttestationsPath, "application/json", bytes.NewReader(body))
+		require.NoError(t, err)
+		defer func() {
+			_ = resp.Body.Close()
+		}()
+		return resp.StatusCode
+	}
+
+	// Tampered attestations are rejected
+	tampered := newAttestation(t, time.Now())
+	tampered.OperatorID = core.OperatorID{9}
+	require.Equal(t, http.StatusBadRequest, post(tampered))
+	// Expired attestations are rejected
+	require.Equal(t, http.StatusBadRequest, post(newAttestation(t, time.Now().Add(-2*time.Hour))))
+	require.Equal(t, http.StatusAccepted, post(newAttestation(t, time.Now())))
+
+	resp, err := http.Get(serverA.URL + lightnode.AttestationsPath)
+	require.NoError(t, err)
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	var attestations []*lightnode.Attestation
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&attestations))
+	require.Len(t, attestations, 2)
+}
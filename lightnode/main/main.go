@@ -1,8 +1,83 @@
 package main
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/geth"
+	"github.com/Layr-Labs/eigenda/core/eth"
+	"github.com/Layr-Labs/eigenda/core/thegraph"
+	"github.com/Layr-Labs/eigenda/encoding/kzg/verifier"
+	"github.com/Layr-Labs/eigenda/lightnode"
+	"github.com/Layr-Labs/eigenda/lightnode/flags"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli"
+)
+
+var (
+	Version   = ""
+	GitCommit = ""
+	GitDate   = ""
+)
 
 // main is the entrypoint for the light node.
 func main() {
-	fmt.Println("Hello world")
+	app := cli.NewApp()
+	app.Version = fmt.Sprintf("%s-%s-%s", Version, GitCommit, GitDate)
+	app.Name = "lnode"
+	app.Usage = "EigenDA Light Node"
+	app.Description = "Samples random chunks of recent blobs from validators and gossips availability attestations"
+	app.Flags = flags.Flags
+	app.Action = LightNodeMain
+	if err := app.Run(os.Args); err != nil {
+		log.Fatalf("application failed: %v", err)
+	}
+}
+
+func LightNodeMain(ctx *cli.Context) error {
+	config, err := lightnode.NewConfig(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to parse the command line flags: %w", err)
+	}
+	logger, err := common.NewLogger(config.LoggerConfig)
+	if err != nil {
+		return fmt.Errorf("failed to create logger: %w", err)
+	}
+
+	config.EncoderConfig.LoadG2Points = true
+	v, err := verifier.NewVerifier(&config.EncoderConfig, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create verifier: %w", err)
+	}
+	gethClient, err := geth.NewMultiHomingClient(config.EthClientConfig, gethcommon.Address{}, logger)
+	if err != nil {
+		return fmt.Errorf("failed to create eth client: %w", err)
+	}
+	tx, err := eth.NewReader(logger, gethClient, config.BLSOperatorStateRetrieverAddr, config.EigenDAServiceManagerAddr)
+	if err != nil {
+		return fmt.Errorf("failed to create eth reader: %w", err)
+	}
+	cs := eth.NewChainState(tx, gethClient)
+	logger.Info("Connecting to subgraph", "url", config.ChainStateConfig.Endpoint)
+	ics := thegraph.MakeIndexedChainState(config.ChainStateConfig, cs, logger)
+
+	runCtx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	gossip := lightnode.NewGossip(config.Peers, config.Timeout, logger)
+	gossip.Start(runCtx, fmt.Sprintf(":%s", config.HTTPPort))
+
+	blobSource := lightnode.NewDataApiBlobSource(config.DataApiUrl, &http.Client{Timeout: config.Timeout})
+	sampler := lightnode.NewSampler(config, blobSource, lightnode.NewGrpcChunkSource(), ics, tx, v, gossip, logger)
+	sampler.Start(runCtx, config.SampleInterval)
+
+	logger.Info("Light node started", "httpPort", config.HTTPPort, "peers", len(config.Peers))
+	<-runCtx.Done()
+	return nil
 }
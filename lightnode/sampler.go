@@ -0,0 +1,248 @@
+package lightnode
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/core"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+// Blob is a recently dispersed blob which can be sampled.
+type Blob struct {
+	Key    corev2.BlobKey
+	Header *corev2.BlobHeader
+}
+
+// BlobSource discovers the blobs to sample.
+type BlobSource interface {
+	// GetRecentBlobs returns up to limit of the most recently certified blobs.
+	GetRecentBlobs(ctx context.Context, limit int) ([]*Blob, error)
+	// GetReferenceBlockNumber returns the reference block number of the batch in which a blob was certified.
+	GetReferenceBlockNumber(ctx context.Context, blobKey corev2.BlobKey) (uint64, error)
+}
+
+// ChunkSource fetches the chunks of a blob from validators.
+type ChunkSource interface {
+	// GetChunks returns the chunks of the blob in the quorum held by the validator at the given socket.
+	GetChunks(ctx context.Context, socket core.OperatorSocket, blobKey corev2.BlobKey, quorumID core.QuorumID) ([]*encoding.Frame, error)
+}
+
+// BlobParamsReader reads the parameters of the blob versions from the chain.
+type BlobParamsReader interface {
+	GetAllVersionedBlobParams(ctx context.Context) (map[corev2.BlobVersion]*core.BlobVersionParameters, error)
+}
+
+// Sampler periodically samples random chunks of recently certified blobs from the validators they're assigned to,
+// verifies them against the blob commitments, and publishes signed availability attestations. Unlike a validator,
+// it doesn't store any chunks, so it's cheap to run and gives an availability signal independent of the validators'
+// own attestations.
+type Sampler struct {
+	blobSource  BlobSource
+	chunkSource ChunkSource
+	chainState  core.IndexedChainState
+	blobParams  BlobParamsReader
+	verifier    encoding.Verifier
+	publisher   *Gossip
+	key         *ecdsa.PrivateKey
+	logger      logging.Logger
+
+	samplesPerRound int
+	chunksPerSample int
+	recentBlobs     int
+	timeout         time.Duration
+	random          *rand.Rand
+}
+
+// NewSampler creates a new Sampler, which publishes its attestations through the given Gossip.
+func NewSampler(
+	config *Config,
+	blobSource BlobSource,
+	chunkSource ChunkSource,
+	chainState core.IndexedChainState,
+	blobParams BlobParamsReader,
+	verifier encoding.Verifier,
+	publisher *Gossip,
+	logger logging.Logger,
+) *Sampler {
+	return &Sampler{
+		blobSource:      blobSource,
+		chunkSource:     chunkSource,
+		chainState:      chainState,
+		blobParams:      blobParams,
+		verifier:        verifier,
+		publisher:       publisher,
+		key:             config.SignerKey,
+		logger:          logger.With("component", "Sampler"),
+		samplesPerRound: config.SamplesPerRound,
+		chunksPerSample: config.ChunksPerSample,
+		recentBlobs:     config.RecentBlobs,
+		timeout:         config.Timeout,
+		random:          rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// Start runs a sampling round at every interval in the background, until the context is cancelled.
+func (s *Sampler) Start(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if _, err := s.SampleRound(ctx); err != nil {
+					s.logger.Error("Sampling round failed", "err", err)
+				}
+			}
+		}
+	}()
+}
+
+// SampleRound samples random chunks of random recent blobs, and publishes an attestation for each sample. Failures
+// to sample which can't be attributed to the validator, such as failing to reach the data api, are logged and don't
+// produce an attestation.
+func (s *Sampler) SampleRound(ctx context.Context) ([]*Attestation, error) {
+	blobs, err := s.blobSource.GetRecentBlobs(ctx, s.recentBlobs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recent blobs: %w", err)
+	}
+	if len(blobs) == 0 {
+		s.logger.Debug("No recent blobs to sample")
+		return nil, nil
+	}
+	blobParams, err := s.blobParams.GetAllVersionedBlobParams(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blob params: %w", err)
+	}
+
+	attestations := make([]*Attestation, 0, s.samplesPerRound)
+	for i := 0; i < s.samplesPerRound; i++ {
+		blob := blobs[s.random.Intn(len(blobs))]
+		attestation, err := s.sample(ctx, blob, blobParams)
+		if err != nil {
+			s.logger.Warn("Failed to sample blob", "blobKey", blob.Key.Hex(), "err", err)
+			continue
+		}
+		if err := s.publisher.Publish(attestation); err != nil {
+			s.logger.Warn("Failed to publish attestation", "blobKey", blob.Key.Hex(), "err", err)
+		}
+		attestations = append(attestations, attestation)
+	}
+	return attestations, nil
+}
+
+// sample samples the chunks of a blob held by a random validator of a random quorum of the blob.
+func (s *Sampler) sample(
+	ctx context.Context,
+	blob *Blob,
+	blobParams map[corev2.BlobVersion]*core.BlobVersionParameters,
+) (*Attestation, error) {
+	header := blob.Header
+	if header == nil || len(header.QuorumNumbers) == 0 {
+		return nil, errors.New("blob has no quorums")
+	}
+	params, ok := blobParams[header.BlobVersion]
+	if !ok {
+		return nil, fmt.Errorf("unknown blob version %d", header.BlobVersion)
+	}
+	encodingParams, err := corev2.GetEncodingParams(header.BlobCommitments.Length, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get encoding params: %w", err)
+	}
+	referenceBlockNumber, err := s.blobSource.GetReferenceBlockNumber(ctx, blob.Key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reference block number: %w", err)
+	}
+
+	quorumID := header.QuorumNumbers[s.random.Intn(len(header.QuorumNumbers))]
+	state, err := s.chainState.GetIndexedOperatorState(ctx, uint(referenceBlockNumber), []core.QuorumID{quorumID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get operator state at block %d: %w", referenceBlockNumber, err)
+	}
+	assignments, err := corev2.GetAssignments(state.OperatorState, params, quorumID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get assignments: %w", err)
+	}
+	operatorIDs := make([]core.OperatorID, 0, len(assignments))
+	for operatorID, assignment := range assignments {
+		if _, ok := state.IndexedOperators[operatorID]; ok && assignment.NumChunks > 0 {
+			operatorIDs = append(operatorIDs, operatorID)
+		}
+	}
+	if len(operatorIDs) == 0 {
+		return nil, fmt.Errorf("no operators are assigned chunks in quorum %d", quorumID)
+	}
+	// Sort the operators so that the random choice only depends on the random source
+	sort.Slice(operatorIDs, func(i, j int) bool {
+		return operatorIDs[i].Hex() < operatorIDs[j].Hex()
+	})
+	operatorID := operatorIDs[s.random.Intn(len(operatorIDs))]
+	assignment := assignments[operatorID]
+
+	attestation := &Attestation{
+		BlobKey:              blob.Key,
+		QuorumID:             quorumID,
+		OperatorID:           operatorID,
+		ReferenceBlockNumber: referenceBlockNumber,
+		Timestamp:            time.Now().Unix(),
+	}
+	attestation.ChunkIndices, attestation.Available = s.sampleChunks(
+		ctx, blob, quorumID, operatorID, state.IndexedOperators[operatorID], assignment, encodingParams)
+
+	if err := attestation.Sign(s.key); err != nil {
+		return nil, err
+	}
+	return attestation, nil
+}
+
+// sampleChunks fetches the chunks assigned to a validator and verifies a random subset of them. It returns the
+// indices of the verified chunks and whether they're available.
+func (s *Sampler) sampleChunks(
+	ctx context.Context,
+	blob *Blob,
+	quorumID core.QuorumID,
+	operatorID core.OperatorID,
+	operator *core.IndexedOperatorInfo,
+	assignment corev2.Assignment,
+	encodingParams encoding.EncodingParams,
+) ([]uint32, bool) {
+	blobKey := blob.Key
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	chunks, err := s.chunkSource.GetChunks(ctx, core.OperatorSocket(operator.Socket), blobKey, quorumID)
+	if err != nil {
+		s.logger.Info("Failed to get chunks from operator", "operator", operatorID.Hex(), "blobKey", blobKey.Hex(), "err", err)
+		return nil, false
+	}
+	indices := assignment.GetIndices()
+	if len(chunks) != len(indices) {
+		s.logger.Info("Operator returned the wrong number of chunks", "operator", operatorID.Hex(), "blobKey", blobKey.Hex(), "expected", len(indices), "got", len(chunks))
+		return nil, false
+	}
+
+	sampleSize := min(s.chunksPerSample, len(chunks))
+	sampledChunks := make([]*encoding.Frame, sampleSize)
+	sampledIndices := make([]uint32, sampleSize)
+	chunkNumbers := make([]encoding.ChunkNumber, sampleSize)
+	for i, position := range s.random.Perm(len(chunks))[:sampleSize] {
+		sampledChunks[i] = chunks[position]
+		sampledIndices[i] = indices[position]
+		chunkNumbers[i] = encoding.ChunkNumber(indices[position])
+	}
+
+	if err := s.verifier.VerifyFrames(sampledChunks, chunkNumbers, blob.Header.BlobCommitments, encodingParams); err != nil {
+		s.logger.Info("Failed to verify chunks from operator", "operator", operatorID.Hex(), "blobKey", blobKey.Hex(), "err", err)
+		return sampledIndices, false
+	}
+	return sampledIndices, true
+}
@@ -0,0 +1,148 @@
+package lightnode_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common/testutils"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/core/mock"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/Layr-Labs/eigenda/lightnode"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+var blobParams = map[corev2.BlobVersion]*core.BlobVersionParameters{
+	0: {
+		NumChunks:       8192,
+		CodingRate:      8,
+		MaxNumOperators: 3537,
+	},
+}
+
+type fakeBlobSource struct {
+	blobs []*lightnode.Blob
+}
+
+func (s *fakeBlobSource) GetRecentBlobs(ctx context.Context, limit int) ([]*lightnode.Blob, error) {
+	return s.blobs, nil
+}
+
+func (s *fakeBlobSource) GetReferenceBlockNumber(ctx context.Context, blobKey corev2.BlobKey) (uint64, error) {
+	return 100, nil
+}
+
+type fakeChunkSource struct {
+	// numChunks is the number of chunks held by the validator at each socket
+	numChunks map[core.OperatorSocket]int
+	err       error
+}
+
+func (s *fakeChunkSource) GetChunks(ctx context.Context, socket core.OperatorSocket, blobKey corev2.BlobKey, quorumID core.QuorumID) ([]*encoding.Frame, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	chunks := make([]*encoding.Frame, s.numChunks[socket])
+	for i := range chunks {
+		chunks[i] = &encoding.Frame{}
+	}
+	return chunks, nil
+}
+
+type fakeBlobParamsReader struct{}
+
+func (fakeBlobParamsReader) GetAllVersionedBlobParams(ctx context.Context) (map[corev2.BlobVersion]*core.BlobVersionParameters, error) {
+	return blobParams, nil
+}
+
+type fakeVerifier struct {
+	encoding.Verifier
+	err error
+	// numChunks is the number of chunks of each verification
+	numChunks []int
+}
+
+func (v *fakeVerifier) VerifyFrames(chunks []*encoding.Frame, indices []encoding.ChunkNumber, commitments encoding.BlobCommitments, params encoding.EncodingParams) error {
+	v.numChunks = append(v.numChunks, len(chunks))
+	return v.err
+}
+
+func TestSampleRound(t *testing.T) {
+	ctx := context.Background()
+	logger := testutils.GetLogger()
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	chainState, err := mock.MakeChainDataMock(map[core.QuorumID]int{0: 3})
+	require.NoError(t, err)
+	state, err := chainState.GetIndexedOperatorState(ctx, 100, []core.QuorumID{0})
+	require.NoError(t, err)
+	assignments, err := corev2.GetAssignments(state.OperatorState, blobParams[0], 0)
+	require.NoError(t, err)
+	chunkSource := &fakeChunkSource{numChunks: make(map[core.OperatorSocket]int)}
+	for operatorID, assignment := range assignments {
+		chunkSource.numChunks[core.OperatorSocket(state.IndexedOperators[operatorID].Socket)] = int(assignment.NumChunks)
+	}
+
+	blobSource := &fakeBlobSource{blobs: []*lightnode.Blob{{
+		Key: corev2.BlobKey{1},
+		Header: &corev2.BlobHeader{
+			BlobVersion:     0,
+			BlobCommitments: encoding.BlobCommitments{Length: 16},
+			QuorumNumbers:   []core.QuorumID{0},
+		},
+	}}}
+	verifier := &fakeVerifier{}
+	gossip := lightnode.NewGossip(nil, time.Second, logger)
+	config := &lightnode.Config{
+		SignerKey:       key,
+		SamplesPerRound: 4,
+		ChunksPerSample: 2,
+		RecentBlobs:     10,
+		Timeout:         time.Second,
+	}
+	sampler := lightnode.NewSampler(config, blobSource, chunkSource, chainState, fakeBlobParamsReader{}, verifier, gossip, logger)
+
+	attestations, err := sampler.SampleRound(ctx)
+	require.NoError(t, err)
+	require.Len(t, attestations, 4)
+	for _, attestation := range attestations {
+		require.True(t, attestation.Available)
+		require.NoError(t, attestation.Verify())
+		require.Equal(t, crypto.PubkeyToAddress(key.PublicKey), attestation.Sampler)
+		require.Equal(t, uint64(100), attestation.ReferenceBlockNumber)
+		require.Len(t, attestation.ChunkIndices, 2)
+		assignment := assignments[attestation.OperatorID]
+		for _, index := range attestation.ChunkIndices {
+			require.GreaterOrEqual(t, index, assignment.StartIndex)
+			require.Less(t, index, assignment.StartIndex+assignment.NumChunks)
+		}
+	}
+	require.Equal(t, []int{2, 2, 2, 2}, verifier.numChunks)
+	require.NotEmpty(t, gossip.Attestations(time.Now()))
+
+	// Chunks which fail verification are unavailable
+	verifier.err = errors.New("invalid chunks")
+	attestations, err = sampler.SampleRound(ctx)
+	require.NoError(t, err)
+	require.Len(t, attestations, 4)
+	for _, attestation := range attestations {
+		require.False(t, attestation.Available)
+		require.NoError(t, attestation.Verify())
+	}
+
+	// Chunks which can't be retrieved are unavailable
+	verifier.err = nil
+	chunkSource.err = errors.New("connection refused")
+	attestations, err = sampler.SampleRound(ctx)
+	require.NoError(t, err)
+	require.Len(t, attestations, 4)
+	for _, attestation := range attestations {
+		require.False(t, attestation.Available)
+		require.Empty(t, attestation.ChunkIndices)
+	}
+}
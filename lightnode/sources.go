@@ -0,0 +1,161 @@
+package lightnode
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	grpcnode "github.com/Layr-Labs/eigenda/api/grpc/validator"
+	"github.com/Layr-Labs/eigenda/core"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	v2 "github.com/Layr-Labs/eigenda/disperser/common/v2"
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/docker/go-units"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// dataApiBasePath is the base path of the v2 data api
+const dataApiBasePath = "/api/v2"
+
+// blobFeedResponse is the subset of the data api's blob feed response used by the light node
+type blobFeedResponse struct {
+	Blobs []struct {
+		BlobKey      string           `json:"blob_key"`
+		BlobMetadata *v2.BlobMetadata `json:"blob_metadata"`
+	} `json:"blobs"`
+}
+
+// blobAttestationInfoResponse is the subset of the data api's blob attestation info response used by the light node
+type blobAttestationInfoResponse struct {
+	InclusionInfo *corev2.BlobInclusionInfo `json:"blob_inclusion_info"`
+}
+
+type dataApiBlobSource struct {
+	url    string
+	client *http.Client
+}
+
+var _ BlobSource = &dataApiBlobSource{}
+
+// NewDataApiBlobSource creates a BlobSource which discovers blobs through the data api at the given URL.
+func NewDataApiBlobSource(url string, client *http.Client) BlobSource {
+	return &dataApiBlobSource{
+		url:    url,
+		client: client,
+	}
+}
+
+// GetRecentBlobs returns the most recent blobs of the data api's blob feed which are certified.
+func (s *dataApiBlobSource) GetRecentBlobs(ctx context.Context, limit int) ([]*Blob, error) {
+	query := url.Values{}
+	query.Set("direction", "backward")
+	query.Set("limit", strconv.Itoa(limit))
+	feed := &blobFeedResponse{}
+	if err := s.get(ctx, "/blobs/feed?"+query.Encode(), feed); err != nil {
+		return nil, err
+	}
+
+	blobs := make([]*Blob, 0, len(feed.Blobs))
+	for _, info := range feed.Blobs {
+		if info.BlobMetadata == nil || info.BlobMetadata.BlobStatus != v2.Complete {
+			continue
+		}
+		blobKey, err := corev2.HexToBlobKey(info.BlobKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid blob key %s: %w", info.BlobKey, err)
+		}
+		blobs = append(blobs, &Blob{
+			Key:    blobKey,
+			Header: info.BlobMetadata.BlobHeader,
+		})
+	}
+	return blobs, nil
+}
+
+// GetReferenceBlockNumber returns the reference block number of the batch in which the blob was certified.
+func (s *dataApiBlobSource) GetReferenceBlockNumber(ctx context.Context, blobKey corev2.BlobKey) (uint64, error) {
+	info := &blobAttestationInfoResponse{}
+	if err := s.get(ctx, fmt.Sprintf("/blobs/%s/attestation-info", blobKey.Hex()), info); err != nil {
+		return 0, err
+	}
+	if info.InclusionInfo == nil || info.InclusionInfo.BatchHeader == nil {
+		return 0, fmt.Errorf("blob %s has no batch header", blobKey.Hex())
+	}
+	return info.InclusionInfo.BatchHeader.ReferenceBlockNumber, nil
+}
+
+// get fetches the JSON response of the data api at the given path, relative to the base path.
+func (s *dataApiBlobSource) get(ctx context.Context, path string, response any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url+dataApiBasePath+path, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to query data api: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("data api returned status %d: %s", resp.StatusCode, string(body))
+	}
+	if err := json.NewDecoder(resp.Body).Decode(response); err != nil {
+		return fmt.Errorf("failed to decode data api response: %w", err)
+	}
+	return nil
+}
+
+type grpcChunkSource struct{}
+
+var _ ChunkSource = &grpcChunkSource{}
+
+// NewGrpcChunkSource creates a ChunkSource which fetches chunks from the v2 retrieval api of the validators.
+func NewGrpcChunkSource() ChunkSource {
+	return &grpcChunkSource{}
+}
+
+// GetChunks fetches the chunks of a blob from the validator's v2 retrieval api.
+func (s *grpcChunkSource) GetChunks(
+	ctx context.Context,
+	socket core.OperatorSocket,
+	blobKey corev2.BlobKey,
+	quorumID core.QuorumID,
+) ([]*encoding.Frame, error) {
+	// A validator with all of the stake of a quorum holds the whole blob, at the maximum coding rate
+	maxMessageSize := 16*units.MiB*8 + units.MiB
+
+	conn, err := grpc.NewClient(
+		socket.GetV2RetrievalSocket(),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(maxMessageSize)),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %s: %w", socket.GetV2RetrievalSocket(), err)
+	}
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	reply, err := grpcnode.NewRetrievalClient(conn).GetChunks(ctx, &grpcnode.GetChunksRequest{
+		BlobKey:  blobKey[:],
+		QuorumId: uint32(quorumID),
+	})
+	if err != nil {
+		return nil, err
+	}
+	chunks := make([]*encoding.Frame, len(reply.GetChunks()))
+	for i, data := range reply.GetChunks() {
+		chunks[i], err = new(encoding.Frame).DeserializeGnark(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to deserialize chunk %d: %w", i, err)
+		}
+	}
+	return chunks, nil
+}
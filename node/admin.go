@@ -0,0 +1,78 @@
+package node
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+// AdminReloadPath is the path at which the admin api reloads the settings file
+const AdminReloadPath = "/api/v1/admin/reload"
+
+// ReloadResult is the response of the admin api to a settings reload.
+type ReloadResult struct {
+	Reloaded bool   `json:"reloaded"`
+	Error    string `json:"error,omitempty"`
+}
+
+// AdminServer serves the admin endpoints of the node over HTTP. It must only listen on localhost, since its endpoints
+// aren't authenticated.
+type AdminServer struct {
+	node       *Node
+	logger     logging.Logger
+	socketAddr string
+}
+
+// NewAdminServer creates a new AdminServer listening at the given address.
+func NewAdminServer(node *Node, socketAddr string, logger logging.Logger) *AdminServer {
+	return &AdminServer{
+		node:       node,
+		logger:     logger.With("component", "AdminServer"),
+		socketAddr: socketAddr,
+	}
+}
+
+// Start serves the admin api in the background, until the context is cancelled.
+func (a *AdminServer) Start(ctx context.Context) {
+	mux := http.NewServeMux()
+	mux.HandleFunc(AdminReloadPath, a.ServeReload)
+	server := &http.Server{
+		Addr:              a.socketAddr,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			a.logger.Error("Admin server failed", "err", err)
+		}
+	}()
+	go func() {
+		<-ctx.Done()
+		_ = server.Close()
+	}()
+}
+
+// ServeReload reloads the settings file, responding with the result as JSON.
+func (a *AdminServer) ServeReload(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	a.logger.Info("Reloading the settings on request of the admin api")
+	result := ReloadResult{Reloaded: true}
+	status := http.StatusOK
+	if err := a.node.ReloadSettings(); err != nil {
+		a.logger.Error("Failed to reload the settings, keeping the current ones", "err", err)
+		result = ReloadResult{Error: err.Error()}
+		status = http.StatusBadRequest
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		a.logger.Error("Failed to write reload result", "err", err)
+	}
+}
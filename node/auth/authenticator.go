@@ -58,9 +58,14 @@ type requestAuthenticator struct {
 
 	// disperserIDFilter is a function that returns true if the given disperser ID is valid.
 	disperserIDFilter func(uint32) bool
+
+	// disperserAddressFilter returns an error if requests from the disperser with the given address must be
+	// refused. It's nil if dispersers aren't filtered by address.
+	disperserAddressFilter func(address gethcommon.Address, now time.Time) error
 }
 
-// NewRequestAuthenticator creates a new RequestAuthenticator.
+// NewRequestAuthenticator creates a new RequestAuthenticator. disperserAddressFilter may be nil, otherwise it's checked
+// for each request, including requests from recently authenticated origins.
 func NewRequestAuthenticator(
 	ctx context.Context,
 	chainReader core.Reader,
@@ -68,6 +73,7 @@ func NewRequestAuthenticator(
 	keyTimeoutDuration time.Duration,
 	authenticationTimeoutDuration time.Duration,
 	disperserIDFilter func(uint32) bool,
+	disperserAddressFilter func(address gethcommon.Address, now time.Time) error,
 	now time.Time) (RequestAuthenticator, error) {
 
 	keyCache, err := lru.New[uint32, *keyWithTimeout](keyCacheSize)
@@ -87,6 +93,7 @@ func NewRequestAuthenticator(
 		authenticatedDispersers:       authenticatedDispersers,
 		authenticationTimeoutDuration: authenticationTimeoutDuration,
 		disperserIDFilter:             disperserIDFilter,
+		disperserAddressFilter:        disperserAddressFilter,
 	}
 
 	err = authenticator.preloadCache(ctx, now)
@@ -113,6 +120,18 @@ func (a *requestAuthenticator) AuthenticateStoreChunksRequest(
 	request *grpc.StoreChunksRequest,
 	now time.Time) error {
 
+	if a.disperserAddressFilter != nil {
+		// The filter is checked before the cached authentications, so that a disperser which was just denied
+		// doesn't keep its access until its authentication expires.
+		key, err := a.getDisperserKey(ctx, now, request.DisperserID)
+		if err != nil {
+			return fmt.Errorf("failed to get operator key: %w", err)
+		}
+		if err := a.disperserAddressFilter(*key, now); err != nil {
+			return err
+		}
+	}
+
 	if a.isAuthenticationStillValid(now, origin) {
 		// We've recently authenticated this client. Do not authenticate again for a while.
 		return nil
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"github.com/Layr-Labs/eigenda/common/testutils/random"
 	wmock "github.com/Layr-Labs/eigenda/core/mock"
+	gethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/stretchr/testify/require"
 	"sync/atomic"
@@ -33,6 +34,7 @@ func TestValidRequest(t *testing.T) {
 		time.Minute,
 		time.Minute,
 		func(uint32) bool { return true },
+		nil,
 		start)
 	require.NoError(t, err)
 
@@ -65,6 +67,7 @@ func TestInvalidRequestWrongHash(t *testing.T) {
 		time.Minute,
 		time.Minute,
 		func(uint32) bool { return true },
+		nil,
 		start)
 	require.NoError(t, err)
 
@@ -100,6 +103,7 @@ func TestInvalidRequestWrongKey(t *testing.T) {
 		time.Minute,
 		time.Minute,
 		func(uint32) bool { return true },
+		nil,
 		start)
 	require.NoError(t, err)
 
@@ -148,6 +152,7 @@ func TestInvalidRequestInvalidDisperserID(t *testing.T) {
 			filterCallCount.Add(1)
 			return id != uint32(1)
 		},
+		nil,
 		start)
 	require.NoError(t, err)
 	require.Equal(t, uint32(1), filterCallCount.Load())
@@ -197,6 +202,7 @@ func TestAuthCaching(t *testing.T) {
 		time.Minute,
 		time.Minute,
 		func(uint32) bool { return true },
+		nil,
 		start)
 	require.NoError(t, err)
 
@@ -245,6 +251,51 @@ func TestAuthCaching(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestDisperserAddressFilter(t *testing.T) {
+	rand := random.NewTestRandom()
+
+	start := rand.Time()
+
+	publicKey, privateKey, err := rand.ECDSA()
+	require.NoError(t, err)
+	disperserAddress := crypto.PubkeyToAddress(*publicKey)
+
+	chainReader := wmock.MockWriter{}
+	chainReader.Mock.On("GetDisperserAddress", uint32(0)).Return(disperserAddress, nil)
+
+	denied := false
+	authenticator, err := NewRequestAuthenticator(
+		context.Background(),
+		&chainReader,
+		10,
+		time.Minute,
+		time.Minute,
+		func(uint32) bool { return true },
+		func(address gethcommon.Address, now time.Time) error {
+			require.Equal(t, disperserAddress, address)
+			if denied {
+				return errors.New("denied")
+			}
+			return nil
+		},
+		start)
+	require.NoError(t, err)
+
+	request := RandomStoreChunksRequest(rand)
+	request.DisperserID = 0
+	signature, err := SignStoreChunksRequest(privateKey, request)
+	require.NoError(t, err)
+	request.Signature = signature
+
+	err = authenticator.AuthenticateStoreChunksRequest(context.Background(), "localhost", request, start)
+	require.NoError(t, err)
+
+	// A denied disperser is refused even though its origin was recently authenticated
+	denied = true
+	err = authenticator.AuthenticateStoreChunksRequest(context.Background(), "localhost", request, start.Add(time.Second))
+	require.ErrorContains(t, err, "denied")
+}
+
 func TestAuthCachingDisabled(t *testing.T) {
 	rand := random.NewTestRandom()
 
@@ -264,6 +315,7 @@ func TestAuthCachingDisabled(t *testing.T) {
 		time.Minute,
 		0, // This disables auth caching
 		func(uint32) bool { return true },
+		nil,
 		start)
 	require.NoError(t, err)
 
@@ -309,6 +361,7 @@ func TestKeyExpiry(t *testing.T) {
 		time.Minute,
 		time.Minute,
 		func(uint32) bool { return true },
+		nil,
 		start)
 	require.NoError(t, err)
 
@@ -365,6 +418,7 @@ func TestAuthCacheSize(t *testing.T) {
 		time.Minute,
 		time.Minute,
 		func(uint32) bool { return true },
+		nil,
 		start)
 	require.NoError(t, err)
 
@@ -457,6 +511,7 @@ func TestKeyCacheSize(t *testing.T) {
 		time.Minute,
 		0, // disable auth caching
 		func(uint32) bool { return true },
+		nil,
 		start)
 	require.NoError(t, err)
 
@@ -0,0 +1,199 @@
+package node
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+	"golang.org/x/time/rate"
+)
+
+// bandwidthLimiterPeerCacheSize is the number of peers whose bandwidth is tracked. The least recently seen peers are
+// forgotten beyond that, which resets their budget.
+const bandwidthLimiterPeerCacheSize = 4096
+
+// BandwidthLimitConfig configures the bandwidth limits of one direction (ingress or egress) of the node's traffic.
+type BandwidthLimitConfig struct {
+	// BytesPerSecond is the maximum bandwidth, in bytes per second, across all peers. 0 means unlimited.
+	BytesPerSecond float64 `json:"bytes_per_second"`
+	// Burstiness is the maximum number of bytes which can be transferred at once across all peers. Requests larger
+	// than the burstiness are always refused. 0 means one second worth of BytesPerSecond.
+	Burstiness int `json:"burstiness"`
+	// PeerBytesPerSecond is the maximum bandwidth, in bytes per second, of a single peer. 0 means unlimited.
+	PeerBytesPerSecond float64 `json:"peer_bytes_per_second"`
+	// PeerBurstiness is the maximum number of bytes which can be transferred at once with a single peer. 0 means one
+	// second worth of PeerBytesPerSecond.
+	PeerBurstiness int `json:"peer_burstiness"`
+}
+
+// validate checks that the limits aren't negative.
+func (c *BandwidthLimitConfig) validate(direction string) error {
+	if c.BytesPerSecond < 0 || c.PeerBytesPerSecond < 0 || c.Burstiness < 0 || c.PeerBurstiness < 0 {
+		return fmt.Errorf("%s bandwidth limits must not be negative", direction)
+	}
+	return nil
+}
+
+// BandwidthLimitError is returned when a transfer is refused because it exceeds a bandwidth limit.
+type BandwidthLimitError struct {
+	// Scope is the limit which was exceeded, "global" or "peer"
+	Scope string
+	// RetryAfter is the time after which the transfer would be allowed. It's 0 if the transfer is larger than the
+	// burstiness, in which case it's never allowed.
+	RetryAfter time.Duration
+	msg        string
+}
+
+func (e *BandwidthLimitError) Error() string {
+	return e.msg
+}
+
+// BandwidthLimiter enforces global and per-peer bandwidth limits on one direction of the node's traffic, so that a
+// storm of requests can't saturate the operator's link and make the node miss attestations.
+type BandwidthLimiter struct {
+	// direction is "ingress" or "egress", used in errors and metrics
+	direction string
+	metrics   *Metrics
+
+	// mu guards the fields below, and the creation of the limiters of new peers
+	mu     sync.Mutex
+	config BandwidthLimitConfig
+	// global limits the bandwidth across all peers. It's nil if unlimited.
+	global *rate.Limiter
+	// peers limits the bandwidth of each peer. It's nil if unlimited.
+	peers *lru.Cache[string, *rate.Limiter]
+}
+
+// NewBandwidthLimiter creates a new BandwidthLimiter for the given direction. It returns nil, which doesn't enforce
+// any limit, if neither the global nor the per-peer limit is set.
+func NewBandwidthLimiter(direction string, config BandwidthLimitConfig, metrics *Metrics) (*BandwidthLimiter, error) {
+	if err := config.validate(direction); err != nil {
+		return nil, err
+	}
+	if config.BytesPerSecond == 0 && config.PeerBytesPerSecond == 0 {
+		return nil, nil
+	}
+	return newBandwidthLimiter(direction, config, metrics)
+}
+
+// newBandwidthLimiter creates a new BandwidthLimiter even if nothing is limited, so that limits can be set later
+// with SetConfig.
+func newBandwidthLimiter(direction string, config BandwidthLimitConfig, metrics *Metrics) (*BandwidthLimiter, error) {
+	limiter := &BandwidthLimiter{
+		direction: direction,
+		metrics:   metrics,
+	}
+	if err := limiter.SetConfig(config); err != nil {
+		return nil, err
+	}
+	return limiter, nil
+}
+
+// SetConfig replaces the limits. The budgets of the peers are reset if the per-peer limits change, while the global
+// budget carries over.
+func (l *BandwidthLimiter) SetConfig(config BandwidthLimitConfig) error {
+	if err := config.validate(l.direction); err != nil {
+		return err
+	}
+	if config.BytesPerSecond > 0 && config.Burstiness == 0 {
+		config.Burstiness = int(config.BytesPerSecond)
+	}
+	if config.PeerBytesPerSecond > 0 && config.PeerBurstiness == 0 {
+		config.PeerBurstiness = int(config.PeerBytesPerSecond)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	peers := l.peers
+	if config.PeerBytesPerSecond == 0 {
+		peers = nil
+	} else if peers == nil || config.PeerBytesPerSecond != l.config.PeerBytesPerSecond || config.PeerBurstiness != l.config.PeerBurstiness {
+		var err error
+		peers, err = lru.New[string, *rate.Limiter](bandwidthLimiterPeerCacheSize)
+		if err != nil {
+			return fmt.Errorf("failed to create peer cache: %w", err)
+		}
+	}
+
+	if config.BytesPerSecond == 0 {
+		l.global = nil
+	} else if l.global == nil {
+		l.global = rate.NewLimiter(rate.Limit(config.BytesPerSecond), config.Burstiness)
+	} else {
+		l.global.SetLimit(rate.Limit(config.BytesPerSecond))
+		l.global.SetBurst(config.Burstiness)
+	}
+	l.peers = peers
+	l.config = config
+	return nil
+}
+
+// RequestBandwidth reserves the bandwidth to transfer the given number of bytes with the peer. It returns a
+// *BandwidthLimitError if the transfer would exceed the global or the peer's limit, in which case nothing is reserved.
+func (l *BandwidthLimiter) RequestBandwidth(now time.Time, peer string, bytes int) error {
+	if l == nil || bytes <= 0 {
+		// If the limiter is nil, do not enforce bandwidth limits.
+		return nil
+	}
+
+	l.mu.Lock()
+	config, global := l.config, l.global
+	var peerLimiter *rate.Limiter
+	if l.peers != nil {
+		peerLimiter = l.peerLimiter(peer)
+	}
+	l.mu.Unlock()
+
+	var globalReservation *rate.Reservation
+	if global != nil {
+		globalReservation = global.ReserveN(now, bytes)
+		if err := l.checkReservation(now, globalReservation, "global", bytes, config.BytesPerSecond, config.Burstiness); err != nil {
+			return err
+		}
+	}
+
+	if peerLimiter != nil {
+		peerReservation := peerLimiter.ReserveN(now, bytes)
+		if err := l.checkReservation(now, peerReservation, "peer", bytes, config.PeerBytesPerSecond, config.PeerBurstiness); err != nil {
+			if globalReservation != nil {
+				globalReservation.CancelAt(now)
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// checkReservation returns a *BandwidthLimitError, after cancelling the reservation, if the transfer can't happen now.
+func (l *BandwidthLimiter) checkReservation(now time.Time, reservation *rate.Reservation, scope string, bytes int, bytesPerSecond float64, burstiness int) error {
+	var retryAfter time.Duration
+	if reservation.OK() {
+		retryAfter = reservation.DelayFrom(now)
+		if retryAfter == 0 {
+			return nil
+		}
+		reservation.CancelAt(now)
+	}
+
+	if l.metrics != nil {
+		l.metrics.RecordBandwidthLimited(l.direction, scope)
+	}
+	return &BandwidthLimitError{
+		Scope:      scope,
+		RetryAfter: retryAfter,
+		msg: fmt.Sprintf("%s %s bandwidth limit of %0.1fMiB/s (burstiness %dMiB) exceeded by a transfer of %d bytes, try again later",
+			scope, l.direction, bytesPerSecond/1024/1024, burstiness/1024/1024, bytes),
+	}
+}
+
+// peerLimiter returns the bandwidth limiter of the peer, creating it if the peer is new. The caller must hold mu.
+func (l *BandwidthLimiter) peerLimiter(peer string) *rate.Limiter {
+	limiter, ok := l.peers.Get(peer)
+	if !ok {
+		limiter = rate.NewLimiter(rate.Limit(l.config.PeerBytesPerSecond), l.config.PeerBurstiness)
+		l.peers.Add(peer, limiter)
+	}
+	return limiter
+}
@@ -0,0 +1,65 @@
+package node_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/node"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBandwidthLimiter(t *testing.T) {
+	now := time.Now()
+
+	// No limit
+	limiter, err := node.NewBandwidthLimiter("egress", node.BandwidthLimitConfig{}, nil)
+	require.NoError(t, err)
+	require.Nil(t, limiter)
+	require.NoError(t, limiter.RequestBandwidth(now, "peer0", 1<<30))
+
+	_, err = node.NewBandwidthLimiter("egress", node.BandwidthLimitConfig{BytesPerSecond: -1}, nil)
+	require.Error(t, err)
+
+	limiter, err = node.NewBandwidthLimiter("egress", node.BandwidthLimitConfig{
+		BytesPerSecond:     1000,
+		Burstiness:         1000,
+		PeerBytesPerSecond: 100,
+		PeerBurstiness:     600,
+	}, nil)
+	require.NoError(t, err)
+
+	// The peer limit is reached first
+	require.NoError(t, limiter.RequestBandwidth(now, "peer0", 600))
+	err = limiter.RequestBandwidth(now, "peer0", 100)
+	var limitErr *node.BandwidthLimitError
+	require.ErrorAs(t, err, &limitErr)
+	require.Equal(t, "peer", limitErr.Scope)
+	require.Equal(t, time.Second, limitErr.RetryAfter)
+
+	// Refused transfers don't consume the global budget
+	require.NoError(t, limiter.RequestBandwidth(now, "peer1", 400))
+	err = limiter.RequestBandwidth(now, "peer2", 100)
+	require.ErrorAs(t, err, &limitErr)
+	require.Equal(t, "global", limitErr.Scope)
+
+	// The budget refills over time
+	require.NoError(t, limiter.RequestBandwidth(now.Add(time.Second), "peer0", 100))
+
+	// Transfers larger than the burstiness are never allowed
+	err = limiter.RequestBandwidth(now.Add(time.Hour), "peer3", 601)
+	require.ErrorAs(t, err, &limitErr)
+	require.Equal(t, "peer", limitErr.Scope)
+	require.Zero(t, limitErr.RetryAfter)
+}
+
+func TestBandwidthLimiterDefaultBurstiness(t *testing.T) {
+	now := time.Now()
+	limiter, err := node.NewBandwidthLimiter("ingress", node.BandwidthLimitConfig{
+		BytesPerSecond: 1000,
+	}, nil)
+	require.NoError(t, err)
+
+	// One second worth of bandwidth can be transferred at once
+	require.NoError(t, limiter.RequestBandwidth(now, "peer0", 1000))
+	require.Error(t, limiter.RequestBandwidth(now, "peer1", 1))
+}
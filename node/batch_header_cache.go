@@ -0,0 +1,76 @@
+package node
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+	"github.com/wealdtech/go-merkletree/v2"
+)
+
+// BatchHeaderCache caches the merkle trees of the batches whose batch root was verified against their blob header
+// hashes, by batch header hash, so that the proofs served at retrieval time and repeated validations of the same
+// batch don't rebuild and verify the tree again.
+//
+// A nil BatchHeaderCache caches nothing. The cached trees are shared, and must not be modified by the callers.
+type BatchHeaderCache struct {
+	trees   *lru.Cache[[32]byte, *merkletree.MerkleTree]
+	metrics *Metrics
+}
+
+// NewBatchHeaderCache creates a new BatchHeaderCache holding the trees of up to size batches.
+func NewBatchHeaderCache(size int, metrics *Metrics) (*BatchHeaderCache, error) {
+	if size <= 0 {
+		return nil, errors.New("the batch header cache size must be positive")
+	}
+	trees, err := lru.New[[32]byte, *merkletree.MerkleTree](size)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create batch header cache: %w", err)
+	}
+	return &BatchHeaderCache{trees: trees, metrics: metrics}, nil
+}
+
+// Get returns the verified merkle tree of the batch, or nil if it isn't cached.
+func (c *BatchHeaderCache) Get(batchHeaderHash [32]byte) *merkletree.MerkleTree {
+	if c == nil {
+		return nil
+	}
+	tree, ok := c.trees.Get(batchHeaderHash)
+	c.recordLookup(ok)
+	return tree
+}
+
+// Add caches the merkle tree of the batch. The root of the tree must have been verified against the batch root of
+// the batch header.
+func (c *BatchHeaderCache) Add(batchHeaderHash [32]byte, tree *merkletree.MerkleTree) {
+	if c == nil {
+		return
+	}
+	c.trees.Add(batchHeaderHash, tree)
+}
+
+// Verified returns true if the batch root of the batch was verified against the given blob header hashes.
+func (c *BatchHeaderCache) Verified(batchHeaderHash [32]byte, blobHeaderHashes [][32]byte) bool {
+	tree := c.Get(batchHeaderHash)
+	if tree == nil || len(tree.Data) != len(blobHeaderHashes) {
+		return false
+	}
+	for i := range blobHeaderHashes {
+		if !bytes.Equal(tree.Data[i], blobHeaderHashes[i][:]) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *BatchHeaderCache) recordLookup(hit bool) {
+	if c.metrics == nil {
+		return
+	}
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	c.metrics.RecordBatchHeaderCacheLookup(result)
+}
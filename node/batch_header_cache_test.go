@@ -0,0 +1,43 @@
+package node_test
+
+import (
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/node"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatchHeaderCache(t *testing.T) {
+	blobHeaderHashes := [][32]byte{{1}, {2}, {3}}
+	header := &core.BatchHeader{ReferenceBlockNumber: 10}
+	tree, err := header.SetBatchRootFromBlobHeaderHashes(blobHeaderHashes)
+	require.NoError(t, err)
+	batchHeaderHash, err := header.GetBatchHeaderHash()
+	require.NoError(t, err)
+
+	_, err = node.NewBatchHeaderCache(0, nil)
+	require.Error(t, err)
+	cache, err := node.NewBatchHeaderCache(1, nil)
+	require.NoError(t, err)
+
+	require.Nil(t, cache.Get(batchHeaderHash))
+	require.False(t, cache.Verified(batchHeaderHash, blobHeaderHashes))
+	cache.Add(batchHeaderHash, tree)
+	require.Same(t, tree, cache.Get(batchHeaderHash))
+	require.True(t, cache.Verified(batchHeaderHash, blobHeaderHashes))
+
+	// Other blob header hashes weren't verified against the batch root
+	require.False(t, cache.Verified(batchHeaderHash, blobHeaderHashes[:2]))
+	require.False(t, cache.Verified(batchHeaderHash, [][32]byte{{1}, {2}, {4}}))
+
+	// The least recently used batch is evicted
+	cache.Add([32]byte{9}, tree)
+	require.Nil(t, cache.Get(batchHeaderHash))
+
+	// A nil cache caches nothing
+	var disabled *node.BatchHeaderCache
+	disabled.Add(batchHeaderHash, tree)
+	require.Nil(t, disabled.Get(batchHeaderHash))
+	require.False(t, disabled.Verified(batchHeaderHash, blobHeaderHashes))
+}
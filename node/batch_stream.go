@@ -0,0 +1,156 @@
+package node
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api/grpc/node"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/gammazero/workerpool"
+)
+
+// BatchStream processes a batch whose blobs are received one at a time, as by the StoreChunksStream RPC. Each blob
+// is validated and stored as it arrives, so the node only holds one blob of the batch in memory, rather than the
+// whole batch as in ProcessBatch. The batch is signed once all its blobs are received and its root matches their
+// headers.
+//
+// If the batch is stored already, its blobs are validated but not stored again. Blobs stored by a stream which fails
+// are rolled back by Abort.
+type BatchStream struct {
+	node            *Node
+	header          *core.BatchHeader
+	batchHeaderHash [32]byte
+	operatorState   *core.OperatorState
+	pool            *workerpool.WorkerPool
+	// exists is true if the batch was stored already when the stream started
+	exists bool
+
+	// blobHeaders are the headers of the blobs received so far, in the order of the batch
+	blobHeaders []*core.BlobHeader
+	// keys are the keys stored by the stream so far
+	keys      [][]byte
+	batchSize uint64
+	start     time.Time
+}
+
+// NewBatchStream starts processing the batch with the given header, whose blobs are passed to AddBlob.
+func (n *Node) NewBatchStream(ctx context.Context, header *core.BatchHeader) (*BatchStream, error) {
+	batchHeaderHash, err := header.GetBatchHeaderHash()
+	if err != nil {
+		return nil, err
+	}
+	operatorState, err := n.ChainState.GetOperatorStateByOperator(ctx, header.ReferenceBlockNumber, n.Config.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &BatchStream{
+		node:            n,
+		header:          header,
+		batchHeaderHash: batchHeaderHash,
+		operatorState:   operatorState,
+		pool:            workerpool.New(n.Config.NumBatchValidators),
+		exists:          n.Store.HasKey(ctx, EncodeBatchHeaderKey(batchHeaderHash)),
+		blobHeaders:     make([]*core.BlobHeader, 0),
+		keys:            make([][]byte, 0),
+		start:           time.Now(),
+	}, nil
+}
+
+// AddBlob validates the next blob of the batch, and stores it unless the batch is stored already.
+func (s *BatchStream) AddBlob(ctx context.Context, blob *core.BlobMessage, rawBlob *node.Blob) error {
+	n := s.node
+	for quorumID, bundle := range blob.Bundles {
+		n.Metrics.AcceptBlobs(quorumID, bundle.Size())
+	}
+	blobSize := blob.Bundles.Size()
+	s.batchSize += blobSize
+
+	stageTimer := time.Now()
+	err := n.Validator.ValidateBlobs([]*core.BlobMessage{blob}, s.operatorState, s.pool)
+	if err != nil {
+		return fmt.Errorf("failed to validate blob %d: %w", len(s.blobHeaders), err)
+	}
+	n.Metrics.RecordStoreChunksStage("validated", blobSize, time.Since(stageTimer))
+
+	if !s.exists {
+		stageTimer = time.Now()
+		keys, err := n.Store.StoreBatchBlob(ctx, s.batchHeaderHash, len(s.blobHeaders), blob, rawBlob)
+		if err != nil {
+			return fmt.Errorf("failed to store blob %d: %w", len(s.blobHeaders), err)
+		}
+		s.keys = append(s.keys, *keys...)
+		n.Metrics.RecordStoreChunksStage("stored", blobSize, time.Since(stageTimer))
+	}
+
+	s.blobHeaders = append(s.blobHeaders, blob.BlobHeader)
+	return nil
+}
+
+// Finish checks that the received blobs make up the batch, completes storing the batch, and signs it. The stream
+// must be aborted if Finish fails.
+func (s *BatchStream) Finish(ctx context.Context) (*core.Signature, error) {
+	n := s.node
+	batchHeaderHashHex := hex.EncodeToString(s.batchHeaderHash[:])
+	n.Metrics.AcceptBatches("received", s.batchSize)
+
+	if len(s.blobHeaders) == 0 {
+		return nil, errors.New("number of blobs must be greater than zero")
+	}
+	if err := core.ValidateBatchHeaderRoot(s.header, s.blobHeaders); err != nil {
+		return nil, err
+	}
+
+	if !s.exists {
+		keys, err := n.Store.StoreBatchHeader(ctx, s.header)
+		if errors.Is(err, ErrBatchAlreadyExist) {
+			// The batch was completed concurrently, so the blobs stored by this stream duplicate it
+			n.Logger.Warn("Store batch skipped because the batch already exists in the store", "batchHeaderHash", batchHeaderHashHex)
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to store batch: %w", err)
+		} else {
+			s.keys = append(s.keys, *keys...)
+		}
+	} else {
+		n.Logger.Warn("Store batch skipped because the batch already exists in the store", "batchHeaderHash", batchHeaderHashHex)
+	}
+
+	stageTimer := time.Now()
+	blobHeaderHashes := make([][32]byte, len(s.blobHeaders))
+	for i, blobHeader := range s.blobHeaders {
+		var err error
+		blobHeaderHashes[i], err = blobHeader.GetBlobHeaderHash()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get blob header hash: %w", err)
+		}
+	}
+	if err := n.RecordAttestation(s.batchHeaderHash, uint64(s.header.ReferenceBlockNumber), blobHeaderHashes); err != nil {
+		return nil, err
+	}
+	signature, err := n.SignMessage(ctx, s.batchHeaderHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign batch: %w", err)
+	}
+	n.Metrics.RecordStoreChunksStage("signed", s.batchSize, time.Since(stageTimer))
+	n.Logger.Debug("Exiting process batch stream", "batchHeaderHash", batchHeaderHashHex, "num of blobs", len(s.blobHeaders), "duration", time.Since(s.start))
+
+	s.pool.Stop()
+	return signature, nil
+}
+
+// Abort rolls back the entries stored by the stream.
+func (s *BatchStream) Abort(ctx context.Context) {
+	s.pool.Stop()
+	if len(s.keys) == 0 {
+		return
+	}
+	n := s.node
+	batchHeaderHashHex := hex.EncodeToString(s.batchHeaderHash[:])
+	n.Logger.Debug("Batch stream failed, rolling back the key/value entries stored in database", "number of entires", len(s.keys), "batchHeaderHash", batchHeaderHashHex)
+	if err := n.Store.DeleteKeys(ctx, &s.keys); err != nil {
+		n.Logger.Error("Failed to delete the invalid batch that should be rolled back", "batchHeaderHash", batchHeaderHashHex, "err", err)
+	}
+	s.keys = s.keys[:0]
+}
@@ -0,0 +1,95 @@
+package node
+
+import (
+	"fmt"
+
+	pb "github.com/Layr-Labs/eigenda/api/grpc/node"
+	"github.com/Layr-Labs/eigenda/core"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+)
+
+// BlobSizeLimitError is returned when a dispersal is refused because some of its blobs, or the chunks downloaded for
+// them, are larger than allowed.
+type BlobSizeLimitError struct {
+	// Violations are the blobs over a size limit, in the order of the blobs in the request
+	Violations []BlobSizeViolation
+}
+
+// BlobSizeViolation describes a blob over the size limit of one of its quorums.
+type BlobSizeViolation struct {
+	// BlobIndex is the index of the blob in the request
+	BlobIndex int
+	Quorum    core.QuorumID
+	// Bundle is true if the limit is on the size of the bundle of chunks assigned to the operator, which follows
+	// from the length of the blob and the on-chain blob version parameters, rather than on the size of the blob
+	Bundle bool
+	// Size is the size of the blob, or of the bundle, in bytes
+	Size uint64
+	// Limit is the maximum size of the blob, or of the bundle, in bytes
+	Limit uint64
+}
+
+func (e *BlobSizeLimitError) Error() string {
+	v := e.Violations[0]
+	what := "blob"
+	if v.Bundle {
+		what = "bundle of blob"
+	}
+	msg := fmt.Sprintf("%s %d of %d bytes exceeds the size limit of %d bytes in quorum %d", what, v.BlobIndex, v.Size, v.Limit, v.Quorum)
+	if len(e.Violations) > 1 {
+		msg += fmt.Sprintf(" (and %d more violations)", len(e.Violations)-1)
+	}
+	return msg
+}
+
+// CheckBlobSizesV1 checks the blobs of a v1 request against the maximum blob sizes of their quorums, in bytes.
+// Quorums without a maximum are unlimited. It returns a *BlobSizeLimitError listing the blobs over the size limit.
+func CheckBlobSizesV1(blobs []*pb.Blob, maxBlobSizes map[core.QuorumID]uint64) error {
+	if len(maxBlobSizes) == 0 {
+		return nil
+	}
+	violations := make([]BlobSizeViolation, 0)
+	for i, blob := range blobs {
+		size := uint64(blob.GetHeader().GetLength()) * encoding.BYTES_PER_SYMBOL
+		for _, quorumHeader := range blob.GetHeader().GetQuorumHeaders() {
+			quorum := core.QuorumID(quorumHeader.GetQuorumId())
+			if limit, ok := maxBlobSizes[quorum]; ok && size > limit {
+				violations = append(violations, BlobSizeViolation{BlobIndex: i, Quorum: quorum, Size: size, Limit: limit})
+			}
+		}
+	}
+	if len(violations) > 0 {
+		return &BlobSizeLimitError{Violations: violations}
+	}
+	return nil
+}
+
+// CheckBlobSizesV2 checks the blobs of a v2 batch against the maximum blob sizes of their quorums, in bytes.
+// Quorums without a maximum are unlimited. It returns a *BlobSizeLimitError listing the blobs over the size limit.
+// The blobs are checked from their headers, before any chunk is downloaded.
+func CheckBlobSizesV2(batch *corev2.Batch, maxBlobSizes map[core.QuorumID]uint64) error {
+	if len(maxBlobSizes) == 0 {
+		return nil
+	}
+	violations := make([]BlobSizeViolation, 0)
+	for i, cert := range batch.BlobCertificates {
+		size := uint64(cert.BlobHeader.BlobCommitments.Length) * encoding.BYTES_PER_SYMBOL
+		for _, quorum := range cert.BlobHeader.QuorumNumbers {
+			if limit, ok := maxBlobSizes[quorum]; ok && size > limit {
+				violations = append(violations, BlobSizeViolation{BlobIndex: i, Quorum: quorum, Size: size, Limit: limit})
+			}
+		}
+	}
+	if len(violations) > 0 {
+		return &BlobSizeLimitError{Violations: violations}
+	}
+	return nil
+}
+
+// maxBundleSize returns the size, in bytes, of a serialized bundle of numChunks chunks of chunkLength symbols.
+// Bundles sent by the relays can't be larger without holding chunks the blob's encoding doesn't have.
+func maxBundleSize(numChunks uint32, chunkLength uint64) uint64 {
+	return 8 + uint64(numChunks)*(bn254.SizeOfG1AffineCompressed+encoding.BYTES_PER_SYMBOL*chunkLength)
+}
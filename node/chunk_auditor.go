@@ -0,0 +1,148 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common/kvstore"
+	"github.com/Layr-Labs/eigenda/core"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+// CorruptBundle is a stored bundle whose chunks failed re-verification.
+type CorruptBundle struct {
+	BlobKey corev2.BlobKey
+	Quorum  core.QuorumID
+	Err     error
+}
+
+// ChunkAuditReport is the result of an audit of the stored bundles.
+type ChunkAuditReport struct {
+	// NumBundles is the number of bundles which were re-verified
+	NumBundles int
+	// Corrupt are the bundles which failed re-verification
+	Corrupt []CorruptBundle
+}
+
+// ChunkAuditor re-verifies the chunks of randomly sampled stored blobs against their commitments, so that disk
+// corruption is detected before the chunks are needed for retrieval.
+type ChunkAuditor struct {
+	store    StoreV2
+	verifier encoding.Verifier
+	metrics  *Metrics
+	logger   logging.Logger
+
+	// mu serializes the audits, so that on-demand audits don't add up with the background audits
+	mu sync.Mutex
+}
+
+// NewChunkAuditor creates a new ChunkAuditor.
+func NewChunkAuditor(store StoreV2, verifier encoding.Verifier, metrics *Metrics, logger logging.Logger) *ChunkAuditor {
+	return &ChunkAuditor{
+		store:    store,
+		verifier: verifier,
+		metrics:  metrics,
+		logger:   logger.With("component", "ChunkAuditor"),
+	}
+}
+
+// Start audits numBlobs random blobs once per interval in the background, until the context is cancelled.
+func (a *ChunkAuditor) Start(ctx context.Context, interval time.Duration, numBlobs int) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				report, err := a.Audit(ctx, numBlobs)
+				if err != nil {
+					a.logger.Error("Failed to audit stored chunks", "err", err)
+					continue
+				}
+				a.logger.Debug("Audited stored chunks", "bundles", report.NumBundles, "corrupt", len(report.Corrupt))
+			}
+		}
+	}()
+}
+
+// Audit re-verifies the bundles of up to numBlobs blobs picked at random among the stored blobs. Bundles which expire
+// while the audit is running are skipped.
+func (a *ChunkAuditor) Audit(ctx context.Context, numBlobs int) (*ChunkAuditReport, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	blobs, err := a.store.SampleBlobs(numBlobs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sample stored blobs: %w", err)
+	}
+
+	report := &ChunkAuditReport{
+		Corrupt: make([]CorruptBundle, 0),
+	}
+	for _, blob := range blobs {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		blobKey, err := blob.BlobCertificate.BlobHeader.BlobKey()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get blob key: %w", err)
+		}
+		for quorum, assignment := range blob.Assignments {
+			chunks, err := a.store.GetChunks(blobKey, quorum)
+			if errors.Is(err, kvstore.ErrNotFound) {
+				continue
+			}
+			if err == nil {
+				err = verifyChunks(a.verifier, blob, assignment, chunks)
+			}
+
+			report.NumBundles++
+			if a.metrics != nil {
+				a.metrics.RecordAuditedBundle(quorum, err != nil)
+			}
+			if err != nil {
+				a.logger.Error("Stored bundle failed verification", "blobKey", blobKey.Hex(), "quorum", quorum, "err", err)
+				report.Corrupt = append(report.Corrupt, CorruptBundle{
+					BlobKey: blobKey,
+					Quorum:  quorum,
+					Err:     err,
+				})
+			}
+		}
+	}
+	return report, nil
+}
+
+// verifyChunks verifies the chunks of a bundle against the commitment of the blob.
+func verifyChunks(verifier encoding.Verifier, blob *StoredBlob, assignment corev2.Assignment, chunks [][]byte) error {
+	if uint32(len(chunks)) != assignment.NumChunks {
+		return fmt.Errorf("number of chunks (%d) does not match assignment (%d)", len(chunks), assignment.NumChunks)
+	}
+
+	indices := assignment.GetIndices()
+	samples := make([]encoding.Sample, len(chunks))
+	for i, chunk := range chunks {
+		frame, err := new(encoding.Frame).DeserializeGnark(chunk)
+		if err != nil {
+			return fmt.Errorf("failed to deserialize chunk %d: %w", i, err)
+		}
+		if uint64(frame.Length()) != blob.EncodingParams.ChunkLength {
+			return fmt.Errorf("%w: chunk length (%d) does not match encoding params (%d)", corev2.ErrChunkLengthMismatch, frame.Length(), blob.EncodingParams.ChunkLength)
+		}
+		samples[i] = encoding.Sample{
+			Commitment:      blob.BlobCertificate.BlobHeader.BlobCommitments.Commitment,
+			Chunk:           frame,
+			AssignmentIndex: uint(indices[i]),
+			BlobIndex:       0,
+		}
+	}
+	return verifier.UniversalVerifySubBatch(blob.EncodingParams, samples, 1)
+}
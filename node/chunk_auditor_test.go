@@ -0,0 +1,80 @@
+package node_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/common/testutils"
+	"github.com/Layr-Labs/eigenda/core"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/encoding"
+	encmock "github.com/Layr-Labs/eigenda/encoding/mock"
+	"github.com/Layr-Labs/eigenda/node"
+	nodemock "github.com/Layr-Labs/eigenda/node/mock"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChunkAuditor(t *testing.T) {
+	ctx := context.Background()
+	blobKeys, batch, bundles := nodemock.MockBatch(t)
+
+	// Store a single blob, so that it's always sampled
+	batch.BlobCertificates = batch.BlobCertificates[:1]
+	rawBundles := &node.RawBundles{
+		BlobCertificate: batch.BlobCertificates[0],
+		Bundles:         make(map[core.QuorumID][]byte),
+		EncodingParams:  encoding.EncodingParams{NumChunks: 8, ChunkLength: 2},
+		Assignments:     make(map[core.QuorumID]corev2.Assignment),
+	}
+	for quorum, bundle := range bundles[0] {
+		bundleBytes, err := bundle.Serialize()
+		require.NoError(t, err)
+		rawBundles.Bundles[quorum] = bundleBytes
+		rawBundles.Assignments[quorum] = corev2.Assignment{StartIndex: 0, NumChunks: uint32(len(bundle))}
+	}
+
+	s, db := createStoreV2(t)
+	defer func() {
+		_ = db.Shutdown()
+	}()
+
+	verifier := &encmock.MockEncoder{}
+	auditor := node.NewChunkAuditor(s, verifier, nil, testutils.GetLogger())
+
+	// Nothing is stored yet
+	report, err := auditor.Audit(ctx, 4)
+	require.NoError(t, err)
+	require.Equal(t, 0, report.NumBundles)
+
+	_, _, err = s.StoreBatch(batch, []*node.RawBundles{rawBundles})
+	require.NoError(t, err)
+
+	// The chunks of quorum 1 fail verification
+	verifier.On("UniversalVerifySubBatch", rawBundles.EncodingParams, mock.MatchedBy(func(samples []encoding.Sample) bool {
+		return len(samples) == len(bundles[0][1])
+	}), 1).Return(errors.New("invalid proof"))
+	verifier.On("UniversalVerifySubBatch", rawBundles.EncodingParams, mock.Anything, 1).Return(nil)
+
+	report, err = auditor.Audit(ctx, 4)
+	require.NoError(t, err)
+	require.Equal(t, 2, report.NumBundles)
+	require.Len(t, report.Corrupt, 1)
+	require.Equal(t, blobKeys[0], report.Corrupt[0].BlobKey)
+	require.Equal(t, core.QuorumID(1), report.Corrupt[0].Quorum)
+	require.ErrorContains(t, report.Corrupt[0].Err, "invalid proof")
+
+	// The chunks of quorum 0 are truncated on disk
+	bundleKeyBuilder, err := db.GetKeyBuilder(node.BundleTableName)
+	require.NoError(t, err)
+	k, err := node.BundleKey(blobKeys[0], 0)
+	require.NoError(t, err)
+	err = db.Put(bundleKeyBuilder.Key(k), rawBundles.Bundles[0][:len(rawBundles.Bundles[0])-1])
+	require.NoError(t, err)
+
+	report, err = auditor.Audit(ctx, 4)
+	require.NoError(t, err)
+	require.Equal(t, 2, report.NumBundles)
+	require.Len(t, report.Corrupt, 2)
+}
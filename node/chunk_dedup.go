@@ -0,0 +1,329 @@
+package node
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common/kvstore"
+	"github.com/Layr-Labs/eigenda/core"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+)
+
+// chunkRecordMagic starts the value of a bundle stored as a record of the hashes of its chunks, whose data is stored
+// once per blob in the chunk table. The last byte is the encoding format byte of a bundle header, and isn't a valid
+// format, so a serialized bundle can't start with the magic. Neither can an encrypted bundle, whose envelope starts
+// with a different magic.
+var chunkRecordMagic = []byte{0xED, 0xA0, 'C', 'H', 'U', 'N', 'K', 0xFF}
+
+// chunkRecordHeaderSize is the size of the magic, bundle header and bundle size which precede the chunk hashes
+const chunkRecordHeaderSize = 24
+
+// chunkRecord is a bundle whose chunks are deduplicated with the other bundles of its blob.
+type chunkRecord struct {
+	// header is the header of the serialized bundle, which determines how the chunks are serialized back into it
+	header uint64
+	// size is the size of the serialized bundle, in bytes
+	size uint64
+	// hashes are the hashes of the chunks of the bundle, in order
+	hashes [][32]byte
+}
+
+func encodeChunkRecord(record *chunkRecord) []byte {
+	data := make([]byte, chunkRecordHeaderSize, chunkRecordHeaderSize+32*len(record.hashes))
+	copy(data, chunkRecordMagic)
+	binary.LittleEndian.PutUint64(data[8:], record.header)
+	binary.LittleEndian.PutUint64(data[16:], record.size)
+	for _, hash := range record.hashes {
+		data = append(data, hash[:]...)
+	}
+	return data
+}
+
+// decodeChunkRecord decodes the stored value of a bundle. It returns nil if the bundle isn't stored as a record.
+func decodeChunkRecord(data []byte) (*chunkRecord, error) {
+	if !bytes.HasPrefix(data, chunkRecordMagic) {
+		return nil, nil
+	}
+	if len(data) < chunkRecordHeaderSize || (len(data)-chunkRecordHeaderSize)%32 != 0 {
+		return nil, errors.New("invalid chunk record")
+	}
+	record := &chunkRecord{
+		header: binary.LittleEndian.Uint64(data[8:]),
+		size:   binary.LittleEndian.Uint64(data[16:]),
+		hashes: make([][32]byte, (len(data)-chunkRecordHeaderSize)/32),
+	}
+	for i := range record.hashes {
+		copy(record.hashes[i][:], data[chunkRecordHeaderSize+32*i:])
+	}
+	return record, nil
+}
+
+// serializeBundle serializes the chunks of a bundle stored as a record back into the bundle.
+func (r *chunkRecord) serializeBundle(chunks [][]byte) ([]byte, error) {
+	format := core.BundleEncodingFormat(r.header >> (core.NumBundleHeaderBits - core.NumBundleEncodingFormatBits))
+	switch format {
+	case core.GnarkBundleEncodingFormat:
+		bundle := make([]byte, 8, r.size)
+		binary.LittleEndian.PutUint64(bundle, r.header)
+		for _, chunk := range chunks {
+			bundle = append(bundle, chunk...)
+		}
+		return bundle, nil
+	case core.GobBundleEncodingFormat:
+		return EncodeChunks(chunks)
+	default:
+		return nil, fmt.Errorf("invalid bundle encoding format %d", format)
+	}
+}
+
+// chunkRefs records the quorums whose bundles reference a deduplicated chunk, one bit per quorum. The chunk is deleted
+// once no quorum references it.
+type chunkRefs [32]byte
+
+func (r *chunkRefs) add(quorum core.QuorumID) {
+	r[quorum/8] |= 1 << (quorum % 8)
+}
+
+func (r *chunkRefs) remove(quorum core.QuorumID) {
+	r[quorum/8] &^= 1 << (quorum % 8)
+}
+
+func (r *chunkRefs) empty() bool {
+	return *r == chunkRefs{}
+}
+
+// chunkEntry is a deduplicated chunk of a blob, which is stored as its references followed by its data, encrypted if
+// encryption at rest is enabled.
+type chunkEntry struct {
+	refs chunkRefs
+	// data is nil if the chunk isn't stored
+	data []byte
+	// written is true if the data was written by the current operation
+	written bool
+	// modified is true if the entry must be written back
+	modified bool
+}
+
+// chunkEntries are the entries of the chunks of a blob modified by an operation, by chunk hash.
+type chunkEntries map[[32]byte]*chunkEntry
+
+// ChunkKey returns the key of a deduplicated chunk of a blob, which is the blob key followed by the hash of the chunk.
+func ChunkKey(blobKey corev2.BlobKey, hash [32]byte) []byte {
+	return append(bytes.Clone(blobKey[:]), hash[:]...)
+}
+
+// chunkEntry returns the entry of a chunk of the blob, reading it from the store the first time.
+func (s *storeV2) chunkEntry(blobKey corev2.BlobKey, hash [32]byte, entries chunkEntries) (*chunkEntry, error) {
+	if entry, ok := entries[hash]; ok {
+		return entry, nil
+	}
+	chunkKeyBuilder, err := s.db.GetKeyBuilder(ChunkTableName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get key builder for chunks: %v", err)
+	}
+	entry := &chunkEntry{}
+	value, err := s.db.Get(chunkKeyBuilder.Key(ChunkKey(blobKey, hash)))
+	if err != nil && !errors.Is(err, kvstore.ErrNotFound) {
+		return nil, fmt.Errorf("failed to get chunk: %w", err)
+	}
+	if err == nil {
+		if len(value) < len(entry.refs) {
+			return nil, fmt.Errorf("invalid chunk %x of blob %s", hash, blobKey.Hex())
+		}
+		copy(entry.refs[:], value)
+		entry.data = bytes.Clone(value[len(entry.refs):])
+	}
+	entries[hash] = entry
+	return entry, nil
+}
+
+// addBundleChunks adds the chunks of a serialized bundle of the blob to the entries, referenced by the quorum, and
+// returns the record the bundle is stored as. Empty bundles, which have no chunks, are stored as they are.
+func (s *storeV2) addBundleChunks(blobKey corev2.BlobKey, quorum core.QuorumID, bundle []byte, entries chunkEntries) ([]byte, error) {
+	if len(bundle) == 0 {
+		return bundle, nil
+	}
+	chunks, _, err := DecodeChunks(bundle)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode chunks: %v", err)
+	}
+	record := &chunkRecord{
+		header: binary.LittleEndian.Uint64(bundle),
+		size:   uint64(len(bundle)),
+		hashes: make([][32]byte, len(chunks)),
+	}
+	for i, chunk := range chunks {
+		record.hashes[i] = sha256.Sum256(chunk)
+		entry, err := s.chunkEntry(blobKey, record.hashes[i], entries)
+		if err != nil {
+			return nil, err
+		}
+		// The data is written even if the chunk is stored already, so that a repaired bundle repairs its chunks
+		if !entry.written {
+			entry.data, err = s.encryptor.Encrypt(context.Background(), chunk)
+			if err != nil {
+				return nil, fmt.Errorf("failed to encrypt chunk: %w", err)
+			}
+			entry.written = true
+		}
+		entry.refs.add(quorum)
+		entry.modified = true
+	}
+	return encodeChunkRecord(record), nil
+}
+
+// releaseBundleChunks removes the references of the quorum from the chunks of a bundle of the blob stored as a
+// record.
+func (s *storeV2) releaseBundleChunks(blobKey corev2.BlobKey, quorum core.QuorumID, record *chunkRecord, entries chunkEntries) error {
+	for _, hash := range record.hashes {
+		entry, err := s.chunkEntry(blobKey, hash, entries)
+		if err != nil {
+			return err
+		}
+		entry.refs.remove(quorum)
+		entry.modified = true
+	}
+	return nil
+}
+
+// putChunkEntries writes the modified entries of the chunks of the blob to the batch, and deletes the chunks which
+// are no longer referenced. Returns the keys of the written chunks, and their size in bytes.
+func (s *storeV2) putChunkEntries(
+	dbBatch kvstore.TTLBatch[kvstore.Key],
+	blobKey corev2.BlobKey,
+	entries chunkEntries,
+	expiry time.Time,
+) ([]kvstore.Key, uint64, error) {
+	if len(entries) == 0 {
+		return nil, 0, nil
+	}
+	chunkKeyBuilder, err := s.db.GetKeyBuilder(ChunkTableName)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get key builder for chunks: %v", err)
+	}
+	keys := make([]kvstore.Key, 0, len(entries))
+	var size uint64
+	for hash, entry := range entries {
+		if !entry.modified {
+			continue
+		}
+		key := chunkKeyBuilder.Key(ChunkKey(blobKey, hash))
+		if entry.refs.empty() || entry.data == nil {
+			dbBatch.Delete(key)
+			continue
+		}
+		value := make([]byte, 0, len(entry.refs)+len(entry.data))
+		value = append(value, entry.refs[:]...)
+		value = append(value, entry.data...)
+		dbBatch.PutWithExpiration(key, value, expiry)
+		keys = append(keys, key)
+		size += uint64(len(value))
+	}
+	return keys, size, nil
+}
+
+// readBundleChunks returns the chunks of a stored bundle of the blob, reading the chunks of a bundle stored as a
+// record from the chunk table. cache holds the chunks of the blob read so far, so that the chunks shared by several
+// bundles are read once.
+func (s *storeV2) readBundleChunks(blobKey corev2.BlobKey, stored []byte, cache map[[32]byte][]byte) ([][]byte, error) {
+	record, err := decodeChunkRecord(stored)
+	if err != nil {
+		return nil, err
+	}
+	if record == nil {
+		bundle, err := s.encryptor.Decrypt(context.Background(), stored)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt bundle: %w", err)
+		}
+		chunks, _, err := DecodeChunks(bundle)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode chunks: %v", err)
+		}
+		return chunks, nil
+	}
+
+	chunkKeyBuilder, err := s.db.GetKeyBuilder(ChunkTableName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get key builder for chunks: %v", err)
+	}
+	chunks := make([][]byte, len(record.hashes))
+	for i, hash := range record.hashes {
+		if chunk, ok := cache[hash]; ok {
+			chunks[i] = chunk
+			continue
+		}
+		value, err := s.db.Get(chunkKeyBuilder.Key(ChunkKey(blobKey, hash)))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get chunk %x: %w", hash, err)
+		}
+		if len(value) < len(chunkRefs{}) {
+			return nil, fmt.Errorf("invalid chunk %x of blob %s", hash, blobKey.Hex())
+		}
+		chunk, err := s.encryptor.Decrypt(context.Background(), value[len(chunkRefs{}):])
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt chunk: %w", err)
+		}
+		chunks[i] = chunk
+		if cache != nil {
+			cache[hash] = chunk
+		}
+	}
+	return chunks, nil
+}
+
+// storedBundleSize returns the size of the serialized bundle from its stored value, which is smaller if its chunks
+// are deduplicated.
+func storedBundleSize(stored []byte) uint64 {
+	if record, err := decodeChunkRecord(stored); err == nil && record != nil {
+		return record.size
+	}
+	return uint64(len(stored))
+}
+
+// releaseStoredBundle removes the references of the quorum from the chunks of the stored bundle of the blob, if it's
+// stored as a record.
+func (s *storeV2) releaseStoredBundle(key kvstore.Key, blobKey corev2.BlobKey, quorum core.QuorumID, entries chunkEntries) error {
+	stored, err := s.db.Get(key)
+	if errors.Is(err, kvstore.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get bundle: %w", err)
+	}
+	record, err := decodeChunkRecord(stored)
+	if err != nil || record == nil {
+		return err
+	}
+	return s.releaseBundleChunks(blobKey, quorum, record, entries)
+}
+
+// serializeStoredBundle returns the serialized bundle of the blob from its stored value, encrypted if encryption at
+// rest is enabled, and removes the references of the quorum from its chunks if it's stored as a record. Other bundles
+// are returned as they're stored.
+func (s *storeV2) serializeStoredBundle(blobKey corev2.BlobKey, quorum core.QuorumID, stored []byte, entries chunkEntries) ([]byte, error) {
+	record, err := decodeChunkRecord(stored)
+	if err != nil || record == nil {
+		return stored, err
+	}
+	chunks, err := s.readBundleChunks(blobKey, stored, nil)
+	if err != nil {
+		return nil, err
+	}
+	bundle, err := record.serializeBundle(chunks)
+	if err != nil {
+		return nil, err
+	}
+	bundle, err = s.encryptor.Encrypt(context.Background(), bundle)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt bundle: %w", err)
+	}
+	if err := s.releaseBundleChunks(blobKey, quorum, record, entries); err != nil {
+		return nil, err
+	}
+	return bundle, nil
+}
@@ -0,0 +1,47 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	commonaws "github.com/Layr-Labs/eigenda/common/aws"
+	"github.com/Layr-Labs/eigenda/common/aws/envelope"
+)
+
+// ChunkEncryptionConfig configures the encryption at rest of the chunks written to the node's stores. Chunks are
+// encrypted with AES-256-GCM data keys, which are themselves encrypted with a master key from a local file or KMS.
+type ChunkEncryptionConfig struct {
+	// KeyFile is the path to a file holding a hex encoded 32 byte master key. Empty if unused.
+	KeyFile string
+	// KMSKeyID is the ID of the KMS master key. Empty if unused.
+	KMSKeyID string
+	// KMSRegion is the region of the KMS master key. If set without a key ID, chunks are written in plaintext but
+	// chunks encrypted previously can still be read.
+	KMSRegion string
+	// KMSEndpoint overrides the KMS endpoint, e.g. for localstack. Leave empty to use the default endpoint.
+	KMSEndpoint string
+	// DataKeyLifetime is the time a data key is used to encrypt chunks before a new one is generated
+	DataKeyLifetime time.Duration
+}
+
+// NewChunkEncryptor creates the encryptor of the chunk stores. It returns nil if chunk encryption isn't configured,
+// in which case chunks are stored in plaintext. Reads are transparent: chunks stored in plaintext before encryption
+// was enabled are read as is.
+func NewChunkEncryptor(ctx context.Context, config ChunkEncryptionConfig) (*envelope.Encryptor, error) {
+	switch {
+	case config.KeyFile != "" && (config.KMSKeyID != "" || config.KMSRegion != ""):
+		return nil, errors.New("chunk encryption key file and KMS key are mutually exclusive")
+	case config.KeyFile != "":
+		return envelope.NewLocalEncryptor(config.KeyFile, config.DataKeyLifetime)
+	case config.KMSKeyID != "" || config.KMSRegion != "":
+		return envelope.NewKMSEncryptor(ctx, commonaws.ClientConfig{
+			Region:                    config.KMSRegion,
+			EndpointURL:               config.KMSEndpoint,
+			EncryptionKMSKeyID:        config.KMSKeyID,
+			EncryptionDataKeyLifetime: config.DataKeyLifetime,
+		})
+	default:
+		return nil, nil
+	}
+}
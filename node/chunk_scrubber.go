@@ -0,0 +1,199 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common/kvstore"
+	"github.com/Layr-Labs/eigenda/core"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+// BundleRepairer fetches a fresh copy of a stored bundle, to replace a copy found corrupt.
+type BundleRepairer interface {
+	// FetchBundle returns the serialized bundle of a stored blob in a quorum.
+	FetchBundle(ctx context.Context, blobKey corev2.BlobKey, blob *StoredBlob, quorum core.QuorumID) ([]byte, error)
+}
+
+// ChunkScrubReport is the result of a scrub of the stored bundles.
+type ChunkScrubReport struct {
+	// NumBundles is the number of bundles which were re-verified
+	NumBundles int
+	// Corrupt are the bundles which failed re-verification, whether they were repaired or not
+	Corrupt []CorruptBundle
+	// NumRepaired is the number of corrupt bundles which were replaced with a verified copy
+	NumRepaired int
+}
+
+// ChunkScrubber walks the whole v2 store over time, re-verifying the stored bundles of a few blobs at a time, and
+// repairs the corrupt bundles it finds by fetching them again, so that they're fixed before they're needed for
+// retrieval. Unlike the ChunkAuditor, which samples blobs at random, the scrubber eventually checks every stored
+// blob. It only reads the bundles stored locally, not those migrated to the cold tier.
+type ChunkScrubber struct {
+	store    StoreV2
+	verifier encoding.Verifier
+	// repairer fetches copies of corrupt bundles. If nil, corrupt bundles are only reported.
+	repairer BundleRepairer
+	metrics  *Metrics
+	logger   logging.Logger
+
+	// mu serializes the scrubs and guards cursor
+	mu sync.Mutex
+	// cursor is the key of the last blob scrubbed. Nil at the start of a pass over the store.
+	cursor *corev2.BlobKey
+}
+
+// NewChunkScrubber creates a new ChunkScrubber.
+func NewChunkScrubber(
+	store StoreV2,
+	verifier encoding.Verifier,
+	repairer BundleRepairer,
+	metrics *Metrics,
+	logger logging.Logger,
+) *ChunkScrubber {
+	return &ChunkScrubber{
+		store:    store,
+		verifier: verifier,
+		repairer: repairer,
+		metrics:  metrics,
+		logger:   logger.With("component", "ChunkScrubber"),
+	}
+}
+
+// Start scrubs the next numBlobs blobs once per interval in the background, until the context is cancelled. The
+// interval and number of blobs bound the disk and CPU load of the scrubber, which should stay low relative to
+// serving requests.
+func (s *ChunkScrubber) Start(ctx context.Context, interval time.Duration, numBlobs int) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				report, err := s.Scrub(ctx, numBlobs)
+				if err != nil {
+					s.logger.Error("Failed to scrub stored chunks", "err", err)
+					continue
+				}
+				s.logger.Debug("Scrubbed stored chunks", "bundles", report.NumBundles, "corrupt", len(report.Corrupt), "repaired", report.NumRepaired)
+			}
+		}
+	}()
+}
+
+// Scrub re-verifies the bundles of the next numBlobs stored blobs after the blobs scrubbed last, and repairs the
+// corrupt ones. Once the end of the store is reached, the next scrub starts over from the first blob.
+func (s *ChunkScrubber) Scrub(ctx context.Context, numBlobs int) (*ChunkScrubReport, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	blobs, err := s.store.NextBlobs(s.cursor, numBlobs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stored blobs: %w", err)
+	}
+
+	report := &ChunkScrubReport{
+		Corrupt: make([]CorruptBundle, 0),
+	}
+	for _, blob := range blobs {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		blobKey, err := blob.BlobCertificate.BlobHeader.BlobKey()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get blob key: %w", err)
+		}
+		blobChunks, err := s.store.GetBlobChunks(blobKey)
+		if err != nil {
+			// A corrupt bundle may fail to decode, in which case the bundles are read one at a time to find it
+			blobChunks = nil
+		}
+		for quorum, assignment := range blob.Assignments {
+			var chunks [][]byte
+			var err error
+			if blobChunks != nil {
+				var ok bool
+				chunks, ok = blobChunks[quorum]
+				if !ok {
+					// The bundle was pruned or migrated to the cold tier
+					continue
+				}
+			} else {
+				chunks, err = s.store.GetChunks(blobKey, quorum)
+				if errors.Is(err, kvstore.ErrNotFound) {
+					continue
+				}
+			}
+
+			report.NumBundles++
+			if err == nil {
+				err = verifyChunks(s.verifier, blob, assignment, chunks)
+			}
+			if err == nil {
+				s.recordBundle(quorum, "ok")
+				continue
+			}
+
+			s.logger.Error("Stored bundle failed verification", "blobKey", blobKey.Hex(), "quorum", quorum, "err", err)
+			report.Corrupt = append(report.Corrupt, CorruptBundle{
+				BlobKey: blobKey,
+				Quorum:  quorum,
+				Err:     err,
+			})
+			if s.repairer == nil {
+				s.recordBundle(quorum, "corrupt")
+				continue
+			}
+			if err := s.repair(ctx, blobKey, blob, quorum, assignment); err != nil {
+				s.logger.Error("Failed to repair corrupt bundle", "blobKey", blobKey.Hex(), "quorum", quorum, "err", err)
+				s.recordBundle(quorum, "repair_failed")
+				continue
+			}
+			s.logger.Info("Repaired corrupt bundle", "blobKey", blobKey.Hex(), "quorum", quorum)
+			s.recordBundle(quorum, "repaired")
+			report.NumRepaired++
+		}
+		s.cursor = &blobKey
+	}
+	if len(blobs) < numBlobs {
+		// The pass over the store is complete
+		s.cursor = nil
+	}
+	return report, nil
+}
+
+// repair fetches a copy of a corrupt bundle, and replaces the stored bundle with it if it verifies.
+func (s *ChunkScrubber) repair(
+	ctx context.Context,
+	blobKey corev2.BlobKey,
+	blob *StoredBlob,
+	quorum core.QuorumID,
+	assignment corev2.Assignment,
+) error {
+	bundle, err := s.repairer.FetchBundle(ctx, blobKey, blob, quorum)
+	if err != nil {
+		return fmt.Errorf("failed to fetch bundle: %w", err)
+	}
+	chunks, _, err := DecodeChunks(bundle)
+	if err != nil {
+		return fmt.Errorf("failed to decode fetched bundle: %w", err)
+	}
+	if err := verifyChunks(s.verifier, blob, assignment, chunks); err != nil {
+		return fmt.Errorf("fetched bundle failed verification: %w", err)
+	}
+	return s.store.RepairBundle(blobKey, quorum, bundle)
+}
+
+func (s *ChunkScrubber) recordBundle(quorum core.QuorumID, result string) {
+	if s.metrics != nil {
+		s.metrics.RecordScrubbedBundle(quorum, result)
+	}
+}
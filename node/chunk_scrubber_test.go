@@ -0,0 +1,115 @@
+package node_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/common/testutils"
+	"github.com/Layr-Labs/eigenda/core"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/encoding"
+	encmock "github.com/Layr-Labs/eigenda/encoding/mock"
+	"github.com/Layr-Labs/eigenda/node"
+	nodemock "github.com/Layr-Labs/eigenda/node/mock"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// mockRepairer serves the bundles of blobs from memory.
+type mockRepairer struct {
+	bundles map[corev2.BlobKey]map[core.QuorumID][]byte
+	err     error
+}
+
+func (r *mockRepairer) FetchBundle(
+	ctx context.Context,
+	blobKey corev2.BlobKey,
+	blob *node.StoredBlob,
+	quorum core.QuorumID,
+) ([]byte, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	return r.bundles[blobKey][quorum], nil
+}
+
+func TestChunkScrubber(t *testing.T) {
+	ctx := context.Background()
+	blobKeys, batch, bundles := nodemock.MockBatch(t)
+
+	repairer := &mockRepairer{bundles: make(map[corev2.BlobKey]map[core.QuorumID][]byte)}
+	rawBundles := make([]*node.RawBundles, len(batch.BlobCertificates))
+	numBundles := 0
+	for i, cert := range batch.BlobCertificates {
+		rawBundles[i] = &node.RawBundles{
+			BlobCertificate: cert,
+			Bundles:         make(map[core.QuorumID][]byte),
+			EncodingParams:  encoding.EncodingParams{NumChunks: 8, ChunkLength: 2},
+			Assignments:     make(map[core.QuorumID]corev2.Assignment),
+		}
+		repairer.bundles[blobKeys[i]] = rawBundles[i].Bundles
+		for quorum, bundle := range bundles[i] {
+			bundleBytes, err := bundle.Serialize()
+			require.NoError(t, err)
+			rawBundles[i].Bundles[quorum] = bundleBytes
+			rawBundles[i].Assignments[quorum] = corev2.Assignment{StartIndex: 0, NumChunks: uint32(len(bundle))}
+			numBundles++
+		}
+	}
+
+	s, db := createStoreV2(t)
+	defer func() {
+		_ = db.Shutdown()
+	}()
+	_, _, err := s.StoreBatch(batch, rawBundles)
+	require.NoError(t, err)
+
+	verifier := &encmock.MockEncoder{}
+	verifier.On("UniversalVerifySubBatch", mock.Anything, mock.Anything, 1).Return(nil)
+	scrubber := node.NewChunkScrubber(s, verifier, repairer, nil, testutils.GetLogger())
+
+	// A pass over the store spans several scrubs, and the next pass starts over
+	scrubPass := func() []*node.ChunkScrubReport {
+		reports := make([]*node.ChunkScrubReport, 0)
+		for i := 0; i < 2; i++ {
+			report, err := scrubber.Scrub(ctx, 2)
+			require.NoError(t, err)
+			reports = append(reports, report)
+		}
+		return reports
+	}
+	reports := scrubPass()
+	require.Equal(t, numBundles, reports[0].NumBundles+reports[1].NumBundles)
+	require.Empty(t, reports[0].Corrupt)
+	require.Empty(t, reports[1].Corrupt)
+
+	// The chunks of quorum 0 of the first blob are truncated on disk, and repaired from the relay
+	bundleKeyBuilder, err := db.GetKeyBuilder(node.BundleTableName)
+	require.NoError(t, err)
+	k, err := node.BundleKey(blobKeys[0], 0)
+	require.NoError(t, err)
+	corrupt := rawBundles[0].Bundles[0][:len(rawBundles[0].Bundles[0])-1]
+	require.NoError(t, db.Put(bundleKeyBuilder.Key(k), corrupt))
+
+	reports = scrubPass()
+	require.Equal(t, numBundles, reports[0].NumBundles+reports[1].NumBundles)
+	corruptBundles := append(reports[0].Corrupt, reports[1].Corrupt...)
+	require.Len(t, corruptBundles, 1)
+	require.Equal(t, blobKeys[0], corruptBundles[0].BlobKey)
+	require.Equal(t, core.QuorumID(0), corruptBundles[0].Quorum)
+	require.Equal(t, 1, reports[0].NumRepaired+reports[1].NumRepaired)
+	stored, err := db.Get(bundleKeyBuilder.Key(k))
+	require.NoError(t, err)
+	require.Equal(t, rawBundles[0].Bundles[0], stored)
+
+	// Corrupt bundles are still reported if they can't be repaired
+	require.NoError(t, db.Put(bundleKeyBuilder.Key(k), corrupt))
+	repairer.err = errors.New("relay unavailable")
+	reports = scrubPass()
+	require.Len(t, append(reports[0].Corrupt, reports[1].Corrupt...), 1)
+	require.Equal(t, 0, reports[0].NumRepaired+reports[1].NumRepaired)
+	stored, err = db.Get(bundleKeyBuilder.Key(k))
+	require.NoError(t, err)
+	require.Equal(t, corrupt, stored)
+}
@@ -0,0 +1,173 @@
+package node
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common/aws/s3"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+// coldBundlePrefix is the prefix of the object keys of the bundles in the cold tier.
+const coldBundlePrefix = "bundles/"
+
+// ChunkTieringConfig configures the migration of older chunks from the local v2 store to an S3 compatible object
+// store. Migrated chunks are fetched back from the object store on retrieval, which is slower than a local read but
+// lets operators keep long retention periods without provisioning the disk for them.
+type ChunkTieringConfig struct {
+	// Bucket is the bucket holding the migrated chunks. Tiering is disabled if empty. The bucket should expire
+	// objects after the storage period of the chunks, since chunks expiring in the local store aren't deleted from it.
+	Bucket string
+	// Region is the region of the bucket
+	Region string
+	// EndpointURL overrides the object store endpoint, e.g. for localstack or another S3 compatible store
+	EndpointURL string
+	// MigrationAge is the time after which stored chunks are migrated to the bucket
+	MigrationAge time.Duration
+	// Interval is the interval between migration cycles
+	Interval time.Duration
+}
+
+// Enabled returns true if chunks are migrated to an object store.
+func (c *ChunkTieringConfig) Enabled() bool {
+	return c.Bucket != ""
+}
+
+// Validate checks that the config is consistent.
+func (c *ChunkTieringConfig) Validate() error {
+	if !c.Enabled() {
+		return nil
+	}
+	if c.MigrationAge <= 0 {
+		return errors.New("the chunk tiering migration age must be positive")
+	}
+	if c.Interval <= 0 {
+		return errors.New("the chunk tiering interval must be positive")
+	}
+	return nil
+}
+
+// ColdTier stores the bundles migrated off the local store. Bundles are keyed by their key in the local store.
+type ColdTier interface {
+	// PutBundle stores a bundle.
+	PutBundle(ctx context.Context, key []byte, bundle []byte) error
+	// GetBundle returns a bundle stored by PutBundle.
+	GetBundle(ctx context.Context, key []byte) ([]byte, error)
+	// DeleteBundle deletes a bundle stored by PutBundle.
+	DeleteBundle(ctx context.Context, key []byte) error
+}
+
+// S3ColdTier is a ColdTier storing bundles as objects of an S3 bucket.
+type S3ColdTier struct {
+	client s3.Client
+	bucket string
+}
+
+var _ ColdTier = &S3ColdTier{}
+
+// NewS3ColdTier creates a ColdTier storing bundles in the given bucket.
+func NewS3ColdTier(client s3.Client, bucket string) *S3ColdTier {
+	return &S3ColdTier{
+		client: client,
+		bucket: bucket,
+	}
+}
+
+func (t *S3ColdTier) PutBundle(ctx context.Context, key []byte, bundle []byte) error {
+	return t.client.UploadObject(ctx, t.bucket, coldBundleObjectKey(key), bundle)
+}
+
+func (t *S3ColdTier) GetBundle(ctx context.Context, key []byte) ([]byte, error) {
+	return t.client.DownloadObject(ctx, t.bucket, coldBundleObjectKey(key))
+}
+
+func (t *S3ColdTier) DeleteBundle(ctx context.Context, key []byte) error {
+	return t.client.DeleteObject(ctx, t.bucket, coldBundleObjectKey(key))
+}
+
+func coldBundleObjectKey(key []byte) string {
+	return coldBundlePrefix + hex.EncodeToString(key)
+}
+
+// ChunkTieringManager periodically migrates the bundles of the v2 store older than the migration age to its cold
+// tier. The disk space of the migrated bundles is freed in the quorum quotas, since quotas only bound the local disk.
+type ChunkTieringManager struct {
+	store        StoreV2
+	migrationAge time.Duration
+	// quotas tracks the disk usage of quorums with a disk quota. Nil if no quorum has a quota.
+	quotas  *QuorumQuotaTracker
+	metrics *Metrics
+	logger  logging.Logger
+
+	// mu serializes the migration cycles
+	mu sync.Mutex
+}
+
+// NewChunkTieringManager creates a new ChunkTieringManager which migrates the bundles stored longer than
+// migrationAge.
+func NewChunkTieringManager(
+	store StoreV2,
+	migrationAge time.Duration,
+	quotas *QuorumQuotaTracker,
+	metrics *Metrics,
+	logger logging.Logger,
+) *ChunkTieringManager {
+	return &ChunkTieringManager{
+		store:        store,
+		migrationAge: migrationAge,
+		quotas:       quotas,
+		metrics:      metrics,
+		logger:       logger.With("component", "ChunkTieringManager"),
+	}
+}
+
+// Start runs a migration cycle once per interval in the background, until the context is cancelled.
+func (m *ChunkTieringManager) Start(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				migrated, err := m.RunMigrationCycle(ctx, time.Now())
+				if err != nil {
+					m.logger.Error("Failed to migrate stored bundles", "err", err)
+				}
+				if len(migrated) > 0 {
+					m.logger.Info("Migrated stored bundles to the cold tier", "bundles", len(migrated))
+				}
+			}
+		}
+	}()
+}
+
+// RunMigrationCycle migrates the bundles stored longer than the migration age at the given time, and returns the
+// migrated bundles. The bundles migrated before a failure are returned along with the error.
+func (m *ChunkTieringManager) RunMigrationCycle(ctx context.Context, now time.Time) ([]MigratedBundle, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	migrated, err := m.store.MigrateBundles(ctx, now.Add(-m.migrationAge))
+	freed := make(map[time.Time]map[core.QuorumID]uint64)
+	for _, bundle := range migrated {
+		if m.metrics != nil {
+			m.metrics.RecordMigratedBundle(bundle.Quorum, bundle.Size)
+		}
+		if _, ok := freed[bundle.StoredAt]; !ok {
+			freed[bundle.StoredAt] = make(map[core.QuorumID]uint64)
+		}
+		freed[bundle.StoredAt][bundle.Quorum] += bundle.Size
+	}
+	if m.quotas != nil {
+		for storedAt, sizes := range freed {
+			m.quotas.Free(sizes, storedAt)
+		}
+	}
+	return migrated, err
+}
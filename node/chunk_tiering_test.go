@@ -0,0 +1,109 @@
+package node_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	awsmock "github.com/Layr-Labs/eigenda/common/aws/mock"
+	"github.com/Layr-Labs/eigenda/common/kvstore"
+	"github.com/Layr-Labs/eigenda/common/kvstore/tablestore"
+	"github.com/Layr-Labs/eigenda/common/testutils"
+	"github.com/Layr-Labs/eigenda/core"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/Layr-Labs/eigenda/node"
+	nodemock "github.com/Layr-Labs/eigenda/node/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChunkTieringManager(t *testing.T) {
+	logger := testutils.GetLogger()
+	blobKeys, batch, bundles := nodemock.MockBatch(t)
+
+	batch.BlobCertificates = batch.BlobCertificates[:1]
+	rawBundles := &node.RawBundles{
+		BlobCertificate: batch.BlobCertificates[0],
+		Bundles:         make(map[core.QuorumID][]byte),
+		EncodingParams:  encoding.EncodingParams{NumChunks: 8, ChunkLength: 2},
+		Assignments: map[core.QuorumID]corev2.Assignment{
+			0: {StartIndex: 0, NumChunks: 1},
+			1: {StartIndex: 0, NumChunks: 4},
+		},
+	}
+	for quorum, bundle := range bundles[0] {
+		bundleBytes, err := bundle.Serialize()
+		require.NoError(t, err)
+		rawBundles.Bundles[quorum] = bundleBytes
+	}
+	expectedChunks, _, err := node.DecodeChunks(rawBundles.Bundles[0])
+	require.NoError(t, err)
+
+	config := tablestore.DefaultLevelDBConfig(t.TempDir())
+	config.Schema = []string{node.BatchHeaderTableName, node.BlobCertificateTableName, node.BundleTableName, node.ColdBundleTableName, node.PendingBatchTableName}
+	db, err := tablestore.Start(logger, config)
+	require.NoError(t, err)
+	defer func() {
+		_ = db.Shutdown()
+	}()
+	s3Client := awsmock.NewS3Client()
+	s := node.NewLevelDBStoreV2(db, logger, 10*time.Hour, nil, node.NewS3ColdTier(s3Client, "bucket"), false)
+
+	quotas, err := node.NewQuorumQuotaTracker(map[core.QuorumID]uint64{0: 1 << 20}, 1, 10*time.Hour, nil, nil)
+	require.NoError(t, err)
+	_, err = quotas.Reserve(node.BundleSizesByQuorum([]*node.RawBundles{rawBundles}), time.Now())
+	require.NoError(t, err)
+	_, _, err = s.StoreBatch(batch, []*node.RawBundles{rawBundles})
+	require.NoError(t, err)
+
+	tiering := node.NewChunkTieringManager(s, time.Hour, quotas, nil, logger)
+
+	// Recent bundles stay local
+	migrated, err := tiering.RunMigrationCycle(context.Background(), time.Now())
+	require.NoError(t, err)
+	require.Empty(t, migrated)
+
+	migrated, err = tiering.RunMigrationCycle(context.Background(), time.Now().Add(2*time.Hour))
+	require.NoError(t, err)
+	require.Len(t, migrated, 2)
+	require.Equal(t, 2, s3Client.Called["UploadObject"])
+	require.Equal(t, uint64(0), quotas.Usage(0, time.Now()))
+
+	// Migrated bundles are no longer stored locally, but are fetched back from the cold tier
+	bundleKeyBuilder, err := db.GetKeyBuilder(node.BundleTableName)
+	require.NoError(t, err)
+	k, err := node.BundleKey(blobKeys[0], 0)
+	require.NoError(t, err)
+	_, err = db.Get(bundleKeyBuilder.Key(k))
+	require.True(t, errors.Is(err, kvstore.ErrNotFound))
+	chunks, err := s.GetChunks(blobKeys[0], 0)
+	require.NoError(t, err)
+	require.Equal(t, expectedChunks, chunks)
+
+	// Bundles are only migrated once
+	migrated, err = tiering.RunMigrationCycle(context.Background(), time.Now().Add(2*time.Hour))
+	require.NoError(t, err)
+	require.Empty(t, migrated)
+
+	// Pruning a migrated bundle deletes it from the cold tier
+	pruner := node.NewPruningManager(s, []node.PruningPolicy{
+		node.NewQuorumRetentionPolicy(map[core.QuorumID]time.Duration{0: time.Hour}),
+	}, quotas, nil, logger)
+	report, err := pruner.RunPruningCycle(time.Now().Add(2 * time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, map[string]int{"quorum_retention": 1}, report.NumBundles)
+	require.Equal(t, uint64(0), report.Size)
+	require.Equal(t, 1, s3Client.Called["DeleteObject"])
+	_, err = s.GetChunks(blobKeys[0], 0)
+	require.True(t, errors.Is(err, kvstore.ErrNotFound))
+	_, err = s.GetChunks(blobKeys[0], 1)
+	require.NoError(t, err)
+}
+
+func TestChunkTieringConfigValidate(t *testing.T) {
+	require.NoError(t, (&node.ChunkTieringConfig{}).Validate())
+	require.Error(t, (&node.ChunkTieringConfig{Bucket: "bucket", Interval: time.Minute}).Validate())
+	require.Error(t, (&node.ChunkTieringConfig{Bucket: "bucket", MigrationAge: time.Hour}).Validate())
+	require.NoError(t, (&node.ChunkTieringConfig{Bucket: "bucket", MigrationAge: time.Hour, Interval: time.Minute}).Validate())
+}
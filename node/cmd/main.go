@@ -39,12 +39,60 @@ func main() {
 	app.Description = "Service for receiving and storing encoded blobs from disperser"
 
 	app.Action = NodeMain
+	app.Commands = []cli.Command{
+		{
+			Name:  "exit",
+			Usage: "start a clean exit of the operator",
+			Description: "Stops the running node from accepting new batches. The node keeps serving retrievals until " +
+				"the data it stored has expired, then deregisters the operator from its quorums.",
+			Action: ExitMain,
+		},
+		{
+			Name:  "rotate-keys",
+			Usage: "start a rotation of the operator to its next keys",
+			Description: "Registers the operator with the next ECDSA and BLS keys while the current keys stay registered, " +
+				"then deregisters the current keys once no batch can reference a block before the registration. " +
+				"The running node must be configured with the next keys.",
+			Action: RotateKeysMain,
+		},
+	}
 	err := app.Run(os.Args)
 	if err != nil {
 		log.Fatalf("application failed: %v", err)
 	}
+}
 
-	select {}
+// ExitMain requests a clean exit of the operator, which the running node picks up from its db directory.
+func ExitMain(ctx *cli.Context) error {
+	dbPath := ctx.GlobalString(flags.DbPathFlag.Name)
+	state, err := node.RequestExit(dbPath, time.Now())
+	if err != nil {
+		return err
+	}
+	if state.Deregistered() {
+		log.Printf("Operator exit started at %s, deregistered at %s. The node can be stopped.", state.StartedAt, state.DeregisteredAt)
+	} else {
+		log.Printf("Operator exit started at %s. The node no longer accepts new batches, and deregisters once the data it stored has expired.", state.StartedAt)
+	}
+	return nil
+}
+
+// RotateKeysMain requests a rotation of the operator keys, which the running node picks up from its db directory.
+func RotateKeysMain(ctx *cli.Context) error {
+	if ctx.GlobalString(flags.NextEcdsaKeyFileFlag.Name) == "" || ctx.GlobalString(flags.NextBlsKeyFileFlag.Name) == "" {
+		return fmt.Errorf("%s and %s are required to rotate the keys", flags.NextEcdsaKeyFileFlag.Name, flags.NextBlsKeyFileFlag.Name)
+	}
+	dbPath := ctx.GlobalString(flags.DbPathFlag.Name)
+	state, err := node.RequestKeyRotation(dbPath, time.Now())
+	if err != nil {
+		return err
+	}
+	if state.Registered() {
+		log.Printf("Key rotation started at %s, next keys registered at block %d.", state.StartedAt, state.RegisteredAtBlock)
+	} else {
+		log.Printf("Key rotation started at %s. The node registers the next keys, then deregisters the current keys once the transition has elapsed.", state.StartedAt)
+	}
+	return nil
 }
 
 func NodeMain(ctx *cli.Context) error {
@@ -117,6 +165,9 @@ func NodeMain(ctx *cli.Context) error {
 		}
 	}
 	err = nodegrpc.RunServers(server, serverV2, config, logger)
+	if err != nil {
+		return err
+	}
 
-	return err
+	select {}
 }
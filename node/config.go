@@ -3,6 +3,8 @@ package node
 import (
 	"errors"
 	"fmt"
+	"log/slog"
+	"math/big"
 	"os"
 	"strconv"
 	"strings"
@@ -10,9 +12,11 @@ import (
 
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/common/geth"
+	"github.com/Layr-Labs/eigenda/common/kvstore/tablestore"
 	"github.com/Layr-Labs/eigenda/core"
 	"github.com/Layr-Labs/eigenda/encoding/kzg"
 	"github.com/Layr-Labs/eigenda/node/flags"
+	"github.com/Layr-Labs/eigenda/node/signer"
 
 	blssignerTypes "github.com/Layr-Labs/eigensdk-go/signer/bls/types"
 
@@ -43,35 +47,90 @@ var (
 
 // Config contains all of the configuration information for a DA node.
 type Config struct {
-	Hostname                       string
-	RetrievalPort                  string
-	DispersalPort                  string
-	InternalRetrievalPort          string
-	InternalDispersalPort          string
-	V2DispersalPort                string
-	V2RetrievalPort                string
-	EnableNodeApi                  bool
-	NodeApiPort                    string
-	EnableMetrics                  bool
-	MetricsPort                    int
-	OnchainMetricsInterval         int64
-	Timeout                        time.Duration
-	RegisterNodeAtStart            bool
-	ExpirationPollIntervalSec      uint64
-	EnableTestMode                 bool
-	OverrideBlockStaleMeasure      uint64
-	OverrideStoreDurationBlocks    uint64
-	QuorumIDList                   []core.QuorumID
-	DbPath                         string
+	Hostname                    string
+	RetrievalPort               string
+	DispersalPort               string
+	InternalRetrievalPort       string
+	InternalDispersalPort       string
+	V2DispersalPort             string
+	V2RetrievalPort             string
+	EnableNodeApi               bool
+	NodeApiPort                 string
+	EnableMetrics               bool
+	MetricsPort                 int
+	OnchainMetricsInterval      int64
+	Timeout                     time.Duration
+	RegisterNodeAtStart         bool
+	ExpirationPollIntervalSec   uint64
+	EnableTestMode              bool
+	OverrideBlockStaleMeasure   uint64
+	OverrideStoreDurationBlocks uint64
+	QuorumIDList                []core.QuorumID
+	DbPath                      string
+	// DbBackend is the storage engine of the chunk stores
+	DbBackend tablestore.StoreType
+	// MigrateDb migrates the chunk stores to DbMigrationTarget while the node is running
+	MigrateDb         bool
+	DbMigrationTarget tablestore.StoreType
+	// ExpirationCompactionThreshold is the number of expired batches deleted between compactions of the chunk store.
+	// 0 disables the compactions.
+	ExpirationCompactionThreshold uint64
+	// ChunkAuditInterval is the interval at which the chunks of randomly sampled stored blobs are re-verified.
+	// 0 disables the background audits.
+	ChunkAuditInterval time.Duration
+	// ChunkAuditBlobs is the number of blobs re-verified per audit, and the maximum for on-demand audits
+	ChunkAuditBlobs int
+	// ChunkScrubInterval is the interval at which the scrubber re-verifies the next stored blobs. 0 disables it.
+	ChunkScrubInterval time.Duration
+	// ChunkScrubBlobs is the number of blobs re-verified per scrub
+	ChunkScrubBlobs int
+	// EgressBandwidthLimit limits the bandwidth used to serve chunks to retrievers
+	EgressBandwidthLimit BandwidthLimitConfig
+	// IngressBandwidthLimit limits the bandwidth used to receive chunks for StoreChunks requests
+	IngressBandwidthLimit BandwidthLimitConfig
+	// RetrievalLimit limits the chunk retrieval requests of each retriever
+	RetrievalLimit RetrievalLimitConfig
+	// ChunkEncryption configures the encryption at rest of the stored chunks
+	ChunkEncryption ChunkEncryptionConfig
+	// EnableDashboardApi serves the dashboard report for operators at DashboardApiPort
+	EnableDashboardApi bool
+	DashboardApiPort   string
+	// Pruning configures the deletion of stored chunks before they expire
+	Pruning PruningConfig
+	// TLS configures TLS, and optionally client certificate authentication, on the dispersal and retrieval servers
+	TLS TLSConfig
+	// SigningJournalRetention is how long attested batches are kept in the signing journal. 0 disables the journal.
+	SigningJournalRetention time.Duration
+	// ChunkTiering configures the migration of older chunks to an object store
+	ChunkTiering ChunkTieringConfig
+	// RetrievalCacheSize is the maximum size of the chunks cached for retrieval clients, in bytes. 0 disables the cache.
+	RetrievalCacheSize int
+	// OperatorStateCache configures the cache of the operator states batches are validated against
+	OperatorStateCache OperatorStateCacheConfig
+	// BatchHeaderCacheSize is the number of batches whose verified merkle tree is cached. 0 disables the cache.
+	BatchHeaderCacheSize int
+	// DeduplicateChunks stores the chunks assigned to the operator in several quorums of a blob once
+	DeduplicateChunks bool
+	// SocketUpdate configures the update of the socket registered on-chain when the node's public IP changes
+	SocketUpdate SocketUpdateConfig
+	// StorageAlerts configures the alerts raised before the disk holding the stores fills up
+	StorageAlerts StorageAlertConfig
+	// MaxBlobSizes are the maximum sizes of the blobs dispersed to quorums, in bytes. Quorums without a maximum are
+	// unlimited.
+	MaxBlobSizes map[core.QuorumID]uint64
+	// DiskQuotas are the disk quotas of the chunks of quorums, in bytes. Quorums without a quota are unlimited.
+	DiskQuotas map[core.QuorumID]uint64
+	// DiskQuotaHighWatermark is the fraction of its disk quota at which a quorum stops accepting new chunks
+	DiskQuotaHighWatermark         float64
 	LogPath                        string
 	ID                             core.OperatorID
 	BLSOperatorStateRetrieverAddr  string
 	EigenDAServiceManagerAddr      string
 	PubIPProviders                 []string
-	PubIPCheckInterval             time.Duration
 	ChurnerUrl                     string
 	DataApiUrl                     string
 	NumBatchValidators             int
+	ValidationSubBatchSize         int
 	NumBatchDeserializationWorkers int
 	EnableGnarkBundleEncoding      bool
 	ClientIPHeader                 string
@@ -81,6 +140,20 @@ type Config struct {
 	DisableNodeInfoResources       bool
 
 	BlsSignerConfig blssignerTypes.SignerConfig
+	// BlsSignerFallbackConfigs are remote signers holding the same key as the BLS signer, used when it fails
+	BlsSignerFallbackConfigs []blssignerTypes.SignerConfig
+	// BlsKMSConfig configures a BLS key decrypted with AWS KMS, which replaces BlsSignerConfig. Nil if unused.
+	BlsKMSConfig *signer.KMSConfig
+	// BlsSignerTimeout is the latency budget of each BLS signer. 0 means no budget.
+	BlsSignerTimeout time.Duration
+	// BlsSigningBatchWindow is how long attestations are collected before they're signed in one pass. 0 disables it.
+	BlsSigningBatchWindow time.Duration
+	// BlsSigningMaxBatchSize is the maximum number of attestations signed in one pass
+	BlsSigningMaxBatchSize int
+	// NextEcdsaPrivateKeyString and NextBlsSignerConfig are the keys the operator rotates to with the rotate-keys
+	// subcommand. NextBlsSignerConfig is nil if no rotation is configured.
+	NextEcdsaPrivateKeyString string
+	NextBlsSignerConfig       *blssignerTypes.SignerConfig
 
 	EthClientConfig geth.EthClientConfig
 	LoggerConfig    common.LoggerConfig
@@ -107,6 +180,16 @@ type Config struct {
 	// for this duration. Adds risk of disruptive behavior if an attacker is able to send requests from the same IP
 	// address as a legitimate disperser, but reduces performance overhead of StoreChunks validation.
 	DispersalAuthenticationTimeout time.Duration
+	// DisperserFilter configures which dispersers StoreChunks requests are accepted from (v2 only)
+	DisperserFilter DisperserFilterConfig
+	// SettingsFile is the path of the file the tunable settings are reloaded from on SIGHUP. Reloading is disabled if
+	// it's empty.
+	SettingsFile string
+	// LogLevel is the log level set by the flags, which the settings file may override
+	LogLevel slog.Level
+	// EnableAdminApi serves the admin endpoints, such as the settings reload, on localhost at AdminApiPort
+	EnableAdminApi bool
+	AdminApiPort   string
 }
 
 // NewConfig parses the Config from the provided flags or environment variables and
@@ -157,22 +240,37 @@ func NewConfig(ctx *cli.Context) (*Config, error) {
 	if !testMode {
 		ethClientConfig = geth.ReadEthClientConfigRPCOnly(ctx)
 		if needECDSAKey {
-			// Decrypt ECDSA key
-			keyContents, err := os.ReadFile(ctx.GlobalString(flags.EcdsaKeyFileFlag.Name))
+			ethClientConfig.PrivateKeyString, err = decryptEcdsaKey(ctx.GlobalString(flags.EcdsaKeyFileFlag.Name), ctx.GlobalString(flags.EcdsaKeyPasswordFlag.Name))
 			if err != nil {
-				return nil, fmt.Errorf("could not read ECDSA key file: %v", err)
+				return nil, err
 			}
-			sk, err := keystore.DecryptKey(keyContents, ctx.GlobalString(flags.EcdsaKeyPasswordFlag.Name))
-			if err != nil {
-				return nil, fmt.Errorf("could not decrypt the ECDSA file: %s", ctx.GlobalString(flags.EcdsaKeyFileFlag.Name))
-			}
-			ethClientConfig.PrivateKeyString = fmt.Sprintf("%x", crypto.FromECDSA(sk.PrivateKey))
 		}
 	} else {
 		ethClientConfig = geth.ReadEthClientConfig(ctx)
 	}
 
+	var nextEcdsaPrivateKeyString string
+	var nextBlsSignerConfig *blssignerTypes.SignerConfig
+	nextEcdsaKeyFile := ctx.GlobalString(flags.NextEcdsaKeyFileFlag.Name)
+	nextBlsKeyFile := ctx.GlobalString(flags.NextBlsKeyFileFlag.Name)
+	if nextEcdsaKeyFile != "" || nextBlsKeyFile != "" {
+		if nextEcdsaKeyFile == "" || nextBlsKeyFile == "" {
+			return nil, fmt.Errorf("%s and %s must be set together", flags.NextEcdsaKeyFileFlag.Name, flags.NextBlsKeyFileFlag.Name)
+		}
+		nextEcdsaPrivateKeyString, err = decryptEcdsaKey(nextEcdsaKeyFile, ctx.GlobalString(flags.NextEcdsaKeyPasswordFlag.Name))
+		if err != nil {
+			return nil, err
+		}
+		nextBlsSignerConfig = &blssignerTypes.SignerConfig{
+			SignerType: blssignerTypes.Local,
+			Path:       nextBlsKeyFile,
+			Password:   ctx.GlobalString(flags.NextBlsKeyPasswordFlag.Name),
+		}
+	}
+
 	var blsSignerConfig blssignerTypes.SignerConfig
+	var blsSignerFallbackConfigs []blssignerTypes.SignerConfig
+	var blsKMSConfig *signer.KMSConfig
 	if testMode && ctx.GlobalString(flags.TestPrivateBlsFlag.Name) != "" {
 		privateBls := ctx.GlobalString(flags.TestPrivateBlsFlag.Name)
 		blsSignerConfig = blssignerTypes.SignerConfig{
@@ -192,12 +290,31 @@ func NewConfig(ctx *cli.Context) (*Config, error) {
 		blsKeyPassword := ctx.GlobalString(flags.BlsKeyPasswordFlag.Name)
 		blsSignerAPIKey := ctx.GlobalString(flags.BLSSignerAPIKeyFlag.Name)
 
+		blsKMSEncryptedKeyFile := ctx.GlobalString(flags.BLSKMSEncryptedKeyFileFlag.Name)
+		blsRemoteSignerFallbackUrls := ctx.GlobalStringSlice(flags.BLSRemoteSignerFallbackUrlsFlag.Name)
+
+		if blsRemoteSignerEnabled && blsKMSEncryptedKeyFile != "" {
+			return nil, errors.New("BLS remote signer and KMS encrypted BLS key are mutually exclusive")
+		}
 		if blsRemoteSignerEnabled && (blsRemoteSignerUrl == "" || blsPublicKeyHex == "") {
 			return nil, errors.New("BLS remote signer URL and Public Key Hex is required if BLS remote signer is enabled")
 		}
-		if !blsRemoteSignerEnabled && (blsKeyFilePath == "" || blsKeyPassword == "") {
+		if !blsRemoteSignerEnabled && blsKMSEncryptedKeyFile == "" && (blsKeyFilePath == "" || blsKeyPassword == "") {
 			return nil, errors.New("BLS key file and password is required if BLS remote signer is disabled")
 		}
+		if !blsRemoteSignerEnabled && len(blsRemoteSignerFallbackUrls) > 0 {
+			return nil, errors.New("BLS remote signer fallback URLs require the BLS remote signer to be enabled")
+		}
+		if blsKMSEncryptedKeyFile != "" {
+			if ctx.GlobalString(flags.BLSKMSRegionFlag.Name) == "" {
+				return nil, errors.New("BLS KMS region is required if the KMS encrypted BLS key is set")
+			}
+			blsKMSConfig = &signer.KMSConfig{
+				EncryptedKeyFile: blsKMSEncryptedKeyFile,
+				Region:           ctx.GlobalString(flags.BLSKMSRegionFlag.Name),
+				Endpoint:         ctx.GlobalString(flags.BLSKMSEndpointFlag.Name),
+			}
+		}
 
 		if blsRemoteSignerEnabled && blsSignerAPIKey == "" {
 			return nil, errors.New("BLS signer API key is required if BLS remote signer is enabled")
@@ -218,6 +335,11 @@ func NewConfig(ctx *cli.Context) (*Config, error) {
 			TLSCertFilePath:  ctx.GlobalString(flags.BLSSignerCertFileFlag.Name),
 			CerberusAPIKey:   blsSignerAPIKey,
 		}
+		for _, url := range blsRemoteSignerFallbackUrls {
+			fallbackConfig := blsSignerConfig
+			fallbackConfig.CerberusUrl = url
+			blsSignerFallbackConfigs = append(blsSignerFallbackConfigs, fallbackConfig)
+		}
 	}
 
 	internalDispersalFlag := ctx.GlobalString(flags.InternalDispersalPortFlag.Name)
@@ -233,6 +355,11 @@ func NewConfig(ctx *cli.Context) (*Config, error) {
 	if err != nil {
 		return nil, err
 	}
+	// The log level is variable so that it can be changed by reloading the settings
+	logLevel := loggerConfig.HandlerOpts.Level.Level()
+	levelVar := new(slog.LevelVar)
+	levelVar.Set(logLevel)
+	loggerConfig.HandlerOpts.Level = levelVar
 
 	runtimeMode := ctx.GlobalString(flags.RuntimeModeFlag.Name)
 	switch runtimeMode {
@@ -242,6 +369,119 @@ func NewConfig(ctx *cli.Context) (*Config, error) {
 		return nil, fmt.Errorf("invalid runtime mode %q: must be one of %s, %s, or %s", runtimeMode, flags.ModeV1Only, flags.ModeV2Only, flags.ModeV1AndV2)
 	}
 
+	dbBackend, err := parseDbBackend(ctx.GlobalString(flags.DbBackendFlag.Name))
+	if err != nil {
+		return nil, err
+	}
+	var dbMigrationTarget tablestore.StoreType
+	migrateDb := ctx.GlobalString(flags.DbMigrationTargetFlag.Name) != ""
+	if migrateDb {
+		dbMigrationTarget, err = parseDbBackend(ctx.GlobalString(flags.DbMigrationTargetFlag.Name))
+		if err != nil {
+			return nil, fmt.Errorf("invalid db migration target: %w", err)
+		}
+		if dbMigrationTarget == dbBackend {
+			return nil, fmt.Errorf("the db migration target must differ from the db backend %q", ctx.GlobalString(flags.DbBackendFlag.Name))
+		}
+	}
+
+	storageAlerts := StorageAlertConfig{
+		CheckInterval:        ctx.GlobalDuration(flags.StorageAlertCheckIntervalFlag.Name),
+		WarningUsage:         ctx.GlobalFloat64(flags.StorageAlertWarningUsageFlag.Name),
+		CriticalUsage:        ctx.GlobalFloat64(flags.StorageAlertCriticalUsageFlag.Name),
+		WarningDaysUntilFull: ctx.GlobalFloat64(flags.StorageAlertWarningDaysUntilFullFlag.Name),
+		IngestRateWindow:     ctx.GlobalDuration(flags.StorageAlertIngestRateWindowFlag.Name),
+		WebhookURL:           ctx.GlobalString(flags.StorageAlertWebhookURLFlag.Name),
+	}
+	if err := storageAlerts.Validate(); err != nil {
+		return nil, err
+	}
+	socketUpdate, err := readSocketUpdateConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	diskQuotas, err := readDiskQuotas(ctx)
+	if err != nil {
+		return nil, err
+	}
+	maxBlobSizes, err := readMaxBlobSizes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	pruning, err := readPruningConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig := TLSConfig{
+		CertFile:              ctx.GlobalString(flags.TLSCertFileFlag.Name),
+		KeyFile:               ctx.GlobalString(flags.TLSKeyFileFlag.Name),
+		DispersalClientCAFile: ctx.GlobalString(flags.TLSDispersalClientCAFileFlag.Name),
+		RetrievalClientCAFile: ctx.GlobalString(flags.TLSRetrievalClientCAFileFlag.Name),
+		ReloadInterval:        ctx.GlobalDuration(flags.TLSReloadIntervalFlag.Name),
+	}
+	if err := tlsConfig.Validate(); err != nil {
+		return nil, err
+	}
+	disperserFilter := DisperserFilterConfig{
+		AllowlistFile:  ctx.GlobalString(flags.DisperserAllowlistFileFlag.Name),
+		DenylistFile:   ctx.GlobalString(flags.DisperserDenylistFileFlag.Name),
+		ReloadInterval: ctx.GlobalDuration(flags.DisperserFilterReloadIntervalFlag.Name),
+	}
+	if err := disperserFilter.Validate(); err != nil {
+		return nil, err
+	}
+	if disperserFilter.Enabled() && ctx.GlobalBool(flags.DisableDispersalAuthenticationFlag.Name) {
+		return nil, errors.New("the disperser allowlist and denylist require dispersal authentication")
+	}
+	if ctx.GlobalBool(flags.EnableAdminApiFlag.Name) && ctx.GlobalString(flags.SettingsFileFlag.Name) == "" {
+		return nil, errors.New("the admin api requires a settings file")
+	}
+	chunkTiering := ChunkTieringConfig{
+		Bucket:       ctx.GlobalString(flags.ChunkTieringBucketFlag.Name),
+		Region:       ctx.GlobalString(flags.ChunkTieringRegionFlag.Name),
+		EndpointURL:  ctx.GlobalString(flags.ChunkTieringEndpointFlag.Name),
+		MigrationAge: ctx.GlobalDuration(flags.ChunkTieringMigrationAgeFlag.Name),
+		Interval:     ctx.GlobalDuration(flags.ChunkTieringIntervalFlag.Name),
+	}
+	if err := chunkTiering.Validate(); err != nil {
+		return nil, err
+	}
+	if ctx.GlobalInt(flags.RetrievalCacheSizeFlag.Name) < 0 {
+		return nil, errors.New("the retrieval-cache-size flag must not be negative")
+	}
+	operatorStateCache := OperatorStateCacheConfig{
+		Size:                ctx.GlobalInt(flags.OperatorStateCacheSizeFlag.Name),
+		MaxDerivationBlocks: ctx.GlobalUint(flags.OperatorStateMaxDerivationBlocksFlag.Name),
+	}
+	if operatorStateCache.Size < 0 {
+		return nil, errors.New("the operator-state-cache-size flag must not be negative")
+	}
+	if ctx.GlobalInt(flags.BatchHeaderCacheSizeFlag.Name) < 0 {
+		return nil, errors.New("the batch-header-cache-size flag must not be negative")
+	}
+	diskQuotaHighWatermark := ctx.GlobalFloat64(flags.DiskQuotaHighWatermarkFlag.Name)
+	if len(diskQuotas) > 0 && (diskQuotaHighWatermark <= 0 || diskQuotaHighWatermark > 1) {
+		return nil, fmt.Errorf("the disk-quota-high-watermark flag must be in (0, 1], got %f", diskQuotaHighWatermark)
+	}
+
+	if ctx.GlobalInt(flags.ValidationSubBatchSizeFlag.Name) < 0 {
+		return nil, fmt.Errorf("the validation-sub-batch-size flag must not be negative")
+	}
+
+	if ctx.GlobalDuration(flags.SigningJournalRetentionFlag.Name) < 0 {
+		return nil, fmt.Errorf("the signing-journal-retention flag must not be negative")
+	}
+	if ctx.GlobalInt(flags.ChunkAuditBlobsFlag.Name) <= 0 {
+		return nil, fmt.Errorf("the chunk-audit-blobs flag must be positive")
+	}
+	if ctx.GlobalInt(flags.ChunkScrubBlobsFlag.Name) <= 0 {
+		return nil, fmt.Errorf("the chunk-scrub-blobs flag must be positive")
+	}
+	if ctx.GlobalInt(flags.BLSSigningMaxBatchSizeFlag.Name) <= 0 {
+		return nil, fmt.Errorf("the bls-signing-max-batch-size flag must be positive")
+	}
+
 	// Convert mode to v1/v2 enabled flags
 	v1Enabled := runtimeMode == flags.ModeV1Only || runtimeMode == flags.ModeV1AndV2
 	v2Enabled := runtimeMode == flags.ModeV2Only || runtimeMode == flags.ModeV1AndV2
@@ -273,6 +513,33 @@ func NewConfig(ctx *cli.Context) (*Config, error) {
 		}
 	}
 
+	egressBandwidthLimit := BandwidthLimitConfig{
+		BytesPerSecond:     ctx.GlobalFloat64(flags.MaxEgressBytesPerSecondFlag.Name),
+		Burstiness:         ctx.GlobalInt(flags.EgressBytesBurstinessFlag.Name),
+		PeerBytesPerSecond: ctx.GlobalFloat64(flags.MaxEgressBytesPerSecondPeerFlag.Name),
+		PeerBurstiness:     ctx.GlobalInt(flags.EgressBytesBurstinessPeerFlag.Name),
+	}
+	ingressBandwidthLimit := BandwidthLimitConfig{
+		BytesPerSecond:     ctx.GlobalFloat64(flags.MaxIngressBytesPerSecondFlag.Name),
+		Burstiness:         ctx.GlobalInt(flags.IngressBytesBurstinessFlag.Name),
+		PeerBytesPerSecond: ctx.GlobalFloat64(flags.MaxIngressBytesPerSecondPeerFlag.Name),
+		PeerBurstiness:     ctx.GlobalInt(flags.IngressBytesBurstinessPeerFlag.Name),
+	}
+	retrievalLimit := RetrievalLimitConfig{
+		PeerRequestsPerSecond:    ctx.GlobalFloat64(flags.MaxRetrievalRequestsPerSecondPeerFlag.Name),
+		PeerBurstiness:           ctx.GlobalInt(flags.RetrievalRequestsBurstinessPeerFlag.Name),
+		MaxConcurrentRequests:    ctx.GlobalInt(flags.MaxConcurrentRetrievalsFlag.Name),
+		MaxQueuedRequestsPerPeer: ctx.GlobalInt(flags.MaxQueuedRetrievalsPeerFlag.Name),
+	}
+
+	chunkEncryption := ChunkEncryptionConfig{
+		KeyFile:         ctx.GlobalString(flags.ChunkEncryptionKeyFileFlag.Name),
+		KMSKeyID:        ctx.GlobalString(flags.ChunkEncryptionKMSKeyIDFlag.Name),
+		KMSRegion:       ctx.GlobalString(flags.ChunkEncryptionKMSRegionFlag.Name),
+		KMSEndpoint:     ctx.GlobalString(flags.ChunkEncryptionKMSEndpointFlag.Name),
+		DataKeyLifetime: ctx.GlobalDuration(flags.ChunkEncryptionDataKeyLifetimeFlag.Name),
+	}
+
 	return &Config{
 		Hostname:                            ctx.GlobalString(flags.HostnameFlag.Name),
 		DispersalPort:                       dispersalPort,
@@ -289,22 +556,49 @@ func NewConfig(ctx *cli.Context) (*Config, error) {
 		Timeout:                             timeout,
 		RegisterNodeAtStart:                 registerNodeAtStart,
 		ExpirationPollIntervalSec:           expirationPollIntervalSec,
+		ExpirationCompactionThreshold:       ctx.GlobalUint64(flags.ExpirationCompactionThresholdFlag.Name),
+		DiskQuotas:                          diskQuotas,
+		DiskQuotaHighWatermark:              diskQuotaHighWatermark,
+		MaxBlobSizes:                        maxBlobSizes,
+		Pruning:                             pruning,
+		TLS:                                 tlsConfig,
+		SigningJournalRetention:             ctx.GlobalDuration(flags.SigningJournalRetentionFlag.Name),
+		ChunkTiering:                        chunkTiering,
+		RetrievalCacheSize:                  ctx.GlobalInt(flags.RetrievalCacheSizeFlag.Name),
+		OperatorStateCache:                  operatorStateCache,
+		BatchHeaderCacheSize:                ctx.GlobalInt(flags.BatchHeaderCacheSizeFlag.Name),
+		DeduplicateChunks:                   ctx.GlobalBool(flags.DeduplicateChunksFlag.Name),
 		ReachabilityPollIntervalSec:         reachabilityPollIntervalSec,
 		EnableTestMode:                      testMode,
 		OverrideBlockStaleMeasure:           ctx.GlobalUint64(flags.OverrideBlockStaleMeasureFlag.Name),
 		OverrideStoreDurationBlocks:         ctx.GlobalUint64(flags.OverrideStoreDurationBlocksFlag.Name),
 		QuorumIDList:                        ids,
 		DbPath:                              ctx.GlobalString(flags.DbPathFlag.Name),
+		DbBackend:                           dbBackend,
+		MigrateDb:                           migrateDb,
+		DbMigrationTarget:                   dbMigrationTarget,
 		EthClientConfig:                     ethClientConfig,
 		EncoderConfig:                       kzg.ReadCLIConfig(ctx),
 		LoggerConfig:                        *loggerConfig,
 		BLSOperatorStateRetrieverAddr:       ctx.GlobalString(flags.BlsOperatorStateRetrieverFlag.Name),
 		EigenDAServiceManagerAddr:           ctx.GlobalString(flags.EigenDAServiceManagerFlag.Name),
 		PubIPProviders:                      ctx.GlobalStringSlice(flags.PubIPProviderFlag.Name),
-		PubIPCheckInterval:                  pubIPCheckInterval,
+		SocketUpdate:                        socketUpdate,
+		StorageAlerts:                       storageAlerts,
 		ChurnerUrl:                          ctx.GlobalString(flags.ChurnerUrlFlag.Name),
 		DataApiUrl:                          ctx.GlobalString(flags.DataApiUrlFlag.Name),
 		NumBatchValidators:                  ctx.GlobalInt(flags.NumBatchValidatorsFlag.Name),
+		ValidationSubBatchSize:              ctx.GlobalInt(flags.ValidationSubBatchSizeFlag.Name),
+		ChunkAuditInterval:                  ctx.GlobalDuration(flags.ChunkAuditIntervalFlag.Name),
+		ChunkAuditBlobs:                     ctx.GlobalInt(flags.ChunkAuditBlobsFlag.Name),
+		ChunkScrubInterval:                  ctx.GlobalDuration(flags.ChunkScrubIntervalFlag.Name),
+		ChunkScrubBlobs:                     ctx.GlobalInt(flags.ChunkScrubBlobsFlag.Name),
+		EgressBandwidthLimit:                egressBandwidthLimit,
+		IngressBandwidthLimit:               ingressBandwidthLimit,
+		RetrievalLimit:                      retrievalLimit,
+		ChunkEncryption:                     chunkEncryption,
+		EnableDashboardApi:                  ctx.GlobalBool(flags.EnableDashboardApiFlag.Name),
+		DashboardApiPort:                    ctx.GlobalString(flags.DashboardApiPortFlag.Name),
 		NumBatchDeserializationWorkers:      ctx.GlobalInt(flags.NumBatchDeserializationWorkersFlag.Name),
 		EnableGnarkBundleEncoding:           ctx.Bool(flags.EnableGnarkBundleEncodingFlag.Name),
 		ClientIPHeader:                      ctx.GlobalString(flags.ClientIPHeaderFlag.Name),
@@ -312,6 +606,13 @@ func NewConfig(ctx *cli.Context) (*Config, error) {
 		RelayMaxMessageSize:                 uint(ctx.GlobalInt(flags.RelayMaxGRPCMessageSizeFlag.Name)),
 		DisableNodeInfoResources:            ctx.GlobalBool(flags.DisableNodeInfoResourcesFlag.Name),
 		BlsSignerConfig:                     blsSignerConfig,
+		BlsSignerFallbackConfigs:            blsSignerFallbackConfigs,
+		BlsKMSConfig:                        blsKMSConfig,
+		BlsSignerTimeout:                    ctx.GlobalDuration(flags.BLSSignerTimeoutFlag.Name),
+		BlsSigningBatchWindow:               ctx.GlobalDuration(flags.BLSSigningBatchWindowFlag.Name),
+		BlsSigningMaxBatchSize:              ctx.GlobalInt(flags.BLSSigningMaxBatchSizeFlag.Name),
+		NextEcdsaPrivateKeyString:           nextEcdsaPrivateKeyString,
+		NextBlsSignerConfig:                 nextBlsSignerConfig,
 		EnableV2:                            v2Enabled,
 		EnableV1:                            v1Enabled,
 		OnchainStateRefreshInterval:         ctx.GlobalDuration(flags.OnchainStateRefreshIntervalFlag.Name),
@@ -322,6 +623,152 @@ func NewConfig(ctx *cli.Context) (*Config, error) {
 		DisableDispersalAuthentication:      ctx.GlobalBool(flags.DisableDispersalAuthenticationFlag.Name),
 		DispersalAuthenticationKeyCacheSize: ctx.GlobalInt(flags.DispersalAuthenticationKeyCacheSizeFlag.Name),
 		DisperserKeyTimeout:                 ctx.GlobalDuration(flags.DisperserKeyTimeoutFlag.Name),
+		DisperserFilter:                     disperserFilter,
 		DispersalAuthenticationTimeout:      ctx.GlobalDuration(flags.DispersalAuthenticationTimeoutFlag.Name),
+		SettingsFile:                        ctx.GlobalString(flags.SettingsFileFlag.Name),
+		LogLevel:                            logLevel,
+		EnableAdminApi:                      ctx.GlobalBool(flags.EnableAdminApiFlag.Name),
+		AdminApiPort:                        ctx.GlobalString(flags.AdminApiPortFlag.Name),
 	}, nil
 }
+
+// parseDbBackend parses the name of a chunk store backend.
+func parseDbBackend(backend string) (tablestore.StoreType, error) {
+	switch backend {
+	case flags.DbBackendLevelDB:
+		return tablestore.LevelDB, nil
+	case flags.DbBackendPebble:
+		return tablestore.Pebble, nil
+	default:
+		return 0, fmt.Errorf("invalid db backend %q: must be one of %s or %s", backend, flags.DbBackendLevelDB, flags.DbBackendPebble)
+	}
+}
+
+// readDiskQuotas reads the per-quorum disk quotas. Each quorum ID must have a corresponding entry in the disk quota
+// flag.
+func readDiskQuotas(ctx *cli.Context) (map[core.QuorumID]uint64, error) {
+	quorums := ctx.GlobalIntSlice(flags.DiskQuotaQuorumsFlag.Name)
+	quotasGB := ctx.GlobalIntSlice(flags.DiskQuotaGBFlag.Name)
+	if len(quorums) != len(quotasGB) {
+		return nil, errors.New("number of disk quotas does not match number of disk quota quorums")
+	}
+
+	quotas := make(map[core.QuorumID]uint64, len(quorums))
+	for i, quorumID := range quorums {
+		if quorumID < 0 || quorumID > core.MaxQuorumID {
+			return nil, fmt.Errorf("invalid disk quota quorum %d", quorumID)
+		}
+		if quotasGB[i] <= 0 {
+			return nil, fmt.Errorf("disk quota of quorum %d must be positive", quorumID)
+		}
+		if _, ok := quotas[core.QuorumID(quorumID)]; ok {
+			return nil, fmt.Errorf("duplicate disk quota for quorum %d", quorumID)
+		}
+		quotas[core.QuorumID(quorumID)] = uint64(quotasGB[i]) << 30
+	}
+	return quotas, nil
+}
+
+// readMaxBlobSizes reads the per-quorum maximum blob sizes. Each quorum ID must have a corresponding entry in the max
+// blob size flag.
+func readMaxBlobSizes(ctx *cli.Context) (map[core.QuorumID]uint64, error) {
+	quorums := ctx.GlobalIntSlice(flags.MaxBlobSizeQuorumsFlag.Name)
+	sizesKiB := ctx.GlobalIntSlice(flags.MaxBlobSizeKiBFlag.Name)
+	if len(quorums) != len(sizesKiB) {
+		return nil, errors.New("number of max blob sizes does not match number of max blob size quorums")
+	}
+
+	sizes := make(map[core.QuorumID]uint64, len(quorums))
+	for i, quorumID := range quorums {
+		if quorumID < 0 || quorumID > core.MaxQuorumID {
+			return nil, fmt.Errorf("invalid max blob size quorum %d", quorumID)
+		}
+		if sizesKiB[i] <= 0 {
+			return nil, fmt.Errorf("max blob size of quorum %d must be positive", quorumID)
+		}
+		if _, ok := sizes[core.QuorumID(quorumID)]; ok {
+			return nil, fmt.Errorf("duplicate max blob size for quorum %d", quorumID)
+		}
+		sizes[core.QuorumID(quorumID)] = uint64(sizesKiB[i]) << 10
+	}
+	return sizes, nil
+}
+
+// readSocketUpdateConfig reads the configuration of the update of the socket registered on-chain.
+func readSocketUpdateConfig(ctx *cli.Context) (SocketUpdateConfig, error) {
+	config := SocketUpdateConfig{
+		CheckInterval:    ctx.GlobalDuration(flags.PubIPCheckIntervalFlag.Name),
+		ConfirmChecks:    ctx.GlobalInt(flags.PubIPConfirmChecksFlag.Name),
+		RetryInterval:    ctx.GlobalDuration(flags.SocketUpdateRetryIntervalFlag.Name),
+		MaxRetryInterval: ctx.GlobalDuration(flags.SocketUpdateMaxRetryIntervalFlag.Name),
+	}
+	if config.ConfirmChecks < 1 {
+		return SocketUpdateConfig{}, fmt.Errorf("%s must be positive", flags.PubIPConfirmChecksFlag.Name)
+	}
+	if config.RetryInterval <= 0 || config.MaxRetryInterval < config.RetryInterval {
+		return SocketUpdateConfig{}, fmt.Errorf("%s must be positive and at most %s",
+			flags.SocketUpdateRetryIntervalFlag.Name, flags.SocketUpdateMaxRetryIntervalFlag.Name)
+	}
+	maxGasPriceGwei := ctx.GlobalFloat64(flags.SocketUpdateMaxGasPriceGweiFlag.Name)
+	if maxGasPriceGwei < 0 {
+		return SocketUpdateConfig{}, fmt.Errorf("%s must not be negative", flags.SocketUpdateMaxGasPriceGweiFlag.Name)
+	}
+	if maxGasPriceGwei > 0 {
+		config.MaxGasPrice, _ = new(big.Float).Mul(big.NewFloat(maxGasPriceGwei), big.NewFloat(1e9)).Int(nil)
+	}
+	return config, nil
+}
+
+// readPruningConfig reads the pruning policies. Each pruning retention quorum ID must have a corresponding entry in the
+// pruning retention days flag.
+func readPruningConfig(ctx *cli.Context) (PruningConfig, error) {
+	config := PruningConfig{
+		Interval:              ctx.GlobalDuration(flags.PruningIntervalFlag.Name),
+		QuorumRetention:       make(map[core.QuorumID]time.Duration),
+		MinStakeFraction:      ctx.GlobalFloat64(flags.PruningMinStakeFractionFlag.Name),
+		MaxDiskUsage:          ctx.GlobalFloat64(flags.PruningMaxDiskUsageFlag.Name),
+		DiskPressureRetention: ctx.GlobalDuration(flags.PruningDiskPressureRetentionFlag.Name),
+	}
+
+	quorums := ctx.GlobalIntSlice(flags.PruningRetentionQuorumsFlag.Name)
+	days := ctx.GlobalIntSlice(flags.PruningRetentionDaysFlag.Name)
+	if len(quorums) != len(days) {
+		return PruningConfig{}, errors.New("number of pruning retentions does not match number of pruning retention quorums")
+	}
+	for i, quorumID := range quorums {
+		if quorumID < 0 || quorumID > core.MaxQuorumID {
+			return PruningConfig{}, fmt.Errorf("invalid pruning retention quorum %d", quorumID)
+		}
+		if days[i] <= 0 {
+			return PruningConfig{}, fmt.Errorf("pruning retention of quorum %d must be positive", quorumID)
+		}
+		if _, ok := config.QuorumRetention[core.QuorumID(quorumID)]; ok {
+			return PruningConfig{}, fmt.Errorf("duplicate pruning retention for quorum %d", quorumID)
+		}
+		config.QuorumRetention[core.QuorumID(quorumID)] = time.Duration(days[i]) * 24 * time.Hour
+	}
+
+	if config.MinStakeFraction < 0 || config.MinStakeFraction >= 1 {
+		return PruningConfig{}, fmt.Errorf("the pruning-min-stake-fraction flag must be in [0, 1), got %f", config.MinStakeFraction)
+	}
+	if config.MaxDiskUsage < 0 || config.MaxDiskUsage >= 1 {
+		return PruningConfig{}, fmt.Errorf("the pruning-max-disk-usage flag must be in [0, 1), got %f", config.MaxDiskUsage)
+	}
+	if config.MaxDiskUsage > 0 && config.DiskPressureRetention <= 0 {
+		return PruningConfig{}, errors.New("the pruning-disk-pressure-retention flag must be positive")
+	}
+	return config, nil
+}
+
+// decryptEcdsaKey decrypts the ECDSA key in the given keystore file, and returns it in hex.
+func decryptEcdsaKey(path string, password string) (string, error) {
+	keyContents, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("could not read ECDSA key file: %v", err)
+	}
+	sk, err := keystore.DecryptKey(keyContents, password)
+	if err != nil {
+		return "", fmt.Errorf("could not decrypt the ECDSA file: %s", path)
+	}
+	return fmt.Sprintf("%x", crypto.FromECDSA(sk.PrivateKey)), nil
+}
@@ -0,0 +1,246 @@
+package node
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+const (
+	// DashboardPath is the path at which the dashboard report is served
+	DashboardPath = "/api/v1/dashboard"
+
+	// dashboardStorageRefreshInterval is the interval at which the storage utilization is recomputed, since it
+	// requires a scan of the whole store
+	dashboardStorageRefreshInterval = 5 * time.Minute
+	// dashboardChainTimeout is the timeout for fetching the current block number from the chain
+	dashboardChainTimeout = 5 * time.Second
+)
+
+// QuorumStorage is the storage utilization of the chunks of a quorum.
+type QuorumStorage struct {
+	QuorumID core.QuorumID `json:"quorum_id"`
+	// UsedBytes is the size of the stored bundles of the quorum
+	UsedBytes uint64 `json:"used_bytes"`
+	// QuotaBytes is the disk quota of the quorum, or 0 if it's unlimited
+	QuotaBytes uint64 `json:"quota_bytes"`
+}
+
+// StorageReport is the storage utilization of the v2 chunk store.
+type StorageReport struct {
+	Quorums []QuorumStorage `json:"quorums"`
+	// UpdatedAt is the time at which the utilization was computed. It's zero until the first scan of the store.
+	UpdatedAt time.Time `json:"updated_at"`
+	// Disk is the usage of the disk holding the stores at the last storage alert check. It's nil if storage alerts
+	// are disabled, or the disk wasn't checked yet.
+	Disk *StorageStatus `json:"disk,omitempty"`
+}
+
+// AttestationReport is the number of batches signed and missed by the node over recent time windows.
+type AttestationReport struct {
+	LastHour AttestationStats `json:"last_hour"`
+	LastDay  AttestationStats `json:"last_day"`
+}
+
+// ChainSyncReport is the health of the node's view of the chain.
+type ChainSyncReport struct {
+	// Healthy is true if the node can reach the chain and its on-chain state is up to date
+	Healthy            bool   `json:"healthy"`
+	CurrentBlockNumber uint64 `json:"current_block_number"`
+	// LatestReferenceBlockNumber is the highest reference block of the batches received by the node
+	LatestReferenceBlockNumber uint64 `json:"latest_reference_block_number"`
+	// ReferenceBlockLag is the number of blocks between the current block and the latest reference block
+	ReferenceBlockLag uint64 `json:"reference_block_lag"`
+	// LastOnchainStateRefresh is the time at which the on-chain state was last refreshed
+	LastOnchainStateRefresh time.Time `json:"last_onchain_state_refresh"`
+	Error                   string    `json:"error,omitempty"`
+}
+
+// DashboardReport is the machine-readable summary of the node's state served to operator dashboards.
+type DashboardReport struct {
+	OperatorID        string             `json:"operator_id"`
+	Timestamp         time.Time          `json:"timestamp"`
+	Storage           StorageReport      `json:"storage"`
+	Attestations      AttestationReport  `json:"attestations"`
+	ValidationLatency LatencyPercentiles `json:"validation_latency"`
+	ChainSync         ChainSyncReport    `json:"chain_sync"`
+}
+
+// DashboardServer serves a read-only report of the node's state over HTTP, for operator dashboards.
+type DashboardServer struct {
+	node       *Node
+	logger     logging.Logger
+	socketAddr string
+
+	mu                 sync.Mutex
+	storageUsage       map[core.QuorumID]uint64
+	storageUpdatedTime time.Time
+}
+
+// NewDashboardServer creates a new DashboardServer, which listens at the given socket address once started.
+func NewDashboardServer(node *Node, socketAddr string, logger logging.Logger) *DashboardServer {
+	return &DashboardServer{
+		node:         node,
+		logger:       logger.With("component", "DashboardServer"),
+		socketAddr:   socketAddr,
+		storageUsage: make(map[core.QuorumID]uint64),
+	}
+}
+
+// Start serves the dashboard in the background, until the context is cancelled.
+func (d *DashboardServer) Start(ctx context.Context) {
+	if d.node.StoreV2 != nil {
+		go d.refreshStorageLoop(ctx)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(DashboardPath, d.ServeHTTP)
+	server := &http.Server{
+		Addr:              d.socketAddr,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			d.logger.Error("Dashboard server failed", "err", err)
+		}
+	}()
+	go func() {
+		<-ctx.Done()
+		_ = server.Close()
+	}()
+}
+
+// ServeHTTP serves the dashboard report as JSON.
+func (d *DashboardServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	report := d.Report(r.Context(), time.Now())
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(report); err != nil {
+		d.logger.Error("Failed to write dashboard report", "err", err)
+	}
+}
+
+// Report returns the dashboard report at the given time.
+func (d *DashboardServer) Report(ctx context.Context, now time.Time) *DashboardReport {
+	stats := d.node.Stats
+	return &DashboardReport{
+		OperatorID: d.node.Config.ID.Hex(),
+		Timestamp:  now,
+		Storage:    d.storageReport(),
+		Attestations: AttestationReport{
+			LastHour: stats.Attestations(time.Hour, now),
+			LastDay:  stats.Attestations(24*time.Hour, now),
+		},
+		ValidationLatency: stats.ValidationLatency(),
+		ChainSync:         d.chainSyncReport(ctx, now),
+	}
+}
+
+// RefreshStorage recomputes the storage utilization of the quorums from the store.
+func (d *DashboardServer) RefreshStorage() error {
+	usage, err := d.node.StoreV2.GetQuorumUsage()
+	if err != nil {
+		return fmt.Errorf("failed to get the disk usage of quorums: %w", err)
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.storageUsage = usage
+	d.storageUpdatedTime = time.Now()
+	return nil
+}
+
+// refreshStorageLoop periodically recomputes the storage utilization, until the context is cancelled.
+func (d *DashboardServer) refreshStorageLoop(ctx context.Context) {
+	ticker := time.NewTicker(dashboardStorageRefreshInterval)
+	defer ticker.Stop()
+	for {
+		if err := d.RefreshStorage(); err != nil {
+			d.logger.Error("Failed to refresh storage utilization", "err", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// storageReport returns the last computed storage utilization. Quorums with a disk quota are reported even if they
+// have no stored bundles.
+func (d *DashboardServer) storageReport() StorageReport {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	quorums := make(map[core.QuorumID]*QuorumStorage)
+	for quorum, used := range d.storageUsage {
+		quorums[quorum] = &QuorumStorage{QuorumID: quorum, UsedBytes: used}
+	}
+	quotas := d.node.Config.DiskQuotas
+	if d.node.DiskQuotas != nil {
+		// The quotas may have been reloaded since the node started
+		quotas = d.node.DiskQuotas.Quotas()
+	}
+	for quorum, quota := range quotas {
+		if _, ok := quorums[quorum]; !ok {
+			quorums[quorum] = &QuorumStorage{QuorumID: quorum}
+		}
+		quorums[quorum].QuotaBytes = quota
+	}
+
+	report := StorageReport{
+		Quorums:   make([]QuorumStorage, 0, len(quorums)),
+		UpdatedAt: d.storageUpdatedTime,
+	}
+	if d.node.StorageAlerter != nil {
+		report.Disk = d.node.StorageAlerter.Status()
+	}
+	for _, storage := range quorums {
+		report.Quorums = append(report.Quorums, *storage)
+	}
+	sort.Slice(report.Quorums, func(i, j int) bool {
+		return report.Quorums[i].QuorumID < report.Quorums[j].QuorumID
+	})
+	return report
+}
+
+// chainSyncReport checks that the chain is reachable and that the on-chain state has been refreshed recently.
+func (d *DashboardServer) chainSyncReport(ctx context.Context, now time.Time) ChainSyncReport {
+	stats := d.node.Stats
+	report := ChainSyncReport{
+		LatestReferenceBlockNumber: stats.LatestReferenceBlock(),
+		LastOnchainStateRefresh:    stats.LastOnchainStateRefresh(),
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, dashboardChainTimeout)
+	defer cancel()
+	currentBlock, err := d.node.Transactor.GetCurrentBlockNumber(ctx)
+	if err != nil {
+		report.Error = fmt.Sprintf("failed to get current block number: %v", err)
+		return report
+	}
+	report.CurrentBlockNumber = uint64(currentBlock)
+	if report.CurrentBlockNumber > report.LatestReferenceBlockNumber && report.LatestReferenceBlockNumber > 0 {
+		report.ReferenceBlockLag = report.CurrentBlockNumber - report.LatestReferenceBlockNumber
+	}
+
+	// The on-chain state is stale if it missed more than one refresh
+	refreshInterval := d.node.Config.OnchainStateRefreshInterval
+	if d.node.Config.EnableV2 && refreshInterval > 0 && now.Sub(report.LastOnchainStateRefresh) > 2*refreshInterval {
+		report.Error = fmt.Sprintf("on-chain state was last refreshed at %s", report.LastOnchainStateRefresh.Format(time.RFC3339))
+		return report
+	}
+	report.Healthy = true
+	return report
+}
@@ -0,0 +1,114 @@
+package node_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common/testutils"
+	"github.com/Layr-Labs/eigenda/core"
+	coremock "github.com/Layr-Labs/eigenda/core/mock"
+	"github.com/Layr-Labs/eigenda/node"
+	nodemock "github.com/Layr-Labs/eigenda/node/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDashboardReport(t *testing.T) {
+	ctx := context.Background()
+	_, batch, bundles := nodemock.MockBatch(t)
+	rawBundles := make([]*node.RawBundles, len(batch.BlobCertificates))
+	for i, cert := range batch.BlobCertificates {
+		rawBundles[i] = &node.RawBundles{
+			BlobCertificate: cert,
+			Bundles:         make(map[core.QuorumID][]byte),
+		}
+		for quorum, bundle := range bundles[i] {
+			bundleBytes, err := bundle.Serialize()
+			require.NoError(t, err)
+			rawBundles[i].Bundles[quorum] = bundleBytes
+		}
+	}
+
+	s, db := createStoreV2(t)
+	defer func() {
+		_ = db.Shutdown()
+	}()
+	_, _, err := s.StoreBatch(batch, rawBundles)
+	require.NoError(t, err)
+
+	tx := &coremock.MockWriter{}
+	tx.On("GetCurrentBlockNumber").Return(uint32(110), nil).Once()
+	n := &node.Node{
+		Config: &node.Config{
+			EnableV2:                    true,
+			OnchainStateRefreshInterval: time.Minute,
+			DiskQuotas:                  map[core.QuorumID]uint64{1: 1 << 30, 5: 1 << 20},
+		},
+		StoreV2:    s,
+		Transactor: tx,
+		Stats:      node.NewNodeStats(),
+	}
+	now := time.Now()
+	n.Stats.RecordAttestation(true, now)
+	n.Stats.RecordAttestation(false, now.Add(-2*time.Hour))
+	n.Stats.RecordValidationLatency(20 * time.Millisecond)
+	n.Stats.RecordReferenceBlock(100)
+	n.Stats.RecordOnchainStateRefresh(now)
+
+	dashboard := node.NewDashboardServer(n, ":0", testutils.GetLogger())
+	require.NoError(t, dashboard.RefreshStorage())
+
+	report := dashboard.Report(ctx, now)
+	expectedUsage := node.BundleSizesByQuorum(rawBundles)
+	require.Len(t, report.Storage.Quorums, len(expectedUsage)+1)
+	for _, storage := range report.Storage.Quorums {
+		require.Equal(t, expectedUsage[storage.QuorumID], storage.UsedBytes)
+		require.Equal(t, n.Config.DiskQuotas[storage.QuorumID], storage.QuotaBytes)
+	}
+	require.Equal(t, node.AttestationStats{Signed: 1}, report.Attestations.LastHour)
+	require.Equal(t, node.AttestationStats{Signed: 1, Missed: 1}, report.Attestations.LastDay)
+	require.Equal(t, 20.0, report.ValidationLatency.P50Ms)
+	require.True(t, report.ChainSync.Healthy)
+	require.Equal(t, uint64(110), report.ChainSync.CurrentBlockNumber)
+	require.Equal(t, uint64(10), report.ChainSync.ReferenceBlockLag)
+
+	// The on-chain state hasn't been refreshed recently
+	tx.On("GetCurrentBlockNumber").Return(uint32(110), nil).Once()
+	report = dashboard.Report(ctx, now.Add(time.Hour))
+	require.False(t, report.ChainSync.Healthy)
+	require.NotEmpty(t, report.ChainSync.Error)
+
+	// The chain is unreachable
+	tx.On("GetCurrentBlockNumber").Return(uint32(0), errors.New("connection refused")).Once()
+	report = dashboard.Report(ctx, now)
+	require.False(t, report.ChainSync.Healthy)
+	require.Contains(t, report.ChainSync.Error, "connection refused")
+}
+
+func TestDashboardServeHTTP(t *testing.T) {
+	tx := &coremock.MockWriter{}
+	tx.On("GetCurrentBlockNumber").Return(uint32(1), nil)
+	n := &node.Node{
+		Config:     &node.Config{},
+		Transactor: tx,
+		Stats:      node.NewNodeStats(),
+	}
+	dashboard := node.NewDashboardServer(n, ":0", testutils.GetLogger())
+
+	recorder := httptest.NewRecorder()
+	dashboard.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, node.DashboardPath, nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+	report := &node.DashboardReport{}
+	require.NoError(t, json.NewDecoder(recorder.Body).Decode(report))
+	require.True(t, report.ChainSync.Healthy)
+	require.Equal(t, uint64(1), report.ChainSync.CurrentBlockNumber)
+
+	recorder = httptest.NewRecorder()
+	dashboard.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, node.DashboardPath, nil))
+	require.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+}
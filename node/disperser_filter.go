@@ -0,0 +1,226 @@
+package node
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+)
+
+// ErrDisperserNotAllowed is returned when a request comes from a disperser the node doesn't accept dispersals from.
+var ErrDisperserNotAllowed = errors.New("disperser not allowed")
+
+// DisperserFilterConfig configures which dispersers the node accepts StoreChunks requests from, by the ECDSA
+// address the dispersers sign their requests with.
+type DisperserFilterConfig struct {
+	// AllowlistFile lists the addresses of the only dispersers whose requests are accepted. All dispersers are
+	// allowed if it's empty.
+	AllowlistFile string
+	// DenylistFile lists the addresses of dispersers whose requests are refused, even if they're in the allowlist.
+	DenylistFile string
+	// ReloadInterval is the minimum interval between checks of the files for changes.
+	ReloadInterval time.Duration
+}
+
+// Enabled returns true if requests are filtered by disperser.
+func (c *DisperserFilterConfig) Enabled() bool {
+	return c.AllowlistFile != "" || c.DenylistFile != ""
+}
+
+// Validate checks that the config is consistent.
+func (c *DisperserFilterConfig) Validate() error {
+	if c.Enabled() && c.ReloadInterval <= 0 {
+		return errors.New("the disperser filter reload interval must be positive")
+	}
+	return nil
+}
+
+// DisperserFilter accepts or refuses dispersers by their address. The lists are reloaded when their files change,
+// so that operators can block a misbehaving disperser without restarting the node.
+type DisperserFilter struct {
+	logger logging.Logger
+
+	// mu guards the fields below
+	mu        sync.Mutex
+	config    DisperserFilterConfig
+	lastCheck time.Time
+	// modTimes are the modification times of the loaded files, by path
+	modTimes map[string]time.Time
+	// allowed is nil if all dispersers are allowed
+	allowed map[gethcommon.Address]struct{}
+	denied  map[gethcommon.Address]struct{}
+}
+
+// NewDisperserFilter creates a DisperserFilter for the given config, loading its files.
+func NewDisperserFilter(config DisperserFilterConfig, logger logging.Logger) (*DisperserFilter, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	if !config.Enabled() {
+		return nil, errors.New("the disperser filter is not configured")
+	}
+	return newDisperserFilter(config, logger)
+}
+
+// newDisperserFilter creates a DisperserFilter even if it's not configured, in which case it allows all dispersers
+// until it's configured with SetConfig.
+func newDisperserFilter(config DisperserFilterConfig, logger logging.Logger) (*DisperserFilter, error) {
+	f := &DisperserFilter{
+		config:   config,
+		logger:   logger.With("component", "DisperserFilter"),
+		modTimes: make(map[string]time.Time),
+		denied:   make(map[gethcommon.Address]struct{}),
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	modTimes, err := f.statFiles()
+	if err != nil {
+		return nil, err
+	}
+	if err := f.loadLists(modTimes); err != nil {
+		return nil, err
+	}
+	f.lastCheck = time.Now()
+	return f, nil
+}
+
+// CheckDisperser returns an error wrapping ErrDisperserNotAllowed if requests from the disperser with the given
+// address must be refused. The files are reloaded first if they may have changed.
+func (f *DisperserFilter) CheckDisperser(address gethcommon.Address, now time.Time) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if now.Sub(f.lastCheck) >= f.config.ReloadInterval {
+		if err := f.load(now); err != nil {
+			// Keep filtering with the lists loaded last
+			f.logger.Error("Failed to reload the disperser filter", "err", err)
+		}
+	}
+
+	if _, ok := f.denied[address]; ok {
+		return fmt.Errorf("%w: disperser %s is in the denylist", ErrDisperserNotAllowed, address.Hex())
+	}
+	if f.allowed != nil {
+		if _, ok := f.allowed[address]; !ok {
+			return fmt.Errorf("%w: disperser %s is not in the allowlist", ErrDisperserNotAllowed, address.Hex())
+		}
+	}
+	return nil
+}
+
+// SetConfig replaces the config, loading the lists from its files right away. The previous lists are kept if the
+// files fail to load.
+func (f *DisperserFilter) SetConfig(config DisperserFilterConfig) error {
+	if err := config.Validate(); err != nil {
+		return err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	previous := f.config
+	f.config = config
+	modTimes, err := f.statFiles()
+	if err == nil {
+		err = f.loadLists(modTimes)
+	}
+	if err != nil {
+		f.config = previous
+		return err
+	}
+	f.lastCheck = time.Now()
+	return nil
+}
+
+// load loads the lists if any of their files changed since they were last loaded. Nothing is replaced if any file
+// fails to load. The caller must hold the lock.
+func (f *DisperserFilter) load(now time.Time) error {
+	f.lastCheck = now
+
+	modTimes, err := f.statFiles()
+	if err != nil {
+		return err
+	}
+	changed := false
+	for path, modTime := range modTimes {
+		if !modTime.Equal(f.modTimes[path]) {
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+	return f.loadLists(modTimes)
+}
+
+// statFiles returns the modification times of the configured files, by path. The caller must hold the lock.
+func (f *DisperserFilter) statFiles() (map[string]time.Time, error) {
+	modTimes := make(map[string]time.Time)
+	for _, path := range []string{f.config.AllowlistFile, f.config.DenylistFile} {
+		if path == "" {
+			continue
+		}
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to stat %s: %w", path, err)
+		}
+		modTimes[path] = info.ModTime()
+	}
+	return modTimes, nil
+}
+
+// loadLists loads the lists from the configured files. Nothing is replaced if any file fails to load. The caller
+// must hold the lock.
+func (f *DisperserFilter) loadLists(modTimes map[string]time.Time) error {
+	var allowed map[gethcommon.Address]struct{}
+	if f.config.AllowlistFile != "" {
+		list, err := readAddressList(f.config.AllowlistFile)
+		if err != nil {
+			return err
+		}
+		allowed = list
+	}
+	denied := make(map[gethcommon.Address]struct{})
+	if f.config.DenylistFile != "" {
+		list, err := readAddressList(f.config.DenylistFile)
+		if err != nil {
+			return err
+		}
+		denied = list
+	}
+
+	f.logger.Info("Loaded the disperser filter", "allowed", len(allowed), "denied", len(denied))
+	f.modTimes = modTimes
+	f.allowed = allowed
+	f.denied = denied
+	return nil
+}
+
+// readAddressList reads a file of hex encoded addresses, one per line. Empty lines and lines starting with # are
+// ignored.
+func readAddressList(path string) (map[gethcommon.Address]struct{}, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	addresses := make(map[gethcommon.Address]struct{})
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		if !gethcommon.IsHexAddress(text) {
+			return nil, fmt.Errorf("invalid address %q on line %d of %s", text, line, path)
+		}
+		addresses[gethcommon.HexToAddress(text)] = struct{}{}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return addresses, nil
+}
@@ -0,0 +1,62 @@
+package node_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common/testutils"
+	"github.com/Layr-Labs/eigenda/node"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDisperserFilter(t *testing.T) {
+	dir := t.TempDir()
+	allowlist := filepath.Join(dir, "allowlist")
+	denylist := filepath.Join(dir, "denylist")
+	disperser1 := gethcommon.HexToAddress("0x1111111111111111111111111111111111111111")
+	disperser2 := gethcommon.HexToAddress("0x2222222222222222222222222222222222222222")
+	disperser3 := gethcommon.HexToAddress("0x3333333333333333333333333333333333333333")
+
+	require.NoError(t, os.WriteFile(allowlist, []byte("# dispersers\n"+disperser1.Hex()+"\n\n"+disperser2.Hex()+"\n"), 0600))
+	require.NoError(t, os.WriteFile(denylist, []byte(disperser2.Hex()+"\n"), 0600))
+	config := node.DisperserFilterConfig{
+		AllowlistFile:  allowlist,
+		DenylistFile:   denylist,
+		ReloadInterval: time.Minute,
+	}
+	filter, err := node.NewDisperserFilter(config, testutils.GetLogger())
+	require.NoError(t, err)
+
+	now := time.Now()
+	require.NoError(t, filter.CheckDisperser(disperser1, now))
+	// The denylist takes precedence over the allowlist
+	require.ErrorIs(t, filter.CheckDisperser(disperser2, now), node.ErrDisperserNotAllowed)
+	require.ErrorIs(t, filter.CheckDisperser(disperser3, now), node.ErrDisperserNotAllowed)
+
+	// Changes are picked up once the reload interval has passed
+	require.NoError(t, os.WriteFile(denylist, []byte(disperser1.Hex()+"\n"), 0600))
+	require.NoError(t, os.Chtimes(denylist, now.Add(time.Second), now.Add(time.Second)))
+	require.NoError(t, filter.CheckDisperser(disperser1, now.Add(time.Second)))
+	require.ErrorIs(t, filter.CheckDisperser(disperser1, now.Add(time.Minute)), node.ErrDisperserNotAllowed)
+	require.NoError(t, filter.CheckDisperser(disperser2, now.Add(time.Minute)))
+
+	// An invalid file is ignored, and the last loaded lists are kept
+	require.NoError(t, os.WriteFile(denylist, []byte("not an address\n"), 0600))
+	require.NoError(t, os.Chtimes(denylist, now.Add(2*time.Second), now.Add(2*time.Second)))
+	require.ErrorIs(t, filter.CheckDisperser(disperser1, now.Add(2*time.Minute)), node.ErrDisperserNotAllowed)
+
+	// Without an allowlist, all dispersers which aren't denied are allowed
+	require.NoError(t, os.WriteFile(denylist, []byte(disperser2.Hex()+"\n"), 0600))
+	filter, err = node.NewDisperserFilter(node.DisperserFilterConfig{DenylistFile: denylist, ReloadInterval: time.Minute}, testutils.GetLogger())
+	require.NoError(t, err)
+	require.NoError(t, filter.CheckDisperser(disperser3, now))
+	require.ErrorIs(t, filter.CheckDisperser(disperser2, now), node.ErrDisperserNotAllowed)
+
+	// Invalid files are refused at startup
+	require.NoError(t, os.WriteFile(denylist, []byte("0x1234\n"), 0600))
+	_, err = node.NewDisperserFilter(node.DisperserFilterConfig{DenylistFile: denylist, ReloadInterval: time.Minute}, testutils.GetLogger())
+	require.ErrorContains(t, err, "invalid address")
+}
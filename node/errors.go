@@ -9,4 +9,6 @@ var (
 	ErrKeyNotFound          = errors.New("commit not found in db")
 	ErrKeyExpired           = errors.New("commit is expired")
 	ErrKeyNotFoundOrExpired = errors.New("data is either expired or not found")
+	// ErrChunkNotStored is returned when a chunk of a blob isn't stored by the node
+	ErrChunkNotStored = errors.New("chunk is not stored")
 )
@@ -0,0 +1,195 @@
+package node
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+// ExitStateFileName is the name of the file, in the node's db directory, which records the progress of the exit of
+// the operator.
+const ExitStateFileName = "exit.json"
+
+// ExitState is the progress of the exit of the operator.
+type ExitState struct {
+	// StartedAt is the time the exit was requested. From then on the node stops accepting new batches.
+	StartedAt time.Time `json:"startedAt"`
+	// DeregisteredAt is the time the operator was deregistered from its quorums, once the data it stored before the
+	// exit has expired. Zero until then.
+	DeregisteredAt time.Time `json:"deregisteredAt,omitempty"`
+}
+
+// Deregistered returns true if the operator has been deregistered from its quorums.
+func (s *ExitState) Deregistered() bool {
+	return !s.DeregisteredAt.IsZero()
+}
+
+// ReadExitState reads the exit state from the db directory of the node. It returns nil if no exit was requested.
+func ReadExitState(dbPath string) (*ExitState, error) {
+	data, err := os.ReadFile(filepath.Join(dbPath, ExitStateFileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read exit state: %w", err)
+	}
+	state := &ExitState{}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("failed to parse exit state: %w", err)
+	}
+	return state, nil
+}
+
+// writeExitState atomically replaces the exit state in the db directory of the node.
+func writeExitState(dbPath string, state *ExitState) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("failed to encode exit state: %w", err)
+	}
+	path := filepath.Join(dbPath, ExitStateFileName)
+	if err := os.WriteFile(path+".tmp", data, 0644); err != nil {
+		return fmt.Errorf("failed to write exit state: %w", err)
+	}
+	if err := os.Rename(path+".tmp", path); err != nil {
+		return fmt.Errorf("failed to write exit state: %w", err)
+	}
+	return nil
+}
+
+// RequestExit records that the operator is exiting, in the db directory of the node, which the running node picks
+// up. If an exit was already requested, its state is returned unchanged.
+func RequestExit(dbPath string, now time.Time) (*ExitState, error) {
+	state, err := ReadExitState(dbPath)
+	if err != nil {
+		return nil, err
+	}
+	if state != nil {
+		return state, nil
+	}
+	state = &ExitState{StartedAt: now}
+	if err := writeExitState(dbPath, state); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+// OperatorDeregisterer deregisters the operator from its quorums.
+type OperatorDeregisterer interface {
+	Deregister(ctx context.Context) error
+}
+
+// ExitManager coordinates the clean exit of an operator. Once an exit is requested the node stops accepting new
+// batches, but keeps serving retrievals until the data it already stored has expired, after which the operator is
+// deregistered from its quorums. This keeps the data dispersed to the operator available to the end of its storage
+// period, unlike stopping the node outright. Handing the data off to other operators to exit sooner isn't supported.
+type ExitManager struct {
+	dbPath string
+	// storagePeriod is the time the node keeps the data it stores
+	storagePeriod time.Duration
+	deregisterer  OperatorDeregisterer
+	logger        logging.Logger
+
+	// mu guards state
+	mu    sync.Mutex
+	state *ExitState
+}
+
+// NewExitManager creates a new ExitManager, resuming the exit recorded in the db directory of the node if any.
+func NewExitManager(
+	dbPath string,
+	storagePeriod time.Duration,
+	deregisterer OperatorDeregisterer,
+	logger logging.Logger,
+) (*ExitManager, error) {
+	state, err := ReadExitState(dbPath)
+	if err != nil {
+		return nil, err
+	}
+	return &ExitManager{
+		dbPath:        dbPath,
+		storagePeriod: storagePeriod,
+		deregisterer:  deregisterer,
+		logger:        logger.With("component", "ExitManager"),
+		state:         state,
+	}, nil
+}
+
+// Exiting returns true if the operator is exiting, in which case the node must not accept new batches.
+func (m *ExitManager) Exiting() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.state != nil
+}
+
+// State returns the progress of the exit, or nil if the operator isn't exiting.
+func (m *ExitManager) State() *ExitState {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.state == nil {
+		return nil
+	}
+	state := *m.state
+	return &state
+}
+
+// Start checks for a requested exit and advances it once per interval in the background, until the context is
+// cancelled.
+func (m *ExitManager) Start(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := m.RunExitCycle(ctx, time.Now()); err != nil {
+					m.logger.Error("Failed to advance the operator exit", "err", err)
+				}
+			}
+		}
+	}()
+}
+
+// RunExitCycle picks up a newly requested exit, and deregisters the operator once the storage period has elapsed
+// since the exit started.
+func (m *ExitManager) RunExitCycle(ctx context.Context, now time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.state == nil {
+		state, err := ReadExitState(m.dbPath)
+		if err != nil {
+			return err
+		}
+		if state == nil {
+			return nil
+		}
+		m.logger.Info("Operator exit requested, no longer accepting new batches",
+			"startedAt", state.StartedAt, "deregisterAt", state.StartedAt.Add(m.storagePeriod))
+		m.state = state
+	}
+	if m.state.Deregistered() || now.Before(m.state.StartedAt.Add(m.storagePeriod)) {
+		return nil
+	}
+
+	m.logger.Info("Storage period elapsed, deregistering the operator")
+	if err := m.deregisterer.Deregister(ctx); err != nil {
+		return fmt.Errorf("failed to deregister the operator: %w", err)
+	}
+	state := *m.state
+	state.DeregisteredAt = now
+	if err := writeExitState(m.dbPath, &state); err != nil {
+		return err
+	}
+	m.state = &state
+	m.logger.Info("Operator deregistered, the node can be stopped")
+	return nil
+}
@@ -0,0 +1,70 @@
+package node_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common/testutils"
+	"github.com/Layr-Labs/eigenda/node"
+	"github.com/stretchr/testify/require"
+)
+
+// mockDeregisterer counts the deregistrations of the operator.
+type mockDeregisterer struct {
+	calls int
+	err   error
+}
+
+func (d *mockDeregisterer) Deregister(ctx context.Context) error {
+	d.calls++
+	return d.err
+}
+
+func TestExitManager(t *testing.T) {
+	ctx := context.Background()
+	dbPath := t.TempDir()
+	deregisterer := &mockDeregisterer{}
+	m, err := node.NewExitManager(dbPath, time.Hour, deregisterer, testutils.GetLogger())
+	require.NoError(t, err)
+
+	// Nothing happens until an exit is requested
+	require.NoError(t, m.RunExitCycle(ctx, time.Now()))
+	require.False(t, m.Exiting())
+	require.Nil(t, m.State())
+
+	start := time.Now()
+	state, err := node.RequestExit(dbPath, start)
+	require.NoError(t, err)
+	require.False(t, state.Deregistered())
+	// Requesting an exit again doesn't restart it
+	again, err := node.RequestExit(dbPath, start.Add(time.Minute))
+	require.NoError(t, err)
+	require.True(t, state.StartedAt.Equal(again.StartedAt))
+
+	// The node stops accepting batches, but stays registered until the storage period has elapsed
+	require.NoError(t, m.RunExitCycle(ctx, start.Add(time.Minute)))
+	require.True(t, m.Exiting())
+	require.Equal(t, 0, deregisterer.calls)
+
+	// Failed deregistrations are retried
+	deregisterer.err = errors.New("transaction failed")
+	require.Error(t, m.RunExitCycle(ctx, start.Add(time.Hour)))
+	require.False(t, m.State().Deregistered())
+	deregisterer.err = nil
+	require.NoError(t, m.RunExitCycle(ctx, start.Add(time.Hour)))
+	require.True(t, m.State().Deregistered())
+	require.NoError(t, m.RunExitCycle(ctx, start.Add(2*time.Hour)))
+	require.Equal(t, 2, deregisterer.calls)
+
+	// The exit resumes after a restart
+	state, err = node.ReadExitState(dbPath)
+	require.NoError(t, err)
+	require.True(t, state.Deregistered())
+	m, err = node.NewExitManager(dbPath, time.Hour, deregisterer, testutils.GetLogger())
+	require.NoError(t, err)
+	require.True(t, m.Exiting())
+	require.NoError(t, m.RunExitCycle(ctx, start.Add(2*time.Hour)))
+	require.Equal(t, 2, deregisterer.calls)
+}
@@ -0,0 +1,68 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+// ExpiryManager removes the batches and blobs whose storage period has ended from the store. Chunks are dropped in
+// contiguous deletes where the store supports them, and the store is compacted once enough batches have been
+// deleted, so that the space of the expired chunks is reclaimed in large, cheap compactions rather than left to
+// background compactions which rewrite live data along with it.
+type ExpiryManager struct {
+	store  *Store
+	logger logging.Logger
+
+	// compactionThreshold is the number of batches to delete between compactions. 0 disables the compactions.
+	compactionThreshold uint64
+	// numBatchesSinceCompaction is the number of batches deleted since the last compaction
+	numBatchesSinceCompaction uint64
+}
+
+// NewExpiryManager creates a new ExpiryManager for the given store.
+func NewExpiryManager(store *Store, compactionThreshold uint64, logger logging.Logger) *ExpiryManager {
+	return &ExpiryManager{
+		store:               store,
+		logger:              logger.With("component", "ExpiryManager"),
+		compactionThreshold: compactionThreshold,
+	}
+}
+
+// RunExpirationCycle deletes the entries which expired by currentTimeUnixSec, spending at most timeLimitSec seconds
+// on it, then compacts the store if enough batches have been deleted since the last compaction. It returns the
+// number of batches deleted. Like DeleteExpiredEntries, batches may have been deleted even if an error is returned.
+func (m *ExpiryManager) RunExpirationCycle(currentTimeUnixSec int64, timeLimitSec uint64) (int, error) {
+	numBatchesDeleted, numMappingsDeleted, numBlobsDeleted, err := m.store.DeleteExpiredEntries(currentTimeUnixSec, timeLimitSec)
+	m.logger.Info("Complete an expiration cycle to remove expired batches", "num expired batches found and removed", numBatchesDeleted, "num expired mappings found and removed", numMappingsDeleted, "num expired blobs found and removed", numBlobsDeleted)
+	if numBatchesDeleted > 0 {
+		m.numBatchesSinceCompaction += uint64(numBatchesDeleted)
+	}
+	if err != nil {
+		return numBatchesDeleted, err
+	}
+
+	// Only compact once the backlog of expired batches has been worked through, so that compactions don't eat into
+	// the time needed to delete it.
+	if m.compactionThreshold == 0 || m.numBatchesSinceCompaction < m.compactionThreshold {
+		return numBatchesDeleted, nil
+	}
+	start := time.Now()
+	if err := m.store.Compact(); err != nil {
+		return numBatchesDeleted, err
+	}
+	m.logger.Info("Compacted the store after removing expired batches", "num batches", m.numBatchesSinceCompaction, "duration", time.Since(start))
+	m.numBatchesSinceCompaction = 0
+	return numBatchesDeleted, nil
+}
+
+// logExpirationError logs the error of an expiration cycle, which is retried in the next cycle.
+func (m *ExpiryManager) logExpirationError(err error, timeLimitSec uint64) {
+	if errors.Is(err, context.DeadlineExceeded) {
+		m.logger.Error("Expiration cycle exited with ContextDeadlineExceed, meaning more expired batches need to be removed, which will continue in next cycle", "time limit (sec)", timeLimitSec)
+	} else {
+		m.logger.Error("Expiration cycle encountered error when removing expired batches, which will be retried in next cycle", "err", err)
+	}
+}
@@ -0,0 +1,59 @@
+package node_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common/kvstore/tablestore"
+	"github.com/Layr-Labs/eigenda/common/testutils"
+	"github.com/Layr-Labs/eigenda/node"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpiryManager(t *testing.T) {
+	for _, backend := range []tablestore.StoreType{tablestore.LevelDB, tablestore.Pebble} {
+		s := createStoreWithBackend(t, backend)
+		ctx := context.Background()
+		expiryManager := node.NewExpiryManager(s, 2, testutils.GetLogger())
+
+		batchHeader, blobs, blobsProto := CreateBatch(t)
+		_, err := s.StoreBatch(ctx, batchHeader, blobs, blobsProto)
+		assert.Nil(t, err)
+		batchHeaderHash, err := batchHeader.GetBatchHeaderHash()
+		assert.Nil(t, err)
+		blobKey, err := node.EncodeBlobKey(batchHeaderHash, 0, 0)
+		assert.Nil(t, err)
+
+		// Nothing has expired yet.
+		curTime := time.Now().Unix() + int64(staleMeasure+storeDuration)*12
+		numDeleted, err := expiryManager.RunExpirationCycle(curTime-10, 1)
+		assert.Nil(t, err)
+		assert.Equal(t, 0, numDeleted)
+		assert.True(t, s.HasKey(ctx, blobKey))
+
+		// The batch expires, which doesn't reach the compaction threshold yet.
+		numDeleted, err = expiryManager.RunExpirationCycle(curTime+10, 1)
+		assert.Nil(t, err)
+		assert.Equal(t, 1, numDeleted)
+		assert.False(t, s.HasKey(ctx, node.EncodeBatchHeaderKey(batchHeaderHash)))
+		assert.False(t, s.HasKey(ctx, blobKey))
+
+		// Expiring a second batch triggers a compaction.
+		batchHeader.ReferenceBlockNumber++
+		_, err = s.StoreBatch(ctx, batchHeader, blobs, blobsProto)
+		assert.Nil(t, err)
+		numDeleted, err = expiryManager.RunExpirationCycle(curTime+10, 1)
+		assert.Nil(t, err)
+		assert.Equal(t, 1, numDeleted)
+
+		// The store is still usable after the compaction.
+		_, err = s.StoreBatch(ctx, batchHeader, blobs, blobsProto)
+		assert.Nil(t, err)
+		batchHeaderHash, err = batchHeader.GetBatchHeaderHash()
+		assert.Nil(t, err)
+		chunks, _, err := s.GetChunks(ctx, batchHeaderHash, 0, 0)
+		assert.Nil(t, err)
+		assert.Equal(t, blobsProto[0].Bundles[0].Chunks, chunks)
+	}
+}
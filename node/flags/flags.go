@@ -20,6 +20,10 @@ const (
 	ModeV1Only  = "v1-only"
 	ModeV2Only  = "v2-only"
 	ModeV1AndV2 = "v1-and-v2"
+
+	// Chunk store backend values
+	DbBackendLevelDB = "leveldb"
+	DbBackendPebble  = "pebble"
 )
 
 var (
@@ -170,7 +174,7 @@ var (
 	}
 	PubIPProviderFlag = cli.StringSliceFlag{
 		Name:     common.PrefixFlag(FlagPrefix, "public-ip-provider"),
-		Usage:    "The ip provider service(s) used to obtain a node's public IP. Valid options: 'seeip', 'ipify'",
+		Usage:    "The ip provider service(s) used to obtain a node's public IP. Valid options: 'seeip', 'ipify', 'stun' or 'stun:<host>:<port>' to use a STUN server",
 		Required: true,
 		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "PUBLIC_IP_PROVIDER"),
 	}
@@ -184,6 +188,34 @@ var (
 
 	/* Optional Flags */
 
+	PubIPConfirmChecksFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "public-ip-confirm-checks"),
+		Usage:    "Number of consecutive public IP checks which must detect the same new socket before it's registered on-chain",
+		Required: false,
+		Value:    3,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "PUBLIC_IP_CONFIRM_CHECKS"),
+	}
+	SocketUpdateRetryIntervalFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "socket-update-retry-interval"),
+		Usage:    "Time waited before retrying a failed or postponed update of the socket registered on-chain. It doubles with each failure, up to the max socket update retry interval",
+		Required: false,
+		Value:    30 * time.Second,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "SOCKET_UPDATE_RETRY_INTERVAL"),
+	}
+	SocketUpdateMaxRetryIntervalFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "socket-update-max-retry-interval"),
+		Usage:    "Maximum time waited before retrying a failed or postponed update of the socket registered on-chain",
+		Required: false,
+		Value:    10 * time.Minute,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "SOCKET_UPDATE_MAX_RETRY_INTERVAL"),
+	}
+	SocketUpdateMaxGasPriceGweiFlag = cli.Float64Flag{
+		Name:     common.PrefixFlag(FlagPrefix, "socket-update-max-gas-price-gwei"),
+		Usage:    "Gas price in gwei above which updates of the socket registered on-chain are postponed. 0 means no limit",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "SOCKET_UPDATE_MAX_GAS_PRICE_GWEI"),
+	}
+
 	// This flag is used to control if the DA Node registers itself when it starts.
 	// This is useful for testing and for hosted node where we don't want to have
 	// mannual operation with CLI to register.
@@ -225,6 +257,13 @@ var (
 		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "NUM_BATCH_VALIDATORS"),
 		Value:    128,
 	}
+	ValidationSubBatchSizeFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "validation-sub-batch-size"),
+		Usage:    "maximum number of blobs whose chunk proofs are verified with a single pairing check. Sub-batches are verified in parallel by the batch validators. 0 verifies all blobs with the same encoding params together",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "VALIDATION_SUB_BATCH_SIZE"),
+		Value:    16,
+	}
 	NumBatchDeserializationWorkersFlag = cli.IntFlag{
 		Name:     "num-batch-deserialization-workers",
 		Usage:    "maximum number of parallel workers used to deserialize a batch (defaults to 128)",
@@ -344,6 +383,82 @@ var (
 		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "BLS_SIGNER_API_KEY"),
 	}
 
+	BLSRemoteSignerFallbackUrlsFlag = cli.StringSliceFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "bls-remote-signer-fallback-urls"),
+		Usage:    "The URLs of remote signers holding the same key as the BLS signer, which are used in order when the signers before them fail or exceed the BLS signer timeout. They share the public key, API key and certificate of the BLS remote signer",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "BLS_REMOTE_SIGNER_FALLBACK_URLS"),
+	}
+
+	BLSSignerTimeoutFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "bls-signer-timeout"),
+		Usage:    "The latency budget of each BLS signer, after which signing fails over to the next signer. 0 means no budget",
+		Required: false,
+		Value:    0,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "BLS_SIGNER_TIMEOUT"),
+	}
+	BLSSigningBatchWindowFlag = cli.DurationFlag{
+		Name: common.PrefixFlag(FlagPrefix, "bls-signing-batch-window"),
+		Usage: "How long batch attestations are collected before they're signed in one pass by a shared signing worker, " +
+			"which smooths the CPU usage of bursts of StoreChunks requests. 0 signs each batch as soon as it's validated",
+		Required: false,
+		Value:    0,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "BLS_SIGNING_BATCH_WINDOW"),
+	}
+	BLSSigningMaxBatchSizeFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "bls-signing-max-batch-size"),
+		Usage:    "The maximum number of batch attestations signed in one pass when the bls signing batch window is set",
+		Required: false,
+		Value:    32,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "BLS_SIGNING_MAX_BATCH_SIZE"),
+	}
+
+	BLSKMSEncryptedKeyFileFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "bls-kms-encrypted-key-file"),
+		Usage:    "The path to the base64 encoded AWS KMS ciphertext of the BLS private key. If set, the key is decrypted with KMS into memory instead of being read from a keystore or a remote signer",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "BLS_KMS_ENCRYPTED_KEY_FILE"),
+	}
+
+	BLSKMSRegionFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "bls-kms-region"),
+		Usage:    "The AWS region of the KMS key encrypting the BLS private key",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "BLS_KMS_REGION"),
+	}
+
+	BLSKMSEndpointFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "bls-kms-endpoint"),
+		Usage:    "The endpoint of AWS KMS, which overrides the default endpoint of the region",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "BLS_KMS_ENDPOINT"),
+	}
+	// The keys the operator rotates to with the rotate-keys subcommand.
+	NextEcdsaKeyFileFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "next-ecdsa-key-file"),
+		Usage:    "Path to the encrypted ecdsa private key the operator rotates to",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "NEXT_ECDSA_KEY_FILE"),
+	}
+	NextEcdsaKeyPasswordFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "next-ecdsa-key-password"),
+		Usage:    "Password to decrypt the ecdsa private key the operator rotates to",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "NEXT_ECDSA_KEY_PASSWORD"),
+	}
+	NextBlsKeyFileFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "next-bls-key-file"),
+		Usage:    "Path to the encrypted bls private key the operator rotates to",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "NEXT_BLS_KEY_FILE"),
+	}
+	NextBlsKeyPasswordFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "next-bls-key-password"),
+		Usage:    "Password to decrypt the bls private key the operator rotates to",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "NEXT_BLS_KEY_PASSWORD"),
+	}
+
 	PprofHttpPort = cli.StringFlag{
 		Name:     common.PrefixFlag(FlagPrefix, "pprof-http-port"),
 		Usage:    "the http port which the pprof server is listening",
@@ -358,6 +473,443 @@ var (
 		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "ENABLE_PPROF"),
 	}
 
+	DbBackendFlag = cli.StringFlag{
+		Name: common.PrefixFlag(FlagPrefix, "db-backend"),
+		Usage: fmt.Sprintf("Storage engine of the chunk store (%s (default) or %s). %s is tuned for high-IOPS NVMe drives. "+
+			"Each backend keeps its data in its own directory under the db path, so switching backends starts with an empty store, unless the stores were migrated with db-migration-target",
+			DbBackendLevelDB, DbBackendPebble, DbBackendPebble),
+		Required: false,
+		Value:    DbBackendLevelDB,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "DB_BACKEND"),
+	}
+	DbMigrationTargetFlag = cli.StringFlag{
+		Name: common.PrefixFlag(FlagPrefix, "db-migration-target"),
+		Usage: fmt.Sprintf("Storage engine (%s or %s) to migrate the chunk stores to while the node is running. Once the "+
+			"migration is verified, restart the node with it as the db backend, and without this flag, to cut over", DbBackendLevelDB, DbBackendPebble),
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "DB_MIGRATION_TARGET"),
+	}
+
+	ExpirationCompactionThresholdFlag = cli.Uint64Flag{
+		Name: common.PrefixFlag(FlagPrefix, "expiration-compaction-threshold"),
+		Usage: "Number of expired batches to delete from the chunk store before compacting it, which reclaims the disk space " +
+			"of the deleted chunks in one pass. 0 disables the compactions, leaving it to the storage engine",
+		Required: false,
+		Value:    100,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "EXPIRATION_COMPACTION_THRESHOLD"),
+	}
+
+	DiskQuotaQuorumsFlag = cli.IntSliceFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "disk-quota-quorums"),
+		Usage:    "The quorum IDs with a disk quota for their chunks. Each quorum must have a corresponding entry in the disk quota flag. Quorums without a quota are unlimited",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "DISK_QUOTA_QUORUMS"),
+	}
+	DiskQuotaGBFlag = cli.IntSliceFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "disk-quota-gb"),
+		Usage:    "The disk quota in GiB of the chunks of each quorum in the disk quota quorums flag",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "DISK_QUOTA_GB"),
+	}
+	DiskQuotaHighWatermarkFlag = cli.Float64Flag{
+		Name:     common.PrefixFlag(FlagPrefix, "disk-quota-high-watermark"),
+		Usage:    "The fraction of its disk quota at which a quorum stops accepting new chunks",
+		Required: false,
+		Value:    0.95,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "DISK_QUOTA_HIGH_WATERMARK"),
+	}
+	MaxBlobSizeQuorumsFlag = cli.IntSliceFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "max-blob-size-quorums"),
+		Usage:    "The quorum IDs with a maximum blob size. Each quorum must have a corresponding entry in the max blob size flag. Quorums without a maximum accept blobs of any size",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "MAX_BLOB_SIZE_QUORUMS"),
+	}
+	MaxBlobSizeKiBFlag = cli.IntSliceFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "max-blob-size-kib"),
+		Usage:    "The maximum size in KiB of the blobs dispersed to each quorum in the max blob size quorums flag. Larger blobs are refused before their chunks are downloaded or decoded",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "MAX_BLOB_SIZE_KIB"),
+	}
+	ChunkAuditIntervalFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "chunk-audit-interval"),
+		Usage:    "The interval at which the chunks of randomly sampled stored blobs are re-verified against their commitments. 0 disables the background audits",
+		Required: false,
+		Value:    0,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "CHUNK_AUDIT_INTERVAL"),
+	}
+	ChunkAuditBlobsFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "chunk-audit-blobs"),
+		Usage:    "The number of stored blobs whose chunks are re-verified per audit, which is also the maximum for on-demand audits",
+		Required: false,
+		Value:    16,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "CHUNK_AUDIT_BLOBS"),
+	}
+	ChunkScrubIntervalFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "chunk-scrub-interval"),
+		Usage:    "The interval at which the chunk scrubber re-verifies the next stored blobs, walking the whole store over time and repairing corrupt chunks from the relays. 0 disables the scrubber",
+		Required: false,
+		Value:    0,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "CHUNK_SCRUB_INTERVAL"),
+	}
+	ChunkScrubBlobsFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "chunk-scrub-blobs"),
+		Usage:    "The number of stored blobs whose chunks are re-verified per scrub interval",
+		Required: false,
+		Value:    4,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "CHUNK_SCRUB_BLOBS"),
+	}
+	MaxEgressBytesPerSecondFlag = cli.Float64Flag{
+		Name:     common.PrefixFlag(FlagPrefix, "max-egress-bytes-per-second"),
+		Usage:    "Max bandwidth in bytes per second used across all peers when serving chunks to retrievers. 0 means unlimited",
+		Required: false,
+		Value:    0,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "MAX_EGRESS_BYTES_PER_SECOND"),
+	}
+	EgressBytesBurstinessFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "egress-bytes-burstiness"),
+		Usage:    "Burstiness in bytes of the egress bandwidth limit across all peers. Larger requests are always refused. 0 means one second worth of the limit",
+		Required: false,
+		Value:    0,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "EGRESS_BYTES_BURSTINESS"),
+	}
+	MaxEgressBytesPerSecondPeerFlag = cli.Float64Flag{
+		Name:     common.PrefixFlag(FlagPrefix, "max-egress-bytes-per-second-peer"),
+		Usage:    "Max bandwidth in bytes per second used per peer when serving chunks to retrievers. 0 means unlimited",
+		Required: false,
+		Value:    0,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "MAX_EGRESS_BYTES_PER_SECOND_PEER"),
+	}
+	EgressBytesBurstinessPeerFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "egress-bytes-burstiness-peer"),
+		Usage:    "Burstiness in bytes of the egress bandwidth limit per peer. Larger requests are always refused. 0 means one second worth of the limit",
+		Required: false,
+		Value:    0,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "EGRESS_BYTES_BURSTINESS_PEER"),
+	}
+	MaxIngressBytesPerSecondFlag = cli.Float64Flag{
+		Name:     common.PrefixFlag(FlagPrefix, "max-ingress-bytes-per-second"),
+		Usage:    "Max bandwidth in bytes per second used across all peers when receiving chunks for StoreChunks requests. 0 means unlimited",
+		Required: false,
+		Value:    0,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "MAX_INGRESS_BYTES_PER_SECOND"),
+	}
+	IngressBytesBurstinessFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "ingress-bytes-burstiness"),
+		Usage:    "Burstiness in bytes of the ingress bandwidth limit across all peers. Larger requests are always refused. 0 means one second worth of the limit",
+		Required: false,
+		Value:    0,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "INGRESS_BYTES_BURSTINESS"),
+	}
+	MaxIngressBytesPerSecondPeerFlag = cli.Float64Flag{
+		Name:     common.PrefixFlag(FlagPrefix, "max-ingress-bytes-per-second-peer"),
+		Usage:    "Max bandwidth in bytes per second used per peer when receiving chunks for StoreChunks requests. 0 means unlimited",
+		Required: false,
+		Value:    0,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "MAX_INGRESS_BYTES_PER_SECOND_PEER"),
+	}
+	IngressBytesBurstinessPeerFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "ingress-bytes-burstiness-peer"),
+		Usage:    "Burstiness in bytes of the ingress bandwidth limit per peer. Larger requests are always refused. 0 means one second worth of the limit",
+		Required: false,
+		Value:    0,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "INGRESS_BYTES_BURSTINESS_PEER"),
+	}
+	MaxRetrievalRequestsPerSecondPeerFlag = cli.Float64Flag{
+		Name:     common.PrefixFlag(FlagPrefix, "max-retrieval-requests-per-second-peer"),
+		Usage:    "Max rate of chunk retrieval requests per peer. 0 means unlimited",
+		Required: false,
+		Value:    0,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "MAX_RETRIEVAL_REQUESTS_PER_SECOND_PEER"),
+	}
+	RetrievalRequestsBurstinessPeerFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "retrieval-requests-burstiness-peer"),
+		Usage:    "Burstiness of the retrieval request rate limit per peer. 0 means one second worth of the limit",
+		Required: false,
+		Value:    0,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "RETRIEVAL_REQUESTS_BURSTINESS_PEER"),
+	}
+	MaxConcurrentRetrievalsFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "max-concurrent-retrievals"),
+		Usage:    "Max number of chunk retrieval requests served at once. Requests beyond it wait and are served in turn across peers. 0 means unlimited",
+		Required: false,
+		Value:    0,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "MAX_CONCURRENT_RETRIEVALS"),
+	}
+	MaxQueuedRetrievalsPeerFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "max-queued-retrievals-peer"),
+		Usage:    "Max number of chunk retrieval requests of a peer waiting to be served when max-concurrent-retrievals is reached. Requests beyond it are refused",
+		Required: false,
+		Value:    16,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "MAX_QUEUED_RETRIEVALS_PEER"),
+	}
+	ChunkEncryptionKeyFileFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "chunk-encryption-key-file"),
+		Usage:    "Path to a file holding a hex encoded 32 byte master key used to encrypt the stored chunks at rest. Exclusive with the chunk encryption KMS flags",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "CHUNK_ENCRYPTION_KEY_FILE"),
+	}
+	ChunkEncryptionKMSKeyIDFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "chunk-encryption-kms-key-id"),
+		Usage:    "ID of the AWS KMS key used to encrypt the stored chunks at rest",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "CHUNK_ENCRYPTION_KMS_KEY_ID"),
+	}
+	ChunkEncryptionKMSRegionFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "chunk-encryption-kms-region"),
+		Usage:    "AWS region of the chunk encryption KMS key. If set without a key ID, new chunks are stored in plaintext but encrypted chunks can still be read",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "CHUNK_ENCRYPTION_KMS_REGION"),
+	}
+	ChunkEncryptionKMSEndpointFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "chunk-encryption-kms-endpoint"),
+		Usage:    "Overrides the AWS KMS endpoint of the chunk encryption. Leave empty to use the default endpoint",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "CHUNK_ENCRYPTION_KMS_ENDPOINT"),
+	}
+	ChunkEncryptionDataKeyLifetimeFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "chunk-encryption-data-key-lifetime"),
+		Usage:    "The time a data key is used to encrypt chunks before a new one is generated",
+		Required: false,
+		Value:    time.Hour,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "CHUNK_ENCRYPTION_DATA_KEY_LIFETIME"),
+	}
+	EnableDashboardApiFlag = cli.BoolFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "enable-dashboard-api"),
+		Usage:    "Serve a read-only JSON report of storage utilization, attestations, validation latency and chain sync health for operator dashboards",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "ENABLE_DASHBOARD_API"),
+	}
+	DashboardApiPortFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "dashboard-api-port"),
+		Usage:    "Port at which node serves the dashboard api",
+		Required: false,
+		Value:    "9095",
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "DASHBOARD_API_PORT"),
+	}
+	PruningIntervalFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "pruning-interval"),
+		Usage:    "The interval at which the pruning policies are evaluated over the stored chunks, which deletes chunks before they expire. 0 disables pruning",
+		Required: false,
+		Value:    0,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "PRUNING_INTERVAL"),
+	}
+	PruningRetentionQuorumsFlag = cli.IntSliceFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "pruning-retention-quorums"),
+		Usage:    "The quorum IDs whose chunks are only kept for a number of days. Each quorum must have a corresponding entry in the pruning retention days flag",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "PRUNING_RETENTION_QUORUMS"),
+	}
+	PruningRetentionDaysFlag = cli.IntSliceFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "pruning-retention-days"),
+		Usage:    "The number of days for which the chunks of each quorum in the pruning retention quorums flag are kept",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "PRUNING_RETENTION_DAYS"),
+	}
+	PruningMinStakeFractionFlag = cli.Float64Flag{
+		Name:     common.PrefixFlag(FlagPrefix, "pruning-min-stake-fraction"),
+		Usage:    "The fraction of the chunks of a blob in a quorum, which follows the node's stake in the quorum, below which the node's chunks of the blob are pruned. 0 disables this policy",
+		Required: false,
+		Value:    0,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "PRUNING_MIN_STAKE_FRACTION"),
+	}
+	PruningMaxDiskUsageFlag = cli.Float64Flag{
+		Name:     common.PrefixFlag(FlagPrefix, "pruning-max-disk-usage"),
+		Usage:    "The fraction of the disk holding the chunk stores above which chunks older than the pruning disk pressure retention are pruned. 0 disables this policy",
+		Required: false,
+		Value:    0,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "PRUNING_MAX_DISK_USAGE"),
+	}
+	PruningDiskPressureRetentionFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "pruning-disk-pressure-retention"),
+		Usage:    "How long chunks are kept while the disk usage is above the pruning max disk usage",
+		Required: false,
+		Value:    time.Hour,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "PRUNING_DISK_PRESSURE_RETENTION"),
+	}
+	TLSCertFileFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "tls-cert-file"),
+		Usage:    "Path of the PEM encoded certificate served by the dispersal and retrieval gRPC servers. TLS is disabled if empty",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "TLS_CERT_FILE"),
+	}
+	TLSKeyFileFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "tls-key-file"),
+		Usage:    "Path of the PEM encoded private key of the tls certificate",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "TLS_KEY_FILE"),
+	}
+	TLSDispersalClientCAFileFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "tls-dispersal-client-ca-file"),
+		Usage:    "Path of the PEM encoded CA certificates allowed to sign client certificates. If set, the dispersal gRPC servers require clients to present such a certificate",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "TLS_DISPERSAL_CLIENT_CA_FILE"),
+	}
+	TLSRetrievalClientCAFileFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "tls-retrieval-client-ca-file"),
+		Usage:    "Path of the PEM encoded CA certificates allowed to sign client certificates. If set, the retrieval gRPC servers require clients to present such a certificate",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "TLS_RETRIEVAL_CLIENT_CA_FILE"),
+	}
+	TLSReloadIntervalFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "tls-reload-interval"),
+		Usage:    "The minimum interval at which the tls certificate, key and client CA files are checked for changes, so that they can be rotated without a restart",
+		Required: false,
+		Value:    time.Minute,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "TLS_RELOAD_INTERVAL"),
+	}
+	DisperserAllowlistFileFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "disperser-allowlist-file"),
+		Usage:    "Path of a file listing the addresses of the only dispersers StoreChunks requests are accepted from, one per line. All dispersers are allowed if empty",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "DISPERSER_ALLOWLIST_FILE"),
+	}
+	DisperserDenylistFileFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "disperser-denylist-file"),
+		Usage:    "Path of a file listing the addresses of dispersers whose StoreChunks requests are refused, one per line",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "DISPERSER_DENYLIST_FILE"),
+	}
+	DisperserFilterReloadIntervalFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "disperser-filter-reload-interval"),
+		Usage:    "The minimum interval at which the disperser allowlist and denylist files are checked for changes",
+		Required: false,
+		Value:    10 * time.Second,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "DISPERSER_FILTER_RELOAD_INTERVAL"),
+	}
+	StorageAlertCheckIntervalFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "storage-alert-check-interval"),
+		Usage:    "Interval at which the usage of the disk holding the stores is checked against the storage alert thresholds. 0 disables the alerts",
+		Required: false,
+		Value:    time.Minute,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "STORAGE_ALERT_CHECK_INTERVAL"),
+	}
+	StorageAlertWarningUsageFlag = cli.Float64Flag{
+		Name:     common.PrefixFlag(FlagPrefix, "storage-alert-warning-usage"),
+		Usage:    "Fraction of the disk holding the stores above which a storage warning is raised. 0 disables it",
+		Required: false,
+		Value:    0.8,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "STORAGE_ALERT_WARNING_USAGE"),
+	}
+	StorageAlertCriticalUsageFlag = cli.Float64Flag{
+		Name:     common.PrefixFlag(FlagPrefix, "storage-alert-critical-usage"),
+		Usage:    "Fraction of the disk holding the stores above which a critical storage alert is raised. 0 disables it",
+		Required: false,
+		Value:    0.95,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "STORAGE_ALERT_CRITICAL_USAGE"),
+	}
+	StorageAlertWarningDaysUntilFullFlag = cli.Float64Flag{
+		Name:     common.PrefixFlag(FlagPrefix, "storage-alert-warning-days-until-full"),
+		Usage:    "Projected number of days until the disk holding the stores is full, at the recent ingest rate, below which a storage warning is raised. 0 disables it",
+		Required: false,
+		Value:    3,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "STORAGE_ALERT_WARNING_DAYS_UNTIL_FULL"),
+	}
+	StorageAlertIngestRateWindowFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "storage-alert-ingest-rate-window"),
+		Usage:    "Period over which the ingest rate projecting when the disk is full is measured",
+		Required: false,
+		Value:    6 * time.Hour,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "STORAGE_ALERT_INGEST_RATE_WINDOW"),
+	}
+	StorageAlertWebhookURLFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "storage-alert-webhook-url"),
+		Usage:    "URL to which storage alerts are posted as JSON whenever their level changes",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "STORAGE_ALERT_WEBHOOK_URL"),
+	}
+	SettingsFileFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "settings-file"),
+		Usage:    "Path of a JSON file of tunable settings (rate limits, disk quotas, log level, disperser allowlist and denylist) which are reloaded on SIGHUP or through the admin api. Settings missing from the file keep the values set by the flags",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "SETTINGS_FILE"),
+	}
+	EnableAdminApiFlag = cli.BoolFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "enable-admin-api"),
+		Usage:    "Serve the admin api, which reloads the settings file, on localhost",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "ENABLE_ADMIN_API"),
+	}
+	AdminApiPortFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "admin-api-port"),
+		Usage:    "Port at which node serves the admin api on localhost",
+		Required: false,
+		Value:    "9096",
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "ADMIN_API_PORT"),
+	}
+	SigningJournalRetentionFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "signing-journal-retention"),
+		Usage:    "How long the batches the node attests to are kept in the signing journal, which refuses to attest to batches conflicting with them. 0 disables the signing journal",
+		Required: false,
+		Value:    14 * 24 * time.Hour,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "SIGNING_JOURNAL_RETENTION"),
+	}
+	ChunkTieringBucketFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "chunk-tiering-bucket"),
+		Usage:    "The S3 bucket to which stored chunks older than the chunk tiering migration age are migrated. The bucket should expire objects after the storage period of the chunks. Chunk tiering is disabled if empty",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "CHUNK_TIERING_BUCKET"),
+	}
+	ChunkTieringRegionFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "chunk-tiering-region"),
+		Usage:    "The region of the chunk tiering bucket",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "CHUNK_TIERING_REGION"),
+	}
+	ChunkTieringEndpointFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "chunk-tiering-endpoint"),
+		Usage:    "Override of the object store endpoint of the chunk tiering bucket, e.g. for an S3 compatible store. Leave empty to use S3",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "CHUNK_TIERING_ENDPOINT"),
+	}
+	ChunkTieringMigrationAgeFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "chunk-tiering-migration-age"),
+		Usage:    "The time after which stored chunks are migrated to the chunk tiering bucket",
+		Required: false,
+		Value:    24 * time.Hour,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "CHUNK_TIERING_MIGRATION_AGE"),
+	}
+	ChunkTieringIntervalFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "chunk-tiering-interval"),
+		Usage:    "The interval at which stored chunks older than the chunk tiering migration age are migrated",
+		Required: false,
+		Value:    10 * time.Minute,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "CHUNK_TIERING_INTERVAL"),
+	}
+	RetrievalCacheSizeFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "retrieval-cache-size"),
+		Usage:    "The maximum size in bytes of the chunks cached for retrieval clients. On a miss, the chunks of all the quorums of the blob are read ahead. 0 disables the cache",
+		Required: false,
+		Value:    256 * 1024 * 1024,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "RETRIEVAL_CACHE_SIZE"),
+	}
+	OperatorStateCacheSizeFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "operator-state-cache-size"),
+		Usage:    "The number of operator states, by reference block, cached for the validation of batches. 0 disables the cache",
+		Required: false,
+		Value:    32,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "OPERATOR_STATE_CACHE_SIZE"),
+	}
+	OperatorStateMaxDerivationBlocksFlag = cli.UintFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "operator-state-max-derivation-blocks"),
+		Usage:    "The maximum number of blocks between a cached operator state and a new reference block for the state at the reference block to be derived from the stake updates in between, rather than fetched. 0 always fetches the state",
+		Required: false,
+		Value:    300,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "OPERATOR_STATE_MAX_DERIVATION_BLOCKS"),
+	}
+	BatchHeaderCacheSizeFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "batch-header-cache-size"),
+		Usage:    "The number of batches whose merkle tree, verified against the batch root, is cached for serving blob header proofs and validating attestations. 0 disables the cache",
+		Required: false,
+		Value:    64,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "BATCH_HEADER_CACHE_SIZE"),
+	}
+	DeduplicateChunksFlag = cli.BoolFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "deduplicate-chunks"),
+		Usage:    "Store the chunks assigned to the operator in several quorums of a blob once, rather than once per quorum. Chunks stored either way are read back regardless",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "DEDUPLICATE_CHUNKS"),
+	}
+
 	RuntimeModeFlag = cli.StringFlag{
 		Name:     common.PrefixFlag(FlagPrefix, "runtime-mode"),
 		Usage:    fmt.Sprintf("Node runtime mode (%s (default), %s, or %s)", ModeV1AndV2, ModeV1Only, ModeV2Only),
@@ -447,6 +999,10 @@ var requiredFlags = []cli.Flag{
 }
 
 var optionalFlags = []cli.Flag{
+	PubIPConfirmChecksFlag,
+	SocketUpdateRetryIntervalFlag,
+	SocketUpdateMaxRetryIntervalFlag,
+	SocketUpdateMaxGasPriceGweiFlag,
 	RegisterAtNodeStartFlag,
 	ExpirationPollIntervalSecFlag,
 	ReachabilityPollIntervalSecFlag,
@@ -470,6 +1026,17 @@ var optionalFlags = []cli.Flag{
 	BLSPublicKeyHexFlag,
 	BLSSignerCertFileFlag,
 	BLSSignerAPIKeyFlag,
+	BLSRemoteSignerFallbackUrlsFlag,
+	BLSSignerTimeoutFlag,
+	BLSSigningBatchWindowFlag,
+	BLSSigningMaxBatchSizeFlag,
+	BLSKMSEncryptedKeyFileFlag,
+	BLSKMSRegionFlag,
+	BLSKMSEndpointFlag,
+	NextEcdsaKeyFileFlag,
+	NextEcdsaKeyPasswordFlag,
+	NextBlsKeyFileFlag,
+	NextBlsKeyPasswordFlag,
 	V2DispersalPortFlag,
 	V2RetrievalPortFlag,
 	OnchainStateRefreshIntervalFlag,
@@ -483,6 +1050,72 @@ var optionalFlags = []cli.Flag{
 	DispersalAuthenticationTimeoutFlag,
 	RelayMaxGRPCMessageSizeFlag,
 	RuntimeModeFlag,
+	DbBackendFlag,
+	DbMigrationTargetFlag,
+	ExpirationCompactionThresholdFlag,
+	DiskQuotaQuorumsFlag,
+	DiskQuotaGBFlag,
+	DiskQuotaHighWatermarkFlag,
+	MaxBlobSizeQuorumsFlag,
+	MaxBlobSizeKiBFlag,
+	ValidationSubBatchSizeFlag,
+	ChunkAuditIntervalFlag,
+	ChunkAuditBlobsFlag,
+	ChunkScrubIntervalFlag,
+	ChunkScrubBlobsFlag,
+	MaxEgressBytesPerSecondFlag,
+	EgressBytesBurstinessFlag,
+	MaxEgressBytesPerSecondPeerFlag,
+	EgressBytesBurstinessPeerFlag,
+	MaxIngressBytesPerSecondFlag,
+	IngressBytesBurstinessFlag,
+	MaxIngressBytesPerSecondPeerFlag,
+	IngressBytesBurstinessPeerFlag,
+	MaxRetrievalRequestsPerSecondPeerFlag,
+	RetrievalRequestsBurstinessPeerFlag,
+	MaxConcurrentRetrievalsFlag,
+	MaxQueuedRetrievalsPeerFlag,
+	ChunkEncryptionKeyFileFlag,
+	ChunkEncryptionKMSKeyIDFlag,
+	ChunkEncryptionKMSRegionFlag,
+	ChunkEncryptionKMSEndpointFlag,
+	ChunkEncryptionDataKeyLifetimeFlag,
+	EnableDashboardApiFlag,
+	DashboardApiPortFlag,
+	PruningIntervalFlag,
+	PruningRetentionQuorumsFlag,
+	PruningRetentionDaysFlag,
+	PruningMinStakeFractionFlag,
+	PruningMaxDiskUsageFlag,
+	PruningDiskPressureRetentionFlag,
+	TLSCertFileFlag,
+	TLSKeyFileFlag,
+	TLSDispersalClientCAFileFlag,
+	TLSRetrievalClientCAFileFlag,
+	TLSReloadIntervalFlag,
+	DisperserAllowlistFileFlag,
+	DisperserDenylistFileFlag,
+	DisperserFilterReloadIntervalFlag,
+	StorageAlertCheckIntervalFlag,
+	StorageAlertWarningUsageFlag,
+	StorageAlertCriticalUsageFlag,
+	StorageAlertWarningDaysUntilFullFlag,
+	StorageAlertIngestRateWindowFlag,
+	StorageAlertWebhookURLFlag,
+	SettingsFileFlag,
+	EnableAdminApiFlag,
+	AdminApiPortFlag,
+	SigningJournalRetentionFlag,
+	ChunkTieringBucketFlag,
+	ChunkTieringRegionFlag,
+	ChunkTieringEndpointFlag,
+	ChunkTieringMigrationAgeFlag,
+	ChunkTieringIntervalFlag,
+	RetrievalCacheSizeFlag,
+	OperatorStateCacheSizeFlag,
+	OperatorStateMaxDerivationBlocksFlag,
+	BatchHeaderCacheSizeFlag,
+	DeduplicateChunksFlag,
 }
 
 func init() {
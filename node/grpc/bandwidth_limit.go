@@ -0,0 +1,74 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api"
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/node"
+)
+
+// requestBandwidth reserves the bandwidth to transfer the given number of bytes with the requester. It returns a
+// ResourceExhausted error if the transfer exceeds a bandwidth limit.
+func requestBandwidth(ctx context.Context, limiter *node.BandwidthLimiter, clientIPHeader string, bytes int) error {
+	if limiter == nil {
+		return nil
+	}
+
+	requester, err := common.GetClientAddress(ctx, clientIPHeader, 1, true)
+	if err != nil {
+		return api.NewErrorInvalidArg(fmt.Sprintf("failed to get the address of the requester: %v", err))
+	}
+
+	err = limiter.RequestBandwidth(time.Now(), requester, bytes)
+	if err == nil {
+		return nil
+	}
+	var limitErr *node.BandwidthLimitError
+	if errors.As(err, &limitErr) && limitErr.RetryAfter > 0 {
+		return api.NewErrorResourceExhaustedWithRetryAfter(limitErr.Error(), limitErr.RetryAfter)
+	}
+	return api.NewErrorResourceExhausted(err.Error())
+}
+
+// acquireRetrieval admits a chunk retrieval request of the requester, waiting for its turn if the node is serving as
+// many retrievals as it can. The returned function must be called once the request is served. It returns a
+// ResourceExhausted error if the request exceeds the requester's retrieval limits.
+func acquireRetrieval(ctx context.Context, limiter *node.RetrievalLimiter, clientIPHeader string) (func(), error) {
+	if limiter == nil {
+		return func() {}, nil
+	}
+
+	requester, err := common.GetClientAddress(ctx, clientIPHeader, 1, true)
+	if err != nil {
+		return nil, api.NewErrorInvalidArg(fmt.Sprintf("failed to get the address of the requester: %v", err))
+	}
+
+	release, err := limiter.Acquire(ctx, time.Now(), requester)
+	if err == nil {
+		return release, nil
+	}
+	if errors.Is(err, context.Canceled) {
+		return nil, api.NewErrorCanceled(err.Error())
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return nil, api.NewErrorDeadlineExceeded(err.Error())
+	}
+	var limitErr *node.RetrievalLimitError
+	if errors.As(err, &limitErr) && limitErr.RetryAfter > 0 {
+		return nil, api.NewErrorResourceExhaustedWithRetryAfter(limitErr.Error(), limitErr.RetryAfter)
+	}
+	return nil, api.NewErrorResourceExhausted(err.Error())
+}
+
+// chunksSize returns the total size of the chunks, in bytes.
+func chunksSize(chunks [][]byte) int {
+	size := 0
+	for _, chunk := range chunks {
+		size += len(chunk)
+	}
+	return size
+}
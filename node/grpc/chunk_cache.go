@@ -0,0 +1,111 @@
+package grpc
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/Layr-Labs/eigenda/core"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/node"
+	lru "github.com/hashicorp/golang-lru/v2"
+	"golang.org/x/sync/singleflight"
+)
+
+// bundleID identifies the bundle of a blob in a quorum.
+type bundleID struct {
+	blobKey corev2.BlobKey
+	quorum  core.QuorumID
+}
+
+// chunkCache serves the chunks of the v2 store to retrieval clients. Clients fetching the chunks of a blob in one
+// quorum usually fetch its other quorums next, so on a miss all the bundles of the blob are read ahead in a single
+// sequential read, and kept in an LRU cache bounded by the total size of the cached chunks.
+type chunkCache struct {
+	store   node.StoreV2
+	metrics *MetricsV2
+
+	// mu guards cache and size
+	mu    sync.Mutex
+	cache *lru.Cache[bundleID, [][]byte]
+	// size is the total size of the cached chunks, in bytes
+	size    int
+	maxSize int
+
+	// readAhead deduplicates the concurrent read-aheads of a blob
+	readAhead singleflight.Group
+}
+
+// newChunkCache creates a chunkCache holding up to maxSize bytes of chunks.
+func newChunkCache(store node.StoreV2, maxSize int, metrics *MetricsV2) (*chunkCache, error) {
+	c := &chunkCache{
+		store:   store,
+		metrics: metrics,
+		maxSize: maxSize,
+	}
+	// The cache is bounded by size rather than by number of entries, which is enforced in add
+	cache, err := lru.NewWithEvict[bundleID, [][]byte](maxSize, func(_ bundleID, chunks [][]byte) {
+		c.size -= chunksSize(chunks)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create chunk cache: %w", err)
+	}
+	c.cache = cache
+	return c, nil
+}
+
+// GetChunks returns the chunks of a blob in a quorum, from the cache if possible.
+func (c *chunkCache) GetChunks(blobKey corev2.BlobKey, quorum core.QuorumID) ([][]byte, error) {
+	id := bundleID{blobKey: blobKey, quorum: quorum}
+	c.mu.Lock()
+	chunks, ok := c.cache.Get(id)
+	c.mu.Unlock()
+	if ok {
+		c.metrics.ReportGetChunksCacheRequest("hit")
+		return chunks, nil
+	}
+	c.metrics.ReportGetChunksCacheRequest("miss")
+
+	result, err, _ := c.readAhead.Do(string(blobKey[:]), func() (interface{}, error) {
+		blobChunks, err := c.store.GetBlobChunks(blobKey)
+		if err != nil {
+			return nil, err
+		}
+		for q, chunks := range blobChunks {
+			c.add(bundleID{blobKey: blobKey, quorum: q}, chunks)
+		}
+		return blobChunks, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	chunks, ok = result.(map[core.QuorumID][][]byte)[quorum]
+	if ok {
+		return chunks, nil
+	}
+	// The bundle isn't stored locally, e.g. it was migrated to the cold tier, or doesn't exist
+	chunks, err = c.store.GetChunks(blobKey, quorum)
+	if err != nil {
+		return nil, err
+	}
+	c.add(id, chunks)
+	return chunks, nil
+}
+
+// add caches the chunks of a bundle, evicting the least recently used bundles to stay within the maximum size.
+// Bundles larger than the cache aren't cached.
+func (c *chunkCache) add(id bundleID, chunks [][]byte) {
+	size := chunksSize(chunks)
+	if size > c.maxSize {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.cache.Contains(id) {
+		return
+	}
+	c.cache.Add(id, chunks)
+	c.size += size
+	for c.size > c.maxSize {
+		c.cache.RemoveOldest()
+	}
+}
@@ -1,9 +1,11 @@
 package grpc
 
 import (
+	"strconv"
 	"time"
 
 	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/core"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	grpcprom "github.com/grpc-ecosystem/go-grpc-middleware/providers/prometheus"
 	"github.com/prometheus/client_golang/prometheus"
@@ -22,9 +24,15 @@ type MetricsV2 struct {
 
 	storeChunksLatency     *prometheus.SummaryVec
 	storeChunksRequestSize *prometheus.GaugeVec
+	// storeChunksQuorumLatency breaks down the latency of StoreChunks() by the quorums of the batch, so that the
+	// quorums whose parameters slow down signing can be told apart
+	storeChunksQuorumLatency *prometheus.HistogramVec
+	storeChunksRejections    *prometheus.CounterVec
 
 	getChunksLatency  *prometheus.SummaryVec
 	getChunksDataSize *prometheus.GaugeVec
+	// getChunksCacheRequests counts the GetChunks() calls served from the chunk cache, by result
+	getChunksCacheRequests *prometheus.CounterVec
 }
 
 // NewV2Metrics creates a new MetricsV2 instance. dbSizePollPeriod is the period at which the database size is polled.
@@ -60,6 +68,25 @@ func NewV2Metrics(logger logging.Logger, registry *prometheus.Registry) (*Metric
 		[]string{},
 	)
 
+	storeChunksQuorumLatency := promauto.With(registry).NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "store_chunks_quorum_latency_ms",
+			Help:      "The latency of the stages of StoreChunks() RPC calls, by the quorums of the batch.",
+			Buckets:   prometheus.ExponentialBuckets(10, 2, 12),
+		},
+		[]string{"quorum", "stage"},
+	)
+
+	storeChunksRejections := promauto.With(registry).NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "store_chunks_rejections_total",
+			Help:      "The number of StoreChunks() RPC calls which the node failed to sign, by reason.",
+		},
+		[]string{"reason"},
+	)
+
 	getChunksLatency := promauto.With(registry).NewSummaryVec(
 		prometheus.SummaryOpts{
 			Namespace:  namespace,
@@ -79,14 +106,26 @@ func NewV2Metrics(logger logging.Logger, registry *prometheus.Registry) (*Metric
 		[]string{},
 	)
 
+	getChunksCacheRequests := promauto.With(registry).NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "get_chunks_cache_requests_total",
+			Help:      "The number of GetChunks() RPC calls looked up in the chunk cache, by hit or miss.",
+		},
+		[]string{"result"},
+	)
+
 	return &MetricsV2{
-		logger:                 logger,
-		registry:               registry,
-		grpcServerOption:       grpcServerOption,
-		storeChunksLatency:     storeChunksLatency,
-		storeChunksRequestSize: storeChunksRequestSize,
-		getChunksLatency:       getChunksLatency,
-		getChunksDataSize:      getChunksDataSize,
+		logger:                   logger,
+		registry:                 registry,
+		grpcServerOption:         grpcServerOption,
+		storeChunksLatency:       storeChunksLatency,
+		storeChunksRequestSize:   storeChunksRequestSize,
+		storeChunksQuorumLatency: storeChunksQuorumLatency,
+		storeChunksRejections:    storeChunksRejections,
+		getChunksLatency:         getChunksLatency,
+		getChunksDataSize:        getChunksDataSize,
+		getChunksCacheRequests:   getChunksCacheRequests,
 	}, nil
 }
 
@@ -103,6 +142,20 @@ func (m *MetricsV2) ReportStoreChunksRequestSize(size uint64) {
 	m.storeChunksRequestSize.WithLabelValues().Set(float64(size))
 }
 
+// ReportStoreChunksQuorumLatency reports the latency of a stage of a StoreChunks() call for each quorum of the batch.
+// The stages cover all blobs of the batch at once, so the latency of the whole batch is attributed to each of its
+// quorums.
+func (m *MetricsV2) ReportStoreChunksQuorumLatency(quorums []core.QuorumID, stage string, latency time.Duration) {
+	for _, quorum := range quorums {
+		m.storeChunksQuorumLatency.WithLabelValues(strconv.Itoa(int(quorum)), stage).Observe(common.ToMilliseconds(latency))
+	}
+}
+
+// ReportStoreChunksRejection reports a StoreChunks() call which the node failed to sign.
+func (m *MetricsV2) ReportStoreChunksRejection(reason string) {
+	m.storeChunksRejections.WithLabelValues(reason).Inc()
+}
+
 func (m *MetricsV2) ReportGetChunksLatency(latency time.Duration) {
 	m.getChunksLatency.WithLabelValues().Observe(common.ToMilliseconds(latency))
 }
@@ -110,3 +163,7 @@ func (m *MetricsV2) ReportGetChunksLatency(latency time.Duration) {
 func (m *MetricsV2) ReportGetChunksDataSize(size int) {
 	m.getChunksDataSize.WithLabelValues().Set(float64(size))
 }
+
+func (m *MetricsV2) ReportGetChunksCacheRequest(result string) {
+	m.getChunksCacheRequests.WithLabelValues(result).Inc()
+}
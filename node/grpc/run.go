@@ -1,6 +1,7 @@
 package grpc
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net"
@@ -11,6 +12,7 @@ import (
 	"github.com/Layr-Labs/eigenda/node"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/reflection"
 )
 
@@ -27,6 +29,35 @@ func RunServers(serverV1 *Server, serverV2 *ServerV2, config *node.Config, logge
 		return errors.New("node is not configured to run any servers")
 	}
 
+	var n *node.Node
+	if config.EnableV1 {
+		n = serverV1.node
+	} else {
+		n = serverV2.node
+	}
+	// The health servers of all services report the health of the node's dependencies
+	dependencyChecker := healthcheck.NewDependencyChecker(logger,
+		healthcheck.Dependency{
+			Name: "chain",
+			Check: func(ctx context.Context) error {
+				_, err := n.Transactor.GetCurrentBlockNumber(ctx)
+				return err
+			},
+		},
+	)
+	dependencyChecker.Start(context.Background())
+
+	// The servers serve TLS if configured, and the dispersal or retrieval servers may require client certificates
+	var dispersalOpts, retrievalOpts []grpc.ServerOption
+	if config.TLS.Enabled() {
+		tlsCredentials, err := node.NewTLSCredentials(config.TLS, logger)
+		if err != nil {
+			return fmt.Errorf("failed to load tls credentials: %w", err)
+		}
+		dispersalOpts = append(dispersalOpts, grpc.Creds(credentials.NewTLS(tlsCredentials.DispersalConfig())))
+		retrievalOpts = append(retrievalOpts, grpc.Creds(credentials.NewTLS(tlsCredentials.RetrievalConfig())))
+	}
+
 	// V1 dispersal service
 	go func() {
 		if !config.EnableV1 {
@@ -41,7 +72,7 @@ func RunServers(serverV1 *Server, serverV2 *ServerV2, config *node.Config, logge
 			}
 
 			opt := grpc.MaxRecvMsgSize(60 * 1024 * 1024 * 1024) // 60 GiB
-			gs := grpc.NewServer(opt)
+			gs := grpc.NewServer(append(dispersalOpts, opt)...)
 
 			// Register reflection service on gRPC server
 			// This makes "grpcurl -plaintext localhost:9000 list" command work
@@ -49,7 +80,7 @@ func RunServers(serverV1 *Server, serverV2 *ServerV2, config *node.Config, logge
 
 			pb.RegisterDispersalServer(gs, serverV1)
 
-			healthcheck.RegisterHealthServer("node.Dispersal", gs)
+			dependencyChecker.RegisterHealthServer("node.Dispersal", gs)
 
 			logger.Info("v1 dispersal enabled on port", config.InternalDispersalPort, "address", listener.Addr().String(), "GRPC Listening")
 			if err := gs.Serve(listener); err != nil {
@@ -72,7 +103,7 @@ func RunServers(serverV1 *Server, serverV2 *ServerV2, config *node.Config, logge
 			}
 
 			opt := grpc.MaxRecvMsgSize(config.GRPCMsgSizeLimitV2)
-			gs := grpc.NewServer(opt, serverV2.metrics.GetGRPCServerOption())
+			gs := grpc.NewServer(append(dispersalOpts, opt, serverV2.metrics.GetGRPCServerOption())...)
 
 			// Register reflection service on gRPC server
 			// This makes "grpcurl -plaintext localhost:9000 list" command work
@@ -80,7 +111,7 @@ func RunServers(serverV1 *Server, serverV2 *ServerV2, config *node.Config, logge
 
 			validator.RegisterDispersalServer(gs, serverV2)
 
-			healthcheck.RegisterHealthServer("node.v2.Dispersal", gs)
+			dependencyChecker.RegisterHealthServer("node.v2.Dispersal", gs)
 
 			logger.Info("v2 dispersal enabled on port", config.V2DispersalPort, "address", listener.Addr().String(), "GRPC Listening")
 			if err := gs.Serve(listener); err != nil {
@@ -103,14 +134,14 @@ func RunServers(serverV1 *Server, serverV2 *ServerV2, config *node.Config, logge
 			}
 
 			opt := grpc.MaxRecvMsgSize(1024 * 1024 * 300) // 300 MiB
-			gs := grpc.NewServer(opt)
+			gs := grpc.NewServer(append(retrievalOpts, opt)...)
 
 			// Register reflection service on gRPC server
 			// This makes "grpcurl -plaintext localhost:9000 list" command work
 			reflection.Register(gs)
 
 			pb.RegisterRetrievalServer(gs, serverV1)
-			healthcheck.RegisterHealthServer("node.Retrieval", gs)
+			dependencyChecker.RegisterHealthServer("node.Retrieval", gs)
 
 			logger.Info("v1 retrieval enabled on port", config.InternalRetrievalPort, "address", listener.Addr().String(), "GRPC Listening")
 			if err := gs.Serve(listener); err != nil {
@@ -132,7 +163,7 @@ func RunServers(serverV1 *Server, serverV2 *ServerV2, config *node.Config, logge
 				logger.Fatalf("Could not start tcp listener: %v", err)
 			}
 			opt := grpc.MaxRecvMsgSize(config.GRPCMsgSizeLimitV2)
-			gs := grpc.NewServer(opt, serverV2.metrics.GetGRPCServerOption())
+			gs := grpc.NewServer(append(retrievalOpts, opt, serverV2.metrics.GetGRPCServerOption())...)
 
 			// Register reflection service on gRPC server
 			// This makes "grpcurl -plaintext localhost:9000 list" command work
@@ -140,7 +171,7 @@ func RunServers(serverV1 *Server, serverV2 *ServerV2, config *node.Config, logge
 
 			validator.RegisterRetrievalServer(gs, serverV2)
 
-			healthcheck.RegisterHealthServer("node.v2.Retrieval", gs)
+			dependencyChecker.RegisterHealthServer("node.v2.Retrieval", gs)
 
 			logger.Info("v2 retrieval enabled on port", config.V2RetrievalPort, "address", listener.Addr().String(), "GRPC Listening")
 			if err := gs.Serve(listener); err != nil {
@@ -5,6 +5,7 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"io"
 	"reflect"
 	"runtime"
 	"sync"
@@ -23,9 +24,14 @@ import (
 
 	_ "go.uber.org/automaxprocs"
 
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
+// errExiting rejects the new batches sent to a node whose operator is exiting.
+var errExiting = api.NewErrorUnavailable("operator is exiting, not accepting new batches")
+
 // Server implements the Node proto APIs.
 type Server struct {
 	pb.UnimplementedDispersalServer
@@ -55,8 +61,9 @@ func NewServer(config *node.Config, node *node.Node, logger logging.Logger, rate
 }
 
 func (s *Server) NodeInfo(ctx context.Context, in *pb.NodeInfoRequest) (*pb.NodeInfoReply, error) {
+	apiVersions, features := nodeAPIVersions(s.config), nodeFeatures(s.config)
 	if s.config.DisableNodeInfoResources {
-		return &pb.NodeInfoReply{Semver: node.SemVer}, nil
+		return &pb.NodeInfoReply{Semver: node.SemVer, ApiVersions: apiVersions, Features: features}, nil
 	}
 
 	memBytes := uint64(0)
@@ -65,7 +72,59 @@ func (s *Server) NodeInfo(ctx context.Context, in *pb.NodeInfoRequest) (*pb.Node
 		memBytes = v.Total
 	}
 
-	return &pb.NodeInfoReply{Semver: node.SemVer, Os: runtime.GOOS, Arch: runtime.GOARCH, NumCpu: uint32(runtime.GOMAXPROCS(0)), MemBytes: memBytes}, nil
+	return &pb.NodeInfoReply{Semver: node.SemVer, Os: runtime.GOOS, Arch: runtime.GOARCH, NumCpu: uint32(runtime.GOMAXPROCS(0)), MemBytes: memBytes, ApiVersions: apiVersions, Features: features}, nil
+}
+
+// blobSizeLimitError converts an error returned when checking the sizes of the blobs of a request into a grpc error.
+// Blobs over a size limit are returned as a structured InvalidArgument error listing the fields of the oversized
+// blobs, given by field from the index of the blob in the request.
+func blobSizeLimitError(err error, field func(blobIndex int) string) error {
+	var sizeErr *node.BlobSizeLimitError
+	if !errors.As(err, &sizeErr) {
+		return api.NewErrorInternal(fmt.Sprintf("failed to check blob sizes: %v", err))
+	}
+	violations := make([]api.FieldViolation, len(sizeErr.Violations))
+	for i, violation := range sizeErr.Violations {
+		what := "blob"
+		if violation.Bundle {
+			what = "bundle of chunks"
+		}
+		violations[i] = api.FieldViolation{
+			Field: field(violation.BlobIndex),
+			Description: fmt.Sprintf("%s of %d bytes exceeds the size limit of %d bytes in quorum %d",
+				what, violation.Size, violation.Limit, violation.Quorum),
+		}
+	}
+	return api.NewErrorInvalidArgWithFieldViolations(sizeErr.Error(), violations)
+}
+
+// blobLengthField returns the path to the length of a blob of a v1 request.
+func blobLengthField(blobIndex int) string {
+	return fmt.Sprintf("blobs[%d].header.length", blobIndex)
+}
+
+// nodeAPIVersions returns the versions of the node APIs the node serves.
+func nodeAPIVersions(config *node.Config) []string {
+	versions := make([]string, 0, 2)
+	if config.EnableV1 {
+		versions = append(versions, api.NodeAPIVersionV1)
+	}
+	if config.EnableV2 {
+		versions = append(versions, api.NodeAPIVersionV2)
+	}
+	return versions
+}
+
+// nodeFeatures returns the optional features the node supports, which dispersers use to adapt to the node.
+func nodeFeatures(config *node.Config) []string {
+	features := make([]string, 0, 2)
+	if config.EnableV1 {
+		features = append(features, api.NodeFeatureStoreChunksStream)
+	}
+	if config.EnableV2 {
+		features = append(features, api.NodeFeatureBatchVerification)
+	}
+	return features
 }
 
 func (s *Server) handleStoreChunksRequest(ctx context.Context, in *pb.StoreChunksRequest) (*pb.StoreChunksReply, error) {
@@ -85,7 +144,8 @@ func (s *Server) handleStoreChunksRequest(ctx context.Context, in *pb.StoreChunk
 	s.node.Metrics.ObserveLatency("StoreChunks", "deserialization", float64(time.Since(start).Milliseconds()))
 	s.node.Logger.Info("StoreChunksRequest deserialized", "duration", time.Since(start))
 
-	sig, err := s.node.ProcessBatch(ctx, batchHeader, blobs, in.GetBlobs())
+	identity := s.node.IdentityFor(requestedOperatorID(ctx), uint64(batchHeader.ReferenceBlockNumber))
+	sig, err := s.node.ProcessBatch(ctx, identity, batchHeader, blobs, in.GetBlobs())
 	if err != nil {
 		return nil, err
 	}
@@ -95,21 +155,46 @@ func (s *Server) handleStoreChunksRequest(ctx context.Context, in *pb.StoreChunk
 	return &pb.StoreChunksReply{Signature: sigData[:]}, nil
 }
 
+// requestedOperatorID returns the operator a dispersal request is for, which the disperser says in the
+// api.OperatorIDHeader, or the zero ID if it doesn't say.
+func requestedOperatorID(ctx context.Context) core.OperatorID {
+	values := metadata.ValueFromIncomingContext(ctx, api.OperatorIDHeader)
+	if len(values) == 0 {
+		return core.OperatorID{}
+	}
+	operatorID, err := core.OperatorIDFromHex(values[0])
+	if err != nil {
+		return core.OperatorID{}
+	}
+	return operatorID
+}
+
 func (s *Server) validateStoreChunkRequest(in *pb.StoreChunksRequest) error {
-	if in.GetBatchHeader() == nil {
+	if err := validateBatchHeader(in.GetBatchHeader()); err != nil {
+		return err
+	}
+
+	return validateBlobs(in.GetBlobs())
+}
+
+func validateBatchHeader(header *pb.BatchHeader) error {
+	if header == nil {
 		return api.NewErrorInvalidArg("missing batch_header in request")
 	}
-	if in.GetBatchHeader().GetBatchRoot() == nil {
+	if header.GetBatchRoot() == nil {
 		return api.NewErrorInvalidArg("missing batch_root in request")
 	}
-	if in.GetBatchHeader().GetReferenceBlockNumber() == 0 {
+	if header.GetReferenceBlockNumber() == 0 {
 		return api.NewErrorInvalidArg("missing reference_block_number in request")
 	}
+	return nil
+}
 
-	if len(in.GetBlobs()) == 0 {
+func validateBlobs(blobs []*pb.Blob) error {
+	if len(blobs) == 0 {
 		return api.NewErrorInvalidArg("missing blobs in request")
 	}
-	for _, blob := range in.Blobs {
+	for _, blob := range blobs {
 		if blob.GetHeader() == nil {
 			return api.NewErrorInvalidArg("missing blob header in request")
 		}
@@ -149,9 +234,18 @@ func (s *Server) StoreChunks(ctx context.Context, in *pb.StoreChunksRequest) (*p
 	s.node.Logger.Info("StoreChunks RPC request received", "num of blobs", len(in.Blobs), "request message size", proto.Size(in), "total size of blob headers", blobHeadersSize, "total size of bundles", bundleSize)
 
 	// Validate the request.
+	if s.node.Exiting() {
+		return nil, errExiting
+	}
 	if err := s.validateStoreChunkRequest(in); err != nil {
 		return nil, err
 	}
+	if err := node.CheckBlobSizesV1(in.GetBlobs(), s.node.Config.MaxBlobSizes); err != nil {
+		return nil, blobSizeLimitError(err, blobLengthField)
+	}
+	if err := requestBandwidth(ctx, s.node.IngressLimiter, s.config.ClientIPHeader, proto.Size(in)); err != nil {
+		return nil, err
+	}
 
 	// Process the request.
 	reply, err := s.handleStoreChunksRequest(ctx, in)
@@ -168,12 +262,204 @@ func (s *Server) StoreChunks(ctx context.Context, in *pb.StoreChunksRequest) (*p
 	return reply, err
 }
 
+// StoreChunksStream is called by dispersers to store data one blob at a time.
+func (s *Server) StoreChunksStream(stream pb.Dispersal_StoreChunksStreamServer) error {
+	start := time.Now()
+
+	numBlobs, err := s.handleStoreChunksStream(stream)
+
+	// Record metrics.
+	if err != nil {
+		s.node.Metrics.RecordRPCRequest("StoreChunksStream", "failure", time.Since(start))
+		s.node.Logger.Error("StoreChunksStream RPC failed", "duration", time.Since(start), "num of blobs", numBlobs, "err", err)
+	} else {
+		s.node.Metrics.RecordRPCRequest("StoreChunksStream", "success", time.Since(start))
+		s.node.Logger.Info("StoreChunksStream RPC succeeded", "duration", time.Since(start), "num of blobs", numBlobs)
+	}
+
+	return err
+}
+
+// handleStoreChunksStream processes the batch received on the stream, and returns the number of blobs received.
+func (s *Server) handleStoreChunksStream(stream pb.Dispersal_StoreChunksStreamServer) (int, error) {
+	ctx := stream.Context()
+	if s.node.Exiting() {
+		return 0, errExiting
+	}
+
+	// The first message carries the batch header
+	in, err := stream.Recv()
+	if err != nil {
+		return 0, err
+	}
+	if err := validateBatchHeader(in.GetBatchHeader()); err != nil {
+		return 0, err
+	}
+	batchHeader, err := core.BatchHeaderFromProtobuf(in.GetBatchHeader())
+	if err != nil {
+		return 0, api.NewErrorInvalidArg(err.Error())
+	}
+	s.node.Logger.Info("StoreChunksStream RPC request received", "batch header size", proto.Size(in))
+
+	batchStream, err := s.node.NewBatchStream(ctx, batchHeader)
+	if err != nil {
+		return 0, err
+	}
+	numBlobs := 0
+	for {
+		in, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			batchStream.Abort(ctx)
+			return numBlobs, err
+		}
+		if err := s.addStreamedBlob(ctx, batchStream, in); err != nil {
+			batchStream.Abort(ctx)
+			return numBlobs, err
+		}
+		numBlobs++
+	}
+
+	sig, err := batchStream.Finish(ctx)
+	if err != nil {
+		batchStream.Abort(ctx)
+		return numBlobs, err
+	}
+	sigData := sig.Serialize()
+	return numBlobs, stream.SendAndClose(&pb.StoreChunksReply{Signature: sigData[:]})
+}
+
+// addStreamedBlob validates a blob received on a StoreChunksStream, and adds it to the batch.
+func (s *Server) addStreamedBlob(ctx context.Context, batchStream *node.BatchStream, in *pb.StoreChunksStreamRequest) error {
+	blob := in.GetBlob()
+	if blob == nil {
+		return api.NewErrorInvalidArg("missing blob in stream message")
+	}
+	if err := validateBlobs([]*pb.Blob{blob}); err != nil {
+		return err
+	}
+	if err := node.CheckBlobSizesV1([]*pb.Blob{blob}, s.node.Config.MaxBlobSizes); err != nil {
+		return blobSizeLimitError(err, func(int) string { return "blob.header.length" })
+	}
+	if err := requestBandwidth(ctx, s.node.IngressLimiter, s.config.ClientIPHeader, proto.Size(in)); err != nil {
+		return err
+	}
+	blobs, err := node.GetBlobMessages([]*pb.Blob{blob}, s.node.Config.NumBatchDeserializationWorkers)
+	if err != nil {
+		return err
+	}
+	return batchStream.AddBlob(ctx, blobs[0], blob)
+}
+
+// StoreBlobs is called by dispersers to store the blobs of a minibatch.
 func (s *Server) StoreBlobs(ctx context.Context, in *pb.StoreBlobsRequest) (*pb.StoreBlobsReply, error) {
-	return &pb.StoreBlobsReply{}, api.NewErrorUnimplemented()
+	start := time.Now()
+	s.node.Logger.Info("StoreBlobs RPC request received", "num of blobs", len(in.GetBlobs()), "request message size", proto.Size(in))
+
+	// Validate the request.
+	if s.node.Exiting() {
+		return nil, errExiting
+	}
+	if in.GetReferenceBlockNumber() == 0 {
+		return nil, api.NewErrorInvalidArg("missing reference_block_number in request")
+	}
+	if err := validateBlobs(in.GetBlobs()); err != nil {
+		return nil, err
+	}
+	if err := node.CheckBlobSizesV1(in.GetBlobs(), s.node.Config.MaxBlobSizes); err != nil {
+		return nil, blobSizeLimitError(err, blobLengthField)
+	}
+	if err := requestBandwidth(ctx, s.node.IngressLimiter, s.config.ClientIPHeader, proto.Size(in)); err != nil {
+		return nil, err
+	}
+
+	// Process the request.
+	reply, err := s.handleStoreBlobsRequest(ctx, in)
+
+	// Record metrics.
+	if err != nil {
+		s.node.Metrics.RecordRPCRequest("StoreBlobs", "failure", time.Since(start))
+		s.node.Logger.Error("StoreBlobs RPC failed", "duration", time.Since(start), "err", err)
+	} else {
+		s.node.Metrics.RecordRPCRequest("StoreBlobs", "success", time.Since(start))
+		s.node.Logger.Info("StoreBlobs RPC succeeded", "duration", time.Since(start))
+	}
+
+	return reply, err
+}
+
+func (s *Server) handleStoreBlobsRequest(ctx context.Context, in *pb.StoreBlobsRequest) (*pb.StoreBlobsReply, error) {
+	start := time.Now()
+
+	blobs, err := node.GetBlobMessages(in.GetBlobs(), s.node.Config.NumBatchDeserializationWorkers)
+	if err != nil {
+		return nil, err
+	}
+	s.node.Metrics.ObserveLatency("StoreBlobs", "deserialization", float64(time.Since(start).Milliseconds()))
+
+	identity := s.node.IdentityFor(requestedOperatorID(ctx), uint64(in.GetReferenceBlockNumber()))
+	sigs, err := s.node.ProcessBlobs(ctx, identity, blobs, in.GetBlobs(), uint(in.GetReferenceBlockNumber()))
+	if err != nil {
+		return nil, err
+	}
+
+	signatures := make([]*wrapperspb.BytesValue, len(sigs))
+	for i, sig := range sigs {
+		sigData := sig.Serialize()
+		signatures[i] = wrapperspb.Bytes(sigData[:])
+	}
+	return &pb.StoreBlobsReply{Signatures: signatures}, nil
 }
 
+// AttestBatch is called by dispersers to attest a batch of the blobs stored by StoreBlobs.
 func (s *Server) AttestBatch(ctx context.Context, in *pb.AttestBatchRequest) (*pb.AttestBatchReply, error) {
-	return &pb.AttestBatchReply{}, api.NewErrorUnimplemented()
+	start := time.Now()
+
+	// Validate the request.
+	if s.node.Exiting() {
+		return nil, errExiting
+	}
+	if in.GetBatchHeader() == nil {
+		return nil, api.NewErrorInvalidArg("missing batch_header in request")
+	}
+	if in.GetBatchHeader().GetBatchRoot() == nil {
+		return nil, api.NewErrorInvalidArg("missing batch_root in request")
+	}
+	if in.GetBatchHeader().GetReferenceBlockNumber() == 0 {
+		return nil, api.NewErrorInvalidArg("missing reference_block_number in request")
+	}
+	if len(in.GetBlobHeaderHashes()) == 0 {
+		return nil, api.NewErrorInvalidArg("missing blob_header_hashes in request")
+	}
+	batchHeader, err := core.BatchHeaderFromProtobuf(in.GetBatchHeader())
+	if err != nil {
+		return nil, api.NewErrorInvalidArg(err.Error())
+	}
+	blobHeaderHashes := make([][32]byte, len(in.GetBlobHeaderHashes()))
+	for i, hash := range in.GetBlobHeaderHashes() {
+		if len(hash) != 32 {
+			return nil, api.NewErrorInvalidArg("invalid blob header hash in request")
+		}
+		copy(blobHeaderHashes[i][:], hash)
+	}
+
+	// Process the request.
+	identity := s.node.IdentityFor(requestedOperatorID(ctx), uint64(batchHeader.ReferenceBlockNumber))
+	sig, err := s.node.ProcessAttestBatch(ctx, identity, batchHeader, blobHeaderHashes)
+
+	// Record metrics.
+	if err != nil {
+		s.node.Metrics.RecordRPCRequest("AttestBatch", "failure", time.Since(start))
+		s.node.Logger.Error("AttestBatch RPC failed", "duration", time.Since(start), "err", err)
+		return nil, err
+	}
+	s.node.Metrics.RecordRPCRequest("AttestBatch", "success", time.Since(start))
+	s.node.Logger.Info("AttestBatch RPC succeeded", "num of blobs", len(blobHeaderHashes), "duration", time.Since(start))
+
+	sigData := sig.Serialize()
+	return &pb.AttestBatchReply{Signature: sigData[:]}, nil
 }
 
 func (s *Server) RetrieveChunks(ctx context.Context, in *pb.RetrieveChunksRequest) (*pb.RetrieveChunksReply, error) {
@@ -183,6 +469,13 @@ func (s *Server) RetrieveChunks(ctx context.Context, in *pb.RetrieveChunksReques
 		return nil, fmt.Errorf("invalid request: quorum ID must be in range [0, %d], but found %d", core.MaxQuorumID, in.GetQuorumId())
 	}
 
+	release, err := acquireRetrieval(ctx, s.node.RetrievalLimiter, s.config.ClientIPHeader)
+	if err != nil {
+		s.node.Metrics.RecordRPCRequest("RetrieveChunks", "failure", time.Since(start))
+		return nil, err
+	}
+	defer release()
+
 	var batchHeaderHash [32]byte
 	copy(batchHeaderHash[:], in.GetBatchHeaderHash())
 
@@ -247,6 +540,10 @@ func (s *Server) RetrieveChunks(ctx context.Context, in *pb.RetrieveChunksReques
 		}
 		chunks = gobChunks
 	}
+	if err := requestBandwidth(ctx, s.node.EgressLimiter, s.config.ClientIPHeader, chunksSize(chunks)); err != nil {
+		s.node.Metrics.RecordRPCRequest("RetrieveChunks", "failure", time.Since(start))
+		return nil, err
+	}
 	s.node.Metrics.RecordRPCRequest("RetrieveChunks", "success", time.Since(start))
 	return &pb.RetrieveChunksReply{Chunks: chunks, ChunkEncodingFormat: format}, nil
 }
@@ -284,8 +581,13 @@ func (s *Server) GetBlobHeader(ctx context.Context, in *pb.GetBlobHeaderRequest)
 	}, nil
 }
 
-// rebuildMerkleTree rebuilds the merkle tree from the blob headers and batch header.
+// rebuildMerkleTree rebuilds the merkle tree from the blob headers and batch header, unless the verified tree of the
+// batch is cached.
 func (s *Server) rebuildMerkleTree(batchHeaderHash [32]byte) (*merkletree.MerkleTree, error) {
+	if tree := s.node.BatchHeaderCache.Get(batchHeaderHash); tree != nil {
+		return tree, nil
+	}
+
 	batchHeaderBytes, err := s.node.Store.GetBatchHeader(context.Background(), batchHeaderHash)
 	if err != nil {
 		return nil, err
@@ -338,6 +640,7 @@ func (s *Server) rebuildMerkleTree(batchHeaderHash [32]byte) (*merkletree.Merkle
 	if !reflect.DeepEqual(tree.Root(), batchHeader.BatchRoot[:]) {
 		return nil, errors.New("invalid batch header")
 	}
+	s.node.BatchHeaderCache.Add(batchHeaderHash, tree)
 
 	return tree, nil
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"github.com/docker/go-units"
+	"io"
 	"net"
 	"os"
 	"runtime"
@@ -11,6 +12,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/Layr-Labs/eigenda/api"
 	commonpb "github.com/Layr-Labs/eigenda/api/grpc/common"
 	pb "github.com/Layr-Labs/eigenda/api/grpc/node"
 	"github.com/Layr-Labs/eigenda/common"
@@ -32,6 +34,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/wealdtech/go-merkletree/v2"
 	"github.com/wealdtech/go-merkletree/v2/keccak256"
+	grpclib "google.golang.org/grpc"
 	"google.golang.org/grpc/peer"
 	"google.golang.org/protobuf/proto"
 )
@@ -335,10 +338,14 @@ func storeChunks(t *testing.T, server *grpc.Server, useGnarkBundleEncoding bool)
 }
 
 func TestNodeInfoRequest(t *testing.T) {
-	server := newTestServer(t, true)
+	config := makeConfig(t)
+	config.EnableV1 = true
+	server := newTestServerWithConfig(t, true, config)
 	resp, err := server.NodeInfo(context.Background(), &pb.NodeInfoRequest{})
 	assert.True(t, resp.Semver == "0.0.0")
 	assert.True(t, err == nil)
+	assert.Contains(t, resp.ApiVersions, api.NodeAPIVersionV1)
+	assert.Contains(t, resp.Features, api.NodeFeatureStoreChunksStream)
 }
 
 func TestStoreChunksRequestValidation(t *testing.T) {
@@ -396,6 +403,20 @@ func TestStoreChunksRequestValidation(t *testing.T) {
 	assert.True(t, strings.Contains(err.Error(), "adversary threshold equals 0"))
 }
 
+func TestStoreChunksBlobSizeLimit(t *testing.T) {
+	config := makeConfig(t)
+	// The second blob, of 50 symbols, is over the limit of quorum 0
+	config.MaxBlobSizes = map[core.QuorumID]uint64{0: 49 * encoding.BYTES_PER_SYMBOL}
+	server := newTestServerWithConfig(t, true, config)
+
+	req, _, _, _, _ := makeStoreChunksRequest(t, 66, 33)
+	_, err := server.StoreChunks(context.Background(), req)
+	assert.ErrorContains(t, err, "blob 1 of 1600 bytes exceeds the size limit of 1568 bytes in quorum 0")
+	violations := api.FieldViolations(err)
+	assert.Len(t, violations, 1)
+	assert.Equal(t, "blobs[1].header.length", violations[0].Field)
+}
+
 func TestRetrieveChunks(t *testing.T) {
 	server := newTestServer(t, true)
 	batchHeaderHash, _, _, _ := storeChunks(t, server, false)
@@ -431,6 +452,63 @@ func TestRetrieveChunks(t *testing.T) {
 	assert.Empty(t, retrievalReply.GetChunks())
 }
 
+func TestStoreBlobsAndAttestBatch(t *testing.T) {
+	server := newTestServer(t, true)
+	req, batchHeaderHash, _, blobHeaders, _ := makeStoreChunksRequest(t, 100, 90)
+	ctx := peer.NewContext(context.Background(), &peer.Peer{
+		Addr: &net.TCPAddr{
+			IP:   net.ParseIP("0.0.0.0"),
+			Port: 3000,
+		},
+	})
+
+	_, err := server.StoreBlobs(ctx, &pb.StoreBlobsRequest{Blobs: req.GetBlobs()})
+	assert.Error(t, err)
+	assert.True(t, strings.Contains(err.Error(), "missing reference_block_number in request"))
+
+	storeReply, err := server.StoreBlobs(ctx, &pb.StoreBlobsRequest{
+		Blobs:                req.GetBlobs(),
+		ReferenceBlockNumber: req.GetBatchHeader().GetReferenceBlockNumber(),
+	})
+	assert.NoError(t, err)
+	assert.Len(t, storeReply.GetSignatures(), len(blobHeaders))
+	blobHeaderHashes := make([][]byte, len(blobHeaders))
+	for i, blobHeader := range blobHeaders {
+		blobHeaderHash, err := blobHeader.GetBlobHeaderHash()
+		assert.NoError(t, err)
+		blobHeaderHashes[i] = blobHeaderHash[:]
+		sig, err := new(core.Signature).Deserialize(storeReply.GetSignatures()[i].GetValue())
+		assert.NoError(t, err)
+		assert.True(t, (&core.Signature{G1Point: sig}).Verify(keyPair.GetPubKeyG2(), blobHeaderHash))
+	}
+
+	// The batch root must commit to the blobs
+	_, err = server.AttestBatch(ctx, &pb.AttestBatchRequest{
+		BatchHeader:      req.GetBatchHeader(),
+		BlobHeaderHashes: blobHeaderHashes[:1],
+	})
+	assert.Error(t, err)
+
+	attestReply, err := server.AttestBatch(ctx, &pb.AttestBatchRequest{
+		BatchHeader:      req.GetBatchHeader(),
+		BlobHeaderHashes: blobHeaderHashes,
+	})
+	assert.NoError(t, err)
+	sig, err := new(core.Signature).Deserialize(attestReply.GetSignature())
+	assert.NoError(t, err)
+	assert.True(t, (&core.Signature{G1Point: sig}).Verify(keyPair.GetPubKeyG2(), batchHeaderHash))
+
+	// The blobs can be retrieved by their index in the batch once it's attested
+	retrievalReply, err := server.RetrieveChunks(ctx, &pb.RetrieveChunksRequest{
+		BatchHeaderHash: batchHeaderHash[:],
+		BlobIndex:       0,
+		QuorumId:        0,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, pb.ChunkEncodingFormat_GOB, retrievalReply.ChunkEncodingFormat)
+	assert.Equal(t, req.GetBlobs()[0].GetBundles()[0].GetChunks(), retrievalReply.GetChunks())
+}
+
 func TestGnarkBundleEncoding(t *testing.T) {
 	config := makeConfig(t)
 	config.EnableGnarkBundleEncoding = true
@@ -524,6 +602,101 @@ func TestRevertInvalidBatch(t *testing.T) {
 	assert.Error(t, err)
 }
 
+// storeChunksStream is a StoreChunksStream server stream which receives the given requests.
+type storeChunksStream struct {
+	grpclib.ServerStream
+	requests []*pb.StoreChunksStreamRequest
+	reply    *pb.StoreChunksReply
+}
+
+func (s *storeChunksStream) Context() context.Context {
+	return context.Background()
+}
+
+func (s *storeChunksStream) Recv() (*pb.StoreChunksStreamRequest, error) {
+	if len(s.requests) == 0 {
+		return nil, io.EOF
+	}
+	request := s.requests[0]
+	s.requests = s.requests[1:]
+	return request, nil
+}
+
+func (s *storeChunksStream) SendAndClose(reply *pb.StoreChunksReply) error {
+	s.reply = reply
+	return nil
+}
+
+func newStoreChunksStream(req *pb.StoreChunksRequest) *storeChunksStream {
+	stream := &storeChunksStream{}
+	stream.requests = append(stream.requests, &pb.StoreChunksStreamRequest{
+		Payload: &pb.StoreChunksStreamRequest_BatchHeader{BatchHeader: req.GetBatchHeader()},
+	})
+	for _, blob := range req.GetBlobs() {
+		stream.requests = append(stream.requests, &pb.StoreChunksStreamRequest{
+			Payload: &pb.StoreChunksStreamRequest_Blob{Blob: blob},
+		})
+	}
+	return stream
+}
+
+func TestStoreChunksStream(t *testing.T) {
+	server := newTestServer(t, true)
+	req, batchHeaderHash, _, _, protoBlobHeaders := makeStoreChunksRequest(t, 100, 90)
+
+	// A stream missing a blob of the batch doesn't match the batch root, and is rolled back
+	partial := proto.Clone(req).(*pb.StoreChunksRequest)
+	partial.Blobs = partial.Blobs[:1]
+	stream := newStoreChunksStream(partial)
+	err := server.StoreChunksStream(stream)
+	assert.Error(t, err)
+	assert.Nil(t, stream.reply)
+	_, err = server.GetBlobHeader(context.Background(), &pb.GetBlobHeaderRequest{
+		BatchHeaderHash: batchHeaderHash[:],
+		BlobIndex:       0,
+		QuorumId:        0,
+	})
+	assert.Error(t, err)
+
+	// The first message must carry the batch header
+	stream = newStoreChunksStream(req)
+	stream.requests = stream.requests[1:]
+	assert.Error(t, server.StoreChunksStream(stream))
+
+	stream = newStoreChunksStream(req)
+	err = server.StoreChunksStream(stream)
+	assert.NoError(t, err)
+	assert.NotNil(t, stream.reply.GetSignature())
+
+	// The streamed batch is stored like a batch stored by StoreChunks
+	reply, err := server.GetBlobHeader(context.Background(), &pb.GetBlobHeaderRequest{
+		BatchHeaderHash: batchHeaderHash[:],
+		BlobIndex:       1,
+		QuorumId:        0,
+	})
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(protoBlobHeaders[1], reply.GetBlobHeader()))
+
+	p := &peer.Peer{
+		Addr: &net.TCPAddr{
+			IP:   net.ParseIP("0.0.0.0"),
+			Port: 3000,
+		},
+	}
+	retrievalReply, err := server.RetrieveChunks(peer.NewContext(context.Background(), p), &pb.RetrieveChunksRequest{
+		BatchHeaderHash: batchHeaderHash[:],
+		BlobIndex:       0,
+		QuorumId:        0,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]byte{encodedChunk}, retrievalReply.GetChunks())
+
+	// Streaming a stored batch again is signed without storing it again
+	stream = newStoreChunksStream(req)
+	assert.NoError(t, server.StoreChunksStream(stream))
+	assert.NotNil(t, stream.reply.GetSignature())
+}
+
 func TestGetBlobHeader(t *testing.T) {
 	server := newTestServer(t, true)
 	batchHeaderHash, batchRoot, blobHeaders, protoBlobHeaders := storeChunks(t, server, false)
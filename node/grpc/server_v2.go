@@ -17,6 +17,7 @@ import (
 	"github.com/Layr-Labs/eigenda/node"
 	"github.com/Layr-Labs/eigenda/node/auth"
 	"github.com/Layr-Labs/eigensdk-go/logging"
+	gethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/shirou/gopsutil/mem"
 	"google.golang.org/grpc/peer"
@@ -33,6 +34,8 @@ type ServerV2 struct {
 	logger        logging.Logger
 	metrics       *MetricsV2
 	authenticator auth.RequestAuthenticator
+	// chunkCache caches the chunks served to retrieval clients. It's nil if the cache is disabled.
+	chunkCache *chunkCache
 }
 
 // NewServerV2 creates a new Server instance with the provided parameters.
@@ -52,6 +55,10 @@ func NewServerV2(
 
 	var authenticator auth.RequestAuthenticator
 	if !config.DisableDispersalAuthentication {
+		var disperserFilter func(address gethcommon.Address, now time.Time) error
+		if node.DisperserFilter != nil {
+			disperserFilter = node.DisperserFilter.CheckDisperser
+		}
 		authenticator, err = auth.NewRequestAuthenticator(
 			ctx,
 			reader,
@@ -61,12 +68,21 @@ func NewServerV2(
 			func(id uint32) bool {
 				return id == api.EigenLabsDisperserID
 			},
+			disperserFilter,
 			time.Now())
 		if err != nil {
 			return nil, fmt.Errorf("failed to create authenticator: %w", err)
 		}
 	}
 
+	var cache *chunkCache
+	if config.RetrievalCacheSize > 0 && node.StoreV2 != nil {
+		cache, err = newChunkCache(node.StoreV2, config.RetrievalCacheSize, metrics)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return &ServerV2{
 		config:        config,
 		node:          node,
@@ -74,12 +90,14 @@ func NewServerV2(
 		logger:        logger,
 		metrics:       metrics,
 		authenticator: authenticator,
+		chunkCache:    cache,
 	}, nil
 }
 
 func (s *ServerV2) GetNodeInfo(ctx context.Context, in *pb.GetNodeInfoRequest) (*pb.GetNodeInfoReply, error) {
+	apiVersions, features := nodeAPIVersions(s.config), nodeFeatures(s.config)
 	if s.config.DisableNodeInfoResources {
-		return &pb.GetNodeInfoReply{Semver: node.SemVer}, nil
+		return &pb.GetNodeInfoReply{Semver: node.SemVer, ApiVersions: apiVersions, Features: features}, nil
 	}
 
 	memBytes := uint64(0)
@@ -89,67 +107,131 @@ func (s *ServerV2) GetNodeInfo(ctx context.Context, in *pb.GetNodeInfoRequest) (
 	}
 
 	return &pb.GetNodeInfoReply{
-		Semver:   node.SemVer,
-		Os:       runtime.GOOS,
-		Arch:     runtime.GOARCH,
-		NumCpu:   uint32(runtime.GOMAXPROCS(0)),
-		MemBytes: memBytes,
+		Semver:      node.SemVer,
+		Os:          runtime.GOOS,
+		Arch:        runtime.GOARCH,
+		NumCpu:      uint32(runtime.GOMAXPROCS(0)),
+		MemBytes:    memBytes,
+		ApiVersions: apiVersions,
+		Features:    features,
 	}, nil
 }
 
 func (s *ServerV2) StoreChunks(ctx context.Context, in *pb.StoreChunksRequest) (*pb.StoreChunksReply, error) {
 	start := time.Now()
 
+	// reject reports a request which the node fails to sign, attributing it to the deadline if the request
+	// was cancelled
+	reject := func(reason string, err error) (*pb.StoreChunksReply, error) {
+		if ctx.Err() != nil {
+			reason = "deadline_exceeded"
+		}
+		s.metrics.ReportStoreChunksRejection(reason)
+		return nil, err
+	}
+
 	if !s.config.EnableV2 {
-		return nil, api.NewErrorInvalidArg("v2 API is disabled")
+		return reject("disabled", api.NewErrorInvalidArg("v2 API is disabled"))
 	}
 
 	if s.node.StoreV2 == nil {
-		return nil, api.NewErrorInternal("v2 store not initialized")
+		return reject("disabled", api.NewErrorInternal("v2 store not initialized"))
 	}
 
 	if s.node.BLSSigner == nil {
-		return nil, api.NewErrorInternal("missing bls signer")
+		return reject("disabled", api.NewErrorInternal("missing bls signer"))
+	}
+
+	if s.node.Exiting() {
+		return reject("exiting", errExiting)
 	}
 
 	// Validate the request parameters (which is cheap) before starting any further
 	// processing of the request.
 	batch, err := s.validateStoreChunksRequest(in)
 	if err != nil {
-		return nil, api.NewErrorInvalidArg(fmt.Sprintf("failed to validate store chunk request: %v", err))
+		return reject("invalid_request", api.NewErrorInvalidArg(fmt.Sprintf("failed to validate store chunk request: %v", err)))
 	}
 
 	batchHeaderHash, err := batch.BatchHeader.Hash()
 	if err != nil {
-		return nil, api.NewErrorInvalidArg(fmt.Sprintf("failed to serialize batch header hash: %v", err))
+		return reject("invalid_request", api.NewErrorInvalidArg(fmt.Sprintf("failed to serialize batch header hash: %v", err)))
 	}
 
 	if s.authenticator != nil {
 		disperserPeer, ok := peer.FromContext(ctx)
 		if !ok {
-			return nil, api.NewErrorInvalidArg("could not get peer information from request context")
+			return reject("authentication", api.NewErrorInvalidArg("could not get peer information from request context"))
 		}
 		disperserAddress := disperserPeer.Addr.String()
 
 		err := s.authenticator.AuthenticateStoreChunksRequest(ctx, disperserAddress, in, time.Now())
+		if errors.Is(err, node.ErrDisperserNotAllowed) {
+			return reject("disperser_not_allowed", api.NewErrorPermissionDenied(err.Error()))
+		}
 		if err != nil {
-			return nil, api.NewErrorInvalidArg(fmt.Sprintf("failed to authenticate request: %v", err))
+			return reject("authentication", api.NewErrorInvalidArg(fmt.Sprintf("failed to authenticate request: %v", err)))
 		}
 	}
 
+	// Authenticated batches which the node fails to sign are reported as missed attestations
+	signed := false
+	defer func() {
+		s.node.Stats.RecordAttestation(signed, time.Now())
+	}()
+	s.node.Stats.RecordReferenceBlock(uint64(batch.BatchHeader.ReferenceBlockNumber))
+	quorums := batchQuorums(batch)
+
+	if err := node.CheckBlobSizesV2(batch, s.node.Config.MaxBlobSizes); err != nil {
+		return reject("blob_size", blobSizeLimitError(err, func(i int) string {
+			return fmt.Sprintf("batch.blob_certificates[%d].blob_header.commitment.length", i)
+		}))
+	}
+
 	s.logger.Info("new StoreChunks request", "batchHeaderHash", hex.EncodeToString(batchHeaderHash[:]), "numBlobs", len(batch.BlobCertificates), "referenceBlockNumber", batch.BatchHeader.ReferenceBlockNumber)
-	operatorState, err := s.node.ChainState.GetOperatorStateByOperator(ctx, uint(batch.BatchHeader.ReferenceBlockNumber), s.node.Config.ID)
+	// During a key rotation the node is registered as two operators, and attests as the one the request is for
+	identity := s.node.IdentityFor(requestedOperatorID(ctx), uint64(batch.BatchHeader.ReferenceBlockNumber))
+	operatorState, err := s.node.ChainState.GetOperatorStateByOperator(ctx, uint(batch.BatchHeader.ReferenceBlockNumber), identity.ID)
 	if err != nil {
-		return nil, api.NewErrorInternal(fmt.Sprintf("failed to get the operator state: %v", err))
+		return reject("operator_state", api.NewErrorInternal(fmt.Sprintf("failed to get the operator state: %v", err)))
+	}
+
+	if s.node.IngressLimiter != nil {
+		bundlesSize, err := s.node.BundlesSize(identity.ID, batch, operatorState)
+		if err != nil {
+			return reject("operator_state", api.NewErrorInternal(fmt.Sprintf("failed to get the size of the bundles: %v", err)))
+		}
+		if err := requestBandwidth(ctx, s.node.IngressLimiter, s.config.ClientIPHeader, int(bundlesSize)); err != nil {
+			return reject("bandwidth_limited", err)
+		}
 	}
 
 	stageTimer := time.Now()
-	blobShards, rawBundles, err := s.node.DownloadBundles(ctx, batch, operatorState)
+	blobShards, rawBundles, err := s.node.DownloadBundles(ctx, identity.ID, batch, operatorState)
+	var sizeErr *node.BlobSizeLimitError
+	if errors.As(err, &sizeErr) {
+		return reject("blob_size", blobSizeLimitError(err, func(i int) string {
+			return fmt.Sprintf("batch.blob_certificates[%d]", i)
+		}))
+	}
 	if err != nil {
-		return nil, api.NewErrorInternal(fmt.Sprintf("failed to get the operator state: %v", err))
+		return reject("download", api.NewErrorInternal(fmt.Sprintf("failed to get the operator state: %v", err)))
 	}
 	s.metrics.ReportStoreChunksLatency("download", time.Since(stageTimer))
 
+	var quotaReservation *node.QuotaReservation
+	if s.node.DiskQuotas != nil {
+		quotaReservation, err = s.node.DiskQuotas.Reserve(node.BundleSizesByQuorum(rawBundles), time.Now())
+		if err != nil {
+			return reject("quota_exceeded", quotaExceededError(err))
+		}
+	}
+	releaseQuota := func() {
+		if quotaReservation != nil {
+			s.node.DiskQuotas.Release(quotaReservation)
+		}
+	}
+
 	type storeResult struct {
 		keys []kvstore.Key
 		err  error
@@ -175,7 +257,7 @@ func (s *ServerV2) StoreChunks(ctx context.Context, in *pb.StoreChunksRequest) (
 	}()
 
 	stageTimer = time.Now()
-	err = s.node.ValidateBatchV2(ctx, batch, blobShards, operatorState)
+	err = s.node.ValidateBatchV2(ctx, identity.ID, batch, blobShards, operatorState)
 	if err != nil {
 		res := <-storeChan
 		if len(res.keys) > 0 {
@@ -183,27 +265,95 @@ func (s *ServerV2) StoreChunks(ctx context.Context, in *pb.StoreChunksRequest) (
 				s.logger.Error("failed to delete keys", "err", deleteErr, "batchHeaderHash", hex.EncodeToString(batchHeaderHash[:]))
 			}
 		}
-		return nil, api.NewErrorInternal(fmt.Sprintf("failed to validate batch: %v", err))
+		releaseQuota()
+		return reject("validation", api.NewErrorInternal(fmt.Sprintf("failed to validate batch: %v", err)))
 	}
-	s.metrics.ReportStoreChunksLatency("validation", time.Since(stageTimer))
+	validationLatency := time.Since(stageTimer)
+	s.metrics.ReportStoreChunksLatency("validation", validationLatency)
+	s.metrics.ReportStoreChunksQuorumLatency(quorums, "validation", validationLatency)
+	s.node.Stats.RecordValidationLatency(validationLatency)
 
 	res := <-storeChan
 	if res.err != nil {
-		return nil, api.NewErrorInternal(fmt.Sprintf("failed to store batch: %v", res.err))
+		releaseQuota()
+		return reject("storage", api.NewErrorInternal(fmt.Sprintf("failed to store batch: %v", res.err)))
+	}
+	// The chunks are validated, so they must survive a crash from now on
+	if err := s.node.StoreV2.CommitBatch(batchHeaderHash); err != nil {
+		if deleteErr := s.node.StoreV2.DeleteKeys(res.keys); deleteErr != nil {
+			s.logger.Error("failed to delete keys", "err", deleteErr, "batchHeaderHash", hex.EncodeToString(batchHeaderHash[:]))
+		}
+		releaseQuota()
+		return reject("storage", api.NewErrorInternal(fmt.Sprintf("failed to commit batch: %v", err)))
 	}
 
-	sig, err := s.node.BLSSigner.Sign(ctx, batchHeaderHash[:])
+	blobKeys := make([][32]byte, len(batch.BlobCertificates))
+	for i, cert := range batch.BlobCertificates {
+		blobKeys[i], err = cert.BlobHeader.BlobKey()
+		if err != nil {
+			return reject("invalid_request", api.NewErrorInternal(fmt.Sprintf("failed to get blob key: %v", err)))
+		}
+	}
+	err = s.node.RecordAttestation(batchHeaderHash, uint64(batch.BatchHeader.ReferenceBlockNumber), blobKeys)
+	if errors.Is(err, node.ErrConflictingAttestation) {
+		// The stored chunks aren't rolled back, since they're keyed by blob and shared with the batch attested to
+		return reject("conflicting_attestation", api.NewErrorInvalidArg(err.Error()))
+	} else if err != nil {
+		return reject("signing", api.NewErrorInternal(fmt.Sprintf("failed to record attestation: %v", err)))
+	}
+
+	stageTimer = time.Now()
+	sig, err := identity.Signer.Sign(ctx, batchHeaderHash[:])
 	if err != nil {
-		return nil, api.NewErrorInternal(fmt.Sprintf("failed to sign batch: %v", err))
+		return reject("signing", api.NewErrorInternal(fmt.Sprintf("failed to sign batch: %v", err)))
 	}
+	signed = true
+	signingLatency := time.Since(stageTimer)
+	s.metrics.ReportStoreChunksLatency("signing", signingLatency)
+	s.metrics.ReportStoreChunksQuorumLatency(quorums, "signing", signingLatency)
 
-	s.metrics.ReportStoreChunksLatency("total", time.Since(start))
+	totalLatency := time.Since(start)
+	s.metrics.ReportStoreChunksLatency("total", totalLatency)
+	s.metrics.ReportStoreChunksQuorumLatency(quorums, "total", totalLatency)
 
 	return &pb.StoreChunksReply{
 		Signature: sig,
 	}, nil
 }
 
+// batchQuorums returns the quorums of the blobs of a batch.
+func batchQuorums(batch *corev2.Batch) []core.QuorumID {
+	seen := make(map[core.QuorumID]struct{})
+	quorums := make([]core.QuorumID, 0)
+	for _, cert := range batch.BlobCertificates {
+		for _, quorum := range cert.BlobHeader.QuorumNumbers {
+			if _, ok := seen[quorum]; !ok {
+				seen[quorum] = struct{}{}
+				quorums = append(quorums, quorum)
+			}
+		}
+	}
+	return quorums
+}
+
+// quotaExceededError converts an error returned when reserving disk space for chunks into a grpc error. Quota
+// violations are returned as a structured ResourceExhausted error listing the quorums over their quota.
+func quotaExceededError(err error) error {
+	var quotaErr *node.QuotaExceededError
+	if !errors.As(err, &quotaErr) {
+		return api.NewErrorInternal(fmt.Sprintf("failed to reserve disk space: %v", err))
+	}
+	violations := make([]api.QuotaViolation, len(quotaErr.Violations))
+	for i, violation := range quotaErr.Violations {
+		violations[i] = api.QuotaViolation{
+			Subject: fmt.Sprintf("quorum:%d", violation.Quorum),
+			Description: fmt.Sprintf("disk usage of %d bytes plus %d requested bytes exceeds the limit of %d bytes",
+				violation.Usage, violation.Requested, violation.Limit),
+		}
+	}
+	return api.NewErrorResourceExhaustedWithQuotaViolations(quotaErr.Error(), violations)
+}
+
 // validateStoreChunksRequest validates the StoreChunksRequest and returns deserialized batch in the request
 func (s *ServerV2) validateStoreChunksRequest(req *pb.StoreChunksRequest) (*corev2.Batch, error) {
 	// The signature is created by go-ethereum library, which contains 1 additional byte (for
@@ -245,14 +395,26 @@ func (s *ServerV2) GetChunks(ctx context.Context, in *pb.GetChunksRequest) (*pb.
 		return nil, api.NewErrorInvalidArg("invalid quorum ID")
 	}
 	quorumID := core.QuorumID(in.GetQuorumId())
-	chunks, err := s.node.StoreV2.GetChunks(blobKey, quorumID)
+
+	release, err := acquireRetrieval(ctx, s.node.RetrievalLimiter, s.config.ClientIPHeader)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	var chunks [][]byte
+	if s.chunkCache != nil {
+		chunks, err = s.chunkCache.GetChunks(blobKey, quorumID)
+	} else {
+		chunks, err = s.node.StoreV2.GetChunks(blobKey, quorumID)
+	}
 	if err != nil {
 		return nil, api.NewErrorInternal(fmt.Sprintf("failed to get chunks: %v", err))
 	}
 
-	size := 0
-	if len(chunks) > 0 {
-		size = len(chunks[0]) * len(chunks)
+	size := chunksSize(chunks)
+	if err := requestBandwidth(ctx, s.node.EgressLimiter, s.config.ClientIPHeader, size); err != nil {
+		return nil, err
 	}
 	s.metrics.ReportGetChunksDataSize(size)
 
@@ -263,3 +425,83 @@ func (s *ServerV2) GetChunks(ctx context.Context, in *pb.GetChunksRequest) (*pb.
 		ChunkEncodingFormat: pb.ChunkEncodingFormat_GNARK,
 	}, nil
 }
+
+func (s *ServerV2) AuditChunks(ctx context.Context, in *pb.AuditChunksRequest) (*pb.AuditChunksReply, error) {
+	if !s.config.EnableV2 {
+		return nil, api.NewErrorInvalidArg("v2 API is disabled")
+	}
+
+	if s.node.ChunkAuditor == nil {
+		return nil, api.NewErrorInternal("chunk auditor not initialized")
+	}
+
+	numBlobs := int(in.GetNumBlobs())
+	if numBlobs == 0 || numBlobs > s.config.ChunkAuditBlobs {
+		numBlobs = s.config.ChunkAuditBlobs
+	}
+	report, err := s.node.ChunkAuditor.Audit(ctx, numBlobs)
+	if err != nil {
+		return nil, api.NewErrorInternal(fmt.Sprintf("failed to audit chunks: %v", err))
+	}
+
+	corruptBundles := make([]*pb.CorruptBundle, len(report.Corrupt))
+	for i := range report.Corrupt {
+		bundle := &report.Corrupt[i]
+		corruptBundles[i] = &pb.CorruptBundle{
+			BlobKey:  bundle.BlobKey[:],
+			QuorumId: uint32(bundle.Quorum),
+			Reason:   bundle.Err.Error(),
+		}
+	}
+	return &pb.AuditChunksReply{
+		NumBundles:     uint32(report.NumBundles),
+		CorruptBundles: corruptBundles,
+	}, nil
+}
+
+func (s *ServerV2) GetChunkProof(ctx context.Context, in *pb.GetChunkProofRequest) (*pb.GetChunkProofReply, error) {
+	if !s.config.EnableV2 {
+		return nil, api.NewErrorInvalidArg("v2 API is disabled")
+	}
+
+	if s.node.StoreV2 == nil {
+		return nil, api.NewErrorInternal("v2 store not initialized")
+	}
+
+	blobKey, err := corev2.BytesToBlobKey(in.GetBlobKey())
+	if err != nil {
+		return nil, api.NewErrorInvalidArg(fmt.Sprintf("invalid blob key: %v", err))
+	}
+
+	release, err := acquireRetrieval(ctx, s.node.RetrievalLimiter, s.config.ClientIPHeader)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	proof, err := s.node.GetChunkProof(blobKey, in.GetChunkIndex())
+	if errors.Is(err, node.ErrChunkNotStored) {
+		return nil, api.NewErrorNotFound(err.Error())
+	}
+	if err != nil {
+		return nil, api.NewErrorInternal(fmt.Sprintf("failed to get chunk proof: %v", err))
+	}
+
+	if err := requestBandwidth(ctx, s.node.EgressLimiter, s.config.ClientIPHeader, len(proof.Chunk)); err != nil {
+		return nil, err
+	}
+
+	blobCertificate, err := proof.BlobCertificate.ToProtobuf()
+	if err != nil {
+		return nil, api.NewErrorInternal(fmt.Sprintf("failed to serialize blob certificate: %v", err))
+	}
+	return &pb.GetChunkProofReply{
+		Chunk:           proof.Chunk,
+		KzgProof:        proof.KZGProof,
+		QuorumId:        uint32(proof.Quorum),
+		BlobCertificate: blobCertificate,
+		BatchHeader:     proof.Inclusion.BatchHeader.ToProtobuf(),
+		BlobIndex:       proof.Inclusion.BlobIndex,
+		InclusionProof:  proof.Inclusion.InclusionProof,
+	}, nil
+}
@@ -3,35 +3,46 @@ package grpc_test
 import (
 	"context"
 	"errors"
+	"fmt"
+	"net"
 	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
 	"sync/atomic"
 	"testing"
+	"time"
 
 	coreeth "github.com/Layr-Labs/eigenda/core/eth"
 
+	"github.com/Layr-Labs/eigenda/api"
 	"github.com/Layr-Labs/eigenda/api/clients/v2"
 	clientsmock "github.com/Layr-Labs/eigenda/api/clients/v2/mock"
 	pbcommon "github.com/Layr-Labs/eigenda/api/grpc/common/v2"
 	"github.com/Layr-Labs/eigenda/api/grpc/validator"
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/common/kvstore"
+	"github.com/Layr-Labs/eigenda/common/kvstore/mapstore"
 	commonmock "github.com/Layr-Labs/eigenda/common/mock"
 	"github.com/Layr-Labs/eigenda/core"
 	coremock "github.com/Layr-Labs/eigenda/core/mock"
 	coremockv2 "github.com/Layr-Labs/eigenda/core/mock/v2"
 	v2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/encoding"
+	encmock "github.com/Layr-Labs/eigenda/encoding/mock"
 	"github.com/Layr-Labs/eigenda/node"
 	"github.com/Layr-Labs/eigenda/node/grpc"
 	nodemock "github.com/Layr-Labs/eigenda/node/mock"
 	"github.com/Layr-Labs/eigensdk-go/metrics"
 	blssigner "github.com/Layr-Labs/eigensdk-go/signer/bls"
 	blssignerTypes "github.com/Layr-Labs/eigensdk-go/signer/bls/types"
+	gethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
 )
 
@@ -63,6 +74,8 @@ type testComponents struct {
 	store       *nodemock.MockStoreV2
 	validator   *coremockv2.MockShardValidator
 	relayClient *clientsmock.MockRelayClient
+	// registry holds the metrics of the server
+	registry *prometheus.Registry
 }
 
 func newTestComponents(t *testing.T, config *node.Config) *testComponents {
@@ -109,13 +122,14 @@ func newTestComponents(t *testing.T, config *node.Config) *testComponents {
 	// The eth client is only utilized for StoreChunks validation, which is disabled in these tests
 	var reader *coreeth.Reader
 
+	registry := prometheus.NewRegistry()
 	server, err := grpc.NewServerV2(
 		context.Background(),
 		config,
 		node,
 		logger,
 		ratelimiter,
-		prometheus.NewRegistry(),
+		registry,
 		reader)
 
 	require.NoError(t, err)
@@ -125,14 +139,19 @@ func newTestComponents(t *testing.T, config *node.Config) *testComponents {
 		store:       s,
 		validator:   val,
 		relayClient: relay,
+		registry:    registry,
 	}
 }
 
 func TestV2NodeInfoRequest(t *testing.T) {
-	c := newTestComponents(t, makeConfig(t))
+	config := makeConfig(t)
+	config.EnableV2 = true
+	c := newTestComponents(t, config)
 	resp, err := c.server.GetNodeInfo(context.Background(), &validator.GetNodeInfoRequest{})
 	assert.True(t, resp.Semver == "0.0.0")
 	assert.True(t, err == nil)
+	assert.Contains(t, resp.ApiVersions, api.NodeAPIVersionV2)
+	assert.Contains(t, resp.Features, api.NodeFeatureBatchVerification)
 }
 
 func TestV2ServerWithoutV2(t *testing.T) {
@@ -141,6 +160,7 @@ func TestV2ServerWithoutV2(t *testing.T) {
 	c := newTestComponents(t, config)
 	_, err := c.server.StoreChunks(context.Background(), &validator.StoreChunksRequest{})
 	requireErrorStatus(t, err, codes.InvalidArgument)
+	requireRejections(t, c.registry, "disabled", 1)
 
 	_, err = c.server.GetChunks(context.Background(), &validator.GetChunksRequest{})
 	requireErrorStatus(t, err, codes.InvalidArgument)
@@ -189,6 +209,7 @@ func TestV2StoreChunksInputValidation(t *testing.T) {
 	}
 	_, err = c.server.StoreChunks(context.Background(), req)
 	requireErrorStatusAndMsg(t, err, codes.InvalidArgument, "failed to deserialize batch")
+	requireRejections(t, c.registry, "invalid_request", 4)
 }
 
 func TestV2StoreChunksSuccess(t *testing.T) {
@@ -230,6 +251,7 @@ func TestV2StoreChunksSuccess(t *testing.T) {
 		require.Equal(t, blobKeys[1], requests[1].BlobKey)
 	})
 	c.store.On("StoreBatch", batch, mock.Anything).Return(nil, nil)
+	c.store.On("CommitBatch", mock.Anything).Return(nil)
 	reply, err := c.server.StoreChunks(context.Background(), &validator.StoreChunksRequest{
 		DisperserID: 0,
 		Signature:   ecdsaSig,
@@ -244,6 +266,18 @@ func TestV2StoreChunksSuccess(t *testing.T) {
 	bhh, err := batch.BatchHeader.Hash()
 	require.NoError(t, err)
 	require.True(t, sig.Verify(c.node.KeyPair.GetPubKeyG2(), bhh))
+	c.store.AssertCalled(t, "CommitBatch", bhh)
+
+	// Each stage is reported for every quorum of the batch
+	quorums := make(map[string]struct{})
+	for _, cert := range batch.BlobCertificates {
+		for _, quorum := range cert.BlobHeader.QuorumNumbers {
+			quorums[strconv.Itoa(int(quorum))] = struct{}{}
+		}
+	}
+	for _, stage := range []string{"validation", "signing", "total"} {
+		require.Equal(t, quorums, quorumLatencySamples(t, c.registry, stage))
+	}
 }
 
 func TestV2StoreChunksDownloadFailure(t *testing.T) {
@@ -267,6 +301,7 @@ func TestV2StoreChunksDownloadFailure(t *testing.T) {
 	})
 	require.Nil(t, reply.GetSignature())
 	requireErrorStatus(t, err, codes.Internal)
+	requireRejections(t, c.registry, "download", 1)
 }
 
 func TestV2StoreChunksStorageFailure(t *testing.T) {
@@ -315,6 +350,7 @@ func TestV2StoreChunksStorageFailure(t *testing.T) {
 	})
 	require.Nil(t, reply.GetSignature())
 	requireErrorStatusAndMsg(t, err, codes.Internal, "failed to store batch")
+	requireRejections(t, c.registry, "storage", 1)
 }
 
 func TestV2StoreChunksValidationFailure(t *testing.T) {
@@ -364,10 +400,367 @@ func TestV2StoreChunksValidationFailure(t *testing.T) {
 	})
 	require.Nil(t, reply.GetSignature())
 	requireErrorStatus(t, err, codes.Internal)
+	requireRejections(t, c.registry, "validation", 1)
 
 	c.store.AssertCalled(t, "DeleteKeys", mock.Anything, mock.Anything)
 }
 
+func TestV2StoreChunksQuotaExceeded(t *testing.T) {
+	config := makeConfig(t)
+	config.EnableV2 = true
+	c := newTestComponents(t, config)
+
+	// Quorum 1 has no room for the chunks of the batch
+	diskQuotas, err := node.NewQuorumQuotaTracker(map[core.QuorumID]uint64{1: 1}, 1, time.Hour, nil, c.node.Metrics)
+	require.NoError(t, err)
+	c.node.DiskQuotas = diskQuotas
+
+	_, batch, bundles := nodemock.MockBatch(t)
+	batchProto, err := batch.ToProtobuf()
+	require.NoError(t, err)
+
+	c.validator.On("ValidateBlobs", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	c.validator.On("ValidateBatchHeader", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	bundles00Bytes, err := bundles[0][0].Serialize()
+	require.NoError(t, err)
+	bundles01Bytes, err := bundles[0][1].Serialize()
+	require.NoError(t, err)
+	bundles10Bytes, err := bundles[1][0].Serialize()
+	require.NoError(t, err)
+	bundles11Bytes, err := bundles[1][1].Serialize()
+	require.NoError(t, err)
+	bundles21Bytes, err := bundles[2][1].Serialize()
+	require.NoError(t, err)
+	bundles22Bytes, err := bundles[2][2].Serialize()
+	require.NoError(t, err)
+	c.relayClient.On("GetChunksByRange", mock.Anything, v2.RelayKey(0), mock.Anything).Return([][]byte{bundles00Bytes, bundles01Bytes, bundles21Bytes, bundles22Bytes}, nil)
+	c.relayClient.On("GetChunksByRange", mock.Anything, v2.RelayKey(1), mock.Anything).Return([][]byte{bundles10Bytes, bundles11Bytes}, nil)
+	reply, err := c.server.StoreChunks(context.Background(), &validator.StoreChunksRequest{
+		DisperserID: 0,
+		Signature:   ecdsaSig,
+		Batch:       batchProto,
+	})
+	require.Nil(t, reply.GetSignature())
+	requireErrorStatus(t, err, codes.ResourceExhausted)
+	requireRejections(t, c.registry, "quota_exceeded", 1)
+	violations := api.QuotaViolations(err)
+	require.Len(t, violations, 1)
+	require.Equal(t, "quorum:1", violations[0].Subject)
+
+	c.store.AssertNotCalled(t, "StoreBatch", mock.Anything, mock.Anything)
+}
+
+func TestV2StoreChunksBlobSizeLimit(t *testing.T) {
+	config := makeConfig(t)
+	config.EnableV2 = true
+	// Quorum 1 only accepts blobs of up to 1 byte
+	config.MaxBlobSizes = map[core.QuorumID]uint64{1: 1}
+	c := newTestComponents(t, config)
+
+	_, batch, _ := nodemock.MockBatch(t)
+	batchProto, err := batch.ToProtobuf()
+	require.NoError(t, err)
+	reply, err := c.server.StoreChunks(context.Background(), &validator.StoreChunksRequest{
+		DisperserID: 0,
+		Signature:   ecdsaSig,
+		Batch:       batchProto,
+	})
+	require.Nil(t, reply.GetSignature())
+	requireErrorStatus(t, err, codes.InvalidArgument)
+	requireRejections(t, c.registry, "blob_size", 1)
+	violations := api.FieldViolations(err)
+	numViolations := 0
+	for i, cert := range batch.BlobCertificates {
+		for _, quorum := range cert.BlobHeader.QuorumNumbers {
+			if quorum == 1 {
+				require.Equal(t, fmt.Sprintf("batch.blob_certificates[%d].blob_header.commitment.length", i), violations[numViolations].Field)
+				numViolations++
+			}
+		}
+	}
+	require.Len(t, violations, numViolations)
+	require.Positive(t, numViolations)
+
+	// The chunks aren't downloaded
+	c.relayClient.AssertNotCalled(t, "GetChunksByRange", mock.Anything, mock.Anything, mock.Anything)
+	c.store.AssertNotCalled(t, "StoreBatch", mock.Anything, mock.Anything)
+}
+
+func TestV2StoreChunksOversizedBundle(t *testing.T) {
+	config := makeConfig(t)
+	config.EnableV2 = true
+	c := newTestComponents(t, config)
+
+	_, batch, bundles := nodemock.MockBatch(t)
+	// The size of the chunks follows from the length of the blob, which must be a power of 2
+	batch.BlobCertificates[2].BlobHeader.BlobCommitments.Length = 16
+	batchProto, err := batch.ToProtobuf()
+	require.NoError(t, err)
+
+	bundles00Bytes, err := bundles[0][0].Serialize()
+	require.NoError(t, err)
+	bundles01Bytes, err := bundles[0][1].Serialize()
+	require.NoError(t, err)
+	bundles10Bytes, err := bundles[1][0].Serialize()
+	require.NoError(t, err)
+	bundles11Bytes, err := bundles[1][1].Serialize()
+	require.NoError(t, err)
+	bundles21Bytes, err := bundles[2][1].Serialize()
+	require.NoError(t, err)
+	bundles22Bytes, err := bundles[2][2].Serialize()
+	require.NoError(t, err)
+	// The relay pads a bundle beyond the size of the chunks of the blob
+	bundles21Bytes = append(bundles21Bytes, make([]byte, 1<<20)...)
+	c.relayClient.On("GetChunksByRange", mock.Anything, v2.RelayKey(0), mock.Anything).Return([][]byte{bundles00Bytes, bundles01Bytes, bundles21Bytes, bundles22Bytes}, nil)
+	c.relayClient.On("GetChunksByRange", mock.Anything, v2.RelayKey(1), mock.Anything).Return([][]byte{bundles10Bytes, bundles11Bytes}, nil)
+	reply, err := c.server.StoreChunks(context.Background(), &validator.StoreChunksRequest{
+		DisperserID: 0,
+		Signature:   ecdsaSig,
+		Batch:       batchProto,
+	})
+	require.Nil(t, reply.GetSignature())
+	requireErrorStatus(t, err, codes.InvalidArgument)
+	requireRejections(t, c.registry, "blob_size", 1)
+	violations := api.FieldViolations(err)
+	require.Len(t, violations, 1)
+	require.Equal(t, "batch.blob_certificates[2]", violations[0].Field)
+	c.store.AssertNotCalled(t, "StoreBatch", mock.Anything, mock.Anything)
+}
+
+func TestV2StoreChunksExiting(t *testing.T) {
+	config := makeConfig(t)
+	config.EnableV2 = true
+	c := newTestComponents(t, config)
+
+	_, err := node.RequestExit(config.DbPath, time.Now())
+	require.NoError(t, err)
+	c.node.Exit, err = node.NewExitManager(config.DbPath, time.Hour, nil, c.node.Logger)
+	require.NoError(t, err)
+
+	_, batch, _ := nodemock.MockBatch(t)
+	batchProto, err := batch.ToProtobuf()
+	require.NoError(t, err)
+	reply, err := c.server.StoreChunks(context.Background(), &validator.StoreChunksRequest{
+		DisperserID: 0,
+		Signature:   ecdsaSig,
+		Batch:       batchProto,
+	})
+	require.Nil(t, reply.GetSignature())
+	requireErrorStatus(t, err, codes.Unavailable)
+	requireRejections(t, c.registry, "exiting", 1)
+	c.store.AssertNotCalled(t, "StoreBatch", mock.Anything, mock.Anything)
+}
+
+// failingChainState fails to read the operator state, like an unavailable RPC node.
+type failingChainState struct {
+	*coremock.ChainDataMock
+}
+
+func (failingChainState) GetOperatorStateByOperator(ctx context.Context, blockNumber uint, operator core.OperatorID) (*core.OperatorState, error) {
+	return nil, errors.New("rpc unavailable")
+}
+
+// failingSigner fails to sign, like an unreachable remote signer.
+type failingSigner struct {
+	blssigner.Signer
+}
+
+func (failingSigner) Sign(ctx context.Context, msg []byte) ([]byte, error) {
+	return nil, errors.New("signer unavailable")
+}
+
+// mockRelayBundles makes the relays serve the bundles of nodemock.MockBatch.
+func mockRelayBundles(t *testing.T, c *testComponents, bundles []map[core.QuorumID]core.Bundle) {
+	bundles00Bytes, err := bundles[0][0].Serialize()
+	require.NoError(t, err)
+	bundles01Bytes, err := bundles[0][1].Serialize()
+	require.NoError(t, err)
+	bundles10Bytes, err := bundles[1][0].Serialize()
+	require.NoError(t, err)
+	bundles11Bytes, err := bundles[1][1].Serialize()
+	require.NoError(t, err)
+	bundles21Bytes, err := bundles[2][1].Serialize()
+	require.NoError(t, err)
+	bundles22Bytes, err := bundles[2][2].Serialize()
+	require.NoError(t, err)
+	c.relayClient.On("GetChunksByRange", mock.Anything, v2.RelayKey(0), mock.Anything).Return([][]byte{bundles00Bytes, bundles01Bytes, bundles21Bytes, bundles22Bytes}, nil)
+	c.relayClient.On("GetChunksByRange", mock.Anything, v2.RelayKey(1), mock.Anything).Return([][]byte{bundles10Bytes, bundles11Bytes}, nil)
+}
+
+func TestV2StoreChunksOperatorStateFailure(t *testing.T) {
+	config := makeConfig(t)
+	config.EnableV2 = true
+	c := newTestComponents(t, config)
+	c.node.ChainState = failingChainState{ChainDataMock: chainState}
+
+	_, batch, _ := nodemock.MockBatch(t)
+	batchProto, err := batch.ToProtobuf()
+	require.NoError(t, err)
+	reply, err := c.server.StoreChunks(context.Background(), &validator.StoreChunksRequest{
+		DisperserID: 0,
+		Signature:   ecdsaSig,
+		Batch:       batchProto,
+	})
+	require.Nil(t, reply.GetSignature())
+	requireErrorStatusAndMsg(t, err, codes.Internal, "failed to get the operator state")
+	requireRejections(t, c.registry, "operator_state", 1)
+}
+
+func TestV2StoreChunksBandwidthLimited(t *testing.T) {
+	config := makeConfig(t)
+	config.EnableV2 = true
+	c := newTestComponents(t, config)
+	limiter, err := node.NewBandwidthLimiter("ingress", node.BandwidthLimitConfig{
+		PeerBytesPerSecond: 1,
+		PeerBurstiness:     1,
+	}, nil)
+	require.NoError(t, err)
+	c.node.IngressLimiter = limiter
+
+	ctx := peer.NewContext(context.Background(), &peer.Peer{
+		Addr: &net.TCPAddr{IP: net.ParseIP("1.2.3.4"), Port: 1234},
+	})
+	_, batch, _ := nodemock.MockBatch(t)
+	batchProto, err := batch.ToProtobuf()
+	require.NoError(t, err)
+	reply, err := c.server.StoreChunks(ctx, &validator.StoreChunksRequest{
+		DisperserID: 0,
+		Signature:   ecdsaSig,
+		Batch:       batchProto,
+	})
+	require.Nil(t, reply.GetSignature())
+	requireErrorStatus(t, err, codes.ResourceExhausted)
+	requireRejections(t, c.registry, "bandwidth_limited", 1)
+	c.relayClient.AssertNotCalled(t, "GetChunksByRange", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestV2StoreChunksAuthenticationFailure(t *testing.T) {
+	config := makeConfig(t)
+	config.EnableV2 = true
+	c := newTestComponents(t, config)
+	config.DisableDispersalAuthentication = false
+	config.DispersalAuthenticationKeyCacheSize = 10
+	config.DisperserKeyTimeout = time.Hour
+	config.DispersalAuthenticationTimeout = time.Minute
+
+	disperserAddress := gethcommon.HexToAddress("0x1234567890123456789012345678901234567890")
+	denylistFile := filepath.Join(t.TempDir(), "denylist")
+	require.NoError(t, os.WriteFile(denylistFile, []byte(disperserAddress.Hex()+"\n"), 0644))
+	filter, err := node.NewDisperserFilter(node.DisperserFilterConfig{
+		DenylistFile:   denylistFile,
+		ReloadInterval: time.Hour,
+	}, c.node.Logger)
+	require.NoError(t, err)
+	c.node.DisperserFilter = filter
+	reader := &coremock.MockWriter{}
+	reader.On("GetDisperserAddress", uint32(0)).Return(disperserAddress, nil)
+	registry := prometheus.NewRegistry()
+	server, err := grpc.NewServerV2(context.Background(), config, c.node, c.node.Logger, &commonmock.NoopRatelimiter{}, registry, reader)
+	require.NoError(t, err)
+
+	_, batch, _ := nodemock.MockBatch(t)
+	batchProto, err := batch.ToProtobuf()
+	require.NoError(t, err)
+	req := &validator.StoreChunksRequest{
+		DisperserID: 0,
+		Signature:   ecdsaSig,
+		Batch:       batchProto,
+	}
+
+	// The disperser can't be authenticated without the peer of the request
+	_, err = server.StoreChunks(context.Background(), req)
+	requireErrorStatus(t, err, codes.InvalidArgument)
+	requireRejections(t, registry, "authentication", 1)
+
+	ctx := peer.NewContext(context.Background(), &peer.Peer{
+		Addr: &net.TCPAddr{IP: net.ParseIP("1.2.3.4"), Port: 1234},
+	})
+	_, err = server.StoreChunks(ctx, req)
+	requireErrorStatus(t, err, codes.PermissionDenied)
+	requireRejections(t, registry, "disperser_not_allowed", 1)
+}
+
+func TestV2StoreChunksConflictingAttestation(t *testing.T) {
+	config := makeConfig(t)
+	config.EnableV2 = true
+	c := newTestComponents(t, config)
+	c.node.SigningJournal = node.NewSigningJournal(mapstore.NewStore(), time.Hour, nil, c.node.Logger)
+
+	blobKeys, batch, bundles := nodemock.MockBatch(t)
+	batchProto, err := batch.ToProtobuf()
+	require.NoError(t, err)
+	// Another batch with the same blobs and reference block was attested to already
+	otherBatchHeaderHash := [32]byte{1}
+	keys := make([][32]byte, len(blobKeys))
+	for i, blobKey := range blobKeys {
+		keys[i] = blobKey
+	}
+	require.NoError(t, c.node.RecordAttestation(otherBatchHeaderHash, uint64(batch.BatchHeader.ReferenceBlockNumber), keys))
+
+	c.validator.On("ValidateBlobs", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	c.validator.On("ValidateBatchHeader", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mockRelayBundles(t, c, bundles)
+	c.store.On("StoreBatch", batch, mock.Anything).Return(nil, nil)
+	c.store.On("CommitBatch", mock.Anything).Return(nil)
+	reply, err := c.server.StoreChunks(context.Background(), &validator.StoreChunksRequest{
+		DisperserID: 0,
+		Signature:   ecdsaSig,
+		Batch:       batchProto,
+	})
+	require.Nil(t, reply.GetSignature())
+	requireErrorStatus(t, err, codes.InvalidArgument)
+	requireRejections(t, c.registry, "conflicting_attestation", 1)
+}
+
+func TestV2StoreChunksSigningFailure(t *testing.T) {
+	config := makeConfig(t)
+	config.EnableV2 = true
+	c := newTestComponents(t, config)
+	c.node.BLSSigner = failingSigner{Signer: c.node.BLSSigner}
+
+	_, batch, bundles := nodemock.MockBatch(t)
+	batchProto, err := batch.ToProtobuf()
+	require.NoError(t, err)
+
+	c.validator.On("ValidateBlobs", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	c.validator.On("ValidateBatchHeader", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mockRelayBundles(t, c, bundles)
+	c.store.On("StoreBatch", batch, mock.Anything).Return(nil, nil)
+	c.store.On("CommitBatch", mock.Anything).Return(nil)
+	reply, err := c.server.StoreChunks(context.Background(), &validator.StoreChunksRequest{
+		DisperserID: 0,
+		Signature:   ecdsaSig,
+		Batch:       batchProto,
+	})
+	require.Nil(t, reply.GetSignature())
+	requireErrorStatusAndMsg(t, err, codes.Internal, "failed to sign batch")
+	requireRejections(t, c.registry, "signing", 1)
+}
+
+func TestV2StoreChunksDeadlineExceeded(t *testing.T) {
+	config := makeConfig(t)
+	config.EnableV2 = true
+	config.MaxBlobSizes = map[core.QuorumID]uint64{1: 1}
+	c := newTestComponents(t, config)
+
+	_, batch, _ := nodemock.MockBatch(t)
+	batchProto, err := batch.ToProtobuf()
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	reply, err := c.server.StoreChunks(ctx, &validator.StoreChunksRequest{
+		DisperserID: 0,
+		Signature:   ecdsaSig,
+		Batch:       batchProto,
+	})
+	require.Nil(t, reply.GetSignature())
+	require.Error(t, err)
+	// Requests failing after the disperser gave up are counted apart from the reason they failed for
+	requireRejections(t, c.registry, "deadline_exceeded", 1)
+	requireRejections(t, c.registry, "blob_size", 0)
+}
+
 func TestV2GetChunksInputValidation(t *testing.T) {
 	config := makeConfig(t)
 	config.EnableV2 = true
@@ -389,6 +782,263 @@ func TestV2GetChunksInputValidation(t *testing.T) {
 	requireErrorStatus(t, err, codes.InvalidArgument)
 }
 
+func TestV2GetChunksBandwidthLimited(t *testing.T) {
+	config := makeConfig(t)
+	config.EnableV2 = true
+	c := newTestComponents(t, config)
+	limiter, err := node.NewBandwidthLimiter("egress", node.BandwidthLimitConfig{
+		PeerBytesPerSecond: 100,
+		PeerBurstiness:     150,
+	}, nil)
+	require.NoError(t, err)
+	c.node.EgressLimiter = limiter
+
+	ctx := peer.NewContext(context.Background(), &peer.Peer{
+		Addr: &net.TCPAddr{IP: net.ParseIP("1.2.3.4"), Port: 1234},
+	})
+	bk := [32]byte{0}
+	chunks := [][]byte{make([]byte, 50), make([]byte, 50)}
+	c.store.On("GetChunks", v2.BlobKey(bk), core.QuorumID(0)).Return(chunks, nil)
+
+	req := &validator.GetChunksRequest{
+		BlobKey:  bk[:],
+		QuorumId: 0,
+	}
+	reply, err := c.server.GetChunks(ctx, req)
+	require.NoError(t, err)
+	require.Equal(t, chunks, reply.GetChunks())
+
+	_, err = c.server.GetChunks(ctx, req)
+	requireErrorStatus(t, err, codes.ResourceExhausted)
+	retryAfter, ok := api.RetryAfter(err)
+	require.True(t, ok)
+	require.Greater(t, retryAfter, time.Duration(0))
+}
+
+func TestV2GetChunksRetrievalLimited(t *testing.T) {
+	config := makeConfig(t)
+	config.EnableV2 = true
+	c := newTestComponents(t, config)
+	limiter, err := node.NewRetrievalLimiter(node.RetrievalLimitConfig{PeerRequestsPerSecond: 1}, nil)
+	require.NoError(t, err)
+	c.node.RetrievalLimiter = limiter
+
+	ctx := peer.NewContext(context.Background(), &peer.Peer{
+		Addr: &net.TCPAddr{IP: net.ParseIP("1.2.3.4"), Port: 1234},
+	})
+	otherCtx := peer.NewContext(context.Background(), &peer.Peer{
+		Addr: &net.TCPAddr{IP: net.ParseIP("5.6.7.8"), Port: 1234},
+	})
+	bk := [32]byte{0}
+	c.store.On("GetChunks", v2.BlobKey(bk), core.QuorumID(0)).Return([][]byte{make([]byte, 10)}, nil)
+	req := &validator.GetChunksRequest{
+		BlobKey:  bk[:],
+		QuorumId: 0,
+	}
+	_, err = c.server.GetChunks(ctx, req)
+	require.NoError(t, err)
+
+	// The retriever is limited, but not the others
+	_, err = c.server.GetChunks(ctx, req)
+	requireErrorStatus(t, err, codes.ResourceExhausted)
+	retryAfter, ok := api.RetryAfter(err)
+	require.True(t, ok)
+	require.Greater(t, retryAfter, time.Duration(0))
+	_, err = c.server.GetChunks(otherCtx, req)
+	require.NoError(t, err)
+}
+
+func TestV2GetChunksReadAhead(t *testing.T) {
+	config := makeConfig(t)
+	config.EnableV2 = true
+	config.RetrievalCacheSize = 150
+	c := newTestComponents(t, config)
+
+	bk := [32]byte{1}
+	blobChunks := map[core.QuorumID][][]byte{
+		0: {make([]byte, 50), make([]byte, 50)},
+		1: {make([]byte, 50)},
+	}
+	c.store.On("GetBlobChunks", v2.BlobKey(bk)).Return(blobChunks, nil)
+	coldChunks := [][]byte{make([]byte, 10)}
+	c.store.On("GetChunks", v2.BlobKey(bk), core.QuorumID(2)).Return(coldChunks, nil)
+
+	// The first request reads ahead the chunks of all the quorums of the blob
+	for _, quorum := range []core.QuorumID{0, 1} {
+		reply, err := c.server.GetChunks(context.Background(), &validator.GetChunksRequest{
+			BlobKey:  bk[:],
+			QuorumId: uint32(quorum),
+		})
+		require.NoError(t, err)
+		require.Equal(t, blobChunks[quorum], reply.GetChunks())
+	}
+	c.store.AssertNumberOfCalls(t, "GetBlobChunks", 1)
+
+	// Bundles which aren't read ahead are fetched individually, and cached too
+	for i := 0; i < 2; i++ {
+		reply, err := c.server.GetChunks(context.Background(), &validator.GetChunksRequest{
+			BlobKey:  bk[:],
+			QuorumId: 2,
+		})
+		require.NoError(t, err)
+		require.Equal(t, coldChunks, reply.GetChunks())
+	}
+	c.store.AssertNumberOfCalls(t, "GetBlobChunks", 2)
+	c.store.AssertNumberOfCalls(t, "GetChunks", 1)
+
+	// The cache is bounded by size, so caching the chunks of quorum 2 evicted the least recently used chunks of
+	// quorum 0, which are read ahead again
+	otherBk := [32]byte{2}
+	c.store.On("GetBlobChunks", v2.BlobKey(otherBk)).Return(map[core.QuorumID][][]byte{
+		0: {make([]byte, 100)},
+	}, nil)
+	_, err := c.server.GetChunks(context.Background(), &validator.GetChunksRequest{
+		BlobKey:  otherBk[:],
+		QuorumId: 0,
+	})
+	require.NoError(t, err)
+	_, err = c.server.GetChunks(context.Background(), &validator.GetChunksRequest{
+		BlobKey:  bk[:],
+		QuorumId: 0,
+	})
+	require.NoError(t, err)
+	c.store.AssertNumberOfCalls(t, "GetBlobChunks", 4)
+}
+
+func TestV2AuditChunks(t *testing.T) {
+	config := makeConfig(t)
+	config.EnableV2 = true
+	config.ChunkAuditBlobs = 2
+	c := newTestComponents(t, config)
+	ctx := context.Background()
+
+	// The auditor isn't initialized
+	_, err := c.server.AuditChunks(ctx, &validator.AuditChunksRequest{})
+	requireErrorStatus(t, err, codes.Internal)
+
+	verifier := &encmock.MockEncoder{}
+	c.node.ChunkAuditor = node.NewChunkAuditor(c.store, verifier, nil, c.node.Logger)
+
+	blobKeys, batch, bundles := nodemock.MockBatch(t)
+	chunks := make([][]byte, len(bundles[0][0]))
+	for i, frame := range bundles[0][0] {
+		chunks[i], err = frame.SerializeGnark()
+		require.NoError(t, err)
+	}
+	params := encoding.EncodingParams{NumChunks: 8, ChunkLength: 2}
+	storedBlob := &node.StoredBlob{
+		BlobCertificate: batch.BlobCertificates[0],
+		EncodingParams:  params,
+		Assignments: map[core.QuorumID]v2.Assignment{
+			0: {StartIndex: 0, NumChunks: uint32(len(chunks))},
+		},
+	}
+	// The number of sampled blobs is capped by the config
+	c.store.On("SampleBlobs", 2).Return([]*node.StoredBlob{storedBlob}, nil)
+	c.store.On("GetChunks", blobKeys[0], core.QuorumID(0)).Return(chunks, nil)
+	verifier.On("UniversalVerifySubBatch", params, mock.Anything, 1).Return(errors.New("invalid proof"))
+
+	reply, err := c.server.AuditChunks(ctx, &validator.AuditChunksRequest{NumBlobs: 100})
+	require.NoError(t, err)
+	require.Equal(t, uint32(1), reply.GetNumBundles())
+	require.Len(t, reply.GetCorruptBundles(), 1)
+	require.Equal(t, blobKeys[0][:], reply.GetCorruptBundles()[0].GetBlobKey())
+	require.Equal(t, uint32(0), reply.GetCorruptBundles()[0].GetQuorumId())
+	require.Contains(t, reply.GetCorruptBundles()[0].GetReason(), "invalid proof")
+}
+
+func TestV2GetChunkProof(t *testing.T) {
+	config := makeConfig(t)
+	config.EnableV2 = true
+	c := newTestComponents(t, config)
+	ctx := context.Background()
+
+	_, err := c.server.GetChunkProof(ctx, &validator.GetChunkProofRequest{BlobKey: []byte{0}})
+	requireErrorStatus(t, err, codes.InvalidArgument)
+
+	blobKeys, batch, bundles := nodemock.MockBatch(t)
+	chunks := make([][]byte, len(bundles[0][1]))
+	for i, frame := range bundles[0][1] {
+		chunks[i], err = frame.SerializeGnark()
+		require.NoError(t, err)
+	}
+	storedBlob := &node.StoredBlob{
+		BlobCertificate: batch.BlobCertificates[0],
+		Assignments: map[core.QuorumID]v2.Assignment{
+			1: {StartIndex: 2, NumChunks: uint32(len(chunks))},
+		},
+		Inclusion: &v2.BlobInclusionInfo{
+			BatchHeader:    batch.BatchHeader,
+			BlobKey:        blobKeys[0],
+			BlobIndex:      0,
+			InclusionProof: []byte{1, 2, 3},
+		},
+	}
+	c.store.On("GetStoredBlob", blobKeys[0]).Return(storedBlob, nil)
+	c.store.On("GetStoredBlob", blobKeys[1]).Return(nil, kvstore.ErrNotFound)
+	c.store.On("GetChunks", blobKeys[0], core.QuorumID(1)).Return(chunks, nil)
+
+	reply, err := c.server.GetChunkProof(ctx, &validator.GetChunkProofRequest{BlobKey: blobKeys[0][:], ChunkIndex: 3})
+	require.NoError(t, err)
+	require.Equal(t, chunks[1], reply.GetChunk())
+	require.Equal(t, chunks[1][:len(reply.GetKzgProof())], reply.GetKzgProof())
+	require.Equal(t, uint32(1), reply.GetQuorumId())
+	require.Equal(t, uint32(0), reply.GetBlobIndex())
+	require.Equal(t, []byte{1, 2, 3}, reply.GetInclusionProof())
+	require.Equal(t, batch.BatchHeader.ReferenceBlockNumber, reply.GetBatchHeader().GetReferenceBlockNumber())
+	require.NotNil(t, reply.GetBlobCertificate())
+
+	// The chunk isn't assigned to the operator
+	_, err = c.server.GetChunkProof(ctx, &validator.GetChunkProofRequest{BlobKey: blobKeys[0][:], ChunkIndex: 1})
+	requireErrorStatus(t, err, codes.NotFound)
+
+	// The blob isn't stored
+	_, err = c.server.GetChunkProof(ctx, &validator.GetChunkProofRequest{BlobKey: blobKeys[1][:], ChunkIndex: 0})
+	requireErrorStatus(t, err, codes.NotFound)
+}
+
+// requireRejections checks the number of StoreChunks requests rejected for the given reason.
+func requireRejections(t *testing.T, registry *prometheus.Registry, reason string, expected int) {
+	families, err := registry.Gather()
+	require.NoError(t, err)
+	count := 0.0
+	for _, family := range families {
+		if family.GetName() != "eigenda_node_store_chunks_rejections_total" {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			for _, label := range metric.GetLabel() {
+				if label.GetName() == "reason" && label.GetValue() == reason {
+					count = metric.GetCounter().GetValue()
+				}
+			}
+		}
+	}
+	require.Equal(t, float64(expected), count, "rejections for reason %s", reason)
+}
+
+// quorumLatencySamples returns the quorums with a StoreChunks latency sample for the stage.
+func quorumLatencySamples(t *testing.T, registry *prometheus.Registry, stage string) map[string]struct{} {
+	families, err := registry.Gather()
+	require.NoError(t, err)
+	quorums := make(map[string]struct{})
+	for _, family := range families {
+		if family.GetName() != "eigenda_node_store_chunks_quorum_latency_ms" {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			labels := make(map[string]string)
+			for _, label := range metric.GetLabel() {
+				labels[label.GetName()] = label.GetValue()
+			}
+			if labels["stage"] == stage && metric.GetHistogram().GetSampleCount() == 1 {
+				quorums[labels["quorum"]] = struct{}{}
+			}
+		}
+	}
+	return quorums
+}
+
 func requireErrorStatus(t *testing.T, err error, code codes.Code) {
 	require.Error(t, err)
 	s, ok := status.FromError(err)
@@ -0,0 +1,432 @@
+package node
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common/geth"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/core/eth"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/node/signer"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	blssigner "github.com/Layr-Labs/eigensdk-go/signer/bls"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// KeyRotationStateFileName is the name of the file, in the node's db directory, which records the progress of the
+// rotation of the operator keys.
+const KeyRotationStateFileName = "key_rotation.json"
+
+// KeyRotationState is the progress of the rotation of the operator keys.
+type KeyRotationState struct {
+	// StartedAt is the time the rotation was requested.
+	StartedAt time.Time `json:"startedAt"`
+	// NewOperatorID is the ID, in hex, of the operator with the new keys. Empty until the node picks up the rotation.
+	NewOperatorID string `json:"newOperatorId,omitempty"`
+	// RegisteredAtBlock is a block at which the operator with the new keys is registered in the quorums of the node.
+	// Zero until then.
+	RegisteredAtBlock uint32 `json:"registeredAtBlock,omitempty"`
+	// RetiredAt is the time the operator with the old keys was deregistered from its quorums. Zero until then.
+	RetiredAt time.Time `json:"retiredAt,omitempty"`
+}
+
+// Registered returns true if the operator with the new keys is registered in the quorums of the node.
+func (s *KeyRotationState) Registered() bool {
+	return s.RegisteredAtBlock > 0
+}
+
+// Retired returns true if the operator with the old keys has been deregistered, which completes the rotation.
+func (s *KeyRotationState) Retired() bool {
+	return !s.RetiredAt.IsZero()
+}
+
+// ReadKeyRotationState reads the key rotation state from the db directory of the node. It returns nil if no rotation
+// was requested.
+func ReadKeyRotationState(dbPath string) (*KeyRotationState, error) {
+	data, err := os.ReadFile(filepath.Join(dbPath, KeyRotationStateFileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key rotation state: %w", err)
+	}
+	state := &KeyRotationState{}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("failed to parse key rotation state: %w", err)
+	}
+	return state, nil
+}
+
+// writeKeyRotationState atomically replaces the key rotation state in the db directory of the node.
+func writeKeyRotationState(dbPath string, state *KeyRotationState) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("failed to encode key rotation state: %w", err)
+	}
+	path := filepath.Join(dbPath, KeyRotationStateFileName)
+	if err := os.WriteFile(path+".tmp", data, 0644); err != nil {
+		return fmt.Errorf("failed to write key rotation state: %w", err)
+	}
+	if err := os.Rename(path+".tmp", path); err != nil {
+		return fmt.Errorf("failed to write key rotation state: %w", err)
+	}
+	return nil
+}
+
+// RequestKeyRotation records that the operator rotates to its next keys, in the db directory of the node, which the
+// running node picks up. If a rotation is already in progress, its state is returned unchanged.
+func RequestKeyRotation(dbPath string, now time.Time) (*KeyRotationState, error) {
+	state, err := ReadKeyRotationState(dbPath)
+	if err != nil {
+		return nil, err
+	}
+	if state != nil && !state.Retired() {
+		return state, nil
+	}
+	state = &KeyRotationState{StartedAt: now}
+	if err := writeKeyRotationState(dbPath, state); err != nil {
+		return nil, err
+	}
+	return state, nil
+}
+
+// OperatorIdentity is an operator the node attests as: its ID, and the signer holding its BLS key.
+type OperatorIdentity struct {
+	ID     core.OperatorID
+	Signer blssigner.Signer
+}
+
+// OperatorKeyRotator performs the on-chain steps of a key rotation.
+type OperatorKeyRotator interface {
+	// RegisterNewKeys registers the operator with the new keys in the quorums of the node. It's a no-op for the
+	// quorums the operator is already registered in.
+	RegisterNewKeys(ctx context.Context) error
+	// DeregisterOldKeys deregisters the operator with the old keys from the quorums of the node.
+	DeregisterOldKeys(ctx context.Context) error
+	GetCurrentBlockNumber(ctx context.Context) (uint32, error)
+}
+
+// KeyRotationManager moves the operator to new keys without downtime. The keys of an operator can't be replaced in
+// place, since the operator ID is the hash of the BLS public key, and the operator address is the ECDSA key. Instead,
+// an operator with the new keys is registered in the same quorums, with the same socket, while the operator with the
+// old keys stays registered. Batches referencing a block before the registration are attested to with the old keys,
+// and later ones with the new keys. Once no batch can reference a block before the registration anymore, the operator
+// with the old keys is deregistered.
+//
+// While both operators are registered, dispersers send the node a request for each of them, and say which operator
+// each request is for in the api.OperatorIDHeader, so that the node attests to each as the operator it's for, with
+// either keys. Requests of dispersers which don't say are attested to as the operator of their reference block.
+type KeyRotationManager struct {
+	dbPath      string
+	oldIdentity OperatorIdentity
+	newIdentity OperatorIdentity
+	// transitionBlocks is how many blocks after the registration of the new keys batches may still reference a block
+	// before it
+	transitionBlocks uint32
+	rotator          OperatorKeyRotator
+	logger           logging.Logger
+
+	// cycleMu serializes rotation cycles, which hold it across chain calls
+	cycleMu sync.Mutex
+	// mu guards state. It's never held across chain calls, since attesting to batches takes it.
+	mu    sync.Mutex
+	state *KeyRotationState
+}
+
+// NewKeyRotationManager creates a new KeyRotationManager, resuming the rotation recorded in the db directory of the
+// node if any. It returns an error if a rotation to other keys than newIdentity is in progress.
+func NewKeyRotationManager(
+	dbPath string,
+	oldIdentity OperatorIdentity,
+	newIdentity OperatorIdentity,
+	transitionBlocks uint32,
+	rotator OperatorKeyRotator,
+	logger logging.Logger,
+) (*KeyRotationManager, error) {
+	m := &KeyRotationManager{
+		dbPath:           dbPath,
+		oldIdentity:      oldIdentity,
+		newIdentity:      newIdentity,
+		transitionBlocks: transitionBlocks,
+		rotator:          rotator,
+		logger:           logger.With("component", "KeyRotationManager"),
+	}
+	state, err := ReadKeyRotationState(dbPath)
+	if err != nil {
+		return nil, err
+	}
+	m.state, err = m.adopt(state)
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// adopt returns the rotation to the new keys of the manager recorded in state, or nil if state records an earlier,
+// completed rotation to other keys.
+func (m *KeyRotationManager) adopt(state *KeyRotationState) (*KeyRotationState, error) {
+	if state == nil {
+		return nil, nil
+	}
+	newOperatorID := m.newIdentity.ID.Hex()
+	switch {
+	case state.NewOperatorID == newOperatorID:
+		return state, nil
+	case state.NewOperatorID == "":
+		adopted := *state
+		adopted.NewOperatorID = newOperatorID
+		if err := writeKeyRotationState(m.dbPath, &adopted); err != nil {
+			return nil, err
+		}
+		return &adopted, nil
+	case state.Retired():
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("a key rotation to operator %s is in progress, but the next keys are of operator %s",
+			state.NewOperatorID, newOperatorID)
+	}
+}
+
+// State returns the progress of the rotation, or nil if the keys aren't being rotated.
+func (m *KeyRotationManager) State() *KeyRotationState {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.state == nil {
+		return nil
+	}
+	state := *m.state
+	return &state
+}
+
+// IdentityAt returns the operator the node attests as to batches with the given reference block.
+func (m *KeyRotationManager) IdentityAt(referenceBlockNumber uint64) OperatorIdentity {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.state != nil && m.state.Registered() && referenceBlockNumber >= uint64(m.state.RegisteredAtBlock) {
+		return m.newIdentity
+	}
+	return m.oldIdentity
+}
+
+// IdentityFor returns the operator the node attests as to a request for the given operator with the given reference
+// block. Once the rotation is picked up, both operators are valid, since the node is registered as both from the
+// registration of the new keys until the old keys are deregistered. Requests for other operators are attested to as
+// IdentityAt.
+func (m *KeyRotationManager) IdentityFor(operatorID core.OperatorID, referenceBlockNumber uint64) OperatorIdentity {
+	m.mu.Lock()
+	rotating := m.state != nil
+	m.mu.Unlock()
+	switch {
+	case operatorID == m.oldIdentity.ID:
+		return m.oldIdentity
+	case rotating && operatorID == m.newIdentity.ID:
+		return m.newIdentity
+	}
+	return m.IdentityAt(referenceBlockNumber)
+}
+
+// setState replaces the progress of the rotation.
+func (m *KeyRotationManager) setState(state KeyRotationState) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.state = &state
+}
+
+// Start checks for a requested rotation and advances it once per interval in the background, until the context is
+// cancelled.
+func (m *KeyRotationManager) Start(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := m.RunRotationCycle(ctx, time.Now()); err != nil {
+					m.logger.Error("Failed to advance the key rotation", "err", err)
+				}
+			}
+		}
+	}()
+}
+
+// RunRotationCycle picks up a newly requested rotation, registers the operator with the new keys, and deregisters the
+// operator with the old keys once the transition has elapsed since the registration.
+func (m *KeyRotationManager) RunRotationCycle(ctx context.Context, now time.Time) error {
+	m.cycleMu.Lock()
+	defer m.cycleMu.Unlock()
+
+	state := m.State()
+	if state == nil {
+		requested, err := ReadKeyRotationState(m.dbPath)
+		if err != nil {
+			return err
+		}
+		state, err = m.adopt(requested)
+		if err != nil || state == nil {
+			return err
+		}
+		m.logger.Info("Key rotation requested", "oldOperatorId", m.oldIdentity.ID.Hex(), "newOperatorId", state.NewOperatorID)
+		m.setState(*state)
+	}
+	if state.Retired() {
+		return nil
+	}
+
+	if !state.Registered() {
+		m.logger.Info("Registering the operator with the new keys", "newOperatorId", state.NewOperatorID)
+		if err := m.rotator.RegisterNewKeys(ctx); err != nil {
+			return fmt.Errorf("failed to register the operator with the new keys: %w", err)
+		}
+		blockNumber, err := m.rotator.GetCurrentBlockNumber(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get current block number: %w", err)
+		}
+		state.RegisteredAtBlock = blockNumber
+		if err := writeKeyRotationState(m.dbPath, state); err != nil {
+			return err
+		}
+		m.setState(*state)
+		m.logger.Info("Operator with the new keys registered, attesting with the new keys from the registration block",
+			"registeredAtBlock", blockNumber, "retireAtBlock", blockNumber+m.transitionBlocks)
+		return nil
+	}
+
+	blockNumber, err := m.rotator.GetCurrentBlockNumber(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get current block number: %w", err)
+	}
+	if blockNumber < state.RegisteredAtBlock+m.transitionBlocks {
+		return nil
+	}
+
+	m.logger.Info("Transition elapsed, deregistering the operator with the old keys", "oldOperatorId", m.oldIdentity.ID.Hex())
+	if err := m.rotator.DeregisterOldKeys(ctx); err != nil {
+		return fmt.Errorf("failed to deregister the operator with the old keys: %w", err)
+	}
+	state.RetiredAt = now
+	if err := writeKeyRotationState(m.dbPath, state); err != nil {
+		return err
+	}
+	m.setState(*state)
+	m.logger.Info("Key rotation completed, the node must be configured with the new keys before it's restarted",
+		"newOperatorId", state.NewOperatorID)
+	return nil
+}
+
+// chainKeyRotator registers and deregisters the operator keys of a node on chain.
+type chainKeyRotator struct {
+	node          *Node
+	newIdentity   OperatorIdentity
+	newPrivateKey *ecdsa.PrivateKey
+	// newTransactor sends transactions from the address of the new ECDSA key
+	newTransactor core.Writer
+	// newValidator and newValidatorV2 validate the chunks assigned to the operator with the new keys
+	newValidator   core.ShardValidator
+	newValidatorV2 corev2.ShardValidator
+}
+
+var _ OperatorKeyRotator = (*chainKeyRotator)(nil)
+
+// newChainKeyRotator creates the signer and the transactor of the next keys in the config of the node.
+func newChainKeyRotator(n *Node, logger logging.Logger) (*chainKeyRotator, error) {
+	newSigner, err := signer.NewSigner(context.Background(), signer.Config{
+		Signer:  *n.Config.NextBlsSignerConfig,
+		Timeout: n.Config.BlsSignerTimeout,
+	}, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create next BLS signer: %w", err)
+	}
+	operatorID, err := newSigner.GetOperatorId()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get next operator ID: %w", err)
+	}
+	newOperatorID, err := core.OperatorIDFromHex(operatorID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert next operator ID: %w", err)
+	}
+	if newOperatorID == n.Config.ID {
+		return nil, errors.New("the next BLS key is the current BLS key")
+	}
+
+	newPrivateKey, err := crypto.HexToECDSA(n.Config.NextEcdsaPrivateKeyString)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse next private key: %w", err)
+	}
+	ethClientConfig := n.Config.EthClientConfig
+	ethClientConfig.PrivateKeyString = n.Config.NextEcdsaPrivateKeyString
+	newClient, err := geth.NewClient(ethClientConfig, gethcommon.Address{}, 0, logger)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create chain client of the next keys: %w", err)
+	}
+	newTransactor, err := eth.NewWriter(logger, newClient, n.Config.BLSOperatorStateRetrieverAddr, n.Config.EigenDAServiceManagerAddr)
+	if err != nil {
+		return nil, err
+	}
+	return &chainKeyRotator{
+		node:          n,
+		newIdentity:   OperatorIdentity{ID: newOperatorID, Signer: newSigner},
+		newPrivateKey: newPrivateKey,
+		newTransactor: newTransactor,
+	}, nil
+}
+
+func (r *chainKeyRotator) RegisterNewKeys(ctx context.Context) error {
+	if r.node.Exiting() {
+		return errors.New("the operator is exiting")
+	}
+	// The operator with the new keys takes over the socket the node is registered with
+	socket, err := r.node.Transactor.GetOperatorSocket(ctx, r.node.Config.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get operator socket: %w", err)
+	}
+	operator := &Operator{
+		Address:             crypto.PubkeyToAddress(r.newPrivateKey.PublicKey).Hex(),
+		Socket:              socket,
+		Timeout:             10 * time.Second,
+		PrivKey:             r.newPrivateKey,
+		Signer:              r.newIdentity.Signer,
+		OperatorId:          r.newIdentity.ID,
+		QuorumIDs:           r.node.Config.QuorumIDList,
+		RegisterNodeAtStart: true,
+	}
+	churnerClient := NewChurnerClient(r.node.Config.ChurnerUrl, r.node.Config.UseSecureGrpc, r.node.Config.Timeout, r.node.Logger)
+	return RegisterOperator(ctx, operator, r.newTransactor, churnerClient, r.node.Logger)
+}
+
+func (r *chainKeyRotator) DeregisterOldKeys(ctx context.Context) error {
+	return deregisterIdentity(ctx, OperatorIdentity{ID: r.node.Config.ID, Signer: r.node.BLSSigner}, r.node.Config.QuorumIDList, r.node.Transactor)
+}
+
+// deregisterNewKeys deregisters the operator with the new keys, when the operator exits during or after a rotation.
+func (r *chainKeyRotator) deregisterNewKeys(ctx context.Context) error {
+	return deregisterIdentity(ctx, r.newIdentity, r.node.Config.QuorumIDList, r.newTransactor)
+}
+
+func (r *chainKeyRotator) GetCurrentBlockNumber(ctx context.Context) (uint32, error) {
+	return r.node.Transactor.GetCurrentBlockNumber(ctx)
+}
+
+// deregisterIdentity deregisters the given operator from the given quorums, with a transactor sending transactions
+// from its address.
+func deregisterIdentity(ctx context.Context, identity OperatorIdentity, quorumIDs []core.QuorumID, transactor core.Writer) error {
+	pubKeyG1, _, err := getG1G2Fromblssigner(identity.Signer)
+	if err != nil {
+		return fmt.Errorf("failed to get the operator public key: %w", err)
+	}
+	operator := &Operator{
+		OperatorId: identity.ID,
+		QuorumIDs:  quorumIDs,
+	}
+	return DeregisterOperator(ctx, operator, pubKeyG1, transactor)
+}
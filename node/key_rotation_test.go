@@ -0,0 +1,188 @@
+package node_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common/testutils"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/node"
+	"github.com/stretchr/testify/require"
+)
+
+// mockKeyRotator records the on-chain steps of a key rotation.
+type mockKeyRotator struct {
+	blockNumber   uint32
+	registrations int
+	retirements   int
+	err           error
+}
+
+func (r *mockKeyRotator) RegisterNewKeys(ctx context.Context) error {
+	r.registrations++
+	return r.err
+}
+
+func (r *mockKeyRotator) DeregisterOldKeys(ctx context.Context) error {
+	r.retirements++
+	return r.err
+}
+
+func (r *mockKeyRotator) GetCurrentBlockNumber(ctx context.Context) (uint32, error) {
+	return r.blockNumber, nil
+}
+
+func TestKeyRotationManager(t *testing.T) {
+	ctx := context.Background()
+	dbPath := t.TempDir()
+	oldIdentity := node.OperatorIdentity{ID: core.OperatorID{1}}
+	newIdentity := node.OperatorIdentity{ID: core.OperatorID{2}}
+	rotator := &mockKeyRotator{blockNumber: 100}
+	m, err := node.NewKeyRotationManager(dbPath, oldIdentity, newIdentity, 10, rotator, testutils.GetLogger())
+	require.NoError(t, err)
+
+	// Nothing happens until a rotation is requested
+	require.NoError(t, m.RunRotationCycle(ctx, time.Now()))
+	require.Nil(t, m.State())
+	require.Equal(t, oldIdentity.ID, m.IdentityAt(100).ID)
+	require.Equal(t, 0, rotator.registrations)
+
+	start := time.Now()
+	state, err := node.RequestKeyRotation(dbPath, start)
+	require.NoError(t, err)
+	// Requesting a rotation again doesn't restart it
+	again, err := node.RequestKeyRotation(dbPath, start.Add(time.Minute))
+	require.NoError(t, err)
+	require.True(t, state.StartedAt.Equal(again.StartedAt))
+
+	// Failed registrations are retried, and the old keys are used meanwhile
+	rotator.err = errors.New("transaction failed")
+	require.Error(t, m.RunRotationCycle(ctx, start))
+	require.False(t, m.State().Registered())
+	require.Equal(t, oldIdentity.ID, m.IdentityAt(100).ID)
+	rotator.err = nil
+
+	// Once the new keys are registered, batches referencing a later block are attested to with them, while the old
+	// keys stay registered
+	require.NoError(t, m.RunRotationCycle(ctx, start))
+	require.Equal(t, newIdentity.ID.Hex(), m.State().NewOperatorID)
+	require.Equal(t, uint32(100), m.State().RegisteredAtBlock)
+	require.Equal(t, oldIdentity.ID, m.IdentityAt(99).ID)
+	require.Equal(t, newIdentity.ID, m.IdentityAt(100).ID)
+	require.Equal(t, newIdentity.ID, m.IdentityAt(101).ID)
+	require.Equal(t, 2, rotator.registrations)
+
+	// The old keys are deregistered once batches can't reference a block before the registration anymore
+	rotator.blockNumber = 109
+	require.NoError(t, m.RunRotationCycle(ctx, start))
+	require.Equal(t, 0, rotator.retirements)
+	require.False(t, m.State().Retired())
+	rotator.blockNumber = 110
+	require.NoError(t, m.RunRotationCycle(ctx, start.Add(time.Hour)))
+	require.Equal(t, 1, rotator.retirements)
+	require.True(t, m.State().Retired())
+	require.NoError(t, m.RunRotationCycle(ctx, start.Add(2*time.Hour)))
+	require.Equal(t, 2, rotator.registrations)
+	require.Equal(t, 1, rotator.retirements)
+
+	// The rotation resumes after a restart
+	m, err = node.NewKeyRotationManager(dbPath, oldIdentity, newIdentity, 10, rotator, testutils.GetLogger())
+	require.NoError(t, err)
+	require.True(t, m.State().Retired())
+	require.Equal(t, newIdentity.ID, m.IdentityAt(110).ID)
+
+	// A completed rotation doesn't affect the next one
+	nextIdentity := node.OperatorIdentity{ID: core.OperatorID{3}}
+	m, err = node.NewKeyRotationManager(dbPath, newIdentity, nextIdentity, 10, rotator, testutils.GetLogger())
+	require.NoError(t, err)
+	require.Nil(t, m.State())
+	require.Equal(t, newIdentity.ID, m.IdentityAt(110).ID)
+}
+
+func TestKeyRotationManagerOtherKeys(t *testing.T) {
+	dbPath := t.TempDir()
+	oldIdentity := node.OperatorIdentity{ID: core.OperatorID{1}}
+	rotator := &mockKeyRotator{blockNumber: 100}
+	m, err := node.NewKeyRotationManager(dbPath, oldIdentity, node.OperatorIdentity{ID: core.OperatorID{2}}, 10, rotator, testutils.GetLogger())
+	require.NoError(t, err)
+	_, err = node.RequestKeyRotation(dbPath, time.Now())
+	require.NoError(t, err)
+	require.NoError(t, m.RunRotationCycle(context.Background(), time.Now()))
+
+	// The node refuses to start with other next keys while a rotation is in progress
+	_, err = node.NewKeyRotationManager(dbPath, oldIdentity, node.OperatorIdentity{ID: core.OperatorID{3}}, 10, rotator, testutils.GetLogger())
+	require.ErrorContains(t, err, "in progress")
+}
+
+func TestKeyRotationManagerIdentityFor(t *testing.T) {
+	ctx := context.Background()
+	dbPath := t.TempDir()
+	oldIdentity := node.OperatorIdentity{ID: core.OperatorID{1}}
+	newIdentity := node.OperatorIdentity{ID: core.OperatorID{2}}
+	rotator := &mockKeyRotator{blockNumber: 100}
+	m, err := node.NewKeyRotationManager(dbPath, oldIdentity, newIdentity, 10, rotator, testutils.GetLogger())
+	require.NoError(t, err)
+
+	// The new keys aren't used before a rotation is picked up, even if a request names them
+	require.Equal(t, oldIdentity.ID, m.IdentityFor(newIdentity.ID, 200).ID)
+
+	start := time.Now()
+	_, err = node.RequestKeyRotation(dbPath, start)
+	require.NoError(t, err)
+	require.NoError(t, m.RunRotationCycle(ctx, start))
+
+	// While both operators are registered, the node attests as the one the request is for
+	require.Equal(t, oldIdentity.ID, m.IdentityFor(oldIdentity.ID, 200).ID)
+	require.Equal(t, newIdentity.ID, m.IdentityFor(newIdentity.ID, 50).ID)
+	// Requests which don't name an operator fall back to the reference block
+	require.Equal(t, oldIdentity.ID, m.IdentityFor(core.OperatorID{}, 99).ID)
+	require.Equal(t, newIdentity.ID, m.IdentityFor(core.OperatorID{}, 100).ID)
+	require.Equal(t, newIdentity.ID, m.IdentityFor(core.OperatorID{3}, 100).ID)
+}
+
+// blockingKeyRotator blocks registrations until it is released
+type blockingKeyRotator struct {
+	mockKeyRotator
+	registering chan struct{}
+	release     chan struct{}
+}
+
+func (r *blockingKeyRotator) RegisterNewKeys(ctx context.Context) error {
+	close(r.registering)
+	<-r.release
+	return r.mockKeyRotator.RegisterNewKeys(ctx)
+}
+
+func TestKeyRotationManagerChainCallsDontBlockSigning(t *testing.T) {
+	dbPath := t.TempDir()
+	oldIdentity := node.OperatorIdentity{ID: core.OperatorID{1}}
+	newIdentity := node.OperatorIdentity{ID: core.OperatorID{2}}
+	rotator := &blockingKeyRotator{
+		mockKeyRotator: mockKeyRotator{blockNumber: 100},
+		registering:    make(chan struct{}),
+		release:        make(chan struct{}),
+	}
+	m, err := node.NewKeyRotationManager(dbPath, oldIdentity, newIdentity, 10, rotator, testutils.GetLogger())
+	require.NoError(t, err)
+	start := time.Now()
+	_, err = node.RequestKeyRotation(dbPath, start)
+	require.NoError(t, err)
+
+	done := make(chan error)
+	go func() {
+		done <- m.RunRotationCycle(context.Background(), start)
+	}()
+	<-rotator.registering
+
+	// Requests are served with the old keys while the registration is pending
+	require.False(t, m.State().Registered())
+	require.Equal(t, oldIdentity.ID, m.IdentityAt(100).ID)
+	require.Equal(t, oldIdentity.ID, m.IdentityFor(oldIdentity.ID, 100).ID)
+
+	close(rotator.release)
+	require.NoError(t, <-done)
+	require.True(t, m.State().Registered())
+	require.Equal(t, newIdentity.ID, m.IdentityAt(100).ID)
+}
@@ -42,12 +42,45 @@ type Metrics struct {
 	AccuBlobs *prometheus.CounterVec
 	// Total number of changes in the node's socket address.
 	AccuSocketUpdates prometheus.Counter
+	// Accumulated number of failed or postponed socket updates, by reason.
+	AccuSocketUpdateFailures *prometheus.CounterVec
+	// Accumulated number of operator state lookups, by where the state came from.
+	AccuOperatorStateLookups *prometheus.CounterVec
+	// Accumulated number of lookups of verified batch merkle trees in the batch header cache, by result.
+	AccuBatchHeaderCacheLookups *prometheus.CounterVec
 	// avs node spec eigen_ metrics: https://eigen.nethermind.io/docs/spec/metrics/metrics-prom-spec
 	EigenMetrics eigenmetrics.Metrics
 	// Reachability gauge to monitoring the reachability of the node's retrieval/dispersal sockets
 	ReachabilityGauge *prometheus.GaugeVec
 	// The throughput (bytes per second) at which the data is written to database.
 	DBWriteThroughput prometheus.Gauge
+	// Disk space (in bytes) used by the chunks of each quorum with a disk quota.
+	QuorumDiskUsage *prometheus.GaugeVec
+	// Disk quota (in bytes) of each quorum with a disk quota.
+	QuorumDiskQuota *prometheus.GaugeVec
+	// Used and total size (in bytes) of the disk holding the stores.
+	DiskUsedBytes  prometheus.Gauge
+	DiskTotalBytes prometheus.Gauge
+	// Projected number of days until the disk holding the stores is full at the recent ingest rate.
+	DiskDaysUntilFull prometheus.Gauge
+	// Level of the storage alert: 0 ok, 1 warning, 2 critical.
+	StorageAlertLevel prometheus.Gauge
+	// Accumulated number of requests refused because a quorum reached its disk quota.
+	AccuQuotaRejections *prometheus.CounterVec
+	// Accumulated number of stored bundles audited by the chunk auditor, by quorum and result.
+	AccuAuditedBundles *prometheus.CounterVec
+	// Accumulated number of requests refused because they exceeded a bandwidth limit.
+	AccuBandwidthLimited *prometheus.CounterVec
+	// Accumulated number of retrieval requests refused because they exceeded a per-peer retrieval limit.
+	AccuRetrievalLimited *prometheus.CounterVec
+	// Accumulated number and size of bundles pruned before their expiry, by pruning policy.
+	AccuPrunedBundles *prometheus.CounterVec
+	// Accumulated number of stored bundles scrubbed by the chunk scrubber, by quorum and result.
+	AccuScrubbedBundles *prometheus.CounterVec
+	// Accumulated number and size of bundles migrated to the cold tier, by quorum.
+	AccuMigratedBundles *prometheus.CounterVec
+	// Accumulated number of attestations refused because they conflict with an attestation in the signing journal.
+	AccuConflictingAttestations prometheus.Counter
 
 	registry *prometheus.Registry
 	// socketAddr is the address at which the metrics server will be listening.
@@ -142,6 +175,33 @@ func NewMetrics(eigenMetrics eigenmetrics.Metrics, reg *prometheus.Registry, log
 				Help:      "the total number of node's socket address updates",
 			},
 		),
+		// The "reason" label has values: gas_price, transaction.
+		AccuSocketUpdateFailures: promauto.With(reg).NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: Namespace,
+				Name:      "eigenda_node_socket_update_failures_total",
+				Help:      "the total number of failed or postponed updates of the node's socket address",
+			},
+			[]string{"reason"},
+		),
+		// The "source" label has values: cache, derived, chain.
+		AccuOperatorStateLookups: promauto.With(reg).NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: Namespace,
+				Name:      "eigenda_node_operator_state_lookups_total",
+				Help:      "the total number of operator state lookups, by whether the state was cached, derived from a cached state or fetched from the chain",
+			},
+			[]string{"source"},
+		),
+		// The "result" label has values: hit, miss.
+		AccuBatchHeaderCacheLookups: promauto.With(reg).NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: Namespace,
+				Name:      "eigenda_node_batch_header_cache_lookups_total",
+				Help:      "the total number of lookups of verified batch merkle trees in the batch header cache, by whether the tree was cached",
+			},
+			[]string{"result"},
+		),
 		ReachabilityGauge: promauto.With(reg).NewGaugeVec(
 			prometheus.GaugeOpts{
 				Namespace: Namespace,
@@ -157,6 +217,119 @@ func NewMetrics(eigenMetrics eigenmetrics.Metrics, reg *prometheus.Registry, log
 				Help:      "the throughput (bytes per second) at which the data is written to database",
 			},
 		),
+		QuorumDiskUsage: promauto.With(reg).NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: Namespace,
+				Name:      "quorum_disk_usage_bytes",
+				Help:      "the disk space (in bytes) used by the chunks of a quorum with a disk quota",
+			},
+			[]string{"quorum"},
+		),
+		QuorumDiskQuota: promauto.With(reg).NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: Namespace,
+				Name:      "quorum_disk_quota_bytes",
+				Help:      "the disk quota (in bytes) of a quorum",
+			},
+			[]string{"quorum"},
+		),
+		DiskUsedBytes: promauto.With(reg).NewGauge(
+			prometheus.GaugeOpts{
+				Namespace: Namespace,
+				Name:      "disk_used_bytes",
+				Help:      "the used size (in bytes) of the disk holding the stores",
+			},
+		),
+		DiskTotalBytes: promauto.With(reg).NewGauge(
+			prometheus.GaugeOpts{
+				Namespace: Namespace,
+				Name:      "disk_total_bytes",
+				Help:      "the total size (in bytes) of the disk holding the stores",
+			},
+		),
+		DiskDaysUntilFull: promauto.With(reg).NewGauge(
+			prometheus.GaugeOpts{
+				Namespace: Namespace,
+				Name:      "disk_days_until_full",
+				Help:      "the projected number of days until the disk holding the stores is full at the recent ingest rate, +Inf if it isn't filling up",
+			},
+		),
+		StorageAlertLevel: promauto.With(reg).NewGauge(
+			prometheus.GaugeOpts{
+				Namespace: Namespace,
+				Name:      "storage_alert_level",
+				Help:      "the level of the storage alert: 0 ok, 1 warning, 2 critical",
+			},
+		),
+		AccuQuotaRejections: promauto.With(reg).NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: Namespace,
+				Name:      "eigenda_quota_rejected_requests_total",
+				Help:      "the total number of requests refused because a quorum reached its disk quota",
+			},
+			[]string{"quorum"},
+		),
+		// The "result" label has values: valid, corrupt.
+		AccuAuditedBundles: promauto.With(reg).NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: Namespace,
+				Name:      "eigenda_audited_bundles_total",
+				Help:      "the total number of stored bundles re-verified by the chunk auditor",
+			},
+			[]string{"quorum", "result"},
+		),
+		// The "direction" label has values: ingress, egress. The "scope" label has values: global, peer.
+		AccuBandwidthLimited: promauto.With(reg).NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: Namespace,
+				Name:      "eigenda_bandwidth_limited_requests_total",
+				Help:      "the total number of requests refused because they exceeded a bandwidth limit",
+			},
+			[]string{"direction", "scope"},
+		),
+		// The "reason" label has values: rate, queue.
+		AccuRetrievalLimited: promauto.With(reg).NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: Namespace,
+				Name:      "eigenda_retrieval_limited_requests_total",
+				Help:      "the total number of retrieval requests refused because they exceeded a per-peer retrieval limit",
+			},
+			[]string{"reason"},
+		),
+		// The "type" label has values: number, size.
+		AccuPrunedBundles: promauto.With(reg).NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: Namespace,
+				Name:      "eigenda_pruned_bundles_total",
+				Help:      "the total number and size (in bytes) of stored bundles pruned before their expiry",
+			},
+			[]string{"policy", "quorum", "type"},
+		),
+		// The "result" label has values: ok, corrupt, repaired, repair_failed.
+		AccuScrubbedBundles: promauto.With(reg).NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: Namespace,
+				Name:      "eigenda_scrubbed_bundles_total",
+				Help:      "the total number of stored bundles re-verified by the chunk scrubber",
+			},
+			[]string{"quorum", "result"},
+		),
+		// The "type" label has values: number, size.
+		AccuMigratedBundles: promauto.With(reg).NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: Namespace,
+				Name:      "eigenda_migrated_bundles_total",
+				Help:      "the total number and size (in bytes) of stored bundles migrated to the cold tier",
+			},
+			[]string{"quorum", "type"},
+		),
+		AccuConflictingAttestations: promauto.With(reg).NewCounter(
+			prometheus.CounterOpts{
+				Namespace: Namespace,
+				Name:      "eigenda_conflicting_attestations_total",
+				Help:      "the total number of attestations refused because they conflict with a batch the node attested to",
+			},
+		),
 
 		EigenMetrics:           eigenMetrics,
 		logger:                 logger.With("component", "NodeMetrics"),
@@ -189,10 +362,75 @@ func (g *Metrics) RecordSocketAddressChange() {
 	g.AccuSocketUpdates.Inc()
 }
 
+func (g *Metrics) RecordSocketUpdateFailure(reason string) {
+	g.AccuSocketUpdateFailures.WithLabelValues(reason).Inc()
+}
+
+func (g *Metrics) RecordOperatorStateLookup(source string) {
+	g.AccuOperatorStateLookups.WithLabelValues(source).Inc()
+}
+
+func (g *Metrics) RecordBatchHeaderCacheLookup(result string) {
+	g.AccuBatchHeaderCacheLookups.WithLabelValues(result).Inc()
+}
+
 func (g *Metrics) ObserveLatency(method, stage string, latencyMs float64) {
 	g.RequestLatency.WithLabelValues(method, stage).Observe(latencyMs)
 }
 
+func (g *Metrics) ReportQuorumDiskUsage(quorum core.QuorumID, usage uint64, quota uint64) {
+	quorumLabel := fmt.Sprintf("%d", quorum)
+	g.QuorumDiskUsage.WithLabelValues(quorumLabel).Set(float64(usage))
+	g.QuorumDiskQuota.WithLabelValues(quorumLabel).Set(float64(quota))
+}
+
+func (g *Metrics) ReportStorageStatus(usedBytes uint64, totalBytes uint64, daysUntilFull float64, level float64) {
+	g.DiskUsedBytes.Set(float64(usedBytes))
+	g.DiskTotalBytes.Set(float64(totalBytes))
+	g.DiskDaysUntilFull.Set(daysUntilFull)
+	g.StorageAlertLevel.Set(level)
+}
+
+func (g *Metrics) RecordQuotaRejection(quorum core.QuorumID) {
+	g.AccuQuotaRejections.WithLabelValues(fmt.Sprintf("%d", quorum)).Inc()
+}
+
+func (g *Metrics) RecordAuditedBundle(quorum core.QuorumID, corrupt bool) {
+	result := "valid"
+	if corrupt {
+		result = "corrupt"
+	}
+	g.AccuAuditedBundles.WithLabelValues(fmt.Sprintf("%d", quorum), result).Inc()
+}
+
+func (g *Metrics) RecordBandwidthLimited(direction string, scope string) {
+	g.AccuBandwidthLimited.WithLabelValues(direction, scope).Inc()
+}
+
+func (g *Metrics) RecordRetrievalLimited(reason string) {
+	g.AccuRetrievalLimited.WithLabelValues(reason).Inc()
+}
+
+func (g *Metrics) RecordPrunedBundle(policy string, quorum core.QuorumID, size uint64) {
+	quorumLabel := fmt.Sprintf("%d", quorum)
+	g.AccuPrunedBundles.WithLabelValues(policy, quorumLabel, "number").Inc()
+	g.AccuPrunedBundles.WithLabelValues(policy, quorumLabel, "size").Add(float64(size))
+}
+
+func (g *Metrics) RecordScrubbedBundle(quorum core.QuorumID, result string) {
+	g.AccuScrubbedBundles.WithLabelValues(fmt.Sprintf("%d", quorum), result).Inc()
+}
+
+func (g *Metrics) RecordMigratedBundle(quorum core.QuorumID, size uint64) {
+	quorumLabel := fmt.Sprintf("%d", quorum)
+	g.AccuMigratedBundles.WithLabelValues(quorumLabel, "number").Inc()
+	g.AccuMigratedBundles.WithLabelValues(quorumLabel, "size").Add(float64(size))
+}
+
+func (g *Metrics) RecordConflictingAttestation() {
+	g.AccuConflictingAttestations.Inc()
+}
+
 func (g *Metrics) RemoveNCurrentBatch(numBatches int, totalBatchSize int64) {
 	for i := 0; i < numBatches; i++ {
 		g.AccuRemovedBatches.WithLabelValues("number").Inc()
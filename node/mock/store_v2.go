@@ -1,6 +1,9 @@
 package mock
 
 import (
+	"context"
+	"time"
+
 	"github.com/Layr-Labs/eigenda/common/kvstore"
 	"github.com/Layr-Labs/eigenda/core"
 	corev2 "github.com/Layr-Labs/eigenda/core/v2"
@@ -27,6 +30,16 @@ func (m *MockStoreV2) StoreBatch(batch *corev2.Batch, rawBundles []*node.RawBund
 	return args.Get(0).([]kvstore.Key), 0, args.Error(1)
 }
 
+func (m *MockStoreV2) CommitBatch(batchHeaderHash [32]byte) error {
+	args := m.Called(batchHeaderHash)
+	return args.Error(0)
+}
+
+func (m *MockStoreV2) DiscardPendingBatches() (int, error) {
+	args := m.Called()
+	return args.Int(0), args.Error(1)
+}
+
 func (m *MockStoreV2) DeleteKeys(keys []kvstore.Key) error {
 	args := m.Called(keys)
 	return args.Error(0)
@@ -39,3 +52,64 @@ func (m *MockStoreV2) GetChunks(blobKey corev2.BlobKey, quorum core.QuorumID) ([
 	}
 	return args.Get(0).([][]byte), args.Error(1)
 }
+
+func (m *MockStoreV2) GetStoredBlob(blobKey corev2.BlobKey) (*node.StoredBlob, error) {
+	args := m.Called(blobKey)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*node.StoredBlob), args.Error(1)
+}
+
+func (m *MockStoreV2) GetBlobChunks(blobKey corev2.BlobKey) (map[core.QuorumID][][]byte, error) {
+	args := m.Called(blobKey)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[core.QuorumID][][]byte), args.Error(1)
+}
+
+func (m *MockStoreV2) SampleBlobs(n int) ([]*node.StoredBlob, error) {
+	args := m.Called(n)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*node.StoredBlob), args.Error(1)
+}
+
+func (m *MockStoreV2) NextBlobs(after *corev2.BlobKey, n int) ([]*node.StoredBlob, error) {
+	args := m.Called(after, n)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*node.StoredBlob), args.Error(1)
+}
+
+func (m *MockStoreV2) RepairBundle(blobKey corev2.BlobKey, quorum core.QuorumID, bundle []byte) error {
+	args := m.Called(blobKey, quorum, bundle)
+	return args.Error(0)
+}
+
+func (m *MockStoreV2) GetQuorumUsage() (map[core.QuorumID]uint64, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[core.QuorumID]uint64), args.Error(1)
+}
+
+func (m *MockStoreV2) PruneBundles(prune func(blobKey corev2.BlobKey, blob *node.StoredBlob, quorum core.QuorumID) bool) ([]node.PrunedBundle, error) {
+	args := m.Called(prune)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]node.PrunedBundle), args.Error(1)
+}
+
+func (m *MockStoreV2) MigrateBundles(ctx context.Context, storedBefore time.Time) ([]node.MigratedBundle, error) {
+	args := m.Called(ctx, storedBefore)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]node.MigratedBundle), args.Error(1)
+}
@@ -18,6 +18,9 @@ import (
 	"time"
 
 	"github.com/Layr-Labs/eigenda/api/clients/v2/relay"
+	commonaws "github.com/Layr-Labs/eigenda/common/aws"
+	"github.com/Layr-Labs/eigenda/common/aws/s3"
+	"github.com/Layr-Labs/eigenda/common/kvstore"
 	"github.com/Layr-Labs/eigenda/common/kvstore/tablestore"
 	"github.com/Layr-Labs/eigenda/common/pprof"
 	"github.com/Layr-Labs/eigenda/common/pubip"
@@ -35,6 +38,7 @@ import (
 	"github.com/Layr-Labs/eigenda/core/eth"
 	"github.com/Layr-Labs/eigenda/core/indexer"
 	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/node/signer"
 
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	"github.com/Layr-Labs/eigensdk-go/metrics"
@@ -42,6 +46,7 @@ import (
 	blssigner "github.com/Layr-Labs/eigensdk-go/signer/bls"
 
 	"github.com/gammazero/workerpool"
+	"github.com/shirou/gopsutil/disk"
 )
 
 const (
@@ -50,6 +55,11 @@ const (
 
 	v1CheckPath = "api/v1/operators-info/port-check"
 	v2CheckPath = "api/v2/operators/liveness"
+
+	// exitCheckInterval is how often the node checks for a requested exit, and whether it can deregister
+	exitCheckInterval = time.Minute
+	// keyRotationCheckInterval is how often the node checks for a requested key rotation, and advances it
+	keyRotationCheckInterval = time.Minute
 )
 
 var (
@@ -61,13 +71,51 @@ var (
 )
 
 type Node struct {
-	Config                  *Config
-	Logger                  logging.Logger
-	KeyPair                 *core.KeyPair
-	Metrics                 *Metrics
-	NodeApi                 *nodeapi.NodeApi
-	Store                   *Store
-	StoreV2                 StoreV2
+	Config  *Config
+	Logger  logging.Logger
+	KeyPair *core.KeyPair
+	Metrics *Metrics
+	NodeApi *nodeapi.NodeApi
+	Store   *Store
+	StoreV2 StoreV2
+	// DiskQuotas enforces the disk quotas of quorums on the v2 store. It's nil if no quorum has a quota.
+	DiskQuotas *QuorumQuotaTracker
+	// ChunkAuditor re-verifies the stored chunks of the v2 store. It's nil if v2 is disabled.
+	ChunkAuditor *ChunkAuditor
+	// ChunkScrubber walks the v2 store re-verifying and repairing the stored chunks. It's nil if v2 is disabled.
+	ChunkScrubber *ChunkScrubber
+	// Stats keeps recent statistics about the node's activity for the dashboard api
+	Stats *NodeStats
+	// Pruner deletes the chunks of the v2 store selected by the pruning policies before they expire. It's nil if
+	// pruning is disabled.
+	Pruner *PruningManager
+	// SigningJournal records the batches the node attests to, and refuses conflicting attestations. It's nil if
+	// disabled.
+	SigningJournal *SigningJournal
+	// ChunkTiering migrates the older chunks of the v2 store to an object store. It's nil if tiering is disabled.
+	ChunkTiering *ChunkTieringManager
+	// Exit coordinates the clean exit of the operator, requested with the exit subcommand. It's nil if the node wasn't
+	// created by NewNode.
+	Exit *ExitManager
+	// KeyRotation moves the operator to the next keys of the config, requested with the rotate-keys subcommand. It's
+	// nil if no next keys are configured.
+	KeyRotation *KeyRotationManager
+	keyRotator  *chainKeyRotator
+	// StoreMigration migrates the stores of the node to another backend while it's running. It's nil if the stores
+	// aren't being migrated.
+	StoreMigration *StoreMigration
+	// settingsMu serializes the reloads of the settings
+	settingsMu sync.Mutex
+	// DisperserFilter refuses StoreChunks requests from dispersers which aren't allowed. It's nil if all dispersers
+	// are allowed, unless the settings can be reloaded.
+	DisperserFilter *DisperserFilter
+	// RetrievalLimiter limits the rate of chunk retrieval requests of each retriever, and serves them fairly across
+	// retrievers. It's nil if unlimited, unless the settings can be reloaded.
+	RetrievalLimiter *RetrievalLimiter
+	// IngressLimiter and EgressLimiter limit the bandwidth of StoreChunks requests and chunk retrievals. They're nil
+	// if unlimited, unless the settings can be reloaded.
+	IngressLimiter          *BandwidthLimiter
+	EgressLimiter           *BandwidthLimiter
 	ChainState              core.ChainState
 	Validator               core.ShardValidator
 	ValidatorV2             corev2.ShardValidator
@@ -80,8 +128,13 @@ type Node struct {
 
 	RelayClient atomic.Value
 
-	mu            sync.Mutex
-	CurrentSocket string
+	// SocketUpdater registers the socket of the node on-chain again when its public IP changes. It's nil if
+	// disabled.
+	SocketUpdater *SocketUpdater
+	// StorageAlerter warns operators before the disk holding the stores fills up. It's nil if disabled.
+	StorageAlerter *StorageAlerter
+	// BatchHeaderCache caches the verified merkle trees of the v1 batches. It's nil if disabled.
+	BatchHeaderCache *BatchHeaderCache
 
 	// BlobVersionParams is a map of blob version parameters loaded from the chain.
 	// It is used to determine blob parameters based on the version number.
@@ -126,7 +179,14 @@ func NewNode(
 	// Create ChainState Client
 	cst := eth.NewChainState(tx, client)
 
-	blsSigner, err := blssigner.NewSigner(config.BlsSignerConfig)
+	blsSigner, err := signer.NewSigner(context.Background(), signer.Config{
+		Signer:       config.BlsSignerConfig,
+		KMS:          config.BlsKMSConfig,
+		Fallbacks:    config.BlsSignerFallbackConfigs,
+		Timeout:      config.BlsSignerTimeout,
+		BatchWindow:  config.BlsSigningBatchWindow,
+		MaxBatchSize: config.BlsSigningMaxBatchSize,
+	}, logger)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create BLS signer: %w", err)
 	}
@@ -144,6 +204,51 @@ func NewNode(
 
 	metrics := NewMetrics(eigenMetrics, reg, logger, fmt.Sprintf(":%d", config.MetricsPort), config.ID, config.OnchainMetricsInterval, tx, cst)
 
+	// When the settings can be reloaded, the limiters and the disperser filter are created even if nothing is
+	// limited, so that limits can be set without a restart
+	newBandwidth, newRetrieval := NewBandwidthLimiter, NewRetrievalLimiter
+	if config.SettingsFile != "" {
+		newBandwidth, newRetrieval = newBandwidthLimiter, newRetrievalLimiter
+	}
+	ingressLimiter, err := newBandwidth("ingress", config.IngressBandwidthLimit, metrics)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create ingress bandwidth limiter: %w", err)
+	}
+	egressLimiter, err := newBandwidth("egress", config.EgressBandwidthLimit, metrics)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create egress bandwidth limiter: %w", err)
+	}
+	var disperserFilter *DisperserFilter
+	if config.SettingsFile != "" && !config.DisableDispersalAuthentication {
+		disperserFilter, err = newDisperserFilter(config.DisperserFilter, logger)
+	} else if config.DisperserFilter.Enabled() {
+		disperserFilter, err = NewDisperserFilter(config.DisperserFilter, logger)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the disperser filter: %w", err)
+	}
+	retrievalLimiter, err := newRetrieval(config.RetrievalLimit, metrics)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create retrieval limiter: %w", err)
+	}
+
+	// Cache the operator states batches are validated against
+	eigenDAServiceManagerAddr := gethcommon.HexToAddress(config.EigenDAServiceManagerAddr)
+	var chainState core.ChainState = cst
+	if config.OperatorStateCache.Size > 0 {
+		var stakesFilterer indexer.OperatorStakesFilterer
+		if config.OperatorStateCache.MaxDerivationBlocks > 0 {
+			stakesFilterer, err = indexer.NewOperatorStakesFilterer(eigenDAServiceManagerAddr, client)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create operator stakes filterer: %w", err)
+			}
+		}
+		chainState, err = NewOperatorStateCache(config.OperatorStateCache, cst, stakesFilterer, metrics, logger)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Make validator
 	config.EncoderConfig.LoadG2Points = false
 	v, err := verifier.NewVerifier(&config.EncoderConfig, nil)
@@ -151,8 +256,8 @@ func NewNode(
 		return nil, err
 	}
 	asgn := &core.StdAssignmentCoordinator{}
-	validator := core.NewShardValidator(v, asgn, cst, config.ID)
-	validatorV2 := corev2.NewShardValidator(v, config.ID, logger)
+	validator := core.NewShardValidator(v, asgn, chainState, config.ID)
+	validatorV2 := corev2.NewShardValidator(v, config.ID, config.ValidationSubBatchSize, logger)
 
 	// Resolve the BLOCK_STALE_MEASURE and STORE_DURATION_BLOCKS.
 	var blockStaleMeasure, storeDurationBlocks uint32
@@ -176,13 +281,50 @@ func NewNode(
 		}
 		storeDurationBlocks = storeDuration
 	}
+	chunkEncryptor, err := NewChunkEncryptor(context.Background(), config.ChunkEncryption)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create chunk encryptor: %w", err)
+	}
+	if chunkEncryptor.Enabled() {
+		logger.Info("Encrypting chunks at rest")
+	}
+
+	// Cut the stores over to the backend the node is started with, if they were migrated to it, and mirror them to
+	// the migration target if they're being migrated
+	err = CompleteStoreMigration(context.Background(), config.DbPath, config.DbBackend, config.MigrateDb, storeNames, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to complete the store migration: %w", err)
+	}
+	var storeMigration *StoreMigration
+	if config.MigrateDb {
+		storeMigration, err = NewStoreMigration(config.DbPath, config.DbBackend, config.DbMigrationTarget, logger)
+		if err != nil {
+			return nil, fmt.Errorf("failed to start the store migration: %w", err)
+		}
+	}
+	openDB := func(name string) (kvstore.Store[[]byte], error) {
+		if storeMigration != nil {
+			return storeMigration.Open(name)
+		}
+		return openKVStore(config.DbBackend, config.DbPath+"/"+name+dbPathSuffix(config.DbBackend), logger)
+	}
+
 	// Create new store
-	store, err := NewLevelDBStore(config.DbPath+"/chunk", logger, metrics, blockStaleMeasure, storeDurationBlocks)
+	db, err := openDB(chunkStoreName)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create new store: %w", err)
 	}
+	store := NewStoreWithDB(db, logger, metrics, blockStaleMeasure, storeDurationBlocks, chunkEncryptor)
+
+	var signingJournal *SigningJournal
+	if config.SigningJournalRetention > 0 {
+		db, err := openDB(signingJournalStoreName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open the signing journal: %w", err)
+		}
+		signingJournal = NewSigningJournal(db, config.SigningJournalRetention, metrics, logger)
+	}
 
-	eigenDAServiceManagerAddr := gethcommon.HexToAddress(config.EigenDAServiceManagerAddr)
 	socketsFilterer, err := indexer.NewOperatorSocketsFilterer(eigenDAServiceManagerAddr, client)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create new operator sockets filterer: %w", err)
@@ -190,7 +332,7 @@ func NewNode(
 
 	nodeLogger.Info("Creating node", "chainID", chainID.String(), "operatorID", config.ID.Hex(),
 		"dispersalPort", config.DispersalPort, "v2DispersalPort", config.V2DispersalPort, "retrievalPort", config.RetrievalPort, "v2RetrievalPort", config.V2RetrievalPort, "churnerUrl", config.ChurnerUrl,
-		"quorumIDs", fmt.Sprint(config.QuorumIDList), "registerNodeAtStart", config.RegisterNodeAtStart, "pubIPCheckInterval", config.PubIPCheckInterval,
+		"quorumIDs", fmt.Sprint(config.QuorumIDList), "registerNodeAtStart", config.RegisterNodeAtStart, "pubIPCheckInterval", config.SocketUpdate.CheckInterval,
 		"eigenDAServiceManagerAddr", config.EigenDAServiceManagerAddr, "blockStaleMeasure", blockStaleMeasure, "storeDurationBlocks", storeDurationBlocks, "enableGnarkBundleEncoding", config.EnableGnarkBundleEncoding)
 
 	n := &Node{
@@ -199,7 +341,7 @@ func NewNode(
 		Metrics:                 metrics,
 		NodeApi:                 nodeApi,
 		Store:                   store,
-		ChainState:              cst,
+		ChainState:              chainState,
 		Transactor:              tx,
 		Validator:               validator,
 		ValidatorV2:             validatorV2,
@@ -207,6 +349,76 @@ func NewNode(
 		OperatorSocketsFilterer: socketsFilterer,
 		ChainID:                 chainID,
 		BLSSigner:               blsSigner,
+		IngressLimiter:          ingressLimiter,
+		EgressLimiter:           egressLimiter,
+		RetrievalLimiter:        retrievalLimiter,
+		DisperserFilter:         disperserFilter,
+		Stats:                   NewNodeStats(),
+		SigningJournal:          signingJournal,
+		StoreMigration:          storeMigration,
+	}
+
+	if config.SocketUpdate.CheckInterval > 0 {
+		// The socket registered on-chain is checked against the configured socket when the node starts
+		socket := string(core.MakeOperatorSocket(config.Hostname, config.DispersalPort, config.RetrievalPort, config.V2DispersalPort, config.V2RetrievalPort))
+		detect := func(ctx context.Context) (string, error) {
+			return SocketAddress(ctx, pubIPProvider, config.DispersalPort, config.RetrievalPort, config.V2DispersalPort, config.V2RetrievalPort)
+		}
+		var gasPrice GasPriceOracle
+		if client != nil {
+			gasPrice = client
+		}
+		n.SocketUpdater, err = NewSocketUpdater(config.SocketUpdate, socket, detect, tx, gasPrice, metrics, logger)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create socket updater: %w", err)
+		}
+	}
+
+	if config.StorageAlerts.CheckInterval > 0 {
+		diskUsage := func() (uint64, uint64, error) {
+			usage, err := disk.Usage(config.DbPath)
+			if err != nil {
+				return 0, 0, err
+			}
+			return usage.Used, usage.Total, nil
+		}
+		n.StorageAlerter, err = NewStorageAlerter(config.StorageAlerts, config.ID.Hex(), diskUsage, metrics, logger)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create storage alerter: %w", err)
+		}
+	}
+
+	if config.BatchHeaderCacheSize > 0 {
+		n.BatchHeaderCache, err = NewBatchHeaderCache(config.BatchHeaderCacheSize, metrics)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	timeToExpire := (blockStaleMeasure + storeDurationBlocks) * 12 // 12s per block
+	n.Exit, err = NewExitManager(config.DbPath, time.Duration(timeToExpire)*time.Second, n, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create exit manager: %w", err)
+	}
+
+	if config.NextBlsSignerConfig != nil {
+		n.keyRotator, err = newChainKeyRotator(n, logger)
+		if err != nil {
+			return nil, err
+		}
+		n.keyRotator.newValidator = core.NewShardValidator(v, asgn, chainState, n.keyRotator.newIdentity.ID)
+		n.keyRotator.newValidatorV2 = corev2.NewShardValidator(v, n.keyRotator.newIdentity.ID, config.ValidationSubBatchSize, logger)
+		// Batches reference blocks at most blockStaleMeasure blocks old
+		n.KeyRotation, err = NewKeyRotationManager(
+			config.DbPath,
+			OperatorIdentity{ID: config.ID, Signer: blsSigner},
+			n.keyRotator.newIdentity,
+			blockStaleMeasure,
+			n.keyRotator,
+			logger)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create key rotation manager: %w", err)
+		}
 	}
 
 	if !config.EnableV2 {
@@ -216,26 +428,60 @@ func NewNode(
 	var storeV2 StoreV2
 	var blobVersionParams *corev2.BlobVersionParameterMap
 	if config.EnableV2 {
-		v2Path := config.DbPath + "/chunk_v2"
-		dbV2, err := tablestore.Start(logger, &tablestore.Config{
-			Type:                       tablestore.LevelDB,
-			Path:                       &v2Path,
+		baseV2, err := openDB(chunkStoreV2Name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create new tablestore: %w", err)
+		}
+		dbV2, err := tablestore.StartWithStore(logger, baseV2, &tablestore.Config{
 			GarbageCollectionEnabled:   true,
 			GarbageCollectionInterval:  time.Duration(config.ExpirationPollIntervalSec) * time.Second,
 			GarbageCollectionBatchSize: 1024,
-			Schema:                     []string{BatchHeaderTableName, BlobCertificateTableName, BundleTableName},
+			Schema:                     []string{BatchHeaderTableName, BlobCertificateTableName, BundleTableName, ColdBundleTableName, PendingBatchTableName, ChunkTableName},
 		})
 		if err != nil {
 			return nil, fmt.Errorf("failed to create new tablestore: %w", err)
 		}
-		timeToExpire := (blockStaleMeasure + storeDurationBlocks) * 12 // 12s per block
-		storeV2 = NewLevelDBStoreV2(dbV2, logger, time.Duration(timeToExpire)*time.Second)
+		var coldTier ColdTier
+		if config.ChunkTiering.Enabled() {
+			s3Client, err := s3.NewClient(context.Background(), commonaws.ClientConfig{
+				Region:      config.ChunkTiering.Region,
+				EndpointURL: config.ChunkTiering.EndpointURL,
+			}, logger)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create the chunk tiering s3 client: %w", err)
+			}
+			coldTier = NewS3ColdTier(s3Client, config.ChunkTiering.Bucket)
+		}
+		storeV2 = NewLevelDBStoreV2(dbV2, logger, time.Duration(timeToExpire)*time.Second, chunkEncryptor, coldTier, config.DeduplicateChunks)
+
+		// Batches interrupted by a crash before their chunks were validated are discarded, so that unvalidated chunks
+		// are never served
+		discarded, err := storeV2.DiscardPendingBatches()
+		if err != nil {
+			return nil, fmt.Errorf("failed to discard the pending batches: %w", err)
+		}
+		if discarded > 0 {
+			logger.Warn("Discarded the batches interrupted by the last shutdown", "batches", discarded)
+		}
+
+		if len(config.DiskQuotas) > 0 {
+			logger.Info("Computing the disk usage of quorums to enforce their disk quotas")
+			usage, err := storeV2.GetQuorumUsage()
+			if err != nil {
+				return nil, fmt.Errorf("failed to get the disk usage of quorums: %w", err)
+			}
+			n.DiskQuotas, err = NewQuorumQuotaTracker(config.DiskQuotas, config.DiskQuotaHighWatermark, time.Duration(timeToExpire)*time.Second, usage, n.Metrics)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create quorum quota tracker: %w", err)
+			}
+		}
 
 		blobParams, err := tx.GetAllVersionedBlobParams(context.Background())
 		if err != nil {
 			return nil, fmt.Errorf("failed to get versioned blob parameters: %w", err)
 		}
 		blobVersionParams = corev2.NewBlobVersionParameterMap(blobParams)
+		n.Stats.RecordOnchainStateRefresh(time.Now())
 
 		relayClientConfig := &clients.RelayClientConfig{
 			UseSecureGrpcFlag:  config.UseSecureGrpc,
@@ -258,6 +504,14 @@ func NewNode(
 	}
 
 	n.StoreV2 = storeV2
+	n.ChunkAuditor = NewChunkAuditor(storeV2, v, metrics, logger)
+	n.ChunkScrubber = NewChunkScrubber(storeV2, v, n, metrics, logger)
+	if policies := config.Pruning.Policies(config.DbPath); storeV2 != nil && config.Pruning.Interval > 0 && len(policies) > 0 {
+		n.Pruner = NewPruningManager(storeV2, policies, n.DiskQuotas, metrics, logger)
+	}
+	if storeV2 != nil && config.ChunkTiering.Enabled() {
+		n.ChunkTiering = NewChunkTieringManager(storeV2, config.ChunkTiering.MigrationAge, n.DiskQuotas, metrics, logger)
+	}
 	n.BlobVersionParams.Store(blobVersionParams)
 	return n, nil
 }
@@ -278,6 +532,52 @@ func (n *Node) Start(ctx context.Context) error {
 		n.NodeApi.Start()
 		n.Logger.Info("Enabled node api", "port", n.Config.NodeApiPort)
 	}
+	if n.Config.EnableDashboardApi {
+		NewDashboardServer(n, ":"+n.Config.DashboardApiPort, n.Logger).Start(ctx)
+		n.Logger.Info("Enabled dashboard api", "port", n.Config.DashboardApiPort, "path", DashboardPath)
+	}
+
+	if n.StorageAlerter != nil {
+		n.StorageAlerter.Start(ctx)
+		n.Logger.Info("Enabled storage alerts", "interval", n.Config.StorageAlerts.CheckInterval,
+			"warningUsage", n.Config.StorageAlerts.WarningUsage, "criticalUsage", n.Config.StorageAlerts.CriticalUsage)
+	}
+
+	if n.Config.SettingsFile != "" {
+		if err := n.ReloadSettings(); err != nil {
+			return fmt.Errorf("failed to apply the settings file: %w", err)
+		}
+		n.watchSettingsReload(ctx)
+		n.Logger.Info("Enabled settings reload on SIGHUP", "file", n.Config.SettingsFile)
+	}
+	if n.Config.EnableAdminApi {
+		NewAdminServer(n, "localhost:"+n.Config.AdminApiPort, n.Logger).Start(ctx)
+		n.Logger.Info("Enabled admin api", "port", n.Config.AdminApiPort, "path", AdminReloadPath)
+	}
+
+	if n.StoreMigration != nil {
+		n.StoreMigration.Start(ctx)
+		n.Logger.Info("Migrating the stores", "target", StoreBackendName(n.Config.DbMigrationTarget))
+	}
+
+	if n.SigningJournal != nil {
+		n.SigningJournal.Start(ctx, time.Duration(n.Config.ExpirationPollIntervalSec)*time.Second)
+		n.Logger.Info("Enabled signing journal", "retention", n.Config.SigningJournalRetention)
+	}
+
+	if n.Exit != nil {
+		n.Exit.Start(ctx, exitCheckInterval)
+		if state := n.Exit.State(); state != nil {
+			n.Logger.Warn("Operator is exiting, not accepting new batches", "startedAt", state.StartedAt, "deregistered", state.Deregistered())
+		}
+	}
+
+	if n.KeyRotation != nil {
+		n.KeyRotation.Start(ctx, keyRotationCheckInterval)
+		if state := n.KeyRotation.State(); state != nil && !state.Retired() {
+			n.Logger.Info("Rotating the operator keys", "newOperatorId", state.NewOperatorID, "registeredAtBlock", state.RegisteredAtBlock)
+		}
+	}
 
 	if n.Config.EnableV1 {
 		go n.expireLoop()
@@ -289,6 +589,22 @@ func (n *Node) Start(ctx context.Context) error {
 			_ = n.RefreshOnchainState(ctx)
 		}()
 		go n.checkNodeReachability(v2CheckPath)
+		if n.Config.ChunkAuditInterval > 0 {
+			n.ChunkAuditor.Start(ctx, n.Config.ChunkAuditInterval, n.Config.ChunkAuditBlobs)
+			n.Logger.Info("Enabled chunk audits", "interval", n.Config.ChunkAuditInterval, "blobs", n.Config.ChunkAuditBlobs)
+		}
+		if n.Config.ChunkScrubInterval > 0 {
+			n.ChunkScrubber.Start(ctx, n.Config.ChunkScrubInterval, n.Config.ChunkScrubBlobs)
+			n.Logger.Info("Enabled chunk scrubbing", "interval", n.Config.ChunkScrubInterval, "blobs", n.Config.ChunkScrubBlobs)
+		}
+		if n.Pruner != nil {
+			n.Pruner.Start(ctx, n.Config.Pruning.Interval)
+			n.Logger.Info("Enabled pruning", "interval", n.Config.Pruning.Interval)
+		}
+		if n.ChunkTiering != nil {
+			n.ChunkTiering.Start(ctx, n.Config.ChunkTiering.Interval)
+			n.Logger.Info("Enabled chunk tiering", "bucket", n.Config.ChunkTiering.Bucket, "migrationAge", n.Config.ChunkTiering.MigrationAge)
+		}
 	}
 
 	// Build the socket based on the hostname/IP provided in the CLI
@@ -345,11 +661,12 @@ func (n *Node) Start(ctx context.Context) error {
 		}
 	}
 
-	n.CurrentSocket = socket
-	// Start the Node IP updater only if the PUBLIC_IP_PROVIDER is greater than 0.
-	if n.Config.PubIPCheckInterval > 0 && n.Config.EnableV1 && n.Config.EnableV2 {
+	// Start the Node IP updater only if the PUBLIC_IP_CHECK_INTERVAL is greater than 0.
+	if n.SocketUpdater != nil {
+		n.SocketUpdater.SetRegistered(socket)
 		go n.checkRegisteredNodeIpOnChain(ctx)
-		go n.checkCurrentNodeIp(ctx)
+		n.SocketUpdater.Start(ctx)
+		n.Logger.Info("Enabled socket updates", "interval", n.Config.SocketUpdate.CheckInterval)
 	}
 
 	return nil
@@ -359,6 +676,7 @@ func (n *Node) Start(ctx context.Context) error {
 // is running. It scans for expired batches and removes them from the local database.
 func (n *Node) expireLoop() {
 	n.Logger.Info("Start expireLoop goroutine in background to periodically remove expired batches on the node")
+	expiryManager := NewExpiryManager(n.Store, n.Config.ExpirationCompactionThreshold, n.Logger)
 	ticker := time.NewTicker(time.Duration(n.Config.ExpirationPollIntervalSec) * time.Second)
 	defer ticker.Stop()
 
@@ -370,14 +688,9 @@ func (n *Node) expireLoop() {
 		// The heuristic is to cap the GC time to a percentage of the poll interval, but at
 		// least have 1 second.
 		timeLimitSec := uint64(math.Max(float64(n.Config.ExpirationPollIntervalSec)*gcPercentageTime, 1.0))
-		numBatchesDeleted, numMappingsDeleted, numBlobsDeleted, err := n.Store.DeleteExpiredEntries(time.Now().Unix(), timeLimitSec)
-		n.Logger.Info("Complete an expiration cycle to remove expired batches", "num expired batches found and removed", numBatchesDeleted, "num expired mappings found and removed", numMappingsDeleted, "num expired blobs found and removed", numBlobsDeleted)
+		_, err := expiryManager.RunExpirationCycle(time.Now().Unix(), timeLimitSec)
 		if err != nil {
-			if errors.Is(err, context.DeadlineExceeded) {
-				n.Logger.Error("Expiration cycle exited with ContextDeadlineExceed, meaning more expired batches need to be removed, which will continue in next cycle", "time limit (sec)", timeLimitSec)
-			} else {
-				n.Logger.Error("Expiration cycle encountered error when removing expired batches, which will be retried in next cycle", "err", err)
-			}
+			expiryManager.logExpirationError(err, timeLimitSec)
 		}
 	}
 }
@@ -403,6 +716,7 @@ func (n *Node) RefreshOnchainState(ctx context.Context) error {
 				if existingBlobParams == nil || !existingBlobParams.Equal(blobParams) {
 					n.BlobVersionParams.Store(corev2.NewBlobVersionParameterMap(blobParams))
 				}
+				n.Stats.RecordOnchainStateRefresh(time.Now())
 			} else {
 				n.Logger.Error("error fetching blob params", "err", err)
 			}
@@ -421,7 +735,7 @@ func (n *Node) RefreshOnchainState(ctx context.Context) error {
 //   - If the batch is stored already, it's no-op to store it more than once
 //   - If the batch is stored, but the processing fails after that, these data items will not be rollback
 //   - These data items will be garbage collected eventually when they become stale.
-func (n *Node) ProcessBatch(ctx context.Context, header *core.BatchHeader, blobs []*core.BlobMessage, rawBlobs []*node.Blob) (*core.Signature, error) {
+func (n *Node) ProcessBatch(ctx context.Context, identity OperatorIdentity, header *core.BatchHeader, blobs []*core.BlobMessage, rawBlobs []*node.Blob) (*core.Signature, error) {
 	start := time.Now()
 	log := n.Logger
 
@@ -487,7 +801,7 @@ func (n *Node) ProcessBatch(ctx context.Context, header *core.BatchHeader, blobs
 
 	// Validate batch.
 	stageTimer := time.Now()
-	err = n.ValidateBatch(ctx, header, blobs)
+	err = n.ValidateBatch(ctx, identity.ID, header, blobs)
 	if err != nil {
 		// If we have already stored the batch into database, but it's not valid, we
 		// revert all the keys for that batch.
@@ -518,20 +832,225 @@ func (n *Node) ProcessBatch(ctx context.Context, header *core.BatchHeader, blobs
 
 	// Sign batch header hash if all validation checks pass and data items are written to database.
 	stageTimer = time.Now()
-	signature, err := n.SignMessage(ctx, batchHeaderHash)
+	blobHeaderHashes := make([][32]byte, len(blobs))
+	for i, blob := range blobs {
+		blobHeaderHashes[i], err = blob.BlobHeader.GetBlobHeaderHash()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get blob header hash: %w", err)
+		}
+	}
+	if err := n.RecordAttestation(batchHeaderHash, uint64(header.ReferenceBlockNumber), blobHeaderHashes); err != nil {
+		return nil, err
+	}
+	signature, err := signMessage(ctx, identity.Signer, batchHeaderHash)
 	if err != nil {
 		return nil, fmt.Errorf("failed to sign batch: %w", err)
 	}
 
 	n.Metrics.RecordStoreChunksStage("signed", batchSize, time.Since(stageTimer))
-	log.Debug("Sign batch succeeded", "pubkey", n.BLSSigner.GetPublicKeyG1(), "duration", time.Since(stageTimer))
+	log.Debug("Sign batch succeeded", "pubkey", identity.Signer.GetPublicKeyG1(), "duration", time.Since(stageTimer))
 
 	log.Debug("Exiting process batch", "duration", time.Since(start))
 	return signature, nil
 }
 
+// ProcessBlobs validates and stores the blobs of a minibatch, and signs the blob header hash of each blob.
+// The blobs are attested as part of a batch later on, by ProcessAttestBatch.
+// Notes:
+//   - Blobs which are stored already aren't stored again
+//   - The stored blobs will be garbage collected when they expire if they're never attested
+func (n *Node) ProcessBlobs(ctx context.Context, identity OperatorIdentity, blobs []*core.BlobMessage, rawBlobs []*node.Blob, referenceBlockNumber uint) ([]*core.Signature, error) {
+	start := time.Now()
+	log := n.Logger
+
+	if len(blobs) == 0 {
+		return nil, errors.New("number of blobs must be greater than zero")
+	}
+	if len(blobs) != len(rawBlobs) {
+		return nil, errors.New("number of parsed blobs must be the same as number of blobs from protobuf request")
+	}
+
+	blobsSize := uint64(0)
+	for _, blob := range blobs {
+		for quorumID, bundle := range blob.Bundles {
+			n.Metrics.AcceptBlobs(quorumID, bundle.Size())
+		}
+		blobsSize += blob.Bundles.Size()
+	}
+	log.Debug("Start processing blobs", "size (in bytes)", blobsSize, "num of blobs", len(blobs), "referenceBlockNumber", referenceBlockNumber)
+
+	// Validate the blobs before they're stored, since they're not rolled back as part of a batch
+	stageTimer := time.Now()
+	operatorState, err := n.ChainState.GetOperatorStateByOperator(ctx, referenceBlockNumber, identity.ID)
+	if err != nil {
+		return nil, err
+	}
+	pool := workerpool.New(n.Config.NumBatchValidators)
+	if err = n.validatorFor(identity.ID).ValidateBlobs(blobs, operatorState, pool); err != nil {
+		return nil, fmt.Errorf("failed to validate blobs: %w", err)
+	}
+	n.Metrics.RecordStoreChunksStage("validated", blobsSize, time.Since(stageTimer))
+
+	stageTimer = time.Now()
+	if _, err = n.Store.StoreBlobs(ctx, blobs, rawBlobs); err != nil {
+		return nil, fmt.Errorf("failed to store blobs: %w", err)
+	}
+	n.Metrics.RecordStoreChunksStage("stored", blobsSize, time.Since(stageTimer))
+
+	stageTimer = time.Now()
+	signatures := make([]*core.Signature, len(blobs))
+	for i, blob := range blobs {
+		blobHeaderHash, err := blob.BlobHeader.GetBlobHeaderHash()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get blob header hash: %w", err)
+		}
+		signatures[i], err = signMessage(ctx, identity.Signer, blobHeaderHash)
+		if err != nil {
+			return nil, fmt.Errorf("failed to sign blob: %w", err)
+		}
+	}
+	n.Metrics.RecordStoreChunksStage("signed", blobsSize, time.Since(stageTimer))
+
+	log.Debug("Exiting process blobs", "duration", time.Since(start))
+	return signatures, nil
+}
+
+// ProcessAttestBatch signs a batch made of blobs which were stored by ProcessBlobs, after checking that they're all
+// stored and that the batch root commits to them. The batch is then mapped to the blobs, so that they can be
+// retrieved by their index in the batch.
+func (n *Node) ProcessAttestBatch(ctx context.Context, identity OperatorIdentity, header *core.BatchHeader, blobHeaderHashes [][32]byte) (*core.Signature, error) {
+	if len(blobHeaderHashes) == 0 {
+		return nil, errors.New("number of blobs must be greater than zero")
+	}
+
+	batchHeaderHash, err := header.GetBatchHeaderHash()
+	if err != nil {
+		return nil, err
+	}
+	if err = n.ValidateBatchContents(ctx, blobHeaderHashes, header); err != nil {
+		return nil, fmt.Errorf("failed to validate batch contents: %w", err)
+	}
+
+	err = n.Store.StoreBatchBlobMapping(ctx, header, blobHeaderHashes)
+	if errors.Is(err, ErrBatchAlreadyExist) {
+		n.Logger.Warn("Batch mapping skipped because the batch already exists in the store", "batchHeaderHash", hex.EncodeToString(batchHeaderHash[:]))
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to store batch mapping: %w", err)
+	}
+
+	if err := n.RecordAttestation(batchHeaderHash, uint64(header.ReferenceBlockNumber), blobHeaderHashes); err != nil {
+		return nil, err
+	}
+	signature, err := signMessage(ctx, identity.Signer, batchHeaderHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign batch: %w", err)
+	}
+	return signature, nil
+}
+
+// ValidateBatchContents checks that the batch root is the merkle root of the blob header hashes, and that every blob
+// of the batch is stored.
+func (n *Node) ValidateBatchContents(ctx context.Context, blobHeaderHashes [][32]byte, header *core.BatchHeader) error {
+	batchHeaderHash, err := header.GetBatchHeaderHash()
+	if err != nil {
+		return fmt.Errorf("failed to get batch header hash: %w", err)
+	}
+	if !n.BatchHeaderCache.Verified(batchHeaderHash, blobHeaderHashes) {
+		expected := &core.BatchHeader{ReferenceBlockNumber: header.ReferenceBlockNumber}
+		tree, err := expected.SetBatchRootFromBlobHeaderHashes(blobHeaderHashes)
+		if err != nil {
+			return fmt.Errorf("failed to compute batch root: %w", err)
+		}
+		if expected.BatchRoot != header.BatchRoot {
+			return errors.New("batch root does not match the blob header hashes")
+		}
+		n.BatchHeaderCache.Add(batchHeaderHash, tree)
+	}
+
+	for _, blobHeaderHash := range blobHeaderHashes {
+		if _, err := n.Store.GetBlobHeaderByHeaderHash(ctx, blobHeaderHash); err != nil {
+			return fmt.Errorf("blob %s is not stored: %w", hex.EncodeToString(blobHeaderHash[:]), err)
+		}
+	}
+	return nil
+}
+
+// RecordAttestation records the attestation of a batch in the signing journal, if enabled, before the batch is
+// signed. It returns an error wrapping ErrConflictingAttestation if the batch conflicts with a batch the node attested
+// to, in which case the batch must not be signed.
+func (n *Node) RecordAttestation(batchHeaderHash [32]byte, referenceBlockNumber uint64, blobKeys [][32]byte) error {
+	if n.SigningJournal == nil {
+		return nil
+	}
+	return n.SigningJournal.Record(batchHeaderHash, referenceBlockNumber, blobKeys, time.Now())
+}
+
+// Exiting returns true if the operator is exiting, in which case the node must not accept new batches.
+func (n *Node) Exiting() bool {
+	return n.Exit != nil && n.Exit.Exiting()
+}
+
+// Deregister deregisters the operator from the quorums of the node. During or after a key rotation, the operator with
+// the new keys is deregistered as well.
+func (n *Node) Deregister(ctx context.Context) error {
+	var rotation *KeyRotationState
+	if n.KeyRotation != nil {
+		rotation = n.KeyRotation.State()
+	}
+	if rotation == nil || !rotation.Retired() {
+		err := deregisterIdentity(ctx, OperatorIdentity{ID: n.Config.ID, Signer: n.BLSSigner}, n.Config.QuorumIDList, n.Transactor)
+		if err != nil {
+			return err
+		}
+	}
+	if rotation != nil && rotation.Registered() {
+		return n.keyRotator.deregisterNewKeys(ctx)
+	}
+	return nil
+}
+
+// IdentityAt returns the operator the node attests as to batches with the given reference block, which changes
+// during a key rotation.
+func (n *Node) IdentityAt(referenceBlockNumber uint64) OperatorIdentity {
+	if n.KeyRotation != nil {
+		return n.KeyRotation.IdentityAt(referenceBlockNumber)
+	}
+	return OperatorIdentity{ID: n.Config.ID, Signer: n.BLSSigner}
+}
+
+// IdentityFor returns the operator the node attests as to a dispersal request for the given operator, which the
+// disperser says in the api.OperatorIDHeader, with the given reference block. Requests which don't say, or which are
+// for another operator, are attested as IdentityAt.
+func (n *Node) IdentityFor(operatorID core.OperatorID, referenceBlockNumber uint64) OperatorIdentity {
+	if n.KeyRotation != nil {
+		return n.KeyRotation.IdentityFor(operatorID, referenceBlockNumber)
+	}
+	return OperatorIdentity{ID: n.Config.ID, Signer: n.BLSSigner}
+}
+
+// validatorFor returns the validator of the v1 chunks assigned to the given operator of the node.
+func (n *Node) validatorFor(operatorID core.OperatorID) core.ShardValidator {
+	if n.keyRotator != nil && operatorID == n.keyRotator.newIdentity.ID {
+		return n.keyRotator.newValidator
+	}
+	return n.Validator
+}
+
+// validatorV2For returns the validator of the chunks assigned to the given operator of the node.
+func (n *Node) validatorV2For(operatorID core.OperatorID) corev2.ShardValidator {
+	if n.keyRotator != nil && operatorID == n.keyRotator.newIdentity.ID {
+		return n.keyRotator.newValidatorV2
+	}
+	return n.ValidatorV2
+}
+
 func (n *Node) SignMessage(ctx context.Context, data [32]byte) (*core.Signature, error) {
-	signature, err := n.BLSSigner.Sign(ctx, data[:])
+	return signMessage(ctx, n.BLSSigner, data)
+}
+
+// signMessage signs data with the BLS key of the signer.
+func signMessage(ctx context.Context, signer blssigner.Signer, data [32]byte) (*core.Signature, error) {
+	signature, err := signer.Sign(ctx, data[:])
 	if err != nil {
 		return nil, fmt.Errorf("failed to sign message: %w", err)
 	}
@@ -545,16 +1064,16 @@ func (n *Node) SignMessage(ctx context.Context, data [32]byte) (*core.Signature,
 	}, nil
 }
 
-func (n *Node) ValidateBatch(ctx context.Context, header *core.BatchHeader, blobs []*core.BlobMessage) error {
+func (n *Node) ValidateBatch(ctx context.Context, operatorID core.OperatorID, header *core.BatchHeader, blobs []*core.BlobMessage) error {
 	start := time.Now()
-	operatorState, err := n.ChainState.GetOperatorStateByOperator(ctx, header.ReferenceBlockNumber, n.Config.ID)
+	operatorState, err := n.ChainState.GetOperatorStateByOperator(ctx, header.ReferenceBlockNumber, operatorID)
 	if err != nil {
 		return err
 	}
 	getStateDuration := time.Since(start)
 
 	pool := workerpool.New(n.Config.NumBatchValidators)
-	err = n.Validator.ValidateBatch(header, blobs, operatorState, pool)
+	err = n.validatorFor(operatorID).ValidateBatch(header, blobs, operatorState, pool)
 	if err != nil {
 		h, hashErr := operatorState.Hash()
 		if hashErr != nil {
@@ -571,23 +1090,6 @@ func (n *Node) ValidateBatch(ctx context.Context, header *core.BatchHeader, blob
 	return nil
 }
 
-func (n *Node) updateSocketAddress(ctx context.Context, newSocketAddr string) {
-	n.mu.Lock()
-	defer n.mu.Unlock()
-	if newSocketAddr == n.CurrentSocket {
-		return
-	}
-
-	if err := n.Transactor.UpdateOperatorSocket(ctx, newSocketAddr); err != nil {
-		n.Logger.Error("failed to update operator's socket", err)
-		return
-	}
-
-	n.Logger.Info("Socket update", "old socket", n.CurrentSocket, "new socket", newSocketAddr)
-	n.Metrics.RecordSocketAddressChange()
-	n.CurrentSocket = newSocketAddr
-}
-
 func (n *Node) checkRegisteredNodeIpOnChain(ctx context.Context) {
 	n.Logger.Info("Start checkRegisteredNodeIpOnChain goroutine in background to subscribe the operator socket change events onchain")
 
@@ -601,31 +1103,7 @@ func (n *Node) checkRegisteredNodeIpOnChain(ctx context.Context) {
 		case <-ctx.Done():
 			return
 		case socket := <-socketChan:
-			n.mu.Lock()
-			if socket != n.CurrentSocket {
-				n.Logger.Info("Detected socket registered onchain which is different than the socket kept at the DA Node", "socket kept at DA Node", n.CurrentSocket, "socket registered onchain", socket, "the action taken", "update the socket kept at DA Node")
-				n.CurrentSocket = socket
-			}
-			n.mu.Unlock()
-		}
-	}
-}
-
-func (n *Node) checkCurrentNodeIp(ctx context.Context) {
-	n.Logger.Info("Start checkCurrentNodeIp goroutine in background to detect the current public IP of the operator node")
-
-	t := time.NewTimer(n.Config.PubIPCheckInterval)
-	for {
-		select {
-		case <-ctx.Done():
-			return
-		case <-t.C:
-			newSocketAddr, err := SocketAddress(ctx, n.PubIPProvider, n.Config.DispersalPort, n.Config.RetrievalPort, n.Config.V2DispersalPort, n.Config.V2RetrievalPort)
-			if err != nil {
-				n.Logger.Error("failed to get socket address", "err", err)
-				continue
-			}
-			n.updateSocketAddress(ctx, newSocketAddr)
+			n.SocketUpdater.SetRegistered(socket)
 		}
 	}
 }
@@ -5,12 +5,17 @@ package node
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/rand"
+	"sort"
 
 	"github.com/Layr-Labs/eigenda/api/clients/v2"
+	"github.com/Layr-Labs/eigenda/common/kvstore"
 	"github.com/Layr-Labs/eigenda/core"
 	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
 	"github.com/gammazero/workerpool"
 )
 
@@ -31,9 +36,15 @@ type response struct {
 type RawBundles struct {
 	BlobCertificate *corev2.BlobCertificate
 	Bundles         map[core.QuorumID][]byte
+	// EncodingParams and Assignments describe the chunks of the bundles, so that they can be re-verified once stored
+	EncodingParams encoding.EncodingParams
+	Assignments    map[core.QuorumID]corev2.Assignment
 }
 
-func (n *Node) DownloadBundles(ctx context.Context, batch *corev2.Batch, operatorState *core.OperatorState) ([]*corev2.BlobShard, []*RawBundles, error) {
+// DownloadBundles downloads the chunks assigned to the given operator of the node for the blobs of the batch from the
+// relays. It returns
+// a *BlobSizeLimitError if a relay sends a bundle larger than the chunks of the blob can be.
+func (n *Node) DownloadBundles(ctx context.Context, operatorID core.OperatorID, batch *corev2.Batch, operatorState *core.OperatorState) ([]*corev2.BlobShard, []*RawBundles, error) {
 	relayClient, ok := n.RelayClient.Load().(clients.RelayClient)
 	if !ok || relayClient == nil {
 		return nil, nil, fmt.Errorf("relay client is not set")
@@ -63,6 +74,7 @@ func (n *Node) DownloadBundles(ctx context.Context, batch *corev2.Batch, operato
 		rawBundles[i] = &RawBundles{
 			BlobCertificate: cert,
 			Bundles:         make(map[core.QuorumID][]byte),
+			Assignments:     make(map[core.QuorumID]corev2.Assignment),
 		}
 		relayIndex := rand.Intn(len(cert.RelayKeys))
 		relayKey := cert.RelayKeys[relayIndex]
@@ -78,11 +90,16 @@ func (n *Node) DownloadBundles(ctx context.Context, batch *corev2.Batch, operato
 				continue
 			}
 
-			assgn, err := corev2.GetAssignment(operatorState, blobParams, quorum, n.Config.ID)
+			assgn, err := corev2.GetAssignment(operatorState, blobParams, quorum, operatorID)
 			if err != nil {
 				n.Logger.Errorf("failed to get assignment: %v", err)
 				continue
 			}
+			rawBundles[i].Assignments[quorum] = assgn
+			rawBundles[i].EncodingParams, err = corev2.GetEncodingParams(cert.BlobHeader.BlobCommitments.Length, blobParams)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to get encoding params of blob %s: %w", blobKey.Hex(), err)
+			}
 
 			req, ok := requests[relayKey]
 			if !ok {
@@ -140,6 +157,20 @@ func (n *Node) DownloadBundles(ctx context.Context, batch *corev2.Batch, operato
 		}
 		for i, bundle := range resp.bundles {
 			metadata := resp.metadata[i]
+			// Oversized bundles are refused before they're deserialized
+			raw := rawBundles[metadata.blobShardIndex]
+			if chunkLength := raw.EncodingParams.ChunkLength; chunkLength > 0 {
+				limit := maxBundleSize(raw.Assignments[metadata.quorum].NumChunks, chunkLength)
+				if uint64(len(bundle)) > limit {
+					return nil, nil, &BlobSizeLimitError{Violations: []BlobSizeViolation{{
+						BlobIndex: metadata.blobShardIndex,
+						Quorum:    metadata.quorum,
+						Bundle:    true,
+						Size:      uint64(len(bundle)),
+						Limit:     limit,
+					}}}
+				}
+			}
 			blobShards[metadata.blobShardIndex].Bundles[metadata.quorum], err = new(core.Bundle).Deserialize(bundle)
 			if err != nil {
 				return nil, nil, fmt.Errorf("failed to deserialize bundle: %v", err)
@@ -151,20 +182,166 @@ func (n *Node) DownloadBundles(ctx context.Context, batch *corev2.Batch, operato
 	return blobShards, rawBundles, nil
 }
 
+// FetchBundle downloads the chunks of a stored blob assigned to the operator in a quorum from one of the relays of
+// the blob. It implements BundleRepairer.
+func (n *Node) FetchBundle(ctx context.Context, blobKey corev2.BlobKey, blob *StoredBlob, quorum core.QuorumID) ([]byte, error) {
+	relayClient, ok := n.RelayClient.Load().(clients.RelayClient)
+	if !ok || relayClient == nil {
+		return nil, fmt.Errorf("relay client is not set")
+	}
+	assgn, ok := blob.Assignments[quorum]
+	if !ok {
+		return nil, fmt.Errorf("no assignment in quorum %d", quorum)
+	}
+	relayKeys := blob.BlobCertificate.RelayKeys
+	if len(relayKeys) == 0 {
+		return nil, fmt.Errorf("no relay keys in the certificate")
+	}
+	relayKey := relayKeys[rand.Intn(len(relayKeys))]
+
+	ctxTimeout, cancel := context.WithTimeout(ctx, n.Config.ChunkDownloadTimeout)
+	defer cancel()
+	bundles, err := relayClient.GetChunksByRange(ctxTimeout, relayKey, []*clients.ChunkRequestByRange{
+		{
+			BlobKey: blobKey,
+			Start:   assgn.StartIndex,
+			End:     assgn.StartIndex + assgn.NumChunks,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chunks from relay %d: %w", relayKey, err)
+	}
+	if len(bundles) != 1 {
+		return nil, fmt.Errorf("expected 1 bundle from relay %d, got %d", relayKey, len(bundles))
+	}
+	return bundles[0], nil
+}
+
+var _ BundleRepairer = &Node{}
+
 func (n *Node) ValidateBatchV2(
 	ctx context.Context,
+	operatorID core.OperatorID,
 	batch *corev2.Batch,
 	blobShards []*corev2.BlobShard,
 	operatorState *core.OperatorState,
 ) error {
-	if n.ValidatorV2 == nil {
+	validator := n.validatorV2For(operatorID)
+	if validator == nil {
 		return fmt.Errorf("store v2 is not set")
 	}
 
-	if err := n.ValidatorV2.ValidateBatchHeader(ctx, batch.BatchHeader, batch.BlobCertificates); err != nil {
+	// The batch header is recomputed from the blob certificates while the blobs are validated
+	headerErr := make(chan error, 1)
+	go func() {
+		headerErr <- validator.ValidateBatchHeader(ctx, batch.BatchHeader, batch.BlobCertificates)
+	}()
+
+	pool := workerpool.New(n.Config.NumBatchValidators)
+	defer pool.Stop()
+	blobVersionParams := n.BlobVersionParams.Load()
+	blobsErr := validator.ValidateBlobs(ctx, blobShards, blobVersionParams, pool, operatorState)
+
+	if err := <-headerErr; err != nil {
 		return fmt.Errorf("failed to validate batch header: %v", err)
 	}
-	pool := workerpool.New(n.Config.NumBatchValidators)
+	return blobsErr
+}
+
+// BundlesSize returns the size, in bytes, of the chunks assigned to the operator for the blobs of the batch, which
+// is the amount of data downloaded from the relays to store the batch.
+func (n *Node) BundlesSize(operatorID core.OperatorID, batch *corev2.Batch, operatorState *core.OperatorState) (uint64, error) {
 	blobVersionParams := n.BlobVersionParams.Load()
-	return n.ValidatorV2.ValidateBlobs(ctx, blobShards, blobVersionParams, pool, operatorState)
+	if blobVersionParams == nil {
+		return 0, fmt.Errorf("blob version params is nil")
+	}
+
+	size := uint64(0)
+	for _, cert := range batch.BlobCertificates {
+		blobParams, ok := blobVersionParams.Get(cert.BlobHeader.BlobVersion)
+		if !ok {
+			return 0, fmt.Errorf("blob version %d not found", cert.BlobHeader.BlobVersion)
+		}
+		chunkLength, err := corev2.GetChunkLength(uint32(cert.BlobHeader.BlobCommitments.Length), blobParams)
+		if err != nil {
+			// Blobs with an invalid length are rejected by the validation
+			continue
+		}
+		chunkSize := uint64(bn254.SizeOfG1AffineCompressed + encoding.BYTES_PER_SYMBOL*int(chunkLength))
+
+		for _, quorum := range cert.BlobHeader.QuorumNumbers {
+			if _, ok := operatorState.Operators[quorum]; !ok {
+				continue
+			}
+			assgn, err := corev2.GetAssignment(operatorState, blobParams, quorum, operatorID)
+			if err != nil {
+				continue
+			}
+			size += uint64(assgn.NumChunks) * chunkSize
+		}
+	}
+	return size, nil
+}
+
+// ChunkProof is a chunk of a stored blob, along with the proofs that the chunk belongs to the blob and that the blob
+// belongs to a batch.
+type ChunkProof struct {
+	// Chunk is the chunk, serialized with Frame.SerializeGnark()
+	Chunk []byte
+	// KZGProof is the KZG proof of the chunk against the commitment of the blob, which starts the serialized chunk
+	KZGProof []byte
+	// Quorum is the quorum in which the chunk is assigned to the operator
+	Quorum          core.QuorumID
+	BlobCertificate *corev2.BlobCertificate
+	// Inclusion is the inclusion of the blob in the batch it was stored with
+	Inclusion *corev2.BlobInclusionInfo
+}
+
+// GetChunkProof returns the chunk at the given index of a stored blob, along with the proofs that it belongs to the
+// blob and that the blob belongs to a batch. It returns an error wrapping ErrChunkNotStored if the chunk isn't
+// assigned to the operator, or the blob isn't stored.
+func (n *Node) GetChunkProof(blobKey corev2.BlobKey, chunkIndex uint32) (*ChunkProof, error) {
+	if n.StoreV2 == nil {
+		return nil, fmt.Errorf("store v2 is not set")
+	}
+	blob, err := n.StoreV2.GetStoredBlob(blobKey)
+	if errors.Is(err, kvstore.ErrNotFound) {
+		return nil, fmt.Errorf("%w: blob %s is not stored", ErrChunkNotStored, blobKey.Hex())
+	}
+	if err != nil {
+		return nil, err
+	}
+	if blob.Inclusion == nil {
+		return nil, fmt.Errorf("the batch of blob %s was not recorded when it was stored", blobKey.Hex())
+	}
+
+	// The chunks of a blob are the same in all quorums, so the chunk is read from the first quorum it's assigned in
+	quorums := make([]core.QuorumID, 0, len(blob.Assignments))
+	for quorum := range blob.Assignments {
+		quorums = append(quorums, quorum)
+	}
+	sort.Slice(quorums, func(i, j int) bool { return quorums[i] < quorums[j] })
+	for _, quorum := range quorums {
+		assignment := blob.Assignments[quorum]
+		if chunkIndex < assignment.StartIndex || chunkIndex-assignment.StartIndex >= assignment.NumChunks {
+			continue
+		}
+		chunks, err := n.StoreV2.GetChunks(blobKey, quorum)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get chunks: %w", err)
+		}
+		position := chunkIndex - assignment.StartIndex
+		if int(position) >= len(chunks) || len(chunks[position]) < bn254.SizeOfG1AffineCompressed {
+			return nil, fmt.Errorf("the stored chunks of blob %s in quorum %d don't match its assignment", blobKey.Hex(), quorum)
+		}
+		chunk := chunks[position]
+		return &ChunkProof{
+			Chunk:           chunk,
+			KZGProof:        chunk[:bn254.SizeOfG1AffineCompressed],
+			Quorum:          quorum,
+			BlobCertificate: blob.BlobCertificate,
+			Inclusion:       blob.Inclusion,
+		}, nil
+	}
+	return nil, fmt.Errorf("%w: chunk %d of blob %s is not assigned to the operator", ErrChunkNotStored, chunkIndex, blobKey.Hex())
 }
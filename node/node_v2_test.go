@@ -54,7 +54,7 @@ func TestDownloadBundles(t *testing.T) {
 	})
 	state, err := c.node.ChainState.GetOperatorStateByOperator(ctx, uint(10), op0)
 	require.NoError(t, err)
-	blobShards, rawBundles, err := c.node.DownloadBundles(ctx, batch, state)
+	blobShards, rawBundles, err := c.node.DownloadBundles(ctx, c.node.Config.ID, batch, state)
 	require.NoError(t, err)
 	require.Len(t, blobShards, 3)
 	require.Equal(t, blobCerts[0], blobShards[0].BlobCertificate)
@@ -123,12 +123,30 @@ func TestDownloadBundlesFail(t *testing.T) {
 	})
 	state, err := c.node.ChainState.GetOperatorState(ctx, uint(10), []core.QuorumID{0, 1, 2})
 	require.NoError(t, err)
-	blobShards, rawBundles, err := c.node.DownloadBundles(ctx, batch, state)
+	blobShards, rawBundles, err := c.node.DownloadBundles(ctx, c.node.Config.ID, batch, state)
 	require.Error(t, err)
 	require.Nil(t, blobShards)
 	require.Nil(t, rawBundles)
 }
 
+func TestDownloadBundlesInvalidLength(t *testing.T) {
+	c := newComponents(t, op0)
+	c.node.RelayClient.Store(c.relayClient)
+	ctx := context.Background()
+	_, batch, _ := nodemock.MockBatch(t)
+
+	// the encoding params of a blob whose length isn't a power of 2 can't be computed
+	batch.BlobCertificates[1].BlobHeader.BlobCommitments.Length = 10
+
+	state, err := c.node.ChainState.GetOperatorStateByOperator(ctx, uint(10), op0)
+	require.NoError(t, err)
+	blobShards, rawBundles, err := c.node.DownloadBundles(ctx, c.node.Config.ID, batch, state)
+	require.ErrorContains(t, err, "failed to get encoding params")
+	require.Nil(t, blobShards)
+	require.Nil(t, rawBundles)
+	c.relayClient.AssertNotCalled(t, "GetChunksByRange", mock.Anything, mock.Anything, mock.Anything)
+}
+
 func TestDownloadBundlesOnlyParticipatingQuorums(t *testing.T) {
 	// Operator 3 is not participating in quorum 2, so it should only download bundles for quorums 0 and 1
 	c := newComponents(t, op3)
@@ -163,7 +181,7 @@ func TestDownloadBundlesOnlyParticipatingQuorums(t *testing.T) {
 	})
 	state, err := c.node.ChainState.GetOperatorStateByOperator(ctx, uint(10), op3)
 	require.NoError(t, err)
-	blobShards, rawBundles, err := c.node.DownloadBundles(ctx, batch, state)
+	blobShards, rawBundles, err := c.node.DownloadBundles(ctx, c.node.Config.ID, batch, state)
 	require.NoError(t, err)
 	require.Len(t, blobShards, 3)
 	require.Equal(t, blobCerts[0], blobShards[0].BlobCertificate)
@@ -16,6 +16,7 @@ import (
 	"github.com/ethereum/go-ethereum/crypto"
 )
 
+// Operator is the on-chain identity of the node. Its keys can't be changed in place, see KeyRotationManager.
 type Operator struct {
 	Address             string
 	Socket              string
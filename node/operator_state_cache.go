@@ -0,0 +1,240 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/core/indexer"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	lru "github.com/hashicorp/golang-lru/v2"
+)
+
+// OperatorStateCacheConfig configures the cache of the operator states the node validates batches against.
+type OperatorStateCacheConfig struct {
+	// Size is the number of operator states cached. 0 disables the cache.
+	Size int
+	// MaxDerivationBlocks is the maximum number of blocks between a cached state and a newer reference block for the
+	// state at the reference block to be derived from the cached state and the stake updates in between, rather
+	// than fetched. 0 disables the derivation.
+	MaxDerivationBlocks uint
+}
+
+// operatorStateKey identifies a cached operator state.
+type operatorStateKey struct {
+	blockNumber uint
+	// query identifies the quorums, or the operator, the state was requested for
+	query string
+}
+
+// operatorStateEntry is an operator state which is fetched, or being fetched. done is closed once state or err is
+// set.
+type operatorStateEntry struct {
+	done  chan struct{}
+	state *core.OperatorState
+	err   error
+}
+
+// OperatorStateCache is a core.ChainState which caches operator states by reference block, so that validating many
+// batches at the same reference block reuses one fetch. Concurrent lookups of the same state wait for a single
+// fetch. The state at a new reference block is derived from the cached state at the closest earlier block and the
+// stake updates emitted in between, if no operator registered, deregistered or changed index in between.
+//
+// The cached states are shared, and must not be modified by the callers.
+type OperatorStateCache struct {
+	core.ChainState
+
+	config OperatorStateCacheConfig
+	// filterer is nil if states aren't derived from stake updates
+	filterer indexer.OperatorStakesFilterer
+	metrics  *Metrics
+	logger   logging.Logger
+
+	mu     sync.Mutex
+	states *lru.Cache[operatorStateKey, *operatorStateEntry]
+}
+
+var _ core.ChainState = (*OperatorStateCache)(nil)
+
+// NewOperatorStateCache creates a new OperatorStateCache in front of the given chain state. filterer may be nil if
+// states aren't derived from stake updates.
+func NewOperatorStateCache(
+	config OperatorStateCacheConfig,
+	chainState core.ChainState,
+	filterer indexer.OperatorStakesFilterer,
+	metrics *Metrics,
+	logger logging.Logger,
+) (*OperatorStateCache, error) {
+	if config.Size <= 0 {
+		return nil, errors.New("the operator state cache size must be positive")
+	}
+	states, err := lru.New[operatorStateKey, *operatorStateEntry](config.Size)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create operator state cache: %w", err)
+	}
+	if config.MaxDerivationBlocks == 0 {
+		filterer = nil
+	}
+	return &OperatorStateCache{
+		ChainState: chainState,
+		config:     config,
+		filterer:   filterer,
+		metrics:    metrics,
+		logger:     logger.With("component", "OperatorStateCache"),
+		states:     states,
+	}, nil
+}
+
+// GetOperatorState returns the state of the operators in the given quorums at the given block.
+func (c *OperatorStateCache) GetOperatorState(ctx context.Context, blockNumber uint, quorums []core.QuorumID) (*core.OperatorState, error) {
+	sorted := make([]core.QuorumID, len(quorums))
+	copy(sorted, quorums)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	key := operatorStateKey{blockNumber: blockNumber, query: fmt.Sprintf("quorums:%v", sorted)}
+	return c.get(ctx, key, func() (*core.OperatorState, error) {
+		return c.ChainState.GetOperatorState(ctx, blockNumber, quorums)
+	})
+}
+
+// GetOperatorStateByOperator returns the state of the operators in the quorums of the given operator at the given
+// block.
+func (c *OperatorStateCache) GetOperatorStateByOperator(ctx context.Context, blockNumber uint, operator core.OperatorID) (*core.OperatorState, error) {
+	key := operatorStateKey{blockNumber: blockNumber, query: "operator:" + operator.Hex()}
+	return c.get(ctx, key, func() (*core.OperatorState, error) {
+		return c.ChainState.GetOperatorStateByOperator(ctx, blockNumber, operator)
+	})
+}
+
+// get returns the cached state for the key, or derives or fetches it.
+func (c *OperatorStateCache) get(ctx context.Context, key operatorStateKey, fetch func() (*core.OperatorState, error)) (*core.OperatorState, error) {
+	c.mu.Lock()
+	if entry, ok := c.states.Get(key); ok {
+		c.mu.Unlock()
+		select {
+		case <-entry.done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		if entry.err == nil {
+			c.recordLookup("cache")
+		}
+		return entry.state, entry.err
+	}
+	base := c.closestEarlierState(key)
+	entry := &operatorStateEntry{done: make(chan struct{})}
+	c.states.Add(key, entry)
+	c.mu.Unlock()
+
+	entry.state, entry.err = c.deriveOrFetch(ctx, key, base, fetch)
+	close(entry.done)
+	if entry.err != nil {
+		// Failures aren't cached, so that the next lookup tries again
+		c.mu.Lock()
+		if cached, ok := c.states.Peek(key); ok && cached == entry {
+			c.states.Remove(key)
+		}
+		c.mu.Unlock()
+	}
+	return entry.state, entry.err
+}
+
+// deriveOrFetch derives the state for the key from the base state if possible, and fetches it otherwise.
+func (c *OperatorStateCache) deriveOrFetch(
+	ctx context.Context,
+	key operatorStateKey,
+	base *core.OperatorState,
+	fetch func() (*core.OperatorState, error),
+) (*core.OperatorState, error) {
+	if base != nil {
+		updates, complete, err := c.filterer.FilterStakeUpdates(ctx, base.BlockNumber, key.blockNumber)
+		if err != nil {
+			c.logger.Warn("Failed to get the stake updates, fetching the operator state", "fromBlock", base.BlockNumber, "toBlock", key.blockNumber, "err", err)
+		} else if complete {
+			if state, ok := applyStakeUpdates(base, updates, key.blockNumber); ok {
+				c.recordLookup("derived")
+				return state, nil
+			}
+		}
+	}
+	state, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+	c.recordLookup("chain")
+	return state, nil
+}
+
+// closestEarlierState returns the fetched state for the same query at the closest earlier block within
+// MaxDerivationBlocks, or nil if there is none or states aren't derived. The caller must hold mu.
+func (c *OperatorStateCache) closestEarlierState(key operatorStateKey) *core.OperatorState {
+	if c.filterer == nil {
+		return nil
+	}
+	var closest *core.OperatorState
+	for _, k := range c.states.Keys() {
+		if k.query != key.query || k.blockNumber >= key.blockNumber || key.blockNumber-k.blockNumber > c.config.MaxDerivationBlocks {
+			continue
+		}
+		if closest != nil && k.blockNumber <= closest.BlockNumber {
+			continue
+		}
+		entry, ok := c.states.Peek(k)
+		if !ok {
+			continue
+		}
+		select {
+		case <-entry.done:
+			if entry.err == nil {
+				closest = entry.state
+			}
+		default:
+		}
+	}
+	return closest
+}
+
+func (c *OperatorStateCache) recordLookup(source string) {
+	if c.metrics != nil {
+		c.metrics.RecordOperatorStateLookup(source)
+	}
+}
+
+// applyStakeUpdates returns a copy of the state with the stake updates applied, at the given block. Updates in
+// quorums which aren't in the state are ignored. It returns false if an update is for an operator which isn't in
+// its quorum, in which case the state can't be derived.
+func applyStakeUpdates(state *core.OperatorState, updates []indexer.OperatorStakeUpdate, blockNumber uint) (*core.OperatorState, bool) {
+	derived := &core.OperatorState{
+		Operators:   make(map[core.QuorumID]map[core.OperatorID]*core.OperatorInfo, len(state.Operators)),
+		Totals:      make(map[core.QuorumID]*core.OperatorInfo, len(state.Totals)),
+		BlockNumber: blockNumber,
+	}
+	for quorum, operators := range state.Operators {
+		derived.Operators[quorum] = make(map[core.OperatorID]*core.OperatorInfo, len(operators))
+		for id, info := range operators {
+			derived.Operators[quorum][id] = &core.OperatorInfo{Stake: new(big.Int).Set(info.Stake), Index: info.Index}
+		}
+	}
+	for quorum, total := range state.Totals {
+		derived.Totals[quorum] = &core.OperatorInfo{Stake: new(big.Int).Set(total.Stake), Index: total.Index}
+	}
+
+	for _, update := range updates {
+		operators, ok := derived.Operators[update.Quorum]
+		if !ok {
+			continue
+		}
+		info, ok := operators[update.Operator]
+		if !ok {
+			return nil, false
+		}
+		total := derived.Totals[update.Quorum].Stake
+		total.Sub(total, info.Stake)
+		total.Add(total, update.Stake)
+		info.Stake = new(big.Int).Set(update.Stake)
+	}
+	return derived, true
+}
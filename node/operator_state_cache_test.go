@@ -0,0 +1,151 @@
+package node_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/common/testutils"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/core/indexer"
+	"github.com/Layr-Labs/eigenda/node"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	stateOperator0 = core.OperatorID{0}
+	stateOperator1 = core.OperatorID{1}
+)
+
+// countingChainState returns the same operator state at every block, counting the fetches.
+type countingChainState struct {
+	core.ChainState
+	fetches atomic.Int32
+	err     error
+}
+
+func (c *countingChainState) state(blockNumber uint) (*core.OperatorState, error) {
+	c.fetches.Add(1)
+	if c.err != nil {
+		return nil, c.err
+	}
+	return &core.OperatorState{
+		Operators: map[core.QuorumID]map[core.OperatorID]*core.OperatorInfo{
+			0: {
+				stateOperator0: {Stake: big.NewInt(10), Index: 0},
+				stateOperator1: {Stake: big.NewInt(30), Index: 1},
+			},
+		},
+		Totals: map[core.QuorumID]*core.OperatorInfo{
+			0: {Stake: big.NewInt(40), Index: 2},
+		},
+		BlockNumber: blockNumber,
+	}, nil
+}
+
+func (c *countingChainState) GetOperatorState(ctx context.Context, blockNumber uint, quorums []core.QuorumID) (*core.OperatorState, error) {
+	return c.state(blockNumber)
+}
+
+func (c *countingChainState) GetOperatorStateByOperator(ctx context.Context, blockNumber uint, operator core.OperatorID) (*core.OperatorState, error) {
+	return c.state(blockNumber)
+}
+
+// fakeStakesFilterer returns the configured stake updates for any range of blocks.
+type fakeStakesFilterer struct {
+	updates  []indexer.OperatorStakeUpdate
+	complete bool
+}
+
+func (f *fakeStakesFilterer) FilterStakeUpdates(ctx context.Context, fromBlock, toBlock uint) ([]indexer.OperatorStakeUpdate, bool, error) {
+	return f.updates, f.complete, nil
+}
+
+func TestOperatorStateCache(t *testing.T) {
+	ctx := context.Background()
+	chainState := &countingChainState{}
+	filterer := &fakeStakesFilterer{complete: true}
+	config := node.OperatorStateCacheConfig{Size: 10, MaxDerivationBlocks: 100}
+	cache, err := node.NewOperatorStateCache(config, chainState, filterer, nil, testutils.GetLogger())
+	require.NoError(t, err)
+
+	// Concurrent lookups at the same reference block share one fetch
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			state, err := cache.GetOperatorStateByOperator(ctx, 1000, stateOperator0)
+			require.NoError(t, err)
+			require.Equal(t, uint(1000), state.BlockNumber)
+		}()
+	}
+	wg.Wait()
+	require.Equal(t, int32(1), chainState.fetches.Load())
+
+	// Other queries at the same block are fetched separately
+	_, err = cache.GetOperatorState(ctx, 1000, []core.QuorumID{0})
+	require.NoError(t, err)
+	require.Equal(t, int32(2), chainState.fetches.Load())
+
+	// A later block is derived from the stake updates
+	filterer.updates = []indexer.OperatorStakeUpdate{{Operator: stateOperator1, Quorum: 0, Stake: big.NewInt(50)}}
+	state, err := cache.GetOperatorStateByOperator(ctx, 1010, stateOperator0)
+	require.NoError(t, err)
+	require.Equal(t, int32(2), chainState.fetches.Load())
+	require.Equal(t, uint(1010), state.BlockNumber)
+	require.Equal(t, big.NewInt(50), state.Operators[0][stateOperator1].Stake)
+	require.Equal(t, big.NewInt(60), state.Totals[0].Stake)
+	require.Equal(t, core.OperatorIndex(2), state.Totals[0].Index)
+	// The cached state is unchanged
+	state, err = cache.GetOperatorStateByOperator(ctx, 1000, stateOperator0)
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(30), state.Operators[0][stateOperator1].Stake)
+	require.Equal(t, big.NewInt(40), state.Totals[0].Stake)
+
+	// Registrations in between require a fetch
+	filterer.complete = false
+	state, err = cache.GetOperatorStateByOperator(ctx, 1020, stateOperator0)
+	require.NoError(t, err)
+	require.Equal(t, int32(3), chainState.fetches.Load())
+	require.Equal(t, big.NewInt(30), state.Operators[0][stateOperator1].Stake)
+
+	// So do updates of unknown operators
+	filterer.complete = true
+	filterer.updates = []indexer.OperatorStakeUpdate{{Operator: core.OperatorID{2}, Quorum: 0, Stake: big.NewInt(5)}}
+	_, err = cache.GetOperatorStateByOperator(ctx, 1030, stateOperator0)
+	require.NoError(t, err)
+	require.Equal(t, int32(4), chainState.fetches.Load())
+
+	// And blocks too far from the cached states
+	filterer.updates = nil
+	_, err = cache.GetOperatorStateByOperator(ctx, 1200, stateOperator0)
+	require.NoError(t, err)
+	require.Equal(t, int32(5), chainState.fetches.Load())
+}
+
+func TestOperatorStateCacheErrors(t *testing.T) {
+	ctx := context.Background()
+	chainState := &countingChainState{err: errors.New("rpc error")}
+	config := node.OperatorStateCacheConfig{Size: 10}
+	cache, err := node.NewOperatorStateCache(config, chainState, nil, nil, testutils.GetLogger())
+	require.NoError(t, err)
+
+	// Failures aren't cached
+	_, err = cache.GetOperatorStateByOperator(ctx, 1000, stateOperator0)
+	require.ErrorContains(t, err, "rpc error")
+	chainState.err = nil
+	_, err = cache.GetOperatorStateByOperator(ctx, 1000, stateOperator0)
+	require.NoError(t, err)
+	_, err = cache.GetOperatorStateByOperator(ctx, 1000, stateOperator0)
+	require.NoError(t, err)
+	require.Equal(t, int32(2), chainState.fetches.Load())
+
+	// Without derivation, every block is fetched
+	_, err = cache.GetOperatorStateByOperator(ctx, 1001, stateOperator0)
+	require.NoError(t, err)
+	require.Equal(t, int32(3), chainState.fetches.Load())
+}
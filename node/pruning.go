@@ -0,0 +1,287 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/core"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/shirou/gopsutil/disk"
+)
+
+// PruningConfig configures the pruning policies of the stored chunks.
+type PruningConfig struct {
+	// Interval is the interval between pruning cycles. 0 disables pruning.
+	Interval time.Duration
+	// QuorumRetention is how long the bundles of each quorum are kept. Quorums without a retention are kept until
+	// they expire.
+	QuorumRetention map[core.QuorumID]time.Duration
+	// MinStakeFraction is the fraction of the chunks of a blob below which the node's bundles are pruned. 0 disables it.
+	MinStakeFraction float64
+	// MaxDiskUsage is the fraction of the disk above which bundles older than DiskPressureRetention are pruned.
+	// 0 disables it.
+	MaxDiskUsage          float64
+	DiskPressureRetention time.Duration
+}
+
+// Policies returns the pruning policies enabled by the config. dbPath is the path of the stores, which is used to
+// measure the disk usage.
+func (c *PruningConfig) Policies(dbPath string) []PruningPolicy {
+	policies := make([]PruningPolicy, 0)
+	if len(c.QuorumRetention) > 0 {
+		policies = append(policies, NewQuorumRetentionPolicy(c.QuorumRetention))
+	}
+	if c.MinStakeFraction > 0 {
+		policies = append(policies, NewStakeThresholdPolicy(c.MinStakeFraction))
+	}
+	if c.MaxDiskUsage > 0 {
+		policies = append(policies, NewDiskPressurePolicy(dbPath, c.MaxDiskUsage, c.DiskPressureRetention))
+	}
+	return policies
+}
+
+// PruningPolicy decides which stored bundles are deleted before they expire.
+type PruningPolicy interface {
+	// Name identifies the policy in logs and metrics.
+	Name() string
+	// Begin is called at the start of each pruning cycle. It returns false if the policy prunes nothing in the cycle,
+	// in which case ShouldPrune isn't called.
+	Begin(now time.Time) (bool, error)
+	// ShouldPrune returns true if the bundle of the blob in the quorum should be deleted at the given time.
+	ShouldPrune(blob *StoredBlob, quorum core.QuorumID, now time.Time) bool
+}
+
+// QuorumRetentionPolicy keeps the bundles of each configured quorum for a fixed period, which may be shorter than
+// the period for which the node is required to store chunks.
+type QuorumRetentionPolicy struct {
+	retention map[core.QuorumID]time.Duration
+}
+
+var _ PruningPolicy = &QuorumRetentionPolicy{}
+
+// NewQuorumRetentionPolicy creates a policy which prunes the bundles of the quorums stored longer than their
+// retention. Quorums without a retention are not pruned.
+func NewQuorumRetentionPolicy(retention map[core.QuorumID]time.Duration) *QuorumRetentionPolicy {
+	return &QuorumRetentionPolicy{retention: retention}
+}
+
+func (p *QuorumRetentionPolicy) Name() string {
+	return "quorum_retention"
+}
+
+func (p *QuorumRetentionPolicy) Begin(now time.Time) (bool, error) {
+	return len(p.retention) > 0, nil
+}
+
+func (p *QuorumRetentionPolicy) ShouldPrune(blob *StoredBlob, quorum core.QuorumID, now time.Time) bool {
+	retention, ok := p.retention[quorum]
+	return ok && now.Sub(blob.StoredAt) > retention
+}
+
+// StakeThresholdPolicy prunes the bundles of blobs in which the node holds a small share of the chunks. The share of
+// the chunks of a blob assigned to the node is proportional to its stake in the quorum when the blob was dispersed,
+// so the pruned bundles are those whose loss affects the availability of the blob the least.
+type StakeThresholdPolicy struct {
+	// minFraction is the fraction of the chunks of a blob below which the node's bundle is pruned
+	minFraction float64
+}
+
+var _ PruningPolicy = &StakeThresholdPolicy{}
+
+// NewStakeThresholdPolicy creates a policy which prunes the bundles holding less than minFraction of the chunks of
+// their blob in the quorum.
+func NewStakeThresholdPolicy(minFraction float64) *StakeThresholdPolicy {
+	return &StakeThresholdPolicy{minFraction: minFraction}
+}
+
+func (p *StakeThresholdPolicy) Name() string {
+	return "stake_threshold"
+}
+
+func (p *StakeThresholdPolicy) Begin(now time.Time) (bool, error) {
+	return p.minFraction > 0, nil
+}
+
+func (p *StakeThresholdPolicy) ShouldPrune(blob *StoredBlob, quorum core.QuorumID, now time.Time) bool {
+	assignment, ok := blob.Assignments[quorum]
+	if !ok || blob.EncodingParams.NumChunks == 0 {
+		return false
+	}
+	return float64(assignment.NumChunks)/float64(blob.EncodingParams.NumChunks) < p.minFraction
+}
+
+// DiskPressurePolicy prunes aggressively while the disk holding the stores is fuller than a threshold: all bundles
+// stored longer than a short retention are deleted, whatever their quorum.
+type DiskPressurePolicy struct {
+	// maxUsage is the fraction of the disk above which bundles are pruned
+	maxUsage float64
+	// retention is how long bundles are kept while the disk is under pressure
+	retention time.Duration
+	// diskUsage returns the used fraction of the disk
+	diskUsage func() (float64, error)
+}
+
+var _ PruningPolicy = &DiskPressurePolicy{}
+
+// NewDiskPressurePolicy creates a policy which prunes the bundles stored longer than retention while the disk
+// holding path is more than maxUsage full.
+func NewDiskPressurePolicy(path string, maxUsage float64, retention time.Duration) *DiskPressurePolicy {
+	return &DiskPressurePolicy{
+		maxUsage:  maxUsage,
+		retention: retention,
+		diskUsage: func() (float64, error) {
+			usage, err := disk.Usage(path)
+			if err != nil {
+				return 0, err
+			}
+			return usage.UsedPercent / 100, nil
+		},
+	}
+}
+
+func (p *DiskPressurePolicy) Name() string {
+	return "disk_pressure"
+}
+
+func (p *DiskPressurePolicy) Begin(now time.Time) (bool, error) {
+	if p.maxUsage <= 0 {
+		return false, nil
+	}
+	usage, err := p.diskUsage()
+	if err != nil {
+		return false, fmt.Errorf("failed to get disk usage: %w", err)
+	}
+	return usage > p.maxUsage, nil
+}
+
+func (p *DiskPressurePolicy) ShouldPrune(blob *StoredBlob, quorum core.QuorumID, now time.Time) bool {
+	return now.Sub(blob.StoredAt) > p.retention
+}
+
+// PruningReport is the result of a pruning cycle.
+type PruningReport struct {
+	// NumBundles is the number of bundles pruned by each policy
+	NumBundles map[string]int
+	// Size is the total size of the pruned bundles, in bytes
+	Size uint64
+}
+
+// PruningManager evaluates the pruning policies over the bundles of the v2 store, and deletes the bundles which any
+// policy selects, on top of the expiry of the bundles at the end of their storage period. The disk space of the
+// pruned bundles is freed in the quorum quotas, so that quorums at their quota can accept chunks again.
+type PruningManager struct {
+	store    StoreV2
+	policies []PruningPolicy
+	// quotas tracks the disk usage of quorums with a disk quota. Nil if no quorum has a quota.
+	quotas  *QuorumQuotaTracker
+	metrics *Metrics
+	logger  logging.Logger
+
+	// mu serializes the pruning cycles
+	mu sync.Mutex
+}
+
+// NewPruningManager creates a new PruningManager with the given policies.
+func NewPruningManager(
+	store StoreV2,
+	policies []PruningPolicy,
+	quotas *QuorumQuotaTracker,
+	metrics *Metrics,
+	logger logging.Logger,
+) *PruningManager {
+	return &PruningManager{
+		store:    store,
+		policies: policies,
+		quotas:   quotas,
+		metrics:  metrics,
+		logger:   logger.With("component", "PruningManager"),
+	}
+}
+
+// Start runs a pruning cycle once per interval in the background, until the context is cancelled.
+func (m *PruningManager) Start(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				report, err := m.RunPruningCycle(time.Now())
+				if err != nil {
+					m.logger.Error("Failed to prune stored bundles", "err", err)
+					continue
+				}
+				m.logger.Info("Completed a pruning cycle", "bundles", report.NumBundles, "bytes", report.Size)
+			}
+		}
+	}()
+}
+
+// RunPruningCycle deletes the stored bundles selected by the policies which are active at the given time. A bundle
+// selected by several policies is attributed to the first of them.
+func (m *PruningManager) RunPruningCycle(now time.Time) (*PruningReport, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	report := &PruningReport{NumBundles: make(map[string]int)}
+	active := make([]PruningPolicy, 0, len(m.policies))
+	var policyErrs error
+	for _, policy := range m.policies {
+		ok, err := policy.Begin(now)
+		if err != nil {
+			// The other policies still run, so that one failing policy doesn't stop all pruning
+			policyErrs = errors.Join(policyErrs, fmt.Errorf("policy %s: %w", policy.Name(), err))
+			continue
+		}
+		if ok {
+			active = append(active, policy)
+		}
+	}
+	if len(active) == 0 {
+		return report, policyErrs
+	}
+
+	type bundleID struct {
+		blobKey corev2.BlobKey
+		quorum  core.QuorumID
+	}
+	prunedBy := make(map[bundleID]string)
+	pruned, err := m.store.PruneBundles(func(blobKey corev2.BlobKey, blob *StoredBlob, quorum core.QuorumID) bool {
+		for _, policy := range active {
+			if policy.ShouldPrune(blob, quorum, now) {
+				prunedBy[bundleID{blobKey: blobKey, quorum: quorum}] = policy.Name()
+				return true
+			}
+		}
+		return false
+	})
+	if err != nil {
+		return nil, errors.Join(policyErrs, fmt.Errorf("failed to prune bundles: %w", err))
+	}
+
+	freed := make(map[time.Time]map[core.QuorumID]uint64)
+	for _, bundle := range pruned {
+		policy := prunedBy[bundleID{blobKey: bundle.BlobKey, quorum: bundle.Quorum}]
+		report.NumBundles[policy]++
+		report.Size += bundle.Size
+		if m.metrics != nil {
+			m.metrics.RecordPrunedBundle(policy, bundle.Quorum, bundle.Size)
+		}
+		if _, ok := freed[bundle.StoredAt]; !ok {
+			freed[bundle.StoredAt] = make(map[core.QuorumID]uint64)
+		}
+		freed[bundle.StoredAt][bundle.Quorum] += bundle.Size
+	}
+	if m.quotas != nil {
+		for storedAt, sizes := range freed {
+			m.quotas.Free(sizes, storedAt)
+		}
+	}
+	return report, policyErrs
+}
@@ -0,0 +1,106 @@
+package node_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common/kvstore"
+	"github.com/Layr-Labs/eigenda/common/testutils"
+	"github.com/Layr-Labs/eigenda/core"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/Layr-Labs/eigenda/node"
+	nodemock "github.com/Layr-Labs/eigenda/node/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPruningManager(t *testing.T) {
+	logger := testutils.GetLogger()
+	blobKeys, batch, bundles := nodemock.MockBatch(t)
+
+	// The node holds 1 of the 8 chunks of the blob in quorum 0, and 4 of them in quorum 1
+	batch.BlobCertificates = batch.BlobCertificates[:1]
+	rawBundles := &node.RawBundles{
+		BlobCertificate: batch.BlobCertificates[0],
+		Bundles:         make(map[core.QuorumID][]byte),
+		EncodingParams:  encoding.EncodingParams{NumChunks: 8, ChunkLength: 2},
+		Assignments: map[core.QuorumID]corev2.Assignment{
+			0: {StartIndex: 0, NumChunks: 1},
+			1: {StartIndex: 0, NumChunks: 4},
+		},
+	}
+	for quorum, bundle := range bundles[0] {
+		bundleBytes, err := bundle.Serialize()
+		require.NoError(t, err)
+		rawBundles.Bundles[quorum] = bundleBytes
+	}
+
+	s, db := createStoreV2(t)
+	defer func() {
+		_ = db.Shutdown()
+	}()
+	quotas, err := node.NewQuorumQuotaTracker(map[core.QuorumID]uint64{0: 1 << 20}, 1, 10*time.Second, nil, nil)
+	require.NoError(t, err)
+	_, err = quotas.Reserve(node.BundleSizesByQuorum([]*node.RawBundles{rawBundles}), time.Now())
+	require.NoError(t, err)
+	_, _, err = s.StoreBatch(batch, []*node.RawBundles{rawBundles})
+	require.NoError(t, err)
+	require.Equal(t, uint64(len(rawBundles.Bundles[0])), quotas.Usage(0, time.Now()))
+
+	policies := []node.PruningPolicy{
+		node.NewStakeThresholdPolicy(0.25),
+		node.NewQuorumRetentionPolicy(map[core.QuorumID]time.Duration{1: time.Hour}),
+	}
+	pruner := node.NewPruningManager(s, policies, quotas, nil, logger)
+
+	// Only the bundle of quorum 0 holds less than a quarter of the chunks
+	report, err := pruner.RunPruningCycle(time.Now())
+	require.NoError(t, err)
+	require.Equal(t, map[string]int{"stake_threshold": 1}, report.NumBundles)
+	require.Equal(t, uint64(len(rawBundles.Bundles[0])), report.Size)
+	_, err = s.GetChunks(blobKeys[0], 0)
+	require.True(t, errors.Is(err, kvstore.ErrNotFound))
+	_, err = s.GetChunks(blobKeys[0], 1)
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), quotas.Usage(0, time.Now()))
+
+	// The pruned quorum is dropped from the blob's metadata
+	blobs, err := s.SampleBlobs(1)
+	require.NoError(t, err)
+	require.Len(t, blobs, 1)
+	require.NotContains(t, blobs[0].Assignments, core.QuorumID(0))
+
+	// Nothing else is pruned until the retention of quorum 1 elapses
+	report, err = pruner.RunPruningCycle(time.Now())
+	require.NoError(t, err)
+	require.Empty(t, report.NumBundles)
+
+	report, err = pruner.RunPruningCycle(time.Now().Add(2 * time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, map[string]int{"quorum_retention": 1}, report.NumBundles)
+	_, err = s.GetChunks(blobKeys[0], 1)
+	require.True(t, errors.Is(err, kvstore.ErrNotFound))
+
+	// The blob is deleted along with its last bundle
+	blobs, err = s.SampleBlobs(1)
+	require.NoError(t, err)
+	require.Empty(t, blobs)
+}
+
+func TestDiskPressurePolicy(t *testing.T) {
+	now := time.Now()
+	blob := &node.StoredBlob{StoredAt: now.Add(-2 * time.Hour)}
+
+	policy := node.NewDiskPressurePolicy(t.TempDir(), 1e-9, time.Hour)
+	active, err := policy.Begin(now)
+	require.NoError(t, err)
+	require.True(t, active)
+	require.True(t, policy.ShouldPrune(blob, 0, now))
+	require.False(t, policy.ShouldPrune(blob, 0, now.Add(-90*time.Minute)))
+
+	policy = node.NewDiskPressurePolicy(t.TempDir(), 1-1e-9, time.Hour)
+	active, err = policy.Begin(now)
+	require.NoError(t, err)
+	require.False(t, active)
+}
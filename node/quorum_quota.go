@@ -0,0 +1,271 @@
+package node
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/core"
+)
+
+// QuotaExceededError is returned when storing bundles would take quorums over their disk quotas.
+type QuotaExceededError struct {
+	// Violations are the quorums whose quota would be exceeded, sorted by quorum ID
+	Violations []QuorumQuotaViolation
+}
+
+// QuorumQuotaViolation describes a quorum whose disk quota would be exceeded.
+type QuorumQuotaViolation struct {
+	Quorum core.QuorumID
+	// Usage is the disk space used by the quorum, in bytes
+	Usage uint64
+	// Requested is the disk space which the request would add, in bytes
+	Requested uint64
+	// Limit is the disk space at which the quorum stops accepting new chunks, in bytes
+	Limit uint64
+}
+
+func (e *QuotaExceededError) Error() string {
+	quorums := make([]core.QuorumID, len(e.Violations))
+	for i, violation := range e.Violations {
+		quorums[i] = violation.Quorum
+	}
+	return fmt.Sprintf("disk quota exceeded for quorums %v", quorums)
+}
+
+// QuotaReservation is the disk space reserved for the bundles of a request, which is released if they aren't stored.
+type QuotaReservation struct {
+	expiryMinute int64
+	sizes        map[core.QuorumID]uint64
+}
+
+// QuorumQuotaTracker tracks the disk space used by the bundles of each quorum with a disk quota, and refuses to
+// reserve space for new bundles of a quorum once its usage reaches a high watermark of its quota, so that one
+// quorum's growth can't take the disk space needed by the others.
+//
+// Usage is tracked in memory, bucketed by the minute at which bundles expire, and is dropped once they have expired.
+// Bundles are accounted with the default TTL of the store, so bundles of blobs with a shorter retention period are
+// counted until the default TTL elapses, which errs on the side of refusing chunks.
+type QuorumQuotaTracker struct {
+	// quotas are the disk quotas of the quorums, in bytes. Quorums without a quota are not tracked.
+	quotas map[core.QuorumID]uint64
+	// highWatermark is the fraction of its quota at which a quorum stops accepting new bundles
+	highWatermark float64
+	// ttl is the time after which stored bundles expire
+	ttl     time.Duration
+	metrics *Metrics
+
+	mu sync.Mutex
+	// usage is the size of the bundles of each quorum, by the UNIX minute at which they expire
+	usage map[core.QuorumID]map[int64]uint64
+}
+
+// NewQuorumQuotaTracker creates a new QuorumQuotaTracker. initialUsage is the disk space already used by each
+// quorum, which is assumed to expire after the full TTL.
+func NewQuorumQuotaTracker(
+	quotas map[core.QuorumID]uint64,
+	highWatermark float64,
+	ttl time.Duration,
+	initialUsage map[core.QuorumID]uint64,
+	metrics *Metrics,
+) (*QuorumQuotaTracker, error) {
+	if highWatermark <= 0 || highWatermark > 1 {
+		return nil, fmt.Errorf("disk quota high watermark must be in (0, 1], got %f", highWatermark)
+	}
+	tracker := &QuorumQuotaTracker{
+		quotas:        quotas,
+		highWatermark: highWatermark,
+		ttl:           ttl,
+		metrics:       metrics,
+		usage:         make(map[core.QuorumID]map[int64]uint64, len(quotas)),
+	}
+	for quorum := range quotas {
+		tracker.usage[quorum] = make(map[int64]uint64)
+	}
+
+	now := time.Now()
+	tracker.mu.Lock()
+	defer tracker.mu.Unlock()
+	tracker.add(initialUsage, tracker.expiryMinute(now))
+	tracker.reportUsage(now)
+	return tracker, nil
+}
+
+// SetQuotas replaces the disk quotas and the high watermark. Quorums whose quota is removed are unlimited, while
+// quotas can't be added to quorums which had none when the tracker was created, since their usage isn't tracked.
+func (t *QuorumQuotaTracker) SetQuotas(quotas map[core.QuorumID]uint64, highWatermark float64) error {
+	if highWatermark <= 0 || highWatermark > 1 {
+		return fmt.Errorf("disk quota high watermark must be in (0, 1], got %f", highWatermark)
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for quorum := range quotas {
+		if _, ok := t.usage[quorum]; !ok {
+			return fmt.Errorf("the disk usage of quorum %d isn't tracked, adding a disk quota to it requires a restart", quorum)
+		}
+	}
+	t.quotas = quotas
+	t.highWatermark = highWatermark
+	t.reportUsage(time.Now())
+	return nil
+}
+
+// Quotas returns the disk quotas of the quorums, in bytes.
+func (t *QuorumQuotaTracker) Quotas() map[core.QuorumID]uint64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	quotas := make(map[core.QuorumID]uint64, len(t.quotas))
+	for quorum, quota := range t.quotas {
+		quotas[quorum] = quota
+	}
+	return quotas
+}
+
+// Reserve reserves disk space for bundles of the given sizes, by quorum, which are stored at the given time. It
+// returns a *QuotaExceededError if any quorum would go over the high watermark of its quota, in which case nothing
+// is reserved.
+func (t *QuorumQuotaTracker) Reserve(sizes map[core.QuorumID]uint64, now time.Time) (*QuotaReservation, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	violations := make([]QuorumQuotaViolation, 0)
+	for quorum, size := range sizes {
+		quota, ok := t.quotas[quorum]
+		if !ok {
+			continue
+		}
+		usage := t.quorumUsage(quorum, now)
+		limit := uint64(float64(quota) * t.highWatermark)
+		if usage+size > limit {
+			violations = append(violations, QuorumQuotaViolation{
+				Quorum:    quorum,
+				Usage:     usage,
+				Requested: size,
+				Limit:     limit,
+			})
+		}
+	}
+	if len(violations) > 0 {
+		sort.Slice(violations, func(i, j int) bool {
+			return violations[i].Quorum < violations[j].Quorum
+		})
+		if t.metrics != nil {
+			for _, violation := range violations {
+				t.metrics.RecordQuotaRejection(violation.Quorum)
+			}
+		}
+		return nil, &QuotaExceededError{Violations: violations}
+	}
+
+	reservation := &QuotaReservation{
+		expiryMinute: t.expiryMinute(now),
+		sizes:        sizes,
+	}
+	t.add(sizes, reservation.expiryMinute)
+	t.reportUsage(now)
+	return reservation, nil
+}
+
+// Release releases the disk space of a reservation whose bundles weren't stored.
+func (t *QuorumQuotaTracker) Release(reservation *QuotaReservation) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for quorum, size := range reservation.sizes {
+		buckets, ok := t.usage[quorum]
+		if !ok {
+			continue
+		}
+		if buckets[reservation.expiryMinute] <= size {
+			delete(buckets, reservation.expiryMinute)
+		} else {
+			buckets[reservation.expiryMinute] -= size
+		}
+	}
+	t.reportUsage(time.Now())
+}
+
+// Free releases the disk space of bundles stored at the given time which were deleted before they expired. Bundles
+// which were stored before the tracker was created may not be found, in which case their space stays accounted until
+// their expiry.
+func (t *QuorumQuotaTracker) Free(sizes map[core.QuorumID]uint64, storedAt time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	// The space was reserved shortly before the bundles were stored, possibly in the previous minute
+	expiryMinute := t.expiryMinute(storedAt)
+	for quorum, size := range sizes {
+		buckets, ok := t.usage[quorum]
+		if !ok {
+			continue
+		}
+		for _, minute := range []int64{expiryMinute, expiryMinute - 1} {
+			freed := min(size, buckets[minute])
+			size -= freed
+			if buckets[minute] == freed {
+				delete(buckets, minute)
+			} else {
+				buckets[minute] -= freed
+			}
+		}
+	}
+	t.reportUsage(time.Now())
+}
+
+// Usage returns the disk space used by the bundles of the quorum at the given time, in bytes.
+func (t *QuorumQuotaTracker) Usage(quorum core.QuorumID, now time.Time) uint64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.quorumUsage(quorum, now)
+}
+
+// quorumUsage returns the usage of the quorum, dropping the buckets which have expired. The caller must hold the lock.
+func (t *QuorumQuotaTracker) quorumUsage(quorum core.QuorumID, now time.Time) uint64 {
+	currentMinute := now.Unix() / 60
+	usage := uint64(0)
+	for expiryMinute, size := range t.usage[quorum] {
+		if expiryMinute < currentMinute {
+			delete(t.usage[quorum], expiryMinute)
+			continue
+		}
+		usage += size
+	}
+	return usage
+}
+
+// add adds the sizes of the quorums with a quota to the given bucket. The caller must hold the lock.
+func (t *QuorumQuotaTracker) add(sizes map[core.QuorumID]uint64, expiryMinute int64) {
+	for quorum, size := range sizes {
+		if buckets, ok := t.usage[quorum]; ok && size > 0 {
+			buckets[expiryMinute] += size
+		}
+	}
+}
+
+// expiryMinute returns the UNIX minute at which bundles stored at the given time expire, rounded up.
+func (t *QuorumQuotaTracker) expiryMinute(now time.Time) int64 {
+	expiry := now.Add(t.ttl).Unix()
+	return (expiry + 59) / 60
+}
+
+// reportUsage updates the usage metrics of all quorums. The caller must hold the lock.
+func (t *QuorumQuotaTracker) reportUsage(now time.Time) {
+	if t.metrics == nil {
+		return
+	}
+	for quorum, quota := range t.quotas {
+		t.metrics.ReportQuorumDiskUsage(quorum, t.quorumUsage(quorum, now), quota)
+	}
+}
+
+// BundleSizesByQuorum returns the total size of the given bundles of each quorum, in bytes.
+func BundleSizesByQuorum(rawBundles []*RawBundles) map[core.QuorumID]uint64 {
+	sizes := make(map[core.QuorumID]uint64)
+	for _, bundles := range rawBundles {
+		for quorum, bundle := range bundles.Bundles {
+			sizes[quorum] += uint64(len(bundle))
+		}
+	}
+	return sizes
+}
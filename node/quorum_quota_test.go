@@ -0,0 +1,59 @@
+package node_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/node"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuorumQuotaTracker(t *testing.T) {
+	ttl := time.Hour
+	tracker, err := node.NewQuorumQuotaTracker(
+		map[core.QuorumID]uint64{0: 1000, 1: 100},
+		0.9,
+		ttl,
+		map[core.QuorumID]uint64{0: 500, 2: 1 << 40},
+		nil)
+	require.NoError(t, err)
+
+	now := time.Now()
+	assert.Equal(t, uint64(500), tracker.Usage(0, now))
+	assert.Equal(t, uint64(0), tracker.Usage(1, now))
+	// Quorums without a quota aren't tracked
+	assert.Equal(t, uint64(0), tracker.Usage(2, now))
+
+	// Within the high watermark of both quorums
+	reservation, err := tracker.Reserve(map[core.QuorumID]uint64{0: 300, 1: 90, 2: 1 << 40}, now)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(800), tracker.Usage(0, now))
+	assert.Equal(t, uint64(90), tracker.Usage(1, now))
+
+	// Quorum 0 would go over the high watermark of 900 bytes, so nothing is reserved
+	_, err = tracker.Reserve(map[core.QuorumID]uint64{0: 101, 1: 0}, now)
+	var quotaErr *node.QuotaExceededError
+	require.True(t, errors.As(err, &quotaErr))
+	require.Len(t, quotaErr.Violations, 1)
+	assert.Equal(t, node.QuorumQuotaViolation{Quorum: 0, Usage: 800, Requested: 101, Limit: 900}, quotaErr.Violations[0])
+	assert.Equal(t, uint64(800), tracker.Usage(0, now))
+
+	// Releasing a reservation frees its space
+	tracker.Release(reservation)
+	assert.Equal(t, uint64(500), tracker.Usage(0, now))
+	assert.Equal(t, uint64(0), tracker.Usage(1, now))
+	_, err = tracker.Reserve(map[core.QuorumID]uint64{0: 101}, now)
+	require.NoError(t, err)
+
+	// Usage is dropped once it expires
+	later := now.Add(ttl + 2*time.Minute)
+	assert.Equal(t, uint64(0), tracker.Usage(0, later))
+	_, err = tracker.Reserve(map[core.QuorumID]uint64{0: 900}, later)
+	require.NoError(t, err)
+
+	_, err = node.NewQuorumQuotaTracker(map[core.QuorumID]uint64{0: 1000}, 1.5, ttl, nil, nil)
+	require.Error(t, err)
+}
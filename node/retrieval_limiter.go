@@ -0,0 +1,264 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+	"golang.org/x/time/rate"
+)
+
+// RetrievalLimitConfig configures the limits on the chunk retrievals served by the node to each retriever. The
+// bandwidth of each retriever is limited separately, by the egress BandwidthLimitConfig.
+type RetrievalLimitConfig struct {
+	// PeerRequestsPerSecond is the maximum rate of retrieval requests of a single peer. 0 means unlimited.
+	PeerRequestsPerSecond float64 `json:"peer_requests_per_second"`
+	// PeerBurstiness is the maximum number of retrieval requests a single peer can send at once. 0 means one second
+	// worth of PeerRequestsPerSecond, and at least one.
+	PeerBurstiness int `json:"peer_burstiness"`
+	// MaxConcurrentRequests is the maximum number of retrieval requests served at once across all peers. Requests
+	// beyond it wait, and are served in turn across peers so that a peer sending many requests can't delay the
+	// requests of the others. 0 means unlimited.
+	MaxConcurrentRequests int `json:"max_concurrent_requests"`
+	// MaxQueuedRequestsPerPeer is the maximum number of requests of a single peer waiting to be served. Requests
+	// beyond it are refused.
+	MaxQueuedRequestsPerPeer int `json:"max_queued_requests_per_peer"`
+}
+
+// validate checks that the limits aren't negative.
+func (c *RetrievalLimitConfig) validate() error {
+	if c.PeerRequestsPerSecond < 0 || c.PeerBurstiness < 0 || c.MaxConcurrentRequests < 0 || c.MaxQueuedRequestsPerPeer < 0 {
+		return errors.New("retrieval limits must not be negative")
+	}
+	return nil
+}
+
+// RetrievalLimitError is returned when a retrieval request is refused because it exceeds a limit.
+type RetrievalLimitError struct {
+	// Reason is the limit which was exceeded, "rate" or "queue"
+	Reason string
+	// RetryAfter is the time after which the request would be allowed. It's 0 if unknown.
+	RetryAfter time.Duration
+	msg        string
+}
+
+func (e *RetrievalLimitError) Error() string {
+	return e.msg
+}
+
+// RetrievalLimiter enforces per-peer request rates on the chunk retrievals served by the node, and schedules the
+// requests fairly across peers when the node is serving as many requests as it can at once. Waiting requests are
+// served round-robin across peers, so one aggressive retriever can't crowd out the others.
+type RetrievalLimiter struct {
+	metrics *Metrics
+
+	// mu guards the fields below, and the creation of the rate limiters of new peers
+	mu     sync.Mutex
+	config RetrievalLimitConfig
+	// peers limits the request rate of each peer. It's nil if unlimited.
+	peers *lru.Cache[string, *rate.Limiter]
+	// active is the number of requests being served
+	active int
+	// queues are the requests waiting to be served, by peer
+	queues map[string][]chan struct{}
+	// turns is the order in which the peers with waiting requests are served
+	turns []string
+}
+
+// NewRetrievalLimiter creates a new RetrievalLimiter. It returns nil, which doesn't enforce any limit, if neither the
+// per-peer rate nor the concurrency is limited.
+func NewRetrievalLimiter(config RetrievalLimitConfig, metrics *Metrics) (*RetrievalLimiter, error) {
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+	if config.PeerRequestsPerSecond == 0 && config.MaxConcurrentRequests == 0 {
+		return nil, nil
+	}
+	return newRetrievalLimiter(config, metrics)
+}
+
+// newRetrievalLimiter creates a new RetrievalLimiter even if nothing is limited, so that limits can be set later with
+// SetConfig.
+func newRetrievalLimiter(config RetrievalLimitConfig, metrics *Metrics) (*RetrievalLimiter, error) {
+	limiter := &RetrievalLimiter{
+		metrics: metrics,
+		queues:  make(map[string][]chan struct{}),
+	}
+	if err := limiter.SetConfig(config); err != nil {
+		return nil, err
+	}
+	return limiter, nil
+}
+
+// SetConfig replaces the limits. The budgets of the peers are reset if the per-peer rate changes. Waiting requests
+// are admitted if the new concurrency limit allows it.
+func (l *RetrievalLimiter) SetConfig(config RetrievalLimitConfig) error {
+	if err := config.validate(); err != nil {
+		return err
+	}
+	if config.PeerRequestsPerSecond > 0 && config.PeerBurstiness == 0 {
+		config.PeerBurstiness = max(1, int(config.PeerRequestsPerSecond))
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	peers := l.peers
+	if config.PeerRequestsPerSecond == 0 {
+		peers = nil
+	} else if peers == nil || config.PeerRequestsPerSecond != l.config.PeerRequestsPerSecond || config.PeerBurstiness != l.config.PeerBurstiness {
+		var err error
+		peers, err = lru.New[string, *rate.Limiter](bandwidthLimiterPeerCacheSize)
+		if err != nil {
+			return fmt.Errorf("failed to create peer cache: %w", err)
+		}
+	}
+	l.peers = peers
+	l.config = config
+
+	// Give the slots the new limit frees to the waiting requests
+	for len(l.turns) > 0 && (config.MaxConcurrentRequests == 0 || l.active < config.MaxConcurrentRequests) {
+		l.active++
+		l.releaseLocked()
+	}
+	return nil
+}
+
+// Acquire admits a retrieval request of the peer, waiting for its turn if the node is serving as many requests as
+// it can. The returned function must be called once the request is served. It returns a *RetrievalLimitError if the
+// request exceeds the peer's rate or queue limit, or the context's error if it's done before the request's turn.
+func (l *RetrievalLimiter) Acquire(ctx context.Context, now time.Time, peer string) (func(), error) {
+	if l == nil {
+		// If the limiter is nil, do not enforce retrieval limits.
+		return func() {}, nil
+	}
+
+	l.mu.Lock()
+	if l.peers != nil {
+		reservation := l.peerLimiter(peer).ReserveN(now, 1)
+		if delay := reservation.DelayFrom(now); delay > 0 {
+			reservation.CancelAt(now)
+			config := l.config
+			l.mu.Unlock()
+			l.recordLimited("rate")
+			return nil, &RetrievalLimitError{
+				Reason:     "rate",
+				RetryAfter: delay,
+				msg: fmt.Sprintf("retrieval rate limit of %0.1f requests/s (burstiness %d) exceeded, try again later",
+					config.PeerRequestsPerSecond, config.PeerBurstiness),
+			}
+		}
+	}
+
+	// Requests are counted even when the concurrency is unlimited, so that a limit set later accounts for them
+	if l.config.MaxConcurrentRequests == 0 || (l.active < l.config.MaxConcurrentRequests && len(l.turns) == 0) {
+		l.active++
+		l.mu.Unlock()
+		return l.release, nil
+	}
+	queue := l.queues[peer]
+	if len(queue) >= l.config.MaxQueuedRequestsPerPeer {
+		limit := l.config.MaxQueuedRequestsPerPeer
+		l.mu.Unlock()
+		l.recordLimited("queue")
+		return nil, &RetrievalLimitError{
+			Reason: "queue",
+			msg:    fmt.Sprintf("too many retrieval requests waiting (limit %d), try again later", limit),
+		}
+	}
+	turn := make(chan struct{})
+	if len(queue) == 0 {
+		l.turns = append(l.turns, peer)
+	}
+	l.queues[peer] = append(queue, turn)
+	l.mu.Unlock()
+
+	select {
+	case <-turn:
+		return l.release, nil
+	case <-ctx.Done():
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		if !l.dequeue(peer, turn) {
+			// The turn was given concurrently, pass it on
+			l.releaseLocked()
+		}
+		return nil, ctx.Err()
+	}
+}
+
+// NumQueued returns the number of requests of the peer waiting to be served.
+func (l *RetrievalLimiter) NumQueued(peer string) int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return len(l.queues[peer])
+}
+
+// release ends a request, and gives its slot to the next waiting request.
+func (l *RetrievalLimiter) release() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.releaseLocked()
+}
+
+// releaseLocked ends a request, and gives its slot to the first waiting request of the next peer in turn, which then
+// goes to the back of the line if it has more requests waiting. The slot is dropped instead if the limit was lowered
+// below the number of requests being served. The caller must hold mu.
+func (l *RetrievalLimiter) releaseLocked() {
+	if len(l.turns) == 0 || (l.config.MaxConcurrentRequests > 0 && l.active > l.config.MaxConcurrentRequests) {
+		l.active--
+		return
+	}
+	peer := l.turns[0]
+	l.turns = l.turns[1:]
+	queue := l.queues[peer]
+	close(queue[0])
+	if len(queue) == 1 {
+		delete(l.queues, peer)
+	} else {
+		l.queues[peer] = queue[1:]
+		l.turns = append(l.turns, peer)
+	}
+}
+
+// dequeue removes a waiting request of the peer, returning false if it isn't waiting anymore. The caller must hold mu.
+func (l *RetrievalLimiter) dequeue(peer string, turn chan struct{}) bool {
+	queue := l.queues[peer]
+	for i, t := range queue {
+		if t != turn {
+			continue
+		}
+		if len(queue) > 1 {
+			l.queues[peer] = append(queue[:i:i], queue[i+1:]...)
+			return true
+		}
+		delete(l.queues, peer)
+		for j, p := range l.turns {
+			if p == peer {
+				l.turns = append(l.turns[:j:j], l.turns[j+1:]...)
+				break
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// peerLimiter returns the request rate limiter of the peer, creating it if the peer is new. The caller must hold mu.
+func (l *RetrievalLimiter) peerLimiter(peer string) *rate.Limiter {
+	limiter, ok := l.peers.Get(peer)
+	if !ok {
+		limiter = rate.NewLimiter(rate.Limit(l.config.PeerRequestsPerSecond), l.config.PeerBurstiness)
+		l.peers.Add(peer, limiter)
+	}
+	return limiter
+}
+
+func (l *RetrievalLimiter) recordLimited(reason string) {
+	if l.metrics != nil {
+		l.metrics.RecordRetrievalLimited(reason)
+	}
+}
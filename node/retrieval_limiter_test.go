@@ -0,0 +1,146 @@
+package node_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/node"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetrievalLimiterRate(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	// No limit
+	limiter, err := node.NewRetrievalLimiter(node.RetrievalLimitConfig{}, nil)
+	require.NoError(t, err)
+	require.Nil(t, limiter)
+	release, err := limiter.Acquire(ctx, now, "peer0")
+	require.NoError(t, err)
+	release()
+
+	_, err = node.NewRetrievalLimiter(node.RetrievalLimitConfig{PeerRequestsPerSecond: -1}, nil)
+	require.Error(t, err)
+
+	limiter, err = node.NewRetrievalLimiter(node.RetrievalLimitConfig{PeerRequestsPerSecond: 1, PeerBurstiness: 2}, nil)
+	require.NoError(t, err)
+	for i := 0; i < 2; i++ {
+		release, err = limiter.Acquire(ctx, now, "peer0")
+		require.NoError(t, err)
+		release()
+	}
+	_, err = limiter.Acquire(ctx, now, "peer0")
+	var limitErr *node.RetrievalLimitError
+	require.ErrorAs(t, err, &limitErr)
+	require.Equal(t, "rate", limitErr.Reason)
+	require.Equal(t, time.Second, limitErr.RetryAfter)
+
+	// Peers are limited separately, and their budget refills over time
+	release, err = limiter.Acquire(ctx, now, "peer1")
+	require.NoError(t, err)
+	release()
+	release, err = limiter.Acquire(ctx, now.Add(time.Second), "peer0")
+	require.NoError(t, err)
+	release()
+}
+
+func TestRetrievalLimiterFairQueueing(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	limiter, err := node.NewRetrievalLimiter(node.RetrievalLimitConfig{MaxConcurrentRequests: 1, MaxQueuedRequestsPerPeer: 3}, nil)
+	require.NoError(t, err)
+
+	release, err := limiter.Acquire(ctx, now, "peer0")
+	require.NoError(t, err)
+
+	// The aggressive peer queues 3 requests before the other peer queues 1
+	served := make(chan string, 4)
+	releases := make(chan func(), 4)
+	acquire := func(peer string) {
+		go func() {
+			release, err := limiter.Acquire(ctx, now, peer)
+			if err != nil {
+				served <- "error"
+				return
+			}
+			served <- peer
+			releases <- release
+		}()
+	}
+	for i := 0; i < 3; i++ {
+		acquire("peer0")
+		waitQueued(t, limiter, "peer0", i+1)
+	}
+	acquire("peer1")
+	waitQueued(t, limiter, "peer1", 1)
+
+	// Requests beyond the queue limit are refused
+	_, err = limiter.Acquire(ctx, now, "peer0")
+	var limitErr *node.RetrievalLimitError
+	require.ErrorAs(t, err, &limitErr)
+	require.Equal(t, "queue", limitErr.Reason)
+
+	// Waiting requests are served in turn across peers, so the other peer isn't served last
+	order := make([]string, 0, 4)
+	for i := 0; i < 4; i++ {
+		release()
+		order = append(order, <-served)
+		release = <-releases
+	}
+	release()
+	require.Equal(t, []string{"peer0", "peer1", "peer0", "peer0"}, order)
+
+	// Requests whose context is done stop waiting
+	release, err = limiter.Acquire(ctx, now, "peer0")
+	require.NoError(t, err)
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = limiter.Acquire(cancelled, now, "peer1")
+	require.ErrorIs(t, err, context.Canceled)
+	release()
+	release, err = limiter.Acquire(ctx, now, "peer1")
+	require.NoError(t, err)
+	release()
+}
+
+func TestRetrievalLimiterSetConfig(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	limiter, err := node.NewRetrievalLimiter(node.RetrievalLimitConfig{MaxConcurrentRequests: 1, MaxQueuedRequestsPerPeer: 1}, nil)
+	require.NoError(t, err)
+	release, err := limiter.Acquire(ctx, now, "peer0")
+	require.NoError(t, err)
+	served := make(chan func())
+	go func() {
+		release, err := limiter.Acquire(ctx, now, "peer1")
+		if err == nil {
+			served <- release
+		}
+	}()
+	waitQueued(t, limiter, "peer1", 1)
+
+	// Raising the concurrency limit admits the waiting request
+	require.Error(t, limiter.SetConfig(node.RetrievalLimitConfig{MaxConcurrentRequests: -1}))
+	require.NoError(t, limiter.SetConfig(node.RetrievalLimitConfig{MaxConcurrentRequests: 2, PeerRequestsPerSecond: 1}))
+	release1 := <-served
+	release()
+	release1()
+
+	// The new rate applies
+	release, err = limiter.Acquire(ctx, now, "peer0")
+	require.NoError(t, err)
+	release()
+	_, err = limiter.Acquire(ctx, now, "peer0")
+	var limitErr *node.RetrievalLimitError
+	require.ErrorAs(t, err, &limitErr)
+	require.Equal(t, "rate", limitErr.Reason)
+}
+
+// waitQueued waits until the peer has the given number of requests waiting.
+func waitQueued(t *testing.T, limiter *node.RetrievalLimiter, peer string, n int) {
+	require.Eventually(t, func() bool {
+		return limiter.NumQueued(peer) == n
+	}, time.Second, time.Millisecond)
+}
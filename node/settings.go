@@ -0,0 +1,181 @@
+package node
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/Layr-Labs/eigenda/core"
+)
+
+// Settings are the tunable settings of the node which can be changed without a restart, since restarts risk missing
+// attestations. They're read from the settings file when the node receives SIGHUP or the admin api is asked to
+// reload them. Settings missing from the file keep the values configured by the flags.
+type Settings struct {
+	// LogLevel is the lowest log level output, "debug", "info", "warn" or "error"
+	LogLevel              *string               `json:"log_level,omitempty"`
+	IngressBandwidthLimit *BandwidthLimitConfig `json:"ingress_bandwidth_limit,omitempty"`
+	EgressBandwidthLimit  *BandwidthLimitConfig `json:"egress_bandwidth_limit,omitempty"`
+	RetrievalLimit        *RetrievalLimitConfig `json:"retrieval_limit,omitempty"`
+	// DiskQuotasGB are the disk quotas of quorums, in GiB. Quotas can only be set for quorums which had one when the
+	// node started.
+	DiskQuotasGB           map[core.QuorumID]uint64 `json:"disk_quotas_gb,omitempty"`
+	DiskQuotaHighWatermark *float64                 `json:"disk_quota_high_watermark,omitempty"`
+	// DisperserAllowlistFile and DisperserDenylistFile are the files listing the dispersers StoreChunks requests
+	// are accepted from, or refused from.
+	DisperserAllowlistFile *string `json:"disperser_allowlist_file,omitempty"`
+	DisperserDenylistFile  *string `json:"disperser_denylist_file,omitempty"`
+}
+
+// ReadSettings reads the settings file at the given path. Unknown settings are refused, so that typos aren't silently
+// ignored.
+func ReadSettings(path string) (*Settings, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read settings file %s: %w", path, err)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	settings := &Settings{}
+	if err := decoder.Decode(settings); err != nil {
+		return nil, fmt.Errorf("failed to parse settings file %s: %w", path, err)
+	}
+	return settings, nil
+}
+
+// ReloadSettings reads the settings file and applies it to the running node. Nothing is changed if the settings are
+// invalid.
+func (n *Node) ReloadSettings() error {
+	if n.Config.SettingsFile == "" {
+		return errors.New("no settings file is configured")
+	}
+	n.settingsMu.Lock()
+	defer n.settingsMu.Unlock()
+
+	settings, err := ReadSettings(n.Config.SettingsFile)
+	if err != nil {
+		return err
+	}
+
+	// Settings missing from the file fall back to the flags
+	logLevel := n.Config.LogLevel
+	if settings.LogLevel != nil {
+		if err := logLevel.UnmarshalText([]byte(*settings.LogLevel)); err != nil {
+			return fmt.Errorf("invalid log level %q: %w", *settings.LogLevel, err)
+		}
+	}
+	levelVar, ok := n.Config.LoggerConfig.HandlerOpts.Level.(*slog.LevelVar)
+	if !ok {
+		return errors.New("the log level can't be changed at runtime")
+	}
+	ingress := n.Config.IngressBandwidthLimit
+	if settings.IngressBandwidthLimit != nil {
+		ingress = *settings.IngressBandwidthLimit
+	}
+	egress := n.Config.EgressBandwidthLimit
+	if settings.EgressBandwidthLimit != nil {
+		egress = *settings.EgressBandwidthLimit
+	}
+	retrieval := n.Config.RetrievalLimit
+	if settings.RetrievalLimit != nil {
+		retrieval = *settings.RetrievalLimit
+	}
+	if err := ingress.validate("ingress"); err != nil {
+		return err
+	}
+	if err := egress.validate("egress"); err != nil {
+		return err
+	}
+	if err := retrieval.validate(); err != nil {
+		return err
+	}
+	if n.IngressLimiter == nil || n.EgressLimiter == nil || n.RetrievalLimiter == nil {
+		return errors.New("the node wasn't created with reloadable limiters")
+	}
+	quotas := n.Config.DiskQuotas
+	if settings.DiskQuotasGB != nil {
+		quotas = make(map[core.QuorumID]uint64, len(settings.DiskQuotasGB))
+		for quorum, quotaGB := range settings.DiskQuotasGB {
+			if quotaGB == 0 {
+				return fmt.Errorf("disk quota of quorum %d must be positive", quorum)
+			}
+			quotas[quorum] = quotaGB << 30
+		}
+	}
+	highWatermark := n.Config.DiskQuotaHighWatermark
+	if settings.DiskQuotaHighWatermark != nil {
+		highWatermark = *settings.DiskQuotaHighWatermark
+	}
+	if n.DiskQuotas == nil && len(quotas) > 0 {
+		return errors.New("disk quotas can't be set on a node started without any")
+	}
+	disperserFilter := n.Config.DisperserFilter
+	if settings.DisperserAllowlistFile != nil {
+		disperserFilter.AllowlistFile = *settings.DisperserAllowlistFile
+	}
+	if settings.DisperserDenylistFile != nil {
+		disperserFilter.DenylistFile = *settings.DisperserDenylistFile
+	}
+	if n.DisperserFilter == nil && disperserFilter.Enabled() {
+		return errors.New("the disperser allowlist and denylist require dispersal authentication")
+	}
+	for _, path := range []string{disperserFilter.AllowlistFile, disperserFilter.DenylistFile} {
+		if path == "" {
+			continue
+		}
+		if _, err := readAddressList(path); err != nil {
+			return err
+		}
+	}
+
+	// Apply the settings which may still fail first
+	if n.DiskQuotas != nil {
+		if err := n.DiskQuotas.SetQuotas(quotas, highWatermark); err != nil {
+			return err
+		}
+	}
+	if n.DisperserFilter != nil {
+		if err := n.DisperserFilter.SetConfig(disperserFilter); err != nil {
+			return err
+		}
+	}
+	if err := n.IngressLimiter.SetConfig(ingress); err != nil {
+		return err
+	}
+	if err := n.EgressLimiter.SetConfig(egress); err != nil {
+		return err
+	}
+	if err := n.RetrievalLimiter.SetConfig(retrieval); err != nil {
+		return err
+	}
+	levelVar.Set(logLevel)
+
+	n.Logger.Info("Reloaded the settings", "file", n.Config.SettingsFile, "logLevel", logLevel.String())
+	return nil
+}
+
+// watchSettingsReload reloads the settings whenever the node receives SIGHUP, until the context is cancelled.
+func (n *Node) watchSettingsReload(ctx context.Context) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP)
+	go func() {
+		defer signal.Stop(signals)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-signals:
+				n.Logger.Info("Received SIGHUP, reloading the settings")
+				if err := n.ReloadSettings(); err != nil {
+					n.Logger.Error("Failed to reload the settings, keeping the current ones", "err", err)
+				}
+			}
+		}
+	}()
+}
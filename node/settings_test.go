@@ -0,0 +1,142 @@
+package node_test
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/testutils"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/node"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+// newReloadableNode creates a node whose settings are reloaded from the given settings file. The node has no disperser
+// filter if denylist is empty.
+func newReloadableNode(t *testing.T, settingsFile string, denylist string) (*node.Node, *slog.LevelVar) {
+	levelVar := new(slog.LevelVar)
+	bandwidthConfig := node.BandwidthLimitConfig{BytesPerSecond: 1000}
+	retrievalConfig := node.RetrievalLimitConfig{PeerRequestsPerSecond: 1, PeerBurstiness: 1}
+	filterConfig := node.DisperserFilterConfig{DenylistFile: denylist, ReloadInterval: time.Hour}
+	config := &node.Config{
+		SettingsFile:           settingsFile,
+		LogLevel:               slog.LevelInfo,
+		LoggerConfig:           common.LoggerConfig{HandlerOpts: logging.SLoggerOptions{Level: levelVar}},
+		IngressBandwidthLimit:  bandwidthConfig,
+		EgressBandwidthLimit:   bandwidthConfig,
+		RetrievalLimit:         retrievalConfig,
+		DiskQuotas:             map[core.QuorumID]uint64{0: 1 << 30},
+		DiskQuotaHighWatermark: 0.9,
+		DisperserFilter:        filterConfig,
+	}
+
+	ingress, err := node.NewBandwidthLimiter("ingress", bandwidthConfig, nil)
+	require.NoError(t, err)
+	egress, err := node.NewBandwidthLimiter("egress", bandwidthConfig, nil)
+	require.NoError(t, err)
+	retrieval, err := node.NewRetrievalLimiter(retrievalConfig, nil)
+	require.NoError(t, err)
+	var filter *node.DisperserFilter
+	if denylist != "" {
+		filter, err = node.NewDisperserFilter(filterConfig, testutils.GetLogger())
+		require.NoError(t, err)
+	}
+	quotas, err := node.NewQuorumQuotaTracker(config.DiskQuotas, config.DiskQuotaHighWatermark, time.Hour, nil, nil)
+	require.NoError(t, err)
+
+	return &node.Node{
+		Config:           config,
+		Logger:           testutils.GetLogger(),
+		DiskQuotas:       quotas,
+		DisperserFilter:  filter,
+		RetrievalLimiter: retrieval,
+		IngressLimiter:   ingress,
+		EgressLimiter:    egress,
+	}, levelVar
+}
+
+func TestReloadSettings(t *testing.T) {
+	dir := t.TempDir()
+	settingsFile := filepath.Join(dir, "settings.json")
+	denylist := filepath.Join(dir, "denylist")
+	disperser := gethcommon.HexToAddress("0x1111111111111111111111111111111111111111")
+	require.NoError(t, os.WriteFile(denylist, []byte(disperser.Hex()+"\n"), 0600))
+	n, levelVar := newReloadableNode(t, settingsFile, denylist)
+	ctx := context.Background()
+	now := time.Now()
+
+	require.NoError(t, os.WriteFile(settingsFile, []byte(`{
+		"log_level": "debug",
+		"ingress_bandwidth_limit": {"bytes_per_second": 10},
+		"retrieval_limit": {},
+		"disk_quotas_gb": {"0": 2},
+		"disperser_denylist_file": ""
+	}`), 0600))
+	require.NoError(t, n.ReloadSettings())
+	require.Equal(t, slog.LevelDebug, levelVar.Level())
+	require.Error(t, n.IngressLimiter.RequestBandwidth(now, "peer0", 11))
+	// Settings missing from the file keep the values of the flags
+	require.NoError(t, n.EgressLimiter.RequestBandwidth(now, "peer0", 1000))
+	for i := 0; i < 3; i++ {
+		release, err := n.RetrievalLimiter.Acquire(ctx, now, "peer0")
+		require.NoError(t, err)
+		release()
+	}
+	require.Equal(t, uint64(2<<30), n.DiskQuotas.Quotas()[0])
+	require.NoError(t, n.DisperserFilter.CheckDisperser(disperser, now))
+
+	// Nothing is changed by invalid settings
+	for _, invalid := range []string{
+		`{"log_level": "debug", "unknown": 1}`,
+		`{"log_level": "verbose"}`,
+		`{"ingress_bandwidth_limit": {"bytes_per_second": -1}}`,
+		`{"disk_quotas_gb": {"1": 2}}`,
+		`{"disperser_allowlist_file": "` + filepath.Join(dir, "missing") + `"}`,
+	} {
+		require.NoError(t, os.WriteFile(settingsFile, []byte(invalid), 0600))
+		require.Error(t, n.ReloadSettings(), invalid)
+		require.Equal(t, slog.LevelDebug, levelVar.Level())
+		require.Equal(t, uint64(2<<30), n.DiskQuotas.Quotas()[0])
+		require.NoError(t, n.DisperserFilter.CheckDisperser(disperser, now))
+	}
+
+	// Removing the settings restores the values of the flags
+	require.NoError(t, os.WriteFile(settingsFile, []byte(`{}`), 0600))
+	require.NoError(t, n.ReloadSettings())
+	require.Equal(t, slog.LevelInfo, levelVar.Level())
+	require.NoError(t, n.IngressLimiter.RequestBandwidth(now.Add(time.Hour), "peer0", 1000))
+	require.Equal(t, uint64(1<<30), n.DiskQuotas.Quotas()[0])
+	require.ErrorIs(t, n.DisperserFilter.CheckDisperser(disperser, now), node.ErrDisperserNotAllowed)
+}
+
+func TestAdminServerReload(t *testing.T) {
+	settingsFile := filepath.Join(t.TempDir(), "settings.json")
+	n, levelVar := newReloadableNode(t, settingsFile, "")
+	server := node.NewAdminServer(n, "localhost:0", testutils.GetLogger())
+
+	recorder := httptest.NewRecorder()
+	server.ServeReload(recorder, httptest.NewRequest(http.MethodGet, node.AdminReloadPath, nil))
+	require.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+
+	require.NoError(t, os.WriteFile(settingsFile, []byte(`{"log_level": "warn"}`), 0600))
+	recorder = httptest.NewRecorder()
+	server.ServeReload(recorder, httptest.NewRequest(http.MethodPost, node.AdminReloadPath, nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.JSONEq(t, `{"reloaded": true}`, recorder.Body.String())
+	require.Equal(t, slog.LevelWarn, levelVar.Level())
+
+	require.NoError(t, os.WriteFile(settingsFile, []byte(`{"log_level": 1}`), 0600))
+	recorder = httptest.NewRecorder()
+	server.ServeReload(recorder, httptest.NewRequest(http.MethodPost, node.AdminReloadPath, nil))
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+	require.Contains(t, recorder.Body.String(), "failed to parse settings file")
+	require.Equal(t, slog.LevelWarn, levelVar.Level())
+}
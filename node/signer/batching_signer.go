@@ -0,0 +1,143 @@
+package signer
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	blssigner "github.com/Layr-Labs/eigensdk-go/signer/bls"
+)
+
+var errSignerStopped = errors.New("signing worker is stopped")
+
+// signRequest is a message waiting to be signed by the signing worker
+type signRequest struct {
+	ctx    context.Context
+	msg    []byte
+	result chan signResult
+}
+
+type signResult struct {
+	signature []byte
+	err       error
+}
+
+// batchingSigner funnels the signatures of messages through a single signing worker. Messages which arrive within a
+// short window of each other are signed in one pass, so that bursts of StoreChunks requests don't each pay the
+// signing overhead concurrently and spike the CPU of small operators.
+type batchingSigner struct {
+	// Signer signs the batched messages. Its other methods are used as is.
+	blssigner.Signer
+
+	// window is how long the worker waits for more messages after the first message of a batch
+	window time.Duration
+	// maxBatchSize is the maximum number of messages signed in one pass
+	maxBatchSize int
+	requests     chan *signRequest
+	done         <-chan struct{}
+	logger       logging.Logger
+}
+
+var _ blssigner.Signer = (*batchingSigner)(nil)
+
+// NewBatchingSigner creates a signer which batches the messages to sign with the given signer. The signing worker
+// stops when the context is cancelled, after which signing fails.
+func NewBatchingSigner(
+	ctx context.Context,
+	signer blssigner.Signer,
+	window time.Duration,
+	maxBatchSize int,
+	logger logging.Logger,
+) (blssigner.Signer, error) {
+	if window <= 0 {
+		return nil, errors.New("batching window must be positive")
+	}
+	if maxBatchSize <= 0 {
+		return nil, errors.New("max batch size must be positive")
+	}
+	s := &batchingSigner{
+		Signer:       signer,
+		window:       window,
+		maxBatchSize: maxBatchSize,
+		requests:     make(chan *signRequest, maxBatchSize),
+		done:         ctx.Done(),
+		logger:       logger.With("component", "BatchingSigner"),
+	}
+	go s.run()
+	return s, nil
+}
+
+// Sign queues the message for the signing worker and waits for its signature.
+func (s *batchingSigner) Sign(ctx context.Context, msg []byte) ([]byte, error) {
+	req := &signRequest{
+		ctx:    ctx,
+		msg:    msg,
+		result: make(chan signResult, 1),
+	}
+	select {
+	case s.requests <- req:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-s.done:
+		return nil, errSignerStopped
+	}
+
+	select {
+	case res := <-req.result:
+		return res.signature, res.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-s.done:
+		return nil, errSignerStopped
+	}
+}
+
+// run collects the queued messages into batches and signs them, until the signer is stopped.
+func (s *batchingSigner) run() {
+	for {
+		var batch []*signRequest
+		select {
+		case <-s.done:
+			return
+		case req := <-s.requests:
+			batch = append(batch, req)
+		}
+
+		timer := time.NewTimer(s.window)
+	collect:
+		for len(batch) < s.maxBatchSize {
+			select {
+			case req := <-s.requests:
+				batch = append(batch, req)
+			case <-timer.C:
+				break collect
+			case <-s.done:
+				break collect
+			}
+		}
+		timer.Stop()
+
+		s.signBatch(batch)
+	}
+}
+
+// signBatch signs the messages of a batch one after the other. Identical messages, such as retried batch headers,
+// are only signed once.
+func (s *batchingSigner) signBatch(batch []*signRequest) {
+	start := time.Now()
+	signatures := make(map[string]signResult, len(batch))
+	for _, req := range batch {
+		if err := req.ctx.Err(); err != nil {
+			req.result <- signResult{err: err}
+			continue
+		}
+		res, ok := signatures[string(req.msg)]
+		if !ok {
+			res.signature, res.err = s.Signer.Sign(req.ctx, req.msg)
+			signatures[string(req.msg)] = res
+		}
+		req.result <- res
+	}
+	s.logger.Debug("Signed batch of messages", "messages", len(batch), "signatures", len(signatures), "duration", time.Since(start))
+}
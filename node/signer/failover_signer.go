@@ -0,0 +1,129 @@
+package signer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	blssigner "github.com/Layr-Labs/eigensdk-go/signer/bls"
+)
+
+// failoverSigner signs with the first of several signers holding the same BLS key which answers within the latency
+// budget, so that a slow or unavailable remote signer doesn't make the node miss attestations.
+type failoverSigner struct {
+	signers []blssigner.Signer
+	// timeout is the latency budget of each signer. 0 means no budget.
+	timeout time.Duration
+	logger  logging.Logger
+}
+
+var _ blssigner.Signer = (*failoverSigner)(nil)
+
+// NewFailoverSigner creates a signer which signs with the given signers in order, failing over to the next signer
+// when one fails or doesn't answer within the timeout. All signers must hold the same key.
+func NewFailoverSigner(signers []blssigner.Signer, timeout time.Duration, logger logging.Logger) (blssigner.Signer, error) {
+	if len(signers) == 0 {
+		return nil, errors.New("no BLS signers")
+	}
+	if len(signers) == 1 && timeout == 0 {
+		return signers[0], nil
+	}
+	for i, signer := range signers[1:] {
+		if signer.GetPublicKeyG1() != signers[0].GetPublicKeyG1() {
+			return nil, fmt.Errorf("BLS signer %d has public key %s, which doesn't match the public key %s of the primary signer",
+				i+1, signer.GetPublicKeyG1(), signers[0].GetPublicKeyG1())
+		}
+	}
+	return &failoverSigner{
+		signers: signers,
+		timeout: timeout,
+		logger:  logger.With("component", "FailoverSigner"),
+	}, nil
+}
+
+func (s *failoverSigner) Sign(ctx context.Context, msg []byte) ([]byte, error) {
+	return s.sign(ctx, func(ctx context.Context, signer blssigner.Signer) ([]byte, error) {
+		return signer.Sign(ctx, msg)
+	})
+}
+
+func (s *failoverSigner) SignG1(ctx context.Context, msg []byte) ([]byte, error) {
+	return s.sign(ctx, func(ctx context.Context, signer blssigner.Signer) ([]byte, error) {
+		return signer.SignG1(ctx, msg)
+	})
+}
+
+// sign signs with each signer in turn until one succeeds within its latency budget
+func (s *failoverSigner) sign(ctx context.Context, sign func(context.Context, blssigner.Signer) ([]byte, error)) ([]byte, error) {
+	var errs []error
+	for i, signer := range s.signers {
+		if ctx.Err() != nil {
+			errs = append(errs, ctx.Err())
+			break
+		}
+
+		signature, err := s.signWithTimeout(ctx, signer, sign)
+		if err == nil {
+			if i > 0 {
+				s.logger.Warn("Signed with a fallback BLS signer", "signer", i)
+			}
+			return signature, nil
+		}
+		s.logger.Warn("BLS signer failed", "signer", i, "err", err)
+		errs = append(errs, fmt.Errorf("signer %d: %w", i, err))
+	}
+	return nil, fmt.Errorf("all BLS signers failed: %w", errors.Join(errs...))
+}
+
+// signWithTimeout signs with the signer, giving up once the latency budget is spent even if the signer ignores the
+// context.
+func (s *failoverSigner) signWithTimeout(
+	ctx context.Context,
+	signer blssigner.Signer,
+	sign func(context.Context, blssigner.Signer) ([]byte, error),
+) ([]byte, error) {
+	if s.timeout == 0 {
+		return sign(ctx, signer)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	type result struct {
+		signature []byte
+		err       error
+	}
+	resultChan := make(chan result, 1)
+	go func() {
+		signature, err := sign(ctx, signer)
+		resultChan <- result{signature: signature, err: err}
+	}()
+
+	select {
+	case res := <-resultChan:
+		return res.signature, res.err
+	case <-ctx.Done():
+		return nil, fmt.Errorf("signer did not answer within %v: %w", s.timeout, ctx.Err())
+	}
+}
+
+func (s *failoverSigner) GetOperatorId() (string, error) {
+	return s.signers[0].GetOperatorId()
+}
+
+func (s *failoverSigner) GetPublicKeyG1() string {
+	return s.signers[0].GetPublicKeyG1()
+}
+
+// GetPublicKeyG2 returns the G2 public key from the first signer which can provide it, since remote signers look it
+// up from the signer service.
+func (s *failoverSigner) GetPublicKeyG2() string {
+	for _, signer := range s.signers {
+		if key := signer.GetPublicKeyG2(); key != "" {
+			return key
+		}
+	}
+	return ""
+}
@@ -0,0 +1,78 @@
+package signer
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"os"
+
+	blssigner "github.com/Layr-Labs/eigensdk-go/signer/bls"
+	"github.com/Layr-Labs/eigensdk-go/signer/bls/privatekey"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+)
+
+// KMSDecrypter decrypts data encrypted with a KMS key. It is implemented by *kms.Client.
+type KMSDecrypter interface {
+	Decrypt(ctx context.Context, params *kms.DecryptInput, optFns ...func(*kms.Options)) (*kms.DecryptOutput, error)
+}
+
+// KMSConfig configures a BLS signer whose private key is stored encrypted with an AWS KMS key.
+type KMSConfig struct {
+	// EncryptedKeyFile is the path to the file holding the base64 encoded KMS ciphertext of the BLS private key,
+	// e.g. the output of `aws kms encrypt --output text --query CiphertextBlob`.
+	EncryptedKeyFile string
+	// Region is the AWS region of the KMS key
+	Region string
+	// Endpoint overrides the KMS endpoint, e.g. for localstack. Leave empty to use the default endpoint.
+	Endpoint string
+}
+
+// NewKMSSigner creates a BLS signer whose private key is decrypted with AWS KMS. KMS doesn't support BLS signatures
+// on BN254, so the key is only decrypted into memory at startup, and never stored in plaintext on disk.
+func NewKMSSigner(ctx context.Context, cfg KMSConfig) (blssigner.Signer, error) {
+	var keyManager *kms.Client
+	if cfg.Endpoint != "" {
+		keyManager = kms.New(kms.Options{
+			Region:       cfg.Region,
+			BaseEndpoint: aws.String(cfg.Endpoint),
+		})
+	} else {
+		awsConfig, err := config.LoadDefaultConfig(ctx, config.WithRegion(cfg.Region))
+		if err != nil {
+			return nil, fmt.Errorf("failed to load AWS config: %w", err)
+		}
+		keyManager = kms.NewFromConfig(awsConfig)
+	}
+
+	encryptedKey, err := os.ReadFile(cfg.EncryptedKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read encrypted BLS key file: %w", err)
+	}
+	return NewKMSSignerFromCiphertext(ctx, keyManager, encryptedKey)
+}
+
+// NewKMSSignerFromCiphertext creates a BLS signer from the base64 encoded KMS ciphertext of its private key.
+func NewKMSSignerFromCiphertext(ctx context.Context, decrypter KMSDecrypter, encryptedKey []byte) (blssigner.Signer, error) {
+	ciphertext, err := base64.StdEncoding.DecodeString(string(bytes.TrimSpace(encryptedKey)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode encrypted BLS key: %w", err)
+	}
+
+	output, err := decrypter.Decrypt(ctx, &kms.DecryptInput{
+		CiphertextBlob: ciphertext,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt BLS key with KMS: %w", err)
+	}
+
+	signer, err := privatekey.New(privatekey.Config{
+		PrivateKey: string(bytes.TrimSpace(output.Plaintext)),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load decrypted BLS key: %w", err)
+	}
+	return signer, nil
+}
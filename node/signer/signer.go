@@ -0,0 +1,65 @@
+package signer
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	blssigner "github.com/Layr-Labs/eigensdk-go/signer/bls"
+	blssignerTypes "github.com/Layr-Labs/eigensdk-go/signer/bls/types"
+)
+
+// Config configures the BLS signer of the node.
+type Config struct {
+	// Signer configures the primary signer, which may be a local keystore or a remote signer service.
+	// It's ignored if KMS is set.
+	Signer blssignerTypes.SignerConfig
+	// KMS configures a primary signer whose key is decrypted with AWS KMS. Nil if unused.
+	KMS *KMSConfig
+	// Fallbacks configure signers holding the same key as the primary signer, which are used in order when the
+	// signers before them fail or exceed the timeout.
+	Fallbacks []blssignerTypes.SignerConfig
+	// Timeout is the latency budget of each signer. 0 means no budget.
+	Timeout time.Duration
+	// BatchWindow is how long messages to sign are collected before they're signed in one pass by a shared signing
+	// worker. 0 disables the batching, signing each message as soon as it's received.
+	BatchWindow time.Duration
+	// MaxBatchSize is the maximum number of messages signed in one pass
+	MaxBatchSize int
+}
+
+// NewSigner creates the BLS signer described by the config.
+func NewSigner(ctx context.Context, cfg Config, logger logging.Logger) (blssigner.Signer, error) {
+	signers := make([]blssigner.Signer, 0, 1+len(cfg.Fallbacks))
+	if cfg.KMS != nil {
+		signer, err := NewKMSSigner(ctx, *cfg.KMS)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create KMS BLS signer: %w", err)
+		}
+		signers = append(signers, signer)
+	} else {
+		signer, err := blssigner.NewSigner(cfg.Signer)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create BLS signer: %w", err)
+		}
+		signers = append(signers, signer)
+	}
+
+	for i, fallbackConfig := range cfg.Fallbacks {
+		signer, err := blssigner.NewSigner(fallbackConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create fallback BLS signer %d: %w", i, err)
+		}
+		signers = append(signers, signer)
+	}
+
+	signer, err := NewFailoverSigner(signers, cfg.Timeout, logger)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.BatchWindow > 0 {
+		return NewBatchingSigner(ctx, signer, cfg.BatchWindow, cfg.MaxBatchSize, logger)
+	}
+	return signer, nil
+}
@@ -0,0 +1,197 @@
+package signer_test
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common/testutils"
+	"github.com/Layr-Labs/eigenda/node/signer"
+	blssigner "github.com/Layr-Labs/eigensdk-go/signer/bls"
+	"github.com/Layr-Labs/eigensdk-go/signer/bls/privatekey"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testPrivateKey  = "3"
+	otherPrivateKey = "5"
+)
+
+// faultySigner wraps a signer, failing or delaying its signatures
+type faultySigner struct {
+	blssigner.Signer
+	err   error
+	delay time.Duration
+}
+
+func (s *faultySigner) Sign(ctx context.Context, msg []byte) ([]byte, error) {
+	time.Sleep(s.delay)
+	if s.err != nil {
+		return nil, s.err
+	}
+	return s.Signer.Sign(ctx, msg)
+}
+
+// countingSigner wraps a signer, counting its signatures and the maximum number of concurrent signatures
+type countingSigner struct {
+	blssigner.Signer
+	calls         atomic.Int32
+	active        atomic.Int32
+	maxConcurrent atomic.Int32
+}
+
+func (s *countingSigner) Sign(ctx context.Context, msg []byte) ([]byte, error) {
+	s.calls.Add(1)
+	active := s.active.Add(1)
+	defer s.active.Add(-1)
+	for {
+		current := s.maxConcurrent.Load()
+		if active <= current || s.maxConcurrent.CompareAndSwap(current, active) {
+			break
+		}
+	}
+	time.Sleep(time.Millisecond)
+	return s.Signer.Sign(ctx, msg)
+}
+
+// fakeDecrypter "decrypts" ciphertexts which are the plaintext prefixed with "encrypted:"
+type fakeDecrypter struct{}
+
+func (fakeDecrypter) Decrypt(ctx context.Context, params *kms.DecryptInput, optFns ...func(*kms.Options)) (*kms.DecryptOutput, error) {
+	prefix := "encrypted:"
+	if len(params.CiphertextBlob) < len(prefix) || string(params.CiphertextBlob[:len(prefix)]) != prefix {
+		return nil, errors.New("invalid ciphertext")
+	}
+	return &kms.DecryptOutput{Plaintext: params.CiphertextBlob[len(prefix):]}, nil
+}
+
+func newPrivateKeySigner(t *testing.T, key string) blssigner.Signer {
+	s, err := privatekey.New(privatekey.Config{PrivateKey: key})
+	require.NoError(t, err)
+	return s
+}
+
+func TestFailoverSigner(t *testing.T) {
+	ctx := context.Background()
+	msg := make([]byte, 32)
+	msg[0] = 1
+	primary := newPrivateKeySigner(t, testPrivateKey)
+	expected, err := primary.Sign(ctx, msg)
+	require.NoError(t, err)
+
+	// The failing and slow signers are skipped
+	s, err := signer.NewFailoverSigner([]blssigner.Signer{
+		&faultySigner{Signer: primary, err: errors.New("unavailable")},
+		&faultySigner{Signer: primary, delay: time.Second},
+		primary,
+	}, 50*time.Millisecond, testutils.GetLogger())
+	require.NoError(t, err)
+	require.Equal(t, primary.GetPublicKeyG1(), s.GetPublicKeyG1())
+	require.Equal(t, primary.GetPublicKeyG2(), s.GetPublicKeyG2())
+
+	start := time.Now()
+	signature, err := s.Sign(ctx, msg)
+	require.NoError(t, err)
+	require.Equal(t, expected, signature)
+	require.Less(t, time.Since(start), time.Second)
+
+	// Signing fails if all signers fail
+	s, err = signer.NewFailoverSigner([]blssigner.Signer{
+		&faultySigner{Signer: primary, err: errors.New("unavailable")},
+	}, 0, testutils.GetLogger())
+	require.NoError(t, err)
+	_, err = s.Sign(ctx, msg)
+	require.ErrorContains(t, err, "unavailable")
+
+	// All signers must hold the same key
+	_, err = signer.NewFailoverSigner([]blssigner.Signer{
+		primary,
+		newPrivateKeySigner(t, otherPrivateKey),
+	}, 0, testutils.GetLogger())
+	require.Error(t, err)
+
+	_, err = signer.NewFailoverSigner(nil, 0, testutils.GetLogger())
+	require.Error(t, err)
+}
+
+func TestKMSSigner(t *testing.T) {
+	ctx := context.Background()
+	encryptedKey := base64.StdEncoding.EncodeToString([]byte("encrypted:" + testPrivateKey + "\n"))
+
+	s, err := signer.NewKMSSignerFromCiphertext(ctx, fakeDecrypter{}, []byte(encryptedKey+"\n"))
+	require.NoError(t, err)
+	require.Equal(t, newPrivateKeySigner(t, testPrivateKey).GetPublicKeyG1(), s.GetPublicKeyG1())
+
+	_, err = signer.NewKMSSignerFromCiphertext(ctx, fakeDecrypter{}, []byte(base64.StdEncoding.EncodeToString([]byte(testPrivateKey))))
+	require.ErrorContains(t, err, "failed to decrypt")
+
+	_, err = signer.NewKMSSignerFromCiphertext(ctx, fakeDecrypter{}, []byte("not base64!"))
+	require.ErrorContains(t, err, "failed to decode")
+}
+
+func TestBatchingSigner(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	primary := newPrivateKeySigner(t, testPrivateKey)
+	counting := &countingSigner{Signer: primary}
+	s, err := signer.NewBatchingSigner(ctx, counting, 50*time.Millisecond, 8, testutils.GetLogger())
+	require.NoError(t, err)
+	require.Equal(t, primary.GetPublicKeyG1(), s.GetPublicKeyG1())
+
+	// Concurrent messages are signed one at a time, and each message is signed correctly
+	numMessages := 20
+	var wg sync.WaitGroup
+	for i := 0; i < numMessages; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			msg := make([]byte, 32)
+			msg[0] = byte(i % 5)
+			expected, err := primary.Sign(ctx, msg)
+			require.NoError(t, err)
+			signature, err := s.Sign(ctx, msg)
+			require.NoError(t, err)
+			require.Equal(t, expected, signature)
+		}(i)
+	}
+	wg.Wait()
+	require.Equal(t, int32(1), counting.maxConcurrent.Load())
+	require.LessOrEqual(t, counting.calls.Load(), int32(numMessages))
+
+	// Identical messages of a batch are signed once
+	counting.calls.Store(0)
+	msg := make([]byte, 32)
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := s.Sign(ctx, msg)
+			require.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+	require.Equal(t, int32(1), counting.calls.Load())
+
+	// A message whose context is cancelled isn't signed
+	cancelledCtx, cancelRequest := context.WithCancel(ctx)
+	cancelRequest()
+	_, err = s.Sign(cancelledCtx, msg)
+	require.ErrorIs(t, err, context.Canceled)
+
+	// Signing fails once the worker is stopped
+	cancel()
+	require.Eventually(t, func() bool {
+		_, err := s.Sign(context.Background(), msg)
+		return err != nil
+	}, time.Second, 10*time.Millisecond)
+
+	_, err = signer.NewBatchingSigner(context.Background(), primary, 0, 8, testutils.GetLogger())
+	require.Error(t, err)
+	_, err = signer.NewBatchingSigner(context.Background(), primary, time.Millisecond, 0, testutils.GetLogger())
+	require.Error(t, err)
+}
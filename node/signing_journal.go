@@ -0,0 +1,232 @@
+package node
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common/kvstore"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+const (
+	// attestedBatchPrefix keys the attested batches by batch header hash. The value is the reference block number of
+	// the batch followed by the keys of its blobs.
+	attestedBatchPrefix = "attested-batch-"
+	// attestedBlobPrefix keys the batch header hash of the batch in which a blob was attested by the reference block
+	// number of the batch and the blob key.
+	attestedBlobPrefix = "attested-blob-"
+	// attestationExpiryPrefix keys the attested batches by the time at which they're dropped from the journal.
+	attestationExpiryPrefix = "attestation-expiry-"
+)
+
+// ErrConflictingAttestation is returned when the node refuses to attest to a batch which conflicts with a batch it
+// attested to before.
+var ErrConflictingAttestation = errors.New("conflicting attestation")
+
+// ConflictingAttestationError describes a batch which conflicts with a batch attested to before, because a blob of
+// the batch was attested as part of the other batch at the same reference block.
+type ConflictingAttestationError struct {
+	// BatchHeaderHash is the hash of the refused batch
+	BatchHeaderHash [32]byte
+	// AttestedBatchHeaderHash is the hash of the batch attested to before
+	AttestedBatchHeaderHash [32]byte
+	// BlobKey is the key of the blob in both batches
+	BlobKey [32]byte
+	// ReferenceBlockNumber is the reference block number of both batches
+	ReferenceBlockNumber uint64
+}
+
+func (e *ConflictingAttestationError) Error() string {
+	return fmt.Sprintf("%v: blob %s of batch %s was attested in batch %s at reference block %d", ErrConflictingAttestation,
+		hex.EncodeToString(e.BlobKey[:]), hex.EncodeToString(e.BatchHeaderHash[:]), hex.EncodeToString(e.AttestedBatchHeaderHash[:]),
+		e.ReferenceBlockNumber)
+}
+
+func (e *ConflictingAttestationError) Is(target error) bool {
+	return target == ErrConflictingAttestation
+}
+
+// SigningJournal records the batches the node attests to, so that it never attests to conflicting batches, e.g. if a
+// buggy disperser puts a blob in two batches built on the same reference block, or if a request is replayed with a
+// tampered batch. A blob is only attested as part of one batch for each reference block: a batch containing a blob
+// which was attested as part of another batch with the same reference block number is refused. Attesting to the
+// same batch again, e.g. when a disperser retries a request, is allowed, and so is attesting to a blob in a batch
+// with a newer reference block, e.g. when a disperser re-disperses the blobs of a failed batch.
+//
+// Batches are recorded before they're signed, and are kept in the journal for a retention period, after which they
+// can't be replayed anyway since their reference block is stale.
+type SigningJournal struct {
+	db        kvstore.Store[[]byte]
+	retention time.Duration
+	metrics   *Metrics
+	logger    logging.Logger
+
+	// mu serializes the checks and writes of the attestations
+	mu sync.Mutex
+}
+
+// NewSigningJournal creates a SigningJournal which stores the attestations in db for the retention period.
+func NewSigningJournal(db kvstore.Store[[]byte], retention time.Duration, metrics *Metrics, logger logging.Logger) *SigningJournal {
+	return &SigningJournal{
+		db:        db,
+		retention: retention,
+		metrics:   metrics,
+		logger:    logger.With("component", "SigningJournal"),
+	}
+}
+
+// Record records the attestation of a batch, unless the batch conflicts with a batch recorded before, in which case
+// a ConflictingAttestationError is returned and the batch must not be signed. blobKeys identifies the blobs of the
+// batch: the blob keys of v2 batches, or the blob header hashes of v1 batches.
+func (j *SigningJournal) Record(batchHeaderHash [32]byte, referenceBlockNumber uint64, blobKeys [][32]byte, now time.Time) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	_, err := j.db.Get(attestedBatchKey(batchHeaderHash))
+	if err == nil {
+		// The batch was attested to already
+		return nil
+	}
+	if !errors.Is(err, kvstore.ErrNotFound) {
+		return fmt.Errorf("failed to read the signing journal: %w", err)
+	}
+
+	for _, blobKey := range blobKeys {
+		attested, err := j.db.Get(attestedBlobKey(referenceBlockNumber, blobKey))
+		if errors.Is(err, kvstore.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read the signing journal: %w", err)
+		}
+		if !bytes.Equal(attested, batchHeaderHash[:]) {
+			conflict := &ConflictingAttestationError{
+				BatchHeaderHash:      batchHeaderHash,
+				BlobKey:              blobKey,
+				ReferenceBlockNumber: referenceBlockNumber,
+			}
+			copy(conflict.AttestedBatchHeaderHash[:], attested)
+			if j.metrics != nil {
+				j.metrics.RecordConflictingAttestation()
+			}
+			j.logger.Error("Refusing to attest to a conflicting batch", "err", conflict)
+			return conflict
+		}
+	}
+
+	value := make([]byte, 8, 8+32*len(blobKeys))
+	binary.BigEndian.PutUint64(value, referenceBlockNumber)
+	batch := j.db.NewBatch()
+	for _, blobKey := range blobKeys {
+		value = append(value, blobKey[:]...)
+		batch.Put(attestedBlobKey(referenceBlockNumber, blobKey), batchHeaderHash[:])
+	}
+	batch.Put(attestedBatchKey(batchHeaderHash), value)
+	batch.Put(attestationExpiryKey(now.Add(j.retention), batchHeaderHash), nil)
+	if err := batch.Apply(); err != nil {
+		return fmt.Errorf("failed to write the signing journal: %w", err)
+	}
+	return nil
+}
+
+// Start drops the attestations past their retention from the journal once per interval in the background, until
+// the context is cancelled.
+func (j *SigningJournal) Start(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				numBatches, err := j.Prune(time.Now())
+				if err != nil {
+					j.logger.Error("Failed to prune the signing journal", "err", err)
+					continue
+				}
+				if numBatches > 0 {
+					j.logger.Debug("Pruned the signing journal", "batches", numBatches)
+				}
+			}
+		}
+	}()
+}
+
+// Prune drops the attestations past their retention at the given time from the journal, and returns the number of
+// batches dropped.
+func (j *SigningJournal) Prune(now time.Time) (int, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	iter, err := j.db.NewIterator([]byte(attestationExpiryPrefix))
+	if err != nil {
+		return 0, fmt.Errorf("failed to iterate over the signing journal: %w", err)
+	}
+	defer iter.Release()
+
+	batch := j.db.NewBatch()
+	numBatches := 0
+	for iter.Next() {
+		key := iter.Key()
+		if len(key) != len(attestationExpiryPrefix)+8+32 {
+			return 0, fmt.Errorf("invalid signing journal expiry key %x", key)
+		}
+		expiry := int64(binary.BigEndian.Uint64(key[len(attestationExpiryPrefix):]))
+		if expiry > now.UnixNano() {
+			break
+		}
+		var batchHeaderHash [32]byte
+		copy(batchHeaderHash[:], key[len(attestationExpiryPrefix)+8:])
+
+		value, err := j.db.Get(attestedBatchKey(batchHeaderHash))
+		if err != nil && !errors.Is(err, kvstore.ErrNotFound) {
+			return 0, fmt.Errorf("failed to read the signing journal: %w", err)
+		}
+		if len(value) >= 8 {
+			referenceBlockNumber := binary.BigEndian.Uint64(value)
+			for i := 8; i+32 <= len(value); i += 32 {
+				var blobKey [32]byte
+				copy(blobKey[:], value[i:i+32])
+				batch.Delete(attestedBlobKey(referenceBlockNumber, blobKey))
+			}
+		}
+		batch.Delete(attestedBatchKey(batchHeaderHash))
+		batch.Delete(copyBytes(key))
+		numBatches++
+	}
+	if err := iter.Error(); err != nil {
+		return 0, fmt.Errorf("failed to iterate over the signing journal: %w", err)
+	}
+	if numBatches == 0 {
+		return 0, nil
+	}
+	if err := batch.Apply(); err != nil {
+		return 0, fmt.Errorf("failed to prune the signing journal: %w", err)
+	}
+	return numBatches, nil
+}
+
+func attestedBatchKey(batchHeaderHash [32]byte) []byte {
+	return append([]byte(attestedBatchPrefix), batchHeaderHash[:]...)
+}
+
+func attestedBlobKey(referenceBlockNumber uint64, blobKey [32]byte) []byte {
+	key := make([]byte, len(attestedBlobPrefix)+8, len(attestedBlobPrefix)+8+32)
+	copy(key, attestedBlobPrefix)
+	binary.BigEndian.PutUint64(key[len(attestedBlobPrefix):], referenceBlockNumber)
+	return append(key, blobKey[:]...)
+}
+
+func attestationExpiryKey(expiry time.Time, batchHeaderHash [32]byte) []byte {
+	key := make([]byte, len(attestationExpiryPrefix)+8, len(attestationExpiryPrefix)+8+32)
+	copy(key, attestationExpiryPrefix)
+	binary.BigEndian.PutUint64(key[len(attestationExpiryPrefix):], uint64(expiry.UnixNano()))
+	return append(key, batchHeaderHash[:]...)
+}
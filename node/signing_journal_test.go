@@ -0,0 +1,46 @@
+package node_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common/kvstore/mapstore"
+	"github.com/Layr-Labs/eigenda/common/testutils"
+	"github.com/Layr-Labs/eigenda/node"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSigningJournal(t *testing.T) {
+	journal := node.NewSigningJournal(mapstore.NewStore(), time.Hour, nil, testutils.GetLogger())
+	now := time.Now()
+	blob1, blob2, blob3 := [32]byte{1}, [32]byte{2}, [32]byte{3}
+	batchA, batchB, batchC := [32]byte{0xa}, [32]byte{0xb}, [32]byte{0xc}
+
+	require.NoError(t, journal.Record(batchA, 100, [][32]byte{blob1, blob2}, now))
+	// Attesting to the same batch again is allowed
+	require.NoError(t, journal.Record(batchA, 100, [][32]byte{blob1, blob2}, now))
+
+	// A batch with a blob of batch A at the same reference block conflicts with it
+	err := journal.Record(batchB, 100, [][32]byte{blob3, blob2}, now)
+	require.True(t, errors.Is(err, node.ErrConflictingAttestation))
+	var conflict *node.ConflictingAttestationError
+	require.True(t, errors.As(err, &conflict))
+	require.Equal(t, batchA, conflict.AttestedBatchHeaderHash)
+	require.Equal(t, blob2, conflict.BlobKey)
+	// The refused batch isn't recorded, so its other blobs don't conflict
+	require.NoError(t, journal.Record(batchC, 100, [][32]byte{blob3}, now))
+
+	// The blobs of batch A can be attested in a batch with a newer reference block
+	require.NoError(t, journal.Record(batchB, 101, [][32]byte{blob1, blob2}, now.Add(time.Minute)))
+
+	// Attestations are dropped after the retention
+	numBatches, err := journal.Prune(now.Add(30 * time.Minute))
+	require.NoError(t, err)
+	require.Equal(t, 0, numBatches)
+	numBatches, err = journal.Prune(now.Add(time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, 2, numBatches)
+	require.NoError(t, journal.Record([32]byte{0xd}, 100, [][32]byte{blob1, blob2, blob3}, now))
+	require.Error(t, journal.Record([32]byte{0xe}, 101, [][32]byte{blob2}, now))
+}
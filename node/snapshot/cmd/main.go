@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/aws/s3"
+	"github.com/Layr-Labs/eigenda/node/snapshot"
+	"github.com/urfave/cli"
+)
+
+func main() {
+	app := cli.NewApp()
+	app.Flags = snapshot.Flags
+	app.Name = "eigenda-node-snapshot"
+	app.Usage = "EigenDA Node Snapshot"
+	app.Description = "Snapshot the chunk stores of a stopped EigenDA node to S3, or restore them onto a new machine"
+	app.Action = run
+	err := app.Run(os.Args)
+	if err != nil {
+		log.Fatalln("Application failed.", "Message:", err)
+	}
+}
+
+func run(ctx *cli.Context) error {
+	config, err := snapshot.NewConfig(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to parse the command line flags: %w", err)
+	}
+
+	logger, err := common.NewLogger(common.DefaultLoggerConfig())
+	if err != nil {
+		return fmt.Errorf("failed to create logger: %w", err)
+	}
+
+	client, err := s3.NewClient(context.Background(), config.AWS, logger)
+	if err != nil {
+		return fmt.Errorf("failed to create S3 client: %w", err)
+	}
+	snapshotter, err := snapshot.NewSnapshotter(client, config.Bucket, config.Parallelism, logger)
+	if err != nil {
+		return err
+	}
+
+	switch config.Operation {
+	case snapshot.OperationSnapshot:
+		_, err = snapshotter.Snapshot(context.Background(), config.DbPath, config.Name)
+		if err != nil {
+			return fmt.Errorf("failed to snapshot %s: %w", config.DbPath, err)
+		}
+		logger.Info("Created snapshot", "bucket", config.Bucket, "name", config.Name)
+	case snapshot.OperationRestore:
+		_, err = snapshotter.Restore(context.Background(), config.Name, config.DbPath)
+		if err != nil {
+			return fmt.Errorf("failed to restore snapshot %s: %w", config.Name, err)
+		}
+		logger.Info("Restored snapshot", "bucket", config.Bucket, "name", config.Name, "dbPath", config.DbPath)
+	}
+	return nil
+}
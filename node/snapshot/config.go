@@ -0,0 +1,94 @@
+package snapshot
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/aws"
+	"github.com/Layr-Labs/eigenda/node/flags"
+	"github.com/urfave/cli"
+)
+
+const (
+	OperationSnapshot = "snapshot"
+	OperationRestore  = "restore"
+)
+
+var (
+	OperationFlag = cli.StringFlag{
+		Name:     "operation",
+		Required: true,
+		Usage:    "Supported operations: snapshot, restore",
+		EnvVar:   common.PrefixEnvVar(flags.EnvVarPrefix, "SNAPSHOT_OPERATION"),
+	}
+	DbPathFlag = cli.StringFlag{
+		Name:     "db-path",
+		Required: true,
+		Usage:    "Path to the node's db directory, as in the node's db-path flag. The node must be stopped",
+		EnvVar:   common.PrefixEnvVar(flags.EnvVarPrefix, "DB_PATH"),
+	}
+	BucketFlag = cli.StringFlag{
+		Name:     "bucket",
+		Required: true,
+		Usage:    "The S3 bucket holding the snapshots",
+		EnvVar:   common.PrefixEnvVar(flags.EnvVarPrefix, "SNAPSHOT_BUCKET"),
+	}
+	NameFlag = cli.StringFlag{
+		Name:     "name",
+		Required: false,
+		Usage:    "The name of the snapshot. Required for restore, and defaults to the current time for snapshot",
+		EnvVar:   common.PrefixEnvVar(flags.EnvVarPrefix, "SNAPSHOT_NAME"),
+	}
+	ParallelismFlag = cli.IntFlag{
+		Name:     "parallelism",
+		Required: false,
+		Usage:    "The number of files uploaded or downloaded concurrently",
+		Value:    8,
+		EnvVar:   common.PrefixEnvVar(flags.EnvVarPrefix, "SNAPSHOT_PARALLELISM"),
+	}
+)
+
+// Flags are the flags of the snapshot command.
+var Flags = append([]cli.Flag{
+	OperationFlag,
+	DbPathFlag,
+	BucketFlag,
+	NameFlag,
+	ParallelismFlag,
+}, aws.ClientFlags(flags.EnvVarPrefix, "")...)
+
+type Config struct {
+	Operation   string
+	DbPath      string
+	Bucket      string
+	Name        string
+	Parallelism int
+	AWS         aws.ClientConfig
+}
+
+// NewConfig parses the Config from the provided flags or environment variables.
+func NewConfig(ctx *cli.Context) (*Config, error) {
+	op := ctx.GlobalString(OperationFlag.Name)
+	if op != OperationSnapshot && op != OperationRestore {
+		return nil, fmt.Errorf("unsupported operation type: %s", op)
+	}
+
+	name := ctx.GlobalString(NameFlag.Name)
+	if name == "" {
+		if op == OperationRestore {
+			return nil, errors.New("the name of the snapshot to restore is required")
+		}
+		name = time.Now().UTC().Format("20060102T150405Z")
+	}
+
+	return &Config{
+		Operation:   op,
+		DbPath:      ctx.GlobalString(DbPathFlag.Name),
+		Bucket:      ctx.GlobalString(BucketFlag.Name),
+		Name:        name,
+		Parallelism: ctx.GlobalInt(ParallelismFlag.Name),
+		AWS:         aws.ReadClientConfig(ctx, ""),
+	}, nil
+}
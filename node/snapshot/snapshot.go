@@ -0,0 +1,233 @@
+package snapshot
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common/aws/s3"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	// manifestObject is the name of the object listing the files of a snapshot. It's uploaded after all the files,
+	// so a snapshot without a manifest is incomplete and can't be restored.
+	manifestObject = "manifest.json"
+	// filesPrefix is the prefix of the objects holding the files of a snapshot
+	filesPrefix = "files"
+)
+
+// skippedFiles are the files of the stores which are specific to the process using them, and aren't snapshotted
+var skippedFiles = map[string]struct{}{
+	"LOCK":    {},
+	"LOG":     {},
+	"LOG.old": {},
+}
+
+// Manifest describes the files of a snapshot.
+type Manifest struct {
+	CreatedAt time.Time `json:"created_at"`
+	Files     []File    `json:"files"`
+}
+
+// File is a file of a snapshot.
+type File struct {
+	// Path is the path of the file relative to the db path
+	Path   string `json:"path"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// Snapshotter copies the node's chunk stores, which hold the chunks and their metadata, to and from an object store,
+// so that a node can be moved to a new machine or recovered from a disk failure without missing the chunks it
+// attested to.
+//
+// The node must be stopped while a snapshot is taken or restored, since the stores must not be written meanwhile.
+type Snapshotter struct {
+	client s3.Client
+	bucket string
+	// parallelism is the number of files uploaded or downloaded concurrently
+	parallelism int
+	logger      logging.Logger
+}
+
+// NewSnapshotter creates a Snapshotter which stores snapshots in the given bucket.
+func NewSnapshotter(client s3.Client, bucket string, parallelism int, logger logging.Logger) (*Snapshotter, error) {
+	if bucket == "" {
+		return nil, errors.New("bucket is required")
+	}
+	if parallelism <= 0 {
+		return nil, errors.New("parallelism must be positive")
+	}
+	return &Snapshotter{
+		client:      client,
+		bucket:      bucket,
+		parallelism: parallelism,
+		logger:      logger.With("component", "Snapshotter"),
+	}, nil
+}
+
+// Snapshot uploads the files of the stores under dbPath as the snapshot with the given name.
+func (s *Snapshotter) Snapshot(ctx context.Context, dbPath string, name string) (*Manifest, error) {
+	var paths []string
+	err := filepath.WalkDir(dbPath, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		if _, ok := skippedFiles[d.Name()]; ok {
+			return nil
+		}
+		relPath, err := filepath.Rel(dbPath, p)
+		if err != nil {
+			return err
+		}
+		paths = append(paths, filepath.ToSlash(relPath))
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the files of %s: %w", dbPath, err)
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no files to snapshot in %s", dbPath)
+	}
+
+	manifest := &Manifest{
+		CreatedAt: time.Now().UTC(),
+		Files:     make([]File, len(paths)),
+	}
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(s.parallelism)
+	for i, relPath := range paths {
+		i, relPath := i, relPath
+		group.Go(func() error {
+			data, err := os.ReadFile(filepath.Join(dbPath, filepath.FromSlash(relPath)))
+			if err != nil {
+				return fmt.Errorf("failed to read %s: %w", relPath, err)
+			}
+			if err := s.client.UploadObject(groupCtx, s.bucket, fileObject(name, relPath), data); err != nil {
+				return fmt.Errorf("failed to upload %s: %w", relPath, err)
+			}
+			hash := sha256.Sum256(data)
+			manifest.Files[i] = File{
+				Path:   relPath,
+				Size:   int64(len(data)),
+				SHA256: hex.EncodeToString(hash[:]),
+			}
+			s.logger.Debug("Uploaded file", "path", relPath, "size", len(data))
+			return nil
+		})
+	}
+	if err := group.Wait(); err != nil {
+		return nil, err
+	}
+
+	manifestBytes, err := json.Marshal(manifest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize manifest: %w", err)
+	}
+	if err := s.client.UploadObject(ctx, s.bucket, path.Join(name, manifestObject), manifestBytes); err != nil {
+		return nil, fmt.Errorf("failed to upload manifest: %w", err)
+	}
+	s.logger.Info("Snapshot complete", "name", name, "files", len(manifest.Files), "bytes", manifest.Size())
+	return manifest, nil
+}
+
+// Restore downloads the snapshot with the given name into dbPath, which must not exist or be empty. The files are
+// verified against the manifest before they're moved into dbPath, so a failed restore leaves dbPath untouched.
+func (s *Snapshotter) Restore(ctx context.Context, name string, dbPath string) (*Manifest, error) {
+	entries, err := os.ReadDir(dbPath)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("failed to read %s: %w", dbPath, err)
+	}
+	if len(entries) > 0 {
+		return nil, fmt.Errorf("%s is not empty, refusing to overwrite it", dbPath)
+	}
+
+	manifestBytes, err := s.client.DownloadObject(ctx, s.bucket, path.Join(name, manifestObject))
+	if err != nil {
+		return nil, fmt.Errorf("failed to download the manifest of snapshot %s: %w", name, err)
+	}
+	manifest := &Manifest{}
+	if err := json.Unmarshal(manifestBytes, manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse the manifest of snapshot %s: %w", name, err)
+	}
+
+	restorePath := filepath.Clean(dbPath) + ".restoring"
+	if err := os.RemoveAll(restorePath); err != nil {
+		return nil, fmt.Errorf("failed to clean up %s: %w", restorePath, err)
+	}
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(s.parallelism)
+	for _, file := range manifest.Files {
+		file := file
+		group.Go(func() error {
+			return s.restoreFile(groupCtx, name, file, restorePath)
+		})
+	}
+	if err := group.Wait(); err != nil {
+		_ = os.RemoveAll(restorePath)
+		return nil, err
+	}
+
+	if err := os.RemoveAll(dbPath); err != nil {
+		return nil, fmt.Errorf("failed to remove %s: %w", dbPath, err)
+	}
+	if err := os.Rename(restorePath, dbPath); err != nil {
+		return nil, fmt.Errorf("failed to move the restored files to %s: %w", dbPath, err)
+	}
+	s.logger.Info("Restore complete", "name", name, "createdAt", manifest.CreatedAt, "files", len(manifest.Files), "bytes", manifest.Size())
+	return manifest, nil
+}
+
+// restoreFile downloads a file of the snapshot into dir, checking it against the manifest.
+func (s *Snapshotter) restoreFile(ctx context.Context, name string, file File, dir string) error {
+	localPath := filepath.Join(dir, filepath.FromSlash(file.Path))
+	if !strings.HasPrefix(localPath, filepath.Clean(dir)+string(filepath.Separator)) {
+		return fmt.Errorf("invalid path %s in manifest", file.Path)
+	}
+
+	data, err := s.client.DownloadObject(ctx, s.bucket, fileObject(name, file.Path))
+	if err != nil {
+		return fmt.Errorf("failed to download %s: %w", file.Path, err)
+	}
+	hash := sha256.Sum256(data)
+	if int64(len(data)) != file.Size || hex.EncodeToString(hash[:]) != file.SHA256 {
+		return fmt.Errorf("%s doesn't match the manifest", file.Path)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(localPath), 0755); err != nil {
+		return fmt.Errorf("failed to create the directory of %s: %w", file.Path, err)
+	}
+	if err := os.WriteFile(localPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", file.Path, err)
+	}
+	s.logger.Debug("Downloaded file", "path", file.Path, "size", file.Size)
+	return nil
+}
+
+// Size returns the total size of the files of the snapshot, in bytes.
+func (m *Manifest) Size() int64 {
+	size := int64(0)
+	for _, file := range m.Files {
+		size += file.Size
+	}
+	return size
+}
+
+// fileObject returns the key of the object holding a file of a snapshot
+func fileObject(name string, relPath string) string {
+	return path.Join(name, filesPrefix, relPath)
+}
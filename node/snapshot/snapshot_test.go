@@ -0,0 +1,73 @@
+package snapshot_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/common/aws/mock"
+	"github.com/Layr-Labs/eigenda/common/testutils"
+	"github.com/Layr-Labs/eigenda/node/snapshot"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFile(t *testing.T, path string, data []byte) {
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, data, 0644))
+}
+
+func TestSnapshotRestore(t *testing.T) {
+	testutils.InitializeRandom()
+	ctx := context.Background()
+	client := mock.NewS3Client()
+	snapshotter, err := snapshot.NewSnapshotter(client, "snapshots", 4, testutils.GetLogger())
+	require.NoError(t, err)
+
+	dbPath := t.TempDir()
+	files := map[string][]byte{
+		"chunk/000001.ldb":    testutils.RandomBytes(1024),
+		"chunk/MANIFEST-0001": testutils.RandomBytes(64),
+		"chunk_v2/000002.ldb": testutils.RandomBytes(2048),
+		"chunk_v2/CURRENT":    []byte("MANIFEST-0001\n"),
+	}
+	for path, data := range files {
+		writeFile(t, filepath.Join(dbPath, path), data)
+	}
+	// Lock files are specific to the running store
+	writeFile(t, filepath.Join(dbPath, "chunk", "LOCK"), nil)
+
+	manifest, err := snapshotter.Snapshot(ctx, dbPath, "snapshot-1")
+	require.NoError(t, err)
+	require.Len(t, manifest.Files, len(files))
+	require.Equal(t, int64(1024+64+2048+14), manifest.Size())
+
+	// Restore onto a new machine
+	restorePath := filepath.Join(t.TempDir(), "db")
+	restored, err := snapshotter.Restore(ctx, "snapshot-1", restorePath)
+	require.NoError(t, err)
+	require.Equal(t, manifest.Files, restored.Files)
+	for path, data := range files {
+		restoredData, err := os.ReadFile(filepath.Join(restorePath, path))
+		require.NoError(t, err)
+		require.Equal(t, data, restoredData)
+	}
+	_, err = os.Stat(filepath.Join(restorePath, "chunk", "LOCK"))
+	require.True(t, os.IsNotExist(err))
+
+	// Existing data is never overwritten
+	_, err = snapshotter.Restore(ctx, "snapshot-1", restorePath)
+	require.ErrorContains(t, err, "not empty")
+
+	// Snapshots without a manifest are incomplete
+	_, err = snapshotter.Restore(ctx, "snapshot-2", filepath.Join(t.TempDir(), "db"))
+	require.Error(t, err)
+
+	// Corrupt files are detected, leaving the db path untouched
+	require.NoError(t, client.UploadObject(ctx, "snapshots", "snapshot-1/files/chunk/000001.ldb", []byte("corrupt")))
+	corruptPath := filepath.Join(t.TempDir(), "db")
+	_, err = snapshotter.Restore(ctx, "snapshot-1", corruptPath)
+	require.ErrorContains(t, err, "doesn't match the manifest")
+	_, err = os.Stat(corruptPath)
+	require.True(t, os.IsNotExist(err))
+}
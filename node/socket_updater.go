@@ -0,0 +1,200 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+// SocketUpdateConfig configures the automatic update of the socket registered on-chain when the node's public IP
+// changes.
+type SocketUpdateConfig struct {
+	// CheckInterval is the interval at which the node's public IP is checked. 0 disables the updates.
+	CheckInterval time.Duration
+	// ConfirmChecks is the number of consecutive checks which must detect the same new socket before it's
+	// registered, so that a flapping IP provider doesn't trigger transactions
+	ConfirmChecks int
+	// RetryInterval is the time waited before retrying a failed socket update. It doubles with each failure, up to
+	// MaxRetryInterval.
+	RetryInterval    time.Duration
+	MaxRetryInterval time.Duration
+	// MaxGasPrice is the gas price, in wei, above which socket updates are postponed. nil means no limit.
+	MaxGasPrice *big.Int
+}
+
+// OperatorSocketRegistrar updates the socket registered on-chain for the operator.
+type OperatorSocketRegistrar interface {
+	UpdateOperatorSocket(ctx context.Context, socket string) error
+}
+
+// GasPriceOracle suggests the gas price of new transactions.
+type GasPriceOracle interface {
+	SuggestGasPrice(ctx context.Context) (*big.Int, error)
+}
+
+// SocketUpdater registers the socket of the node on-chain again when its public IP changes, so that operators
+// whose IP changes, e.g. on home connections, don't become unreachable. A new socket is registered once it's
+// detected by enough consecutive checks, and failed or postponed updates are retried with an exponential backoff.
+type SocketUpdater struct {
+	config    SocketUpdateConfig
+	detect    func(ctx context.Context) (string, error)
+	registrar OperatorSocketRegistrar
+	// gasPrice is nil if the gas price isn't limited
+	gasPrice GasPriceOracle
+	metrics  *Metrics
+	logger   logging.Logger
+
+	// mu guards the fields below, and serializes the checks
+	mu sync.Mutex
+	// registered is the socket registered on-chain
+	registered string
+	// candidate is the new socket detected by the last checks, and confirmations the number of consecutive checks
+	// which detected it
+	candidate     string
+	confirmations int
+	// failures is the number of failed attempts to register the candidate, and nextAttempt the time before which
+	// it isn't attempted again
+	failures    int
+	nextAttempt time.Time
+}
+
+// NewSocketUpdater creates a new SocketUpdater. detect returns the current socket of the node, and registered is
+// the socket registered on-chain when the node starts.
+func NewSocketUpdater(
+	config SocketUpdateConfig,
+	registered string,
+	detect func(ctx context.Context) (string, error),
+	registrar OperatorSocketRegistrar,
+	gasPrice GasPriceOracle,
+	metrics *Metrics,
+	logger logging.Logger,
+) (*SocketUpdater, error) {
+	if config.ConfirmChecks < 1 {
+		return nil, fmt.Errorf("the number of checks confirming a new socket must be positive, got %d", config.ConfirmChecks)
+	}
+	if config.RetryInterval <= 0 || config.MaxRetryInterval < config.RetryInterval {
+		return nil, fmt.Errorf("invalid socket update retry intervals %v and %v", config.RetryInterval, config.MaxRetryInterval)
+	}
+	if config.MaxGasPrice != nil && gasPrice == nil {
+		return nil, fmt.Errorf("a gas price oracle is required to limit the gas price of socket updates")
+	}
+	return &SocketUpdater{
+		config:     config,
+		detect:     detect,
+		registrar:  registrar,
+		gasPrice:   gasPrice,
+		metrics:    metrics,
+		logger:     logger.With("component", "SocketUpdater"),
+		registered: registered,
+	}, nil
+}
+
+// Socket returns the socket registered on-chain.
+func (u *SocketUpdater) Socket() string {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return u.registered
+}
+
+// SetRegistered records the socket registered on-chain, e.g. when it's updated by the operator.
+func (u *SocketUpdater) SetRegistered(socket string) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if socket != u.registered {
+		u.logger.Info("Socket registered on-chain changed", "old", u.registered, "new", socket)
+		u.registered = socket
+	}
+}
+
+// Start checks the socket of the node periodically in the background, until the context is cancelled.
+func (u *SocketUpdater) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(u.config.CheckInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				if err := u.RunCheck(ctx, now); err != nil {
+					u.logger.Warn("Failed to update the socket", "err", err)
+				}
+			}
+		}
+	}()
+}
+
+// RunCheck detects the current socket of the node, and registers it on-chain if it changed and the update is due.
+func (u *SocketUpdater) RunCheck(ctx context.Context, now time.Time) error {
+	socket, err := u.detect(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to detect the socket: %w", err)
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if socket == u.registered {
+		u.resetCandidate("")
+		return nil
+	}
+	if socket != u.candidate {
+		u.logger.Info("Detected a new socket", "registered", u.registered, "detected", socket)
+		u.resetCandidate(socket)
+	}
+	u.confirmations++
+	if u.confirmations < u.config.ConfirmChecks || now.Before(u.nextAttempt) {
+		return nil
+	}
+
+	if u.config.MaxGasPrice != nil {
+		price, err := u.gasPrice.SuggestGasPrice(ctx)
+		if err != nil {
+			u.retryLater(now, "gas_price")
+			return fmt.Errorf("failed to get the gas price: %w", err)
+		}
+		if price.Cmp(u.config.MaxGasPrice) > 0 {
+			u.retryLater(now, "gas_price")
+			return fmt.Errorf("gas price of %s wei is above the limit of %s wei, postponing the update to %s",
+				price, u.config.MaxGasPrice, u.nextAttempt.Format(time.RFC3339))
+		}
+	}
+
+	if err := u.registrar.UpdateOperatorSocket(ctx, socket); err != nil {
+		u.retryLater(now, "transaction")
+		return fmt.Errorf("failed to update the socket to %s, retrying at %s: %w", socket, u.nextAttempt.Format(time.RFC3339), err)
+	}
+	u.logger.Info("Socket update", "old socket", u.registered, "new socket", socket)
+	if u.metrics != nil {
+		u.metrics.RecordSocketAddressChange()
+	}
+	u.registered = socket
+	u.resetCandidate("")
+	return nil
+}
+
+// resetCandidate starts confirming a new candidate socket. The caller must hold mu.
+func (u *SocketUpdater) resetCandidate(socket string) {
+	u.candidate = socket
+	u.confirmations = 0
+	u.failures = 0
+	u.nextAttempt = time.Time{}
+}
+
+// retryLater postpones the next attempt to register the candidate with an exponential backoff. The caller must hold
+// mu.
+func (u *SocketUpdater) retryLater(now time.Time, reason string) {
+	backoff := u.config.RetryInterval
+	for i := 0; i < u.failures && backoff < u.config.MaxRetryInterval; i++ {
+		backoff *= 2
+	}
+	u.failures++
+	u.nextAttempt = now.Add(min(backoff, u.config.MaxRetryInterval))
+	if u.metrics != nil {
+		u.metrics.RecordSocketUpdateFailure(reason)
+	}
+}
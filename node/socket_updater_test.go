@@ -0,0 +1,132 @@
+package node_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common/testutils"
+	"github.com/Layr-Labs/eigenda/node"
+	"github.com/stretchr/testify/require"
+)
+
+// mockSocketRegistrar records the sockets registered on-chain.
+type mockSocketRegistrar struct {
+	sockets []string
+	err     error
+}
+
+func (r *mockSocketRegistrar) UpdateOperatorSocket(ctx context.Context, socket string) error {
+	if r.err != nil {
+		return r.err
+	}
+	r.sockets = append(r.sockets, socket)
+	return nil
+}
+
+// mockGasPriceOracle suggests a fixed gas price.
+type mockGasPriceOracle struct {
+	price *big.Int
+}
+
+func (o *mockGasPriceOracle) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	return o.price, nil
+}
+
+func newTestSocketUpdater(
+	t *testing.T,
+	config node.SocketUpdateConfig,
+	detected *string,
+	registrar *mockSocketRegistrar,
+	gasPrice node.GasPriceOracle,
+) *node.SocketUpdater {
+	detect := func(ctx context.Context) (string, error) {
+		return *detected, nil
+	}
+	updater, err := node.NewSocketUpdater(config, "1.1.1.1:1;2;3;4", detect, registrar, gasPrice, nil, testutils.GetLogger())
+	require.NoError(t, err)
+	return updater
+}
+
+func TestSocketUpdater(t *testing.T) {
+	ctx := context.Background()
+	config := node.SocketUpdateConfig{
+		CheckInterval:    time.Second,
+		ConfirmChecks:    2,
+		RetryInterval:    time.Minute,
+		MaxRetryInterval: 3 * time.Minute,
+	}
+	detected := "1.1.1.1:1;2;3;4"
+	registrar := &mockSocketRegistrar{}
+	updater := newTestSocketUpdater(t, config, &detected, registrar, nil)
+
+	// Nothing happens while the socket doesn't change
+	now := time.Now()
+	require.NoError(t, updater.RunCheck(ctx, now))
+	require.Empty(t, registrar.sockets)
+
+	// A flapping socket isn't registered
+	detected = "2.2.2.2:1;2;3;4"
+	require.NoError(t, updater.RunCheck(ctx, now))
+	detected = "1.1.1.1:1;2;3;4"
+	require.NoError(t, updater.RunCheck(ctx, now))
+	detected = "2.2.2.2:1;2;3;4"
+	require.NoError(t, updater.RunCheck(ctx, now))
+	require.Empty(t, registrar.sockets)
+
+	// The new socket is registered once confirmed
+	require.NoError(t, updater.RunCheck(ctx, now))
+	require.Equal(t, []string{"2.2.2.2:1;2;3;4"}, registrar.sockets)
+	require.Equal(t, "2.2.2.2:1;2;3;4", updater.Socket())
+
+	// Failed updates are retried with a backoff
+	registrar.err = errors.New("transaction failed")
+	detected = "3.3.3.3:1;2;3;4"
+	require.NoError(t, updater.RunCheck(ctx, now))
+	require.Error(t, updater.RunCheck(ctx, now))
+	require.NoError(t, updater.RunCheck(ctx, now.Add(59*time.Second)))
+	require.Error(t, updater.RunCheck(ctx, now.Add(time.Minute)))
+	// The second retry waits twice as long
+	require.NoError(t, updater.RunCheck(ctx, now.Add(time.Minute+119*time.Second)))
+	registrar.err = nil
+	require.NoError(t, updater.RunCheck(ctx, now.Add(3*time.Minute)))
+	require.Equal(t, []string{"2.2.2.2:1;2;3;4", "3.3.3.3:1;2;3;4"}, registrar.sockets)
+
+	// A socket registered on-chain which differs from the detected socket is replaced
+	updater.SetRegistered("3.3.3.3:5;6;7;8")
+	require.NoError(t, updater.RunCheck(ctx, now))
+	require.NoError(t, updater.RunCheck(ctx, now))
+	require.Equal(t, []string{"2.2.2.2:1;2;3;4", "3.3.3.3:1;2;3;4", "3.3.3.3:1;2;3;4"}, registrar.sockets)
+}
+
+func TestSocketUpdaterGasPriceLimit(t *testing.T) {
+	ctx := context.Background()
+	config := node.SocketUpdateConfig{
+		CheckInterval:    time.Second,
+		ConfirmChecks:    1,
+		RetryInterval:    time.Minute,
+		MaxRetryInterval: time.Minute,
+		MaxGasPrice:      big.NewInt(100),
+	}
+	detected := "2.2.2.2:1;2;3;4"
+	registrar := &mockSocketRegistrar{}
+	gasPrice := &mockGasPriceOracle{price: big.NewInt(101)}
+	updater := newTestSocketUpdater(t, config, &detected, registrar, gasPrice)
+
+	// The update is postponed while the gas price is above the limit
+	now := time.Now()
+	require.ErrorContains(t, updater.RunCheck(ctx, now), "above the limit")
+	require.Empty(t, registrar.sockets)
+
+	gasPrice.price = big.NewInt(100)
+	require.NoError(t, updater.RunCheck(ctx, now.Add(30*time.Second)))
+	require.Empty(t, registrar.sockets)
+	require.NoError(t, updater.RunCheck(ctx, now.Add(time.Minute)))
+	require.Equal(t, []string{"2.2.2.2:1;2;3;4"}, registrar.sockets)
+
+	// A gas price limit requires an oracle
+	_, err := node.NewSocketUpdater(config, "", nil, registrar, nil, nil, testutils.GetLogger())
+	require.Error(t, err)
+}
@@ -0,0 +1,186 @@
+package node
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// statsRetention is how long attestation outcomes are kept
+	statsRetention = 24 * time.Hour
+	// maxLatencySamples is the number of most recent validation latencies kept to compute percentiles
+	maxLatencySamples = 1024
+)
+
+// AttestationStats are the numbers of batches signed and missed by the node over a time window.
+type AttestationStats struct {
+	Signed uint64 `json:"signed"`
+	Missed uint64 `json:"missed"`
+}
+
+// LatencyPercentiles are percentiles of a latency, in milliseconds.
+type LatencyPercentiles struct {
+	NumSamples int     `json:"num_samples"`
+	P50Ms      float64 `json:"p50_ms"`
+	P90Ms      float64 `json:"p90_ms"`
+	P99Ms      float64 `json:"p99_ms"`
+}
+
+// NodeStats keeps recent statistics about the node's activity, which are reported to operators. The methods are
+// no-ops on a nil NodeStats.
+type NodeStats struct {
+	mu sync.Mutex
+	// attestations are the attestation outcomes, by the UNIX minute at which they happened
+	attestations map[int64]*AttestationStats
+	// validationLatencies is a ring buffer of the most recent validation latencies
+	validationLatencies []time.Duration
+	nextLatency         int
+	// latestReferenceBlock is the highest reference block number of the batches received by the node
+	latestReferenceBlock uint64
+	// lastOnchainStateRefresh is the time at which the on-chain state was last refreshed
+	lastOnchainStateRefresh time.Time
+}
+
+// NewNodeStats creates a new NodeStats.
+func NewNodeStats() *NodeStats {
+	return &NodeStats{
+		attestations:        make(map[int64]*AttestationStats),
+		validationLatencies: make([]time.Duration, 0, maxLatencySamples),
+	}
+}
+
+// RecordAttestation records whether the node signed a batch it received at the given time.
+func (s *NodeStats) RecordAttestation(signed bool, now time.Time) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	minute := now.Unix() / 60
+	stats, ok := s.attestations[minute]
+	if !ok {
+		stats = &AttestationStats{}
+		s.attestations[minute] = stats
+		s.pruneAttestations(now)
+	}
+	if signed {
+		stats.Signed++
+	} else {
+		stats.Missed++
+	}
+}
+
+// Attestations returns the numbers of batches signed and missed over the given window before now.
+func (s *NodeStats) Attestations(window time.Duration, now time.Time) AttestationStats {
+	total := AttestationStats{}
+	if s == nil {
+		return total
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	since := now.Add(-window).Unix() / 60
+	for minute, stats := range s.attestations {
+		if minute >= since {
+			total.Signed += stats.Signed
+			total.Missed += stats.Missed
+		}
+	}
+	return total
+}
+
+// pruneAttestations drops the attestation outcomes older than the retention. The caller must hold the lock.
+func (s *NodeStats) pruneAttestations(now time.Time) {
+	oldest := now.Add(-statsRetention).Unix() / 60
+	for minute := range s.attestations {
+		if minute < oldest {
+			delete(s.attestations, minute)
+		}
+	}
+}
+
+// RecordValidationLatency records the time taken to validate a batch.
+func (s *NodeStats) RecordValidationLatency(latency time.Duration) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.validationLatencies) < maxLatencySamples {
+		s.validationLatencies = append(s.validationLatencies, latency)
+		return
+	}
+	s.validationLatencies[s.nextLatency] = latency
+	s.nextLatency = (s.nextLatency + 1) % maxLatencySamples
+}
+
+// ValidationLatency returns the percentiles of the most recent validation latencies.
+func (s *NodeStats) ValidationLatency() LatencyPercentiles {
+	if s == nil {
+		return LatencyPercentiles{}
+	}
+	s.mu.Lock()
+	latencies := make([]time.Duration, len(s.validationLatencies))
+	copy(latencies, s.validationLatencies)
+	s.mu.Unlock()
+
+	if len(latencies) == 0 {
+		return LatencyPercentiles{}
+	}
+	sort.Slice(latencies, func(i, j int) bool {
+		return latencies[i] < latencies[j]
+	})
+	percentile := func(p float64) float64 {
+		index := int(p * float64(len(latencies)-1))
+		return float64(latencies[index].Microseconds()) / 1000
+	}
+	return LatencyPercentiles{
+		NumSamples: len(latencies),
+		P50Ms:      percentile(0.5),
+		P90Ms:      percentile(0.9),
+		P99Ms:      percentile(0.99),
+	}
+}
+
+// RecordReferenceBlock records the reference block number of a batch received by the node.
+func (s *NodeStats) RecordReferenceBlock(referenceBlockNumber uint64) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.latestReferenceBlock = max(s.latestReferenceBlock, referenceBlockNumber)
+}
+
+// LatestReferenceBlock returns the highest reference block number of the batches received by the node.
+func (s *NodeStats) LatestReferenceBlock() uint64 {
+	if s == nil {
+		return 0
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.latestReferenceBlock
+}
+
+// RecordOnchainStateRefresh records that the on-chain state was refreshed at the given time.
+func (s *NodeStats) RecordOnchainStateRefresh(now time.Time) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lastOnchainStateRefresh = now
+}
+
+// LastOnchainStateRefresh returns the time at which the on-chain state was last refreshed.
+func (s *NodeStats) LastOnchainStateRefresh() time.Time {
+	if s == nil {
+		return time.Time{}
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.lastOnchainStateRefresh
+}
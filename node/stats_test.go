@@ -0,0 +1,61 @@
+package node_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/node"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNodeStatsAttestations(t *testing.T) {
+	stats := node.NewNodeStats()
+	now := time.Unix(1_000_000, 0)
+
+	stats.RecordAttestation(true, now.Add(-2*time.Hour))
+	stats.RecordAttestation(false, now.Add(-2*time.Hour))
+	stats.RecordAttestation(true, now.Add(-time.Minute))
+	stats.RecordAttestation(true, now)
+	stats.RecordAttestation(false, now)
+
+	require.Equal(t, node.AttestationStats{Signed: 2, Missed: 1}, stats.Attestations(time.Hour, now))
+	require.Equal(t, node.AttestationStats{Signed: 3, Missed: 2}, stats.Attestations(24*time.Hour, now))
+
+	// Outcomes older than a day are dropped
+	later := now.Add(25 * time.Hour)
+	stats.RecordAttestation(true, later)
+	require.Equal(t, node.AttestationStats{Signed: 1}, stats.Attestations(48*time.Hour, later))
+}
+
+func TestNodeStatsValidationLatency(t *testing.T) {
+	stats := node.NewNodeStats()
+	require.Equal(t, node.LatencyPercentiles{}, stats.ValidationLatency())
+
+	for i := 1; i <= 100; i++ {
+		stats.RecordValidationLatency(time.Duration(i) * time.Millisecond)
+	}
+	latency := stats.ValidationLatency()
+	require.Equal(t, 100, latency.NumSamples)
+	require.Equal(t, 50.0, latency.P50Ms)
+	require.Equal(t, 90.0, latency.P90Ms)
+	require.Equal(t, 99.0, latency.P99Ms)
+
+	// Only the most recent samples are kept
+	for i := 0; i < 2000; i++ {
+		stats.RecordValidationLatency(time.Second)
+	}
+	latency = stats.ValidationLatency()
+	require.Equal(t, 1024, latency.NumSamples)
+	require.Equal(t, 1000.0, latency.P50Ms)
+}
+
+func TestNodeStatsNil(t *testing.T) {
+	var stats *node.NodeStats
+	stats.RecordAttestation(true, time.Now())
+	stats.RecordValidationLatency(time.Second)
+	stats.RecordReferenceBlock(10)
+	stats.RecordOnchainStateRefresh(time.Now())
+	require.Equal(t, node.AttestationStats{}, stats.Attestations(time.Hour, time.Now()))
+	require.Equal(t, uint64(0), stats.LatestReferenceBlock())
+	require.True(t, stats.LastOnchainStateRefresh().IsZero())
+}
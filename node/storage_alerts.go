@@ -0,0 +1,305 @@
+package node
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+// storageAlertWebhookTimeout is the timeout for delivering an alert to the webhook
+const storageAlertWebhookTimeout = 10 * time.Second
+
+// StorageAlertConfig configures the alerts raised before the disk holding the stores fills up and writes start
+// failing.
+type StorageAlertConfig struct {
+	// CheckInterval is the interval at which the disk usage is checked. 0 disables the alerts.
+	CheckInterval time.Duration
+	// WarningUsage and CriticalUsage are the fractions of the disk above which warning and critical alerts are
+	// raised. 0 disables the level.
+	WarningUsage  float64
+	CriticalUsage float64
+	// WarningDaysUntilFull is the projected number of days until the disk is full below which a warning is raised.
+	// 0 disables it.
+	WarningDaysUntilFull float64
+	// IngestRateWindow is the period over which the rate at which the disk fills up is measured, to project when
+	// it's full.
+	IngestRateWindow time.Duration
+	// WebhookURL receives a StorageAlert as JSON whenever the alert level changes. Empty disables the webhook.
+	WebhookURL string
+}
+
+// Validate checks that the config is consistent.
+func (c *StorageAlertConfig) Validate() error {
+	if c.CheckInterval <= 0 {
+		return nil
+	}
+	if c.WarningUsage < 0 || c.WarningUsage > 1 || c.CriticalUsage < 0 || c.CriticalUsage > 1 {
+		return fmt.Errorf("storage alert thresholds must be in [0, 1], got %f and %f", c.WarningUsage, c.CriticalUsage)
+	}
+	if c.WarningUsage > 0 && c.CriticalUsage > 0 && c.CriticalUsage < c.WarningUsage {
+		return fmt.Errorf("the critical storage alert threshold %f is below the warning threshold %f",
+			c.CriticalUsage, c.WarningUsage)
+	}
+	if c.WarningDaysUntilFull < 0 {
+		return errors.New("the storage alert days until full must not be negative")
+	}
+	if c.IngestRateWindow <= 0 {
+		return errors.New("the storage alert ingest rate window must be positive")
+	}
+	return nil
+}
+
+// StorageAlertLevel is the severity of a storage alert.
+type StorageAlertLevel string
+
+const (
+	StorageAlertOK       StorageAlertLevel = "ok"
+	StorageAlertWarning  StorageAlertLevel = "warning"
+	StorageAlertCritical StorageAlertLevel = "critical"
+)
+
+// gauge returns the value of the level reported in metrics.
+func (l StorageAlertLevel) gauge() float64 {
+	switch l {
+	case StorageAlertWarning:
+		return 1
+	case StorageAlertCritical:
+		return 2
+	default:
+		return 0
+	}
+}
+
+// StorageStatus is the usage of the disk holding the stores at a check.
+type StorageStatus struct {
+	UsedBytes    uint64  `json:"used_bytes"`
+	TotalBytes   uint64  `json:"total_bytes"`
+	UsedFraction float64 `json:"used_fraction"`
+	// BytesPerDay is the rate at which the disk filled up over the ingest rate window. It's negative if the usage
+	// decreased.
+	BytesPerDay float64 `json:"bytes_per_day"`
+	// DaysUntilFull is the projected number of days until the disk is full at BytesPerDay. It's nil if the disk
+	// isn't filling up, or the rate isn't known yet.
+	DaysUntilFull *float64          `json:"days_until_full,omitempty"`
+	Level         StorageAlertLevel `json:"level"`
+	// Reasons are the thresholds which raised the level
+	Reasons   []string  `json:"reasons,omitempty"`
+	CheckedAt time.Time `json:"checked_at"`
+}
+
+// StorageAlert is the payload posted to the webhook when the alert level changes.
+type StorageAlert struct {
+	OperatorID    string            `json:"operator_id"`
+	PreviousLevel StorageAlertLevel `json:"previous_level"`
+	Status        StorageStatus     `json:"status"`
+}
+
+// storageSample is the disk usage at a check.
+type storageSample struct {
+	time      time.Time
+	usedBytes uint64
+}
+
+// StorageAlerter checks the usage of the disk holding the stores, and warns operators through logs, metrics and an
+// optional webhook when it crosses the configured thresholds, or is projected to be full soon at the recent ingest
+// rate.
+type StorageAlerter struct {
+	config     StorageAlertConfig
+	operatorID string
+	// diskUsage returns the used and total bytes of the disk
+	diskUsage func() (uint64, uint64, error)
+	client    *http.Client
+	metrics   *Metrics
+	logger    logging.Logger
+
+	// mu guards the fields below, and serializes the checks
+	mu sync.Mutex
+	// samples are the disk usages within the ingest rate window, oldest first
+	samples []storageSample
+	status  *StorageStatus
+	// notified is the level last delivered to the webhook
+	notified StorageAlertLevel
+}
+
+// NewStorageAlerter creates a new StorageAlerter. diskUsage returns the used and total bytes of the disk holding the
+// stores.
+func NewStorageAlerter(
+	config StorageAlertConfig,
+	operatorID string,
+	diskUsage func() (uint64, uint64, error),
+	metrics *Metrics,
+	logger logging.Logger,
+) (*StorageAlerter, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return &StorageAlerter{
+		config:     config,
+		operatorID: operatorID,
+		diskUsage:  diskUsage,
+		client:     &http.Client{Timeout: storageAlertWebhookTimeout},
+		metrics:    metrics,
+		logger:     logger.With("component", "StorageAlerter"),
+		notified:   StorageAlertOK,
+	}, nil
+}
+
+// Start checks the disk usage periodically in the background, until the context is cancelled.
+func (a *StorageAlerter) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(a.config.CheckInterval)
+		defer ticker.Stop()
+		for {
+			if _, err := a.RunCheck(ctx, time.Now()); err != nil {
+				a.logger.Error("Failed to check the disk usage", "err", err)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Status returns the status at the last check, or nil if the disk usage wasn't checked yet.
+func (a *StorageAlerter) Status() *StorageStatus {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.status == nil {
+		return nil
+	}
+	status := *a.status
+	return &status
+}
+
+// RunCheck checks the disk usage at the given time, and raises an alert if its level changed. Alerts which fail to
+// be delivered to the webhook are retried at the next check.
+func (a *StorageAlerter) RunCheck(ctx context.Context, now time.Time) (*StorageStatus, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	used, total, err := a.diskUsage()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get disk usage: %w", err)
+	}
+	if total == 0 {
+		return nil, errors.New("the disk size is 0")
+	}
+
+	a.samples = append(a.samples, storageSample{time: now, usedBytes: used})
+	start := 0
+	for start < len(a.samples)-1 && now.Sub(a.samples[start].time) > a.config.IngestRateWindow {
+		start++
+	}
+	a.samples = a.samples[start:]
+
+	status := &StorageStatus{
+		UsedBytes:    used,
+		TotalBytes:   total,
+		UsedFraction: float64(used) / float64(total),
+		Level:        StorageAlertOK,
+		CheckedAt:    now,
+	}
+	oldest := a.samples[0]
+	if elapsed := now.Sub(oldest.time); elapsed > 0 {
+		status.BytesPerDay = (float64(used) - float64(oldest.usedBytes)) / elapsed.Hours() * 24
+		if status.BytesPerDay > 0 && used < total {
+			days := float64(total-used) / status.BytesPerDay
+			status.DaysUntilFull = &days
+		}
+	}
+
+	if a.config.WarningUsage > 0 && status.UsedFraction >= a.config.WarningUsage {
+		status.Level = StorageAlertWarning
+		status.Reasons = append(status.Reasons, fmt.Sprintf("disk usage %.1f%% is above %.1f%%",
+			status.UsedFraction*100, a.config.WarningUsage*100))
+	}
+	if a.config.WarningDaysUntilFull > 0 && status.DaysUntilFull != nil && *status.DaysUntilFull < a.config.WarningDaysUntilFull {
+		status.Level = StorageAlertWarning
+		status.Reasons = append(status.Reasons, fmt.Sprintf("disk projected to be full in %.1f days at %d bytes per day",
+			*status.DaysUntilFull, uint64(status.BytesPerDay)))
+	}
+	if a.config.CriticalUsage > 0 && status.UsedFraction >= a.config.CriticalUsage {
+		status.Level = StorageAlertCritical
+		status.Reasons = append(status.Reasons, fmt.Sprintf("disk usage %.1f%% is above the critical %.1f%%",
+			status.UsedFraction*100, a.config.CriticalUsage*100))
+	}
+
+	previous := StorageAlertOK
+	if a.status != nil {
+		previous = a.status.Level
+	}
+	a.status = status
+	a.reportMetrics(status)
+	if status.Level != previous {
+		a.logLevelChange(status, previous)
+	}
+	if a.config.WebhookURL != "" && status.Level != a.notified {
+		alert := StorageAlert{OperatorID: a.operatorID, PreviousLevel: a.notified, Status: *status}
+		if err := a.postAlert(ctx, alert); err != nil {
+			a.logger.Error("Failed to deliver the storage alert to the webhook, retrying at the next check", "err", err)
+		} else {
+			a.notified = status.Level
+		}
+	}
+	return status, nil
+}
+
+// logLevelChange logs a change of the alert level, at the severity of the new level.
+func (a *StorageAlerter) logLevelChange(status *StorageStatus, previous StorageAlertLevel) {
+	args := []any{"previous", previous, "usedBytes", status.UsedBytes, "totalBytes", status.TotalBytes,
+		"bytesPerDay", int64(status.BytesPerDay), "reasons", status.Reasons}
+	if status.DaysUntilFull != nil {
+		args = append(args, "daysUntilFull", *status.DaysUntilFull)
+	}
+	switch status.Level {
+	case StorageAlertCritical:
+		a.logger.Error("Disk usage is critical, writes will fail once the disk is full", args...)
+	case StorageAlertWarning:
+		a.logger.Warn("Disk usage is high", args...)
+	default:
+		a.logger.Info("Disk usage is back to normal", args...)
+	}
+}
+
+// postAlert posts the alert to the webhook.
+func (a *StorageAlerter) postAlert(ctx context.Context, alert StorageAlert) error {
+	body, err := json.Marshal(alert)
+	if err != nil {
+		return fmt.Errorf("failed to encode storage alert: %w", err)
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, a.config.WebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	request.Header.Set("Content-Type", "application/json")
+	response, err := a.client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = response.Body.Close() }()
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded with status %d", response.StatusCode)
+	}
+	return nil
+}
+
+func (a *StorageAlerter) reportMetrics(status *StorageStatus) {
+	if a.metrics == nil {
+		return
+	}
+	daysUntilFull := math.Inf(1)
+	if status.DaysUntilFull != nil {
+		daysUntilFull = *status.DaysUntilFull
+	}
+	a.metrics.ReportStorageStatus(status.UsedBytes, status.TotalBytes, daysUntilFull, status.Level.gauge())
+}
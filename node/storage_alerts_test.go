@@ -0,0 +1,141 @@
+package node_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common/testutils"
+	"github.com/Layr-Labs/eigenda/node"
+	"github.com/stretchr/testify/require"
+)
+
+// alertWebhook records the storage alerts posted to it, and fails while failing is set.
+type alertWebhook struct {
+	mu      sync.Mutex
+	alerts  []node.StorageAlert
+	failing bool
+}
+
+func (w *alertWebhook) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.failing {
+		rw.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	var alert node.StorageAlert
+	if err := json.NewDecoder(r.Body).Decode(&alert); err != nil {
+		rw.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	w.alerts = append(w.alerts, alert)
+}
+
+func (w *alertWebhook) setFailing(failing bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.failing = failing
+}
+
+func (w *alertWebhook) received() []node.StorageAlert {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return append([]node.StorageAlert(nil), w.alerts...)
+}
+
+func TestStorageAlerter(t *testing.T) {
+	ctx := context.Background()
+	webhook := &alertWebhook{}
+	server := httptest.NewServer(webhook)
+	defer server.Close()
+
+	const total = 1000
+	used := uint64(100)
+	config := node.StorageAlertConfig{
+		CheckInterval:        time.Minute,
+		WarningUsage:         0.8,
+		CriticalUsage:        0.9,
+		WarningDaysUntilFull: 3,
+		IngestRateWindow:     24 * time.Hour,
+		WebhookURL:           server.URL,
+	}
+	diskUsage := func() (uint64, uint64, error) {
+		return used, total, nil
+	}
+	alerter, err := node.NewStorageAlerter(config, "operator", diskUsage, nil, testutils.GetLogger())
+	require.NoError(t, err)
+	require.Nil(t, alerter.Status())
+
+	// The rate isn't known from a single check
+	now := time.Now()
+	status, err := alerter.RunCheck(ctx, now)
+	require.NoError(t, err)
+	require.Equal(t, node.StorageAlertOK, status.Level)
+	require.Nil(t, status.DaysUntilFull)
+	require.Empty(t, webhook.received())
+
+	// Filling up slowly isn't a concern
+	used = 110
+	status, err = alerter.RunCheck(ctx, now.Add(24*time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, node.StorageAlertOK, status.Level)
+	require.InDelta(t, 10, status.BytesPerDay, 1e-9)
+	require.InDelta(t, 89, *status.DaysUntilFull, 1e-9)
+
+	// Filling up fast is, even below the usage thresholds
+	used = 710
+	status, err = alerter.RunCheck(ctx, now.Add(48*time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, node.StorageAlertWarning, status.Level)
+	require.InDelta(t, 290.0/600, *status.DaysUntilFull, 1e-9)
+	alerts := webhook.received()
+	require.Len(t, alerts, 1)
+	require.Equal(t, "operator", alerts[0].OperatorID)
+	require.Equal(t, node.StorageAlertOK, alerts[0].PreviousLevel)
+	require.Equal(t, node.StorageAlertWarning, alerts[0].Status.Level)
+	require.Equal(t, uint64(710), alerts[0].Status.UsedBytes)
+
+	// Alerts which fail to be delivered are retried at the next check
+	webhook.setFailing(true)
+	used = 950
+	status, err = alerter.RunCheck(ctx, now.Add(49*time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, node.StorageAlertCritical, status.Level)
+	require.Len(t, webhook.received(), 1)
+	webhook.setFailing(false)
+	_, err = alerter.RunCheck(ctx, now.Add(50*time.Hour))
+	require.NoError(t, err)
+	alerts = webhook.received()
+	require.Len(t, alerts, 2)
+	require.Equal(t, node.StorageAlertWarning, alerts[1].PreviousLevel)
+	require.Equal(t, node.StorageAlertCritical, alerts[1].Status.Level)
+
+	// The alert is resolved once the disk is freed and the usage stops growing
+	used = 200
+	status, err = alerter.RunCheck(ctx, now.Add(100*time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, node.StorageAlertOK, status.Level)
+	require.Nil(t, status.DaysUntilFull)
+	alerts = webhook.received()
+	require.Len(t, alerts, 3)
+	require.Equal(t, node.StorageAlertOK, alerts[2].Status.Level)
+	require.Equal(t, status, alerter.Status())
+}
+
+func TestStorageAlertConfigValidate(t *testing.T) {
+	// Disabled alerts aren't validated
+	config := node.StorageAlertConfig{WarningUsage: 2}
+	require.NoError(t, config.Validate())
+
+	config = node.StorageAlertConfig{CheckInterval: time.Minute, WarningUsage: 0.9, CriticalUsage: 0.8, IngestRateWindow: time.Hour}
+	require.Error(t, config.Validate())
+	config.CriticalUsage = 0.95
+	require.NoError(t, config.Validate())
+	config.IngestRateWindow = 0
+	require.Error(t, config.Validate())
+}
@@ -8,8 +8,11 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/Layr-Labs/eigenda/common/aws/envelope"
 	"github.com/Layr-Labs/eigenda/common/kvstore"
 	"github.com/Layr-Labs/eigenda/common/kvstore/leveldb"
+	"github.com/Layr-Labs/eigenda/common/kvstore/pebble"
+	"github.com/Layr-Labs/eigenda/common/kvstore/tablestore"
 
 	"github.com/Layr-Labs/eigenda/api/grpc/node"
 	"github.com/Layr-Labs/eigenda/core"
@@ -17,6 +20,7 @@ import (
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	"github.com/consensys/gnark-crypto/ecc/bn254"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/syndtr/goleveldb/leveldb/util"
 	"google.golang.org/protobuf/proto"
 )
 
@@ -32,6 +36,8 @@ var ErrBatchAlreadyExist = errors.New("batch already exists")
 type Store struct {
 	db     kvstore.Store[[]byte]
 	logger logging.Logger
+	// encryptor encrypts the chunks at rest. If nil, chunks are stored in plaintext.
+	encryptor *envelope.Encryptor
 
 	blockStaleMeasure   uint32
 	storeDurationBlocks uint32
@@ -40,24 +46,66 @@ type Store struct {
 	metrics *Metrics
 }
 
-// NewLevelDBStore creates a new Store object with a db at the provided path and the given logger.
-// TODO(jianoaix): parameterize this so we can switch between different database backends.
+// NewLevelDBStore creates a new Store object with a LevelDB db at the provided path and the given logger.
 func NewLevelDBStore(path string, logger logging.Logger, metrics *Metrics, blockStaleMeasure, storeDurationBlocks uint32) (*Store, error) {
-	// Create the db at the path. This is currently hardcoded to use
-	// levelDB.
-	db, err := leveldb.NewStore(logger, path)
+	return NewStore(tablestore.LevelDB, path, logger, metrics, blockStaleMeasure, storeDurationBlocks, nil)
+}
+
+// NewStore creates a new Store object with a db of the given backend at the provided path and the given logger.
+// The chunks are encrypted at rest with the encryptor, unless it's nil.
+func NewStore(
+	backend tablestore.StoreType,
+	path string,
+	logger logging.Logger,
+	metrics *Metrics,
+	blockStaleMeasure, storeDurationBlocks uint32,
+	encryptor *envelope.Encryptor,
+) (*Store, error) {
+	db, err := openKVStore(backend, path, logger)
 	if err != nil {
-		logger.Error("Could not create leveldb database", "err", err)
+		logger.Error("Could not create database", "backend", backend, "err", err)
 		return nil, err
 	}
+	return NewStoreWithDB(db, logger, metrics, blockStaleMeasure, storeDurationBlocks, encryptor), nil
+}
 
+// NewStoreWithDB creates a new Store object on top of the given db.
+func NewStoreWithDB(
+	db kvstore.Store[[]byte],
+	logger logging.Logger,
+	metrics *Metrics,
+	blockStaleMeasure, storeDurationBlocks uint32,
+	encryptor *envelope.Encryptor,
+) *Store {
 	return &Store{
 		db:                  db,
 		logger:              logger.With("component", "NodeStore"),
 		blockStaleMeasure:   blockStaleMeasure,
 		storeDurationBlocks: storeDurationBlocks,
 		metrics:             metrics,
-	}, nil
+		encryptor:           encryptor,
+	}
+}
+
+// openKVStore opens the kv store of the given backend at the provided path.
+func openKVStore(backend tablestore.StoreType, path string, logger logging.Logger) (kvstore.Store[[]byte], error) {
+	switch backend {
+	case tablestore.LevelDB:
+		return leveldb.NewStore(logger, path)
+	case tablestore.Pebble:
+		return pebble.NewStore(logger, path)
+	default:
+		return nil, fmt.Errorf("unsupported store backend: %d", backend)
+	}
+}
+
+// dbPathSuffix returns the suffix of the directories of the stores of the given backend. LevelDB stores keep the
+// original directories, and the stores of other backends get their own, since backends can't read each other's data.
+func dbPathSuffix(backend tablestore.StoreType) string {
+	if backend == tablestore.Pebble {
+		return "_pebble"
+	}
+	return ""
 }
 
 // Delete expired entries in the store.
@@ -163,15 +211,11 @@ func (s *Store) deleteExpiredBlobs(currentTimeUnixSec int64, numBlobs int) (int,
 		blobHeaderIter.Release()
 
 		// Blob chunks.
-		blobIter, err := s.db.NewIterator(EncodeBlobKeyByHashPrefix(blobHeaderHash))
+		chunksSize, err := s.deleteChunks(batch, EncodeBlobKeyByHashPrefix(blobHeaderHash))
 		if err != nil {
-			return -1, fmt.Errorf("failed to create an iterator for the blob chunks: %w", err)
+			return -1, err
 		}
-		for blobIter.Next() {
-			batch.Delete(copyBytes(blobIter.Key()))
-			size += int64(len(blobIter.Value()))
-		}
-		blobIter.Release()
+		size += chunksSize
 	}
 
 	// Perform the removal.
@@ -309,15 +353,11 @@ func (s *Store) deleteNBatches(currentTimeUnixSec int64, numBatches int) (int, e
 		blobHeaderIter.Release()
 
 		// Blob chunks.
-		blobIter, err := s.db.NewIterator(bytes.NewBuffer(hash).Bytes())
+		chunksSize, err := s.deleteChunks(batch, bytes.NewBuffer(hash).Bytes())
 		if err != nil {
-			return -1, fmt.Errorf("failed to create an iterator for the blob chunks: %w", err)
-		}
-		for blobIter.Next() {
-			batch.Delete(copyBytes(blobIter.Key()))
-			size += int64(len(blobIter.Value()))
+			return -1, err
 		}
-		blobIter.Release()
+		size += chunksSize
 	}
 
 	// Perform the removal.
@@ -334,6 +374,48 @@ func (s *Store) deleteNBatches(currentTimeUnixSec int64, numBatches int) (int, e
 	return len(expiredBatches), nil
 }
 
+// deleteChunks deletes the chunks whose keys start with the given prefix, and returns their size in bytes.
+// If the db supports range deletes, the chunks are dropped right away with a single range delete, without reading
+// them, and their size is estimated from disk usage. This is safe to do ahead of the batch, since the keys which
+// lead to the chunks are only deleted by the batch, so a failure before the batch is applied is retried in the next
+// expiration cycle. Otherwise, the chunks are deleted one by one in the batch.
+func (s *Store) deleteChunks(batch kvstore.Batch[[]byte], prefix []byte) (int64, error) {
+	if rangeDeleter, ok := s.db.(kvstore.RangeDeleter); ok {
+		keyRange := util.BytesPrefix(prefix)
+		size, err := rangeDeleter.ApproximateSize(keyRange.Start, keyRange.Limit)
+		if err != nil {
+			return 0, fmt.Errorf("failed to estimate the size of the blob chunks: %w", err)
+		}
+		err = rangeDeleter.DeleteRange(keyRange.Start, keyRange.Limit)
+		if err != nil {
+			return 0, fmt.Errorf("failed to delete the blob chunks: %w", err)
+		}
+		return int64(size), nil
+	}
+
+	iter, err := s.db.NewIterator(prefix)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create an iterator for the blob chunks: %w", err)
+	}
+	defer iter.Release()
+	size := int64(0)
+	for iter.Next() {
+		batch.Delete(copyBytes(iter.Key()))
+		size += int64(len(iter.Value()))
+	}
+	return size, nil
+}
+
+// Compact compacts the whole db, so that the disk space of deleted entries is reclaimed. It's a no-op if the db
+// doesn't support compactions.
+func (s *Store) Compact() error {
+	compactor, ok := s.db.(kvstore.Compactor)
+	if !ok {
+		return nil
+	}
+	return compactor.Compact(nil, nil)
+}
+
 // Store the batch into the store.
 //
 // The batch will be itemized into multiple entries when it's stored:
@@ -380,14 +462,165 @@ func (s *Store) StoreBatch(ctx context.Context, header *core.BatchHeader, blobs
 
 	// Generate key/value pairs for all blob headers and blob chunks .
 	size := int64(0)
-	var serializationDuration, encodingDuration time.Duration
+	var serializationDuration time.Duration
 	for idx, blob := range blobs {
-		// blob header
-		blobHeaderKey, err := EncodeBlobHeaderKey(batchHeaderHash, idx)
+		blobSize, blobSerializationDuration, err := s.putBatchBlob(ctx, batch, &keys, batchHeaderHash, idx, blob, blobsProto[idx])
 		if err != nil {
-			log.Error("Cannot generate the key for storing blob header:", "err", err)
 			return nil, err
 		}
+		size += blobSize
+		serializationDuration += blobSerializationDuration
+	}
+
+	start := time.Now()
+	// Write all the key/value pairs to the local database atomically.
+	err = batch.Apply()
+	if err != nil {
+		log.Error("Failed to write the batch into local database:", "err", err)
+		return nil, err
+	}
+	throughput := float64(size) / time.Since(start).Seconds()
+	s.metrics.DBWriteThroughput.Set(throughput)
+	log.Debug("StoreBatch succeeded", "chunk serialization duration", serializationDuration, "num blobs", len(blobs), "num of key-value pair entries", len(keys), "write batch duration", time.Since(start), "write throughput (MB/s)", throughput/1000_000, "total store batch duration", time.Since(storeBatchStart), "total bytes", size)
+
+	return &keys, nil
+}
+
+// StoreBatchBlob stores the header and chunks of the blob at the given index of a batch, for batches which are
+// received one blob at a time. The batch is only complete once its header is stored by StoreBatchHeader. The expiry
+// of the batch is stored along with its first blob, so that the blobs of a batch which is never completed are garbage
+// collected like those of complete batches.
+//
+// The entries of the blob are stored atomically, and the keys are returned so that they can be rolled back.
+func (s *Store) StoreBatchBlob(ctx context.Context, batchHeaderHash [32]byte, idx int, blob *core.BlobMessage, blobProto *node.Blob) (*[][]byte, error) {
+	start := time.Now()
+	keys := make([][]byte, 0)
+	batch := s.db.NewBatch()
+
+	if idx == 0 {
+		expirationKey := EncodeBatchExpirationKey(s.expirationTime())
+		keys = append(keys, expirationKey)
+		batch.Put(expirationKey, batchHeaderHash[:])
+	}
+	size, serializationDuration, err := s.putBatchBlob(ctx, batch, &keys, batchHeaderHash, idx, blob, blobProto)
+	if err != nil {
+		return nil, err
+	}
+
+	writeStart := time.Now()
+	err = batch.Apply()
+	if err != nil {
+		s.logger.Error("Failed to write the blob into local database:", "err", err)
+		return nil, err
+	}
+	throughput := float64(size) / time.Since(writeStart).Seconds()
+	s.metrics.DBWriteThroughput.Set(throughput)
+	s.logger.Debug("StoreBatchBlob succeeded", "blob index", idx, "chunk serialization duration", serializationDuration, "write batch duration", time.Since(writeStart), "total store blob duration", time.Since(start), "total bytes", size)
+
+	return &keys, nil
+}
+
+// StoreBatchHeader completes a batch whose blobs are stored by StoreBatchBlob, by storing the batch header. It
+// returns ErrBatchAlreadyExist if the batch is stored already.
+func (s *Store) StoreBatchHeader(ctx context.Context, header *core.BatchHeader) (*[][]byte, error) {
+	batchHeaderHash, err := header.GetBatchHeaderHash()
+	if err != nil {
+		return nil, err
+	}
+	batchHeaderKey := EncodeBatchHeaderKey(batchHeaderHash)
+	if s.HasKey(ctx, batchHeaderKey) {
+		return nil, ErrBatchAlreadyExist
+	}
+	batchHeaderBytes, err := header.Serialize()
+	if err != nil {
+		s.logger.Error("Cannot serialize the batch header:", "err", err)
+		return nil, err
+	}
+	err = s.db.Put(batchHeaderKey, batchHeaderBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write the batch header into local database: %w", err)
+	}
+	return &[][]byte{batchHeaderKey}, nil
+}
+
+// putBatchBlob adds the header and chunks of the blob at the given index of a batch to the write batch, and appends
+// their keys to keys. It returns the size of the chunks and the time spent serializing them.
+func (s *Store) putBatchBlob(
+	ctx context.Context,
+	batch kvstore.Batch[[]byte],
+	keys *[][]byte,
+	batchHeaderHash [32]byte,
+	idx int,
+	blob *core.BlobMessage,
+	blobProto *node.Blob,
+) (int64, time.Duration, error) {
+	log := s.logger
+
+	// blob header
+	blobHeaderKey, err := EncodeBlobHeaderKey(batchHeaderHash, idx)
+	if err != nil {
+		log.Error("Cannot generate the key for storing blob header:", "err", err)
+		return 0, 0, err
+	}
+	blobHeaderBytes, err := proto.Marshal(blobProto.GetHeader())
+	if err != nil {
+		log.Error("Cannot serialize the blob header proto:", "err", err)
+		return 0, 0, err
+	}
+	*keys = append(*keys, blobHeaderKey)
+	batch.Put(blobHeaderKey, blobHeaderBytes)
+
+	// blob chunks
+	start := time.Now()
+	bundles, err := serializeBundles(blob, blobProto)
+	if err != nil {
+		return 0, 0, err
+	}
+	serializationDuration := time.Since(start)
+	size := int64(0)
+	for quorumID, bundle := range bundles {
+		key, err := EncodeBlobKey(batchHeaderHash, idx, quorumID)
+		if err != nil {
+			log.Error("Cannot generate the key for storing blob:", "err", err)
+			return 0, 0, err
+		}
+		bundle, err = s.encryptor.Encrypt(ctx, bundle)
+		if err != nil {
+			return 0, 0, fmt.Errorf("failed to encrypt chunks: %w", err)
+		}
+		size += int64(len(bundle))
+		*keys = append(*keys, key)
+		batch.Put(key, bundle)
+	}
+	return size, serializationDuration, nil
+}
+
+// StoreBlobs stores the blobs dispersed in a minibatch. The blobs aren't part of a batch yet, so they are keyed by
+// their blob header hash, and a batch is mapped to them once it's attested (see StoreBatchBlobMapping):
+//   - The header of each blob: keyed by <blobHeaderPrefix, blobHeaderHash>
+//   - The chunks of each blob: keyed by <blobHeaderHash, quorumID>
+//   - The expiry of each blob: keyed by <blobExpirationPrefix, expirationTime, blobHeaderHash>
+//
+// Blobs which are stored already are skipped. These entries will be stored atomically.
+func (s *Store) StoreBlobs(ctx context.Context, blobs []*core.BlobMessage, blobsProto []*node.Blob) (*[][]byte, error) {
+	start := time.Now()
+	log := s.logger
+
+	keys := make([][]byte, 0)
+	batch := s.db.NewBatch()
+	expirationTime := s.expirationTime()
+	size := int64(0)
+	numBlobs := 0
+	for idx, blob := range blobs {
+		blobHeaderHash, err := blob.BlobHeader.GetBlobHeaderHash()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get blob header hash: %w", err)
+		}
+		blobHeaderKey := EncodeBlobHeaderKeyByHash(blobHeaderHash)
+		if s.HasKey(ctx, blobHeaderKey) {
+			log.Warn("Blob already exists", "blobHeaderHash", hexutil.Encode(blobHeaderHash[:]))
+			continue
+		}
 		blobHeaderBytes, err := proto.Marshal(blobsProto[idx].GetHeader())
 		if err != nil {
 			log.Error("Cannot serialize the blob header proto:", "err", err)
@@ -396,86 +629,130 @@ func (s *Store) StoreBatch(ctx context.Context, header *core.BatchHeader, blobs
 		keys = append(keys, blobHeaderKey)
 		batch.Put(blobHeaderKey, blobHeaderBytes)
 
-		// Get raw chunks
-		start := time.Now()
-		rawBlob := blobsProto[idx]
-		if len(rawBlob.GetBundles()) != len(blob.Bundles) {
-			return nil, errors.New("internal error: the number of bundles in parsed blob must be the same as in raw blob")
-		}
-		format := GetBundleEncodingFormat(rawBlob)
-		rawBundles := make(map[core.QuorumID][]byte)
-		rawChunks := make(map[core.QuorumID][][]byte)
-		for i, bundle := range rawBlob.GetBundles() {
-			quorumID := uint8(rawBlob.GetHeader().GetQuorumHeaders()[i].GetQuorumId())
-			if format == core.GnarkBundleEncodingFormat {
-				if len(bundle.GetChunks()) > 0 && len(bundle.GetChunks()[0]) > 0 {
-					return nil, errors.New("chunks of a bundle are encoded together already")
-				}
-				rawBundles[quorumID] = bundle.GetBundle()
-			} else {
-				rawChunks[quorumID] = make([][]byte, len(bundle.GetChunks()))
-				for j, chunk := range bundle.GetChunks() {
-					rawChunks[quorumID][j] = chunk
-				}
-			}
+		expirationKey := EncodeBlobExpirationKey(expirationTime, blobHeaderHash)
+		keys = append(keys, expirationKey)
+		batch.Put(expirationKey, blobHeaderHash[:])
+
+		bundles, err := serializeBundles(blob, blobsProto[idx])
+		if err != nil {
+			return nil, err
 		}
-		serializationDuration += time.Since(start)
-		start = time.Now()
-		// blob chunks
-		for quorumID, bundle := range blob.Bundles {
-			key, err := EncodeBlobKey(batchHeaderHash, idx, quorumID)
+		for quorumID, bundle := range bundles {
+			key, err := EncodeBlobKeyByHash(blobHeaderHash, quorumID)
 			if err != nil {
 				log.Error("Cannot generate the key for storing blob:", "err", err)
 				return nil, err
 			}
-
-			if format == core.GnarkBundleEncodingFormat {
-				rawBundle, ok := rawBundles[quorumID]
-				if ok {
-					size += int64(len(rawBundle))
-					keys = append(keys, key)
-					batch.Put(key, rawBundle)
-				}
-			} else if format == core.GobBundleEncodingFormat {
-				if len(rawChunks[quorumID]) != len(bundle) {
-					return nil, errors.New("internal error: the number of chunks in parsed blob bundle must be the same as in raw blob bundle")
-				}
-				chunksBytes, ok := rawChunks[quorumID]
-				if ok {
-
-					bundleRaw := make([][]byte, len(bundle))
-					for i := 0; i < len(bundle); i++ {
-						bundleRaw[i] = chunksBytes[i]
-					}
-					chunkBytes, err := EncodeChunks(bundleRaw)
-					if err != nil {
-						return nil, err
-					}
-					size += int64(len(chunkBytes))
-					keys = append(keys, key)
-					batch.Put(key, chunkBytes)
-				}
-			} else {
-				return nil, fmt.Errorf("invalid bundle encoding format: %d", format)
+			bundle, err = s.encryptor.Encrypt(ctx, bundle)
+			if err != nil {
+				return nil, fmt.Errorf("failed to encrypt chunks: %w", err)
 			}
+			size += int64(len(bundle))
+			keys = append(keys, key)
+			batch.Put(key, bundle)
 		}
-		encodingDuration += time.Since(start)
+		numBlobs++
 	}
 
-	start := time.Now()
-	// Write all the key/value pairs to the local database atomically.
-	err = batch.Apply()
+	err := batch.Apply()
 	if err != nil {
-		log.Error("Failed to write the batch into local database:", "err", err)
+		log.Error("Failed to write the blobs into local database:", "err", err)
 		return nil, err
 	}
-	throughput := float64(size) / time.Since(start).Seconds()
-	s.metrics.DBWriteThroughput.Set(throughput)
-	log.Debug("StoreBatch succeeded", "chunk serialization duration", serializationDuration, "bytes encoding duration", encodingDuration, "num blobs", len(blobs), "num of key-value pair entries", len(keys), "write batch duration", time.Since(start), "write throughput (MB/s)", throughput/1000_000, "total store batch duration", time.Since(storeBatchStart), "total bytes", size)
+	log.Debug("StoreBlobs succeeded", "num blobs", len(blobs), "num blobs stored", numBlobs, "num of key-value pair entries", len(keys), "total bytes", size, "duration", time.Since(start))
 
 	return &keys, nil
 }
 
+// StoreBatchBlobMapping stores the mapping of an attested batch to the blobs stored by StoreBlobs, so that the blobs
+// can be looked up by their index in the batch:
+//   - Batch header: keyed by <batchHeaderPrefix, batchHeaderHash>
+//   - The blob header hash of each blob in the batch: keyed by <blobIndexPrefix, batchHeaderHash, blobIdx>
+//   - The expiry of the mapping: keyed by <batchMappingExpirationPrefix, expirationTime, batchHeaderHash>
+//
+// These entries will be stored atomically. It returns ErrBatchAlreadyExist if the batch is stored already.
+func (s *Store) StoreBatchBlobMapping(ctx context.Context, header *core.BatchHeader, blobHeaderHashes [][32]byte) error {
+	batchHeaderHash, err := header.GetBatchHeaderHash()
+	if err != nil {
+		return err
+	}
+	batchHeaderKey := EncodeBatchHeaderKey(batchHeaderHash)
+	if s.HasKey(ctx, batchHeaderKey) {
+		return ErrBatchAlreadyExist
+	}
+	batchHeaderBytes, err := header.Serialize()
+	if err != nil {
+		return fmt.Errorf("failed to serialize batch header: %w", err)
+	}
+
+	batch := s.db.NewBatch()
+	batch.Put(batchHeaderKey, batchHeaderBytes)
+	batch.Put(EncodeBatchMappingExpirationKey(s.expirationTime(), batchHeaderHash), batchHeaderHash[:])
+	for idx, blobHeaderHash := range blobHeaderHashes {
+		batch.Put(EncodeBlobIndexKey(batchHeaderHash, idx), blobHeaderHash[:])
+	}
+
+	err = batch.Apply()
+	if err != nil {
+		return fmt.Errorf("failed to write the batch mapping into local database: %w", err)
+	}
+	return nil
+}
+
+// serializeBundles returns the bundles of the blob for each quorum, serialized in the format they are stored in.
+func serializeBundles(blob *core.BlobMessage, rawBlob *node.Blob) (map[core.QuorumID][]byte, error) {
+	if len(rawBlob.GetBundles()) != len(blob.Bundles) {
+		return nil, errors.New("internal error: the number of bundles in parsed blob must be the same as in raw blob")
+	}
+	format := GetBundleEncodingFormat(rawBlob)
+	rawBundles := make(map[core.QuorumID][]byte)
+	rawChunks := make(map[core.QuorumID][][]byte)
+	for i, bundle := range rawBlob.GetBundles() {
+		quorumID := uint8(rawBlob.GetHeader().GetQuorumHeaders()[i].GetQuorumId())
+		if format == core.GnarkBundleEncodingFormat {
+			if len(bundle.GetChunks()) > 0 && len(bundle.GetChunks()[0]) > 0 {
+				return nil, errors.New("chunks of a bundle are encoded together already")
+			}
+			rawBundles[quorumID] = bundle.GetBundle()
+		} else {
+			rawChunks[quorumID] = make([][]byte, len(bundle.GetChunks()))
+			for j, chunk := range bundle.GetChunks() {
+				rawChunks[quorumID][j] = chunk
+			}
+		}
+	}
+
+	serialized := make(map[core.QuorumID][]byte)
+	for quorumID, bundle := range blob.Bundles {
+		if format == core.GnarkBundleEncodingFormat {
+			rawBundle, ok := rawBundles[quorumID]
+			if ok {
+				serialized[quorumID] = rawBundle
+			}
+		} else if format == core.GobBundleEncodingFormat {
+			if len(rawChunks[quorumID]) != len(bundle) {
+				return nil, errors.New("internal error: the number of chunks in parsed blob bundle must be the same as in raw blob bundle")
+			}
+			chunksBytes, ok := rawChunks[quorumID]
+			if ok {
+
+				bundleRaw := make([][]byte, len(bundle))
+				for i := 0; i < len(bundle); i++ {
+					bundleRaw[i] = chunksBytes[i]
+				}
+				chunkBytes, err := EncodeChunks(bundleRaw)
+				if err != nil {
+					return nil, err
+				}
+				serialized[quorumID] = chunkBytes
+			}
+		} else {
+			return nil, fmt.Errorf("invalid bundle encoding format: %d", format)
+		}
+	}
+	return serialized, nil
+}
+
 func (s *Store) expirationTime() int64 {
 	// Setting the expiration time for the batch.
 	curr := time.Now().Unix()
@@ -581,6 +858,10 @@ func (s *Store) GetChunks(ctx context.Context, batchHeaderHash [32]byte, blobInd
 	if err != nil {
 		return nil, node.ChunkEncodingFormat_UNKNOWN, err
 	}
+	data, err = s.encryptor.Decrypt(ctx, data)
+	if err != nil {
+		return nil, node.ChunkEncodingFormat_UNKNOWN, fmt.Errorf("failed to decrypt chunks: %w", err)
+	}
 
 	chunks, format, err := DecodeChunks(data)
 	if err != nil {
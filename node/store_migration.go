@@ -0,0 +1,489 @@
+package node
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common/kvstore"
+	"github.com/Layr-Labs/eigenda/common/kvstore/tablestore"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/syndtr/goleveldb/leveldb/iterator"
+)
+
+// StoreMigrationFileName is the name of the file, in the node's db directory, which records the progress of the
+// migration of the node's stores to another backend.
+const StoreMigrationFileName = "store_migration.json"
+
+// storeMigrationRetryInterval is the time waited between the passes over the stores which found differences, e.g.
+// writes which failed to be mirrored.
+const storeMigrationRetryInterval = time.Minute
+
+// The names of the stores of the node, in its db directory. The name of the directory of each store has a suffix
+// per backend, see dbPathSuffix.
+const (
+	chunkStoreName          = "chunk"
+	chunkStoreV2Name        = "chunk_v2"
+	signingJournalStoreName = "signing_journal"
+)
+
+// storeNames are the names of all the stores of the node, which are migrated together.
+var storeNames = []string{chunkStoreName, chunkStoreV2Name, signingJournalStoreName}
+
+// StoreMigrationState is the progress of the migration of the node's stores to another backend.
+type StoreMigrationState struct {
+	// Source is the backend the stores are migrated from
+	Source string `json:"source"`
+	// Target is the backend the stores are migrated to
+	Target string `json:"target"`
+	// VerifiedAt is the time the stores of the target were verified to hold the same data as the source, after
+	// which the node can be restarted with the target backend. Zero until then.
+	VerifiedAt time.Time `json:"verifiedAt,omitempty"`
+}
+
+// Verified returns true if the node can be restarted with the target backend.
+func (s *StoreMigrationState) Verified() bool {
+	return !s.VerifiedAt.IsZero()
+}
+
+// ReadStoreMigrationState reads the progress of the store migration from the db directory of the node. It returns nil
+// if no migration is in progress.
+func ReadStoreMigrationState(dbPath string) (*StoreMigrationState, error) {
+	data, err := os.ReadFile(filepath.Join(dbPath, StoreMigrationFileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read store migration state: %w", err)
+	}
+	state := &StoreMigrationState{}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("failed to parse store migration state: %w", err)
+	}
+	return state, nil
+}
+
+// writeStoreMigrationState atomically replaces the progress of the store migration in the db directory of the node.
+func writeStoreMigrationState(dbPath string, state *StoreMigrationState) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("failed to encode store migration state: %w", err)
+	}
+	path := filepath.Join(dbPath, StoreMigrationFileName)
+	if err := os.WriteFile(path+".tmp", data, 0644); err != nil {
+		return fmt.Errorf("failed to write store migration state: %w", err)
+	}
+	if err := os.Rename(path+".tmp", path); err != nil {
+		return fmt.Errorf("failed to write store migration state: %w", err)
+	}
+	return nil
+}
+
+// StoreBackendName returns the name of a store backend, as given to the db-backend flag.
+func StoreBackendName(backend tablestore.StoreType) string {
+	switch backend {
+	case tablestore.LevelDB:
+		return "leveldb"
+	case tablestore.Pebble:
+		return "pebble"
+	default:
+		return fmt.Sprintf("unknown(%d)", backend)
+	}
+}
+
+// StoreMigration migrates the kv stores of the node to another backend while the node is running. The writes to the
+// stores are mirrored to the stores of the target backend, while the data written before the migration started is
+// copied over in the background. Once a pass over the stores finds that the target holds the same data as the
+// source, the migration is verified, and the node can be cut over to the target backend by restarting it with that
+// backend, which catches up on the writes made since then with CompleteStoreMigration. The data of the source backend
+// is kept, and can be deleted by the operator once the node runs with the target backend.
+type StoreMigration struct {
+	dbPath string
+	source tablestore.StoreType
+	target tablestore.StoreType
+	logger logging.Logger
+
+	// stores are the stores being migrated, by name
+	stores map[string]*mirroredStore
+}
+
+// NewStoreMigration creates a new StoreMigration of the stores in the db directory of the node from the source to the
+// target backend, and records that the migration is in progress.
+func NewStoreMigration(
+	dbPath string,
+	source tablestore.StoreType,
+	target tablestore.StoreType,
+	logger logging.Logger,
+) (*StoreMigration, error) {
+	if source == target {
+		return nil, fmt.Errorf("the stores are already on the %s backend", StoreBackendName(target))
+	}
+	state, err := ReadStoreMigrationState(dbPath)
+	if err != nil {
+		return nil, err
+	}
+	if state == nil || state.Source != StoreBackendName(source) || state.Target != StoreBackendName(target) {
+		// The stores are only verified by this migration
+		state = &StoreMigrationState{Source: StoreBackendName(source), Target: StoreBackendName(target)}
+		if err := writeStoreMigrationState(dbPath, state); err != nil {
+			return nil, err
+		}
+	}
+	return &StoreMigration{
+		dbPath: dbPath,
+		source: source,
+		target: target,
+		logger: logger.With("component", "StoreMigration"),
+		stores: make(map[string]*mirroredStore),
+	}, nil
+}
+
+// Open opens the store of the node with the given name on the source backend, mirroring its writes to the same store
+// on the target backend.
+func (m *StoreMigration) Open(name string) (kvstore.Store[[]byte], error) {
+	source, err := openKVStore(m.source, filepath.Join(m.dbPath, name+dbPathSuffix(m.source)), m.logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the %s store: %w", name, err)
+	}
+	target, err := openKVStore(m.target, filepath.Join(m.dbPath, name+dbPathSuffix(m.target)), m.logger)
+	if err != nil {
+		_ = source.Shutdown()
+		return nil, fmt.Errorf("failed to open the %s store on the %s backend: %w", name, StoreBackendName(m.target), err)
+	}
+	store := newMirroredStore(name, source, target, m.logger)
+	m.stores[name] = store
+	return store, nil
+}
+
+// Start copies the stores to the target backend in the background, until they're verified or the context is
+// cancelled.
+func (m *StoreMigration) Start(ctx context.Context) {
+	go func() {
+		for {
+			verified, err := m.Run(ctx)
+			if err != nil {
+				m.logger.Error("Failed to migrate the stores", "err", err)
+			}
+			if verified {
+				m.logger.Info("The stores are migrated, restart the node with the target db backend to cut over",
+					"target", StoreBackendName(m.target))
+				return
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(storeMigrationRetryInterval):
+			}
+		}
+	}()
+}
+
+// Run makes a pass over the stores, copying the data missing from the target, and records the migration as verified
+// if the target already held the same data as the source.
+func (m *StoreMigration) Run(ctx context.Context) (bool, error) {
+	numRepaired := 0
+	for name, store := range m.stores {
+		start := time.Now()
+		n, err := store.sync(ctx)
+		if err != nil {
+			return false, fmt.Errorf("failed to migrate the %s store: %w", name, err)
+		}
+		m.logger.Info("Migrated store", "store", name, "keysCopied", n, "duration", time.Since(start))
+		numRepaired += n
+	}
+	if numRepaired > 0 {
+		return false, nil
+	}
+	state := &StoreMigrationState{
+		Source:     StoreBackendName(m.source),
+		Target:     StoreBackendName(m.target),
+		VerifiedAt: time.Now(),
+	}
+	if err := writeStoreMigrationState(m.dbPath, state); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// CompleteStoreMigration cuts the stores with the given names over to the backend the node is started with, if they
+// were being migrated to it. The writes made to the source backend since the migration was verified are copied over,
+// before the node opens its stores. A migration to another backend which the node isn't continuing is abandoned.
+func CompleteStoreMigration(
+	ctx context.Context,
+	dbPath string,
+	backend tablestore.StoreType,
+	migrating bool,
+	names []string,
+	logger logging.Logger,
+) error {
+	state, err := ReadStoreMigrationState(dbPath)
+	if err != nil || state == nil {
+		return err
+	}
+
+	if state.Target != StoreBackendName(backend) {
+		if migrating {
+			return nil
+		}
+		logger.Warn("Abandoning the migration of the stores", "source", state.Source, "target", state.Target)
+		return os.Remove(filepath.Join(dbPath, StoreMigrationFileName))
+	}
+	if !state.Verified() {
+		return fmt.Errorf("the migration of the stores from %s to %s isn't complete: restart the node with the %s "+
+			"db backend and %s as the migration target to complete it", state.Source, state.Target, state.Source, state.Target)
+	}
+
+	var source tablestore.StoreType
+	switch state.Source {
+	case StoreBackendName(tablestore.LevelDB):
+		source = tablestore.LevelDB
+	case StoreBackendName(tablestore.Pebble):
+		source = tablestore.Pebble
+	default:
+		return fmt.Errorf("unknown source backend %q of the store migration", state.Source)
+	}
+	migration := &StoreMigration{
+		dbPath: dbPath,
+		source: source,
+		target: backend,
+		logger: logger.With("component", "StoreMigration"),
+		stores: make(map[string]*mirroredStore),
+	}
+	defer func() {
+		for _, store := range migration.stores {
+			_ = store.Shutdown()
+		}
+	}()
+	for _, name := range names {
+		if _, err := os.Stat(filepath.Join(dbPath, name+dbPathSuffix(source))); errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if _, err := migration.Open(name); err != nil {
+			return err
+		}
+	}
+	logger.Info("Completing the migration of the stores", "source", state.Source, "target", state.Target)
+	// Nothing else writes to the stores, so a second pass verifies the first
+	verified := false
+	for i := 0; i < 2 && !verified; i++ {
+		verified, err = migration.Run(ctx)
+		if err != nil {
+			return err
+		}
+	}
+	if !verified {
+		return fmt.Errorf("the stores of the %s backend differ from the %s backend after the migration", state.Target, state.Source)
+	}
+	if err := os.Remove(filepath.Join(dbPath, StoreMigrationFileName)); err != nil {
+		return fmt.Errorf("failed to complete the store migration: %w", err)
+	}
+	logger.Info("Migrated the stores, the stores of the previous backend can be deleted",
+		"source", state.Source, "target", state.Target)
+	return nil
+}
+
+// mirroredStore is a kv store whose writes are applied to a source store, and mirrored to a target store. Reads are
+// served by the source. Failed writes to the target don't fail the writes, but are repaired by sync.
+type mirroredStore struct {
+	name   string
+	source kvstore.Store[[]byte]
+	target kvstore.Store[[]byte]
+	logger logging.Logger
+
+	// mu serializes the writes to both stores with the repairs of the target by sync, so that sync doesn't copy a
+	// value which was overwritten concurrently
+	mu sync.Mutex
+}
+
+var _ kvstore.Store[[]byte] = &mirroredStore{}
+var _ kvstore.Compactor = &mirroredStore{}
+
+func newMirroredStore(name string, source kvstore.Store[[]byte], target kvstore.Store[[]byte], logger logging.Logger) *mirroredStore {
+	return &mirroredStore{
+		name:   name,
+		source: source,
+		target: target,
+		logger: logger,
+	}
+}
+
+func (s *mirroredStore) Put(k []byte, value []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.source.Put(k, value); err != nil {
+		return err
+	}
+	s.mirrorErr(s.target.Put(k, value))
+	return nil
+}
+
+func (s *mirroredStore) Get(k []byte) ([]byte, error) {
+	return s.source.Get(k)
+}
+
+func (s *mirroredStore) Delete(k []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.source.Delete(k); err != nil {
+		return err
+	}
+	s.mirrorErr(s.target.Delete(k))
+	return nil
+}
+
+func (s *mirroredStore) NewBatch() kvstore.Batch[[]byte] {
+	return &mirroredBatch{
+		store:  s,
+		source: s.source.NewBatch(),
+		target: s.target.NewBatch(),
+	}
+}
+
+func (s *mirroredStore) NewIterator(prefix []byte) (iterator.Iterator, error) {
+	return s.source.NewIterator(prefix)
+}
+
+func (s *mirroredStore) Shutdown() error {
+	return errors.Join(s.source.Shutdown(), s.target.Shutdown())
+}
+
+func (s *mirroredStore) Destroy() error {
+	return errors.Join(s.source.Destroy(), s.target.Destroy())
+}
+
+func (s *mirroredStore) Compact(start []byte, limit []byte) error {
+	if compactor, ok := s.source.(kvstore.Compactor); ok {
+		if err := compactor.Compact(start, limit); err != nil {
+			return err
+		}
+	}
+	if compactor, ok := s.target.(kvstore.Compactor); ok {
+		s.mirrorErr(compactor.Compact(start, limit))
+	}
+	return nil
+}
+
+// mirrorErr logs a failure to mirror a write to the target, which is repaired by the next sync.
+func (s *mirroredStore) mirrorErr(err error) {
+	if err != nil {
+		s.logger.Warn("Failed to mirror a write to the migrated store", "store", s.name, "err", err)
+	}
+}
+
+// sync makes the target hold the same data as the source, and returns the number of keys of the target it had to
+// write or delete. The keys are compared without blocking writes, and only the keys which differ are repaired, from
+// the current value of the source.
+func (s *mirroredStore) sync(ctx context.Context) (int, error) {
+	numRepaired := 0
+
+	// Copy the keys of the source which are missing from the target, or differ
+	iter, err := s.source.NewIterator(nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to iterate the source store: %w", err)
+	}
+	for iter.Next() {
+		if err := ctx.Err(); err != nil {
+			iter.Release()
+			return 0, err
+		}
+		value, err := s.target.Get(iter.Key())
+		if err == nil && bytes.Equal(value, iter.Value()) {
+			continue
+		}
+		if err != nil && !errors.Is(err, kvstore.ErrNotFound) {
+			iter.Release()
+			return 0, fmt.Errorf("failed to read the target store: %w", err)
+		}
+		if err := s.repair(iter.Key()); err != nil {
+			iter.Release()
+			return 0, err
+		}
+		numRepaired++
+	}
+	iter.Release()
+	if err := iter.Error(); err != nil {
+		return 0, fmt.Errorf("failed to iterate the source store: %w", err)
+	}
+
+	// Delete the keys of the target which aren't in the source anymore
+	iter, err = s.target.NewIterator(nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to iterate the target store: %w", err)
+	}
+	defer iter.Release()
+	for iter.Next() {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+		_, err := s.source.Get(iter.Key())
+		if err == nil {
+			continue
+		}
+		if !errors.Is(err, kvstore.ErrNotFound) {
+			return 0, fmt.Errorf("failed to read the source store: %w", err)
+		}
+		if err := s.repair(iter.Key()); err != nil {
+			return 0, err
+		}
+		numRepaired++
+	}
+	if err := iter.Error(); err != nil {
+		return 0, fmt.Errorf("failed to iterate the target store: %w", err)
+	}
+	return numRepaired, nil
+}
+
+// repair copies the current value of a key from the source to the target, or deletes it from the target if it's not
+// in the source.
+func (s *mirroredStore) repair(key []byte) error {
+	key = copyBytes(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	value, err := s.source.Get(key)
+	if errors.Is(err, kvstore.ErrNotFound) {
+		err = s.target.Delete(key)
+	} else if err == nil {
+		err = s.target.Put(key, value)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to copy key to the target store: %w", err)
+	}
+	return nil
+}
+
+// mirroredBatch is a batch of writes applied to the source and the target of a mirroredStore.
+type mirroredBatch struct {
+	store  *mirroredStore
+	source kvstore.Batch[[]byte]
+	target kvstore.Batch[[]byte]
+}
+
+func (b *mirroredBatch) Put(k []byte, value []byte) {
+	b.source.Put(k, value)
+	b.target.Put(k, value)
+}
+
+func (b *mirroredBatch) Delete(k []byte) {
+	b.source.Delete(k)
+	b.target.Delete(k)
+}
+
+func (b *mirroredBatch) Apply() error {
+	b.store.mu.Lock()
+	defer b.store.mu.Unlock()
+	if err := b.source.Apply(); err != nil {
+		return err
+	}
+	b.store.mirrorErr(b.target.Apply())
+	return nil
+}
+
+func (b *mirroredBatch) Size() uint32 {
+	return b.source.Size()
+}
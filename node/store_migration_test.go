@@ -0,0 +1,144 @@
+package node_test
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/common/kvstore"
+	"github.com/Layr-Labs/eigenda/common/kvstore/pebble"
+	"github.com/Layr-Labs/eigenda/common/kvstore/tablestore"
+	"github.com/Layr-Labs/eigenda/common/testutils"
+	"github.com/Layr-Labs/eigenda/node"
+	"github.com/stretchr/testify/require"
+)
+
+// requireStoreContents checks that the store holds exactly the given keys and values.
+func requireStoreContents(t *testing.T, store kvstore.Store[[]byte], expected map[string]string) {
+	iter, err := store.NewIterator(nil)
+	require.NoError(t, err)
+	defer iter.Release()
+	actual := make(map[string]string)
+	for iter.Next() {
+		actual[string(iter.Key())] = string(iter.Value())
+	}
+	require.NoError(t, iter.Error())
+	require.Equal(t, expected, actual)
+}
+
+func TestStoreMigration(t *testing.T) {
+	ctx := context.Background()
+	logger := testutils.GetLogger()
+	dbPath := t.TempDir()
+
+	// Write some data before the migration starts
+	migration, err := node.NewStoreMigration(dbPath, tablestore.LevelDB, tablestore.Pebble, logger)
+	require.NoError(t, err)
+	store, err := migration.Open("chunk")
+	require.NoError(t, err)
+	expected := make(map[string]string)
+	batch := store.NewBatch()
+	for i := 0; i < 100; i++ {
+		batch.Put([]byte(fmt.Sprintf("key%d", i)), []byte(fmt.Sprintf("value%d", i)))
+		expected[fmt.Sprintf("key%d", i)] = fmt.Sprintf("value%d", i)
+	}
+	require.NoError(t, batch.Apply())
+	require.NoError(t, store.Shutdown())
+
+	// Restart the migration, so that the data is only in the source
+	state, err := node.ReadStoreMigrationState(dbPath)
+	require.NoError(t, err)
+	require.Equal(t, "leveldb", state.Source)
+	require.Equal(t, "pebble", state.Target)
+	require.False(t, state.Verified())
+	target, err := pebble.NewStore(logger, filepath.Join(dbPath, "chunk_pebble"))
+	require.NoError(t, err)
+	require.NoError(t, target.Destroy())
+
+	migration, err = node.NewStoreMigration(dbPath, tablestore.LevelDB, tablestore.Pebble, logger)
+	require.NoError(t, err)
+	store, err = migration.Open("chunk")
+	require.NoError(t, err)
+
+	// Write concurrently with the first pass, which copies the data
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 50; i++ {
+			require.NoError(t, store.Put([]byte(fmt.Sprintf("key%d", i)), []byte(fmt.Sprintf("updated%d", i))))
+			require.NoError(t, store.Delete([]byte(fmt.Sprintf("key%d", i+50))))
+		}
+	}()
+	verified, err := migration.Run(ctx)
+	require.NoError(t, err)
+	require.False(t, verified)
+	wg.Wait()
+	for i := 0; i < 50; i++ {
+		expected[fmt.Sprintf("key%d", i)] = fmt.Sprintf("updated%d", i)
+		delete(expected, fmt.Sprintf("key%d", i+50))
+	}
+	requireStoreContents(t, store, expected)
+
+	// The second pass finds nothing to copy, and verifies the migration
+	verified, err = migration.Run(ctx)
+	require.NoError(t, err)
+	require.True(t, verified)
+	state, err = node.ReadStoreMigrationState(dbPath)
+	require.NoError(t, err)
+	require.True(t, state.Verified())
+
+	// Writes made after the verification are mirrored too
+	require.NoError(t, store.Put([]byte("new"), []byte("value")))
+	expected["new"] = "value"
+	require.NoError(t, store.Shutdown())
+
+	// Cut over to the target
+	err = node.CompleteStoreMigration(ctx, dbPath, tablestore.Pebble, false, []string{"chunk", "chunk_v2"}, logger)
+	require.NoError(t, err)
+	state, err = node.ReadStoreMigrationState(dbPath)
+	require.NoError(t, err)
+	require.Nil(t, state)
+
+	target, err = pebble.NewStore(logger, filepath.Join(dbPath, "chunk_pebble"))
+	require.NoError(t, err)
+	defer func() { require.NoError(t, target.Shutdown()) }()
+	requireStoreContents(t, target, expected)
+}
+
+func TestCompleteStoreMigration(t *testing.T) {
+	ctx := context.Background()
+	logger := testutils.GetLogger()
+	dbPath := t.TempDir()
+
+	// Nothing to do without a migration
+	require.NoError(t, node.CompleteStoreMigration(ctx, dbPath, tablestore.Pebble, false, []string{"chunk"}, logger))
+
+	migration, err := node.NewStoreMigration(dbPath, tablestore.LevelDB, tablestore.Pebble, logger)
+	require.NoError(t, err)
+	store, err := migration.Open("chunk")
+	require.NoError(t, err)
+	require.NoError(t, store.Put([]byte("key"), []byte("value")))
+	require.NoError(t, store.Shutdown())
+
+	// The node can't be cut over before the migration is verified
+	err = node.CompleteStoreMigration(ctx, dbPath, tablestore.Pebble, false, []string{"chunk"}, logger)
+	require.ErrorContains(t, err, "isn't complete")
+
+	// The migration is kept while it continues
+	require.NoError(t, node.CompleteStoreMigration(ctx, dbPath, tablestore.LevelDB, true, []string{"chunk"}, logger))
+	state, err := node.ReadStoreMigrationState(dbPath)
+	require.NoError(t, err)
+	require.NotNil(t, state)
+
+	// Restarting with the source backend without migrating abandons the migration
+	require.NoError(t, node.CompleteStoreMigration(ctx, dbPath, tablestore.LevelDB, false, []string{"chunk"}, logger))
+	state, err = node.ReadStoreMigrationState(dbPath)
+	require.NoError(t, err)
+	require.Nil(t, state)
+
+	_, err = node.NewStoreMigration(dbPath, tablestore.Pebble, tablestore.Pebble, logger)
+	require.Error(t, err)
+}
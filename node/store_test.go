@@ -4,6 +4,9 @@ import (
 	"bytes"
 	"context"
 	cryptorand "crypto/rand"
+	"encoding/hex"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -15,6 +18,8 @@ import (
 	"github.com/Layr-Labs/eigenda/encoding"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 
+	"github.com/Layr-Labs/eigenda/common/aws/envelope"
+	"github.com/Layr-Labs/eigenda/common/kvstore/tablestore"
 	"github.com/Layr-Labs/eigenda/common/testutils"
 	"github.com/Layr-Labs/eigenda/node"
 	"github.com/Layr-Labs/eigensdk-go/metrics"
@@ -22,6 +27,7 @@ import (
 	"github.com/consensys/gnark-crypto/ecc/bn254/fp"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"google.golang.org/protobuf/proto"
 )
 
@@ -211,6 +217,14 @@ func CreateBatchWith(t *testing.T, encodeBundle bool) (*core.BatchHeader, []*cor
 }
 
 func createStore(t *testing.T) *node.Store {
+	return createStoreWithBackend(t, tablestore.LevelDB)
+}
+
+func createStoreWithBackend(t *testing.T, backend tablestore.StoreType) *node.Store {
+	return createStoreWithEncryptor(t, backend, nil)
+}
+
+func createStoreWithEncryptor(t *testing.T, backend tablestore.StoreType, encryptor *envelope.Encryptor) *node.Store {
 	noopMetrics := metrics.NewNoopMetrics()
 	reg := prometheus.NewRegistry()
 	logger := testutils.GetLogger()
@@ -220,7 +234,8 @@ func createStore(t *testing.T) *node.Store {
 		0: 6,
 		1: 3,
 	})
-	s, _ := node.NewLevelDBStore(t.TempDir(), logger, node.NewMetrics(noopMetrics, reg, logger, ":9090", operatorId, -1, tx, dat), staleMeasure, storeDuration)
+	s, err := node.NewStore(backend, t.TempDir(), logger, node.NewMetrics(noopMetrics, reg, logger, ":9090", operatorId, -1, tx, dat), staleMeasure, storeDuration, encryptor)
+	require.NoError(t, err)
 	return s
 }
 
@@ -261,7 +276,16 @@ func TestStoreBatchInvalidBlob(t *testing.T) {
 }
 
 func TestStoreBatchSuccess(t *testing.T) {
-	s := createStore(t)
+	t.Run("leveldb", func(t *testing.T) {
+		testStoreBatchSuccess(t, tablestore.LevelDB)
+	})
+	t.Run("pebble", func(t *testing.T) {
+		testStoreBatchSuccess(t, tablestore.Pebble)
+	})
+}
+
+func testStoreBatchSuccess(t *testing.T, backend tablestore.StoreType) {
+	s := createStoreWithBackend(t, backend)
 	ctx := context.Background()
 
 	// Empty store
@@ -351,6 +375,76 @@ func TestStoreBatchSuccess(t *testing.T) {
 	assert.False(t, s.HasKey(ctx, blobKey2))
 }
 
+func TestStoreBlobsAndBatchMapping(t *testing.T) {
+	t.Run("leveldb", func(t *testing.T) {
+		testStoreBlobsAndBatchMapping(t, tablestore.LevelDB)
+	})
+	t.Run("pebble", func(t *testing.T) {
+		testStoreBlobsAndBatchMapping(t, tablestore.Pebble)
+	})
+}
+
+func testStoreBlobsAndBatchMapping(t *testing.T, backend tablestore.StoreType) {
+	s := createStoreWithBackend(t, backend)
+	ctx := context.Background()
+
+	// Store the blobs of a minibatch.
+	batchHeader, blobs, blobsProto := CreateBatch(t)
+	_, err := s.StoreBlobs(ctx, blobs, blobsProto)
+	assert.Nil(t, err)
+	blobHeaderHashes := make([][32]byte, len(blobs))
+	for i, blob := range blobs {
+		blobHeaderHashes[i], err = blob.BlobHeader.GetBlobHeaderHash()
+		assert.Nil(t, err)
+		blobHeaderBytes, err := s.GetBlobHeaderByHeaderHash(ctx, blobHeaderHashes[i])
+		assert.Nil(t, err)
+		expected, err := proto.Marshal(blobsProto[i].GetHeader())
+		assert.Nil(t, err)
+		assert.True(t, bytes.Equal(blobHeaderBytes, expected))
+	}
+
+	// The blobs can't be looked up by batch until the batch is mapped to them.
+	batchHeaderHash, err := batchHeader.GetBatchHeaderHash()
+	assert.Nil(t, err)
+	_, _, err = s.GetChunks(ctx, batchHeaderHash, 0, 0)
+	assert.ErrorIs(t, err, node.ErrKeyNotFound)
+
+	err = s.StoreBatchBlobMapping(ctx, batchHeader, blobHeaderHashes)
+	assert.Nil(t, err)
+	err = s.StoreBatchBlobMapping(ctx, batchHeader, blobHeaderHashes)
+	assert.ErrorIs(t, err, node.ErrBatchAlreadyExist)
+	for i := range blobs {
+		blobHeaderBytes, err := s.GetBlobHeader(ctx, batchHeaderHash, i)
+		assert.Nil(t, err)
+		expected, err := proto.Marshal(blobsProto[i].GetHeader())
+		assert.Nil(t, err)
+		assert.True(t, bytes.Equal(blobHeaderBytes, expected))
+		chunks, format, err := s.GetChunks(ctx, batchHeaderHash, i, 0)
+		assert.Nil(t, err)
+		assert.Equal(t, pb.ChunkEncodingFormat_GOB, format)
+		assert.Equal(t, blobsProto[i].Bundles[0].Chunks, chunks)
+	}
+
+	// Storing the blobs again is a no-op.
+	keys, err := s.StoreBlobs(ctx, blobs, blobsProto)
+	assert.Nil(t, err)
+	assert.Empty(t, *keys)
+
+	// Expire the blobs and the batch mapping.
+	curTime := time.Now().Unix() + int64(staleMeasure+storeDuration)*12
+	_, numMappings, numBlobs, err := s.DeleteExpiredEntries(curTime+10, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, len(blobs), numMappings)
+	assert.Equal(t, len(blobs), numBlobs)
+	assert.False(t, s.HasKey(ctx, node.EncodeBatchHeaderKey(batchHeaderHash)))
+	for _, blobHeaderHash := range blobHeaderHashes {
+		assert.False(t, s.HasKey(ctx, node.EncodeBlobHeaderKeyByHash(blobHeaderHash)))
+		blobKey, err := node.EncodeBlobKeyByHash(blobHeaderHash, 0)
+		assert.Nil(t, err)
+		assert.False(t, s.HasKey(ctx, blobKey))
+	}
+}
+
 func decodeChunks(t *testing.T, s *node.Store, batchHeaderHash [32]byte, blobIdx int, chunkEncoding pb.ChunkEncodingFormat) []*encoding.Frame {
 	ctx := context.Background()
 	chunks, format, err := s.GetChunks(ctx, batchHeaderHash, blobIdx, 0)
@@ -406,6 +500,29 @@ func TestBundleEncodingEquivalence(t *testing.T) {
 	checkBundleEquivalence(t, bundle1, bundle2)
 }
 
+func TestStoreBatchEncrypted(t *testing.T) {
+	ctx := context.Background()
+	keyFile := filepath.Join(t.TempDir(), "chunk-key")
+	require.NoError(t, os.WriteFile(keyFile, []byte(hex.EncodeToString(make([]byte, 32))), 0600))
+	encryptor, err := envelope.NewLocalEncryptor(keyFile, time.Hour)
+	require.NoError(t, err)
+
+	s1 := createStore(t)
+	s2 := createStoreWithEncryptor(t, tablestore.LevelDB, encryptor)
+	batchHeader, blobs, blobsProto := CreateBatchWith(t, true)
+	_, err = s1.StoreBatch(ctx, batchHeader, blobs, blobsProto)
+	require.NoError(t, err)
+	_, err = s2.StoreBatch(ctx, batchHeader, blobs, blobsProto)
+	require.NoError(t, err)
+
+	// The chunks are decrypted transparently
+	batchHeaderHash, err := batchHeader.GetBatchHeaderHash()
+	require.NoError(t, err)
+	checkBundleEquivalence(t,
+		decodeChunks(t, s1, batchHeaderHash, 0, pb.ChunkEncodingFormat_GNARK),
+		decodeChunks(t, s2, batchHeaderHash, 0, pb.ChunkEncodingFormat_GNARK))
+}
+
 func BenchmarkEncodeChunks(b *testing.B) {
 	numSamples := 32
 	numChunks := 10
@@ -2,13 +2,20 @@ package node
 
 import (
 	"bytes"
+	"context"
 	"encoding/binary"
+	"encoding/gob"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"math/rand"
 	"time"
 
+	"github.com/Layr-Labs/eigenda/common/aws/envelope"
 	"github.com/Layr-Labs/eigenda/common/kvstore"
 	"github.com/Layr-Labs/eigenda/core"
 	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/encoding"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 )
 
@@ -16,6 +23,17 @@ const (
 	BatchHeaderTableName     = "batch_headers"
 	BlobCertificateTableName = "blob_certificates"
 	BundleTableName          = "bundles"
+	// ColdBundleTableName records the bundles migrated to the cold tier. The value is the size of the bundle.
+	ColdBundleTableName = "cold_bundles"
+	// PendingBatchTableName records the batches stored by StoreBatch which aren't committed yet, by batch header
+	// hash. The value lists the keys the batch added to the store.
+	PendingBatchTableName = "pending_batches"
+	// ChunkTableName holds the chunks of the bundles stored with their chunks deduplicated, by blob key and chunk
+	// hash, so that a chunk assigned to the operator in several quorums is stored once.
+	ChunkTableName = "chunks"
+
+	// pruneBatchSize is the number of writes after which the deletions of pruned bundles are applied
+	pruneBatchSize = 1024
 )
 
 type StoreV2 interface {
@@ -23,9 +41,19 @@ type StoreV2 interface {
 	// StoreBatch stores a batch and its raw bundles in the database. Returns the keys of the stored data
 	// and the size of the stored data, in bytes.
 	//
-	// All modifications to the database within this method are performed atomically.
+	// All modifications to the database within this method are performed atomically. The batch is recorded as
+	// pending until it's committed with CommitBatch, so that it's discarded by DiscardPendingBatches if the node
+	// crashes before its chunks are validated.
 	StoreBatch(batch *corev2.Batch, rawBundles []*RawBundles) ([]kvstore.Key, uint64, error)
 
+	// CommitBatch marks a batch stored by StoreBatch as complete, once its chunks are validated.
+	CommitBatch(batchHeaderHash [32]byte) error
+
+	// DiscardPendingBatches deletes the data added by the batches stored by StoreBatch which were never committed,
+	// e.g. because the node crashed while validating them. It must be called before any batch is stored. Returns the
+	// number of discarded batches.
+	DiscardPendingBatches() (int, error)
+
 	// DeleteKeys deletes the keys from local storage.
 	//
 	// All modifications to the database within this method are performed atomically.
@@ -33,21 +61,102 @@ type StoreV2 interface {
 
 	// GetChunks returns the chunks of a blob with the given blob key and quorum.
 	GetChunks(blobKey corev2.BlobKey, quorum core.QuorumID) ([][]byte, error)
+
+	// GetStoredBlob returns the metadata of a stored blob. Returns an error wrapping kvstore.ErrNotFound if the blob
+	// isn't stored.
+	GetStoredBlob(blobKey corev2.BlobKey) (*StoredBlob, error)
+
+	// GetBlobChunks returns the chunks of all the bundles of a blob stored locally, by quorum. The bundles of a blob
+	// are adjacent in the store, so they're read sequentially. Bundles migrated to the cold tier aren't returned.
+	GetBlobChunks(blobKey corev2.BlobKey) (map[core.QuorumID][][]byte, error)
+
+	// SampleBlobs returns the metadata of up to n blobs picked at random among the stored blobs.
+	SampleBlobs(n int) ([]*StoredBlob, error)
+
+	// NextBlobs returns the metadata of up to n stored blobs in the order of their blob keys, starting after the
+	// given blob key, or at the first blob if after is nil.
+	NextBlobs(after *corev2.BlobKey, n int) ([]*StoredBlob, error)
+
+	// RepairBundle replaces the stored bundle of a blob in a quorum, e.g. with a copy fetched from a relay after the
+	// stored bundle was found corrupt. The bundle expires along with its blob.
+	RepairBundle(blobKey corev2.BlobKey, quorum core.QuorumID, bundle []byte) error
+
+	// GetQuorumUsage returns the total size of the stored bundles of each quorum, in bytes.
+	// This scans all stored bundles, so it's expensive.
+	GetQuorumUsage() (map[core.QuorumID]uint64, error)
+
+	// PruneBundles deletes the stored bundles for which prune returns true before they expire, and removes their
+	// quorums from the metadata of their blobs. Blobs without any remaining bundle are deleted. Blobs stored before
+	// their storage time was recorded are skipped. This scans all stored blobs, so it's expensive.
+	PruneBundles(prune func(blobKey corev2.BlobKey, blob *StoredBlob, quorum core.QuorumID) bool) ([]PrunedBundle, error)
+
+	// MigrateBundles moves the bundles of the blobs stored before storedBefore to the cold tier, from which GetChunks
+	// fetches them back. The metadata of the blobs stays in the local store. Blobs stored before their storage time
+	// was recorded are skipped. This scans all stored blobs, so it's expensive.
+	MigrateBundles(ctx context.Context, storedBefore time.Time) ([]MigratedBundle, error)
+}
+
+// StoredBlob is the metadata stored for a blob along with its bundles, which is needed to re-verify them.
+type StoredBlob struct {
+	BlobCertificate *corev2.BlobCertificate
+	EncodingParams  encoding.EncodingParams
+	// Assignments are the chunks of each quorum assigned to the operator when the blob was stored
+	Assignments map[core.QuorumID]corev2.Assignment
+	// StoredAt is the time at which the blob was stored. It's zero for blobs stored by older versions of the node.
+	StoredAt time.Time
+	// Inclusion is the inclusion of the blob in the batch it was last stored with. It's nil for blobs stored by older
+	// versions of the node.
+	Inclusion *corev2.BlobInclusionInfo
+}
+
+// PrunedBundle is a bundle deleted by PruneBundles.
+type PrunedBundle struct {
+	BlobKey corev2.BlobKey
+	Quorum  core.QuorumID
+	// Size is the size of the deleted bundle, in bytes
+	Size     uint64
+	StoredAt time.Time
+}
+
+// MigratedBundle is a bundle moved to the cold tier by MigrateBundles.
+type MigratedBundle struct {
+	BlobKey corev2.BlobKey
+	Quorum  core.QuorumID
+	// Size is the size of the bundle freed from the local store, in bytes
+	Size     uint64
+	StoredAt time.Time
 }
 
 type storeV2 struct {
 	db     kvstore.TableStore
 	logger logging.Logger
+	// encryptor encrypts the bundles at rest. If nil, bundles are stored in plaintext.
+	encryptor *envelope.Encryptor
+	// coldTier holds the bundles migrated off the local store. If nil, all bundles are stored locally.
+	coldTier ColdTier
+	// deduplicateChunks stores the chunks shared by the bundles of a blob in different quorums once. Bundles stored
+	// with deduplicated chunks are read back whether it's enabled or not.
+	deduplicateChunks bool
 
 	ttl time.Duration
 }
 
 var _ StoreV2 = &storeV2{}
 
-func NewLevelDBStoreV2(db kvstore.TableStore, logger logging.Logger, ttl time.Duration) *storeV2 {
+func NewLevelDBStoreV2(
+	db kvstore.TableStore,
+	logger logging.Logger,
+	ttl time.Duration,
+	encryptor *envelope.Encryptor,
+	coldTier ColdTier,
+	deduplicateChunks bool,
+) *storeV2 {
 	return &storeV2{
-		db:     db,
-		logger: logger,
+		db:                db,
+		logger:            logger,
+		encryptor:         encryptor,
+		coldTier:          coldTier,
+		deduplicateChunks: deduplicateChunks,
 
 		ttl: ttl,
 	}
@@ -89,14 +198,64 @@ func (s *storeV2) StoreBatch(batch *corev2.Batch, rawBundles []*RawBundles) ([]k
 	dbBatch.PutWithTTL(batchHeaderKey, batchHeaderBytes, s.ttl)
 	size += uint64(len(batchHeaderBytes))
 
+	// The keys added by the batch are recorded as pending along with the batch. Blobs stored by earlier batches
+	// aren't recorded, since discarding the batch must not delete them.
+	pendingKeys := map[string][][]byte{BatchHeaderTableName: {batchHeaderHash[:]}}
+
+	// The inclusion proofs of the blobs are recorded, so that the node can prove which batch each chunk was stored with
+	tree, err := corev2.BuildMerkleTree(batch.BlobCertificates)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to build merkle tree: %v", err)
+	}
+
 	// Store blob shards
-	for _, bundles := range rawBundles {
+	storedAt := time.Now()
+	for i, bundles := range rawBundles {
 		blobKey, err := bundles.BlobCertificate.BlobHeader.BlobKey()
 		if err != nil {
 			return nil, 0, fmt.Errorf("failed to get blob key: %v", err)
 		}
+		proof, err := tree.GenerateProofWithIndex(uint64(i), 0)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to generate inclusion proof: %v", err)
+		}
+
+		ttl := s.blobTTL(bundles.BlobCertificate)
+
+		// Store the blob metadata
+		blobCertificateKeyBuilder, err := s.db.GetKeyBuilder(BlobCertificateTableName)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to get key builder for blob certificates: %v", err)
+		}
+		storedBlobBytes, err := encodeStoredBlob(&StoredBlob{
+			BlobCertificate: bundles.BlobCertificate,
+			EncodingParams:  bundles.EncodingParams,
+			Assignments:     bundles.Assignments,
+			StoredAt:        storedAt,
+			Inclusion: &corev2.BlobInclusionInfo{
+				BatchHeader:    batch.BatchHeader,
+				BlobKey:        blobKey,
+				BlobIndex:      uint32(i),
+				InclusionProof: core.SerializeMerkleProof(proof),
+			},
+		})
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to serialize blob metadata: %v", err)
+		}
+		_, err = s.db.Get(blobCertificateKeyBuilder.Key(blobKey[:]))
+		if err != nil && !errors.Is(err, kvstore.ErrNotFound) {
+			return nil, 0, fmt.Errorf("failed to get blob metadata: %w", err)
+		}
+		newBlob := errors.Is(err, kvstore.ErrNotFound)
+		if newBlob {
+			pendingKeys[BlobCertificateTableName] = append(pendingKeys[BlobCertificateTableName], blobKey[:])
+		}
+		keys = append(keys, blobCertificateKeyBuilder.Key(blobKey[:]))
+		dbBatch.PutWithTTL(blobCertificateKeyBuilder.Key(blobKey[:]), storedBlobBytes, ttl)
+		size += uint64(len(storedBlobBytes))
 
 		// Store bundles
+		entries := make(chunkEntries)
 		for quorum, bundle := range bundles.Bundles {
 			bundlesKeyBuilder, err := s.db.GetKeyBuilder(BundleTableName)
 			if err != nil {
@@ -108,11 +267,56 @@ func (s *storeV2) StoreBatch(batch *corev2.Batch, rawBundles []*RawBundles) ([]k
 				return nil, 0, fmt.Errorf("failed to get key for bundles: %v", err)
 			}
 
+			if s.deduplicateChunks {
+				// The chunks of the bundle replaced by a blob stored again are no longer referenced by the quorum
+				if !newBlob {
+					if err := s.releaseStoredBundle(bundlesKeyBuilder.Key(k), blobKey, quorum, entries); err != nil {
+						return nil, 0, err
+					}
+				}
+				bundle, err = s.addBundleChunks(blobKey, quorum, bundle, entries)
+				if err != nil {
+					return nil, 0, err
+				}
+			} else {
+				bundle, err = s.encryptor.Encrypt(context.Background(), bundle)
+				if err != nil {
+					return nil, 0, fmt.Errorf("failed to encrypt bundle: %w", err)
+				}
+			}
+
+			if newBlob {
+				pendingKeys[BundleTableName] = append(pendingKeys[BundleTableName], k)
+			}
 			keys = append(keys, bundlesKeyBuilder.Key(k))
-			dbBatch.PutWithTTL(bundlesKeyBuilder.Key(k), bundle, s.ttl)
+			dbBatch.PutWithTTL(bundlesKeyBuilder.Key(k), bundle, ttl)
 			size += uint64(len(bundle))
 		}
+
+		chunkKeys, chunksSize, err := s.putChunkEntries(dbBatch, blobKey, entries, storedAt.Add(ttl))
+		if err != nil {
+			return nil, 0, err
+		}
+		if newBlob {
+			for hash := range entries {
+				pendingKeys[ChunkTableName] = append(pendingKeys[ChunkTableName], ChunkKey(blobKey, hash))
+			}
+		}
+		keys = append(keys, chunkKeys...)
+		size += chunksSize
+	}
+
+	pendingBatchKeyBuilder, err := s.db.GetKeyBuilder(PendingBatchTableName)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get key builder for pending batches: %v", err)
+	}
+	pendingBatchBytes, err := encodePendingBatch(&pendingBatch{Keys: pendingKeys})
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to serialize pending batch: %v", err)
 	}
+	// The pending batch is deleted along with the stored data if the batch is rolled back
+	keys = append(keys, pendingBatchKeyBuilder.Key(batchHeaderHash[:]))
+	dbBatch.Put(pendingBatchKeyBuilder.Key(batchHeaderHash[:]), pendingBatchBytes)
 
 	if err := dbBatch.Apply(); err != nil {
 		return nil, 0, fmt.Errorf("failed to apply batch: %v", err)
@@ -121,6 +325,59 @@ func (s *storeV2) StoreBatch(batch *corev2.Batch, rawBundles []*RawBundles) ([]k
 	return keys, size, nil
 }
 
+func (s *storeV2) CommitBatch(batchHeaderHash [32]byte) error {
+	pendingBatchKeyBuilder, err := s.db.GetKeyBuilder(PendingBatchTableName)
+	if err != nil {
+		return fmt.Errorf("failed to get key builder for pending batches: %v", err)
+	}
+	if err := s.db.Delete(pendingBatchKeyBuilder.Key(batchHeaderHash[:])); err != nil {
+		return fmt.Errorf("failed to commit batch: %w", err)
+	}
+	return nil
+}
+
+func (s *storeV2) DiscardPendingBatches() (int, error) {
+	pendingBatchKeyBuilder, err := s.db.GetKeyBuilder(PendingBatchTableName)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get key builder for pending batches: %v", err)
+	}
+	iter, err := s.db.NewTableIterator(pendingBatchKeyBuilder)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create an iterator for the pending batches: %v", err)
+	}
+	defer iter.Release()
+
+	// Each batch is discarded atomically with its record, in the order of the batch header hashes
+	discarded := 0
+	for iter.Next() {
+		batchHeaderHash := bytes.Clone(iter.Key())
+		pending, err := decodePendingBatch(iter.Value())
+		if err != nil {
+			return discarded, fmt.Errorf("failed to deserialize pending batch %x: %v", batchHeaderHash, err)
+		}
+		dbBatch := s.db.NewTTLBatch()
+		for table, tableKeys := range pending.Keys {
+			keyBuilder, err := s.db.GetKeyBuilder(table)
+			if err != nil {
+				return discarded, fmt.Errorf("failed to get key builder for %s: %v", table, err)
+			}
+			for _, k := range tableKeys {
+				dbBatch.Delete(keyBuilder.Key(k))
+			}
+		}
+		dbBatch.Delete(pendingBatchKeyBuilder.Key(batchHeaderHash))
+		if err := dbBatch.Apply(); err != nil {
+			return discarded, fmt.Errorf("failed to discard pending batch %x: %v", batchHeaderHash, err)
+		}
+		s.logger.Warn("Discarded a batch whose chunks were stored but never validated", "batchHeaderHash", hex.EncodeToString(batchHeaderHash))
+		discarded++
+	}
+	if err := iter.Error(); err != nil {
+		return discarded, fmt.Errorf("failed to iterate over the pending batches: %v", err)
+	}
+	return discarded, nil
+}
+
 func (s *storeV2) DeleteKeys(keys []kvstore.Key) error {
 	dbBatch := s.db.NewTTLBatch()
 	for _, key := range keys {
@@ -141,16 +398,518 @@ func (s *storeV2) GetChunks(blobKey corev2.BlobKey, quorum core.QuorumID) ([][]b
 	}
 
 	bundle, err := s.db.Get(bundlesKeyBuilder.Key(k))
+	if errors.Is(err, kvstore.ErrNotFound) && s.coldTier != nil {
+		bundle, err = s.getColdBundle(k)
+	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to get bundle: %v", err)
+		return nil, fmt.Errorf("failed to get bundle: %w", err)
 	}
 
-	chunks, _, err := DecodeChunks(bundle)
+	return s.readBundleChunks(blobKey, bundle, nil)
+}
+
+func (s *storeV2) GetStoredBlob(blobKey corev2.BlobKey) (*StoredBlob, error) {
+	blobCertificateKeyBuilder, err := s.db.GetKeyBuilder(BlobCertificateTableName)
 	if err != nil {
-		return nil, fmt.Errorf("failed to decode chunks: %v", err)
+		return nil, fmt.Errorf("failed to get key builder for blob certificates: %v", err)
+	}
+	storedBlobBytes, err := s.db.Get(blobCertificateKeyBuilder.Key(blobKey[:]))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blob metadata: %w", err)
+	}
+	blob, err := decodeStoredBlob(storedBlobBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to deserialize blob metadata: %v", err)
+	}
+	return blob, nil
+}
+
+func (s *storeV2) GetBlobChunks(blobKey corev2.BlobKey) (map[core.QuorumID][][]byte, error) {
+	bundlesKeyBuilder, err := s.db.GetKeyBuilder(BundleTableName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get key builder for bundles: %v", err)
+	}
+
+	iter, err := s.db.NewTableIterator(bundlesKeyBuilder)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create an iterator for the bundles: %v", err)
+	}
+	defer iter.Release()
+
+	// Bundle keys are the blob key followed by the quorum ID
+	blobChunks := make(map[core.QuorumID][][]byte)
+	cache := make(map[[32]byte][]byte)
+	for ok := iter.Seek(blobKey[:]); ok; ok = iter.Next() {
+		key := iter.Key()
+		if len(key) != len(blobKey)+1 || !bytes.HasPrefix(key, blobKey[:]) {
+			break
+		}
+		chunks, err := s.readBundleChunks(blobKey, iter.Value(), cache)
+		if err != nil {
+			return nil, err
+		}
+		blobChunks[core.QuorumID(key[len(key)-1])] = chunks
+	}
+	if err := iter.Error(); err != nil {
+		return nil, fmt.Errorf("failed to iterate over the bundles: %v", err)
+	}
+	return blobChunks, nil
+}
+
+func (s *storeV2) GetQuorumUsage() (map[core.QuorumID]uint64, error) {
+	bundlesKeyBuilder, err := s.db.GetKeyBuilder(BundleTableName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get key builder for bundles: %v", err)
+	}
+
+	iter, err := s.db.NewTableIterator(bundlesKeyBuilder)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create an iterator for the bundles: %v", err)
+	}
+	defer iter.Release()
+
+	usage := make(map[core.QuorumID]uint64)
+	for iter.Next() {
+		// The quorum ID is the last byte of the bundle key
+		key := iter.Key()
+		if len(key) == 0 {
+			continue
+		}
+		// Bundles with deduplicated chunks count at their full size in each quorum
+		usage[core.QuorumID(key[len(key)-1])] += storedBundleSize(iter.Value())
+	}
+	if err := iter.Error(); err != nil {
+		return nil, fmt.Errorf("failed to iterate over the bundles: %v", err)
+	}
+	return usage, nil
+}
+
+func (s *storeV2) SampleBlobs(n int) ([]*StoredBlob, error) {
+	blobCertificateKeyBuilder, err := s.db.GetKeyBuilder(BlobCertificateTableName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get key builder for blob certificates: %v", err)
 	}
 
-	return chunks, nil
+	iter, err := s.db.NewTableIterator(blobCertificateKeyBuilder)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create an iterator for the blob certificates: %v", err)
+	}
+	defer iter.Release()
+
+	// Blob keys are hashes, so seeking a random key picks the blobs close to uniformly
+	blobs := make([]*StoredBlob, 0, n)
+	sampled := make(map[string]struct{}, n)
+	for i := 0; i < n; i++ {
+		var start corev2.BlobKey
+		_, _ = rand.Read(start[:])
+		if !iter.Seek(start[:]) && !iter.First() {
+			break
+		}
+		if _, ok := sampled[string(iter.Key())]; ok {
+			continue
+		}
+		sampled[string(iter.Key())] = struct{}{}
+
+		blob, err := decodeStoredBlob(iter.Value())
+		if err != nil {
+			return nil, fmt.Errorf("failed to deserialize blob metadata: %v", err)
+		}
+		blobs = append(blobs, blob)
+	}
+	if err := iter.Error(); err != nil {
+		return nil, fmt.Errorf("failed to iterate over the blob certificates: %v", err)
+	}
+	return blobs, nil
+}
+
+func (s *storeV2) NextBlobs(after *corev2.BlobKey, n int) ([]*StoredBlob, error) {
+	blobCertificateKeyBuilder, err := s.db.GetKeyBuilder(BlobCertificateTableName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get key builder for blob certificates: %v", err)
+	}
+
+	iter, err := s.db.NewTableIterator(blobCertificateKeyBuilder)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create an iterator for the blob certificates: %v", err)
+	}
+	defer iter.Release()
+
+	ok := iter.First()
+	if after != nil {
+		ok = iter.Seek(after[:])
+		if ok && bytes.Equal(iter.Key(), after[:]) {
+			ok = iter.Next()
+		}
+	}
+	blobs := make([]*StoredBlob, 0, n)
+	for ; ok && len(blobs) < n; ok = iter.Next() {
+		blob, err := decodeStoredBlob(iter.Value())
+		if err != nil {
+			return nil, fmt.Errorf("failed to deserialize blob metadata: %v", err)
+		}
+		blobs = append(blobs, blob)
+	}
+	if err := iter.Error(); err != nil {
+		return nil, fmt.Errorf("failed to iterate over the blob certificates: %v", err)
+	}
+	return blobs, nil
+}
+
+func (s *storeV2) RepairBundle(blobKey corev2.BlobKey, quorum core.QuorumID, bundle []byte) error {
+	blobCertificateKeyBuilder, err := s.db.GetKeyBuilder(BlobCertificateTableName)
+	if err != nil {
+		return fmt.Errorf("failed to get key builder for blob certificates: %v", err)
+	}
+	bundlesKeyBuilder, err := s.db.GetKeyBuilder(BundleTableName)
+	if err != nil {
+		return fmt.Errorf("failed to get key builder for bundles: %v", err)
+	}
+
+	storedBlobBytes, err := s.db.Get(blobCertificateKeyBuilder.Key(blobKey[:]))
+	if err != nil {
+		return fmt.Errorf("failed to get blob metadata: %w", err)
+	}
+	blob, err := decodeStoredBlob(storedBlobBytes)
+	if err != nil {
+		return fmt.Errorf("failed to deserialize blob metadata: %v", err)
+	}
+	if _, ok := blob.Assignments[quorum]; !ok {
+		return fmt.Errorf("blob %s has no bundle in quorum %d", blobKey.Hex(), quorum)
+	}
+	ttl := s.ttl
+	if !blob.StoredAt.IsZero() {
+		ttl = time.Until(blob.StoredAt.Add(s.blobTTL(blob.BlobCertificate)))
+	}
+
+	k, err := BundleKey(blobKey, quorum)
+	if err != nil {
+		return fmt.Errorf("failed to get key for bundles: %v", err)
+	}
+	// The chunks of the replaced bundle are rewritten if they're still part of the bundle, which repairs them in the
+	// other quorums sharing them too
+	entries := make(chunkEntries)
+	if err := s.releaseStoredBundle(bundlesKeyBuilder.Key(k), blobKey, quorum, entries); err != nil {
+		return err
+	}
+	if s.deduplicateChunks {
+		bundle, err = s.addBundleChunks(blobKey, quorum, bundle, entries)
+		if err != nil {
+			return err
+		}
+	} else {
+		bundle, err = s.encryptor.Encrypt(context.Background(), bundle)
+		if err != nil {
+			return fmt.Errorf("failed to encrypt bundle: %w", err)
+		}
+	}
+	dbBatch := s.db.NewTTLBatch()
+	dbBatch.PutWithTTL(bundlesKeyBuilder.Key(k), bundle, ttl)
+	if _, _, err := s.putChunkEntries(dbBatch, blobKey, entries, time.Now().Add(ttl)); err != nil {
+		return err
+	}
+	if err := dbBatch.Apply(); err != nil {
+		return fmt.Errorf("failed to apply batch: %v", err)
+	}
+	return nil
+}
+
+func (s *storeV2) PruneBundles(prune func(blobKey corev2.BlobKey, blob *StoredBlob, quorum core.QuorumID) bool) ([]PrunedBundle, error) {
+	blobCertificateKeyBuilder, err := s.db.GetKeyBuilder(BlobCertificateTableName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get key builder for blob certificates: %v", err)
+	}
+	bundlesKeyBuilder, err := s.db.GetKeyBuilder(BundleTableName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get key builder for bundles: %v", err)
+	}
+
+	iter, err := s.db.NewTableIterator(blobCertificateKeyBuilder)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create an iterator for the blob certificates: %v", err)
+	}
+	defer iter.Release()
+
+	var coldBundleKeyBuilder kvstore.KeyBuilder
+	if s.coldTier != nil {
+		coldBundleKeyBuilder, err = s.db.GetKeyBuilder(ColdBundleTableName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get key builder for cold bundles: %v", err)
+		}
+	}
+
+	now := time.Now()
+	pruned := make([]PrunedBundle, 0)
+	dbBatch := s.db.NewTTLBatch()
+	// coldKeys are the keys of the pruned bundles of the cold tier, which are deleted from it once the batch is applied
+	coldKeys := make([][]byte, 0)
+	for iter.Next() {
+		var blobKey corev2.BlobKey
+		copy(blobKey[:], iter.Key())
+		blob, err := decodeStoredBlob(iter.Value())
+		if err != nil {
+			return nil, fmt.Errorf("failed to deserialize blob metadata: %v", err)
+		}
+		if blob.StoredAt.IsZero() {
+			continue
+		}
+		// The blob expires along with its bundles, so the remaining bundles keep their expiry
+		expiry := blob.StoredAt.Add(s.blobTTL(blob.BlobCertificate))
+		if !expiry.After(now) {
+			continue
+		}
+
+		prunedQuorums := make([]core.QuorumID, 0)
+		for quorum := range blob.Assignments {
+			if prune(blobKey, blob, quorum) {
+				prunedQuorums = append(prunedQuorums, quorum)
+			}
+		}
+		if len(prunedQuorums) == 0 {
+			continue
+		}
+
+		entries := make(chunkEntries)
+		for _, quorum := range prunedQuorums {
+			k, err := BundleKey(blobKey, quorum)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get key for bundles: %v", err)
+			}
+			bundle, err := s.db.Get(bundlesKeyBuilder.Key(k))
+			if err != nil && !errors.Is(err, kvstore.ErrNotFound) {
+				return nil, fmt.Errorf("failed to get bundle: %w", err)
+			}
+			if err == nil {
+				if err := s.releaseStoredBundle(bundlesKeyBuilder.Key(k), blobKey, quorum, entries); err != nil {
+					return nil, err
+				}
+			}
+			if errors.Is(err, kvstore.ErrNotFound) && s.coldTier != nil {
+				// The bundle may have been migrated, in which case it doesn't take any local space
+				_, err := s.db.Get(coldBundleKeyBuilder.Key(k))
+				if err == nil {
+					dbBatch.Delete(coldBundleKeyBuilder.Key(k))
+					coldKeys = append(coldKeys, k)
+				} else if !errors.Is(err, kvstore.ErrNotFound) {
+					return nil, fmt.Errorf("failed to get cold bundle: %w", err)
+				}
+			}
+			dbBatch.Delete(bundlesKeyBuilder.Key(k))
+			delete(blob.Assignments, quorum)
+			pruned = append(pruned, PrunedBundle{
+				BlobKey:  blobKey,
+				Quorum:   quorum,
+				Size:     storedBundleSize(bundle),
+				StoredAt: blob.StoredAt,
+			})
+		}
+		if _, _, err := s.putChunkEntries(dbBatch, blobKey, entries, expiry); err != nil {
+			return nil, err
+		}
+
+		if len(blob.Assignments) == 0 {
+			dbBatch.Delete(blobCertificateKeyBuilder.Key(blobKey[:]))
+		} else {
+			storedBlobBytes, err := encodeStoredBlob(blob)
+			if err != nil {
+				return nil, fmt.Errorf("failed to serialize blob metadata: %v", err)
+			}
+			dbBatch.PutWithExpiration(blobCertificateKeyBuilder.Key(blobKey[:]), storedBlobBytes, expiry)
+		}
+
+		if dbBatch.Size() >= pruneBatchSize {
+			if err := dbBatch.Apply(); err != nil {
+				return nil, fmt.Errorf("failed to apply batch: %v", err)
+			}
+			dbBatch = s.db.NewTTLBatch()
+			s.deleteColdBundles(coldKeys)
+			coldKeys = coldKeys[:0]
+		}
+	}
+	if err := iter.Error(); err != nil {
+		return nil, fmt.Errorf("failed to iterate over the blob certificates: %v", err)
+	}
+	if err := dbBatch.Apply(); err != nil {
+		return nil, fmt.Errorf("failed to apply batch: %v", err)
+	}
+	s.deleteColdBundles(coldKeys)
+	return pruned, nil
+}
+
+func (s *storeV2) MigrateBundles(ctx context.Context, storedBefore time.Time) ([]MigratedBundle, error) {
+	if s.coldTier == nil {
+		return nil, errors.New("no cold tier is configured")
+	}
+	blobCertificateKeyBuilder, err := s.db.GetKeyBuilder(BlobCertificateTableName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get key builder for blob certificates: %v", err)
+	}
+	bundlesKeyBuilder, err := s.db.GetKeyBuilder(BundleTableName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get key builder for bundles: %v", err)
+	}
+	coldBundleKeyBuilder, err := s.db.GetKeyBuilder(ColdBundleTableName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get key builder for cold bundles: %v", err)
+	}
+
+	iter, err := s.db.NewTableIterator(blobCertificateKeyBuilder)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create an iterator for the blob certificates: %v", err)
+	}
+	defer iter.Release()
+
+	now := time.Now()
+	migrated := make([]MigratedBundle, 0)
+	dbBatch := s.db.NewTTLBatch()
+	// The bundles uploaded before a failure are still recorded, so that they aren't uploaded again
+	var migrateErr error
+	for migrateErr == nil && iter.Next() {
+		var blobKey corev2.BlobKey
+		copy(blobKey[:], iter.Key())
+		blob, err := decodeStoredBlob(iter.Value())
+		if err != nil {
+			migrateErr = fmt.Errorf("failed to deserialize blob metadata: %v", err)
+			break
+		}
+		if blob.StoredAt.IsZero() || !blob.StoredAt.Before(storedBefore) {
+			continue
+		}
+		expiry := blob.StoredAt.Add(s.blobTTL(blob.BlobCertificate))
+		if !expiry.After(now) {
+			continue
+		}
+
+		entries := make(chunkEntries)
+		for quorum := range blob.Assignments {
+			k, err := BundleKey(blobKey, quorum)
+			if err != nil {
+				migrateErr = fmt.Errorf("failed to get key for bundles: %v", err)
+				break
+			}
+			bundle, err := s.db.Get(bundlesKeyBuilder.Key(k))
+			if errors.Is(err, kvstore.ErrNotFound) {
+				// The bundle was migrated already
+				continue
+			}
+			if err != nil {
+				migrateErr = fmt.Errorf("failed to get bundle: %w", err)
+				break
+			}
+			bundleSize := storedBundleSize(bundle)
+			// Bundles are uploaded as stored, so they stay encrypted if encryption at rest is enabled. Bundles with
+			// deduplicated chunks are uploaded serialized, and their chunks are released.
+			bundle, err = s.serializeStoredBundle(blobKey, quorum, bundle, entries)
+			if err != nil {
+				migrateErr = err
+				break
+			}
+			if err := s.coldTier.PutBundle(ctx, k, bundle); err != nil {
+				migrateErr = fmt.Errorf("failed to upload bundle: %w", err)
+				break
+			}
+			size := make([]byte, 8)
+			binary.BigEndian.PutUint64(size, bundleSize)
+			dbBatch.PutWithExpiration(coldBundleKeyBuilder.Key(k), size, expiry)
+			dbBatch.Delete(bundlesKeyBuilder.Key(k))
+			migrated = append(migrated, MigratedBundle{
+				BlobKey:  blobKey,
+				Quorum:   quorum,
+				Size:     bundleSize,
+				StoredAt: blob.StoredAt,
+			})
+		}
+		if _, _, err := s.putChunkEntries(dbBatch, blobKey, entries, expiry); err != nil && migrateErr == nil {
+			migrateErr = err
+		}
+
+		if dbBatch.Size() >= pruneBatchSize {
+			if err := dbBatch.Apply(); err != nil {
+				return nil, fmt.Errorf("failed to apply batch: %v", err)
+			}
+			dbBatch = s.db.NewTTLBatch()
+		}
+	}
+	if err := iter.Error(); err != nil && migrateErr == nil {
+		migrateErr = fmt.Errorf("failed to iterate over the blob certificates: %v", err)
+	}
+	if err := dbBatch.Apply(); err != nil {
+		return nil, fmt.Errorf("failed to apply batch: %v", err)
+	}
+	return migrated, migrateErr
+}
+
+// getColdBundle fetches a bundle migrated to the cold tier. Returns kvstore.ErrNotFound if the bundle wasn't migrated.
+func (s *storeV2) getColdBundle(key []byte) ([]byte, error) {
+	coldBundleKeyBuilder, err := s.db.GetKeyBuilder(ColdBundleTableName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get key builder for cold bundles: %v", err)
+	}
+	if _, err := s.db.Get(coldBundleKeyBuilder.Key(key)); err != nil {
+		return nil, err
+	}
+	bundle, err := s.coldTier.GetBundle(context.Background(), key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download bundle from the cold tier: %w", err)
+	}
+	return bundle, nil
+}
+
+// deleteColdBundles deletes pruned bundles from the cold tier. Failures are only logged, since the bundles are no
+// longer referenced and the cold tier expires them eventually.
+func (s *storeV2) deleteColdBundles(keys [][]byte) {
+	for _, key := range keys {
+		if err := s.coldTier.DeleteBundle(context.Background(), key); err != nil {
+			s.logger.Warn("Failed to delete pruned bundle from the cold tier", "key", hex.EncodeToString(key), "err", err)
+		}
+	}
+}
+
+// blobTTL returns the time after which the data of a blob expires. Blobs with a requested retention period shorter
+// than the default are expired early.
+func (s *storeV2) blobTTL(blobCertificate *corev2.BlobCertificate) time.Duration {
+	ttl := s.ttl
+	if retentionPeriod := blobCertificate.BlobHeader.PaymentMetadata.RetentionPeriod(); retentionPeriod > 0 && retentionPeriod < ttl {
+		ttl = retentionPeriod
+	}
+	return ttl
+}
+
+func encodeStoredBlob(blob *StoredBlob) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(blob); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func decodeStoredBlob(data []byte) (*StoredBlob, error) {
+	blob := new(StoredBlob)
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(blob); err != nil {
+		return nil, err
+	}
+	return blob, nil
+}
+
+// pendingBatch is the record of a batch stored by StoreBatch which isn't committed yet.
+type pendingBatch struct {
+	// Keys are the keys the batch added to the store, by table
+	Keys map[string][][]byte
+}
+
+func encodePendingBatch(pending *pendingBatch) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(pending); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func decodePendingBatch(data []byte) (*pendingBatch, error) {
+	pending := new(pendingBatch)
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(pending); err != nil {
+		return nil, err
+	}
+	return pending, nil
 }
 
 func BundleKey(blobKey corev2.BlobKey, quorumID core.QuorumID) ([]byte, error) {
@@ -1,9 +1,14 @@
 package node_test
 
 import (
+	"context"
+	"encoding/hex"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
+	"github.com/Layr-Labs/eigenda/common/aws/envelope"
 	"github.com/Layr-Labs/eigenda/common/kvstore"
 	"github.com/Layr-Labs/eigenda/common/kvstore/tablestore"
 	"github.com/Layr-Labs/eigenda/common/testutils"
@@ -44,10 +49,10 @@ func TestStoreBatchV2(t *testing.T) {
 	}()
 	keys, _, err := s.StoreBatch(batch, rawBundles)
 	require.NoError(t, err)
-	require.Len(t, keys, 7)
+	require.Len(t, keys, 11)
 
 	tables := db.GetTables()
-	require.ElementsMatch(t, []string{node.BatchHeaderTableName, node.BlobCertificateTableName, node.BundleTableName}, tables)
+	require.ElementsMatch(t, []string{node.BatchHeaderTableName, node.BlobCertificateTableName, node.BundleTableName, node.PendingBatchTableName}, tables)
 
 	// Check batch header
 	bhh, err := batch.BatchHeader.Hash()
@@ -77,6 +82,25 @@ func TestStoreBatchV2(t *testing.T) {
 		}
 	}
 
+	// Check blob metadata
+	storedBlobs, err := s.SampleBlobs(10)
+	require.NoError(t, err)
+	require.NotEmpty(t, storedBlobs)
+	for _, storedBlob := range storedBlobs {
+		require.Contains(t, batch.BlobCertificates, storedBlob.BlobCertificate)
+	}
+	for i, cert := range batch.BlobCertificates {
+		blobKey, err := cert.BlobHeader.BlobKey()
+		require.NoError(t, err)
+		storedBlob, err := s.GetStoredBlob(blobKey)
+		require.NoError(t, err)
+		require.NotNil(t, storedBlob.Inclusion)
+		require.Equal(t, uint32(i), storedBlob.Inclusion.BlobIndex)
+		require.Equal(t, blobKey, storedBlob.Inclusion.BlobKey)
+		require.Equal(t, batch.BatchHeader, storedBlob.Inclusion.BatchHeader)
+		require.NotEmpty(t, storedBlob.Inclusion.InclusionProof)
+	}
+
 	// Try to store the same batch again
 	_, _, err = s.StoreBatch(batch, rawBundles)
 	require.ErrorIs(t, err, node.ErrBatchAlreadyExist)
@@ -102,6 +126,71 @@ func TestStoreBatchV2(t *testing.T) {
 	}
 }
 
+func TestStoreBatchV2Pending(t *testing.T) {
+	_, batch, bundles := nodemock.MockBatch(t)
+	rawBundles := make([]*node.RawBundles, len(batch.BlobCertificates))
+	for i, cert := range batch.BlobCertificates {
+		rawBundles[i] = &node.RawBundles{
+			BlobCertificate: cert,
+			Bundles:         make(map[core.QuorumID][]byte),
+		}
+		for quorum, bundle := range bundles[i] {
+			bundleBytes, err := bundle.Serialize()
+			require.NoError(t, err)
+			rawBundles[i].Bundles[quorum] = bundleBytes
+		}
+	}
+	blobKey, err := batch.BlobCertificates[0].BlobHeader.BlobKey()
+	require.NoError(t, err)
+
+	s, db := createStoreV2(t)
+	defer func() {
+		_ = db.Shutdown()
+	}()
+
+	// A batch which isn't committed is discarded, and can be stored again
+	_, _, err = s.StoreBatch(batch, rawBundles)
+	require.NoError(t, err)
+	discarded, err := s.DiscardPendingBatches()
+	require.NoError(t, err)
+	require.Equal(t, 1, discarded)
+	storedBlobs, err := s.SampleBlobs(10)
+	require.NoError(t, err)
+	require.Empty(t, storedBlobs)
+	_, err = s.GetChunks(blobKey, 0)
+	require.Error(t, err)
+
+	// A committed batch is kept
+	_, _, err = s.StoreBatch(batch, rawBundles)
+	require.NoError(t, err)
+	bhh, err := batch.BatchHeader.Hash()
+	require.NoError(t, err)
+	require.NoError(t, s.CommitBatch(bhh))
+	discarded, err = s.DiscardPendingBatches()
+	require.NoError(t, err)
+	require.Zero(t, discarded)
+	_, err = s.GetChunks(blobKey, 0)
+	require.NoError(t, err)
+
+	// Discarding a batch doesn't delete the blobs it shares with committed batches
+	otherBatch := &corev2.Batch{
+		BatchHeader: &corev2.BatchHeader{
+			BatchRoot:            batch.BatchHeader.BatchRoot,
+			ReferenceBlockNumber: batch.BatchHeader.ReferenceBlockNumber + 1,
+		},
+		BlobCertificates: batch.BlobCertificates,
+	}
+	_, _, err = s.StoreBatch(otherBatch, rawBundles)
+	require.NoError(t, err)
+	discarded, err = s.DiscardPendingBatches()
+	require.NoError(t, err)
+	require.Equal(t, 1, discarded)
+	_, err = s.GetChunks(blobKey, 0)
+	require.NoError(t, err)
+	_, _, err = s.StoreBatch(otherBatch, rawBundles)
+	require.NoError(t, err)
+}
+
 func TestGetChunks(t *testing.T) {
 	blobKeys, batch, bundles := nodemock.MockBatch(t)
 
@@ -159,14 +248,261 @@ func TestGetChunks(t *testing.T) {
 	// wrong quorum
 	_, err = s.GetChunks(blobKeys[0], 2)
 	require.Error(t, err)
+
+	// All the bundles of a blob are read at once
+	blobChunks, err := s.GetBlobChunks(blobKeys[2])
+	require.NoError(t, err)
+	require.Len(t, blobChunks, 2)
+	require.Len(t, blobChunks[1], len(bundles[2][1]))
+	require.Len(t, blobChunks[2], len(bundles[2][2]))
+}
+
+func TestStoreBatchV2RetentionPeriod(t *testing.T) {
+	_, batch, bundles := nodemock.MockBatch(t)
+
+	// The first blob requests a retention period shorter than the store's TTL
+	batch.BlobCertificates[0].BlobHeader.PaymentMetadata.RetentionPeriodSeconds = 1
+
+	rawBundles := make([]*node.RawBundles, len(batch.BlobCertificates))
+	for i, cert := range batch.BlobCertificates {
+		rawBundles[i] = &node.RawBundles{
+			BlobCertificate: cert,
+			Bundles:         make(map[core.QuorumID][]byte),
+		}
+		for quorum, bundle := range bundles[i] {
+			bundleBytes, err := bundle.Serialize()
+			require.NoError(t, err)
+			rawBundles[i].Bundles[quorum] = bundleBytes
+		}
+	}
+
+	logger := testutils.GetLogger()
+	config := tablestore.DefaultLevelDBConfig(t.TempDir())
+	config.Schema = []string{node.BatchHeaderTableName, node.BlobCertificateTableName, node.BundleTableName, node.PendingBatchTableName}
+	config.GarbageCollectionInterval = 100 * time.Millisecond
+	db, err := tablestore.Start(logger, config)
+	require.NoError(t, err)
+	defer func() {
+		_ = db.Shutdown()
+	}()
+	s := node.NewLevelDBStoreV2(db, logger, time.Hour, nil, nil, false)
+
+	_, _, err = s.StoreBatch(batch, rawBundles)
+	require.NoError(t, err)
+
+	shortLivedKey, err := batch.BlobCertificates[0].BlobHeader.BlobKey()
+	require.NoError(t, err)
+	longLivedKey, err := batch.BlobCertificates[1].BlobHeader.BlobKey()
+	require.NoError(t, err)
+
+	_, err = s.GetChunks(shortLivedKey, 0)
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		_, err := s.GetChunks(shortLivedKey, 0)
+		return err != nil
+	}, 5*time.Second, 100*time.Millisecond)
+
+	_, err = s.GetChunks(longLivedKey, 0)
+	require.NoError(t, err)
+}
+
+func TestStoreBatchV2Encrypted(t *testing.T) {
+	blobKeys, batch, bundles := nodemock.MockBatch(t)
+	rawBundles := make([]*node.RawBundles, len(batch.BlobCertificates))
+	for i, cert := range batch.BlobCertificates {
+		rawBundles[i] = &node.RawBundles{
+			BlobCertificate: cert,
+			Bundles:         make(map[core.QuorumID][]byte),
+		}
+		for quorum, bundle := range bundles[i] {
+			bundleBytes, err := bundle.Serialize()
+			require.NoError(t, err)
+			rawBundles[i].Bundles[quorum] = bundleBytes
+		}
+	}
+	expectedChunks, _, err := node.DecodeChunks(rawBundles[0].Bundles[0])
+	require.NoError(t, err)
+
+	keyFile := filepath.Join(t.TempDir(), "chunk-key")
+	require.NoError(t, os.WriteFile(keyFile, []byte(hex.EncodeToString(make([]byte, 32))), 0600))
+	encryptor, err := node.NewChunkEncryptor(context.Background(), node.ChunkEncryptionConfig{
+		KeyFile:         keyFile,
+		DataKeyLifetime: time.Hour,
+	})
+	require.NoError(t, err)
+
+	logger := testutils.GetLogger()
+	plaintextStore, db := createStoreV2(t)
+	defer func() {
+		_ = db.Shutdown()
+	}()
+	encryptedStore := node.NewLevelDBStoreV2(db, logger, 10*time.Second, encryptor, nil, false)
+
+	// Chunks stored in plaintext are read transparently
+	keys, _, err := plaintextStore.StoreBatch(batch, rawBundles)
+	require.NoError(t, err)
+	chunks, err := encryptedStore.GetChunks(blobKeys[0], 0)
+	require.NoError(t, err)
+	require.Equal(t, expectedChunks, chunks)
+	require.NoError(t, plaintextStore.DeleteKeys(keys))
+
+	// Chunks are encrypted on disk
+	_, _, err = encryptedStore.StoreBatch(batch, rawBundles)
+	require.NoError(t, err)
+	bundleKeyBuilder, err := db.GetKeyBuilder(node.BundleTableName)
+	require.NoError(t, err)
+	k, err := node.BundleKey(blobKeys[0], 0)
+	require.NoError(t, err)
+	stored, err := db.Get(bundleKeyBuilder.Key(k))
+	require.NoError(t, err)
+	require.True(t, envelope.IsEncrypted(stored))
+
+	chunks, err = encryptedStore.GetChunks(blobKeys[0], 0)
+	require.NoError(t, err)
+	require.Equal(t, expectedChunks, chunks)
+
+	// Encrypted chunks can't be read without the key
+	_, err = plaintextStore.GetChunks(blobKeys[0], 0)
+	require.ErrorIs(t, err, envelope.ErrNoDataKeyProvider)
+
+	// The key file and KMS are exclusive
+	_, err = node.NewChunkEncryptor(context.Background(), node.ChunkEncryptionConfig{
+		KeyFile:   keyFile,
+		KMSRegion: "us-east-1",
+	})
+	require.Error(t, err)
 }
 
 func createStoreV2(t *testing.T) (node.StoreV2, kvstore.TableStore) {
 	logger := testutils.GetLogger()
 	config := tablestore.DefaultLevelDBConfig(t.TempDir())
-	config.Schema = []string{node.BatchHeaderTableName, node.BlobCertificateTableName, node.BundleTableName}
+	config.Schema = []string{node.BatchHeaderTableName, node.BlobCertificateTableName, node.BundleTableName, node.PendingBatchTableName}
 	tStore, err := tablestore.Start(logger, config)
 	require.NoError(t, err)
-	s := node.NewLevelDBStoreV2(tStore, logger, 10*time.Second)
+	s := node.NewLevelDBStoreV2(tStore, logger, 10*time.Second, nil, nil, false)
 	return s, tStore
 }
+
+func TestStoreBatchV2DeduplicatedChunks(t *testing.T) {
+	blobKeys, batch, bundles := nodemock.MockBatch(t)
+	rawBundles := make([]*node.RawBundles, len(batch.BlobCertificates))
+	for i, cert := range batch.BlobCertificates {
+		rawBundles[i] = &node.RawBundles{
+			BlobCertificate: cert,
+			Bundles:         make(map[core.QuorumID][]byte),
+			Assignments:     make(map[core.QuorumID]corev2.Assignment),
+		}
+		for quorum, bundle := range bundles[i] {
+			bundleBytes, err := bundle.Serialize()
+			require.NoError(t, err)
+			rawBundles[i].Bundles[quorum] = bundleBytes
+			rawBundles[i].Assignments[quorum] = corev2.Assignment{}
+		}
+	}
+	// The chunk of the first blob in quorum 0 is assigned to the operator in quorum 1 as well
+	shared := append(core.Bundle{}, bundles[0][0]...)
+	shared = append(shared, bundles[0][1]...)
+	sharedBytes, err := shared.Serialize()
+	require.NoError(t, err)
+	rawBundles[0].Bundles[1] = sharedBytes
+	// Every chunk is stored once, but the shared one
+	numChunks := -1
+	for i := range rawBundles {
+		for quorum := range rawBundles[i].Bundles {
+			chunks, _, err := node.DecodeChunks(rawBundles[i].Bundles[quorum])
+			require.NoError(t, err)
+			numChunks += len(chunks)
+		}
+	}
+
+	logger := testutils.GetLogger()
+	config := tablestore.DefaultLevelDBConfig(t.TempDir())
+	config.Schema = []string{node.BatchHeaderTableName, node.BlobCertificateTableName, node.BundleTableName, node.PendingBatchTableName, node.ChunkTableName}
+	db, err := tablestore.Start(logger, config)
+	require.NoError(t, err)
+	defer func() {
+		_ = db.Shutdown()
+	}()
+	s := node.NewLevelDBStoreV2(db, logger, time.Hour, nil, nil, true)
+	chunkKeyBuilder, err := db.GetKeyBuilder(node.ChunkTableName)
+	require.NoError(t, err)
+	countChunks := func() int {
+		iter, err := db.NewTableIterator(chunkKeyBuilder)
+		require.NoError(t, err)
+		defer iter.Release()
+		count := 0
+		for iter.Next() {
+			count++
+		}
+		return count
+	}
+
+	// A batch which isn't committed is discarded along with its chunks
+	_, _, err = s.StoreBatch(batch, rawBundles)
+	require.NoError(t, err)
+	require.Equal(t, numChunks, countChunks())
+	discarded, err := s.DiscardPendingBatches()
+	require.NoError(t, err)
+	require.Equal(t, 1, discarded)
+	require.Zero(t, countChunks())
+
+	_, _, err = s.StoreBatch(batch, rawBundles)
+	require.NoError(t, err)
+	bhh, err := batch.BatchHeader.Hash()
+	require.NoError(t, err)
+	require.NoError(t, s.CommitBatch(bhh))
+
+	// The shared chunk is stored once, and read back in both quorums
+	require.Equal(t, numChunks, countChunks())
+	for quorum, bundle := range rawBundles[0].Bundles {
+		expected, _, err := node.DecodeChunks(bundle)
+		require.NoError(t, err)
+		chunks, err := s.GetChunks(blobKeys[0], quorum)
+		require.NoError(t, err)
+		require.Equal(t, expected, chunks)
+	}
+	blobChunks, err := s.GetBlobChunks(blobKeys[0])
+	require.NoError(t, err)
+	require.Len(t, blobChunks, 2)
+	require.Len(t, blobChunks[1], 3)
+	require.Equal(t, blobChunks[0][0], blobChunks[1][0])
+
+	// Each quorum's usage counts the full size of its bundles
+	expectedUsage := make(map[core.QuorumID]uint64)
+	for _, raw := range rawBundles {
+		for quorum, bundle := range raw.Bundles {
+			expectedUsage[quorum] += uint64(len(bundle))
+		}
+	}
+	usage, err := s.GetQuorumUsage()
+	require.NoError(t, err)
+	require.Equal(t, expectedUsage, usage)
+
+	// Repairing a bundle rewrites its chunks in all the quorums sharing them
+	require.NoError(t, s.RepairBundle(blobKeys[0], 0, rawBundles[0].Bundles[0]))
+	require.Equal(t, numChunks, countChunks())
+	chunks, err := s.GetChunks(blobKeys[0], 1)
+	require.NoError(t, err)
+	require.Len(t, chunks, 3)
+
+	// A chunk is deleted once no quorum references it
+	pruneQuorum := func(quorum core.QuorumID) []node.PrunedBundle {
+		pruned, err := s.PruneBundles(func(blobKey corev2.BlobKey, blob *node.StoredBlob, q core.QuorumID) bool {
+			return blobKey == blobKeys[0] && q == quorum
+		})
+		require.NoError(t, err)
+		return pruned
+	}
+	pruned := pruneQuorum(1)
+	require.Len(t, pruned, 1)
+	require.Equal(t, uint64(len(sharedBytes)), pruned[0].Size)
+	require.Equal(t, numChunks-2, countChunks())
+	chunks, err = s.GetChunks(blobKeys[0], 0)
+	require.NoError(t, err)
+	require.Len(t, chunks, 1)
+	require.Len(t, pruneQuorum(0), 1)
+	require.Equal(t, numChunks-3, countChunks())
+	_, err = s.GetChunks(blobKeys[0], 0)
+	require.Error(t, err)
+}
@@ -0,0 +1,177 @@
+package node
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+// TLSConfig configures TLS on the node's gRPC servers.
+type TLSConfig struct {
+	// CertFile and KeyFile are the PEM encoded certificate and key of the servers. TLS is disabled if they're empty.
+	CertFile string
+	KeyFile  string
+	// DispersalClientCAFile holds the PEM encoded CA certificates which are allowed to sign the client certificates of
+	// the dispersal servers. If set, the dispersal servers require a client certificate signed by one of them.
+	DispersalClientCAFile string
+	// RetrievalClientCAFile is like DispersalClientCAFile, for the retrieval servers.
+	RetrievalClientCAFile string
+	// ReloadInterval is the minimum interval between checks of the files for a rotated certificate or CA.
+	ReloadInterval time.Duration
+}
+
+// Enabled returns true if the gRPC servers serve TLS.
+func (c *TLSConfig) Enabled() bool {
+	return c.CertFile != ""
+}
+
+// Validate checks that the config is consistent.
+func (c *TLSConfig) Validate() error {
+	if (c.CertFile == "") != (c.KeyFile == "") {
+		return errors.New("the tls cert file and key file must be set together")
+	}
+	if !c.Enabled() && (c.DispersalClientCAFile != "" || c.RetrievalClientCAFile != "") {
+		return errors.New("client CA files require the tls cert file and key file")
+	}
+	if c.Enabled() && c.ReloadInterval <= 0 {
+		return errors.New("the tls reload interval must be positive")
+	}
+	return nil
+}
+
+// TLSCredentials provides the TLS configs of the node's gRPC servers. The certificate, key and client CAs are
+// reloaded when their files change, so that they can be rotated without restarting the node. New connections use
+// the reloaded files, while established connections are unaffected.
+type TLSCredentials struct {
+	config TLSConfig
+	logger logging.Logger
+
+	mu        sync.Mutex
+	lastCheck time.Time
+	// modTimes are the modification times of the loaded files, by path
+	modTimes    map[string]time.Time
+	certificate *tls.Certificate
+	// clientCAs are the loaded client CA pools, by path
+	clientCAs map[string]*x509.CertPool
+}
+
+// NewTLSCredentials creates TLSCredentials for the given config, loading its files.
+func NewTLSCredentials(config TLSConfig, logger logging.Logger) (*TLSCredentials, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	if !config.Enabled() {
+		return nil, errors.New("tls is not configured")
+	}
+	c := &TLSCredentials{
+		config:    config,
+		logger:    logger.With("component", "TLSCredentials"),
+		modTimes:  make(map[string]time.Time),
+		clientCAs: make(map[string]*x509.CertPool),
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.load(time.Now()); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// DispersalConfig returns the TLS config of the dispersal servers.
+func (c *TLSCredentials) DispersalConfig() *tls.Config {
+	return c.serverConfig(c.config.DispersalClientCAFile)
+}
+
+// RetrievalConfig returns the TLS config of the retrieval servers.
+func (c *TLSCredentials) RetrievalConfig() *tls.Config {
+	return c.serverConfig(c.config.RetrievalClientCAFile)
+}
+
+// serverConfig returns a TLS config which requires client certificates signed by the CAs in clientCAFile, if set.
+// The certificates are resolved at each handshake, so that rotated files are picked up.
+func (c *TLSCredentials) serverConfig(clientCAFile string) *tls.Config {
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			c.mu.Lock()
+			defer c.mu.Unlock()
+			now := time.Now()
+			if now.Sub(c.lastCheck) >= c.config.ReloadInterval {
+				if err := c.load(now); err != nil {
+					// Keep serving the files loaded last, which are still valid
+					c.logger.Error("Failed to reload tls files", "err", err)
+				}
+			}
+
+			config := &tls.Config{
+				MinVersion:   tls.VersionTLS12,
+				Certificates: []tls.Certificate{*c.certificate},
+			}
+			if clientCAFile != "" {
+				config.ClientAuth = tls.RequireAndVerifyClientCert
+				config.ClientCAs = c.clientCAs[clientCAFile]
+			}
+			return config, nil
+		},
+	}
+}
+
+// load loads the files which changed since they were last loaded. Nothing is replaced if any file fails to load. The
+// caller must hold the lock.
+func (c *TLSCredentials) load(now time.Time) error {
+	c.lastCheck = now
+
+	modTimes := make(map[string]time.Time)
+	changed := false
+	paths := []string{c.config.CertFile, c.config.KeyFile, c.config.DispersalClientCAFile, c.config.RetrievalClientCAFile}
+	for _, path := range paths {
+		if path == "" {
+			continue
+		}
+		info, err := os.Stat(path)
+		if err != nil {
+			return fmt.Errorf("failed to stat %s: %w", path, err)
+		}
+		modTimes[path] = info.ModTime()
+		if !info.ModTime().Equal(c.modTimes[path]) {
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+
+	certificate, err := tls.LoadX509KeyPair(c.config.CertFile, c.config.KeyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load tls certificate: %w", err)
+	}
+	clientCAs := make(map[string]*x509.CertPool)
+	for _, path := range []string{c.config.DispersalClientCAFile, c.config.RetrievalClientCAFile} {
+		if path == "" {
+			continue
+		}
+		pem, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read client CA file %s: %w", path, err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("no CA certificates found in %s", path)
+		}
+		clientCAs[path] = pool
+	}
+
+	if c.certificate != nil {
+		c.logger.Info("Reloaded tls files")
+	}
+	c.modTimes = modTimes
+	c.certificate = &certificate
+	c.clientCAs = clientCAs
+	return nil
+}
@@ -0,0 +1,305 @@
+package node_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api/clients/v2"
+	"github.com/Layr-Labs/eigenda/api/grpc/validator"
+	"github.com/Layr-Labs/eigenda/common/grpctls"
+	"github.com/Layr-Labs/eigenda/common/testutils"
+	"github.com/Layr-Labs/eigenda/core"
+	corev2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/node"
+	nodemock "github.com/Layr-Labs/eigenda/node/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+type testCertificate struct {
+	cert    *x509.Certificate
+	key     *ecdsa.PrivateKey
+	certPEM []byte
+	keyPEM  []byte
+}
+
+// newTestCertificate creates a certificate signed by parent, or a self-signed CA certificate if parent is nil.
+func newTestCertificate(t *testing.T, name string, parent *testCertificate) *testCertificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: name},
+		DNSNames:     []string{"localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	signerCert, signerKey := template, key
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+	} else {
+		signerCert, signerKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, signerCert, &key.PublicKey, signerKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	return &testCertificate{
+		cert:    cert,
+		key:     key,
+		certPEM: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		keyPEM:  pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+	}
+}
+
+func (c *testCertificate) tlsCertificate(t *testing.T) tls.Certificate {
+	certificate, err := tls.X509KeyPair(c.certPEM, c.keyPEM)
+	require.NoError(t, err)
+	return certificate
+}
+
+func writeFile(t *testing.T, path string, data []byte, modTime time.Time) {
+	require.NoError(t, os.WriteFile(path, data, 0600))
+	require.NoError(t, os.Chtimes(path, modTime, modTime))
+}
+
+// handshake connects to the listener with the given client certificates, and returns the certificate served by the
+// server.
+func handshake(listener net.Listener, serverCA *x509.Certificate, clientCerts []tls.Certificate) (*x509.Certificate, error) {
+	roots := x509.NewCertPool()
+	roots.AddCert(serverCA)
+	conn, err := tls.Dial("tcp", listener.Addr().String(), &tls.Config{
+		ServerName:   "localhost",
+		RootCAs:      roots,
+		Certificates: clientCerts,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = conn.Close()
+	}()
+	// With TLS 1.3, the server verifies the client certificate after the client completes the handshake, so a
+	// rejection surfaces on the first read
+	_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	if _, err := conn.Read(make([]byte, 1)); err != nil {
+		return nil, err
+	}
+	return conn.ConnectionState().PeerCertificates[0], nil
+}
+
+// serve accepts connections on a TLS listener, writing a byte to each after the handshake.
+func serve(t *testing.T, config *tls.Config) net.Listener {
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", config)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = listener.Close()
+	})
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer func() {
+					_ = conn.Close()
+				}()
+				if err := conn.(*tls.Conn).Handshake(); err == nil {
+					_, _ = conn.Write([]byte{1})
+				}
+			}()
+		}
+	}()
+	return listener
+}
+
+func TestTLSCredentials(t *testing.T) {
+	logger := testutils.GetLogger()
+	dir := t.TempDir()
+	modTime := time.Now().Add(-time.Hour)
+
+	serverCA := newTestCertificate(t, "server-ca", nil)
+	serverCert := newTestCertificate(t, "server", serverCA)
+	disperserCA := newTestCertificate(t, "disperser-ca", nil)
+	disperserCert := newTestCertificate(t, "disperser", disperserCA)
+	otherCA := newTestCertificate(t, "other-ca", nil)
+	otherCert := newTestCertificate(t, "other", otherCA)
+
+	config := node.TLSConfig{
+		CertFile:              filepath.Join(dir, "server.crt"),
+		KeyFile:               filepath.Join(dir, "server.key"),
+		DispersalClientCAFile: filepath.Join(dir, "dispersal-ca.crt"),
+		ReloadInterval:        time.Nanosecond,
+	}
+	writeFile(t, config.CertFile, serverCert.certPEM, modTime)
+	writeFile(t, config.KeyFile, serverCert.keyPEM, modTime)
+	writeFile(t, config.DispersalClientCAFile, disperserCA.certPEM, modTime)
+
+	credentials, err := node.NewTLSCredentials(config, logger)
+	require.NoError(t, err)
+	dispersal := serve(t, credentials.DispersalConfig())
+	retrieval := serve(t, credentials.RetrievalConfig())
+
+	// The dispersal server only accepts clients with a certificate signed by the allowed CA
+	served, err := handshake(dispersal, serverCA.cert, []tls.Certificate{disperserCert.tlsCertificate(t)})
+	require.NoError(t, err)
+	require.Equal(t, "server", served.Subject.CommonName)
+	_, err = handshake(dispersal, serverCA.cert, nil)
+	require.Error(t, err)
+	_, err = handshake(dispersal, serverCA.cert, []tls.Certificate{otherCert.tlsCertificate(t)})
+	require.Error(t, err)
+
+	// The retrieval server doesn't require a client certificate
+	_, err = handshake(retrieval, serverCA.cert, nil)
+	require.NoError(t, err)
+
+	// Rotating the certificate and the allowed CA takes effect without restarting the servers
+	rotatedCert := newTestCertificate(t, "rotated", serverCA)
+	writeFile(t, config.CertFile, rotatedCert.certPEM, modTime.Add(time.Minute))
+	writeFile(t, config.KeyFile, rotatedCert.keyPEM, modTime.Add(time.Minute))
+	writeFile(t, config.DispersalClientCAFile, otherCA.certPEM, modTime.Add(time.Minute))
+	served, err = handshake(dispersal, serverCA.cert, []tls.Certificate{otherCert.tlsCertificate(t)})
+	require.NoError(t, err)
+	require.Equal(t, "rotated", served.Subject.CommonName)
+	_, err = handshake(dispersal, serverCA.cert, []tls.Certificate{disperserCert.tlsCertificate(t)})
+	require.Error(t, err)
+
+	// A broken rotation keeps the files loaded last
+	writeFile(t, config.KeyFile, serverCert.keyPEM, modTime.Add(2*time.Minute))
+	served, err = handshake(dispersal, serverCA.cert, []tls.Certificate{otherCert.tlsCertificate(t)})
+	require.NoError(t, err)
+	require.Equal(t, "rotated", served.Subject.CommonName)
+}
+
+func TestTLSConfigValidate(t *testing.T) {
+	require.NoError(t, (&node.TLSConfig{}).Validate())
+	require.Error(t, (&node.TLSConfig{CertFile: "server.crt"}).Validate())
+	require.Error(t, (&node.TLSConfig{DispersalClientCAFile: "ca.crt"}).Validate())
+	require.Error(t, (&node.TLSConfig{CertFile: "server.crt", KeyFile: "server.key"}).Validate())
+	require.NoError(t, (&node.TLSConfig{CertFile: "server.crt", KeyFile: "server.key", ReloadInterval: time.Minute}).Validate())
+}
+
+// signingDispersalServer accepts every StoreChunks request, signing the batch header hash with its BLS key.
+type signingDispersalServer struct {
+	validator.UnimplementedDispersalServer
+	keyPair *core.KeyPair
+}
+
+func (s *signingDispersalServer) StoreChunks(
+	_ context.Context,
+	request *validator.StoreChunksRequest,
+) (*validator.StoreChunksReply, error) {
+	batchHeader := corev2.BatchHeader{ReferenceBlockNumber: request.GetBatch().GetHeader().GetReferenceBlockNumber()}
+	copy(batchHeader.BatchRoot[:], request.GetBatch().GetHeader().GetBatchRoot())
+	hash, err := batchHeader.Hash()
+	if err != nil {
+		return nil, err
+	}
+	return &validator.StoreChunksReply{Signature: s.keyPair.SignMessage(hash).Serialize()}, nil
+}
+
+func TestTLSDispersalEndToEnd(t *testing.T) {
+	logger := testutils.GetLogger()
+	dir := t.TempDir()
+	modTime := time.Now().Add(-time.Hour)
+
+	serverCA := newTestCertificate(t, "server-ca", nil)
+	serverCert := newTestCertificate(t, "server", serverCA)
+	disperserCA := newTestCertificate(t, "disperser-ca", nil)
+	disperserCert := newTestCertificate(t, "disperser", disperserCA)
+	otherCA := newTestCertificate(t, "other-ca", nil)
+	otherCert := newTestCertificate(t, "other", otherCA)
+
+	config := node.TLSConfig{
+		CertFile:              filepath.Join(dir, "server.crt"),
+		KeyFile:               filepath.Join(dir, "server.key"),
+		DispersalClientCAFile: filepath.Join(dir, "dispersal-ca.crt"),
+		ReloadInterval:        time.Minute,
+	}
+	writeFile(t, config.CertFile, serverCert.certPEM, modTime)
+	writeFile(t, config.KeyFile, serverCert.keyPEM, modTime)
+	writeFile(t, config.DispersalClientCAFile, disperserCA.certPEM, modTime)
+	writeFile(t, filepath.Join(dir, "server-ca.crt"), serverCA.certPEM, modTime)
+	writeFile(t, filepath.Join(dir, "disperser.crt"), disperserCert.certPEM, modTime)
+	writeFile(t, filepath.Join(dir, "disperser.key"), disperserCert.keyPEM, modTime)
+	writeFile(t, filepath.Join(dir, "other.crt"), otherCert.certPEM, modTime)
+	writeFile(t, filepath.Join(dir, "other.key"), otherCert.keyPEM, modTime)
+
+	tlsCredentials, err := node.NewTLSCredentials(config, logger)
+	require.NoError(t, err)
+	keyPair, err := core.GenRandomBlsKeys()
+	require.NoError(t, err)
+	server := grpc.NewServer(grpc.Creds(credentials.NewTLS(tlsCredentials.DispersalConfig())))
+	validator.RegisterDispersalServer(server, &signingDispersalServer{keyPair: keyPair})
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	t.Cleanup(server.Stop)
+	_, port, err := net.SplitHostPort(listener.Addr().String())
+	require.NoError(t, err)
+
+	_, batch, _ := nodemock.MockBatch(t)
+	batchHeaderHash, err := batch.BatchHeader.Hash()
+	require.NoError(t, err)
+
+	storeChunks := func(clientConfig grpctls.ClientConfig) (*core.Signature, error) {
+		creds, err := clientConfig.Credentials()
+		require.NoError(t, err)
+		client, err := clients.NewNodeClient(&clients.NodeClientConfig{
+			Hostname:    "localhost",
+			Port:        port,
+			Credentials: creds,
+		}, nil)
+		require.NoError(t, err)
+		defer func() {
+			_ = client.Close()
+		}()
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		return client.StoreChunks(ctx, batch)
+	}
+
+	// A disperser presenting a certificate signed by the allowed CA gets the batch signed
+	signature, err := storeChunks(grpctls.ClientConfig{
+		CertFile: filepath.Join(dir, "disperser.crt"),
+		KeyFile:  filepath.Join(dir, "disperser.key"),
+		CAFile:   filepath.Join(dir, "server-ca.crt"),
+	})
+	require.NoError(t, err)
+	require.True(t, signature.Verify(keyPair.GetPubKeyG2(), batchHeaderHash))
+
+	// Dispersers without a certificate, with a certificate of another CA, or connecting in plaintext are rejected
+	_, err = storeChunks(grpctls.ClientConfig{CAFile: filepath.Join(dir, "server-ca.crt")})
+	require.Error(t, err)
+	_, err = storeChunks(grpctls.ClientConfig{
+		CertFile: filepath.Join(dir, "other.crt"),
+		KeyFile:  filepath.Join(dir, "other.key"),
+		CAFile:   filepath.Join(dir, "server-ca.crt"),
+	})
+	require.Error(t, err)
+	_, err = storeChunks(grpctls.ClientConfig{})
+	require.Error(t, err)
+}
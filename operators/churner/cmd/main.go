@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net"
@@ -106,7 +107,17 @@ func run(ctx *cli.Context) error {
 
 	// Register Server for Health Checks
 	name := pb.Churner_ServiceDesc.ServiceName
-	healthcheck.RegisterHealthServer(name, gs)
+	dependencyChecker := healthcheck.NewDependencyChecker(logger,
+		healthcheck.Dependency{
+			Name: "chain",
+			Check: func(ctx context.Context) error {
+				_, err := tx.GetCurrentBlockNumber(ctx)
+				return err
+			},
+		},
+	)
+	dependencyChecker.RegisterHealthServer(name, gs)
+	dependencyChecker.Start(context.Background())
 
 	log.Printf("churner server listening at %s", addr)
 	return gs.Serve(listener)
@@ -0,0 +1,127 @@
+package relay
+
+import (
+	"context"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common"
+	v2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/relay/cache"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// The kinds of callers of the relay, as reported by the access log and the egress metrics.
+const (
+	callerAnonymous = "anonymous"
+	callerValidator = "validator"
+	callerClient    = "client"
+	callerPeer      = "peer"
+)
+
+// accessLogEntry holds the details of a GetBlob or GetChunks request. They are filled in while the request is served,
+// and reported to the metrics and the access log once it completes.
+type accessLogEntry struct {
+	// method is the name of the RPC.
+	method string
+
+	// start is the time at which the request was received.
+	start time.Time
+
+	// caller is the kind of caller which made the request.
+	caller string
+
+	// client is the ID of the validator or relay client which made the request, if known.
+	client string
+
+	// address is the network address of the caller.
+	address string
+
+	// blobKeys are the keys of the blobs requested.
+	blobKeys []v2.BlobKey
+
+	// bytes is the size of the data sent in the reply.
+	bytes int
+
+	// tiers records the cache tiers which served the data of the request. Nil until the data is looked up.
+	tiers *cache.TierRecorder
+
+	// forwarded is true if some of the data was fetched from a peer of the fleet, or from a fallback relay.
+	forwarded bool
+}
+
+// newAccessLogEntry creates the access log entry of a request received now.
+func newAccessLogEntry(ctx context.Context, method string) *accessLogEntry {
+	entry := &accessLogEntry{
+		method: method,
+		start:  time.Now(),
+		caller: callerAnonymous,
+	}
+	if client, ok := peer.FromContext(ctx); ok {
+		entry.address = client.Addr.String()
+	}
+	return entry
+}
+
+// trackTiers returns the context with which the data of the request is looked up, so that the cache tiers which
+// served it are recorded.
+func (e *accessLogEntry) trackTiers(ctx context.Context) context.Context {
+	ctx, e.tiers = cache.WithTierRecorder(ctx)
+	return ctx
+}
+
+// tier returns the slowest tier which served the data of the request. Data fetched from peers is only reported if no
+// data was served locally, since the peers report their own tiers. Empty if no data was looked up.
+func (e *accessLogEntry) tier() string {
+	if e.tiers != nil {
+		if tier := e.tiers.Slowest(); tier != "" {
+			return string(tier)
+		}
+	}
+	if e.forwarded {
+		return callerPeer
+	}
+	return ""
+}
+
+// finishRequest reports a completed request to the metrics, and to the access log if it is enabled.
+func (s *Server) finishRequest(entry *accessLogEntry, err error) {
+	latency := time.Since(entry.start)
+	code := status.Code(err).String()
+	tier := entry.tier()
+
+	s.metrics.ReportRequestLatency(entry.method, code, latency)
+	if err == nil {
+		s.metrics.ReportEgress(entry.method, entry.caller, entry.bytes)
+		if tier != "" {
+			s.metrics.ReportRequestCacheTier(entry.method, tier)
+		}
+	}
+
+	if !s.config.EnableAccessLog {
+		return
+	}
+
+	// GetChunks requests may ask for several chunk ranges of the same blob
+	keys := make([]string, 0, len(entry.blobKeys))
+	seen := make(map[v2.BlobKey]struct{}, len(entry.blobKeys))
+	for _, key := range entry.blobKeys {
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		keys = append(keys, key.Hex())
+	}
+
+	s.accessLogger.Info("access",
+		"method", entry.method,
+		"caller", entry.caller,
+		"client", entry.client,
+		"address", entry.address,
+		"blobKeys", keys,
+		"bytes", entry.bytes,
+		"latencyMs", common.ToMilliseconds(latency),
+		"tier", tier,
+		"forwarded", entry.forwarded,
+		"status", code)
+}
@@ -0,0 +1,219 @@
+package auth
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api/hashing"
+	"github.com/Layr-Labs/eigenda/core/meterer"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/hashicorp/golang-lru/v2/expirable"
+	"google.golang.org/grpc/metadata"
+)
+
+// The gRPC metadata keys with which relay clients authenticate their requests. Each request carries the client ID,
+// and either the API key of the client, or a timestamp and an ECDSA signature of the request.
+const (
+	// ClientIDHeader holds the ID of the relay client.
+	ClientIDHeader = "relay-client-id"
+	// APIKeyHeader holds the API key of the relay client.
+	APIKeyHeader = "relay-api-key"
+	// TimestampHeader holds the Unix time in nanoseconds at which the request was signed.
+	TimestampHeader = "relay-timestamp"
+	// SignatureHeader holds the hex encoded ECDSA signature of the hash of the request, computed with
+	// hashing.HashRelayClientRequest.
+	SignatureHeader = "relay-signature"
+)
+
+// ClientStore provides the relay clients which can authenticate with the relay.
+type ClientStore interface {
+	// GetRelayClient returns the relay client with the given ID, or meterer.ErrRelayClientNotFound.
+	GetRelayClient(ctx context.Context, clientID string) (*meterer.RelayClient, error)
+}
+
+var _ ClientStore = &meterer.RelayClientStore{}
+
+// ClientAuthenticator authenticates the relay clients making requests to the relay service. Unlike the
+// RequestAuthenticator, which authenticates validators with their BLS keys, it authenticates clients registered in a
+// ClientStore, with an API key or an ECDSA signature. This object is thread safe.
+type ClientAuthenticator interface {
+	// AuthenticateClient authenticates the client which made a request from the gRPC metadata of the context, and
+	// returns the client. The method is the full gRPC method name of the request, and the requestHash is the hash of
+	// the request message, which are covered by the signature of the client.
+	AuthenticateClient(
+		ctx context.Context,
+		method string,
+		requestHash []byte,
+		now time.Time) (*meterer.RelayClient, error)
+}
+
+var _ ClientAuthenticator = &clientAuthenticator{}
+
+type clientAuthenticator struct {
+	store ClientStore
+
+	// clientCache caches the clients read from the store, so that changes to a client take effect once its cache
+	// entry expires.
+	clientCache *expirable.LRU[string, *meterer.RelayClient]
+
+	// maxSignatureAge is the maximum difference between the timestamp of a signed request and the current time.
+	maxSignatureAge time.Duration
+}
+
+// NewClientAuthenticator creates a new ClientAuthenticator. Up to clientCacheSize clients are cached for
+// clientCacheTTL. Signed requests are rejected if their timestamp differs from the current time by more than
+// maxSignatureAge, which limits the time during which a signed request can be replayed.
+func NewClientAuthenticator(
+	store ClientStore,
+	clientCacheSize int,
+	clientCacheTTL time.Duration,
+	maxSignatureAge time.Duration) (ClientAuthenticator, error) {
+
+	if store == nil {
+		return nil, errors.New("client store is required")
+	}
+	if clientCacheSize <= 0 {
+		return nil, fmt.Errorf("client cache size must be positive, got %d", clientCacheSize)
+	}
+	if maxSignatureAge <= 0 {
+		return nil, fmt.Errorf("max signature age must be positive, got %s", maxSignatureAge)
+	}
+
+	return &clientAuthenticator{
+		store:           store,
+		clientCache:     expirable.NewLRU[string, *meterer.RelayClient](clientCacheSize, nil, clientCacheTTL),
+		maxSignatureAge: maxSignatureAge,
+	}, nil
+}
+
+func (a *clientAuthenticator) AuthenticateClient(
+	ctx context.Context,
+	method string,
+	requestHash []byte,
+	now time.Time) (*meterer.RelayClient, error) {
+
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, errors.New("missing client credentials")
+	}
+
+	clientID, err := getHeader(md, ClientIDHeader)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := a.getClient(ctx, clientID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(md.Get(APIKeyHeader)) > 0 {
+		apiKey, err := getHeader(md, APIKeyHeader)
+		if err != nil {
+			return nil, err
+		}
+		err = authenticateAPIKey(client, apiKey)
+		if err != nil {
+			return nil, err
+		}
+		return client, nil
+	}
+
+	err = a.authenticateSignature(client, md, method, requestHash, now)
+	if err != nil {
+		return nil, err
+	}
+	return client, nil
+}
+
+// getClient returns the client with the given ID, caching the result.
+func (a *clientAuthenticator) getClient(ctx context.Context, clientID string) (*meterer.RelayClient, error) {
+	client, ok := a.clientCache.Get(clientID)
+	if ok {
+		return client, nil
+	}
+
+	client, err := a.store.GetRelayClient(ctx, clientID)
+	if errors.Is(err, meterer.ErrRelayClientNotFound) {
+		return nil, fmt.Errorf("unknown client %s", clientID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get client %s: %w", clientID, err)
+	}
+
+	a.clientCache.Add(clientID, client)
+	return client, nil
+}
+
+// authenticateAPIKey checks that the API key matches the hash of the API key of the client.
+func authenticateAPIKey(client *meterer.RelayClient, apiKey string) error {
+	if len(client.APIKeyHash) == 0 {
+		return fmt.Errorf("client %s can't authenticate with an API key", client.ClientID)
+	}
+
+	hash := crypto.Keccak256([]byte(apiKey))
+	if subtle.ConstantTimeCompare(hash, client.APIKeyHash) != 1 {
+		return errors.New("invalid API key")
+	}
+	return nil
+}
+
+// authenticateSignature checks that the request was signed by the key of the client recently.
+func (a *clientAuthenticator) authenticateSignature(
+	client *meterer.RelayClient,
+	md metadata.MD,
+	method string,
+	requestHash []byte,
+	now time.Time) error {
+
+	if client.Address == (gethcommon.Address{}) {
+		return fmt.Errorf("client %s can't authenticate with a signature", client.ClientID)
+	}
+
+	timestampHeader, err := getHeader(md, TimestampHeader)
+	if err != nil {
+		return err
+	}
+	timestamp, err := strconv.ParseInt(timestampHeader, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid timestamp: %w", err)
+	}
+	age := now.Sub(time.Unix(0, timestamp))
+	if age > a.maxSignatureAge || age < -a.maxSignatureAge {
+		return fmt.Errorf("request timestamp is %s away from the current time, max is %s", age, a.maxSignatureAge)
+	}
+
+	signatureHeader, err := getHeader(md, SignatureHeader)
+	if err != nil {
+		return err
+	}
+	signature, err := hex.DecodeString(signatureHeader)
+	if err != nil {
+		return fmt.Errorf("invalid signature encoding: %w", err)
+	}
+
+	hash := hashing.HashRelayClientRequest(method, client.ClientID, timestamp, requestHash)
+	publicKey, err := crypto.SigToPub(hash, signature)
+	if err != nil {
+		return fmt.Errorf("failed to recover public key from signature: %w", err)
+	}
+	if crypto.PubkeyToAddress(*publicKey) != client.Address {
+		return errors.New("signature doesn't match the key of the client")
+	}
+	return nil
+}
+
+// getHeader returns the single value of a gRPC metadata key.
+func getHeader(md metadata.MD, key string) (string, error) {
+	values := md.Get(key)
+	if len(values) != 1 {
+		return "", fmt.Errorf("expected exactly one %s header, got %d", key, len(values))
+	}
+	return values[0], nil
+}
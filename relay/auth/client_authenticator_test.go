@@ -0,0 +1,271 @@
+package auth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/hex"
+	"strconv"
+	"testing"
+	"time"
+
+	pb "github.com/Layr-Labs/eigenda/api/grpc/relay"
+	"github.com/Layr-Labs/eigenda/api/hashing"
+	tu "github.com/Layr-Labs/eigenda/common/testutils"
+	"github.com/Layr-Labs/eigenda/core/meterer"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// mockClientStore is a ClientStore backed by a map, which counts the lookups it serves.
+type mockClientStore struct {
+	clients map[string]*meterer.RelayClient
+	lookups int
+}
+
+func (s *mockClientStore) GetRelayClient(_ context.Context, clientID string) (*meterer.RelayClient, error) {
+	s.lookups++
+	client, ok := s.clients[clientID]
+	if !ok {
+		return nil, meterer.ErrRelayClientNotFound
+	}
+	return client, nil
+}
+
+func TestAuthenticateClientWithAPIKey(t *testing.T) {
+	tu.InitializeRandom()
+
+	apiKey := tu.RandomString(32)
+	store := &mockClientStore{
+		clients: map[string]*meterer.RelayClient{
+			"client": {
+				ClientID:   "client",
+				APIKeyHash: crypto.Keccak256([]byte(apiKey)),
+			},
+		},
+	}
+	authenticator, err := NewClientAuthenticator(store, 10, time.Minute, time.Minute)
+	require.NoError(t, err)
+
+	requestHash := tu.RandomBytes(32)
+	now := time.Now()
+
+	ctx := metadata.NewIncomingContext(context.Background(),
+		metadata.Pairs(ClientIDHeader, "client", APIKeyHeader, apiKey))
+	client, err := authenticator.AuthenticateClient(ctx, pb.Relay_GetBlob_FullMethodName, requestHash, now)
+	require.NoError(t, err)
+	require.Equal(t, "client", client.ClientID)
+
+	// the client is cached
+	_, err = authenticator.AuthenticateClient(ctx, pb.Relay_GetBlob_FullMethodName, requestHash, now)
+	require.NoError(t, err)
+	require.Equal(t, 1, store.lookups)
+
+	// wrong API key
+	ctx = metadata.NewIncomingContext(context.Background(),
+		metadata.Pairs(ClientIDHeader, "client", APIKeyHeader, tu.RandomString(32)))
+	_, err = authenticator.AuthenticateClient(ctx, pb.Relay_GetBlob_FullMethodName, requestHash, now)
+	require.Error(t, err)
+
+	// unknown client
+	ctx = metadata.NewIncomingContext(context.Background(),
+		metadata.Pairs(ClientIDHeader, "other", APIKeyHeader, apiKey))
+	_, err = authenticator.AuthenticateClient(ctx, pb.Relay_GetBlob_FullMethodName, requestHash, now)
+	require.Error(t, err)
+
+	// missing credentials
+	_, err = authenticator.AuthenticateClient(
+		context.Background(), pb.Relay_GetBlob_FullMethodName, requestHash, now)
+	require.Error(t, err)
+
+	// the client can't authenticate with a signature
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	signature, err := SignRelayClientRequest(
+		key, pb.Relay_GetBlob_FullMethodName, "client", now.UnixNano(), requestHash)
+	require.NoError(t, err)
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+		ClientIDHeader, "client",
+		TimestampHeader, strconv.FormatInt(now.UnixNano(), 10),
+		SignatureHeader, hex.EncodeToString(signature)))
+	_, err = authenticator.AuthenticateClient(ctx, pb.Relay_GetBlob_FullMethodName, requestHash, now)
+	require.Error(t, err)
+}
+
+func TestAuthenticateClientWithSignature(t *testing.T) {
+	tu.InitializeRandom()
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	store := &mockClientStore{
+		clients: map[string]*meterer.RelayClient{
+			"client": {
+				ClientID: "client",
+				Address:  crypto.PubkeyToAddress(key.PublicKey),
+			},
+		},
+	}
+	maxSignatureAge := time.Minute
+	authenticator, err := NewClientAuthenticator(store, 10, time.Minute, maxSignatureAge)
+	require.NoError(t, err)
+
+	method := pb.Relay_GetBlob_FullMethodName
+	requestHash := tu.RandomBytes(32)
+	now := time.Now()
+
+	signedContext := func(privateKey *ecdsa.PrivateKey, timestamp time.Time) context.Context {
+		signature, err := SignRelayClientRequest(privateKey, method, "client", timestamp.UnixNano(), requestHash)
+		require.NoError(t, err)
+		return metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+			ClientIDHeader, "client",
+			TimestampHeader, strconv.FormatInt(timestamp.UnixNano(), 10),
+			SignatureHeader, hex.EncodeToString(signature)))
+	}
+
+	ctx := signedContext(key, now)
+	client, err := authenticator.AuthenticateClient(ctx, method, requestHash, now)
+	require.NoError(t, err)
+	require.Equal(t, "client", client.ClientID)
+
+	// the signature covers the method and the request
+	_, err = authenticator.AuthenticateClient(ctx, pb.Relay_GetChunks_FullMethodName, requestHash, now)
+	require.Error(t, err)
+	_, err = authenticator.AuthenticateClient(ctx, method, tu.RandomBytes(32), now)
+	require.Error(t, err)
+
+	// signed with a different key
+	otherKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	_, err = authenticator.AuthenticateClient(signedContext(otherKey, now), method, requestHash, now)
+	require.Error(t, err)
+
+	// the timestamp must be within maxSignatureAge of the current time
+	_, err = authenticator.AuthenticateClient(
+		signedContext(key, now.Add(-maxSignatureAge/2)), method, requestHash, now)
+	require.NoError(t, err)
+	_, err = authenticator.AuthenticateClient(
+		signedContext(key, now.Add(-2*maxSignatureAge)), method, requestHash, now)
+	require.Error(t, err)
+	_, err = authenticator.AuthenticateClient(
+		signedContext(key, now.Add(2*maxSignatureAge)), method, requestHash, now)
+	require.Error(t, err)
+
+	// the client can't authenticate with an API key
+	ctx = metadata.NewIncomingContext(context.Background(),
+		metadata.Pairs(ClientIDHeader, "client", APIKeyHeader, tu.RandomString(32)))
+	_, err = authenticator.AuthenticateClient(ctx, method, requestHash, now)
+	require.Error(t, err)
+}
+
+func TestClientSigningInterceptor(t *testing.T) {
+	tu.InitializeRandom()
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	store := &mockClientStore{
+		clients: map[string]*meterer.RelayClient{
+			"client": {
+				ClientID: "client",
+				Address:  crypto.PubkeyToAddress(key.PublicKey),
+			},
+		},
+	}
+	authenticator, err := NewClientAuthenticator(store, 10, time.Minute, time.Minute)
+	require.NoError(t, err)
+
+	request := randomGetChunksRequest()
+	request.OperatorId = nil
+
+	interceptor := NewClientSigningInterceptor("client", key)
+	invoked := false
+	err = interceptor(
+		context.Background(),
+		pb.Relay_GetChunks_FullMethodName,
+		request,
+		nil,
+		nil,
+		func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+			invoked = true
+
+			// pass the outgoing metadata to the server, as gRPC would
+			md, ok := metadata.FromOutgoingContext(ctx)
+			require.True(t, ok)
+			serverCtx := metadata.NewIncomingContext(context.Background(), md)
+
+			client, err := authenticator.AuthenticateClient(
+				serverCtx, method, hashing.HashGetChunksRequest(request), time.Now())
+			require.NoError(t, err)
+			require.Equal(t, "client", client.ClientID)
+			return nil
+		})
+	require.NoError(t, err)
+	require.True(t, invoked)
+}
+
+// mockClientStream is a grpc.ClientStream which records the messages sent on it.
+type mockClientStream struct {
+	grpc.ClientStream
+	sent []any
+}
+
+func (s *mockClientStream) SendMsg(m any) error {
+	s.sent = append(s.sent, m)
+	return nil
+}
+
+func TestClientSigningStreamInterceptor(t *testing.T) {
+	tu.InitializeRandom()
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	store := &mockClientStore{
+		clients: map[string]*meterer.RelayClient{
+			"client": {
+				ClientID: "client",
+				Address:  crypto.PubkeyToAddress(key.PublicKey),
+			},
+		},
+	}
+	authenticator, err := NewClientAuthenticator(store, 10, time.Minute, time.Minute)
+	require.NoError(t, err)
+
+	request := &pb.GetBlobRequest{BlobKey: tu.RandomBytes(32)}
+
+	interceptor := NewClientSigningStreamInterceptor("client", key)
+	underlying := &mockClientStream{}
+	opened := false
+	stream, err := interceptor(
+		context.Background(),
+		&grpc.StreamDesc{StreamName: "GetBlobStream", ServerStreams: true},
+		nil,
+		pb.Relay_GetBlobStream_FullMethodName,
+		func(
+			ctx context.Context,
+			desc *grpc.StreamDesc,
+			cc *grpc.ClientConn,
+			method string,
+			opts ...grpc.CallOption) (grpc.ClientStream, error) {
+
+			opened = true
+
+			// pass the outgoing metadata to the server, as gRPC would
+			md, ok := metadata.FromOutgoingContext(ctx)
+			require.True(t, ok)
+			serverCtx := metadata.NewIncomingContext(context.Background(), md)
+
+			client, err := authenticator.AuthenticateClient(
+				serverCtx, method, hashing.HashGetBlobRequest(request), time.Now())
+			require.NoError(t, err)
+			require.Equal(t, "client", client.ClientID)
+			return underlying, nil
+		})
+	require.NoError(t, err)
+
+	// the stream is only opened once the request is sent, since the signature covers the request
+	require.False(t, opened)
+	err = stream.SendMsg(request)
+	require.NoError(t, err)
+	require.True(t, opened)
+	require.Equal(t, []any{request}, underlying.sent)
+}
@@ -1,11 +1,25 @@
 package auth
 
 import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
 	pb "github.com/Layr-Labs/eigenda/api/grpc/relay"
 	"github.com/Layr-Labs/eigenda/api/hashing"
 	"github.com/Layr-Labs/eigenda/core"
+	"github.com/ethereum/go-ethereum/crypto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 )
 
+// errStreamNotOpen is returned by the methods of a signingClientStream called before its request is sent.
+var errStreamNotOpen = errors.New("stream isn't open until its request is sent")
+
 // SignGetChunksRequest signs the given GetChunksRequest with the given private key. Does not
 // write the signature into the request.
 func SignGetChunksRequest(keys *core.KeyPair, request *pb.GetChunksRequest) []byte {
@@ -13,3 +27,176 @@ func SignGetChunksRequest(keys *core.KeyPair, request *pb.GetChunksRequest) []by
 	signature := keys.SignMessage(([32]byte)(hash))
 	return signature.G1Point.Serialize()
 }
+
+// SignRelayClientRequest signs a request made by a relay client with the given private key. The method is the full
+// gRPC method name of the request, the timestamp is the current Unix time in nanoseconds, and the requestHash is the
+// hash of the request message.
+func SignRelayClientRequest(
+	privateKey *ecdsa.PrivateKey,
+	method string,
+	clientID string,
+	timestamp int64,
+	requestHash []byte) ([]byte, error) {
+
+	hash := hashing.HashRelayClientRequest(method, clientID, timestamp, requestHash)
+	signature, err := crypto.Sign(hash, privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign request: %w", err)
+	}
+	return signature, nil
+}
+
+// NewClientSigningInterceptor returns an interceptor which authenticates the requests of a relay client with ECDSA
+// signatures, for the ClientAuthenticator of the relay. It can be installed on a relay client with the Interceptors
+// of its config. Clients which authenticate with an API key instead send the ClientIDHeader and APIKeyHeader
+// metadata, e.g. with clients.NewHeaderInterceptors.
+func NewClientSigningInterceptor(clientID string, privateKey *ecdsa.PrivateKey) grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req any,
+		reply any,
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		requestHash, ok := hashRelayClientRequest(req)
+		if !ok {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+
+		ctx, err := signOutgoingContext(ctx, privateKey, method, clientID, requestHash)
+		if err != nil {
+			return err
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// NewClientSigningStreamInterceptor returns the streaming counterpart of NewClientSigningInterceptor, which
+// authenticates the server-streaming requests of a relay client (e.g. GetBlobStream) with ECDSA signatures. Since the
+// signature covers the request message, the stream is only opened once the request is sent.
+func NewClientSigningStreamInterceptor(clientID string, privateKey *ecdsa.PrivateKey) grpc.StreamClientInterceptor {
+	return func(
+		ctx context.Context,
+		desc *grpc.StreamDesc,
+		cc *grpc.ClientConn,
+		method string,
+		streamer grpc.Streamer,
+		opts ...grpc.CallOption,
+	) (grpc.ClientStream, error) {
+		if desc.ClientStreams || !desc.ServerStreams {
+			return streamer(ctx, desc, cc, method, opts...)
+		}
+		return &signingClientStream{
+			ctx: ctx,
+			open: func(req any) (grpc.ClientStream, error) {
+				requestHash, ok := hashRelayClientRequest(req)
+				if !ok {
+					return streamer(ctx, desc, cc, method, opts...)
+				}
+
+				signedCtx, err := signOutgoingContext(ctx, privateKey, method, clientID, requestHash)
+				if err != nil {
+					return nil, err
+				}
+				return streamer(signedCtx, desc, cc, method, opts...)
+			},
+		}, nil
+	}
+}
+
+// hashRelayClientRequest returns the hash of a request message signed by relay clients, and false if the message
+// isn't signed.
+func hashRelayClientRequest(req any) ([]byte, bool) {
+	switch request := req.(type) {
+	case *pb.GetBlobRequest:
+		return hashing.HashGetBlobRequest(request), true
+	case *pb.GetChunksRequest:
+		return hashing.HashGetChunksRequest(request), true
+	default:
+		return nil, false
+	}
+}
+
+// signOutgoingContext signs a request of a relay client, and returns a context which sends the signature to the relay.
+func signOutgoingContext(
+	ctx context.Context,
+	privateKey *ecdsa.PrivateKey,
+	method string,
+	clientID string,
+	requestHash []byte) (context.Context, error) {
+
+	timestamp := time.Now().UnixNano()
+	signature, err := SignRelayClientRequest(privateKey, method, clientID, timestamp, requestHash)
+	if err != nil {
+		return nil, err
+	}
+
+	return metadata.AppendToOutgoingContext(ctx,
+		ClientIDHeader, clientID,
+		TimestampHeader, strconv.FormatInt(timestamp, 10),
+		SignatureHeader, hex.EncodeToString(signature)), nil
+}
+
+// signingClientStream is a server-streaming grpc.ClientStream which is opened when its request message is sent, so
+// that the request can be signed.
+type signingClientStream struct {
+	ctx context.Context
+
+	// open opens the underlying stream, given the request message.
+	open func(req any) (grpc.ClientStream, error)
+
+	// stream is the underlying stream. Nil until the request is sent.
+	stream grpc.ClientStream
+}
+
+var _ grpc.ClientStream = &signingClientStream{}
+
+func (s *signingClientStream) SendMsg(m any) error {
+	if s.stream != nil {
+		return s.stream.SendMsg(m)
+	}
+
+	stream, err := s.open(m)
+	if err != nil {
+		return err
+	}
+	s.stream = stream
+	return s.stream.SendMsg(m)
+}
+
+func (s *signingClientStream) RecvMsg(m any) error {
+	if s.stream == nil {
+		return errStreamNotOpen
+	}
+	return s.stream.RecvMsg(m)
+}
+
+func (s *signingClientStream) Header() (metadata.MD, error) {
+	if s.stream == nil {
+		return nil, errStreamNotOpen
+	}
+	return s.stream.Header()
+}
+
+func (s *signingClientStream) Trailer() metadata.MD {
+	if s.stream == nil {
+		return nil
+	}
+	return s.stream.Trailer()
+}
+
+func (s *signingClientStream) CloseSend() error {
+	if s.stream == nil {
+		return errStreamNotOpen
+	}
+	return s.stream.CloseSend()
+}
+
+func (s *signingClientStream) Context() context.Context {
+	if s.stream == nil {
+		return s.ctx
+	}
+	return s.stream.Context()
+}
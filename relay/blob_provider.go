@@ -26,15 +26,19 @@ type blobProvider struct {
 	fetchTimeout time.Duration
 }
 
-// newBlobProvider creates a new blobProvider.
+// newBlobProvider creates a new blobProvider. If diskCacheDirectory is not empty, blobs are also cached on disk in
+// that directory, behind the in-memory cache.
 func newBlobProvider(
 	ctx context.Context,
 	logger logging.Logger,
 	blobStore *blobstore.BlobStore,
 	blobCacheSize uint64,
+	diskCacheDirectory string,
+	diskCacheSize uint64,
 	maxIOConcurrency int,
 	fetchTimeout time.Duration,
-	metrics *cache.CacheAccessorMetrics) (*blobProvider, error) {
+	metrics *cache.CacheAccessorMetrics,
+	tieredMetrics *cache.TieredCacheMetrics) (*blobProvider, error) {
 
 	server := &blobProvider{
 		ctx:          ctx,
@@ -43,8 +47,31 @@ func newBlobProvider(
 		fetchTimeout: fetchTimeout,
 	}
 
+	blobCache := cache.NewFIFOCache[v2.BlobKey, []byte](blobCacheSize, computeBlobCacheWeight)
+	if diskCacheDirectory != "" {
+		diskCache, err := cache.NewDiskCache[v2.BlobKey, []byte](
+			logger,
+			diskCacheDirectory,
+			diskCacheSize,
+			func(key v2.BlobKey) string {
+				return key.Hex()
+			},
+			cache.Serializer[[]byte]{
+				Serialize: func(blob []byte) ([]byte, error) {
+					return blob, nil
+				},
+				Deserialize: func(data []byte) ([]byte, error) {
+					return data, nil
+				},
+			})
+		if err != nil {
+			return nil, fmt.Errorf("error creating disk blob cache: %w", err)
+		}
+		blobCache = cache.NewTieredCache[v2.BlobKey, []byte](ctx, logger, blobCache, diskCache, tieredMetrics)
+	}
+
 	cacheAccessor, err := cache.NewCacheAccessor[v2.BlobKey, []byte](
-		cache.NewFIFOCache[v2.BlobKey, []byte](blobCacheSize, computeBlobCacheWeight),
+		blobCache,
 		maxIOConcurrency,
 		server.fetchBlob,
 		metrics)
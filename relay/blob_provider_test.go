@@ -40,8 +40,11 @@ func TestReadWrite(t *testing.T) {
 		logger,
 		blobStore,
 		1024*1024*32,
+		"",
+		0,
 		32,
 		10*time.Second,
+		nil,
 		nil)
 	require.NoError(t, err)
 
@@ -78,8 +81,11 @@ func TestNonExistentBlob(t *testing.T) {
 		logger,
 		blobStore,
 		1024*1024*32,
+		"",
+		0,
 		32,
 		10*time.Second,
+		nil,
 		nil)
 	require.NoError(t, err)
 
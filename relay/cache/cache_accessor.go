@@ -12,8 +12,12 @@ import (
 type CacheAccessor[K comparable, V any] interface {
 	// Get returns the value for the given key. If the value is not in the cache, it will be fetched using the Accessor.
 	// If the context is cancelled, the function may abort early. If multiple goroutines request the same key,
-	// cancellation of one request will not affect the others.
+	// cancellation of one request will not affect the others. If the context has a TierRecorder, the tier which served
+	// the value is recorded.
 	Get(ctx context.Context, key K) (V, error)
+
+	// Peek returns the value for the given key if it is in the cache. Unlike Get, it never fetches the value.
+	Peek(key K) (V, bool)
 }
 
 // Accessor is function capable of fetching a value from a resource. Used by CacheAccessor when there is a cache miss.
@@ -103,13 +107,14 @@ func (c *cacheAccessor[K, V]) Get(ctx context.Context, key K) (V, error) {
 	c.cacheLock.Lock()
 
 	// first, attempt to get the value from the cache
-	v, ok := c.cache.Get(key)
+	v, tier, ok := c.lookup(key)
 	if ok {
 		c.cacheLock.Unlock()
 
 		if c.metrics != nil {
 			c.metrics.ReportCacheHit()
 		}
+		RecordTier(ctx, tier)
 		return v, nil
 	}
 
@@ -132,6 +137,8 @@ func (c *cacheAccessor[K, V]) Get(ctx context.Context, key K) (V, error) {
 		}
 	}
 
+	RecordTier(ctx, TierSource)
+
 	if alreadyLoading {
 		// The result is being fetched on another goroutine. Wait for it to finish.
 		return c.waitForResult(ctx, result)
@@ -141,6 +148,23 @@ func (c *cacheAccessor[K, V]) Get(ctx context.Context, key K) (V, error) {
 	}
 }
 
+// lookup looks up a key in the cache, and returns the tier which held it. Caches which don't tell the tier of their
+// values are held in memory.
+func (c *cacheAccessor[K, V]) lookup(key K) (V, Tier, bool) {
+	if tiered, ok := c.cache.(tieredGetter[K, V]); ok {
+		return tiered.getWithTier(key)
+	}
+	v, ok := c.cache.Get(key)
+	return v, TierMemory, ok
+}
+
+func (c *cacheAccessor[K, V]) Peek(key K) (V, bool) {
+	c.cacheLock.Lock()
+	defer c.cacheLock.Unlock()
+
+	return c.cache.Get(key)
+}
+
 // waitForResult waits for the result of a lookup that was initiated by another requester and returns it
 // when it becomes is available. This method will return quickly if the provided context is cancelled.
 // Doing so does not disrupt the other requesters that are also waiting for this result.
@@ -499,3 +499,29 @@ func TestSecondaryRequesterTimesOut(t *testing.T) {
 	// The internal lookupsInProgress map should no longer contain the key.
 	require.Equal(t, 0, len(ca.(*cacheAccessor[int, *string]).lookupsInProgress))
 }
+
+func TestPeek(t *testing.T) {
+	accessCount := atomic.Int32{}
+	accessor := func(key int) (*string, error) {
+		accessCount.Add(1)
+		str := tu.RandomString(10)
+		return &str, nil
+	}
+
+	cache := NewFIFOCache[int, *string](10, nil)
+	ca, err := NewCacheAccessor[int, *string](cache, 0, accessor, nil)
+	require.NoError(t, err)
+
+	// peeking at a missing value doesn't fetch it
+	value, ok := ca.Peek(0)
+	require.False(t, ok)
+	require.Nil(t, value)
+	require.Equal(t, int32(0), accessCount.Load())
+
+	expected, err := ca.Get(context.Background(), 0)
+	require.NoError(t, err)
+	value, ok = ca.Peek(0)
+	require.True(t, ok)
+	require.Equal(t, expected, value)
+	require.Equal(t, int32(1), accessCount.Load())
+}
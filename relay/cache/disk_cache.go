@@ -0,0 +1,179 @@
+package cache
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/emirpasic/gods/queues"
+	"github.com/emirpasic/gods/queues/linkedlistqueue"
+)
+
+var _ Cache[string, string] = &DiskCache[string, string]{}
+
+// Serializer converts the values stored in a DiskCache to and from bytes.
+type Serializer[V any] struct {
+	// Serialize converts a value to bytes.
+	Serialize func(value V) ([]byte, error)
+	// Deserialize converts bytes written by Serialize back to a value.
+	Deserialize func(data []byte) (V, error)
+}
+
+// KeyNamer returns the name of the file holding the value of a key in a DiskCache. Distinct keys must have distinct
+// names, and names must be valid file names.
+type KeyNamer[K comparable] func(key K) string
+
+// DiskCache is a cache which stores values as files in a directory on the local disk, and evicts the least recently
+// added item when the cache is full. The weight of each value is the size of its file, in bytes.
+//
+// The index of the cache is held in memory, so the directory is emptied when the cache is created, rather than
+// trusting files left behind by a previous process. Values which can't be read back are treated as missing.
+//
+// Unlike the other Cache implementations, DiskCache is thread safe, so that it can be written to in the background.
+type DiskCache[K comparable, V any] struct {
+	logger     logging.Logger
+	directory  string
+	keyNamer   KeyNamer[K]
+	serializer Serializer[V]
+
+	lock            sync.Mutex
+	currentWeight   uint64
+	maxWeight       uint64
+	data            map[K]uint64
+	expirationQueue queues.Queue
+}
+
+// NewDiskCache creates a new DiskCache holding at most maxWeight bytes in the given directory. The directory is
+// created if it doesn't exist, and its contents are deleted.
+func NewDiskCache[K comparable, V any](
+	logger logging.Logger,
+	directory string,
+	maxWeight uint64,
+	keyNamer KeyNamer[K],
+	serializer Serializer[V]) (*DiskCache[K, V], error) {
+
+	err := os.RemoveAll(directory)
+	if err != nil {
+		return nil, fmt.Errorf("failed to clear disk cache directory %s: %w", directory, err)
+	}
+	err = os.MkdirAll(directory, 0755)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create disk cache directory %s: %w", directory, err)
+	}
+
+	return &DiskCache[K, V]{
+		logger:          logger,
+		directory:       directory,
+		keyNamer:        keyNamer,
+		serializer:      serializer,
+		maxWeight:       maxWeight,
+		data:            make(map[K]uint64),
+		expirationQueue: linkedlistqueue.New(),
+	}, nil
+}
+
+func (d *DiskCache[K, V]) path(key K) string {
+	return filepath.Join(d.directory, d.keyNamer(key))
+}
+
+func (d *DiskCache[K, V]) Get(key K) (V, bool) {
+	var zero V
+
+	d.lock.Lock()
+	_, ok := d.data[key]
+	d.lock.Unlock()
+	if !ok {
+		return zero, false
+	}
+
+	// the file is read without holding the lock. If the value is evicted in the meantime, the read fails, and the
+	// value is treated as missing.
+	data, err := os.ReadFile(d.path(key))
+	if err != nil {
+		d.logger.Warn("failed to read value from disk cache", "key", d.keyNamer(key), "error", err)
+		return zero, false
+	}
+
+	value, err := d.serializer.Deserialize(data)
+	if err != nil {
+		d.logger.Warn("failed to deserialize value from disk cache", "key", d.keyNamer(key), "error", err)
+		return zero, false
+	}
+
+	return value, true
+}
+
+func (d *DiskCache[K, V]) Put(key K, value V) {
+	data, err := d.serializer.Serialize(value)
+	if err != nil {
+		d.logger.Warn("failed to serialize value for disk cache", "key", d.keyNamer(key), "error", err)
+		return
+	}
+
+	weight := uint64(len(data))
+	if weight > d.maxWeight {
+		// this item won't fit in the cache no matter what we evict
+		return
+	}
+
+	// write to a temporary file and rename it, so that readers never see a partially written file
+	path := d.path(key)
+	file, err := os.CreateTemp(d.directory, d.keyNamer(key)+".*.tmp")
+	if err != nil {
+		d.logger.Warn("failed to create disk cache file", "key", d.keyNamer(key), "error", err)
+		return
+	}
+	_, err = file.Write(data)
+	closeErr := file.Close()
+	if err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(file.Name(), path)
+	}
+	if err != nil {
+		d.logger.Warn("failed to write disk cache file", "key", d.keyNamer(key), "error", err)
+		_ = os.Remove(file.Name())
+		return
+	}
+
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	oldWeight, ok := d.data[key]
+	d.currentWeight += weight
+	d.data[key] = weight
+	if ok {
+		d.currentWeight -= oldWeight
+	} else {
+		d.expirationQueue.Enqueue(key)
+	}
+
+	for d.currentWeight > d.maxWeight {
+		val, _ := d.expirationQueue.Dequeue()
+		keyToEvict := val.(K)
+		d.currentWeight -= d.data[keyToEvict]
+		delete(d.data, keyToEvict)
+
+		err = os.Remove(d.path(keyToEvict))
+		if err != nil && !os.IsNotExist(err) {
+			d.logger.Warn("failed to remove disk cache file", "key", d.keyNamer(keyToEvict), "error", err)
+		}
+	}
+}
+
+func (d *DiskCache[K, V]) Size() int {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	return len(d.data)
+}
+
+func (d *DiskCache[K, V]) Weight() uint64 {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	return d.currentWeight
+}
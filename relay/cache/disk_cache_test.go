@@ -0,0 +1,98 @@
+package cache
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/common"
+	tu "github.com/Layr-Labs/eigenda/common/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func stringSerializer() Serializer[string] {
+	return Serializer[string]{
+		Serialize: func(value string) ([]byte, error) {
+			return []byte(value), nil
+		},
+		Deserialize: func(data []byte) (string, error) {
+			return string(data), nil
+		},
+	}
+}
+
+func intKeyNamer(key int) string {
+	return fmt.Sprintf("%d", key)
+}
+
+func TestDiskCacheExpirationOrder(t *testing.T) {
+	tu.InitializeRandom()
+
+	logger, err := common.NewLogger(common.DefaultLoggerConfig())
+	require.NoError(t, err)
+
+	directory := t.TempDir()
+
+	// files left behind by a previous process are deleted
+	err = os.WriteFile(filepath.Join(directory, "stale"), []byte("stale"), 0644)
+	require.NoError(t, err)
+
+	// each value is 10 bytes, so the cache holds 5 values
+	c, err := NewDiskCache[int, string](logger, directory, 50, intKeyNamer, stringSerializer())
+	require.NoError(t, err)
+	_, err = os.Stat(filepath.Join(directory, "stale"))
+	require.True(t, os.IsNotExist(err))
+
+	expectedValues := make(map[int]string)
+	for i := 0; i < 5; i++ {
+		value := tu.RandomString(10)
+		expectedValues[i] = value
+
+		_, ok := c.Get(i)
+		require.False(t, ok)
+
+		c.Put(i, value)
+		require.Equal(t, uint64(10*(i+1)), c.Weight())
+		require.Equal(t, i+1, c.Size())
+	}
+
+	for k, v := range expectedValues {
+		value, ok := c.Get(k)
+		require.True(t, ok)
+		require.Equal(t, v, value)
+	}
+
+	// adding values evicts the oldest ones, and deletes their files
+	for i := 5; i < 8; i++ {
+		value := tu.RandomString(10)
+		expectedValues[i] = value
+		c.Put(i, value)
+		require.Equal(t, uint64(50), c.Weight())
+		require.Equal(t, 5, c.Size())
+	}
+
+	for k, v := range expectedValues {
+		value, ok := c.Get(k)
+		if k < 3 {
+			require.False(t, ok)
+			_, err = os.Stat(filepath.Join(directory, intKeyNamer(k)))
+			require.True(t, os.IsNotExist(err))
+		} else {
+			require.True(t, ok)
+			require.Equal(t, v, value)
+		}
+	}
+
+	// values bigger than the cache are ignored
+	c.Put(100, tu.RandomString(51))
+	_, ok := c.Get(100)
+	require.False(t, ok)
+	require.Equal(t, uint64(50), c.Weight())
+
+	// values whose files are lost are treated as missing
+	err = os.Remove(filepath.Join(directory, intKeyNamer(7)))
+	require.NoError(t, err)
+	_, ok = c.Get(7)
+	require.False(t, ok)
+}
@@ -0,0 +1,67 @@
+package cache
+
+import (
+	"context"
+	"sync"
+)
+
+// Tier identifies where a value served by a CacheAccessor came from.
+type Tier string
+
+const (
+	// TierMemory means the value was cached in memory.
+	TierMemory Tier = "memory"
+	// TierDisk means the value was cached on disk.
+	TierDisk Tier = "disk"
+	// TierSource means the value wasn't cached, and was fetched from its source.
+	TierSource Tier = "source"
+)
+
+// tierRank orders the tiers from the fastest to the slowest.
+var tierRank = map[Tier]int{
+	TierMemory: 1,
+	TierDisk:   2,
+	TierSource: 3,
+}
+
+// tieredGetter is implemented by caches which can tell in which tier they found a value.
+type tieredGetter[K comparable, V any] interface {
+	getWithTier(key K) (V, Tier, bool)
+}
+
+// TierRecorder records the tiers which served the lookups made with a context, so that a request can tell where its
+// data came from. This object is thread safe.
+type TierRecorder struct {
+	lock    sync.Mutex
+	slowest Tier
+}
+
+type tierRecorderKey struct{}
+
+// WithTierRecorder returns a context whose CacheAccessor lookups are recorded by the returned TierRecorder.
+func WithTierRecorder(ctx context.Context) (context.Context, *TierRecorder) {
+	recorder := &TierRecorder{}
+	return context.WithValue(ctx, tierRecorderKey{}, recorder), recorder
+}
+
+// RecordTier records that a value was served from the given tier, if the context has a TierRecorder. Lookups which
+// bypass a CacheAccessor use this to report where their data came from.
+func RecordTier(ctx context.Context, tier Tier) {
+	recorder, ok := ctx.Value(tierRecorderKey{}).(*TierRecorder)
+	if !ok {
+		return
+	}
+
+	recorder.lock.Lock()
+	defer recorder.lock.Unlock()
+	if tierRank[tier] > tierRank[recorder.slowest] {
+		recorder.slowest = tier
+	}
+}
+
+// Slowest returns the slowest tier which served a lookup, or an empty tier if nothing was looked up.
+func (r *TierRecorder) Slowest() Tier {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.slowest
+}
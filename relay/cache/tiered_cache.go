@@ -0,0 +1,121 @@
+package cache
+
+import (
+	"context"
+
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+var _ Cache[string, string] = &TieredCache[string, string]{}
+var _ tieredGetter[string, string] = &TieredCache[string, string]{}
+
+// diskWriteQueueSize is the maximum number of values waiting to be written to the disk tier of a TieredCache. Values
+// put while the queue is full are not written to disk.
+const diskWriteQueueSize = 64
+
+// diskWrite is a value waiting to be written to the disk tier of a TieredCache.
+type diskWrite[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+// TieredCache is a cache with two tiers: a small in-memory cache holding the hottest values, in front of a larger
+// DiskCache. Values are looked up in memory first, then on disk, and values found on disk are moved back into memory.
+//
+// Values put in the cache are added to memory immediately, and written to disk in the background, so that callers
+// aren't held up by disk IO. Size and Weight report the memory tier.
+//
+// Like the other Cache implementations, TieredCache is not thread safe. The disk tier is written to by a background
+// goroutine, which exits when the context is cancelled.
+type TieredCache[K comparable, V any] struct {
+	logger  logging.Logger
+	memory  Cache[K, V]
+	disk    *DiskCache[K, V]
+	metrics *TieredCacheMetrics
+
+	diskWrites chan *diskWrite[K, V]
+}
+
+// NewTieredCache creates a new TieredCache. If metrics is not nil, it is used to record the hits of each tier.
+func NewTieredCache[K comparable, V any](
+	ctx context.Context,
+	logger logging.Logger,
+	memory Cache[K, V],
+	disk *DiskCache[K, V],
+	metrics *TieredCacheMetrics) Cache[K, V] {
+
+	cache := &TieredCache[K, V]{
+		logger:     logger,
+		memory:     memory,
+		disk:       disk,
+		metrics:    metrics,
+		diskWrites: make(chan *diskWrite[K, V], diskWriteQueueSize),
+	}
+	go cache.writeToDisk(ctx)
+
+	return cache
+}
+
+func (t *TieredCache[K, V]) Get(key K) (V, bool) {
+	value, _, ok := t.getWithTier(key)
+	return value, ok
+}
+
+// getWithTier looks up a value, and returns the tier which held it.
+func (t *TieredCache[K, V]) getWithTier(key K) (V, Tier, bool) {
+	value, ok := t.memory.Get(key)
+	if ok {
+		if t.metrics != nil {
+			t.metrics.ReportMemoryHit()
+		}
+		return value, TierMemory, true
+	}
+
+	value, ok = t.disk.Get(key)
+	if ok {
+		if t.metrics != nil {
+			t.metrics.ReportDiskHit()
+		}
+		t.memory.Put(key, value)
+		return value, TierDisk, true
+	}
+
+	if t.metrics != nil {
+		t.metrics.ReportMiss()
+	}
+	return value, "", false
+}
+
+func (t *TieredCache[K, V]) Put(key K, value V) {
+	t.memory.Put(key, value)
+
+	select {
+	case t.diskWrites <- &diskWrite[K, V]{key: key, value: value}:
+	default:
+		t.logger.Debug("disk cache write queue is full, value is only cached in memory")
+	}
+}
+
+func (t *TieredCache[K, V]) Size() int {
+	return t.memory.Size()
+}
+
+func (t *TieredCache[K, V]) Weight() uint64 {
+	return t.memory.Weight()
+}
+
+// writeToDisk writes the values put in the cache to the disk tier, until the context is cancelled.
+func (t *TieredCache[K, V]) writeToDisk(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case write := <-t.diskWrites:
+			t.disk.Put(write.key, write.value)
+			if t.metrics != nil {
+				t.metrics.ReportDiskSize(t.disk.Size())
+				t.metrics.ReportDiskWeight(t.disk.Weight())
+			}
+		}
+	}
+}
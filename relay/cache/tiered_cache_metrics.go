@@ -0,0 +1,85 @@
+package cache
+
+import (
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// TieredCacheMetrics provides metrics for a TieredCache.
+type TieredCacheMetrics struct {
+	tierHits   *prometheus.CounterVec
+	tierMisses *prometheus.CounterVec
+	diskSize   *prometheus.GaugeVec
+	diskWeight *prometheus.GaugeVec
+}
+
+// NewTieredCacheMetrics creates a new TieredCacheMetrics.
+func NewTieredCacheMetrics(
+	registry *prometheus.Registry,
+	cacheName string) *TieredCacheMetrics {
+
+	tierHits := promauto.With(registry).NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      fmt.Sprintf("%s_cache_tier_hit_count", cacheName),
+			Help:      "Number of cache hits, by the tier which held the value",
+		},
+		[]string{"tier"},
+	)
+
+	tierMisses := promauto.With(registry).NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      fmt.Sprintf("%s_cache_tier_miss_count", cacheName),
+			Help:      "Number of lookups which missed all the cache tiers",
+		},
+		[]string{},
+	)
+
+	diskSize := promauto.With(registry).NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      fmt.Sprintf("%s_disk_cache_size", cacheName),
+			Help:      "Number of items in the disk cache",
+		},
+		[]string{},
+	)
+
+	diskWeight := promauto.With(registry).NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      fmt.Sprintf("%s_disk_cache_weight", cacheName),
+			Help:      "Total size of the items in the disk cache, in bytes",
+		},
+		[]string{},
+	)
+
+	return &TieredCacheMetrics{
+		tierHits:   tierHits,
+		tierMisses: tierMisses,
+		diskSize:   diskSize,
+		diskWeight: diskWeight,
+	}
+}
+
+func (m *TieredCacheMetrics) ReportMemoryHit() {
+	m.tierHits.WithLabelValues(string(TierMemory)).Inc()
+}
+
+func (m *TieredCacheMetrics) ReportDiskHit() {
+	m.tierHits.WithLabelValues(string(TierDisk)).Inc()
+}
+
+func (m *TieredCacheMetrics) ReportMiss() {
+	m.tierMisses.WithLabelValues().Inc()
+}
+
+func (m *TieredCacheMetrics) ReportDiskSize(size int) {
+	m.diskSize.WithLabelValues().Set(float64(size))
+}
+
+func (m *TieredCacheMetrics) ReportDiskWeight(weight uint64) {
+	m.diskWeight.WithLabelValues().Set(float64(weight))
+}
@@ -0,0 +1,119 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common"
+	tu "github.com/Layr-Labs/eigenda/common/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTieredCache(t *testing.T) {
+	tu.InitializeRandom()
+
+	logger, err := common.NewLogger(common.DefaultLoggerConfig())
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	disk, err := NewDiskCache[int, string](logger, t.TempDir(), 1024, intKeyNamer, stringSerializer())
+	require.NoError(t, err)
+
+	// the memory tier holds 2 values
+	memory := NewFIFOCache[int, string](2, nil)
+	c := NewTieredCache[int, string](ctx, logger, memory, disk, nil)
+
+	expectedValues := make(map[int]string)
+	for i := 0; i < 10; i++ {
+		value := tu.RandomString(10)
+		expectedValues[i] = value
+		c.Put(i, value)
+	}
+	require.Equal(t, 2, c.Size())
+
+	// values are written to disk in the background
+	require.Eventually(t, func() bool {
+		return disk.Size() == 10
+	}, time.Second, 10*time.Millisecond)
+
+	// values evicted from memory are read from disk, and moved back into memory
+	_, ok := memory.Get(0)
+	require.False(t, ok)
+	value, ok := c.Get(0)
+	require.True(t, ok)
+	require.Equal(t, expectedValues[0], value)
+	value, ok = memory.Get(0)
+	require.True(t, ok)
+	require.Equal(t, expectedValues[0], value)
+
+	for k, v := range expectedValues {
+		value, ok := c.Get(k)
+		require.True(t, ok)
+		require.Equal(t, v, value)
+	}
+
+	_, ok = c.Get(100)
+	require.False(t, ok)
+}
+
+func TestTierRecorder(t *testing.T) {
+	tu.InitializeRandom()
+
+	logger, err := common.NewLogger(common.DefaultLoggerConfig())
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	disk, err := NewDiskCache[int, string](logger, t.TempDir(), 1024, intKeyNamer, stringSerializer())
+	require.NoError(t, err)
+	memory := NewFIFOCache[int, string](2, nil)
+	c := NewTieredCache[int, string](ctx, logger, memory, disk, nil)
+
+	accessor := func(key int) (string, error) {
+		return tu.RandomString(10), nil
+	}
+	ca, err := NewCacheAccessor[int, string](c, 0, accessor, nil)
+	require.NoError(t, err)
+
+	// lookups made without a recorder aren't recorded
+	_, err = ca.Get(ctx, 0)
+	require.NoError(t, err)
+
+	// values which aren't cached are fetched from the source
+	recorderCtx, recorder := WithTierRecorder(ctx)
+	require.Equal(t, Tier(""), recorder.Slowest())
+	_, err = ca.Get(recorderCtx, 1)
+	require.NoError(t, err)
+	require.Equal(t, TierSource, recorder.Slowest())
+
+	// values held in memory
+	recorderCtx, recorder = WithTierRecorder(ctx)
+	_, err = ca.Get(recorderCtx, 1)
+	require.NoError(t, err)
+	require.Equal(t, TierMemory, recorder.Slowest())
+
+	// evict the values from memory, once they are on disk
+	for i := 2; i < 4; i++ {
+		_, err = ca.Get(ctx, i)
+		require.NoError(t, err)
+	}
+	require.Eventually(t, func() bool {
+		return disk.Size() == 4
+	}, time.Second, 10*time.Millisecond)
+
+	// the slowest tier of the lookups is recorded
+	recorderCtx, recorder = WithTierRecorder(ctx)
+	_, err = ca.Get(recorderCtx, 3)
+	require.NoError(t, err)
+	require.Equal(t, TierMemory, recorder.Slowest())
+	_, err = ca.Get(recorderCtx, 0)
+	require.NoError(t, err)
+	require.Equal(t, TierDisk, recorder.Slowest())
+	_, err = ca.Get(recorderCtx, 3)
+	require.NoError(t, err)
+	require.Equal(t, TierDisk, recorder.Slowest())
+}
@@ -0,0 +1,194 @@
+package relay
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	v2 "github.com/Layr-Labs/eigenda/core/v2"
+	dispv2 "github.com/Layr-Labs/eigenda/disperser/common/v2"
+	"github.com/Layr-Labs/eigenda/disperser/common/v2/blobstore"
+	"github.com/Layr-Labs/eigenda/relay/fleet"
+	"github.com/Layr-Labs/eigenda/relay/metrics"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+// The outcomes of the prefetch of a newly certified blob, as reported by the metrics.
+const (
+	warmingOutcomeWarmed  = "warmed"
+	warmingOutcomeSkipped = "skipped"
+	warmingOutcomeFailed  = "failed"
+)
+
+// cacheWarmer prefetches newly certified blobs into the blob cache, so that the first retrieval of a fresh blob, which
+// is the common case for rollups deriving their chain, is a cache hit. It polls the metadata store for blobs which
+// became complete since its last poll, and prefetches the ones served by this relay.
+type cacheWarmer struct {
+	logger logging.Logger
+
+	// metadataStore is polled for newly certified blobs.
+	metadataStore blobstore.MetadataStore
+
+	// metadataProvider tells whether a blob is served by this relay.
+	metadataProvider *metadataProvider
+
+	// blobProvider holds the blob cache which is warmed.
+	blobProvider *blobProvider
+
+	// fleet tells which blobs are owned by this relay. If nil, the relay isn't part of a fleet.
+	fleet *fleet.Fleet
+
+	metrics *metrics.RelayMetrics
+
+	// interval is the interval at which the metadata store is polled.
+	interval time.Duration
+
+	// batchSize is the maximum number of blobs read from the metadata store per query.
+	batchSize int32
+
+	// maxConcurrency is the maximum number of blobs prefetched concurrently.
+	maxConcurrency int
+
+	// cursor is the position in the status index of the last certified blob seen.
+	cursor *blobstore.StatusIndexCursor
+}
+
+// newCacheWarmer creates a new cacheWarmer.
+func newCacheWarmer(
+	logger logging.Logger,
+	metadataStore blobstore.MetadataStore,
+	metadataProvider *metadataProvider,
+	blobProvider *blobProvider,
+	relayFleet *fleet.Fleet,
+	relayMetrics *metrics.RelayMetrics,
+	interval time.Duration,
+	batchSize int32,
+	maxConcurrency int) (*cacheWarmer, error) {
+
+	if batchSize <= 0 {
+		return nil, fmt.Errorf("cache warming batch size must be positive, got %d", batchSize)
+	}
+	if maxConcurrency <= 0 {
+		return nil, fmt.Errorf("cache warming max concurrency must be positive, got %d", maxConcurrency)
+	}
+
+	return &cacheWarmer{
+		logger:           logger.With("component", "RelayCacheWarmer"),
+		metadataStore:    metadataStore,
+		metadataProvider: metadataProvider,
+		blobProvider:     blobProvider,
+		fleet:            relayFleet,
+		metrics:          relayMetrics,
+		interval:         interval,
+		batchSize:        batchSize,
+		maxConcurrency:   maxConcurrency,
+	}, nil
+}
+
+// run warms the cache until the context is cancelled. Only the blobs certified after the warmer starts are
+// prefetched.
+func (w *cacheWarmer) run(ctx context.Context) {
+	w.cursor = &blobstore.StatusIndexCursor{
+		UpdatedAt: uint64(time.Now().UnixNano()),
+	}
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			err := w.warm(ctx)
+			if err != nil {
+				w.logger.Warn("error warming the blob cache", "err", err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// warm prefetches the blobs certified since the last call.
+func (w *cacheWarmer) warm(ctx context.Context) error {
+	for {
+		blobs, _, err := w.metadataStore.GetBlobMetadataByStatusPaginated(ctx, dispv2.Complete, w.cursor, w.batchSize)
+		if err != nil {
+			return fmt.Errorf("error fetching newly certified blobs: %w", err)
+		}
+		if len(blobs) == 0 {
+			return nil
+		}
+
+		// The cursor returned by the store is nil once the end of the index is reached, so the position after the
+		// last blob of the page is tracked instead.
+		last := blobs[len(blobs)-1]
+		lastKey, err := last.BlobHeader.BlobKey()
+		if err != nil {
+			return fmt.Errorf("error computing blob key: %w", err)
+		}
+
+		w.prefetch(ctx, blobs)
+
+		w.cursor = &blobstore.StatusIndexCursor{
+			BlobKey:   &lastKey,
+			UpdatedAt: last.UpdatedAt,
+		}
+		if len(blobs) < int(w.batchSize) {
+			return nil
+		}
+	}
+}
+
+// prefetch fetches the given blobs into the blob cache, if they are served by this relay.
+func (w *cacheWarmer) prefetch(ctx context.Context, blobs []*dispv2.BlobMetadata) {
+	semaphore := make(chan struct{}, w.maxConcurrency)
+	wg := sync.WaitGroup{}
+	for _, blob := range blobs {
+		blob := blob
+		semaphore <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-semaphore
+				wg.Done()
+			}()
+			w.metrics.ReportWarmedBlob(w.prefetchBlob(ctx, blob))
+		}()
+	}
+	wg.Wait()
+}
+
+// prefetchBlob fetches a blob into the blob cache if it's served by this relay, and returns the outcome.
+func (w *cacheWarmer) prefetchBlob(ctx context.Context, blob *dispv2.BlobMetadata) string {
+	if blob.Purged {
+		return warmingOutcomeSkipped
+	}
+
+	key, err := blob.BlobHeader.BlobKey()
+	if err != nil {
+		w.logger.Warn("error computing blob key", "err", err)
+		return warmingOutcomeFailed
+	}
+
+	// Blobs owned by a peer are cached by the peer, which warms its own cache.
+	if w.fleet != nil {
+		if _, self := w.fleet.Owner(key); !self {
+			return warmingOutcomeSkipped
+		}
+	}
+
+	// Fails if the blob isn't assigned to the relay keys of this relay, or if its retention period is over.
+	_, err = w.metadataProvider.GetMetadataForBlobs(ctx, []v2.BlobKey{key})
+	if err != nil {
+		w.logger.Debug("not prefetching blob", "key", key.Hex(), "err", err)
+		return warmingOutcomeSkipped
+	}
+
+	_, err = w.blobProvider.GetBlob(ctx, key)
+	if err != nil {
+		w.logger.Warn("error prefetching blob", "key", key.Hex(), "err", err)
+		return warmingOutcomeFailed
+	}
+	return warmingOutcomeWarmed
+}
@@ -0,0 +1,128 @@
+package relay
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common"
+	tu "github.com/Layr-Labs/eigenda/common/testutils"
+	v2 "github.com/Layr-Labs/eigenda/core/v2"
+	dispv2 "github.com/Layr-Labs/eigenda/disperser/common/v2"
+	"github.com/Layr-Labs/eigenda/disperser/common/v2/blobstore"
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/Layr-Labs/eigenda/relay/metrics"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCacheWarming(t *testing.T) {
+	tu.InitializeRandom()
+
+	logger, err := common.NewLogger(common.DefaultLoggerConfig())
+	require.NoError(t, err)
+
+	setup(t)
+	defer teardown()
+
+	metadataStore := buildMetadataStore(t)
+	blobStore := buildBlobStore(t, logger)
+
+	mp, err := newMetadataProvider(
+		context.Background(),
+		logger,
+		metadataStore,
+		1024*1024,
+		32,
+		[]v2.RelayKey{1},
+		10*time.Second,
+		v2.NewBlobVersionParameterMap(mockBlobParamsMap()),
+		nil)
+	require.NoError(t, err)
+
+	bp, err := newBlobProvider(
+		context.Background(),
+		logger,
+		blobStore,
+		1024*1024*32,
+		"",
+		0,
+		32,
+		10*time.Second,
+		nil,
+		nil)
+	require.NoError(t, err)
+
+	warmer, err := newCacheWarmer(
+		logger,
+		metadataStore,
+		mp,
+		bp,
+		nil,
+		metrics.NewRelayMetrics(logger, 9101),
+		time.Second,
+		2,
+		4)
+	require.NoError(t, err)
+
+	start := uint64(time.Now().UnixNano())
+	warmer.cursor = &blobstore.StatusIndexCursor{UpdatedAt: start}
+
+	// storeBlob stores a certified blob assigned to the given relay key, which became complete at the given time.
+	storeBlob := func(relayKey v2.RelayKey, updatedAt uint64) v2.BlobKey {
+		header, data := randomBlob(t)
+		blobKey, err := header.BlobKey()
+		require.NoError(t, err)
+
+		err = metadataStore.PutBlobCertificate(
+			context.Background(),
+			&v2.BlobCertificate{
+				BlobHeader: header,
+				RelayKeys:  []v2.RelayKey{relayKey},
+			},
+			&encoding.FragmentInfo{})
+		require.NoError(t, err)
+		err = metadataStore.PutBlobMetadata(context.Background(), &dispv2.BlobMetadata{
+			BlobHeader:  header,
+			BlobStatus:  dispv2.Complete,
+			BlobSize:    uint64(len(data)),
+			RequestedAt: updatedAt,
+			UpdatedAt:   updatedAt,
+		})
+		require.NoError(t, err)
+		err = blobStore.StoreBlob(context.Background(), blobKey, data)
+		require.NoError(t, err)
+		return blobKey
+	}
+
+	// certified before the warmer started
+	oldBlob := storeBlob(1, start-1)
+
+	// certified after the warmer started, more than a page of them
+	newBlobs := make([]v2.BlobKey, 0)
+	for i := 0; i < 5; i++ {
+		newBlobs = append(newBlobs, storeBlob(1, start+uint64(i)+1))
+	}
+
+	// assigned to another relay
+	otherBlob := storeBlob(2, start+10)
+
+	err = warmer.warm(context.Background())
+	require.NoError(t, err)
+
+	for _, key := range newBlobs {
+		_, ok := bp.blobCache.Peek(key)
+		require.True(t, ok)
+	}
+	_, ok := bp.blobCache.Peek(oldBlob)
+	require.False(t, ok)
+	_, ok = bp.blobCache.Peek(otherBlob)
+	require.False(t, ok)
+
+	// blobs certified later are picked up by the next poll
+	lastBlob := storeBlob(1, start+20)
+	err = warmer.warm(context.Background())
+	require.NoError(t, err)
+	_, ok = bp.blobCache.Peek(lastBlob)
+	require.True(t, ok)
+	require.Equal(t, lastBlob, *warmer.cursor.BlobKey)
+}
@@ -3,6 +3,7 @@ package relay
 import (
 	"bytes"
 	"context"
+	"encoding/binary"
 	"fmt"
 	"sync"
 	"time"
@@ -45,16 +46,20 @@ func (m *blobKeyWithMetadata) Compare(other *blobKeyWithMetadata) int {
 	return bytes.Compare(m.blobKey[:], other.blobKey[:])
 }
 
-// newChunkProvider creates a new chunkProvider.
+// newChunkProvider creates a new chunkProvider. If diskCacheDirectory is not empty, frames are also cached on disk in
+// that directory, behind the in-memory cache.
 func newChunkProvider(
 	ctx context.Context,
 	logger logging.Logger,
 	chunkReader chunkstore.ChunkReader,
 	cacheSize uint64,
+	diskCacheDirectory string,
+	diskCacheSize uint64,
 	maxIOConcurrency int,
 	proofFetchTimeout time.Duration,
 	coefficientFetchTimeout time.Duration,
-	metrics *cache.CacheAccessorMetrics) (*chunkProvider, error) {
+	metrics *cache.CacheAccessorMetrics,
+	tieredMetrics *cache.TieredCacheMetrics) (*chunkProvider, error) {
 
 	server := &chunkProvider{
 		ctx:                     ctx,
@@ -64,9 +69,29 @@ func newChunkProvider(
 		coefficientFetchTimeout: coefficientFetchTimeout,
 	}
 
+	frameCache := cache.NewFIFOCache[blobKeyWithMetadata, *core.ChunksData](cacheSize, server.computeFramesCacheWeight)
+	if diskCacheDirectory != "" {
+		diskCache, err := cache.NewDiskCache[blobKeyWithMetadata, *core.ChunksData](
+			logger,
+			diskCacheDirectory,
+			diskCacheSize,
+			func(key blobKeyWithMetadata) string {
+				return key.blobKey.Hex()
+			},
+			cache.Serializer[*core.ChunksData]{
+				Serialize:   serializeChunksData,
+				Deserialize: deserializeChunksData,
+			})
+		if err != nil {
+			return nil, fmt.Errorf("error creating disk chunk cache: %w", err)
+		}
+		frameCache = cache.NewTieredCache[blobKeyWithMetadata, *core.ChunksData](
+			ctx, logger, frameCache, diskCache, tieredMetrics)
+	}
+
 	var err error
 	server.frameCache, err = cache.NewCacheAccessor[blobKeyWithMetadata, *core.ChunksData](
-		cache.NewFIFOCache[blobKeyWithMetadata, *core.ChunksData](cacheSize, server.computeFramesCacheWeight),
+		frameCache,
 		maxIOConcurrency,
 		server.fetchFrames,
 		metrics)
@@ -86,6 +111,59 @@ func (s *chunkProvider) computeFramesCacheWeight(_ blobKeyWithMetadata, frames *
 	return frames.Size()
 }
 
+// serializeChunksData serializes frames for the disk cache. The frames are serialized as the chunk format, the chunk
+// length and the number of chunks, followed by the length and bytes of each chunk.
+func serializeChunksData(frames *core.ChunksData) ([]byte, error) {
+	size := 9
+	for _, chunk := range frames.Chunks {
+		size += 4 + len(chunk)
+	}
+
+	data := make([]byte, 0, size)
+	data = append(data, byte(frames.Format))
+	data = binary.BigEndian.AppendUint32(data, uint32(frames.ChunkLen))
+	data = binary.BigEndian.AppendUint32(data, uint32(len(frames.Chunks)))
+	for _, chunk := range frames.Chunks {
+		data = binary.BigEndian.AppendUint32(data, uint32(len(chunk)))
+		data = append(data, chunk...)
+	}
+
+	return data, nil
+}
+
+// deserializeChunksData deserializes frames serialized by serializeChunksData.
+func deserializeChunksData(data []byte) (*core.ChunksData, error) {
+	if len(data) < 9 {
+		return nil, fmt.Errorf("serialized frames are too short: %d bytes", len(data))
+	}
+
+	frames := &core.ChunksData{
+		Format:   core.ChunkEncodingFormat(data[0]),
+		ChunkLen: int(binary.BigEndian.Uint32(data[1:5])),
+	}
+	chunkCount := binary.BigEndian.Uint32(data[5:9])
+	data = data[9:]
+
+	frames.Chunks = make([][]byte, 0, chunkCount)
+	for i := uint32(0); i < chunkCount; i++ {
+		if len(data) < 4 {
+			return nil, fmt.Errorf("serialized frames are truncated at chunk %d", i)
+		}
+		chunkLength := binary.BigEndian.Uint32(data[:4])
+		data = data[4:]
+		if uint32(len(data)) < chunkLength {
+			return nil, fmt.Errorf("serialized frames are truncated at chunk %d", i)
+		}
+		frames.Chunks = append(frames.Chunks, data[:chunkLength])
+		data = data[chunkLength:]
+	}
+	if len(data) != 0 {
+		return nil, fmt.Errorf("serialized frames have %d trailing bytes", len(data))
+	}
+
+	return frames, nil
+}
+
 // GetFrames retrieves the frames for a blob.
 func (s *chunkProvider) GetFrames(ctx context.Context, mMap metadataMap) (frameMap, error) {
 
@@ -140,6 +218,135 @@ func (s *chunkProvider) GetFrames(ctx context.Context, mMap metadataMap) (frameM
 	return fMap, nil
 }
 
+// chunkRange is a range [startIndex, endIndex) of the chunk indices of a blob.
+type chunkRange struct {
+	startIndex uint32
+	endIndex   uint32
+}
+
+// GetFrameRanges retrieves the frames in a range of chunk indices for each blob. The frames of a blob are served from
+// the cache if it holds them, otherwise only the frames in the range are read from the chunk store, and they aren't
+// cached. The ChunksData of each blob holds as many chunks as the blob, of which only those in the range are set.
+func (s *chunkProvider) GetFrameRanges(
+	ctx context.Context,
+	mMap metadataMap,
+	ranges map[v2.BlobKey]chunkRange) (frameMap, error) {
+
+	if len(ranges) == 0 {
+		return nil, fmt.Errorf("no chunk ranges provided")
+	}
+
+	type framesResult struct {
+		key  v2.BlobKey
+		data *core.ChunksData
+		err  error
+	}
+
+	// Channel for results.
+	completionChannel := make(chan *framesResult, len(ranges))
+
+	for key, chunkRange := range ranges {
+		metadata, ok := mMap[key]
+		if !ok {
+			return nil, fmt.Errorf("no metadata provided for blob %v", key.Hex())
+		}
+
+		boundKey := blobKeyWithMetadata{blobKey: key, metadata: *metadata}
+		boundRange := chunkRange
+		go func() {
+			frames, err := s.fetchFrameRange(ctx, boundKey, boundRange)
+			if err != nil {
+				s.logger.Errorf("Failed to get frames %d-%d for blob %v: %v",
+					boundRange.startIndex, boundRange.endIndex, boundKey.blobKey.Hex(), err)
+			}
+			completionChannel <- &framesResult{
+				key:  boundKey.blobKey,
+				data: frames,
+				err:  err,
+			}
+		}()
+	}
+
+	fMap := make(frameMap, len(ranges))
+	for len(fMap) < len(ranges) {
+		result := <-completionChannel
+		if result.err != nil {
+			return nil, fmt.Errorf("error fetching frames for blob %v: %w", result.key.Hex(), result.err)
+		}
+		fMap[result.key] = result.data
+	}
+
+	return fMap, nil
+}
+
+// fetchFrameRange retrieves the frames in a range of chunk indices for a single blob.
+func (s *chunkProvider) fetchFrameRange(
+	ctx context.Context,
+	key blobKeyWithMetadata,
+	chunkRange chunkRange) (*core.ChunksData, error) {
+
+	if frames, ok := s.frameCache.Peek(key); ok {
+		// Peek doesn't tell the tier of the frames, but frames found on disk are moved back into memory.
+		cache.RecordTier(ctx, cache.TierMemory)
+		return frames, nil
+	}
+	cache.RecordTier(ctx, cache.TierSource)
+
+	numChunks := key.metadata.numChunks
+	if chunkRange.startIndex >= chunkRange.endIndex || chunkRange.endIndex > numChunks {
+		return nil, fmt.Errorf("chunk range %d-%d is invalid for blob with %d chunks",
+			chunkRange.startIndex, chunkRange.endIndex, numChunks)
+	}
+
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+
+	var proofs [][]byte
+	var proofsErr error
+
+	go func() {
+		ctx, cancel := context.WithTimeout(ctx, s.proofFetchTimeout)
+		defer func() {
+			wg.Done()
+			cancel()
+		}()
+
+		proofs, proofsErr = s.chunkReader.GetBinaryChunkProofsRange(
+			ctx, key.blobKey, chunkRange.startIndex, chunkRange.endIndex)
+	}()
+
+	fragmentInfo := &encoding.FragmentInfo{
+		TotalChunkSizeBytes: key.metadata.totalChunkSizeBytes,
+		FragmentSizeBytes:   key.metadata.fragmentSizeBytes,
+	}
+
+	coefficientCtx, cancel := context.WithTimeout(ctx, s.coefficientFetchTimeout)
+	defer cancel()
+
+	elementCount, coefficients, err := s.chunkReader.GetBinaryChunkCoefficientsRange(
+		coefficientCtx, key.blobKey, fragmentInfo, numChunks, chunkRange.startIndex, chunkRange.endIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	wg.Wait()
+	if proofsErr != nil {
+		return nil, proofsErr
+	}
+
+	frames, err := rs.BuildChunksData(proofs, int(elementCount), coefficients)
+	if err != nil {
+		return nil, err
+	}
+
+	// place the frames at their indices, so that they are selected like the frames of a whole blob
+	chunks := make([][]byte, numChunks)
+	copy(chunks[chunkRange.startIndex:], frames.Chunks)
+	frames.Chunks = chunks
+
+	return frames, nil
+}
+
 // fetchFrames retrieves the frames for a single blob.
 func (s *chunkProvider) fetchFrames(key blobKeyWithMetadata) (*core.ChunksData, error) {
 
@@ -8,6 +8,7 @@ import (
 	v2 "github.com/Layr-Labs/eigenda/core/v2"
 	"github.com/Layr-Labs/eigenda/encoding"
 	"github.com/stretchr/testify/require"
+	"math/rand"
 	"testing"
 	"time"
 )
@@ -60,9 +61,12 @@ func TestFetchingIndividualBlobs(t *testing.T) {
 		logger,
 		chunkReader,
 		1024*1024*32,
+		"",
+		0,
 		32,
 		10*time.Second,
 		10*time.Second,
+		nil,
 		nil)
 	require.NoError(t, err)
 
@@ -111,6 +115,94 @@ func TestFetchingIndividualBlobs(t *testing.T) {
 	}
 }
 
+func TestFetchingFrameRanges(t *testing.T) {
+	tu.InitializeRandom()
+
+	logger, err := common.NewLogger(common.DefaultLoggerConfig())
+	require.NoError(t, err)
+
+	setup(t)
+	defer teardown()
+
+	chunkReader, chunkWriter := buildChunkStore(t, logger)
+
+	expectedFrames := make(map[v2.BlobKey][]*encoding.Frame)
+	mMap := make(metadataMap)
+
+	// Write some data.
+	blobCount := 10
+	for i := 0; i < blobCount; i++ {
+
+		header, _, frames := randomBlobChunks(t)
+		blobKey, err := header.BlobKey()
+		require.NoError(t, err)
+
+		rsFrames, proofs := disassembleFrames(frames)
+
+		err = chunkWriter.PutFrameProofs(context.Background(), blobKey, proofs)
+		require.NoError(t, err)
+
+		fragmentInfo, err := chunkWriter.PutFrameCoefficients(context.Background(), blobKey, rsFrames)
+		require.NoError(t, err)
+
+		expectedFrames[blobKey] = frames
+		mMap[blobKey] = &blobMetadata{
+			numChunks:           uint32(len(frames)),
+			totalChunkSizeBytes: fragmentInfo.TotalChunkSizeBytes,
+			fragmentSizeBytes:   fragmentInfo.FragmentSizeBytes,
+		}
+	}
+
+	server, err := newChunkProvider(
+		context.Background(),
+		logger,
+		chunkReader,
+		1024*1024*32,
+		"",
+		0,
+		32,
+		10*time.Second,
+		10*time.Second,
+		nil,
+		nil)
+	require.NoError(t, err)
+
+	// Read a random range of the chunks of each blob.
+	ranges := make(map[v2.BlobKey]chunkRange)
+	for key, frames := range expectedFrames {
+		startIndex := uint32(rand.Intn(len(frames)))
+		endIndex := startIndex + uint32(rand.Intn(len(frames)-int(startIndex))) + 1
+		ranges[key] = chunkRange{startIndex: startIndex, endIndex: endIndex}
+	}
+
+	fMap, err := server.GetFrameRanges(context.Background(), mMap, ranges)
+	require.NoError(t, err)
+	require.Equal(t, blobCount, len(fMap))
+
+	for key, frames := range expectedFrames {
+		readFrames := fMap[key]
+		require.NotNil(t, readFrames)
+		require.Equal(t, len(frames), len(readFrames.Chunks))
+
+		r := ranges[key]
+		readRange := &core.ChunksData{
+			Chunks:   readFrames.Chunks[r.startIndex:r.endIndex],
+			Format:   readFrames.Format,
+			ChunkLen: readFrames.ChunkLen,
+		}
+		deserializedFrames := deserializeBinaryFrames(t, readRange)
+		require.Equal(t, frames[r.startIndex:r.endIndex], deserializedFrames)
+	}
+
+	// Ranges which are out of bounds are rejected.
+	for key, frames := range expectedFrames {
+		_, err = server.GetFrameRanges(context.Background(), mMap,
+			map[v2.BlobKey]chunkRange{key: {startIndex: 0, endIndex: uint32(len(frames)) + 1}})
+		require.Error(t, err)
+		break
+	}
+}
+
 func TestFetchingBatchedBlobs(t *testing.T) {
 	tu.InitializeRandom()
 
@@ -150,9 +242,12 @@ func TestFetchingBatchedBlobs(t *testing.T) {
 		logger,
 		chunkReader,
 		1024*1024*32,
+		"",
+		0,
 		32,
 		10*time.Second,
 		10*time.Second,
+		nil,
 		nil)
 	require.NoError(t, err)
 
@@ -186,3 +281,24 @@ func TestFetchingBatchedBlobs(t *testing.T) {
 		}
 	}
 }
+
+func TestSerializeChunksData(t *testing.T) {
+	tu.InitializeRandom()
+
+	frames := &core.ChunksData{
+		Chunks:   [][]byte{tu.RandomBytes(64), tu.RandomBytes(64), tu.RandomBytes(64)},
+		Format:   core.GnarkChunkEncodingFormat,
+		ChunkLen: 2,
+	}
+
+	data, err := serializeChunksData(frames)
+	require.NoError(t, err)
+	deserializedFrames, err := deserializeChunksData(data)
+	require.NoError(t, err)
+	require.Equal(t, frames, deserializedFrames)
+
+	_, err = deserializeChunksData(data[:len(data)-1])
+	require.Error(t, err)
+	_, err = deserializeChunksData(append(data, 0))
+	require.Error(t, err)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/Layr-Labs/eigenda/common/aws/envelope"
 	"github.com/Layr-Labs/eigenda/common/aws/s3"
 	corev2 "github.com/Layr-Labs/eigenda/core/v2"
 	"github.com/Layr-Labs/eigenda/encoding"
@@ -26,29 +27,55 @@ type ChunkReader interface {
 		ctx context.Context,
 		blobKey corev2.BlobKey,
 		fragmentInfo *encoding.FragmentInfo) (uint32, [][]byte, error)
+
+	// GetBinaryChunkProofsRange reads the proofs of the chunks with indices in [startIndex, endIndex), similar to
+	// GetBinaryChunkProofs, with a single ranged read rather than reading the proofs of all the chunks.
+	GetBinaryChunkProofsRange(
+		ctx context.Context,
+		blobKey corev2.BlobKey,
+		startIndex uint32,
+		endIndex uint32) ([][]byte, error)
+
+	// GetBinaryChunkCoefficientsRange reads the coefficients of the chunks with indices in [startIndex, endIndex),
+	// similar to GetBinaryChunkCoefficients, with a ranged read of each fragment which holds them rather than reading
+	// the coefficients of all the chunks. The numChunks is the number of chunks of the blob.
+	GetBinaryChunkCoefficientsRange(
+		ctx context.Context,
+		blobKey corev2.BlobKey,
+		fragmentInfo *encoding.FragmentInfo,
+		numChunks uint32,
+		startIndex uint32,
+		endIndex uint32) (uint32, [][]byte, error)
 }
 
 var _ ChunkReader = (*chunkReader)(nil)
 
 type chunkReader struct {
-	logger logging.Logger
-	client s3.Client
-	bucket string
+	logger    logging.Logger
+	client    s3.Client
+	bucket    string
+	encryptor *envelope.Encryptor
 }
 
 // NewChunkReader creates a new ChunkReader.
 //
 // This chunk reader will only return data for the shards specified in the shards parameter.
 // If empty, it will return data for all shards. (Note: shard feature is not yet implemented.)
+//
+// Encrypted chunks are decrypted with the encryptor. If it's nil, only plaintext chunks can be read. Encrypted objects
+// can only be decrypted in full, so if encryption is enabled, ranges of chunks are read by reading all the chunks.
+// Otherwise chunks are expected to be stored in plaintext, and ranges of chunks are read with ranged reads.
 func NewChunkReader(
 	logger logging.Logger,
 	s3Client s3.Client,
-	bucketName string) ChunkReader {
+	bucketName string,
+	encryptor *envelope.Encryptor) ChunkReader {
 
 	return &chunkReader{
-		logger: logger,
-		client: s3Client,
-		bucket: bucketName,
+		logger:    logger,
+		client:    s3Client,
+		bucket:    bucketName,
+		encryptor: encryptor,
 	}
 }
 
@@ -58,6 +85,11 @@ func (r *chunkReader) GetBinaryChunkProofs(ctx context.Context, blobKey corev2.B
 		r.logger.Error("failed to download proofs from S3", "blob", blobKey.Hex(), "error", err)
 		return nil, fmt.Errorf("failed to download proofs from S3 for blob %s: %w", blobKey.Hex(), err)
 	}
+	bytes, err = r.encryptor.Decrypt(ctx, bytes)
+	if err != nil {
+		r.logger.Error("failed to decrypt proofs", "blob", blobKey.Hex(), "error", err)
+		return nil, fmt.Errorf("failed to decrypt proofs for blob %s: %w", blobKey.Hex(), err)
+	}
 
 	proofs, err := rs.SplitSerializedFrameProofs(bytes)
 	if err != nil {
@@ -89,6 +121,11 @@ func (r *chunkReader) GetBinaryChunkCoefficients(
 		return 0, nil, fmt.Errorf("failed to download coefficients from S3 for blob %s (total size: %d, fragment size: %d): %w",
 			blobKey.Hex(), fragmentInfo.TotalChunkSizeBytes, fragmentInfo.FragmentSizeBytes, err)
 	}
+	bytes, err = r.encryptor.Decrypt(ctx, bytes)
+	if err != nil {
+		r.logger.Error("failed to decrypt coefficients", "blob", blobKey.Hex(), "error", err)
+		return 0, nil, fmt.Errorf("failed to decrypt coefficients for blob %s: %w", blobKey.Hex(), err)
+	}
 
 	elementCount, frames, err := rs.SplitSerializedFrameCoeffs(bytes)
 	if err != nil {
@@ -98,3 +135,104 @@ func (r *chunkReader) GetBinaryChunkCoefficients(
 
 	return elementCount, frames, nil
 }
+
+func (r *chunkReader) GetBinaryChunkProofsRange(
+	ctx context.Context,
+	blobKey corev2.BlobKey,
+	startIndex uint32,
+	endIndex uint32) ([][]byte, error) {
+
+	if startIndex >= endIndex {
+		return nil, fmt.Errorf("invalid chunk range %d-%d for blob %s", startIndex, endIndex, blobKey.Hex())
+	}
+
+	if r.encryptor.Enabled() {
+		proofs, err := r.GetBinaryChunkProofs(ctx, blobKey)
+		if err != nil {
+			return nil, err
+		}
+		if endIndex > uint32(len(proofs)) {
+			return nil, fmt.Errorf("chunk range %d-%d is invalid for blob %s with %d chunks",
+				startIndex, endIndex, blobKey.Hex(), len(proofs))
+		}
+		return proofs[startIndex:endIndex], nil
+	}
+
+	bytes, err := r.client.DownloadObjectRange(
+		ctx,
+		r.bucket,
+		s3.ScopedProofKey(blobKey),
+		int(startIndex)*rs.SerializedProofLength,
+		int(endIndex-startIndex)*rs.SerializedProofLength)
+	if err != nil {
+		r.logger.Error("failed to download proof range from S3",
+			"blob", blobKey.Hex(), "startIndex", startIndex, "endIndex", endIndex, "error", err)
+		return nil, fmt.Errorf("failed to download proofs %d-%d from S3 for blob %s: %w",
+			startIndex, endIndex, blobKey.Hex(), err)
+	}
+
+	proofs, err := rs.SplitSerializedFrameProofs(bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to split proofs for blob %s: %w", blobKey.Hex(), err)
+	}
+
+	return proofs, nil
+}
+
+func (r *chunkReader) GetBinaryChunkCoefficientsRange(
+	ctx context.Context,
+	blobKey corev2.BlobKey,
+	fragmentInfo *encoding.FragmentInfo,
+	numChunks uint32,
+	startIndex uint32,
+	endIndex uint32) (uint32, [][]byte, error) {
+
+	if startIndex >= endIndex || endIndex > numChunks {
+		return 0, nil, fmt.Errorf("chunk range %d-%d is invalid for blob %s with %d chunks",
+			startIndex, endIndex, blobKey.Hex(), numChunks)
+	}
+
+	if r.encryptor.Enabled() {
+		elementCount, frames, err := r.GetBinaryChunkCoefficients(ctx, blobKey, fragmentInfo)
+		if err != nil {
+			return 0, nil, err
+		}
+		if uint32(len(frames)) != numChunks {
+			return 0, nil, fmt.Errorf("blob %s has %d chunks, expected %d", blobKey.Hex(), len(frames), numChunks)
+		}
+		return elementCount, frames[startIndex:endIndex], nil
+	}
+
+	// the coefficients are serialized as the element count of each frame, followed by the frames, which all have the
+	// same size, so the frames in the range are at a known offset
+	const headerSize = 4
+	framesSize := fragmentInfo.TotalChunkSizeBytes - headerSize
+	if fragmentInfo.TotalChunkSizeBytes <= headerSize || framesSize%numChunks != 0 ||
+		(framesSize/numChunks)%encoding.BYTES_PER_SYMBOL != 0 {
+		return 0, nil, fmt.Errorf("coefficients of %d bytes can't hold %d chunks for blob %s",
+			fragmentInfo.TotalChunkSizeBytes, numChunks, blobKey.Hex())
+	}
+	bytesPerFrame := framesSize / numChunks
+
+	bytes, err := r.client.FragmentedDownloadObjectRange(
+		ctx,
+		r.bucket,
+		s3.ScopedChunkKey(blobKey),
+		int(fragmentInfo.TotalChunkSizeBytes),
+		int(fragmentInfo.FragmentSizeBytes),
+		int(headerSize+startIndex*bytesPerFrame),
+		int((endIndex-startIndex)*bytesPerFrame))
+	if err != nil {
+		r.logger.Error("failed to download coefficient range from S3",
+			"blob", blobKey.Hex(), "startIndex", startIndex, "endIndex", endIndex, "error", err)
+		return 0, nil, fmt.Errorf("failed to download coefficients %d-%d from S3 for blob %s: %w",
+			startIndex, endIndex, blobKey.Hex(), err)
+	}
+
+	frames := make([][]byte, 0, endIndex-startIndex)
+	for offset := uint32(0); offset < uint32(len(bytes)); offset += bytesPerFrame {
+		frames = append(frames, bytes[offset:offset+bytesPerFrame])
+	}
+
+	return bytesPerFrame / encoding.BYTES_PER_SYMBOL, frames, nil
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/common/aws"
+	"github.com/Layr-Labs/eigenda/common/aws/envelope"
 	"github.com/Layr-Labs/eigenda/common/aws/mock"
 	"github.com/Layr-Labs/eigenda/common/aws/s3"
 	"github.com/Layr-Labs/eigenda/common/testutils"
@@ -149,8 +150,8 @@ func RandomProofsTest(t *testing.T, client s3.Client) {
 
 	fragmentSize := rand.Intn(1024) + 100 // ignored since we aren't writing coefficients
 
-	writer := NewChunkWriter(logger, client, bucket, fragmentSize)
-	reader := NewChunkReader(logger, client, bucket)
+	writer := NewChunkWriter(logger, client, bucket, fragmentSize, nil)
+	reader := NewChunkReader(logger, client, bucket, nil)
 
 	expectedValues := make(map[corev2.BlobKey][]*encoding.Proof)
 
@@ -171,6 +172,12 @@ func RandomProofsTest(t *testing.T, client s3.Client) {
 		proofs := rs.DeserializeSplitFrameProofs(binaryProofs)
 		require.NoError(t, err)
 		require.Equal(t, expectedProofs, proofs)
+
+		startIndex := uint32(rand.Intn(len(expectedProofs)))
+		endIndex := startIndex + uint32(rand.Intn(len(expectedProofs)-int(startIndex))) + 1
+		binaryProofs, err = reader.GetBinaryChunkProofsRange(context.Background(), key, startIndex, endIndex)
+		require.NoError(t, err)
+		require.Equal(t, expectedProofs[startIndex:endIndex], rs.DeserializeSplitFrameProofs(binaryProofs))
 	}
 }
 
@@ -212,8 +219,8 @@ func RandomCoefficientsTest(t *testing.T, client s3.Client) {
 	assert.Nil(t, err)
 	require.NotNil(t, encoder)
 
-	writer := NewChunkWriter(logger, client, bucket, fragmentSize)
-	reader := NewChunkReader(logger, client, bucket)
+	writer := NewChunkWriter(logger, client, bucket, fragmentSize, nil)
+	reader := NewChunkReader(logger, client, bucket, nil)
 
 	expectedValues := make(map[corev2.BlobKey][]rs.FrameCoeffs)
 	metadataMap := make(map[corev2.BlobKey]*encoding.FragmentInfo)
@@ -241,6 +248,15 @@ func RandomCoefficientsTest(t *testing.T, client s3.Client) {
 		for i := 0; i < len(expectedCoefficients); i++ {
 			require.Equal(t, expectedCoefficients[i], coefficients[i])
 		}
+
+		numChunks := uint32(len(expectedCoefficients))
+		startIndex := uint32(rand.Intn(int(numChunks)))
+		endIndex := startIndex + uint32(rand.Intn(int(numChunks-startIndex))) + 1
+		elementCount, binaryCoefficients, err = reader.GetBinaryChunkCoefficientsRange(
+			context.Background(), key, metadataMap[key], numChunks, startIndex, endIndex)
+		require.NoError(t, err)
+		coefficients = rs.DeserializeSplitFrameCoeffs(elementCount, binaryCoefficients)
+		require.Equal(t, expectedCoefficients[startIndex:endIndex], coefficients)
 	}
 }
 
@@ -276,7 +292,7 @@ func TestCheckProofCoefficientsExist(t *testing.T) {
 	assert.Nil(t, err)
 	require.NotNil(t, encoder)
 
-	writer := NewChunkWriter(logger, client, bucket, fragmentSize)
+	writer := NewChunkWriter(logger, client, bucket, fragmentSize, nil)
 	ctx := context.Background()
 	for i := 0; i < 100; i++ {
 		key := corev2.BlobKey(tu.RandomBytes(32))
@@ -294,3 +310,62 @@ func TestCheckProofCoefficientsExist(t *testing.T) {
 		require.Equal(t, metadata, fragmentInfo)
 	}
 }
+
+// fullReadCountingClient counts the reads of whole objects.
+type fullReadCountingClient struct {
+	s3.Client
+	fullReads int
+}
+
+func (c *fullReadCountingClient) DownloadObject(ctx context.Context, bucket string, key string) ([]byte, error) {
+	c.fullReads++
+	return c.Client.DownloadObject(ctx, bucket, key)
+}
+
+func (c *fullReadCountingClient) FragmentedDownloadObject(
+	ctx context.Context,
+	bucket string,
+	key string,
+	fileSize int,
+	fragmentSize int) ([]byte, error) {
+
+	c.fullReads++
+	return c.Client.FragmentedDownloadObject(ctx, bucket, key, fileSize, fragmentSize)
+}
+
+func TestRangedReadsWithEncryptionDisabled(t *testing.T) {
+	tu.InitializeRandom()
+	logger := testutils.GetLogger()
+	client := &fullReadCountingClient{Client: mock.NewS3Client()}
+
+	chunkSize := uint64(rand.Intn(1024) + 100)
+	params := encoding.ParamsFromSysPar(3, 1, chunkSize)
+	encoder, err := rs.NewEncoder(encoding.DefaultConfig())
+	require.NoError(t, err)
+
+	// The encryptor can decrypt objects, but doesn't encrypt them
+	encryptor, err := envelope.NewEncryptor(nil, "", 0)
+	require.NoError(t, err)
+	writer := NewChunkWriter(logger, client, bucket, int(chunkSize/2), encryptor)
+	reader := NewChunkReader(logger, client, bucket, encryptor)
+
+	key := corev2.BlobKey(tu.RandomBytes(32))
+	proofs := getProofs(t, 32)
+	require.NoError(t, writer.PutFrameProofs(context.Background(), key, proofs))
+	coefficients := generateRandomFrameCoeffs(t, encoder, int(chunkSize), params)
+	fragmentInfo, err := writer.PutFrameCoefficients(context.Background(), key, coefficients)
+	require.NoError(t, err)
+
+	binaryProofs, err := reader.GetBinaryChunkProofsRange(context.Background(), key, 4, 8)
+	require.NoError(t, err)
+	require.Equal(t, proofs[4:8], rs.DeserializeSplitFrameProofs(binaryProofs))
+
+	numChunks := uint32(len(coefficients))
+	elementCount, binaryCoefficients, err := reader.GetBinaryChunkCoefficientsRange(
+		context.Background(), key, fragmentInfo, numChunks, 1, numChunks-1)
+	require.NoError(t, err)
+	require.Equal(t, coefficients[1:numChunks-1], rs.DeserializeSplitFrameCoeffs(elementCount, binaryCoefficients))
+
+	// The ranges are read without reading the whole objects
+	require.Equal(t, 0, client.fullReads)
+}
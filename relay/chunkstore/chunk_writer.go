@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/Layr-Labs/eigenda/common/aws/envelope"
 	"github.com/Layr-Labs/eigenda/common/aws/s3"
 	corev2 "github.com/Layr-Labs/eigenda/core/v2"
 	"github.com/Layr-Labs/eigenda/encoding"
@@ -34,20 +35,23 @@ type chunkWriter struct {
 	s3Client     s3.Client
 	bucketName   string
 	fragmentSize int
+	encryptor    *envelope.Encryptor
 }
 
-// NewChunkWriter creates a new ChunkWriter.
+// NewChunkWriter creates a new ChunkWriter. If encryptor is nil, chunks are stored in plaintext.
 func NewChunkWriter(
 	logger logging.Logger,
 	s3Client s3.Client,
 	bucketName string,
-	fragmentSize int) ChunkWriter {
+	fragmentSize int,
+	encryptor *envelope.Encryptor) ChunkWriter {
 
 	return &chunkWriter{
 		logger:       logger,
 		s3Client:     s3Client,
 		bucketName:   bucketName,
 		fragmentSize: fragmentSize,
+		encryptor:    encryptor,
 	}
 }
 
@@ -61,6 +65,11 @@ func (c *chunkWriter) PutFrameProofs(ctx context.Context, blobKey corev2.BlobKey
 		c.logger.Error("Failed to encode proofs", "err", err)
 		return fmt.Errorf("failed to encode proofs: %v", err)
 	}
+	bytes, err = c.encryptor.Encrypt(ctx, bytes)
+	if err != nil {
+		c.logger.Error("Failed to encrypt proofs", "err", err)
+		return fmt.Errorf("failed to encrypt proofs: %w", err)
+	}
 	err = c.s3Client.UploadObject(ctx, c.bucketName, s3.ScopedProofKey(blobKey), bytes)
 	if err != nil {
 		c.logger.Errorf("Failed to upload chunk proofs to S3: %v", err)
@@ -82,6 +91,12 @@ func (c *chunkWriter) PutFrameCoefficients(
 		c.logger.Error("Failed to encode frames", "err", err)
 		return nil, fmt.Errorf("failed to encode frames: %v", err)
 	}
+	// The size recorded in the fragment info is the size of the encrypted object, which is what the reader downloads
+	bytes, err = c.encryptor.Encrypt(ctx, bytes)
+	if err != nil {
+		c.logger.Error("Failed to encrypt frames", "err", err)
+		return nil, fmt.Errorf("failed to encrypt frames: %w", err)
+	}
 
 	err = c.s3Client.FragmentedUploadObject(ctx, c.bucketName, s3.ScopedChunkKey(blobKey), bytes, c.fragmentSize)
 	if err != nil {
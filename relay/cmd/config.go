@@ -8,8 +8,10 @@ import (
 	"github.com/Layr-Labs/eigenda/common/geth"
 	"github.com/Layr-Labs/eigenda/core/thegraph"
 	core "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/disperser/common/blobstore"
 	"github.com/Layr-Labs/eigenda/relay"
 	"github.com/Layr-Labs/eigenda/relay/cmd/flags"
+	"github.com/Layr-Labs/eigenda/relay/fleet"
 	"github.com/Layr-Labs/eigenda/relay/limiter"
 	"github.com/urfave/cli"
 )
@@ -29,6 +31,17 @@ type Config struct {
 	// MetadataTableName is the name of the DynamoDB table that stores metadata. Default is "metadata".
 	MetadataTableName string
 
+	// MetadataStoreConfig selects the backend storing metadata. Default is DynamoDB, in MetadataTableName.
+	MetadataStoreConfig blobstore.MetadataStoreConfig
+
+	// RelayClientTableName is the name of the DynamoDB table that stores the relay clients. Only used if client
+	// authentication is enabled.
+	RelayClientTableName string
+
+	// RelayClientUsageTableName is the name of the DynamoDB table that stores the usage of the relay clients. Only
+	// used if client authentication is enabled.
+	RelayClientUsageTableName string
+
 	// RelayConfig is the configuration for the relay.
 	RelayConfig relay.Config
 
@@ -50,21 +63,29 @@ func NewConfig(ctx *cli.Context) (Config, error) {
 		return Config{}, fmt.Errorf("no relay keys specified")
 	}
 	config := Config{
-		Log:               *loggerConfig,
-		AWS:               awsClientConfig,
-		BucketName:        ctx.String(flags.BucketNameFlag.Name),
-		MetadataTableName: ctx.String(flags.MetadataTableNameFlag.Name),
+		Log:                       *loggerConfig,
+		AWS:                       awsClientConfig,
+		BucketName:                ctx.String(flags.BucketNameFlag.Name),
+		MetadataTableName:         ctx.String(flags.MetadataTableNameFlag.Name),
+		MetadataStoreConfig:       blobstore.ReadMetadataStoreConfig(ctx, flags.FlagPrefix),
+		RelayClientTableName:      ctx.String(flags.RelayClientTableNameFlag.Name),
+		RelayClientUsageTableName: ctx.String(flags.RelayClientUsageTableNameFlag.Name),
 		RelayConfig: relay.Config{
 			RelayKeys:                  make([]core.RelayKey, len(relayKeys)),
 			GRPCPort:                   ctx.Int(flags.GRPCPortFlag.Name),
 			MaxGRPCMessageSize:         ctx.Int(flags.MaxGRPCMessageSizeFlag.Name),
+			GetBlobStreamPartSize:      ctx.Int(flags.GetBlobStreamPartSizeFlag.Name),
 			MetadataCacheSize:          ctx.Int(flags.MetadataCacheSizeFlag.Name),
 			MetadataMaxConcurrency:     ctx.Int(flags.MetadataMaxConcurrencyFlag.Name),
 			BlobCacheBytes:             ctx.Uint64(flags.BlobCacheBytes.Name),
 			BlobMaxConcurrency:         ctx.Int(flags.BlobMaxConcurrencyFlag.Name),
 			ChunkCacheBytes:            ctx.Uint64(flags.ChunkCacheBytesFlag.Name),
 			ChunkMaxConcurrency:        ctx.Int(flags.ChunkMaxConcurrencyFlag.Name),
+			DiskCacheDirectory:         ctx.String(flags.DiskCacheDirectoryFlag.Name),
+			BlobDiskCacheBytes:         ctx.Uint64(flags.BlobDiskCacheBytesFlag.Name),
+			ChunkDiskCacheBytes:        ctx.Uint64(flags.ChunkDiskCacheBytesFlag.Name),
 			MaxKeysPerGetChunksRequest: ctx.Int(flags.MaxKeysPerGetChunksRequestFlag.Name),
+			RangedChunkReads:           ctx.Bool(flags.RangedChunkReadsFlag.Name),
 			RateLimits: limiter.Config{
 				MaxGetBlobOpsPerSecond:          ctx.Float64(flags.MaxGetBlobOpsPerSecondFlag.Name),
 				GetBlobOpsBurstiness:            ctx.Int(flags.GetBlobOpsBurstinessFlag.Name),
@@ -81,11 +102,28 @@ func NewConfig(ctx *cli.Context) (Config, error) {
 				MaxGetChunkBytesPerSecondClient: ctx.Float64(flags.MaxGetChunkBytesPerSecondClientFlag.Name),
 				GetChunkBytesBurstinessClient:   ctx.Int(flags.GetChunkBytesBurstinessClientFlag.Name),
 				MaxConcurrentGetChunkOpsClient:  ctx.Int(flags.MaxConcurrentGetChunkOpsClientFlag.Name),
+				AccountLimitsFile:               ctx.String(flags.AccountLimitsFileFlag.Name),
+				ClientQuotaPeriod:               ctx.Duration(flags.ClientQuotaPeriodFlag.Name),
+				ClientQuotaFlushInterval:        ctx.Duration(flags.ClientQuotaFlushIntervalFlag.Name),
+			},
+			Fleet: fleet.Config{
+				Relays:       ctx.StringSlice(flags.FleetRelaysFlag.Name),
+				Self:         ctx.String(flags.FleetSelfFlag.Name),
+				VirtualNodes: ctx.Int(flags.FleetVirtualNodesFlag.Name),
+				Secret:       ctx.String(flags.FleetSecretFlag.Name),
+				Fallbacks:    ctx.StringSlice(flags.FleetFallbacksFlag.Name),
 			},
 			AuthenticationKeyCacheSize:  ctx.Int(flags.AuthenticationKeyCacheSizeFlag.Name),
 			AuthenticationTimeout:       ctx.Duration(flags.AuthenticationTimeoutFlag.Name),
 			AuthenticationDisabled:      ctx.Bool(flags.AuthenticationDisabledFlag.Name),
+			ClientAuthenticationEnabled: ctx.Bool(flags.ClientAuthenticationEnabledFlag.Name),
+			ClientCacheSize:             ctx.Int(flags.ClientCacheSizeFlag.Name),
+			ClientCacheTTL:              ctx.Duration(flags.ClientCacheTTLFlag.Name),
+			ClientSignatureMaxAge:       ctx.Duration(flags.ClientSignatureMaxAgeFlag.Name),
 			OnchainStateRefreshInterval: ctx.Duration(flags.OnchainStateRefreshIntervalFlag.Name),
+			CacheWarmingInterval:        ctx.Duration(flags.CacheWarmingIntervalFlag.Name),
+			CacheWarmingBatchSize:       int32(ctx.Int(flags.CacheWarmingBatchSizeFlag.Name)),
+			CacheWarmingMaxConcurrency:  ctx.Int(flags.CacheWarmingMaxConcurrencyFlag.Name),
 			Timeouts: relay.TimeoutConfig{
 				GetChunksTimeout:               ctx.Duration(flags.GetChunksTimeoutFlag.Name),
 				GetBlobTimeout:                 ctx.Duration(flags.GetBlobTimeoutFlag.Name),
@@ -94,10 +132,11 @@ func NewConfig(ctx *cli.Context) (Config, error) {
 				InternalGetProofsTimeout:       ctx.Duration(flags.InternalGetProofsTimeoutFlag.Name),
 				InternalGetCoefficientsTimeout: ctx.Duration(flags.InternalGetCoefficientsTimeoutFlag.Name),
 			},
-			MetricsPort:   ctx.Int(flags.MetricsPortFlag.Name),
-			EnableMetrics: ctx.Bool(flags.EnableMetricsFlag.Name),
-			EnablePprof:   ctx.Bool(flags.EnablePprofFlag.Name),
-			PprofHttpPort: ctx.Int(flags.PprofHttpPortFlag.Name),
+			MetricsPort:     ctx.Int(flags.MetricsPortFlag.Name),
+			EnableMetrics:   ctx.Bool(flags.EnableMetricsFlag.Name),
+			EnableAccessLog: ctx.Bool(flags.EnableAccessLogFlag.Name),
+			EnablePprof:     ctx.Bool(flags.EnablePprofFlag.Name),
+			PprofHttpPort:   ctx.Int(flags.PprofHttpPortFlag.Name),
 		},
 		EthClientConfig:               geth.ReadEthClientConfigRPCOnly(ctx),
 		BLSOperatorStateRetrieverAddr: ctx.String(flags.BlsOperatorStateRetrieverAddrFlag.Name),
@@ -7,6 +7,7 @@ import (
 	"github.com/Layr-Labs/eigenda/common/aws"
 	"github.com/Layr-Labs/eigenda/common/geth"
 	"github.com/Layr-Labs/eigenda/core/thegraph"
+	"github.com/Layr-Labs/eigenda/disperser/common/blobstore"
 	"github.com/docker/go-units"
 	"github.com/urfave/cli"
 )
@@ -35,6 +36,20 @@ var (
 		Required: true,
 		EnvVar:   common.PrefixEnvVar(envVarPrefix, "METADATA_TABLE_NAME"),
 	}
+	RelayClientTableNameFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "relay-client-table-name"),
+		Usage:    "Name of the dynamodb table to store relay clients",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "RELAY_CLIENT_TABLE_NAME"),
+		Value:    "relay_clients",
+	}
+	RelayClientUsageTableNameFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "relay-client-usage-table-name"),
+		Usage:    "Name of the dynamodb table to store the usage of relay clients",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "RELAY_CLIENT_USAGE_TABLE_NAME"),
+		Value:    "relay_client_usage",
+	}
 	RelayKeysFlag = cli.IntSliceFlag{
 		Name:     common.PrefixFlag(FlagPrefix, "relay-keys"),
 		Usage:    "Relay keys to use",
@@ -48,6 +63,13 @@ var (
 		EnvVar:   common.PrefixEnvVar(envVarPrefix, "MAX_GRPC_MESSAGE_SIZE"),
 		Value:    4 * units.MiB,
 	}
+	GetBlobStreamPartSizeFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "get-blob-stream-part-size"),
+		Usage:    "Max size of the parts in which GetBlobStream sends a blob, in bytes",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "GET_BLOB_STREAM_PART_SIZE"),
+		Value:    units.MiB,
+	}
 	MetadataCacheSizeFlag = cli.IntFlag{
 		Name:     common.PrefixFlag(FlagPrefix, "metadata-cache-size"),
 		Usage:    "Max number of items in the metadata cache",
@@ -83,6 +105,27 @@ var (
 		EnvVar:   common.PrefixEnvVar(envVarPrefix, "CHUNK_CACHE_BYTES"),
 		Value:    units.GiB,
 	}
+	DiskCacheDirectoryFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "disk-cache-directory"),
+		Usage:    "Directory in which to cache blobs and chunks on disk. Its contents are deleted at startup. If empty, nothing is cached on disk.",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "DISK_CACHE_DIRECTORY"),
+		Value:    "",
+	}
+	BlobDiskCacheBytesFlag = cli.Uint64Flag{
+		Name:     common.PrefixFlag(FlagPrefix, "blob-disk-cache-bytes"),
+		Usage:    "Size of the disk blob cache, in bytes.",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "BLOB_DISK_CACHE_BYTES"),
+		Value:    16 * units.GiB,
+	}
+	ChunkDiskCacheBytesFlag = cli.Uint64Flag{
+		Name:     common.PrefixFlag(FlagPrefix, "chunk-disk-cache-bytes"),
+		Usage:    "Size of the disk chunk cache, in bytes.",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "CHUNK_DISK_CACHE_BYTES"),
+		Value:    64 * units.GiB,
+	}
 	ChunkMaxConcurrencyFlag = cli.IntFlag{
 		Name:     common.PrefixFlag(FlagPrefix, "chunk-max-concurrency"),
 		Usage:    "Max number of concurrent chunk fetches",
@@ -97,6 +140,45 @@ var (
 		EnvVar:   common.PrefixEnvVar(envVarPrefix, "MAX_KEYS_PER_GET_CHUNKS_REQUEST"),
 		Value:    1024,
 	}
+	RangedChunkReadsFlag = cli.BoolFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "ranged-chunk-reads"),
+		Usage:    "Read the chunks requested by range with ranged reads of the chunk store, rather than caching whole blobs",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "RANGED_CHUNK_READS"),
+	}
+	FleetRelaysFlag = cli.StringSliceFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "fleet-relays"),
+		Usage:    "gRPC addresses of all the relays of the fleet this relay is part of, including this one",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "FLEET_RELAYS"),
+	}
+	FleetSelfFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "fleet-self"),
+		Usage:    "Address of this relay, as listed in the fleet relays",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "FLEET_SELF"),
+		Value:    "",
+	}
+	FleetVirtualNodesFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "fleet-virtual-nodes"),
+		Usage:    "Number of points of each relay of the fleet on the consistent hashing ring",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "FLEET_VIRTUAL_NODES"),
+		Value:    128,
+	}
+	FleetSecretFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "fleet-secret"),
+		Usage:    "Secret shared by the relays of the fleet and their fallbacks, which authenticates their peer requests",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "FLEET_SECRET"),
+		Value:    "",
+	}
+	FleetFallbacksFlag = cli.StringSliceFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "fleet-fallbacks"),
+		Usage:    "gRPC addresses of relays asked, in order, for the data this relay fails to read from its stores",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "FLEET_FALLBACKS"),
+	}
 	MaxGetBlobOpsPerSecondFlag = cli.Float64Flag{
 		Name:     common.PrefixFlag(FlagPrefix, "max-get-blob-ops-per-second"),
 		Usage:    "Max number of GetBlob operations per second",
@@ -202,6 +284,13 @@ var (
 		EnvVar:   common.PrefixEnvVar(envVarPrefix, "MAX_CONCURRENT_GET_CHUNK_OPS_CLIENT"),
 		Value:    1,
 	}
+	AccountLimitsFileFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "account-limits-file"),
+		Usage:    "Path of a JSON file with the GetChunks rate limits of individual accounts, reloaded on SIGHUP",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "ACCOUNT_LIMITS_FILE"),
+		Value:    "",
+	}
 	BlsOperatorStateRetrieverAddrFlag = cli.StringFlag{
 		Name:     common.PrefixFlag(FlagPrefix, "bls-operator-state-retriever-addr"),
 		Usage:    "Address of the BLS operator state retriever",
@@ -234,6 +323,47 @@ var (
 		Required: false,
 		EnvVar:   common.PrefixEnvVar(envVarPrefix, "AUTHENTICATION_DISABLED"),
 	}
+	ClientAuthenticationEnabledFlag = cli.BoolFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "client-authentication-enabled"),
+		Usage:    "Require relay clients to authenticate with an API key or ECDSA signature, and enforce their quotas",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "CLIENT_AUTHENTICATION_ENABLED"),
+	}
+	ClientCacheSizeFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "client-cache-size"),
+		Usage:    "Max number of relay clients to cache",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "CLIENT_CACHE_SIZE"),
+		Value:    1024,
+	}
+	ClientCacheTTLFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "client-cache-ttl"),
+		Usage:    "Duration for which relay clients are cached",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "CLIENT_CACHE_TTL"),
+		Value:    time.Minute,
+	}
+	ClientSignatureMaxAgeFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "client-signature-max-age"),
+		Usage:    "Max difference between the timestamp of a request signed by a relay client and the current time",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "CLIENT_SIGNATURE_MAX_AGE"),
+		Value:    time.Minute,
+	}
+	ClientQuotaPeriodFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "client-quota-period"),
+		Usage:    "Length of the periods in which the usage of relay clients is limited by their quotas",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "CLIENT_QUOTA_PERIOD"),
+		Value:    time.Hour,
+	}
+	ClientQuotaFlushIntervalFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "client-quota-flush-interval"),
+		Usage:    "Minimum time between writes of the usage of a relay client to the usage table, 0 to write on every request",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "CLIENT_QUOTA_FLUSH_INTERVAL"),
+		Value:    5 * time.Second,
+	}
 	GetChunksTimeoutFlag = cli.DurationFlag{
 		Name:     common.PrefixFlag(FlagPrefix, "get-chunks-timeout"),
 		Usage:    "Timeout for GetChunks()",
@@ -283,6 +413,27 @@ var (
 		EnvVar:   common.PrefixEnvVar(envVarPrefix, "ONCHAIN_STATE_REFRESH_INTERVAL"),
 		Value:    1 * time.Hour,
 	}
+	CacheWarmingIntervalFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "cache-warming-interval"),
+		Usage:    "Interval at which to poll for newly certified blobs and prefetch them into the blob cache (0 to disable)",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "CACHE_WARMING_INTERVAL"),
+		Value:    0,
+	}
+	CacheWarmingBatchSizeFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "cache-warming-batch-size"),
+		Usage:    "Max number of newly certified blobs read from the metadata store per query when warming the cache",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "CACHE_WARMING_BATCH_SIZE"),
+		Value:    100,
+	}
+	CacheWarmingMaxConcurrencyFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "cache-warming-max-concurrency"),
+		Usage:    "Max number of blobs prefetched concurrently when warming the cache",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "CACHE_WARMING_MAX_CONCURRENCY"),
+		Value:    4,
+	}
 	MetricsPortFlag = cli.IntFlag{
 		Name:     common.PrefixFlag(FlagPrefix, "metrics-port"),
 		Usage:    "Port to listen on for metrics",
@@ -296,6 +447,12 @@ var (
 		Required: true,
 		EnvVar:   common.PrefixEnvVar(envVarPrefix, "ENABLE_METRICS"),
 	}
+	EnableAccessLogFlag = cli.BoolFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "enable-access-log"),
+		Usage:    "Log the client, blob keys, size, latency and cache tier of each GetBlob and GetChunks request",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "ENABLE_ACCESS_LOG"),
+	}
 	EnablePprofFlag = cli.BoolFlag{
 		Name:     common.PrefixFlag(FlagPrefix, "enable-pprof"),
 		Usage:    "Enable pprof profiling",
@@ -323,13 +480,23 @@ var requiredFlags = []cli.Flag{
 
 var optionalFlags = []cli.Flag{
 	MaxGRPCMessageSizeFlag,
+	GetBlobStreamPartSizeFlag,
 	MetadataCacheSizeFlag,
 	MetadataMaxConcurrencyFlag,
 	BlobCacheBytes,
 	BlobMaxConcurrencyFlag,
 	ChunkCacheBytesFlag,
 	ChunkMaxConcurrencyFlag,
+	DiskCacheDirectoryFlag,
+	BlobDiskCacheBytesFlag,
+	ChunkDiskCacheBytesFlag,
 	MaxKeysPerGetChunksRequestFlag,
+	RangedChunkReadsFlag,
+	FleetRelaysFlag,
+	FleetSelfFlag,
+	FleetVirtualNodesFlag,
+	FleetSecretFlag,
+	FleetFallbacksFlag,
 	MaxGetBlobOpsPerSecondFlag,
 	GetBlobOpsBurstinessFlag,
 	MaxGetBlobBytesPerSecondFlag,
@@ -345,9 +512,18 @@ var optionalFlags = []cli.Flag{
 	MaxGetChunkBytesPerSecondClientFlag,
 	GetChunkBytesBurstinessClientFlag,
 	MaxConcurrentGetChunkOpsClientFlag,
+	AccountLimitsFileFlag,
 	AuthenticationKeyCacheSizeFlag,
 	AuthenticationTimeoutFlag,
 	AuthenticationDisabledFlag,
+	ClientAuthenticationEnabledFlag,
+	ClientCacheSizeFlag,
+	ClientCacheTTLFlag,
+	ClientSignatureMaxAgeFlag,
+	ClientQuotaPeriodFlag,
+	ClientQuotaFlushIntervalFlag,
+	RelayClientTableNameFlag,
+	RelayClientUsageTableNameFlag,
 	GetChunksTimeoutFlag,
 	GetBlobTimeoutFlag,
 	InternalGetMetadataTimeoutFlag,
@@ -355,7 +531,11 @@ var optionalFlags = []cli.Flag{
 	InternalGetProofsTimeoutFlag,
 	InternalGetCoefficientsTimeoutFlag,
 	OnchainStateRefreshIntervalFlag,
+	CacheWarmingIntervalFlag,
+	CacheWarmingBatchSizeFlag,
+	CacheWarmingMaxConcurrencyFlag,
 	MetricsPortFlag,
+	EnableAccessLogFlag,
 	EnablePprofFlag,
 	PprofHttpPortFlag,
 }
@@ -366,6 +546,7 @@ func init() {
 	Flags = append(requiredFlags, optionalFlags...)
 	Flags = append(Flags, common.LoggerCLIFlags(envVarPrefix, FlagPrefix)...)
 	Flags = append(Flags, aws.ClientFlags(envVarPrefix, FlagPrefix)...)
+	Flags = append(Flags, blobstore.MetadataStoreFlags(envVarPrefix, FlagPrefix)...)
 	Flags = append(Flags, geth.EthClientFlags(envVarPrefix)...)
 	Flags = append(Flags, thegraph.CLIFlags(envVarPrefix)...)
 }
@@ -8,11 +8,13 @@ import (
 
 	"github.com/Layr-Labs/eigenda/common/geth"
 	coreeth "github.com/Layr-Labs/eigenda/core/eth"
+	"github.com/Layr-Labs/eigenda/core/meterer"
 	"github.com/Layr-Labs/eigenda/core/thegraph"
 	gethcommon "github.com/ethereum/go-ethereum/common"
 
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/common/aws/dynamodb"
+	"github.com/Layr-Labs/eigenda/common/aws/envelope"
 	"github.com/Layr-Labs/eigenda/common/aws/s3"
 	"github.com/Layr-Labs/eigenda/disperser/common/v2/blobstore"
 	"github.com/Layr-Labs/eigenda/relay"
@@ -60,14 +62,23 @@ func RunRelay(ctx *cli.Context) error {
 		return fmt.Errorf("failed to create dynamodb client: %w", err)
 	}
 
-	s3Client, err := s3.NewClient(context.Background(), config.AWS, logger)
+	// The relay only reads from S3, so replication is only used to fall back to the replica region
+	s3Client, err := s3.NewClientWithReplication(context.Background(), config.AWS, logger, nil)
 	if err != nil {
 		return fmt.Errorf("failed to create s3 client: %w", err)
 	}
 
-	metadataStore := blobstore.NewBlobMetadataStore(dynamoClient, logger, config.MetadataTableName)
-	blobStore := blobstore.NewBlobStore(config.BucketName, s3Client, logger)
-	chunkReader := chunkstore.NewChunkReader(logger, s3Client, config.BucketName)
+	encryptor, err := envelope.NewKMSEncryptor(context.Background(), config.AWS)
+	if err != nil {
+		return fmt.Errorf("failed to create encryptor: %w", err)
+	}
+
+	metadataStore, err := blobstore.NewMetadataStore(context.Background(), config.MetadataStoreConfig, dynamoClient, config.MetadataTableName, logger)
+	if err != nil {
+		return fmt.Errorf("failed to create metadata store: %w", err)
+	}
+	blobStore := blobstore.NewBlobStore(config.BucketName, s3Client, encryptor, logger)
+	chunkReader := chunkstore.NewChunkReader(logger, s3Client, config.BucketName, encryptor)
 	client, err := geth.NewMultiHomingClient(config.EthClientConfig, gethcommon.Address{}, logger)
 	if err != nil {
 		return fmt.Errorf("failed to create eth client: %w", err)
@@ -81,6 +92,16 @@ func RunRelay(ctx *cli.Context) error {
 	cs := coreeth.NewChainState(tx, client)
 	ics := thegraph.MakeIndexedChainState(config.ChainStateConfig, cs, logger)
 
+	var clientStore relay.ClientStore
+	if config.RelayConfig.ClientAuthenticationEnabled {
+		relayClientStore, err := meterer.NewRelayClientStore(
+			config.AWS, config.RelayClientTableName, config.RelayClientUsageTableName, logger)
+		if err != nil {
+			return fmt.Errorf("failed to create relay client store: %w", err)
+		}
+		clientStore = &relayClientStore
+	}
+
 	server, err := relay.NewServer(
 		context.Background(),
 		logger,
@@ -90,6 +111,7 @@ func RunRelay(ctx *cli.Context) error {
 		chunkReader,
 		tx,
 		ics,
+		clientStore,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to create relay server: %w", err)
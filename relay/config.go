@@ -4,6 +4,7 @@ import (
 	"time"
 
 	v2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/relay/fleet"
 	"github.com/Layr-Labs/eigenda/relay/limiter"
 )
 
@@ -20,6 +21,9 @@ type Config struct {
 	// MaxGRPCMessageSize is the maximum size of a gRPC message that the server will accept.
 	MaxGRPCMessageSize int
 
+	// GetBlobStreamPartSize is the maximum size of the parts in which GetBlobStream sends a blob, in bytes.
+	GetBlobStreamPartSize int
+
 	// MetadataCacheSize is the maximum number of items in the metadata cache.
 	MetadataCacheSize int
 
@@ -36,6 +40,17 @@ type Config struct {
 	// ChunkCacheBytes is the maximum size of the chunk cache, in bytes.
 	ChunkCacheBytes uint64
 
+	// DiskCacheDirectory is the directory on the local disk in which blobs and chunks are cached, behind the in-memory
+	// blob and chunk caches, so that popular data which doesn't fit in memory isn't repeatedly read from S3. The
+	// contents of the directory are deleted at startup. If empty, nothing is cached on disk.
+	DiskCacheDirectory string
+
+	// BlobDiskCacheBytes is the maximum size of the disk blob cache, in bytes.
+	BlobDiskCacheBytes uint64
+
+	// ChunkDiskCacheBytes is the maximum size of the disk chunk cache, in bytes.
+	ChunkDiskCacheBytes uint64
+
 	// ChunkMaxConcurrency is the size of the work pool for fetching chunks. Note that this does not
 	// impact concurrency utilized by the s3 client to upload/download fragmented files.
 	ChunkMaxConcurrency int
@@ -43,9 +58,21 @@ type Config struct {
 	// MaxKeysPerGetChunksRequest is the maximum number of keys that can be requested in a single GetChunks request.
 	MaxKeysPerGetChunksRequest int
 
+	// RangedChunkReads enables ranged reads of the chunk store. If true, the chunks of a blob which are requested by
+	// range only are read with a single ranged read of the chunk store, unless the blob is already in the chunk cache,
+	// rather than reading and caching all the chunks of the blob. This suits relays serving retrieval clients, which
+	// read a few chunks of many blobs, rather than validators, which between them read all the chunks of each blob.
+	RangedChunkReads bool
+
 	// RateLimits contains configuration for rate limiting.
 	RateLimits limiter.Config
 
+	// Fleet is the configuration of the fleet of relays this relay is part of. Relays of a fleet split the blobs
+	// between them with consistent hashing, and forward the data requests for blobs owned by a peer to it, so that
+	// each blob is cached by a single relay. If no relays are listed, the relay isn't part of a fleet. It also lists
+	// the fallback relays, which are asked for the data this relay fails to read from its stores.
+	Fleet fleet.Config
+
 	// AuthenticationKeyCacheSize is the maximum number of operator public keys that can be cached.
 	AuthenticationKeyCacheSize int
 
@@ -57,18 +84,49 @@ type Config struct {
 	// AuthenticationDisabled will disable authentication if set to true.
 	AuthenticationDisabled bool
 
+	// ClientAuthenticationEnabled enables the authentication of relay clients, which are registered in the client
+	// store with an API key or an ECDSA key, and whose usage is limited by per-client quotas. If true, GetBlob requests,
+	// and GetChunks requests which aren't made by a validator, are rejected unless they are made by a relay client.
+	ClientAuthenticationEnabled bool
+
+	// ClientCacheSize is the maximum number of relay clients that can be cached.
+	ClientCacheSize int
+
+	// ClientCacheTTL is the duration for which relay clients are cached, after which changes to a client in the
+	// client store take effect.
+	ClientCacheTTL time.Duration
+
+	// ClientSignatureMaxAge is the maximum difference between the timestamp of a request signed by a relay client and
+	// the current time.
+	ClientSignatureMaxAge time.Duration
+
 	// Timeouts contains configuration for relay timeouts.
 	Timeouts TimeoutConfig
 
 	// OnchainStateRefreshInterval is the interval at which the onchain state is refreshed.
 	OnchainStateRefreshInterval time.Duration
 
+	// CacheWarmingInterval is the interval at which the relay polls the metadata store for newly certified blobs, and
+	// prefetches the ones it serves into the blob cache, so that the first retrieval of a fresh blob is a cache hit.
+	// If zero, the cache isn't warmed.
+	CacheWarmingInterval time.Duration
+
+	// CacheWarmingBatchSize is the maximum number of blobs read from the metadata store per query by the cache warmer.
+	CacheWarmingBatchSize int32
+
+	// CacheWarmingMaxConcurrency is the maximum number of blobs prefetched concurrently by the cache warmer.
+	CacheWarmingMaxConcurrency int
+
 	// MetricsPort is the port that the relay metrics server listens on.
 	MetricsPort int
 
 	// EnableMetrics enables the metrics HTTP server for prometheus metrics collection
 	EnableMetrics bool
 
+	// EnableAccessLog enables the access log, which records the client, blob keys, size, latency and cache tier of
+	// each data request served by the relay.
+	EnableAccessLog bool
+
 	// EnablePprof enables the pprof HTTP server for profiling
 	EnablePprof bool
 
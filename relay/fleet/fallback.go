@@ -0,0 +1,101 @@
+package fleet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	pb "github.com/Layr-Labs/eigenda/api/grpc/relay"
+	v2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/hashicorp/go-multierror"
+	"google.golang.org/grpc"
+)
+
+// Fallback fetches the data a relay fails to read from its stores from other relays, so that blobs remain retrievable
+// during partial outages of the stores. The requests sent to the fallback relays are peer requests, which they serve
+// from their own caches and stores, without falling back further. This object is thread safe.
+type Fallback struct {
+	secret []byte
+
+	// relays are the addresses of the fallback relays, in the order in which they are tried.
+	relays []string
+
+	// clients are the clients of the fallback relays, in the same order as relays.
+	clients []pb.RelayClient
+
+	// connections are the connections to the fallback relays.
+	connections []*grpc.ClientConn
+}
+
+// NewFallback creates a new Fallback, which tries the relays in order. Connections to the relays are established
+// lazily. Replies larger than maxGRPCMessageSize are refused.
+func NewFallback(relays []string, secret string, maxGRPCMessageSize int) (*Fallback, error) {
+	if len(relays) == 0 {
+		return nil, errors.New("no fallback relays")
+	}
+	if secret == "" {
+		return nil, errors.New("fleet secret is required to fall back to other relays")
+	}
+
+	fallback := &Fallback{
+		secret: []byte(secret),
+		relays: relays,
+	}
+	for _, relay := range relays {
+		conn, err := dial(relay, maxGRPCMessageSize)
+		if err != nil {
+			_ = fallback.Close()
+			return nil, fmt.Errorf("failed to create client for relay %s: %w", relay, err)
+		}
+		fallback.connections = append(fallback.connections, conn)
+		fallback.clients = append(fallback.clients, pb.NewRelayClient(conn))
+	}
+
+	return fallback, nil
+}
+
+// GetBlob fetches a blob from the first fallback relay which serves it, and returns the address of that relay.
+func (f *Fallback) GetBlob(ctx context.Context, key v2.BlobKey) ([]byte, string, error) {
+	var errList *multierror.Error
+	for i, client := range f.clients {
+		reply, err := client.GetBlob(peerContext(ctx, f.secret), &pb.GetBlobRequest{BlobKey: key[:]})
+		if err == nil {
+			return reply.GetBlob(), f.relays[i], nil
+		}
+		errList = multierror.Append(errList, fmt.Errorf("relay %s: %w", f.relays[i], err))
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	return nil, "", fmt.Errorf("failed to get blob %s from the fallback relays: %w", key.Hex(), errList)
+}
+
+// GetChunks fetches chunks from the first fallback relay which serves all of them, and returns the address of that
+// relay. The reply holds the data of each chunk request, in order.
+func (f *Fallback) GetChunks(ctx context.Context, chunkRequests []*pb.ChunkRequest) ([][]byte, string, error) {
+	var errList *multierror.Error
+	for i, client := range f.clients {
+		reply, err := client.GetChunks(peerContext(ctx, f.secret), &pb.GetChunksRequest{ChunkRequests: chunkRequests})
+		if err == nil && len(reply.GetData()) != len(chunkRequests) {
+			err = fmt.Errorf("returned data for %d chunk requests, expected %d",
+				len(reply.GetData()), len(chunkRequests))
+		}
+		if err == nil {
+			return reply.GetData(), f.relays[i], nil
+		}
+		errList = multierror.Append(errList, fmt.Errorf("relay %s: %w", f.relays[i], err))
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	return nil, "", fmt.Errorf("failed to get chunks from the fallback relays: %w", errList)
+}
+
+// Close closes the connections to the fallback relays.
+func (f *Fallback) Close() error {
+	var errList *multierror.Error
+	for _, conn := range f.connections {
+		errList = multierror.Append(errList, conn.Close())
+	}
+	return errList.ErrorOrNil()
+}
@@ -0,0 +1,185 @@
+package fleet
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"slices"
+
+	pb "github.com/Layr-Labs/eigenda/api/grpc/relay"
+	v2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/hashicorp/go-multierror"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+)
+
+// SecretHeader is the gRPC metadata key with which relays prove to their peers that a request is forwarded by a
+// relay of the fleet.
+const SecretHeader = "relay-fleet-secret"
+
+// Config is the configuration of a relay fleet.
+type Config struct {
+	// Relays are the gRPC addresses of all the relays of the fleet, including this one. Every relay of the fleet must
+	// be configured with the same relays, so that they agree on the owner of each blob.
+	Relays []string
+
+	// Self is the address of this relay, as listed in Relays.
+	Self string
+
+	// VirtualNodes is the number of points of each relay on the hash ring.
+	VirtualNodes int
+
+	// Secret is shared by the relays of the fleet, and authenticates the requests they forward to each other. The
+	// fallback relays of a relay share it too.
+	Secret string
+
+	// Fallbacks are the gRPC addresses of relays, e.g. in another region, which are asked for the data this relay
+	// fails to read from its stores, in order, before failing the request. They must serve the same blobs as this
+	// relay, and be configured with the same Secret. If empty, the relay doesn't fall back to other relays.
+	Fallbacks []string
+}
+
+// Enabled returns true if the relay is part of a fleet.
+func (c *Config) Enabled() bool {
+	return len(c.Relays) > 0
+}
+
+// Fleet lets a relay forward the data requests for blobs owned by its peers to them, so that each blob is cached by a
+// single relay of the fleet, and the capacity of the caches of the fleet grows with its size. This object is thread
+// safe.
+type Fleet struct {
+	self   string
+	secret []byte
+	ring   *Ring
+
+	// peers are the clients of the other relays of the fleet, by address.
+	peers map[string]pb.RelayClient
+
+	// connections are the connections to the other relays of the fleet.
+	connections []*grpc.ClientConn
+}
+
+// NewFleet creates a new Fleet. Connections to the peers are established lazily. Replies of the peers larger than
+// maxGRPCMessageSize are refused.
+func NewFleet(config *Config, maxGRPCMessageSize int) (*Fleet, error) {
+	if config.Secret == "" {
+		return nil, errors.New("fleet secret is required")
+	}
+	if !slices.Contains(config.Relays, config.Self) {
+		return nil, fmt.Errorf("relay %s isn't part of the fleet", config.Self)
+	}
+
+	ring, err := NewRing(config.Relays, config.VirtualNodes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create hash ring: %w", err)
+	}
+
+	fleet := &Fleet{
+		self:   config.Self,
+		secret: []byte(config.Secret),
+		ring:   ring,
+		peers:  make(map[string]pb.RelayClient),
+	}
+	for _, relay := range config.Relays {
+		if relay == config.Self {
+			continue
+		}
+		conn, err := dial(relay, maxGRPCMessageSize)
+		if err != nil {
+			_ = fleet.Close()
+			return nil, fmt.Errorf("failed to create client for relay %s: %w", relay, err)
+		}
+		fleet.connections = append(fleet.connections, conn)
+		fleet.peers[relay] = pb.NewRelayClient(conn)
+	}
+
+	return fleet, nil
+}
+
+// Owner returns the relay which owns a blob, and whether it's this relay.
+func (f *Fleet) Owner(key v2.BlobKey) (string, bool) {
+	owner := f.ring.Owner(key[:])
+	return owner, owner == f.self
+}
+
+// IsPeerRequest returns true if the request of the context was made by a relay which knows the secret, i.e. forwarded
+// by a relay of the fleet, or sent by a relay falling back to this one. Peer requests must be served locally, and
+// aren't authenticated or rate limited, since the relay which sent them already did so.
+func IsPeerRequest(ctx context.Context, secret string) bool {
+	if secret == "" {
+		return false
+	}
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return false
+	}
+	values := md.Get(SecretHeader)
+	if len(values) != 1 {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(values[0]), []byte(secret)) == 1
+}
+
+// GetBlob fetches a blob from the peer which owns it.
+func (f *Fleet) GetBlob(ctx context.Context, peer string, key v2.BlobKey) ([]byte, error) {
+	client, err := f.peer(peer)
+	if err != nil {
+		return nil, err
+	}
+
+	reply, err := client.GetBlob(peerContext(ctx, f.secret), &pb.GetBlobRequest{BlobKey: key[:]})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blob %s from relay %s: %w", key.Hex(), peer, err)
+	}
+	return reply.GetBlob(), nil
+}
+
+// GetChunks fetches chunks of blobs owned by a peer from it. The reply holds the data of each chunk request, in order.
+func (f *Fleet) GetChunks(ctx context.Context, peer string, chunkRequests []*pb.ChunkRequest) ([][]byte, error) {
+	client, err := f.peer(peer)
+	if err != nil {
+		return nil, err
+	}
+
+	reply, err := client.GetChunks(peerContext(ctx, f.secret), &pb.GetChunksRequest{ChunkRequests: chunkRequests})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chunks from relay %s: %w", peer, err)
+	}
+	if len(reply.GetData()) != len(chunkRequests) {
+		return nil, fmt.Errorf("relay %s returned data for %d chunk requests, expected %d",
+			peer, len(reply.GetData()), len(chunkRequests))
+	}
+	return reply.GetData(), nil
+}
+
+// Close closes the connections to the peers.
+func (f *Fleet) Close() error {
+	var errList *multierror.Error
+	for _, conn := range f.connections {
+		errList = multierror.Append(errList, conn.Close())
+	}
+	return errList.ErrorOrNil()
+}
+
+func (f *Fleet) peer(address string) (pb.RelayClient, error) {
+	client, ok := f.peers[address]
+	if !ok {
+		return nil, fmt.Errorf("relay %s isn't a peer of this relay", address)
+	}
+	return client, nil
+}
+
+// dial creates a client connection to another relay. Replies larger than maxGRPCMessageSize are refused.
+func dial(relay string, maxGRPCMessageSize int) (*grpc.ClientConn, error) {
+	return grpc.NewClient(
+		relay,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(maxGRPCMessageSize)))
+}
+
+// peerContext returns the context of a request sent to another relay, which proves that it's a peer request.
+func peerContext(ctx context.Context, secret []byte) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, SecretHeader, string(secret))
+}
@@ -0,0 +1,84 @@
+package fleet
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+// Ring assigns blob keys to the relays of a fleet with consistent hashing. Each relay is placed at several points of a
+// hash ring, and a key is owned by the relay at the first point following the hash of the key. When a relay joins or
+// leaves the fleet, only the keys next to its points change owner, so the caches of the other relays stay warm.
+// This object is immutable, and thus thread safe.
+type Ring struct {
+	// points are the sorted positions of the relays on the ring.
+	points []uint64
+
+	// owners are the relays at each of the points.
+	owners map[uint64]string
+}
+
+// NewRing creates a ring of the given relays, each placed at virtualNodes points. The more points, the more evenly
+// the keys are spread between the relays.
+func NewRing(relays []string, virtualNodes int) (*Ring, error) {
+	if len(relays) == 0 {
+		return nil, errors.New("a fleet must have at least one relay")
+	}
+	if virtualNodes <= 0 {
+		return nil, fmt.Errorf("virtual nodes must be positive, got %d", virtualNodes)
+	}
+
+	points := make([]uint64, 0, len(relays)*virtualNodes)
+	owners := make(map[uint64]string, len(relays)*virtualNodes)
+	for _, relay := range relays {
+		if relay == "" {
+			return nil, errors.New("relay address must not be empty")
+		}
+		for i := 0; i < virtualNodes; i++ {
+			point := hash([]byte(relay + "#" + strconv.Itoa(i)))
+			if owner, ok := owners[point]; ok {
+				if owner == relay {
+					return nil, fmt.Errorf("relay %s is listed more than once", relay)
+				}
+				// Hash collisions between relays are astronomically unlikely, but the owner of a point must not
+				// depend on the order of the relays.
+				if owner < relay {
+					continue
+				}
+			} else {
+				points = append(points, point)
+			}
+			owners[point] = relay
+		}
+	}
+	sort.Slice(points, func(i, j int) bool {
+		return points[i] < points[j]
+	})
+
+	return &Ring{
+		points: points,
+		owners: owners,
+	}, nil
+}
+
+// Owner returns the relay which owns the given key.
+func (r *Ring) Owner(key []byte) string {
+	keyHash := hash(key)
+	index := sort.Search(len(r.points), func(i int) bool {
+		return r.points[i] >= keyHash
+	})
+	if index == len(r.points) {
+		// wrap around the ring
+		index = 0
+	}
+	return r.owners[r.points[index]]
+}
+
+// hash maps data to a position on the ring.
+func hash(data []byte) uint64 {
+	digest := sha256.Sum256(data)
+	return binary.BigEndian.Uint64(digest[:8])
+}
@@ -0,0 +1,84 @@
+package fleet
+
+import (
+	"fmt"
+	"testing"
+
+	tu "github.com/Layr-Labs/eigenda/common/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func relayAddresses(count int) []string {
+	relays := make([]string, count)
+	for i := 0; i < count; i++ {
+		relays[i] = fmt.Sprintf("relay-%d:32011", i)
+	}
+	return relays
+}
+
+func TestRingOwnership(t *testing.T) {
+	tu.InitializeRandom()
+
+	relays := relayAddresses(4)
+	ring, err := NewRing(relays, 128)
+	require.NoError(t, err)
+
+	// the owner of a key doesn't depend on the order of the relays
+	reversed := make([]string, len(relays))
+	for i, relay := range relays {
+		reversed[len(relays)-1-i] = relay
+	}
+	reversedRing, err := NewRing(reversed, 128)
+	require.NoError(t, err)
+
+	keyCount := 10_000
+	keys := make([][]byte, keyCount)
+	counts := make(map[string]int)
+	for i := 0; i < keyCount; i++ {
+		keys[i] = tu.RandomBytes(32)
+		owner := ring.Owner(keys[i])
+		require.Equal(t, owner, reversedRing.Owner(keys[i]))
+		counts[owner]++
+	}
+
+	// the keys are spread roughly evenly between the relays
+	require.Len(t, counts, len(relays))
+	for _, count := range counts {
+		require.Greater(t, count, keyCount/len(relays)/2)
+		require.Less(t, count, keyCount/len(relays)*2)
+	}
+
+	// when a relay joins the fleet, keys only move to the new relay
+	grownRing, err := NewRing(relayAddresses(5), 128)
+	require.NoError(t, err)
+	moved := 0
+	for _, key := range keys {
+		owner := grownRing.Owner(key)
+		if owner != ring.Owner(key) {
+			require.Equal(t, "relay-4:32011", owner)
+			moved++
+		}
+	}
+	require.Greater(t, moved, 0)
+	require.Less(t, moved, keyCount/2)
+}
+
+func TestInvalidRing(t *testing.T) {
+	_, err := NewRing(nil, 128)
+	require.Error(t, err)
+
+	_, err = NewRing(relayAddresses(2), 0)
+	require.Error(t, err)
+
+	_, err = NewRing([]string{"relay-0:32011", "relay-0:32011"}, 16)
+	require.Error(t, err)
+
+	_, err = NewRing([]string{""}, 16)
+	require.Error(t, err)
+
+	ring, err := NewRing(relayAddresses(1), 1)
+	require.NoError(t, err)
+	for i := 0; i < 100; i++ {
+		require.Equal(t, "relay-0:32011", ring.Owner(tu.RandomBytes(32)))
+	}
+}
@@ -0,0 +1,75 @@
+package limiter
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// AccountLimits are the rate limits of the GetChunks requests of an account. The account of a request is the hex
+// encoded operator ID of the validator which made it, or the ID of the relay client which made it.
+type AccountLimits struct {
+	// MaxGetChunkOpsPerSecond is the maximum permitted number of GetChunk operations per second.
+	MaxGetChunkOpsPerSecond float64 `json:"max_get_chunk_ops_per_second"`
+	// GetChunkOpsBurstiness is the burstiness of the MaxGetChunkOpsPerSecond rate limiter.
+	GetChunkOpsBurstiness int `json:"get_chunk_ops_burstiness"`
+	// MaxGetChunkBytesPerSecond is the maximum bandwidth, in bytes, that GetChunk operations are permitted to consume
+	// per second.
+	MaxGetChunkBytesPerSecond float64 `json:"max_get_chunk_bytes_per_second"`
+	// GetChunkBytesBurstiness is the burstiness of the MaxGetChunkBytesPerSecond rate limiter.
+	GetChunkBytesBurstiness int `json:"get_chunk_bytes_burstiness"`
+	// MaxConcurrentGetChunkOps is the maximum number of concurrent GetChunk operations that are permitted.
+	MaxConcurrentGetChunkOps int `json:"max_concurrent_get_chunk_ops"`
+}
+
+// validate checks that the limits of the named account can be enforced.
+func (l *AccountLimits) validate(name string) error {
+	if l.MaxGetChunkOpsPerSecond <= 0 || l.GetChunkOpsBurstiness <= 0 {
+		return fmt.Errorf("GetChunk op rate limit of %s must be positive", name)
+	}
+	if l.MaxGetChunkBytesPerSecond <= 0 || l.GetChunkBytesBurstiness <= 0 {
+		return fmt.Errorf("GetChunk bandwidth limit of %s must be positive", name)
+	}
+	if l.MaxConcurrentGetChunkOps <= 0 {
+		return fmt.Errorf("GetChunk concurrency limit of %s must be positive", name)
+	}
+	return nil
+}
+
+// DefaultAccountLimits returns the limits of the accounts without limits of their own, unless the account limits file
+// sets a default tier.
+func (c *Config) DefaultAccountLimits() AccountLimits {
+	return AccountLimits{
+		MaxGetChunkOpsPerSecond:   c.MaxGetChunkOpsPerSecondClient,
+		GetChunkOpsBurstiness:     c.GetChunkOpsBurstinessClient,
+		MaxGetChunkBytesPerSecond: c.MaxGetChunkBytesPerSecondClient,
+		GetChunkBytesBurstiness:   c.GetChunkBytesBurstinessClient,
+		MaxConcurrentGetChunkOps:  c.MaxConcurrentGetChunkOpsClient,
+	}
+}
+
+// AccountLimitsConfig is the content of the account limits file, which can be reloaded while the relay is running.
+type AccountLimitsConfig struct {
+	// Default is the default tier, which applies to the accounts missing from Accounts. If nil, the default tier is
+	// configured by the per-client rate limit flags.
+	Default *AccountLimits `json:"default,omitempty"`
+	// Accounts are the limits of individual accounts, by account ID.
+	Accounts map[string]AccountLimits `json:"accounts,omitempty"`
+}
+
+// ReadAccountLimits reads the account limits file at the given path. Unknown fields are refused, so that typos aren't
+// silently ignored.
+func ReadAccountLimits(path string) (*AccountLimitsConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read account limits file %s: %w", path, err)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	limits := &AccountLimitsConfig{}
+	if err := decoder.Decode(limits); err != nil {
+		return nil, fmt.Errorf("failed to parse account limits file %s: %w", path, err)
+	}
+	return limits, nil
+}
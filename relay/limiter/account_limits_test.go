@@ -0,0 +1,50 @@
+package limiter
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadAccountLimits(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "limits.json")
+
+	err := os.WriteFile(path, []byte(`{
+		"default": {
+			"max_get_chunk_ops_per_second": 4,
+			"get_chunk_ops_burstiness": 8,
+			"max_get_chunk_bytes_per_second": 1048576,
+			"get_chunk_bytes_burstiness": 2097152,
+			"max_concurrent_get_chunk_ops": 1
+		},
+		"accounts": {
+			"client": {
+				"max_get_chunk_ops_per_second": 100,
+				"get_chunk_ops_burstiness": 100,
+				"max_get_chunk_bytes_per_second": 104857600,
+				"get_chunk_bytes_burstiness": 104857600,
+				"max_concurrent_get_chunk_ops": 16
+			}
+		}
+	}`), 0644)
+	require.NoError(t, err)
+
+	limits, err := ReadAccountLimits(path)
+	require.NoError(t, err)
+	require.Equal(t, &AccountLimits{
+		MaxGetChunkOpsPerSecond:   4,
+		GetChunkOpsBurstiness:     8,
+		MaxGetChunkBytesPerSecond: 1048576,
+		GetChunkBytesBurstiness:   2097152,
+		MaxConcurrentGetChunkOps:  1,
+	}, limits.Default)
+	require.Equal(t, 16, limits.Accounts["client"].MaxConcurrentGetChunkOps)
+
+	// unknown fields are refused
+	err = os.WriteFile(path, []byte(`{"accounts": {"client": {"max_get_chunk_ops": 1}}}`), 0644)
+	require.NoError(t, err)
+	_, err = ReadAccountLimits(path)
+	require.Error(t, err)
+}
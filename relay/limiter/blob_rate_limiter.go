@@ -51,7 +51,7 @@ func NewBlobRateLimiter(config *Config, relayMetrics *metrics.RelayMetrics) *Blo
 
 // BeginGetBlobOperation should be called when a GetBlob operation is about to begin. If it returns an error,
 // the operation should not be performed. If it does not return an error, FinishGetBlobOperation should be
-// called when the operation completes.
+// called when the operation completes. Errors are of type *RateLimitError.
 func (l *BlobRateLimiter) BeginGetBlobOperation(now time.Time) error {
 	if l == nil {
 		// If the rate limiter is nil, do not enforce rate limits.
@@ -62,18 +62,14 @@ func (l *BlobRateLimiter) BeginGetBlobOperation(now time.Time) error {
 	defer l.lock.Unlock()
 
 	if l.operationsInFlight >= l.config.MaxConcurrentGetBlobOps {
-		if l.relayMetrics != nil {
-			l.relayMetrics.ReportBlobRateLimited("global concurrency")
-		}
-		return fmt.Errorf("global concurrent request limit %d exceeded for getBlob operations, try again later",
-			l.config.MaxConcurrentGetBlobOps)
+		return l.rateLimited("global concurrency", 0, fmt.Sprintf(
+			"global concurrent request limit %d exceeded for getBlob operations, try again later",
+			l.config.MaxConcurrentGetBlobOps))
 	}
 	if l.opLimiter.TokensAt(now) < 1 {
-		if l.relayMetrics != nil {
-			l.relayMetrics.ReportBlobRateLimited("global rate")
-		}
-		return fmt.Errorf("global rate limit %0.1fhz exceeded for getBlob operations, try again later",
-			l.config.MaxGetBlobOpsPerSecond)
+		return l.rateLimited("global rate", retryAfter(l.opLimiter, now, 1), fmt.Sprintf(
+			"global rate limit %0.1fhz exceeded for getBlob operations, try again later",
+			l.config.MaxGetBlobOpsPerSecond))
 	}
 
 	l.operationsInFlight++
@@ -98,7 +94,7 @@ func (l *BlobRateLimiter) FinishGetBlobOperation() {
 
 // RequestGetBlobBandwidth should be called when a GetBlob is about to start downloading blob data
 // from S3. It returns an error if there is insufficient bandwidth available. If it returns nil, the
-// operation should proceed.
+// operation should proceed. Errors are of type *RateLimitError.
 func (l *BlobRateLimiter) RequestGetBlobBandwidth(now time.Time, bytes uint32) error {
 	if l == nil {
 		// If the rate limiter is nil, do not enforce rate limits.
@@ -109,16 +105,24 @@ func (l *BlobRateLimiter) RequestGetBlobBandwidth(now time.Time, bytes uint32) e
 
 	allowed := l.bandwidthLimiter.AllowN(now, int(bytes))
 	if !allowed {
-		if l.relayMetrics != nil {
-			l.relayMetrics.ReportBlobRateLimited("global bandwidth")
-		}
-
 		rateLimit := l.config.MaxGetBlobBytesPerSecond / 1024 / 1024
 		burstiness := l.config.GetBlobBytesBurstiness / 1024 / 1024
 
-		return fmt.Errorf(
+		return l.rateLimited("global bandwidth", retryAfter(l.bandwidthLimiter, now, int(bytes)), fmt.Sprintf(
 			"global rate limit %0.1fMiB/s (burstiness %dMiB) exceeded for getBlob bandwidth, try again later",
-			rateLimit, burstiness)
+			rateLimit, burstiness))
 	}
 	return nil
 }
+
+// rateLimited reports that an operation was rate limited, and returns the error for it.
+func (l *BlobRateLimiter) rateLimited(scope string, retryAfter time.Duration, msg string) error {
+	if l.relayMetrics != nil {
+		l.relayMetrics.ReportBlobRateLimited(scope)
+	}
+	return &RateLimitError{
+		Scope:      scope,
+		RetryAfter: retryAfter,
+		msg:        msg,
+	}
+}
@@ -28,6 +28,12 @@ type ChunkRateLimiter struct {
 
 	// per-client limiters
 
+	// defaultLimits are the limits of the clients without limits of their own.
+	defaultLimits AccountLimits
+
+	// accountLimits are the limits of individual clients, by client ID.
+	accountLimits map[string]AccountLimits
+
 	// perClientOpLimiter enforces per-client rate limits on the maximum rate of GetChunk operations
 	perClientOpLimiter map[string]*rate.Limiter
 
@@ -62,6 +68,8 @@ func NewChunkRateLimiter(
 		config:                      config,
 		globalOpLimiter:             globalOpLimiter,
 		globalBandwidthLimiter:      globalBandwidthLimiter,
+		defaultLimits:               config.DefaultAccountLimits(),
+		accountLimits:               make(map[string]AccountLimits),
 		perClientOpLimiter:          make(map[string]*rate.Limiter),
 		perClientBandwidthLimiter:   make(map[string]*rate.Limiter),
 		perClientOperationsInFlight: make(map[string]int),
@@ -69,9 +77,54 @@ func NewChunkRateLimiter(
 	}
 }
 
+// SetAccountLimits replaces the per-client limits. The new limits apply immediately to all clients, including those
+// with operations in flight. Nothing is changed if the limits are invalid.
+func (l *ChunkRateLimiter) SetAccountLimits(now time.Time, limits *AccountLimitsConfig) error {
+	defaultLimits := l.config.DefaultAccountLimits()
+	if limits.Default != nil {
+		defaultLimits = *limits.Default
+	}
+	if err := defaultLimits.validate("the default tier"); err != nil {
+		return err
+	}
+	accountLimits := make(map[string]AccountLimits, len(limits.Accounts))
+	for account, accountLimit := range limits.Accounts {
+		if err := accountLimit.validate(fmt.Sprintf("account %s", account)); err != nil {
+			return err
+		}
+		accountLimits[account] = accountLimit
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	l.defaultLimits = defaultLimits
+	l.accountLimits = accountLimits
+
+	for requesterID, opLimiter := range l.perClientOpLimiter {
+		clientLimits := l.limitsOf(requesterID)
+		opLimiter.SetLimitAt(now, rate.Limit(clientLimits.MaxGetChunkOpsPerSecond))
+		opLimiter.SetBurstAt(now, clientLimits.GetChunkOpsBurstiness)
+
+		bandwidthLimiter := l.perClientBandwidthLimiter[requesterID]
+		bandwidthLimiter.SetLimitAt(now, rate.Limit(clientLimits.MaxGetChunkBytesPerSecond))
+		bandwidthLimiter.SetBurstAt(now, clientLimits.GetChunkBytesBurstiness)
+	}
+
+	return nil
+}
+
+// limitsOf returns the limits of a client. The caller must hold the lock.
+func (l *ChunkRateLimiter) limitsOf(requesterID string) AccountLimits {
+	if limits, ok := l.accountLimits[requesterID]; ok {
+		return limits
+	}
+	return l.defaultLimits
+}
+
 // BeginGetChunkOperation should be called when a GetChunk operation is about to begin. If it returns an error,
 // the operation should not be performed. If it does not return an error, FinishGetChunkOperation should be
-// called when the operation completes.
+// called when the operation completes. Errors are of type *RateLimitError.
 func (l *ChunkRateLimiter) BeginGetChunkOperation(
 	now time.Time,
 	requesterID string) error {
@@ -83,54 +136,48 @@ func (l *ChunkRateLimiter) BeginGetChunkOperation(
 	l.lock.Lock()
 	defer l.lock.Unlock()
 
+	clientLimits := l.limitsOf(requesterID)
+
 	_, ok := l.perClientOperationsInFlight[requesterID]
 	if !ok {
 		// This is the first time we've seen this client ID.
 		l.perClientOperationsInFlight[requesterID] = 0
 
 		l.perClientOpLimiter[requesterID] = rate.NewLimiter(
-			rate.Limit(l.config.MaxGetChunkOpsPerSecondClient),
-			l.config.GetChunkOpsBurstinessClient)
+			rate.Limit(clientLimits.MaxGetChunkOpsPerSecond),
+			clientLimits.GetChunkOpsBurstiness)
 
 		l.perClientBandwidthLimiter[requesterID] = rate.NewLimiter(
-			rate.Limit(l.config.MaxGetChunkBytesPerSecondClient),
-			l.config.GetChunkBytesBurstinessClient)
+			rate.Limit(clientLimits.MaxGetChunkBytesPerSecond),
+			clientLimits.GetChunkBytesBurstiness)
 	}
 
 	if l.globalOperationsInFlight >= l.config.MaxConcurrentGetChunkOps {
-		if l.relayMetrics != nil {
-			l.relayMetrics.ReportChunkRateLimited("global concurrency")
-		}
-		return fmt.Errorf(
+		return l.rateLimited("global concurrency", 0, fmt.Sprintf(
 			"global concurrent request limit %d exceeded for GetChunks operations, try again later",
-			l.config.MaxConcurrentGetChunkOps)
+			l.config.MaxConcurrentGetChunkOps))
 	}
 	if l.globalOpLimiter.TokensAt(now) < 1 {
-		if l.relayMetrics != nil {
-			l.relayMetrics.ReportChunkRateLimited("global rate")
-		}
-		return fmt.Errorf("global rate limit %0.1fhz exceeded for GetChunks operations, try again later",
-			l.config.MaxGetChunkOpsPerSecond)
+		return l.rateLimited("global rate", retryAfter(l.globalOpLimiter, now, 1), fmt.Sprintf(
+			"global rate limit %0.1fhz exceeded for GetChunks operations, try again later",
+			l.config.MaxGetChunkOpsPerSecond))
 	}
-	if l.perClientOperationsInFlight[requesterID] >= l.config.MaxConcurrentGetChunkOpsClient {
-		if l.relayMetrics != nil {
-			l.relayMetrics.ReportChunkRateLimited("client concurrency")
-		}
-		return fmt.Errorf("client concurrent request limit %d exceeded for GetChunks",
-			l.config.MaxConcurrentGetChunkOpsClient)
+	if l.perClientOperationsInFlight[requesterID] >= clientLimits.MaxConcurrentGetChunkOps {
+		return l.rateLimited("client concurrency", 0, fmt.Sprintf(
+			"client concurrent request limit %d exceeded for GetChunks",
+			clientLimits.MaxConcurrentGetChunkOps))
 	}
-	if l.perClientOpLimiter[requesterID].TokensAt(now) < 1 {
-		if l.relayMetrics != nil {
-			l.relayMetrics.ReportChunkRateLimited("client rate")
-		}
-		return fmt.Errorf("client rate limit %0.1fhz exceeded for GetChunks, try again later",
-			l.config.MaxGetChunkOpsPerSecondClient)
+	clientOpLimiter := l.perClientOpLimiter[requesterID]
+	if clientOpLimiter.TokensAt(now) < 1 {
+		return l.rateLimited("client rate", retryAfter(clientOpLimiter, now, 1), fmt.Sprintf(
+			"client rate limit %0.1fhz exceeded for GetChunks, try again later",
+			clientLimits.MaxGetChunkOpsPerSecond))
 	}
 
 	l.globalOperationsInFlight++
 	l.perClientOperationsInFlight[requesterID]++
 	l.globalOpLimiter.AllowN(now, 1)
-	l.perClientOpLimiter[requesterID].AllowN(now, 1)
+	clientOpLimiter.AllowN(now, 1)
 
 	return nil
 }
@@ -148,47 +195,56 @@ func (l *ChunkRateLimiter) FinishGetChunkOperation(requesterID string) {
 	l.perClientOperationsInFlight[requesterID]--
 }
 
-// RequestGetChunkBandwidth should be called when a GetChunk is about to start downloading chunk data.
+// RequestGetChunkBandwidth should be called when a GetChunk is about to start downloading chunk data. Errors caused
+// by rate limits are of type *RateLimitError.
 func (l *ChunkRateLimiter) RequestGetChunkBandwidth(now time.Time, requesterID string, bytes uint32) error {
 	if l == nil {
 		// If the rate limiter is nil, do not enforce rate limits.
 		return nil
 	}
 
-	// no lock needed here, as the bandwidth limiters themselves are thread-safe
+	// the lock only guards the lookup of the client's limiter, the bandwidth limiters themselves are thread-safe
+	l.lock.Lock()
+	limiter, ok := l.perClientBandwidthLimiter[requesterID]
+	clientLimits := l.limitsOf(requesterID)
+	l.lock.Unlock()
 
 	allowed := l.globalBandwidthLimiter.AllowN(now, int(bytes))
 	if !allowed {
-		if l.relayMetrics != nil {
-			l.relayMetrics.ReportChunkRateLimited("global bandwidth")
-		}
-
 		rateLimit := l.config.MaxGetChunkBytesPerSecond / 1024 / 1024
 		burstiness := l.config.GetChunkBytesBurstiness / 1024 / 1024
 
-		return fmt.Errorf(
+		return l.rateLimited("global bandwidth", retryAfter(l.globalBandwidthLimiter, now, int(bytes)), fmt.Sprintf(
 			"global rate limit %0.1fMiB (burstiness %dMiB) exceeded for GetChunk bandwidth, try again later",
-			rateLimit, burstiness)
+			rateLimit, burstiness))
 	}
 
-	limiter, ok := l.perClientBandwidthLimiter[requesterID]
 	if !ok {
 		return fmt.Errorf("internal error, unable to find bandwidth limiter for client ID %s", requesterID)
 	}
 	allowed = limiter.AllowN(now, int(bytes))
 	if !allowed {
 		l.globalBandwidthLimiter.AllowN(now, -int(bytes))
-		if l.relayMetrics != nil {
-			l.relayMetrics.ReportChunkRateLimited("client bandwidth")
-		}
 
-		rateLimit := l.config.MaxGetChunkBytesPerSecondClient / 1024 / 1024
-		burstiness := l.config.GetChunkBytesBurstinessClient / 1024 / 1024
+		rateLimit := clientLimits.MaxGetChunkBytesPerSecond / 1024 / 1024
+		burstiness := clientLimits.GetChunkBytesBurstiness / 1024 / 1024
 
-		return fmt.Errorf(
+		return l.rateLimited("client bandwidth", retryAfter(limiter, now, int(bytes)), fmt.Sprintf(
 			"client rate limit %0.1fMiB (burstiness %dMiB) exceeded for GetChunk bandwidth, try again later",
-			rateLimit, burstiness)
+			rateLimit, burstiness))
 	}
 
 	return nil
 }
+
+// rateLimited reports that an operation was rate limited, and returns the error for it.
+func (l *ChunkRateLimiter) rateLimited(scope string, retryAfter time.Duration, msg string) error {
+	if l.relayMetrics != nil {
+		l.relayMetrics.ReportChunkRateLimited(scope)
+	}
+	return &RateLimitError{
+		Scope:      scope,
+		RetryAfter: retryAfter,
+		msg:        msg,
+	}
+}
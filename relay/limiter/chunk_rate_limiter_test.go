@@ -333,3 +333,118 @@ func TestBandwidthLimitPerClient(t *testing.T) {
 	err = limiter.RequestGetChunkBandwidth(now, userID2, 1)
 	require.Error(t, err)
 }
+
+func TestAccountLimits(t *testing.T) {
+	tu.InitializeRandom()
+
+	config := defaultConfig()
+	config.MaxGetChunkOpsPerSecondClient = 2
+	config.GetChunkOpsBurstinessClient = 2
+	config.GetChunkOpsBurstiness = math.MaxInt32
+
+	premiumID := tu.RandomString(64)
+	defaultID := tu.RandomString(64)
+
+	limiter := NewChunkRateLimiter(config, nil)
+
+	// time starts at current time, but advances manually afterward
+	now := time.Now()
+
+	// Both clients start with the per-client limits.
+	for _, userID := range []string{premiumID, defaultID} {
+		for i := 0; i < 2; i++ {
+			err := limiter.BeginGetChunkOperation(now, userID)
+			require.NoError(t, err)
+			limiter.FinishGetChunkOperation(userID)
+		}
+		err := limiter.BeginGetChunkOperation(now, userID)
+		require.Error(t, err)
+
+		// The error tells the client when it can retry.
+		var limitErr *RateLimitError
+		require.ErrorAs(t, err, &limitErr)
+		require.Equal(t, "client rate", limitErr.Scope)
+		require.Equal(t, 500*time.Millisecond, limitErr.RetryAfter)
+	}
+
+	premiumLimits := config.DefaultAccountLimits()
+	premiumLimits.MaxGetChunkOpsPerSecond = 10
+	premiumLimits.GetChunkOpsBurstiness = 10
+	defaultLimits := config.DefaultAccountLimits()
+	defaultLimits.MaxGetChunkOpsPerSecond = 1
+	defaultLimits.GetChunkOpsBurstiness = 1
+
+	// Invalid limits are refused.
+	invalidLimits := premiumLimits
+	invalidLimits.MaxConcurrentGetChunkOps = 0
+	err := limiter.SetAccountLimits(now, &AccountLimitsConfig{
+		Accounts: map[string]AccountLimits{premiumID: invalidLimits},
+	})
+	require.Error(t, err)
+
+	err = limiter.SetAccountLimits(now, &AccountLimitsConfig{
+		Default:  &defaultLimits,
+		Accounts: map[string]AccountLimits{premiumID: premiumLimits},
+	})
+	require.NoError(t, err)
+
+	// The new limits apply to the clients seen before the change, and to new clients.
+	now = now.Add(time.Second)
+	for i := 0; i < 10; i++ {
+		err = limiter.BeginGetChunkOperation(now, premiumID)
+		require.NoError(t, err)
+		limiter.FinishGetChunkOperation(premiumID)
+	}
+	err = limiter.BeginGetChunkOperation(now, premiumID)
+	require.Error(t, err)
+
+	newID := tu.RandomString(64)
+	for _, userID := range []string{defaultID, newID} {
+		err = limiter.BeginGetChunkOperation(now, userID)
+		require.NoError(t, err)
+		limiter.FinishGetChunkOperation(userID)
+		err = limiter.BeginGetChunkOperation(now, userID)
+		require.Error(t, err)
+	}
+
+	// Removing the default tier restores the per-client limits.
+	err = limiter.SetAccountLimits(now, &AccountLimitsConfig{})
+	require.NoError(t, err)
+	now = now.Add(time.Second)
+	for _, userID := range []string{premiumID, defaultID} {
+		for i := 0; i < 2; i++ {
+			err = limiter.BeginGetChunkOperation(now, userID)
+			require.NoError(t, err)
+			limiter.FinishGetChunkOperation(userID)
+		}
+		err = limiter.BeginGetChunkOperation(now, userID)
+		require.Error(t, err)
+	}
+}
+
+func TestBandwidthRetryAfter(t *testing.T) {
+	config := defaultConfig()
+	config.MaxGetChunkBytesPerSecondClient = 1024
+	config.GetChunkBytesBurstinessClient = 1024
+
+	limiter := NewChunkRateLimiter(config, nil)
+	userID := tu.RandomString(64)
+	now := time.Now()
+
+	err := limiter.BeginGetChunkOperation(now, userID)
+	require.NoError(t, err)
+	err = limiter.RequestGetChunkBandwidth(now, userID, 1024)
+	require.NoError(t, err)
+
+	// Half the bucket refills in half a second.
+	err = limiter.RequestGetChunkBandwidth(now, userID, 512)
+	var limitErr *RateLimitError
+	require.ErrorAs(t, err, &limitErr)
+	require.Equal(t, "client bandwidth", limitErr.Scope)
+	require.Equal(t, 500*time.Millisecond, limitErr.RetryAfter)
+
+	// Requests larger than the burstiness are never allowed.
+	err = limiter.RequestGetChunkBandwidth(now, userID, 2048)
+	require.ErrorAs(t, err, &limitErr)
+	require.Equal(t, time.Duration(0), limitErr.RetryAfter)
+}
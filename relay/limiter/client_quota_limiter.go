@@ -0,0 +1,214 @@
+package limiter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/core/meterer"
+)
+
+// ErrClientQuotaExceeded is returned when a request would exceed the quotas of a relay client.
+var ErrClientQuotaExceeded = errors.New("client quota exceeded")
+
+// UsageStore records the usage of the relays by relay clients.
+type UsageStore interface {
+	// IncrementRelayUsage adds requests and bytes to the usage of a client in a quota period, and returns the new usage.
+	IncrementRelayUsage(
+		ctx context.Context,
+		clientID string,
+		period uint64,
+		requests uint64,
+		bytes uint64) (*meterer.RelayUsage, error)
+	// CreditRelayUsage removes requests and bytes from the usage of a client in a quota period.
+	CreditRelayUsage(ctx context.Context, clientID string, period uint64, requests uint64, bytes uint64) error
+}
+
+var _ UsageStore = &meterer.RelayClientStore{}
+
+// ClientQuotaLimiter enforces the per-period request and bandwidth quotas of relay clients. The usage of each client
+// is recorded in a UsageStore shared by all the relays, so the quotas of a client apply across the relays, and its
+// usage can be billed.
+//
+// To avoid a write to the store for every request, the usage recorded by this relay is batched locally, and written
+// to the store at most once every ClientQuotaFlushInterval per client. Between writes, the quotas of a client are
+// enforced against its usage read back on the last write plus its local usage since, so a client may exceed its
+// quotas across the relays by at most the usage of one flush interval. This object is thread safe.
+type ClientQuotaLimiter struct {
+	// config is the rate limit configuration.
+	config *Config
+
+	// store records the usage of the clients.
+	store UsageStore
+
+	// usage is the usage of each client in its current quota period, keyed by client ID.
+	usage map[string]*clientUsage
+
+	// lock protects usage. The usage of each client is protected by its own lock.
+	lock sync.Mutex
+}
+
+// clientUsage is the usage of a client in a quota period, as known to this relay.
+type clientUsage struct {
+	lock sync.Mutex
+
+	// period is the quota period of the usage.
+	period uint64
+	// stored is the usage of the client across all the relays, as read back on the last write to the store.
+	stored meterer.RelayUsage
+	// pending is the usage recorded by this relay which hasn't been written to the store yet.
+	pending meterer.RelayUsage
+	// lastWrite is the time of the last write to the store, or zero if the usage of the period was never written.
+	lastWrite time.Time
+}
+
+// NewClientQuotaLimiter creates a new ClientQuotaLimiter.
+func NewClientQuotaLimiter(config *Config, store UsageStore) *ClientQuotaLimiter {
+	return &ClientQuotaLimiter{
+		config: config,
+		store:  store,
+		usage:  make(map[string]*clientUsage),
+	}
+}
+
+// RequestQuota should be called when a request of a relay client is about to send data. It records a request for the
+// given number of bytes in the current quota period. If this exceeds a quota of the client, the request is not
+// recorded, and an error wrapping ErrClientQuotaExceeded and a *RateLimitError is returned.
+func (l *ClientQuotaLimiter) RequestQuota(
+	ctx context.Context,
+	client *meterer.RelayClient,
+	bytes uint64,
+	now time.Time) error {
+
+	period := uint64(now.UnixNano() / l.config.ClientQuotaPeriod.Nanoseconds())
+
+	usage := l.getUsage(client.ClientID)
+	usage.lock.Lock()
+	defer usage.lock.Unlock()
+
+	if usage.period != period {
+		// the usage of the previous period is written before the client starts a new one
+		if err := l.write(ctx, client.ClientID, usage, now); err != nil {
+			return fmt.Errorf("internal error, unable to record usage of client %s: %w", client.ClientID, err)
+		}
+		usage.period = period
+		usage.stored = meterer.RelayUsage{}
+		usage.lastWrite = time.Time{}
+	}
+
+	usage.pending.Requests++
+	usage.pending.Bytes += bytes
+	written := false
+	if now.Sub(usage.lastWrite) >= l.config.ClientQuotaFlushInterval {
+		if err := l.write(ctx, client.ClientID, usage, now); err != nil {
+			usage.pending.Requests--
+			usage.pending.Bytes -= bytes
+			return fmt.Errorf("internal error, unable to record usage of client %s: %w", client.ClientID, err)
+		}
+		written = true
+	}
+
+	var msg string
+	requests := usage.stored.Requests + usage.pending.Requests
+	usedBytes := usage.stored.Bytes + usage.pending.Bytes
+	if client.MaxRequestsPerPeriod > 0 && requests > client.MaxRequestsPerPeriod {
+		msg = fmt.Sprintf("client %s is limited to %d requests every %s",
+			client.ClientID, client.MaxRequestsPerPeriod, l.config.ClientQuotaPeriod)
+	} else if client.MaxBytesPerPeriod > 0 && usedBytes > client.MaxBytesPerPeriod {
+		msg = fmt.Sprintf("client %s is limited to %d bytes every %s",
+			client.ClientID, client.MaxBytesPerPeriod, l.config.ClientQuotaPeriod)
+	}
+	if msg == "" {
+		return nil
+	}
+
+	// the quota resets at the start of the next period
+	nextPeriodStart := time.Unix(0, int64(period+1)*l.config.ClientQuotaPeriod.Nanoseconds())
+	quotaErr := fmt.Errorf("%w: %w", ErrClientQuotaExceeded, &RateLimitError{
+		Scope:      "client quota",
+		RetryAfter: nextPeriodStart.Sub(now),
+		msg:        msg,
+	})
+
+	if !written {
+		usage.pending.Requests--
+		usage.pending.Bytes -= bytes
+		return quotaErr
+	}
+
+	// the request was already written to the store, so it's credited back
+	usage.stored.Requests--
+	usage.stored.Bytes -= bytes
+	err := l.store.CreditRelayUsage(ctx, client.ClientID, period, 1, bytes)
+	if err != nil {
+		return fmt.Errorf("%w (failed to credit back the request: %v)", quotaErr, err)
+	}
+	return quotaErr
+}
+
+// Flush writes the usage batched by this relay to the store, and forgets the clients which haven't made requests in
+// the current quota period. It should be called periodically, and before the relay shuts down, so that the usage of
+// clients which stop making requests is recorded.
+func (l *ClientQuotaLimiter) Flush(ctx context.Context, now time.Time) error {
+	period := uint64(now.UnixNano() / l.config.ClientQuotaPeriod.Nanoseconds())
+
+	l.lock.Lock()
+	clients := make(map[string]*clientUsage, len(l.usage))
+	for clientID, usage := range l.usage {
+		clients[clientID] = usage
+	}
+	l.lock.Unlock()
+
+	var errs []error
+	for clientID, usage := range clients {
+		usage.lock.Lock()
+		err := l.write(ctx, clientID, usage, now)
+		stale := err == nil && usage.period != period
+		usage.lock.Unlock()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("unable to record usage of client %s: %w", clientID, err))
+			continue
+		}
+		if stale {
+			l.lock.Lock()
+			// the client may have made a request in the current period since it was copied
+			if l.usage[clientID] == usage && usage.period != period {
+				delete(l.usage, clientID)
+			}
+			l.lock.Unlock()
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// getUsage returns the usage of a client, creating it if the client is unknown.
+func (l *ClientQuotaLimiter) getUsage(clientID string) *clientUsage {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	usage, ok := l.usage[clientID]
+	if !ok {
+		usage = &clientUsage{}
+		l.usage[clientID] = usage
+	}
+	return usage
+}
+
+// write writes the pending usage of a client to the store, and updates the stored usage with the usage read back.
+// The lock of the usage must be held.
+func (l *ClientQuotaLimiter) write(ctx context.Context, clientID string, usage *clientUsage, now time.Time) error {
+	if usage.pending == (meterer.RelayUsage{}) {
+		return nil
+	}
+	stored, err := l.store.IncrementRelayUsage(
+		ctx, clientID, usage.period, usage.pending.Requests, usage.pending.Bytes)
+	if err != nil {
+		return err
+	}
+	usage.stored = *stored
+	usage.pending = meterer.RelayUsage{}
+	usage.lastWrite = now
+	return nil
+}
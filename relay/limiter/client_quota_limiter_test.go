@@ -0,0 +1,218 @@
+package limiter
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/core/meterer"
+	"github.com/stretchr/testify/require"
+)
+
+type usageKey struct {
+	clientID string
+	period   uint64
+}
+
+// mockUsageStore is a UsageStore backed by a map.
+type mockUsageStore struct {
+	usage  map[usageKey]meterer.RelayUsage
+	err    error
+	writes int
+}
+
+func (s *mockUsageStore) IncrementRelayUsage(
+	_ context.Context,
+	clientID string,
+	period uint64,
+	requests uint64,
+	bytes uint64) (*meterer.RelayUsage, error) {
+
+	if s.err != nil {
+		return nil, s.err
+	}
+	s.writes++
+	key := usageKey{clientID, period}
+	usage := s.usage[key]
+	usage.Requests += requests
+	usage.Bytes += bytes
+	s.usage[key] = usage
+	return &usage, nil
+}
+
+func (s *mockUsageStore) CreditRelayUsage(
+	_ context.Context,
+	clientID string,
+	period uint64,
+	requests uint64,
+	bytes uint64) error {
+
+	key := usageKey{clientID, period}
+	usage := s.usage[key]
+	usage.Requests -= requests
+	usage.Bytes -= bytes
+	s.usage[key] = usage
+	return nil
+}
+
+func TestClientRequestQuota(t *testing.T) {
+	config := defaultConfig()
+	config.ClientQuotaPeriod = time.Hour
+
+	store := &mockUsageStore{usage: make(map[usageKey]meterer.RelayUsage)}
+	limiter := NewClientQuotaLimiter(config, store)
+
+	client := &meterer.RelayClient{
+		ClientID:             "client",
+		MaxRequestsPerPeriod: 3,
+	}
+	now := time.Now().Truncate(time.Hour)
+	period := uint64(now.UnixNano() / time.Hour.Nanoseconds())
+
+	for i := 0; i < 3; i++ {
+		err := limiter.RequestQuota(context.Background(), client, 100, now)
+		require.NoError(t, err)
+	}
+	err := limiter.RequestQuota(context.Background(), client, 100, now.Add(15*time.Minute))
+	require.ErrorIs(t, err, ErrClientQuotaExceeded)
+
+	// the client is told to retry once the quota resets
+	var limitErr *RateLimitError
+	require.ErrorAs(t, err, &limitErr)
+	require.Equal(t, 45*time.Minute, limitErr.RetryAfter)
+
+	// the rejected request is credited back
+	require.Equal(t, meterer.RelayUsage{Requests: 3, Bytes: 300}, store.usage[usageKey{"client", period}])
+
+	// the quota resets in the next period
+	err = limiter.RequestQuota(context.Background(), client, 100, now.Add(time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, meterer.RelayUsage{Requests: 1, Bytes: 100}, store.usage[usageKey{"client", period + 1}])
+
+	// the quotas of other clients are independent
+	otherClient := &meterer.RelayClient{
+		ClientID:             "other",
+		MaxRequestsPerPeriod: 3,
+	}
+	err = limiter.RequestQuota(context.Background(), otherClient, 100, now)
+	require.NoError(t, err)
+}
+
+func TestClientBandwidthQuota(t *testing.T) {
+	config := defaultConfig()
+	config.ClientQuotaPeriod = time.Minute
+
+	store := &mockUsageStore{usage: make(map[usageKey]meterer.RelayUsage)}
+	limiter := NewClientQuotaLimiter(config, store)
+
+	client := &meterer.RelayClient{
+		ClientID:          "client",
+		MaxBytesPerPeriod: 1000,
+	}
+	now := time.Now()
+
+	err := limiter.RequestQuota(context.Background(), client, 600, now)
+	require.NoError(t, err)
+	err = limiter.RequestQuota(context.Background(), client, 600, now)
+	require.ErrorIs(t, err, ErrClientQuotaExceeded)
+
+	// smaller requests still fit in the quota
+	err = limiter.RequestQuota(context.Background(), client, 400, now)
+	require.NoError(t, err)
+	err = limiter.RequestQuota(context.Background(), client, 1, now)
+	require.ErrorIs(t, err, ErrClientQuotaExceeded)
+
+	// clients without quotas are not limited
+	unlimitedClient := &meterer.RelayClient{ClientID: "unlimited"}
+	for i := 0; i < 100; i++ {
+		err = limiter.RequestQuota(context.Background(), unlimitedClient, 1000, now)
+		require.NoError(t, err)
+	}
+
+	// store failures are internal errors, not quota errors
+	store.err = errors.New("store failure")
+	err = limiter.RequestQuota(context.Background(), client, 1, now)
+	require.Error(t, err)
+	require.NotErrorIs(t, err, ErrClientQuotaExceeded)
+}
+
+func TestClientQuotaBatching(t *testing.T) {
+	config := defaultConfig()
+	config.ClientQuotaPeriod = time.Hour
+	config.ClientQuotaFlushInterval = time.Minute
+
+	store := &mockUsageStore{usage: make(map[usageKey]meterer.RelayUsage)}
+	limiter := NewClientQuotaLimiter(config, store)
+
+	client := &meterer.RelayClient{
+		ClientID:             "client",
+		MaxRequestsPerPeriod: 10,
+	}
+	now := time.Now().Truncate(time.Hour)
+	period := uint64(now.UnixNano() / time.Hour.Nanoseconds())
+
+	// the first request of the period is written, and the following ones are batched until the interval elapses
+	for i := 0; i < 5; i++ {
+		err := limiter.RequestQuota(context.Background(), client, 100, now.Add(time.Duration(i)*time.Second))
+		require.NoError(t, err)
+	}
+	require.Equal(t, 1, store.writes)
+	require.Equal(t, meterer.RelayUsage{Requests: 1, Bytes: 100}, store.usage[usageKey{"client", period}])
+
+	err := limiter.RequestQuota(context.Background(), client, 100, now.Add(time.Minute))
+	require.NoError(t, err)
+	require.Equal(t, 2, store.writes)
+	require.Equal(t, meterer.RelayUsage{Requests: 6, Bytes: 600}, store.usage[usageKey{"client", period}])
+
+	// usage recorded by other relays is picked up on the next write
+	store.usage[usageKey{"client", period}] = meterer.RelayUsage{Requests: 9, Bytes: 900}
+	err = limiter.RequestQuota(context.Background(), client, 100, now.Add(2*time.Minute))
+	require.NoError(t, err)
+	require.Equal(t, 3, store.writes)
+
+	// batched requests are checked against the local usage, and rejected ones are never written
+	err = limiter.RequestQuota(context.Background(), client, 100, now.Add(2*time.Minute+time.Second))
+	require.ErrorIs(t, err, ErrClientQuotaExceeded)
+	require.Equal(t, 3, store.writes)
+	require.Equal(t, meterer.RelayUsage{Requests: 10, Bytes: 1000}, store.usage[usageKey{"client", period}])
+}
+
+func TestClientQuotaFlush(t *testing.T) {
+	config := defaultConfig()
+	config.ClientQuotaPeriod = time.Hour
+	config.ClientQuotaFlushInterval = time.Minute
+
+	store := &mockUsageStore{usage: make(map[usageKey]meterer.RelayUsage)}
+	limiter := NewClientQuotaLimiter(config, store)
+
+	client := &meterer.RelayClient{ClientID: "client"}
+	now := time.Now().Truncate(time.Hour)
+	period := uint64(now.UnixNano() / time.Hour.Nanoseconds())
+
+	for i := 0; i < 3; i++ {
+		err := limiter.RequestQuota(context.Background(), client, 100, now)
+		require.NoError(t, err)
+	}
+	require.Equal(t, meterer.RelayUsage{Requests: 1, Bytes: 100}, store.usage[usageKey{"client", period}])
+
+	// flushing writes the batched usage
+	err := limiter.Flush(context.Background(), now.Add(time.Second))
+	require.NoError(t, err)
+	require.Equal(t, meterer.RelayUsage{Requests: 3, Bytes: 300}, store.usage[usageKey{"client", period}])
+	require.Len(t, limiter.usage, 1)
+
+	// failed writes keep the usage until the next flush
+	err = limiter.RequestQuota(context.Background(), client, 100, now.Add(2*time.Second))
+	require.NoError(t, err)
+	store.err = errors.New("store failure")
+	err = limiter.Flush(context.Background(), now.Add(3*time.Second))
+	require.Error(t, err)
+	store.err = nil
+
+	// the usage of a past period is written, and the client is forgotten
+	err = limiter.Flush(context.Background(), now.Add(time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, meterer.RelayUsage{Requests: 4, Bytes: 400}, store.usage[usageKey{"client", period}])
+	require.Empty(t, limiter.usage)
+}
@@ -1,5 +1,7 @@
 package limiter
 
+import "time"
+
 // Config is the configuration for the relay rate limiting.
 type Config struct {
 
@@ -62,4 +64,20 @@ type Config struct {
 	// MaxConcurrentGetChunkOpsClient is the maximum number of concurrent GetChunk operations that are permitted.
 	// Default is 1.
 	MaxConcurrentGetChunkOpsClient int
+
+	// AccountLimitsFile is the path of a JSON file setting the GetChunks rate limits of individual accounts, and
+	// optionally of the default tier, which otherwise uses the per-client limits above. See AccountLimitsConfig. The
+	// file is read again when the relay receives SIGHUP. If empty, all clients have the per-client limits above.
+	AccountLimitsFile string
+
+	// Relay client quotas
+
+	// ClientQuotaPeriod is the length of the periods in which the usage of each relay client is limited by its
+	// quotas, and recorded for billing. Default is 1 hour.
+	ClientQuotaPeriod time.Duration
+
+	// ClientQuotaFlushInterval is the minimum time between writes of the usage of a relay client to the usage store.
+	// Between writes, the usage is batched locally, so a client may exceed its quotas across the relays by the usage
+	// of one interval. If zero, the usage is written on every request. Default is 5 seconds.
+	ClientQuotaFlushInterval time.Duration
 }
@@ -0,0 +1,34 @@
+package limiter
+
+import (
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// RateLimitError is returned when an operation is refused because it exceeds a rate limit, a concurrency limit or a
+// quota.
+type RateLimitError struct {
+	// Scope is the limit which was exceeded, e.g. "global rate" or "client bandwidth"
+	Scope string
+	// RetryAfter is the time after which the operation would be allowed. It's 0 if that time is unknown, e.g. for
+	// concurrency limits, or if the operation is never allowed, e.g. if it's larger than the burstiness of the limit.
+	RetryAfter time.Duration
+	msg        string
+}
+
+func (e *RateLimitError) Error() string {
+	return e.msg
+}
+
+// retryAfter returns how long it takes for the limiter to hold the given number of tokens, or 0 if it never will.
+func retryAfter(limiter *rate.Limiter, now time.Time, tokens int) time.Duration {
+	if tokens > limiter.Burst() || limiter.Limit() <= 0 {
+		return 0
+	}
+	missing := float64(tokens) - limiter.TokensAt(now)
+	if missing <= 0 {
+		return 0
+	}
+	return time.Duration(missing / float64(limiter.Limit()) * float64(time.Second))
+}
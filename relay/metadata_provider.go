@@ -21,10 +21,15 @@ type blobMetadata struct {
 	blobSizeBytes uint32
 	// the size of each encoded chunk
 	chunkSizeBytes uint32
+	// the number of encoded chunks
+	numChunks uint32
 	// the size of the file containing the encoded chunks
 	totalChunkSizeBytes uint32
 	// the fragment size used for uploading the encoded chunks
 	fragmentSizeBytes uint32
+	// the time after which the blob is no longer served, derived from the retention period requested by the client.
+	// If zero, the blob is served for as long as it is stored.
+	expiresAt time.Time
 }
 
 // metadataProvider encapsulates logic for fetching metadata for blobs. Utilized by the relay Server.
@@ -33,7 +38,7 @@ type metadataProvider struct {
 	logger logging.Logger
 
 	// metadataStore can be used to read blob metadata from dynamoDB.
-	metadataStore *blobstore.BlobMetadataStore
+	metadataStore blobstore.MetadataStore
 
 	// metadataCache is an LRU cache of blob metadata. Blobs that do not belong to one of the relay shards
 	// assigned to this server will not be in the cache.
@@ -54,7 +59,7 @@ type metadataProvider struct {
 func newMetadataProvider(
 	ctx context.Context,
 	logger logging.Logger,
-	metadataStore *blobstore.BlobMetadataStore,
+	metadataStore blobstore.MetadataStore,
 	metadataCacheSize int,
 	maxIOConcurrency int,
 	relayKeys []v2.RelayKey,
@@ -145,11 +150,15 @@ func (m *metadataProvider) GetMetadataForBlobs(ctx context.Context, keys []v2.Bl
 		}()
 	}
 
+	now := time.Now()
 	for range mMap {
 		result := <-completionChannel
 		if result.err != nil {
 			return nil, fmt.Errorf("error fetching metadata for blob %s: %w", result.key.Hex(), result.err)
 		}
+		if !result.metadata.expiresAt.IsZero() && now.After(result.metadata.expiresAt) {
+			return nil, fmt.Errorf("blob %s has exceeded its retention period", result.key.Hex())
+		}
 		mMap[result.key] = result.metadata
 	}
 
@@ -160,7 +169,8 @@ func (m *metadataProvider) UpdateBlobVersionParameters(blobParamsMap *v2.BlobVer
 	m.blobParamsMap.Store(blobParamsMap)
 }
 
-func (m *metadataProvider) computeChunkSize(header *v2.BlobHeader, totalChunkSizeBytes uint32) (uint32, error) {
+// getNumChunks returns the number of chunks of a blob, as set by the parameters of its blob version.
+func (m *metadataProvider) getNumChunks(header *v2.BlobHeader) (uint32, error) {
 	blobParamsMap := m.blobParamsMap.Load()
 	if blobParamsMap == nil {
 		return 0, fmt.Errorf("blob version parameters is nil")
@@ -175,7 +185,7 @@ func (m *metadataProvider) computeChunkSize(header *v2.BlobHeader, totalChunkSiz
 		return 0, fmt.Errorf("numChunks is 0, this should never happen")
 	}
 
-	return totalChunkSizeBytes / blobParams.NumChunks, nil
+	return blobParams.NumChunks, nil
 }
 
 // fetchMetadata retrieves metadata about a blob. Fetches from the cache if available, otherwise from the store.
@@ -211,16 +221,25 @@ func (m *metadataProvider) fetchMetadata(key v2.BlobKey) (*blobMetadata, error)
 	// TODO(cody-littley): blob size is not correct https://github.com/Layr-Labs/eigenda/pull/906#discussion_r1847396530
 	blobSize := uint32(cert.BlobHeader.BlobCommitments.Length) * encoding.BYTES_PER_SYMBOL
 
-	chunkSize, err := m.computeChunkSize(cert.BlobHeader, fragmentInfo.TotalChunkSizeBytes)
+	numChunks, err := m.getNumChunks(cert.BlobHeader)
 	if err != nil {
 		return nil, fmt.Errorf("error getting chunk length: %w", err)
 	}
+	chunkSize := fragmentInfo.TotalChunkSizeBytes / numChunks
+
+	var expiresAt time.Time
+	paymentMetadata := cert.BlobHeader.PaymentMetadata
+	if retentionPeriod := paymentMetadata.RetentionPeriod(); retentionPeriod > 0 {
+		expiresAt = time.Unix(0, paymentMetadata.Timestamp).Add(retentionPeriod)
+	}
 
 	metadata := &blobMetadata{
 		blobSizeBytes:       blobSize,
 		chunkSizeBytes:      chunkSize,
+		numChunks:           numChunks,
 		totalChunkSizeBytes: fragmentInfo.TotalChunkSizeBytes,
 		fragmentSizeBytes:   fragmentInfo.FragmentSizeBytes,
+		expiresAt:           expiresAt,
 	}
 
 	return metadata, nil
@@ -471,3 +471,60 @@ func TestBatchedFetchWithSharding(t *testing.T) {
 		}
 	}
 }
+
+func TestFetchExpiredBlob(t *testing.T) {
+	tu.InitializeRandom()
+
+	logger, err := common.NewLogger(common.DefaultLoggerConfig())
+	require.NoError(t, err)
+
+	setup(t)
+	defer teardown()
+	metadataStore := buildMetadataStore(t)
+
+	// A blob whose retention period has elapsed
+	expiredHeader, _ := randomBlob(t)
+	expiredHeader.PaymentMetadata.Timestamp = time.Now().Add(-2 * time.Hour).UnixNano()
+	expiredHeader.PaymentMetadata.RetentionPeriodSeconds = uint32(time.Hour.Seconds())
+	expiredKey, err := expiredHeader.BlobKey()
+	require.NoError(t, err)
+
+	// A blob whose retention period has not yet elapsed
+	retainedHeader, _ := randomBlob(t)
+	retainedHeader.PaymentMetadata.Timestamp = time.Now().UnixNano()
+	retainedHeader.PaymentMetadata.RetentionPeriodSeconds = uint32(time.Hour.Seconds())
+	retainedKey, err := retainedHeader.BlobKey()
+	require.NoError(t, err)
+
+	for _, header := range []*v2.BlobHeader{expiredHeader, retainedHeader} {
+		err = metadataStore.PutBlobCertificate(
+			context.Background(),
+			&v2.BlobCertificate{
+				BlobHeader: header,
+			},
+			&encoding.FragmentInfo{
+				TotalChunkSizeBytes: 1024,
+				FragmentSizeBytes:   1024,
+			})
+		require.NoError(t, err)
+	}
+
+	server, err := newMetadataProvider(
+		context.Background(),
+		logger,
+		metadataStore,
+		1024*1024,
+		32,
+		nil,
+		10*time.Second,
+		v2.NewBlobVersionParameterMap(mockBlobParamsMap()),
+		nil)
+	require.NoError(t, err)
+
+	_, err = server.GetMetadataForBlobs(context.Background(), []v2.BlobKey{expiredKey})
+	require.Error(t, err)
+
+	mMap, err := server.GetMetadataForBlobs(context.Background(), []v2.BlobKey{retainedKey})
+	require.NoError(t, err)
+	require.Len(t, mMap, 1)
+}
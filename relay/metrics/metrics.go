@@ -28,6 +28,10 @@ type RelayMetrics struct {
 	ChunkCacheMetrics    *cache.CacheAccessorMetrics
 	BlobCacheMetrics     *cache.CacheAccessorMetrics
 
+	// Tiered cache metrics, used if blobs and chunks are also cached on disk
+	ChunkTieredCacheMetrics *cache.TieredCacheMetrics
+	BlobTieredCacheMetrics  *cache.TieredCacheMetrics
+
 	// GetChunks metrics
 	getChunksLatency               *prometheus.SummaryVec
 	getChunksAuthenticationLatency *prometheus.SummaryVec
@@ -44,8 +48,21 @@ type RelayMetrics struct {
 	getBlobMetadataLatency    *prometheus.SummaryVec
 	getBlobDataLatency        *prometheus.SummaryVec
 	getBlobRateLimited        *prometheus.CounterVec
+	getBlobAuthFailures       *prometheus.CounterVec
 	getBlobBandwidth          *prometheus.CounterVec
 	getBlobRequestedBandwidth *prometheus.CounterVec
+
+	// Fleet metrics
+	fleetForwards *prometheus.CounterVec
+	fallbacks     *prometheus.CounterVec
+
+	// Cache warming metrics
+	warmedBlobs *prometheus.CounterVec
+
+	// Per request metrics, for all the endpoints
+	requestLatency    *prometheus.HistogramVec
+	requestCacheTiers *prometheus.CounterVec
+	egressBytes       *prometheus.CounterVec
 }
 
 // NewRelayMetrics creates a new RelayMetrics instance, which encapsulates all metrics related to the relay.
@@ -76,6 +93,8 @@ func NewRelayMetrics(logger logging.Logger, port int) *RelayMetrics {
 	metadataCacheMetrics := cache.NewCacheAccessorMetrics(registry, "metadata")
 	chunkCacheMetrics := cache.NewCacheAccessorMetrics(registry, "chunk")
 	blobCacheMetrics := cache.NewCacheAccessorMetrics(registry, "blob")
+	chunkTieredCacheMetrics := cache.NewTieredCacheMetrics(registry, "chunk")
+	blobTieredCacheMetrics := cache.NewTieredCacheMetrics(registry, "blob")
 
 	objectives := map[float64]float64{0.5: 0.05, 0.9: 0.01, 0.99: 0.001}
 
@@ -194,6 +213,15 @@ func NewRelayMetrics(logger logging.Logger, port int) *RelayMetrics {
 		[]string{},
 	)
 
+	getBlobAuthFailures := promauto.With(registry).NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "get_blob_auth_failure_count",
+			Help:      "Number of GetBlob RPC client authentication failures",
+		},
+		[]string{},
+	)
+
 	getBlobRateLimited := promauto.With(registry).NewCounterVec(
 		prometheus.CounterOpts{
 			Namespace: namespace,
@@ -221,6 +249,61 @@ func NewRelayMetrics(logger logging.Logger, port int) *RelayMetrics {
 		[]string{},
 	)
 
+	fleetForwards := promauto.With(registry).NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "fleet_forward_count",
+			Help:      "Number of requests for data owned by a peer of the fleet forwarded to it, by outcome.",
+		},
+		[]string{"method", "outcome"},
+	)
+
+	fallbacks := promauto.With(registry).NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "fallback_count",
+			Help:      "Number of requests for data which failed to be read locally sent to the fallback relays, by outcome.",
+		},
+		[]string{"method", "outcome"},
+	)
+
+	warmedBlobs := promauto.With(registry).NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "cache_warming_blob_count",
+			Help:      "Number of newly certified blobs seen by the cache warmer, by outcome.",
+		},
+		[]string{"outcome"},
+	)
+
+	requestLatency := promauto.With(registry).NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "request_latency_ms",
+			Help:      "Latency of the relay RPCs, by method and status code",
+			Buckets:   prometheus.ExponentialBuckets(1, 2, 16),
+		},
+		[]string{"method", "status"},
+	)
+
+	requestCacheTiers := promauto.With(registry).NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "request_cache_tier_count",
+			Help:      "Number of requests served, by method and the slowest tier which served their data.",
+		},
+		[]string{"method", "tier"},
+	)
+
+	egressBytes := promauto.With(registry).NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "egress_bytes",
+			Help:      "Running total of the data sent in replies, by method and kind of caller.",
+		},
+		[]string{"method", "caller"},
+	)
+
 	return &RelayMetrics{
 		logger:                         logger,
 		grpcServerOption:               grpcServerOption,
@@ -228,6 +311,8 @@ func NewRelayMetrics(logger logging.Logger, port int) *RelayMetrics {
 		MetadataCacheMetrics:           metadataCacheMetrics,
 		ChunkCacheMetrics:              chunkCacheMetrics,
 		BlobCacheMetrics:               blobCacheMetrics,
+		ChunkTieredCacheMetrics:        chunkTieredCacheMetrics,
+		BlobTieredCacheMetrics:         blobTieredCacheMetrics,
 		getChunksLatency:               getChunksLatency,
 		getChunksAuthenticationLatency: getChunksAuthenticationLatency,
 		getChunksMetadataLatency:       getChunksMetadataLatency,
@@ -241,8 +326,15 @@ func NewRelayMetrics(logger logging.Logger, port int) *RelayMetrics {
 		getBlobMetadataLatency:         getBlobMetadataLatency,
 		getBlobDataLatency:             getBlobDataLatency,
 		getBlobRateLimited:             getBlobRateLimited,
+		getBlobAuthFailures:            getBlobAuthFailures,
 		getBlobBandwidth:               getBlobBandwidth,
 		getBlobRequestedBandwidth:      getBlobRequestedBandwidth,
+		fleetForwards:                  fleetForwards,
+		fallbacks:                      fallbacks,
+		warmedBlobs:                    warmedBlobs,
+		requestLatency:                 requestLatency,
+		requestCacheTiers:              requestCacheTiers,
+		egressBytes:                    egressBytes,
 	}
 }
 
@@ -318,6 +410,10 @@ func (m *RelayMetrics) ReportBlobRateLimited(reason string) {
 	m.getBlobRateLimited.WithLabelValues(reason).Inc()
 }
 
+func (m *RelayMetrics) ReportBlobAuthFailure() {
+	m.getBlobAuthFailures.WithLabelValues().Inc()
+}
+
 func (m *RelayMetrics) ReportBlobBandwidthUsage(size int) {
 	m.getBlobBandwidth.WithLabelValues().Add(float64(size))
 }
@@ -325,3 +421,27 @@ func (m *RelayMetrics) ReportBlobBandwidthUsage(size int) {
 func (m *RelayMetrics) ReportBlobRequestedBandwidthUsage(size int) {
 	m.getBlobRequestedBandwidth.WithLabelValues().Add(float64(size))
 }
+
+func (m *RelayMetrics) ReportFleetForward(method string, outcome string) {
+	m.fleetForwards.WithLabelValues(method, outcome).Inc()
+}
+
+func (m *RelayMetrics) ReportFallback(method string, outcome string) {
+	m.fallbacks.WithLabelValues(method, outcome).Inc()
+}
+
+func (m *RelayMetrics) ReportWarmedBlob(outcome string) {
+	m.warmedBlobs.WithLabelValues(outcome).Inc()
+}
+
+func (m *RelayMetrics) ReportRequestLatency(method string, status string, duration time.Duration) {
+	m.requestLatency.WithLabelValues(method, status).Observe(common.ToMilliseconds(duration))
+}
+
+func (m *RelayMetrics) ReportRequestCacheTier(method string, tier string) {
+	m.requestCacheTiers.WithLabelValues(method, tier).Inc()
+}
+
+func (m *RelayMetrics) ReportEgress(method string, caller string, size int) {
+	m.egressBytes.WithLabelValues(method, caller).Add(float64(size))
+}
@@ -151,7 +151,7 @@ func buildBlobStore(t *testing.T, logger logging.Logger) *blobstore.BlobStore {
 	err = client.CreateBucket(context.Background(), bucketName)
 	require.NoError(t, err)
 
-	return blobstore.NewBlobStore(bucketName, client, logger)
+	return blobstore.NewBlobStore(bucketName, client, nil, logger)
 }
 
 func buildChunkStore(t *testing.T, logger logging.Logger) (chunkstore.ChunkReader, chunkstore.ChunkWriter) {
@@ -170,8 +170,8 @@ func buildChunkStore(t *testing.T, logger logging.Logger) (chunkstore.ChunkReade
 	require.NoError(t, err)
 
 	// intentionally use very small fragment size
-	chunkWriter := chunkstore.NewChunkWriter(logger, client, bucketName, 32)
-	chunkReader := chunkstore.NewChunkReader(logger, client, bucketName)
+	chunkWriter := chunkstore.NewChunkWriter(logger, client, bucketName, 32, nil)
+	chunkReader := chunkstore.NewChunkReader(logger, client, bucketName, nil)
 
 	return chunkReader, chunkWriter
 }
@@ -179,6 +179,7 @@ func buildChunkStore(t *testing.T, logger logging.Logger) (chunkstore.ChunkReade
 func newMockChainReader() *coremock.MockWriter {
 	w := &coremock.MockWriter{}
 	w.On("GetAllVersionedBlobParams", mock.Anything).Return(mockBlobParamsMap(), nil)
+	w.On("GetCurrentBlockNumber").Return(uint32(0), nil)
 	return w
 }
 
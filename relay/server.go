@@ -2,21 +2,31 @@ package relay
 
 import (
 	"context"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"net"
+	"os"
+	"os/signal"
+	"path/filepath"
 	"strings"
+	"sync"
+	"syscall"
 	"time"
 
 	"github.com/Layr-Labs/eigenda/api"
 	pb "github.com/Layr-Labs/eigenda/api/grpc/relay"
+	"github.com/Layr-Labs/eigenda/api/hashing"
 	"github.com/Layr-Labs/eigenda/common/healthcheck"
 	"github.com/Layr-Labs/eigenda/common/pprof"
 	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/core/meterer"
 	v2 "github.com/Layr-Labs/eigenda/core/v2"
+	dispcommon "github.com/Layr-Labs/eigenda/disperser/common"
 	"github.com/Layr-Labs/eigenda/disperser/common/v2/blobstore"
 	"github.com/Layr-Labs/eigenda/relay/auth"
 	"github.com/Layr-Labs/eigenda/relay/chunkstore"
+	"github.com/Layr-Labs/eigenda/relay/fleet"
 	"github.com/Layr-Labs/eigenda/relay/limiter"
 	"github.com/Layr-Labs/eigenda/relay/metrics"
 	"github.com/Layr-Labs/eigensdk-go/logging"
@@ -27,6 +37,12 @@ import (
 
 var _ pb.RelayServer = &Server{}
 
+// ClientStore holds the relay clients which can authenticate with the relay, and records their usage of the relays.
+type ClientStore interface {
+	auth.ClientStore
+	limiter.UsageStore
+}
+
 // Server implements the Relay service defined in api/proto/relay/relay.proto
 type Server struct {
 	pb.UnimplementedRelayServer
@@ -37,6 +53,9 @@ type Server struct {
 	// the logger for the server
 	logger logging.Logger
 
+	// accessLogger records the requests served by the relay, if the access log is enabled.
+	accessLogger logging.Logger
+
 	// metadataProvider encapsulates logic for fetching metadata for blobs.
 	metadataProvider *metadataProvider
 
@@ -52,6 +71,23 @@ type Server struct {
 	// chunkRateLimiter enforces rate limits on GetChunk operations.
 	chunkRateLimiter *limiter.ChunkRateLimiter
 
+	// clientAuthenticator authenticates relay clients. If nil, relay clients aren't authenticated.
+	clientAuthenticator auth.ClientAuthenticator
+
+	// clientQuotaLimiter enforces the quotas of relay clients.
+	clientQuotaLimiter *limiter.ClientQuotaLimiter
+
+	// fleet forwards the data requests for blobs owned by the peers of this relay to them. If nil, the relay isn't
+	// part of a fleet.
+	fleet *fleet.Fleet
+
+	// fallback fetches the data this relay fails to read from its stores from other relays. If nil, the relay doesn't
+	// fall back to other relays.
+	fallback *fleet.Fallback
+
+	// cacheWarmer prefetches newly certified blobs into the blob cache. If nil, the cache isn't warmed.
+	cacheWarmer *cacheWarmer
+
 	// grpcServer is the gRPC server.
 	grpcServer *grpc.Server
 
@@ -70,16 +106,23 @@ func NewServer(
 	ctx context.Context,
 	logger logging.Logger,
 	config *Config,
-	metadataStore *blobstore.BlobMetadataStore,
+	metadataStore blobstore.MetadataStore,
 	blobStore *blobstore.BlobStore,
 	chunkReader chunkstore.ChunkReader,
 	chainReader core.Reader,
 	ics core.IndexedChainState,
+	clientStore ClientStore,
 ) (*Server, error) {
 
 	if chainReader == nil {
 		return nil, errors.New("chainReader is required")
 	}
+	if config.ClientAuthenticationEnabled && clientStore == nil {
+		return nil, errors.New("clientStore is required if client authentication is enabled")
+	}
+	if config.GetBlobStreamPartSize <= 0 {
+		return nil, fmt.Errorf("GetBlobStream part size must be positive, got %d", config.GetBlobStreamPartSize)
+	}
 
 	blobParams, err := chainReader.GetAllVersionedBlobParams(ctx)
 	if err != nil {
@@ -108,9 +151,12 @@ func NewServer(
 		logger,
 		blobStore,
 		config.BlobCacheBytes,
+		diskCacheDirectory(config, "blobs"),
+		config.BlobDiskCacheBytes,
 		config.BlobMaxConcurrency,
 		config.Timeouts.InternalGetBlobTimeout,
-		relayMetrics.BlobCacheMetrics)
+		relayMetrics.BlobCacheMetrics,
+		relayMetrics.BlobTieredCacheMetrics)
 	if err != nil {
 		return nil, fmt.Errorf("error creating blob provider: %w", err)
 	}
@@ -120,10 +166,13 @@ func NewServer(
 		logger,
 		chunkReader,
 		config.ChunkCacheBytes,
+		diskCacheDirectory(config, "chunks"),
+		config.ChunkDiskCacheBytes,
 		config.ChunkMaxConcurrency,
 		config.Timeouts.InternalGetProofsTimeout,
 		config.Timeouts.InternalGetCoefficientsTimeout,
-		relayMetrics.ChunkCacheMetrics)
+		relayMetrics.ChunkCacheMetrics,
+		relayMetrics.ChunkTieredCacheMetrics)
 	if err != nil {
 		return nil, fmt.Errorf("error creating chunk provider: %w", err)
 	}
@@ -140,9 +189,61 @@ func NewServer(
 		}
 	}
 
-	return &Server{
+	var clientAuthenticator auth.ClientAuthenticator
+	var clientQuotaLimiter *limiter.ClientQuotaLimiter
+	if config.ClientAuthenticationEnabled {
+		clientAuthenticator, err = auth.NewClientAuthenticator(
+			clientStore,
+			config.ClientCacheSize,
+			config.ClientCacheTTL,
+			config.ClientSignatureMaxAge)
+		if err != nil {
+			return nil, fmt.Errorf("error creating client authenticator: %w", err)
+		}
+		if config.RateLimits.ClientQuotaPeriod <= 0 {
+			return nil, fmt.Errorf("client quota period must be positive, got %s",
+				config.RateLimits.ClientQuotaPeriod)
+		}
+		clientQuotaLimiter = limiter.NewClientQuotaLimiter(&config.RateLimits, clientStore)
+	}
+
+	var relayFleet *fleet.Fleet
+	if config.Fleet.Enabled() {
+		relayFleet, err = fleet.NewFleet(&config.Fleet, config.MaxGRPCMessageSize)
+		if err != nil {
+			return nil, fmt.Errorf("error creating relay fleet: %w", err)
+		}
+	}
+
+	var fallback *fleet.Fallback
+	if len(config.Fleet.Fallbacks) > 0 {
+		fallback, err = fleet.NewFallback(config.Fleet.Fallbacks, config.Fleet.Secret, config.MaxGRPCMessageSize)
+		if err != nil {
+			return nil, fmt.Errorf("error creating relay fallback: %w", err)
+		}
+	}
+
+	var warmer *cacheWarmer
+	if config.CacheWarmingInterval > 0 {
+		warmer, err = newCacheWarmer(
+			logger,
+			metadataStore,
+			mp,
+			bp,
+			relayFleet,
+			relayMetrics,
+			config.CacheWarmingInterval,
+			config.CacheWarmingBatchSize,
+			config.CacheWarmingMaxConcurrency)
+		if err != nil {
+			return nil, fmt.Errorf("error creating cache warmer: %w", err)
+		}
+	}
+
+	server := &Server{
 		config:           config,
 		logger:           logger.With("component", "RelayServer"),
+		accessLogger:     logger.With("component", "RelayAccessLog"),
 		metadataProvider: mp,
 		blobProvider:     bp,
 		chunkProvider:    cp,
@@ -150,12 +251,128 @@ func NewServer(
 		chunkRateLimiter: limiter.NewChunkRateLimiter(&config.RateLimits, relayMetrics),
 		authenticator:    authenticator,
 		metrics:          relayMetrics,
-	}, nil
+
+		clientAuthenticator: clientAuthenticator,
+		clientQuotaLimiter:  clientQuotaLimiter,
+		fleet:               relayFleet,
+		fallback:            fallback,
+		cacheWarmer:         warmer,
+	}
+
+	if config.RateLimits.AccountLimitsFile != "" {
+		err = server.ReloadAccountLimits()
+		if err != nil {
+			return nil, fmt.Errorf("error loading account limits: %w", err)
+		}
+	}
+
+	return server, nil
+}
+
+// ReloadAccountLimits reads the account limits file and applies it to the running relay. Nothing is changed if the
+// limits are invalid.
+func (s *Server) ReloadAccountLimits() error {
+	if s.config.RateLimits.AccountLimitsFile == "" {
+		return errors.New("no account limits file is configured")
+	}
+
+	limits, err := limiter.ReadAccountLimits(s.config.RateLimits.AccountLimitsFile)
+	if err != nil {
+		return err
+	}
+	err = s.chunkRateLimiter.SetAccountLimits(time.Now(), limits)
+	if err != nil {
+		return err
+	}
+
+	s.logger.Info("Loaded the account limits",
+		"file", s.config.RateLimits.AccountLimitsFile, "accounts", len(limits.Accounts))
+	return nil
+}
+
+// watchAccountLimitsReload reloads the account limits whenever the relay receives SIGHUP, until the context is
+// cancelled.
+func (s *Server) watchAccountLimitsReload(ctx context.Context) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP)
+	go func() {
+		defer signal.Stop(signals)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-signals:
+				s.logger.Info("Received SIGHUP, reloading the account limits")
+				if err := s.ReloadAccountLimits(); err != nil {
+					s.logger.Error("Failed to reload the account limits, keeping the current ones", "err", err)
+				}
+			}
+		}
+	}()
+}
+
+// flushClientQuotas periodically writes the usage batched by the client quota limiter to the usage store, until the
+// context is cancelled.
+func (s *Server) flushClientQuotas(ctx context.Context) {
+	interval := s.config.RateLimits.ClientQuotaFlushInterval
+	if interval <= 0 {
+		// usage is written on every request, so only the clients of past periods are forgotten
+		interval = s.config.RateLimits.ClientQuotaPeriod
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.clientQuotaLimiter.Flush(ctx, time.Now()); err != nil {
+				s.logger.Error("Failed to record the usage of relay clients", "err", err)
+			}
+		}
+	}
+}
+
+// diskCacheDirectory returns the directory of a disk cache, or an empty string if nothing is cached on disk.
+func diskCacheDirectory(config *Config, name string) string {
+	if config.DiskCacheDirectory == "" {
+		return ""
+	}
+	return filepath.Join(config.DiskCacheDirectory, name)
 }
 
 // GetBlob retrieves a blob stored by the relay.
-func (s *Server) GetBlob(ctx context.Context, request *pb.GetBlobRequest) (*pb.GetBlobReply, error) {
-	start := time.Now()
+func (s *Server) GetBlob(ctx context.Context, request *pb.GetBlobRequest) (reply *pb.GetBlobReply, err error) {
+	access := newAccessLogEntry(ctx, pb.Relay_GetBlob_FullMethodName)
+	defer func() {
+		s.finishRequest(access, err)
+	}()
+
+	if s.config.Timeouts.GetBlobTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.config.Timeouts.GetBlobTimeout)
+		defer cancel()
+	}
+
+	data, err := s.getBlob(ctx, request, access)
+	if err != nil {
+		return nil, err
+	}
+
+	reply = &pb.GetBlobReply{
+		Blob: data,
+	}
+	return reply, nil
+}
+
+// GetBlobStream retrieves a blob stored by the relay, and sends it in parts of at most GetBlobStreamPartSize bytes.
+func (s *Server) GetBlobStream(request *pb.GetBlobRequest, stream pb.Relay_GetBlobStreamServer) (err error) {
+	ctx := stream.Context()
+	access := newAccessLogEntry(ctx, pb.Relay_GetBlobStream_FullMethodName)
+	defer func() {
+		s.finishRequest(access, err)
+	}()
 
 	if s.config.Timeouts.GetBlobTimeout > 0 {
 		var cancel context.CancelFunc
@@ -163,16 +380,60 @@ func (s *Server) GetBlob(ctx context.Context, request *pb.GetBlobRequest) (*pb.G
 		defer cancel()
 	}
 
+	data, err := s.getBlob(ctx, request, access)
+	if err != nil {
+		return err
+	}
+
+	for offset := 0; offset == 0 || offset < len(data); offset += s.config.GetBlobStreamPartSize {
+		part := &pb.GetBlobStreamReply{
+			Data: data[offset:min(offset+s.config.GetBlobStreamPartSize, len(data))],
+		}
+		if offset == 0 {
+			part.BlobSize = uint32(len(data))
+		}
+		err = stream.Send(part)
+		if err != nil {
+			return fmt.Errorf("error sending part of blob at offset %d: %w", offset, err)
+		}
+	}
+
+	return nil
+}
+
+// getBlob authenticates and rate limits a request for a blob, and fetches the blob. The method of the access log entry
+// is the method with which the client signed the request, if it authenticates with a signature.
+func (s *Server) getBlob(ctx context.Context, request *pb.GetBlobRequest, access *accessLogEntry) ([]byte, error) {
+	start := access.start
+
 	// Validate the request params before any further processing (as validation is cheaper)
 	key, err := v2.BytesToBlobKey(request.BlobKey)
 	if err != nil {
 		return nil, api.NewErrorInvalidArg(fmt.Sprintf("invalid blob key: %v", err))
 	}
 	s.logger.Debug("GetBlob request received", "key", key.Hex())
+	access.blobKeys = []v2.BlobKey{key}
+
+	if fleet.IsPeerRequest(ctx, s.config.Fleet.Secret) {
+		access.caller = callerPeer
+		return s.getBlobForPeer(ctx, key, access)
+	}
+
+	var relayClient *meterer.RelayClient
+	if s.clientAuthenticator != nil {
+		relayClient, err = s.clientAuthenticator.AuthenticateClient(
+			ctx, access.method, hashing.HashGetBlobRequest(request), time.Now())
+		if err != nil {
+			s.metrics.ReportBlobAuthFailure()
+			return nil, api.NewErrorUnauthenticated(fmt.Sprintf("client auth failed: %v", err))
+		}
+		access.caller = callerClient
+		access.client = relayClient.ClientID
+	}
 
 	err = s.blobRateLimiter.BeginGetBlobOperation(time.Now())
 	if err != nil {
-		return nil, api.NewErrorResourceExhausted(fmt.Sprintf("rate limit exceeded: %v", err))
+		return nil, rateLimitedError(fmt.Sprintf("rate limit exceeded: %v", err), err)
 	}
 	defer s.blobRateLimiter.FinishGetBlobOperation()
 
@@ -193,27 +454,40 @@ func (s *Server) GetBlob(ctx context.Context, request *pb.GetBlobRequest) (*pb.G
 	s.metrics.ReportBlobRequestedBandwidthUsage(int(metadata.blobSizeBytes))
 	err = s.blobRateLimiter.RequestGetBlobBandwidth(time.Now(), metadata.blobSizeBytes)
 	if err != nil {
-		return nil, api.NewErrorResourceExhausted(fmt.Sprintf("bandwidth limit exceeded: %v", err))
+		return nil, rateLimitedError(fmt.Sprintf("bandwidth limit exceeded: %v", err), err)
 	}
 
-	data, err := s.blobProvider.GetBlob(ctx, key)
+	if relayClient != nil {
+		err = s.clientQuotaLimiter.RequestQuota(ctx, relayClient, uint64(metadata.blobSizeBytes), time.Now())
+		if err != nil {
+			if errors.Is(err, limiter.ErrClientQuotaExceeded) {
+				s.metrics.ReportBlobRateLimited("client quota")
+				return nil, rateLimitedError(err.Error(), err)
+			}
+			return nil, api.NewErrorInternal(err.Error())
+		}
+	}
+
+	data, err := s.getBlobData(access.trackTiers(ctx), key, access)
 	if err != nil {
 		return nil, api.NewErrorInternal(fmt.Sprintf("error fetching blob %s: %v", key.Hex(), err))
 	}
+	access.bytes = len(data)
 
 	s.metrics.ReportBlobBandwidthUsage(len(data))
 	s.metrics.ReportBlobDataLatency(time.Since(finishedFetchingMetadata))
 	s.metrics.ReportBlobLatency(time.Since(start))
 
-	reply := &pb.GetBlobReply{
-		Blob: data,
-	}
-	return reply, nil
+	return data, nil
 }
 
 // GetChunks retrieves chunks from blobs stored by the relay.
-func (s *Server) GetChunks(ctx context.Context, request *pb.GetChunksRequest) (*pb.GetChunksReply, error) {
-	start := time.Now()
+func (s *Server) GetChunks(ctx context.Context, request *pb.GetChunksRequest) (reply *pb.GetChunksReply, err error) {
+	access := newAccessLogEntry(ctx, pb.Relay_GetChunks_FullMethodName)
+	defer func() {
+		s.finishRequest(access, err)
+	}()
+	start := access.start
 
 	if s.config.Timeouts.GetChunksTimeout > 0 {
 		var cancel context.CancelFunc
@@ -230,14 +504,43 @@ func (s *Server) GetChunks(ctx context.Context, request *pb.GetChunksRequest) (*
 	}
 	s.metrics.ReportChunkKeyCount(len(request.ChunkRequests))
 
-	if s.authenticator != nil {
+	// keys might contain duplicate keys
+	keys, err := getKeysFromChunkRequest(request)
+	if err != nil {
+		return nil, api.NewErrorInvalidArg(fmt.Sprintf("invalid request: %v", err))
+	}
+	access.blobKeys = keys
+
+	if fleet.IsPeerRequest(ctx, s.config.Fleet.Secret) {
+		access.caller = callerPeer
+		return s.getChunksForPeer(ctx, request, keys, access)
+	}
+
+	// Requests which aren't made by a validator are made by relay clients, which authenticate with the client
+	// authenticator if it is enabled.
+	var relayClient *meterer.RelayClient
+	if s.clientAuthenticator != nil && len(request.OperatorId) > 0 && s.authenticator == nil {
+		// Validators can't be authenticated, so a request claiming to be made by one would bypass client
+		// authentication.
+		s.metrics.ReportChunkAuthFailure()
+		return nil, api.NewErrorUnauthenticated(
+			"validator authentication is disabled, requests with an operator ID are not accepted")
+	}
+	if s.clientAuthenticator != nil && len(request.OperatorId) == 0 {
+		relayClient, err = s.clientAuthenticator.AuthenticateClient(
+			ctx, pb.Relay_GetChunks_FullMethodName, hashing.HashGetChunksRequest(request), time.Now())
+		if err != nil {
+			s.metrics.ReportChunkAuthFailure()
+			return nil, api.NewErrorUnauthenticated(fmt.Sprintf("client auth failed: %v", err))
+		}
+	} else if s.authenticator != nil {
 		client, ok := peer.FromContext(ctx)
 		if !ok {
 			return nil, api.NewErrorInvalidArg("could not get peer information")
 		}
 		clientAddress := client.Addr.String()
 
-		err := s.authenticator.AuthenticateGetChunksRequest(ctx, clientAddress, request, time.Now())
+		err = s.authenticator.AuthenticateGetChunksRequest(ctx, clientAddress, request, time.Now())
 		if err != nil {
 			s.metrics.ReportChunkAuthFailure()
 			s.logger.Debug("rejected GetChunks request", "client", clientAddress)
@@ -247,22 +550,25 @@ func (s *Server) GetChunks(ctx context.Context, request *pb.GetChunksRequest) (*
 	}
 
 	finishedAuthenticating := time.Now()
-	if s.authenticator != nil {
+	if s.authenticator != nil || relayClient != nil {
 		s.metrics.ReportChunkAuthenticationLatency(finishedAuthenticating.Sub(start))
 	}
 
-	clientID := string(request.OperatorId)
-	err := s.chunkRateLimiter.BeginGetChunkOperation(time.Now(), clientID)
-	if err != nil {
-		return nil, api.NewErrorResourceExhausted(fmt.Sprintf("rate limit exceeded: %v", err))
+	// The account of the request, whose rate limits apply to it
+	clientID := hex.EncodeToString(request.OperatorId)
+	if relayClient != nil {
+		clientID = relayClient.ClientID
+		access.caller = callerClient
+	} else if len(request.OperatorId) > 0 {
+		access.caller = callerValidator
 	}
-	defer s.chunkRateLimiter.FinishGetChunkOperation(clientID)
+	access.client = clientID
 
-	// keys might contain duplicate keys
-	keys, err := getKeysFromChunkRequest(request)
+	err = s.chunkRateLimiter.BeginGetChunkOperation(time.Now(), clientID)
 	if err != nil {
-		return nil, api.NewErrorInvalidArg(fmt.Sprintf("invalid request: %v", err))
+		return nil, rateLimitedError(fmt.Sprintf("rate limit exceeded: %v", err), err)
 	}
+	defer s.chunkRateLimiter.FinishGetChunkOperation(clientID)
 
 	mMap, err := s.metadataProvider.GetMetadataForBlobs(ctx, keys)
 	if err != nil {
@@ -287,15 +593,22 @@ func (s *Server) GetChunks(ctx context.Context, request *pb.GetChunksRequest) (*
 	}
 	s.metrics.ReportGetChunksBandwidthUsage(requiredBandwidth)
 
-	frames, err := s.chunkProvider.GetFrames(ctx, mMap)
-	if err != nil {
-		return nil, api.NewErrorInternal(fmt.Sprintf("error fetching frames: %v", err))
+	if relayClient != nil {
+		err = s.clientQuotaLimiter.RequestQuota(ctx, relayClient, uint64(requiredBandwidth), time.Now())
+		if err != nil {
+			if errors.Is(err, limiter.ErrClientQuotaExceeded) {
+				s.metrics.ReportChunkRateLimited("client quota")
+				return nil, rateLimitedError(err.Error(), err)
+			}
+			return nil, api.NewErrorInternal(err.Error())
+		}
 	}
 
-	bytesToSend, err := gatherChunkDataToSend(frames, request)
+	bytesToSend, err := s.getChunkData(access.trackTiers(ctx), request, mMap, access)
 	if err != nil {
-		return nil, api.NewErrorInternal(fmt.Sprintf("error gathering chunk data: %v", err))
+		return nil, api.NewErrorInternal(err.Error())
 	}
+	access.bytes = replySize(bytesToSend)
 
 	s.metrics.ReportChunkDataLatency(time.Since(finishedFetchingMetadata))
 	s.metrics.ReportChunkLatency(time.Since(start))
@@ -305,6 +618,219 @@ func (s *Server) GetChunks(ctx context.Context, request *pb.GetChunksRequest) (*
 	}, nil
 }
 
+// getBlobData fetches the data of a blob. If the relay is part of a fleet, blobs owned by a peer are fetched from it,
+// or from the blob store if the peer fails. Blobs fetched from peers are recorded in the access log entry.
+func (s *Server) getBlobData(ctx context.Context, key v2.BlobKey, access *accessLogEntry) ([]byte, error) {
+	if s.fleet != nil {
+		owner, local := s.fleet.Owner(key)
+		if !local {
+			data, err := s.fleet.GetBlob(ctx, owner, key)
+			if err == nil {
+				s.metrics.ReportFleetForward("GetBlob", "success")
+				access.forwarded = true
+				return data, nil
+			}
+			s.metrics.ReportFleetForward("GetBlob", "failure")
+			s.logger.Warn("failed to fetch blob from its owner, fetching it locally", "key", key.Hex(), "err", err)
+		}
+	}
+
+	data, err := s.blobProvider.GetBlob(ctx, key)
+	if err == nil || s.fallback == nil {
+		return data, err
+	}
+
+	data, relay, fallbackErr := s.fallback.GetBlob(ctx, key)
+	if fallbackErr != nil {
+		s.metrics.ReportFallback("GetBlob", "failure")
+		s.logger.Warn("failed to fetch blob from the fallback relays", "key", key.Hex(), "err", fallbackErr)
+		return nil, err
+	}
+	s.metrics.ReportFallback("GetBlob", "success")
+	s.logger.Info("fetched blob from a fallback relay", "key", key.Hex(), "relay", relay, "localErr", err)
+	access.forwarded = true
+	return data, nil
+}
+
+// getBlobForPeer serves a GetBlob request forwarded by a peer of the fleet, which already authenticated and rate
+// limited it.
+func (s *Server) getBlobForPeer(ctx context.Context, key v2.BlobKey, access *accessLogEntry) ([]byte, error) {
+	data, err := s.blobProvider.GetBlob(access.trackTiers(ctx), key)
+	if err != nil {
+		return nil, api.NewErrorInternal(fmt.Sprintf("error fetching blob %s: %v", key.Hex(), err))
+	}
+	access.bytes = len(data)
+	return data, nil
+}
+
+// getChunkData fetches the data of each chunk request of a GetChunks request. If the relay is part of a fleet, the
+// chunks of blobs owned by a peer are fetched from it, or from the chunk store if the peer fails. Chunks fetched from
+// peers are recorded in the access log entry.
+func (s *Server) getChunkData(
+	ctx context.Context,
+	request *pb.GetChunksRequest,
+	mMap metadataMap,
+	access *accessLogEntry) ([][]byte, error) {
+
+	bytesToSend := make([][]byte, len(request.ChunkRequests))
+
+	// the indices of the chunk requests served locally, and of those served by each peer
+	localIndices := make([]int, 0, len(request.ChunkRequests))
+	peerIndices := make(map[string][]int)
+	for i, chunkRequest := range request.ChunkRequests {
+		if s.fleet == nil {
+			localIndices = append(localIndices, i)
+			continue
+		}
+		owner, local := s.fleet.Owner(v2.BlobKey(getBlobKeyFromChunkRequest(chunkRequest)))
+		if local {
+			localIndices = append(localIndices, i)
+		} else {
+			peerIndices[owner] = append(peerIndices[owner], i)
+		}
+	}
+
+	if len(peerIndices) > 0 {
+		var lock sync.Mutex
+		wg := sync.WaitGroup{}
+		for peer, indices := range peerIndices {
+			wg.Add(1)
+			go func(peer string, indices []int) {
+				defer wg.Done()
+
+				chunkRequests := make([]*pb.ChunkRequest, len(indices))
+				for i, index := range indices {
+					chunkRequests[i] = request.ChunkRequests[index]
+				}
+				data, err := s.fleet.GetChunks(ctx, peer, chunkRequests)
+
+				lock.Lock()
+				defer lock.Unlock()
+				if err != nil {
+					s.metrics.ReportFleetForward("GetChunks", "failure")
+					s.logger.Warn("failed to fetch chunks from their owner, fetching them locally",
+						"relay", peer, "err", err)
+					localIndices = append(localIndices, indices...)
+					return
+				}
+				s.metrics.ReportFleetForward("GetChunks", "success")
+				access.forwarded = true
+				for i, index := range indices {
+					bytesToSend[index] = data[i]
+				}
+			}(peer, indices)
+		}
+		wg.Wait()
+	}
+
+	if len(localIndices) == 0 {
+		return bytesToSend, nil
+	}
+
+	localRequest := request
+	localMetadata := mMap
+	if len(localIndices) < len(request.ChunkRequests) {
+		localRequest = &pb.GetChunksRequest{ChunkRequests: make([]*pb.ChunkRequest, len(localIndices))}
+		localMetadata = make(metadataMap)
+		for i, index := range localIndices {
+			chunkRequest := request.ChunkRequests[index]
+			localRequest.ChunkRequests[i] = chunkRequest
+			key := v2.BlobKey(getBlobKeyFromChunkRequest(chunkRequest))
+			localMetadata[key] = mMap[key]
+		}
+	}
+
+	localData, err := s.getLocalChunkData(ctx, localRequest, localMetadata, access)
+	if err != nil {
+		return nil, err
+	}
+	for i, index := range localIndices {
+		bytesToSend[index] = localData[i]
+	}
+
+	return bytesToSend, nil
+}
+
+// getLocalChunkData fetches the data of each chunk request of a GetChunks request from the chunk store, or from the
+// fallback relays if that fails.
+func (s *Server) getLocalChunkData(
+	ctx context.Context,
+	request *pb.GetChunksRequest,
+	mMap metadataMap,
+	access *accessLogEntry) ([][]byte, error) {
+
+	frames, err := s.getFrames(ctx, request, mMap)
+	if err != nil {
+		err = fmt.Errorf("error fetching frames: %w", err)
+	} else {
+		var data [][]byte
+		data, err = gatherChunkDataToSend(frames, request)
+		if err == nil {
+			return data, nil
+		}
+		err = fmt.Errorf("error gathering chunk data: %w", err)
+	}
+	if s.fallback == nil {
+		return nil, err
+	}
+
+	data, relay, fallbackErr := s.fallback.GetChunks(ctx, request.ChunkRequests)
+	if fallbackErr != nil {
+		s.metrics.ReportFallback("GetChunks", "failure")
+		s.logger.Warn("failed to fetch chunks from the fallback relays", "err", fallbackErr)
+		return nil, err
+	}
+	s.metrics.ReportFallback("GetChunks", "success")
+	s.logger.Info("fetched chunks from a fallback relay", "relay", relay, "localErr", err)
+	access.forwarded = true
+	return data, nil
+}
+
+// getChunksForPeer serves a GetChunks request forwarded by a peer of the fleet, which already authenticated and rate
+// limited it.
+func (s *Server) getChunksForPeer(
+	ctx context.Context,
+	request *pb.GetChunksRequest,
+	keys []v2.BlobKey,
+	access *accessLogEntry) (*pb.GetChunksReply, error) {
+
+	mMap, err := s.metadataProvider.GetMetadataForBlobs(ctx, keys)
+	if err != nil {
+		return nil, api.NewErrorInternal(fmt.Sprintf(
+			"error fetching metadata for blob, check if blob exists and is assigned to this relay: %v", err))
+	}
+
+	frames, err := s.getFrames(access.trackTiers(ctx), request, mMap)
+	if err != nil {
+		return nil, api.NewErrorInternal(fmt.Sprintf("error fetching frames: %v", err))
+	}
+
+	bytesToSend, err := gatherChunkDataToSend(frames, request)
+	if err != nil {
+		return nil, api.NewErrorInternal(fmt.Sprintf("error gathering chunk data: %v", err))
+	}
+	access.bytes = replySize(bytesToSend)
+
+	return &pb.GetChunksReply{Data: bytesToSend}, nil
+}
+
+// replySize returns the size of the data of a GetChunks reply.
+func replySize(data [][]byte) int {
+	size := 0
+	for _, chunkData := range data {
+		size += len(chunkData)
+	}
+	return size
+}
+
+// getBlobKeyFromChunkRequest returns the blob key of a chunk request, which may be invalid.
+func getBlobKeyFromChunkRequest(chunkRequest *pb.ChunkRequest) []byte {
+	if chunkRequest.GetByIndex() != nil {
+		return chunkRequest.GetByIndex().GetBlobKey()
+	}
+	return chunkRequest.GetByRange().GetBlobKey()
+}
+
 // getKeysFromChunkRequest gathers a slice of blob keys from a GetChunks request.
 func getKeysFromChunkRequest(request *pb.GetChunksRequest) ([]v2.BlobKey, error) {
 	keys := make([]v2.BlobKey, 0, len(request.ChunkRequests))
@@ -330,6 +856,81 @@ func getKeysFromChunkRequest(request *pb.GetChunksRequest) ([]v2.BlobKey, error)
 	return keys, nil
 }
 
+// getFrames fetches the frames needed to serve a GetChunks request. If ranged chunk reads are enabled, the frames of
+// the blobs which are requested by range only are fetched with ranged reads, and the frames of the other blobs are
+// fetched whole.
+func (s *Server) getFrames(ctx context.Context, request *pb.GetChunksRequest, mMap metadataMap) (frameMap, error) {
+	if !s.config.RangedChunkReads {
+		return s.chunkProvider.GetFrames(ctx, mMap)
+	}
+
+	ranges := getChunkRangesFromChunkRequest(request, mMap)
+	if len(ranges) == 0 {
+		return s.chunkProvider.GetFrames(ctx, mMap)
+	}
+
+	frames, err := s.chunkProvider.GetFrameRanges(ctx, mMap, ranges)
+	if err != nil {
+		return nil, err
+	}
+
+	wholeBlobs := make(metadataMap, len(mMap)-len(ranges))
+	for key, metadata := range mMap {
+		if _, ok := ranges[key]; !ok {
+			wholeBlobs[key] = metadata
+		}
+	}
+	if len(wholeBlobs) == 0 {
+		return frames, nil
+	}
+
+	wholeFrames, err := s.chunkProvider.GetFrames(ctx, wholeBlobs)
+	if err != nil {
+		return nil, err
+	}
+	for key, data := range wholeFrames {
+		frames[key] = data
+	}
+
+	return frames, nil
+}
+
+// getChunkRangesFromChunkRequest returns the range of chunks spanning all the requests for each blob which is only
+// requested by range. Blobs which are requested by index, or whose ranges are empty or out of bounds, are left out.
+func getChunkRangesFromChunkRequest(request *pb.GetChunksRequest, mMap metadataMap) map[v2.BlobKey]chunkRange {
+	ranges := make(map[v2.BlobKey]chunkRange)
+	excluded := make(map[v2.BlobKey]struct{})
+
+	for _, chunkRequest := range request.ChunkRequests {
+		if chunkRequest.GetByIndex() != nil {
+			excluded[v2.BlobKey(chunkRequest.GetByIndex().GetBlobKey())] = struct{}{}
+			continue
+		}
+
+		byRange := chunkRequest.GetByRange()
+		key := v2.BlobKey(byRange.GetBlobKey())
+		metadata, ok := mMap[key]
+		if !ok || byRange.StartIndex >= byRange.EndIndex || byRange.EndIndex > metadata.numChunks {
+			excluded[key] = struct{}{}
+			continue
+		}
+
+		span, ok := ranges[key]
+		if !ok {
+			span = chunkRange{startIndex: byRange.StartIndex, endIndex: byRange.EndIndex}
+		}
+		span.startIndex = min(span.startIndex, byRange.StartIndex)
+		span.endIndex = max(span.endIndex, byRange.EndIndex)
+		ranges[key] = span
+	}
+
+	for key := range excluded {
+		delete(ranges, key)
+	}
+
+	return ranges
+}
+
 // gatherChunkDataToSend takes the chunk data and narrows it down to the data requested in the GetChunks request.
 func gatherChunkDataToSend(
 	frames map[v2.BlobKey]*core.ChunksData,
@@ -484,8 +1085,18 @@ func buildInsufficientGetChunksBandwidthError(
 
 	blobCount := len(request.ChunkRequests)
 
-	return api.NewErrorResourceExhausted(fmt.Sprintf("unable to serve data (%d blobs, %d chunks, %d bytes): %v",
-		blobCount, chunkCount, requiredBandwidth, originalError))
+	return rateLimitedError(fmt.Sprintf("unable to serve data (%d blobs, %d chunks, %d bytes): %v",
+		blobCount, chunkCount, requiredBandwidth, originalError), originalError)
+}
+
+// rateLimitedError builds the ResourceExhausted error for a request refused by a rate limiter. If the rate limiter
+// knows when the request would be allowed, the error tells the client how long to wait before retrying.
+func rateLimitedError(msg string, limiterError error) error {
+	var limitErr *limiter.RateLimitError
+	if errors.As(limiterError, &limitErr) && limitErr.RetryAfter > 0 {
+		return api.NewErrorResourceExhaustedWithRetryAfter(msg, limitErr.RetryAfter)
+	}
+	return api.NewErrorResourceExhausted(msg)
 }
 
 // Start starts the server listening for requests. This method will block until the server is stopped.
@@ -509,6 +1120,19 @@ func (s *Server) Start(ctx context.Context) error {
 		}()
 	}
 
+	if s.config.RateLimits.AccountLimitsFile != "" {
+		s.watchAccountLimitsReload(ctx)
+	}
+
+	if s.clientQuotaLimiter != nil {
+		go s.flushClientQuotas(ctx)
+	}
+
+	if s.cacheWarmer != nil {
+		go s.cacheWarmer.run(ctx)
+		s.logger.Info("Enabled cache warming", "interval", s.config.CacheWarmingInterval)
+	}
+
 	// Serve grpc requests
 	addr := fmt.Sprintf("0.0.0.0:%d", s.config.GRPCPort)
 	listener, err := net.Listen("tcp", addr)
@@ -524,7 +1148,34 @@ func (s *Server) Start(ctx context.Context) error {
 
 	// Register Server for Health Checks
 	name := pb.Relay_ServiceDesc.ServiceName
-	healthcheck.RegisterHealthServer(name, s.grpcServer)
+	dependencies := []healthcheck.Dependency{
+		{
+			Name: "dynamodb",
+			Check: func(ctx context.Context) error {
+				_, err := s.metadataProvider.metadataStore.GetBlobMetadata(ctx, v2.BlobKey{})
+				if err != nil && !errors.Is(err, dispcommon.ErrMetadataNotFound) {
+					return err
+				}
+				return nil
+			},
+		},
+		{
+			Name:  "s3",
+			Check: s.blobProvider.blobStore.CheckHealth,
+		},
+	}
+	if s.chainReader != nil {
+		dependencies = append(dependencies, healthcheck.Dependency{
+			Name: "chain",
+			Check: func(ctx context.Context) error {
+				_, err := s.chainReader.GetCurrentBlockNumber(ctx)
+				return err
+			},
+		})
+	}
+	dependencyChecker := healthcheck.NewDependencyChecker(s.logger, dependencies...)
+	dependencyChecker.RegisterHealthServer(name, s.grpcServer)
+	dependencyChecker.Start(ctx)
 
 	s.logger.Info("GRPC Listening", "port", s.config.GRPCPort, "address", listener.Addr().String())
 	if err = s.grpcServer.Serve(listener); err != nil {
@@ -560,6 +1211,30 @@ func (s *Server) Stop() error {
 		s.grpcServer.GracefulStop()
 	}
 
+	if s.clientQuotaLimiter != nil {
+		// record the usage batched since the last flush, so that it is billed
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		err := s.clientQuotaLimiter.Flush(ctx, time.Now())
+		cancel()
+		if err != nil {
+			s.logger.Error("Failed to record the usage of relay clients", "err", err)
+		}
+	}
+
+	if s.fleet != nil {
+		err := s.fleet.Close()
+		if err != nil {
+			return fmt.Errorf("error closing connections to the fleet: %w", err)
+		}
+	}
+
+	if s.fallback != nil {
+		err := s.fallback.Close()
+		if err != nil {
+			return fmt.Errorf("error closing connections to the fallback relays: %w", err)
+		}
+	}
+
 	if s.config.EnableMetrics {
 		err := s.metrics.Stop()
 		if err != nil {
@@ -3,7 +3,9 @@ package relay
 import (
 	"context"
 	"encoding/binary"
+	"errors"
 	"github.com/docker/go-units"
+	"io"
 	"testing"
 	"time"
 
@@ -15,10 +17,13 @@ import (
 
 	pb "github.com/Layr-Labs/eigenda/api/grpc/relay"
 	"github.com/Layr-Labs/eigenda/common"
+	awsmock "github.com/Layr-Labs/eigenda/common/aws/mock"
 	tu "github.com/Layr-Labs/eigenda/common/testutils"
 	"github.com/Layr-Labs/eigenda/core"
 	v2 "github.com/Layr-Labs/eigenda/core/v2"
+	"github.com/Layr-Labs/eigenda/disperser/common/v2/blobstore"
 	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/Layr-Labs/eigenda/relay/chunkstore"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
@@ -28,6 +33,7 @@ func defaultConfig() *Config {
 	return &Config{
 		GRPCPort:                   50051,
 		MaxGRPCMessageSize:         units.MB,
+		GetBlobStreamPartSize:      units.KiB,
 		MetadataCacheSize:          1024 * 1024,
 		MetadataMaxConcurrency:     32,
 		BlobCacheBytes:             1024 * 1024,
@@ -82,6 +88,35 @@ func getBlob(t *testing.T, request *pb.GetBlobRequest) (*pb.GetBlobReply, error)
 	return response, err
 }
 
+// getBlobStream retrieves a blob with GetBlobStream, and returns the parts in which it was sent.
+func getBlobStream(t *testing.T, request *pb.GetBlobRequest) ([]*pb.GetBlobStreamReply, error) {
+	var opts []grpc.DialOption
+	opts = append(opts, grpc.WithTransportCredentials(insecure.NewCredentials()))
+
+	conn, err := grpc.NewClient("0.0.0.0:50051", opts...)
+	require.NoError(t, err)
+	defer func() {
+		err = conn.Close()
+		require.NoError(t, err)
+	}()
+
+	client := pb.NewRelayClient(conn)
+	stream, err := client.GetBlobStream(context.Background(), request)
+	require.NoError(t, err)
+
+	var parts []*pb.GetBlobStreamReply
+	for {
+		part, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return parts, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, part)
+	}
+}
+
 func getChunks(
 	t *testing.T,
 	random *random.TestRandom,
@@ -141,7 +176,8 @@ func TestReadWriteBlobs(t *testing.T) {
 		blobStore,
 		nil, /* not used in this test*/
 		chainReader,
-		ics)
+		ics,
+		nil)
 	require.NoError(t, err)
 
 	go func() {
@@ -230,7 +266,8 @@ func TestReadNonExistentBlob(t *testing.T) {
 		blobStore,
 		nil, /* not used in this test */
 		chainReader,
-		ics)
+		ics,
+		nil)
 	require.NoError(t, err)
 
 	go func() {
@@ -253,6 +290,102 @@ func TestReadNonExistentBlob(t *testing.T) {
 	}
 }
 
+func TestReadWriteBlobsStream(t *testing.T) {
+	rand := random.NewTestRandom()
+
+	logger, err := common.NewLogger(common.DefaultLoggerConfig())
+	require.NoError(t, err)
+
+	setup(t)
+	defer teardown()
+
+	// These are used to write data to S3/dynamoDB
+	metadataStore := buildMetadataStore(t)
+	blobStore := buildBlobStore(t, logger)
+	chainReader := newMockChainReader()
+
+	ics := &mock.IndexedChainState{}
+	blockNumber := uint(rand.Uint32())
+	ics.Mock.On("GetCurrentBlockNumber").Return(blockNumber, nil)
+	operatorInfo := make(map[core.OperatorID]*core.IndexedOperatorInfo)
+	ics.Mock.On("GetIndexedOperators", blockNumber).Return(operatorInfo, nil)
+
+	// This is the server used to read it back. The blobs are larger than the parts, so they're sent in several parts.
+	config := defaultConfig()
+	config.GetBlobStreamPartSize = 64
+	server, err := NewServer(
+		context.Background(),
+		logger,
+		config,
+		metadataStore,
+		blobStore,
+		nil, /* not used in this test*/
+		chainReader,
+		ics,
+		nil)
+	require.NoError(t, err)
+
+	go func() {
+		err = server.Start(context.Background())
+		require.NoError(t, err)
+	}()
+	defer func() {
+		err = server.Stop()
+		require.NoError(t, err)
+	}()
+
+	expectedData := make(map[v2.BlobKey][]byte)
+
+	blobCount := 10
+	for i := 0; i < blobCount; i++ {
+		header, data := randomBlob(t)
+
+		blobKey, err := header.BlobKey()
+		require.NoError(t, err)
+		expectedData[blobKey] = data
+
+		err = metadataStore.PutBlobCertificate(
+			context.Background(),
+			&v2.BlobCertificate{
+				BlobHeader: header,
+			},
+			&encoding.FragmentInfo{})
+		require.NoError(t, err)
+
+		err = blobStore.StoreBlob(context.Background(), blobKey, data)
+		require.NoError(t, err)
+	}
+
+	// Read the blobs back.
+	for key, data := range expectedData {
+		request := &pb.GetBlobRequest{
+			BlobKey: key[:],
+		}
+
+		parts, err := getBlobStream(t, request)
+		require.NoError(t, err)
+
+		require.Len(t, parts, (len(data)+config.GetBlobStreamPartSize-1)/config.GetBlobStreamPartSize)
+		require.Equal(t, uint32(len(data)), parts[0].BlobSize)
+		blob := make([]byte, 0, len(data))
+		for i, part := range parts {
+			require.LessOrEqual(t, len(part.Data), config.GetBlobStreamPartSize)
+			if i > 0 {
+				require.Zero(t, part.BlobSize)
+			}
+			blob = append(blob, part.Data...)
+		}
+		require.Equal(t, data, blob)
+	}
+
+	// Blobs which don't exist are reported as errors.
+	request := &pb.GetBlobRequest{
+		BlobKey: rand.Bytes(32),
+	}
+	_, err = getBlobStream(t, request)
+	require.Error(t, err)
+}
+
 func TestReadWriteBlobsWithSharding(t *testing.T) {
 	rand := random.NewTestRandom()
 
@@ -294,7 +427,8 @@ func TestReadWriteBlobsWithSharding(t *testing.T) {
 		blobStore,
 		nil, /* not used in this test*/
 		chainReader,
-		ics)
+		ics,
+		nil)
 	require.NoError(t, err)
 
 	go func() {
@@ -438,7 +572,8 @@ func TestReadWriteChunks(t *testing.T) {
 		nil, /* not used in this test*/
 		chunkReader,
 		chainReader,
-		ics)
+		ics,
+		nil)
 	require.NoError(t, err)
 
 	go func() {
@@ -660,7 +795,8 @@ func TestBatchedReadWriteChunks(t *testing.T) {
 		nil, /* not used in this test */
 		chunkReader,
 		chainReader,
-		ics)
+		ics,
+		nil)
 	require.NoError(t, err)
 
 	go func() {
@@ -812,7 +948,8 @@ func TestReadWriteChunksWithSharding(t *testing.T) {
 		nil, /* not used in this test*/
 		chunkReader,
 		chainReader,
-		ics)
+		ics,
+		nil)
 	require.NoError(t, err)
 
 	go func() {
@@ -1113,7 +1250,8 @@ func TestBatchedReadWriteChunksWithSharding(t *testing.T) {
 		nil, /* not used in this test */
 		chunkReader,
 		chainReader,
-		ics)
+		ics,
+		nil)
 	require.NoError(t, err)
 
 	go func() {
@@ -1253,3 +1391,352 @@ func TestBatchedReadWriteChunksWithSharding(t *testing.T) {
 		}
 	}
 }
+
+func TestGetChunkRangesFromChunkRequest(t *testing.T) {
+	byRange := func(key v2.BlobKey, startIndex uint32, endIndex uint32) *pb.ChunkRequest {
+		return &pb.ChunkRequest{
+			Request: &pb.ChunkRequest_ByRange{
+				ByRange: &pb.ChunkRequestByRange{
+					BlobKey:    key[:],
+					StartIndex: startIndex,
+					EndIndex:   endIndex,
+				},
+			},
+		}
+	}
+
+	rangedKey := v2.BlobKey{1}
+	indexedKey := v2.BlobKey{2}
+	outOfBoundsKey := v2.BlobKey{3}
+	mMap := metadataMap{
+		rangedKey:      &blobMetadata{numChunks: 16},
+		indexedKey:     &blobMetadata{numChunks: 16},
+		outOfBoundsKey: &blobMetadata{numChunks: 16},
+	}
+
+	request := &pb.GetChunksRequest{
+		ChunkRequests: []*pb.ChunkRequest{
+			byRange(rangedKey, 2, 4),
+			byRange(rangedKey, 8, 10),
+			byRange(indexedKey, 0, 4),
+			{
+				Request: &pb.ChunkRequest_ByIndex{
+					ByIndex: &pb.ChunkRequestByIndex{
+						BlobKey:      indexedKey[:],
+						ChunkIndices: []uint32{5},
+					},
+				},
+			},
+			byRange(outOfBoundsKey, 0, 17),
+		},
+	}
+
+	ranges := getChunkRangesFromChunkRequest(request, mMap)
+	require.Equal(t, map[v2.BlobKey]chunkRange{rangedKey: {startIndex: 2, endIndex: 10}}, ranges)
+}
+
+func TestFleetForwarding(t *testing.T) {
+	rand := random.NewTestRandom()
+
+	logger, err := common.NewLogger(common.DefaultLoggerConfig())
+	require.NoError(t, err)
+
+	setup(t)
+	defer teardown()
+
+	// These are used to write data to S3/dynamoDB
+	metadataStore := buildMetadataStore(t)
+	blobStore := buildBlobStore(t, logger)
+	chunkReader, chunkWriter := buildChunkStore(t, logger)
+
+	operatorKeys := make(map[uint32]*core.KeyPair)
+	operatorInfo := make(map[core.OperatorID]*core.IndexedOperatorInfo)
+	keypair, err := rand.BLS()
+	require.NoError(t, err)
+	operatorKeys[0] = keypair
+	operatorInfo[core.OperatorID{}] = &core.IndexedOperatorInfo{
+		PubkeyG1: keypair.GetPubKeyG1(),
+		PubkeyG2: keypair.GetPubKeyG2(),
+	}
+
+	ics := &mock.IndexedChainState{}
+	blockNumber := uint(rand.Uint32())
+	ics.Mock.On("GetCurrentBlockNumber").Return(blockNumber, nil)
+	ics.Mock.On("GetIndexedOperators", blockNumber).Return(operatorInfo, nil)
+
+	// Requests are sent to the first relay, which forwards those for blobs owned by the second relay to it.
+	relays := []string{"localhost:50051", "localhost:50052"}
+	servers := make([]*Server, len(relays))
+	for i := range relays {
+		config := defaultConfig()
+		config.GRPCPort = 50051 + i
+		config.RateLimits.MaxGetChunkOpsPerSecondClient = 1000
+		config.RateLimits.GetChunkOpsBurstinessClient = 1000
+		config.Fleet.Relays = relays
+		config.Fleet.Self = relays[i]
+		config.Fleet.VirtualNodes = 16
+		config.Fleet.Secret = "fleet secret"
+
+		server, err := NewServer(
+			context.Background(),
+			logger,
+			config,
+			metadataStore,
+			blobStore,
+			chunkReader,
+			newMockChainReader(),
+			ics,
+			nil)
+		require.NoError(t, err)
+		servers[i] = server
+
+		go func() {
+			err := server.Start(context.Background())
+			require.NoError(t, err)
+		}()
+	}
+	defer func() {
+		err = servers[0].Stop()
+		require.NoError(t, err)
+	}()
+
+	expectedBlobs := make(map[v2.BlobKey][]byte)
+	expectedChunks := make(map[v2.BlobKey][]*encoding.Frame)
+	owners := make(map[v2.BlobKey]int)
+
+	blobCount := 20
+	for i := 0; i < blobCount; i++ {
+		header, data, chunks := randomBlobChunks(t)
+
+		blobKey, err := header.BlobKey()
+		require.NoError(t, err)
+		expectedBlobs[blobKey] = data
+		expectedChunks[blobKey] = chunks
+
+		owner, _ := servers[0].fleet.Owner(blobKey)
+		if owner == relays[1] {
+			owners[blobKey] = 1
+		}
+
+		err = blobStore.StoreBlob(context.Background(), blobKey, data)
+		require.NoError(t, err)
+
+		coeffs, chunkProofs := disassembleFrames(chunks)
+		err = chunkWriter.PutFrameProofs(context.Background(), blobKey, chunkProofs)
+		require.NoError(t, err)
+		fragmentInfo, err := chunkWriter.PutFrameCoefficients(context.Background(), blobKey, coeffs)
+		require.NoError(t, err)
+
+		err = metadataStore.PutBlobCertificate(
+			context.Background(),
+			&v2.BlobCertificate{
+				BlobHeader: header,
+			},
+			&encoding.FragmentInfo{
+				TotalChunkSizeBytes: fragmentInfo.TotalChunkSizeBytes,
+				FragmentSizeBytes:   fragmentInfo.FragmentSizeBytes,
+			})
+		require.NoError(t, err)
+	}
+
+	// Each blob is only cached by its owner.
+	for key, data := range expectedBlobs {
+		response, err := getBlob(t, &pb.GetBlobRequest{BlobKey: key[:]})
+		require.NoError(t, err)
+		require.Equal(t, data, response.Blob)
+
+		owner := owners[key]
+		_, ok := servers[owner].blobProvider.blobCache.Peek(key)
+		require.True(t, ok)
+		_, ok = servers[1-owner].blobProvider.blobCache.Peek(key)
+		require.False(t, ok)
+	}
+
+	// Chunks of blobs owned by both relays can be requested together.
+	requestedChunks := make([]*pb.ChunkRequest, 0, len(expectedChunks))
+	keys := make([]v2.BlobKey, 0, len(expectedChunks))
+	for key, chunks := range expectedChunks {
+		boundKey := key
+		keys = append(keys, key)
+		requestedChunks = append(requestedChunks, &pb.ChunkRequest{
+			Request: &pb.ChunkRequest_ByRange{
+				ByRange: &pb.ChunkRequestByRange{
+					BlobKey:    boundKey[:],
+					StartIndex: 0,
+					EndIndex:   uint32(len(chunks)),
+				},
+			},
+		})
+	}
+	response, err := getChunks(t, rand, operatorKeys, &pb.GetChunksRequest{ChunkRequests: requestedChunks})
+	require.NoError(t, err)
+	require.Equal(t, len(keys), len(response.Data))
+	for i, key := range keys {
+		bundle, err := core.Bundle{}.Deserialize(response.Data[i])
+		require.NoError(t, err)
+		require.Equal(t, len(expectedChunks[key]), len(bundle))
+		for j, frame := range bundle {
+			require.Equal(t, expectedChunks[key][j], frame)
+		}
+	}
+
+	// If the owner of a blob is down, the blob is fetched locally.
+	err = servers[1].Stop()
+	require.NoError(t, err)
+	for key, data := range expectedBlobs {
+		response, err := getBlob(t, &pb.GetBlobRequest{BlobKey: key[:]})
+		require.NoError(t, err)
+		require.Equal(t, data, response.Blob)
+	}
+}
+
+func TestFallback(t *testing.T) {
+	rand := random.NewTestRandom()
+
+	logger, err := common.NewLogger(common.DefaultLoggerConfig())
+	require.NoError(t, err)
+
+	setup(t)
+	defer teardown()
+
+	// These are used to write data to S3/dynamoDB
+	metadataStore := buildMetadataStore(t)
+	blobStore := buildBlobStore(t, logger)
+	chunkReader, chunkWriter := buildChunkStore(t, logger)
+
+	// The first relay reads from an empty object store, as if its object store was down.
+	emptyS3 := awsmock.NewS3Client()
+	emptyBlobStore := blobstore.NewBlobStore(bucketName, emptyS3, nil, logger)
+	emptyChunkReader := chunkstore.NewChunkReader(logger, emptyS3, bucketName, nil)
+
+	operatorKeys := make(map[uint32]*core.KeyPair)
+	operatorInfo := make(map[core.OperatorID]*core.IndexedOperatorInfo)
+	keypair, err := rand.BLS()
+	require.NoError(t, err)
+	operatorKeys[0] = keypair
+	operatorInfo[core.OperatorID{}] = &core.IndexedOperatorInfo{
+		PubkeyG1: keypair.GetPubKeyG1(),
+		PubkeyG2: keypair.GetPubKeyG2(),
+	}
+
+	ics := &mock.IndexedChainState{}
+	blockNumber := uint(rand.Uint32())
+	ics.Mock.On("GetCurrentBlockNumber").Return(blockNumber, nil)
+	ics.Mock.On("GetIndexedOperators", blockNumber).Return(operatorInfo, nil)
+
+	// Requests are sent to the first relay, which falls back to the second relay.
+	blobStores := []*blobstore.BlobStore{emptyBlobStore, blobStore}
+	chunkReaders := []chunkstore.ChunkReader{emptyChunkReader, chunkReader}
+	servers := make([]*Server, 2)
+	for i := range servers {
+		config := defaultConfig()
+		config.GRPCPort = 50051 + i
+		config.RateLimits.MaxGetChunkOpsPerSecondClient = 1000
+		config.RateLimits.GetChunkOpsBurstinessClient = 1000
+		config.Fleet.Secret = "fleet secret"
+		if i == 0 {
+			config.Fleet.Fallbacks = []string{"localhost:50052"}
+		}
+
+		server, err := NewServer(
+			context.Background(),
+			logger,
+			config,
+			metadataStore,
+			blobStores[i],
+			chunkReaders[i],
+			newMockChainReader(),
+			ics,
+			nil)
+		require.NoError(t, err)
+		servers[i] = server
+
+		go func() {
+			err := server.Start(context.Background())
+			require.NoError(t, err)
+		}()
+	}
+	defer func() {
+		err = servers[0].Stop()
+		require.NoError(t, err)
+	}()
+
+	expectedBlobs := make(map[v2.BlobKey][]byte)
+	expectedChunks := make(map[v2.BlobKey][]*encoding.Frame)
+
+	blobCount := 10
+	for i := 0; i < blobCount; i++ {
+		header, data, chunks := randomBlobChunks(t)
+
+		blobKey, err := header.BlobKey()
+		require.NoError(t, err)
+		expectedBlobs[blobKey] = data
+		expectedChunks[blobKey] = chunks
+
+		err = blobStore.StoreBlob(context.Background(), blobKey, data)
+		require.NoError(t, err)
+
+		coeffs, chunkProofs := disassembleFrames(chunks)
+		err = chunkWriter.PutFrameProofs(context.Background(), blobKey, chunkProofs)
+		require.NoError(t, err)
+		fragmentInfo, err := chunkWriter.PutFrameCoefficients(context.Background(), blobKey, coeffs)
+		require.NoError(t, err)
+
+		err = metadataStore.PutBlobCertificate(
+			context.Background(),
+			&v2.BlobCertificate{
+				BlobHeader: header,
+			},
+			&encoding.FragmentInfo{
+				TotalChunkSizeBytes: fragmentInfo.TotalChunkSizeBytes,
+				FragmentSizeBytes:   fragmentInfo.FragmentSizeBytes,
+			})
+		require.NoError(t, err)
+	}
+
+	// Blobs are fetched from the fallback relay.
+	for key, data := range expectedBlobs {
+		response, err := getBlob(t, &pb.GetBlobRequest{BlobKey: key[:]})
+		require.NoError(t, err)
+		require.Equal(t, data, response.Blob)
+
+		_, ok := servers[1].blobProvider.blobCache.Peek(key)
+		require.True(t, ok)
+	}
+
+	// So are chunks.
+	requestedChunks := make([]*pb.ChunkRequest, 0, len(expectedChunks))
+	keys := make([]v2.BlobKey, 0, len(expectedChunks))
+	for key, chunks := range expectedChunks {
+		boundKey := key
+		keys = append(keys, key)
+		requestedChunks = append(requestedChunks, &pb.ChunkRequest{
+			Request: &pb.ChunkRequest_ByRange{
+				ByRange: &pb.ChunkRequestByRange{
+					BlobKey:    boundKey[:],
+					StartIndex: 0,
+					EndIndex:   uint32(len(chunks)),
+				},
+			},
+		})
+	}
+	response, err := getChunks(t, rand, operatorKeys, &pb.GetChunksRequest{ChunkRequests: requestedChunks})
+	require.NoError(t, err)
+	require.Equal(t, len(keys), len(response.Data))
+	for i, key := range keys {
+		bundle, err := core.Bundle{}.Deserialize(response.Data[i])
+		require.NoError(t, err)
+		require.Equal(t, len(expectedChunks[key]), len(bundle))
+		for j, frame := range bundle {
+			require.Equal(t, expectedChunks[key][j], frame)
+		}
+	}
+
+	// If the fallback relay is down too, the request fails.
+	err = servers[1].Stop()
+	require.NoError(t, err)
+	for key := range expectedBlobs {
+		_, err := getBlob(t, &pb.GetBlobRequest{BlobKey: key[:]})
+		require.Error(t, err)
+	}
+}
@@ -79,7 +79,11 @@ func RetrieverMain(ctx *cli.Context) error {
 		log.Fatalf("failed to create logger: %v", err)
 	}
 
-	nodeClient := clients.NewNodeClient(config.Timeout)
+	nodeClientCredentials, err := config.NodeClientTLSConfig.Credentials()
+	if err != nil {
+		log.Fatalf("failed to load the node client tls config: %v", err)
+	}
+	nodeClient := clients.NewNodeClient(config.Timeout, nodeClientCredentials)
 
 	config.EncoderConfig.LoadG2Points = true
 	v, err := verifier.NewVerifier(&config.EncoderConfig, nil)
@@ -131,7 +135,15 @@ func RetrieverMain(ctx *cli.Context) error {
 	}
 
 	if config.EigenDAVersion == 2 {
-		retrievalClient := clientsv2.NewRetrievalClient(logger, tx, ics, v, config.NumConnections)
+		retrievalClient, err := clientsv2.NewRetrievalClient(logger, tx, ics, v, clientsv2.RetrievalClientConfig{
+			MaxConnectionCount:     config.NumConnections,
+			MaxRequestsPerOperator: config.MaxRequestsPerOperator,
+			HedgePercentile:        config.HedgePercentile,
+			HedgeDelay:             config.HedgeDelay,
+		})
+		if err != nil {
+			log.Fatalln("could not create retrieval client", err)
+		}
 		retrieverServiceServer := retrieverv2.NewServer(config, logger, retrievalClient, ics)
 		if err = retrieverServiceServer.Start(context.Background()); err != nil {
 			log.Fatalln("failed to start retriever service server", err)
@@ -6,6 +6,7 @@ import (
 
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/common/geth"
+	"github.com/Layr-Labs/eigenda/common/grpctls"
 	"github.com/Layr-Labs/eigenda/core/thegraph"
 	"github.com/Layr-Labs/eigenda/encoding/kzg"
 	"github.com/Layr-Labs/eigenda/retriever/flags"
@@ -18,9 +19,14 @@ type Config struct {
 	LoggerConfig     common.LoggerConfig
 	MetricsConfig    MetricsConfig
 	ChainStateConfig thegraph.Config
+	// NodeClientTLSConfig configures TLS on the connections to the retrieval servers of the nodes
+	NodeClientTLSConfig grpctls.ClientConfig
 
 	Timeout                       time.Duration
 	NumConnections                int
+	MaxRequestsPerOperator        int
+	HedgePercentile               float64
+	HedgeDelay                    time.Duration
 	BLSOperatorStateRetrieverAddr string
 	EigenDAServiceManagerAddr     string
 
@@ -45,8 +51,12 @@ func NewConfig(ctx *cli.Context) (*Config, error) {
 			HTTPPort: ctx.GlobalString(flags.MetricsHTTPPortFlag.Name),
 		},
 		ChainStateConfig:              thegraph.ReadCLIConfig(ctx),
+		NodeClientTLSConfig:           grpctls.ReadNodeClientConfig(ctx, flags.FlagPrefix),
 		Timeout:                       ctx.Duration(flags.TimeoutFlag.Name),
 		NumConnections:                ctx.Int(flags.NumConnectionsFlag.Name),
+		MaxRequestsPerOperator:        ctx.Int(flags.MaxRequestsPerOperatorFlag.Name),
+		HedgePercentile:               ctx.Float64(flags.HedgePercentileFlag.Name),
+		HedgeDelay:                    ctx.Duration(flags.HedgeDelayFlag.Name),
 		BLSOperatorStateRetrieverAddr: ctx.GlobalString(flags.BlsOperatorStateRetrieverFlag.Name),
 		EigenDAServiceManagerAddr:     ctx.GlobalString(flags.EigenDAServiceManagerFlag.Name),
 		EigenDAVersion:                version,
@@ -1,8 +1,11 @@
 package flags
 
 import (
+	"time"
+
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/common/geth"
+	"github.com/Layr-Labs/eigenda/common/grpctls"
 	"github.com/Layr-Labs/eigenda/core/thegraph"
 	"github.com/Layr-Labs/eigenda/encoding/kzg"
 	"github.com/urfave/cli"
@@ -54,6 +57,27 @@ var (
 		EnvVar:   common.PrefixEnvVar(envPrefix, "NUM_CONNECTIONS"),
 		Value:    20,
 	}
+	MaxRequestsPerOperatorFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "max-requests-per-operator"),
+		Usage:    "maximum number of simultaneous chunk requests to a single DA node (v2 only)",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "MAX_REQUESTS_PER_OPERATOR"),
+		Value:    8,
+	}
+	HedgePercentileFlag = cli.Float64Flag{
+		Name:     common.PrefixFlag(FlagPrefix, "hedge-percentile"),
+		Usage:    "percentile of recent chunk request latencies after which a request to another DA node is started (v2 only)",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "HEDGE_PERCENTILE"),
+		Value:    0.95,
+	}
+	HedgeDelayFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "hedge-delay"),
+		Usage:    "delay after which a chunk request is hedged, until enough latencies are recorded (v2 only)",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "HEDGE_DELAY"),
+		Value:    2 * time.Second,
+	}
 	MetricsHTTPPortFlag = cli.StringFlag{
 		Name:     common.PrefixFlag(FlagPrefix, "metrics-http-port"),
 		Usage:    "the http port which the metrics prometheus server is listening",
@@ -78,6 +102,9 @@ func RetrieverFlags(envPrefix string) []cli.Flag {
 		BlsOperatorStateRetrieverFlag,
 		EigenDAServiceManagerFlag,
 		NumConnectionsFlag,
+		MaxRequestsPerOperatorFlag,
+		HedgePercentileFlag,
+		HedgeDelayFlag,
 		MetricsHTTPPortFlag,
 		EigenDAVersionFlag,
 	}
@@ -92,4 +119,5 @@ func init() {
 	Flags = append(Flags, geth.EthClientFlags(envPrefix)...)
 	Flags = append(Flags, common.LoggerCLIFlags(envPrefix, FlagPrefix)...)
 	Flags = append(Flags, thegraph.CLIFlags(envPrefix)...)
+	Flags = append(Flags, grpctls.NodeClientFlags(envPrefix, FlagPrefix)...)
 }
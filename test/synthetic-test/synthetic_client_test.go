@@ -225,7 +225,7 @@ func setupRetrievalClient(ethClient common.EthClient, retrievalClientConfig *Ret
 	agn := &core.StdAssignmentCoordinator{}
 
 	// TODO: What should be the value here?
-	nodeClient := clients.NewNodeClient(20 * time.Second)
+	nodeClient := clients.NewNodeClient(20*time.Second, nil)
 	srsOrder, err := strconv.Atoi(retrievalClientConfig.RetrieverSrsOrder)
 	if err != nil {
 		return err
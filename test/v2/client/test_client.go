@@ -253,12 +253,17 @@ func NewTestClient(
 		RetrievalTimeout:              1337 * time.Hour, // this suite enforces its own timeouts
 	}
 
-	retrievalClient := clients.NewRetrievalClient(
+	retrievalClientConfig := clients.GetDefaultRetrievalClientConfig()
+	retrievalClientConfig.MaxConnectionCount = int(validatorPayloadRetrieverConfig.MaxConnectionCount)
+	retrievalClient, err := clients.NewRetrievalClient(
 		logger,
 		ethReader,
 		indexedChainState,
 		blobVerifier,
-		int(validatorPayloadRetrieverConfig.MaxConnectionCount))
+		*retrievalClientConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create retrieval client: %w", err)
+	}
 
 	validatorPayloadRetriever, err := clients.NewValidatorPayloadRetriever(
 		logger,
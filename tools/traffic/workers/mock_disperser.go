@@ -33,11 +33,24 @@ func (m *MockDisperserClient) GetBlobStatus(ctx context.Context, blobKey corev2.
 	return args.Get(0).(*disperser_rpc.BlobStatusReply), args.Error(1)
 }
 
+func (m *MockDisperserClient) GetBlobStatuses(ctx context.Context, blobKeys []corev2.BlobKey) (*disperser_rpc.BlobStatusesReply, error) {
+	args := m.mock.Called(blobKeys)
+	return args.Get(0).(*disperser_rpc.BlobStatusesReply), args.Error(1)
+}
+
 func (m *MockDisperserClient) GetBlobCommitment(ctx context.Context, data []byte) (*disperser_rpc.BlobCommitmentReply, error) {
 	args := m.mock.Called(data)
 	return args.Get(0).(*disperser_rpc.BlobCommitmentReply), args.Error(1)
 }
 
+func (m *MockDisperserClient) SubscribeCertificationEvents(ctx context.Context, startTimestamp uint64) (disperser_rpc.Disperser_SubscribeCertificationEventsClient, error) {
+	args := m.mock.Called(startTimestamp)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(disperser_rpc.Disperser_SubscribeCertificationEventsClient), args.Error(1)
+}
+
 func (m *MockDisperserClient) Close() error {
 	args := m.mock.Called()
 	return args.Error(0)